@@ -68,6 +68,14 @@ type ACL struct {
 	variables         *iradix.Tree[capabilitySet]
 	wildcardVariables *iradix.Tree[capabilitySet]
 
+	// The attributes below map namespaces to the sets of task drivers allowed
+	// or denied in them. Every namespace policy adds a key to both trees, so
+	// that both sets are looked up for the same namespace policy.
+	allowedDrivers         *iradix.Tree[capabilitySet]
+	wildcardAllowedDrivers *iradix.Tree[capabilitySet]
+	deniedDrivers          *iradix.Tree[capabilitySet]
+	wildcardDeniedDrivers  *iradix.Tree[capabilitySet]
+
 	// The attributes below store the policy value for policies that don't have
 	// fine-grained capabilities.
 	agent    string
@@ -123,6 +131,11 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 	svTxn := iradix.New[capabilitySet]().Txn()
 	wsvTxn := iradix.New[capabilitySet]().Txn()
 
+	adTxn := iradix.New[capabilitySet]().Txn()
+	wadTxn := iradix.New[capabilitySet]().Txn()
+	ddTxn := iradix.New[capabilitySet]().Txn()
+	wddTxn := iradix.New[capabilitySet]().Txn()
+
 	for _, policy := range policies {
 	NAMESPACES:
 		for _, ns := range policy.Namespaces {
@@ -177,6 +190,28 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 				}
 			}
 
+			// Add in the driver allow and deny lists
+			allowedTxn, deniedTxn := adTxn, ddTxn
+			if globDefinition {
+				allowedTxn, deniedTxn = wadTxn, wddTxn
+			}
+			allowed, ok := allowedTxn.Get([]byte(ns.Name))
+			if !ok {
+				allowed = make(capabilitySet)
+				allowedTxn.Insert([]byte(ns.Name), allowed)
+			}
+			denied, ok := deniedTxn.Get([]byte(ns.Name))
+			if !ok {
+				denied = make(capabilitySet)
+				deniedTxn.Insert([]byte(ns.Name), denied)
+			}
+			for _, driver := range ns.AllowedDrivers {
+				allowed.Set(driver)
+			}
+			for _, driver := range ns.DeniedDrivers {
+				denied.Set(driver)
+			}
+
 			// Deny always takes precedence
 			if capabilities.Check(NamespaceCapabilityDeny) {
 				continue NAMESPACES
@@ -304,6 +339,11 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 	acl.variables = svTxn.Commit()
 	acl.wildcardVariables = wsvTxn.Commit()
 
+	acl.allowedDrivers = adTxn.Commit()
+	acl.wildcardAllowedDrivers = wadTxn.Commit()
+	acl.deniedDrivers = ddTxn.Commit()
+	acl.wildcardDeniedDrivers = wddTxn.Commit()
+
 	acl.client = PolicyDeny
 	acl.server = PolicyDeny
 	acl.isLeader = false
@@ -383,6 +423,28 @@ func (a *ACL) AllowNamespace(ns string) bool {
 	return !capabilities.Check(PolicyDeny)
 }
 
+// AllowNamespaceDriver checks if jobs of a namespace may use a task driver. A
+// driver is not allowed if the namespace policy denies it, or if the policy
+// allows a set of drivers that doesn't include it.
+func (a *ACL) AllowNamespaceDriver(ns string, driver string) bool {
+	if a == nil {
+		return false
+	}
+
+	// Hot path management tokens or when ACLs are disabled
+	if a.aclsDisabled || a.management {
+		return true
+	}
+
+	denied, _ := a.matchingNamespaceDriverSet(a.deniedDrivers, a.wildcardDeniedDrivers, ns)
+	if denied.Check(driver) {
+		return false
+	}
+
+	allowed, _ := a.matchingNamespaceDriverSet(a.allowedDrivers, a.wildcardAllowedDrivers, ns)
+	return len(allowed) == 0 || allowed.Check(driver)
+}
+
 // AllowNodePoolOperation returns true if the given operation is allowed in the
 // node pool specified.
 func (a *ACL) AllowNodePoolOperation(pool string, op string) bool {
@@ -580,6 +642,18 @@ func (a *ACL) matchingNamespaceCapabilitySet(ns string) (capabilitySet, bool) {
 	return a.findClosestMatchingGlob(a.wildcardNamespaces, ns)
 }
 
+// matchingNamespaceDriverSet looks for the set of drivers that matches the
+// namespace in the concrete and glob trees, in the same way as
+// matchingNamespaceCapabilitySet.
+func (a *ACL) matchingNamespaceDriverSet(concrete, wildcard *iradix.Tree[capabilitySet], ns string) (capabilitySet, bool) {
+	raw, ok := concrete.Get([]byte(ns))
+	if ok {
+		return raw, true
+	}
+
+	return a.findClosestMatchingGlob(wildcard, ns)
+}
+
 // anyNamespaceAllowsOp returns true if any namespace in ACL object allows the
 // given operation.
 func (a *ACL) anyNamespaceAllowsOp(op string) bool {
@@ -710,6 +710,118 @@ func TestWildcardHostVolumeMatching(t *testing.T) {
 	}
 }
 
+func TestAllowNamespaceDriver(t *testing.T) {
+	ci.Parallel(t)
+
+	tests := []struct {
+		name     string
+		policies []string
+		ns       string
+		allowed  []string
+		denied   []string
+	}{
+		{
+			name:     "no restrictions",
+			policies: []string{`namespace "default" { policy = "write" }`},
+			ns:       "default",
+			allowed:  []string{"docker", "raw_exec"},
+		},
+		{
+			name: "allowed drivers",
+			policies: []string{`namespace "default" {
+				policy          = "write"
+				allowed_drivers = ["docker", "exec"]
+			}`},
+			ns:      "default",
+			allowed: []string{"docker", "exec"},
+			denied:  []string{"raw_exec"},
+		},
+		{
+			name: "denied drivers",
+			policies: []string{`namespace "default" {
+				policy         = "write"
+				denied_drivers = ["raw_exec"]
+			}`},
+			ns:      "default",
+			allowed: []string{"docker", "exec"},
+			denied:  []string{"raw_exec"},
+		},
+		{
+			name: "deny takes precedence",
+			policies: []string{`namespace "default" {
+				policy          = "write"
+				allowed_drivers = ["docker", "raw_exec"]
+			}`, `namespace "default" {
+				denied_drivers = ["raw_exec"]
+			}`},
+			ns:      "default",
+			allowed: []string{"docker"},
+			denied:  []string{"raw_exec", "exec"},
+		},
+		{
+			name: "allow lists are merged",
+			policies: []string{`namespace "default" {
+				policy          = "write"
+				allowed_drivers = ["docker"]
+			}`, `namespace "default" {
+				allowed_drivers = ["exec"]
+			}`},
+			ns:      "default",
+			allowed: []string{"docker", "exec"},
+			denied:  []string{"raw_exec"},
+		},
+		{
+			name: "concrete namespace takes precedence",
+			policies: []string{`namespace "prod-*" {
+				policy          = "write"
+				allowed_drivers = ["docker"]
+			}
+			namespace "prod-platform" {
+				policy = "write"
+			}`},
+			ns:      "prod-platform",
+			allowed: []string{"docker", "raw_exec"},
+		},
+		{
+			name: "wildcard namespace",
+			policies: []string{`namespace "prod-*" {
+				policy         = "write"
+				denied_drivers = ["raw_exec"]
+			}`},
+			ns:      "prod-api",
+			allowed: []string{"docker"},
+			denied:  []string{"raw_exec"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var policies []*Policy
+			for _, raw := range tc.policies {
+				policy, err := Parse(raw)
+				must.NoError(t, err)
+				policies = append(policies, policy)
+			}
+
+			acl, err := NewACL(false, policies)
+			must.NoError(t, err)
+
+			for _, driver := range tc.allowed {
+				must.True(t, acl.AllowNamespaceDriver(tc.ns, driver), must.Sprint(driver))
+			}
+			for _, driver := range tc.denied {
+				must.False(t, acl.AllowNamespaceDriver(tc.ns, driver), must.Sprint(driver))
+			}
+		})
+	}
+
+	t.Run("management", func(t *testing.T) {
+		acl, err := NewACL(true, nil)
+		must.NoError(t, err)
+		must.True(t, acl.AllowNamespaceDriver("default", "raw_exec"))
+	})
+}
+
 func TestVariablesMatching(t *testing.T) {
 	ci.Parallel(t)
 
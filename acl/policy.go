@@ -97,6 +97,10 @@ var (
 	validVolume = regexp.MustCompile("^[a-zA-Z0-9-*]{1,128}$")
 )
 
+var (
+	validDriver = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+)
+
 const (
 	// The following are the fine-grained capabilities that can be
 	// granted for a variables path. When capabilities are
@@ -140,6 +144,11 @@ type NamespacePolicy struct {
 	Policy       string
 	Capabilities []string
 	Variables    *VariablesPolicy `hcl:"variables"`
+
+	// AllowedDrivers and DeniedDrivers restrict the task drivers of the jobs
+	// submitted to the namespace.
+	AllowedDrivers []string `hcl:"allowed_drivers"`
+	DeniedDrivers  []string `hcl:"denied_drivers"`
 }
 
 // NodePoolPolicy is the policfy for a specific node pool.
@@ -424,6 +433,12 @@ func Parse(rules string) (*Policy, error) {
 		// Expand implicit capabilities
 		expandNamespaceCapabilities(ns)
 
+		for _, driver := range append(ns.AllowedDrivers, ns.DeniedDrivers...) {
+			if !validDriver.MatchString(driver) {
+				return nil, fmt.Errorf("Invalid task driver name '%s' in namespace %s", driver, ns.Name)
+			}
+		}
+
 		if ns.Variables != nil {
 			if len(ns.Variables.Paths) == 0 {
 				return nil, fmt.Errorf("Invalid variable policy: no variable paths in namespace %s", ns.Name)
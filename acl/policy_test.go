@@ -694,6 +694,44 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			`
+			namespace "default" {
+				capabilities    = ["submit-job"]
+				allowed_drivers = ["docker", "exec"]
+			}
+
+			namespace "platform" {
+				capabilities   = ["submit-job"]
+				denied_drivers = ["raw_exec"]
+			}
+			`,
+			"",
+			&Policy{
+				Namespaces: []*NamespacePolicy{
+					{
+						Name:           "default",
+						Capabilities:   []string{NamespaceCapabilitySubmitJob},
+						AllowedDrivers: []string{"docker", "exec"},
+					},
+					{
+						Name:          "platform",
+						Capabilities:  []string{NamespaceCapabilitySubmitJob},
+						DeniedDrivers: []string{"raw_exec"},
+					},
+				},
+			},
+		},
+		{
+			`
+			namespace "default" {
+				policy          = "write"
+				allowed_drivers = ["raw exec"]
+			}
+			`,
+			"Invalid task driver name 'raw exec' in namespace default",
+			nil,
+		},
 		{
 			`
 			node_pool "pool-read-only" {
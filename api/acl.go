@@ -388,6 +388,22 @@ func (a *ACLAuthMethods) Get(authMethodName string, q *QueryOptions) (*ACLAuthMe
 	return &resp, qm, nil
 }
 
+// Test computes the roles and policies the binding rules of the named
+// auth-method bind for the claims, without creating a token. This requires a
+// management token.
+func (a *ACLAuthMethods) Test(authMethodName string, claims map[string]any, q *QueryOptions) (*ACLAuthMethodTestResult, *QueryMeta, error) {
+	if authMethodName == "" {
+		return nil, nil, errMissingACLAuthMethodName
+	}
+	req := &ACLAuthMethodTestRequest{Claims: claims}
+	var resp ACLAuthMethodTestResult
+	qm, err := a.client.postQuery("/v1/acl/auth-method/"+authMethodName+"/test", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // ACLBindingRules is used to query the ACL auth-methods endpoints.
 type ACLBindingRules struct {
 	client *Client
@@ -565,10 +581,24 @@ type ACLToken struct {
 	ExpirationTime *time.Time `json:",omitempty"`
 
 	// ExpirationTTL is a convenience field for helping set ExpirationTime to a
-	// value of CreateTime+ExpirationTTL. This can only be set during token
-	// creation. This is a string version of a time.Duration like "2m".
+	// value of CreateTime+ExpirationTTL, or NotBefore+ExpirationTTL if the
+	// token has a NotBefore time. This can only be set during token creation.
+	// This is a string version of a time.Duration like "2m".
 	ExpirationTTL time.Duration `json:",omitempty"`
 
+	// NotBefore represents the point before which the token should not be
+	// accepted. This can only be set during token creation.
+	NotBefore *time.Time `json:",omitempty"`
+
+	// AllowedCIDRs restricts the source addresses of the HTTP API requests
+	// that use the token. The token can be used from any address if empty.
+	AllowedCIDRs []string `json:",omitempty"`
+
+	// AuthMethod and AuthSubject identify the auth method and the subject of
+	// the identity that logged in to create the token.
+	AuthMethod  string `json:",omitempty"`
+	AuthSubject string `json:",omitempty"`
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -648,6 +678,14 @@ type ACLTokenListStub struct {
 	// indicates no expiration has been set on the token.
 	ExpirationTime *time.Time `json:",omitempty"`
 
+	// NotBefore represents the point before which the token should not be
+	// accepted. A nil value indicates the token is valid from creation.
+	NotBefore *time.Time `json:",omitempty"`
+
+	// AllowedCIDRs restricts the source addresses of the HTTP API requests
+	// that use the token.
+	AllowedCIDRs []string `json:",omitempty"`
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -775,6 +813,11 @@ type ACLAuthMethod struct {
 	// attempting to login without specifying an auth-method name to use.
 	Default bool
 
+	// PruneTokens removes the roles and policies an identity is no longer
+	// bound to from the tokens previously created for the identity by the
+	// auth-method, each time the identity logs in.
+	PruneTokens bool
+
 	// Config contains the detailed configuration which is specific to the
 	// auth-method.
 	Config *ACLAuthMethodConfig
@@ -1078,6 +1121,34 @@ type OIDCClientAssertionKey struct {
 	PemCertFile string
 }
 
+// ACLAuthMethodTestRequest is the request body of an auth-method test.
+type ACLAuthMethodTestRequest struct {
+
+	// Claims are the claims of the identity, as they would be found in the
+	// token or user info of the provider.
+	Claims map[string]any
+}
+
+// ACLAuthMethodTestResult is the result of an auth-method test.
+type ACLAuthMethodTestResult struct {
+
+	// Claims are the claims of the identity after they have been mapped by
+	// the auth-method, which the binding rules are evaluated against.
+	Claims *ACLAuthClaims
+
+	// Management, Roles, and Policies are the bindings a token created for
+	// the identity would get.
+	Management bool
+	Roles      []*ACLTokenRoleLink
+	Policies   []string
+}
+
+// ACLAuthClaims are the claims of an identity mapped by an auth-method.
+type ACLAuthClaims struct {
+	Value map[string]string
+	List  map[string][]string
+}
+
 // ACLAuthMethodListStub is the stub object returned when performing a listing
 // of ACL auth-methods. It is intentionally minimal due to the unauthenticated
 // nature of the list endpoint.
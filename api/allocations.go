@@ -134,6 +134,42 @@ func (a *Allocations) Checks(allocID string, q *QueryOptions) (AllocCheckStatuse
 	return resp, err
 }
 
+// TemplateDebug renders the templates of a task once without writing them to
+// the task directory. Templates whose dependencies are not available within
+// the timeout are returned with their missing dependencies. A zero timeout
+// uses the agent's default.
+//
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *Allocations) TemplateDebug(alloc *Allocation, taskName string, timeout time.Duration, q *QueryOptions) ([]*TemplateDryRunResult, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+
+	q.Params["task"] = taskName
+	if timeout > 0 {
+		q.Params["timeout"] = timeout.String()
+	}
+
+	var resp []*TemplateDryRunResult
+	_, err := a.client.query("/v1/client/allocation/"+alloc.ID+"/template-debug", &resp, q)
+	return resp, err
+}
+
+// TemplateDryRunResult is the result of rendering a single template without
+// writing it to the task directory.
+type TemplateDryRunResult struct {
+	DestPath    string
+	Rendered    bool
+	Contents    string
+	Redacted    bool
+	MissingDeps []string
+}
+
 // GC forces a garbage collection of client state for an allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -219,6 +255,36 @@ type AllocStopResponse struct {
 	WriteMeta
 }
 
+// Resize updates the CPU and memory resources of the tasks of an allocation in
+// place, without rescheduling it. The resources are keyed by task name and
+// must fit on the node of the allocation.
+func (a *Allocations) Resize(allocID string, resources map[string]*Resources, w *WriteOptions) (*AllocResizeResponse, error) {
+	req := &AllocResizeRequest{
+		Resources: resources,
+	}
+
+	var resp AllocResizeResponse
+	wm, err := a.client.put("/v1/allocation/"+allocID+"/resize", req, &resp, w)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.WriteMeta = *wm
+	return &resp, nil
+}
+
+// AllocResizeRequest is the request to resize the tasks of an allocation.
+type AllocResizeRequest struct {
+	// Resources are the new resources of the tasks, keyed by task name. Only
+	// the CPU, CPUMax, MemoryMB, and MemoryMaxMB fields are used.
+	Resources map[string]*Resources
+}
+
+// AllocResizeResponse is the response to an `AllocResizeRequest`
+type AllocResizeResponse struct {
+	WriteMeta
+}
+
 // Signal sends a signal to the allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -314,6 +380,7 @@ type AllocationMetric struct {
 	ClassExhausted     map[string]int
 	DimensionExhausted map[string]int
 	QuotaExhausted     []string
+	GangFailed         []string
 	ResourcesExhausted map[string]*Resources
 	// Deprecated, replaced with ScoreMetaData
 	Scores            map[string]float64
@@ -437,6 +504,16 @@ type AllocNetworkStatus struct {
 	Address       string
 	AddressIPv6   string
 	DNS           *DNSConfig
+	Interfaces    []*AllocNetworkInterface
+}
+
+// AllocNetworkInterface is the status of an interface attached by one of the
+// additional CNI networks of the group network.
+type AllocNetworkInterface struct {
+	Network       string
+	InterfaceName string
+	Address       string
+	AddressIPv6   string
 }
 
 type AllocatedResources struct {
@@ -466,6 +543,7 @@ type PortMapping struct {
 
 type AllocatedCpuResources struct {
 	CpuShares int64
+	CpuMax    int64
 }
 
 type AllocatedMemoryResources struct {
@@ -589,6 +667,11 @@ type DesiredTransition struct {
 	// Reschedule is used to indicate that this allocation is eligible to be
 	// rescheduled.
 	Reschedule *bool
+
+	// Decommission is used to indicate that this allocation was replaced by
+	// a promoted canary and is kept running until its decommission delay
+	// expires.
+	Decommission *bool
 }
 
 // ShouldMigrate returns whether the transition object dictates a migration.
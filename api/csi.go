@@ -237,6 +237,16 @@ const (
 	CSIVolumeTypeCSI  = "csi"
 )
 
+// CSIVolumeSnapshotSchedule configures the snapshots of a volume created
+// periodically by the servers.
+type CSIVolumeSnapshotSchedule struct {
+	// Cron is the cron expression of the snapshot times, in UTC.
+	Cron string `hcl:"cron"`
+
+	// Retain is the number of snapshots to keep.
+	Retain int `hcl:"retain"`
+}
+
 // CSIMountOptions contain optional additional configuration that can be used
 // when specifying that a Volume should be used with VolumeAccessTypeMount.
 type CSIMountOptions struct {
@@ -316,6 +326,10 @@ type CSIVolume struct {
 	Context        map[string]string       `mapstructure:"context" hcl:"context"`
 	Capacity       int64                   `hcl:"-"`
 
+	// NodeExpansionRequired is set when the volume was expanded by the
+	// controller plugin and must be expanded by the node plugins as well.
+	NodeExpansionRequired bool `hcl:"-"`
+
 	// These fields are used as part of the volume creation request
 	RequestedCapacityMin  int64                  `hcl:"capacity_min"`
 	RequestedCapacityMax  int64                  `hcl:"capacity_max"`
@@ -323,6 +337,12 @@ type CSIVolume struct {
 	CloneID               string                 `mapstructure:"clone_id" hcl:"clone_id"`
 	SnapshotID            string                 `mapstructure:"snapshot_id" hcl:"snapshot_id"`
 
+	// SnapshotSchedule configures the snapshots of the volume created
+	// periodically by the servers. ManagedSnapshots are the snapshots they
+	// created which haven't been pruned yet.
+	SnapshotSchedule *CSIVolumeSnapshotSchedule `hcl:"snapshot_schedule"`
+	ManagedSnapshots []*CSISnapshot             `hcl:"-"`
+
 	// ReadAllocs is a map of allocation IDs for tracking reader claim status.
 	// The Allocation value will always be nil; clients can populate this data
 	// by iterating over the Allocations field.
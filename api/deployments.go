@@ -110,6 +110,24 @@ func (d *Deployments) PromoteGroups(deploymentID string, groups []string, q *Wri
 	return &resp, wm, nil
 }
 
+// PromoteStep is used to move the canaries of the passed groups, or of all the
+// groups if none is passed, to their next canary step in the given
+// deployment. The canaries are promoted once they reached their last step.
+func (d *Deployments) PromoteStep(deploymentID string, groups []string, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
+	var resp DeploymentUpdateResponse
+	req := &DeploymentPromoteRequest{
+		DeploymentID: deploymentID,
+		All:          len(groups) == 0,
+		Groups:       groups,
+		Step:         true,
+	}
+	wm, err := d.client.put("/v1/deployment/promote/"+deploymentID, req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 // Unblock is used to unblock the given deployment.
 func (d *Deployments) Unblock(deploymentID string, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
 	var resp DeploymentUpdateResponse
@@ -207,6 +225,8 @@ type DeploymentState struct {
 	RequireProgressBy time.Time
 	Promoted          bool
 	DesiredCanaries   int
+	CanarySteps       []int
+	CanaryStep        int
 	DesiredTotal      int
 	PlacedAllocs      int
 	HealthyAllocs     int
@@ -265,6 +285,10 @@ type DeploymentPromoteRequest struct {
 	// Groups is used to set the promotion status per task group
 	Groups []string
 
+	// Step is used to move the canaries of the task groups with canary steps
+	// left to their next step instead of promoting them
+	Step bool
+
 	// PromotedAt is the timestamp stored as Unix nano
 	PromotedAt int64
 
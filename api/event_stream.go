@@ -14,14 +14,17 @@ import (
 )
 
 const (
-	TopicDeployment Topic = "Deployment"
-	TopicEvaluation Topic = "Evaluation"
-	TopicAllocation Topic = "Allocation"
-	TopicJob        Topic = "Job"
-	TopicNode       Topic = "Node"
-	TopicNodePool   Topic = "NodePool"
-	TopicService    Topic = "Service"
-	TopicAll        Topic = "*"
+	TopicDeployment      Topic = "Deployment"
+	TopicEvaluation      Topic = "Evaluation"
+	TopicAllocation      Topic = "Allocation"
+	TopicJob             Topic = "Job"
+	TopicNode            Topic = "Node"
+	TopicNodePool        Topic = "NodePool"
+	TopicNodeMaintenance Topic = "NodeMaintenance"
+	TopicService         Topic = "Service"
+	TopicVariable        Topic = "Variable"
+	TopicRootKey         Topic = "RootKey"
+	TopicAll             Topic = "*"
 )
 
 // Events is a set of events for a corresponding index. Events returned for the
@@ -110,6 +113,17 @@ func (e *Event) NodePool() (*NodePool, error) {
 	return out.NodePool, nil
 }
 
+// NodeMaintenanceWindow returns a NodeMaintenanceWindow struct from a given
+// event payload. If the Event Topic is NodeMaintenance this will return a
+// valid NodeMaintenanceWindow.
+func (e *Event) NodeMaintenanceWindow() (*NodeMaintenanceWindow, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.Window, nil
+}
+
 // Service returns a ServiceRegistration struct from a given event payload. If
 // the Event Topic is Service this will return a valid ServiceRegistration.
 func (e *Event) Service() (*ServiceRegistration, error) {
@@ -120,14 +134,39 @@ func (e *Event) Service() (*ServiceRegistration, error) {
 	return out.Service, nil
 }
 
+// Variable returns the metadata of a variable from a given event payload. If
+// the Event Topic is Variable this will return a valid VariableMetadata. The
+// items of the variable are not included in events.
+func (e *Event) Variable() (*VariableMetadata, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.Variable, nil
+}
+
+// RootKey returns the metadata of a root key from a given event payload. If
+// the Event Topic is RootKey this will return a valid RootKeyMeta. The key
+// material is not included in events.
+func (e *Event) RootKey() (*RootKeyMeta, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.RootKey, nil
+}
+
 type eventPayload struct {
-	Allocation *Allocation          `mapstructure:"Allocation"`
-	Deployment *Deployment          `mapstructure:"Deployment"`
-	Evaluation *Evaluation          `mapstructure:"Evaluation"`
-	Job        *Job                 `mapstructure:"Job"`
-	Node       *Node                `mapstructure:"Node"`
-	NodePool   *NodePool            `mapstructure:"NodePool"`
-	Service    *ServiceRegistration `mapstructure:"Service"`
+	Allocation *Allocation            `mapstructure:"Allocation"`
+	Deployment *Deployment            `mapstructure:"Deployment"`
+	Evaluation *Evaluation            `mapstructure:"Evaluation"`
+	Job        *Job                   `mapstructure:"Job"`
+	Node       *Node                  `mapstructure:"Node"`
+	NodePool   *NodePool              `mapstructure:"NodePool"`
+	Service    *ServiceRegistration   `mapstructure:"Service"`
+	Variable   *VariableMetadata      `mapstructure:"Variable"`
+	RootKey    *RootKeyMeta           `mapstructure:"RootKey"`
+	Window     *NodeMaintenanceWindow `mapstructure:"Window"`
 }
 
 func (e *Event) decodePayload() (*eventPayload, error) {
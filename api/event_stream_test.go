@@ -331,6 +331,35 @@ func TestEventStream_PayloadValueHelpers(t *testing.T) {
 				must.Eq(t, "some-service-namespace-id", a.Namespace)
 			},
 		},
+		{
+			desc:  "variable",
+			input: []byte(`{"Topic": "Variable", "Payload": {"Variable":{"Namespace":"default","Path":"app/config","ModifyIndex":12,"Version":2}}}`),
+			expectFn: func(t *testing.T, event Event) {
+				must.Eq(t, TopicVariable, event.Topic)
+				v, err := event.Variable()
+				must.NoError(t, err)
+				must.Eq(t, &VariableMetadata{
+					Namespace:   "default",
+					Path:        "app/config",
+					ModifyIndex: 12,
+					Version:     2,
+				}, v)
+			},
+		},
+		{
+			desc:  "root key",
+			input: []byte(`{"Topic": "RootKey", "Payload": {"RootKey":{"KeyID":"9b2f7d3c-8ae7-3f68-2d60-9b3b4c3a7d8e","State":"active","Providers":["aead"]}}}`),
+			expectFn: func(t *testing.T, event Event) {
+				must.Eq(t, TopicRootKey, event.Topic)
+				k, err := event.RootKey()
+				must.NoError(t, err)
+				must.Eq(t, &RootKeyMeta{
+					KeyID:     "9b2f7d3c-8ae7-3f68-2d60-9b3b4c3a7d8e",
+					State:     RootKeyStateActive,
+					Providers: []string{"aead"},
+				}, k)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/url"
+	"os"
 	"strconv"
 	"sync"
 	"time"
@@ -137,6 +139,40 @@ func (a *AllocFS) Cat(alloc *Allocation, path string, q *QueryOptions) (io.ReadC
 		})
 }
 
+// FSPutOptions are the options used to upload a file with AllocFS.Put.
+type FSPutOptions struct {
+	// Size is the size of the file contents in bytes.
+	Size int64
+
+	// Checksum is the hex encoded SHA-256 checksum of the file contents. The
+	// file is only written if the uploaded contents match it.
+	Checksum string
+
+	// FileMode is the permission bits of the file. Defaults to 0644.
+	FileMode os.FileMode
+}
+
+// Put uploads the contents read from r to the file at the given path of an
+// allocation directory, replacing the file if it exists. The directory of the
+// file must exist. The contents are sent to the Nomad client in chunks and
+// only written once their size and checksum have been validated.
+func (a *AllocFS) Put(alloc *Allocation, path string, r io.Reader, opts *FSPutOptions, q *WriteOptions) (*WriteMeta, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("put options are required")
+	}
+
+	v := url.Values{}
+	v.Set("path", path)
+	v.Set("size", strconv.FormatInt(opts.Size, 10))
+	v.Set("checksum", opts.Checksum)
+	if opts.FileMode != 0 {
+		v.Set("mode", strconv.FormatUint(uint64(opts.FileMode.Perm()), 8))
+	}
+
+	reqPath := fmt.Sprintf("/v1/client/fs/put/%s?%s", alloc.ID, v.Encode())
+	return a.client.put(reqPath, r, nil, q)
+}
+
 // Stream streams the content of a file blocking on EOF.
 // The parameters are:
 // * path: path to file to stream.
@@ -260,16 +296,59 @@ func queryClientNode(c *Client, alloc *Allocation, reqPath string, q *QueryOptio
 func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin string,
 	offset int64, cancel <-chan struct{}, q *QueryOptions) (<-chan *StreamFrame, <-chan error) {
 
+	opts := &FSLogsOptions{
+		Follow:  follow,
+		Task:    task,
+		LogType: logType,
+		Origin:  origin,
+		Offset:  offset,
+	}
+	return a.LogsWithOptions(alloc, opts, cancel, q)
+}
+
+// FSLogsOptions are the options used to stream the logs of a task with
+// AllocFS.LogsWithOptions.
+type FSLogsOptions struct {
+	// Follow sets whether the logs should be followed.
+	Follow bool
+
+	// Task is the name of the task to stream logs for.
+	Task string
+
+	// LogType is either "stdout" or "stderr".
+	LogType string
+
+	// Origin is either "start" or "end" and defines from where the offset is
+	// applied.
+	Origin string
+
+	// Offset is the offset to start streaming data at.
+	Offset int64
+
+	// Filter is a regular expression the lines of the logs must match to be
+	// streamed. Lines are filtered by the Nomad client running the task, and
+	// the offset is applied before filtering.
+	Filter string
+}
+
+// LogsWithOptions streams the content of a tasks logs like Logs, with the
+// options given in opts.
+func (a *AllocFS) LogsWithOptions(alloc *Allocation, opts *FSLogsOptions,
+	cancel <-chan struct{}, q *QueryOptions) (<-chan *StreamFrame, <-chan error) {
+
 	errCh := make(chan error, 1)
 
 	reqPath := fmt.Sprintf("/v1/client/fs/logs/%s", alloc.ID)
 	r, err := queryClientNode(a.client, alloc, reqPath, q,
 		func(q *QueryOptions) {
-			q.Params["follow"] = strconv.FormatBool(follow)
-			q.Params["task"] = task
-			q.Params["type"] = logType
-			q.Params["origin"] = origin
-			q.Params["offset"] = strconv.FormatInt(offset, 10)
+			q.Params["follow"] = strconv.FormatBool(opts.Follow)
+			q.Params["task"] = opts.Task
+			q.Params["type"] = opts.LogType
+			q.Params["origin"] = opts.Origin
+			q.Params["offset"] = strconv.FormatInt(opts.Offset, 10)
+			if opts.Filter != "" {
+				q.Params["filter"] = opts.Filter
+			}
 		})
 	if err != nil {
 		errCh <- err
@@ -10,6 +10,7 @@ import (
 	"io"
 	"maps"
 	"net/url"
+	"slices"
 	"sort"
 	"strconv"
 	"time"
@@ -37,6 +38,30 @@ const (
 	// PeriodicSpecCron is used for a cron spec.
 	PeriodicSpecCron = "cron"
 
+	// PeriodicConcurrencyAllow launches the periodic job even if its previous
+	// children are still running.
+	PeriodicConcurrencyAllow = "allow"
+
+	// PeriodicConcurrencyForbid skips the launches of the periodic job while
+	// its previous children are still running.
+	PeriodicConcurrencyForbid = "forbid"
+
+	// PeriodicConcurrencyReplace stops the running children of the periodic
+	// job before launching it.
+	PeriodicConcurrencyReplace = "replace"
+
+	// PeriodicProhibitActionSkip skips the launches of a periodic job falling
+	// in a prohibit window.
+	PeriodicProhibitActionSkip = "skip"
+
+	// PeriodicProhibitActionDefer defers the launches of a periodic job
+	// falling in a prohibit window to the end of the window.
+	PeriodicProhibitActionDefer = "defer"
+
+	// periodicProhibitMaxIterations is the number of prohibit windows the
+	// next launch of a periodic job can be moved by before giving up.
+	periodicProhibitMaxIterations = 1000
+
 	// DefaultNamespace is the default namespace.
 	DefaultNamespace = "default"
 
@@ -424,6 +449,11 @@ type DeregisterOptions struct {
 	// task shutdown_delay configuration and ignore the delay for any
 	// allocations stopped as a result of this Deregister call.
 	NoShutdownDelay bool
+
+	// ShutdownDelay, if set, will override the group and task shutdown_delay
+	// configuration of any allocations stopped as a result of this Deregister
+	// call.
+	ShutdownDelay time.Duration
 }
 
 // DeregisterOpts is used to remove an existing job. See DeregisterOptions
@@ -439,6 +469,9 @@ func (j *Jobs) DeregisterOpts(jobID string, opts *DeregisterOptions, q *WriteOpt
 	if opts != nil {
 		endpoint += fmt.Sprintf("?purge=%t&global=%t&eval_priority=%v&no_shutdown_delay=%t",
 			opts.Purge, opts.Global, opts.EvalPriority, opts.NoShutdownDelay)
+		if opts.ShutdownDelay > 0 {
+			endpoint += "&shutdown_delay=" + opts.ShutdownDelay.String()
+		}
 	}
 
 	wm, err := j.client.delete(endpoint, nil, &resp, q)
@@ -529,6 +562,17 @@ func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta,
 	return &resp, qm, nil
 }
 
+// ArraySummary is used to retrieve the state of each index of the array of a
+// job.
+func (j *Jobs) ArraySummary(jobID string, q *QueryOptions) (*JobArraySummary, *QueryMeta, error) {
+	var resp JobArraySummary
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/array", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // DispatchOptions is used to pass through job dispatch parameters
 type DispatchOptions struct {
 	JobID            string
@@ -568,6 +612,27 @@ func (j *Jobs) DispatchOpts(opts *DispatchOptions, q *WriteOptions) (*JobDispatc
 	return &resp, wm, nil
 }
 
+// DispatchQueue is used to list the dispatched jobs of a parameterized job
+// waiting for fewer of its dispatched jobs to run.
+func (j *Jobs) DispatchQueue(jobID string, q *QueryOptions) (*JobDispatchQueue, *QueryMeta, error) {
+	var resp JobDispatchQueue
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/dispatch/queue", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// DispatchCancel is used to cancel a dispatched job waiting in the dispatch
+// queue of its parameterized job.
+func (j *Jobs) DispatchCancel(dispatchedJobID string, q *WriteOptions) (*WriteMeta, error) {
+	wm, err := j.client.delete("/v1/job/"+url.PathEscape(dispatchedJobID)+"/dispatch/queue", nil, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
 // Revert is used to revert the given job to the passed version. If
 // enforceVersion is set, the job is only reverted if the current version is at
 // the passed version.
@@ -619,15 +684,19 @@ type periodicForceResponse struct {
 
 // UpdateStrategy defines a task groups update strategy.
 type UpdateStrategy struct {
-	Stagger          *time.Duration `mapstructure:"stagger" hcl:"stagger,optional"`
-	MaxParallel      *int           `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
-	HealthCheck      *string        `mapstructure:"health_check" hcl:"health_check,optional"`
-	MinHealthyTime   *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
-	HealthyDeadline  *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
-	ProgressDeadline *time.Duration `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
-	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
-	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
-	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Stagger           *time.Duration `mapstructure:"stagger" hcl:"stagger,optional"`
+	MaxParallel       *int           `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
+	HealthCheck       *string        `mapstructure:"health_check" hcl:"health_check,optional"`
+	MinHealthyTime    *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
+	HealthyDeadline   *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
+	ProgressDeadline  *time.Duration `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
+	Canary            *int           `mapstructure:"canary" hcl:"canary,optional"`
+	CanarySteps       []int          `mapstructure:"canary_steps" hcl:"canary_steps,optional"`
+	BakeTime          *time.Duration `mapstructure:"bake_time" hcl:"bake_time,optional"`
+	DecommissionDelay *time.Duration `mapstructure:"decommission_delay" hcl:"decommission_delay,optional"`
+	AutoRevert        *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
+	AutoPromote       *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Verify            *UpdateVerify  `mapstructure:"verify" hcl:"verify,block"`
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -685,10 +754,22 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.Canary = pointerOf(*u.Canary)
 	}
 
+	copy.CanarySteps = slices.Clone(u.CanarySteps)
+
+	if u.BakeTime != nil {
+		copy.BakeTime = pointerOf(*u.BakeTime)
+	}
+
+	if u.DecommissionDelay != nil {
+		copy.DecommissionDelay = pointerOf(*u.DecommissionDelay)
+	}
+
 	if u.AutoPromote != nil {
 		copy.AutoPromote = pointerOf(*u.AutoPromote)
 	}
 
+	copy.Verify = u.Verify.Copy()
+
 	return copy
 }
 
@@ -729,9 +810,25 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 		u.Canary = pointerOf(*o.Canary)
 	}
 
+	if o.CanarySteps != nil {
+		u.CanarySteps = slices.Clone(o.CanarySteps)
+	}
+
+	if o.BakeTime != nil {
+		u.BakeTime = pointerOf(*o.BakeTime)
+	}
+
+	if o.DecommissionDelay != nil {
+		u.DecommissionDelay = pointerOf(*o.DecommissionDelay)
+	}
+
 	if o.AutoPromote != nil {
 		u.AutoPromote = pointerOf(*o.AutoPromote)
 	}
+
+	if o.Verify != nil {
+		u.Verify = o.Verify.Copy()
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -772,6 +869,10 @@ func (u *UpdateStrategy) Canonicalize() {
 	if u.AutoPromote == nil {
 		u.AutoPromote = d.AutoPromote
 	}
+
+	if u.Verify != nil {
+		u.Verify.Canonicalize()
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -816,9 +917,107 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if len(u.CanarySteps) != 0 {
+		return false
+	}
+
+	if u.BakeTime != nil && *u.BakeTime != 0 {
+		return false
+	}
+
+	if u.DecommissionDelay != nil && *u.DecommissionDelay != 0 {
+		return false
+	}
+
+	if u.Verify != nil {
+		return false
+	}
+
 	return true
 }
 
+const (
+	// UpdateVerifyTypeHTTP verifies a deployment by querying an HTTP
+	// endpoint, and passes if it responds with a 2xx status code.
+	UpdateVerifyTypeHTTP = "http"
+
+	// UpdateVerifyTypePrometheus verifies a deployment by running a query
+	// against a Prometheus server, and passes if the query returns at least
+	// one value and all its values are non-zero.
+	UpdateVerifyTypePrometheus = "prometheus"
+)
+
+// UpdateVerify verifies a deployment against an external signal once its
+// canaries are healthy, before it is automatically promoted.
+type UpdateVerify struct {
+	Type     *string        `mapstructure:"type" hcl:"type,optional"`
+	Address  *string        `mapstructure:"address" hcl:"address,optional"`
+	Query    *string        `mapstructure:"query" hcl:"query,optional"`
+	Checks   *int           `mapstructure:"checks" hcl:"checks,optional"`
+	Interval *time.Duration `mapstructure:"interval" hcl:"interval,optional"`
+	Timeout  *time.Duration `mapstructure:"timeout" hcl:"timeout,optional"`
+}
+
+func (v *UpdateVerify) Copy() *UpdateVerify {
+	if v == nil {
+		return nil
+	}
+
+	copy := new(UpdateVerify)
+
+	if v.Type != nil {
+		copy.Type = pointerOf(*v.Type)
+	}
+
+	if v.Address != nil {
+		copy.Address = pointerOf(*v.Address)
+	}
+
+	if v.Query != nil {
+		copy.Query = pointerOf(*v.Query)
+	}
+
+	if v.Checks != nil {
+		copy.Checks = pointerOf(*v.Checks)
+	}
+
+	if v.Interval != nil {
+		copy.Interval = pointerOf(*v.Interval)
+	}
+
+	if v.Timeout != nil {
+		copy.Timeout = pointerOf(*v.Timeout)
+	}
+
+	return copy
+}
+
+func (v *UpdateVerify) Canonicalize() {
+	if v.Type == nil {
+		v.Type = pointerOf(UpdateVerifyTypeHTTP)
+	}
+
+	if v.Address == nil {
+		v.Address = pointerOf("")
+	}
+
+	if v.Query == nil {
+		v.Query = pointerOf("")
+	}
+
+	if v.Checks == nil {
+		v.Checks = pointerOf(1)
+	}
+
+	if v.Interval == nil {
+		v.Interval = pointerOf(10 * time.Second)
+	}
+
+	if v.Timeout == nil {
+		v.Timeout = pointerOf(5 * time.Second)
+	}
+}
+
 type Multiregion struct {
 	Strategy *MultiregionStrategy `hcl:"strategy,block"`
 	Regions  []*MultiregionRegion `hcl:"region,block"`
@@ -894,12 +1093,36 @@ type MultiregionRegion struct {
 
 // PeriodicConfig is for serializing periodic config for a job.
 type PeriodicConfig struct {
-	Enabled         *bool    `hcl:"enabled,optional"`
-	Spec            *string  `hcl:"cron,optional"`
-	Specs           []string `hcl:"crons,optional"`
-	SpecType        *string
-	ProhibitOverlap *bool   `mapstructure:"prohibit_overlap" hcl:"prohibit_overlap,optional"`
-	TimeZone        *string `mapstructure:"time_zone" hcl:"time_zone,optional"`
+	Enabled           *bool    `hcl:"enabled,optional"`
+	Spec              *string  `hcl:"cron,optional"`
+	Specs             []string `hcl:"crons,optional"`
+	SpecType          *string
+	ProhibitOverlap   *bool   `mapstructure:"prohibit_overlap" hcl:"prohibit_overlap,optional"`
+	ConcurrencyPolicy *string `mapstructure:"concurrency_policy" hcl:"concurrency_policy,optional"`
+	HistoryLimit      *int    `mapstructure:"history_limit" hcl:"history_limit,optional"`
+	TimeZone          *string `mapstructure:"time_zone" hcl:"time_zone,optional"`
+
+	Prohibit []*PeriodicProhibit `hcl:"prohibit,block"`
+}
+
+// PeriodicProhibit is a recurring window during which a periodic job isn't
+// launched.
+type PeriodicProhibit struct {
+	Start    *string        `hcl:"start,optional"`
+	Duration *time.Duration `hcl:"duration,optional"`
+	Action   *string        `hcl:"action,optional"`
+}
+
+func (w *PeriodicProhibit) Canonicalize() {
+	if w.Start == nil {
+		w.Start = pointerOf("")
+	}
+	if w.Duration == nil {
+		w.Duration = pointerOf(time.Duration(0))
+	}
+	if w.Action == nil || *w.Action == "" {
+		w.Action = pointerOf(PeriodicProhibitActionSkip)
+	}
 }
 
 func (p *PeriodicConfig) Canonicalize() {
@@ -918,16 +1141,81 @@ func (p *PeriodicConfig) Canonicalize() {
 	if p.ProhibitOverlap == nil {
 		p.ProhibitOverlap = pointerOf(false)
 	}
+	if p.ConcurrencyPolicy == nil || *p.ConcurrencyPolicy == "" {
+		if *p.ProhibitOverlap {
+			p.ConcurrencyPolicy = pointerOf(PeriodicConcurrencyForbid)
+		} else {
+			p.ConcurrencyPolicy = pointerOf(PeriodicConcurrencyAllow)
+		}
+	}
+	if p.HistoryLimit == nil {
+		p.HistoryLimit = pointerOf(0)
+	}
 	if p.TimeZone == nil || *p.TimeZone == "" {
 		p.TimeZone = pointerOf("UTC")
 	}
+	for _, w := range p.Prohibit {
+		w.Canonicalize()
+	}
 }
 
 // Next returns the closest time instant matching the spec that is after the
-// passed time. If no matching instance exists, the zero value of time.Time is
-// returned. The `time.Location` of the returned value matches that of the
-// passed time.
+// passed time and outside of the prohibit windows. If no matching instance
+// exists, the zero value of time.Time is returned. The `time.Location` of the
+// returned value matches that of the passed time.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
 func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
+	next, err := p.nextSpec(fromTime)
+	if err != nil || next.IsZero() || len(p.Prohibit) == 0 {
+		return next, err
+	}
+
+	for i := 0; i < periodicProhibitMaxIterations; i++ {
+		window, end, err := p.prohibitedUntil(next)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if window == nil {
+			return next, nil
+		}
+
+		if window.Action != nil && *window.Action == PeriodicProhibitActionDefer {
+			next = end
+			continue
+		}
+
+		// The next launch at or after the end of the window
+		next, err = p.nextSpec(end.Add(-time.Nanosecond))
+		if err != nil || next.IsZero() {
+			return next, err
+		}
+	}
+	return time.Time{}, fmt.Errorf("no launch outside of the prohibit windows after %d attempts",
+		periodicProhibitMaxIterations)
+}
+
+// prohibitedUntil returns the prohibit window containing the launch time and
+// the end of the window, or nil if the launch time isn't prohibited.
+func (p *PeriodicConfig) prohibitedUntil(launch time.Time) (*PeriodicProhibit, time.Time, error) {
+	for _, w := range p.Prohibit {
+		if w.Start == nil || w.Duration == nil {
+			continue
+		}
+		start, err := cronParseNext(launch.Add(-*w.Duration), *w.Start)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		if !start.IsZero() && !start.After(launch) {
+			return w, start.Add(*w.Duration), nil
+		}
+	}
+	return nil, time.Time{}, nil
+}
+
+// nextSpec returns the closest time instant matching the spec that is after
+// the passed time, regardless of the prohibit windows.
+func (p *PeriodicConfig) nextSpec(fromTime time.Time) (time.Time, error) {
 	// Single spec parsing
 	if p != nil && *p.SpecType == PeriodicSpecCron {
 		if p.Spec != nil && *p.Spec != "" {
@@ -977,9 +1265,33 @@ func (p *PeriodicConfig) GetLocation() (*time.Location, error) {
 
 // ParameterizedJobConfig is used to configure the parameterized job.
 type ParameterizedJobConfig struct {
-	Payload      string   `hcl:"payload,optional"`
-	MetaRequired []string `mapstructure:"meta_required" hcl:"meta_required,optional"`
-	MetaOptional []string `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
+	Payload       string   `hcl:"payload,optional"`
+	MetaRequired  []string `mapstructure:"meta_required" hcl:"meta_required,optional"`
+	MetaOptional  []string `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
+	MaxConcurrent int      `mapstructure:"max_concurrent" hcl:"max_concurrent,optional"`
+	QueuePolicy   string   `mapstructure:"queue_policy" hcl:"queue_policy,optional"`
+}
+
+// JobArray is used to expand the task groups of a batch job into indexed
+// allocations.
+type JobArray struct {
+	Count *int `hcl:"count,optional"`
+}
+
+// JobDependency is used to hold the evaluation of a batch job until another
+// job reaches a terminal status.
+type JobDependency struct {
+	JobID  *string `mapstructure:"job_id" hcl:"job_id,optional"`
+	Status *string `hcl:"status,optional"`
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.JobID == nil {
+		d.JobID = pointerOf("")
+	}
+	if d.Status == nil {
+		d.Status = pointerOf("complete")
+	}
 }
 
 // JobSubmission is used to hold information about the original content of a job
@@ -1120,6 +1432,8 @@ type Job struct {
 	Spreads          []*Spread               `hcl:"spread,block"`
 	Periodic         *PeriodicConfig         `hcl:"periodic,block"`
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
+	Array            *JobArray               `hcl:"array,block"`
+	DependsOn        []*JobDependency        `mapstructure:"depends_on" hcl:"depends_on,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
@@ -1235,6 +1549,10 @@ func (j *Job) Canonicalize() {
 	}
 
 	for _, tg := range j.TaskGroups {
+		// the groups of an array run an allocation per index
+		if j.Array != nil && j.Array.Count != nil && tg.Count == nil {
+			tg.Count = pointerOf(*j.Array.Count)
+		}
 		tg.Canonicalize(j)
 	}
 
@@ -1244,6 +1562,9 @@ func (j *Job) Canonicalize() {
 	for _, a := range j.Affinities {
 		a.Canonicalize()
 	}
+	for _, d := range j.DependsOn {
+		d.Canonicalize()
+	}
 
 	if j.UI != nil {
 		j.UI.Canonicalize()
@@ -1299,6 +1620,35 @@ type TaskGroupSummary struct {
 	Unknown  int
 }
 
+// JobArraySummary is the state of each index of the array of a job
+type JobArraySummary struct {
+	JobID      string
+	Namespace  string
+	Count      int
+	TaskGroups map[string]*TaskGroupArraySummary
+}
+
+// TaskGroupArraySummary is the state of the indexes of the array of a task
+// group, and the number of indexes in each state
+type TaskGroupArraySummary struct {
+	Pending  int
+	Running  int
+	Complete int
+	Failed   int
+	Lost     int
+	Unknown  int
+	Indexes  []*JobArrayIndex
+}
+
+// JobArrayIndex is the state of an index of the array of a task group, as
+// reported by its latest allocation
+type JobArrayIndex struct {
+	Index    uint
+	Status   string
+	AllocID  string
+	Attempts int
+}
+
 // JobListStub is used to return a subset of information about
 // jobs during list operations.
 type JobListStub struct {
@@ -1602,9 +1952,33 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+
+	// Queued is set if the dispatched job waits in the dispatch queue of the
+	// parameterized job, in which case no evaluation was created.
+	Queued bool
 	WriteMeta
 }
 
+// JobDispatchQueue is the dispatch queue of a parameterized job.
+type JobDispatchQueue struct {
+	JobID         string
+	Namespace     string
+	MaxConcurrent int
+	Running       int
+	Queued        []*QueuedDispatch
+}
+
+// QueuedDispatch is a dispatched job waiting in the dispatch queue of its
+// parameterized job.
+type QueuedDispatch struct {
+	ID          string
+	Namespace   string
+	Priority    int
+	SubmitTime  int64
+	CreateIndex uint64
+	Position    int
+}
+
 // JobVersionsResponse is used for a job get versions request
 type JobVersionsResponse struct {
 	Versions []*Job
@@ -749,7 +749,11 @@ func TestJobs_Canonicalize(t *testing.T) {
 										Envvars:       pointerOf(false),
 										VaultGrace:    pointerOf(time.Duration(0)),
 										ErrMissingKey: pointerOf(false),
+										UnsetIfEmpty:  pointerOf(false),
 										Once:          pointerOf(false),
+
+										FirstRenderTimeout:       pointerOf(time.Duration(0)),
+										FirstRenderTimeoutAction: pointerOf("fail"),
 									},
 									{
 										SourcePath:    pointerOf(""),
@@ -764,7 +768,11 @@ func TestJobs_Canonicalize(t *testing.T) {
 										Envvars:       pointerOf(true),
 										VaultGrace:    pointerOf(time.Duration(0)),
 										ErrMissingKey: pointerOf(false),
+										UnsetIfEmpty:  pointerOf(false),
 										Once:          pointerOf(false),
+
+										FirstRenderTimeout:       pointerOf(time.Duration(0)),
+										FirstRenderTimeoutAction: pointerOf("fail"),
 									},
 								},
 							},
@@ -812,12 +820,14 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoPromote:      pointerOf(false),
 				},
 				Periodic: &PeriodicConfig{
-					Enabled:         pointerOf(true),
-					Spec:            pointerOf(""),
-					Specs:           []string{},
-					SpecType:        pointerOf(PeriodicSpecCron),
-					ProhibitOverlap: pointerOf(false),
-					TimeZone:        pointerOf("UTC"),
+					Enabled:           pointerOf(true),
+					Spec:              pointerOf(""),
+					Specs:             []string{},
+					SpecType:          pointerOf(PeriodicSpecCron),
+					ProhibitOverlap:   pointerOf(false),
+					ConcurrencyPolicy: pointerOf(PeriodicConcurrencyAllow),
+					HistoryLimit:      pointerOf(0),
+					TimeZone:          pointerOf("UTC"),
 				},
 			},
 		},
@@ -2040,6 +2050,30 @@ func TestJobs_PeriodicForce(t *testing.T) {
 	must.Eq(t, eval.ID, evalID)
 }
 
+func TestJobs_PeriodicConfig_Next_Prohibit(t *testing.T) {
+	testutil.Parallel(t)
+
+	p := &PeriodicConfig{
+		Specs:    []string{"0 * * * *"},
+		TimeZone: pointerOf("Europe/Paris"),
+		Prohibit: []*PeriodicProhibit{
+			{Start: pointerOf("0 0 * * SAT"), Duration: pointerOf(48 * time.Hour)},
+			{Start: pointerOf("0 0 * * MON"), Duration: pointerOf(2 * time.Hour), Action: pointerOf("defer")},
+		},
+	}
+	p.Canonicalize()
+	must.Eq(t, PeriodicProhibitActionSkip, *p.Prohibit[0].Action)
+
+	paris, err := p.GetLocation()
+	must.NoError(t, err)
+
+	// Launches during the weekend are skipped, and the first launch of the
+	// week is deferred to the end of the second window
+	next, err := p.Next(time.Date(2024, time.March, 8, 23, 30, 0, 0, paris))
+	must.NoError(t, err)
+	must.Eq(t, time.Date(2024, time.March, 11, 2, 0, 0, 0, paris), next)
+}
+
 func TestJobs_Plan(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -36,6 +36,9 @@ type RootKeyMeta struct {
 	ModifyIndex uint64
 	State       RootKeyState
 	PublishTime int64
+
+	// Providers are the IDs of the KEK providers that wrapped the key
+	Providers []string
 }
 
 // RootKeyState enum describes the lifecycle of a root key.
@@ -59,6 +62,45 @@ func (k *Keyring) List(q *QueryOptions) ([]*RootKeyMeta, *QueryMeta, error) {
 	return resp, qm, nil
 }
 
+// Status returns the progress of decrypting the keyring of the server that
+// serves the request
+func (k *Keyring) Status(q *QueryOptions) (*KeyringStatus, *QueryMeta, error) {
+	var resp KeyringStatus
+	qm, err := k.client.query("/v1/operator/keyring/status", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// KeyringStatus is the progress of decrypting the keyring of a server, which
+// isn't ready until all keys have been decrypted
+type KeyringStatus struct {
+	ServerName string
+	Ready      bool
+	NumKeys    int
+	Pending    []*KeyringDecryptStatus
+}
+
+// KeyringDecryptState enum describes the progress of decrypting a key.
+type KeyringDecryptState string
+
+const (
+	KeyringDecryptStateQueued     KeyringDecryptState = "queued"
+	KeyringDecryptStateDecrypting KeyringDecryptState = "decrypting"
+)
+
+// KeyringDecryptStatus is the decryption status of a key
+type KeyringDecryptStatus struct {
+	KeyID     string
+	State     KeyringDecryptState
+	Providers []string
+	QueueTime int64
+	StartTime int64
+	Attempts  int
+	LastError string
+}
+
 // Delete deletes a specific inactive key from the keyring
 func (k *Keyring) Delete(opts *KeyringDeleteOptions, w *WriteOptions) (*WriteMeta, error) {
 	wm, err := k.client.delete(fmt.Sprintf("/v1/operator/keyring/key/%v?force=%v",
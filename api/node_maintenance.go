@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+const (
+	// NodeMaintenanceWindowStatusScheduled is the status of a maintenance
+	// window waiting for its next start.
+	NodeMaintenanceWindowStatusScheduled = "scheduled"
+
+	// NodeMaintenanceWindowStatusActive is the status of a maintenance window
+	// holding its nodes drained and ineligible.
+	NodeMaintenanceWindowStatusActive = "active"
+
+	// NodeMaintenanceWindowStatusComplete is the status of a maintenance
+	// window that won't start again.
+	NodeMaintenanceWindowStatusComplete = "complete"
+)
+
+// NodeMaintenance is used to access the node maintenance windows endpoints.
+type NodeMaintenance struct {
+	client *Client
+}
+
+// NodeMaintenance returns a handle on the node maintenance windows endpoints.
+func (c *Client) NodeMaintenance() *NodeMaintenance {
+	return &NodeMaintenance{client: c}
+}
+
+// List is used to list all maintenance windows.
+func (n *NodeMaintenance) List(q *QueryOptions) ([]*NodeMaintenanceWindow, *QueryMeta, error) {
+	var resp []*NodeMaintenanceWindow
+	qm, err := n.client.query("/v1/node/maintenance/windows", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// PrefixList is used to list maintenance windows whose ID matches a given
+// prefix.
+func (n *NodeMaintenance) PrefixList(prefix string, q *QueryOptions) ([]*NodeMaintenanceWindow, *QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	q.Prefix = prefix
+	return n.List(q)
+}
+
+// Info is used to fetch details of a specific maintenance window.
+func (n *NodeMaintenance) Info(id string, q *QueryOptions) (*NodeMaintenanceWindow, *QueryMeta, error) {
+	if id == "" {
+		return nil, nil, errors.New("missing maintenance window ID")
+	}
+
+	var resp NodeMaintenanceWindow
+	qm, err := n.client.query("/v1/node/maintenance/window/"+url.PathEscape(id), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Register is used to create or update a maintenance window. The window is
+// created if its ID is empty. The window is returned as it was stored, with
+// its ID and its next start time.
+func (n *NodeMaintenance) Register(window *NodeMaintenanceWindow, w *WriteOptions) (*NodeMaintenanceWindow, *WriteMeta, error) {
+	if window == nil {
+		return nil, nil, errors.New("missing maintenance window")
+	}
+
+	var resp NodeMaintenanceWindow
+	wm, err := n.client.put("/v1/node/maintenance/windows", window, &resp, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Delete is used to delete a maintenance window. The nodes held by the window
+// are made eligible again if it is active.
+func (n *NodeMaintenance) Delete(id string, w *WriteOptions) (*WriteMeta, error) {
+	if id == "" {
+		return nil, errors.New("missing maintenance window ID")
+	}
+
+	wm, err := n.client.delete("/v1/node/maintenance/window/"+url.PathEscape(id), nil, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// NodeMaintenanceWindow is used to serialize a node maintenance window, a
+// scheduled period during which the selected nodes are drained and held
+// ineligible for scheduling.
+type NodeMaintenanceWindow struct {
+	ID          string
+	Name        string
+	Description string
+
+	// NodeIDs, NodePool and Datacenter select the nodes under maintenance.
+	// The nodes must match all of those that are set.
+	NodeIDs    []string
+	NodePool   string
+	Datacenter string
+
+	// Start is the start time of the window, or the time before which
+	// recurring windows don't start.
+	Start time.Time
+
+	// Recurrence is the cron expression of the starts of recurring windows,
+	// evaluated in TimeZone.
+	Recurrence string
+	TimeZone   string
+
+	// Duration is how long each window lasts.
+	Duration time.Duration
+
+	// DrainSpec is how the nodes are drained at the start of each window.
+	DrainSpec *DrainSpec
+
+	Status        string
+	NextStart     time.Time
+	ActiveNodeIDs []string
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
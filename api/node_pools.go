@@ -119,10 +119,18 @@ type NodePool struct {
 	Description            string                          `hcl:"description,optional"`
 	Meta                   map[string]string               `hcl:"meta,block"`
 	SchedulerConfiguration *NodePoolSchedulerConfiguration `hcl:"scheduler_config,block"`
+	Overflow               []*NodePoolOverflow             `hcl:"overflow,block"`
 	CreateIndex            uint64
 	ModifyIndex            uint64
 }
 
+// NodePoolOverflow is used to serialize a node pool the jobs of a node pool
+// overflow into when none of its nodes are feasible.
+type NodePoolOverflow struct {
+	Pool     string `hcl:"pool"`
+	Priority int    `hcl:"priority,optional"`
+}
+
 // NodePoolSchedulerConfiguration is used to serialize the scheduler
 // configuration of a node pool.
 type NodePoolSchedulerConfiguration struct {
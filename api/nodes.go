@@ -6,6 +6,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"maps"
 	"sort"
 	"strconv"
 	"time"
@@ -316,6 +317,15 @@ func (n *Nodes) monitorDrainAllocs(ctx context.Context, nodeID string, ignoreSys
 	q := QueryOptions{AllowStale: true}
 	initial := make(map[string]*Allocation, 4)
 
+	// Track the allocations to drain, so that the progress of the drain can
+	// be reported, and the drain strategy for their deadlines.
+	toDrain := make(map[string]struct{}, 4)
+	lastDrained := -1
+	var strategy *DrainStrategy
+	if node, _, err := n.Info(nodeID, &QueryOptions{AllowStale: true}); err == nil {
+		strategy = node.DrainStrategy
+	}
+
 	for {
 		allocs, meta, err := n.Allocations(nodeID, &q)
 		if err != nil {
@@ -330,6 +340,7 @@ func (n *Nodes) monitorDrainAllocs(ctx context.Context, nodeID string, ignoreSys
 		q.WaitIndex = meta.LastIndex
 
 		runningAllocs := 0
+		drained := 0
 		for _, a := range allocs {
 			// Get previous version of alloc
 			orig, existing := initial[a.ID]
@@ -361,7 +372,7 @@ func (n *Nodes) monitorDrainAllocs(ctx context.Context, nodeID string, ignoreSys
 
 			if msg != "" {
 				select {
-				case allocCh <- Messagef(MonitorMsgLevelNormal, "Alloc %q %s", a.ID, msg):
+				case allocCh <- Messagef(MonitorMsgLevelNormal, "Alloc %q %s", a.ID, msg+drainProgress(a, strategy)):
 				case <-ctx.Done():
 					return
 				}
@@ -373,16 +384,34 @@ func (n *Nodes) monitorDrainAllocs(ctx context.Context, nodeID string, ignoreSys
 			}
 
 			// Track how many allocs are still running
-			if ignoreSys && a.Job.Type != nil && *a.Job.Type == JobTypeSystem {
+			if ignoreSys && *a.Job.Type == JobTypeSystem {
 				continue
 			}
 
+			if !existing && !a.ClientTerminalStatus() {
+				toDrain[a.ID] = struct{}{}
+			}
+			if _, ok := toDrain[a.ID]; ok && a.ClientTerminalStatus() {
+				drained++
+			}
+
 			switch a.ClientStatus {
 			case AllocClientStatusPending, AllocClientStatusRunning:
 				runningAllocs++
 			}
 		}
 
+		// Report the progress of the drain as allocs stop
+		if drained != lastDrained && len(toDrain) > 0 {
+			lastDrained = drained
+			msg := Messagef(MonitorMsgLevelNormal, "Drained %d of %d allocations on node %q", drained, len(toDrain), nodeID)
+			select {
+			case allocCh <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+
 		// Exit if all allocs are terminal
 		if runningAllocs == 0 {
 			msg := Messagef(MonitorMsgLevelInfo, "All allocations on node %q have stopped", nodeID)
@@ -574,6 +603,14 @@ type Node struct {
 	CreateIndex           uint64
 	ModifyIndex           uint64
 	NodeMaxAllocs         int
+	HostVolumeLimits      *HostVolumeLimits
+}
+
+// HostVolumeLimits caps the capacity of the dynamic host volumes on a node, in
+// total and per namespace.
+type HostVolumeLimits struct {
+	MaxBytes          int64
+	NamespaceMaxBytes map[string]int64
 }
 
 type NodeResources struct {
@@ -725,6 +762,10 @@ type DrainStrategy struct {
 	// be forced
 	ForceDeadline time.Time
 
+	// JobTypeForceDeadlines are the deadline times for the drain of the
+	// allocations of the job types with their own deadline.
+	JobTypeForceDeadlines map[string]time.Time
+
 	// StartedAt is the time the drain process started
 	StartedAt time.Time
 }
@@ -738,6 +779,25 @@ type DrainSpec struct {
 	// IgnoreSystemJobs allows systems jobs to remain on the node even though it
 	// has been marked for draining.
 	IgnoreSystemJobs bool
+
+	// JobTypeDeadlines overrides Deadline for the allocations of the given
+	// job types. The values follow the semantics of Deadline.
+	JobTypeDeadlines map[string]time.Duration `json:",omitempty"`
+
+	// KillPolicy, if set, overrides how the tasks of the allocations stopped
+	// by the drain are killed.
+	KillPolicy *DrainKillPolicy `json:",omitempty"`
+}
+
+// DrainKillPolicy describes how the tasks of allocations stopped by a drain
+// are killed.
+type DrainKillPolicy struct {
+	// Signal, if set, is sent to the tasks in place of their kill_signal.
+	Signal string
+
+	// Timeout, if set, caps the time the tasks are given to exit after the
+	// signal before they are forcefully killed.
+	Timeout time.Duration
 }
 
 func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
@@ -754,10 +814,59 @@ func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 	if d.IgnoreSystemJobs != o.IgnoreSystemJobs {
 		return false
 	}
+	if !maps.Equal(d.JobTypeDeadlines, o.JobTypeDeadlines) {
+		return false
+	}
+	if !maps.EqualFunc(d.JobTypeForceDeadlines, o.JobTypeForceDeadlines, time.Time.Equal) {
+		return false
+	}
+	if (d.KillPolicy == nil) != (o.KillPolicy == nil) ||
+		(d.KillPolicy != nil && *d.KillPolicy != *o.KillPolicy) {
+		return false
+	}
 
 	return true
 }
 
+// jobTypeDeadlineRemaining returns the time left until the allocations of
+// the job type are force stopped by the drain, if they have a deadline.
+func (d *DrainStrategy) jobTypeDeadlineRemaining(jobType string) (time.Duration, bool) {
+	if d == nil {
+		return 0, false
+	}
+
+	deadline, forceDeadline := d.Deadline, d.ForceDeadline
+	if jobTypeDeadline, ok := d.JobTypeDeadlines[jobType]; ok {
+		deadline, forceDeadline = jobTypeDeadline, d.JobTypeForceDeadlines[jobType]
+	}
+	switch {
+	case deadline < 0:
+		return 0, true
+	case deadline == 0 || forceDeadline.IsZero():
+		return 0, false
+	default:
+		remaining := time.Until(forceDeadline)
+		if remaining < 0 {
+			remaining = 0
+		}
+		return remaining.Truncate(time.Second), true
+	}
+}
+
+// drainProgress returns the details of the drain of the alloc reported when
+// monitoring the drain: its job, and the time left until it is force stopped.
+func drainProgress(a *Allocation, strategy *DrainStrategy) string {
+	if a.Job == nil || a.Job.Type == nil {
+		return ""
+	}
+
+	progress := fmt.Sprintf(" (job %q, type %s", a.JobID, *a.Job.Type)
+	if remaining, ok := strategy.jobTypeDeadlineRemaining(*a.Job.Type); ok && !a.ClientTerminalStatus() {
+		progress += fmt.Sprintf(", force stopped in %s", remaining)
+	}
+	return progress + ")"
+}
+
 // String returns a human readable version of the drain strategy.
 func (d *DrainStrategy) String() string {
 	if d.IgnoreSystemJobs {
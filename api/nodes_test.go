@@ -641,3 +641,37 @@ func TestNodeStatValueFormatting(t *testing.T) {
 		})
 	}
 }
+
+func TestNodes_DrainProgress(t *testing.T) {
+	testutil.Parallel(t)
+
+	strategy := &DrainStrategy{
+		DrainSpec: DrainSpec{
+			Deadline: time.Hour,
+			JobTypeDeadlines: map[string]time.Duration{
+				JobTypeBatch:  0,
+				JobTypeSystem: -1,
+			},
+		},
+		ForceDeadline: time.Now().Add(time.Hour),
+	}
+
+	alloc := &Allocation{
+		JobID:        "web",
+		Job:          &Job{Type: pointerOf(JobTypeService)},
+		ClientStatus: AllocClientStatusRunning,
+	}
+	must.StrContains(t, drainProgress(alloc, strategy), `(job "web", type service, force stopped in 59m`)
+
+	alloc.Job.Type = pointerOf(JobTypeSystem)
+	must.Eq(t, ` (job "web", type system, force stopped in 0s)`, drainProgress(alloc, strategy))
+
+	alloc.Job.Type = pointerOf(JobTypeBatch)
+	must.Eq(t, ` (job "web", type batch)`, drainProgress(alloc, strategy))
+
+	alloc.Job.Type = pointerOf(JobTypeService)
+	alloc.ClientStatus = AllocClientStatusComplete
+	must.Eq(t, ` (job "web", type service)`, drainProgress(alloc, strategy))
+
+	must.Eq(t, "", drainProgress(&Allocation{}, strategy))
+}
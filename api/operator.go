@@ -224,6 +224,7 @@ type PreemptionConfig struct {
 	SysBatchSchedulerEnabled bool
 	BatchSchedulerEnabled    bool
 	ServiceSchedulerEnabled  bool
+	MinPriorityDelta         int
 }
 
 // SchedulerGetConfiguration is used to query the current Scheduler configuration.
@@ -12,6 +12,7 @@ import (
 // a given task or task group.
 type Resources struct {
 	CPU         *int               `hcl:"cpu,optional"`
+	CPUMax      *int               `mapstructure:"cpu_max" hcl:"cpu_max,optional"`
 	Cores       *int               `hcl:"cores,optional"`
 	MemoryMB    *int               `mapstructure:"memory" hcl:"memory,optional"`
 	MemoryMaxMB *int               `mapstructure:"memory_max" hcl:"memory_max,optional"`
@@ -20,17 +21,31 @@ type Resources struct {
 	Devices     []*RequestedDevice `hcl:"device,block"`
 	NUMA        *NUMAResource      `hcl:"numa,block"`
 	SecretsMB   *int               `mapstructure:"secrets" hcl:"secrets,optional"`
+	DiskIO      *DiskIOResource    `mapstructure:"disk_io" hcl:"disk_io,block"`
 
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
 	// 0.10 and is only being kept to allow any references to be removed before
 	// then.
 	IOPS *int `hcl:"iops,optional"`
+
+	// CPUMin and MemoryMinMB are aliases of CPU and MemoryMB, which burstable
+	// tasks may use alongside CPUMax and MemoryMaxMB. CPU and MemoryMB take
+	// precedence when both are set.
+	CPUMin      *int `mapstructure:"cpu_min" hcl:"cpu_min,optional"`
+	MemoryMinMB *int `mapstructure:"memory_min" hcl:"memory_min,optional"`
 }
 
 // Canonicalize will supply missing values in the cases
 // where they are not provided.
 func (r *Resources) Canonicalize() {
+	if r.CPU == nil && r.CPUMin != nil {
+		r.CPU = pointerOf(*r.CPUMin)
+	}
+	if r.MemoryMB == nil && r.MemoryMinMB != nil {
+		r.MemoryMB = pointerOf(*r.MemoryMinMB)
+	}
+
 	defaultResources := DefaultResources()
 	if r.Cores == nil {
 		r.Cores = defaultResources.Cores
@@ -108,6 +123,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.SecretsMB != nil {
 		r.SecretsMB = other.SecretsMB
 	}
+	if other.DiskIO != nil {
+		r.DiskIO = other.DiskIO.Copy()
+	}
 }
 
 // NUMAResource contains the NUMA affinity request for scheduling purposes.
@@ -144,6 +162,30 @@ func (n *NUMAResource) Canonicalize() {
 	}
 }
 
+// DiskIOResource contains the disk I/O limits of a task on the block device
+// of the allocation directory. Unset limits leave the task unlimited.
+type DiskIOResource struct {
+	// ReadBPS and WriteBPS are the limits in bytes per second.
+	ReadBPS  *int64 `mapstructure:"read_bps" hcl:"read_bps,optional"`
+	WriteBPS *int64 `mapstructure:"write_bps" hcl:"write_bps,optional"`
+
+	// ReadIOPS and WriteIOPS are the limits in I/O operations per second.
+	ReadIOPS  *int64 `mapstructure:"read_iops" hcl:"read_iops,optional"`
+	WriteIOPS *int64 `mapstructure:"write_iops" hcl:"write_iops,optional"`
+}
+
+func (d *DiskIOResource) Copy() *DiskIOResource {
+	if d == nil {
+		return nil
+	}
+	return &DiskIOResource{
+		ReadBPS:   pointerCopy(d.ReadBPS),
+		WriteBPS:  pointerCopy(d.WriteBPS),
+		ReadIOPS:  pointerCopy(d.ReadIOPS),
+		WriteIOPS: pointerCopy(d.WriteIOPS),
+	}
+}
+
 type Port struct {
 	Label           string `hcl:",label"`
 	Value           int    `hcl:"static,optional"`
@@ -173,6 +215,19 @@ type NetworkResource struct {
 	DynamicPorts  []Port     `hcl:"port,block"`
 	Hostname      string     `hcl:"hostname,optional"`
 
+	// EgressMBits and IngressMBits limit the bandwidth of bridge networks.
+	EgressMBits  int `hcl:"egress_mbits,optional"`
+	IngressMBits int `hcl:"ingress_mbits,optional"`
+
+	// CNINetworks are additional CNI networks attached to the network
+	// namespace of bridge and cni networks.
+	CNINetworks []string `hcl:"cni_networks,optional"`
+
+	// IPv4Address and IPv6Address are static addresses assigned to the
+	// network namespace of bridge and cni networks.
+	IPv4Address string `hcl:"ipv4_address,optional"`
+	IPv6Address string `hcl:"ipv6_address,optional"`
+
 	// COMPAT(0.13)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
 	// 0.13 and is only being kept to allow any references to be removed before
@@ -46,6 +46,24 @@ func TestResources_Canonicalize(t *testing.T) {
 				MemoryMB: pointerOf(1024),
 			},
 		},
+		{
+			name: "burstable min aliases",
+			input: &Resources{
+				CPUMin:      pointerOf(500),
+				CPUMax:      pointerOf(1000),
+				MemoryMinMB: pointerOf(256),
+				MemoryMaxMB: pointerOf(512),
+			},
+			expected: &Resources{
+				CPU:         pointerOf(500),
+				CPUMax:      pointerOf(1000),
+				CPUMin:      pointerOf(500),
+				Cores:       pointerOf(0),
+				MemoryMB:    pointerOf(256),
+				MemoryMinMB: pointerOf(256),
+				MemoryMaxMB: pointerOf(512),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
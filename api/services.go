@@ -55,10 +55,23 @@ type ServiceRegistration struct {
 	// on cluster network topology.
 	Address string
 
+	// AddressIPv6 is the IPv6 address of service registrations in dual-stack
+	// allocation networks, in which case Address is the IPv4 address.
+	AddressIPv6 string
+
 	// Port is the port number on which this service registration is bound. It
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// Weight is the relative weight of this service registration to send
+	// traffic to, determined from the passing weight of either
+	// Service.Weights or Service.CanaryWeights.
+	Weight int
+
+	// Canary indicates the allocation of this service registration is a
+	// canary of a deployment which has not been promoted yet.
+	Canary bool
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -215,11 +228,13 @@ type ServiceCheck struct {
 	Notes                  string              `hcl:"notes,optional"`
 	TLSServerName          string              `mapstructure:"tls_server_name" hcl:"tls_server_name,optional"`
 	TLSSkipVerify          bool                `mapstructure:"tls_skip_verify" hcl:"tls_skip_verify,optional"`
+	TLSCACert              string              `mapstructure:"tls_ca_cert" hcl:"tls_ca_cert,optional"`
 	Header                 map[string][]string `hcl:"header,block"`
 	Method                 string              `hcl:"method,optional"`
 	CheckRestart           *CheckRestart       `mapstructure:"check_restart" hcl:"check_restart,block"`
 	GRPCService            string              `mapstructure:"grpc_service" hcl:"grpc_service,optional"`
 	GRPCUseTLS             bool                `mapstructure:"grpc_use_tls" hcl:"grpc_use_tls,optional"`
+	TCPUseTLS              bool                `mapstructure:"tcp_use_tls" hcl:"tcp_use_tls,optional"`
 	TaskName               string              `mapstructure:"task" hcl:"task,optional"`
 	SuccessBeforePassing   int                 `mapstructure:"success_before_passing" hcl:"success_before_passing,optional"`
 	FailuresBeforeCritical int                 `mapstructure:"failures_before_critical" hcl:"failures_before_critical,optional"`
@@ -247,6 +262,7 @@ type Service struct {
 	OnUpdate          string            `mapstructure:"on_update" hcl:"on_update,optional"`
 	Identity          *WorkloadIdentity `hcl:"identity,block"`
 	Weights           *ServiceWeights   `mapstructure:"weights" hcl:"weights,block"`
+	CanaryWeights     *ServiceWeights   `mapstructure:"canary_weights" hcl:"canary_weights,block"`
 
 	// Provider defines which backend system provides the service registration,
 	// either "consul" (default) or "nomad".
@@ -309,6 +325,7 @@ func (s *Service) Canonicalize(t *Task, tg *TaskGroup, job *Job) {
 
 	s.Connect.Canonicalize()
 	s.Weights.Canonicalize()
+	s.CanaryWeights.Canonicalize()
 
 	// Canonicalize CheckRestart on Checks and merge Service.CheckRestart
 	// into each check.
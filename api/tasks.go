@@ -40,6 +40,7 @@ type MemoryStats struct {
 	MaxUsage       uint64
 	KernelUsage    uint64
 	KernelMaxUsage uint64
+	Pressure       *PressureStats
 	Measured       []string
 }
 
@@ -51,14 +52,51 @@ type CpuStats struct {
 	ThrottledPeriods uint64
 	ThrottledTime    uint64
 	Percent          float64
+	Pressure         *PressureStats
 	Measured         []string
 }
 
-// ResourceUsage holds information related to cpu and memory stats
+// PressureStats holds the pressure stall information of a resource
+type PressureStats struct {
+	SomeAvg10  float64
+	SomeAvg60  float64
+	SomeAvg300 float64
+	SomeTotal  uint64
+	FullAvg10  float64
+	FullAvg60  float64
+	FullAvg300 float64
+	FullTotal  uint64
+}
+
+// BlockIOStats holds disk I/O related stats
+type BlockIOStats struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadOps    uint64
+	WriteOps   uint64
+	Measured   []string
+}
+
+// NetworkStats holds network I/O related stats
+type NetworkStats struct {
+	RxBytes   uint64
+	RxPackets uint64
+	RxErrors  uint64
+	RxDropped uint64
+	TxBytes   uint64
+	TxPackets uint64
+	TxErrors  uint64
+	TxDropped uint64
+	Measured  []string
+}
+
+// ResourceUsage holds information related to cpu, memory, and I/O stats
 type ResourceUsage struct {
-	MemoryStats *MemoryStats
-	CpuStats    *CpuStats
-	DeviceStats []*DeviceGroupStats
+	MemoryStats  *MemoryStats
+	CpuStats     *CpuStats
+	DeviceStats  []*DeviceGroupStats
+	BlockIOStats *BlockIOStats
+	NetworkStats *NetworkStats
 }
 
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
@@ -123,6 +161,22 @@ func (r *RestartPolicy) Merge(rp *RestartPolicy) {
 	}
 }
 
+// RestartBudget limits the restarts of all the tasks of a task group. Once it
+// is exceeded the allocation fails and can be rescheduled.
+type RestartBudget struct {
+	Attempts *int           `hcl:"attempts,optional"`
+	Interval *time.Duration `hcl:"interval,optional"`
+}
+
+func (r *RestartBudget) Canonicalize() {
+	if r.Attempts == nil {
+		r.Attempts = pointerOf(0)
+	}
+	if r.Interval == nil {
+		r.Interval = pointerOf(30 * time.Minute)
+	}
+}
+
 // Disconnect strategy defines how both clients and server should behave in case of
 // disconnection between them.
 type DisconnectStrategy struct {
@@ -175,6 +229,14 @@ type ReschedulePolicy struct {
 
 	// Unlimited allows rescheduling attempts until they succeed
 	Unlimited *bool `mapstructure:"unlimited" hcl:"unlimited,optional"`
+
+	// Jitter is the fraction of the delay by which each reschedule delay is
+	// randomly shortened.
+	Jitter *float64 `mapstructure:"jitter" hcl:"jitter,optional"`
+
+	// AvoidNodeWindow is the duration during which the nodes an allocation
+	// failed on are avoided when rescheduling it.
+	AvoidNodeWindow *time.Duration `mapstructure:"avoid_node_window" hcl:"avoid_node_window,optional"`
 }
 
 func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
@@ -199,6 +261,12 @@ func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
 	if rp.Unlimited != nil {
 		r.Unlimited = rp.Unlimited
 	}
+	if rp.Jitter != nil {
+		r.Jitter = rp.Jitter
+	}
+	if rp.AvoidNodeWindow != nil {
+		r.AvoidNodeWindow = rp.AvoidNodeWindow
+	}
 }
 
 func (r *ReschedulePolicy) Canonicalize(jobType string) {
@@ -246,6 +314,24 @@ func (a *Affinity) Canonicalize() {
 	}
 }
 
+// JobAffinity is used to serialize task group affinities to allocations of
+// other jobs or services
+type JobAffinity struct {
+	Job      string `hcl:"job,optional"`      // ID of the job whose allocations are matched
+	Service  string `hcl:"service,optional"`  // Name of the service whose allocations are matched
+	Weight   *int8  `hcl:"weight,optional"`   // Weight applied to nodes running matching allocations. Can be negative
+	Required *bool  `hcl:"required,optional"` // Whether nodes must satisfy the affinity
+}
+
+func (a *JobAffinity) Canonicalize() {
+	if a.Weight == nil {
+		a.Weight = pointerOf(int8(50))
+	}
+	if a.Required == nil {
+		a.Required = pointerOf(false)
+	}
+}
+
 func NewDefaultDisconnectStrategy() *DisconnectStrategy {
 	return &DisconnectStrategy{
 		LostAfter: pointerOf(0 * time.Minute),
@@ -332,6 +418,7 @@ type Spread struct {
 	Attribute    string          `hcl:"attribute,optional"`
 	Weight       *int8           `hcl:"weight,optional"`
 	SpreadTarget []*SpreadTarget `hcl:"target,block"`
+	MaxSkew      *int            `mapstructure:"max_skew" hcl:"max_skew,optional"`
 }
 
 // SpreadTarget is used to serialize target allocation spread percentages
@@ -499,10 +586,13 @@ type TaskGroup struct {
 	Count            *int                      `hcl:"count,optional"`
 	Constraints      []*Constraint             `hcl:"constraint,block"`
 	Affinities       []*Affinity               `hcl:"affinity,block"`
+	JobAffinities    []*JobAffinity            `hcl:"job_affinity,block"`
 	Tasks            []*Task                   `hcl:"task,block"`
 	Spreads          []*Spread                 `hcl:"spread,block"`
+	Gang             *string                   `hcl:"gang,optional"`
 	Volumes          map[string]*VolumeRequest `hcl:"volume,block"`
 	RestartPolicy    *RestartPolicy            `hcl:"restart,block"`
+	RestartBudget    *RestartBudget            `hcl:"restart_budget,block"`
 	Disconnect       *DisconnectStrategy       `hcl:"disconnect,block"`
 	ReschedulePolicy *ReschedulePolicy         `hcl:"reschedule,block"`
 	EphemeralDisk    *EphemeralDisk            `hcl:"ephemeral_disk,block"`
@@ -622,6 +712,10 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	}
 	g.RestartPolicy = defaultRestartPolicy
 
+	if g.RestartBudget != nil {
+		g.RestartBudget.Canonicalize()
+	}
+
 	for _, t := range g.Tasks {
 		t.Canonicalize(g, job)
 	}
@@ -632,6 +726,9 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	for _, a := range g.Affinities {
 		a.Canonicalize()
 	}
+	for _, a := range g.JobAffinities {
+		a.Canonicalize()
+	}
 	for _, n := range g.Networks {
 		n.Canonicalize()
 	}
@@ -717,6 +814,22 @@ type LogConfig struct {
 	Enabled *bool `mapstructure:"enabled" hcl:"enabled,optional"`
 
 	Disabled *bool `mapstructure:"disabled" hcl:"disabled,optional"`
+
+	// Format is the format of the log files, either "text" or "json".
+	Format string `mapstructure:"format" hcl:"format,optional"`
+
+	// Sink is a remote log sink the client ships the logs of the task to.
+	Sink *LogSink `mapstructure:"sink" hcl:"sink,block"`
+}
+
+// LogSink is a remote log sink for the logs of a task. Type is one of
+// "syslog", "fluentd", or "file".
+type LogSink struct {
+	Type     string `mapstructure:"type" hcl:"type,optional"`
+	Address  string `mapstructure:"address" hcl:"address,optional"`
+	Path     string `mapstructure:"path" hcl:"path,optional"`
+	Tag      string `mapstructure:"tag" hcl:"tag,optional"`
+	Facility string `mapstructure:"facility" hcl:"facility,optional"`
 }
 
 func DefaultLogConfig() *LogConfig {
@@ -745,14 +858,16 @@ type DispatchPayloadConfig struct {
 }
 
 const (
-	TaskLifecycleHookPrestart  = "prestart"
-	TaskLifecycleHookPoststart = "poststart"
-	TaskLifecycleHookPoststop  = "poststop"
+	TaskLifecycleHookPrestartInit = "prestart_init"
+	TaskLifecycleHookPrestart     = "prestart"
+	TaskLifecycleHookPoststart    = "poststart"
+	TaskLifecycleHookPoststop     = "poststop"
 )
 
 type TaskLifecycle struct {
-	Hook    string `mapstructure:"hook" hcl:"hook,optional"`
-	Sidecar bool   `mapstructure:"sidecar" hcl:"sidecar,optional"`
+	Hook       string         `mapstructure:"hook" hcl:"hook,optional"`
+	Sidecar    bool           `mapstructure:"sidecar" hcl:"sidecar,optional"`
+	DrainDelay *time.Duration `mapstructure:"drain_delay" hcl:"drain_delay,optional"`
 }
 
 // Determine if lifecycle has user-input values
@@ -798,6 +913,8 @@ type Task struct {
 
 	Actions []*Action `hcl:"action,block"`
 
+	Secrets []*Secret `hcl:"secret,block"`
+
 	Schedule *TaskSchedule `hcl:"schedule,block"`
 }
 
@@ -854,13 +971,25 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 
 // TaskArtifact is used to download artifacts before running a task.
 type TaskArtifact struct {
-	GetterSource   *string           `mapstructure:"source" hcl:"source,optional"`
-	GetterOptions  map[string]string `mapstructure:"options" hcl:"options,block"`
-	GetterHeaders  map[string]string `mapstructure:"headers" hcl:"headers,block"`
-	GetterMode     *string           `mapstructure:"mode" hcl:"mode,optional"`
-	GetterInsecure *bool             `mapstructure:"insecure" hcl:"insecure,optional"`
-	RelativeDest   *string           `mapstructure:"destination" hcl:"destination,optional"`
-	Chown          bool              `mapstructure:"chown" hcl:"chown,optional"`
+	GetterSource   *string             `mapstructure:"source" hcl:"source,optional"`
+	GetterOptions  map[string]string   `mapstructure:"options" hcl:"options,block"`
+	GetterHeaders  map[string]string   `mapstructure:"headers" hcl:"headers,block"`
+	GetterMode     *string             `mapstructure:"mode" hcl:"mode,optional"`
+	GetterInsecure *bool               `mapstructure:"insecure" hcl:"insecure,optional"`
+	RelativeDest   *string             `mapstructure:"destination" hcl:"destination,optional"`
+	Chown          bool                `mapstructure:"chown" hcl:"chown,optional"`
+	Verify         *TaskArtifactVerify `mapstructure:"verify" hcl:"verify,block"`
+}
+
+// TaskArtifactVerify is used to verify the detached signature of an artifact
+// before it is made available to the task.
+type TaskArtifactVerify struct {
+	Type                  string `mapstructure:"type" hcl:"type,optional"`
+	Signature             string `mapstructure:"signature" hcl:"signature,optional"`
+	PublicKey             string `mapstructure:"public_key" hcl:"public_key,optional"`
+	Bundle                string `mapstructure:"bundle" hcl:"bundle,optional"`
+	CertificateIdentity   string `mapstructure:"certificate_identity" hcl:"certificate_identity,optional"`
+	CertificateOIDCIssuer string `mapstructure:"certificate_oidc_issuer" hcl:"certificate_oidc_issuer,optional"`
 }
 
 func (a *TaskArtifact) Canonicalize() {
@@ -935,12 +1064,29 @@ func (ch *ChangeScript) Canonicalize() {
 	}
 }
 
+// TemplateExec is a long lived process run inside the task by a template with
+// change_mode "exec_restart".
+type TemplateExec struct {
+	Command *string  `mapstructure:"command" hcl:"command"`
+	Args    []string `mapstructure:"args" hcl:"args,optional"`
+}
+
+func (te *TemplateExec) Canonicalize() {
+	if te.Command == nil {
+		te.Command = pointerOf("")
+	}
+	if te.Args == nil {
+		te.Args = []string{}
+	}
+}
+
 type Template struct {
 	SourcePath    *string        `mapstructure:"source" hcl:"source,optional"`
 	DestPath      *string        `mapstructure:"destination" hcl:"destination,optional"`
 	EmbeddedTmpl  *string        `mapstructure:"data" hcl:"data,optional"`
 	ChangeMode    *string        `mapstructure:"change_mode" hcl:"change_mode,optional"`
 	ChangeScript  *ChangeScript  `mapstructure:"change_script" hcl:"change_script,block"`
+	Exec          *TemplateExec  `mapstructure:"exec" hcl:"exec,block"`
 	ChangeSignal  *string        `mapstructure:"change_signal" hcl:"change_signal,optional"`
 	Once          *bool          `mapstructure:"once" hcl:"once,optional"`
 	Splay         *time.Duration `mapstructure:"splay" hcl:"splay,optional"`
@@ -950,9 +1096,16 @@ type Template struct {
 	LeftDelim     *string        `mapstructure:"left_delimiter" hcl:"left_delimiter,optional"`
 	RightDelim    *string        `mapstructure:"right_delimiter" hcl:"right_delimiter,optional"`
 	Envvars       *bool          `mapstructure:"env" hcl:"env,optional"`
+	EnvPrefix     string         `mapstructure:"env_prefix" hcl:"env_prefix,optional"`
+	UnsetIfEmpty  *bool          `mapstructure:"unset_if_empty" hcl:"unset_if_empty,optional"`
 	VaultGrace    *time.Duration `mapstructure:"vault_grace" hcl:"vault_grace,optional"`
 	Wait          *WaitConfig    `mapstructure:"wait" hcl:"wait,block"`
 	ErrMissingKey *bool          `mapstructure:"error_on_missing_key" hcl:"error_on_missing_key,optional"`
+	VaultRole     string         `mapstructure:"vault_role" hcl:"vault_role,optional"`
+
+	FirstRenderTimeout       *time.Duration `mapstructure:"first_render_timeout" hcl:"first_render_timeout,optional"`
+	FirstRenderTimeoutAction *string        `mapstructure:"first_render_timeout_action" hcl:"first_render_timeout_action,optional"`
+	Checksum                 string         `mapstructure:"checksum" hcl:"checksum,optional"`
 }
 
 func (tmpl *Template) Canonicalize() {
@@ -969,7 +1122,7 @@ func (tmpl *Template) Canonicalize() {
 		tmpl.ChangeMode = pointerOf("restart")
 	}
 	if tmpl.ChangeSignal == nil {
-		if *tmpl.ChangeMode == "signal" {
+		if *tmpl.ChangeMode == "signal" || *tmpl.ChangeMode == "reload" {
 			tmpl.ChangeSignal = pointerOf("SIGHUP")
 		} else {
 			tmpl.ChangeSignal = pointerOf("")
@@ -981,6 +1134,9 @@ func (tmpl *Template) Canonicalize() {
 	if tmpl.ChangeScript != nil {
 		tmpl.ChangeScript.Canonicalize()
 	}
+	if tmpl.Exec != nil {
+		tmpl.Exec.Canonicalize()
+	}
 	if tmpl.Once == nil {
 		tmpl.Once = pointerOf(false)
 	}
@@ -999,9 +1155,18 @@ func (tmpl *Template) Canonicalize() {
 	if tmpl.Envvars == nil {
 		tmpl.Envvars = pointerOf(false)
 	}
+	if tmpl.UnsetIfEmpty == nil {
+		tmpl.UnsetIfEmpty = pointerOf(false)
+	}
 	if tmpl.ErrMissingKey == nil {
 		tmpl.ErrMissingKey = pointerOf(false)
 	}
+	if tmpl.FirstRenderTimeout == nil {
+		tmpl.FirstRenderTimeout = pointerOf(time.Duration(0))
+	}
+	if tmpl.FirstRenderTimeoutAction == nil {
+		tmpl.FirstRenderTimeoutAction = pointerOf("fail")
+	}
 	//COMPAT(0.12) VaultGrace is deprecated and unused as of Vault 0.5
 	if tmpl.VaultGrace == nil {
 		tmpl.VaultGrace = pointerOf(time.Duration(0))
@@ -1018,6 +1183,21 @@ type Vault struct {
 	ChangeMode           *string  `mapstructure:"change_mode" hcl:"change_mode,optional"`
 	ChangeSignal         *string  `mapstructure:"change_signal" hcl:"change_signal,optional"`
 	AllowTokenExpiration *bool    `mapstructure:"allow_token_expiration" hcl:"allow_token_expiration,optional"`
+
+	SecretRotation *VaultSecretRotation `mapstructure:"secret_rotation" hcl:"secret_rotation,block"`
+}
+
+// VaultSecretRotation configures the task to be restarted when the Vault
+// secrets read by its templates are rotated, before the leases of the
+// previous secrets expire.
+type VaultSecretRotation struct {
+	DrainScript *ChangeScript `mapstructure:"drain_script" hcl:"drain_script,block"`
+}
+
+func (r *VaultSecretRotation) Canonicalize() {
+	if r.DrainScript != nil {
+		r.DrainScript.Canonicalize()
+	}
 }
 
 func (v *Vault) Canonicalize() {
@@ -1042,6 +1222,9 @@ func (v *Vault) Canonicalize() {
 	if v.AllowTokenExpiration == nil {
 		v.AllowTokenExpiration = pointerOf(false)
 	}
+	if v.SecretRotation != nil {
+		v.SecretRotation.Canonicalize()
+	}
 }
 
 // NewTask creates and initializes a new Task.
@@ -1111,29 +1294,49 @@ type TaskState struct {
 	StartedAt   time.Time
 	FinishedAt  time.Time
 	Events      []*TaskEvent
+	Templates   []*TemplateState
+}
+
+// TemplateState is the render state of a task's template and its
+// dependencies.
+type TemplateState struct {
+	DestPath     string
+	LastRendered time.Time
+	Dependencies []*TemplateDependencyState
+}
+
+// TemplateDependencyState is the state of a single dependency of a template.
+type TemplateDependencyState struct {
+	Name        string
+	Type        string
+	Missing     bool
+	LastChanged time.Time
 }
 
 const (
-	TaskSetup                  = "Task Setup"
-	TaskSetupFailure           = "Setup Failure"
-	TaskDriverFailure          = "Driver Failure"
-	TaskDriverMessage          = "Driver"
-	TaskReceived               = "Received"
-	TaskFailedValidation       = "Failed Validation"
-	TaskStarted                = "Started"
-	TaskTerminated             = "Terminated"
-	TaskKilling                = "Killing"
-	TaskKilled                 = "Killed"
-	TaskRestarting             = "Restarting"
-	TaskNotRestarting          = "Not Restarting"
-	TaskDownloadingArtifacts   = "Downloading Artifacts"
-	TaskArtifactDownloadFailed = "Failed Artifact Download"
-	TaskSiblingFailed          = "Sibling Task Failed"
-	TaskSignaling              = "Signaling"
-	TaskRestartSignal          = "Restart Signaled"
-	TaskLeaderDead             = "Leader Task Dead"
-	TaskBuildingTaskDir        = "Building Task Directory"
-	TaskClientReconnected      = "Reconnected"
+	TaskSetup                      = "Task Setup"
+	TaskSetupFailure               = "Setup Failure"
+	TaskDriverFailure              = "Driver Failure"
+	TaskDriverMessage              = "Driver"
+	TaskReceived                   = "Received"
+	TaskFailedValidation           = "Failed Validation"
+	TaskStarted                    = "Started"
+	TaskTerminated                 = "Terminated"
+	TaskKilling                    = "Killing"
+	TaskKilled                     = "Killed"
+	TaskRestarting                 = "Restarting"
+	TaskNotRestarting              = "Not Restarting"
+	TaskDownloadingArtifacts       = "Downloading Artifacts"
+	TaskArtifactDownloadFailed     = "Failed Artifact Download"
+	TaskArtifactVerificationFailed = "Failed Artifact Verification"
+	TaskSiblingFailed              = "Sibling Task Failed"
+	TaskSignaling                  = "Signaling"
+	TaskRestartSignal              = "Restart Signaled"
+	TaskVaultSecretRotation        = "Vault Secret Rotation"
+	TaskLeaderDead                 = "Leader Task Dead"
+	TaskBuildingTaskDir            = "Building Task Directory"
+	TaskClientReconnected          = "Reconnected"
+	TaskDeviceUnhealthy            = "Device Unhealthy"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -1239,6 +1442,18 @@ type WorkloadIdentity struct {
 	Filepath     string        `hcl:"filepath,optional"`
 	ServiceName  string        `hcl:"service_name,optional"`
 	TTL          time.Duration `mapstructure:"ttl" hcl:"ttl,optional"`
+
+	AWS *WorkloadIdentityAWS `mapstructure:"aws" hcl:"aws,block"`
+}
+
+// WorkloadIdentityAWS configures exchanging a task's workload identity for AWS
+// credentials, which are written to a shared credentials file in the task's
+// secrets directory.
+type WorkloadIdentityAWS struct {
+	RoleARN  string        `mapstructure:"role_arn" hcl:"role_arn"`
+	Region   string        `hcl:"region,optional"`
+	Profile  string        `hcl:"profile,optional"`
+	Duration time.Duration `hcl:"duration,optional"`
 }
 
 type Action struct {
@@ -1246,3 +1461,11 @@ type Action struct {
 	Command string   `mapstructure:"command" hcl:"command"`
 	Args    []string `mapstructure:"args" hcl:"args,optional"`
 }
+
+// Secret is a Nomad Variable written to the task's secrets directory before
+// the task starts.
+type Secret struct {
+	Name string `hcl:"name,label"`
+	Path string `mapstructure:"path" hcl:"path"`
+	Env  bool   `mapstructure:"env" hcl:"env,optional"`
+}
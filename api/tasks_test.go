@@ -470,6 +470,31 @@ func TestTask_Canonicalize_Vault(t *testing.T) {
 				AllowTokenExpiration: pointerOf(false),
 			},
 		},
+		{
+			name: "secret rotation",
+			input: &Vault{
+				SecretRotation: &VaultSecretRotation{
+					DrainScript: &ChangeScript{Command: pointerOf("/bin/drain")},
+				},
+			},
+			expected: &Vault{
+				Env:                  pointerOf(true),
+				DisableFile:          pointerOf(false),
+				Namespace:            pointerOf(""),
+				Cluster:              "default",
+				ChangeMode:           pointerOf("restart"),
+				ChangeSignal:         pointerOf("SIGHUP"),
+				AllowTokenExpiration: pointerOf(false),
+				SecretRotation: &VaultSecretRotation{
+					DrainScript: &ChangeScript{
+						Command:     pointerOf("/bin/drain"),
+						Args:        []string{},
+						Timeout:     pointerOf(5 * time.Second),
+						FailOnError: pointerOf(false),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -508,6 +533,37 @@ func TestTaskGroup_Canonicalize_Update(t *testing.T) {
 	must.Nil(t, tg.Update)
 }
 
+func TestTaskGroup_Canonicalize_UpdateVerify(t *testing.T) {
+	testutil.Parallel(t)
+
+	job := &Job{
+		ID: pointerOf("test"),
+		Update: &UpdateStrategy{
+			Canary:      pointerOf(1),
+			AutoPromote: pointerOf(true),
+			Verify: &UpdateVerify{
+				Address: pointerOf("http://127.0.0.1:8080/health"),
+			},
+		},
+	}
+	job.Canonicalize()
+	tg := &TaskGroup{
+		Name: pointerOf("foo"),
+	}
+	tg.Canonicalize(job)
+
+	// The group inherits a copy of the verification of the job
+	must.Eq(t, &UpdateVerify{
+		Type:     pointerOf(UpdateVerifyTypeHTTP),
+		Address:  pointerOf("http://127.0.0.1:8080/health"),
+		Query:    pointerOf(""),
+		Checks:   pointerOf(1),
+		Interval: pointerOf(10 * time.Second),
+		Timeout:  pointerOf(5 * time.Second),
+	}, tg.Update.Verify)
+	must.NotEqOp(t, job.Update.Verify, tg.Update.Verify)
+}
+
 func TestTaskGroup_Canonicalize_Scaling(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -73,6 +73,62 @@ func (vars *Variables) Read(path string, qo *QueryOptions) (*Variable, *QueryMet
 	return v, qm, nil
 }
 
+// ReadVersion is used to query a version of a variable by path, which may be
+// the current version or one kept in its history. This will error if the
+// version is not found.
+func (vars *Variables) ReadVersion(path string, version uint64, qo *QueryOptions) (*Variable, *QueryMeta, error) {
+	path = cleanPathString(path)
+	var v = new(Variable)
+	qm, err := vars.readInternal("/v1/var/"+path+"?version="+fmt.Sprint(version), &v, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v == nil {
+		return nil, qm, ErrVariablePathNotFound
+	}
+	return v, qm, nil
+}
+
+// History is used to list the metadata of the versions of a variable, with
+// the current version first.
+func (vars *Variables) History(path string, qo *QueryOptions) ([]*VariableMetadata, *QueryMeta, error) {
+	path = cleanPathString(path)
+	var resp []*VariableMetadata
+	qm, err := vars.client.query("/v1/var/"+path+"?history", &resp, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Rollback is used to restore the items of a version of a variable. The
+// rollback is written as a new version of the variable.
+func (vars *Variables) Rollback(path string, version uint64, qo *WriteOptions) (*Variable, *WriteMeta, error) {
+	path = cleanPathString(path)
+	var out Variable
+
+	wm, err := vars.client.put("/v1/var/"+path+"?rollback="+fmt.Sprint(version), nil, &out, qo)
+	if err != nil {
+		return nil, wm, err
+	}
+	return &out, wm, nil
+}
+
+// CheckedRollback is used to restore the items of a version of a variable if
+// the modify index of the variable matches the checkIndex. If it does not, it
+// will return an ErrCASConflict that can be unwrapped for more details.
+func (vars *Variables) CheckedRollback(path string, version, checkIndex uint64, qo *WriteOptions) (*Variable, *WriteMeta, error) {
+	path = cleanPathString(path)
+	var out Variable
+
+	in := &Variable{Path: path, ModifyIndex: checkIndex}
+	wm, err := vars.writeChecked(fmt.Sprintf("/v1/var/%s?rollback=%d&cas=%d", path, version, checkIndex), in, &out, qo)
+	if err != nil {
+		return nil, wm, err
+	}
+	return &out, wm, nil
+}
+
 // Peek is used to query a single variable by path, but does not error
 // when the variable is not found
 func (vars *Variables) Peek(path string, qo *QueryOptions) (*Variable, *QueryMeta, error) {
@@ -395,6 +451,9 @@ type Variable struct {
 	// ModifyTime is the unix nano of the last modified time
 	ModifyTime int64 `hcl:"modify_time"`
 
+	// Version is incremented each time the items of the variable change
+	Version uint64 `hcl:"version"`
+
 	// Items contains the k/v variable component
 	Items VariableItems `hcl:"items"`
 
@@ -423,6 +482,9 @@ type VariableMetadata struct {
 	// ModifyTime is the unix nano of the last modified time
 	ModifyTime int64 `hcl:"modify_time"`
 
+	// Version is incremented each time the items of the variable change
+	Version uint64 `hcl:"version"`
+
 	// Lock holds the information about the variable lock if its being used.
 	Lock *VariableLock `hcl:",lock,optional" json:",omitempty"`
 }
@@ -475,6 +537,7 @@ func (v *Variable) Metadata() *VariableMetadata {
 		ModifyIndex: v.ModifyIndex,
 		CreateTime:  v.CreateTime,
 		ModifyTime:  v.ModifyTime,
+		Version:     v.Version,
 	}
 }
 
@@ -64,6 +64,19 @@ func (c *Client) ResolveToken(bearerToken string) (*acl.ACL, error) {
 	return a, err
 }
 
+// ResolveSecretToken is used to translate an ACL token secret ID into the ACL
+// token, which is nil for workload identities.
+func (c *Client) ResolveSecretToken(secretID string) (*structs.ACLToken, error) {
+	if !c.GetConfig().ACLEnabled {
+		return structs.ACLsDisabledToken, nil
+	}
+	ident, err := c.resolveTokenValue(secretID)
+	if err != nil {
+		return nil, err
+	}
+	return ident.ACLToken, nil
+}
+
 func (c *Client) resolveTokenAndACL(bearerToken string) (*acl.ACL, *structs.AuthenticatedIdentity, error) {
 	// Fast-path if ACLs are disabled
 	if !c.GetConfig().ACLEnabled {
@@ -87,6 +100,9 @@ func (c *Client) resolveTokenAndACL(bearerToken string) (*acl.ACL, *structs.Auth
 	if ident.IsExpired(time.Now().Add(2 * time.Second)) {
 		return nil, nil, structs.ErrTokenExpired
 	}
+	if ident.ACLToken.IsNotYetValid(time.Now().Add(-2 * time.Second)) {
+		return nil, nil, structs.ErrTokenNotYetValid
+	}
 
 	var policies []*structs.ACLPolicy
 
@@ -15,6 +15,7 @@ import (
 	metrics "github.com/hashicorp/go-metrics/compat"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/client/lib/execrecorder"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -72,6 +73,32 @@ func (a *Allocations) GarbageCollect(args *nstructs.AllocSpecificRequest, reply
 	return nil
 }
 
+// SnapshotChunk returns a chunk of the snapshot of the data of an allocation,
+// so that another node can migrate it. The snapshot is kept until the
+// allocation is garbage collected by the servers, so that interrupted
+// transfers can be resumed.
+func (a *Allocations) SnapshotChunk(args *cstructs.AllocSnapshotChunkRequest, reply *cstructs.AllocSnapshotChunkResponse) error {
+	defer metrics.MeasureSince([]string{"client", "allocations", "snapshot_chunk"}, time.Now())
+
+	if !a.c.ValidateMigrateToken(args.AllocID, args.MigrateToken) {
+		return nstructs.ErrPermissionDenied
+	}
+
+	snap, err := a.c.allocSnapshots.get(args.AllocID, a.c.GetAllocFS)
+	if err != nil {
+		return err
+	}
+	data, err := snap.readChunk(args.Offset)
+	if err != nil {
+		return err
+	}
+
+	reply.Data = data
+	reply.Size = snap.size
+	reply.Checksum = snap.checksum
+	return nil
+}
+
 // Signal is used to send a signal to an allocation's tasks on a client.
 func (a *Allocations) Signal(args *nstructs.AllocSignalRequest, reply *nstructs.GenericResponse) error {
 	defer metrics.MeasureSince([]string{"client", "allocations", "signal"}, time.Now())
@@ -204,6 +231,38 @@ func (a *Allocations) Checks(args *cstructs.AllocChecksRequest, reply *cstructs.
 	return nil
 }
 
+// TemplateDebug is used to render the templates of a task without writing
+// them to the task directory, to help debug template syntax and dependencies.
+func (a *Allocations) TemplateDebug(args *cstructs.AllocTemplateDebugRequest, reply *cstructs.AllocTemplateDebugResponse) error {
+	defer metrics.MeasureSince([]string{"client", "allocations", "template_debug"}, time.Now())
+
+	alloc, err := a.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Rendered templates may contain secrets, so require the same permission
+	// as reading them from the allocation filesystem.
+	if aclObj, aclErr := a.c.ResolveToken(args.AuthToken); aclErr != nil {
+		return aclErr
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadFS) {
+		return nstructs.ErrPermissionDenied
+	}
+
+	ar, err := a.c.getAllocRunner(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	results, err := ar.TemplateDryRun(args.Task, args.Timeout)
+	if err != nil {
+		return err
+	}
+
+	reply.Templates = results
+	return nil
+}
+
 // exec is used to execute command in a running task
 func (a *Allocations) exec(conn io.ReadWriteCloser) {
 	defer metrics.MeasureSince([]string{"client", "allocations", "exec"}, time.Now())
@@ -362,7 +421,27 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 		return pointer.Of(int64(404)), fmt.Errorf("task %q is not running.", req.Task)
 	}
 
-	err = h(ctx, req.Cmd, req.Tty, newExecStream(decoder, encoder))
+	// Record the session if required before running the command, so that
+	// no session goes unrecorded
+	stream := newExecStream(decoder, encoder)
+	sink := a.c.GetConfig().ExecSessionSink
+	if sink == nil && req.RecordingRequired {
+		return pointer.Of(int64(400)), errors.New("exec sessions must be recorded but exec session recording is not enabled on this client")
+	}
+	if sink != nil {
+		recorder, recErr := execrecorder.NewRecorder(sink, execSession(execID, alloc, &req, ident), stream)
+		if recErr != nil {
+			return pointer.Of(int64(500)), fmt.Errorf("failed to record exec session: %w", recErr)
+		}
+		defer func() {
+			if closeErr := recorder.Close(err); closeErr != nil {
+				a.c.logger.Error("failed to record end of exec session", "exec_id", execID, "error", closeErr)
+			}
+		}()
+		stream = recorder
+	}
+
+	err = h(ctx, req.Cmd, req.Tty, stream)
 	if err != nil {
 		code := pointer.Of(int64(500))
 		return code, err
@@ -371,6 +450,33 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 	return nil, nil
 }
 
+// execSession returns the description of an exec session that is recorded
+// with the session.
+func execSession(execID string, alloc *nstructs.Allocation, req *cstructs.AllocExecRequest,
+	ident *nstructs.AuthenticatedIdentity) *execrecorder.Session {
+
+	session := &execrecorder.Session{
+		ID:        execID,
+		AllocID:   alloc.ID,
+		Namespace: alloc.Namespace,
+		JobID:     alloc.JobID,
+		Task:      req.Task,
+		Command:   req.Cmd,
+		Action:    req.Action,
+		Tty:       req.Tty,
+	}
+	if ident != nil {
+		if ident.ACLToken != nil {
+			session.AccessorID = ident.ACLToken.AccessorID
+			session.TokenName = ident.ACLToken.Name
+		} else if ident.Claims != nil {
+			session.ClaimsAllocID = ident.Claims.AllocationID
+			session.ClaimsTask = ident.Claims.TaskName
+		}
+	}
+	return session
+}
+
 // newExecStream returns a new exec stream as expected by drivers that interpolate with RPC streaming format
 func newExecStream(decoder *codec.Decoder, encoder *codec.Encoder) drivers.ExecTaskStream {
 	buf := new(bytes.Buffer)
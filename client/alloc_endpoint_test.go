@@ -9,7 +9,9 @@ import (
 	"io"
 	"net"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/lib/execrecorder"
 	"github.com/hashicorp/nomad/client/lib/proclib"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pluginutils/catalog"
@@ -880,6 +883,147 @@ func TestAlloc_ExecStreaming_DisableRemoteExec(t *testing.T) {
 	}
 }
 
+// testExecSink collects the events of the recorded exec sessions.
+type testExecSink struct {
+	l      sync.Mutex
+	events []*execrecorder.Event
+}
+
+func (s *testExecSink) Start(*execrecorder.Session) (execrecorder.Writer, error) { return s, nil }
+
+func (s *testExecSink) Write(event *execrecorder.Event) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *testExecSink) Close() error { return nil }
+
+func (s *testExecSink) Events() []*execrecorder.Event {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return slices.Clone(s.events)
+}
+
+func TestAlloc_ExecStreaming_Recording(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	sink := &testExecSink{}
+	c, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+		c.ExecSessionSink = sink
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+		"exec_command": map[string]interface{}{
+			"run_for":       "1ms",
+			"stdout_string": "recorded\n",
+			"exit_code":     3,
+		},
+	}
+
+	// Wait for client to be running job
+	testutil.WaitForRunning(t, s.RPC, job)
+
+	// Get the allocation ID
+	args := nstructs.AllocListRequest{}
+	args.Region = "global"
+	resp := nstructs.AllocListResponse{}
+	must.NoError(t, s.RPC("Alloc.List", &args, &resp))
+	must.Len(t, 1, resp.Allocations)
+	allocID := resp.Allocations[0].ID
+
+	// Make the request
+	req := &cstructs.AllocExecRequest{
+		AllocID:      allocID,
+		Task:         job.TaskGroups[0].Tasks[0].Name,
+		Tty:          true,
+		Cmd:          []string{"placeholder command"},
+		QueryOptions: nstructs.QueryOptions{Region: "global"},
+	}
+
+	// Get the handler
+	handler, err := c.StreamingRpcHandler("Allocations.Exec")
+	must.NoError(t, err)
+
+	// Create a pipe
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	defer p2.Close()
+
+	errCh := make(chan error)
+	frames := make(chan *drivers.ExecTaskStreamingResponseMsg)
+
+	// Start the handler
+	go handler(p2)
+	go decodeFrames(t, p1, frames, errCh)
+
+	// Send the request
+	encoder := codec.NewEncoder(p1, nstructs.MsgpackHandle)
+	must.NoError(t, encoder.Encode(req))
+
+	timeout := time.After(3 * time.Second)
+
+OUTER:
+	for {
+		select {
+		case <-timeout:
+			t.Fatal("timed out")
+		case err := <-errCh:
+			must.NoError(t, err)
+		case f := <-frames:
+			if f.Exited {
+				break OUTER
+			}
+		}
+	}
+
+	// The end of the session is recorded once the handler returns
+	testutil.WaitForResult(func() (bool, error) {
+		events := sink.Events()
+		if n := len(events); n == 0 || events[n-1].Type != execrecorder.EventTypeEnd {
+			return false, fmt.Errorf("session end not recorded: %d events", n)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	events := sink.Events()
+	start := events[0]
+	must.Eq(t, execrecorder.EventTypeStart, start.Type)
+	must.NotNil(t, start.Session)
+	must.Eq(t, allocID, start.Session.AllocID)
+	must.Eq(t, job.ID, start.Session.JobID)
+	must.Eq(t, req.Task, start.Session.Task)
+	must.Eq(t, req.Cmd, start.Session.Command)
+	must.True(t, start.Session.Tty)
+
+	var stdout string
+	var exitCode *int32
+	for _, event := range events {
+		switch event.Type {
+		case execrecorder.EventTypeStdout:
+			stdout += string(event.Data)
+		case execrecorder.EventTypeExited:
+			exitCode = event.ExitCode
+		}
+	}
+	must.Eq(t, "recorded\n", stdout)
+	must.NotNil(t, exitCode)
+	must.Eq(t, 3, *exitCode)
+}
+
 func TestAlloc_ExecStreaming_ACL_Basic(t *testing.T) {
 	ci.Parallel(t)
 
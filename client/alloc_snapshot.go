@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+)
+
+const (
+	// allocSnapshotsDir is the directory under the client state dir where
+	// snapshots of the data of allocations are kept while they are migrated.
+	allocSnapshotsDir = "alloc_snapshots"
+
+	// allocSnapshotChunkSize is the maximum size of the chunks of snapshots
+	// returned to the nodes migrating allocation data.
+	allocSnapshotChunkSize = 1 << 20
+)
+
+// allocSnapshot is a snapshot of the data of an allocation written to disk,
+// so that the chunks of an interrupted transfer are read from the same
+// snapshot when it is resumed.
+type allocSnapshot struct {
+	path     string
+	size     int64
+	checksum string
+}
+
+// allocSnapshots keeps the snapshots of the allocations whose data is being
+// migrated to other nodes, until the servers garbage collect the
+// allocations.
+type allocSnapshots struct {
+	dir    string
+	logger hclog.Logger
+
+	snapshots map[string]*allocSnapshot
+	lock      sync.Mutex
+}
+
+// newAllocSnapshots returns an allocSnapshots that writes snapshots to dir.
+// Snapshots left over from a previous run of the client are removed, since
+// their checksums are not known anymore.
+func newAllocSnapshots(logger hclog.Logger, dir string) *allocSnapshots {
+	if err := os.RemoveAll(dir); err != nil {
+		logger.Warn("failed to remove previous alloc snapshots", "error", err)
+	}
+	return &allocSnapshots{
+		dir:       dir,
+		logger:    logger.Named("alloc_snapshots"),
+		snapshots: make(map[string]*allocSnapshot),
+	}
+}
+
+// get returns the snapshot of the data of the allocation, creating it from
+// the alloc dir returned by getAllocFS if it doesn't exist yet.
+func (s *allocSnapshots) get(allocID string, getAllocFS func(string) (allocdir.AllocDirFS, error)) (*allocSnapshot, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if snap, ok := s.snapshots[allocID]; ok {
+		if _, err := os.Stat(snap.path); err == nil {
+			return snap, nil
+		}
+		delete(s.snapshots, allocID)
+	}
+
+	allocFS, err := getAllocFS(allocID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create alloc snapshots dir: %w", err)
+	}
+	f, err := os.CreateTemp(s.dir, allocID+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alloc snapshot: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	h := sha256.New()
+	w := io.MultiWriter(f, h)
+	if err := allocFS.Snapshot(w); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("error making snapshot: %w", err)
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(s.dir, allocID+".tar")
+	if err := os.Rename(f.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to write alloc snapshot: %w", err)
+	}
+
+	snap := &allocSnapshot{
+		path:     path,
+		size:     size,
+		checksum: hex.EncodeToString(h.Sum(nil)),
+	}
+	s.snapshots[allocID] = snap
+	s.logger.Debug("created alloc snapshot", "alloc_id", allocID, "size", size)
+	return snap, nil
+}
+
+// readChunk returns up to allocSnapshotChunkSize bytes of the snapshot,
+// starting at offset.
+func (snap *allocSnapshot) readChunk(offset int64) ([]byte, error) {
+	if offset < 0 || offset > snap.size {
+		return nil, fmt.Errorf("offset %d is outside of snapshot of %d bytes", offset, snap.size)
+	}
+
+	f, err := os.Open(snap.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, min(allocSnapshotChunkSize, snap.size-offset))
+	n, err := f.ReadAt(buf, offset)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// remove deletes the snapshot of the allocation, if any.
+func (s *allocSnapshots) remove(allocID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	snap, ok := s.snapshots[allocID]
+	if !ok {
+		return
+	}
+	delete(s.snapshots, allocID)
+	if err := os.Remove(snap.path); err != nil && !os.IsNotExist(err) {
+		s.logger.Warn("failed to remove alloc snapshot", "alloc_id", allocID, "error", err)
+	}
+}
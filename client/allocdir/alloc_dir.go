@@ -19,6 +19,7 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hpcloud/tail/watch"
 	tomb "gopkg.in/tomb.v1"
@@ -150,6 +151,7 @@ type AllocDirFS interface {
 	List(path string) ([]*cstructs.AllocFileInfo, error)
 	Stat(path string) (*cstructs.AllocFileInfo, error)
 	ReadAt(path string, offset int64) (io.ReadCloser, error)
+	WriteFile(path string, perm os.FileMode, r io.Reader, verify func() error) error
 	Snapshot(w io.Writer) error
 	BlockUntilExists(ctx context.Context, path string) (chan error, error)
 	ChangeEvents(ctx context.Context, path string, curOffset int64) (*watch.FileChanges, error)
@@ -492,6 +494,124 @@ func (d *AllocDir) ReadAt(path string, offset int64) (io.ReadCloser, error) {
 	return f, nil
 }
 
+// WriteFile writes the contents of r to the file at the path relative to the
+// alloc dir. The contents are written to a temporary file in the same
+// directory, which only replaces the file at the path once verify returns
+// without an error, so a failed write never leaves a partial file behind.
+//
+// The directory of the file is resolved before it is checked, and is then
+// opened as an os.Root, so symlinks planted by a task can't make the client
+// write outside of the alloc dir or into a secrets dir.
+func (d *AllocDir) WriteFile(path string, perm os.FileMode, r io.Reader, verify func() error) error {
+	if escapes, err := escapingfs.PathEscapesAllocDir(d.AllocDir, "", path); err != nil {
+		return fmt.Errorf("Failed to check if path escapes alloc directory: %w", err)
+	} else if escapes {
+		return fmt.Errorf("Path escapes the alloc directory")
+	}
+
+	allocDir, err := filepath.EvalSymlinks(d.AllocDir)
+	if err != nil {
+		return err
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(filepath.Join(d.AllocDir, path)))
+	if err != nil {
+		return err
+	}
+	if escapingfs.PathEscapesSandbox(allocDir, dir) {
+		return fmt.Errorf("Path escapes the alloc directory")
+	}
+
+	// Check if it is trying to write into a secret directory, comparing the
+	// resolved path relative to the alloc dir
+	rel, err := filepath.Rel(allocDir, filepath.Join(dir, filepath.Base(path)))
+	if err != nil {
+		return err
+	}
+	p := filepath.Join(d.AllocDir, rel)
+	d.mu.RLock()
+	for _, taskDir := range d.TaskDirs {
+		if caseInsensitiveHasPrefix(p, taskDir.SecretsDir) {
+			d.mu.RUnlock()
+			return fmt.Errorf("Writing secret file prohibited: %s", path)
+		}
+		if caseInsensitiveHasPrefix(p, taskDir.PrivateDir) {
+			d.mu.RUnlock()
+			return fmt.Errorf("Writing private file prohibited: %s", path)
+		}
+	}
+	d.mu.RUnlock()
+
+	// Open the directory, making sure it is still the one that was checked
+	// in case it was replaced in the meantime
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+	dirInfo, err := root.Stat(".")
+	if err != nil {
+		return err
+	}
+	if checked, err := os.Stat(dir); err != nil {
+		return err
+	} else if !os.SameFile(dirInfo, checked) {
+		return fmt.Errorf("Directory of %q changed while writing", path)
+	}
+
+	name := filepath.Base(path)
+	if info, err := root.Lstat(name); err == nil && info.IsDir() {
+		return fmt.Errorf("Path %q is a directory", path)
+	}
+
+	tmpName := fmt.Sprintf(".%s.%s", name, uuid.Short())
+	tmp, err := root.OpenFile(tmpName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("Couldn't create temporary file: %w", err)
+	}
+	renamed := false
+	defer func() {
+		if !renamed {
+			root.Remove(tmpName)
+		}
+	}()
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	// Set the mode and owner through the open file, so that they can't be
+	// redirected to another file by replacing the temporary file.
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("Couldn't set mode of %q: %w", path, err)
+	}
+
+	// Give the file the owner of its directory, so that it can be accessed
+	// by the task that owns the directory.
+	if uid, gid := getOwner(dirInfo); uid != idUnsupported && gid != idUnsupported {
+		if err := tmp.Chown(uid, gid); err != nil {
+			d.logger.Debug("failed to set the owner of written file", "path", path, "error", err)
+		}
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("Couldn't write temporary file: %w", err)
+	}
+	if err := verify(); err != nil {
+		return err
+	}
+
+	// The rename can only succeed within the checked directory since the
+	// temporary file only exists there, and it replaces a symlink at the
+	// destination rather than following it.
+	if err := os.Rename(filepath.Join(dir, tmpName), filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("Couldn't write %q: %w", path, err)
+	}
+	renamed = true
+	return nil
+}
+
 // CaseInsensitiveHasPrefix checks if the prefix is a case-insensitive prefix.
 func caseInsensitiveHasPrefix(s, prefix string) bool {
 	return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
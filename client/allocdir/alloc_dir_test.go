@@ -313,6 +313,110 @@ func TestAllocDir_ReadAt_SecretDir(t *testing.T) {
 	must.EqError(t, err, "Reading secret file prohibited: web/secrets/test_file")
 }
 
+func TestAllocDir_WriteFile(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	td := d.NewTaskDir(t1)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	target := filepath.Join(t1.Name, TaskLocal, "test_file")
+	full := filepath.Join(d.AllocDir, target)
+	verified := func() error { return nil }
+
+	// Writing a file creates it with the given mode
+	err := d.WriteFile(target, 0o640, strings.NewReader("hi"), verified)
+	must.NoError(t, err)
+	b, err := os.ReadFile(full)
+	must.NoError(t, err)
+	must.Eq(t, "hi", string(b))
+	info, err := os.Stat(full)
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0o640), info.Mode().Perm())
+
+	// A file that fails verification doesn't replace the existing file and
+	// no temporary file is left behind
+	err = d.WriteFile(target, 0o640, strings.NewReader("bye"), func() error {
+		return fs.ErrInvalid
+	})
+	must.ErrorIs(t, err, fs.ErrInvalid)
+	b, err = os.ReadFile(full)
+	must.NoError(t, err)
+	must.Eq(t, "hi", string(b))
+	entries, err := os.ReadDir(filepath.Dir(full))
+	must.NoError(t, err)
+	must.Len(t, 1, entries)
+
+	// Directories can't be replaced
+	err = d.WriteFile(filepath.Join(t1.Name, TaskLocal), 0o640, strings.NewReader("hi"), verified)
+	must.ErrorContains(t, err, "is a directory")
+
+	// Files can't be written to the secrets dir or outside the alloc dir
+	err = d.WriteFile(filepath.Join(t1.Name, TaskSecrets, "test_file"), 0o640, strings.NewReader("hi"), verified)
+	must.EqError(t, err, "Writing secret file prohibited: web/secrets/test_file")
+
+	err = d.WriteFile("../test_file", 0o640, strings.NewReader("hi"), verified)
+	must.EqError(t, err, "Path escapes the alloc directory")
+}
+
+// TestAllocDir_WriteFile_Symlink asserts files can't be written outside of the
+// alloc dir or into a secrets dir through symlinked directories planted by a
+// task.
+func TestAllocDir_WriteFile_Symlink(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+	outside := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	td := d.NewTaskDir(t1)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+	verified := func() error { return nil }
+
+	// A directory symlinked outside of the alloc dir
+	must.NoError(t, os.Symlink(outside, filepath.Join(td.LocalDir, "escape")))
+	err := d.WriteFile(filepath.Join(t1.Name, TaskLocal, "escape", "test_file"), 0o640,
+		strings.NewReader("hi"), verified)
+	must.EqError(t, err, "Path escapes the alloc directory")
+	_, err = os.Stat(filepath.Join(outside, "test_file"))
+	must.ErrorIs(t, err, fs.ErrNotExist)
+
+	// A directory symlinked to the secrets dir
+	must.NoError(t, os.Symlink(td.SecretsDir, filepath.Join(td.LocalDir, "secrets")))
+	err = d.WriteFile(filepath.Join(t1.Name, TaskLocal, "secrets", "test_file"), 0o640,
+		strings.NewReader("hi"), verified)
+	must.EqError(t, err, "Writing secret file prohibited: web/local/secrets/test_file")
+
+	// A symlink at the destination is not followed outside of the alloc dir,
+	// even when its target doesn't exist yet
+	outsideFile := filepath.Join(outside, "target")
+	must.NoError(t, os.WriteFile(outsideFile, []byte("original"), 0o644))
+	must.NoError(t, os.Symlink(outsideFile, filepath.Join(td.LocalDir, "link")))
+	err = d.WriteFile(filepath.Join(t1.Name, TaskLocal, "link"), 0o640,
+		strings.NewReader("hi"), verified)
+	must.EqError(t, err, "Path escapes the alloc directory")
+	b, err := os.ReadFile(outsideFile)
+	must.NoError(t, err)
+	must.Eq(t, "original", string(b))
+
+	dangling := filepath.Join(td.LocalDir, "dangling")
+	must.NoError(t, os.Symlink(filepath.Join(outside, "missing"), dangling))
+	err = d.WriteFile(filepath.Join(t1.Name, TaskLocal, "dangling"), 0o640,
+		strings.NewReader("hi"), verified)
+	must.NoError(t, err)
+	_, err = os.Stat(filepath.Join(outside, "missing"))
+	must.ErrorIs(t, err, fs.ErrNotExist)
+	info, err := os.Lstat(dangling)
+	must.NoError(t, err)
+	must.True(t, info.Mode().IsRegular())
+}
+
 func TestAllocDir_SplitPath(t *testing.T) {
 	ci.Parallel(t)
 
@@ -354,7 +354,9 @@ func (t *Tracker) watchTaskEvents() {
 			}
 
 			// One of the tasks has failed so we can exit watching
-			if state.Failed || (!state.FinishedAt.IsZero() && t.lifecycleTasks[taskName] != structs.TaskLifecycleHookPrestart) {
+			hook := t.lifecycleTasks[taskName]
+			prestart := hook == structs.TaskLifecycleHookPrestart || hook == structs.TaskLifecycleHookPrestartInit
+			if state.Failed || (!state.FinishedAt.IsZero() && !prestart) {
 				t.setTaskHealth(false, true)
 				return
 			}
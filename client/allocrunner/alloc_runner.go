@@ -350,6 +350,7 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			AllocHookResources:  ar.hookResources,
 			WIDMgr:              ar.widmgr,
 			Users:               ar.users,
+			RPCClient:           ar.rpcClient,
 		}
 
 		// Create, but do not Run, the task runner
@@ -560,9 +561,19 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 
 	hasSidecars := hasSidecarTasks(ar.tasks)
 
+	restarts := newRestartBudgetTracker(ar.tasks)
+
+	// drainCh fires once the sidecar tasks have been drained after the main
+	// tasks died
+	var drainCh <-chan time.Time
+	drained := false
+
 	for done := false; !done; {
 		select {
 		case <-ar.taskStateUpdatedCh:
+		case <-drainCh:
+			drainCh = nil
+			drained = true
 		case <-ar.waitCh:
 			// Run has exited, sync once more to ensure final
 			// states are collected.
@@ -587,6 +598,7 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 		for name, tr := range ar.tasks {
 			taskState := tr.TaskState()
 			states[name] = taskState
+			restarts.update(name, taskState)
 
 			if tr.IsPoststopTask() {
 				continue
@@ -612,13 +624,33 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 			}
 		}
 
+		// fail the allocation if its tasks restarted too often, so that it
+		// can be rescheduled
+		if budget := ar.restartBudget(); killEvent == nil && restarts.exceeded(budget, time.Now()) {
+			killEvent = structs.NewTaskEvent(structs.TaskRestartBudgetExceeded).
+				SetDisplayMessage(fmt.Sprintf("Tasks in the group restarted more than %d times in %v",
+					budget.Attempts, budget.Interval)).
+				SetFailsTask()
+		}
+
 		// kill remaining live tasks
 		if len(liveRunners) > 0 {
 
 			// if all live runners are sidecars - kill alloc
 			onlySidecarsRemaining := hasSidecars && !hasNonSidecarTasks(liveRunners)
 			if killEvent == nil && onlySidecarsRemaining {
-				killEvent = structs.NewTaskEvent(structs.TaskMainDead)
+				// give the sidecars their drain delay before killing them
+				delay := sidecarDrainDelay(liveRunners)
+				switch {
+				case delay == 0 || drained:
+					killEvent = structs.NewTaskEvent(structs.TaskMainDead)
+				case drainCh == nil:
+					ar.logger.Debug("main tasks dead, draining sidecar tasks", "drain_delay", delay)
+					for _, tr := range liveRunners {
+						tr.EmitEvent(structs.NewTaskEvent(structs.TaskSidecarDraining))
+					}
+					drainCh = time.After(delay)
+				}
 			}
 
 			// If there's a kill event set and live runners, kill them
@@ -630,6 +662,8 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 					ar.logger.Debug("leader task dead, destroying all tasks", "leader_task", killTask)
 				case structs.TaskMainDead:
 					ar.logger.Debug("main tasks dead, destroying all sidecar tasks")
+				case structs.TaskRestartBudgetExceeded:
+					ar.logger.Warn("restart budget exceeded, destroying all tasks")
 				default:
 					ar.logger.Debug("task failure, destroying all tasks", "failed_task", killTask)
 				}
@@ -708,6 +742,28 @@ func hasNonSidecarTasks(tasks []*taskrunner.TaskRunner) bool {
 	return false
 }
 
+// restartBudget returns the restart budget of the task group of the
+// allocation, if any.
+func (ar *allocRunner) restartBudget() *structs.RestartBudget {
+	alloc := ar.Alloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return nil
+	}
+	return tg.RestartBudget
+}
+
+// sidecarDrainDelay returns the longest drain delay of the passed tasks
+func sidecarDrainDelay(tasks []*taskrunner.TaskRunner) time.Duration {
+	var delay time.Duration
+	for _, tr := range tasks {
+		if lc := tr.Task().Lifecycle; lc != nil && lc.DrainDelay > delay {
+			delay = lc.DrainDelay
+		}
+	}
+	return delay
+}
+
 // hasSidecarTasks returns true if any of the passed tasks are sidecar tasks
 func hasSidecarTasks(tasks map[string]*taskrunner.TaskRunner) bool {
 	for _, tr := range tasks {
@@ -736,7 +792,7 @@ func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 		}
 
 		taskEvent := structs.NewTaskEvent(structs.TaskKilling)
-		taskEvent.SetKillTimeout(tr.Task().KillTimeout, ar.clientConfig.MaxKillTimeout)
+		taskEvent.SetKillTimeout(ar.taskKillTimeout(tr), ar.clientConfig.MaxKillTimeout)
 		err := tr.Kill(context.TODO(), taskEvent)
 		if err != nil && err != taskrunner.ErrTaskNotRunning {
 			ar.logger.Warn("error stopping leader task", "error", err, "task_name", name)
@@ -759,7 +815,7 @@ func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 		go func(name string, tr *taskrunner.TaskRunner) {
 			defer wg.Done()
 			taskEvent := structs.NewTaskEvent(structs.TaskKilling)
-			taskEvent.SetKillTimeout(tr.Task().KillTimeout, ar.clientConfig.MaxKillTimeout)
+			taskEvent.SetKillTimeout(ar.taskKillTimeout(tr), ar.clientConfig.MaxKillTimeout)
 			err := tr.Kill(context.TODO(), taskEvent)
 			if err != nil && err != taskrunner.ErrTaskNotRunning {
 				ar.logger.Warn("error stopping task", "error", err, "task_name", name)
@@ -783,7 +839,7 @@ func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 		go func(name string, tr *taskrunner.TaskRunner) {
 			defer wg.Done()
 			taskEvent := structs.NewTaskEvent(structs.TaskKilling)
-			taskEvent.SetKillTimeout(tr.Task().KillTimeout, ar.clientConfig.MaxKillTimeout)
+			taskEvent.SetKillTimeout(ar.taskKillTimeout(tr), ar.clientConfig.MaxKillTimeout)
 			err := tr.Kill(context.TODO(), taskEvent)
 			if err != nil && err != taskrunner.ErrTaskNotRunning {
 				ar.logger.Warn("error stopping sidecar task", "error", err, "task_name", name)
@@ -1355,6 +1411,43 @@ func (ar *allocRunner) RestartAll(event *structs.TaskEvent) error {
 	return ar.restartTasks(context.TODO(), event, false, true)
 }
 
+// taskKillTimeout returns the kill timeout of the task, limited by the kill
+// policy of the drain of the node if the allocation is stopped by one.
+func (ar *allocRunner) taskKillTimeout(tr *taskrunner.TaskRunner) time.Duration {
+	timeout := tr.Task().KillTimeout
+	if limit, ok := ar.Alloc().DesiredTransition.KillTimeoutOverride(); ok {
+		timeout = min(timeout, limit)
+	}
+	return timeout
+}
+
+// KillTask kills the provided task with the event, which fails the task if
+// the event is set to.
+func (ar *allocRunner) KillTask(taskName string, event *structs.TaskEvent) error {
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return fmt.Errorf("Could not find task runner for task: %s", taskName)
+	}
+
+	event.SetKillTimeout(tr.Task().KillTimeout, ar.clientConfig.MaxKillTimeout)
+	err := tr.Kill(context.TODO(), event)
+	if err != nil && err != taskrunner.ErrTaskNotRunning {
+		return err
+	}
+	return nil
+}
+
+// EmitTaskEvent emits the event on the provided task.
+func (ar *allocRunner) EmitTaskEvent(taskName string, event *structs.TaskEvent) error {
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return fmt.Errorf("Could not find task runner for task: %s", taskName)
+	}
+
+	tr.EmitEvent(event)
+	return nil
+}
+
 // restartTasks restarts all task runners concurrently.
 func (ar *allocRunner) restartTasks(ctx context.Context, event *structs.TaskEvent, failure bool, force bool) error {
 
@@ -1476,6 +1569,17 @@ func (ar *allocRunner) GetTaskExecHandler(taskName string) drivermanager.TaskExe
 	return tr.TaskExecHandler()
 }
 
+// TemplateDryRun renders the templates of the given task without writing
+// them to the task directory.
+func (ar *allocRunner) TemplateDryRun(taskName string, timeout time.Duration) ([]*cstructs.TemplateDryRunResult, error) {
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return nil, fmt.Errorf("task not found")
+	}
+
+	return tr.TemplateDryRun(timeout)
+}
+
 func (ar *allocRunner) GetTaskDriverCapabilities(taskName string) (*drivers.Capabilities, error) {
 	tr, ok := ar.tasks[taskName]
 	if !ok {
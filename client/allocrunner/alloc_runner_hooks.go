@@ -123,6 +123,7 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir),
 		newCPUPartsHook(hookLogger, ar.partitions, alloc),
 		newAllocHealthWatcherHook(hookLogger, alloc, hs, ar.Listener(), ar.consulServicesHandler, ar.checkStore),
+		newHostPreStartHook(hookLogger, alloc, config.AllocHooks),
 		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar),
 		newGroupServiceHook(groupServiceHookConfig{
 			alloc:             alloc,
@@ -133,6 +134,7 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 			networkStatus:     ar,
 			logger:            hookLogger,
 			shutdownDelayCtx:  ar.shutdownDelayCtx,
+			allocGetter:       ar,
 		}),
 		newConsulGRPCSocketHook(hookLogger, alloc, ar.allocDir,
 			config.GetConsulConfigs(ar.logger), config.Node.Attributes),
@@ -141,6 +143,16 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 		newCSIHook(alloc, hookLogger, ar.csiManager, ar.rpcClient, ar, ar.hookResources, ar.clientConfig.Node.SecretID),
 		newChecksHook(hookLogger, alloc, ar.checkStore, ar),
 	}
+
+	// The post_stop alloc hooks must run after every other hook has torn
+	// down the network and volumes of the allocation.
+	if len(config.AllocHooks) != 0 {
+		hostEnv := taskenv.NewBuilder(config.Node, alloc, nil, config.Region).
+			SetAllocDir(ar.allocDir.AllocDirPath()).Build()
+		ar.runnerHooks = append(ar.runnerHooks,
+			newHostPostStopHook(hookLogger, alloc, config.AllocHooks, hostEnv.List()))
+	}
+
 	if config.ExtraAllocHooks != nil {
 		ar.runnerHooks = append(ar.runnerHooks, config.ExtraAllocHooks...)
 	}
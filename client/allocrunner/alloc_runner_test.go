@@ -411,6 +411,138 @@ func TestAllocRunner_TaskMain_KillTG(t *testing.T) {
 	})
 }
 
+// TestAllocRunner_TaskMain_DrainSidecar asserts that sidecar tasks keep
+// running for their drain delay once the main tasks died.
+func TestAllocRunner_TaskMain_DrainSidecar(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	tr := alloc.AllocatedResources.Tasks[alloc.Job.TaskGroups[0].Tasks[0].Name]
+	alloc.Job.TaskGroups[0].RestartPolicy.Attempts = 0
+	alloc.Job.TaskGroups[0].Tasks[0].RestartPolicy.Attempts = 0
+
+	sidecar := alloc.Job.TaskGroups[0].Tasks[0].Copy()
+	sidecar.Name = "sidecar"
+	sidecar.Driver = "mock_driver"
+	sidecar.KillTimeout = 10 * time.Millisecond
+	sidecar.Lifecycle = &structs.TaskLifecycleConfig{
+		Hook:       structs.TaskLifecycleHookPrestart,
+		Sidecar:    true,
+		DrainDelay: 500 * time.Millisecond,
+	}
+	sidecar.Config = map[string]interface{}{
+		"run_for": "100s",
+	}
+
+	main := alloc.Job.TaskGroups[0].Tasks[0].Copy()
+	main.Name = "main"
+	main.Driver = "mock_driver"
+	main.Config = map[string]interface{}{
+		"run_for": "100ms",
+	}
+
+	alloc.Job.TaskGroups[0].Tasks = []*structs.Task{sidecar, main}
+	alloc.AllocatedResources.Tasks = map[string]*structs.AllocatedTaskResources{
+		sidecar.Name: tr,
+		main.Name:    tr,
+	}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+
+	upd := conf.StateUpdater.(*MockStateUpdater)
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+		if last.ClientStatus != structs.AllocClientStatusComplete {
+			return false, fmt.Errorf("got status %v; want %v", last.ClientStatus, structs.AllocClientStatusComplete)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	last := upd.Last()
+	mainState := last.TaskStates[main.Name]
+	sidecarState := last.TaskStates[sidecar.Name]
+	must.False(t, mainState.Failed)
+	must.False(t, sidecarState.Failed)
+
+	// The sidecar is drained before being killed
+	var events []string
+	for _, e := range sidecarState.Events {
+		switch e.Type {
+		case structs.TaskSidecarDraining, structs.TaskMainDead:
+			events = append(events, e.Type)
+		}
+	}
+	must.Eq(t, []string{structs.TaskSidecarDraining, structs.TaskMainDead}, events)
+	must.GreaterEq(t, 500*time.Millisecond, sidecarState.FinishedAt.Sub(mainState.FinishedAt))
+}
+
+// TestAllocRunner_RestartBudget asserts that the allocation fails once its
+// tasks restarted more than allowed by the restart budget of the group.
+func TestAllocRunner_RestartBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.RestartBudget = &structs.RestartBudget{Attempts: 2, Interval: time.Minute}
+
+	task := tg.Tasks[0]
+	task.Driver = "mock_driver"
+	task.RestartPolicy = &structs.RestartPolicy{
+		Attempts: 10,
+		Interval: time.Hour,
+		Delay:    10 * time.Millisecond,
+		Mode:     structs.RestartPolicyModeFail,
+	}
+	task.Config = map[string]interface{}{
+		"run_for":   "10ms",
+		"exit_code": 1,
+	}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+
+	upd := conf.StateUpdater.(*MockStateUpdater)
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+		if last.ClientStatus != structs.AllocClientStatusFailed {
+			return false, fmt.Errorf("got status %v; want %v", last.ClientStatus, structs.AllocClientStatusFailed)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	state := upd.Last().TaskStates[task.Name]
+	must.True(t, state.Failed)
+	must.Eq(t, 3, state.Restarts)
+
+	var found bool
+	for _, e := range state.Events {
+		if e.Type == structs.TaskRestartBudgetExceeded {
+			found = true
+			must.True(t, e.FailsTask)
+		}
+	}
+	must.True(t, found, must.Sprintf("missing event in %v", state.Events))
+}
+
 // TestAllocRunner_Lifecycle_Poststop asserts that a service job with 1
 // postop lifecycle hook starts all 3 tasks, only
 // the ephemeral one finishes, and the other 2 exit when the alloc is stopped.
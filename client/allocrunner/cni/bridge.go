@@ -23,7 +23,11 @@ type NomadBridgeConfig struct {
 	AdminChainName string
 	IPv4Subnet     string
 	IPv6Subnet     string
+	IPv6Only       bool
 	HairpinMode    bool
+	NFTables       bool
+	Bandwidth      bool
+	StaticAddress  bool
 	ConsulCNI      bool
 }
 
@@ -35,17 +39,33 @@ func NewNomadBridgeConflist(conf NomadBridgeConfig) Conflist {
 	// If CNI plugins are added or versions need to be updated for new fields,
 	// add a new constraint to nomad/job_endpoint_hooks.go
 
-	ipRanges := [][]Range{
-		{{Subnet: conf.IPv4Subnet}},
-	}
-	ipRoutes := []Route{
-		{Dst: "0.0.0.0/0"},
+	var ipRanges [][]Range
+	var ipRoutes []Route
+	if !conf.IPv6Only {
+		ipRanges = append(ipRanges, []Range{{Subnet: conf.IPv4Subnet}})
+		ipRoutes = append(ipRoutes, Route{Dst: "0.0.0.0/0"})
 	}
 	if conf.IPv6Subnet != "" {
 		ipRanges = append(ipRanges, []Range{{Subnet: conf.IPv6Subnet}})
 		ipRoutes = append(ipRoutes, Route{Dst: "::/0"})
 	}
 
+	// host-local IPAM only assigns a requested address if the bridge plugin
+	// declares the "ips" capability
+	var bridgeCaps *BridgeCapabilities
+	if conf.StaticAddress {
+		bridgeCaps = &BridgeCapabilities{IPs: true}
+	}
+
+	// The firewall plugin only supports iptables and firewalld, so it's left
+	// out of the nftables backend. The bridge and portmap plugins program
+	// their masquerade and port mapping rules into their own nftables tables,
+	// and forwarded traffic of the bridge must be accepted by the host
+	var backend string
+	if conf.NFTables {
+		backend = "nftables"
+	}
+
 	plugins := []any{
 		Generic{
 			Type: "loopback",
@@ -63,19 +83,32 @@ func NewNomadBridgeConflist(conf NomadBridgeConfig) Conflist {
 				Routes:  ipRoutes,
 				DataDir: "/var/run/cni",
 			},
+			IpMasqBackend: backend,
+			Capabilities:  bridgeCaps,
 		},
-		Firewall{
+	}
+	if !conf.NFTables {
+		plugins = append(plugins, Firewall{
 			Type:           "firewall",
 			Backend:        "iptables",
 			AdminChainName: conf.AdminChainName,
+		})
+	}
+	plugins = append(plugins, Portmap{
+		Type: "portmap",
+		Capabilities: PortmapCapabilities{
+			Portmappings: true,
 		},
-		Portmap{
-			Type: "portmap",
-			Capabilities: PortmapCapabilities{
-				Portmappings: true,
+		Snat:    true,
+		Backend: backend,
+	})
+	if conf.Bandwidth {
+		plugins = append(plugins, Bandwidth{
+			Type: "bandwidth",
+			Capabilities: BandwidthCapabilities{
+				Bandwidth: true,
 			},
-			Snat: true,
-		},
+		})
 	}
 	if conf.ConsulCNI {
 		plugins = append(plugins, ConsulCNI{
@@ -18,6 +18,15 @@ type Bridge struct {
 	ForceAddress bool   `json:"forceAddress"`
 	HairpinMode  bool   `json:"hairpinMode"`
 	Ipam         IPAM   `json:"ipam"`
+
+	// IpMasqBackend is only set for the nftables backend, since plugins
+	// before v1.5.0 don't support it
+	IpMasqBackend string `json:"ipMasqBackend,omitempty"`
+
+	Capabilities *BridgeCapabilities `json:"capabilities,omitempty"`
+}
+type BridgeCapabilities struct {
+	IPs bool `json:"ips"`
 }
 type IPAM struct {
 	Type    string    `json:"type"`
@@ -46,11 +55,22 @@ type Portmap struct {
 	Type         string              `json:"type"`
 	Capabilities PortmapCapabilities `json:"capabilities"`
 	Snat         bool                `json:"snat"`
+	Backend      string              `json:"backend,omitempty"`
 }
 type PortmapCapabilities struct {
 	Portmappings bool `json:"portMappings"`
 }
 
+// Bandwidth is the "bandwidth" plugin used for bandwidth limits.
+// https://www.cni.dev/plugins/current/meta/bandwidth/
+type Bandwidth struct {
+	Type         string                `json:"type"`
+	Capabilities BandwidthCapabilities `json:"capabilities"`
+}
+type BandwidthCapabilities struct {
+	Bandwidth bool `json:"bandwidth"`
+}
+
 // ConsulCNI is the "consul-cni" plugin used for transparent proxy.
 // https://github.com/hashicorp/consul-k8s/blob/main/control-plane/cni/main.go
 type ConsulCNI struct {
@@ -123,6 +123,10 @@ func (h *consulGRPCSocketHook) shouldRun() bool {
 	}
 
 	for _, s := range tg.Services {
+		// sidecars of Nomad services don't talk to Consul
+		if s.Provider == structs.ServiceProviderNomad {
+			continue
+		}
 		if s.Connect.HasSidecar() || s.Connect.IsGateway() {
 			return true
 		}
@@ -5,6 +5,7 @@ package allocrunner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -21,6 +22,7 @@ import (
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/csi"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
@@ -379,11 +381,35 @@ func (c *csiHook) mountVolumes(results map[string]*volumePublishResult) error {
 			return err
 		}
 		result.stub.MountInfo = mountInfo
+
+		if result.volume.NodeExpansionRequired {
+			c.expandVolume(manager, result, usageOpts)
+		}
 	}
 
 	return nil
 }
 
+// expandVolume expands the newly mounted volume on this node if the controller
+// plugin expanded it without the node plugin, which is the case when the
+// volume wasn't claimed on this node at the time. The node plugin expands the
+// volume idempotently, so this is safe even if it was expanded here before.
+// Failures are only logged because the volume remains usable at its previous
+// size.
+func (c *csiHook) expandVolume(manager csimanager.VolumeManager, result *volumePublishResult, usageOpts *csimanager.UsageOptions) {
+	vol := result.volume
+	capacity, err := manager.ExpandVolume(c.shutdownCtx, vol.Namespace, vol.ID,
+		vol.RemoteID(), c.alloc.ID, usageOpts, &csi.CapacityRange{
+			RequiredBytes: vol.RequestedCapacityMin,
+			LimitBytes:    vol.RequestedCapacityMax,
+		})
+	if err != nil && !errors.Is(err, structs.ErrCSIClientRPCIgnorable) {
+		c.logger.Warn("failed to expand volume on node", "volume", vol.ID, "error", err)
+		return
+	}
+	c.logger.Debug("expanded volume on node", "volume", vol.ID, "capacity", capacity)
+}
+
 // claimWithRetry tries to claim the volume on the server, retrying
 // with exponential backoff capped to a maximum interval
 func (c *csiHook) claimWithRetry(req *structs.CSIVolumeClaimRequest) (*structs.CSIVolumeClaimResponse, error) {
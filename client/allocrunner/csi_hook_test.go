@@ -48,10 +48,11 @@ func TestCSIHook(t *testing.T) {
 		startingStub          *state.CSIVolumeStub // mount info used in starting mounts/stubs
 		startingVolumeNS      string               // namespace of volume previously mounted
 
-		failsFirstUnmount bool
-		expectedClaimErr  error
-		expectedMounts    map[string]*csimanager.MountInfo
-		expectedCalls     map[string]int
+		failsFirstUnmount     bool
+		nodeExpansionRequired bool // volume was expanded by the controller only
+		expectedClaimErr      error
+		expectedMounts        map[string]*csimanager.MountInfo
+		expectedCalls         map[string]int
 	}{
 
 		{
@@ -98,6 +99,30 @@ func TestCSIHook(t *testing.T) {
 				"claim": 1, "MountVolume": 1, "UnmountVolume": 1, "unpublish": 1},
 		},
 
+		{
+			name:  "node expansion required",
+			rpcNS: "ns",
+			volumeRequests: map[string]*structs.VolumeRequest{
+				volName: {
+					Name:           volName,
+					Type:           structs.VolumeTypeCSI,
+					Source:         volID,
+					ReadOnly:       true,
+					AccessMode:     structs.CSIVolumeAccessModeSingleNodeReader,
+					AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+					MountOptions:   &structs.CSIMountOptions{},
+					PerAlloc:       false,
+				},
+			},
+			nodeExpansionRequired: true,
+			expectedMounts: map[string]*csimanager.MountInfo{
+				volName: &csimanager.MountInfo{Source: testMountSrc},
+			},
+			expectedCalls: map[string]int{
+				"claim": 1, "MountVolume": 1, "ExpandVolume": 1,
+				"UnmountVolume": 1, "unpublish": 1},
+		},
+
 		{
 			name:  "fatal error on claim",
 			rpcNS: "ns",
@@ -314,11 +339,12 @@ func TestCSIHook(t *testing.T) {
 			}
 			mgr := &csimanager.MockCSIManager{VM: vm}
 			rpcer := mockRPCer{
-				alloc:            alloc,
-				ns:               tc.rpcNS,
-				callCounts:       callCounts,
-				hasExistingClaim: pointer.Of(tc.startsWithClaims),
-				schedulable:      pointer.Of(!tc.startsUnschedulable),
+				alloc:                 alloc,
+				ns:                    tc.rpcNS,
+				callCounts:            callCounts,
+				hasExistingClaim:      pointer.Of(tc.startsWithClaims),
+				schedulable:           pointer.Of(!tc.startsUnschedulable),
+				nodeExpansionRequired: tc.nodeExpansionRequired,
 			}
 			ar := mockAllocRunner{
 				res: &cstructs.AllocHookResources{},
@@ -495,6 +521,8 @@ type mockRPCer struct {
 	callCounts       *testutil.CallCounter
 	hasExistingClaim *bool
 	schedulable      *bool
+
+	nodeExpansionRequired bool
 }
 
 // RPC mocks the server RPCs, acting as though any request succeeds
@@ -538,6 +566,7 @@ func (r mockRPCer) testVolume(id, ns string) *structs.CSIVolume {
 	vol.Schedulable = *r.schedulable
 	vol.PluginID = "plugin-" + id
 	vol.Namespace = ns
+	vol.NodeExpansionRequired = r.nodeExpansionRequired
 	vol.RequestedCapabilities = []*structs.CSIVolumeCapability{
 		{
 			AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
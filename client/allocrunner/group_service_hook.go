@@ -22,6 +22,11 @@ const (
 	groupServiceHookName = "group_services"
 )
 
+// allocGetter returns the latest version of the allocation.
+type allocGetter interface {
+	Alloc() *structs.Allocation
+}
+
 // groupServiceHook manages task group Consul service registration and
 // deregistration.
 type groupServiceHook struct {
@@ -33,9 +38,14 @@ type groupServiceHook struct {
 	restarter        serviceregistration.WorkloadRestarter
 	prerun           bool
 	deregistered     bool
+	decommissioned   bool
 	networkStatus    structs.NetworkStatus
 	shutdownDelayCtx context.Context
 
+	// allocGetter, if set, is used to read the shutdown_delay override the
+	// allocation may have been stopped with
+	allocGetter allocGetter
+
 	// providerNamespace is the Nomad or Consul namespace in which service
 	// registrations will be made. This field may be updated.
 	providerNamespace string
@@ -65,6 +75,7 @@ type groupServiceHookConfig struct {
 	restarter        serviceregistration.WorkloadRestarter
 	networkStatus    structs.NetworkStatus
 	shutdownDelayCtx context.Context
+	allocGetter      allocGetter
 	logger           hclog.Logger
 
 	// providerNamespace is the Nomad or Consul namespace in which service
@@ -101,6 +112,7 @@ func newGroupServiceHook(cfg groupServiceHookConfig) *groupServiceHook {
 		tg:                tg,
 		hookResources:     cfg.hookResources,
 		shutdownDelayCtx:  cfg.shutdownDelayCtx,
+		allocGetter:       cfg.allocGetter,
 	}
 
 	if cfg.alloc.AllocatedResources != nil {
@@ -112,6 +124,9 @@ func newGroupServiceHook(cfg groupServiceHookConfig) *groupServiceHook {
 		h.canary = cfg.alloc.DeploymentStatus.Canary
 	}
 
+	// A decommissioned allocation doesn't register its services
+	h.decommissioned = cfg.alloc.DesiredTransition.ShouldDecommission()
+
 	return h
 }
 
@@ -143,7 +158,7 @@ func (h *groupServiceHook) Prerun(allocEnv *taskenv.TaskEnv) error {
 
 // caller must hold h.mu
 func (h *groupServiceHook) preRunLocked(env *taskenv.TaskEnv) error {
-	if len(h.services) == 0 {
+	if len(h.services) == 0 || h.decommissioned {
 		return nil
 	}
 
@@ -196,12 +211,32 @@ func (h *groupServiceHook) Update(req *interfaces.RunnerUpdateRequest) error {
 	// Create new task services struct with those new values
 	newWorkloadServices := h.getWorkloadServicesLocked()
 
+	// Deregister the services of the allocation while it is decommissioned,
+	// and register them again if it is brought back into service
+	decommissioned := h.decommissioned
+	h.decommissioned = req.Alloc.DesiredTransition.ShouldDecommission()
+
 	if !h.prerun {
 		// Update called before Prerun. Update alloc and exit to allow
 		// Prerun to do initial registration.
 		return nil
 	}
 
+	switch {
+	case h.decommissioned && !decommissioned:
+		h.logger.Debug("deregistering services of decommissioned allocation")
+		if len(oldWorkloadServices.Services) > 0 {
+			h.serviceRegWrapper.RemoveWorkload(oldWorkloadServices)
+		}
+		h.deregistered = true
+		return nil
+	case h.decommissioned:
+		return nil
+	case decommissioned:
+		h.deregistered = false
+		return h.serviceRegWrapper.RegisterWorkload(newWorkloadServices)
+	}
+
 	return h.serviceRegWrapper.UpdateWorkload(oldWorkloadServices, newWorkloadServices)
 }
 
@@ -227,9 +262,19 @@ func (h *groupServiceHook) PreKill() {
 //
 // caller must hold h.mu
 func (h *groupServiceHook) preKillLocked() {
+	// The allocation may have been stopped with a shutdown_delay that
+	// overrides the one of the group.
+	delay := h.delay
+	if h.allocGetter != nil {
+		alloc := h.allocGetter.Alloc()
+		if override, ok := alloc.DesiredTransition.ShutdownDelayOverride(); ok {
+			delay = override
+		}
+	}
+
 	// Optimization: If this allocation has already been deregistered,
 	// skip waiting for the shutdown_delay again.
-	if h.deregistered && h.delay != 0 {
+	if h.deregistered && delay != 0 {
 		h.logger.Debug("tasks already deregistered, skipping shutdown_delay")
 		return
 	}
@@ -238,13 +283,13 @@ func (h *groupServiceHook) preKillLocked() {
 	// before continuing to kill tasks.
 	h.deregisterLocked()
 
-	if h.delay == 0 {
+	if delay == 0 {
 		return
 	}
 
-	h.logger.Debug("delay before killing tasks", "group", h.group, "shutdown_delay", h.delay)
+	h.logger.Debug("delay before killing tasks", "group", h.group, "shutdown_delay", delay)
 
-	timer, cancel := helper.NewSafeTimer(h.delay)
+	timer, cancel := helper.NewSafeTimer(delay)
 	defer cancel()
 
 	select {
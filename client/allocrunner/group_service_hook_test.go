@@ -151,6 +151,49 @@ func TestGroupServiceHook_GroupServices(t *testing.T) {
 	must.Eq(t, "add", ops[3].Op)    // Restart -> preRun
 }
 
+// TestGroupServiceHook_Decommission asserts group services are deregistered
+// while the allocation is decommissioned and registered again once it is
+// revived.
+func TestGroupServiceHook_Decommission(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.ConnectAlloc()
+	alloc.Job.Canonicalize()
+	logger := testlog.HCLogger(t)
+	consulMockClient := regMock.NewServiceRegistrationHandler(logger)
+	env := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region).Build()
+
+	regWrapper := wrapper.NewHandlerWrapper(
+		logger,
+		consulMockClient,
+		regMock.NewServiceRegistrationHandler(logger))
+
+	h := newGroupServiceHook(groupServiceHookConfig{
+		alloc:             alloc,
+		serviceRegWrapper: regWrapper,
+		restarter:         agentconsul.NoopRestarter(),
+		logger:            logger,
+		hookResources:     cstructs.NewAllocHookResources(),
+	})
+	must.NoError(t, h.Prerun(env))
+
+	decommissioned := alloc.Copy()
+	decommissioned.DesiredTransition.Decommission = pointer.Of(true)
+	req := &interfaces.RunnerUpdateRequest{Alloc: decommissioned, AllocEnv: env}
+	must.NoError(t, h.Update(req))
+	must.NoError(t, h.Update(req))
+
+	revived := alloc.Copy()
+	req = &interfaces.RunnerUpdateRequest{Alloc: revived, AllocEnv: env}
+	must.NoError(t, h.Update(req))
+
+	ops := consulMockClient.GetOps()
+	must.Len(t, 3, ops)
+	must.Eq(t, "add", ops[0].Op)    // Prerun
+	must.Eq(t, "remove", ops[1].Op) // Decommission
+	must.Eq(t, "add", ops[2].Op)    // Revive
+}
+
 // TestGroupServiceHook_GroupServices_Nomad asserts group service hooks with
 // group services does not error when using the Nomad provider.
 func TestGroupServiceHook_GroupServices_Nomad(t *testing.T) {
@@ -440,4 +483,55 @@ func TestGroupServiceHook_PreKill(t *testing.T) {
 		case <-successChan:
 		}
 	})
+
+	t.Run("waits for shutdown delay override", func(t *testing.T) {
+		alloc := mock.Alloc()
+		alloc.Job.TaskGroups[0].Networks = []*structs.NetworkResource{}
+		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+		tg.Services = []*structs.Service{
+			{
+				Name:      "testconnect",
+				PortLabel: "9999",
+				Connect: &structs.ConsulConnect{
+					SidecarService: &structs.ConsulSidecarService{},
+				},
+			},
+		}
+
+		// the group has no shutdown_delay but the alloc was stopped with one
+		delay := 200 * time.Millisecond
+		alloc.DesiredTransition.ShutdownDelay = &delay
+
+		consulMockClient := regMock.NewServiceRegistrationHandler(logger)
+
+		regWrapper := wrapper.NewHandlerWrapper(
+			logger,
+			consulMockClient,
+			regMock.NewServiceRegistrationHandler(logger))
+
+		shutDownCtx, cancel := context.WithTimeout(context.Background(), delay*2)
+		defer cancel()
+
+		h := newGroupServiceHook(groupServiceHookConfig{
+			alloc:             alloc,
+			serviceRegWrapper: regWrapper,
+			shutdownDelayCtx:  shutDownCtx,
+			allocGetter:       testAllocGetter{alloc: alloc},
+			restarter:         agentconsul.NoopRestarter(),
+			logger:            logger,
+			hookResources:     cstructs.NewAllocHookResources(),
+		})
+
+		before := time.Now()
+		h.PreKill()
+		must.Greater(t, delay, time.Since(before))
+		must.NoError(t, shutDownCtx.Err())
+	})
+}
+
+// testAllocGetter is an allocGetter that always returns the same allocation.
+type testAllocGetter struct {
+	alloc *structs.Allocation
 }
+
+func (g testAllocGetter) Alloc() *structs.Allocation { return g.alloc }
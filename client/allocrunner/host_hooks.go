@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	hostPreStartHookName = "host_pre_start_hook"
+	hostPostStopHookName = "host_post_stop_hook"
+)
+
+// hostHookCommand is a command of an operator configured alloc_hook.
+type hostHookCommand struct {
+	hook *config.AllocHookConfig
+	args []string
+}
+
+// hostHookCommands returns the commands of the alloc hooks that run for the
+// namespace of the allocation, picking the command of each hook with cmdFn.
+func hostHookCommands(alloc *structs.Allocation, hooks []*config.AllocHookConfig,
+	cmdFn func(*config.AllocHookConfig) []string) []hostHookCommand {
+
+	var cmds []hostHookCommand
+	for _, hook := range hooks {
+		args := cmdFn(hook)
+		if len(args) == 0 || !hook.AllowsNamespace(alloc.Namespace) {
+			continue
+		}
+		cmds = append(cmds, hostHookCommand{hook: hook, args: args})
+	}
+	return cmds
+}
+
+// runHostHookCommands runs the commands one at a time on the host, with the
+// environment of the client and the alloc environment, and stops at the first
+// command that fails.
+func runHostHookCommands(logger hclog.Logger, cmds []hostHookCommand, env []string) error {
+	for _, c := range cmds {
+		ctx, cancel := context.WithTimeout(context.Background(), c.hook.Timeout)
+
+		var output bytes.Buffer
+		cmd := exec.CommandContext(ctx, c.args[0], c.args[1:]...)
+		cmd.Env = append(os.Environ(), env...)
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+
+		logger.Debug("running alloc hook", "name", c.hook.Name, "command", c.args[0])
+		err := cmd.Run()
+		cancel()
+
+		if err != nil {
+			out := strings.TrimSpace(output.String())
+			logger.Error("alloc hook failed", "name", c.hook.Name, "error", err, "output", out)
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("alloc hook %q timed out after %s", c.hook.Name, c.hook.Timeout)
+			}
+			if out != "" {
+				return fmt.Errorf("alloc hook %q failed: %w: %s", c.hook.Name, err, out)
+			}
+			return fmt.Errorf("alloc hook %q failed: %w", c.hook.Name, err)
+		}
+	}
+	return nil
+}
+
+// hostPreStartHook runs the pre_start commands of the operator configured
+// alloc hooks before the network and volumes of the allocation are set up.
+type hostPreStartHook struct {
+	logger hclog.Logger
+	cmds   []hostHookCommand
+}
+
+func newHostPreStartHook(logger hclog.Logger, alloc *structs.Allocation,
+	hooks []*config.AllocHookConfig) *hostPreStartHook {
+	return &hostPreStartHook{
+		logger: logger.Named(hostPreStartHookName),
+		cmds: hostHookCommands(alloc, hooks, func(h *config.AllocHookConfig) []string {
+			return h.PreStart
+		}),
+	}
+}
+
+func (h *hostPreStartHook) Name() string {
+	return hostPreStartHookName
+}
+
+// statically assert the hook implements the expected interfaces
+var (
+	_ interfaces.RunnerPrerunHook  = (*hostPreStartHook)(nil)
+	_ interfaces.RunnerPostrunHook = (*hostPostStopHook)(nil)
+)
+
+func (h *hostPreStartHook) Prerun(allocEnv *taskenv.TaskEnv) error {
+	if len(h.cmds) == 0 {
+		return nil
+	}
+	return runHostHookCommands(h.logger, h.cmds, allocEnv.List())
+}
+
+// hostPostStopHook runs the post_stop commands of the operator configured
+// alloc hooks after the network and volumes of the allocation are torn down.
+type hostPostStopHook struct {
+	logger hclog.Logger
+	cmds   []hostHookCommand
+
+	// env is built when the hook is created since post-run hooks don't
+	// receive the alloc environment, and may run for restored allocations
+	// whose pre-run hooks never ran.
+	env []string
+}
+
+func newHostPostStopHook(logger hclog.Logger, alloc *structs.Allocation,
+	hooks []*config.AllocHookConfig, env []string) *hostPostStopHook {
+	return &hostPostStopHook{
+		logger: logger.Named(hostPostStopHookName),
+		cmds: hostHookCommands(alloc, hooks, func(h *config.AllocHookConfig) []string {
+			return h.PostStop
+		}),
+		env: env,
+	}
+}
+
+func (h *hostPostStopHook) Name() string {
+	return hostPostStopHookName
+}
+
+func (h *hostPostStopHook) Postrun() error {
+	if len(h.cmds) == 0 {
+		return nil
+	}
+	return runHostHookCommands(h.logger, h.cmds, h.env)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+
+package allocrunner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/shoenig/test/must"
+)
+
+func TestHostHooks_PrerunPostrun(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	dir := t.TempDir()
+	preOut := filepath.Join(dir, "pre")
+	postOut := filepath.Join(dir, "post")
+	skipped := filepath.Join(dir, "skipped")
+
+	hooks := []*config.AllocHookConfig{
+		{
+			Name:       "firewall",
+			PreStart:   []string{"sh", "-c", `echo "$NOMAD_ALLOC_ID $NOMAD_JOB_NAME" > ` + preOut},
+			PostStop:   []string{"sh", "-c", `echo "$NOMAD_ALLOC_ID" > ` + postOut},
+			Timeout:    time.Second,
+			Namespaces: []string{"*"},
+		},
+		{
+			Name:       "other-namespace",
+			PreStart:   []string{"touch", skipped},
+			Timeout:    time.Second,
+			Namespaces: []string{"other"},
+		},
+	}
+
+	alloc := mock.Alloc()
+	env := taskenv.NewBuilder(mock.Node(), alloc, nil, "global").Build()
+
+	pre := newHostPreStartHook(logger, alloc, hooks)
+	must.NoError(t, pre.Prerun(env))
+
+	b, err := os.ReadFile(preOut)
+	must.NoError(t, err)
+	must.Eq(t, alloc.ID+" "+alloc.Job.Name+"\n", string(b))
+	must.FileNotExists(t, skipped)
+
+	post := newHostPostStopHook(logger, alloc, hooks, env.List())
+	must.NoError(t, post.Postrun())
+
+	b, err = os.ReadFile(postOut)
+	must.NoError(t, err)
+	must.Eq(t, alloc.ID+"\n", string(b))
+}
+
+func TestHostHooks_Prerun_Failure(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	alloc := mock.Alloc()
+	env := taskenv.NewBuilder(mock.Node(), alloc, nil, "global").Build()
+
+	hooks := []*config.AllocHookConfig{{
+		Name:       "firewall",
+		PreStart:   []string{"sh", "-c", "echo no route; exit 3"},
+		Timeout:    time.Second,
+		Namespaces: []string{alloc.Namespace},
+	}}
+	err := newHostPreStartHook(logger, alloc, hooks).Prerun(env)
+	must.EqError(t, err, `alloc hook "firewall" failed: exit status 3: no route`)
+
+	hooks[0].PreStart = []string{"sleep", "10"}
+	hooks[0].Timeout = 100 * time.Millisecond
+	err = newHostPreStartHook(logger, alloc, hooks).Prerun(env)
+	must.EqError(t, err, `alloc hook "firewall" timed out after 100ms`)
+}
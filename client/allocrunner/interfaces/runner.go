@@ -44,6 +44,8 @@ type AllocRunner interface {
 	RestartTask(taskName string, taskEvent *structs.TaskEvent) error
 	RestartRunning(taskEvent *structs.TaskEvent) error
 	RestartAll(taskEvent *structs.TaskEvent) error
+	KillTask(taskName string, taskEvent *structs.TaskEvent) error
+	EmitTaskEvent(taskName string, taskEvent *structs.TaskEvent) error
 
 	GetTaskEventHandler(taskName string) drivermanager.EventHandler
 	GetTaskExecHandler(taskName string) drivermanager.TaskExecHandler
@@ -53,6 +55,7 @@ type AllocRunner interface {
 	GetAllocDir() allocdir.Interface
 	SetTaskPauseState(taskName string, ps structs.TaskScheduleState) error
 	GetTaskPauseState(taskName string) (structs.TaskScheduleState, error)
+	TemplateDryRun(taskName string, timeout time.Duration) ([]*cstructs.TemplateDryRunResult, error)
 }
 
 // TaskStateHandler exposes a handler to be called when a task's state changes
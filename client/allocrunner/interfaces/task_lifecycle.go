@@ -57,6 +57,10 @@ type TaskPrestartRequest struct {
 	// Vault token may optionally be set if a Vault token is available
 	VaultToken string
 
+	// VaultRoleTokens are the Vault tokens derived for templates that request
+	// their own Vault role, keyed by role.
+	VaultRoleTokens map[string]string
+
 	// NomadToken token may optionally be set if a Nomad token is available
 	NomadToken string
 
@@ -120,6 +124,9 @@ type TaskPoststartRequest struct {
 	// Exec hook (may be nil)
 	DriverExec interfaces.ScriptExecutor
 
+	// Inspect hook (may be nil)
+	DriverInspect interfaces.TaskInspector
+
 	// Network info (may be nil)
 	DriverNetwork *drivers.DriverNetwork
 
@@ -170,6 +177,8 @@ type TaskExitedHook interface {
 type TaskUpdateRequest struct {
 	VaultToken string
 
+	VaultRoleTokens map[string]string
+
 	NomadToken string
 
 	// Alloc is the current version of the allocation (may have been
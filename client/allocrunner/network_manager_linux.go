@@ -193,17 +193,25 @@ func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config
 	case netMode == "bridge":
 		c, err := newBridgeNetworkConfigurator(log, alloc,
 			config.BridgeNetworkName, config.BridgeNetworkAllocSubnet, config.BridgeNetworkAllocSubnetIPv6, config.CNIPath,
-			config.BridgeNetworkHairpinMode, ignorePortMappingHostIP,
+			config.BridgeNetworkHairpinMode, config.BridgeNetworkIPv6Only,
+			config.BridgeNetworkFirewallBackend == clientconfig.BridgeFirewallBackendNFTables,
+			ignorePortMappingHostIP,
 			config.Node)
 		if err != nil {
 			return nil, err
 		}
+		if err := c.cni.loadSecondaryNetworks(config.CNIConfigDir, tg.Networks[0].CNINetworks); err != nil {
+			return nil, err
+		}
 		return &synchronizedNetworkConfigurator{c}, nil
 	case strings.HasPrefix(netMode, "cni/"):
 		c, err := newCNINetworkConfigurator(log, config.CNIPath, config.CNIInterfacePrefix, config.CNIConfigDir, netMode[4:], ignorePortMappingHostIP, config.Node)
 		if err != nil {
 			return nil, err
 		}
+		if err := c.loadSecondaryNetworks(config.CNIConfigDir, tg.Networks[0].CNINetworks); err != nil {
+			return nil, err
+		}
 		return &synchronizedNetworkConfigurator{c}, nil
 	default:
 		return &hostNetworkConfigurator{}, nil
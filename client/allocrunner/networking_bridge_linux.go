@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/client/allocrunner/cni"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -37,15 +38,33 @@ type bridgeNetworkConfigurator struct {
 	bridgeName      string
 	hairpinMode     bool
 
+	// ipv6Only is true if allocations only get an address from
+	// allocSubnetIPv6
+	ipv6Only bool
+
+	// nftables is true if the rules of the bridge network are programmed by
+	// the nftables backends of the CNI plugins instead of iptables
+	nftables bool
+
+	// bandwidth is true if the alloc network has bandwidth limits, which
+	// requires the bandwidth plugin in the conflist
+	bandwidth bool
+
+	// staticAddress is true if the alloc network requests a static address,
+	// which requires the ips capability on the bridge plugin
+	staticAddress bool
+
 	newIPTables func(structs.NodeNetworkAF) (IPTablesChain, error)
 
 	logger hclog.Logger
 }
 
-func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, bridgeName, ipv4Range, ipv6Range, cniPath string, hairpinMode, ignorePortMappingHostIP bool, node *structs.Node) (*bridgeNetworkConfigurator, error) {
+func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, bridgeName, ipv4Range, ipv6Range, cniPath string, hairpinMode, ipv6Only, nftables, ignorePortMappingHostIP bool, node *structs.Node) (*bridgeNetworkConfigurator, error) {
 	b := &bridgeNetworkConfigurator{
 		bridgeName:      bridgeName,
 		hairpinMode:     hairpinMode,
+		ipv6Only:        ipv6Only,
+		nftables:        nftables,
 		allocSubnetIPv4: ipv4Range,
 		allocSubnetIPv6: ipv6Range,
 		newIPTables:     newIPTablesChain,
@@ -56,7 +75,12 @@ func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, b
 		b.bridgeName = defaultNomadBridgeName
 	}
 
-	if b.allocSubnetIPv4 == "" {
+	if b.ipv6Only {
+		if b.allocSubnetIPv6 == "" {
+			return nil, fmt.Errorf("IPv6-only bridge network requires an IPv6 subnet")
+		}
+		b.allocSubnetIPv4 = ""
+	} else if b.allocSubnetIPv4 == "" {
 		b.allocSubnetIPv4 = defaultNomadAllocSubnet
 	}
 
@@ -64,6 +88,15 @@ func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, b
 	var err error
 
 	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	for _, net := range tg.Networks {
+		if net.Shaped() {
+			b.bandwidth = true
+		}
+		if net.HasStaticAddress() {
+			b.staticAddress = true
+		}
+	}
+
 	for _, svc := range tg.Services {
 		if svc.Connect.HasTransparentProxy() {
 			netCfg, err = buildNomadBridgeNetConfig(*b, true)
@@ -89,6 +122,10 @@ func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, b
 	if err != nil {
 		return nil, err
 	}
+	if b.allocSubnetIPv6 != "" {
+		c.ipv6PortMappings = ipv6HostAddresses(node)
+	}
+	c.nftables = b.nftables
 	b.cni = c
 
 	return b, nil
@@ -97,6 +134,13 @@ func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, b
 // ensureForwardingRules ensures that a forwarding rule is added to iptables
 // to allow traffic inbound to the bridge network
 func (b *bridgeNetworkConfigurator) ensureForwardingRules() error {
+	// The nftables backend has no admin chain. Accepting traffic in a chain of
+	// another table doesn't override the verdicts of the host's own forward
+	// chains, so hosts that drop forwarded traffic must accept the bridge.
+	if b.nftables {
+		return nil
+	}
+
 	if b.allocSubnetIPv6 != "" {
 		ip6t, err := b.newIPTables(structs.NodeNetworkAF_IPv6)
 		if err != nil {
@@ -107,6 +151,10 @@ func (b *bridgeNetworkConfigurator) ensureForwardingRules() error {
 		}
 	}
 
+	if b.ipv6Only {
+		return nil
+	}
+
 	ipt, err := b.newIPTables(structs.NodeNetworkAF_IPv4)
 	if err != nil {
 		return err
@@ -119,8 +167,66 @@ func (b *bridgeNetworkConfigurator) ensureForwardingRules() error {
 	return nil
 }
 
+// supportsNFTables is the version of the bridge and portmap CNI plugins that
+// added their nftables backends.
+var supportsNFTables = version.MustConstraints(version.NewConstraint(">= 1.5.0"))
+
+// checkNFTablesPlugins returns an error if the fingerprinted bridge or portmap
+// CNI plugins don't support the nftables backend.
+func checkNFTablesPlugins(nodeAttrs map[string]string) error {
+	for _, plugin := range []string{"bridge", "portmap"} {
+		v, err := version.NewSemver(nodeAttrs["plugins.cni.version."+plugin])
+		if err != nil || !supportsNFTables.Check(v) {
+			return fmt.Errorf("nftables firewall backend requires the %s CNI plugin v1.5.0 or later", plugin)
+		}
+	}
+	return nil
+}
+
+// ipv6HostAddresses maps each IPv4 address of the node networks to the IPv6
+// addresses of the same host network. The scheduler assigns ports on a
+// single address, so ports assigned on an IPv4 address are also mapped on
+// these addresses for allocations with an IPv6 address on the bridge.
+func ipv6HostAddresses(node *structs.Node) map[string][]string {
+	if node == nil || node.NodeResources == nil {
+		return nil
+	}
+
+	byAlias := map[string][]structs.NodeNetworkAddress{}
+	for _, nw := range node.NodeResources.NodeNetworks {
+		for _, addr := range nw.Addresses {
+			byAlias[addr.Alias] = append(byAlias[addr.Alias], addr)
+		}
+	}
+
+	mappings := map[string][]string{}
+	for _, addrs := range byAlias {
+		var ipv4, ipv6 []string
+		for _, addr := range addrs {
+			if addr.Family == structs.NodeNetworkAF_IPv6 {
+				ipv6 = append(ipv6, addr.Address)
+			} else {
+				ipv4 = append(ipv4, addr.Address)
+			}
+		}
+		if len(ipv6) == 0 {
+			continue
+		}
+		for _, addr := range ipv4 {
+			mappings[addr] = ipv6
+		}
+	}
+	return mappings
+}
+
 // Setup calls the CNI plugins with the add action
 func (b *bridgeNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Allocation, spec *drivers.NetworkIsolationSpec, created bool) (*structs.AllocNetworkStatus, error) {
+	if b.nftables {
+		if err := checkNFTablesPlugins(b.cni.nodeAttrs); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := b.ensureForwardingRules(); err != nil {
 		return nil, fmt.Errorf("failed to initialize table forwarding rules: %v", err)
 	}
@@ -139,7 +245,11 @@ func buildNomadBridgeNetConfig(b bridgeNetworkConfigurator, withConsulCNI bool)
 		AdminChainName: cniAdminChainName,
 		IPv4Subnet:     b.allocSubnetIPv4,
 		IPv6Subnet:     b.allocSubnetIPv6,
+		IPv6Only:       b.ipv6Only,
 		HairpinMode:    b.hairpinMode,
+		NFTables:       b.nftables,
+		Bandwidth:      b.bandwidth,
+		StaticAddress:  b.staticAddress,
 		ConsulCNI:      withConsulCNI,
 	})
 	return conf.Json()
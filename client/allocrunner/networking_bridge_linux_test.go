@@ -47,6 +47,14 @@ func Test_buildNomadBridgeNetConfig(t *testing.T) {
 				hairpinMode:     true,
 			},
 		},
+		{
+			name: "ipv6_only",
+			b: &bridgeNetworkConfigurator{
+				bridgeName:      defaultNomadBridgeName,
+				allocSubnetIPv6: "3fff:cab0:0d13::/120",
+				ipv6Only:        true,
+			},
+		},
 		{
 			name: "bad_input",
 			b: &bridgeNetworkConfigurator{
@@ -55,6 +63,30 @@ func Test_buildNomadBridgeNetConfig(t *testing.T) {
 				hairpinMode:     true,
 			},
 		},
+		{
+			name: "bandwidth",
+			b: &bridgeNetworkConfigurator{
+				bridgeName:      defaultNomadBridgeName,
+				allocSubnetIPv4: defaultNomadAllocSubnet,
+				bandwidth:       true,
+			},
+		},
+		{
+			name: "static_address",
+			b: &bridgeNetworkConfigurator{
+				bridgeName:      defaultNomadBridgeName,
+				allocSubnetIPv4: defaultNomadAllocSubnet,
+				staticAddress:   true,
+			},
+		},
+		{
+			name: "nftables",
+			b: &bridgeNetworkConfigurator{
+				bridgeName:      defaultNomadBridgeName,
+				allocSubnetIPv4: defaultNomadAllocSubnet,
+				nftables:        true,
+			},
+		},
 		{
 			name:          "consul-cni",
 			withConsulCNI: true,
@@ -88,7 +120,7 @@ func TestBridgeNetworkConfigurator_newIPTables_default(t *testing.T) {
 	b, err := newBridgeNetworkConfigurator(hclog.Default(),
 		mock.MinAlloc(),
 		"", "", "", "",
-		false, false,
+		false, false, false, false,
 		mock.Node())
 	must.NoError(t, err)
 
@@ -127,6 +159,7 @@ func TestBridgeNetworkConfigurator_ensureForwardingRules(t *testing.T) {
 	cases := []struct {
 		name                 string
 		bridgeName, ip4, ip6 string
+		ipv6Only, nftables   bool
 		expectIP4Rules       []string
 		expectIP6Rules       []string
 		ip4Err, ip6Err       error
@@ -143,6 +176,17 @@ func TestBridgeNetworkConfigurator_ensureForwardingRules(t *testing.T) {
 			expectIP4Rules: []string{"-o", "golden-gate", "-d", "a.b.c.d/z", "-j", "ACCEPT"},
 			expectIP6Rules: []string{"-o", "golden-gate", "-d", "aa:bb:cc:dd/z", "-j", "ACCEPT"},
 		},
+		{
+			name:           "ipv6 only",
+			ip6:            "aa:bb:cc:dd/z",
+			ipv6Only:       true,
+			expectIP6Rules: []string{"-o", defaultNomadBridgeName, "-d", "aa:bb:cc:dd/z", "-j", "ACCEPT"},
+		},
+		{
+			name:     "nftables",
+			ip6:      "aa:bb:cc:dd/z",
+			nftables: true,
+		},
 		{
 			name:   "ip4error",
 			ip4Err: errors.New("test ip4error"),
@@ -159,7 +203,7 @@ func TestBridgeNetworkConfigurator_ensureForwardingRules(t *testing.T) {
 			b, err := newBridgeNetworkConfigurator(hclog.Default(),
 				mock.MinAlloc(),
 				tc.bridgeName, tc.ip4, tc.ip6, "",
-				false, false,
+				false, tc.ipv6Only, tc.nftables, false,
 				mock.Node())
 			must.NoError(t, err)
 
@@ -180,9 +224,14 @@ func TestBridgeNetworkConfigurator_ensureForwardingRules(t *testing.T) {
 			}
 			must.NoError(t, err)
 
-			must.Eq(t, ipt.chain, cniAdminChainName)
-			must.Eq(t, ipt.table, "filter")
-			must.Eq(t, ipt.rules, tc.expectIP4Rules)
+			if tc.expectIP4Rules != nil {
+				must.Eq(t, ipt.chain, cniAdminChainName)
+				must.Eq(t, ipt.table, "filter")
+				must.Eq(t, ipt.rules, tc.expectIP4Rules)
+			} else {
+				must.Eq(t, "", ipt.chain, must.Sprint("expect empty iptables chain"))
+				must.Len(t, 0, ipt.rules, must.Sprint("expect empty iptables rules"))
+			}
 
 			if tc.expectIP6Rules != nil {
 				must.Eq(t, ip6t.chain, cniAdminChainName)
@@ -196,3 +245,21 @@ func TestBridgeNetworkConfigurator_ensureForwardingRules(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkNFTablesPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, checkNFTablesPlugins(map[string]string{
+		"plugins.cni.version.bridge":  "1.5.0",
+		"plugins.cni.version.portmap": "1.6.1",
+	}))
+
+	err := checkNFTablesPlugins(map[string]string{
+		"plugins.cni.version.bridge":  "1.5.1",
+		"plugins.cni.version.portmap": "1.4.0",
+	})
+	must.EqError(t, err, "nftables firewall backend requires the portmap CNI plugin v1.5.0 or later")
+
+	err = checkNFTablesPlugins(map[string]string{})
+	must.EqError(t, err, "nftables firewall backend requires the bridge CNI plugin v1.5.0 or later")
+}
@@ -61,6 +61,25 @@ type cniNetworkConfigurator struct {
 	logger                  log.Logger
 	nsOpts                  *nsOpts
 	newIPTables             func(structs.NodeNetworkAF) (IPTablesCleanup, error)
+
+	// interfacePrefix is the prefix of the interfaces created in the network
+	// namespace, which are numbered in the order the networks are loaded.
+	interfacePrefix string
+
+	// secondaryNetworks are the names of the additional CNI networks of the
+	// group network, attached after the primary network, and
+	// secondaryParsers their configs.
+	secondaryNetworks []string
+	secondaryParsers  []*cniConfParser
+
+	// ipv6PortMappings maps host IPv4 addresses to the IPv6 addresses of the
+	// same host network, which ports are also mapped on. It's only set for
+	// bridge networks with an IPv6 subnet.
+	ipv6PortMappings map[string][]string
+
+	// nftables is true if the bridge network uses the nftables backends of
+	// the CNI plugins
+	nftables bool
 }
 
 func newCNINetworkConfigurator(logger log.Logger, cniPath, cniInterfacePrefix, cniConfDir, networkName string, ignorePortMappingHostIP bool, node *structs.Node) (*cniNetworkConfigurator, error) {
@@ -92,6 +111,7 @@ func newCNINetworkConfiguratorWithConf(logger log.Logger, cniPath, cniInterfaceP
 	if cniInterfacePrefix == "" {
 		cniInterfacePrefix = defaultCNIInterfacePrefix
 	}
+	conf.interfacePrefix = cniInterfacePrefix
 
 	c, err := cni.New(cni.WithPluginDir(filepath.SplitList(cniPath)),
 		cni.WithInterfacePrefix(cniInterfacePrefix))
@@ -103,6 +123,26 @@ func newCNINetworkConfiguratorWithConf(logger log.Logger, cniPath, cniInterfaceP
 	return conf, nil
 }
 
+// loadSecondaryNetworks loads the configs of the additional CNI networks
+// attached to the network namespace after the primary network.
+func (c *cniNetworkConfigurator) loadSecondaryNetworks(confDir string, names []string) error {
+	for _, name := range names {
+		parser, err := loadCNIConf(confDir, name)
+		if err != nil {
+			return fmt.Errorf("failed to load CNI config of network %q: %v", name, err)
+		}
+		c.secondaryNetworks = append(c.secondaryNetworks, name)
+		c.secondaryParsers = append(c.secondaryParsers, parser)
+	}
+	return nil
+}
+
+// secondaryInterfaceName returns the name of the interface created by the
+// i-th additional CNI network. The primary network creates the first one.
+func (c *cniNetworkConfigurator) secondaryInterfaceName(i int) string {
+	return fmt.Sprintf("%s%d", c.interfacePrefix, i+1)
+}
+
 const (
 	ConsulIPTablesConfigEnvVar = "CONSUL_IPTABLES_CONFIG"
 )
@@ -170,6 +210,7 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 	addNomadWorkloadCNIArgs(c.logger, alloc, cniArgs)
 
 	portMaps := getPortMapping(alloc, c.ignorePortMappingHostIP)
+	portMaps.addIPv6(c.ipv6PortMappings)
 
 	tproxyArgs, err := c.setupTransparentProxyArgs(alloc, spec, portMaps)
 	if err != nil {
@@ -183,6 +224,17 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 		cniArgs[ConsulIPTablesConfigEnvVar] = string(iptablesCfg)
 	}
 
+	nsOpts := []cni.NamespaceOpts{
+		c.nsOpts.withCapabilityPortMap(portMaps.ports),
+		c.nsOpts.withArgs(cniArgs),
+	}
+	if bandwidth := getBandwidth(tg.Networks); bandwidth != nil {
+		nsOpts = append(nsOpts, c.nsOpts.withCapabilityBandWidth(*bandwidth))
+	}
+	if ips := getStaticAddresses(tg.Networks); len(ips) > 0 {
+		nsOpts = append(nsOpts, c.nsOpts.withCapabilityIPs(ips))
+	}
+
 	if !created {
 		// The netns will not be created if it already exists, typically on
 		// agent restart. If the configuration of a prexisting netns is wrong
@@ -193,10 +245,7 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 		// case of a host reboot with docker-created netns there.
 		cniVersion, err := version.NewSemver(c.nodeAttrs["plugins.cni.version.bridge"])
 		if err == nil && supportsCNICheck.Check(cniVersion) {
-			err := c.cni.Check(ctx, alloc.ID, spec.Path,
-				c.nsOpts.withCapabilityPortMap(portMaps.ports),
-				c.nsOpts.withArgs(cniArgs),
-			)
+			err := c.cni.Check(ctx, alloc.ID, spec.Path, nsOpts...)
 			if err != nil {
 				return nil, fmt.Errorf("%w: %w", ErrCNICheckFailed, err)
 			}
@@ -216,10 +265,7 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 	var res *cni.Result
 	for attempt := 1; ; attempt++ {
 		var err error
-		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path,
-			c.nsOpts.withCapabilityPortMap(portMaps.ports),
-			c.nsOpts.withArgs(cniArgs),
-		); err != nil {
+		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path, nsOpts...); err != nil {
 			c.logger.Warn("failed to configure network", "error", err, "attempt", attempt)
 			switch attempt {
 			case 1:
@@ -454,6 +500,12 @@ func (c *cniNetworkConfigurator) cniToAllocNet(res *cni.Result) (*structs.AllocN
 	}
 	sort.Strings(names)
 
+	// the interfaces of the additional networks are never the primary one
+	secondary := make(map[string]string, len(c.secondaryNetworks))
+	for i, network := range c.secondaryNetworks {
+		secondary[c.secondaryInterfaceName(i)] = network
+	}
+
 	// setStatus sets netStatus.Address and netStatus.InterfaceName
 	// if it finds a suitable interface that has IP address(es)
 	// (at least IPv4, possibly also IPv6)
@@ -469,6 +521,9 @@ func (c *cniNetworkConfigurator) cniToAllocNet(res *cni.Result) (*structs.AllocN
 			if requireSandbox && iface.Sandbox == "" {
 				continue
 			}
+			if _, ok := secondary[name]; ok {
+				continue
+			}
 
 			for _, ipConfig := range iface.IPConfigs {
 				isIP4 := ipConfig.IP.To4() != nil
@@ -501,12 +556,55 @@ func (c *cniNetworkConfigurator) cniToAllocNet(res *cni.Result) (*structs.AllocN
 		)
 	}
 
+	// If no IPv4 address was found, the network may be IPv6-only, so use the
+	// first sandbox interface with an IPv6 address as the primary address
+	if netStatus.Address == "" {
+		for _, name := range names {
+			iface := res.Interfaces[name]
+			if iface == nil || iface.Sandbox == "" {
+				continue
+			}
+			if _, ok := secondary[name]; ok {
+				continue
+			}
+			if ip := firstIPv6(iface.IPConfigs); ip != "" {
+				netStatus.Address = ip
+				netStatus.AddressIPv6 = ip
+				netStatus.InterfaceName = name
+				break
+			}
+		}
+	}
+
 	// If no IP address could be found, return an error
 	if netStatus.Address == "" {
 		return nil, fmt.Errorf("failed to configure network: no interface with an address")
 
 	}
 
+	for i, network := range c.secondaryNetworks {
+		name := c.secondaryInterfaceName(i)
+		iface := res.Interfaces[name]
+		if iface == nil {
+			return nil, fmt.Errorf("failed to configure network: no interface %q found for CNI network %q", name, network)
+		}
+
+		status := &structs.AllocNetworkInterface{
+			Network:       network,
+			InterfaceName: name,
+		}
+		for _, ipConfig := range iface.IPConfigs {
+			isIP4 := ipConfig.IP.To4() != nil
+			if status.Address == "" && isIP4 {
+				status.Address = ipConfig.IP.String()
+			}
+			if status.AddressIPv6 == "" && !isIP4 {
+				status.AddressIPv6 = ipConfig.IP.String()
+			}
+		}
+		netStatus.Interfaces = append(netStatus.Interfaces, status)
+	}
+
 	// Use the first DNS results, if non-empty
 	if len(res.DNS) > 0 {
 		cniDNS := res.DNS[0]
@@ -530,11 +628,18 @@ type cniConfParser struct {
 
 // getOpt produces a cni.Opt to load with cni.CNI.Load()
 func (c *cniConfParser) getOpt() (cni.Opt, error) {
+	return c.getOptIndex(0)
+}
+
+// getOptIndex produces a cni.Opt to load with cni.CNI.Load() as the network
+// with the given index, which sets the name of its interface. Config lists
+// are always loaded after the networks loaded before them.
+func (c *cniConfParser) getOptIndex(index int) (cni.Opt, error) {
 	if len(c.listBytes) > 0 {
 		return cni.WithConfListBytes(c.listBytes), nil
 	}
 	if len(c.confBytes) > 0 {
-		return cni.WithConf(c.confBytes), nil
+		return cni.WithConfIndex(c.confBytes, index), nil
 	}
 	// theoretically should never be reached
 	return nil, errors.New("no CNI network config found")
@@ -587,8 +692,20 @@ func (c *cniNetworkConfigurator) Teardown(ctx context.Context, alloc *structs.Al
 	}
 
 	portMap := getPortMapping(alloc, c.ignorePortMappingHostIP)
+	portMap.addIPv6(c.ipv6PortMappings)
+
+	err := c.cni.Remove(ctx, alloc.ID, spec.Path, cni.WithCapabilityPortMap(portMap.ports))
+	if c.nftables {
+		// The allocation may have been set up before the client switched to
+		// nftables, in which case the plugins leave its iptables rules behind
+		c.cleanupIPTablesMigration(alloc.ID)
+		if err != nil {
+			return fmt.Errorf("failed to remove CNI network: %w", err)
+		}
+		return nil
+	}
 
-	if err := c.cni.Remove(ctx, alloc.ID, spec.Path, cni.WithCapabilityPortMap(portMap.ports)); err != nil {
+	if err != nil {
 		c.logger.Warn("error from cni.Remove; attempting manual iptables cleanup", "err", err)
 
 		// best effort cleanup ipv6
@@ -619,6 +736,31 @@ var (
 	ipRuleRe = regexp.MustCompile(`-A POSTROUTING -s (\S+) -m comment --comment "name: \\"nomad\\" id: \\"([[:xdigit:]-]+)\\"" -j (CNI-[[:xdigit:]]+)`)
 )
 
+// cleanupIPTablesMigration removes the iptables rules of an allocation that was
+// set up with the iptables backend before the client switched to nftables. It
+// does nothing on hosts without iptables.
+func (c *cniNetworkConfigurator) cleanupIPTablesMigration(allocID string) {
+	matcher := fmt.Sprintf(`--comment "name: \"nomad\" id: \"%s\""`, allocID)
+
+	for _, family := range []structs.NodeNetworkAF{structs.NodeNetworkAF_IPv4, structs.NodeNetworkAF_IPv6} {
+		ipt, err := c.newIPTables(family)
+		if err != nil {
+			continue
+		}
+		rules, err := ipt.List("nat", "POSTROUTING")
+		if err != nil {
+			continue
+		}
+		if !slices.ContainsFunc(rules, func(rule string) bool { return strings.Contains(rule, matcher) }) {
+			continue
+		}
+		c.logger.Info("removing iptables rules of allocation set up before switching to nftables", "alloc_id", allocID, "family", family)
+		if err := c.forceCleanup(ipt, allocID); err != nil {
+			c.logger.Warn("failed to remove iptables rules", "alloc_id", allocID, "family", family, "error", err)
+		}
+	}
+}
+
 // forceCleanup is the backup plan for removing the iptables rule and chain associated with
 // an allocation that was using bridge networking. The cni library refuses to handle a
 // dirty state - e.g. the pause container is removed out of band, and so we must cleanup
@@ -699,13 +841,33 @@ func (c *cniNetworkConfigurator) ensureCNIInitialized() error {
 	if err != nil {
 		return err
 	}
-	return c.cni.Load(opt)
+	opts := []cni.Opt{opt}
+	for i, parser := range c.secondaryParsers {
+		opt, err := parser.getOptIndex(i + 1)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, opt)
+	}
+	return c.cni.Load(opts...)
+}
+
+// firstIPv6 returns the first IPv6 address of the IP configs of an interface.
+func firstIPv6(ipConfigs []*cni.IPConfig) string {
+	for _, ipConfig := range ipConfigs {
+		if ipConfig.IP.To4() == nil {
+			return ipConfig.IP.String()
+		}
+	}
+	return ""
 }
 
 // nsOpts keeps track of NamespaceOpts usage, mainly for test assertions.
 type nsOpts struct {
-	args  map[string]string
-	ports []cni.PortMapping
+	args      map[string]string
+	ports     []cni.PortMapping
+	bandwidth *cni.BandWidth
+	ips       []string
 }
 
 func (o *nsOpts) withArgs(args map[string]string) cni.NamespaceOpts {
@@ -718,6 +880,52 @@ func (o *nsOpts) withCapabilityPortMap(ports []cni.PortMapping) cni.NamespaceOpt
 	return cni.WithCapabilityPortMap(ports)
 }
 
+func (o *nsOpts) withCapabilityBandWidth(bandwidth cni.BandWidth) cni.NamespaceOpts {
+	o.bandwidth = &bandwidth
+	return cni.WithCapabilityBandWidth(bandwidth)
+}
+
+func (o *nsOpts) withCapabilityIPs(ips []string) cni.NamespaceOpts {
+	o.ips = ips
+	return cni.WithCapability("ips", ips)
+}
+
+// getStaticAddresses returns the static addresses requested by the first
+// network that sets one. They're passed to plugins that declare the "ips"
+// capability, such as the host-local and static IPAM plugins.
+func getStaticAddresses(networks structs.Networks) []string {
+	for _, net := range networks {
+		if net.HasStaticAddress() {
+			return net.StaticAddresses()
+		}
+	}
+	return nil
+}
+
+// getBandwidth returns the bandwidth limits of the first shaped network, or
+// nil if none of the networks are shaped. Rates are in bits per second, and
+// the bandwidth plugin requires a burst with each rate, so we allow bursts of
+// 100ms of traffic at the limited rate.
+func getBandwidth(networks structs.Networks) *cni.BandWidth {
+	for _, net := range networks {
+		if !net.Shaped() {
+			continue
+		}
+
+		var bandwidth cni.BandWidth
+		if net.IngressMBits > 0 {
+			bandwidth.IngressRate = uint64(net.IngressMBits) * 1_000_000
+			bandwidth.IngressBurst = bandwidth.IngressRate / 10
+		}
+		if net.EgressMBits > 0 {
+			bandwidth.EgressRate = uint64(net.EgressMBits) * 1_000_000
+			bandwidth.EgressBurst = bandwidth.EgressRate / 10
+		}
+		return &bandwidth
+	}
+	return nil
+}
+
 // portMappings is a wrapper around a slice of cni.PortMapping that lets us
 // index via the port's label, which isn't otherwise included in the
 // cni.PortMapping struct
@@ -731,6 +939,21 @@ func (pm *portMappings) set(label string, port cni.PortMapping) {
 	pm.labels[label] = len(pm.ports) - 1
 }
 
+// addIPv6 adds a copy of each port mapping on an IPv4 host address for the
+// IPv6 addresses it maps to. The copies are not indexed by label, so get
+// still returns the mapping assigned by the scheduler.
+func (pm *portMappings) addIPv6(addrs map[string][]string) {
+	if len(addrs) == 0 {
+		return
+	}
+	for _, port := range pm.ports {
+		for _, ip := range addrs[port.HostIP] {
+			port.HostIP = ip
+			pm.ports = append(pm.ports, port)
+		}
+	}
+}
+
 func (pm *portMappings) get(label string) (cni.PortMapping, bool) {
 	idx, ok := pm.labels[label]
 	if !ok {
@@ -129,6 +129,8 @@ func TestSetup(t *testing.T) {
 		expectResult *structs.AllocNetworkStatus
 		expectErr    string
 		expectArgs   map[string]string
+		expectBW     *cni.BandWidth
+		expectIPs    []string
 	}{
 		{
 			name: "defaults",
@@ -194,6 +196,56 @@ func TestSetup(t *testing.T) {
 				"NOMAD_REGION":     "global",
 			},
 		},
+		{
+			name: "with bandwidth limits",
+			modAlloc: func(a *structs.Allocation) {
+				tg := a.Job.LookupTaskGroup(a.TaskGroup)
+				tg.Networks = []*structs.NetworkResource{{
+					Mode:        "bridge",
+					EgressMBits: 10,
+				}}
+			},
+			expectResult: &structs.AllocNetworkStatus{
+				InterfaceName: "eth0",
+				Address:       "99.99.99.99",
+			},
+			expectArgs: map[string]string{
+				"IgnoreUnknown":    "true",
+				"NOMAD_ALLOC_ID":   "7cd08c6c-86c8-0bfa-f7ca-338466447711",
+				"NOMAD_GROUP_NAME": "web",
+				"NOMAD_JOB_ID":     "mock-service",
+				"NOMAD_NAMESPACE":  "default",
+				"NOMAD_REGION":     "global",
+			},
+			expectBW: &cni.BandWidth{
+				EgressRate:  10_000_000,
+				EgressBurst: 1_000_000,
+			},
+		},
+		{
+			name: "with static addresses",
+			modAlloc: func(a *structs.Allocation) {
+				tg := a.Job.LookupTaskGroup(a.TaskGroup)
+				tg.Networks = []*structs.NetworkResource{{
+					Mode:        "bridge",
+					IPv4Address: "172.26.64.10",
+					IPv6Address: "fd00::10",
+				}}
+			},
+			expectResult: &structs.AllocNetworkStatus{
+				InterfaceName: "eth0",
+				Address:       "99.99.99.99",
+			},
+			expectArgs: map[string]string{
+				"IgnoreUnknown":    "true",
+				"NOMAD_ALLOC_ID":   "7cd08c6c-86c8-0bfa-f7ca-338466447711",
+				"NOMAD_GROUP_NAME": "web",
+				"NOMAD_JOB_ID":     "mock-service",
+				"NOMAD_NAMESPACE":  "default",
+				"NOMAD_REGION":     "global",
+			},
+			expectIPs: []string{"172.26.64.10", "fd00::10"},
+		},
 		{
 			name: "cni workload with invalid job id and namespace",
 			modAlloc: func(a *structs.Allocation) {
@@ -301,6 +353,8 @@ func TestSetup(t *testing.T) {
 				must.NoError(t, err)
 				must.Eq(t, tc.expectResult, result)
 				must.Eq(t, tc.expectArgs, c.nsOpts.args)
+				must.Eq(t, tc.expectBW, c.nsOpts.bandwidth)
+				must.Eq(t, tc.expectIPs, c.nsOpts.ips)
 				expectCalls := len(tc.setupErrors) + 1
 				must.Eq(t, fakePlugin.counter.Get()["Setup"], expectCalls,
 					must.Sprint("unexpected call count"))
@@ -498,6 +552,124 @@ func TestCNI_cniToAllocNet_Dualstack(t *testing.T) {
 	test.Eq(t, "eth0", allocNet.InterfaceName)
 }
 
+func TestCNI_cniToAllocNet_IPv6Only(t *testing.T) {
+	ci.Parallel(t)
+
+	cniResult := &cni.Result{
+		Interfaces: map[string]*cni.Config{
+			"eth0": {
+				Sandbox: "at-the-park",
+				IPConfigs: []*cni.IPConfig{
+					{IP: net.ParseIP("fd00::10")},
+				},
+			},
+			"a-host-interface": {
+				IPConfigs: []*cni.IPConfig{
+					{IP: net.ParseIP("fd00::1")},
+				},
+			},
+		},
+	}
+
+	c := &cniNetworkConfigurator{
+		logger: testlog.HCLogger(t),
+	}
+	allocNet, err := c.cniToAllocNet(cniResult)
+	must.NoError(t, err)
+	test.Eq(t, "fd00::10", allocNet.Address)
+	test.Eq(t, "fd00::10", allocNet.AddressIPv6)
+	test.Eq(t, "eth0", allocNet.InterfaceName)
+}
+
+// TestCNI_cniToAllocNet_SecondaryNetworks asserts the interfaces of the
+// additional CNI networks are reported separately and never picked as the
+// primary interface.
+func TestCNI_cniToAllocNet_SecondaryNetworks(t *testing.T) {
+	ci.Parallel(t)
+
+	cniResult := &cni.Result{
+		Interfaces: map[string]*cni.Config{
+			"eth0": {
+				Sandbox: "nomad-sandbox",
+				IPConfigs: []*cni.IPConfig{
+					{IP: net.IPv4(172, 26, 64, 10)},
+				},
+			},
+			"eth1": {
+				Sandbox: "nomad-sandbox",
+				IPConfigs: []*cni.IPConfig{
+					{IP: net.IPv4(10, 10, 0, 5)},
+					{IP: net.ParseIP("fd00::5")},
+				},
+			},
+			"eth2": {
+				Sandbox: "nomad-sandbox",
+			},
+		},
+	}
+
+	c := &cniNetworkConfigurator{
+		logger:            testlog.HCLogger(t),
+		interfacePrefix:   "eth",
+		secondaryNetworks: []string{"storage", "sriov"},
+	}
+	allocNet, err := c.cniToAllocNet(cniResult)
+	must.NoError(t, err)
+	must.Eq(t, "eth0", allocNet.InterfaceName)
+	must.Eq(t, "172.26.64.10", allocNet.Address)
+	must.Eq(t, []*structs.AllocNetworkInterface{
+		{
+			Network:       "storage",
+			InterfaceName: "eth1",
+			Address:       "10.10.0.5",
+			AddressIPv6:   "fd00::5",
+		},
+		{
+			Network:       "sriov",
+			InterfaceName: "eth2",
+		},
+	}, allocNet.Interfaces)
+
+	// a secondary network without an interface fails the setup
+	c.secondaryNetworks = append(c.secondaryNetworks, "missing")
+	_, err = c.cniToAllocNet(cniResult)
+	must.EqError(t, err, `failed to configure network: no interface "eth3" found for CNI network "missing"`)
+}
+
+// TestCNI_loadSecondaryNetworks asserts the additional CNI networks are loaded
+// after the primary network, so their interfaces are numbered after it.
+func TestCNI_loadSecondaryNetworks(t *testing.T) {
+	ci.Parallel(t)
+
+	confDir := t.TempDir()
+	for name, content := range map[string]string{
+		"10-primary.conflist": `{"cniVersion": "1.0.0", "name": "primary", "plugins": [{"type": "bridge"}]}`,
+		"20-storage.conf":     `{"cniVersion": "1.0.0", "name": "storage", "type": "macvlan"}`,
+		"30-sriov.conflist":   `{"cniVersion": "1.0.0", "name": "sriov", "plugins": [{"type": "sriov"}]}`,
+	} {
+		must.NoError(t, os.WriteFile(filepath.Join(confDir, name), []byte(content), 0644))
+	}
+
+	c, err := newCNINetworkConfigurator(testlog.HCLogger(t), "", "", confDir, "primary", false, mock.Node())
+	must.NoError(t, err)
+	must.NoError(t, c.loadSecondaryNetworks(confDir, []string{"storage", "sriov"}))
+	must.NoError(t, c.ensureCNIInitialized())
+
+	networks := c.cni.GetConfig().Networks
+	must.Len(t, 3, networks)
+	for i, exp := range []struct{ name, ifName string }{
+		{"primary", "eth0"},
+		{"storage", "eth1"},
+		{"sriov", "eth2"},
+	} {
+		must.Eq(t, exp.name, networks[i].Config.Name)
+		must.Eq(t, exp.ifName, networks[i].IFName)
+	}
+
+	err = c.loadSecondaryNetworks(confDir, []string{"unknown"})
+	must.ErrorContains(t, err, `failed to load CNI config of network "unknown"`)
+}
+
 func TestCNI_addCustomCNIArgs(t *testing.T) {
 	ci.Parallel(t)
 	cniArgs := map[string]string{
@@ -778,3 +950,42 @@ func TestCNI_setupTproxyArgs(t *testing.T) {
 	}
 
 }
+
+func TestCNI_portMappings_addIPv6(t *testing.T) {
+	ci.Parallel(t)
+
+	node := mock.Node()
+	node.NodeResources.NodeNetworks = []*structs.NodeNetworkResource{{
+		Mode:   "host",
+		Device: "eth0",
+		Addresses: []structs.NodeNetworkAddress{
+			{Alias: "default", Family: structs.NodeNetworkAF_IPv4, Address: "192.168.0.100"},
+			{Alias: "default", Family: structs.NodeNetworkAF_IPv6, Address: "2001:db8::100"},
+			{Alias: "private", Family: structs.NodeNetworkAF_IPv4, Address: "10.0.0.100"},
+		},
+	}}
+	addrs := ipv6HostAddresses(node)
+	must.Eq(t, map[string][]string{"192.168.0.100": {"2001:db8::100"}}, addrs)
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "http", Value: 8080, To: 80, HostIP: "192.168.0.100"},
+		{Label: "admin", Value: 9000, To: 9000, HostIP: "10.0.0.100"},
+	}
+	portMaps := getPortMapping(alloc, false)
+	portMaps.addIPv6(addrs)
+
+	must.Eq(t, []cni.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", HostIP: "192.168.0.100"},
+		{HostPort: 8080, ContainerPort: 80, Protocol: "udp", HostIP: "192.168.0.100"},
+		{HostPort: 9000, ContainerPort: 9000, Protocol: "tcp", HostIP: "10.0.0.100"},
+		{HostPort: 9000, ContainerPort: 9000, Protocol: "udp", HostIP: "10.0.0.100"},
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", HostIP: "2001:db8::100"},
+		{HostPort: 8080, ContainerPort: 80, Protocol: "udp", HostIP: "2001:db8::100"},
+	}, portMaps.ports)
+
+	// the label still refers to the mapping assigned by the scheduler
+	http, ok := portMaps.get("http")
+	must.True(t, ok)
+	must.Eq(t, "192.168.0.100", http.HostIP)
+}
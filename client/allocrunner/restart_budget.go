@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// restartBudgetTracker counts the restarts of the tasks of an allocation
+// against the restart budget of its task group.
+type restartBudgetTracker struct {
+	// restarts is the last known number of restarts of each task
+	restarts map[string]uint64
+
+	// times are the times of the restarts counted so far
+	times []time.Time
+}
+
+// newRestartBudgetTracker returns a tracker which only counts the restarts
+// happening after it was created, so restarts restored from the client state
+// are not counted twice.
+func newRestartBudgetTracker(tasks map[string]*taskrunner.TaskRunner) *restartBudgetTracker {
+	t := &restartBudgetTracker{
+		restarts: make(map[string]uint64, len(tasks)),
+	}
+	for name, tr := range tasks {
+		t.restarts[name] = tr.TaskState().Restarts
+	}
+	return t
+}
+
+// update records the restarts of the task since its last update.
+func (t *restartBudgetTracker) update(name string, state *structs.TaskState) {
+	for i := t.restarts[name]; i < state.Restarts; i++ {
+		t.times = append(t.times, state.LastRestart)
+	}
+	t.restarts[name] = state.Restarts
+}
+
+// exceeded returns whether the tasks restarted more than allowed by the budget
+// within its interval. Restarts outside the interval are forgotten.
+func (t *restartBudgetTracker) exceeded(budget *structs.RestartBudget, now time.Time) bool {
+	if budget == nil {
+		return false
+	}
+
+	since := now.Add(-budget.Interval)
+	recent := t.times[:0]
+	for _, restart := range t.times {
+		if restart.After(since) {
+			recent = append(recent, restart)
+		}
+	}
+	t.times = recent
+	return len(t.times) > budget.Attempts
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestRestartBudgetTracker(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	budget := &structs.RestartBudget{Attempts: 2, Interval: time.Minute}
+	tracker := newRestartBudgetTracker(map[string]*taskrunner.TaskRunner{})
+	must.False(t, tracker.exceeded(nil, now))
+
+	tracker.update("web", &structs.TaskState{Restarts: 1, LastRestart: now.Add(-2 * time.Minute)})
+	tracker.update("sidecar", &structs.TaskState{Restarts: 1, LastRestart: now.Add(-10 * time.Second)})
+	must.False(t, tracker.exceeded(budget, now))
+
+	// The restarts of all the tasks are counted, but only once
+	tracker.update("sidecar", &structs.TaskState{Restarts: 1, LastRestart: now.Add(-10 * time.Second)})
+	tracker.update("web", &structs.TaskState{Restarts: 2, LastRestart: now})
+	must.False(t, tracker.exceeded(budget, now))
+
+	tracker.update("sidecar", &structs.TaskState{Restarts: 2, LastRestart: now})
+	must.True(t, tracker.exceeded(budget, now))
+
+	// Older restarts are forgotten
+	must.False(t, tracker.exceeded(budget, now.Add(55*time.Second)))
+}
@@ -16,6 +16,7 @@ type coordinatorState uint8
 
 const (
 	coordinatorStateInit coordinatorState = iota
+	coordinatorStatePrestartInit
 	coordinatorStatePrestart
 	coordinatorStateMain
 	coordinatorStatePoststart
@@ -27,6 +28,8 @@ func (s coordinatorState) String() string {
 	switch s {
 	case coordinatorStateInit:
 		return "init"
+	case coordinatorStatePrestartInit:
+		return "prestart_init"
 	case coordinatorStatePrestart:
 		return "prestart"
 	case coordinatorStateMain:
@@ -51,9 +54,13 @@ func (s coordinatorState) String() string {
 type lifecycleStage uint8
 
 const (
+	// lifecycleStagePrestartInit are tasks with the "prestart_init" hook. They
+	// run one at a time, in the order they are defined in the task group.
+	lifecycleStagePrestartInit lifecycleStage = iota
+
 	// lifecycleStagePrestartEphemeral are tasks with the "prestart" hook and
 	// sidecar set to "false".
-	lifecycleStagePrestartEphemeral lifecycleStage = iota
+	lifecycleStagePrestartEphemeral
 
 	// lifecycleStagePrestartSidecar are tasks with the "prestart" hook and
 	// sidecar set to "true".
@@ -94,6 +101,10 @@ type Coordinator struct {
 
 	// gates store the gates that control each task lifecycle stage.
 	gates map[lifecycleStage]*Gate
+
+	// initGates store the gates that control each init task, since only one
+	// of them is allowed to run at a time.
+	initGates map[string]*Gate
 }
 
 // NewCoordinator returns a new Coordinator with all tasks initially blocked.
@@ -102,11 +113,15 @@ func NewCoordinator(logger hclog.Logger, tasks []*structs.Task, shutdownCh <-cha
 		logger:           logger.Named("task_coordinator"),
 		tasksByLifecycle: indexTasksByLifecycle(tasks),
 		gates:            make(map[lifecycleStage]*Gate),
+		initGates:        make(map[string]*Gate),
 	}
 
 	for lifecycle := range c.tasksByLifecycle {
 		c.gates[lifecycle] = NewGate(shutdownCh)
 	}
+	for _, task := range c.tasksByLifecycle[lifecycleStagePrestartInit] {
+		c.initGates[task] = NewGate(shutdownCh)
+	}
 
 	c.enterStateLocked(coordinatorStateInit)
 	return c
@@ -127,7 +142,7 @@ func (c *Coordinator) Restore(states map[string]*structs.TaskState) {
 	// Skip the "init" state when restoring since the tasks were likely already
 	// running, causing the Coordinator to be stuck waiting for them to be
 	// "pending".
-	c.enterStateLocked(coordinatorStatePrestart)
+	c.enterStateLocked(coordinatorStatePrestartInit)
 	c.TaskStateUpdated(states)
 }
 
@@ -135,6 +150,9 @@ func (c *Coordinator) Restore(states map[string]*structs.TaskState) {
 // allowed to run.
 func (c *Coordinator) StartConditionForTask(task *structs.Task) <-chan struct{} {
 	lifecycle := taskLifecycleStage(task)
+	if lifecycle == lifecycleStagePrestartInit {
+		return c.initGates[task.Name].WaitCh()
+	}
 	return c.gates[lifecycle].WaitCh()
 }
 
@@ -152,6 +170,9 @@ func (c *Coordinator) TaskStateUpdated(states map[string]*structs.TaskState) {
 	for {
 		nextState := c.nextStateLocked(states)
 		if nextState == c.currentState {
+			if c.currentState == coordinatorStatePrestartInit {
+				c.allowNextInitTaskLocked(states)
+			}
 			return
 		}
 
@@ -175,6 +196,12 @@ func (c *Coordinator) nextStateLocked(states map[string]*structs.TaskState) coor
 		if !c.isInitDone(states) {
 			return coordinatorStateInit
 		}
+		return coordinatorStatePrestartInit
+
+	case coordinatorStatePrestartInit:
+		if !c.isPrestartInitDone(states) {
+			return coordinatorStatePrestartInit
+		}
 		return coordinatorStatePrestart
 
 	case coordinatorStatePrestart:
@@ -216,6 +243,12 @@ func (c *Coordinator) nextStateLocked(states map[string]*structs.TaskState) coor
 func (c *Coordinator) enterStateLocked(state coordinatorState) {
 	c.logger.Trace("state transition", "from", c.currentState, "to", state)
 
+	// Init tasks are only allowed to run in the prestart_init state, where
+	// allowNextInitTaskLocked opens their gates one at a time.
+	for _, gate := range c.initGates {
+		gate.Close()
+	}
+
 	switch state {
 	case coordinatorStateInit:
 		c.block(lifecycleStagePrestartEphemeral)
@@ -225,6 +258,14 @@ func (c *Coordinator) enterStateLocked(state coordinatorState) {
 		c.block(lifecycleStagePoststartSidecar)
 		c.block(lifecycleStagePoststop)
 
+	case coordinatorStatePrestartInit:
+		c.block(lifecycleStagePrestartEphemeral)
+		c.block(lifecycleStagePrestartSidecar)
+		c.block(lifecycleStageMain)
+		c.block(lifecycleStagePoststartEphemeral)
+		c.block(lifecycleStagePoststartSidecar)
+		c.block(lifecycleStagePoststop)
+
 	case coordinatorStatePrestart:
 		c.block(lifecycleStageMain)
 		c.block(lifecycleStagePoststartEphemeral)
@@ -285,6 +326,29 @@ func (c *Coordinator) isInitDone(states map[string]*structs.TaskState) bool {
 	return true
 }
 
+// isPrestartInitDone returns true when the following conditions are met:
+//   - all init tasks are successful.
+func (c *Coordinator) isPrestartInitDone(states map[string]*structs.TaskState) bool {
+	for _, task := range c.tasksByLifecycle[lifecycleStagePrestartInit] {
+		if !states[task].Successful() {
+			return false
+		}
+	}
+	return true
+}
+
+// allowNextInitTaskLocked allows the first init task that isn't successful
+// yet to run, keeping the init tasks after it blocked until it succeeds.
+// The currentStateLock must be held before calling this method.
+func (c *Coordinator) allowNextInitTaskLocked(states map[string]*structs.TaskState) {
+	for _, task := range c.tasksByLifecycle[lifecycleStagePrestartInit] {
+		if !states[task].Successful() {
+			c.initGates[task].Open()
+			return
+		}
+	}
+}
+
 // isPrestartDone returns true when the following conditions are met:
 //   - there is at least one prestart task
 //   - all ephemeral prestart tasks are successful.
@@ -411,7 +475,9 @@ func indexTasksByLifecycle(tasks []*structs.Task) map[lifecycleStage][]string {
 
 // taskLifecycleStage returns the relevant lifecycle stage for a given task.
 func taskLifecycleStage(task *structs.Task) lifecycleStage {
-	if task.IsPrestart() {
+	if task.IsPrestartInit() {
+		return lifecycleStagePrestartInit
+	} else if task.IsPrestart() {
 		if task.Lifecycle.Sidecar {
 			return lifecycleStagePrestartSidecar
 		}
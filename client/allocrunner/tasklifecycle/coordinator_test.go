@@ -223,6 +223,82 @@ func TestCoordinator_MainRunsAfterManyInitTasks(t *testing.T) {
 	RequireTaskAllowed(t, coord, mainTask)
 }
 
+func TestCoordinator_PrestartInitTasksRunSequentially(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.LifecycleAllocFromTasks([]mock.LifecycleTaskDef{
+		{Name: "init1", RunFor: "1s", Hook: structs.TaskLifecycleHookPrestartInit},
+		{Name: "init2", RunFor: "1s", Hook: structs.TaskLifecycleHookPrestartInit},
+		{Name: "prestart", RunFor: "1s", Hook: structs.TaskLifecycleHookPrestart},
+		{Name: "main", RunFor: "10s"},
+	})
+	tasks := alloc.Job.TaskGroups[0].Tasks
+
+	init1Task := tasks[0]
+	init2Task := tasks[1]
+	prestartTask := tasks[2]
+	mainTask := tasks[3]
+
+	shutdownCh := make(chan struct{})
+	defer close(shutdownCh)
+	coord := NewCoordinator(logger, tasks, shutdownCh)
+
+	// All tasks start blocked.
+	RequireTaskBlocked(t, coord, init1Task)
+	RequireTaskBlocked(t, coord, init2Task)
+	RequireTaskBlocked(t, coord, prestartTask)
+	RequireTaskBlocked(t, coord, mainTask)
+
+	// Set initial state, only the first init task is allowed to run.
+	states := map[string]*structs.TaskState{
+		init1Task.Name:    {State: structs.TaskStatePending},
+		init2Task.Name:    {State: structs.TaskStatePending},
+		prestartTask.Name: {State: structs.TaskStatePending},
+		mainTask.Name:     {State: structs.TaskStatePending},
+	}
+	coord.TaskStateUpdated(states)
+	RequireTaskAllowed(t, coord, init1Task)
+	RequireTaskBlocked(t, coord, init2Task)
+	RequireTaskBlocked(t, coord, prestartTask)
+	RequireTaskBlocked(t, coord, mainTask)
+
+	// First init task completes, the second one is allowed to run.
+	states[init1Task.Name] = &structs.TaskState{
+		State:      structs.TaskStateDead,
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+	}
+	coord.TaskStateUpdated(states)
+	RequireTaskAllowed(t, coord, init2Task)
+	RequireTaskBlocked(t, coord, prestartTask)
+	RequireTaskBlocked(t, coord, mainTask)
+
+	// Second init task fails, the remaining tasks stay blocked.
+	states[init2Task.Name] = &structs.TaskState{
+		State:      structs.TaskStateDead,
+		Failed:     true,
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+	}
+	coord.TaskStateUpdated(states)
+	RequireTaskBlocked(t, coord, prestartTask)
+	RequireTaskBlocked(t, coord, mainTask)
+
+	// Second init task completes, prestart tasks are allowed to run.
+	states[init2Task.Name] = &structs.TaskState{
+		State:      structs.TaskStateDead,
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+	}
+	coord.TaskStateUpdated(states)
+	RequireTaskBlocked(t, coord, init1Task)
+	RequireTaskBlocked(t, coord, init2Task)
+	RequireTaskAllowed(t, coord, prestartTask)
+	RequireTaskBlocked(t, coord, mainTask)
+}
+
 func TestCoordinator_FailedInitTask(t *testing.T) {
 	ci.Parallel(t)
 
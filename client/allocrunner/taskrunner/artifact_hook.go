@@ -5,11 +5,13 @@ package taskrunner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
 	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	ci "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -53,6 +55,20 @@ func (h *artifactHook) doWork(
 		h.logger.Debug("downloading artifact", "artifact", artifact.GetterSource, "aid", aid)
 
 		if err := h.getter.Get(req.TaskEnv, artifact, req.Task.User); err != nil {
+			if errors.Is(err, getter.ErrVerificationFailed) {
+				// Downloading the artifact again won't make its signature
+				// valid, so fail the task instead of retrying.
+				wrapped := structs.NewRecoverableError(
+					fmt.Errorf("failed to verify artifact %q: %v", artifact.GetterSource, err),
+					false,
+				)
+				event := structs.NewTaskEvent(structs.TaskArtifactVerificationFailed).
+					SetDownloadError(wrapped).
+					SetFailsTask()
+				errorChannel <- NewHookError(wrapped, event)
+				continue
+			}
+
 			wrapped := structs.NewRecoverableError(
 				fmt.Errorf("failed to download artifact %q: %v", artifact.GetterSource, err),
 				true,
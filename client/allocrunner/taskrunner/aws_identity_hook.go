@@ -0,0 +1,356 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/hashicorp/consul-template/signals"
+	log "github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/client/widmgr"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/users"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// awsCredentialsFile is the name of the file holding the AWS shared
+	// credentials inside the task's secret directory
+	awsCredentialsFile = "aws_credentials"
+
+	// awsCredentialsFileEnv is the environment variable AWS SDKs and the AWS
+	// CLI read the location of the shared credentials file from
+	awsCredentialsFileEnv = "AWS_SHARED_CREDENTIALS_FILE"
+
+	// awsDefaultRegion is the region of the STS endpoint used when the
+	// identity does not set one
+	awsDefaultRegion = "us-east-1"
+
+	// awsBackoffBaseline is the baseline time for exponential backoff when
+	// attempting to refresh AWS credentials
+	awsBackoffBaseline = 5 * time.Second
+
+	// awsBackoffLimit is the limit of the exponential backoff when attempting
+	// to refresh AWS credentials
+	awsBackoffLimit = 3 * time.Minute
+
+	// awsRefreshMinWait is the minimum time to wait before refreshing AWS
+	// credentials
+	awsRefreshMinWait = 10 * time.Second
+)
+
+// awsSessionNameInvalidChars matches the characters that are not allowed in
+// an STS role session name.
+var awsSessionNameInvalidChars = regexp.MustCompile(`[^\w+=,.@-]`)
+
+// stsClient is the subset of the AWS STS API used to exchange workload
+// identities for AWS credentials.
+type stsClient interface {
+	AssumeRoleWithWebIdentity(context.Context, *sts.AssumeRoleWithWebIdentityInput, ...func(*sts.Options)) (*sts.AssumeRoleWithWebIdentityOutput, error)
+}
+
+// newSTSClient returns an STS client for the region. AssumeRoleWithWebIdentity
+// is authenticated by the workload identity, so the client does not use
+// any of the Nomad agent's AWS credentials.
+func newSTSClient(region string) stsClient {
+	return sts.New(sts.Options{
+		Region:      region,
+		Credentials: aws.AnonymousCredentials{},
+	})
+}
+
+type awsIdentityHookConfig struct {
+	alloc     *structs.Allocation
+	task      *structs.Task
+	widmgr    widmgr.IdentityManager
+	lifecycle ti.TaskLifecycle
+	events    ti.EventEmitter
+	logger    log.Logger
+}
+
+// awsIdentityHook exchanges the workload identities of a task that have an
+// aws block for AWS credentials, and writes them to a shared credentials file
+// in the task's secrets directory. The credentials are refreshed with the
+// latest signed identity before they expire.
+type awsIdentityHook struct {
+	alloc      *structs.Allocation
+	task       *structs.Task
+	identities []*structs.WorkloadIdentity
+	widmgr     widmgr.IdentityManager
+	lifecycle  ti.TaskLifecycle
+	events     ti.EventEmitter
+	logger     log.Logger
+
+	// newClient returns the STS client for a region and is overridden in
+	// tests
+	newClient func(region string) stsClient
+
+	// minWait is the minimum time to wait before refreshing credentials
+	minWait time.Duration
+
+	// credsPath is the path on the host of the shared credentials file
+	credsPath string
+
+	// creds are the current AWS credentials keyed by profile
+	creds map[string]*ststypes.Credentials
+
+	// running is true once the refresh loops have been started
+	running bool
+	lock    sync.Mutex
+
+	stopCtx context.Context
+	stop    context.CancelFunc
+}
+
+func newAWSIdentityHook(config *awsIdentityHookConfig) *awsIdentityHook {
+	stopCtx, stop := context.WithCancel(context.Background())
+	h := &awsIdentityHook{
+		alloc:      config.alloc,
+		task:       config.task,
+		identities: awsIdentities(config.task),
+		widmgr:     config.widmgr,
+		lifecycle:  config.lifecycle,
+		events:     config.events,
+		newClient:  newSTSClient,
+		minWait:    awsRefreshMinWait,
+		creds:      map[string]*ststypes.Credentials{},
+		stopCtx:    stopCtx,
+		stop:       stop,
+	}
+	h.logger = config.logger.Named(h.Name())
+	return h
+}
+
+// awsIdentities returns the workload identities of the task that are
+// exchanged for AWS credentials.
+func awsIdentities(task *structs.Task) []*structs.WorkloadIdentity {
+	var identities []*structs.WorkloadIdentity
+	for _, wid := range task.Identities {
+		if wid.AWS != nil {
+			identities = append(identities, wid)
+		}
+	}
+	return identities
+}
+
+func (*awsIdentityHook) Name() string {
+	return "aws_identity"
+}
+
+func (h *awsIdentityHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	resp.Env = map[string]string{
+		awsCredentialsFileEnv: filepath.Join(req.TaskEnv.EnvMap[taskenv.SecretsDir], awsCredentialsFile),
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	// The credentials are kept fresh across task restarts by the refresh
+	// loops started on the first run.
+	if h.running {
+		return nil
+	}
+	h.credsPath = filepath.Join(req.TaskDir.SecretsDir, awsCredentialsFile)
+
+	for _, wid := range h.identities {
+		creds, err := h.assumeRole(ctx, wid)
+		if err != nil {
+			return structs.NewRecoverableError(
+				fmt.Errorf("failed to get AWS credentials for identity %q: %w", wid.Name, err), true)
+		}
+		h.creds[wid.AWS.Profile] = creds
+	}
+	if err := h.writeCredentials(); err != nil {
+		return err
+	}
+
+	for _, wid := range h.identities {
+		go h.refreshCredentials(wid, aws.ToTime(h.creds[wid.AWS.Profile].Expiration))
+	}
+	h.running = true
+	return nil
+}
+
+// assumeRole exchanges the latest signed JWT of the workload identity for AWS
+// credentials.
+func (h *awsIdentityHook) assumeRole(ctx context.Context, wid *structs.WorkloadIdentity) (*ststypes.Credentials, error) {
+	signed, err := h.widmgr.Get(structs.WIHandle{
+		WorkloadIdentifier: h.task.Name,
+		IdentityName:       wid.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	region := wid.AWS.Region
+	if region == "" {
+		region = awsDefaultRegion
+	}
+
+	input := &sts.AssumeRoleWithWebIdentityInput{
+		RoleArn:          aws.String(wid.AWS.RoleARN),
+		RoleSessionName:  aws.String(h.sessionName()),
+		WebIdentityToken: aws.String(signed.JWT),
+	}
+	if wid.AWS.Duration > 0 {
+		input.DurationSeconds = aws.Int32(int32(wid.AWS.Duration.Seconds()))
+	}
+
+	out, err := h.newClient(region).AssumeRoleWithWebIdentity(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if out.Credentials == nil {
+		return nil, fmt.Errorf("no credentials returned for role %q", wid.AWS.RoleARN)
+	}
+	return out.Credentials, nil
+}
+
+// sessionName returns the STS role session name for the task, which is
+// recorded in AWS CloudTrail for the calls made with the credentials.
+func (h *awsIdentityHook) sessionName() string {
+	name := awsSessionNameInvalidChars.ReplaceAllString(
+		fmt.Sprintf("nomad-%s-%s", h.alloc.ID[:8], h.task.Name), "-")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// refreshCredentials refreshes the credentials of the workload identity before
+// they expire, until the hook is stopped.
+func (h *awsIdentityHook) refreshCredentials(wid *structs.WorkloadIdentity, expiration time.Time) {
+	var attempts uint64
+	wait := helper.ExpiryToRenewTime(expiration, time.Now, h.minWait)
+
+	for {
+		timer, stopTimer := helper.NewSafeTimer(wait)
+		select {
+		case <-h.stopCtx.Done():
+			stopTimer()
+			return
+		case <-timer.C:
+		}
+		stopTimer()
+
+		creds, err := h.assumeRole(h.stopCtx, wid)
+		if err != nil {
+			if h.stopCtx.Err() != nil {
+				return
+			}
+			attempts++
+			wait = helper.Backoff(awsBackoffBaseline, awsBackoffLimit, attempts)
+			h.logger.Error("failed to refresh AWS credentials", "identity", wid.Name,
+				"error", err, "backoff", wait)
+			h.events.EmitEvent(structs.NewTaskEvent(structs.TaskHookMessage).
+				SetDisplayMessage(fmt.Sprintf("Identity[%s]: failed to refresh AWS credentials: %v", wid.Name, err)))
+			continue
+		}
+		attempts = 0
+
+		h.lock.Lock()
+		h.creds[wid.AWS.Profile] = creds
+		err = h.writeCredentials()
+		h.lock.Unlock()
+		if err != nil {
+			h.logger.Error("failed to write AWS credentials", "identity", wid.Name, "error", err)
+			wait = h.minWait
+			continue
+		}
+		h.logger.Trace("refreshed AWS credentials", "identity", wid.Name)
+
+		if err := h.handleChangeMode(wid); err != nil {
+			// Ignore error from kill because if that fails there's really
+			// nothing to be done.
+			_ = h.lifecycle.Kill(h.stopCtx, structs.NewTaskEvent(structs.TaskKilling).
+				SetFailsTask().
+				SetDisplayMessage(fmt.Sprintf("Identity[%s]: %v", wid.Name, err)))
+			return
+		}
+
+		wait = helper.ExpiryToRenewTime(aws.ToTime(creds.Expiration), time.Now, h.minWait)
+	}
+}
+
+// handleChangeMode notifies the task of refreshed credentials as configured by
+// the change_mode of the identity.
+func (h *awsIdentityHook) handleChangeMode(wid *structs.WorkloadIdentity) error {
+	switch wid.ChangeMode {
+	case structs.WIChangeModeRestart:
+		const noFailure = false
+		err := h.lifecycle.Restart(h.stopCtx, structs.NewTaskEvent(structs.TaskRestartSignal).
+			SetDisplayMessage(fmt.Sprintf("Identity[%s]: new AWS credentials acquired", wid.Name)), noFailure)
+		if err != nil {
+			return fmt.Errorf("failed to restart: %w", err)
+		}
+
+	case structs.WIChangeModeSignal:
+		s, err := signals.Parse(wid.ChangeSignal)
+		if err != nil {
+			return fmt.Errorf("failed to parse signal: %w", err)
+		}
+		event := structs.NewTaskEvent(structs.TaskSignaling).
+			SetTaskSignal(s).
+			SetDisplayMessage(fmt.Sprintf("Identity[%s]: new AWS credentials acquired", wid.Name))
+		if err := h.lifecycle.Signal(event, wid.ChangeSignal); err != nil {
+			return fmt.Errorf("failed to send signal: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeCredentials writes the shared credentials file with a profile for each
+// identity. The file is replaced atomically so the task never reads partial
+// credentials. Must be called with the lock held.
+func (h *awsIdentityHook) writeCredentials() error {
+	profiles := make([]string, 0, len(h.creds))
+	for profile := range h.creds {
+		profiles = append(profiles, profile)
+	}
+	slices.Sort(profiles)
+
+	var buf bytes.Buffer
+	for _, profile := range profiles {
+		creds := h.creds[profile]
+		fmt.Fprintf(&buf, "[%s]\n", profile)
+		fmt.Fprintf(&buf, "aws_access_key_id = %s\n", aws.ToString(creds.AccessKeyId))
+		fmt.Fprintf(&buf, "aws_secret_access_key = %s\n", aws.ToString(creds.SecretAccessKey))
+		fmt.Fprintf(&buf, "aws_session_token = %s\n\n", aws.ToString(creds.SessionToken))
+	}
+
+	tmpPath := h.credsPath + ".tmp"
+	if err := users.WriteFileFor(tmpPath, buf.Bytes(), h.task.User); err != nil {
+		return fmt.Errorf("failed to write AWS credentials: %w", err)
+	}
+	if err := os.Rename(tmpPath, h.credsPath); err != nil {
+		return fmt.Errorf("failed to write AWS credentials: %w", err)
+	}
+	return nil
+}
+
+// Stop implements interfaces.TaskStopHook
+func (h *awsIdentityHook) Stop(context.Context, *interfaces.TaskStopRequest, *interfaces.TaskStopResponse) error {
+	h.stop()
+	return nil
+}
+
+// Shutdown implements interfaces.ShutdownHook
+func (h *awsIdentityHook) Shutdown() {
+	h.stop()
+}
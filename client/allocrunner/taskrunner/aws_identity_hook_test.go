@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/client/widmgr"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+var _ interfaces.TaskPrestartHook = (*awsIdentityHook)(nil)
+var _ interfaces.TaskStopHook = (*awsIdentityHook)(nil)
+var _ interfaces.ShutdownHook = (*awsIdentityHook)(nil)
+
+// mockSTS is an stsClient that returns numbered credentials.
+type mockSTS struct {
+	ttl time.Duration
+
+	lock   sync.Mutex
+	err    error
+	inputs []*sts.AssumeRoleWithWebIdentityInput
+}
+
+func (m *mockSTS) setErr(err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.err = err
+}
+
+func (m *mockSTS) calls() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.inputs)
+}
+
+func (m *mockSTS) AssumeRoleWithWebIdentity(_ context.Context, input *sts.AssumeRoleWithWebIdentityInput, _ ...func(*sts.Options)) (*sts.AssumeRoleWithWebIdentityOutput, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.inputs = append(m.inputs, input)
+	n := len(m.inputs)
+	return &sts.AssumeRoleWithWebIdentityOutput{
+		Credentials: &ststypes.Credentials{
+			AccessKeyId:     aws.String(fmt.Sprintf("key-%d", n)),
+			SecretAccessKey: aws.String(fmt.Sprintf("secret-%d", n)),
+			SessionToken:    aws.String(fmt.Sprintf("session-%d", n)),
+			Expiration:      aws.Time(time.Now().Add(m.ttl)),
+		},
+	}, nil
+}
+
+func testAWSIdentityHook(t *testing.T, client *mockSTS) (*awsIdentityHook, *trtesting.MockTaskHooks, *interfaces.TaskPrestartRequest) {
+	alloc := mock.Alloc()
+	task := alloc.LookupTask("web")
+	task.Identities = []*structs.WorkloadIdentity{
+		{
+			Name:     "s3",
+			Audience: []string{"sts.amazonaws.com"},
+			AWS: &structs.WorkloadIdentityAWS{
+				RoleARN:  "arn:aws:iam::123456789012:role/s3",
+				Region:   "eu-west-1",
+				Profile:  "default",
+				Duration: time.Hour,
+			},
+		},
+		{
+			Name:         "dynamo",
+			Audience:     []string{"sts.amazonaws.com"},
+			ChangeMode:   structs.WIChangeModeSignal,
+			ChangeSignal: "SIGHUP",
+			AWS: &structs.WorkloadIdentityAWS{
+				RoleARN: "arn:aws:iam::123456789012:role/dynamo",
+				Profile: "dynamo",
+			},
+		},
+		{
+			Name:     "consul",
+			Audience: []string{"consul.io"},
+		},
+	}
+
+	wids := widmgr.NewMockIdentityManager()
+	for _, wid := range task.Identities {
+		wids.(*widmgr.MockIdentityManager).SetIdentity(
+			structs.WIHandle{WorkloadIdentifier: task.Name, IdentityName: wid.Name},
+			&structs.SignedWorkloadIdentity{JWT: "jwt-" + wid.Name},
+		)
+	}
+
+	lifecycle := trtesting.NewMockTaskHooks()
+	h := newAWSIdentityHook(&awsIdentityHookConfig{
+		alloc:     alloc,
+		task:      task,
+		widmgr:    wids,
+		lifecycle: lifecycle,
+		events:    lifecycle,
+		logger:    testlog.HCLogger(t),
+	})
+	h.newClient = func(string) stsClient { return client }
+	h.minWait = 10 * time.Millisecond
+	t.Cleanup(h.Shutdown)
+
+	req := &interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: &allocdir.TaskDir{SecretsDir: t.TempDir()},
+		TaskEnv: taskenv.NewEmptyBuilder().SetSecretsDir("/secrets").Build(),
+	}
+	return h, lifecycle, req
+}
+
+func TestAWSIdentityHook_Prestart(t *testing.T) {
+	ci.Parallel(t)
+
+	client := &mockSTS{ttl: time.Hour}
+	h, _, req := testAWSIdentityHook(t, client)
+	must.Len(t, 2, h.identities)
+
+	resp := &interfaces.TaskPrestartResponse{}
+	must.NoError(t, h.Prestart(context.Background(), req, resp))
+	must.Eq(t, map[string]string{awsCredentialsFileEnv: "/secrets/aws_credentials"}, resp.Env)
+
+	must.Eq(t, 2, client.calls())
+	first := client.inputs[0]
+	must.Eq(t, "arn:aws:iam::123456789012:role/s3", aws.ToString(first.RoleArn))
+	must.Eq(t, "jwt-s3", aws.ToString(first.WebIdentityToken))
+	must.Eq(t, 3600, aws.ToInt32(first.DurationSeconds))
+	must.Eq(t, fmt.Sprintf("nomad-%s-web", h.alloc.ID[:8]), aws.ToString(first.RoleSessionName))
+	must.Nil(t, client.inputs[1].DurationSeconds)
+
+	creds := testutil.MustReadFile(t, req.TaskDir.SecretsDir, awsCredentialsFile)
+	must.Eq(t, `[default]
+aws_access_key_id = key-1
+aws_secret_access_key = secret-1
+aws_session_token = session-1
+
+[dynamo]
+aws_access_key_id = key-2
+aws_secret_access_key = secret-2
+aws_session_token = session-2
+
+`, string(creds))
+
+	// restarting the task does not exchange the identities again
+	resp = &interfaces.TaskPrestartResponse{}
+	must.NoError(t, h.Prestart(context.Background(), req, resp))
+	must.MapContainsKey(t, resp.Env, awsCredentialsFileEnv)
+	must.Eq(t, 2, client.calls())
+}
+
+func TestAWSIdentityHook_PrestartError(t *testing.T) {
+	ci.Parallel(t)
+
+	client := &mockSTS{ttl: time.Hour}
+	client.setErr(errors.New("AccessDenied"))
+	h, _, req := testAWSIdentityHook(t, client)
+
+	err := h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{})
+	must.ErrorContains(t, err, `failed to get AWS credentials for identity "s3": AccessDenied`)
+	must.True(t, structs.IsRecoverable(err))
+	must.FileNotExists(t, filepath.Join(req.TaskDir.SecretsDir, awsCredentialsFile))
+}
+
+func TestAWSIdentityHook_Refresh(t *testing.T) {
+	ci.Parallel(t)
+
+	// credentials expire quickly so they are refreshed right away
+	client := &mockSTS{ttl: 20 * time.Millisecond}
+	h, lifecycle, req := testAWSIdentityHook(t, client)
+	must.NoError(t, h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{}))
+	orig := testutil.MustReadFile(t, req.TaskDir.SecretsDir, awsCredentialsFile)
+
+	// the change_mode of the dynamo identity is applied
+	select {
+	case <-lifecycle.SignalCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for signal")
+	}
+	must.Eq(t, []string{"SIGHUP"}, lifecycle.Signals()[:1])
+	must.NotEq(t, orig, testutil.MustReadFile(t, req.TaskDir.SecretsDir, awsCredentialsFile))
+
+	// refresh errors are reported
+	client.setErr(errors.New("throttled"))
+	select {
+	case ev := <-lifecycle.EmitEventCh:
+		must.StrContains(t, ev.DisplayMessage, "failed to refresh AWS credentials: throttled")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	// credentials are no longer refreshed once the task stops
+	must.NoError(t, h.Stop(context.Background(), nil, nil))
+	client.setErr(nil)
+	calls := client.calls()
+	time.Sleep(100 * time.Millisecond)
+	must.Eq(t, calls, client.calls())
+	must.Zero(t, lifecycle.Restarts())
+	must.Nil(t, lifecycle.KillEvent())
+}
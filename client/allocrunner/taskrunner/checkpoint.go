@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// checkpointDirName is the directory of the local dir of a task its process
+// state is checkpointed to when its allocation is migrated. The local dir is
+// carried over to the replacement allocation by ephemeral disk migrations,
+// which restores the task from the checkpoint.
+const checkpointDirName = ".nomad-checkpoint"
+
+// checkpointDir returns the directory the task is checkpointed to.
+func (tr *TaskRunner) checkpointDir() string {
+	return filepath.Join(tr.taskDir.LocalDir, checkpointDirName)
+}
+
+// checkpointDriver returns the driver of the task if it can checkpoint tasks.
+func (tr *TaskRunner) checkpointDriver() (drivers.CheckpointDriver, bool) {
+	if tr.driverCapabilities == nil || !tr.driverCapabilities.Checkpoint {
+		return nil, false
+	}
+	cd, ok := tr.driver.(drivers.CheckpointDriver)
+	return cd, ok
+}
+
+// shouldCheckpoint returns whether the task should be checkpointed instead of
+// killed, which is the case when its allocation is migrated along with its
+// ephemeral disk to a driver that can checkpoint tasks.
+func (tr *TaskRunner) shouldCheckpoint(alloc *structs.Allocation) bool {
+	if !alloc.DesiredTransition.ShouldMigrate() {
+		return false
+	}
+
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.EphemeralDisk == nil || !tg.EphemeralDisk.Migrate {
+		return false
+	}
+
+	_, ok := tr.checkpointDriver()
+	return ok
+}
+
+// checkpointTask checkpoints the task, which exits once checkpointed. It
+// returns false if the task couldn't be checkpointed and must be killed.
+func (tr *TaskRunner) checkpointTask(handle *DriverHandle) bool {
+	cd, ok := tr.checkpointDriver()
+	if !ok {
+		return false
+	}
+
+	dir := tr.checkpointDir()
+	err := os.MkdirAll(dir, 0o700)
+	if err == nil {
+		err = cd.CheckpointTask(handle.ID(), &drivers.CheckpointOptions{Dir: dir})
+	}
+	if err != nil {
+		tr.logger.Warn("failed to checkpoint task, killing it", "error", err)
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+			SetDriverMessage(fmt.Sprintf("Failed to checkpoint task for migration: %v", err)))
+		os.RemoveAll(dir)
+		return false
+	}
+
+	tr.logger.Info("checkpointed task for migration", "dir", dir)
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+		SetDriverMessage("Task checkpointed for migration"))
+	return true
+}
+
+// restoreTask starts the task from the checkpoint carried over from the
+// previous allocation, if there is one. It returns false if the task has to
+// be started by its driver instead. The checkpoint is removed once used, so
+// restarts of the task start it anew.
+func (tr *TaskRunner) restoreTask(taskConfig *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, bool) {
+	dir := tr.checkpointDir()
+	if _, err := os.Stat(dir); err != nil {
+		return nil, nil, false
+	}
+	defer os.RemoveAll(dir)
+
+	cd, ok := tr.checkpointDriver()
+	if !ok {
+		tr.logger.Warn("driver can't restore task from checkpoint, starting it")
+		return nil, nil, false
+	}
+
+	handle, net, err := cd.RestoreTask(taskConfig, dir)
+	if err != nil {
+		tr.logger.Warn("failed to restore task from checkpoint, starting it", "error", err)
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+			SetDriverMessage(fmt.Sprintf("Failed to restore task from checkpoint: %v", err)))
+		return nil, nil, false
+	}
+
+	tr.logger.Info("restored task from checkpoint", "dir", dir)
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+		SetDriverMessage("Task restored from checkpoint"))
+	return handle, net, true
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+// fakeCheckpointDriver is a driver which records checkpoints and restores of
+// tasks.
+type fakeCheckpointDriver struct {
+	drivers.DriverPlugin
+
+	checkpointed []string
+	restored     []string
+}
+
+func (d *fakeCheckpointDriver) CheckpointTask(taskID string, opts *drivers.CheckpointOptions) error {
+	d.checkpointed = append(d.checkpointed, opts.Dir)
+	return nil
+}
+
+func (d *fakeCheckpointDriver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	d.restored = append(d.restored, dir)
+	return drivers.NewTaskHandle(0), nil, nil
+}
+
+func TestTaskRunner_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	t.Cleanup(cleanup)
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+
+	driver := &fakeCheckpointDriver{DriverPlugin: tr.driver}
+	tr.driver = driver
+
+	// tasks are killed unless the allocation is migrated with its ephemeral
+	// disk by a driver which can checkpoint tasks
+	must.False(t, tr.shouldCheckpoint(alloc))
+
+	alloc.DesiredTransition.Migrate = pointer.Of(true)
+	must.False(t, tr.shouldCheckpoint(alloc))
+
+	alloc.Job.TaskGroups[0].EphemeralDisk = &structs.EphemeralDisk{Migrate: true}
+	must.False(t, tr.shouldCheckpoint(alloc))
+
+	tr.driverCapabilities = &drivers.Capabilities{Checkpoint: true}
+	must.True(t, tr.shouldCheckpoint(alloc))
+
+	// tasks are checkpointed to their local dir
+	handle := NewDriverHandle(driver, "task-id", task, conf.ClientConfig.MaxKillTimeout, nil)
+	must.True(t, tr.checkpointTask(handle))
+	must.Eq(t, []string{tr.checkpointDir()}, driver.checkpointed)
+	must.DirExists(t, tr.checkpointDir())
+
+	// tasks are restored from the checkpoint once
+	_, _, ok := tr.restoreTask(&drivers.TaskConfig{ID: "task-id"})
+	must.True(t, ok)
+	must.Eq(t, []string{tr.checkpointDir()}, driver.restored)
+
+	_, err = os.Stat(tr.checkpointDir())
+	must.True(t, os.IsNotExist(err))
+
+	_, _, ok = tr.restoreTask(&drivers.TaskConfig{ID: "task-id"})
+	must.False(t, ok)
+	must.Len(t, 1, driver.restored)
+}
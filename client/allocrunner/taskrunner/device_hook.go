@@ -93,5 +93,6 @@ func convertDevice(in *device.DeviceSpec) *drivers.DeviceConfig {
 		TaskPath:    in.TaskPath,
 		HostPath:    in.HostPath,
 		Permissions: in.CgroupPerms,
+		CDIName:     in.CDIName,
 	}
 }
@@ -66,6 +66,9 @@ func TestDeviceHook_CorrectDevice(t *testing.T) {
 					HostPath:    "bar",
 					CgroupPerms: "123",
 				},
+				{
+					CDIName: "nvidia.com/gpu=0",
+				},
 			},
 		}
 		return res, nil
@@ -96,6 +99,9 @@ func TestDeviceHook_CorrectDevice(t *testing.T) {
 			HostPath:    "bar",
 			Permissions: "123",
 		},
+		{
+			CDIName: "nvidia.com/gpu=0",
+		},
 	}
 	require.EqualValues(expDevices, resp.Devices)
 }
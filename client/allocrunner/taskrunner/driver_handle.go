@@ -52,6 +52,12 @@ func (h *DriverHandle) SetKillSignal(signal string) {
 	h.killSignal = signal
 }
 
+// LimitKillTimeout allows lowering the time given to the task to exit after
+// the kill signal before it is forcefully killed.
+func (h *DriverHandle) LimitKillTimeout(timeout time.Duration) {
+	h.killTimeout = min(h.killTimeout, timeout)
+}
+
 func (h *DriverHandle) Kill() error {
 	return h.driver.StopTask(h.taskID, h.killTimeout, h.killSignal)
 }
@@ -60,6 +66,13 @@ func (h *DriverHandle) Stats(ctx context.Context, interval time.Duration) (<-cha
 	return h.driver.TaskStats(ctx, h.taskID, interval)
 }
 
+func (h *DriverHandle) Inspect() (*drivers.TaskStatus, error) {
+	if h == nil {
+		return nil, ErrTaskNotRunning
+	}
+	return h.driver.InspectTask(h.taskID)
+}
+
 func (h *DriverHandle) Signal(s string) error {
 	return h.driver.SignalTask(h.taskID, s)
 }
@@ -120,6 +133,16 @@ func (h *DriverHandle) ExecStreaming(ctx context.Context,
 	return stream.Send(drivers.NewExecStreamingResponseExit(result.ExitCode))
 }
 
+// UpdateResources applies new resources to the running task, if the task
+// driver supports updating them in place.
+func (h *DriverHandle) UpdateResources(resources *drivers.Resources) error {
+	d, ok := h.driver.(drivers.ResourceUpdateDriver)
+	if !ok {
+		return fmt.Errorf("task driver does not support updating resources")
+	}
+	return d.UpdateTaskResources(h.taskID, resources)
+}
+
 func (h *DriverHandle) Network() *drivers.DriverNetwork {
 	return h.net
 }
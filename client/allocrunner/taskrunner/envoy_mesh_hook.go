@@ -0,0 +1,660 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	ifs "github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/envoy"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const envoyMeshHookName = "envoy_mesh"
+
+const (
+	// envoyMeshCertFile and envoyMeshCAFile are the SDS resources of the leaf
+	// certificate and the CA bundle of the sidecar. Envoy watches the files
+	// and reloads the secrets whenever they are replaced.
+	envoyMeshCertFile = "envoy_mesh_cert.json"
+	envoyMeshCAFile   = "envoy_mesh_ca.json"
+
+	envoyMeshCertSecret = "mesh_cert"
+	envoyMeshCASecret   = "mesh_ca"
+
+	// envoyMeshLocalCluster is the Envoy cluster of the service the sidecar
+	// proxies for.
+	envoyMeshLocalCluster = "local_app"
+
+	// envoyMeshConnectTimeout is the connect timeout of every Envoy cluster.
+	envoyMeshConnectTimeout = "5s"
+
+	// envoyMeshRetryBase and envoyMeshRetryLimit bound the backoff between
+	// failed attempts to renew the certificate or to watch an upstream.
+	envoyMeshRetryBase  = 5 * time.Second
+	envoyMeshRetryLimit = 1 * time.Minute
+
+	// envoyMeshWatchWait is the maximum time of the blocking queries for the
+	// registrations of upstream sidecars.
+	envoyMeshWatchWait = 5 * time.Minute
+)
+
+// usesNomadMesh returns true if the task is the Connect sidecar proxy of a
+// service using the Nomad provider, which Nomad bootstraps without Consul.
+func usesNomadMesh(tg *structs.TaskGroup, task *structs.Task) bool {
+	if tg == nil || !task.Kind.IsConnectProxy() {
+		return false
+	}
+	for _, service := range tg.Services {
+		if service.Name == task.Kind.Value() {
+			return service.Provider == structs.ServiceProviderNomad
+		}
+	}
+	return false
+}
+
+// envoyMeshHook bootstraps the Envoy sidecar proxy of a Nomad service. The
+// sidecar is configured entirely from files in the secrets directory: the
+// bootstrap configuration with static listeners, the leaf certificate signed
+// by the servers, and the endpoints of each upstream, which the hook keeps up
+// to date for as long as the task runs.
+type envoyMeshHook struct {
+	alloc      *structs.Allocation
+	rpc        config.RPCer
+	nomadToken func() string
+	logger     hclog.Logger
+
+	// cancel stops the goroutines renewing the certificate and watching the
+	// upstreams of the sidecar
+	cancel     context.CancelFunc
+	cancelLock sync.Mutex
+}
+
+func newEnvoyMeshHook(alloc *structs.Allocation, rpc config.RPCer, nomadToken func() string, logger hclog.Logger) *envoyMeshHook {
+	return &envoyMeshHook{
+		alloc:      alloc,
+		rpc:        rpc,
+		nomadToken: nomadToken,
+		logger:     logger.Named(envoyMeshHookName),
+		cancel:     func() {},
+	}
+}
+
+func (*envoyMeshHook) Name() string {
+	return envoyMeshHookName
+}
+
+// Prestart writes the Envoy bootstrap configuration, the certificate, and the
+// upstream endpoints to the secrets directory, and starts keeping the
+// certificate and endpoints up to date.
+func (h *envoyMeshHook) Prestart(ctx context.Context, req *ifs.TaskPrestartRequest, resp *ifs.TaskPrestartResponse) error {
+	if !req.Task.Kind.IsConnectProxy() {
+		return nil
+	}
+
+	// stop the goroutines of a previous run of the task
+	h.stop()
+
+	serviceName := req.Task.Kind.Value()
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+
+	var service *structs.Service
+	for _, s := range taskenv.InterpolateServices(req.TaskEnv, tg.Services) {
+		if s.Name == serviceName {
+			service = s
+			break
+		}
+	}
+	if service == nil || !service.Connect.HasSidecar() {
+		return fmt.Errorf("no service %q with a Connect sidecar for task %q", serviceName, req.Task.Name)
+	}
+
+	h.logger.Debug("bootstrapping Nomad mesh sidecar", "task", req.Task.Name, "service", serviceName)
+
+	adminBind := buildEnvoyAdminBind(h.alloc, serviceName, req.Task.Name, req.TaskEnv)
+	resp.Env = map[string]string{
+		helper.CleanEnvVar(envoyAdminBindEnvPrefix+serviceName, '_'): adminBind,
+	}
+
+	bootstrap, err := h.newBootstrap(tg, service, adminBind, req.TaskEnv)
+	if err != nil {
+		return err
+	}
+	bootstrapJSON, err := json.MarshalIndent(bootstrap.config(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	secretsDir := req.TaskDir.SecretsDir
+
+	// Failing to reach the servers is recoverable, so the task is restarted
+	// rather than failed.
+	renewAt, err := h.renewCertificate(secretsDir)
+	if err != nil {
+		return structs.NewRecoverableError(
+			fmt.Errorf("failed to sign certificate of sidecar: %w", err), true)
+	}
+
+	upstreamIndexes := make(map[string]uint64, len(bootstrap.upstreams))
+	for _, upstream := range bootstrap.upstreams {
+		if _, ok := upstreamIndexes[upstream.DestinationName]; ok {
+			continue
+		}
+		index, err := h.updateUpstream(secretsDir, upstream.DestinationName, 0)
+		if err != nil {
+			return structs.NewRecoverableError(
+				fmt.Errorf("failed to look up upstream %q: %w", upstream.DestinationName, err), true)
+		}
+		upstreamIndexes[upstream.DestinationName] = index
+	}
+
+	if err := writeFileAtomic(filepath.Join(secretsDir, "envoy_bootstrap.json"), bootstrapJSON); err != nil {
+		return fmt.Errorf("failed to write envoy bootstrap config: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	h.cancelLock.Lock()
+	h.cancel = cancel
+	h.cancelLock.Unlock()
+
+	go h.renewLoop(runCtx, secretsDir, renewAt)
+	for name, index := range upstreamIndexes {
+		go h.watchUpstream(runCtx, secretsDir, name, index)
+	}
+
+	return nil
+}
+
+// Stop stops renewing the certificate and watching the upstreams.
+func (h *envoyMeshHook) Stop(context.Context, *ifs.TaskStopRequest, *ifs.TaskStopResponse) error {
+	h.stop()
+	return nil
+}
+
+func (h *envoyMeshHook) stop() {
+	h.cancelLock.Lock()
+	defer h.cancelLock.Unlock()
+	h.cancel()
+}
+
+// newBootstrap resolves the ports and addresses of the sidecar listeners.
+func (h *envoyMeshHook) newBootstrap(tg *structs.TaskGroup, service *structs.Service, adminBind string, env *taskenv.TaskEnv) (*envoyMeshBootstrap, error) {
+	sidecar := service.Connect.SidecarService
+
+	publicLabel := sidecar.Port
+	if publicLabel == "" {
+		publicLabel = envoy.PortLabel(structs.ConnectProxyPrefix, service.Name, "")
+	}
+	publicPort, ok := h.portTo(publicLabel)
+	if !ok {
+		return nil, fmt.Errorf("no port %q for sidecar of service %q", publicLabel, service.Name)
+	}
+
+	localAddr, localPort := "127.0.0.1", 0
+	if sidecar.Proxy != nil {
+		if sidecar.Proxy.LocalServiceAddress != "" {
+			localAddr = sidecar.Proxy.LocalServiceAddress
+		}
+		localPort = sidecar.Proxy.LocalServicePort
+	}
+	if localPort == 0 {
+		if port, ok := h.portTo(service.PortLabel); ok {
+			localPort = port
+		} else if port, err := strconv.Atoi(service.PortLabel); err == nil {
+			localPort = port
+		} else {
+			return nil, fmt.Errorf("no local port for service %q", service.Name)
+		}
+	}
+
+	adminHost, adminPort, err := net.SplitHostPort(adminBind)
+	if err != nil {
+		return nil, err
+	}
+	adminPortValue, err := strconv.Atoi(adminPort)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &envoyMeshBootstrap{
+		proxyID:    serviceregistration.MakeAllocServiceID(h.alloc.ID, "group-"+tg.Name, service) + structs.MeshSidecarServiceSuffix,
+		service:    service.Name,
+		namespace:  h.alloc.Namespace,
+		adminHost:  adminHost,
+		adminPort:  adminPortValue,
+		secretsDir: env.EnvMap[taskenv.SecretsDir],
+		publicPort: publicPort,
+		localAddr:  localAddr,
+		localPort:  localPort,
+	}
+	if sidecar.Proxy != nil {
+		b.upstreams = sidecar.Proxy.Upstreams
+	}
+	return b, nil
+}
+
+// portTo returns the port a listener inside the network namespace of the
+// allocation binds to for the port with the given label.
+func (h *envoyMeshHook) portTo(label string) (int, bool) {
+	if h.alloc.AllocatedResources == nil {
+		return 0, false
+	}
+	port, ok := h.alloc.AllocatedResources.Shared.Ports.Get(label)
+	if !ok {
+		return 0, false
+	}
+	if port.To > 0 {
+		return port.To, true
+	}
+	return port.Value, true
+}
+
+// renewCertificate requests a new leaf certificate for a new private key and
+// writes both along with the CA bundle to the secrets directory. It returns
+// when the certificate should be renewed.
+func (h *envoyMeshHook) renewCertificate(secretsDir string) (time.Time, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return time.Time{}, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	args := &structs.MeshCertificateRequest{
+		CSR: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}),
+		QueryOptions: structs.QueryOptions{
+			Region:     h.alloc.Job.Region,
+			Namespace:  h.alloc.Namespace,
+			AuthToken:  h.nomadToken(),
+			AllowStale: true,
+		},
+	}
+	var reply structs.MeshCertificateResponse
+	if err := h.rpc.RPC(structs.MeshSignCertificateRPCMethod, args, &reply); err != nil {
+		return time.Time{}, err
+	}
+
+	block, _ := pem.Decode(reply.Certificate)
+	if block == nil {
+		return time.Time{}, errors.New("invalid certificate in response")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	certSecret := envoySDSSecret(envoyMeshCertSecret, "tls_certificate", map[string]any{
+		"certificate_chain": map[string]any{"inline_string": string(reply.Certificate)},
+		"private_key": map[string]any{"inline_string": string(
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))},
+	})
+	caSecret := envoySDSSecret(envoyMeshCASecret, "validation_context", map[string]any{
+		"trusted_ca": map[string]any{"inline_string": string(reply.CABundle)},
+	})
+
+	// Write the CA bundle first, so that a sidecar never has a certificate
+	// signed by a CA it doesn't trust yet.
+	if err := writeJSONFileAtomic(filepath.Join(secretsDir, envoyMeshCAFile), caSecret); err != nil {
+		return time.Time{}, err
+	}
+	if err := writeJSONFileAtomic(filepath.Join(secretsDir, envoyMeshCertFile), certSecret); err != nil {
+		return time.Time{}, err
+	}
+
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotBefore.Add(lifetime * 2 / 3), nil
+}
+
+// renewLoop renews the leaf certificate before it expires until the context
+// is canceled.
+func (h *envoyMeshHook) renewLoop(ctx context.Context, secretsDir string, renewAt time.Time) {
+	timer, stop := helper.NewSafeTimer(time.Until(renewAt))
+	defer stop()
+
+	var attempt uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		next, err := h.renewCertificate(secretsDir)
+		if err != nil {
+			h.logger.Warn("failed to renew certificate of sidecar", "error", err)
+			timer.Reset(helper.Backoff(envoyMeshRetryBase, envoyMeshRetryLimit, attempt))
+			attempt++
+			continue
+		}
+		attempt = 0
+		timer.Reset(time.Until(next))
+	}
+}
+
+// updateUpstream queries the registrations of the sidecars of an upstream
+// service once the index of the registrations is past the given index, and
+// writes their endpoints to the EDS file of the upstream. It returns the index
+// of the registrations.
+func (h *envoyMeshHook) updateUpstream(secretsDir, name string, index uint64) (uint64, error) {
+	args := &structs.ServiceRegistrationByNameRequest{
+		ServiceName: structs.MeshSidecarServiceName(name),
+		QueryOptions: structs.QueryOptions{
+			Region:        h.alloc.Job.Region,
+			Namespace:     h.alloc.Namespace,
+			AuthToken:     h.nomadToken(),
+			AllowStale:    true,
+			MinQueryIndex: index,
+			MaxQueryTime:  envoyMeshWatchWait,
+		},
+	}
+	var reply structs.ServiceRegistrationByNameResponse
+	if err := h.rpc.RPC(structs.ServiceRegistrationGetServiceRPCMethod, args, &reply); err != nil {
+		return index, err
+	}
+	if index > 0 && reply.Index <= index {
+		return index, nil
+	}
+
+	lbEndpoints := make([]any, 0, len(reply.Services))
+	for _, registration := range reply.Services {
+		lbEndpoint := map[string]any{
+			"endpoint": map[string]any{
+				"address": envoySocketAddress(registration.Address, registration.Port),
+			},
+		}
+		// weight the sidecars of canaries by their canary weights
+		if registration.Weight > 0 {
+			lbEndpoint["load_balancing_weight"] = registration.Weight
+		}
+		lbEndpoints = append(lbEndpoints, lbEndpoint)
+	}
+	assignment := map[string]any{
+		"version_info": strconv.FormatUint(reply.Index, 10),
+		"resources": []any{map[string]any{
+			"@type":        "type.googleapis.com/envoy.config.endpoint.v3.ClusterLoadAssignment",
+			"cluster_name": name,
+			"endpoints":    []any{map[string]any{"lb_endpoints": lbEndpoints}},
+		}},
+	}
+	if err := writeJSONFileAtomic(filepath.Join(secretsDir, envoyMeshUpstreamFile(name)), assignment); err != nil {
+		return index, err
+	}
+	return reply.Index, nil
+}
+
+// watchUpstream keeps the EDS file of an upstream up to date until the
+// context is canceled.
+func (h *envoyMeshHook) watchUpstream(ctx context.Context, secretsDir, name string, index uint64) {
+	var attempt uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		next, err := h.updateUpstream(secretsDir, name, index)
+		if err != nil {
+			h.logger.Warn("failed to watch upstream of sidecar", "upstream", name, "error", err)
+			timer, stop := helper.NewSafeTimer(helper.Backoff(envoyMeshRetryBase, envoyMeshRetryLimit, attempt))
+			select {
+			case <-ctx.Done():
+			case <-timer.C:
+			}
+			stop()
+			attempt++
+			continue
+		}
+		attempt = 0
+		index = next
+	}
+}
+
+// envoyMeshUpstreamFile is the name of the EDS file of an upstream.
+func envoyMeshUpstreamFile(name string) string {
+	return "envoy_upstream_" + name + ".json"
+}
+
+// envoyMeshBootstrap is the static configuration of a Nomad mesh sidecar.
+type envoyMeshBootstrap struct {
+	proxyID   string
+	service   string
+	namespace string
+
+	adminHost string
+	adminPort int
+
+	// secretsDir is the secrets directory as seen by Envoy, where it reads
+	// the SDS and EDS files from
+	secretsDir string
+
+	publicPort int
+	localAddr  string
+	localPort  int
+
+	upstreams []structs.ConsulUpstream
+}
+
+// config returns the Envoy v3 bootstrap configuration.
+func (b *envoyMeshBootstrap) config() map[string]any {
+	listeners := []any{b.publicListener()}
+	clusters := []any{b.localCluster()}
+
+	seen := make(map[string]struct{}, len(b.upstreams))
+	for _, upstream := range b.upstreams {
+		listeners = append(listeners, b.upstreamListener(upstream))
+		if _, ok := seen[upstream.DestinationName]; ok {
+			continue
+		}
+		seen[upstream.DestinationName] = struct{}{}
+		clusters = append(clusters, b.upstreamCluster(upstream.DestinationName))
+	}
+
+	return map[string]any{
+		"node": map[string]any{
+			"id":      b.proxyID,
+			"cluster": b.service,
+		},
+		"admin": map[string]any{
+			"address": envoySocketAddress(b.adminHost, b.adminPort),
+		},
+		"static_resources": map[string]any{
+			"listeners": listeners,
+			"clusters":  clusters,
+		},
+	}
+}
+
+// publicListener accepts mTLS connections from the sidecars of downstream
+// services and proxies them to the local service. Any certificate signed by
+// the mesh CA is accepted.
+func (b *envoyMeshBootstrap) publicListener() map[string]any {
+	return map[string]any{
+		"name":    "public_listener",
+		"address": envoySocketAddress("0.0.0.0", b.publicPort),
+		"filter_chains": []any{map[string]any{
+			"filters": []any{envoyTCPProxy("public_listener", envoyMeshLocalCluster)},
+			"transport_socket": envoyTLSTransportSocket("DownstreamTlsContext", map[string]any{
+				"require_client_certificate": true,
+				"common_tls_context":         b.commonTLSContext("prefix", "spiffe://"+structs.MeshTrustDomain+"/"),
+			}),
+		}},
+	}
+}
+
+func (b *envoyMeshBootstrap) localCluster() map[string]any {
+	return map[string]any{
+		"name":            envoyMeshLocalCluster,
+		"type":            "STATIC",
+		"connect_timeout": envoyMeshConnectTimeout,
+		"load_assignment": map[string]any{
+			"cluster_name": envoyMeshLocalCluster,
+			"endpoints": []any{map[string]any{
+				"lb_endpoints": []any{map[string]any{
+					"endpoint": map[string]any{
+						"address": envoySocketAddress(b.localAddr, b.localPort),
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func (b *envoyMeshBootstrap) upstreamListener(upstream structs.ConsulUpstream) map[string]any {
+	bindAddr := upstream.LocalBindAddress
+	if bindAddr == "" {
+		bindAddr = "127.0.0.1"
+	}
+	name := fmt.Sprintf("upstream_%s_%d", upstream.DestinationName, upstream.LocalBindPort)
+	return map[string]any{
+		"name":    name,
+		"address": envoySocketAddress(bindAddr, upstream.LocalBindPort),
+		"filter_chains": []any{map[string]any{
+			"filters": []any{envoyTCPProxy(name, upstream.DestinationName)},
+		}},
+	}
+}
+
+// upstreamCluster connects to the sidecars of an upstream service with mTLS,
+// and only accepts certificates that identify the upstream service.
+func (b *envoyMeshBootstrap) upstreamCluster(name string) map[string]any {
+	spiffeID := structs.MeshSpiffeID(b.namespace, name).String()
+	return map[string]any{
+		"name":            name,
+		"type":            "EDS",
+		"connect_timeout": envoyMeshConnectTimeout,
+		"eds_cluster_config": map[string]any{
+			"eds_config": envoyPathConfigSource(filepath.Join(b.secretsDir, envoyMeshUpstreamFile(name))),
+		},
+		"transport_socket": envoyTLSTransportSocket("UpstreamTlsContext", map[string]any{
+			"common_tls_context": b.commonTLSContext("exact", spiffeID),
+		}),
+	}
+}
+
+// commonTLSContext presents the leaf certificate of the sidecar and verifies
+// the URI SAN of the peer with the given string matcher.
+func (b *envoyMeshBootstrap) commonTLSContext(matcher, value string) map[string]any {
+	return map[string]any{
+		"tls_certificate_sds_secret_configs": []any{map[string]any{
+			"name":       envoyMeshCertSecret,
+			"sds_config": envoyPathConfigSource(filepath.Join(b.secretsDir, envoyMeshCertFile)),
+		}},
+		"combined_validation_context": map[string]any{
+			"default_validation_context": map[string]any{
+				"match_typed_subject_alt_names": []any{map[string]any{
+					"san_type": "URI",
+					"matcher":  map[string]any{matcher: value},
+				}},
+			},
+			"validation_context_sds_secret_config": map[string]any{
+				"name":       envoyMeshCASecret,
+				"sds_config": envoyPathConfigSource(filepath.Join(b.secretsDir, envoyMeshCAFile)),
+			},
+		},
+	}
+}
+
+func envoySocketAddress(address string, port int) map[string]any {
+	return map[string]any{
+		"socket_address": map[string]any{
+			"address":    address,
+			"port_value": port,
+		},
+	}
+}
+
+func envoyTCPProxy(statPrefix, cluster string) map[string]any {
+	return map[string]any{
+		"name": "envoy.filters.network.tcp_proxy",
+		"typed_config": map[string]any{
+			"@type":       "type.googleapis.com/envoy.extensions.filters.network.tcp_proxy.v3.TcpProxy",
+			"stat_prefix": statPrefix,
+			"cluster":     cluster,
+		},
+	}
+}
+
+func envoyTLSTransportSocket(contextType string, context map[string]any) map[string]any {
+	context["@type"] = "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3." + contextType
+	return map[string]any{
+		"name":         "envoy.transport_sockets.tls",
+		"typed_config": context,
+	}
+}
+
+func envoyPathConfigSource(path string) map[string]any {
+	return map[string]any{
+		"path_config_source":   map[string]any{"path": path},
+		"resource_api_version": "V3",
+	}
+}
+
+// envoySDSSecret returns the contents of an SDS file with a single secret.
+func envoySDSSecret(name, kind string, secret map[string]any) map[string]any {
+	return map[string]any{
+		"resources": []any{map[string]any{
+			"@type": "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",
+			"name":  name,
+			kind:    secret,
+		}},
+	}
+}
+
+func writeJSONFileAtomic(path string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b)
+}
+
+// writeFileAtomic replaces the file by renaming a temporary file over it, so
+// that Envoy, which watches the files with inotify, never reads a partially
+// written file.
+func writeFileAtomic(path string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
+	"github.com/shoenig/test/must"
+)
+
+// mockMeshRPC signs sidecar certificates with a throwaway CA and serves a
+// fixed set of upstream registrations.
+type mockMeshRPC struct {
+	t        *testing.T
+	ca       *x509.Certificate
+	caKey    *ecdsa.PrivateKey
+	services map[string][]*structs.ServiceRegistration
+}
+
+func newMockMeshRPC(t *testing.T) *mockMeshRPC {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	must.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	must.NoError(t, err)
+	return &mockMeshRPC{t: t, ca: ca, caKey: key, services: map[string][]*structs.ServiceRegistration{}}
+}
+
+func (m *mockMeshRPC) RPC(method string, args, reply any) error {
+	switch method {
+	case structs.MeshSignCertificateRPCMethod:
+		req := args.(*structs.MeshCertificateRequest)
+		block, _ := pem.Decode(req.CSR)
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		must.NoError(m.t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			NotBefore:    time.Now().Add(-time.Minute),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, m.ca, csr.PublicKey, m.caKey)
+		must.NoError(m.t, err)
+		resp := reply.(*structs.MeshCertificateResponse)
+		resp.Certificate = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		resp.CABundle = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: m.ca.Raw})
+		return nil
+	case structs.ServiceRegistrationGetServiceRPCMethod:
+		req := args.(*structs.ServiceRegistrationByNameRequest)
+		if req.MinQueryIndex > 0 {
+			// there are no changes to block on
+			time.Sleep(10 * time.Millisecond)
+			return errors.New("no changes")
+		}
+		resp := reply.(*structs.ServiceRegistrationByNameResponse)
+		resp.Services = m.services[req.ServiceName]
+		resp.Index = 10
+		return nil
+	}
+	return errors.New("unexpected method " + method)
+}
+
+func TestEnvoyMeshHook_usesNomadMesh(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.ConnectAlloc()
+	tg := alloc.Job.TaskGroups[0]
+	sidecar := &structs.Task{
+		Name: "sidecar",
+		Kind: structs.NewTaskKind(structs.ConnectProxyPrefix, "testconnect"),
+	}
+
+	must.False(t, usesNomadMesh(tg, sidecar))
+	must.False(t, usesNomadMesh(tg, tg.Tasks[0]))
+
+	tg.Services[0].Provider = structs.ServiceProviderNomad
+	must.True(t, usesNomadMesh(tg, sidecar))
+	must.False(t, usesNomadMesh(tg, tg.Tasks[0]))
+}
+
+func TestEnvoyMeshHook_Prestart(t *testing.T) {
+	ci.Parallel(t)
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.ConnectAlloc()
+	alloc.AllocatedResources.Shared.Networks[0].Mode = "bridge"
+	alloc.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "connect-proxy-api", Value: 25000, To: 21000},
+		{Label: "http", Value: 25001, To: 8080},
+	}
+	tg := alloc.Job.TaskGroups[0]
+	tg.Services = []*structs.Service{{
+		Name:      "api",
+		PortLabel: "http",
+		Provider:  structs.ServiceProviderNomad,
+		Connect: &structs.ConsulConnect{
+			SidecarService: &structs.ConsulSidecarService{
+				Proxy: &structs.ConsulProxy{
+					Upstreams: []structs.ConsulUpstream{{
+						DestinationName: "db",
+						LocalBindPort:   5432,
+					}},
+				},
+			},
+		},
+	}}
+	sidecarTask := &structs.Task{
+		Name: "connect-proxy-api",
+		Kind: structs.NewTaskKind(structs.ConnectProxyPrefix, "api"),
+	}
+	tg.Tasks = append(tg.Tasks, sidecarTask)
+
+	rpc := newMockMeshRPC(t)
+	rpc.services["db-sidecar-proxy"] = []*structs.ServiceRegistration{
+		{ServiceName: "db-sidecar-proxy", Address: "10.0.0.2", Port: 26000},
+	}
+
+	allocDir, cleanup := allocdir.TestAllocDir(t, logger, "EnvoyMesh", alloc.ID)
+	defer cleanup()
+
+	h := newEnvoyMeshHook(alloc, rpc, func() string { return "token" }, logger)
+	req := &interfaces.TaskPrestartRequest{
+		Task:    sidecarTask,
+		TaskDir: allocDir.NewTaskDir(sidecarTask),
+		TaskEnv: taskenv.NewBuilder(mock.Node(), alloc, sidecarTask, "global").
+			SetSecretsDir("/secrets").Build(),
+	}
+	must.NoError(t, req.TaskDir.Build(fsisolation.None, nil, sidecarTask.User))
+
+	resp := &interfaces.TaskPrestartResponse{}
+	must.NoError(t, h.Prestart(context.Background(), req, resp))
+	t.Cleanup(func() {
+		must.NoError(t, h.Stop(context.Background(), nil, nil))
+	})
+	must.Eq(t, "127.0.0.2:19001", resp.Env[envoyAdminBindEnvPrefix+"api"])
+
+	readJSON := func(name string) map[string]any {
+		b, err := os.ReadFile(filepath.Join(req.TaskDir.SecretsDir, name))
+		must.NoError(t, err)
+		var out map[string]any
+		must.NoError(t, json.Unmarshal(b, &out))
+		return out
+	}
+
+	// the listeners bind to the ports inside the network namespace
+	var bootstrap struct {
+		StaticResources struct {
+			Listeners []struct {
+				Name    string
+				Address struct {
+					SocketAddress struct {
+						Address   string `json:"address"`
+						PortValue int    `json:"port_value"`
+					} `json:"socket_address"`
+				}
+			}
+			Clusters []map[string]any
+		} `json:"static_resources"`
+	}
+	b, err := os.ReadFile(filepath.Join(req.TaskDir.SecretsDir, "envoy_bootstrap.json"))
+	must.NoError(t, err)
+	must.NoError(t, json.Unmarshal(b, &bootstrap))
+	must.Len(t, 2, bootstrap.StaticResources.Listeners)
+	must.Eq(t, 21000, bootstrap.StaticResources.Listeners[0].Address.SocketAddress.PortValue)
+	must.Eq(t, "127.0.0.1", bootstrap.StaticResources.Listeners[1].Address.SocketAddress.Address)
+	must.Eq(t, 5432, bootstrap.StaticResources.Listeners[1].Address.SocketAddress.PortValue)
+	must.Len(t, 2, bootstrap.StaticResources.Clusters)
+	must.Eq(t, "local_app", bootstrap.StaticResources.Clusters[0]["name"])
+	must.Eq(t, "db", bootstrap.StaticResources.Clusters[1]["name"])
+
+	// the SDS and EDS files are referenced by their path inside the task
+	must.StrContains(t, string(b), `"path": "/secrets/envoy_mesh_cert.json"`)
+	must.StrContains(t, string(b), `"path": "/secrets/envoy_upstream_db.json"`)
+	must.StrContains(t, string(b), `"exact": "spiffe://nomad/ns/default/svc/db"`)
+
+	must.MapContainsKey(t, readJSON(envoyMeshCertFile), "resources")
+	must.MapContainsKey(t, readJSON(envoyMeshCAFile), "resources")
+
+	eds := readJSON(envoyMeshUpstreamFile("db"))
+	must.Eq(t, "10", eds["version_info"])
+	must.StrContains(t, mustMarshal(t, eds), `"address":"10.0.0.2","port_value":26000`)
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	b, err := json.Marshal(v)
+	must.NoError(t, err)
+	return string(b)
+}
@@ -79,6 +79,15 @@ func (h *envoyVersionHook) Prestart(_ context.Context, request *ifs.TaskPrestart
 		return nil
 	}
 
+	// Sidecars of Nomad services are bootstrapped without Consul, so use the
+	// Envoy version Nomad generates the bootstrap configuration for.
+	if usesNomadMesh(h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup), request.Task) {
+		image := strings.ReplaceAll(h.taskImage(request.Task.Config), envoy.VersionVar, envoy.NomadMeshVersion)
+		h.logger.Trace("setting task envoy image", "image", image)
+		request.Task.Config["image"] = image
+		return nil
+	}
+
 	// We either need to acquire Consul's preferred Envoy version or fallback
 	// to the legacy default. Query Consul and use the (possibly empty) result.
 	//
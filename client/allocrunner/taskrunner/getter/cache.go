@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/client/config"
+)
+
+const (
+	// sharedCacheDir is the directory under the artifact cache dir of the
+	// client holding the artifacts shared by allocations, keyed by checksum.
+	sharedCacheDir = "shared"
+
+	// sharedCacheDefaultName is the name of cached artifacts whose URL has no
+	// file name.
+	sharedCacheDefaultName = "artifact"
+)
+
+// getSharedCacheDir returns the host path of the directory in which
+// artifacts shared by allocations are cached, or the empty string if the
+// shared cache is disabled.
+func getSharedCacheDir(ac *config.ArtifactConfig) string {
+	if ac.CacheDir == "" || ac.CacheMaxBytes <= 0 {
+		return ""
+	}
+	return filepath.Join(ac.CacheDir, sharedCacheDir)
+}
+
+// cacheKeyAlgorithms are the checksum algorithms whose checksums identify
+// artifacts in the shared cache, with the size of their checksums. Weaker
+// algorithms would let an artifact with a colliding checksum take the place
+// of another in the cache.
+var cacheKeyAlgorithms = map[string]int{
+	"sha256": sha256.Size,
+	"sha512": sha512.Size,
+}
+
+// getCacheKey returns the key of the artifact at source in the shared cache,
+// made from the checksum of the artifact. It returns the empty string if the
+// artifact can't be cached, because it is not downloaded over HTTP or its
+// checksum is not a sha256 or sha512 checksum given inline.
+func getCacheKey(source string) string {
+	u, err := url.Parse(source)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+
+	algorithm, sum, ok := strings.Cut(u.Query().Get("checksum"), ":")
+	if !ok {
+		return ""
+	}
+	size, ok := cacheKeyAlgorithms[algorithm]
+	if !ok {
+		return ""
+	}
+	if b, err := hex.DecodeString(sum); err != nil || len(b) != size {
+		return ""
+	}
+	return algorithm + "-" + strings.ToLower(sum)
+}
+
+// artifactName returns the file name of the artifact, so that copies of the
+// artifact are unarchived like the original.
+func (p *parameters) artifactName() (string, error) {
+	u, err := url.Parse(p.Source)
+	if err != nil {
+		return "", err
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." || name == "" {
+		name = sharedCacheDefaultName
+	}
+	return name, nil
+}
+
+// cacheEntry returns the path of the artifact in the shared cache.
+func (p *parameters) cacheEntry() (string, error) {
+	name, err := p.artifactName()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(p.CacheDir, p.CacheKey, name), nil
+}
+
+// getCached gets the artifact from the shared cache, downloading it to the
+// cache first if it isn't cached yet. The artifact is verified against its
+// checksum, and its signature if any, each time it is copied out of the
+// cache.
+func (p *parameters) getCached(ctx context.Context) error {
+	entry, err := p.cacheEntry()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(entry); errors.Is(err, fs.ErrNotExist) {
+		if err := p.fillCache(ctx, entry); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	return p.getFromFile(ctx, entry)
+}
+
+// getFromFile gets the artifact downloaded to file to its destination,
+// verifying its signature first if it must be verified.
+func (p *parameters) getFromFile(ctx context.Context, file string) error {
+	if p.Verify != nil {
+		if err := p.verifyFile(ctx, file); err != nil {
+			return err
+		}
+	}
+
+	u, err := url.Parse(p.Source)
+	if err != nil {
+		return err
+	}
+
+	c := p.client(ctx)
+	c.Src = file + "?" + u.RawQuery
+	c.Getters["file"] = &getter.FileGetter{Copy: true}
+	return c.Get()
+}
+
+// fillCache downloads the artifact, without unarchiving it, to the entry of
+// the shared cache or to another file.
+func (p *parameters) fillCache(ctx context.Context, entry string) error {
+	if err := os.MkdirAll(filepath.Dir(entry), 0o755); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(p.Source)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	q.Set("archive", "false")
+	u.RawQuery = q.Encode()
+
+	tmp := fmt.Sprintf("%s.%d.tmp", entry, os.Getpid())
+	defer os.Remove(tmp)
+
+	c := p.client(ctx)
+	c.Src = u.String()
+	c.Dst = tmp
+	c.Mode = getter.ClientModeFile
+	if err := c.Get(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, entry)
+}
+
+// sharedCacheEntry is an artifact in the shared cache.
+type sharedCacheEntry struct {
+	dir      string
+	size     int64
+	lastUsed time.Time
+}
+
+// touchCacheEntry marks the entry of the shared cache as used, so that it is
+// evicted after entries used less recently.
+func touchCacheEntry(cacheDir, key string) error {
+	now := time.Now()
+	return os.Chtimes(filepath.Join(cacheDir, key), now, now)
+}
+
+// pruneSharedCache evicts the least recently used artifacts from the shared
+// cache until the cache is no larger than maxBytes.
+func pruneSharedCache(cacheDir string, maxBytes int64) ([]string, error) {
+	dirEntries, err := os.ReadDir(cacheDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var (
+		entries []*sharedCacheEntry
+		total   int64
+	)
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		entry := &sharedCacheEntry{
+			dir:      filepath.Join(cacheDir, dirEntry.Name()),
+			lastUsed: info.ModTime(),
+		}
+		filepath.WalkDir(entry.dir, func(_ string, d fs.DirEntry, err error) error {
+			if err == nil && d.Type().IsRegular() {
+				if info, err := d.Info(); err == nil {
+					entry.size += info.Size()
+				}
+			}
+			return nil
+		})
+		entries = append(entries, entry)
+		total += entry.size
+	}
+
+	slices.SortFunc(entries, func(a, b *sharedCacheEntry) int {
+		return a.lastUsed.Compare(b.lastUsed)
+	})
+
+	var evicted []string
+	for _, entry := range entries {
+		if total <= maxBytes {
+			break
+		}
+		if err := os.RemoveAll(entry.dir); err != nil {
+			return evicted, err
+		}
+		total -= entry.size
+		evicted = append(evicted, filepath.Base(entry.dir))
+	}
+	return evicted, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestGetCacheKey(t *testing.T) {
+	ci.Parallel(t)
+
+	sha256Sum := strings.Repeat("ab", sha256.Size)
+	sha512Sum := strings.Repeat("cd", sha512.Size)
+
+	cases := []struct {
+		source string
+		exp    string
+	}{
+		{
+			source: "https://example.com/app.tar.gz?checksum=sha256:" + strings.ToUpper(sha256Sum),
+			exp:    "sha256-" + sha256Sum,
+		},
+		{
+			source: "http://example.com/app?checksum=sha512:" + sha512Sum,
+			exp:    "sha512-" + sha512Sum,
+		},
+		{
+			// weak checksum algorithms
+			source: "http://example.com/app?checksum=md5:d41d8cd98f00b204e9800998ecf8427e",
+		},
+		{
+			source: "http://example.com/app?checksum=sha1:da39a3ee5e6b4b0d3255bfef95601890afd80709",
+		},
+		{
+			// checksum of the wrong size
+			source: "https://example.com/app.tar.gz?checksum=sha256:abcd",
+		},
+		{
+			// no checksum
+			source: "https://example.com/app.tar.gz",
+		},
+		{
+			// checksum from a file
+			source: "https://example.com/app.tar.gz?checksum=file:https://example.com/SHA256SUMS",
+		},
+		{
+			// not downloaded over HTTP
+			source: "git::https://example.com/app.git?checksum=sha256:abcd",
+		},
+		{
+			// not a hex checksum
+			source: "https://example.com/app?checksum=sha256:../../etc",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.source, func(t *testing.T) {
+			must.Eq(t, tc.exp, getCacheKey(tc.source))
+		})
+	}
+}
+
+func TestParameters_getCached(t *testing.T) {
+	ci.Parallel(t)
+
+	content := []byte("large artifact")
+	sum := sha256.Sum256(content)
+	checksum := "sha256:" + hex.EncodeToString(sum[:])
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write(content)
+	}))
+	t.Cleanup(srv.Close)
+
+	cacheDir := t.TempDir()
+	get := func() string {
+		dst := filepath.Join(t.TempDir(), "app.bin")
+		p := &parameters{
+			HTTPReadTimeout: 10 * time.Second,
+			HTTPMaxBytes:    1 << 20,
+			Mode:            getter.ClientModeFile,
+			Source:          srv.URL + "/app.bin?checksum=" + checksum,
+			Destination:     dst,
+			CacheDir:        cacheDir,
+			CacheKey:        getCacheKey(srv.URL + "/app.bin?checksum=" + checksum),
+		}
+		must.NoError(t, p.getCached(context.Background()))
+		return dst
+	}
+
+	must.FileContains(t, get(), "large artifact")
+	must.Eq(t, 1, requests)
+
+	// the second download is served from the cache
+	must.FileContains(t, get(), "large artifact")
+	must.Eq(t, 1, requests)
+	must.FileExists(t, filepath.Join(cacheDir, "sha256-"+hex.EncodeToString(sum[:]), "app.bin"))
+}
+
+func TestPruneSharedCache(t *testing.T) {
+	ci.Parallel(t)
+
+	cacheDir := t.TempDir()
+	now := time.Now()
+	for i, key := range []string{"sha256-old", "sha256-mid", "sha256-new"} {
+		dir := filepath.Join(cacheDir, key)
+		must.NoError(t, os.MkdirAll(dir, 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(dir, "artifact"), make([]byte, 100), 0o644))
+		used := now.Add(time.Duration(i-3) * time.Hour)
+		must.NoError(t, os.Chtimes(dir, used, used))
+	}
+
+	// the cache fits
+	evicted, err := pruneSharedCache(cacheDir, 300)
+	must.NoError(t, err)
+	must.SliceEmpty(t, evicted)
+
+	// the least recently used artifacts are evicted first
+	must.NoError(t, touchCacheEntry(cacheDir, "sha256-old"))
+	evicted, err = pruneSharedCache(cacheDir, 150)
+	must.NoError(t, err)
+	must.Eq(t, []string{"sha256-mid", "sha256-new"}, evicted)
+	must.DirExists(t, filepath.Join(cacheDir, "sha256-old"))
+}
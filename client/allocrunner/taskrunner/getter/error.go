@@ -18,6 +18,10 @@ func (e *Error) Error() string {
 	return e.Err.Error()
 }
 
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
 func (e *Error) IsRecoverable() bool {
 	return e.Recoverable
 }
@@ -0,0 +1,545 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// ociScheme is the URL scheme of artifacts pulled from OCI registries,
+	// e.g. oci://ghcr.io/example/tools:1.0
+	ociScheme = "oci"
+
+	// ociDigestOption is the artifact option used to pin the digest of the
+	// manifest of an OCI artifact referenced by tag.
+	ociDigestOption = "digest"
+
+	// dockerMediaTypeManifest is the media type of Docker image manifests,
+	// which registries may return in place of OCI image manifests.
+	dockerMediaTypeManifest = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// orasAnnotationUnpack marks the layers of directories pushed by ORAS,
+	// which are stored as gzipped tarballs.
+	orasAnnotationUnpack = "io.deis.oras.content.unpack"
+
+	// dockerHubRegistry is the name of Docker Hub in references, and
+	// dockerHubEndpoint and dockerHubAuthKey are its API endpoint and its key
+	// in Docker config files.
+	dockerHubRegistry = "docker.io"
+	dockerHubEndpoint = "registry-1.docker.io"
+	dockerHubAuthKey  = "https://index.docker.io/v1/"
+
+	// ociMaxManifestBytes is the maximum size of a manifest
+	ociMaxManifestBytes = 4 << 20
+)
+
+// ociAuth holds the credentials used to pull an OCI artifact from its
+// registry. They are resolved by the client and passed to the sandbox.
+type ociAuth struct {
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	RegistryToken string `json:"registry_token"`
+}
+
+// Equal returns whether a and o are the same.
+func (a *ociAuth) Equal(o *ociAuth) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	return *a == *o
+}
+
+// ociReference is a reference to an OCI artifact, parsed from its oci:// URL.
+type ociReference struct {
+	// Registry is the host of the registry, e.g. ghcr.io or localhost:5000
+	Registry string
+
+	// Repository is the name of the repository in the registry
+	Repository string
+
+	// Reference is the tag or the digest of the manifest
+	Reference string
+}
+
+// parseOCIReference parses the reference of an artifact from an URL such as
+// oci://ghcr.io/example/tools:1.0 or oci://ghcr.io/example/tools@sha256:...
+// The tag defaults to latest.
+func parseOCIReference(u *url.URL) (*ociReference, error) {
+	repo := strings.Trim(u.Path, "/")
+	if u.Host == "" || repo == "" {
+		return nil, fmt.Errorf("OCI artifact URL %q must include the registry and repository", u.Redacted())
+	}
+
+	ref := &ociReference{Registry: u.Host, Reference: "latest"}
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo, ref.Reference = repo[:i], repo[i+1:]
+	} else if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo, ref.Reference = repo[:i], repo[i+1:]
+	}
+	if repo == "" || ref.Reference == "" {
+		return nil, fmt.Errorf("invalid OCI artifact URL %q", u.Redacted())
+	}
+
+	if ref.Registry == dockerHubRegistry && !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+	ref.Repository = repo
+	return ref, nil
+}
+
+// isDigest returns whether the reference is a digest rather than a tag.
+func (r *ociReference) isDigest() bool {
+	return strings.Contains(r.Reference, ":")
+}
+
+// endpoint returns the base URL of the registry API.
+func (r *ociReference) endpoint() string {
+	if r.Registry == dockerHubRegistry {
+		return "https://" + dockerHubEndpoint
+	}
+	return "https://" + r.Registry
+}
+
+// authKey returns the key of the registry in Docker config files.
+func (r *ociReference) authKey() string {
+	if r.Registry == dockerHubRegistry {
+		return dockerHubAuthKey
+	}
+	return r.Registry
+}
+
+// resolveOCIAuth returns the credentials for the registry of the OCI
+// artifact at source from the Docker config file at path, including those
+// from its credential helpers. It returns nil if source is not an OCI
+// artifact or if no credentials are found.
+func resolveOCIAuth(path, source string) (*ociAuth, error) {
+	if path == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(source)
+	if err != nil || u.Scheme != ociScheme {
+		return nil, nil
+	}
+	ref, err := parseOCIReference(u)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open OCI auth config file: %w", err)
+	}
+	defer f.Close()
+
+	cfile := configfile.New(path)
+	if err := cfile.LoadFromReader(f); err != nil {
+		return nil, fmt.Errorf("failed to parse OCI auth config file: %w", err)
+	}
+
+	authConfig, err := cfile.GetAuthConfig(ref.authKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials for registry %q: %w", ref.Registry, err)
+	}
+
+	auth := &ociAuth{
+		Username:      authConfig.Username,
+		Password:      authConfig.Password,
+		RegistryToken: authConfig.RegistryToken,
+	}
+	if authConfig.IdentityToken != "" {
+		// identity tokens are exchanged for registry tokens like passwords
+		auth.Username, auth.Password = "<token>", authConfig.IdentityToken
+	}
+	if *auth == (ociAuth{}) {
+		return nil, nil
+	}
+	return auth, nil
+}
+
+// ociGetter is a go-getter Getter which pulls artifacts from OCI registries,
+// as pushed by ORAS. Each layer with a title is written to the destination
+// under its title, and layers of directories are unpacked. Layers are cached
+// by digest in the cache dir, if any.
+type ociGetter struct {
+	client *getter.Client
+
+	auth     *ociAuth
+	cacheDir string
+	maxBytes int64
+
+	// token is the bearer token for the registry, once authenticated
+	token string
+	basic bool
+}
+
+var _ getter.Getter = (*ociGetter)(nil)
+
+func (g *ociGetter) SetClient(c *getter.Client) { g.client = c }
+
+// ClientMode returns dir, since artifacts are made of any number of layers.
+func (g *ociGetter) ClientMode(*url.URL) (getter.ClientMode, error) {
+	return getter.ClientModeDir, nil
+}
+
+// Get pulls the OCI artifact at u to the directory dst.
+func (g *ociGetter) Get(dst string, u *url.URL) error {
+	ref, manifest, err := g.manifest(u)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return err
+	}
+
+	for _, layer := range manifest.Layers {
+		title := layer.Annotations[ocispec.AnnotationTitle]
+		if title == "" {
+			// ORAS ignores layers without a title as well
+			continue
+		}
+		target := filepath.Join(dst, title)
+		if escapingfs.PathEscapesSandbox(dst, target) {
+			return fmt.Errorf("layer %s of OCI artifact escapes the destination: %q", layer.Digest, title)
+		}
+
+		blob, cleanup, err := g.blob(ref, layer)
+		if err != nil {
+			return err
+		}
+
+		if layer.Annotations[orasAnnotationUnpack] == "true" {
+			// directories are packed along with their name, like ORAS does
+			err = g.unpack(filepath.Dir(target), blob)
+		} else if err = os.MkdirAll(filepath.Dir(target), 0o755); err == nil {
+			err = copyBlob(target, blob)
+		}
+		cleanup()
+		if err != nil {
+			return fmt.Errorf("failed to write layer %s of OCI artifact: %w", layer.Digest, err)
+		}
+	}
+	return nil
+}
+
+// GetFile pulls the OCI artifact at u, which must have a single file, to the
+// file dst.
+func (g *ociGetter) GetFile(dst string, u *url.URL) error {
+	ref, manifest, err := g.manifest(u)
+	if err != nil {
+		return err
+	}
+
+	var file *ocispec.Descriptor
+	for i, layer := range manifest.Layers {
+		if layer.Annotations[ocispec.AnnotationTitle] == "" {
+			continue
+		}
+		if file != nil || layer.Annotations[orasAnnotationUnpack] == "true" {
+			return errors.New("OCI artifact must have a single file to be downloaded in file mode")
+		}
+		file = &manifest.Layers[i]
+	}
+	if file == nil {
+		return errors.New("OCI artifact has no files")
+	}
+
+	blob, cleanup, err := g.blob(ref, *file)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	return copyBlob(dst, blob)
+}
+
+// manifest fetches the manifest of the OCI artifact at u, verifying its
+// digest if it is pinned.
+func (g *ociGetter) manifest(u *url.URL) (*ociReference, *ocispec.Manifest, error) {
+	q := u.Query()
+	pinned := q.Get(ociDigestOption)
+	q.Del(ociDigestOption)
+	u.RawQuery = q.Encode()
+
+	ref, err := parseOCIReference(u)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := g.get(ref, "/manifests/"+ref.Reference,
+		ocispec.MediaTypeImageManifest+", "+dockerMediaTypeManifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, ociMaxManifestBytes))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read manifest of OCI artifact: %w", err)
+	}
+
+	sum := sha256.Sum256(body)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	if ref.isDigest() && digest != ref.Reference {
+		return nil, nil, fmt.Errorf("manifest of OCI artifact has digest %s, expected %s", digest, ref.Reference)
+	}
+	if pinned != "" && digest != pinned {
+		return nil, nil, fmt.Errorf("manifest of OCI artifact has digest %s, expected pinned digest %s", digest, pinned)
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode manifest of OCI artifact: %w", err)
+	}
+	if manifest.MediaType == ocispec.MediaTypeImageIndex || resp.Header.Get("Content-Type") == ocispec.MediaTypeImageIndex {
+		return nil, nil, errors.New("OCI image indexes are not supported; reference the artifact manifest instead")
+	}
+	return ref, &manifest, nil
+}
+
+// blob returns the path of the blob of the layer, downloading it if it isn't
+// cached. The cleanup function must be called once the blob is used.
+func (g *ociGetter) blob(ref *ociReference, layer ocispec.Descriptor) (string, func(), error) {
+	algorithm, encoded, ok := strings.Cut(string(layer.Digest), ":")
+	if !ok || algorithm != "sha256" || encoded == "" || strings.ContainsAny(encoded, `/\.`) {
+		return "", nil, fmt.Errorf("unsupported digest %q of layer of OCI artifact", layer.Digest)
+	}
+	if g.maxBytes > 0 && layer.Size > g.maxBytes {
+		return "", nil, fmt.Errorf("layer %s of OCI artifact is larger than %d bytes", layer.Digest, g.maxBytes)
+	}
+
+	dir := g.cacheDir
+	cleanup := func() {}
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "oci-blobs")
+		if err != nil {
+			return "", nil, err
+		}
+		dir = tmp
+		cleanup = func() { os.RemoveAll(tmp) }
+	} else {
+		dir = filepath.Join(dir, "blobs", algorithm)
+	}
+
+	path := filepath.Join(dir, encoded)
+	if _, err := os.Stat(path); err == nil {
+		return path, cleanup, nil
+	}
+
+	if err := g.download(ref, layer, path); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
+// download downloads the blob of the layer to path, verifying its size and
+// digest before it is moved in place.
+func (g *ociGetter) download(ref *ociReference, layer ocispec.Descriptor, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	resp, err := g.get(ref, "/blobs/"+string(layer.Digest), "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(resp.Body, layer.Size+1))
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to download layer %s of OCI artifact: %w", layer.Digest, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if n != layer.Size {
+		return fmt.Errorf("layer %s of OCI artifact has %d bytes, expected %d", layer.Digest, n, layer.Size)
+	}
+	if digest := "sha256:" + hex.EncodeToString(h.Sum(nil)); digest != string(layer.Digest) {
+		return fmt.Errorf("layer of OCI artifact has digest %s, expected %s", digest, layer.Digest)
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// unpack decompresses the gzipped tarball of a directory at blob to dst.
+func (g *ociGetter) unpack(dst, blob string) error {
+	d, ok := g.client.Decompressors["tar.gz"]
+	if !ok {
+		return errors.New("no decompressor for tar.gz")
+	}
+	return d.Decompress(dst, blob, true, umask)
+}
+
+// copyBlob copies the blob to the file dst.
+func copyBlob(dst, blob string) error {
+	src, err := os.Open(blob)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644&^umask)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, src); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// get makes a GET request to the path of the repository in the registry API,
+// authenticating as requested by the registry.
+func (g *ociGetter) get(ref *ociReference, path, accept string) (*http.Response, error) {
+	endpoint := ref.endpoint() + "/v2/" + ref.Repository + path
+
+	httpClient := &http.Client{}
+	if g.client.Insecure {
+		httpClient.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	var resp *http.Response
+	for attempt := 0; attempt < 2; attempt++ {
+		req, err := http.NewRequestWithContext(g.client.Ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		switch {
+		case g.token != "":
+			req.Header.Set("Authorization", "Bearer "+g.token)
+		case g.basic && g.auth != nil:
+			req.SetBasicAuth(g.auth.Username, g.auth.Password)
+		}
+
+		resp, err = httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reach registry %q: %w", ref.Registry, err)
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			break
+		}
+
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := g.authenticate(httpClient, challenge); err != nil {
+			return nil, fmt.Errorf("failed to authenticate to registry %q: %w", ref.Registry, err)
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("registry %q returned %s for %s", ref.Registry, resp.Status, path)
+	}
+	return resp, nil
+}
+
+// challengeParamRe matches the parameters of WWW-Authenticate challenges
+var challengeParamRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authenticate answers the WWW-Authenticate challenge of the registry, either
+// with basic auth or by requesting a bearer token from the token server.
+func (g *ociGetter) authenticate(httpClient *http.Client, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if g.auth == nil || g.auth.Username == "" {
+			return errors.New("registry requires credentials")
+		}
+		g.basic = true
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	if g.auth != nil && g.auth.RegistryToken != "" {
+		g.token = g.auth.RegistryToken
+		return nil
+	}
+
+	values := make(map[string]string)
+	for _, m := range challengeParamRe.FindAllStringSubmatch(params, -1) {
+		values[m[1]] = m[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Host == "" {
+		return fmt.Errorf("invalid token realm in challenge %q", challenge)
+	}
+	q := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v := values[k]; v != "" {
+			q.Set(k, v)
+		}
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(g.client.Ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if g.auth != nil && g.auth.Username != "" {
+		req.SetBasicAuth(g.auth.Username, g.auth.Password)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token server returned %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, ociMaxManifestBytes)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode token: %w", err)
+	}
+	g.token = token.Token
+	if g.token == "" {
+		g.token = token.AccessToken
+	}
+	if g.token == "" {
+		return errors.New("token server returned no token")
+	}
+	return nil
+}
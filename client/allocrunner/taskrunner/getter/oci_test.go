@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/shoenig/test/must"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		url string
+		exp *ociReference
+	}{
+		{
+			url: "oci://ghcr.io/example/tools:1.0",
+			exp: &ociReference{Registry: "ghcr.io", Repository: "example/tools", Reference: "1.0"},
+		},
+		{
+			url: "oci://localhost:5000/tools",
+			exp: &ociReference{Registry: "localhost:5000", Repository: "tools", Reference: "latest"},
+		},
+		{
+			url: "oci://ghcr.io/example/tools@sha256:abcd",
+			exp: &ociReference{Registry: "ghcr.io", Repository: "example/tools", Reference: "sha256:abcd"},
+		},
+		{
+			url: "oci://docker.io/tools:2",
+			exp: &ociReference{Registry: "docker.io", Repository: "library/tools", Reference: "2"},
+		},
+		{
+			url: "oci://ghcr.io",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.url, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			must.NoError(t, err)
+			ref, err := parseOCIReference(u)
+			if tc.exp == nil {
+				must.Error(t, err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, ref)
+		})
+	}
+}
+
+func TestResolveOCIAuth(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	auth := base64.StdEncoding.EncodeToString([]byte("nomad:secret"))
+	must.NoError(t, os.WriteFile(path, []byte(`{"auths":{"ghcr.io":{"auth":"`+auth+`"}}}`), 0o600))
+
+	got, err := resolveOCIAuth(path, "oci://ghcr.io/example/tools:1.0")
+	must.NoError(t, err)
+	must.Eq(t, &ociAuth{Username: "nomad", Password: "secret"}, got)
+
+	// no credentials for the registry
+	got, err = resolveOCIAuth(path, "oci://quay.io/example/tools:1.0")
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	// not an OCI artifact
+	got, err = resolveOCIAuth(path, "https://ghcr.io/example/tools")
+	must.NoError(t, err)
+	must.Nil(t, got)
+}
+
+// testRegistry serves an OCI artifact with a file and a directory, requiring
+// a bearer token.
+func testRegistry(t *testing.T) (*httptest.Server, string, *int) {
+	digest := func(b []byte) string {
+		sum := sha256.Sum256(b)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	file := []byte("#!/bin/sh\necho hello\n")
+
+	var dir bytes.Buffer
+	gz := gzip.NewWriter(&dir)
+	tw := tar.NewWriter(gz)
+	content := []byte("key = value\n")
+	must.NoError(t, tw.WriteHeader(&tar.Header{Name: "conf/app.conf", Mode: 0o644, Size: int64(len(content))}))
+	_, err := tw.Write(content)
+	must.NoError(t, err)
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+
+	blobs := map[string][]byte{
+		digest(file):        file,
+		digest(dir.Bytes()): dir.Bytes(),
+	}
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     ocispec.MediaTypeImageManifest,
+		"layers": []map[string]any{
+			{
+				"mediaType":   "application/vnd.oci.image.layer.v1.tar",
+				"digest":      digest(file),
+				"size":        len(file),
+				"annotations": map[string]string{ocispec.AnnotationTitle: "bin/hello.sh"},
+			},
+			{
+				"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip",
+				"digest":    digest(dir.Bytes()),
+				"size":      dir.Len(),
+				"annotations": map[string]string{
+					ocispec.AnnotationTitle: "conf",
+					orasAnnotationUnpack:    "true",
+				},
+			},
+		},
+	})
+	must.NoError(t, err)
+
+	blobRequests := 0
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Write([]byte(`{"token":"t0ken"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.Header().Set("WWW-Authenticate",
+				`Bearer realm="`+srv.URL+`/token",service="test",scope="repository:example/tools:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/v2/example/tools/manifests/1.0":
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			w.Write(manifest)
+		case strings.HasPrefix(r.URL.Path, "/v2/example/tools/blobs/"):
+			blob, ok := blobs[strings.TrimPrefix(r.URL.Path, "/v2/example/tools/blobs/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			blobRequests++
+			w.Write(blob)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, digest(manifest), &blobRequests
+}
+
+func TestOCIGetter_Get(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, manifestDigest, blobRequests := testRegistry(t)
+	host := strings.TrimPrefix(srv.URL, "https://")
+	cacheDir := t.TempDir()
+
+	get := func(source string) (string, error) {
+		g := &ociGetter{cacheDir: cacheDir, maxBytes: 1 << 20}
+		g.SetClient(&getter.Client{
+			Ctx:           context.Background(),
+			Insecure:      true,
+			Decompressors: getter.LimitedDecompressors(10, 1<<20),
+		})
+		u, err := url.Parse(source)
+		must.NoError(t, err)
+		dst := filepath.Join(t.TempDir(), "out")
+		return dst, g.Get(dst, u)
+	}
+
+	dst, err := get("oci://" + host + "/example/tools:1.0?digest=" + manifestDigest)
+	must.NoError(t, err)
+	must.FileContains(t, filepath.Join(dst, "bin", "hello.sh"), "echo hello")
+	must.FileContains(t, filepath.Join(dst, "conf", "app.conf"), "key = value")
+	must.Eq(t, 2, *blobRequests)
+
+	// layers are served from the cache
+	_, err = get("oci://" + host + "/example/tools:1.0")
+	must.NoError(t, err)
+	must.Eq(t, 2, *blobRequests)
+
+	// pinned digest must match
+	_, err = get("oci://" + host + "/example/tools:1.0?digest=sha256:0000")
+	must.ErrorContains(t, err, "expected pinned digest")
+}
@@ -42,6 +42,17 @@ type parameters struct {
 	Destination string              `json:"artifact_destination"`
 	Headers     map[string][]string `json:"artifact_headers"`
 
+	// OCI artifacts
+	OCIAuth     *ociAuth `json:"oci_auth"`
+	OCICacheDir string   `json:"oci_cache_dir"`
+
+	// Shared cache
+	CacheDir string `json:"cache_dir"`
+	CacheKey string `json:"cache_key"`
+
+	// Verification
+	Verify *verification `json:"verify"`
+
 	// Task Filesystem
 	AllocDir string `json:"alloc_dir"`
 	TaskDir  string `json:"task_dir"`
@@ -116,6 +127,16 @@ func (p *parameters) Equal(o *parameters) bool {
 		return false
 	case p.TaskDir != o.TaskDir:
 		return false
+	case !p.OCIAuth.Equal(o.OCIAuth):
+		return false
+	case p.OCICacheDir != o.OCICacheDir:
+		return false
+	case p.CacheDir != o.CacheDir:
+		return false
+	case p.CacheKey != o.CacheKey:
+		return false
+	case !p.Verify.Equal(o.Verify):
+		return false
 	case !maps.EqualFunc(p.Headers, o.Headers, headersCompareFn):
 		return false
 	}
@@ -188,6 +209,11 @@ func (p *parameters) client(ctx context.Context) *getter.Client {
 			},
 			"http":  httpGetter,
 			"https": httpGetter,
+			ociScheme: &ociGetter{
+				auth:     p.OCIAuth,
+				cacheDir: p.OCICacheDir,
+				maxBytes: p.HTTPMaxBytes,
+			},
 		},
 	}
 }
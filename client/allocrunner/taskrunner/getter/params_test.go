@@ -38,6 +38,15 @@ const paramsAsJSON = `
   "artifact_headers": {
     "X-Nomad-Artifact": ["hi"]
   },
+  "oci_auth": {
+    "username": "nomad",
+    "password": "secret",
+    "registry_token": ""
+  },
+  "oci_cache_dir": "/path/to/cache/oci",
+  "cache_dir": "/path/to/cache/shared",
+  "cache_key": "sha256-abcd",
+  "verify": null,
   "alloc_dir": "/path/to/alloc",
   "task_dir": "/path/to/alloc/task",
   "chown": true,
@@ -67,8 +76,15 @@ var paramsAsStruct = &parameters{
 	Headers: map[string][]string{
 		"X-Nomad-Artifact": {"hi"},
 	},
-	User:  "nobody",
-	Chown: true,
+	OCIAuth: &ociAuth{
+		Username: "nomad",
+		Password: "secret",
+	},
+	OCICacheDir: "/path/to/cache/oci",
+	CacheDir:    "/path/to/cache/shared",
+	CacheKey:    "sha256-abcd",
+	User:        "nobody",
+	Chown:       true,
 }
 
 func TestParameters_reader(t *testing.T) {
@@ -4,6 +4,9 @@
 package getter
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/interfaces"
@@ -22,6 +25,9 @@ func New(ac *config.ArtifactConfig, logger hclog.Logger) *Sandbox {
 type Sandbox struct {
 	logger hclog.Logger
 	ac     *config.ArtifactConfig
+
+	// cacheLock serializes the eviction of artifacts from the shared cache
+	cacheLock sync.Mutex
 }
 
 func (s *Sandbox) Get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact, user string) error {
@@ -42,6 +48,30 @@ func (s *Sandbox) Get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact
 	headers := getHeaders(env, artifact)
 	allocDir, taskDir := getWritableDirs(env)
 
+	verify, err := getVerification(env, s.ac, artifact)
+	if err != nil {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         fmt.Errorf("%w: %v", ErrVerificationFailed, err),
+			Recoverable: false,
+		}
+	}
+
+	cacheDir := getSharedCacheDir(s.ac)
+	cacheKey := ""
+	if cacheDir != "" {
+		cacheKey = getCacheKey(source)
+	}
+
+	ociAuth, err := resolveOCIAuth(s.ac.OCIAuthConfig, source)
+	if err != nil {
+		return &Error{
+			URL:         artifact.GetterSource,
+			Err:         err,
+			Recoverable: false,
+		}
+	}
+
 	params := &parameters{
 		// downloader configuration
 		HTTPReadTimeout:               s.ac.HTTPReadTimeout,
@@ -62,6 +92,11 @@ func (s *Sandbox) Get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact
 		Source:      source,
 		Destination: destination,
 		Headers:     headers,
+		OCIAuth:     ociAuth,
+		OCICacheDir: getOCICacheDir(s.ac),
+		CacheDir:    cacheDir,
+		CacheKey:    cacheKey,
+		Verify:      verify,
 
 		// task filesystem
 		AllocDir: allocDir,
@@ -74,5 +109,28 @@ func (s *Sandbox) Get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact
 		return err
 	}
 
+	if cacheKey != "" {
+		s.pruneCache(cacheDir, cacheKey)
+	}
+
 	return nil
 }
+
+// pruneCache marks the artifact as used in the shared cache and evicts the
+// least recently used artifacts once the cache exceeds its maximum size.
+// Failures are logged since the artifact was downloaded already.
+func (s *Sandbox) pruneCache(cacheDir, cacheKey string) {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+
+	if err := touchCacheEntry(cacheDir, cacheKey); err != nil {
+		s.logger.Warn("failed to mark cached artifact as used", "key", cacheKey, "error", err)
+	}
+	evicted, err := pruneSharedCache(cacheDir, s.ac.CacheMaxBytes)
+	if len(evicted) > 0 {
+		s.logger.Debug("evicted artifacts from cache", "keys", evicted)
+	}
+	if err != nil {
+		s.logger.Warn("failed to evict artifacts from cache", "error", err)
+	}
+}
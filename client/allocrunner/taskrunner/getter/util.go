@@ -5,6 +5,7 @@ package getter
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -17,6 +18,7 @@ import (
 	"unicode"
 
 	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/helper/subproc"
 	"github.com/hashicorp/nomad/helper/users"
@@ -128,6 +130,16 @@ func getHeaders(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) map[
 	return headers
 }
 
+// getOCICacheDir returns the host path of the directory in which the layers
+// of OCI artifacts are cached, or the empty string if the client has no cache
+// directory.
+func getOCICacheDir(ac *config.ArtifactConfig) string {
+	if ac.CacheDir == "" {
+		return ""
+	}
+	return filepath.Join(ac.CacheDir, ociScheme)
+}
+
 // getWritableDirs returns host paths to the task's allocation and task specific
 // directories - the locations into which a Task is allowed to download an artifact.
 func getWritableDirs(env interfaces.EnvReplacer) (string, string) {
@@ -177,6 +189,15 @@ func (s *Sandbox) runCmd(env *parameters) error {
 	if err := cmd.Run(); err != nil {
 		msg := subproc.Log(output, s.logger.Error)
 
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == exitVerificationFailed {
+			return &Error{
+				URL:         env.Source,
+				Err:         fmt.Errorf("%w: %v", ErrVerificationFailed, msg),
+				Recoverable: false,
+			}
+		}
+
 		return &Error{
 			URL:         env.Source,
 			Err:         fmt.Errorf("getter subprocess failed: %v: %v", err, msg),
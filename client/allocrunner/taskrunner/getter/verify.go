@@ -0,0 +1,454 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // detached signatures only
+)
+
+const (
+	// exitVerificationFailed is the exit code of the getter sub-process when
+	// the artifact fails verification.
+	exitVerificationFailed = 3
+)
+
+var (
+	// ErrVerificationFailed is returned when the signature of an artifact
+	// fails verification.
+	ErrVerificationFailed = errors.New("artifact failed verification")
+
+	// oidFulcioIssuer and oidFulcioIssuerV2 are the extensions of Fulcio
+	// certificates holding the OIDC issuer of the identity of the signer.
+	oidFulcioIssuer   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidFulcioIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// verification is how the signature of an artifact is verified, passed to
+// the getter sub-process.
+type verification struct {
+	Type                  string `json:"type"`
+	Signature             string `json:"signature"`
+	PublicKey             string `json:"public_key"`
+	Bundle                string `json:"bundle"`
+	CertificateIdentity   string `json:"certificate_identity"`
+	CertificateOIDCIssuer string `json:"certificate_oidc_issuer"`
+	FulcioRoots           string `json:"fulcio_roots"`
+	RekorPublicKeys       string `json:"rekor_public_keys"`
+}
+
+// Equal returns whether v and o are the same.
+func (v *verification) Equal(o *verification) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	return *v == *o
+}
+
+// getVerification returns how the signature of the artifact is verified, or
+// nil if the artifact has no verify block. The Fulcio roots and Rekor public
+// keys of the client are only read for keyless cosign signatures.
+func getVerification(env interfaces.EnvReplacer, ac *config.ArtifactConfig, artifact *structs.TaskArtifact) (*verification, error) {
+	v := artifact.Verify
+	if v == nil {
+		return nil, nil
+	}
+
+	result := &verification{
+		Type:                  v.Type,
+		Signature:             env.ReplaceEnv(v.Signature),
+		PublicKey:             v.PublicKey,
+		CertificateIdentity:   v.CertificateIdentity,
+		CertificateOIDCIssuer: v.CertificateOIDCIssuer,
+	}
+	if v.Bundle != "" {
+		result.Bundle = env.ReplaceEnv(v.Bundle)
+		if ac.FulcioRoots == "" {
+			return nil, errors.New("client has no fulcio_roots to verify keyless signatures")
+		}
+		if ac.RekorPublicKeys == "" {
+			return nil, errors.New("client has no rekor_public_keys to verify keyless signatures")
+		}
+		roots, err := os.ReadFile(ac.FulcioRoots)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fulcio_roots: %w", err)
+		}
+		keys, err := os.ReadFile(ac.RekorPublicKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rekor_public_keys: %w", err)
+		}
+		result.FulcioRoots = string(roots)
+		result.RekorPublicKeys = string(keys)
+	}
+	return result, nil
+}
+
+// getVerified downloads the artifact without unarchiving it, verifies its
+// signature, and only then gets it to its destination.
+func (p *parameters) getVerified(ctx context.Context) error {
+	dir, err := os.MkdirTemp("", "artifact")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	name, err := p.artifactName()
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(dir, name)
+	if err := p.fillCache(ctx, file); err != nil {
+		return err
+	}
+	return p.getFromFile(ctx, file)
+}
+
+// verifyFile verifies the signature of the artifact downloaded to file. The
+// error wraps ErrVerificationFailed if the signature doesn't match.
+func (p *parameters) verifyFile(ctx context.Context, file string) error {
+	v := p.Verify
+	dir, err := os.MkdirTemp("", "signature")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	// keyless cosign signatures are downloaded as part of their bundle
+	source, name := v.Signature, "signature"
+	if v.Bundle != "" {
+		source, name = v.Bundle, "bundle"
+	}
+	signature, err := p.fetch(ctx, source, filepath.Join(dir, name))
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case v.Type == structs.ArtifactVerifyTypeGPG:
+		err = verifyGPG(v.PublicKey, content, signature)
+	case v.Type == structs.ArtifactVerifyTypeCosign && v.Bundle != "":
+		err = verifyCosignBundle(v, content, signature)
+	case v.Type == structs.ArtifactVerifyTypeCosign:
+		err = verifyCosign(v.PublicKey, content, signature)
+	default:
+		err = fmt.Errorf("unsupported signature type %q", v.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrVerificationFailed, err)
+	}
+	return nil
+}
+
+// fetch downloads the file at source, such as a signature, and returns its
+// content.
+func (p *parameters) fetch(ctx context.Context, source, dst string) ([]byte, error) {
+	c := p.client(ctx)
+	c.Src = source
+	c.Dst = dst
+	c.Mode = getter.ClientModeFile
+	if err := c.Get(); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(dst)
+}
+
+// verifyGPG verifies the armored or binary detached GPG signature of content
+// against the armored key ring.
+func verifyGPG(keyRing string, content, signature []byte) error {
+	keys, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyRing))
+	if err != nil {
+		return fmt.Errorf("invalid public key: %v", err)
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN PGP")) {
+		_, err = openpgp.CheckArmoredDetachedSignature(keys, bytes.NewReader(content), bytes.NewReader(signature))
+	} else {
+		_, err = openpgp.CheckDetachedSignature(keys, bytes.NewReader(content), bytes.NewReader(signature))
+	}
+	return err
+}
+
+// verifyCosign verifies the base64 encoded cosign signature of content
+// against the PEM encoded public key.
+func verifyCosign(publicKey string, content, signature []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %v", err)
+	}
+
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return errors.New("invalid public key: no PEM block")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %v", err)
+	}
+	return verifySignature(pub, content, sig)
+}
+
+// cosignBundle is the bundle of a keyless cosign signature, as output by
+// cosign sign-blob --bundle.
+type cosignBundle struct {
+	Base64Signature string       `json:"base64Signature"`
+	Cert            string       `json:"cert"`
+	RekorBundle     *rekorBundle `json:"rekorBundle"`
+}
+
+// rekorBundle is the proof that a signature was recorded in a Rekor
+// transparency log: the entry of the log and the signed entry timestamp the
+// log returned for it.
+type rekorBundle struct {
+	SignedEntryTimestamp []byte       `json:"SignedEntryTimestamp"`
+	Payload              rekorPayload `json:"Payload"`
+}
+
+// rekorPayload is the log entry signed by the signed entry timestamp. Its
+// fields are sorted by name so that it marshals to canonical JSON, which is
+// what the log signs.
+type rekorPayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// hashedRekord is the body of the Rekor log entry of a signature.
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   string `json:"content"`
+			PublicKey struct {
+				Content string `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// verifyCosignBundle verifies the keyless cosign signature of content in the
+// bundle. The certificate of the signature must be issued by the Fulcio roots
+// of the client for the expected identity, and the signature must be recorded
+// in a trusted Rekor transparency log while the certificate was valid.
+func verifyCosignBundle(v *verification, content, bundle []byte) error {
+	var b cosignBundle
+	if err := json.Unmarshal(bundle, &b); err != nil {
+		return fmt.Errorf("invalid bundle: %v", err)
+	}
+	if b.RekorBundle == nil {
+		return errors.New("bundle has no transparency log entry")
+	}
+	sig, err := base64.StdEncoding.DecodeString(b.Base64Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %v", err)
+	}
+	cert, err := parseCertificate(b.Cert)
+	if err != nil {
+		return err
+	}
+
+	signedAt, err := verifyRekorEntry(v.RekorPublicKeys, b.RekorBundle, content, sig, cert)
+	if err != nil {
+		return err
+	}
+	if err := verifyFulcioCertificate(v, cert, signedAt); err != nil {
+		return err
+	}
+	return verifySignature(cert.PublicKey, content, sig)
+}
+
+// parseCertificate parses the base64 encoded PEM certificate of a bundle.
+func parseCertificate(encoded string) (*x509.Certificate, error) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %v", err)
+	}
+	block, _ := pem.Decode(decoded)
+	if block == nil {
+		return nil, errors.New("invalid certificate: no PEM block")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %v", err)
+	}
+	return cert, nil
+}
+
+// verifyRekorEntry verifies that the log entry of the bundle is signed by one
+// of the Rekor public keys of the client, and that it records the signature
+// and certificate of content. It returns the time the entry was added to the
+// log.
+func verifyRekorEntry(publicKeys string, rb *rekorBundle, content, sig []byte, cert *x509.Certificate) (time.Time, error) {
+	keys := make(map[string]crypto.PublicKey)
+	rest := []byte(publicKeys)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid rekor_public_keys: %v", err)
+		}
+		// the ID of a log is the SHA256 digest of its DER encoded public key
+		id := sha256.Sum256(block.Bytes)
+		keys[hex.EncodeToString(id[:])] = key
+	}
+
+	key, ok := keys[rb.Payload.LogID]
+	if !ok {
+		return time.Time{}, fmt.Errorf("transparency log %q is not trusted", rb.Payload.LogID)
+	}
+	payload, err := json.Marshal(rb.Payload)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := verifySignature(key, payload, rb.SignedEntryTimestamp); err != nil {
+		return time.Time{}, fmt.Errorf("invalid signed entry timestamp: %v", err)
+	}
+
+	body, err := base64.StdEncoding.DecodeString(rb.Payload.Body)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log entry: %v", err)
+	}
+	var entry hashedRekord
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log entry: %v", err)
+	}
+	if entry.Kind != "hashedrekord" {
+		return time.Time{}, fmt.Errorf("unsupported transparency log entry kind %q", entry.Kind)
+	}
+
+	digest := sha256.Sum256(content)
+	if h := entry.Spec.Data.Hash; h.Algorithm != "sha256" || h.Value != hex.EncodeToString(digest[:]) {
+		return time.Time{}, errors.New("transparency log entry does not match the artifact")
+	}
+	entrySig, err := base64.StdEncoding.DecodeString(entry.Spec.Signature.Content)
+	if err != nil || !bytes.Equal(entrySig, sig) {
+		return time.Time{}, errors.New("transparency log entry does not match the signature")
+	}
+	entryCert, err := parseCertificate(entry.Spec.Signature.PublicKey.Content)
+	if err != nil || !entryCert.Equal(cert) {
+		return time.Time{}, errors.New("transparency log entry does not match the certificate")
+	}
+
+	return time.Unix(rb.Payload.IntegratedTime, 0), nil
+}
+
+// verifyFulcioCertificate verifies that the certificate of a keyless cosign
+// signature chains to the Fulcio roots of the client, and was issued for the
+// expected identity and OIDC issuer. Since Fulcio certificates are short
+// lived, the chain is verified at the time the signature was recorded in the
+// transparency log.
+func verifyFulcioCertificate(v *verification, cert *x509.Certificate, signedAt time.Time) error {
+	roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+	rest := []byte(v.FulcioRoots)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		ca, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid fulcio_roots: %v", err)
+		}
+		if bytes.Equal(ca.RawIssuer, ca.RawSubject) {
+			roots.AddCert(ca)
+		} else {
+			intermediates.AddCert(ca)
+		}
+	}
+
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   signedAt,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("certificate is not valid for the Fulcio roots when the signature was logged: %v", err)
+	}
+
+	identities := slices.Clone(cert.EmailAddresses)
+	for _, u := range cert.URIs {
+		identities = append(identities, u.String())
+	}
+	if !slices.Contains(identities, v.CertificateIdentity) {
+		return fmt.Errorf("certificate identities %v do not match %q", identities, v.CertificateIdentity)
+	}
+
+	issuer := ""
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidFulcioIssuerV2):
+			var s string
+			if _, err := asn1.Unmarshal(ext.Value, &s); err == nil {
+				issuer = s
+			}
+		case ext.Id.Equal(oidFulcioIssuer) && issuer == "":
+			issuer = string(ext.Value)
+		}
+	}
+	if issuer != v.CertificateOIDCIssuer {
+		return fmt.Errorf("certificate OIDC issuer %q does not match %q", issuer, v.CertificateOIDCIssuer)
+	}
+	return nil
+}
+
+// verifySignature verifies the signature of content by the public key, over
+// the SHA256 digest of content for ECDSA and RSA keys.
+func verifySignature(pub crypto.PublicKey, content, sig []byte) error {
+	digest := sha256.Sum256(content)
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], sig) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+			return errors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, content, sig) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package getter
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"golang.org/x/crypto/openpgp"       //nolint:staticcheck // detached signatures only
+	"golang.org/x/crypto/openpgp/armor" //nolint:staticcheck // detached signatures only
+)
+
+// cosignSign returns a PEM encoded ECDSA public key and the base64 encoded
+// cosign signature of content.
+func cosignSign(t *testing.T, content []byte) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	must.NoError(t, err)
+
+	digest := sha256.Sum256(content)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	must.NoError(t, err)
+
+	pub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	return string(pub), base64.StdEncoding.EncodeToString(sig)
+}
+
+func TestVerifyCosign(t *testing.T) {
+	ci.Parallel(t)
+
+	content := []byte("artifact")
+	pub, sig := cosignSign(t, content)
+
+	must.NoError(t, verifyCosign(pub, content, []byte(sig)))
+	must.ErrorContains(t, verifyCosign(pub, []byte("tampered"), []byte(sig)), "invalid signature")
+
+	// signed by another key
+	other, _ := cosignSign(t, content)
+	must.ErrorContains(t, verifyCosign(other, content, []byte(sig)), "invalid signature")
+}
+
+func TestVerifyGPG(t *testing.T) {
+	ci.Parallel(t)
+
+	entity, err := openpgp.NewEntity("nomad", "", "nomad@example.com", nil)
+	must.NoError(t, err)
+	var keyRing bytes.Buffer
+	w, err := armor.Encode(&keyRing, openpgp.PublicKeyType, nil)
+	must.NoError(t, err)
+	must.NoError(t, entity.Serialize(w))
+	must.NoError(t, w.Close())
+
+	content := []byte("artifact")
+	var sig bytes.Buffer
+	must.NoError(t, openpgp.ArmoredDetachSign(&sig, entity, bytes.NewReader(content), nil))
+
+	must.NoError(t, verifyGPG(keyRing.String(), content, sig.Bytes()))
+	must.Error(t, verifyGPG(keyRing.String(), []byte("tampered"), sig.Bytes()))
+
+	// binary signatures are accepted too
+	var binarySig bytes.Buffer
+	must.NoError(t, openpgp.DetachSign(&binarySig, entity, bytes.NewReader(content), nil))
+	must.NoError(t, verifyGPG(keyRing.String(), content, binarySig.Bytes()))
+}
+
+// keylessSigner signs artifacts the way cosign sign-blob --bundle does
+// without a key, with a certificate issued by a Fulcio root and an entry in a
+// Rekor transparency log.
+type keylessSigner struct {
+	caKey    *ecdsa.PrivateKey
+	ca       *x509.Certificate
+	rekorKey *ecdsa.PrivateKey
+
+	// notBefore and notAfter are the validity of signing certificates
+	notBefore time.Time
+	notAfter  time.Time
+
+	identity string
+	issuer   string
+}
+
+func newKeylessSigner(t *testing.T) *keylessSigner {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	must.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	must.NoError(t, err)
+
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+
+	return &keylessSigner{
+		caKey:     caKey,
+		ca:        ca,
+		rekorKey:  rekorKey,
+		notBefore: time.Now().Add(-10 * time.Minute),
+		notAfter:  time.Now().Add(-time.Minute),
+		identity:  "https://github.com/example/app/.github/workflows/release.yml@refs/heads/main",
+		issuer:    "https://token.actions.githubusercontent.com",
+	}
+}
+
+// verification returns the keyless verification of the artifacts signed by
+// s, trusting its Fulcio root and Rekor log.
+func (s *keylessSigner) verification(t *testing.T) *verification {
+	der, err := x509.MarshalPKIXPublicKey(&s.rekorKey.PublicKey)
+	must.NoError(t, err)
+	return &verification{
+		Type:                  structs.ArtifactVerifyTypeCosign,
+		Bundle:                "bundle",
+		CertificateIdentity:   s.identity,
+		CertificateOIDCIssuer: s.issuer,
+		FulcioRoots:           string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.ca.Raw})),
+		RekorPublicKeys:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}
+}
+
+// sign returns the bundle of the signature of content, recorded in the log at
+// integratedTime.
+func (s *keylessSigner) sign(t *testing.T, content []byte, integratedTime time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	issuer, err := asn1.Marshal(s.issuer)
+	must.NoError(t, err)
+	identity, err := url.Parse(s.identity)
+	must.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       s.notBefore,
+		NotAfter:        s.notAfter,
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:            []*url.URL{identity},
+		ExtraExtensions: []pkix.Extension{{Id: oidFulcioIssuerV2, Value: issuer}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, s.ca, &key.PublicKey, s.caKey)
+	must.NoError(t, err)
+	cert := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	digest := sha256.Sum256(content)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	must.NoError(t, err)
+
+	var entry hashedRekord
+	entry.Kind = "hashedrekord"
+	entry.Spec.Data.Hash.Algorithm = "sha256"
+	entry.Spec.Data.Hash.Value = hex.EncodeToString(digest[:])
+	entry.Spec.Signature.Content = base64.StdEncoding.EncodeToString(sig)
+	entry.Spec.Signature.PublicKey.Content = cert
+	body, err := json.Marshal(entry)
+	must.NoError(t, err)
+
+	rekorDER, err := x509.MarshalPKIXPublicKey(&s.rekorKey.PublicKey)
+	must.NoError(t, err)
+	logID := sha256.Sum256(rekorDER)
+	payload := rekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: integratedTime.Unix(),
+		LogID:          hex.EncodeToString(logID[:]),
+		LogIndex:       42,
+	}
+	canonical, err := json.Marshal(payload)
+	must.NoError(t, err)
+	canonicalDigest := sha256.Sum256(canonical)
+	set, err := ecdsa.SignASN1(rand.Reader, s.rekorKey, canonicalDigest[:])
+	must.NoError(t, err)
+
+	bundle, err := json.Marshal(cosignBundle{
+		Base64Signature: base64.StdEncoding.EncodeToString(sig),
+		Cert:            cert,
+		RekorBundle:     &rekorBundle{SignedEntryTimestamp: set, Payload: payload},
+	})
+	must.NoError(t, err)
+	return bundle
+}
+
+func TestVerifyCosignBundle(t *testing.T) {
+	ci.Parallel(t)
+
+	content := []byte("artifact")
+	signer := newKeylessSigner(t)
+	signedAt := signer.notBefore.Add(time.Minute)
+	bundle := signer.sign(t, content, signedAt)
+	v := signer.verification(t)
+
+	// the certificate has expired, but was valid when the signature was logged
+	must.NoError(t, verifyCosignBundle(v, content, bundle))
+
+	// tampered artifact
+	must.ErrorContains(t, verifyCosignBundle(v, []byte("tampered"), bundle),
+		"transparency log entry does not match the artifact")
+
+	// wrong identity
+	v2 := *v
+	v2.CertificateIdentity = "someone@example.com"
+	must.ErrorContains(t, verifyCosignBundle(&v2, content, bundle), "do not match")
+
+	// wrong issuer
+	v2 = *v
+	v2.CertificateOIDCIssuer = "https://accounts.google.com"
+	must.ErrorContains(t, verifyCosignBundle(&v2, content, bundle), "OIDC issuer")
+
+	// not issued by the Fulcio roots of the client
+	other := newKeylessSigner(t)
+	v2 = *v
+	v2.FulcioRoots = other.verification(t).FulcioRoots
+	must.ErrorContains(t, verifyCosignBundle(&v2, content, bundle), "not valid for the Fulcio roots")
+
+	// not logged in a transparency log trusted by the client
+	v2 = *v
+	v2.RekorPublicKeys = other.verification(t).RekorPublicKeys
+	must.ErrorContains(t, verifyCosignBundle(&v2, content, bundle), "is not trusted")
+
+	// logged after the certificate expired
+	late := signer.sign(t, content, time.Now())
+	must.ErrorContains(t, verifyCosignBundle(v, content, late), "not valid for the Fulcio roots")
+
+	// the log entry was changed after it was signed by the log
+	var b cosignBundle
+	must.NoError(t, json.Unmarshal(bundle, &b))
+	b.RekorBundle.Payload.IntegratedTime++
+	changed, err := json.Marshal(b)
+	must.NoError(t, err)
+	must.ErrorContains(t, verifyCosignBundle(v, content, changed), "invalid signed entry timestamp")
+
+	// without a log entry
+	b.RekorBundle = nil
+	unlogged, err := json.Marshal(b)
+	must.NoError(t, err)
+	must.ErrorContains(t, verifyCosignBundle(v, content, unlogged), "no transparency log entry")
+}
+
+func TestParameters_getVerified(t *testing.T) {
+	ci.Parallel(t)
+
+	content := []byte("signed artifact")
+	pub, sig := cosignSign(t, content)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app.bin":
+			w.Write(content)
+		case "/app.bin.sig":
+			w.Write([]byte(sig))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	get := func(publicKey string) (string, error) {
+		dst := filepath.Join(t.TempDir(), "app.bin")
+		p := &parameters{
+			HTTPReadTimeout: 10 * time.Second,
+			HTTPMaxBytes:    1 << 20,
+			Mode:            getter.ClientModeFile,
+			Source:          srv.URL + "/app.bin",
+			Destination:     dst,
+			Verify: &verification{
+				Type:      structs.ArtifactVerifyTypeCosign,
+				Signature: srv.URL + "/app.bin.sig",
+				PublicKey: publicKey,
+			},
+		}
+		return dst, p.getVerified(context.Background())
+	}
+
+	dst, err := get(pub)
+	must.NoError(t, err)
+	must.FileContains(t, dst, "signed artifact")
+
+	// the artifact is not made available if its signature doesn't match
+	other, _ := cosignSign(t, content)
+	dst, err = get(other)
+	must.ErrorIs(t, err, ErrVerificationFailed)
+	must.FileNotExists(t, dst)
+}
@@ -4,7 +4,9 @@
 package getter
 
 import (
+	"errors"
 	"os"
+	"slices"
 
 	"github.com/hashicorp/nomad/helper/subproc"
 )
@@ -32,9 +34,22 @@ func init() {
 		// force quit after maximum timeout exceeded
 		subproc.SetExpiration(ctx)
 
+		// the cache dirs must exist to be added to the sandbox
+		extraPaths := slices.Clone(env.FilesystemIsolationExtraPaths)
+		for _, dir := range []string{env.OCICacheDir, env.CacheDir} {
+			if dir == "" {
+				continue
+			}
+			if err := os.MkdirAll(dir, 0o700); err != nil {
+				subproc.Print("failed to create cache dir: %v", err)
+				return subproc.ExitFailure
+			}
+			extraPaths = append(extraPaths, "d:rwc:"+dir)
+		}
+
 		// sandbox the host filesystem for this process
 		if !env.DisableFilesystemIsolation {
-			if err := lockdown(env.AllocDir, env.TaskDir, env.FilesystemIsolationExtraPaths); err != nil {
+			if err := lockdown(env.AllocDir, env.TaskDir, extraPaths); err != nil {
 				subproc.Print("failed to sandbox %s process: %v", SubCommand, err)
 				return subproc.ExitFailure
 			}
@@ -45,8 +60,20 @@ func init() {
 		// headers were already replaced and are usable now
 		c := env.client(ctx)
 
-		// run the go-getter client
-		if err := c.Get(); err != nil {
+		// run the go-getter client, through the shared cache if the
+		// artifact can be cached
+		get := c.Get
+		switch {
+		case env.CacheKey != "":
+			get = func() error { return env.getCached(ctx) }
+		case env.Verify != nil:
+			get = func() error { return env.getVerified(ctx) }
+		}
+		if err := get(); err != nil {
+			if errors.Is(err, ErrVerificationFailed) {
+				subproc.Print("failed to verify artifact: %v", err)
+				return exitVerificationFailed
+			}
 			subproc.Print("failed to download artifact: %v", err)
 			return subproc.ExitFailure
 		}
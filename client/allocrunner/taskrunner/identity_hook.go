@@ -157,6 +157,12 @@ func (h *identityHook) watchIdentity(wid *structs.WorkloadIdentity, runCh chan s
 				continue
 			}
 
+			// The change_mode of identities exchanged for AWS credentials is
+			// applied by the aws_identity hook when the credentials refresh
+			if wid.AWS != nil {
+				continue
+			}
+
 			switch wid.ChangeMode {
 			case structs.WIChangeModeRestart:
 				const noFailure = false
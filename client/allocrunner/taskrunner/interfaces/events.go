@@ -8,3 +8,9 @@ import "github.com/hashicorp/nomad/nomad/structs"
 type EventEmitter interface {
 	EmitEvent(event *structs.TaskEvent)
 }
+
+// TemplateStateUpdater is used to record the render state of a task's
+// templates in the task state.
+type TemplateStateUpdater interface {
+	SetTemplateState(states []*structs.TemplateState)
+}
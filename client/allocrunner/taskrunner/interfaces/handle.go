@@ -5,6 +5,8 @@ package interfaces
 
 import (
 	"time"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
 // ScriptExecutor is an interface that supports Exec()ing commands in the
@@ -12,3 +14,9 @@ import (
 type ScriptExecutor interface {
 	Exec(timeout time.Duration, cmd string, args []string) ([]byte, int, error)
 }
+
+// TaskInspector is an interface that supports inspecting the status of a task
+// reported by its driver. Split out of DriverHandle to ease testing.
+type TaskInspector interface {
+	Inspect() (*drivers.TaskStatus, error)
+}
@@ -10,12 +10,15 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/logmon"
+	"github.com/hashicorp/nomad/client/logmon/logsink"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	bstructs "github.com/hashicorp/nomad/plugins/base/structs"
@@ -192,7 +195,12 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		}
 	}
 
-	err := h.logmon.Start(&logmon.LogConfig{
+	sinks, err := h.logSinks(req.Task)
+	if err != nil {
+		return structs.NewRecoverableError(err, false)
+	}
+
+	err = h.logmon.Start(&logmon.LogConfig{
 		LogDir:        h.config.logDir,
 		StdoutLogFile: fmt.Sprintf("%s.stdout", req.Task.Name),
 		StderrLogFile: fmt.Sprintf("%s.stderr", req.Task.Name),
@@ -200,6 +208,9 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		StderrFifo:    h.config.stderrFifo,
 		MaxFiles:      req.Task.LogConfig.MaxFiles,
 		MaxFileSizeMB: req.Task.LogConfig.MaxFileSizeMB,
+		Sinks:         sinks,
+		Labels:        h.logLabels(req.Task),
+		Format:        req.Task.LogConfig.Format,
 	})
 	if err != nil {
 		h.logger.Error("failed to start logmon", "error", err)
@@ -209,6 +220,47 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 	return nil
 }
 
+// logSinks returns the sinks the logs of the task are shipped to: the sinks of
+// the client followed by the sink of the task, if any. The sink of the task may
+// only ship logs to the addresses allowed by the client.
+func (h *logmonHook) logSinks(task *structs.Task) ([]*logsink.Config, error) {
+	sinks := helper.CopySlice(h.runner.clientConfig.LogSinks)
+
+	if sink := task.LogConfig.Sink; sink != nil {
+		if sink.Address != "" &&
+			!slices.Contains(h.runner.clientConfig.LogSinkAllowedAddresses, sink.Address) {
+			return nil, fmt.Errorf("log sink address %q is not allowed by the client", sink.Address)
+		}
+
+		// Paths of file sinks of jobs are relative to the log dir, which
+		// logmon opens them in
+		sinks = append(sinks, &logsink.Config{
+			Type:     sink.Type,
+			Address:  sink.Address,
+			Path:     sink.Path,
+			Tag:      sink.Tag,
+			Facility: sink.Facility,
+		})
+	}
+	return sinks, nil
+}
+
+// logLabels returns the labels attached to the log lines shipped to sinks.
+func (h *logmonHook) logLabels(task *structs.Task) map[string]string {
+	alloc := h.runner.Alloc()
+	labels := map[string]string{
+		"alloc_id":  alloc.ID,
+		"job":       alloc.JobID,
+		"namespace": alloc.Namespace,
+		"group":     alloc.TaskGroup,
+		"task":      task.Name,
+	}
+	if alloc.NodeName != "" {
+		labels["node"] = alloc.NodeName
+	}
+	return labels
+}
+
 func (h *logmonHook) Stop(_ context.Context, req *interfaces.TaskStopRequest, _ *interfaces.TaskStopResponse) error {
 	if h.isLoggingDisabled() {
 		return nil
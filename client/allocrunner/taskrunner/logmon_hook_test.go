@@ -13,8 +13,11 @@ import (
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/logmon/logsink"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
@@ -71,7 +74,11 @@ func TestTaskRunner_LogmonHook_StartStop(t *testing.T) {
 	dir := t.TempDir()
 
 	hookConf := newLogMonHookConfig(task.Name, task.LogConfig, dir)
-	runner := &TaskRunner{logmonHookConfig: hookConf}
+	runner := &TaskRunner{
+		logmonHookConfig: hookConf,
+		clientConfig:     &config.Config{},
+		alloc:            alloc,
+	}
 	hook := newLogMonHook(runner, testlog.HCLogger(t))
 
 	req := interfaces.TaskPrestartRequest{
@@ -105,6 +112,60 @@ func TestTaskRunner_LogmonHook_StartStop(t *testing.T) {
 	require.NoError(t, hook.Stop(context.Background(), &stopReq, nil))
 }
 
+// TestTaskRunner_LogmonHook_Sinks asserts that the sinks of the client and
+// the task are passed to logmon with the labels of the task.
+func TestTaskRunner_LogmonHook_Sinks(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	alloc.NodeName = "node1"
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.LogConfig.Sink = &structs.LogSink{
+		Type: structs.LogSinkTypeFile,
+		Path: "web.json",
+	}
+
+	dir := t.TempDir()
+
+	clientSink := &logsink.Config{Type: logsink.TypeSyslog, Address: "udp://127.0.0.1:514"}
+	hookConf := newLogMonHookConfig(task.Name, task.LogConfig, dir)
+	runner := &TaskRunner{
+		logmonHookConfig: hookConf,
+		clientConfig:     &config.Config{LogSinks: []*logsink.Config{clientSink}},
+		alloc:            alloc,
+	}
+	hook := newLogMonHook(runner, testlog.HCLogger(t))
+
+	sinks, err := hook.logSinks(task)
+	must.NoError(t, err)
+	must.Eq(t, []*logsink.Config{
+		clientSink,
+		{Type: logsink.TypeFile, Path: "web.json"},
+	}, sinks)
+
+	// network sinks of tasks must be allowed by the client
+	task.LogConfig.Sink = &structs.LogSink{
+		Type:    structs.LogSinkTypeSyslog,
+		Address: "tcp://10.0.0.1:514",
+	}
+	_, err = hook.logSinks(task)
+	must.ErrorContains(t, err, `log sink address "tcp://10.0.0.1:514" is not allowed`)
+
+	runner.clientConfig.LogSinkAllowedAddresses = []string{"tcp://10.0.0.1:514"}
+	sinks, err = hook.logSinks(task)
+	must.NoError(t, err)
+	must.Len(t, 2, sinks)
+
+	must.Eq(t, map[string]string{
+		"alloc_id":  alloc.ID,
+		"job":       alloc.JobID,
+		"namespace": alloc.Namespace,
+		"group":     alloc.TaskGroup,
+		"task":      task.Name,
+		"node":      "node1",
+	}, hook.logLabels(task))
+}
+
 // TestTaskRunner_LogmonHook_Disabled asserts that no logmon running or expected
 // by any of the lifecycle hooks.
 func TestTaskRunner_LogmonHook_Disabled(t *testing.T) {
@@ -117,7 +178,11 @@ func TestTaskRunner_LogmonHook_Disabled(t *testing.T) {
 	dir := t.TempDir()
 
 	hookConf := newLogMonHookConfig(task.Name, task.LogConfig, dir)
-	runner := &TaskRunner{logmonHookConfig: hookConf}
+	runner := &TaskRunner{
+		logmonHookConfig: hookConf,
+		clientConfig:     &config.Config{},
+		alloc:            alloc,
+	}
 	hook := newLogMonHook(runner, testlog.HCLogger(t))
 
 	req := interfaces.TaskPrestartRequest{Task: task}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/testutil"
@@ -36,7 +37,11 @@ func TestTaskRunner_LogmonHook_StartCrashStop(t *testing.T) {
 	dir := t.TempDir()
 
 	hookConf := newLogMonHookConfig(task.Name, task.LogConfig, dir)
-	runner := &TaskRunner{logmonHookConfig: hookConf}
+	runner := &TaskRunner{
+		logmonHookConfig: hookConf,
+		clientConfig:     &config.Config{},
+		alloc:            alloc,
+	}
 	hook := newLogMonHook(runner, testlog.HCLogger(t))
 
 	req := interfaces.TaskPrestartRequest{
@@ -101,7 +106,11 @@ func TestTaskRunner_LogmonHook_ShutdownMidStart(t *testing.T) {
 	dir := t.TempDir()
 
 	hookConf := newLogMonHookConfig(task.Name, task.LogConfig, dir)
-	runner := &TaskRunner{logmonHookConfig: hookConf}
+	runner := &TaskRunner{
+		logmonHookConfig: hookConf,
+		clientConfig:     &config.Config{},
+		alloc:            alloc,
+	}
 	hook := newLogMonHook(runner, testlog.HCLogger(t))
 
 	req := interfaces.TaskPrestartRequest{
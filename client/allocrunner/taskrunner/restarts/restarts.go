@@ -31,6 +31,11 @@ func NewRestartTracker(policy *structs.RestartPolicy, jobType string, tlc *struc
 		onSuccess = false
 	}
 
+	// Init tasks should never be restarted on success
+	if tlc != nil && tlc.Hook == structs.TaskLifecycleHookPrestartInit {
+		onSuccess = false
+	}
+
 	// Prestart sidecars should get restarted on success
 	if tlc != nil && tlc.Hook == structs.TaskLifecycleHookPrestart {
 		onSuccess = tlc.Sidecar
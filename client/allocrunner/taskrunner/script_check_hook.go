@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/client/taskenv"
 	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
 var _ interfaces.TaskPoststartHook = &scriptCheckHook{}
@@ -35,7 +36,8 @@ type scriptCheckHookConfig struct {
 }
 
 // scriptCheckHook implements a task runner hook for running script
-// checks in the context of a task
+// checks in the context of a task, and for reporting the health status of
+// tasks for docker checks
 type scriptCheckHook struct {
 	consul serviceregistration.Handler
 
@@ -51,8 +53,9 @@ type scriptCheckHook struct {
 	shutdownCh   chan struct{} // closed when all scripts should shutdown
 
 	// The following fields can be changed by Update()
-	driverExec tinterfaces.ScriptExecutor
-	taskEnv    *taskenv.TaskEnv
+	driverExec    tinterfaces.ScriptExecutor
+	driverInspect tinterfaces.TaskInspector
+	taskEnv       *taskenv.TaskEnv
 
 	// These maintain state and are populated by Poststart() or Update()
 	scripts        map[string]*scriptCheck
@@ -106,11 +109,12 @@ func (h *scriptCheckHook) Poststart(ctx context.Context, req *interfaces.TaskPos
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if req.DriverExec == nil {
+	if req.DriverExec == nil && req.DriverInspect == nil {
 		h.logger.Debug("driver doesn't support script checks")
 		return nil
 	}
 	h.driverExec = req.DriverExec
+	h.driverInspect = req.DriverInspect
 	h.taskEnv = req.TaskEnv
 
 	return h.upsertChecks()
@@ -190,7 +194,8 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 	interpolatedTaskServices := taskenv.InterpolateServices(h.taskEnv, h.task.Services)
 	for _, service := range interpolatedTaskServices {
 		for _, check := range service.Checks {
-			if check.Type != structs.ServiceCheckScript {
+			driverExec := h.checkExec(check)
+			if driverExec == nil {
 				continue
 			}
 			serviceID := serviceregistration.MakeAllocServiceID(
@@ -202,7 +207,7 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 				check:           check,
 				serviceID:       serviceID,
 				ttlUpdater:      h.consul,
-				driverExec:      h.driverExec,
+				driverExec:      driverExec,
 				taskEnv:         h.taskEnv,
 				logger:          h.logger,
 				shutdownCh:      h.shutdownCh,
@@ -226,7 +231,8 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 	interpolatedGroupServices := taskenv.InterpolateServices(h.taskEnv, tg.Services)
 	for _, service := range interpolatedGroupServices {
 		for _, check := range service.Checks {
-			if check.Type != structs.ServiceCheckScript {
+			driverExec := h.checkExec(check)
+			if driverExec == nil {
 				continue
 			}
 			if !h.associated(h.task.Name, service.TaskName, check.TaskName) {
@@ -242,7 +248,7 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 				check:           check,
 				serviceID:       serviceID,
 				ttlUpdater:      h.consul,
-				driverExec:      h.driverExec,
+				driverExec:      driverExec,
 				taskEnv:         h.taskEnv,
 				logger:          h.logger,
 				shutdownCh:      h.shutdownCh,
@@ -256,6 +262,21 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 	return scriptChecks
 }
 
+// checkExec returns the executor running the check, or nil if the check
+// isn't a script or docker check or can't be run by the task driver.
+func (h *scriptCheckHook) checkExec(check *structs.ServiceCheck) tinterfaces.ScriptExecutor {
+	switch check.Type {
+	case structs.ServiceCheckScript:
+		return h.driverExec
+	case structs.ServiceCheckDocker:
+		if h.driverInspect == nil {
+			return nil
+		}
+		return &healthCheckExec{inspector: h.driverInspect}
+	}
+	return nil
+}
+
 // associated returns true if the script check is associated with the task. This
 // would be the case if the check.task is the same as task, or if the service.task
 // is the same as the task _and_ check.task is not configured (i.e. the check
@@ -431,3 +452,35 @@ func (sc *scriptCheck) updateTTL(ctx context.Context, msg, state string) error {
 		}
 	}
 }
+
+// healthCheckExec is the executor of docker checks. Instead of running a
+// command, it reports the status of the health check run by the task driver
+// as the exit code of a script check would: 0 when the task is healthy, 1
+// while the health check is starting, and 2 when the task is unhealthy.
+type healthCheckExec struct {
+	inspector tinterfaces.TaskInspector
+}
+
+func (e *healthCheckExec) Exec(time.Duration, string, []string) ([]byte, int, error) {
+	status, err := e.inspector.Inspect()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	health := status.DriverAttributes[drivers.TaskAttrHealthStatus]
+	output := status.DriverAttributes[drivers.TaskAttrHealthOutput]
+	if output == "" {
+		output = "task is " + health
+	}
+
+	switch health {
+	case drivers.TaskHealthHealthy:
+		return []byte(output), 0, nil
+	case drivers.TaskHealthStarting:
+		return []byte(output), 1, nil
+	case drivers.TaskHealthUnhealthy:
+		return []byte(output), 2, nil
+	default:
+		return []byte("task driver doesn't report a health status for the task"), 2, nil
+	}
+}
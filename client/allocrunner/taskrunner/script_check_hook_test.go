@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
@@ -331,3 +332,119 @@ func TestScript_associated(t *testing.T) {
 		require.False(t, new(scriptCheckHook).associated("task1", "task2", "task2"))
 	})
 }
+
+// fakeTaskInspector implements the TaskInspector interface to mock out the
+// health status reported by task drivers.
+type fakeTaskInspector struct {
+	status    *drivers.TaskStatus
+	err       error
+	inspected atomic.Int32
+}
+
+func (f *fakeTaskInspector) Inspect() (*drivers.TaskStatus, error) {
+	f.inspected.Add(1)
+	return f.status, f.err
+}
+
+func TestScript_HealthCheckExec(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		attrs  map[string]string
+		output string
+		code   int
+	}{
+		{
+			name:   "healthy",
+			attrs:  map[string]string{"health_status": "healthy", "health_output": "ok"},
+			output: "ok",
+			code:   0,
+		},
+		{
+			name:   "starting",
+			attrs:  map[string]string{"health_status": "starting"},
+			output: "task is starting",
+			code:   1,
+		},
+		{
+			name:   "unhealthy",
+			attrs:  map[string]string{"health_status": "unhealthy", "health_output": "connection refused"},
+			output: "connection refused",
+			code:   2,
+		},
+		{
+			name:   "no health check",
+			attrs:  map[string]string{"container_id": "abc"},
+			output: "task driver doesn't report a health status for the task",
+			code:   2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			exec := &healthCheckExec{inspector: &fakeTaskInspector{
+				status: &drivers.TaskStatus{DriverAttributes: tc.attrs},
+			}}
+			output, code, err := exec.Exec(time.Second, "", nil)
+			must.NoError(t, err)
+			must.Eq(t, tc.output, string(output))
+			must.Eq(t, tc.code, code)
+		})
+	}
+
+	exec := &healthCheckExec{inspector: &fakeTaskInspector{err: ErrTaskNotRunning}}
+	_, _, err := exec.Exec(time.Second, "", nil)
+	must.ErrorIs(t, err, ErrTaskNotRunning)
+}
+
+// TestScript_DockerChecks asserts that docker checks are heartbeated with the
+// health status of the task reported by the driver
+func TestScript_DockerChecks(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Services[0].Checks = []*structs.ServiceCheck{{
+		Name:     "container-health",
+		Type:     structs.ServiceCheckDocker,
+		Interval: time.Nanosecond,
+		Timeout:  time.Second,
+	}}
+
+	inspector := &fakeTaskInspector{status: &drivers.TaskStatus{
+		DriverAttributes: map[string]string{"health_status": "unhealthy", "health_output": "exit 1"},
+	}}
+	scHook := newScriptCheckHook(scriptCheckHookConfig{
+		alloc:  alloc,
+		task:   task,
+		consul: regMock.NewServiceRegistrationHandler(logger),
+		logger: logger,
+	})
+	scHook.taskEnv = taskenv.NewBuilder(mock.Node(), alloc, task, "global").Build()
+
+	// docker checks aren't run without an inspector
+	must.MapEmpty(t, scHook.newScriptChecks())
+
+	scHook.driverInspect = inspector
+	checks := scHook.newScriptChecks()
+	must.MapLen(t, 1, checks)
+
+	hb := newFakeHeartbeater()
+	for _, check := range checks {
+		check.ttlUpdater = hb
+		check.callback = newScriptCheckCallback(check)
+
+		handle := check.run()
+		defer handle.cancel()
+
+		select {
+		case update := <-hb.heartbeats:
+			must.Eq(t, heartbeat{check.id, "exit 1", api.HealthCritical}, update)
+		case <-time.After(3 * time.Second):
+			t.Fatal("timed out waiting for docker check")
+		}
+	}
+	must.Positive(t, inspector.inspected.Load())
+}
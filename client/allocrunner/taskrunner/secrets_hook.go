@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	log "github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/users"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// secretsHookEnvName is the name the environment variables of secrets are
+	// stored under in the environment builder. It differs from the name of the
+	// hook so the task runner does not overwrite them with the empty
+	// environment of the prestart response.
+	secretsHookEnvName = "secrets_env"
+)
+
+// validSecretEnvKey matches the variable items that can be exposed as
+// environment variables.
+var validSecretEnvKey = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// secretsHook writes the Nomad Variables referenced by the secret blocks of a
+// task to its secrets directory. The variables are read with the task's
+// workload identity, so the job must have access to them.
+type secretsHook struct {
+	alloc      *structs.Allocation
+	task       *structs.Task
+	rpc        config.RPCer
+	envBuilder *taskenv.Builder
+	logger     log.Logger
+}
+
+func newSecretsHook(alloc *structs.Allocation, task *structs.Task, rpc config.RPCer, envBuilder *taskenv.Builder, logger log.Logger) *secretsHook {
+	h := &secretsHook{
+		alloc:      alloc,
+		task:       task,
+		rpc:        rpc,
+		envBuilder: envBuilder,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (*secretsHook) Name() string {
+	return "secrets"
+}
+
+func (h *secretsHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, _ *interfaces.TaskPrestartResponse) error {
+	env := map[string]string{}
+	for _, secret := range h.task.Secrets {
+		items, err := h.readSecret(secret, req.NomadToken)
+		if err != nil {
+			return structs.NewRecoverableError(
+				fmt.Errorf("failed to read secret %q: %w", secret.Name, err), true)
+		}
+		if err := h.writeSecret(req.TaskDir.SecretsDir, secret, items); err != nil {
+			return err
+		}
+
+		if !secret.Env {
+			continue
+		}
+		for key, value := range items {
+			if !validSecretEnvKey.MatchString(key) {
+				return fmt.Errorf("secret %q item %q is not a valid environment variable name", secret.Name, key)
+			}
+			env[key] = value
+		}
+	}
+
+	// The values are set on the environment builder rather than returned in
+	// the response so they are not persisted in the client state.
+	h.envBuilder.SetHookEnv(secretsHookEnvName, env)
+	return nil
+}
+
+// readSecret reads the items of the variable of a secret.
+func (h *secretsHook) readSecret(secret *structs.Secret, token string) (structs.VariableItems, error) {
+	args := &structs.VariablesReadRequest{
+		Path: secret.Path,
+		QueryOptions: structs.QueryOptions{
+			Region:     h.alloc.Job.Region,
+			Namespace:  h.alloc.Namespace,
+			AuthToken:  token,
+			AllowStale: true,
+		},
+	}
+	var reply structs.VariablesReadResponse
+	if err := h.rpc.RPC(structs.VariablesReadRPCMethod, args, &reply); err != nil {
+		return nil, err
+	}
+	if reply.Data == nil {
+		return nil, fmt.Errorf("variable %q not found", secret.Path)
+	}
+	return reply.Data.Items, nil
+}
+
+// writeSecret writes each item of a secret to a file named after the item in
+// the directory of the secret. Items removed from the variable since the task
+// last started are removed.
+func (h *secretsHook) writeSecret(secretsDir string, secret *structs.Secret, items structs.VariableItems) error {
+	dir := filepath.Join(secretsDir, secret.Name)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove secret %q: %w", secret.Name, err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create secret %q: %w", secret.Name, err)
+	}
+
+	for key, value := range items {
+		if key == "." || key == ".." || filepath.Base(key) != key {
+			return fmt.Errorf("secret %q item %q is not a valid file name", secret.Name, key)
+		}
+		if err := users.WriteFileFor(filepath.Join(dir, key), []byte(value), h.task.User); err != nil {
+			return fmt.Errorf("failed to write secret %q: %w", secret.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+var _ interfaces.TaskPrestartHook = (*secretsHook)(nil)
+
+// mockVariablesRPC serves a fixed set of variables.
+type mockVariablesRPC struct {
+	vars map[string]structs.VariableItems
+	args []*structs.VariablesReadRequest
+}
+
+func (m *mockVariablesRPC) RPC(method string, args, reply any) error {
+	if method != structs.VariablesReadRPCMethod {
+		return errors.New("unexpected method " + method)
+	}
+	req := args.(*structs.VariablesReadRequest)
+	m.args = append(m.args, req)
+	if items, ok := m.vars[req.Path]; ok {
+		reply.(*structs.VariablesReadResponse).Data = &structs.VariableDecrypted{
+			VariableMetadata: structs.VariableMetadata{Namespace: req.Namespace, Path: req.Path},
+			Items:            items,
+		}
+	}
+	return nil
+}
+
+func testSecretsHook(t *testing.T, rpc *mockVariablesRPC, secrets ...*structs.Secret) (*secretsHook, *interfaces.TaskPrestartRequest) {
+	alloc := mock.Alloc()
+	task := alloc.LookupTask("web")
+	task.Secrets = secrets
+
+	envBuilder := taskenv.NewBuilder(mock.Node(), alloc, task, "global")
+	h := newSecretsHook(alloc, task, rpc, envBuilder, testlog.HCLogger(t))
+	req := &interfaces.TaskPrestartRequest{
+		Task:       task,
+		TaskDir:    &allocdir.TaskDir{SecretsDir: t.TempDir()},
+		NomadToken: "token",
+	}
+	return h, req
+}
+
+func TestSecretsHook_Prestart(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := &mockVariablesRPC{vars: map[string]structs.VariableItems{
+		"db/creds": {"DB_USER": "web", "DB_PASSWORD": "hunter2"},
+		"tls":      {"cert.pem": "cert", "key.pem": "key"},
+	}}
+	h, req := testSecretsHook(t, rpc,
+		&structs.Secret{Name: "db", Path: "db/creds", Env: true},
+		&structs.Secret{Name: "tls", Path: "tls"},
+	)
+
+	must.NoError(t, h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{}))
+
+	must.Len(t, 2, rpc.args)
+	must.Eq(t, "token", rpc.args[0].AuthToken)
+	must.Eq(t, h.alloc.Namespace, rpc.args[0].Namespace)
+
+	must.Eq(t, "hunter2", string(testutil.MustReadFile(t, req.TaskDir.SecretsDir, "db", "DB_PASSWORD")))
+	must.Eq(t, "key", string(testutil.MustReadFile(t, req.TaskDir.SecretsDir, "tls", "key.pem")))
+
+	env := h.envBuilder.Build().EnvMap
+	must.Eq(t, "web", env["DB_USER"])
+	must.Eq(t, "hunter2", env["DB_PASSWORD"])
+	must.MapNotContainsKey(t, env, "key.pem")
+
+	// items removed from the variable are removed on restart
+	delete(rpc.vars["tls"], "cert.pem")
+	must.NoError(t, h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{}))
+	must.FileNotExists(t, filepath.Join(req.TaskDir.SecretsDir, "tls", "cert.pem"))
+	must.FileExists(t, filepath.Join(req.TaskDir.SecretsDir, "tls", "key.pem"))
+}
+
+func TestSecretsHook_Prestart_Errors(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := &mockVariablesRPC{vars: map[string]structs.VariableItems{
+		"bad/file": {"../escape": "x"},
+		"bad/env":  {"not-env": "x"},
+	}}
+
+	h, req := testSecretsHook(t, rpc, &structs.Secret{Name: "missing", Path: "missing"})
+	err := h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{})
+	must.ErrorContains(t, err, `failed to read secret "missing": variable "missing" not found`)
+	must.True(t, structs.IsRecoverable(err))
+
+	h, req = testSecretsHook(t, rpc, &structs.Secret{Name: "file", Path: "bad/file"})
+	err = h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{})
+	must.ErrorContains(t, err, "is not a valid file name")
+
+	h, req = testSecretsHook(t, rpc, &structs.Secret{Name: "env", Path: "bad/env", Env: true})
+	err = h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{})
+	must.ErrorContains(t, err, "is not a valid environment variable name")
+}
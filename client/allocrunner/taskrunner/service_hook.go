@@ -80,6 +80,10 @@ type serviceHook struct {
 	// we do not call this multiple times for a single task when not needed.
 	deregistered bool
 
+	// decommissioned tracks whether the allocation is decommissioned, in
+	// which case its services are not registered.
+	decommissioned bool
+
 	hookResources *cstructs.AllocHookResources
 
 	// Since Update() may be called concurrently with any other hook all
@@ -114,6 +118,8 @@ func newServiceHook(c serviceHookConfig) *serviceHook {
 		h.canary = true
 	}
 
+	h.decommissioned = c.alloc.DesiredTransition.ShouldDecommission()
+
 	h.logger = c.logger.Named(h.Name())
 	return h
 }
@@ -133,6 +139,12 @@ func (h *serviceHook) Poststart(ctx context.Context, req *interfaces.TaskPoststa
 	// Ensure deregistered is unset.
 	h.deregistered = false
 
+	// The services of a decommissioned allocation are registered once it is
+	// brought back into service
+	if h.decommissioned {
+		return nil
+	}
+
 	// Create task services struct with request's driver metadata
 	workloadServices := h.getWorkloadServices()
 
@@ -151,6 +163,7 @@ func (h *serviceHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequ
 	// Create old task services struct with request's driver metadata as it
 	// can't change due to Updates
 	oldWorkloadServices := h.getWorkloadServices()
+	decommissioned := h.decommissioned
 
 	if err := h.updateHookFields(req); err != nil {
 		return err
@@ -159,6 +172,20 @@ func (h *serviceHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequ
 	// Create new task services struct with those new values
 	newWorkloadServices := h.getWorkloadServices()
 
+	// Deregister the services of the allocation while it is decommissioned,
+	// and register them again if it is brought back into service
+	switch {
+	case h.decommissioned && !decommissioned:
+		if len(oldWorkloadServices.Services) > 0 {
+			h.serviceRegWrapper.RemoveWorkload(oldWorkloadServices)
+		}
+		return nil
+	case h.decommissioned:
+		return nil
+	case decommissioned:
+		return h.serviceRegWrapper.RegisterWorkload(newWorkloadServices)
+	}
+
 	return h.serviceRegWrapper.UpdateWorkload(oldWorkloadServices, newWorkloadServices)
 }
 
@@ -180,6 +207,7 @@ func (h *serviceHook) updateHookFields(req *interfaces.TaskUpdateRequest) error
 	}
 
 	// Update service hook fields
+	h.decommissioned = req.Alloc.DesiredTransition.ShouldDecommission()
 	h.taskEnv = req.TaskEnv
 	h.services = task.Services
 	h.networks = networks
@@ -0,0 +1,276 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package supervisor runs long lived helper processes inside a task through
+// the task driver's exec API, such as the processes of templates with
+// change_mode "exec_restart".
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// restartBaseBackoff and restartMaxBackoff bound the delay before a
+	// process that exited, or could not be started, is started again
+	restartBaseBackoff = 1 * time.Second
+	restartMaxBackoff  = 30 * time.Second
+
+	// restartResetAfter is how long a process must run before its restart
+	// backoff is reset
+	restartResetAfter = 1 * time.Minute
+)
+
+// Config is used to configure a Supervisor
+type Config struct {
+	// Command is the command and its arguments to run inside the task
+	Command []string
+
+	// ExecHandler returns the handler used to exec into the task, or nil if
+	// the task is not running
+	ExecHandler func() drivermanager.TaskExecHandler
+
+	// Events is used to emit events for the task
+	Events interfaces.EventEmitter
+
+	Logger hclog.Logger
+}
+
+// Supervisor keeps a process running inside a task. The process is started
+// again whenever it exits and can be restarted on demand, for example when a
+// template the process reads has been re-rendered.
+type Supervisor struct {
+	config *Config
+	logger hclog.Logger
+
+	// restartCh is used to ask the running process to be restarted
+	restartCh chan struct{}
+
+	// stopCh is closed to stop the supervisor and the process
+	stopCh chan struct{}
+
+	// doneCh is closed once the supervisor has exited
+	doneCh chan struct{}
+
+	// started and stopped mark whether the supervisor has been started and
+	// stopped
+	started bool
+	stopped bool
+	lock    sync.Mutex
+}
+
+// New returns a new Supervisor. Start must be called to run the process.
+func New(config *Config) *Supervisor {
+	logger := config.Logger
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	return &Supervisor{
+		config:    config,
+		logger:    logger.Named("supervisor").With("command", config.Command[0]),
+		restartCh: make(chan struct{}, 1),
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+}
+
+// Start the supervisor. The process is started as soon as the task is running.
+func (s *Supervisor) Start() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.started || s.stopped {
+		return
+	}
+	s.started = true
+	go s.run()
+}
+
+// Restart the process. It does not block waiting for the process to restart.
+func (s *Supervisor) Restart() {
+	select {
+	case s.restartCh <- struct{}{}:
+	default:
+		// a restart is already pending
+	}
+}
+
+// Stop the process and the supervisor, blocking until the process has been
+// stopped. It is safe to call Stop more than once, or without calling Start.
+func (s *Supervisor) Stop() {
+	s.lock.Lock()
+	if !s.stopped {
+		close(s.stopCh)
+		s.stopped = true
+	}
+	started := s.started
+	s.lock.Unlock()
+
+	if started {
+		<-s.doneCh
+	}
+}
+
+func (s *Supervisor) run() {
+	defer close(s.doneCh)
+
+	var attempt uint64
+	unavailable := false
+	for {
+		handler := s.config.ExecHandler()
+		if handler == nil {
+			// The task is not running yet, or is being restarted. Only log
+			// the first attempt so waiting for the task doesn't flood the
+			// logs.
+			if !unavailable {
+				s.logger.Debug("task exec unavailable, waiting to start process")
+				unavailable = true
+			}
+			if !s.wait(helper.Backoff(restartBaseBackoff, restartMaxBackoff, attempt)) {
+				return
+			}
+			attempt++
+			continue
+		}
+		unavailable = false
+
+		started := time.Now()
+		result, restarted, err := s.exec(handler)
+		if restarted {
+			attempt = 0
+			s.config.Events.EmitEvent(structs.NewTaskEvent(structs.TaskHookMessage).
+				SetDisplayMessage(fmt.Sprintf("Template restarted process %v", s.config.Command)))
+			continue
+		}
+
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+
+		if err != nil {
+			// The process could not be started, for example because the task
+			// driver does not support exec, so keep backing off until it can
+			s.logger.Error("failed to exec process", "error", err,
+				"retry_in", helper.Backoff(restartBaseBackoff, restartMaxBackoff, attempt))
+			s.config.Events.EmitEvent(structs.NewTaskEvent(structs.TaskHookFailed).
+				SetDisplayMessage(fmt.Sprintf("Template failed to exec process %v: %v", s.config.Command, err)))
+		} else {
+			if time.Since(started) > restartResetAfter {
+				attempt = 0
+			}
+			s.logger.Warn("process exited", "result", result)
+			s.config.Events.EmitEvent(structs.NewTaskEvent(structs.TaskHookMessage).
+				SetDisplayMessage(fmt.Sprintf("Template process %v %s, restarting", s.config.Command, result)))
+		}
+
+		if !s.wait(helper.Backoff(restartBaseBackoff, restartMaxBackoff, attempt)) {
+			return
+		}
+		attempt++
+	}
+}
+
+// wait blocks for the given duration or until a restart is requested. It
+// returns false if the supervisor has been stopped.
+func (s *Supervisor) wait(d time.Duration) bool {
+	timer, stop := helper.NewSafeTimer(d)
+	defer stop()
+
+	select {
+	case <-timer.C:
+	case <-s.restartCh:
+	case <-s.stopCh:
+		return false
+	}
+	return true
+}
+
+// exec runs the process until it exits, a restart is requested, or the
+// supervisor is stopped. It returns a description of how the process exited,
+// whether it was stopped for a restart, and the error if the process could
+// not be run.
+func (s *Supervisor) exec(handler drivermanager.TaskExecHandler) (string, bool, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := newExecStream(ctx, s.logger)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- handler(ctx, s.config.Command, false, stream)
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("exited with code %d", stream.exitCode()), false, nil
+	case <-s.restartCh:
+		cancel()
+		<-errCh
+		return "", true, nil
+	case <-s.stopCh:
+		cancel()
+		<-errCh
+		return "", false, nil
+	}
+}
+
+// execStream is a drivers.ExecTaskStream that logs the output of the process
+// and never sends it input.
+type execStream struct {
+	ctx    context.Context
+	logger hclog.Logger
+
+	code int
+	lock sync.Mutex
+}
+
+func newExecStream(ctx context.Context, logger hclog.Logger) *execStream {
+	return &execStream{
+		ctx:    ctx,
+		logger: logger,
+	}
+}
+
+func (e *execStream) Send(msg *drivers.ExecTaskStreamingResponseMsg) error {
+	if msg.Stdout != nil && len(msg.Stdout.Data) > 0 {
+		e.logger.Debug("process output", "stdout", strings.TrimSpace(string(msg.Stdout.Data)))
+	}
+	if msg.Stderr != nil && len(msg.Stderr.Data) > 0 {
+		e.logger.Debug("process output", "stderr", strings.TrimSpace(string(msg.Stderr.Data)))
+	}
+	if msg.Exited && msg.Result != nil {
+		e.lock.Lock()
+		e.code = int(msg.Result.ExitCode)
+		e.lock.Unlock()
+	}
+	return nil
+}
+
+// Recv blocks until the process is stopped since the process does not read
+// any input. Closing stdin early could make some processes exit.
+func (e *execStream) Recv() (*drivers.ExecTaskStreamingRequestMsg, error) {
+	<-e.ctx.Done()
+	return nil, io.EOF
+}
+
+func (e *execStream) exitCode() int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.code
+}
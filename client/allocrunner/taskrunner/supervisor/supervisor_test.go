@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/proto"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// fakeTask is a task whose exec handler runs processes until they are
+// canceled or exited
+type fakeTask struct {
+	lock    sync.Mutex
+	running bool
+	execs   int
+	exitCh  chan int
+}
+
+func newFakeTask() *fakeTask {
+	return &fakeTask{exitCh: make(chan int)}
+}
+
+func (f *fakeTask) handler() drivermanager.TaskExecHandler {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if !f.running {
+		return nil
+	}
+	return f.exec
+}
+
+func (f *fakeTask) exec(ctx context.Context, command []string, tty bool, stream drivers.ExecTaskStream) error {
+	f.lock.Lock()
+	f.execs++
+	f.lock.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case code := <-f.exitCh:
+		return stream.Send(&drivers.ExecTaskStreamingResponseMsg{
+			Exited: true,
+			Result: &proto.ExitResult{ExitCode: int32(code)},
+		})
+	}
+}
+
+func (f *fakeTask) setRunning(running bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.running = running
+}
+
+func (f *fakeTask) getExecs() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.execs
+}
+
+func TestSupervisor_Restart(t *testing.T) {
+	ci.Parallel(t)
+
+	task := newFakeTask()
+	events := trtesting.NewMockTaskHooks()
+	s := New(&Config{
+		Command:     []string{"/bin/helper", "-config", "local/helper.conf"},
+		ExecHandler: task.handler,
+		Events:      events,
+		Logger:      testlog.HCLogger(t),
+	})
+	s.Start()
+	defer s.Stop()
+
+	// the process is started once the task is running
+	task.setRunning(true)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return task.getExecs() == 1 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// a restart stops the running process and starts it again
+	s.Restart()
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return task.getExecs() == 2 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.StrContains(t, events.Events()[0].DisplayMessage, "Template restarted process")
+
+	// a process that exits is started again
+	task.exitCh <- 1
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return task.getExecs() == 3 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.StrContains(t, events.Events()[1].DisplayMessage, "exited with code 1")
+}
+
+func TestSupervisor_Stop(t *testing.T) {
+	ci.Parallel(t)
+
+	task := newFakeTask()
+	task.setRunning(true)
+	s := New(&Config{
+		Command:     []string{"/bin/helper"},
+		ExecHandler: task.handler,
+		Events:      trtesting.NewMockTaskHooks(),
+		Logger:      testlog.HCLogger(t),
+	})
+
+	// stopping a supervisor that was never started does not block
+	New(&Config{
+		Command:     []string{"/bin/helper"},
+		ExecHandler: task.handler,
+		Logger:      testlog.HCLogger(t),
+	}).Stop()
+
+	s.Start()
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return task.getExecs() == 1 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	s.Stop()
+	s.Stop()
+
+	// the process is not started again once stopped
+	s.Restart()
+	time.Sleep(100 * time.Millisecond)
+	must.Eq(t, 1, task.getExecs())
+}
+
+func TestSupervisor_ExecFailed(t *testing.T) {
+	ci.Parallel(t)
+
+	var lock sync.Mutex
+	execs := 0
+	handler := func(context.Context, []string, bool, drivers.ExecTaskStream) error {
+		lock.Lock()
+		defer lock.Unlock()
+		execs++
+		return errors.New("task driver does not support exec")
+	}
+
+	events := trtesting.NewMockTaskHooks()
+	s := New(&Config{
+		Command:     []string{"/bin/helper"},
+		ExecHandler: func() drivermanager.TaskExecHandler { return handler },
+		Events:      events,
+		Logger:      testlog.HCLogger(t),
+	})
+	s.Start()
+	defer s.Stop()
+
+	// the failure is reported and the process is not retried before the
+	// backoff expires
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return len(events.Events()) == 1 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, structs.TaskHookFailed, events.Events()[0].Type)
+	must.StrContains(t, events.Events()[0].DisplayMessage, "does not support exec")
+
+	time.Sleep(100 * time.Millisecond)
+	lock.Lock()
+	defer lock.Unlock()
+	must.Eq(t, 1, execs)
+}
@@ -73,15 +73,23 @@ const (
 	// restarts. It should be exactly 1 as even if multiple restarts have come
 	// we only need to handle the last one.
 	restartChCap = 1
+
+	// cpuBandwidthPeriod is the CFS period in microseconds used to cap the CPU
+	// usage of burstable tasks.
+	cpuBandwidthPeriod = 100000
 )
 
 type TaskRunner struct {
-	// allocID, taskName, taskLeader, and taskResources are immutable so these fields may
+	// allocID, taskName, and taskLeader are immutable so these fields may
 	// be accessed without locks
-	allocID       string
-	taskName      string
-	taskLeader    bool
-	taskResources *structs.AllocatedTaskResources
+	allocID    string
+	taskName   string
+	taskLeader bool
+
+	// taskResources are the resources of the task, which are only updated
+	// when the allocation is resized in place
+	taskResources     *structs.AllocatedTaskResources
+	taskResourcesLock sync.RWMutex
 
 	alloc     *structs.Allocation
 	allocLock sync.Mutex
@@ -201,6 +209,11 @@ type TaskRunner struct {
 	vaultToken     string
 	vaultTokenLock sync.Mutex
 
+	// vaultRoleTokens are the Vault tokens derived for templates that request
+	// their own Vault role, keyed by role. It should be accessed with the
+	// getters and setters.
+	vaultRoleTokens map[string]string
+
 	// nomadToken is the current Nomad workload identity token. It
 	// should be accessed with the getter.
 	nomadToken     string
@@ -286,6 +299,9 @@ type TaskRunner struct {
 	// users manages the pool of dynamic workload users
 	users dynamic.Pool
 
+	// rpcClient is used by hooks to communicate with Nomad servers
+	rpcClient config.RPCer
+
 	// hookStatsHandler is used by certain hooks to emit telemetry data, if the
 	// operator has not disabled this functionality.
 	hookStatsHandler interfaces.HookStatsHandler
@@ -371,6 +387,9 @@ type Config struct {
 
 	// Users manages a pool of dynamic workload users
 	Users dynamic.Pool
+
+	// RPCClient is used by hooks to communicate with Nomad servers
+	RPCClient config.RPCer
 }
 
 func NewTaskRunner(config *Config) (*TaskRunner, error) {
@@ -434,6 +453,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		wranglers:               config.Wranglers,
 		widmgr:                  config.WIDMgr,
 		users:                   config.Users,
+		rpcClient:               config.RPCClient,
 	}
 
 	// Create the logger based on the allocation ID
@@ -483,13 +503,14 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 	// update this with a workload identity if one is available
 	tr.setNomadToken(config.ClientConfig.Node.SecretID)
 
+	// Initialize base labels. Must come before initHooks so hooks can use
+	// tr.baseLabels
+	tr.initLabels()
+
 	// Initialize the runners hooks. Must come after initDriver so hooks
 	// can use tr.driverCapabilities
 	tr.initHooks()
 
-	// Initialize base labels
-	tr.initLabels()
-
 	// Initialize initial task received event
 	tr.appendEvent(structs.NewTaskEvent(structs.TaskReceived))
 
@@ -914,7 +935,7 @@ func (tr *TaskRunner) shouldRestart() (bool, time.Duration) {
 }
 
 func (tr *TaskRunner) assignCgroup(taskConfig *drivers.TaskConfig) {
-	reserveCores := len(tr.taskResources.Cpu.ReservedCores) > 0
+	reserveCores := len(tr.getTaskResources().Cpu.ReservedCores) > 0
 	p := cgroupslib.LinuxResourcesPath(taskConfig.AllocID, taskConfig.Name, reserveCores)
 	taskConfig.Resources.LinuxResources.CpusetCgroupPath = p
 }
@@ -972,8 +993,12 @@ func (tr *TaskRunner) runDriver() error {
 		return nil
 	}
 
-	// Start the job if there's no existing handle (or if RecoverTask failed)
-	handle, net, err := tr.driver.StartTask(taskConfig)
+	// Start the job if there's no existing handle (or if RecoverTask failed),
+	// unless it's restored from the checkpoint of a migrated allocation
+	handle, net, restored := tr.restoreTask(taskConfig)
+	if !restored {
+		handle, net, err = tr.driver.StartTask(taskConfig)
+	}
 	if err != nil {
 		// The plugin has died, try relaunching it
 		if err == bstructs.ErrPluginShutdown {
@@ -1053,10 +1078,16 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 
 	// Wait for task ShutdownDelay after running prekill hooks
 	// This allows for things like service de-registration to run
-	// before waiting to kill task
-	if delay := tr.Task().ShutdownDelay; delay != 0 {
+	// before waiting to kill task. The allocation may have been stopped
+	// with a shutdown delay overriding the one of the task.
+	alloc := tr.Alloc()
+	delay := tr.Task().ShutdownDelay
+	if override, ok := alloc.DesiredTransition.ShutdownDelayOverride(); ok {
+		delay = override
+	}
+	if delay != 0 {
 		var ev *structs.TaskEvent
-		if tr.alloc.DesiredTransition.ShouldIgnoreShutdownDelay() {
+		if alloc.DesiredTransition.ShouldIgnoreShutdownDelay() {
 			tr.logger.Debug("skipping shutdown_delay", "shutdown_delay", delay)
 			ev = structs.NewTaskEvent(structs.TaskSkippingShutdownDelay).
 				SetDisplayMessage(fmt.Sprintf("Skipping shutdown_delay of %s before killing the task.", delay))
@@ -1092,12 +1123,30 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 		return nil
 	}
 
-	// Kill the task using an exponential backoff in-case of failures.
-	result, killErr := tr.killTask(handle, resultCh)
-	if killErr != nil {
-		// We couldn't successfully destroy the resource created.
-		tr.logger.Error("failed to kill task. Resources may have been leaked", "error", killErr)
-		tr.setKillErr(killErr)
+	// Apply the kill policy of the drain of the node, if the allocation is
+	// stopped by one.
+	alloc = tr.Alloc()
+	if signal, ok := alloc.DesiredTransition.KillSignalOverride(); ok {
+		tr.logger.Debug("overriding kill_signal for node drain", "kill_signal", signal)
+		handle.SetKillSignal(signal)
+	}
+	if timeout, ok := alloc.DesiredTransition.KillTimeoutOverride(); ok {
+		tr.logger.Debug("limiting kill_timeout for node drain", "kill_timeout", timeout)
+		handle.LimitKillTimeout(timeout)
+	}
+
+	// Checkpoint the task instead of killing it when its allocation is
+	// migrated, so the replacement allocation can restore it. Otherwise kill
+	// the task using an exponential backoff in-case of failures.
+	var result *drivers.ExitResult
+	var killErr error
+	if !tr.shouldCheckpoint(alloc) || !tr.checkpointTask(handle) {
+		result, killErr = tr.killTask(handle, resultCh)
+		if killErr != nil {
+			// We couldn't successfully destroy the resource created.
+			tr.logger.Error("failed to kill task. Resources may have been leaked", "error", killErr)
+			tr.setKillErr(killErr)
+		}
 	}
 
 	if result != nil {
@@ -1169,6 +1218,37 @@ func (tr *TaskRunner) persistLocalState() error {
 	return tr.stateDB.PutTaskRunnerLocalState(tr.allocID, tr.taskName, tr.localState)
 }
 
+// buildResources returns the resources of the task for its driver.
+func (tr *TaskRunner) buildResources(alloc *structs.Allocation, taskResources *structs.AllocatedTaskResources) *drivers.Resources {
+	ports := alloc.AllocatedResources.Shared.Ports
+
+	memoryLimit := taskResources.Memory.MemoryMB
+	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
+		memoryLimit = max
+	}
+
+	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
+	for i, v := range taskResources.Cpu.ReservedCores {
+		cpusetCpus[i] = fmt.Sprintf("%d", v)
+	}
+
+	cpuPeriod, cpuQuota := tr.cpuBandwidth(taskResources)
+
+	return &drivers.Resources{
+		NomadResources: taskResources,
+		LinuxResources: &drivers.LinuxResources{
+			CPUPeriod:        cpuPeriod,
+			CPUQuota:         cpuQuota,
+			MemoryLimitBytes: memoryLimit * 1024 * 1024,
+			CPUShares:        taskResources.Cpu.CpuShares,
+			CpusetCpus:       strings.Join(cpusetCpus, ","),
+			PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Processors.Topology.UsableCompute()),
+			DiskIO:           tr.diskIOLimits(taskResources),
+		},
+		Ports: &ports,
+	}
+}
+
 // buildTaskConfig builds a drivers.TaskConfig with an unique ID for the task.
 // The ID is unique for every invocation, it is built from the alloc ID, task
 // name and 8 random characters.
@@ -1176,8 +1256,7 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	task := tr.Task()
 	alloc := tr.Alloc()
 	invocationid := uuid.Short()
-	taskResources := tr.taskResources
-	ports := tr.Alloc().AllocatedResources.Shared.Ports
+	taskResources := tr.getTaskResources()
 	env := tr.envBuilder.Build()
 	tr.networkIsolationLock.Lock()
 	defer tr.networkIsolationLock.Unlock()
@@ -1207,36 +1286,17 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		}
 	}
 
-	memoryLimit := taskResources.Memory.MemoryMB
-	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
-		memoryLimit = max
-	}
-
-	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
-	for i, v := range taskResources.Cpu.ReservedCores {
-		cpusetCpus[i] = fmt.Sprintf("%d", v)
-	}
-
 	return &drivers.TaskConfig{
-		ID:            fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
-		Name:          task.Name,
-		JobName:       alloc.Job.Name,
-		JobID:         alloc.Job.ID,
-		TaskGroupName: alloc.TaskGroup,
-		Namespace:     alloc.Namespace,
-		NodeName:      alloc.NodeName,
-		NodeID:        alloc.NodeID,
-		ParentJobID:   alloc.Job.ParentID,
-		Resources: &drivers.Resources{
-			NomadResources: taskResources,
-			LinuxResources: &drivers.LinuxResources{
-				MemoryLimitBytes: memoryLimit * 1024 * 1024,
-				CPUShares:        taskResources.Cpu.CpuShares,
-				CpusetCpus:       strings.Join(cpusetCpus, ","),
-				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Processors.Topology.UsableCompute()),
-			},
-			Ports: &ports,
-		},
+		ID:               fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
+		Name:             task.Name,
+		JobName:          alloc.Job.Name,
+		JobID:            alloc.Job.ID,
+		TaskGroupName:    alloc.TaskGroup,
+		Namespace:        alloc.Namespace,
+		NodeName:         alloc.NodeName,
+		NodeID:           alloc.NodeID,
+		ParentJobID:      alloc.Job.ParentID,
+		Resources:        tr.buildResources(alloc, taskResources),
 		Devices:          tr.hookResources.getDevices(),
 		Mounts:           tr.hookResources.getMounts(),
 		Env:              env.Map(),
@@ -1251,6 +1311,47 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	}
 }
 
+// cpuBandwidth returns the CFS period and quota capping the CPU usage of a
+// burstable task at its maximum CPU, or zeros if the task isn't capped. The
+// quota spans all the cores of the node, so the maximum CPU is converted into
+// a number of cores worth of compute.
+func (tr *TaskRunner) cpuBandwidth(res *structs.AllocatedTaskResources) (int64, int64) {
+	if res.Cpu.CpuMax <= res.Cpu.CpuShares {
+		return 0, 0
+	}
+
+	topology := tr.clientConfig.Node.NodeResources.Processors.Topology
+	total := int64(topology.TotalCompute())
+	if total <= 0 {
+		return 0, 0
+	}
+
+	quota := cpuBandwidthPeriod * res.Cpu.CpuMax * int64(topology.NumCores()) / total
+	return cpuBandwidthPeriod, quota
+}
+
+// diskIOLimits returns the disk I/O limits of the task on the block device of
+// the allocation directory, or nil if the task isn't limited.
+func (tr *TaskRunner) diskIOLimits(res *structs.AllocatedTaskResources) *drivers.DiskIOLimits {
+	if !res.DiskIO.Limited() {
+		return nil
+	}
+
+	device := tr.clientConfig.Node.Attributes["storage.block_device"]
+	if device == "" {
+		tr.logger.Warn("not limiting disk I/O of task, the block device of the allocation directory is unknown")
+		return nil
+	}
+
+	return &drivers.DiskIOLimits{
+		Device:    device,
+		ReadBPS:   res.DiskIO.ReadBPS,
+		WriteBPS:  res.DiskIO.WriteBPS,
+		ReadIOPS:  res.DiskIO.ReadIOPS,
+		WriteIOPS: res.DiskIO.WriteIOPS,
+	}
+}
+
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
 // but before Run so no locks need to be acquired.
 func (tr *TaskRunner) Restore() error {
@@ -1415,6 +1516,44 @@ func (tr *TaskRunner) EmitEvent(event *structs.TaskEvent) {
 	tr.stateUpdater.TaskStateUpdated()
 }
 
+// SetTemplateState records the render state of templates in the TaskState,
+// replacing the state of templates with the same destination so each template
+// manager of the task only updates its own templates. The state is persisted
+// locally and sent to the server, but errors are simply logged.
+func (tr *TaskRunner) SetTemplateState(states []*structs.TemplateState) {
+	tr.stateLock.Lock()
+	defer tr.stateLock.Unlock()
+
+	tr.state.Templates = mergeTemplateStates(tr.state.Templates, states)
+
+	if err := tr.stateDB.PutTaskState(tr.allocID, tr.taskName, tr.state); err != nil {
+		// Only a warning because the next event/state-transition will
+		// try to persist it again.
+		tr.logger.Warn("error persisting template state", "error", err)
+	}
+
+	// Notify the alloc runner of the state change
+	tr.stateUpdater.TaskStateUpdated()
+}
+
+// mergeTemplateStates returns the existing template states updated with the
+// given states by destination, sorted by destination.
+func mergeTemplateStates(existing, updates []*structs.TemplateState) []*structs.TemplateState {
+	merged := make([]*structs.TemplateState, 0, len(existing)+len(updates))
+	for _, state := range existing {
+		if !slices.ContainsFunc(updates, func(u *structs.TemplateState) bool {
+			return u.DestPath == state.DestPath
+		}) {
+			merged = append(merged, state)
+		}
+	}
+	merged = append(merged, updates...)
+	slices.SortFunc(merged, func(a, b *structs.TemplateState) int {
+		return strings.Compare(a.DestPath, b.DestPath)
+	})
+	return merged
+}
+
 // AppendEvent appends a new TaskEvent to this task's TaskState. The actual
 // TaskState.State (pending, running, dead) is not changed. Use UpdateState to
 // transition states.
@@ -1488,10 +1627,59 @@ func (tr *TaskRunner) Update(update *structs.Allocation) {
 
 	// Trigger update hooks if not terminal
 	if !update.TerminalStatus() {
+		tr.updateResources(update, task)
 		tr.triggerUpdateHooks()
 	}
 }
 
+// updateResources applies the resources of the task in the updated allocation
+// to the running task, if they were resized in place.
+func (tr *TaskRunner) updateResources(update *structs.Allocation, task *structs.Task) {
+	if update.AllocatedResources == nil {
+		return
+	}
+	tres, ok := update.AllocatedResources.Tasks[tr.taskName]
+	if !ok {
+		return
+	}
+
+	current := tr.getTaskResources()
+	memoryMB := tres.Memory.MemoryMB - int64(task.Resources.SecretsMB)
+	if tres.Cpu.CpuShares == current.Cpu.CpuShares &&
+		tres.Cpu.CpuMax == current.Cpu.CpuMax &&
+		memoryMB == current.Memory.MemoryMB &&
+		tres.Memory.MemoryMaxMB == current.Memory.MemoryMaxMB {
+		return
+	}
+
+	resized := current.Copy()
+	resized.Cpu.CpuShares = tres.Cpu.CpuShares
+	resized.Cpu.CpuMax = tres.Cpu.CpuMax
+	resized.Memory.MemoryMB = memoryMB
+	resized.Memory.MemoryMaxMB = tres.Memory.MemoryMaxMB
+	tr.setTaskResources(resized)
+
+	// the task picks up the new resources when it is next started
+	handle := tr.getDriverHandle()
+	if handle == nil {
+		return
+	}
+
+	resources := tr.buildResources(update, resized)
+	resources.LinuxResources.CpusetCgroupPath = cgroupslib.LinuxResourcesPath(
+		tr.allocID, tr.taskName, len(resized.Cpu.ReservedCores) > 0)
+
+	event := structs.NewTaskEvent(structs.TaskResized)
+	if err := handle.UpdateResources(resources); err != nil {
+		tr.logger.Error("failed to update task resources", "error", err)
+		event.SetDisplayMessage(fmt.Sprintf("Failed to update resources: %v", err))
+	} else {
+		event.SetDisplayMessage(fmt.Sprintf("Resources updated to %d MHz and %d MB of memory",
+			resized.Cpu.CpuShares, resized.Memory.MemoryMB))
+	}
+	tr.EmitEvent(event)
+}
+
 // SetNetworkIsolation is called by the PreRun allocation hook after configuring
 // the network isolation for the allocation
 func (tr *TaskRunner) SetNetworkIsolation(n *drivers.NetworkIsolationSpec) {
@@ -1551,7 +1739,7 @@ func (tr *TaskRunner) LatestResourceUsage() *cstructs.TaskResourceUsage {
 
 	// Look up device statistics lazily when fetched, as currently we do not emit any stats for them yet
 	if ru != nil && tr.deviceStatsReporter != nil {
-		deviceResources := tr.taskResources.Devices
+		deviceResources := tr.getTaskResources().Devices
 		ru.ResourceUsage.DeviceStats = tr.deviceStatsReporter.LatestDeviceResourceStats(deviceResources)
 	}
 	return ru
@@ -1684,6 +1872,17 @@ func (tr *TaskRunner) DriverCapabilities() (*drivers.Capabilities, error) {
 	return tr.driver.Capabilities()
 }
 
+// TemplateDryRun renders the task's templates once without writing them to
+// the task directory or triggering their change modes.
+func (tr *TaskRunner) TemplateDryRun(timeout time.Duration) ([]*cstructs.TemplateDryRunResult, error) {
+	for _, hook := range tr.runnerHooks {
+		if h, ok := hook.(*templateHook); ok {
+			return h.DryRun(timeout)
+		}
+	}
+	return nil, fmt.Errorf("task %q has no templates", tr.taskName)
+}
+
 // shutdownDelayCancel is used for testing only and cancels the
 // shutdownDelayCtx
 func (tr *TaskRunner) shutdownDelayCancel() {
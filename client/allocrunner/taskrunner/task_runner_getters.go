@@ -4,6 +4,8 @@
 package taskrunner
 
 import (
+	"maps"
+
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -79,6 +81,22 @@ func (tr *TaskRunner) setVaultToken(token string) {
 	tr.envBuilder.SetVaultToken(token, ns, tr.task.Vault.Env)
 }
 
+// getVaultRoleTokens returns the Vault tokens derived for templates that
+// request their own Vault role, keyed by role.
+func (tr *TaskRunner) getVaultRoleTokens() map[string]string {
+	tr.vaultTokenLock.Lock()
+	defer tr.vaultTokenLock.Unlock()
+	return maps.Clone(tr.vaultRoleTokens)
+}
+
+// setVaultRoleTokens updates the Vault tokens derived for templates that
+// request their own Vault role.
+func (tr *TaskRunner) setVaultRoleTokens(tokens map[string]string) {
+	tr.vaultTokenLock.Lock()
+	defer tr.vaultTokenLock.Unlock()
+	tr.vaultRoleTokens = maps.Clone(tokens)
+}
+
 func (tr *TaskRunner) getNomadToken() string {
 	tr.nomadTokenLock.Lock()
 	defer tr.nomadTokenLock.Unlock()
@@ -95,6 +113,20 @@ func (tr *TaskRunner) setNomadToken(token string) {
 	}
 }
 
+// getTaskResources returns the resources of the task.
+func (tr *TaskRunner) getTaskResources() *structs.AllocatedTaskResources {
+	tr.taskResourcesLock.RLock()
+	defer tr.taskResourcesLock.RUnlock()
+	return tr.taskResources
+}
+
+// setTaskResources updates the resources of the task.
+func (tr *TaskRunner) setTaskResources(resources *structs.AllocatedTaskResources) {
+	tr.taskResourcesLock.Lock()
+	defer tr.taskResourcesLock.Unlock()
+	tr.taskResources = resources
+}
+
 // getDriverHandle returns a driver handle.
 func (tr *TaskRunner) getDriverHandle() *DriverHandle {
 	tr.handleLock.Lock()
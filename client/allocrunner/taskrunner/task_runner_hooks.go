@@ -89,6 +89,23 @@ func (tr *TaskRunner) initHooks() {
 			}))
 	}
 
+	// If the task has secrets, add the hook
+	if len(task.Secrets) > 0 {
+		tr.runnerHooks = append(tr.runnerHooks, newSecretsHook(alloc, tr.Task(), tr.rpcClient, tr.envBuilder, hookLogger))
+	}
+
+	// If any identity is exchanged for AWS credentials, add the hook
+	if len(awsIdentities(task)) > 0 {
+		tr.runnerHooks = append(tr.runnerHooks, newAWSIdentityHook(&awsIdentityHookConfig{
+			alloc:     tr.Alloc(),
+			task:      tr.Task(),
+			widmgr:    tr.widmgr,
+			lifecycle: tr,
+			events:    tr,
+			logger:    hookLogger,
+		}))
+	}
+
 	// If Vault is enabled, add the hook
 	if task.Vault != nil && tr.vaultClientFunc != nil {
 		tr.runnerHooks = append(tr.runnerHooks, newVaultHook(&vaultHookConfig{
@@ -118,7 +135,9 @@ func (tr *TaskRunner) initHooks() {
 			alloc:               tr.Alloc(),
 			logger:              hookLogger,
 			lifecycle:           tr,
+			execHandler:         tr.TaskExecHandler,
 			events:              tr,
+			stateUpdater:        tr,
 			templates:           task.Templates,
 			clientConfig:        tr.clientConfig,
 			envBuilder:          tr.envBuilder,
@@ -126,6 +145,9 @@ func (tr *TaskRunner) initHooks() {
 			consulNamespace:     consulNamespace,
 			nomadNamespace:      tr.alloc.Job.Namespace,
 			renderOnTaskRestart: task.RestartPolicy.RenderTemplates,
+			metricLabels:        tr.baseLabels,
+			getter:              tr.getter,
+			rpc:                 tr.rpcClient,
 		}))
 	}
 
@@ -140,11 +162,17 @@ func (tr *TaskRunner) initHooks() {
 		logger:            hookLogger,
 	}))
 
-	// If this is a Connect sidecar proxy (or a Connect Native) service,
-	// add the sidsHook for requesting a Service Identity token (if ACLs).
-	if task.UsesConnect() {
-		tg := tr.Alloc().Job.LookupTaskGroup(tr.Alloc().TaskGroup)
-
+	// If this is the Connect sidecar proxy of a Nomad service, bootstrap it
+	// from the servers. If this is a Connect sidecar proxy (or a Connect
+	// Native) service of Consul, add the sidsHook for requesting a Service
+	// Identity token (if ACLs).
+	tg := tr.Alloc().Job.LookupTaskGroup(tr.Alloc().TaskGroup)
+	if task.UsesConnectSidecar() && usesNomadMesh(tg, task) {
+		tr.runnerHooks = append(tr.runnerHooks,
+			newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, tr.consulProxiesClientFunc, hookLogger)),
+			newEnvoyMeshHook(alloc, tr.rpcClient, tr.getNomadToken, hookLogger),
+		)
+	} else if task.UsesConnect() {
 		consulCfg := tr.clientConfig.GetConsulConfigs(tr.logger)[task.GetConsulClusterName(tg)]
 
 		// Enable the Service Identity hook only if the Nomad client is configured
@@ -243,7 +271,7 @@ func (tr *TaskRunner) prestart() error {
 			Task:          tr.Task(),
 			TaskDir:       tr.taskDir,
 			TaskEnv:       tr.envBuilder.Build(),
-			TaskResources: tr.taskResources,
+			TaskResources: tr.getTaskResources(),
 		}
 
 		origHookState := tr.hookState(name)
@@ -266,6 +294,7 @@ func (tr *TaskRunner) prestart() error {
 		}
 
 		req.VaultToken = tr.getVaultToken()
+		req.VaultRoleTokens = tr.getVaultRoleTokens()
 		req.NomadToken = tr.getNomadToken()
 
 		// Time the prestart hook
@@ -370,6 +399,7 @@ func (tr *TaskRunner) poststart() error {
 
 		req := interfaces.TaskPoststartRequest{
 			DriverExec:    lazyHandle,
+			DriverInspect: lazyHandle,
 			DriverNetwork: net,
 			DriverStats:   lazyHandle,
 			TaskEnv:       tr.envBuilder.Build(),
@@ -515,10 +545,11 @@ func (tr *TaskRunner) updateHooks() {
 
 		// Build the request
 		req := interfaces.TaskUpdateRequest{
-			NomadToken: tr.getNomadToken(),
-			VaultToken: tr.getVaultToken(),
-			Alloc:      alloc,
-			TaskEnv:    tr.envBuilder.Build(),
+			NomadToken:      tr.getNomadToken(),
+			VaultToken:      tr.getVaultToken(),
+			VaultRoleTokens: tr.getVaultRoleTokens(),
+			Alloc:           alloc,
+			TaskEnv:         tr.envBuilder.Build(),
 		}
 
 		// Time the update hook
@@ -215,6 +215,7 @@ func TestTaskRunner_BuildTaskConfig_CPU_Memory(t *testing.T) {
 	cases := []struct {
 		name                  string
 		cpu                   int64
+		cpuMax                int64
 		memoryMB              int64
 		memoryMaxMB           int64
 		expectedLinuxMemoryMB int64
@@ -240,6 +241,13 @@ func TestTaskRunner_BuildTaskConfig_CPU_Memory(t *testing.T) {
 			memoryMaxMB:           200,
 			expectedLinuxMemoryMB: 200,
 		},
+		{
+			name:                  "burstable cpu",
+			cpu:                   100,
+			cpuMax:                500,
+			memoryMB:              100,
+			expectedLinuxMemoryMB: 100,
+		},
 	}
 
 	for _, c := range cases {
@@ -253,6 +261,7 @@ func TestTaskRunner_BuildTaskConfig_CPU_Memory(t *testing.T) {
 			}
 			res := alloc.AllocatedResources.Tasks[task.Name]
 			res.Cpu.CpuShares = c.cpu
+			res.Cpu.CpuMax = c.cpuMax
 			res.Memory.MemoryMB = c.memoryMB
 			res.Memory.MemoryMaxMB = c.memoryMaxMB
 
@@ -268,6 +277,16 @@ func TestTaskRunner_BuildTaskConfig_CPU_Memory(t *testing.T) {
 			require.Equal(t, c.cpu, tc.Resources.LinuxResources.CPUShares)
 			require.Equal(t, c.expectedLinuxMemoryMB*1024*1024, tc.Resources.LinuxResources.MemoryLimitBytes)
 
+			// burstable tasks are capped at their maximum CPU
+			if c.cpuMax > 0 {
+				topology := conf.ClientConfig.Node.NodeResources.Processors.Topology
+				quota := 100000 * c.cpuMax * int64(topology.NumCores()) / int64(topology.TotalCompute())
+				require.Equal(t, int64(100000), tc.Resources.LinuxResources.CPUPeriod)
+				require.Equal(t, quota, tc.Resources.LinuxResources.CPUQuota)
+			} else {
+				require.Zero(t, tc.Resources.LinuxResources.CPUQuota)
+			}
+
 			require.Equal(t, c.cpu, tc.Resources.NomadResources.Cpu.CpuShares)
 			require.Equal(t, c.memoryMB, tc.Resources.NomadResources.Memory.MemoryMB)
 			require.Equal(t, c.memoryMaxMB, tc.Resources.NomadResources.Memory.MemoryMaxMB)
@@ -330,6 +349,43 @@ func TestTaskRunner_Stop_ExitCode(t *testing.T) {
 
 }
 
+// TestTaskRunner_Update_Resources asserts that resizing the allocation in place
+// updates the resources of the running task through its driver.
+func TestTaskRunner_Update_Resources(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+	testWaitForTaskToStart(t, tr)
+
+	update := alloc.Copy()
+	update.AllocatedResources.Tasks[task.Name].Cpu.CpuShares = 1000
+	update.AllocatedResources.Tasks[task.Name].Memory.MemoryMB = 512
+	update.AllocModifyIndex++
+	tr.Update(update)
+
+	resources := tr.getTaskResources()
+	must.Eq(t, 1000, resources.Cpu.CpuShares)
+	must.Eq(t, 512, resources.Memory.MemoryMB)
+
+	// The mock driver can't update the resources of running tasks
+	events := tr.TaskState().Events
+	last := events[len(events)-1]
+	must.Eq(t, structs.TaskResized, last.Type)
+	must.StrContains(t, last.DisplayMessage, "does not support updating resources")
+
+	// Updates without new resources are ignored
+	tr.Update(update.Copy())
+	must.Len(t, len(events), tr.TaskState().Events)
+}
+
 // TestTaskRunner_Restore_Running asserts restoring a running task does not
 // rerun the task.
 func TestTaskRunner_Restore_Running(t *testing.T) {
@@ -1123,6 +1179,38 @@ WAIT:
 	}
 }
 
+// TestTaskRunner_ShutdownDelay_Override asserts the shutdown delay an
+// allocation was stopped with replaces the task's shutdown_delay.
+func TestTaskRunner_ShutdownDelay_Override(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	alloc.DesiredTransition = structs.DesiredTransition{ShutdownDelay: pointer.Of(100 * time.Millisecond)}
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "1000s",
+	}
+	task.ShutdownDelay = time.Hour
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+	testWaitForTaskToStart(t, tr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(testutil.TestMultiplier()*5)*time.Second)
+	defer cancel()
+	must.NoError(t, tr.Kill(ctx, structs.NewTaskEvent("test")))
+
+	var waited *structs.TaskEvent
+	for _, ev := range tr.TaskState().Events {
+		if ev.Type == structs.TaskWaitingShuttingDownDelay {
+			waited = ev
+		}
+	}
+	must.NotNil(t, waited)
+	must.StrContains(t, waited.DisplayMessage, "100ms")
+}
+
 // TestTaskRunner_NoShutdownDelay asserts services are removed from
 // Consul and tasks are killed without waiting for ${shutdown_delay}
 // when the alloc has the NoShutdownDelay transition flag set.
@@ -3092,3 +3180,31 @@ func TestTaskRunner_setHookStatsHandler(t *testing.T) {
 	must.True(t, ok)
 	must.NotNil(t, noopHandler)
 }
+
+// TestTaskRunner_mergeTemplateStates asserts template states from several
+// template managers are merged by destination instead of replaced.
+func TestTaskRunner_mergeTemplateStates(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	once := []*structs.TemplateState{{DestPath: "local/once.env", LastRendered: now}}
+	watched := []*structs.TemplateState{
+		{DestPath: "local/b.conf", LastRendered: now},
+		{DestPath: "local/a.conf", LastRendered: now},
+	}
+
+	states := mergeTemplateStates(nil, once)
+	states = mergeTemplateStates(states, watched)
+	must.Eq(t, []string{"local/a.conf", "local/b.conf", "local/once.env"},
+		helper.ConvertSlice(states, func(s *structs.TemplateState) string { return s.DestPath }))
+
+	// updating one manager's templates keeps the others
+	later := now.Add(time.Minute)
+	states = mergeTemplateStates(states, []*structs.TemplateState{
+		{DestPath: "local/a.conf", LastRendered: later},
+		{DestPath: "local/b.conf", LastRendered: later},
+	})
+	must.Len(t, 3, states)
+	must.Eq(t, later, states[0].LastRendered)
+	must.Eq(t, now, states[2].LastRendered)
+}
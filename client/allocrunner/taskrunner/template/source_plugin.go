@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	gotemplate "text/template"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// sourcePluginFetchTimeout is the maximum amount of time a template
+	// source plugin may take to fetch a single value
+	sourcePluginFetchTimeout = 30 * time.Second
+)
+
+// TemplateSourcePlugin is an external data source, such as etcd, AWS SSM
+// Parameter Store or GCP Secret Manager, that templates can read from
+// alongside Consul, Vault and Nomad. Each plugin is exposed to templates as a
+// function named after the plugin that takes the key to fetch:
+//
+//	{{ ssm "/my-app/db-password" }}
+//
+// Values are fetched each time the template renders and are not watched for
+// changes.
+type TemplateSourcePlugin interface {
+	// Name returns the name of the template function exposing the plugin
+	Name() string
+
+	// Fetch returns the value stored at the requested key
+	Fetch(ctx context.Context, req *TemplateSourceRequest) (string, error)
+}
+
+// TemplateSourceRequest is passed to a TemplateSourcePlugin to fetch a value
+// on behalf of a task.
+type TemplateSourceRequest struct {
+	// Key is the key the template requested
+	Key string
+
+	// Namespace is the Nomad namespace of the task
+	Namespace string
+
+	// TaskID is the ID of the task rendering the template
+	TaskID string
+
+	// NomadToken is the task's default workload identity, which plugins can
+	// exchange for credentials to the external source
+	NomadToken string
+}
+
+// execSourcePlugin is a TemplateSourcePlugin configured in the client's
+// template block, which fetches values by running a command on the host. The
+// requested key is appended to the command's arguments, the task's namespace,
+// ID and workload identity are passed in its environment, and its standard
+// output is the value.
+type execSourcePlugin struct {
+	name    string
+	command []string
+}
+
+func (p *execSourcePlugin) Name() string { return p.name }
+
+func (p *execSourcePlugin) Fetch(ctx context.Context, req *TemplateSourceRequest) (string, error) {
+	args := append(slices.Clone(p.command[1:]), req.Key)
+	cmd := exec.CommandContext(ctx, p.command[0], args...)
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"NOMAD_NAMESPACE=" + req.Namespace,
+		"NOMAD_TASK_ID=" + req.TaskID,
+		"NOMAD_TOKEN=" + req.NomadToken,
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+// sourcePluginFuncs returns the template functions of the source plugins
+// configured on the client for the task. It returns nil if no plugin is
+// configured.
+func sourcePluginFuncs(config *TaskTemplateManagerConfig) gotemplate.FuncMap {
+	tmplConfig := config.ClientConfig.TemplateConfig
+	if tmplConfig == nil || len(tmplConfig.SourcePlugins) == 0 {
+		return nil
+	}
+
+	funcs := make(gotemplate.FuncMap, len(tmplConfig.SourcePlugins))
+	for name, command := range tmplConfig.SourcePlugins {
+		if len(command) == 0 {
+			continue
+		}
+		funcs[name] = sourcePluginFunc(config, &execSourcePlugin{name: name, command: command})
+	}
+	return funcs
+}
+
+func sourcePluginFunc(config *TaskTemplateManagerConfig, p TemplateSourcePlugin) func(string) (string, error) {
+	return func(key string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), sourcePluginFetchTimeout)
+		defer cancel()
+
+		value, err := p.Fetch(ctx, &TemplateSourceRequest{
+			Key:        key,
+			Namespace:  config.NomadNamespace,
+			TaskID:     config.TaskID,
+			NomadToken: config.NomadToken,
+		})
+		if err != nil {
+			err = fmt.Errorf("template source %q failed to fetch %q: %w", p.Name(), key, err)
+			if config.Events != nil {
+				config.Events.EmitEvent(structs.NewTaskEvent(consulTemplateSourceName).
+					SetDisplayMessage(err.Error()))
+			}
+			return "", err
+		}
+		return value, nil
+	}
+}
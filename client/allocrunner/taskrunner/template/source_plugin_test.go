@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+
+package template
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// writeSourcePluginScript writes an executable shell script to be used as the
+// command of a template source plugin.
+func writeSourcePluginScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plugin.sh")
+	must.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755))
+	return path
+}
+
+func TestExecSourcePlugin_Fetch(t *testing.T) {
+	ci.Parallel(t)
+
+	script := writeSourcePluginScript(t, `echo "$1 $2 $NOMAD_NAMESPACE $NOMAD_TASK_ID $NOMAD_TOKEN"`)
+	p := &execSourcePlugin{name: "fake", command: []string{script, "--region=eu"}}
+
+	value, err := p.Fetch(context.Background(), &TemplateSourceRequest{
+		Key:        "db/password",
+		Namespace:  "prod",
+		TaskID:     "task",
+		NomadToken: "workload-identity",
+	})
+	must.NoError(t, err)
+	must.Eq(t, "--region=eu db/password prod task workload-identity", value)
+
+	script = writeSourcePluginScript(t, `echo "key not found" >&2; exit 1`)
+	p = &execSourcePlugin{name: "fake", command: []string{script}}
+	_, err = p.Fetch(context.Background(), &TemplateSourceRequest{Key: "missing"})
+	must.ErrorContains(t, err, "key not found")
+}
+
+// TestTaskTemplateManager_SourcePlugin asserts that the source plugins
+// configured on the client are exposed as template functions and receive the
+// task's identity.
+func TestTaskTemplateManager_SourcePlugin(t *testing.T) {
+	ci.Parallel(t)
+
+	script := writeSourcePluginScript(t,
+		`[ "$1" = "db/password" ] && [ "$NOMAD_TOKEN" = "workload-identity" ] && echo hunter2`)
+
+	templates := []*structs.Template{
+		{
+			EmbeddedTmpl: `password={{ fakeSource "db/password" }}`,
+			DestPath:     "local/a.txt",
+			ChangeMode:   structs.TemplateChangeModeNoop,
+		},
+	}
+
+	harness := newTestHarness(t, templates, false, false)
+	harness.config.TemplateConfig.SourcePlugins = map[string][]string{
+		"fakeSource": {script},
+	}
+	m, err := NewTaskTemplateManager(&TaskTemplateManagerConfig{
+		UnblockCh:            harness.mockHooks.UnblockCh,
+		Lifecycle:            harness.mockHooks,
+		Events:               harness.mockHooks,
+		Templates:            harness.templates,
+		ClientConfig:         harness.config,
+		TaskDir:              harness.taskDir,
+		EnvBuilder:           harness.envBuilder,
+		MaxTemplateEventRate: harness.emitRate,
+		TaskID:               uuid.Generate(),
+		NomadNamespace:       structs.DefaultNamespace,
+		NomadToken:           "workload-identity",
+	})
+	must.NoError(t, err)
+
+	results, err := m.DryRun(5 * time.Second)
+	must.NoError(t, err)
+	must.Len(t, 1, results)
+	must.True(t, results[0].Rendered)
+	must.Eq(t, "password=hunter2", results[0].Contents)
+}
+
+func TestTaskTemplateManager_SourcePlugin_Error(t *testing.T) {
+	ci.Parallel(t)
+
+	script := writeSourcePluginScript(t, `echo "key not found" >&2; exit 1`)
+
+	templates := []*structs.Template{
+		{
+			EmbeddedTmpl: `{{ fakeSourceErr "missing" }}`,
+			DestPath:     "local/a.txt",
+			ChangeMode:   structs.TemplateChangeModeNoop,
+		},
+	}
+
+	harness := newTestHarness(t, templates, false, false)
+	harness.config.TemplateConfig.SourcePlugins = map[string][]string{
+		"fakeSourceErr": {script},
+	}
+	harness.start(t)
+	defer harness.stop()
+
+	select {
+	case <-harness.mockHooks.KillCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("task should have been killed")
+	}
+
+	must.StrContains(t, harness.mockHooks.KillEvent().DisplayMessage,
+		`template source "fakeSourceErr" failed to fetch "missing"`)
+}
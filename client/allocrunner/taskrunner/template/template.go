@@ -7,9 +7,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,14 +20,23 @@ import (
 	"time"
 
 	ctconf "github.com/hashicorp/consul-template/config"
+	dep "github.com/hashicorp/consul-template/dependency"
 	"github.com/hashicorp/consul-template/manager"
+	"github.com/hashicorp/consul-template/renderer"
 	"github.com/hashicorp/consul-template/signals"
 	envparse "github.com/hashicorp/go-envparse"
 	"github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/supervisor"
 	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/escapingfs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
 	structsc "github.com/hashicorp/nomad/nomad/structs/config"
@@ -42,6 +54,10 @@ const (
 	// DefaultMaxTemplateEventRate is the default maximum rate at which a
 	// template event should be fired.
 	DefaultMaxTemplateEventRate = 3 * time.Second
+
+	// reloadCommandTimeout is the maximum amount of time a reload command
+	// executed for change_mode reload may run.
+	reloadCommandTimeout = 30 * time.Second
 )
 
 var (
@@ -64,6 +80,21 @@ type TaskTemplateManager struct {
 	// actual signal
 	signals map[string]os.Signal
 
+	// envSource is the key the manager's env template vars are set under in
+	// the task environment. It is empty if the manager has no env templates.
+	envSource string
+
+	// supervisors run the processes of templates with change_mode
+	// exec_restart inside the task
+	supervisors map[*structs.Template]*supervisor.Supervisor
+
+	// templateStates is the render state of the templates last recorded in
+	// the task state
+	templateStates []*structs.TemplateState
+
+	// vars watches the variables read by the nomadVarWatch template function
+	vars *varWatcher
+
 	// shutdownCh is used to signal and started goroutine to shutdown
 	shutdownCh chan struct{}
 
@@ -82,9 +113,18 @@ type TaskTemplateManagerConfig struct {
 	// run for
 	Lifecycle interfaces.TaskLifecycle
 
+	// ExecHandler returns the handler used to run the processes of templates
+	// with change_mode exec_restart inside the task, or nil if the task is not
+	// running
+	ExecHandler func() drivermanager.TaskExecHandler
+
 	// Events is used to emit events for the task
 	Events interfaces.EventEmitter
 
+	// StateUpdater is used to record the render state of the templates in
+	// the task state. It may be nil.
+	StateUpdater interfaces.TemplateStateUpdater
+
 	// Templates is the set of templates we are managing
 	Templates []*structs.Template
 
@@ -105,6 +145,11 @@ type TaskTemplateManagerConfig struct {
 	// VaultToken is the Vault token for the task.
 	VaultToken string
 
+	// VaultRoleTokens are the Vault tokens derived for templates that request
+	// their own Vault role, keyed by role. Templates without a Vault role use
+	// VaultToken.
+	VaultRoleTokens map[string]string
+
 	// VaultConfig is the Vault configuration to use for this template. It may
 	// be nil if the task does not use Vault.
 	VaultConfig *structsc.VaultConfig
@@ -112,9 +157,18 @@ type TaskTemplateManagerConfig struct {
 	// VaultNamespace is the Vault namespace for the task
 	VaultNamespace string
 
+	// VaultSecretRotation is the task's Vault secret rotation configuration.
+	// When set, re-rendering a template because of a Vault secret restarts
+	// the task instead of applying the template's change_mode.
+	VaultSecretRotation *structs.VaultSecretRotation
+
 	// TaskDir is the task's directory
 	TaskDir string
 
+	// TaskDriver is the name of the task's driver, used to look up the
+	// reload command for templates with change_mode reload
+	TaskDriver string
+
 	// EnvBuilder is the environment variable builder for the task.
 	EnvBuilder *taskenv.Builder
 
@@ -127,10 +181,18 @@ type TaskTemplateManagerConfig struct {
 	// NomadToken is the Nomad token or identity claim for the task
 	NomadToken string
 
+	// RPC is used to read and watch the variables of the nomadVarWatch
+	// template function. It may be nil, in which case the function fails.
+	RPC config.RPCer
+
 	// TaskID is a unique identifier for this task's template manager, for use
 	// in downstream platform-specific template runner consumers
 	TaskID string
 
+	// MetricLabels are the labels attached to every template metric. Metrics
+	// are only emitted when the client publishes allocation metrics.
+	MetricLabels []metrics.Label
+
 	Logger hclog.Logger
 }
 
@@ -155,23 +217,58 @@ func (c *TaskTemplateManagerConfig) Validate() error {
 	}
 
 	// Once is a runner config, but in Nomad it is set per template, so all
-	// templates given to a runner should have the same value for Once.
+	// templates given to a runner should have the same value for Once. The
+	// same applies to the Vault role since a runner holds a single Vault
+	// token.
 	var once bool
+	var vaultRole string
 	for i, t := range c.Templates {
 		if i == 0 {
 			once = t.Once
-		} else if t.Once != once {
+			vaultRole = t.VaultRole
+			continue
+		}
+		if t.Once != once {
 			return fmt.Errorf("All templates should have same Once value")
 		}
+		if t.VaultRole != vaultRole {
+			return fmt.Errorf("All templates should have same Vault role")
+		}
 	}
 
 	return nil
 }
 
+// publishMetrics returns whether template metrics should be emitted.
+func (c *TaskTemplateManagerConfig) publishMetrics() bool {
+	return c.ClientConfig != nil && c.ClientConfig.PublishAllocationMetrics
+}
+
+// metricLabels returns the labels for metrics emitted for the template with
+// the given destination.
+func (c *TaskTemplateManagerConfig) metricLabels(dest string) []metrics.Label {
+	return append(slices.Clone(c.MetricLabels), metrics.Label{Name: "destination", Value: dest})
+}
+
 func (c *TaskTemplateManagerConfig) OnceModeEnabled() bool {
 	return len(c.Templates) > 0 && c.Templates[0].Once
 }
 
+// vaultToken returns the Vault token the runner should use, which is the token
+// derived for the templates' Vault role if they request one.
+func (c *TaskTemplateManagerConfig) vaultToken() (string, error) {
+	if len(c.Templates) == 0 || c.Templates[0].VaultRole == "" {
+		return c.VaultToken, nil
+	}
+
+	role := c.Templates[0].VaultRole
+	token, ok := c.VaultRoleTokens[role]
+	if !ok {
+		return "", fmt.Errorf("no Vault token available for role %q", role)
+	}
+	return token, nil
+}
+
 func NewTaskTemplateManager(config *TaskTemplateManagerConfig) (*TaskTemplateManager, error) {
 	// Check pre-conditions
 	if err := config.Validate(); err != nil {
@@ -181,6 +278,8 @@ func NewTaskTemplateManager(config *TaskTemplateManagerConfig) (*TaskTemplateMan
 	tm := &TaskTemplateManager{
 		config:     config,
 		shutdownCh: make(chan struct{}),
+		envSource:  templateEnvSource(config.Templates),
+		vars:       newVarWatcher(config, !config.OnceModeEnabled()),
 	}
 
 	// Parse the signals that we need
@@ -201,8 +300,28 @@ func NewTaskTemplateManager(config *TaskTemplateManagerConfig) (*TaskTemplateMan
 		tm.signals[tmpl.ChangeSignal] = sig
 	}
 
+	// Build the supervisors of the exec_restart processes
+	for _, tmpl := range config.Templates {
+		if tmpl.ChangeMode != structs.TemplateChangeModeExecRestart {
+			continue
+		}
+		if config.ExecHandler == nil {
+			return nil, fmt.Errorf("Template %q with change_mode exec_restart requires an exec handler", tmpl.DestPath)
+		}
+
+		if tm.supervisors == nil {
+			tm.supervisors = make(map[*structs.Template]*supervisor.Supervisor)
+		}
+		tm.supervisors[tmpl] = supervisor.New(&supervisor.Config{
+			Command:     append([]string{tmpl.Exec.Command}, tmpl.Exec.Args...),
+			ExecHandler: config.ExecHandler,
+			Events:      config.Events,
+			Logger:      config.Logger,
+		})
+	}
+
 	// Build the consul-template runner
-	runner, lookup, err := templateRunner(config)
+	runner, lookup, err := templateRunner(config, tm.vars)
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +347,11 @@ func (tm *TaskTemplateManager) Stop() {
 	if tm.runner != nil {
 		tm.runner.Stop()
 	}
+	tm.vars.stop()
+
+	for _, s := range tm.supervisors {
+		s.Stop()
+	}
 }
 
 // Run is the long lived loop that handles errors and templates being rendered
@@ -257,21 +381,25 @@ func (tm *TaskTemplateManager) Run() {
 	}
 
 	// Read environment variables from env templates before we unblock
-	envMap, err := loadTemplateEnv(tm.config.Templates, tm.config.EnvBuilder.Build())
-	if err != nil {
+	if _, err := tm.reloadTemplateEnv(); err != nil {
 		tm.config.Lifecycle.Kill(context.Background(),
 			structs.NewTaskEvent(structs.TaskKilling).
 				SetFailsTask().
 				SetDisplayMessage(fmt.Sprintf("Template failed to read environment variables: %v", err)))
 		return
 	}
-	tm.config.EnvBuilder.SetTemplateEnv(envMap)
 
 	// Unblock the task
 	close(tm.config.UnblockCh)
 
+	// Start the exec_restart processes, which run once the task has started
+	for _, s := range tm.supervisors {
+		s.Start()
+	}
+
 	// If all our templates are change mode no-op, then we can exit here
-	if tm.allTemplatesNoop() {
+	// unless they must be re-rendered when a watched variable changes
+	if tm.allTemplatesNoop() && !tm.vars.watching() {
 		return
 	}
 
@@ -283,6 +411,104 @@ func (tm *TaskTemplateManager) Templates() []*structs.Template {
 	return tm.config.Templates
 }
 
+// DryRun renders the manager's templates a single time using a separate
+// consul-template runner, without writing them to the task directory or
+// triggering any change mode. It waits at most timeout for the templates'
+// dependencies and returns the templates that could not be rendered along
+// with their missing dependencies. The contents of templates rendered into the
+// secrets or private dir of the task are redacted.
+func (tm *TaskTemplateManager) DryRun(timeout time.Duration) ([]*cstructs.TemplateDryRunResult, error) {
+	if len(tm.config.Templates) == 0 {
+		return nil, nil
+	}
+
+	ctmplMapping, err := parseTemplateConfigs(tm.config, newVarWatcher(tm.config, false))
+	if err != nil {
+		return nil, err
+	}
+
+	runnerConfig, err := newRunnerConfig(tm.config, ctmplMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	// Capture the rendered contents in the render events instead of writing
+	// them to disk. This also skips the render metrics of the real manager.
+	runnerConfig.Once = true
+	runnerConfig.RendererFunc = func(input *renderer.RenderInput) (*renderer.RenderResult, error) {
+		return &renderer.RenderResult{
+			DidRender:   true,
+			WouldRender: true,
+			Contents:    input.Contents,
+		}, nil
+	}
+
+	runner, err := manager.NewRunner(runnerConfig, true)
+	if err != nil {
+		return nil, err
+	}
+	runner.SetOutStream(io.Discard)
+	runner.SetErrStream(io.Discard)
+	runner.Env = maskProcessEnv(tm.config.EnvBuilder.Build().All())
+
+	go runner.Start()
+	defer runner.Stop()
+
+	timer, stop := helper.NewSafeTimer(timeout)
+	defer stop()
+
+	select {
+	case <-runner.DoneCh:
+	case err := <-runner.ErrCh:
+		return nil, err
+	case <-timer.C:
+	}
+
+	events := runner.RenderEvents()
+	results := make([]*cstructs.TemplateDryRunResult, 0, len(ctmplMapping))
+	for id, ctmpls := range runner.TemplateConfigMapping() {
+		event := events[id]
+		for _, ctmpl := range ctmpls {
+			result := &cstructs.TemplateDryRunResult{
+				DestPath: ctmplMapping[ctmpl].DestPath,
+			}
+			if event != nil {
+				result.Rendered = !event.LastDidRender.IsZero()
+				if tm.isPrivateDest(*ctmpl.Destination) {
+					result.Redacted = result.Rendered
+				} else {
+					result.Contents = string(event.Contents)
+				}
+				if event.MissingDeps != nil {
+					for _, dep := range event.MissingDeps.List() {
+						result.MissingDeps = append(result.MissingDeps, dep.String())
+					}
+					sort.Strings(result.MissingDeps)
+				}
+			}
+			results = append(results, result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].DestPath < results[j].DestPath
+	})
+	return results, nil
+}
+
+// isPrivateDest returns whether dest is in the secrets or private dir of the
+// task, which can't be read through the allocation filesystem.
+func (tm *TaskTemplateManager) isPrivateDest(dest string) bool {
+	dest = strings.ToLower(filepath.Clean(dest))
+	for _, name := range []string{allocdir.TaskSecrets, allocdir.TaskPrivate} {
+		dir := strings.ToLower(filepath.Join(tm.config.TaskDir, name))
+		if dest == dir || strings.HasPrefix(dest, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
 // handleFirstRender blocks till all templates have been rendered
 func (tm *TaskTemplateManager) handleFirstRender() {
 	// missingDependencies is the set of missing dependencies.
@@ -303,12 +529,36 @@ func (tm *TaskTemplateManager) handleFirstRender() {
 	// be fired.
 	outstandingEvent := false
 
+	// timeoutCh fires if the templates have not all rendered within the
+	// shortest first_render_timeout. It is nil if no timeout is set.
+	var timeoutCh <-chan time.Time
+	timeout, action := tm.firstRenderTimeout()
+	if timeout > 0 {
+		timeoutTimer := time.NewTimer(timeout)
+		defer timeoutTimer.Stop()
+		timeoutCh = timeoutTimer.C
+	}
+
 	// Wait till all the templates have been rendered
 WAIT:
 	for {
 		select {
 		case <-tm.shutdownCh:
 			return
+		case <-timeoutCh:
+			timeoutCh = nil
+
+			// as with runner errors, we wait for tm.shutdownCh in the next
+			// pass thru the loop rather than returning here
+			msg := fmt.Sprintf("Template failed to render within first_render_timeout of %v", timeout)
+			if len(missingDependencies) > 0 {
+				msg = fmt.Sprintf("%s; missing: %s", msg, missingDepsString(missingDependencies))
+			}
+			event := structs.NewTaskEvent(structs.TaskKilling).SetDisplayMessage(msg)
+			if action != structs.TemplateFirstRenderActionKill {
+				event.SetFailsTask()
+			}
+			tm.config.Lifecycle.Kill(context.Background(), event)
 		case err, ok := <-tm.runner.ErrCh:
 			if !ok {
 				continue
@@ -324,6 +574,7 @@ WAIT:
 		case <-tm.runner.TemplateRenderedCh():
 			// A template has been rendered, figure out what to do
 			events := tm.runner.RenderEvents()
+			tm.updateTemplateState(events)
 
 			// Not all templates have been rendered yet
 			if len(events) < len(tm.lookup) {
@@ -335,6 +586,7 @@ WAIT:
 				if event.LastWouldRender.IsZero() {
 					continue WAIT
 				}
+				tm.emitMissingDeps(event.Template.ID(), 0)
 				// If the template _actually_ rendered to disk, mark it
 				// dirty. We track events here so that onTemplateRendered
 				// doesn't go back to the runner's RenderedEvents and process
@@ -354,12 +606,15 @@ WAIT:
 			break WAIT
 		case <-tm.runner.RenderEventCh():
 			events := tm.runner.RenderEvents()
+			tm.updateTemplateState(events)
 			joinedSet := make(map[string]struct{})
 			for _, event := range events {
 				missing := event.MissingDeps
 				if missing == nil {
+					tm.emitMissingDeps(event.Template.ID(), 0)
 					continue
 				}
+				tm.emitMissingDeps(event.Template.ID(), missing.Len())
 
 				for _, dep := range missing.List() {
 					joinedSet[dep.String()] = struct{}{}
@@ -399,22 +654,45 @@ WAIT:
 			// Clear the outstanding event
 			outstandingEvent = false
 
-			// Build the missing set
-			missingSlice := make([]string, 0, len(missingDependencies))
-			for k := range missingDependencies {
-				missingSlice = append(missingSlice, k)
-			}
-			sort.Strings(missingSlice)
+			missingStr := missingDepsString(missingDependencies)
+			tm.config.Events.EmitEvent(structs.NewTaskEvent(consulTemplateSourceName).SetDisplayMessage(fmt.Sprintf("Missing: %s", missingStr)))
+		}
+	}
+}
 
-			if l := len(missingSlice); l > missingDepEventLimit {
-				missingSlice[missingDepEventLimit] = fmt.Sprintf("and %d more", l-missingDepEventLimit)
-				missingSlice = missingSlice[:missingDepEventLimit+1]
-			}
+// missingDepsString returns a sorted, comma separated list of the missing
+// dependencies, truncated to missingDepEventLimit entries.
+func missingDepsString(missingDependencies map[string]struct{}) string {
+	missingSlice := make([]string, 0, len(missingDependencies))
+	for k := range missingDependencies {
+		missingSlice = append(missingSlice, k)
+	}
+	sort.Strings(missingSlice)
 
-			missingStr := strings.Join(missingSlice, ", ")
-			tm.config.Events.EmitEvent(structs.NewTaskEvent(consulTemplateSourceName).SetDisplayMessage(fmt.Sprintf("Missing: %s", missingStr)))
+	if l := len(missingSlice); l > missingDepEventLimit {
+		missingSlice[missingDepEventLimit] = fmt.Sprintf("and %d more", l-missingDepEventLimit)
+		missingSlice = missingSlice[:missingDepEventLimit+1]
+	}
+
+	return strings.Join(missingSlice, ", ")
+}
+
+// firstRenderTimeout returns the shortest first_render_timeout of the
+// manager's templates along with the action of the template that set it. A
+// zero duration means no template has a timeout.
+func (tm *TaskTemplateManager) firstRenderTimeout() (time.Duration, string) {
+	var timeout time.Duration
+	var action string
+	for _, tmpl := range tm.config.Templates {
+		if tmpl.FirstRenderTimeout <= 0 {
+			continue
+		}
+		if timeout == 0 || tmpl.FirstRenderTimeout < timeout {
+			timeout = tmpl.FirstRenderTimeout
+			action = tmpl.FirstRenderTimeoutAction
 		}
 	}
+	return timeout, action
 }
 
 // handleTemplateRerenders is used to handle template render events after they
@@ -425,6 +703,12 @@ func (tm *TaskTemplateManager) handleTemplateRerenders(allRenderedTime time.Time
 	// A lookup for the last time the template was handled
 	handledRenders := make(map[string]time.Time, len(tm.config.Templates))
 
+	// debounceCh fires at the end of the debounce window opened by the first
+	// re-render after the change modes were last handled. It is nil while no
+	// window is open.
+	var debounceCh <-chan time.Time
+	debounce := tm.changeModeDebounce()
+
 	for {
 		select {
 		case <-tm.shutdownCh:
@@ -444,20 +728,83 @@ func (tm *TaskTemplateManager) handleTemplateRerenders(allRenderedTime time.Time
 					SetFailsTask().
 					SetDisplayMessage(fmt.Sprintf("Template failed: %v", err)))
 		case <-tm.runner.TemplateRenderedCh():
+			if debounce > 0 {
+				if debounceCh == nil {
+					debounceCh = time.After(debounce)
+				}
+				continue
+			}
+
+			events := tm.runner.RenderEvents()
+			tm.updateTemplateState(events)
+			tm.onTemplateRendered(handledRenders, allRenderedTime, events)
+		case <-tm.runner.RenderEventCh():
+			tm.updateTemplateState(tm.runner.RenderEvents())
+		case <-debounceCh:
+			debounceCh = nil
+
+			// every template rendered during the window is handled at once
 			events := tm.runner.RenderEvents()
 			tm.onTemplateRendered(handledRenders, allRenderedTime, events)
+		case <-tm.vars.changeCh:
+			if err := tm.restartRunner(); err != nil {
+				tm.config.Lifecycle.Kill(context.Background(),
+					structs.NewTaskEvent(structs.TaskKilling).
+						SetFailsTask().
+						SetDisplayMessage(fmt.Sprintf("Template failed: %v", err)))
+			}
 		}
 	}
 }
 
+// restartRunner replaces the consul-template runner with a new one, which
+// renders all the templates again. consul-template can't be asked to render
+// outside of changes to its own dependencies, so this is how the templates
+// are re-rendered when a variable read by nomadVarWatch changes.
+func (tm *TaskTemplateManager) restartRunner() error {
+	runner, lookup, err := templateRunner(tm.config, tm.vars)
+	if err != nil {
+		return err
+	}
+
+	tm.shutdownLock.Lock()
+	defer tm.shutdownLock.Unlock()
+	if tm.shutdown {
+		return nil
+	}
+
+	tm.runner.Stop()
+	tm.runner = runner
+	tm.lookup = lookup
+	go tm.runner.Start()
+	return nil
+}
+
+// changeModeDebounce returns the client's window for coalescing template
+// re-renders, if any.
+func (tm *TaskTemplateManager) changeModeDebounce() time.Duration {
+	if tm.config.ClientConfig == nil || tm.config.ClientConfig.TemplateConfig == nil {
+		return 0
+	}
+	return tm.config.ClientConfig.TemplateConfig.ChangeModeDebounce
+}
+
 func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time.Time, allRenderedTime time.Time, events map[string]*manager.RenderEvent) {
 
 	var handling []string
+	var fired []*structs.Template
 	signals := make(map[string]struct{})
+	reloads := make(map[string]struct{})
 	scripts := []*structs.ChangeScript{}
+	var execRestarts []*supervisor.Supervisor
 	restart := false
+	rotate := false
 	var splay time.Duration
 
+	// envLoaded tracks whether the env templates have been read back into the
+	// task environment, which only needs to happen once per pass
+	envLoaded, envChanged := false, false
+
 	for id, event := range events {
 
 		// First time through
@@ -482,24 +829,44 @@ func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time
 		}
 
 		// Read environment variables from templates
-		envMap, err := loadTemplateEnv(tm.config.Templates, tm.config.EnvBuilder.Build())
-		if err != nil {
-			tm.config.Lifecycle.Kill(context.Background(),
-				structs.NewTaskEvent(structs.TaskKilling).
-					SetFailsTask().
-					SetDisplayMessage(fmt.Sprintf("Template failed to read environment variables: %v", err)))
-			return
+		if !envLoaded {
+			var err error
+			envChanged, err = tm.reloadTemplateEnv()
+			if err != nil {
+				tm.config.Lifecycle.Kill(context.Background(),
+					structs.NewTaskEvent(structs.TaskKilling).
+						SetFailsTask().
+						SetDisplayMessage(fmt.Sprintf("Template failed to read environment variables: %v", err)))
+				return
+			}
+			envLoaded = true
+		}
+
+		if tm.config.VaultSecretRotation != nil && usesVaultSecrets(event) {
+			rotate = true
+			handling = append(handling, id)
+			continue
 		}
-		tm.config.EnvBuilder.SetTemplateEnv(envMap)
 
 		for _, tmpl := range tmpls {
+			// Env templates only affect the task environment, so there is
+			// nothing to apply if their env vars did not change, for example
+			// when a service instance changed in a way the template ignores
+			if tmpl.Envvars && !envChanged {
+				continue
+			}
+
 			switch tmpl.ChangeMode {
 			case structs.TemplateChangeModeSignal:
 				signals[tmpl.ChangeSignal] = struct{}{}
 			case structs.TemplateChangeModeRestart:
 				restart = true
+			case structs.TemplateChangeModeReload:
+				reloads[tmpl.ChangeSignal] = struct{}{}
 			case structs.TemplateChangeModeScript:
 				scripts = append(scripts, tmpl.ChangeScript)
+			case structs.TemplateChangeModeExecRestart:
+				execRestarts = append(execRestarts, tm.supervisors[tmpl])
 			case structs.TemplateChangeModeNoop:
 				continue
 			}
@@ -507,12 +874,21 @@ func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time
 			if tmpl.Splay > splay {
 				splay = tmpl.Splay
 			}
+			fired = append(fired, tmpl)
 		}
 
 		handling = append(handling, id)
 	}
 
-	shouldHandle := restart || len(signals) != 0 || len(scripts) != 0
+	if rotate {
+		for _, id := range handling {
+			handledRenders[id] = events[id].LastDidRender
+		}
+		tm.handleSecretRotation()
+		return
+	}
+
+	shouldHandle := restart || len(signals) != 0 || len(reloads) != 0 || len(scripts) != 0 || len(execRestarts) != 0
 	if !shouldHandle {
 		return
 	}
@@ -535,15 +911,21 @@ func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time
 		handledRenders[id] = events[id].LastDidRender
 	}
 
+	for _, tmpl := range fired {
+		tm.emitChangeMode(tmpl)
+	}
+
 	if restart {
 		tm.config.Lifecycle.Restart(context.Background(),
 			structs.NewTaskEvent(structs.TaskRestartSignal).
 				SetDisplayMessage("Template with change_mode restart re-rendered"), false)
 	} else {
-		// Handle signals and scripts since the task may have multiple
-		// templates with mixed change_mode values.
+		// Handle signals, reloads and scripts since the task may have
+		// multiple templates with mixed change_mode values.
 		tm.handleChangeModeSignal(signals)
+		tm.handleChangeModeReload(reloads)
 		tm.handleChangeModeScript(scripts)
+		tm.handleChangeModeExecRestart(execRestarts)
 	}
 }
 
@@ -570,6 +952,51 @@ func (tm *TaskTemplateManager) handleChangeModeSignal(signals map[string]struct{
 	}
 }
 
+// handleChangeModeReload reloads the task. If the client configures a reload
+// command for the task driver, the command is executed inside the task.
+// Otherwise the task driver is asked to reload the task by sending it the given
+// signals.
+func (tm *TaskTemplateManager) handleChangeModeReload(signals map[string]struct{}) {
+	if len(signals) == 0 {
+		return
+	}
+
+	cmd := tm.config.ClientConfig.TemplateConfig.ReloadCommands[tm.config.TaskDriver]
+	if len(cmd) == 0 {
+		var mErr multierror.Error
+		for signal := range signals {
+			s := tm.signals[signal]
+			event := structs.NewTaskEvent(structs.TaskSignaling).SetTaskSignal(s).SetDisplayMessage("Template re-rendered, reloading task")
+			if err := tm.config.Lifecycle.Signal(event, signal); err != nil {
+				_ = multierror.Append(&mErr, err)
+			}
+		}
+
+		if err := mErr.ErrorOrNil(); err != nil {
+			tm.config.Lifecycle.Kill(context.Background(),
+				structs.NewTaskEvent(structs.TaskKilling).
+					SetFailsTask().
+					SetDisplayMessage(fmt.Sprintf("Template failed to reload task: %v", err)))
+		}
+		return
+	}
+
+	_, exitCode, err := tm.config.Lifecycle.Exec(reloadCommandTimeout, cmd[0], cmd[1:])
+	if err == nil && exitCode != 0 {
+		err = fmt.Errorf("exit code %d", exitCode)
+	}
+	if err != nil {
+		tm.config.Lifecycle.Kill(context.Background(),
+			structs.NewTaskEvent(structs.TaskKilling).
+				SetFailsTask().
+				SetDisplayMessage(fmt.Sprintf("Template failed to reload task with command %v: %v", cmd, err)))
+		return
+	}
+
+	tm.config.Events.EmitEvent(structs.NewTaskEvent(structs.TaskHookMessage).
+		SetDisplayMessage(fmt.Sprintf("Template reloaded task with command %v", cmd)))
+}
+
 func (tm *TaskTemplateManager) handleChangeModeScript(scripts []*structs.ChangeScript) {
 	// process script execution concurrently
 	var wg sync.WaitGroup
@@ -580,6 +1007,14 @@ func (tm *TaskTemplateManager) handleChangeModeScript(scripts []*structs.ChangeS
 	wg.Wait()
 }
 
+// handleChangeModeExecRestart restarts the processes supervised by the
+// re-rendered templates. The task itself keeps running.
+func (tm *TaskTemplateManager) handleChangeModeExecRestart(supervisors []*supervisor.Supervisor) {
+	for _, s := range supervisors {
+		s.Restart()
+	}
+}
+
 // handleScriptError is a helper function that produces a TaskKilling event and
 // emits a message
 func (tm *TaskTemplateManager) handleScriptError(script *structs.ChangeScript, msg string) {
@@ -629,6 +1064,95 @@ func (tm *TaskTemplateManager) processScript(script *structs.ChangeScript, wg *s
 			)))
 }
 
+// usesVaultSecrets returns whether the template rendered by the event reads
+// Vault secrets, which consul-template re-fetches before their leases expire.
+func usesVaultSecrets(event *manager.RenderEvent) bool {
+	if event.UsedDeps == nil {
+		return false
+	}
+	for _, d := range event.UsedDeps.List() {
+		switch d.(type) {
+		case *dep.VaultReadQuery, *dep.VaultWriteQuery, *dep.VaultPKIQuery:
+			return true
+		}
+	}
+	return false
+}
+
+// handleSecretRotation restarts the task after its Vault secrets were
+// rotated, running the drain script first if one is configured.
+func (tm *TaskTemplateManager) handleSecretRotation() {
+	tm.config.Events.EmitEvent(structs.NewTaskEvent(structs.TaskVaultSecretRotation).
+		SetDisplayMessage("Vault secrets rotated, restarting task before the previous leases expire"))
+
+	if script := tm.config.VaultSecretRotation.DrainScript; script != nil {
+		_, exitCode, err := tm.config.Lifecycle.Exec(script.Timeout, script.Command, script.Args)
+		var failureMsg string
+		if err != nil {
+			failureMsg = fmt.Sprintf("Failed to run drain script %v with arguments %v: %v. Exit code: %v",
+				script.Command, script.Args, err, exitCode)
+		} else if exitCode != 0 {
+			failureMsg = fmt.Sprintf("Drain script %v with arguments %v exited with code: %v",
+				script.Command, script.Args, exitCode)
+		}
+		if failureMsg != "" {
+			tm.config.Events.EmitEvent(structs.NewTaskEvent(structs.TaskHookFailed).SetDisplayMessage(failureMsg))
+			if script.FailOnError {
+				tm.config.Lifecycle.Kill(context.Background(),
+					structs.NewTaskEvent(structs.TaskKilling).
+						SetFailsTask().
+						SetDisplayMessage("Drain script failed, task is being killed"))
+				return
+			}
+		}
+	}
+
+	tm.config.Lifecycle.Restart(context.Background(),
+		structs.NewTaskEvent(structs.TaskRestartSignal).
+			SetDisplayMessage("Vault secrets rotated"), false)
+}
+
+// emitMissingDeps sets the gauge of missing dependencies for the templates
+// managed by the consul-template runner under the given ID.
+func (tm *TaskTemplateManager) emitMissingDeps(id string, missing int) {
+	if !tm.config.publishMetrics() {
+		return
+	}
+	for _, tmpl := range tm.lookup[id] {
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "template", "missing_dependencies"},
+			float32(missing), tm.config.metricLabels(tmpl.DestPath))
+	}
+}
+
+// emitChangeMode counts the change_mode actions fired for a template.
+func (tm *TaskTemplateManager) emitChangeMode(tmpl *structs.Template) {
+	if !tm.config.publishMetrics() {
+		return
+	}
+	labels := append(tm.config.metricLabels(tmpl.DestPath),
+		metrics.Label{Name: "change_mode", Value: tmpl.ChangeMode})
+	metrics.IncrCounterWithLabels([]string{"client", "allocs", "template", "change_mode"}, 1, labels)
+}
+
+// instrumentRenderer wraps the given renderer to emit the render count and
+// latency of each template. The dests map is used to look up the destination
+// set in the job from the destination on the client.
+func instrumentRenderer(config *TaskTemplateManagerConfig, render renderer.Renderer, dests map[string]string) renderer.Renderer {
+	return func(i *renderer.RenderInput) (*renderer.RenderResult, error) {
+		start := time.Now()
+		result, err := render(i)
+
+		labels := config.metricLabels(dests[i.Path])
+		metrics.MeasureSinceWithLabels([]string{"client", "allocs", "template", "render_time"}, start, labels)
+		if err != nil {
+			metrics.IncrCounterWithLabels([]string{"client", "allocs", "template", "render_failed"}, 1, labels)
+		} else if result != nil && result.DidRender {
+			metrics.IncrCounterWithLabels([]string{"client", "allocs", "template", "rendered"}, 1, labels)
+		}
+		return result, err
+	}
+}
+
 // allTemplatesNoop returns whether all the managed templates have change mode noop.
 func (tm *TaskTemplateManager) allTemplatesNoop() bool {
 	for _, tmpl := range tm.config.Templates {
@@ -643,7 +1167,7 @@ func (tm *TaskTemplateManager) allTemplatesNoop() bool {
 // templateRunner returns a consul-template runner for the given templates and a
 // lookup by destination to the template. If no templates are in the config, a
 // nil template runner and lookup is returned.
-func templateRunner(config *TaskTemplateManagerConfig) (
+func templateRunner(config *TaskTemplateManagerConfig, vars *varWatcher) (
 	*manager.Runner, map[string][]*structs.Template, error) {
 
 	if len(config.Templates) == 0 {
@@ -651,7 +1175,7 @@ func templateRunner(config *TaskTemplateManagerConfig) (
 	}
 
 	// Parse the templates
-	ctmplMapping, err := parseTemplateConfigs(config)
+	ctmplMapping, err := parseTemplateConfigs(config, vars)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -703,11 +1227,21 @@ func maskProcessEnv(env map[string]string) map[string]string {
 }
 
 // parseTemplateConfigs converts the tasks templates in the config into
-// consul-templates
-func parseTemplateConfigs(config *TaskTemplateManagerConfig) (map[*ctconf.TemplateConfig]*structs.Template, error) {
+// consul-templates. The nomadVarWatch function of the templates is backed by
+// vars, if set.
+func parseTemplateConfigs(config *TaskTemplateManagerConfig, vars *varWatcher) (map[*ctconf.TemplateConfig]*structs.Template, error) {
 	sandboxEnabled := !config.ClientConfig.TemplateConfig.DisableSandbox
+	allowedPaths := config.ClientConfig.TemplateConfig.SandboxAllowedPaths
 	taskEnv := config.EnvBuilder.Build()
 
+	funcs := sourcePluginFuncs(config)
+	if vars != nil {
+		// source plugins shadow the functions of consul-template and Nomad
+		varFuncs := vars.funcs()
+		maps.Copy(varFuncs, funcs)
+		funcs = varFuncs
+	}
+
 	ctmpls := make(map[*ctconf.TemplateConfig]*structs.Template, len(config.Templates))
 	for _, tmpl := range config.Templates {
 		var src, dest string
@@ -715,7 +1249,9 @@ func parseTemplateConfigs(config *TaskTemplateManagerConfig) (map[*ctconf.Templa
 			var escapes bool
 			src, escapes = taskEnv.ClientPath(tmpl.SourcePath, false)
 			if escapes && sandboxEnabled {
-				return nil, sourceEscapesErr
+				if _, ok := sandboxAllowedPath(allowedPaths, src); !ok {
+					return nil, sourceEscapesErr
+				}
 			}
 		}
 
@@ -727,6 +1263,12 @@ func parseTemplateConfigs(config *TaskTemplateManagerConfig) (map[*ctconf.Templa
 			}
 		}
 
+		if tmpl.SourcePath == "" {
+			if err := verifyTemplateChecksum(config, tmpl, []byte(tmpl.EmbeddedTmpl)); err != nil {
+				return nil, err
+			}
+		}
+
 		ct := ctconf.DefaultTemplateConfig()
 		ct.Source = &src
 		ct.Destination = &dest
@@ -735,6 +1277,7 @@ func parseTemplateConfigs(config *TaskTemplateManagerConfig) (map[*ctconf.Templa
 		ct.RightDelim = &tmpl.RightDelim
 		ct.ErrMissingKey = &tmpl.ErrMissingKey
 		ct.FunctionDenylist = config.ClientConfig.TemplateConfig.FunctionDenylist
+		ct.ExtFuncMap = funcs
 		if sandboxEnabled {
 			ct.SandboxPath = &config.TaskDir
 		}
@@ -911,7 +1454,11 @@ func newRunnerConfig(config *TaskTemplateManagerConfig,
 	conf.Vault.Token = &emptyStr
 	if config.VaultConfig != nil && config.VaultConfig.IsEnabled() {
 		conf.Vault.Address = &config.VaultConfig.Addr
-		conf.Vault.Token = &config.VaultToken
+		vaultToken, err := config.vaultToken()
+		if err != nil {
+			return nil, err
+		}
+		conf.Vault.Token = &vaultToken
 
 		// Set the Vault Namespace. Passed in Task config has
 		// highest precedence.
@@ -979,8 +1526,22 @@ func newRunnerConfig(config *TaskTemplateManagerConfig,
 
 	sandboxEnabled := isSandboxEnabled(config)
 	sandboxDir := filepath.Dir(config.TaskDir) // alloc working directory
-	conf.ReaderFunc = ReaderFn(config.TaskID, sandboxDir, sandboxEnabled)
+	conf.ReaderFunc = ReaderFn(config.TaskID, sandboxDir, sandboxEnabled,
+		cc.TemplateConfig.SandboxAllowedPaths)
+	conf.ReaderFunc = checksumReader(config, conf.ReaderFunc, templateMapping)
 	conf.RendererFunc = RenderFn(config.TaskID, sandboxDir, sandboxEnabled)
+	if config.publishMetrics() {
+		render := conf.RendererFunc
+		if render == nil {
+			render = renderer.Render
+		}
+
+		dests := make(map[string]string, len(templateMapping))
+		for ctmpl, tmpl := range templateMapping {
+			dests[*ctmpl.Destination] = tmpl.DestPath
+		}
+		conf.RendererFunc = instrumentRenderer(config, render, dests)
+	}
 	conf.Finalize()
 	return conf, nil
 }
@@ -992,6 +1553,69 @@ func isSandboxEnabled(cfg *TaskTemplateManagerConfig) bool {
 	return true
 }
 
+// checksumReader wraps the reader of template sources to verify the sources
+// of templates with a checksum before they are parsed.
+func checksumReader(config *TaskTemplateManagerConfig, read func(string) ([]byte, error),
+	templateMapping map[*ctconf.TemplateConfig]*structs.Template) func(string) ([]byte, error) {
+
+	sources := make(map[string]*structs.Template)
+	for ctmpl, tmpl := range templateMapping {
+		if tmpl.Checksum != "" && ctmpl.Source != nil && *ctmpl.Source != "" {
+			sources[*ctmpl.Source] = tmpl
+		}
+	}
+	if len(sources) == 0 {
+		return read
+	}
+	if read == nil {
+		read = os.ReadFile
+	}
+
+	return func(src string) ([]byte, error) {
+		contents, err := read(src)
+		if err != nil {
+			return nil, err
+		}
+		if tmpl, ok := sources[src]; ok {
+			if err := verifyTemplateChecksum(config, tmpl, contents); err != nil {
+				return nil, err
+			}
+		}
+		return contents, nil
+	}
+}
+
+// verifyTemplateChecksum verifies the contents of the template against its
+// checksum and emits a task event if they do not match.
+func verifyTemplateChecksum(config *TaskTemplateManagerConfig, tmpl *structs.Template, contents []byte) error {
+	err := tmpl.VerifyChecksum(contents)
+	if err == nil {
+		return nil
+	}
+
+	err = fmt.Errorf("template %q failed checksum verification: %w", tmpl.DestPath, err)
+	if config.Events != nil {
+		config.Events.EmitEvent(structs.NewTaskEvent(consulTemplateSourceName).
+			SetDisplayMessage(err.Error()))
+	}
+	return err
+}
+
+// sandboxAllowedPath returns the operator allowed host directory that contains
+// path, if any.
+func sandboxAllowedPath(allowedPaths []string, path string) (string, bool) {
+	for _, allowed := range allowedPaths {
+		allowed = filepath.Clean(allowed)
+		if !filepath.IsAbs(allowed) {
+			continue
+		}
+		if !escapingfs.PathEscapesSandbox(allowed, path) {
+			return allowed, true
+		}
+	}
+	return "", false
+}
+
 type sandboxConfig struct {
 	thisBin     string
 	sandboxPath string
@@ -1004,9 +1628,41 @@ type sandboxConfig struct {
 	contents    []byte
 }
 
-// loadTemplateEnv loads task environment variables from all templates.
-func loadTemplateEnv(tmpls []*structs.Template, taskEnv *taskenv.TaskEnv) (map[string]string, error) {
+// reloadTemplateEnv reads the manager's env templates back into the task
+// environment. It returns true if the task's env vars changed.
+func (tm *TaskTemplateManager) reloadTemplateEnv() (bool, error) {
+	if tm.envSource == "" {
+		return false, nil
+	}
+
+	envMap, unset, err := loadTemplateEnv(tm.config.Templates, tm.config.EnvBuilder.Build())
+	if err != nil {
+		return false, err
+	}
+	return tm.config.EnvBuilder.SetTemplateEnv(tm.envSource, envMap, unset), nil
+}
+
+// templateEnvSource returns the key the env vars of the given templates are
+// set under in the task environment. Keying by destination means managers
+// rebuilt for the same templates replace their previous env vars.
+func templateEnvSource(tmpls []*structs.Template) string {
+	var dests []string
+	for _, t := range tmpls {
+		if t.Envvars {
+			dests = append(dests, t.DestPath)
+		}
+	}
+	return strings.Join(dests, ",")
+}
+
+// loadTemplateEnv loads task environment variables from all templates. Env
+// vars are prefixed with the template's EnvPrefix, and templates later in the
+// list override the env vars of earlier ones. It also returns the sorted env
+// vars unset by templates with UnsetIfEmpty, which must be removed from the
+// task environment.
+func loadTemplateEnv(tmpls []*structs.Template, taskEnv *taskenv.TaskEnv) (map[string]string, []string, error) {
 	all := make(map[string]string, 50)
+	unset := make(map[string]struct{})
 	for _, t := range tmpls {
 		if !t.Envvars {
 			continue
@@ -1016,18 +1672,30 @@ func loadTemplateEnv(tmpls []*structs.Template, taskEnv *taskenv.TaskEnv) (map[s
 		dest, _ := taskEnv.ClientPath(t.DestPath, true)
 		f, err := os.Open(dest)
 		if err != nil {
-			return nil, fmt.Errorf("error opening env template: %v", err)
+			return nil, nil, fmt.Errorf("error opening env template: %v", err)
 		}
 		defer f.Close()
 
 		// Parse environment fil
 		vars, err := envparse.Parse(f)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing env template %q: %v", dest, err)
+			return nil, nil, fmt.Errorf("error parsing env template %q: %v", dest, err)
 		}
 		for k, v := range vars {
+			k = t.EnvPrefix + k
+			if v == "" && t.UnsetIfEmpty {
+				delete(all, k)
+				unset[k] = struct{}{}
+				continue
+			}
 			all[k] = v
+			delete(unset, k)
 		}
 	}
-	return all, nil
+
+	var unsetKeys []string
+	if len(unset) > 0 {
+		unsetKeys = slices.Sorted(maps.Keys(unset))
+	}
+	return all, unsetKeys, nil
 }
@@ -163,7 +163,7 @@ func RenderFn(taskID, taskDir string, sandboxEnabled bool) func(*renderer.Render
 	}
 }
 
-func ReaderFn(taskID, taskDir string, sandboxEnabled bool) func(string) ([]byte, error) {
+func ReaderFn(taskID, taskDir string, sandboxEnabled bool, allowedPaths []string) func(string) ([]byte, error) {
 	if !sandboxEnabled {
 		return nil
 	}
@@ -171,9 +171,16 @@ func ReaderFn(taskID, taskDir string, sandboxEnabled bool) func(string) ([]byte,
 
 	return func(src string) ([]byte, error) {
 
+		// sources from an operator allowed host directory are read with that
+		// directory as the sandbox instead of the alloc dir
+		sandboxPath := taskDir
+		if allowed, ok := sandboxAllowedPath(allowedPaths, src); ok {
+			sandboxPath = allowed
+		}
+
 		sandboxCfg := &sandboxConfig{
 			thisBin:     thisBin,
-			sandboxPath: taskDir,
+			sandboxPath: sandboxPath,
 			sourcePath:  src,
 			taskID:      taskID,
 		}
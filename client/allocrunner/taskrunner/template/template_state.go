@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package template
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	dep "github.com/hashicorp/consul-template/dependency"
+	"github.com/hashicorp/consul-template/manager"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// updateTemplateState records the render state of the templates and their
+// dependencies from the runner's render events in the task state. An event is
+// emitted for each dependency that blocks an already rendered template from
+// being rendered again.
+func (tm *TaskTemplateManager) updateTemplateState(events map[string]*manager.RenderEvent) {
+	if tm.config.StateUpdater == nil {
+		return
+	}
+
+	now := time.Now()
+	var states []*structs.TemplateState
+	for id, event := range events {
+		var used []dep.Dependency
+		if event.UsedDeps != nil {
+			used = event.UsedDeps.List()
+		}
+		missing := make(map[string]struct{})
+		if event.MissingDeps != nil {
+			for _, d := range event.MissingDeps.List() {
+				missing[d.String()] = struct{}{}
+			}
+		}
+
+		for _, tmpl := range tm.lookup[id] {
+			state := &structs.TemplateState{
+				DestPath:     tmpl.DestPath,
+				LastRendered: event.LastDidRender,
+			}
+			for _, d := range used {
+				_, isMissing := missing[d.String()]
+				depState := &structs.TemplateDependencyState{
+					Name:        d.String(),
+					Type:        dependencyType(d.Type()),
+					Missing:     isMissing,
+					LastChanged: now,
+				}
+
+				prev := tm.dependencyState(tmpl.DestPath, depState.Name)
+				if prev != nil && prev.Missing == isMissing {
+					depState.LastChanged = prev.LastChanged
+				} else if isMissing && !event.LastDidRender.IsZero() {
+					tm.emitStaleDependency(tmpl, depState)
+				}
+				state.Dependencies = append(state.Dependencies, depState)
+			}
+			sort.Slice(state.Dependencies, func(i, j int) bool {
+				return state.Dependencies[i].Name < state.Dependencies[j].Name
+			})
+			states = append(states, state)
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].DestPath < states[j].DestPath
+	})
+
+	if slices.EqualFunc(states, tm.templateStates, func(a, b *structs.TemplateState) bool {
+		return a.Equal(b)
+	}) {
+		return
+	}
+	tm.templateStates = states
+	tm.config.StateUpdater.SetTemplateState(states)
+}
+
+// dependencyState returns the last recorded state of the named dependency of
+// the template rendered to dest, or nil if there is none.
+func (tm *TaskTemplateManager) dependencyState(dest, name string) *structs.TemplateDependencyState {
+	for _, state := range tm.templateStates {
+		if state.DestPath != dest {
+			continue
+		}
+		for _, d := range state.Dependencies {
+			if d.Name == name {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// emitStaleDependency emits an event for a dependency that is blocking a
+// template from being rendered again.
+func (tm *TaskTemplateManager) emitStaleDependency(tmpl *structs.Template, d *structs.TemplateDependencyState) {
+	event := structs.NewTaskEvent(consulTemplateSourceName).
+		SetDisplayMessage(fmt.Sprintf("Template %q is waiting for %s dependency %s", tmpl.DestPath, d.Type, d.Name))
+	event.Details["template"] = tmpl.DestPath
+	event.Details["dependency"] = d.Name
+	event.Details["dependency_type"] = d.Type
+	event.Details["missing_since"] = d.LastChanged.Format(time.RFC3339)
+	tm.config.Events.EmitEvent(event)
+}
+
+// dependencyType returns the name of the system a dependency is read from.
+func dependencyType(t dep.Type) string {
+	switch t {
+	case dep.TypeConsul:
+		return "consul"
+	case dep.TypeVault:
+		return "vault"
+	case dep.TypeNomad:
+		return "nomad"
+	case dep.TypeLocal:
+		return "local"
+	default:
+		return fmt.Sprintf("unknown(%d)", t)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// TestTaskTemplateManager_TemplateState asserts the render state of templates
+// and their dependencies is recorded in the task state.
+func TestTaskTemplateManager_TemplateState(t *testing.T) {
+	ci.Parallel(t)
+
+	harness := newTestHarness(t, nil, false, false)
+	input := filepath.Join(harness.taskDir, "local", "input.txt")
+	harness.templates = []*structs.Template{
+		{
+			EmbeddedTmpl: fmt.Sprintf(`{{ file %q }}`, input),
+			DestPath:     "local/output.txt",
+			ChangeMode:   structs.TemplateChangeModeNoop,
+		},
+	}
+	must.NoError(t, os.MkdirAll(filepath.Dir(input), 0755))
+	must.NoError(t, os.WriteFile(input, []byte("hello"), 0644))
+
+	harness.start(t)
+	defer harness.stop()
+
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("task should have been unblocked")
+	}
+
+	var states []*structs.TemplateState
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			states = harness.mockHooks.TemplateStates()
+			return len(states) == 1 && !states[0].LastRendered.IsZero()
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	must.Eq(t, "local/output.txt", states[0].DestPath)
+	must.Len(t, 1, states[0].Dependencies)
+	d := states[0].Dependencies[0]
+	must.Eq(t, fmt.Sprintf("file(%s)", input), d.Name)
+	must.Eq(t, "local", d.Type)
+	must.False(t, d.Missing)
+	must.False(t, d.LastChanged.IsZero())
+}
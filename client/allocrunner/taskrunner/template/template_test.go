@@ -5,6 +5,8 @@ package template
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -16,16 +18,23 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
 	ctconf "github.com/hashicorp/consul-template/config"
 	templateconfig "github.com/hashicorp/consul-template/config"
+	dep "github.com/hashicorp/consul-template/dependency"
+	"github.com/hashicorp/consul-template/manager"
+	"github.com/hashicorp/consul-template/renderer"
 	ctestutil "github.com/hashicorp/consul/sdk/testutil"
+	metrics "github.com/hashicorp/go-metrics/compat"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
 	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
 	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/taskenv"
 	clienttestutil "github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/helper/pointer"
@@ -35,10 +44,12 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	sconfig "github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/kr/pretty"
 	"github.com/shoenig/test"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 )
 
 // TestMain overrides the normal top-level test runner for this package. When
@@ -75,6 +86,8 @@ type testHarness struct {
 	consul         *ctestutil.TestServer
 	emitRate       time.Duration
 	nomadNamespace string
+	execHandler    func() drivermanager.TaskExecHandler
+	rpc            config.RPCer
 }
 
 // newTestHarness returns a harness starting a dev consul and vault server,
@@ -147,7 +160,9 @@ func (h *testHarness) startWithErr() error {
 	h.manager, err = NewTaskTemplateManager(&TaskTemplateManagerConfig{
 		UnblockCh:            h.mockHooks.UnblockCh,
 		Lifecycle:            h.mockHooks,
+		ExecHandler:          h.execHandler,
 		Events:               h.mockHooks,
+		StateUpdater:         h.mockHooks,
 		Templates:            h.templates,
 		ClientConfig:         h.config,
 		ConsulConfig:         h.config.GetDefaultConsul(),
@@ -156,6 +171,7 @@ func (h *testHarness) startWithErr() error {
 		TaskDir:              h.taskDir,
 		EnvBuilder:           h.envBuilder,
 		MaxTemplateEventRate: h.emitRate,
+		RPC:                  h.rpc,
 		TaskID:               uuid.Generate(),
 	})
 
@@ -228,6 +244,23 @@ func TestTaskTemplateManager_InvalidConfig(t *testing.T) {
 			},
 			expectedErr: "event hook",
 		},
+		{
+			name: "mixed vault roles",
+			config: &TaskTemplateManagerConfig{
+				UnblockCh:    hooks.UnblockCh,
+				Lifecycle:    hooks,
+				Events:       hooks,
+				ClientConfig: clientConfig,
+				Templates: []*structs.Template{
+					{DestPath: "local/a", EmbeddedTmpl: "a", VaultRole: "a"},
+					{DestPath: "local/b", EmbeddedTmpl: "b", VaultRole: "b"},
+				},
+				TaskDir:              taskDir,
+				EnvBuilder:           envBuilder,
+				MaxTemplateEventRate: DefaultMaxTemplateEventRate,
+			},
+			expectedErr: "same Vault role",
+		},
 		{
 			name: "bad client config",
 			config: &TaskTemplateManagerConfig{
@@ -489,6 +522,126 @@ func TestTaskTemplateManager_HostPath(t *testing.T) {
 		harness.taskDir, template.SourcePath, err))
 }
 
+// TestTaskTemplateManager_SandboxAllowedPaths asserts that template sources can
+// be read from operator allowed host directories with the sandbox enabled.
+func TestTaskTemplateManager_SandboxAllowedPaths(t *testing.T) {
+	ci.Parallel(t)
+
+	allowedDir := t.TempDir()
+	content := "hello, world!"
+	src := filepath.Join(allowedDir, "my.tmpl")
+	must.NoError(t, os.WriteFile(src, []byte(content), 0o644))
+
+	file := "my.tmpl"
+	template := &structs.Template{
+		SourcePath: src,
+		DestPath:   file,
+		ChangeMode: structs.TemplateChangeModeNoop,
+	}
+
+	harness := newTestHarness(t, []*structs.Template{template}, false, false)
+	harness.config.TemplateConfig.SandboxAllowedPaths = []string{allowedDir}
+	must.NoError(t, harness.startWithErr())
+	defer harness.stop()
+
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatal("Task unblock should have been called")
+	}
+
+	raw, err := os.ReadFile(filepath.Join(harness.taskDir, file))
+	must.NoError(t, err)
+	must.Eq(t, content, string(raw))
+
+	// sources outside of the allowed paths are still rejected
+	harness = newTestHarness(t, []*structs.Template{template}, false, false)
+	harness.config.TemplateConfig.SandboxAllowedPaths = []string{t.TempDir()}
+	err = harness.startWithErr()
+	must.ErrorContains(t, err, "escapes alloc directory")
+}
+
+func TestTaskTemplateManager_sandboxAllowedPath(t *testing.T) {
+	ci.Parallel(t)
+
+	allowed := []string{"etc/relative", "/etc/ssl/certs/", "/opt/shared"}
+
+	dir, ok := sandboxAllowedPath(allowed, "/etc/ssl/certs/ca.pem")
+	must.True(t, ok)
+	must.Eq(t, "/etc/ssl/certs", dir)
+
+	dir, ok = sandboxAllowedPath(allowed, "/opt/shared")
+	must.True(t, ok)
+	must.Eq(t, "/opt/shared", dir)
+
+	_, ok = sandboxAllowedPath(allowed, "/etc/ssl/private/key.pem")
+	must.False(t, ok)
+
+	_, ok = sandboxAllowedPath(allowed, "/opt/shared-other/file")
+	must.False(t, ok)
+
+	_, ok = sandboxAllowedPath(nil, "/etc/ssl/certs/ca.pem")
+	must.False(t, ok)
+}
+
+// TestTaskTemplateManager_Checksum asserts that templates with a checksum are
+// only rendered if their source or embedded data matches it.
+func TestTaskTemplateManager_Checksum(t *testing.T) {
+	ci.Parallel(t)
+
+	// sha256 of "hello"
+	const checksum = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	t.Run("source", func(t *testing.T) {
+		template := &structs.Template{
+			SourcePath: "local/my.tmpl",
+			DestPath:   "local/my.txt",
+			ChangeMode: structs.TemplateChangeModeNoop,
+			Checksum:   checksum,
+		}
+
+		writeSource := func(h *testHarness, contents string) {
+			src := filepath.Join(h.taskDir, template.SourcePath)
+			must.NoError(t, os.MkdirAll(filepath.Dir(src), 0o755))
+			must.NoError(t, os.WriteFile(src, []byte(contents), 0o644))
+		}
+
+		harness := newTestHarness(t, []*structs.Template{template}, false, false)
+		writeSource(harness, "hello")
+		must.NoError(t, harness.startWithErr())
+		defer harness.stop()
+
+		select {
+		case <-harness.mockHooks.UnblockCh:
+		case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+			t.Fatal("Task unblock should have been called")
+		}
+
+		// a tampered source is rejected
+		harness = newTestHarness(t, []*structs.Template{template}, false, false)
+		writeSource(harness, "goodbye")
+		err := harness.startWithErr()
+		must.ErrorContains(t, err, "checksum mismatch")
+		must.SliceNotEmpty(t, harness.mockHooks.Events())
+		must.StrContains(t, harness.mockHooks.Events()[0].DisplayMessage,
+			`template "local/my.txt" failed checksum verification`)
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		template := &structs.Template{
+			EmbeddedTmpl: "goodbye",
+			DestPath:     "local/my.txt",
+			ChangeMode:   structs.TemplateChangeModeNoop,
+			Checksum:     checksum,
+		}
+
+		harness := newTestHarness(t, []*structs.Template{template}, false, false)
+		err := harness.startWithErr()
+		must.ErrorContains(t, err, "checksum mismatch")
+		must.SliceNotEmpty(t, harness.mockHooks.Events())
+	})
+}
+
 func TestTaskTemplateManager_Unblock_Static(t *testing.T) {
 	ci.Parallel(t)
 	// Make a template that will render immediately
@@ -529,6 +682,68 @@ func TestTaskTemplateManager_Unblock_Static(t *testing.T) {
 	}
 }
 
+// TestTaskTemplateManager_DryRun asserts that a dry run returns the rendered
+// templates without writing them to the task directory.
+func TestTaskTemplateManager_DryRun(t *testing.T) {
+	ci.Parallel(t)
+
+	templates := []*structs.Template{
+		{
+			EmbeddedTmpl: `Hello from {{env "NOMAD_TASK_NAME"}}!`,
+			DestPath:     "local/b.txt",
+			ChangeMode:   structs.TemplateChangeModeNoop,
+		},
+		{
+			EmbeddedTmpl: "static",
+			DestPath:     "local/a.txt",
+			ChangeMode:   structs.TemplateChangeModeNoop,
+		},
+		{
+			EmbeddedTmpl: "password=hunter2",
+			DestPath:     "secrets/c.env",
+			ChangeMode:   structs.TemplateChangeModeNoop,
+		},
+	}
+
+	harness := newTestHarness(t, templates, false, false)
+	m, err := NewTaskTemplateManager(&TaskTemplateManagerConfig{
+		UnblockCh:            harness.mockHooks.UnblockCh,
+		Lifecycle:            harness.mockHooks,
+		Events:               harness.mockHooks,
+		Templates:            harness.templates,
+		ClientConfig:         harness.config,
+		TaskDir:              harness.taskDir,
+		EnvBuilder:           harness.envBuilder,
+		MaxTemplateEventRate: harness.emitRate,
+		TaskID:               uuid.Generate(),
+	})
+	must.NoError(t, err)
+
+	results, err := m.DryRun(5 * time.Second)
+	must.NoError(t, err)
+	must.Len(t, 3, results)
+
+	must.Eq(t, "local/a.txt", results[0].DestPath)
+	must.True(t, results[0].Rendered)
+	must.False(t, results[0].Redacted)
+	must.Eq(t, "static", results[0].Contents)
+
+	must.Eq(t, "local/b.txt", results[1].DestPath)
+	must.True(t, results[1].Rendered)
+	must.Eq(t, "Hello from "+TestTaskName+"!", results[1].Contents)
+	must.SliceEmpty(t, results[1].MissingDeps)
+
+	// the contents of templates rendered into the secrets dir are redacted
+	must.Eq(t, "secrets/c.env", results[2].DestPath)
+	must.True(t, results[2].Rendered)
+	must.True(t, results[2].Redacted)
+	must.Eq(t, "", results[2].Contents)
+
+	// nothing is written to the task directory
+	_, err = os.Stat(filepath.Join(harness.taskDir, "local", "a.txt"))
+	must.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestTaskTemplateManager_Unblock_Static_NomadEnv(t *testing.T) {
 	ci.Parallel(t)
 	// Make a template that will render immediately
@@ -1179,6 +1394,182 @@ OUTER:
 	}
 }
 
+// fakeExecTask counts the processes executed in a task and how many of them
+// have been stopped
+type fakeExecTask struct {
+	lock    sync.Mutex
+	execs   int
+	stopped int
+}
+
+func (f *fakeExecTask) handler() drivermanager.TaskExecHandler {
+	return func(ctx context.Context, _ []string, _ bool, _ drivers.ExecTaskStream) error {
+		f.lock.Lock()
+		f.execs++
+		f.lock.Unlock()
+
+		<-ctx.Done()
+
+		f.lock.Lock()
+		f.stopped++
+		f.lock.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (f *fakeExecTask) counts() (int, int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.execs, f.stopped
+}
+
+// TestTaskTemplateManager_ExecRestart asserts that the process of a template
+// with change_mode exec_restart is started once the template renders and
+// stopped with the template manager.
+func TestTaskTemplateManager_ExecRestart(t *testing.T) {
+	ci.Parallel(t)
+
+	template := &structs.Template{
+		EmbeddedTmpl: "hello, world!",
+		DestPath:     "local/helper.conf",
+		ChangeMode:   structs.TemplateChangeModeExecRestart,
+		Exec: &structs.TemplateExec{
+			Command: "/bin/helper",
+			Args:    []string{"-config", "local/helper.conf"},
+		},
+	}
+
+	// templates with exec_restart need a way to exec into the task
+	harness := newTestHarness(t, []*structs.Template{template}, false, false)
+	must.ErrorContains(t, harness.startWithErr(), "requires an exec handler")
+
+	task := &fakeExecTask{}
+	harness = newTestHarness(t, []*structs.Template{template}, false, false)
+	harness.execHandler = task.handler
+	harness.start(t)
+
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatal("Task unblock should have been called")
+	}
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			execs, _ := task.counts()
+			return execs == 1
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	harness.stop()
+	execs, stopped := task.counts()
+	must.Eq(t, 1, execs)
+	must.Eq(t, 1, stopped)
+}
+
+// TestTaskTemplateManager_Rerender_ExecRestart asserts that re-rendering a
+// template with change_mode exec_restart restarts its process but not the
+// task.
+func TestTaskTemplateManager_Rerender_ExecRestart(t *testing.T) {
+	ci.Parallel(t)
+	clienttestutil.RequireConsul(t)
+
+	key := "helper"
+	template := &structs.Template{
+		EmbeddedTmpl: fmt.Sprintf(`{{key "%s"}}`, key),
+		DestPath:     "local/helper.conf",
+		ChangeMode:   structs.TemplateChangeModeExecRestart,
+		Exec: &structs.TemplateExec{
+			Command: "/bin/helper",
+		},
+	}
+
+	task := &fakeExecTask{}
+	harness := newTestHarness(t, []*structs.Template{template}, true, false)
+	harness.execHandler = task.handler
+	harness.start(t)
+	defer harness.stop()
+
+	harness.consul.SetKV(t, key, []byte("cat"))
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatal("Task unblock should have been called")
+	}
+
+	harness.consul.SetKV(t, key, []byte("dog"))
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			execs, stopped := task.counts()
+			return execs == 2 && stopped == 1
+		}),
+		wait.Timeout(time.Duration(10*testutil.TestMultiplier())*time.Second),
+		wait.Gap(100*time.Millisecond),
+	))
+	must.Eq(t, 0, harness.mockHooks.Restarts())
+}
+
+// TestTaskTemplateManager_Rerender_Debounce asserts that re-renders within the
+// client's change_mode_debounce window result in a single restart.
+func TestTaskTemplateManager_Rerender_Debounce(t *testing.T) {
+	ci.Parallel(t)
+	clienttestutil.RequireConsul(t)
+
+	key := "debounce"
+	template := &structs.Template{
+		EmbeddedTmpl: fmt.Sprintf(`{{key "%s"}}`, key),
+		DestPath:     "my.tmpl",
+		ChangeMode:   structs.TemplateChangeModeRestart,
+	}
+
+	harness := newTestHarness(t, []*structs.Template{template}, true, false)
+	harness.config.TemplateConfig.ChangeModeDebounce = time.Duration(2*testutil.TestMultiplier()) * time.Second
+	harness.consul.SetKV(t, key, []byte("0"))
+	harness.start(t)
+	defer harness.stop()
+
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatal("Task unblock should have been called")
+	}
+
+	// Update the key several times within the window
+	for i := 1; i <= 3; i++ {
+		harness.consul.SetKV(t, key, []byte(strconv.Itoa(i)))
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	select {
+	case <-harness.mockHooks.RestartCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatal("Should have received a restart")
+	}
+
+	// No further restarts once the window is handled
+	select {
+	case <-harness.mockHooks.RestartCh:
+		t.Fatal("Should have received a single restart")
+	case <-time.After(time.Second):
+	}
+	must.Eq(t, 1, harness.mockHooks.Restarts())
+}
+
+func TestTaskTemplateManager_changeModeDebounce(t *testing.T) {
+	ci.Parallel(t)
+
+	tm := &TaskTemplateManager{config: &TaskTemplateManagerConfig{}}
+	must.Zero(t, tm.changeModeDebounce())
+
+	tm.config.ClientConfig = config.DefaultConfig()
+	must.Zero(t, tm.changeModeDebounce())
+
+	tm.config.ClientConfig.TemplateConfig.ChangeModeDebounce = 3 * time.Second
+	must.Eq(t, 3*time.Second, tm.changeModeDebounce())
+}
+
 func TestTaskTemplateManager_Interpolate_Destination(t *testing.T) {
 	ci.Parallel(t)
 	// Make a template that will have its destination interpolated
@@ -1263,6 +1654,210 @@ func TestTaskTemplateManager_Signal_Error(t *testing.T) {
 	must.StrContains(t, harness.mockHooks.KillEvent().DisplayMessage, "failed to send signals")
 }
 
+// TestTaskTemplateManager_handleChangeModeReload asserts change_mode reload
+// runs the driver's reload command if configured and otherwise signals the
+// task.
+func TestTaskTemplateManager_handleChangeModeReload(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name       string
+		signalErr  error
+		cmds       map[string][]string
+		execCode   int
+		expSignals []string
+		expKilled  bool
+		expMessage string
+	}{
+		{
+			name:       "signal ok",
+			expSignals: []string{"SIGHUP"},
+		},
+		{
+			name:       "signal failed without reload command",
+			signalErr:  errors.New("no signals"),
+			expSignals: []string{"SIGHUP"},
+			expKilled:  true,
+			expMessage: "no signals",
+		},
+		{
+			name:       "reload command with signal ok",
+			cmds:       map[string][]string{"docker": {"nginx", "-s", "reload"}},
+			expMessage: "Template reloaded task with command [nginx -s reload]",
+		},
+		{
+			name:       "reload command with signal failed",
+			signalErr:  errors.New("no signals"),
+			cmds:       map[string][]string{"docker": {"nginx", "-s", "reload"}},
+			expMessage: "Template reloaded task with command [nginx -s reload]",
+		},
+		{
+			name:       "reload command for other driver",
+			cmds:       map[string][]string{"exec": {"nginx", "-s", "reload"}},
+			expSignals: []string{"SIGHUP"},
+		},
+		{
+			name:       "reload command failed",
+			cmds:       map[string][]string{"docker": {"nginx", "-s", "reload"}},
+			execCode:   1,
+			expKilled:  true,
+			expMessage: "exit code 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hooks := trtesting.NewMockTaskHooks()
+			hooks.SignalError = tc.signalErr
+			hooks.SetupExecTest(tc.execCode, nil)
+
+			tm := &TaskTemplateManager{
+				config: &TaskTemplateManagerConfig{
+					Lifecycle:  hooks,
+					Events:     hooks,
+					TaskDriver: "docker",
+					ClientConfig: &config.Config{
+						TemplateConfig: &config.ClientTemplateConfig{ReloadCommands: tc.cmds},
+					},
+				},
+				signals: map[string]os.Signal{"SIGHUP": syscall.SIGHUP},
+			}
+
+			tm.handleChangeModeReload(map[string]struct{}{"SIGHUP": {}})
+			must.Eq(t, tc.expSignals, hooks.Signals())
+
+			if tc.expKilled {
+				must.NotNil(t, hooks.KillEvent())
+				must.StrContains(t, hooks.KillEvent().DisplayMessage, tc.expMessage)
+				return
+			}
+			must.Nil(t, hooks.KillEvent())
+			if tc.expMessage != "" {
+				events := hooks.Events()
+				must.SliceNotEmpty(t, events)
+				must.Eq(t, tc.expMessage, events[len(events)-1].DisplayMessage)
+			}
+		})
+	}
+}
+
+// TestTaskTemplateManager_VaultSecretRotation asserts templates re-rendered
+// because of Vault secrets restart the task when secret rotation is enabled,
+// after running its drain script.
+func TestTaskTemplateManager_VaultSecretRotation(t *testing.T) {
+	ci.Parallel(t)
+
+	vaultDep, err := dep.NewVaultReadQuery("secret/data/db")
+	must.NoError(t, err)
+	consulDep, err := dep.NewKVGetQuery("db")
+	must.NoError(t, err)
+
+	drainScript := &structs.ChangeScript{Command: "/bin/drain", Timeout: 5 * time.Second}
+
+	testCases := []struct {
+		name        string
+		rotation    *structs.VaultSecretRotation
+		dep         dep.Dependency
+		execCode    int
+		expRestart  bool
+		expKilled   bool
+		expLastType string
+	}{
+		{
+			name:     "rotation disabled",
+			rotation: nil,
+			dep:      vaultDep,
+		},
+		{
+			name:     "not a vault secret",
+			rotation: &structs.VaultSecretRotation{},
+			dep:      consulDep,
+		},
+		{
+			name:        "without drain script",
+			rotation:    &structs.VaultSecretRotation{},
+			dep:         vaultDep,
+			expRestart:  true,
+			expLastType: structs.TaskVaultSecretRotation,
+		},
+		{
+			name:        "with drain script",
+			rotation:    &structs.VaultSecretRotation{DrainScript: drainScript},
+			dep:         vaultDep,
+			expRestart:  true,
+			expLastType: structs.TaskVaultSecretRotation,
+		},
+		{
+			name:        "drain script failed",
+			rotation:    &structs.VaultSecretRotation{DrainScript: drainScript},
+			dep:         vaultDep,
+			execCode:    1,
+			expRestart:  true,
+			expLastType: structs.TaskHookFailed,
+		},
+		{
+			name: "drain script failed with fail_on_error",
+			rotation: &structs.VaultSecretRotation{
+				DrainScript: &structs.ChangeScript{Command: "/bin/drain", FailOnError: true},
+			},
+			dep:         vaultDep,
+			execCode:    1,
+			expKilled:   true,
+			expLastType: structs.TaskHookFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hooks := trtesting.NewMockTaskHooks()
+			hooks.SetupExecTest(tc.execCode, nil)
+
+			tmpl := &structs.Template{
+				DestPath:   "db.txt",
+				ChangeMode: structs.TemplateChangeModeNoop,
+			}
+			tm := &TaskTemplateManager{
+				config: &TaskTemplateManagerConfig{
+					Lifecycle:           hooks,
+					Events:              hooks,
+					ClientConfig:        &config.Config{},
+					VaultSecretRotation: tc.rotation,
+				},
+				lookup: map[string][]*structs.Template{"db": {tmpl}},
+			}
+
+			used := &dep.Set{}
+			used.Add(tc.dep)
+			allRendered := time.Now()
+			events := map[string]*manager.RenderEvent{
+				"db": {LastDidRender: allRendered.Add(time.Minute), UsedDeps: used},
+			}
+			handled := map[string]time.Time{}
+			tm.onTemplateRendered(handled, allRendered, events)
+
+			if tc.expRestart {
+				must.Eq(t, 1, hooks.Restarts())
+				must.Eq(t, events["db"].LastDidRender, handled["db"])
+			} else {
+				must.Eq(t, 0, hooks.Restarts())
+			}
+			if tc.expKilled {
+				must.NotNil(t, hooks.KillEvent())
+			} else {
+				must.Nil(t, hooks.KillEvent())
+			}
+			if tc.expLastType != "" {
+				evs := hooks.Events()
+				must.SliceNotEmpty(t, evs)
+				must.Eq(t, structs.TaskVaultSecretRotation, evs[0].Type)
+				must.Eq(t, tc.expLastType, evs[len(evs)-1].Type)
+			} else {
+				must.SliceEmpty(t, hooks.Events())
+			}
+		})
+	}
+}
+
 func TestTaskTemplateManager_ScriptExecution(t *testing.T) {
 	ci.Parallel(t)
 	clienttestutil.RequireConsul(t)
@@ -1667,7 +2262,7 @@ func TestTaskTemplateManager_Env_Missing(t *testing.T) {
 	}
 
 	taskEnv := taskenv.NewEmptyBuilder().SetClientTaskRoot(d).Build()
-	if vars, err := loadTemplateEnv(templates, taskEnv); err == nil {
+	if vars, _, err := loadTemplateEnv(templates, taskEnv); err == nil {
 		t.Fatalf("expected an error but instead got env vars: %#v", vars)
 	}
 }
@@ -1701,7 +2296,7 @@ func TestTaskTemplateManager_Env_InterpolatedDest(t *testing.T) {
 		map[string]string{},
 		d, "")
 
-	vars, err := loadTemplateEnv(templates, taskEnv)
+	vars, _, err := loadTemplateEnv(templates, taskEnv)
 	must.NoError(t, err)
 	must.MapContainsKey(t, vars, "FOO")
 	must.Eq(t, "bar", vars["FOO"])
@@ -1737,7 +2332,7 @@ func TestTaskTemplateManager_Env_Multi(t *testing.T) {
 	}
 
 	taskEnv := taskenv.NewEmptyBuilder().SetClientTaskRoot(d).Build()
-	vars, err := loadTemplateEnv(templates, taskEnv)
+	vars, _, err := loadTemplateEnv(templates, taskEnv)
 	if err != nil {
 		t.Fatalf("expected no error: %v", err)
 	}
@@ -1752,6 +2347,100 @@ func TestTaskTemplateManager_Env_Multi(t *testing.T) {
 	}
 }
 
+// TestTaskTemplateManager_Env_PrefixUnset asserts env templates can prefix
+// their env vars and unset env vars set by earlier templates.
+func TestTaskTemplateManager_Env_PrefixUnset(t *testing.T) {
+	ci.Parallel(t)
+	d := t.TempDir()
+
+	must.NoError(t, os.WriteFile(filepath.Join(d, "base.env"), []byte("FOO=bar\nDEBUG=1\nEMPTY=x\n"), 0644))
+	must.NoError(t, os.WriteFile(filepath.Join(d, "db.env"), []byte("FOO=db\n"), 0644))
+	must.NoError(t, os.WriteFile(filepath.Join(d, "override.env"), []byte("DEBUG=\nEMPTY=\n"), 0644))
+	must.NoError(t, os.WriteFile(filepath.Join(d, "keep.env"), []byte("EMPTY=\n"), 0644))
+
+	templates := []*structs.Template{
+		{
+			DestPath: "base.env",
+			Envvars:  true,
+		},
+		{
+			DestPath:  "db.env",
+			Envvars:   true,
+			EnvPrefix: "DB_",
+		},
+		{
+			DestPath:     "override.env",
+			Envvars:      true,
+			UnsetIfEmpty: true,
+		},
+		{
+			DestPath: "keep.env",
+			Envvars:  true,
+		},
+	}
+
+	builder := taskenv.NewEmptyBuilder().SetClientTaskRoot(d)
+	vars, unset, err := loadTemplateEnv(templates, builder.Build())
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{
+		"FOO":    "bar",
+		"DB_FOO": "db",
+		"EMPTY":  "",
+	}, vars)
+	must.Eq(t, []string{"DEBUG"}, unset)
+
+	// unset env vars are removed from the task env even when set elsewhere
+	builder.SetHookEnv("test", map[string]string{"DEBUG": "task"})
+	builder.SetTemplateEnv("base.env,db.env,override.env,keep.env", vars, unset)
+	must.MapNotContainsKey(t, builder.Build().All(), "DEBUG")
+}
+
+// TestTaskTemplateManager_reloadTemplateEnv asserts env templates are read
+// back into the task environment and that unchanged env vars are detected.
+func TestTaskTemplateManager_reloadTemplateEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	d := t.TempDir()
+	path := filepath.Join(d, "upstreams.env")
+	must.NoError(t, os.WriteFile(path, []byte("DB_ADDR=10.0.0.1:5432\n"), 0644))
+
+	builder := taskenv.NewEmptyBuilder().SetClientTaskRoot(d)
+	builder.SetTemplateEnv("other.env", map[string]string{"OTHER": "kept"}, nil)
+
+	templates := []*structs.Template{
+		{DestPath: "upstreams.env", Envvars: true},
+		{DestPath: "app.conf"},
+	}
+	tm := &TaskTemplateManager{
+		config:    &TaskTemplateManagerConfig{Templates: templates, EnvBuilder: builder},
+		envSource: templateEnvSource(templates),
+	}
+	must.Eq(t, "upstreams.env", tm.envSource)
+
+	changed, err := tm.reloadTemplateEnv()
+	must.NoError(t, err)
+	must.True(t, changed)
+
+	changed, err = tm.reloadTemplateEnv()
+	must.NoError(t, err)
+	must.False(t, changed)
+
+	must.NoError(t, os.WriteFile(path, []byte("DB_ADDR=10.0.0.2:5432\n"), 0644))
+	changed, err = tm.reloadTemplateEnv()
+	must.NoError(t, err)
+	must.True(t, changed)
+
+	env := builder.Build().All()
+	must.Eq(t, "10.0.0.2:5432", env["DB_ADDR"])
+	must.Eq(t, "kept", env["OTHER"])
+
+	// managers without env templates never change the environment
+	noEnv := &TaskTemplateManager{config: &TaskTemplateManagerConfig{EnvBuilder: builder}}
+	changed, err = noEnv.reloadTemplateEnv()
+	must.NoError(t, err)
+	must.False(t, changed)
+}
+
 func TestTaskTemplateManager_Rerender_Env(t *testing.T) {
 	ci.Parallel(t)
 	clienttestutil.RequireConsul(t)
@@ -1890,7 +2579,7 @@ func TestTaskTemplateManager_Config_VaultNamespace(t *testing.T) {
 		TaskID:       uuid.Generate(),
 	}
 
-	ctmplMapping, err := parseTemplateConfigs(config)
+	ctmplMapping, err := parseTemplateConfigs(config, nil)
 	must.NoError(t, err, must.Sprint("parsing templates"))
 
 	ctconf, err := newRunnerConfig(config, ctmplMapping)
@@ -1928,7 +2617,7 @@ func TestTaskTemplateManager_Config_VaultNamespace_TaskOverride(t *testing.T) {
 		TaskID:         uuid.Generate(),
 	}
 
-	ctmplMapping, err := parseTemplateConfigs(config)
+	ctmplMapping, err := parseTemplateConfigs(config, nil)
 	must.NoError(t, err, must.Sprint("parsing templates"))
 
 	ctconf, err := newRunnerConfig(config, ctmplMapping)
@@ -1936,6 +2625,66 @@ func TestTaskTemplateManager_Config_VaultNamespace_TaskOverride(t *testing.T) {
 	must.Eq(t, overriddenNS, *ctconf.Vault.Namespace, must.Sprintf("Vault Namespace Value"))
 }
 
+// TestTaskTemplateManager_Config_VaultRole asserts templates that request a
+// Vault role use the token derived for that role.
+func TestTaskTemplateManager_Config_VaultRole(t *testing.T) {
+	ci.Parallel(t)
+
+	c := config.DefaultConfig()
+	c.Node = mock.Node()
+	c.TemplateConfig.DisableSandbox = true
+	c.VaultConfigs = map[string]*sconfig.VaultConfig{
+		structs.VaultDefaultCluster: {
+			Enabled: pointer.Of(true),
+			Addr:    "http://localhost/",
+		},
+	}
+
+	alloc := mock.Alloc()
+	newConfig := func(role string) *TaskTemplateManagerConfig {
+		return &TaskTemplateManagerConfig{
+			ClientConfig: c,
+			Templates: []*structs.Template{{
+				DestPath:     "local/test",
+				EmbeddedTmpl: "foo",
+				VaultRole:    role,
+			}},
+			VaultToken:      "task-token",
+			VaultRoleTokens: map[string]string{"db": "db-token"},
+			VaultConfig:     c.GetDefaultVault(),
+			EnvBuilder:      taskenv.NewBuilder(c.Node, alloc, alloc.Job.TaskGroups[0].Tasks[0], c.Region),
+			TaskID:          uuid.Generate(),
+		}
+	}
+
+	testCases := []struct {
+		role      string
+		expToken  string
+		expErrMsg string
+	}{
+		{role: "", expToken: "task-token"},
+		{role: "db", expToken: "db-token"},
+		{role: "web", expErrMsg: `no Vault token available for role "web"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.role, func(t *testing.T) {
+			config := newConfig(tc.role)
+
+			ctmplMapping, err := parseTemplateConfigs(config, nil)
+			must.NoError(t, err, must.Sprint("parsing templates"))
+
+			ctconf, err := newRunnerConfig(config, ctmplMapping)
+			if tc.expErrMsg != "" {
+				must.EqError(t, err, tc.expErrMsg)
+				return
+			}
+			must.NoError(t, err, must.Sprint("building runner config"))
+			must.Eq(t, tc.expToken, *ctconf.Vault.Token)
+		})
+	}
+}
+
 // TestTaskTemplateManager_Escapes asserts that when sandboxing is enabled
 // interpolated paths are not incorrectly treated as escaping the alloc dir.
 func TestTaskTemplateManager_Escapes(t *testing.T) {
@@ -2170,7 +2919,7 @@ func TestTaskTemplateManager_Escapes(t *testing.T) {
 		t.Run(tc.Name, func(t *testing.T) {
 			config := tc.Config()
 			config.TaskID = uuid.Generate()
-			mapping, err := parseTemplateConfigs(config)
+			mapping, err := parseTemplateConfigs(config, nil)
 			if tc.Err == nil {
 				// Ok path
 				must.NoError(t, err)
@@ -2290,6 +3039,65 @@ WAIT_LOOP:
 	}
 }
 
+// TestTaskTemplateManager_FirstRenderTimeout asserts that the task is killed
+// when a template stays blocked past its first_render_timeout, and that the
+// configured action controls whether the task is failed.
+func TestTaskTemplateManager_FirstRenderTimeout(t *testing.T) {
+	ci.Parallel(t)
+	clienttestutil.RequireConsul(t)
+
+	cases := []struct {
+		action string
+		fails  bool
+	}{
+		{action: structs.TemplateFirstRenderActionFail, fails: true},
+		{action: structs.TemplateFirstRenderActionKill, fails: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.action, func(t *testing.T) {
+			template := &structs.Template{
+				EmbeddedTmpl:             `{{key "missing"}}`,
+				DestPath:                 "my.tmpl",
+				ChangeMode:               structs.TemplateChangeModeNoop,
+				FirstRenderTimeout:       200 * time.Millisecond,
+				FirstRenderTimeoutAction: tc.action,
+			}
+
+			harness := newTestHarness(t, []*structs.Template{template}, true, false)
+			harness.start(t)
+			defer harness.stop()
+
+			select {
+			case <-harness.mockHooks.UnblockCh:
+				t.Fatal("task should not have been unblocked")
+			case event := <-harness.mockHooks.KillCh:
+				must.StrContains(t, event.DisplayMessage, "first_render_timeout")
+				must.Eq(t, tc.fails, event.FailsTask)
+			case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+				t.Fatal("task should have been killed")
+			}
+		})
+	}
+}
+
+func TestTaskTemplateManager_firstRenderTimeout(t *testing.T) {
+	ci.Parallel(t)
+
+	tm := &TaskTemplateManager{config: &TaskTemplateManagerConfig{}}
+	timeout, _ := tm.firstRenderTimeout()
+	must.Zero(t, timeout)
+
+	tm.config.Templates = []*structs.Template{
+		{FirstRenderTimeout: 0},
+		{FirstRenderTimeout: time.Minute, FirstRenderTimeoutAction: structs.TemplateFirstRenderActionFail},
+		{FirstRenderTimeout: time.Second, FirstRenderTimeoutAction: structs.TemplateFirstRenderActionKill},
+	}
+	timeout, action := tm.firstRenderTimeout()
+	must.Eq(t, time.Second, timeout)
+	must.Eq(t, structs.TemplateFirstRenderActionKill, action)
+}
+
 // TestTaskTemplateManager_ClientTemplateConfig_Set asserts that all client level
 // configuration is accurately mapped from the client to the TaskTemplateManager
 // and that any operator defined boundaries are enforced.
@@ -2477,7 +3285,7 @@ func TestTaskTemplateManager_ClientTemplateConfig_Set(t *testing.T) {
 			// monkey patch the client config with the version of the ClientTemplateConfig we want to test.
 			_case.TTMConfig.ClientConfig.TemplateConfig = _case.ClientTemplateConfig
 			_case.TTMConfig.TaskID = uuid.Generate()
-			templateMapping, err := parseTemplateConfigs(_case.TTMConfig)
+			templateMapping, err := parseTemplateConfigs(_case.TTMConfig, nil)
 			must.NoError(t, err)
 
 			runnerConfig, err := newRunnerConfig(_case.TTMConfig, templateMapping)
@@ -2544,7 +3352,7 @@ func TestTaskTemplateManager_Template_Wait_Set(t *testing.T) {
 		TaskID: uuid.Generate(),
 	}
 
-	templateMapping, err := parseTemplateConfigs(ttmConfig)
+	templateMapping, err := parseTemplateConfigs(ttmConfig, nil)
 	must.NoError(t, err)
 
 	for k, _ := range templateMapping {
@@ -2641,7 +3449,7 @@ func TestTaskTemplateManager_Template_ErrMissingKey_Set(t *testing.T) {
 		TaskID: uuid.Generate(),
 	}
 
-	templateMapping, err := parseTemplateConfigs(ttmConfig)
+	templateMapping, err := parseTemplateConfigs(ttmConfig, nil)
 	must.NoError(t, err)
 
 	for k, tmpl := range templateMapping {
@@ -2744,3 +3552,45 @@ func TestTaskTemplateManager_writeToFile(t *testing.T) {
 	must.NoError(t, err)
 	must.Eq(t, "hello", string(r))
 }
+
+// TestTaskTemplateManager_instrumentRenderer asserts template renders are
+// counted and timed per destination.
+func TestTaskTemplateManager_instrumentRenderer(t *testing.T) {
+	// Not parallel since it replaces the global metrics sink.
+
+	sink := metrics.NewInmemSink(10*time.Second, 50*time.Second)
+	_, err := metrics.NewGlobal(metrics.DefaultConfig("nomad_test"), sink)
+	must.NoError(t, err)
+
+	config := &TaskTemplateManagerConfig{
+		ClientConfig: &config.Config{PublishAllocationMetrics: true},
+		MetricLabels: []metrics.Label{{Name: "task", Value: "web"}},
+	}
+	dests := map[string]string{"/alloc/web/local/app.conf": "local/app.conf"}
+
+	didRender := true
+	var renderErr error
+	render := instrumentRenderer(config, func(*renderer.RenderInput) (*renderer.RenderResult, error) {
+		return &renderer.RenderResult{DidRender: didRender, WouldRender: true}, renderErr
+	}, dests)
+
+	input := &renderer.RenderInput{Path: "/alloc/web/local/app.conf"}
+	_, err = render(input)
+	must.NoError(t, err)
+
+	// A render that doesn't change the file on disk isn't counted.
+	didRender = false
+	_, err = render(input)
+	must.NoError(t, err)
+
+	renderErr = errors.New("oops")
+	_, err = render(input)
+	must.ErrorIs(t, err, renderErr)
+
+	suffix := ";task=web;destination=local/app.conf"
+	data := sink.Data()
+	must.Len(t, 1, data)
+	must.Eq(t, 1, data[0].Counters["nomad_test.client.allocs.template.rendered"+suffix].Count)
+	must.Eq(t, 1, data[0].Counters["nomad_test.client.allocs.template.render_failed"+suffix].Count)
+	must.Eq(t, 3, data[0].Samples["nomad_test.client.allocs.template.render_time"+suffix].Count)
+}
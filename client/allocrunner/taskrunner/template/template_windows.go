@@ -13,6 +13,6 @@ func RenderFn(taskID, taskDir string, sandboxEnabled bool) func(*renderer.Render
 	return nil
 }
 
-func ReaderFn(taskID, taskDir string, sandboxEnabled bool) func(string) ([]byte, error) {
+func ReaderFn(taskID, taskDir string, sandboxEnabled bool, allowedPaths []string) func(string) ([]byte, error) {
 	return nil
 }
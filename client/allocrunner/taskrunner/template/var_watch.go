@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package template
+
+import (
+	"context"
+	"errors"
+	"sync"
+	gotemplate "text/template"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// varWatchFuncName is the name of the template function that reads a
+	// Nomad Variable and watches it for changes
+	varWatchFuncName = "nomadVarWatch"
+
+	// varWatchRetryInterval is the time to wait before retrying a failed
+	// blocking query of a watched variable
+	varWatchRetryInterval = 5 * time.Second
+)
+
+// varWatcher backs the nomadVarWatch template function, which returns the
+// items of a Nomad Variable:
+//
+//	{{ with nomadVarWatch "nomad/jobs/example" }}{{ .password }}{{ end }}
+//
+// Each variable read is then watched with a blocking query, and the watcher
+// notifies the template manager on its change channel as soon as the
+// variable is written or deleted.
+type varWatcher struct {
+	rpc       config.RPCer
+	region    string
+	namespace string
+	token     string
+	logger    hclog.Logger
+
+	// watch is false if the variables are only read, such as for dry runs or
+	// templates in once mode
+	watch bool
+
+	// changeCh receives a value when a watched variable changes
+	changeCh chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	watchedLock sync.Mutex
+	watched     map[string]struct{}
+}
+
+func newVarWatcher(config *TaskTemplateManagerConfig, watch bool) *varWatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &varWatcher{
+		rpc:       config.RPC,
+		namespace: config.NomadNamespace,
+		token:     config.NomadToken,
+		logger:    hclog.NewNullLogger(),
+		watch:     watch,
+		changeCh:  make(chan struct{}, 1),
+		ctx:       ctx,
+		cancel:    cancel,
+		watched:   map[string]struct{}{},
+	}
+	if config.ClientConfig != nil {
+		w.region = config.ClientConfig.Region
+	}
+	if config.Logger != nil {
+		w.logger = config.Logger.Named("var_watch")
+	}
+	return w
+}
+
+// funcs returns the template functions of the watcher.
+func (w *varWatcher) funcs() gotemplate.FuncMap {
+	return gotemplate.FuncMap{varWatchFuncName: w.read}
+}
+
+// read returns the items of the variable at path, which are empty if the
+// variable doesn't exist, and starts watching the variable.
+func (w *varWatcher) read(path string) (structs.VariableItems, error) {
+	if w.rpc == nil {
+		return nil, errors.New("nomadVarWatch is not available")
+	}
+
+	reply, err := w.query(path, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var items structs.VariableItems
+	var modifyIndex uint64
+	if reply.Data != nil {
+		items = reply.Data.Items
+		modifyIndex = reply.Data.ModifyIndex
+	}
+
+	if w.watch {
+		w.watchedLock.Lock()
+		if _, ok := w.watched[path]; !ok {
+			w.watched[path] = struct{}{}
+			go w.run(path, reply.Index, modifyIndex)
+		}
+		w.watchedLock.Unlock()
+	}
+
+	if items == nil {
+		items = structs.VariableItems{}
+	}
+	return items, nil
+}
+
+// watching returns true if any variable is being watched.
+func (w *varWatcher) watching() bool {
+	w.watchedLock.Lock()
+	defer w.watchedLock.Unlock()
+	return len(w.watched) > 0
+}
+
+// run watches the variable at path until the watcher is stopped, starting
+// from the index and ModifyIndex of the last read.
+func (w *varWatcher) run(path string, index, modifyIndex uint64) {
+	for {
+		reply, err := w.query(path, index)
+		if w.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			w.logger.Warn("failed to watch variable", "path", path, "error", err)
+			select {
+			case <-w.ctx.Done():
+				return
+			case <-time.After(varWatchRetryInterval):
+			}
+			continue
+		}
+
+		index = max(index, reply.Index)
+
+		var latest uint64
+		if reply.Data != nil {
+			latest = reply.Data.ModifyIndex
+		}
+		if latest == modifyIndex {
+			continue
+		}
+		modifyIndex = latest
+
+		select {
+		case w.changeCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// query reads the variable at path, blocking until its index is greater than
+// minIndex.
+func (w *varWatcher) query(path string, minIndex uint64) (*structs.VariablesReadResponse, error) {
+	args := &structs.VariablesReadRequest{
+		Path: path,
+		QueryOptions: structs.QueryOptions{
+			Region:        w.region,
+			Namespace:     w.namespace,
+			AuthToken:     w.token,
+			AllowStale:    true,
+			MinQueryIndex: minIndex,
+		},
+	}
+	var reply structs.VariablesReadResponse
+	if err := w.rpc.RPC(structs.VariablesReadRPCMethod, args, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// stop stops watching the variables.
+func (w *varWatcher) stop() {
+	w.cancel()
+}
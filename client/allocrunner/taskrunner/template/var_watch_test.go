@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package template
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// mockVarRPC serves variables to blocking queries.
+type mockVarRPC struct {
+	lock     sync.Mutex
+	index    uint64
+	vars     map[string]*structs.VariableDecrypted
+	updateCh chan struct{}
+	reqs     []*structs.VariablesReadRequest
+}
+
+func newMockVarRPC() *mockVarRPC {
+	return &mockVarRPC{
+		vars:     map[string]*structs.VariableDecrypted{},
+		updateCh: make(chan struct{}),
+	}
+}
+
+func (m *mockVarRPC) set(path string, items structs.VariableItems) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.index++
+	m.vars[path] = &structs.VariableDecrypted{
+		VariableMetadata: structs.VariableMetadata{Path: path, ModifyIndex: m.index},
+		Items:            items,
+	}
+	close(m.updateCh)
+	m.updateCh = make(chan struct{})
+}
+
+func (m *mockVarRPC) RPC(method string, args, reply any) error {
+	if method != structs.VariablesReadRPCMethod {
+		return errors.New("unexpected method " + method)
+	}
+	req := args.(*structs.VariablesReadRequest)
+	resp := reply.(*structs.VariablesReadResponse)
+
+	m.lock.Lock()
+	m.reqs = append(m.reqs, req)
+	m.lock.Unlock()
+
+	for {
+		m.lock.Lock()
+		v, index, updateCh := m.vars[req.Path], m.index, m.updateCh
+		m.lock.Unlock()
+
+		if v != nil {
+			index = v.ModifyIndex
+		}
+
+		if req.MinQueryIndex == 0 || index > req.MinQueryIndex {
+			resp.Data, resp.Index = v, index
+			return nil
+		}
+		select {
+		case <-updateCh:
+		// a short query time keeps the blocking queries of the test quick
+		case <-time.After(50 * time.Millisecond):
+			resp.Data, resp.Index = v, index
+			return nil
+		}
+	}
+}
+
+// TestTaskTemplateManager_NomadVarWatch asserts that templates using
+// nomadVarWatch are re-rendered as soon as the variable changes.
+func TestTaskTemplateManager_NomadVarWatch(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := newMockVarRPC()
+	rpc.set("db", structs.VariableItems{"password": "hunter2"})
+
+	file := "my.config"
+	templates := []*structs.Template{
+		{
+			EmbeddedTmpl: `password={{ with nomadVarWatch "db" }}{{ .password }}{{ end }}` +
+				`{{ with nomadVarWatch "missing" }}{{ .password }}{{ end }}`,
+			DestPath:     file,
+			ChangeMode:   structs.TemplateChangeModeSignal,
+			ChangeSignal: "SIGHUP",
+		},
+	}
+
+	harness := newTestHarness(t, templates, false, false)
+	harness.rpc = rpc
+	harness.mockHooks.HasHandle = true
+	harness.start(t)
+	defer harness.stop()
+
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Task unblock should have been called")
+	}
+
+	path := filepath.Join(harness.taskDir, file)
+	raw, err := os.ReadFile(path)
+	must.NoError(t, err)
+	must.Eq(t, "password=hunter2", string(raw))
+
+	rpc.lock.Lock()
+	must.Eq(t, "global", rpc.reqs[0].Region)
+	must.True(t, rpc.reqs[0].AllowStale)
+	rpc.lock.Unlock()
+
+	rpc.set("db", structs.VariableItems{"password": "swordfish"})
+
+	select {
+	case <-harness.mockHooks.SignalCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Task should have been signaled")
+	}
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			raw, _ := os.ReadFile(path)
+			return string(raw) == "password=swordfish"
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
+
+func TestTaskTemplateManager_NomadVarWatch_NoRPC(t *testing.T) {
+	ci.Parallel(t)
+
+	templates := []*structs.Template{
+		{
+			EmbeddedTmpl: `{{ nomadVarWatch "db" }}`,
+			DestPath:     "my.config",
+			ChangeMode:   structs.TemplateChangeModeNoop,
+		},
+	}
+
+	harness := newTestHarness(t, templates, false, false)
+	harness.start(t)
+	defer harness.stop()
+
+	select {
+	case <-harness.mockHooks.KillCh:
+		must.StrContains(t, harness.mockHooks.KillEvent().DisplayMessage, "nomadVarWatch is not available")
+	case <-harness.mockHooks.UnblockCh:
+		t.Fatal("Task unblock should not have been called")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Task should have been killed")
+	}
+}
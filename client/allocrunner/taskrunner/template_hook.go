@@ -5,14 +5,23 @@ package taskrunner
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
 	"sync"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/template"
 	"github.com/hashicorp/nomad/client/config"
+	ci "github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -20,6 +29,10 @@ import (
 
 const (
 	templateHookName = "template"
+
+	// templateSourcesDir is the directory inside the task's local directory
+	// where remote template sources are downloaded.
+	templateSourcesDir = ".template-sources"
 )
 
 type templateHookConfig struct {
@@ -32,9 +45,16 @@ type templateHookConfig struct {
 	// lifecycle is used to interact with the task's lifecycle
 	lifecycle ti.TaskLifecycle
 
+	// execHandler returns the handler used to run the processes of
+	// templates with change_mode exec_restart inside the task
+	execHandler func() drivermanager.TaskExecHandler
+
 	// events is used to emit events
 	events ti.EventEmitter
 
+	// stateUpdater is used to record the render state of the templates
+	stateUpdater ti.TemplateStateUpdater
+
 	// templates is the set of templates we are managing
 	templates []*structs.Template
 
@@ -55,6 +75,17 @@ type templateHookConfig struct {
 
 	// hookResources are used to fetch Consul tokens
 	hookResources *cstructs.AllocHookResources
+
+	// metricLabels are the task's base labels used when emitting template
+	// metrics
+	metricLabels []metrics.Label
+
+	// getter is used to download templates whose source is a go-getter URL
+	getter ci.ArtifactGetter
+
+	// rpc is used to read and watch the variables of the nomadVarWatch
+	// template function
+	rpc config.RPCer
 }
 
 type templateHook struct {
@@ -63,9 +94,16 @@ type templateHook struct {
 	// logger is used to log
 	logger log.Logger
 
-	// templateManager is used to manage any consul-templates this task may have
-	templateManager *template.TaskTemplateManager
-	managerLock     sync.Mutex
+	// templateManagers are used to manage any consul-templates this task may
+	// have. There is one manager per Vault role used by the templates.
+	templateManagers []*template.TaskTemplateManager
+	managerLock      sync.Mutex
+
+	// dryRunManagers are all the managers created by the last render,
+	// including once managers. They are guarded by their own lock because
+	// managerLock is held while Prestart waits for the first render.
+	dryRunManagers []*template.TaskTemplateManager
+	dryRunLock     sync.Mutex
 
 	// consulNamespace is the current Consul namespace
 	consulNamespace string
@@ -73,6 +111,10 @@ type templateHook struct {
 	// vaultToken is the current Vault token
 	vaultToken string
 
+	// vaultRoleTokens are the current Vault tokens for templates that request
+	// their own Vault role, keyed by role
+	vaultRoleTokens map[string]string
+
 	// vaultNamespace is the current Vault namespace
 	vaultNamespace string
 
@@ -111,19 +153,19 @@ func (h *templateHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 	defer h.managerLock.Unlock()
 
 	// If we have already run prerun before exit early.
-	if h.templateManager != nil {
+	if h.templateManagers != nil {
 		if !h.config.renderOnTaskRestart {
 			return nil
 		}
 		h.logger.Info("re-rendering templates on task restart")
-		h.templateManager.Stop()
-		h.templateManager = nil
+		h.stopManagers()
 	}
 
 	// Store request information so they can be used in other hooks.
 	h.task = req.Task
 	h.taskDir = req.TaskDir.Dir
 	h.vaultToken = req.VaultToken
+	h.vaultRoleTokens = req.VaultRoleTokens
 	h.nomadToken = req.NomadToken
 	h.taskID = req.Alloc.ID + "-" + req.Task.Name
 
@@ -172,8 +214,13 @@ func (h *templateHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 		h.vaultNamespace = req.Task.Vault.Namespace
 	}
 
+	tmpls, err := h.fetchRemoteSources(req)
+	if err != nil {
+		return err
+	}
+
 	once, watch := []*structs.Template{}, []*structs.Template{}
-	for _, tmpl := range h.config.templates {
+	for _, tmpl := range tmpls {
 		if tmpl.Once {
 			once = append(once, tmpl)
 		} else {
@@ -184,6 +231,40 @@ func (h *templateHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 	return h.renderTemplates(ctx, once, watch)
 }
 
+// fetchRemoteSources downloads the source of every template whose source is a
+// go-getter URL into the task directory. It returns the templates to render,
+// where downloaded templates are copies pointing at their local source.
+func (h *templateHook) fetchRemoteSources(req *interfaces.TaskPrestartRequest) ([]*structs.Template, error) {
+	tmpls := make([]*structs.Template, 0, len(h.config.templates))
+	for _, tmpl := range h.config.templates {
+		if !tmpl.HasRemoteSource() {
+			tmpls = append(tmpls, tmpl)
+			continue
+		}
+
+		source := fmt.Sprintf("%x", sha256.Sum256([]byte(tmpl.SourcePath)))
+		artifact := &structs.TaskArtifact{
+			GetterSource: tmpl.SourcePath,
+			GetterMode:   structs.GetterModeFile,
+			RelativeDest: filepath.Join(allocdir.TaskLocal, templateSourcesDir, source),
+		}
+
+		h.logger.Debug("downloading template source", "source", tmpl.SourcePath)
+		if err := h.config.getter.Get(req.TaskEnv, artifact, req.Task.User); err != nil {
+			wrapped := structs.NewRecoverableError(
+				fmt.Errorf("failed to download template source %q: %v", tmpl.SourcePath, err),
+				true,
+			)
+			return nil, NewHookError(wrapped, structs.NewTaskEvent(structs.TaskArtifactDownloadFailed).SetDownloadError(wrapped))
+		}
+
+		local := tmpl.Copy()
+		local.SourcePath = artifact.RelativeDest
+		tmpls = append(tmpls, local)
+	}
+	return tmpls, nil
+}
+
 func (h *templateHook) newManager(tmpls []*structs.Template) (manager *template.TaskTemplateManager, unblock chan struct{}, err error) {
 	vaultCluster := h.task.GetVaultClusterName()
 	vaultConfig := h.config.clientConfig.GetVaultConfigs(h.logger)[vaultCluster]
@@ -197,25 +278,37 @@ func (h *templateHook) newManager(tmpls []*structs.Template) (manager *template.
 	consulCluster := h.task.GetConsulClusterName(tg)
 	consulConfig := h.config.clientConfig.GetConsulConfigs(h.logger)[consulCluster]
 
+	var secretRotation *structs.VaultSecretRotation
+	if h.task.Vault != nil {
+		secretRotation = h.task.Vault.SecretRotation
+	}
+
 	unblock = make(chan struct{})
 	m, err := template.NewTaskTemplateManager(&template.TaskTemplateManagerConfig{
 		UnblockCh:            unblock,
 		Lifecycle:            h.config.lifecycle,
+		ExecHandler:          h.config.execHandler,
 		Events:               h.config.events,
+		StateUpdater:         h.config.stateUpdater,
 		Templates:            tmpls,
 		ClientConfig:         h.config.clientConfig,
 		ConsulNamespace:      h.config.consulNamespace,
 		ConsulToken:          h.consulToken,
 		ConsulConfig:         consulConfig,
 		VaultToken:           h.vaultToken,
+		VaultRoleTokens:      h.vaultRoleTokens,
 		VaultConfig:          vaultConfig,
 		VaultNamespace:       h.vaultNamespace,
+		VaultSecretRotation:  secretRotation,
 		TaskDir:              h.taskDir,
+		TaskDriver:           h.task.Driver,
 		EnvBuilder:           h.config.envBuilder,
 		MaxTemplateEventRate: template.DefaultMaxTemplateEventRate,
 		NomadNamespace:       h.config.nomadNamespace,
 		NomadToken:           h.nomadToken,
+		RPC:                  h.config.rpc,
 		TaskID:               h.taskID,
+		MetricLabels:         h.config.metricLabels,
 		Logger:               h.logger,
 	})
 	if err != nil {
@@ -231,36 +324,75 @@ func (h *templateHook) Stop(_ context.Context, req *interfaces.TaskStopRequest,
 	defer h.managerLock.Unlock()
 
 	// Shutdown any created template
-	if h.templateManager != nil {
-		h.templateManager.Stop()
-	}
+	h.stopManagers()
+
+	h.dryRunLock.Lock()
+	h.dryRunManagers = nil
+	h.dryRunLock.Unlock()
 
 	return nil
 }
 
+// DryRun renders the task's templates once without writing them to the task
+// directory, waiting at most timeout per group of templates for their
+// dependencies.
+func (h *templateHook) DryRun(timeout time.Duration) ([]*cstructs.TemplateDryRunResult, error) {
+	h.dryRunLock.Lock()
+	managers := h.dryRunManagers
+	h.dryRunLock.Unlock()
+
+	if managers == nil {
+		return nil, fmt.Errorf("templates have not been started")
+	}
+
+	results := []*cstructs.TemplateDryRunResult{}
+	for _, m := range managers {
+		rendered, err := m.DryRun(timeout)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rendered...)
+	}
+	return results, nil
+}
+
+// stopManagers stops all the template managers tracked by the hook. The caller
+// must hold managerLock.
+func (h *templateHook) stopManagers() {
+	for _, m := range h.templateManagers {
+		m.Stop()
+	}
+	h.templateManagers = nil
+}
+
 // Update is used to handle updates to vault and/or nomad tokens.
 func (h *templateHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequest, resp *interfaces.TaskUpdateResponse) error {
 	h.managerLock.Lock()
 	defer h.managerLock.Unlock()
 
 	// no template manager to manage
-	if h.templateManager == nil {
+	if h.templateManagers == nil {
 		return nil
 	}
 
 	// neither vault or nomad token has been updated, nothing to do
-	if req.VaultToken == h.vaultToken && req.NomadToken == h.nomadToken {
+	if req.VaultToken == h.vaultToken &&
+		maps.Equal(req.VaultRoleTokens, h.vaultRoleTokens) &&
+		req.NomadToken == h.nomadToken {
 		return nil
 	} else {
 		h.vaultToken = req.VaultToken
+		h.vaultRoleTokens = req.VaultRoleTokens
 		h.nomadToken = req.NomadToken
 	}
 
-	tmpls := h.templateManager.Templates()
+	var tmpls []*structs.Template
+	for _, m := range h.templateManagers {
+		tmpls = append(tmpls, m.Templates()...)
+	}
 
-	// shutdown the old template
-	h.templateManager.Stop()
-	h.templateManager = nil
+	// shutdown the old templates
+	h.stopManagers()
 
 	err := h.renderTemplates(ctx, nil, tmpls)
 	if err != nil {
@@ -276,35 +408,81 @@ func (h *templateHook) Update(ctx context.Context, req *interfaces.TaskUpdateReq
 }
 
 // renderTemplates creates the template managers and waits until each template has rendered, setting the watch
-// templateManger on the hook when complete so it can be referenced during token updates.
+// templateMangers on the hook when complete so they can be referenced during token updates.
 func (h *templateHook) renderTemplates(ctx context.Context, once []*structs.Template, watch []*structs.Template) error {
-	onceMgr, unblockOne, err := h.newManager(once)
+	onceMgrs, unblockOnce, err := h.newManagers(once)
 	if err != nil {
 		return err
 	}
 
-	watchMgr, unblockWatch, err := h.newManager(watch)
+	watchMgrs, unblockWatch, err := h.newManagers(watch)
 	if err != nil {
 		return err
 	}
 
-	go onceMgr.Run()
-	go watchMgr.Run()
+	h.dryRunLock.Lock()
+	h.dryRunManagers = slices.Concat(onceMgrs, watchMgrs)
+	h.dryRunLock.Unlock()
 
-	select {
-	case <-ctx.Done():
-		onceMgr.Stop()
-	case <-unblockOne:
+	for _, m := range onceMgrs {
+		go m.Run()
+	}
+	for _, m := range watchMgrs {
+		go m.Run()
 	}
 
-	select {
-	case <-ctx.Done():
-		watchMgr.Stop()
-	case <-unblockWatch:
+	for i, unblock := range unblockOnce {
+		select {
+		case <-ctx.Done():
+			onceMgrs[i].Stop()
+		case <-unblock:
+		}
+	}
+
+	for i, unblock := range unblockWatch {
+		select {
+		case <-ctx.Done():
+			watchMgrs[i].Stop()
+		case <-unblock:
+		}
 	}
 
 	// The template hook only needs to manage "watched" templates.
-	// We can ignore the "once" manager after it's templates render.
-	h.templateManager = watchMgr
+	// We can ignore the "once" managers after their templates render.
+	h.templateManagers = watchMgrs
 	return nil
 }
+
+// newManagers creates a template manager for each Vault role used by the given
+// templates, since a consul-template runner only holds a single Vault token.
+// At least one manager is always returned.
+func (h *templateHook) newManagers(tmpls []*structs.Template) ([]*template.TaskTemplateManager, []chan struct{}, error) {
+	byRole := map[string][]*structs.Template{}
+	roles := []string{""}
+	for _, tmpl := range tmpls {
+		if _, ok := byRole[tmpl.VaultRole]; !ok && tmpl.VaultRole != "" {
+			roles = append(roles, tmpl.VaultRole)
+		}
+		byRole[tmpl.VaultRole] = append(byRole[tmpl.VaultRole], tmpl)
+	}
+
+	managers := make([]*template.TaskTemplateManager, 0, len(roles))
+	unblocks := make([]chan struct{}, 0, len(roles))
+	for _, role := range roles {
+		if role != "" && len(byRole[role]) == 0 {
+			continue
+		}
+
+		m, unblock, err := h.newManager(byRole[role])
+		if err != nil {
+			for _, created := range managers {
+				created.Stop()
+			}
+			return nil, nil, err
+		}
+		managers = append(managers, m)
+		unblocks = append(unblocks, unblock)
+	}
+
+	return managers, unblocks, nil
+}
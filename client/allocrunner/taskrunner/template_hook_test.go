@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
 	"github.com/hashicorp/nomad/client/config"
+	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper/pointer"
@@ -395,3 +396,50 @@ func TestTemplateHook_RestoreChangeModeScript(t *testing.T) {
 		gotEvents[0].DisplayMessage)
 
 }
+
+// fakeTemplateGetter records artifacts it is asked to download
+type fakeTemplateGetter struct {
+	artifacts []*structs.TaskArtifact
+	err       error
+}
+
+func (g *fakeTemplateGetter) Get(_ cinterfaces.EnvReplacer, a *structs.TaskArtifact, _ string) error {
+	g.artifacts = append(g.artifacts, a)
+	return g.err
+}
+
+func TestTemplateHook_fetchRemoteSources(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	local := &structs.Template{SourcePath: "local/foo.tpl", DestPath: "local/foo"}
+	remote := &structs.Template{SourcePath: "s3::https://bucket/foo.tpl", DestPath: "local/bar"}
+
+	getter := &fakeTemplateGetter{}
+	hook := newTemplateHook(&templateHookConfig{
+		alloc:     alloc,
+		logger:    testlog.HCLogger(t),
+		templates: []*structs.Template{local, remote},
+		getter:    getter,
+	})
+	req := &interfaces.TaskPrestartRequest{Alloc: alloc, Task: task}
+
+	tmpls, err := hook.fetchRemoteSources(req)
+	must.NoError(t, err)
+	must.Len(t, 2, tmpls)
+	must.Eq(t, local, tmpls[0])
+	must.Len(t, 1, getter.artifacts)
+	must.Eq(t, remote.SourcePath, getter.artifacts[0].GetterSource)
+	must.Eq(t, structs.GetterModeFile, getter.artifacts[0].GetterMode)
+	must.Eq(t, getter.artifacts[0].RelativeDest, tmpls[1].SourcePath)
+	must.StrHasPrefix(t, "local/.template-sources/", tmpls[1].SourcePath)
+
+	// the configured template must not be modified
+	must.Eq(t, "s3::https://bucket/foo.tpl", remote.SourcePath)
+
+	getter.err = fmt.Errorf("oh no")
+	_, err = hook.fetchRemoteSources(req)
+	must.ErrorContains(t, err, "failed to download template source")
+	must.True(t, structs.IsRecoverable(err))
+}
@@ -50,6 +50,8 @@ type MockTaskHooks struct {
 	EmitEventCh chan *structs.TaskEvent
 	events      []*structs.TaskEvent
 
+	templateStates []*structs.TemplateState
+
 	execCode int
 	execErr  error
 
@@ -142,6 +144,18 @@ func (m *MockTaskHooks) EmitEvent(event *structs.TaskEvent) {
 
 func (m *MockTaskHooks) SetState(state string, event *structs.TaskEvent) {}
 
+func (m *MockTaskHooks) SetTemplateState(states []*structs.TemplateState) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.templateStates = states
+}
+
+func (m *MockTaskHooks) TemplateStates() []*structs.TemplateState {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.templateStates
+}
+
 func (m *MockTaskHooks) KillEvent() *structs.TaskEvent {
 	m.lock.Lock()
 	defer m.lock.Unlock()
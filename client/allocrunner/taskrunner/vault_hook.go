@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -41,12 +42,13 @@ const (
 )
 
 type vaultTokenUpdateHandler interface {
-	updatedVaultToken(token string)
+	updatedVaultToken(token string, roleTokens map[string]string)
 }
 
-func (tr *TaskRunner) updatedVaultToken(token string) {
+func (tr *TaskRunner) updatedVaultToken(token string, roleTokens map[string]string) {
 	// Update the task runner and environment
 	tr.setVaultToken(token)
+	tr.setVaultRoleTokens(roleTokens)
 
 	// Trigger update hooks with the new Vault token
 	tr.triggerUpdateHooks()
@@ -122,6 +124,15 @@ type vaultHook struct {
 
 	// future is used to wait on retrieving a Vault token
 	future *tokenFuture
+
+	// templateRoles is the set of additional Vault roles requested by the
+	// task's templates. A dedicated token is derived for each of them.
+	templateRoles []string
+
+	// roleTokens holds the Vault tokens derived for templateRoles, keyed by
+	// role.
+	roleTokens     map[string]string
+	roleTokensLock sync.Mutex
 }
 
 func newVaultHook(config *vaultHookConfig) *vaultHook {
@@ -142,6 +153,7 @@ func newVaultHook(config *vaultHookConfig) *vaultHook {
 		widmgr:               config.widmgr,
 		widName:              config.task.Vault.IdentityName(),
 		allowTokenExpiration: config.vaultBlock.AllowTokenExpiration,
+		templateRoles:        templateVaultRoles(config.task),
 	}
 	h.logger = config.logger.Named(h.Name())
 
@@ -206,7 +218,7 @@ func (h *vaultHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRe
 		return nil
 	}
 
-	h.updater.updatedVaultToken(h.future.Get())
+	h.updater.updatedVaultToken(h.future.Get(), h.getRoleTokens())
 	return nil
 }
 
@@ -233,6 +245,11 @@ func (h *vaultHook) run(token string) {
 		if err := h.client.StopRenewToken(h.future.Get()); err != nil {
 			h.logger.Warn("failed to stop token renewal", "error", err)
 		}
+		for role, roleToken := range h.getRoleTokens() {
+			if err := h.client.StopRenewToken(roleToken); err != nil {
+				h.logger.Warn("failed to stop token renewal", "role", role, "error", err)
+			}
+		}
 	}
 
 	// updatedToken lets us store state between loops. If true, a new token
@@ -273,6 +290,15 @@ OUTER:
 			}
 		}
 
+		// Derive the tokens for any template that requested its own role.
+		// These are never written to disk, so they are derived again when
+		// the task is restored.
+		roleTokens, exit := h.deriveRoleTokens()
+		if exit {
+			return
+		}
+		h.setRoleTokens(roleTokens)
+
 		if h.allowTokenExpiration {
 			h.future.Set(token)
 			h.logger.Debug("Vault token will not renew")
@@ -298,6 +324,14 @@ OUTER:
 			goto OUTER
 		}
 
+		roleRenewCh, stopRoleRenewWatch, err := h.renewRoleTokens(roleTokens)
+		if err != nil {
+			h.logger.Error("failed to start renewal of template Vault token", "error", err)
+			stopRenewal()
+			token = ""
+			goto OUTER
+		}
+
 		// The Vault token is valid now, so set it
 		h.future.Set(token)
 
@@ -338,7 +372,7 @@ OUTER:
 			updatedToken = false
 
 			// Call the handler
-			h.updater.updatedVaultToken(token)
+			h.updater.updatedVaultToken(token, roleTokens)
 		}
 
 		// Start watching for renewal errors
@@ -349,20 +383,40 @@ OUTER:
 			h.logger.Error("failed to renew Vault token", "error", err)
 			stopRenewal()
 			updatedToken = true
+		case err := <-roleRenewCh:
+			// Derive every token again so that all templates are updated in
+			// a single pass
+			token = ""
+			h.logger.Error("failed to renew template Vault token", "error", err)
+			stopRenewal()
+			updatedToken = true
 		case <-h.ctx.Done():
+			stopRoleRenewWatch()
 			stopRenewal()
 			return
 		}
+		stopRoleRenewWatch()
 	}
 }
 
 // deriveVaultToken derives the Vault token using exponential backoffs. It
 // returns the Vault token and whether the manager should exit.
 func (h *vaultHook) deriveVaultToken() (string, bool) {
+	role := h.vaultConfig.Role
+	if h.vaultBlock.Role != "" {
+		role = h.vaultBlock.Role
+	}
+	return h.deriveVaultTokenForRole(role)
+}
+
+// deriveVaultTokenForRole derives a Vault token for the given role using
+// exponential backoffs. It returns the Vault token and whether the manager
+// should exit.
+func (h *vaultHook) deriveVaultTokenForRole(role string) (string, bool) {
 	var attempts uint64
 	var backoff time.Duration
 	for {
-		token, err := h.deriveVaultTokenJWT()
+		token, err := h.deriveVaultTokenJWT(role)
 		if err == nil {
 			return token, false
 		}
@@ -392,8 +446,85 @@ func (h *vaultHook) deriveVaultToken() (string, bool) {
 	}
 }
 
-// deriveVaultTokenJWT returns a Vault ACL token using JWT auth login.
-func (h *vaultHook) deriveVaultTokenJWT() (string, error) {
+// deriveRoleTokens derives a Vault token for each role requested by the task's
+// templates. It returns the tokens keyed by role and whether the manager
+// should exit.
+func (h *vaultHook) deriveRoleTokens() (map[string]string, bool) {
+	if len(h.templateRoles) == 0 {
+		return nil, false
+	}
+
+	tokens := make(map[string]string, len(h.templateRoles))
+	for _, role := range h.templateRoles {
+		token, exit := h.deriveVaultTokenForRole(role)
+		if exit {
+			return nil, true
+		}
+		tokens[role] = token
+	}
+	return tokens, false
+}
+
+// renewRoleTokens starts the renewal of the given template role tokens. The
+// returned channel receives the first renewal error of any of them and the
+// returned function must be called once the channel is no longer watched.
+func (h *vaultHook) renewRoleTokens(tokens map[string]string) (<-chan error, func(), error) {
+	if len(tokens) == 0 || h.allowTokenExpiration {
+		return nil, func() {}, nil
+	}
+
+	errCh := make(chan error, len(tokens))
+	stopCh := make(chan struct{})
+	for role, token := range tokens {
+		renewCh, err := h.client.RenewToken(token, 30)
+		if err != nil {
+			close(stopCh)
+			return nil, nil, fmt.Errorf("failed to renew token for role %q: %w", role, err)
+		}
+
+		go func(role string, renewCh <-chan error) {
+			select {
+			case err := <-renewCh:
+				errCh <- fmt.Errorf("failed to renew token for role %q: %w", role, err)
+			case <-stopCh:
+			}
+		}(role, renewCh)
+	}
+
+	var once sync.Once
+	return errCh, func() { once.Do(func() { close(stopCh) }) }, nil
+}
+
+// setRoleTokens stores the Vault tokens derived for the template roles.
+func (h *vaultHook) setRoleTokens(tokens map[string]string) {
+	h.roleTokensLock.Lock()
+	defer h.roleTokensLock.Unlock()
+	h.roleTokens = tokens
+}
+
+// getRoleTokens returns the Vault tokens derived for the template roles.
+func (h *vaultHook) getRoleTokens() map[string]string {
+	h.roleTokensLock.Lock()
+	defer h.roleTokensLock.Unlock()
+	return h.roleTokens
+}
+
+// templateVaultRoles returns the distinct Vault roles requested by the task's
+// templates.
+func templateVaultRoles(task *structs.Task) []string {
+	var roles []string
+	for _, tmpl := range task.Templates {
+		if tmpl.VaultRole == "" || slices.Contains(roles, tmpl.VaultRole) {
+			continue
+		}
+		roles = append(roles, tmpl.VaultRole)
+	}
+	return roles
+}
+
+// deriveVaultTokenJWT returns a Vault ACL token for the given role using JWT
+// auth login.
+func (h *vaultHook) deriveVaultTokenJWT(role string) (string, error) {
 	// Retrieve signed identity.
 	signed, err := h.widmgr.Get(structs.WIHandle{
 		IdentityName:       h.widName,
@@ -413,11 +544,6 @@ func (h *vaultHook) deriveVaultTokenJWT() (string, error) {
 		)
 	}
 
-	role := h.vaultConfig.Role
-	if h.vaultBlock.Role != "" {
-		role = h.vaultBlock.Role
-	}
-
 	// Derive Vault token with signed identity.
 	token, renewable, err := h.client.DeriveTokenWithJWT(h.ctx, vaultclient.JWTLoginRequest{
 		JWT:       signed.JWT,
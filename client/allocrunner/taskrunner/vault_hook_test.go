@@ -36,11 +36,13 @@ var _ interfaces.ShutdownHook = (*vaultHook)(nil)
 
 // vaultTokenUpdaterMock is a mock of the vaultTokenUpdateHandler interface.
 type vaultTokenUpdaterMock struct {
-	currentToken string
+	currentToken      string
+	currentRoleTokens map[string]string
 }
 
-func (v *vaultTokenUpdaterMock) updatedVaultToken(token string) {
+func (v *vaultTokenUpdaterMock) updatedVaultToken(token string, roleTokens map[string]string) {
 	v.currentToken = token
+	v.currentRoleTokens = roleTokens
 }
 
 func setupTestVaultHook(t *testing.T, config *vaultHookConfig) *vaultHook {
@@ -433,6 +435,71 @@ func TestTaskRunner_VaultHook_recover(t *testing.T) {
 	}
 }
 
+func TestTaskRunner_VaultHook_templateRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.MinJob()
+	task := job.TaskGroups[0].Tasks[0]
+	task.Identities = []*structs.WorkloadIdentity{{Name: "vault_default"}}
+	task.Vault = &structs.Vault{Cluster: structs.VaultDefaultCluster}
+	task.Templates = []*structs.Template{
+		{DestPath: "local/db", VaultRole: "db"},
+		{DestPath: "local/db2", VaultRole: "db"},
+		{DestPath: "local/web", VaultRole: "web"},
+		{DestPath: "local/plain"},
+	}
+	alloc := mock.MinAlloc()
+	alloc.Job = job
+
+	hook := setupTestVaultHook(t, &vaultHookConfig{task: task, alloc: alloc})
+	must.Eq(t, []string{"db", "web"}, hook.templateRoles)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	t.Cleanup(cancel)
+
+	req := &interfaces.TaskPrestartRequest{
+		TaskEnv: taskenv.NewEmptyTaskEnv(),
+		TaskDir: &allocdir.TaskDir{
+			SecretsDir: t.TempDir(),
+			PrivateDir: t.TempDir(),
+		},
+		Task: task,
+	}
+	var resp interfaces.TaskPrestartResponse
+	must.NoError(t, hook.Prestart(ctx, req, &resp))
+	must.NoError(t, ctx.Err())
+
+	// A token must be derived for each template role and passed to the
+	// updater alongside the task token.
+	updater := (hook.updater).(*vaultTokenUpdaterMock)
+	must.UUIDv4(t, updater.currentToken)
+	must.MapLen(t, 2, updater.currentRoleTokens)
+	must.StrHasSuffix(t, "-db", updater.currentRoleTokens["db"])
+	must.StrHasSuffix(t, "-web", updater.currentRoleTokens["web"])
+
+	// Role tokens must not be written to disk.
+	tokenFile, err := os.ReadFile(hook.privateDirTokenPath)
+	must.NoError(t, err)
+	must.Eq(t, updater.currentToken, string(tokenFile))
+
+	// All tokens must be renewed.
+	client := hook.client.(*vaultclient.MockVaultClient)
+	must.MapLen(t, 3, client.RenewTokens())
+
+	// All tokens must stop renewing when the hook stops.
+	must.NoError(t, hook.Stop(ctx, nil, nil))
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			if n := len(client.StoppedTokens()); n != 3 {
+				return fmt.Errorf("expected 3 stopped tokens, got %d", n)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(100*time.Millisecond),
+	))
+}
+
 func TestTaskRunner_VaultHook_deriveError(t *testing.T) {
 	ci.Parallel(t)
 
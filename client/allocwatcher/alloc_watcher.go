@@ -6,6 +6,8 @@ package allocwatcher
 import (
 	"archive/tar"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -15,7 +17,6 @@ import (
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
-	nomadapi "github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/config"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -28,6 +29,18 @@ const (
 	// getRemoteRetryIntv is minimum interval on which we retry
 	// to fetch remote objects. We pick a value between this and 2x this.
 	getRemoteRetryIntv = 30 * time.Second
+
+	// snapshotChunkRetryIntv is the base interval of the backoff between
+	// attempts to download a chunk of the snapshot of a remote alloc.
+	snapshotChunkRetryIntv = time.Second
+
+	// maxSnapshotChunkRetries is the number of consecutive failures to
+	// download a chunk of a snapshot after which the migration fails.
+	maxSnapshotChunkRetries = 10
+
+	// snapshotFilename is the name of the file the snapshot of a remote
+	// alloc is downloaded to, in the alloc dir it is unpacked to.
+	snapshotFilename = "NOMAD-SNAPSHOT.tar"
 )
 
 // RPCer is the interface needed by a prevAllocWatcher to make RPC calls.
@@ -449,12 +462,7 @@ func (p *remotePrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface)
 		return nil
 	}
 
-	addr, err := p.getNodeAddr(ctx, p.nodeID)
-	if err != nil {
-		return err
-	}
-
-	prevAllocDir, err := p.migrateAllocDir(ctx, addr)
+	prevAllocDir, err := p.migrateAllocDir(ctx)
 	if err != nil {
 		return err
 	}
@@ -472,83 +480,142 @@ func (p *remotePrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface)
 	return nil
 }
 
-// getNodeAddr gets the node from the server with the given Node ID
-func (p *remotePrevAlloc) getNodeAddr(ctx context.Context, nodeID string) (string, error) {
-	req := structs.NodeSpecificRequest{
-		NodeID: nodeID,
-		QueryOptions: structs.QueryOptions{
-			Region:     p.config.Region,
-			AllowStale: true,
-			AuthToken:  p.config.Node.SecretID,
-		},
-	}
-
-	resp := structs.SingleNodeResponse{}
-	for {
-		err := p.rpc.RPC("Node.GetNode", &req, &resp)
-		if err != nil {
-			p.logger.Error("failed to query node", "error", err, "node", nodeID)
-			retry := getRemoteRetryIntv + helper.RandomStagger(getRemoteRetryIntv)
-			select {
-			case <-time.After(retry):
-				continue
-			case <-ctx.Done():
-				return "", ctx.Err()
-			}
-		}
-		break
-	}
-
-	if resp.Node == nil {
-		return "", fmt.Errorf("node %q not found", nodeID)
-	}
-
-	scheme := "http://"
-	if resp.Node.TLSEnabled {
-		scheme = "https://"
-	}
-	return scheme + resp.Node.HTTPAddr, nil
-}
-
 // migrate a remote alloc dir to local node. Caller is responsible for calling
 // Destroy on the returned allocdir if no error occurs.
-func (p *remotePrevAlloc) migrateAllocDir(ctx context.Context, nodeAddr string) (*allocdir.AllocDir, error) {
+func (p *remotePrevAlloc) migrateAllocDir(ctx context.Context) (*allocdir.AllocDir, error) {
 	// Create the previous alloc dir
 	prevAllocDir := allocdir.NewAllocDir(p.logger, p.config.AllocDir, p.config.AllocMountsDir, p.prevAllocID)
 	if err := prevAllocDir.Build(); err != nil {
 		return nil, fmt.Errorf("error building alloc dir for previous alloc %q: %w", p.prevAllocID, err)
 	}
 
-	// Create an API client
-	apiConfig := nomadapi.DefaultConfig()
-	apiConfig.Address = nodeAddr
-	apiConfig.TLSConfig = &nomadapi.TLSConfig{
-		CACert:        p.config.TLSConfig.CAFile,
-		ClientCert:    p.config.TLSConfig.CertFile,
-		ClientKey:     p.config.TLSConfig.KeyFile,
-		TLSServerName: fmt.Sprintf("client.%s.nomad", p.config.Region),
-	}
-	apiClient, err := nomadapi.NewClient(apiConfig)
-	if err != nil {
+	snapshotPath := filepath.Join(prevAllocDir.AllocDir, snapshotFilename)
+	if err := p.downloadSnapshot(ctx, snapshotPath); err != nil {
+		prevAllocDir.Destroy()
 		return nil, err
 	}
 
-	url := fmt.Sprintf("/v1/client/allocation/%v/snapshot", p.prevAllocID)
-	qo := &nomadapi.QueryOptions{AuthToken: p.migrateToken}
-	resp, err := apiClient.Raw().Response(url, qo)
+	f, err := os.Open(snapshotPath)
 	if err != nil {
 		prevAllocDir.Destroy()
-		return nil, fmt.Errorf("error getting snapshot from previous alloc %q: %w", p.prevAllocID, err)
+		return nil, err
 	}
-
-	if err := p.streamAllocDir(ctx, resp, prevAllocDir.AllocDir); err != nil {
+	if err := p.streamAllocDir(ctx, f, prevAllocDir.AllocDir); err != nil {
 		prevAllocDir.Destroy()
 		return nil, err
 	}
+	if err := os.Remove(snapshotPath); err != nil {
+		p.logger.Warn("error removing snapshot of previous alloc", "error", err)
+	}
 
 	return prevAllocDir, nil
 }
 
+// downloadSnapshot downloads the snapshot of the previous alloc to path in
+// chunks, through the servers. Failed chunks are retried from the offset
+// already written, and the snapshot is verified against its checksum once
+// complete.
+func (p *remotePrevAlloc) downloadSnapshot(ctx context.Context, path string) error {
+	p.logger.Debug("downloading snapshot of previous alloc", "destination", path)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("error creating snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	var (
+		offset   int64
+		size     int64
+		checksum string
+		failures uint64
+	)
+	for {
+		req := cstructs.AllocSnapshotChunkRequest{
+			AllocID:      p.prevAllocID,
+			MigrateToken: p.migrateToken,
+			Offset:       offset,
+			QueryOptions: structs.QueryOptions{
+				Region:     p.config.Region,
+				AllowStale: true,
+				AuthToken:  p.config.Node.SecretID,
+			},
+		}
+		var resp cstructs.AllocSnapshotChunkResponse
+		err := p.rpc.RPC("ClientAllocations.SnapshotChunk", &req, &resp)
+		if err != nil {
+			if structs.IsErrUnknownAllocation(err) || structs.IsErrPermissionDenied(err) {
+				return fmt.Errorf("error getting snapshot from previous alloc %q: %w", p.prevAllocID, err)
+			}
+			failures++
+			if failures > maxSnapshotChunkRetries {
+				return fmt.Errorf("error getting snapshot from previous alloc %q after %d attempts: %w",
+					p.prevAllocID, failures, err)
+			}
+			retry := helper.Backoff(snapshotChunkRetryIntv, getRemoteRetryIntv, failures)
+			p.logger.Warn("error getting snapshot chunk; resuming",
+				"offset", offset, "error", err, "wait", retry)
+			timer, stop := helper.NewSafeTimer(retry)
+			select {
+			case <-timer.C:
+				continue
+			case <-ctx.Done():
+				stop()
+				return ctx.Err()
+			}
+		}
+		failures = 0
+
+		if checksum == "" {
+			checksum, size = resp.Checksum, resp.Size
+		} else if resp.Checksum != checksum {
+			// the previous node made a new snapshot, for example after it
+			// restarted, so the chunks we have are from another snapshot
+			p.logger.Warn("snapshot of previous alloc changed; restarting download")
+			if err := f.Truncate(0); err != nil {
+				return fmt.Errorf("error truncating snapshot file: %w", err)
+			}
+			offset, checksum, size = 0, "", 0
+			continue
+		}
+
+		if len(resp.Data) > 0 {
+			if _, err := f.WriteAt(resp.Data, offset); err != nil {
+				return fmt.Errorf("error writing snapshot file: %w", err)
+			}
+			offset += int64(len(resp.Data))
+		}
+		if offset >= size {
+			break
+		}
+		if len(resp.Data) == 0 {
+			return fmt.Errorf("snapshot of previous alloc %q ended after %d of %d bytes",
+				p.prevAllocID, offset, size)
+		}
+
+		select {
+		case <-ctx.Done():
+			p.logger.Info("migration of previous alloc canceled")
+			return ctx.Err()
+		default:
+		}
+	}
+
+	// verify the integrity of the snapshot
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("error reading snapshot file: %w", err)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != checksum {
+		return fmt.Errorf("snapshot of previous alloc %q failed integrity check: expected checksum %s, got %s",
+			p.prevAllocID, checksum, actual)
+	}
+	return nil
+}
+
 // stream remote alloc to dir to a local path. Caller should cleanup dest on
 // error.
 func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser, dest string) error {
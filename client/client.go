@@ -42,6 +42,7 @@ import (
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/servers"
+	"github.com/hashicorp/nomad/client/servicedns"
 	"github.com/hashicorp/nomad/client/serviceregistration"
 	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/serviceregistration/nsd"
@@ -116,6 +117,10 @@ const (
 	// allocSyncRetryIntv is the interval on which we retry updating
 	// the status of the allocation
 	allocSyncRetryIntv = 5 * time.Second
+
+	// artifactCacheDir is the directory under the state dir where the
+	// artifact downloader caches artifacts shared by allocations
+	artifactCacheDir = "artifacts"
 )
 
 var (
@@ -222,6 +227,10 @@ type Client struct {
 	// registrations.
 	nomadService serviceregistration.Handler
 
+	// serviceDNS answers DNS queries for the services registered with
+	// nomadService. It's nil if the DNS server is disabled.
+	serviceDNS *servicedns.Server
+
 	// checkStore is used to store group and task checks and their current pass/fail
 	// status.
 	checkStore checkstore.Shim
@@ -262,6 +271,10 @@ type Client struct {
 	// in the node automatically
 	garbageCollector *AllocGarbageCollector
 
+	// allocSnapshots holds the snapshots of the data of allocations being
+	// migrated to other nodes
+	allocSnapshots *allocSnapshots
+
 	// clientACLResolver holds the ACL resolution state
 	clientACLResolver
 
@@ -392,7 +405,6 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 		serversContactedOnce: sync.Once{},
 		registeredCh:         make(chan struct{}),
 		registeredOnce:       sync.Once{},
-		getter:               getter.New(cfg.Artifact, logger),
 		EnterpriseClient:     newEnterpriseClient(logger),
 		allocrunnerFactory:   cfg.AllocRunnerFactory,
 	}
@@ -421,6 +433,14 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 		return nil, fmt.Errorf("failed to initialize client: %v", err)
 	}
 
+	// initialize the artifact downloader (needs to happen after init, so
+	// that artifacts are cached in the state dir)
+	artifactConfig := cfg.Artifact.Copy()
+	if artifactConfig != nil {
+		artifactConfig.CacheDir = filepath.Join(c.GetConfig().StateDir, artifactCacheDir)
+	}
+	c.getter = getter.New(artifactConfig, logger)
+
 	// initialize the dynamic registry (needs to happen after init)
 	c.dynamicRegistry =
 		dynamicplugins.NewRegistry(c.stateDB, map[string]dynamicplugins.PluginDispenser{
@@ -548,6 +568,15 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	c.setupNomadServiceRegistrationHandler()
 	c.serviceRegWrapper = wrapper.NewHandlerWrapper(c.logger, c.consulServices, c.nomadService)
 
+	// Start the DNS server for the services registered with Nomad, if it's
+	// enabled.
+	if dnsConfig := c.GetConfig().ServiceDNS; dnsConfig != nil {
+		c.serviceDNS = servicedns.NewServer(c.logger, dnsConfig, c.RPC, c.Region(), c.secretNodeID())
+		if err := c.serviceDNS.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start service DNS server: %w", err)
+		}
+	}
+
 	// Batching of initial fingerprints is done to reduce the number of node
 	// updates sent to the server on startup.
 	go c.batchFirstFingerprints()
@@ -576,6 +605,8 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	c.garbageCollector = NewAllocGarbageCollector(c.logger, statsCollector, c, gcConfig)
 	go c.garbageCollector.Run()
 
+	c.allocSnapshots = newAllocSnapshots(c.logger, filepath.Join(cfg.StateDir, allocSnapshotsDir))
+
 	// Set the preconfigured list of static servers
 	if len(cfg.Servers) > 0 {
 		if _, err := c.setServersImpl(cfg.Servers, true); err != nil {
@@ -639,6 +670,9 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Start collecting stats
 	c.shutdownGroup.Go(c.emitStats)
 
+	// Start evicting burstable allocations under memory pressure
+	c.shutdownGroup.Go(c.evictBurstableAllocs)
+
 	c.logger.Info("started client", "node_id", c.NodeID())
 	return c, nil
 }
@@ -949,6 +983,9 @@ func (c *Client) Shutdown() error {
 	if h, ok := c.nomadService.(*nsd.ServiceRegistrationHandler); ok {
 		h.Shutdown()
 	}
+	if c.serviceDNS != nil {
+		c.serviceDNS.Shutdown()
+	}
 
 	// Shutdown the plugin managers
 	c.pluginManagers.Shutdown()
@@ -1632,6 +1669,7 @@ func (c *Client) setupNode() error {
 	}
 	// Set NodeMaxAllocs before dynamic configuration is set
 	node.NodeMaxAllocs = newConfig.NodeMaxAllocs
+	node.HostVolumeLimits = newConfig.HostVolumeLimits.Copy()
 
 	// Since node.Meta will get dynamic metadata merged in, save static metadata
 	// here.
@@ -2713,6 +2751,7 @@ func (c *Client) removeAlloc(allocID string) {
 
 	// Stop tracking alloc runner as it's been GC'd by the server
 	delete(c.allocs, allocID)
+	c.allocSnapshots.remove(allocID)
 
 	// Ensure the GC has a reference and then collect. Collecting through the GC
 	// applies rate limiting
@@ -6,6 +6,7 @@ package client
 import (
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
@@ -138,6 +139,12 @@ func (ar *emptyAllocRunner) RestartTask(taskName string, taskEvent *structs.Task
 }
 func (ar *emptyAllocRunner) RestartRunning(taskEvent *structs.TaskEvent) error { return nil }
 func (ar *emptyAllocRunner) RestartAll(taskEvent *structs.TaskEvent) error     { return nil }
+func (ar *emptyAllocRunner) KillTask(taskName string, taskEvent *structs.TaskEvent) error {
+	return nil
+}
+func (ar *emptyAllocRunner) EmitTaskEvent(taskName string, taskEvent *structs.TaskEvent) error {
+	return nil
+}
 
 func (ar *emptyAllocRunner) GetTaskEventHandler(taskName string) drivermanager.EventHandler {
 	return nil
@@ -173,3 +180,7 @@ func (ar *emptyAllocRunner) SetTaskPauseState(taskName string, ps structs.TaskSc
 func (ar *emptyAllocRunner) GetTaskPauseState(taskName string) (structs.TaskScheduleState, error) {
 	return "", nil
 }
+
+func (ar *emptyAllocRunner) TemplateDryRun(taskName string, timeout time.Duration) ([]*cstructs.TemplateDryRunResult, error) {
+	return nil, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// DefaultAllocHookTimeout is the timeout of alloc hook commands when the
+// alloc_hook block doesn't set one.
+const DefaultAllocHookTimeout = 30 * time.Second
+
+// AllocHookConfig describes commands the client runs on the host before the
+// network and volumes of an allocation are set up, and after they are torn
+// down.
+type AllocHookConfig struct {
+	// Name is the label of the hook.
+	Name string
+
+	// PreStart and PostStop are the commands and arguments run before setup
+	// and after teardown of the allocation. Either may be empty.
+	PreStart []string
+	PostStop []string
+
+	// Timeout is the duration after which a command is killed.
+	Timeout time.Duration
+
+	// Namespaces are the namespaces of the allocations the hook runs for.
+	Namespaces []string
+}
+
+func (h *AllocHookConfig) Copy() *AllocHookConfig {
+	if h == nil {
+		return nil
+	}
+
+	nh := new(AllocHookConfig)
+	*nh = *h
+	nh.PreStart = slices.Clone(h.PreStart)
+	nh.PostStop = slices.Clone(h.PostStop)
+	nh.Namespaces = slices.Clone(h.Namespaces)
+	return nh
+}
+
+// AllowsNamespace returns true if the hook runs for allocations of the given
+// namespace.
+func (h *AllocHookConfig) AllowsNamespace(namespace string) bool {
+	return slices.Contains(h.Namespaces, "*") || slices.Contains(h.Namespaces, namespace)
+}
+
+// AllocHookConfigsFromAgent creates the internal read-only copy of the client
+// agent's alloc hooks.
+func AllocHookConfigsFromAgent(hooks []*config.AllocHookConfig) ([]*AllocHookConfig, error) {
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+
+	result := make([]*AllocHookConfig, 0, len(hooks))
+	for _, h := range hooks {
+		if h.Name == "" {
+			return nil, errors.New("alloc hooks must have a name")
+		}
+		if len(h.PreStart) == 0 && len(h.PostStop) == 0 {
+			return nil, fmt.Errorf("alloc hook %q must set pre_start or post_stop", h.Name)
+		}
+
+		// hooks run arbitrary commands as the client user, so the operator
+		// must explicitly opt in the namespaces they run for
+		if len(h.Namespaces) == 0 {
+			return nil, fmt.Errorf("alloc hook %q must set namespaces", h.Name)
+		}
+
+		timeout := DefaultAllocHookTimeout
+		if h.Timeout != nil {
+			var err error
+			timeout, err = time.ParseDuration(*h.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("alloc hook %q: error parsing timeout: %w", h.Name, err)
+			}
+			if timeout <= 0 {
+				return nil, fmt.Errorf("alloc hook %q: timeout must be positive", h.Name)
+			}
+		}
+
+		result = append(result, &AllocHookConfig{
+			Name:       h.Name,
+			PreStart:   slices.Clone(h.PreStart),
+			PostStop:   slices.Clone(h.PostStop),
+			Timeout:    timeout,
+			Namespaces: slices.Clone(h.Namespaces),
+		})
+	}
+	return result, nil
+}
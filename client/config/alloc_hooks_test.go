@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocHookConfigsFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		hook   *config.AllocHookConfig
+		expErr string
+		exp    *AllocHookConfig
+	}{
+		{
+			name: "default timeout",
+			hook: &config.AllocHookConfig{
+				Name:       "fw",
+				PreStart:   []string{"/bin/fw", "up"},
+				Namespaces: []string{"default"},
+			},
+			exp: &AllocHookConfig{
+				Name:       "fw",
+				PreStart:   []string{"/bin/fw", "up"},
+				Timeout:    DefaultAllocHookTimeout,
+				Namespaces: []string{"default"},
+			},
+		},
+		{
+			name: "custom timeout",
+			hook: &config.AllocHookConfig{
+				Name:       "fw",
+				PostStop:   []string{"/bin/fw", "down"},
+				Timeout:    pointer.Of("5s"),
+				Namespaces: []string{"*"},
+			},
+			exp: &AllocHookConfig{
+				Name:       "fw",
+				PostStop:   []string{"/bin/fw", "down"},
+				Timeout:    5 * time.Second,
+				Namespaces: []string{"*"},
+			},
+		},
+		{
+			name: "no commands",
+			hook: &config.AllocHookConfig{
+				Name:       "fw",
+				Namespaces: []string{"default"},
+			},
+			expErr: `alloc hook "fw" must set pre_start or post_stop`,
+		},
+		{
+			name: "no namespaces",
+			hook: &config.AllocHookConfig{
+				Name:     "fw",
+				PreStart: []string{"/bin/fw"},
+			},
+			expErr: `alloc hook "fw" must set namespaces`,
+		},
+		{
+			name: "invalid timeout",
+			hook: &config.AllocHookConfig{
+				Name:       "fw",
+				PreStart:   []string{"/bin/fw"},
+				Timeout:    pointer.Of("-1s"),
+				Namespaces: []string{"default"},
+			},
+			expErr: `alloc hook "fw": timeout must be positive`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hooks, err := AllocHookConfigsFromAgent([]*config.AllocHookConfig{tc.hook})
+			if tc.expErr != "" {
+				must.EqError(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, []*AllocHookConfig{tc.exp}, hooks)
+		})
+	}
+}
+
+func TestAllocHookConfig_AllowsNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	h := &AllocHookConfig{Namespaces: []string{"default", "edge"}}
+	must.True(t, h.AllowsNamespace("edge"))
+	must.False(t, h.AllowsNamespace("batch"))
+
+	h.Namespaces = []string{"*"}
+	must.True(t, h.AllowsNamespace("batch"))
+}
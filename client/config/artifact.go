@@ -29,6 +29,26 @@ type ArtifactConfig struct {
 	DisableFilesystemIsolation    bool
 	FilesystemIsolationExtraPaths []string
 	SetEnvironmentVariables       string
+
+	// OCIAuthConfig is the path to a Docker config file with the registry
+	// auths used to pull OCI artifacts.
+	OCIAuthConfig string
+
+	// CacheMaxBytes is the maximum size of the cache of artifacts shared by
+	// allocations. The cache is disabled if it is 0.
+	CacheMaxBytes int64
+
+	// FulcioRoots is the path to a PEM file with the Fulcio certificates used
+	// to verify keyless cosign signatures of artifacts.
+	FulcioRoots string
+
+	// RekorPublicKeys is the path to a PEM file with the public keys of the
+	// Rekor transparency logs keyless cosign signatures must be recorded in.
+	RekorPublicKeys string
+
+	// CacheDir is the directory where artifacts shared by allocations, such
+	// as the layers of OCI artifacts, are cached. It is set by the client.
+	CacheDir string
 }
 
 // ArtifactConfigFromAgent creates a new internal readonly copy of the client
@@ -69,6 +89,11 @@ func ArtifactConfigFromAgent(c *config.ArtifactConfig) (*ArtifactConfig, error)
 		return nil, fmt.Errorf("error parsing DecompressionLimitSize: %w", err)
 	}
 
+	cacheMaxSize, err := humanize.ParseBytes(*c.CacheMaxSize)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CacheMaxSize: %w", err)
+	}
+
 	return &ArtifactConfig{
 		HTTPReadTimeout:               httpReadTimeout,
 		HTTPMaxBytes:                  int64(httpMaxSize),
@@ -81,6 +106,10 @@ func ArtifactConfigFromAgent(c *config.ArtifactConfig) (*ArtifactConfig, error)
 		DisableFilesystemIsolation:    *c.DisableFilesystemIsolation,
 		FilesystemIsolationExtraPaths: slices.Clone(c.FilesystemIsolationExtraPaths),
 		SetEnvironmentVariables:       *c.SetEnvironmentVariables,
+		OCIAuthConfig:                 *c.OCIAuthConfig,
+		CacheMaxBytes:                 int64(cacheMaxSize),
+		FulcioRoots:                   *c.FulcioRoots,
+		RekorPublicKeys:               *c.RekorPublicKeys,
 	}, nil
 
 }
@@ -10,6 +10,7 @@ import (
 	"maps"
 	"net"
 	"reflect"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -18,8 +19,10 @@ import (
 	"github.com/hashicorp/consul-template/config"
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/lib/execrecorder"
 	"github.com/hashicorp/nomad/client/lib/numalib"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	"github.com/hashicorp/nomad/client/logmon/logsink"
 	"github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/command/agent/host"
 	"github.com/hashicorp/nomad/helper"
@@ -247,6 +250,25 @@ type Config struct {
 	// you know that a GC'd node can never come back
 	GCVolumesOnNodeGC bool
 
+	// BurstableMemoryHeadroomMB is the available memory of the node below
+	// which the client evicts burstable allocations using more memory than
+	// their minimum. Zero disables eviction.
+	BurstableMemoryHeadroomMB int
+
+	// BurstableEvictionInterval is the time interval at which the client
+	// checks whether burstable allocations must be evicted.
+	BurstableEvictionInterval time.Duration
+
+	// MemoryPressureThreshold is the percentage of time the tasks of the node
+	// may stall on memory, as reported by the cgroups pressure stall
+	// information, before the client acts on the lowest priority task using
+	// more memory than it reserved. Zero disables it.
+	MemoryPressureThreshold float64
+
+	// MemoryPressureAction is the action taken on the task once the memory
+	// pressure threshold is exceeded.
+	MemoryPressureAction MemoryPressureAction
+
 	// NoHostUUID disables using the host's UUID and will force generation of a
 	// random UUID.
 	NoHostUUID bool
@@ -267,6 +289,18 @@ type Config struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool
 
+	// ExecSessionSink records the exec sessions of tasks on this client when
+	// set. Sessions are refused if they can't be recorded.
+	ExecSessionSink execrecorder.Sink
+
+	// LogSinks are the remote log sinks the logs of all tasks on this client
+	// are shipped to.
+	LogSinks []*logsink.Config
+
+	// LogSinkAllowedAddresses are the syslog and Fluentd addresses the log
+	// sinks of jobs may ship logs to. Jobs can't use these sinks if empty.
+	LogSinkAllowedAddresses []string
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *ClientTemplateConfig
 
@@ -323,6 +357,17 @@ type Config struct {
 	// notation and must be an IPv6 address.
 	BridgeNetworkAllocSubnetIPv6 string
 
+	// BridgeNetworkIPv6Only disables IPv4 address allocation for allocations
+	// in bridge networking mode, which then only get an address from
+	// BridgeNetworkAllocSubnetIPv6.
+	BridgeNetworkIPv6Only bool
+
+	// BridgeNetworkFirewallBackend is the firewall backend that programs the
+	// forwarding, masquerading, and port mapping rules of the bridge network.
+	// It's either "iptables" or "nftables", since "auto" is resolved when
+	// the agent starts.
+	BridgeNetworkFirewallBackend string
+
 	// HostVolumes is a map of the configured host volumes by name.
 	HostVolumes map[string]*structs.ClientHostVolumeConfig
 
@@ -375,15 +420,31 @@ type Config struct {
 	// Drain configuration from the agent's config file.
 	Drain *DrainConfig
 
+	// AllocHooks are the commands run on the host before the network and
+	// volumes of each allocation are set up and after they are torn down.
+	AllocHooks []*AllocHookConfig
+
 	// Uesrs configuration from the agent's config file.
 	Users *UsersConfig
 
+	// ServiceDNS configures the DNS server for Nomad native services. It's
+	// nil if the DNS server is disabled.
+	ServiceDNS *ServiceDNSConfig
+
 	// ExtraAllocHooks are run with other allocation hooks, mainly for testing.
 	ExtraAllocHooks []interfaces.RunnerHook
 
 	// NodeMaxAllocs is an optional field that sets the maximum number of
 	// allocations a node can be assigned. Defaults to 0 and ignored if unset.
 	NodeMaxAllocs int
+
+	// HostVolumeLimits optionally caps the capacity of the dynamic host
+	// volumes the servers place on the node.
+	HostVolumeLimits *structs.HostVolumeLimits
+
+	// UnhealthyDeviceAction is the action taken on the allocations using a
+	// device once its plugin reports it unhealthy.
+	UnhealthyDeviceAction UnhealthyDeviceAction
 }
 
 type APIListenerRegistrar interface {
@@ -411,6 +472,10 @@ type ClientTemplateConfig struct {
 	// the task directory.
 	DisableSandbox bool `hcl:"disable_file_sandbox"`
 
+	// SandboxAllowedPaths are absolute host directories that template source
+	// paths may read from while the sandbox is enabled.
+	SandboxAllowedPaths []string `hcl:"sandbox_allowed_paths,optional"`
+
 	// This is the maximum interval to allow "stale" data. By default, only the
 	// Consul leader will respond to queries; any requests to a follower will
 	// forward to the leader. In large clusters with many requests, this is not as
@@ -465,8 +530,29 @@ type ClientTemplateConfig struct {
 	// to wait for the cluster to become available, as is customary in distributed
 	// systems.
 	NomadRetry *RetryConfig `hcl:"nomad_retry,optional"`
+
+	// ReloadCommands are the commands executed inside a task, keyed by task
+	// driver, to reload the task when a template with change_mode "reload"
+	// re-renders. Tasks of drivers without a command are signaled instead.
+	ReloadCommands map[string][]string `hcl:"reload_commands,optional"`
+
+	// ChangeModeDebounce is the window during which template re-renders are
+	// coalesced into a single execution of their change modes. Zero disables
+	// debouncing, so change modes run on every re-render after their splay.
+	ChangeModeDebounce    time.Duration `hcl:"-"`
+	ChangeModeDebounceHCL string        `hcl:"change_mode_debounce,optional"`
+
+	// SourcePlugins are the external template data sources, keyed by the name
+	// of the template function exposing them. The first element is the
+	// command fetching a value and the remaining elements are its arguments;
+	// the requested key is appended as the last argument.
+	SourcePlugins map[string][]string `hcl:"source_plugins,optional"`
 }
 
+// ValidTemplateSourcePluginName matches the names of template source plugins,
+// which must be valid template function names.
+var ValidTemplateSourcePluginName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
 func DefaultTemplateConfig() *ClientTemplateConfig {
 	return &ClientTemplateConfig{
 		FunctionDenylist:   DefaultTemplateFunctionDenylist,
@@ -511,6 +597,10 @@ func (c *ClientTemplateConfig) Copy() *ClientTemplateConfig {
 		nc.FunctionDenylist = []string{}
 	}
 
+	if c.SandboxAllowedPaths != nil {
+		nc.SandboxAllowedPaths = slices.Clone(c.SandboxAllowedPaths)
+	}
+
 	if c.BlockQueryWaitTime != nil {
 		nc.BlockQueryWaitTime = &*c.BlockQueryWaitTime
 	}
@@ -535,6 +625,20 @@ func (c *ClientTemplateConfig) Copy() *ClientTemplateConfig {
 		nc.NomadRetry = c.NomadRetry.Copy()
 	}
 
+	if c.ReloadCommands != nil {
+		nc.ReloadCommands = make(map[string][]string, len(c.ReloadCommands))
+		for driver, cmd := range c.ReloadCommands {
+			nc.ReloadCommands[driver] = slices.Clone(cmd)
+		}
+	}
+
+	if c.SourcePlugins != nil {
+		nc.SourcePlugins = make(map[string][]string, len(c.SourcePlugins))
+		for name, cmd := range c.SourcePlugins {
+			nc.SourcePlugins[name] = slices.Clone(cmd)
+		}
+	}
+
 	return nc
 }
 
@@ -546,6 +650,7 @@ func (c *ClientTemplateConfig) IsEmpty() bool {
 	return !c.DisableSandbox &&
 		c.FunctionDenylist == nil &&
 		c.FunctionBlacklist == nil &&
+		len(c.SandboxAllowedPaths) == 0 &&
 		c.BlockQueryWaitTime == nil &&
 		c.BlockQueryWaitTimeHCL == "" &&
 		c.MaxStale == nil &&
@@ -553,7 +658,11 @@ func (c *ClientTemplateConfig) IsEmpty() bool {
 		c.Wait.IsEmpty() &&
 		c.ConsulRetry.IsEmpty() &&
 		c.VaultRetry.IsEmpty() &&
-		c.NomadRetry.IsEmpty()
+		c.NomadRetry.IsEmpty() &&
+		len(c.ReloadCommands) == 0 &&
+		c.ChangeModeDebounce == 0 &&
+		c.ChangeModeDebounceHCL == "" &&
+		len(c.SourcePlugins) == 0
 }
 
 func (c *ClientTemplateConfig) Merge(o *ClientTemplateConfig) *ClientTemplateConfig {
@@ -576,6 +685,9 @@ func (c *ClientTemplateConfig) Merge(o *ClientTemplateConfig) *ClientTemplateCon
 	if o.DisableSandbox {
 		result.DisableSandbox = true
 	}
+	if o.SandboxAllowedPaths != nil {
+		result.SandboxAllowedPaths = slices.Clone(o.SandboxAllowedPaths)
+	}
 
 	result.MaxStale = pointer.Merge(result.MaxStale, o.MaxStale)
 	result.BlockQueryWaitTime = pointer.Merge(result.BlockQueryWaitTime, o.BlockQueryWaitTime)
@@ -597,6 +709,33 @@ func (c *ClientTemplateConfig) Merge(o *ClientTemplateConfig) *ClientTemplateCon
 		result.NomadRetry = c.NomadRetry.Merge(o.NomadRetry)
 	}
 
+	if o.ReloadCommands != nil {
+		result.ReloadCommands = make(map[string][]string, len(c.ReloadCommands)+len(o.ReloadCommands))
+		for driver, cmd := range c.ReloadCommands {
+			result.ReloadCommands[driver] = slices.Clone(cmd)
+		}
+		for driver, cmd := range o.ReloadCommands {
+			result.ReloadCommands[driver] = slices.Clone(cmd)
+		}
+	}
+
+	if o.ChangeModeDebounce != 0 {
+		result.ChangeModeDebounce = o.ChangeModeDebounce
+	}
+
+	if o.SourcePlugins != nil {
+		result.SourcePlugins = make(map[string][]string, len(c.SourcePlugins)+len(o.SourcePlugins))
+		for name, cmd := range c.SourcePlugins {
+			result.SourcePlugins[name] = slices.Clone(cmd)
+		}
+		for name, cmd := range o.SourcePlugins {
+			result.SourcePlugins[name] = slices.Clone(cmd)
+		}
+	}
+	if o.ChangeModeDebounceHCL != "" {
+		result.ChangeModeDebounceHCL = o.ChangeModeDebounceHCL
+	}
+
 	return &result
 }
 
@@ -885,6 +1024,11 @@ func (c *Config) Copy() *Config {
 	nc.ReservableCores = slices.Clone(c.ReservableCores)
 	nc.Artifact = c.Artifact.Copy()
 	nc.Users = c.Users.Copy()
+	nc.ServiceDNS = c.ServiceDNS.Copy()
+	nc.HostVolumeLimits = c.HostVolumeLimits.Copy()
+	nc.LogSinks = helper.CopySlice(c.LogSinks)
+	nc.LogSinkAllowedAddresses = slices.Clone(c.LogSinkAllowedAddresses)
+	nc.AllocHooks = helper.CopySlice(c.AllocHooks)
 	return &nc
 }
 
@@ -896,26 +1040,27 @@ func DefaultConfig() *Config {
 			structs.VaultDefaultCluster: structsc.DefaultVaultConfig()},
 		ConsulConfigs: map[string]*structsc.ConsulConfig{
 			structs.ConsulDefaultCluster: structsc.DefaultConsulConfig()},
-		Region:                  "global",
-		StatsCollectionInterval: 1 * time.Second,
-		TLSConfig:               &structsc.TLSConfig{},
-		GCInterval:              1 * time.Minute,
-		GCParallelDestroys:      2,
-		GCDiskUsageThreshold:    80,
-		GCInodeUsageThreshold:   70,
-		GCMaxAllocs:             50,
-		NoHostUUID:              true,
-		DisableRemoteExec:       false,
-		TemplateConfig:          DefaultTemplateConfig(),
-		RPCHoldTimeout:          5 * time.Second,
-		RPCSessionConfig:        yamux.DefaultConfig(),
-		CNIPath:                 "/opt/cni/bin",
-		CNIConfigDir:            "/opt/cni/config",
-		CNIInterfacePrefix:      "eth",
-		HostNetworks:            map[string]*structs.ClientHostNetworkConfig{},
-		CgroupParent:            "nomad.slice", // SETH todo
-		MaxDynamicPort:          structs.DefaultMinDynamicPort,
-		MinDynamicPort:          structs.DefaultMaxDynamicPort,
+		Region:                    "global",
+		StatsCollectionInterval:   1 * time.Second,
+		TLSConfig:                 &structsc.TLSConfig{},
+		GCInterval:                1 * time.Minute,
+		GCParallelDestroys:        2,
+		GCDiskUsageThreshold:      80,
+		GCInodeUsageThreshold:     70,
+		GCMaxAllocs:               50,
+		BurstableEvictionInterval: 10 * time.Second,
+		NoHostUUID:                true,
+		DisableRemoteExec:         false,
+		TemplateConfig:            DefaultTemplateConfig(),
+		RPCHoldTimeout:            5 * time.Second,
+		RPCSessionConfig:          yamux.DefaultConfig(),
+		CNIPath:                   "/opt/cni/bin",
+		CNIConfigDir:              "/opt/cni/config",
+		CNIInterfacePrefix:        "eth",
+		HostNetworks:              map[string]*structs.ClientHostNetworkConfig{},
+		CgroupParent:              "nomad.slice", // SETH todo
+		MaxDynamicPort:            structs.DefaultMinDynamicPort,
+		MinDynamicPort:            structs.DefaultMaxDynamicPort,
 		Users: &UsersConfig{
 			MinDynamicUser: 80_000,
 			MaxDynamicUser: 89_999,
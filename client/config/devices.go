@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "fmt"
+
+// UnhealthyDeviceAction is the action the client takes on the allocations
+// using a device when the device plugin reports the device unhealthy.
+type UnhealthyDeviceAction string
+
+const (
+	// UnhealthyDeviceActionNone only emits a task event on the tasks using an
+	// unhealthy device, which keep running. The scheduler stops placing new
+	// allocations on the device.
+	UnhealthyDeviceActionNone UnhealthyDeviceAction = "none"
+
+	// UnhealthyDeviceActionReschedule fails the tasks using an unhealthy
+	// device, so their allocations are rescheduled according to the
+	// reschedule block of their job.
+	UnhealthyDeviceActionReschedule UnhealthyDeviceAction = "reschedule"
+
+	// UnhealthyDeviceActionDrain drains the node, so the allocations on the
+	// node are migrated according to the migrate block of their job.
+	UnhealthyDeviceActionDrain UnhealthyDeviceAction = "drain"
+)
+
+// Validate validates that UnhealthyDeviceAction has a legal value.
+func (a UnhealthyDeviceAction) Validate() error {
+	switch a {
+	case "", UnhealthyDeviceActionNone, UnhealthyDeviceActionReschedule, UnhealthyDeviceActionDrain:
+		return nil
+	}
+	return fmt.Errorf(`unhealthy device action must be one of: %q, %q, %q`,
+		UnhealthyDeviceActionNone, UnhealthyDeviceActionReschedule, UnhealthyDeviceActionDrain)
+}
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestUnhealthyDeviceAction_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, action := range []UnhealthyDeviceAction{
+		"",
+		UnhealthyDeviceActionNone,
+		UnhealthyDeviceActionReschedule,
+		UnhealthyDeviceActionDrain,
+	} {
+		must.NoError(t, action.Validate())
+	}
+
+	err := UnhealthyDeviceAction("restart").Validate()
+	must.ErrorContains(t, err, "unhealthy device action must be one of")
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+const (
+	// BridgeFirewallBackendAuto detects the firewall backend of the bridge
+	// network when the client starts.
+	BridgeFirewallBackendAuto = "auto"
+
+	// BridgeFirewallBackendIPTables programs the bridge network rules with
+	// iptables.
+	BridgeFirewallBackendIPTables = "iptables"
+
+	// BridgeFirewallBackendNFTables programs the bridge network rules with
+	// nftables.
+	BridgeFirewallBackendNFTables = "nftables"
+)
+
+// ParseBridgeFirewallBackend validates the configured firewall backend of the
+// bridge network and resolves "auto" to the backend detected on the host.
+func ParseBridgeFirewallBackend(backend string) (string, error) {
+	switch backend {
+	case "", BridgeFirewallBackendAuto:
+		return DetectBridgeFirewallBackend(exec.LookPath), nil
+	case BridgeFirewallBackendIPTables, BridgeFirewallBackendNFTables:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("must be one of %q, %q, or %q", BridgeFirewallBackendAuto,
+			BridgeFirewallBackendIPTables, BridgeFirewallBackendNFTables)
+	}
+}
+
+// DetectBridgeFirewallBackend returns the firewall backend to use on the host.
+// Hosts with iptables keep using it, which includes the iptables-nft variant
+// that programs nftables through the iptables interface, so only hosts that
+// have nft but no iptables use nftables.
+func DetectBridgeFirewallBackend(lookPath func(string) (string, error)) string {
+	if _, err := lookPath("iptables"); err == nil {
+		return BridgeFirewallBackendIPTables
+	}
+	if _, err := lookPath("nft"); err == nil {
+		return BridgeFirewallBackendNFTables
+	}
+	return BridgeFirewallBackendIPTables
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestDetectBridgeFirewallBackend(t *testing.T) {
+	ci.Parallel(t)
+
+	lookPath := func(found ...string) func(string) (string, error) {
+		return func(file string) (string, error) {
+			if slices.Contains(found, file) {
+				return "/usr/sbin/" + file, nil
+			}
+			return "", errors.New("not found")
+		}
+	}
+
+	must.Eq(t, BridgeFirewallBackendIPTables, DetectBridgeFirewallBackend(lookPath("iptables", "nft")))
+	must.Eq(t, BridgeFirewallBackendNFTables, DetectBridgeFirewallBackend(lookPath("nft")))
+	must.Eq(t, BridgeFirewallBackendIPTables, DetectBridgeFirewallBackend(lookPath()))
+}
+
+func TestParseBridgeFirewallBackend(t *testing.T) {
+	ci.Parallel(t)
+
+	backend, err := ParseBridgeFirewallBackend(BridgeFirewallBackendNFTables)
+	must.NoError(t, err)
+	must.Eq(t, BridgeFirewallBackendNFTables, backend)
+
+	backend, err = ParseBridgeFirewallBackend("")
+	must.NoError(t, err)
+	must.NotEq(t, BridgeFirewallBackendAuto, backend)
+
+	_, err = ParseBridgeFirewallBackend("ebtables")
+	must.EqError(t, err, `must be one of "auto", "iptables", or "nftables"`)
+}
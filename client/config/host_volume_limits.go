@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// HostVolumeLimitsFromAgent converts the client agent's HostVolumeLimitsConfig
+// to the limits reported to the servers in the node. Returns nil if no limits
+// are configured.
+func HostVolumeLimitsFromAgent(c *config.HostVolumeLimitsConfig) (*structs.HostVolumeLimits, error) {
+	if c == nil || (c.MaxSize == nil && len(c.NamespaceMaxSize) == 0) {
+		return nil, nil
+	}
+
+	limits := &structs.HostVolumeLimits{}
+	if c.MaxSize != nil {
+		size, err := parseHostVolumeSize(*c.MaxSize)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing max_size: %w", err)
+		}
+		limits.MaxBytes = size
+	}
+
+	if len(c.NamespaceMaxSize) != 0 {
+		limits.NamespaceMaxBytes = make(map[string]int64, len(c.NamespaceMaxSize))
+		for ns, raw := range c.NamespaceMaxSize {
+			size, err := parseHostVolumeSize(raw)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing namespace_max_size for %q: %w", ns, err)
+			}
+			limits.NamespaceMaxBytes[ns] = size
+		}
+	}
+
+	return limits, nil
+}
+
+func parseHostVolumeSize(raw string) (int64, error) {
+	size, err := humanize.ParseBytes(raw)
+	if err != nil {
+		return 0, err
+	}
+	if size == 0 || size > math.MaxInt64 {
+		return 0, fmt.Errorf("size %q must be greater than zero and at most %d bytes", raw, int64(math.MaxInt64))
+	}
+	return int64(size), nil
+}
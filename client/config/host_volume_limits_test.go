@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestHostVolumeLimitsFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	// unset
+	l, err := HostVolumeLimitsFromAgent(nil)
+	must.NoError(t, err)
+	must.Nil(t, l)
+	l, err = HostVolumeLimitsFromAgent(&config.HostVolumeLimitsConfig{})
+	must.NoError(t, err)
+	must.Nil(t, l)
+
+	l, err = HostVolumeLimitsFromAgent(&config.HostVolumeLimitsConfig{
+		MaxSize:          pointer.Of("10GiB"),
+		NamespaceMaxSize: map[string]string{"prod": "1GiB"},
+	})
+	must.NoError(t, err)
+	must.Eq(t, &structs.HostVolumeLimits{
+		MaxBytes:          10 << 30,
+		NamespaceMaxBytes: map[string]int64{"prod": 1 << 30},
+	}, l)
+
+	_, err = HostVolumeLimitsFromAgent(&config.HostVolumeLimitsConfig{
+		MaxSize: pointer.Of("lots"),
+	})
+	must.ErrorContains(t, err, "error parsing max_size")
+
+	_, err = HostVolumeLimitsFromAgent(&config.HostVolumeLimitsConfig{
+		NamespaceMaxSize: map[string]string{"prod": "0"},
+	})
+	must.ErrorContains(t, err, `error parsing namespace_max_size for "prod"`)
+}
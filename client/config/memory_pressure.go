@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "fmt"
+
+// MemoryPressureAction is the action the client takes on the lowest priority
+// task using more memory than it reserved once the memory pressure of the
+// node exceeds the configured threshold.
+type MemoryPressureAction string
+
+const (
+	// MemoryPressureActionReschedule fails the task, so its allocation is
+	// rescheduled according to the reschedule block of its job. This is the
+	// default action.
+	MemoryPressureActionReschedule MemoryPressureAction = "reschedule"
+
+	// MemoryPressureActionRestart restarts the task in place, releasing the
+	// memory it uses beyond its reservation.
+	MemoryPressureActionRestart MemoryPressureAction = "restart"
+)
+
+// Validate validates that MemoryPressureAction has a legal value.
+func (a MemoryPressureAction) Validate() error {
+	switch a {
+	case "", MemoryPressureActionReschedule, MemoryPressureActionRestart:
+		return nil
+	}
+	return fmt.Errorf(`memory pressure action must be one of: %q, %q`,
+		MemoryPressureActionReschedule, MemoryPressureActionRestart)
+}
@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestMemoryPressureAction_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, action := range []MemoryPressureAction{
+		"",
+		MemoryPressureActionReschedule,
+		MemoryPressureActionRestart,
+	} {
+		must.NoError(t, action.Validate())
+	}
+
+	err := MemoryPressureAction("drain").Validate()
+	must.ErrorContains(t, err, "memory pressure action must be one of")
+}
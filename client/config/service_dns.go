@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/miekg/dns"
+)
+
+const (
+	// DefaultServiceDNSPort is the default port of the DNS server of the
+	// client.
+	DefaultServiceDNSPort = 4653
+
+	// DefaultServiceDNSZone is the default zone of the DNS server of the
+	// client.
+	DefaultServiceDNSZone = "nomad"
+)
+
+// ServiceDNSConfig describes the DNS server of the client, which answers
+// queries for the services registered with Nomad service discovery.
+type ServiceDNSConfig struct {
+	// Addr is the address and port the DNS server listens on.
+	Addr string
+
+	// Zone is the fully qualified DNS zone the DNS server is authoritative
+	// for.
+	Zone string
+
+	// TTL is the time to live of the records in responses.
+	TTL time.Duration
+}
+
+// Copy returns a copy of the ServiceDNSConfig.
+func (c *ServiceDNSConfig) Copy() *ServiceDNSConfig {
+	if c == nil {
+		return nil
+	}
+	nc := *c
+	return &nc
+}
+
+// ServiceDNSConfigFromAgent creates the internal read-only copy of the client
+// agent's ServiceDNSConfig. Returns nil if the DNS server is not enabled.
+func ServiceDNSConfigFromAgent(c *config.ServiceDNSConfig) (*ServiceDNSConfig, error) {
+	if c == nil || c.Enabled == nil || !*c.Enabled {
+		return nil, nil
+	}
+
+	address := "127.0.0.1"
+	port := DefaultServiceDNSPort
+	zone := DefaultServiceDNSZone
+	var ttl time.Duration
+
+	if c.Address != nil {
+		address = *c.Address
+	}
+	if net.ParseIP(address) == nil {
+		return nil, fmt.Errorf("address %q is not an IP address", address)
+	}
+	if c.Port != nil {
+		port = *c.Port
+	}
+	if port < 0 || port > 65535 {
+		return nil, fmt.Errorf("port %d is not a valid port", port)
+	}
+	if c.Zone != nil {
+		zone = strings.Trim(*c.Zone, ".")
+	}
+	if _, ok := dns.IsDomainName(zone); !ok || zone == "" {
+		return nil, fmt.Errorf("zone %q is not a valid domain name", zone)
+	}
+	if c.TTL != nil {
+		var err error
+		ttl, err = time.ParseDuration(*c.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing TTL: %w", err)
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("TTL must not be negative")
+		}
+	}
+
+	return &ServiceDNSConfig{
+		Addr: net.JoinHostPort(address, strconv.Itoa(port)),
+		Zone: dns.Fqdn(strings.ToLower(zone)),
+		TTL:  ttl,
+	}, nil
+}
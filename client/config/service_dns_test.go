@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestServiceDNSConfigFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	// disabled
+	c, err := ServiceDNSConfigFromAgent(nil)
+	must.NoError(t, err)
+	must.Nil(t, c)
+	c, err = ServiceDNSConfigFromAgent(&config.ServiceDNSConfig{Enabled: pointer.Of(false)})
+	must.NoError(t, err)
+	must.Nil(t, c)
+
+	// defaults
+	c, err = ServiceDNSConfigFromAgent(&config.ServiceDNSConfig{Enabled: pointer.Of(true)})
+	must.NoError(t, err)
+	must.Eq(t, &ServiceDNSConfig{Addr: "127.0.0.1:4653", Zone: "nomad."}, c)
+
+	c, err = ServiceDNSConfigFromAgent(&config.ServiceDNSConfig{
+		Enabled: pointer.Of(true),
+		Address: pointer.Of("fd00::1"),
+		Port:    pointer.Of(53),
+		Zone:    pointer.Of("Services.Internal."),
+		TTL:     pointer.Of("30s"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, &ServiceDNSConfig{Addr: "[fd00::1]:53", Zone: "services.internal.", TTL: 30 * time.Second}, c)
+
+	for _, invalid := range []*config.ServiceDNSConfig{
+		{Enabled: pointer.Of(true), Address: pointer.Of("localhost")},
+		{Enabled: pointer.Of(true), Port: pointer.Of(70000)},
+		{Enabled: pointer.Of(true), Zone: pointer.Of("")},
+		{Enabled: pointer.Of(true), TTL: pointer.Of("-1s")},
+		{Enabled: pointer.Of(true), TTL: pointer.Of("soon")},
+	} {
+		_, err := ServiceDNSConfigFromAgent(invalid)
+		must.Error(t, err)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// newlyUnhealthyDevices returns the IDs of the device instances that are
+// unhealthy in devices but weren't already unhealthy in old, grouped by the
+// device they belong to.
+func newlyUnhealthyDevices(old, devices []*structs.NodeDeviceResource) map[structs.DeviceIdTuple]*set.Set[string] {
+	wasUnhealthy := set.New[string](0)
+	for _, d := range old {
+		for _, instance := range d.Instances {
+			if !instance.Healthy {
+				wasUnhealthy.Insert(d.ID().String() + "/" + instance.ID)
+			}
+		}
+	}
+
+	unhealthy := make(map[structs.DeviceIdTuple]*set.Set[string])
+	for _, d := range devices {
+		id := d.ID()
+		for _, instance := range d.Instances {
+			if instance.Healthy || wasUnhealthy.Contains(id.String()+"/"+instance.ID) {
+				continue
+			}
+			if _, ok := unhealthy[*id]; !ok {
+				unhealthy[*id] = set.New[string](1)
+			}
+			unhealthy[*id].Insert(instance.ID)
+		}
+	}
+	return unhealthy
+}
+
+// unhealthyDeviceTasks returns a description of the unhealthy device
+// instances allocated to each task of the allocation that uses any of them.
+func unhealthyDeviceTasks(alloc *structs.Allocation, unhealthy map[structs.DeviceIdTuple]*set.Set[string]) map[string]string {
+	if alloc.AllocatedResources == nil {
+		return nil
+	}
+
+	tasks := make(map[string]string)
+	for taskName, resources := range alloc.AllocatedResources.Tasks {
+		var descs []string
+		for _, device := range resources.Devices {
+			instances, ok := unhealthy[*device.ID()]
+			if !ok {
+				continue
+			}
+			var ids []string
+			for _, id := range device.DeviceIDs {
+				if instances.Contains(id) {
+					ids = append(ids, id)
+				}
+			}
+			if len(ids) > 0 {
+				slices.Sort(ids)
+				descs = append(descs, fmt.Sprintf("%s[%s]", device.ID(), strings.Join(ids, ", ")))
+			}
+		}
+		if len(descs) > 0 {
+			tasks[taskName] = strings.Join(descs, ", ")
+		}
+	}
+	return tasks
+}
+
+// handleUnhealthyDevices emits a task event on the tasks that use any of the
+// unhealthy device instances and takes the given action on their allocations.
+func (c *Client) handleUnhealthyDevices(action config.UnhealthyDeviceAction, unhealthy map[structs.DeviceIdTuple]*set.Set[string]) {
+	logger := c.logger.Named("device_health")
+
+	affected := false
+	for _, ar := range c.getAllocRunners() {
+		alloc := ar.Alloc()
+		if alloc.ClientTerminalStatus() || alloc.ServerTerminalStatus() {
+			continue
+		}
+
+		for taskName, devices := range unhealthyDeviceTasks(alloc, unhealthy) {
+			affected = true
+			msg := fmt.Sprintf("Allocated devices became unhealthy: %s", devices)
+			event := structs.NewTaskEvent(structs.TaskDeviceUnhealthy).SetDisplayMessage(msg)
+
+			logger.Warn("task is using unhealthy devices", "alloc_id", alloc.ID,
+				"task", taskName, "devices", devices, "action", action)
+
+			if action != config.UnhealthyDeviceActionReschedule {
+				if err := ar.EmitTaskEvent(taskName, event); err != nil {
+					logger.Error("failed to emit task event", "alloc_id", alloc.ID, "task", taskName, "error", err)
+				}
+				continue
+			}
+
+			// failing the task fails the allocation, which the scheduler
+			// then reschedules according to the reschedule block of the job
+			event.SetFailsTask()
+			go func(taskName string) {
+				if err := ar.KillTask(taskName, event); err != nil {
+					logger.Error("failed to kill task using unhealthy devices",
+						"alloc_id", alloc.ID, "task", taskName, "error", err)
+				}
+			}(taskName)
+		}
+	}
+
+	if affected && action == config.UnhealthyDeviceActionDrain {
+		if err := c.drainForUnhealthyDevices(); err != nil {
+			logger.Error("failed to drain node with unhealthy devices", "error", err)
+		}
+	}
+}
+
+// drainForUnhealthyDevices drains the node without a deadline, so that its
+// allocations are migrated according to the migrate block of their job.
+func (c *Client) drainForUnhealthyDevices() error {
+	drainReq := &structs.NodeUpdateDrainRequest{
+		NodeID: c.NodeID(),
+		DrainStrategy: &structs.DrainStrategy{
+			StartedAt: time.Now(),
+		},
+		MarkEligible: false,
+		Meta:         map[string]string{"message": "unhealthy devices"},
+		WriteRequest: structs.WriteRequest{
+			Region: c.Region(), AuthToken: c.secretNodeID()},
+	}
+
+	var drainResp structs.NodeDrainUpdateResponse
+	return c.RPC("Node.UpdateDrain", drainReq, &drainResp)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestDeviceHealth_newlyUnhealthyDevices(t *testing.T) {
+	ci.Parallel(t)
+
+	gpu := func(healthy ...bool) *structs.NodeDeviceResource {
+		d := &structs.NodeDeviceResource{Vendor: "nvidia", Type: "gpu", Name: "1080ti"}
+		for i, h := range healthy {
+			d.Instances = append(d.Instances, &structs.NodeDevice{
+				ID:      []string{"a", "b", "c"}[i],
+				Healthy: h,
+			})
+		}
+		return d
+	}
+	id := structs.DeviceIdTuple{Vendor: "nvidia", Type: "gpu", Name: "1080ti"}
+
+	// all healthy
+	got := newlyUnhealthyDevices(nil, []*structs.NodeDeviceResource{gpu(true, true)})
+	must.MapEmpty(t, got)
+
+	// unhealthy on the first fingerprint
+	got = newlyUnhealthyDevices(nil, []*structs.NodeDeviceResource{gpu(true, false)})
+	must.MapLen(t, 1, got)
+	must.Eq(t, []string{"b"}, got[id].Slice())
+
+	// becoming unhealthy
+	got = newlyUnhealthyDevices(
+		[]*structs.NodeDeviceResource{gpu(true, false, true)},
+		[]*structs.NodeDeviceResource{gpu(false, false, true)},
+	)
+	must.MapLen(t, 1, got)
+	must.Eq(t, []string{"a"}, got[id].Slice())
+
+	// staying unhealthy or recovering
+	got = newlyUnhealthyDevices(
+		[]*structs.NodeDeviceResource{gpu(false, false)},
+		[]*structs.NodeDeviceResource{gpu(true, false)},
+	)
+	must.MapEmpty(t, got)
+}
+
+func TestDeviceHealth_unhealthyDeviceTasks(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Tasks["web"].Devices = []*structs.AllocatedDeviceResource{
+		{Vendor: "nvidia", Type: "gpu", Name: "1080ti", DeviceIDs: []string{"c", "a"}},
+		{Vendor: "intel", Type: "fpga", Name: "f100", DeviceIDs: []string{"a"}},
+	}
+	alloc.AllocatedResources.Tasks["sidecar"] = &structs.AllocatedTaskResources{
+		Devices: []*structs.AllocatedDeviceResource{
+			{Vendor: "nvidia", Type: "gpu", Name: "1080ti", DeviceIDs: []string{"b"}},
+		},
+	}
+
+	unhealthy := map[structs.DeviceIdTuple]*set.Set[string]{
+		{Vendor: "nvidia", Type: "gpu", Name: "1080ti"}: set.From([]string{"a", "c"}),
+	}
+	must.Eq(t, map[string]string{
+		"web": "nvidia/gpu/1080ti[a, c]",
+	}, unhealthyDeviceTasks(alloc, unhealthy))
+
+	unhealthy = map[structs.DeviceIdTuple]*set.Set[string]{
+		{Vendor: "intel", Type: "fpga", Name: "f100"}: set.From([]string{"b"}),
+	}
+	must.MapEmpty(t, unhealthyDeviceTasks(alloc, unhealthy))
+}
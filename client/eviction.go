@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// evictionCooldown is how long the client waits for an evicted task to
+	// release its memory before evicting another one.
+	evictionCooldown = time.Minute
+
+	evictionReasonHeadroom = "headroom"
+	evictionReasonPressure = "pressure"
+)
+
+// evictionCandidate is a burstable task using more memory than its minimum,
+// which may be evicted to relieve memory pressure on the node.
+type evictionCandidate struct {
+	alloc *structs.Allocation
+	ar    interfaces.AllocRunner
+	task  string
+
+	// overageMB is the memory used by the task beyond its minimum
+	overageMB int64
+}
+
+// burstableOverage returns the burstable task of the allocation using the
+// most memory beyond its minimum and by how much, or an empty task name if no
+// burstable task is using more than its minimum.
+func burstableOverage(alloc *structs.Allocation, usage *cstructs.AllocResourceUsage) (string, int64) {
+	if alloc.AllocatedResources == nil || usage == nil {
+		return "", 0
+	}
+
+	var task string
+	var overage int64
+	for name, resources := range alloc.AllocatedResources.Tasks {
+		taskUsage, ok := usage.Tasks[name]
+		if !resources.Burstable() || !ok || taskUsage.ResourceUsage == nil ||
+			taskUsage.ResourceUsage.MemoryStats == nil {
+			continue
+		}
+
+		stats := taskUsage.ResourceUsage.MemoryStats
+		used := stats.Usage
+		if used == 0 {
+			used = stats.RSS
+		}
+		over := int64(used/MB) - resources.Memory.MemoryMB
+		if over > overage {
+			task, overage = name, over
+		}
+	}
+	return task, overage
+}
+
+// memoryPressure returns the highest share of time any task of the allocation
+// stalled on memory over the last 10 seconds, as reported by the cgroups
+// pressure stall information, or zero if it isn't measured.
+func memoryPressure(usage *cstructs.AllocResourceUsage) float64 {
+	if usage == nil {
+		return 0
+	}
+
+	var pressure float64
+	for _, taskUsage := range usage.Tasks {
+		if taskUsage == nil || taskUsage.ResourceUsage == nil ||
+			taskUsage.ResourceUsage.MemoryStats == nil ||
+			taskUsage.ResourceUsage.MemoryStats.Pressure == nil {
+			continue
+		}
+		pressure = max(pressure, taskUsage.ResourceUsage.MemoryStats.Pressure.SomeAvg10)
+	}
+	return pressure
+}
+
+// sortEvictionCandidates sorts the candidates in the order they should be
+// evicted: allocations of lower priority jobs first, then those using the
+// most memory beyond their minimum.
+func sortEvictionCandidates(candidates []*evictionCandidate) {
+	slices.SortStableFunc(candidates, func(a, b *evictionCandidate) int {
+		if c := cmp.Compare(a.alloc.Job.Priority, b.alloc.Job.Priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.overageMB, a.overageMB)
+	})
+}
+
+// evictBurstableAllocs periodically evicts burstable allocations using more
+// memory than their minimum while the available memory of the node is below
+// the configured headroom, or while the memory pressure of its tasks exceeds
+// the configured threshold, so that the kernel OOM killer doesn't pick
+// arbitrary victims. A single allocation is evicted at a time so that the
+// memory it frees is accounted before evicting another one.
+func (c *Client) evictBurstableAllocs() {
+	conf := c.GetConfig()
+	if conf.BurstableMemoryHeadroomMB <= 0 && conf.MemoryPressureThreshold <= 0 {
+		return
+	}
+
+	logger := c.logger.Named("eviction")
+	evicted := make(map[string]time.Time)
+
+	ticker := time.NewTicker(conf.BurstableEvictionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.shutdownCh:
+			return
+		}
+
+		var candidates []*evictionCandidate
+		var pressure float64
+		stillEvicting := make(map[string]time.Time, len(evicted))
+		for id, ar := range c.getAllocRunners() {
+			alloc := ar.Alloc()
+			if alloc.ClientTerminalStatus() || alloc.ServerTerminalStatus() {
+				continue
+			}
+			usage, err := ar.StatsReporter().LatestAllocStats("")
+			if err != nil {
+				continue
+			}
+			pressure = max(pressure, memoryPressure(usage))
+
+			if at, ok := evicted[id]; ok && time.Since(at) < evictionCooldown {
+				stillEvicting[id] = at
+				continue
+			}
+			if task, overage := burstableOverage(alloc, usage); task != "" {
+				candidates = append(candidates, &evictionCandidate{
+					alloc: alloc, ar: ar, task: task, overageMB: overage})
+			}
+		}
+		evicted = stillEvicting
+
+		if conf.MemoryPressureThreshold > 0 {
+			metrics.SetGaugeWithLabels([]string{"client", "host", "memory", "pressure"},
+				float32(pressure), c.baseLabels)
+		}
+
+		var reason string
+		availableMB := int64(-1)
+		if stats := c.hostStatsCollector.Stats(); stats != nil && stats.Memory != nil {
+			availableMB = int64(stats.Memory.Available / MB)
+		}
+		switch {
+		case conf.BurstableMemoryHeadroomMB > 0 && availableMB >= 0 &&
+			availableMB < int64(conf.BurstableMemoryHeadroomMB):
+			reason = evictionReasonHeadroom
+		case conf.MemoryPressureThreshold > 0 && pressure >= conf.MemoryPressureThreshold:
+			reason = evictionReasonPressure
+		default:
+			continue
+		}
+
+		// wait for the allocation being evicted to free its memory
+		if len(evicted) > 0 {
+			continue
+		}
+		if len(candidates) == 0 {
+			logger.Warn("node memory is contended but no burstable allocation can be evicted",
+				"reason", reason, "available_mb", availableMB, "pressure", pressure)
+			continue
+		}
+
+		sortEvictionCandidates(candidates)
+		victim := candidates[0]
+		evicted[victim.alloc.ID] = time.Now()
+		c.evict(logger, victim, reason, pressure)
+	}
+}
+
+// evict restarts or fails the task of the candidate, depending on the reason
+// of its eviction and on the configured memory pressure action.
+func (c *Client) evict(logger hclog.Logger, victim *evictionCandidate, reason string, pressure float64) {
+	action := config.MemoryPressureActionReschedule
+	var event *structs.TaskEvent
+	switch reason {
+	case evictionReasonHeadroom:
+		msg := fmt.Sprintf("Evicted to relieve node memory pressure, task was using %d MB beyond its minimum", victim.overageMB)
+		event = structs.NewTaskEvent(structs.TaskEvicted).SetDisplayMessage(msg)
+	case evictionReasonPressure:
+		if a := c.GetConfig().MemoryPressureAction; a != "" {
+			action = a
+		}
+		msg := fmt.Sprintf("Node memory pressure reached %.2f%%, task was using %d MB beyond its reservation", pressure, victim.overageMB)
+		event = structs.NewTaskEvent(structs.TaskMemoryPressure).SetDisplayMessage(msg)
+	}
+
+	logger.Warn("evicting burstable task", "alloc_id", victim.alloc.ID, "task", victim.task,
+		"reason", reason, "action", action, "overage_mb", victim.overageMB)
+
+	labels := append(slices.Clone(c.baseLabels),
+		metrics.Label{Name: "namespace", Value: victim.alloc.Namespace},
+		metrics.Label{Name: "job", Value: victim.alloc.Job.Name},
+		metrics.Label{Name: "task_group", Value: victim.alloc.TaskGroup},
+		metrics.Label{Name: "task", Value: victim.task},
+		metrics.Label{Name: "reason", Value: reason},
+		metrics.Label{Name: "action", Value: string(action)},
+	)
+	metrics.IncrCounterWithLabels([]string{"client", "allocs", "evicted"}, 1, labels)
+
+	go func() {
+		var err error
+		if action == config.MemoryPressureActionRestart {
+			err = victim.ar.RestartTask(victim.task, event)
+		} else {
+			// failing the task fails the allocation, which the scheduler
+			// then reschedules according to the reschedule block of the job
+			err = victim.ar.KillTask(victim.task, event.SetFailsTask())
+		}
+		if err != nil {
+			logger.Error("failed to evict burstable task", "alloc_id", victim.alloc.ID,
+				"task", victim.task, "action", action, "error", err)
+		}
+	}()
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/shoenig/test/must"
+)
+
+func TestEviction_burstableOverage(t *testing.T) {
+	ci.Parallel(t)
+
+	usage := func(usedMB uint64) *cstructs.AllocResourceUsage {
+		return &cstructs.AllocResourceUsage{
+			Tasks: map[string]*cstructs.TaskResourceUsage{
+				"web": {ResourceUsage: &cstructs.ResourceUsage{
+					MemoryStats: &cstructs.MemoryStats{Usage: usedMB * MB},
+				}},
+			},
+		}
+	}
+
+	alloc := mock.Alloc()
+	resources := alloc.AllocatedResources.Tasks["web"]
+	resources.Memory.MemoryMB = 256
+
+	// tasks that aren't burstable are never evicted
+	task, _ := burstableOverage(alloc, usage(512))
+	must.Eq(t, "", task)
+
+	resources.Memory.MemoryMaxMB = 1024
+
+	// burstable tasks using less than their minimum are not evicted
+	task, _ = burstableOverage(alloc, usage(128))
+	must.Eq(t, "", task)
+
+	task, overage := burstableOverage(alloc, usage(512))
+	must.Eq(t, "web", task)
+	must.Eq(t, 256, overage)
+
+	// missing stats are ignored
+	task, _ = burstableOverage(alloc, nil)
+	must.Eq(t, "", task)
+}
+
+func TestEviction_sortEvictionCandidates(t *testing.T) {
+	ci.Parallel(t)
+
+	low, high := mock.Alloc(), mock.Alloc()
+	low.Job.Priority = 10
+	high.Job.Priority = 90
+
+	candidates := []*evictionCandidate{
+		{alloc: high, task: "web", overageMB: 1024},
+		{alloc: low, task: "small", overageMB: 10},
+		{alloc: low, task: "large", overageMB: 500},
+	}
+	sortEvictionCandidates(candidates)
+
+	// lower priority jobs are evicted first, then larger overages
+	must.Eq(t, "large", candidates[0].task)
+	must.Eq(t, "small", candidates[1].task)
+	must.Eq(t, "web", candidates[2].task)
+}
+
+func TestEviction_memoryPressure(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, 0, memoryPressure(nil))
+
+	usage := &cstructs.AllocResourceUsage{
+		Tasks: map[string]*cstructs.TaskResourceUsage{
+			"web": {ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{
+					Pressure: &cstructs.PressureStats{SomeAvg10: 12.5},
+				},
+			}},
+			"sidecar": {ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{
+					Pressure: &cstructs.PressureStats{SomeAvg10: 40},
+				},
+			}},
+			// pressure isn't measured on cgroups v1
+			"legacy": {ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{},
+			}},
+		},
+	}
+	must.Eq(t, 40, memoryPressure(usage))
+}
@@ -6,6 +6,7 @@ package fingerprint
 import (
 	"context"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -38,13 +39,21 @@ func (h *DynamicHostVolumePluginFingerprint) Fingerprint(request *FingerprintReq
 	defer response.AddAttribute("plugins.host_volume."+hvm.HostVolumePluginMkdirID+".version", hvm.HostVolumePluginMkdirVersion)
 	response.Detected = true
 
+	// add the "netmount" plugin where it can mount network filesystems
+	netmount := hvm.NewHostVolumePluginNetmount(h.logger, request.Config.HostVolumesDir)
+	if _, err := netmount.Fingerprint(context.Background()); err == nil {
+		h.logger.Debug("detected plugin built-in",
+			"plugin_id", hvm.HostVolumePluginNetmountID, "version", hvm.HostVolumePluginNetmountVersion)
+		defer response.AddAttribute("plugins.host_volume."+hvm.HostVolumePluginNetmountID+".version", hvm.HostVolumePluginNetmountVersion)
+	}
+
 	// this config value will be empty in -dev mode
 	pluginDir := request.Config.HostVolumePluginDir
 	if pluginDir == "" {
 		return nil
 	}
 
-	plugins, err := GetHostVolumePluginVersions(h.logger, pluginDir, request.Node.NodePool)
+	plugins, err := GetHostVolumePlugins(h.logger, pluginDir, request.Node.NodePool)
 	if err != nil {
 		if os.IsNotExist(err) {
 			h.logger.Debug("plugin dir does not exist", "dir", pluginDir)
@@ -62,9 +71,14 @@ func (h *DynamicHostVolumePluginFingerprint) Fingerprint(request *FingerprintReq
 	}
 
 	// set the attribute(s)
-	for plugin, version := range plugins {
+	for plugin, fprint := range plugins {
+		version := fprint.Version.String()
 		h.logger.Debug("detected plugin", "plugin_id", plugin, "version", version)
 		response.AddAttribute("plugins.host_volume."+plugin+".version", version)
+		if fprint.CapacityBytes > 0 {
+			response.AddAttribute("plugins.host_volume."+plugin+".capacity_bytes",
+				strconv.FormatInt(fprint.CapacityBytes, 10))
+		}
 	}
 
 	return nil
@@ -74,16 +88,16 @@ func (h *DynamicHostVolumePluginFingerprint) Periodic() (bool, time.Duration) {
 	return false, 0
 }
 
-// GetHostVolumePluginVersions finds all the executable files on disk that
-// respond to a `fingerprint` call. The return map's keys are plugin IDs,
-// and the values are version strings.
-func GetHostVolumePluginVersions(log hclog.Logger, pluginDir, nodePool string) (map[string]string, error) {
+// GetHostVolumePlugins finds all the executable files on disk that respond to
+// a `fingerprint` call. The return map's keys are plugin IDs, and the values
+// are their fingerprints.
+func GetHostVolumePlugins(log hclog.Logger, pluginDir, nodePool string) (map[string]*hvm.PluginFingerprint, error) {
 	files, err := helper.FindExecutableFiles(pluginDir)
 	if err != nil {
 		return nil, err
 	}
 
-	plugins := make(map[string]string)
+	plugins := make(map[string]*hvm.PluginFingerprint)
 	mut := sync.Mutex{}
 	var wg sync.WaitGroup
 
@@ -110,7 +124,7 @@ func GetHostVolumePluginVersions(log hclog.Logger, pluginDir, nodePool string) (
 			}
 
 			mut.Lock()
-			plugins[file] = fprint.Version.String()
+			plugins[file] = fprint
 			mut.Unlock()
 		}(file)
 	}
@@ -4,6 +4,8 @@
 package fingerprint
 
 import (
+	"context"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -52,8 +54,9 @@ func TestPluginsHostVolumeFingerprint(t *testing.T) {
 		contents string
 		perm     os.FileMode
 	}{
-		// only this first one should be detected as a valid plugin
+		// only these first ones should be detected as valid plugins
 		{"happy-plugin", "#!/usr/bin/env sh\necho '{\"version\": \"0.0.1\"}'", 0700},
+		{"sized-plugin", "#!/usr/bin/env sh\necho '{\"version\": \"0.0.2\", \"capacity_bytes\": 1073741824}'", 0700},
 		{"not-a-plugin", "#!/usr/bin/env sh\necho 'not a version'", 0700},
 		{"unhappy-plugin", "#!/usr/bin/env sh\necho 'sad plugin is sad'; exit 1", 0700},
 		{"not-executable", "do not execute me", 0400},
@@ -64,26 +67,41 @@ func TestPluginsHostVolumeFingerprint(t *testing.T) {
 	// directories should be ignored
 	must.NoError(t, os.Mkdir(filepath.Join(tmp, "a-directory"), 0700))
 
+	// the "netmount" built-in depends on the platform
+	builtIns := map[string]string{
+		"plugins.host_volume.mkdir.version": hvm.HostVolumePluginMkdirVersion,
+	}
+	netmount := hvm.NewHostVolumePluginNetmount(testlog.HCLogger(t), "")
+	if _, err := netmount.Fingerprint(context.Background()); err == nil {
+		builtIns["plugins.host_volume.netmount.version"] = hvm.HostVolumePluginNetmountVersion
+	}
+
 	// do the fingerprint
 	resp := FingerprintResponse{}
 	err := fp.Fingerprint(req, &resp)
 	must.NoError(t, err)
-	must.Eq(t, map[string]string{
-		"plugins.host_volume.mkdir.version":        hvm.HostVolumePluginMkdirVersion, // built-in
-		"plugins.host_volume.happy-plugin.version": "0.0.1",
-	}, resp.Attributes)
+	expect := map[string]string{
+		"plugins.host_volume.happy-plugin.version":        "0.0.1",
+		"plugins.host_volume.sized-plugin.version":        "0.0.2",
+		"plugins.host_volume.sized-plugin.capacity_bytes": "1073741824",
+	}
+	maps.Copy(expect, builtIns)
+	must.Eq(t, expect, resp.Attributes)
 
-	// do it again after deleting our one good plugin.
+	// do it again after deleting our good plugins.
 	// repeat runs should wipe attributes, so nothing should remain.
 	node.Attributes = resp.Attributes
 	must.NoError(t, os.Remove(filepath.Join(tmp, "happy-plugin")))
+	must.NoError(t, os.Remove(filepath.Join(tmp, "sized-plugin")))
 
 	resp = FingerprintResponse{}
 	err = fp.Fingerprint(req, &resp)
 	must.NoError(t, err)
-	must.Eq(t, map[string]string{
-		"plugins.host_volume.happy-plugin.version": "", // empty value means removed
-
-		"plugins.host_volume.mkdir.version": hvm.HostVolumePluginMkdirVersion, // built-in
-	}, resp.Attributes)
+	expect = map[string]string{
+		"plugins.host_volume.happy-plugin.version":        "", // empty value means removed
+		"plugins.host_volume.sized-plugin.version":        "",
+		"plugins.host_volume.sized-plugin.capacity_bytes": "",
+	}
+	maps.Copy(expect, builtIns)
+	must.Eq(t, expect, resp.Attributes)
 }
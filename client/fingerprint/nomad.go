@@ -26,6 +26,7 @@ func (f *NomadFingerprint) Fingerprint(req *FingerprintRequest, resp *Fingerprin
 	resp.AddAttribute("nomad.version", req.Config.Version.VersionNumber())
 	resp.AddAttribute("nomad.revision", req.Config.Version.Revision)
 	resp.AddAttribute("nomad.service_discovery", strconv.FormatBool(req.Config.NomadServiceDiscovery))
+	resp.AddAttribute("nomad.exec_session_recording", strconv.FormatBool(req.Config.ExecSessionSink != nil))
 	resp.Detected = true
 	return nil
 }
@@ -63,4 +63,7 @@ func TestNomadFingerprint(t *testing.T) {
 
 	serviceDisco := response.Attributes["nomad.service_discovery"]
 	require.Equal(t, "true", serviceDisco, "service_discovery attr incorrect")
+
+	execRecording := response.Attributes["nomad.exec_session_recording"]
+	require.Equal(t, "false", execRecording, "exec_session_recording attr incorrect")
 }
@@ -59,6 +59,10 @@ func (f *StorageFingerprint) Fingerprint(req *FingerprintRequest, resp *Fingerpr
 	resp.AddAttribute("unique.storage.bytestotal", strconv.FormatUint(total, 10))
 	resp.AddAttribute("unique.storage.bytesfree", strconv.FormatUint(free, 10))
 
+	if device := blockDevice("/sys", storageDir); device != "" {
+		resp.AddAttribute("storage.block_device", device)
+	}
+
 	// set the disk size for the response
 	resp.NodeResources = &structs.NodeResources{
 		Disk: structs.NodeDiskResources{
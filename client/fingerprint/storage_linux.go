@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package fingerprint
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// blockDevice returns the path of the disk holding path, such as /dev/sda, on
+// which the io controller of cgroups limits the disk I/O of tasks. It returns
+// an empty string if path isn't on a block device, like on overlay or tmpfs
+// file systems.
+func blockDevice(sysfs, path string) string {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return ""
+	}
+
+	// anonymous devices have no block device behind them
+	major, minor := unix.Major(st.Dev), unix.Minor(st.Dev)
+	if major == 0 {
+		return ""
+	}
+
+	devPath, err := filepath.EvalSymlinks(filepath.Join(sysfs, "dev", "block", fmt.Sprintf("%d:%d", major, minor)))
+	if err != nil {
+		return ""
+	}
+
+	// cgroups only limit whole disks, so partitions resolve to their disk
+	if _, err := os.Stat(filepath.Join(devPath, "partition")); err == nil {
+		devPath = filepath.Dir(devPath)
+	}
+
+	f, err := os.Open(filepath.Join(devPath, "uevent"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if name, ok := strings.CutPrefix(scanner.Text(), "DEVNAME="); ok {
+			return filepath.Join("/dev", name)
+		}
+	}
+	return ""
+}
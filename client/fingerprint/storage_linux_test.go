@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package fingerprint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+	"golang.org/x/sys/unix"
+)
+
+func TestStorageFingerprint_blockDevice(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	var st unix.Stat_t
+	must.NoError(t, unix.Stat(dir, &st))
+	if unix.Major(st.Dev) == 0 {
+		t.Skip("temporary directory isn't on a block device")
+	}
+	dev := fmt.Sprintf("%d:%d", unix.Major(st.Dev), unix.Minor(st.Dev))
+
+	// builds a sysfs where the device of dir is the partition of a disk
+	sysfs := func(t *testing.T, partition bool) string {
+		root := t.TempDir()
+		disk := filepath.Join(root, "devices", "vdb")
+		part := filepath.Join(disk, "vdb1")
+		must.NoError(t, os.MkdirAll(part, 0o755))
+		must.NoError(t, os.WriteFile(filepath.Join(disk, "uevent"), []byte("MAJOR=254\nMINOR=16\nDEVNAME=vdb\nDEVTYPE=disk\n"), 0o644))
+		must.NoError(t, os.WriteFile(filepath.Join(part, "uevent"), []byte("MAJOR=254\nMINOR=17\nDEVNAME=vdb1\nDEVTYPE=partition\n"), 0o644))
+
+		target := disk
+		if partition {
+			must.NoError(t, os.WriteFile(filepath.Join(part, "partition"), []byte("1\n"), 0o644))
+			target = part
+		}
+		must.NoError(t, os.MkdirAll(filepath.Join(root, "dev", "block"), 0o755))
+		must.NoError(t, os.Symlink(target, filepath.Join(root, "dev", "block", dev)))
+		return root
+	}
+
+	t.Run("disk", func(t *testing.T) {
+		must.Eq(t, "/dev/vdb", blockDevice(sysfs(t, false), dir))
+	})
+
+	t.Run("partition", func(t *testing.T) {
+		must.Eq(t, "/dev/vdb", blockDevice(sysfs(t, true), dir))
+	})
+
+	t.Run("unknown device", func(t *testing.T) {
+		must.Eq(t, "", blockDevice(t.TempDir(), dir))
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		must.Eq(t, "", blockDevice(sysfs(t, false), filepath.Join(dir, "missing")))
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fingerprint
+
+import (
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+)
+
+const (
+	userNSKey = "kernel.userns"
+)
+
+// UserNSFingerprint is used to fingerprint the kernel support of user
+// namespaces, which the exec driver uses to isolate the IDs of tasks from the
+// IDs of the host.
+type UserNSFingerprint struct {
+	StaticFingerprinter
+	logger   hclog.Logger
+	detector func() (int, error)
+}
+
+func NewUserNSFingerprint(logger hclog.Logger) Fingerprint {
+	return &UserNSFingerprint{
+		logger:   logger.Named("userns"),
+		detector: nsutil.MaxUserNamespaces,
+	}
+}
+
+func (f *UserNSFingerprint) Fingerprint(_ *FingerprintRequest, resp *FingerprintResponse) error {
+	max, err := f.detector()
+	if err != nil {
+		f.logger.Warn("failed to fingerprint kernel user namespaces", "error", err)
+		max = 0
+	}
+	if max > 0 {
+		resp.AddAttribute(userNSKey, "true")
+	}
+	return nil
+}
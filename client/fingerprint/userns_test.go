@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fingerprint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+)
+
+func TestUserNSFingerprint(t *testing.T) {
+	testutil.RequireLinux(t)
+	ci.Parallel(t)
+
+	max, err := nsutil.MaxUserNamespaces()
+	must.NoError(t, err)
+
+	f := NewUserNSFingerprint(testlog.HCLogger(t))
+
+	var response FingerprintResponse
+	err = f.Fingerprint(nil, &response)
+	must.NoError(t, err)
+
+	result, exists := response.Attributes[userNSKey]
+	must.Eq(t, max > 0, exists)
+	if exists {
+		must.Eq(t, "true", result)
+	}
+}
+
+func TestUserNSFingerprint_disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	f := NewUserNSFingerprint(testlog.HCLogger(t))
+	f.(*UserNSFingerprint).detector = func() (int, error) {
+		return 0, nil
+	}
+
+	var response FingerprintResponse
+	err := f.Fingerprint(nil, &response)
+	must.NoError(t, err)
+
+	_, exists := response.Attributes[userNSKey]
+	must.False(t, exists)
+}
+
+func TestUserNSFingerprint_error(t *testing.T) {
+	ci.Parallel(t)
+
+	f := NewUserNSFingerprint(testlog.HCLogger(t))
+	f.(*UserNSFingerprint).detector = func() (int, error) {
+		return 0, errors.New("oops")
+	}
+
+	var response FingerprintResponse
+	err := f.Fingerprint(nil, &response)
+	must.NoError(t, err)
+
+	_, exists := response.Attributes[userNSKey]
+	must.False(t, exists)
+}
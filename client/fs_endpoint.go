@@ -6,12 +6,16 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -36,6 +40,7 @@ var (
 	taskNotPresentErr    = fmt.Errorf("must provide task name")
 	logTypeNotPresentErr = fmt.Errorf("must provide log type (stdout/stderr)")
 	invalidOrigin        = fmt.Errorf("origin must be start or end")
+	invalidChecksum      = fmt.Errorf("checksum must be a hex encoded SHA-256 checksum")
 )
 
 const (
@@ -46,6 +51,10 @@ const (
 	// streamFrameSize is the maximum number of bytes to send in a single frame
 	streamFrameSize = 64 * 1024
 
+	// logFilterMaxLine is the length after which a log line that hasn't ended
+	// is matched by a log filter anyway, to bound the memory held back
+	logFilterMaxLine = 64 * 1024
+
 	// streamHeartbeatRate is the rate at which a heartbeat will occur to detect
 	// a closed connection without sending any additional data
 	streamHeartbeatRate = 1 * time.Second
@@ -82,6 +91,7 @@ func NewFileSystemEndpoint(c *Client) *FileSystem {
 	f := &FileSystem{c}
 	f.c.streamingRpcs.Register("FileSystem.Logs", f.logs)
 	f.c.streamingRpcs.Register("FileSystem.Stream", f.stream)
+	f.c.streamingRpcs.Register("FileSystem.Put", f.put)
 	return f
 }
 
@@ -343,6 +353,139 @@ OUTER:
 	}
 }
 
+// put is used to upload a file to an allocation's directory.
+func (f *FileSystem) put(conn io.ReadWriteCloser) {
+	defer metrics.MeasureSince([]string{"client", "file_system", "put"}, time.Now())
+	defer conn.Close()
+
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	code, err := f.putImpl(decoder)
+	if err != nil {
+		handleStreamResultError(err, code, encoder)
+		return
+	}
+
+	// Let the caller know the file was written
+	encoder.Encode(&cstructs.StreamErrWrapper{})
+}
+
+func (f *FileSystem) putImpl(decoder *codec.Decoder) (*int64, error) {
+	// Decode the arguments
+	var req cstructs.FsPutRequest
+	if err := decoder.Decode(&req); err != nil {
+		return pointer.Of(int64(http.StatusInternalServerError)), err
+	}
+
+	// Uploading files requires the same access as executing commands in the
+	// tasks of the allocation.
+	if f.c.GetConfig().DisableRemoteExec {
+		return pointer.Of(int64(http.StatusForbidden)), structs.ErrPermissionDenied
+	}
+
+	if req.AllocID == "" {
+		return pointer.Of(int64(http.StatusBadRequest)), allocIDNotPresentErr
+	}
+
+	ar, err := f.c.getAllocRunner(req.AllocID)
+	if err != nil {
+		return pointer.Of(int64(http.StatusNotFound)), structs.NewErrUnknownAllocation(req.AllocID)
+	}
+	if ar.IsDestroyed() {
+		return pointer.Of(int64(http.StatusNotFound)),
+			fmt.Errorf("state for allocation %s not found on client", req.AllocID)
+	}
+	alloc := ar.Alloc()
+
+	// Check alloc-exec permission.
+	if aclObj, err := f.c.ResolveToken(req.QueryOptions.AuthToken); err != nil {
+		return pointer.Of(int64(http.StatusForbidden)), err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityAllocExec) {
+		return pointer.Of(int64(http.StatusForbidden)), structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if alloc.ClientTerminalStatus() {
+		return pointer.Of(int64(http.StatusBadRequest)),
+			fmt.Errorf("allocation %s is not running", req.AllocID)
+	}
+	if req.Path == "" {
+		return pointer.Of(int64(http.StatusBadRequest)), pathNotPresentErr
+	}
+	if req.Size < 0 {
+		return pointer.Of(int64(http.StatusBadRequest)), errors.New("file size must not be negative")
+	}
+	checksum, err := hex.DecodeString(req.Checksum)
+	if err != nil || len(checksum) != sha256.Size {
+		return pointer.Of(int64(http.StatusBadRequest)), invalidChecksum
+	}
+
+	fs, err := f.c.GetAllocFS(req.AllocID)
+	if err != nil {
+		code := pointer.Of(int64(http.StatusInternalServerError))
+		if structs.IsErrUnknownAllocation(err) {
+			code = pointer.Of(int64(http.StatusNotFound))
+		}
+		return code, err
+	}
+
+	perm := req.FileMode.Perm()
+	if perm == 0 {
+		perm = 0o644
+	}
+
+	hash := sha256.New()
+	r := io.TeeReader(&putReader{decoder: decoder, remaining: req.Size}, hash)
+	verify := func() error {
+		if sum := hash.Sum(nil); !bytes.Equal(sum, checksum) {
+			return fmt.Errorf("checksum mismatch: expected %s, got %x", req.Checksum, sum)
+		}
+		return nil
+	}
+	if err := fs.WriteFile(req.Path, perm, r, verify); err != nil {
+		return pointer.Of(int64(http.StatusBadRequest)), err
+	}
+
+	f.c.logger.Info("file uploaded to allocation", "alloc_id", req.AllocID, "path", req.Path, "size", req.Size)
+	return nil, nil
+}
+
+// putReader reads the contents of an uploaded file from the payloads of the
+// StreamErrWrappers that follow a FsPutRequest, until all of the expected
+// bytes have been read.
+type putReader struct {
+	decoder   *codec.Decoder
+	remaining int64
+	buf       []byte
+}
+
+func (r *putReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.remaining == 0 {
+			return 0, io.EOF
+		}
+
+		var frame cstructs.StreamErrWrapper
+		if err := r.decoder.Decode(&frame); err != nil {
+			return 0, err
+		}
+		if frame.Error != nil {
+			return 0, frame.Error
+		}
+		if int64(len(frame.Payload)) > r.remaining {
+			return 0, errors.New("received more data than the size of the file")
+		}
+
+		r.remaining -= int64(len(frame.Payload))
+		r.buf = frame.Payload
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
 // logs is is used to stream a task's logs.
 func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	defer metrics.MeasureSince([]string{"client", "file_system", "logs"}, time.Now())
@@ -412,6 +555,19 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 		return
 	}
 
+	var filter *logFilter
+	if req.Filter != "" {
+		re, err := regexp.Compile(req.Filter)
+		if err != nil {
+			handleStreamResultError(
+				fmt.Errorf("invalid filter: %v", err),
+				pointer.Of(int64(http.StatusBadRequest)),
+				encoder)
+			return
+		}
+		filter = &logFilter{re: re}
+	}
+
 	fs, err := f.c.GetAllocFS(req.AllocID)
 	if err != nil {
 		code := pointer.Of(int64(http.StatusInternalServerError))
@@ -487,9 +643,30 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 		}
 	}()
 
-	var streamErr error
 	buf := new(bytes.Buffer)
 	frameCodec := codec.NewEncoder(buf, structs.JsonHandle)
+	send := func(frame *sframer.StreamFrame) error {
+		var resp cstructs.StreamErrWrapper
+		if req.PlainText {
+			resp.Payload = frame.Data
+		} else {
+			if err := frameCodec.Encode(frame); err != nil {
+				return err
+			}
+			frameCodec.Reset(buf)
+
+			resp.Payload = buf.Bytes()
+			buf.Reset()
+		}
+
+		if err := encoder.Encode(resp); err != nil {
+			return err
+		}
+		encoder.Reset(conn)
+		return nil
+	}
+
+	var streamErr error
 OUTER:
 	for {
 		select {
@@ -506,28 +683,26 @@ OUTER:
 					// No error, continue on
 				}
 
+				// Send the last line of the logs if it didn't end
+				// with a newline
+				if streamErr == nil && filter != nil {
+					if last := filter.flush(); last != nil {
+						streamErr = send(last)
+					}
+				}
 				break OUTER
 			}
 
-			var resp cstructs.StreamErrWrapper
-			if req.PlainText {
-				resp.Payload = frame.Data
-			} else {
-				if err = frameCodec.Encode(frame); err != nil {
-					streamErr = err
-					break OUTER
+			if filter != nil {
+				if frame = filter.filter(frame); frame == nil {
+					continue
 				}
-				frameCodec.Reset(buf)
-
-				resp.Payload = buf.Bytes()
-				buf.Reset()
 			}
 
-			if err := encoder.Encode(resp); err != nil {
+			if err := send(frame); err != nil {
 				streamErr = err
 				break OUTER
 			}
-			encoder.Reset(conn)
 		}
 	}
 
@@ -542,6 +717,69 @@ OUTER:
 	}
 }
 
+// logFilter filters the frames of a log stream down to the lines matching a
+// regular expression. Lines split across frames are held back until they end.
+type logFilter struct {
+	re *regexp.Regexp
+
+	// partial is the start of a line that has not ended yet, and last the
+	// last frame it was read from.
+	partial []byte
+	last    sframer.StreamFrame
+}
+
+// filter returns a copy of the frame with only the matching lines, or nil if
+// there is nothing to send. Heartbeats and file events are kept.
+func (l *logFilter) filter(frame *sframer.StreamFrame) *sframer.StreamFrame {
+	if frame.IsHeartbeat() {
+		return frame
+	}
+
+	var out []byte
+	data := append(l.partial, frame.Data...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		if l.re.Match(data[:i]) {
+			out = append(out, data[:i+1]...)
+		}
+		data = data[i+1:]
+	}
+	l.partial = bytes.Clone(data)
+	l.last = *frame
+
+	if len(l.partial) > logFilterMaxLine {
+		if l.re.Match(l.partial) {
+			out = append(out, l.partial...)
+		}
+		l.partial = nil
+	}
+
+	if len(out) == 0 && frame.FileEvent == "" {
+		return nil
+	}
+
+	filtered := *frame
+	filtered.Data = out
+	return &filtered
+}
+
+// flush returns a frame with the line held back if it matches, or nil.
+func (l *logFilter) flush() *sframer.StreamFrame {
+	line := l.partial
+	l.partial = nil
+	if len(line) == 0 || !l.re.Match(line) {
+		return nil
+	}
+
+	frame := l.last
+	frame.Data = line
+	frame.FileEvent = ""
+	return &frame
+}
+
 // logsImpl is used to stream the logs of a the given task. Output is sent on
 // the passed frames channel and the method will return on EOF if follow is not
 // true otherwise when the context is cancelled or on an error.
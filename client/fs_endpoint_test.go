@@ -4,7 +4,10 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"math"
@@ -13,6 +16,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
 	"strings"
 	"sync"
@@ -614,6 +618,106 @@ func TestFS_Stream_ACL(t *testing.T) {
 	}
 }
 
+func TestFS_Put(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+
+	// Wait for alloc to be running
+	alloc := testutil.WaitForRunning(t, s.RPC, job)[0]
+
+	contents := []byte("Hello from the other side")
+	sum := sha256.Sum256(contents)
+	checksum := hex.EncodeToString(sum[:])
+
+	cases := []struct {
+		Name          string
+		Checksum      string
+		ExpectedError string
+	}{
+		{
+			Name:          "invalid checksum",
+			Checksum:      "foo",
+			ExpectedError: invalidChecksum.Error(),
+		},
+		{
+			Name:          "checksum mismatch",
+			Checksum:      strings.Repeat("0", 64),
+			ExpectedError: "checksum mismatch",
+		},
+		{
+			Name:     "valid checksum",
+			Checksum: checksum,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := &cstructs.FsPutRequest{
+				AllocID:      alloc.ID,
+				Path:         "alloc/data/test_file",
+				Size:         int64(len(contents)),
+				Checksum:     tc.Checksum,
+				QueryOptions: structs.QueryOptions{Region: "global"},
+			}
+
+			// Get the handler
+			handler, err := c.StreamingRpcHandler("FileSystem.Put")
+			must.NoError(t, err)
+
+			// Create a pipe
+			p1, p2 := net.Pipe()
+			defer p1.Close()
+			defer p2.Close()
+
+			// Start the handler
+			go handler(p2)
+
+			// Send the request followed by the contents in two chunks
+			go func() {
+				encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+				encoder.Encode(req)
+				encoder.Encode(&cstructs.StreamErrWrapper{Payload: contents[:5]})
+				encoder.Encode(&cstructs.StreamErrWrapper{Payload: contents[5:]})
+			}()
+
+			var msg cstructs.StreamErrWrapper
+			decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+			must.NoError(t, decoder.Decode(&msg))
+
+			if tc.ExpectedError != "" {
+				must.NotNil(t, msg.Error)
+				must.StrContains(t, msg.Error.Error(), tc.ExpectedError)
+				return
+			}
+			must.Nil(t, msg.Error)
+
+			fs, err := c.GetAllocFS(alloc.ID)
+			must.NoError(t, err)
+			r, err := fs.ReadAt(req.Path, 0)
+			must.NoError(t, err)
+			defer r.Close()
+			written, err := io.ReadAll(r)
+			must.NoError(t, err)
+			must.Eq(t, contents, written)
+		})
+	}
+}
+
 func TestFS_Stream(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -1437,6 +1541,135 @@ OUTER:
 	}
 }
 
+func TestFS_Logs_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for":       "2s",
+		"stdout_string": "first match\nskipped\nsecond match",
+	}
+
+	// Wait for client to be running job
+	testutil.WaitForRunning(t, s.RPC, job)
+
+	// Get the allocation ID
+	args := structs.AllocListRequest{}
+	args.Region = "global"
+	resp := structs.AllocListResponse{}
+	must.NoError(t, s.RPC("Alloc.List", &args, &resp))
+	must.Len(t, 1, resp.Allocations)
+	allocID := resp.Allocations[0].ID
+
+	// Get the handler
+	handler, err := c.StreamingRpcHandler("FileSystem.Logs")
+	must.NoError(t, err)
+
+	streamLogs := func(filter string) (string, error) {
+		req := &cstructs.FsLogsRequest{
+			AllocID:      allocID,
+			Task:         job.TaskGroups[0].Tasks[0].Name,
+			LogType:      "stdout",
+			Origin:       "start",
+			PlainText:    true,
+			Filter:       filter,
+			QueryOptions: structs.QueryOptions{Region: "global"},
+		}
+
+		// Create a pipe
+		p1, p2 := net.Pipe()
+		defer p1.Close()
+		defer p2.Close()
+
+		// Start the handler
+		go handler(p2)
+
+		// Send the request
+		encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+		must.NoError(t, encoder.Encode(req))
+
+		// Read the stream until it ends
+		received := ""
+		decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+		for {
+			var msg cstructs.StreamErrWrapper
+			if err := decoder.Decode(&msg); err != nil {
+				if err == io.EOF || strings.Contains(err.Error(), "closed") {
+					return received, nil
+				}
+				return "", fmt.Errorf("error decoding: %v", err)
+			}
+			if msg.Error != nil {
+				return "", msg.Error
+			}
+			received += string(msg.Payload)
+		}
+	}
+
+	// Lines that don't match are never sent, and the last line is sent even
+	// though it didn't end
+	received, err := streamLogs("match$")
+	must.NoError(t, err)
+	must.Eq(t, "first match\nsecond match", received)
+
+	_, err = streamLogs("(")
+	must.ErrorContains(t, err, "invalid filter")
+}
+
+func TestFS_logFilter(t *testing.T) {
+	ci.Parallel(t)
+
+	filter := &logFilter{re: regexp.MustCompile("^match")}
+
+	// Heartbeats are kept
+	heartbeat := &sframer.StreamFrame{}
+	must.Eq(t, heartbeat, filter.filter(heartbeat))
+
+	// Frames without matches are dropped
+	must.Nil(t, filter.filter(&sframer.StreamFrame{Offset: 1, Data: []byte("skip\n")}))
+
+	// Lines split across frames are held back until they end
+	frame := filter.filter(&sframer.StreamFrame{Offset: 2, Data: []byte("match 1\nmat")})
+	must.NotNil(t, frame)
+	must.Eq(t, "match 1\n", string(frame.Data))
+	must.Eq(t, 2, frame.Offset)
+
+	frame = filter.filter(&sframer.StreamFrame{Offset: 3, Data: []byte("ch 2\nskip\nmatch 3")})
+	must.NotNil(t, frame)
+	must.Eq(t, "match 2\n", string(frame.Data))
+
+	// File events are sent even without matches
+	frame = filter.filter(&sframer.StreamFrame{Offset: 4, FileEvent: truncateEvent})
+	must.NotNil(t, frame)
+	must.Eq(t, truncateEvent, frame.FileEvent)
+	must.SliceEmpty(t, frame.Data)
+
+	// The line held back is sent when flushed
+	frame = filter.flush()
+	must.NotNil(t, frame)
+	must.Eq(t, "match 3", string(frame.Data))
+	must.Eq(t, "", frame.FileEvent)
+	must.Nil(t, filter.flush())
+
+	// Long lines are matched without waiting for their end
+	long := append([]byte("match"), bytes.Repeat([]byte("a"), logFilterMaxLine)...)
+	frame = filter.filter(&sframer.StreamFrame{Offset: 5, Data: long})
+	must.NotNil(t, frame)
+	must.Eq(t, long, frame.Data)
+	must.Nil(t, filter.flush())
+}
+
 func TestFS_Logs_Follow(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -51,6 +51,11 @@ type HostVolumePlugin interface {
 // unmarshals to this struct.
 type PluginFingerprint struct {
 	Version *version.Version `json:"version"`
+
+	// CapacityBytes is the total capacity available to the volumes created
+	// by the plugin on the node. It is optional, and the servers don't place
+	// volumes on the node once their capacity would exceed it.
+	CapacityBytes int64 `json:"capacity_bytes,omitempty"`
 }
 
 // HostVolumePluginCreateResponse returns values to the server that may be shown
@@ -249,7 +254,8 @@ type HostVolumePluginExternal struct {
 // Response should be valid JSON on stdout, with a "version" key, e.g.:
 // {"version": "0.0.1"}
 // The version value should be a valid version number as allowed by
-// version.NewVersion()
+// version.NewVersion(). An optional "capacity_bytes" key reports the total
+// capacity available to the volumes of the plugin on the node.
 //
 // Must complete within 5 seconds
 func (p *HostVolumePluginExternal) Fingerprint(ctx context.Context) (*PluginFingerprint, error) {
@@ -0,0 +1,342 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostvolumemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-set/v3"
+	"github.com/hashicorp/go-version"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+const HostVolumePluginNetmountID = "netmount"
+const HostVolumePluginNetmountVersion = "0.0.1"
+
+const (
+	// NetmountTypeNFS mounts NFS exports
+	NetmountTypeNFS = "nfs"
+
+	// NetmountTypeSMB mounts SMB (CIFS) shares
+	NetmountTypeSMB = "smb"
+)
+
+// netmountForcedOptions are always given to mount, so that network
+// filesystems can't be used to run setuid binaries or access devices.
+const netmountForcedOptions = "nosuid,nodev"
+
+// netmountAllowedOptions are the mount options that can be set for each type
+// of network filesystem.
+var netmountAllowedOptions = map[string]*set.Set[string]{
+	NetmountTypeNFS: set.From([]string{
+		"ro", "rw", "noexec", "noatime", "nodiratime", "relatime",
+		"vers", "nfsvers", "minorversion", "proto", "port", "mountport",
+		"mountproto", "rsize", "wsize", "timeo", "retrans", "hard", "soft",
+		"intr", "nointr", "ac", "noac", "actimeo", "acregmin", "acregmax",
+		"acdirmin", "acdirmax", "lookupcache", "sec", "lock", "nolock",
+		"local_lock", "nconnect", "namlen", "fsc", "nofsc",
+	}),
+	NetmountTypeSMB: set.From([]string{
+		"ro", "rw", "noexec", "noatime", "nodiratime", "relatime",
+		"vers", "port", "username", "domain", "credentials", "sec",
+		"seal", "uid", "gid", "forceuid", "forcegid", "file_mode",
+		"dir_mode", "cache", "actimeo", "rsize", "wsize", "hard", "soft",
+		"iocharset", "nounix", "noperm", "serverino", "noserverino",
+		"mfsymlinks", "nobrl", "echo_interval",
+	}),
+}
+
+// netmountHealthTimeout is how long the health check waits for the root of
+// a mount to respond before considering it stale.
+const netmountHealthTimeout = 5 * time.Second
+
+// ErrNetmountUnsupported is returned by the "netmount" plugin on platforms
+// where it can't mount network filesystems.
+var ErrNetmountUnsupported = errors.New("netmount plugin is only supported on linux")
+
+// HostVolumePluginHealthChecker is implemented by plugins whose volumes can
+// become unavailable after they are created, such as network mounts. The host
+// volume manager periodically checks the health of their volumes and runs
+// Create again to repair unhealthy volumes.
+type HostVolumePluginHealthChecker interface {
+	CheckHealth(ctx context.Context, req *cstructs.ClientHostVolumeCreateRequest) error
+}
+
+// HostVolumePluginNetmountParams represents the parameters{} that the
+// "netmount" plugin will accept.
+type HostVolumePluginNetmountParams struct {
+	// Type is the type of the network filesystem, "nfs" or "smb".
+	Type string
+
+	// Server is the hostname or IP address of the file server.
+	Server string
+
+	// Export is the NFS export path or the SMB share name.
+	Export string
+
+	// Options are the comma-separated mount options, as given to
+	// `mount -o`.
+	Options string
+}
+
+// source returns the device and filesystem type given to mount.
+func (p HostVolumePluginNetmountParams) source() (string, string) {
+	if p.Type == NetmountTypeSMB {
+		return "//" + p.Server + "/" + strings.TrimPrefix(p.Export, "/"), "cifs"
+	}
+	return p.Server + ":" + p.Export, "nfs"
+}
+
+// netmountArgs returns the arguments given to mount to mount the source on
+// the target. The options are always prefixed with netmountForcedOptions.
+func netmountArgs(source, target, fsType, options string) []string {
+	opts := netmountForcedOptions
+	if options != "" {
+		opts += "," + options
+	}
+	return []string{"-t", fsType, "-o", opts, "--", source, target}
+}
+
+// netMounter mounts and unmounts network filesystems. It is implemented per
+// platform.
+type netMounter interface {
+	Mount(ctx context.Context, source, target, fsType, options string) error
+	Unmount(ctx context.Context, target string) error
+	Mounted(target string) (bool, error)
+	Capacity(target string) (int64, error)
+}
+
+var (
+	_ HostVolumePlugin              = &HostVolumePluginNetmount{}
+	_ HostVolumePluginHealthChecker = &HostVolumePluginNetmount{}
+)
+
+// HostVolumePluginNetmount is a plugin that mounts NFS exports or SMB shares
+// within the specified VolumesDir, so simple network storage doesn't require
+// a CSI plugin. It is built-in to Nomad, but only available on linux clients
+// with the mount helpers for the filesystem installed.
+type HostVolumePluginNetmount struct {
+	ID         string
+	VolumesDir string
+
+	mounter netMounter
+	log     hclog.Logger
+}
+
+// NewHostVolumePluginNetmount returns the "netmount" plugin for the current
+// platform.
+func NewHostVolumePluginNetmount(log hclog.Logger, volumesDir string) *HostVolumePluginNetmount {
+	return &HostVolumePluginNetmount{
+		ID:         HostVolumePluginNetmountID,
+		VolumesDir: volumesDir,
+		mounter:    newNetMounter(),
+		log:        log.With("plugin_id", HostVolumePluginNetmountID),
+	}
+}
+
+func (p *HostVolumePluginNetmount) Fingerprint(_ context.Context) (*PluginFingerprint, error) {
+	if p.mounter == nil {
+		return nil, ErrNetmountUnsupported
+	}
+	v, err := version.NewVersion(HostVolumePluginNetmountVersion)
+	return &PluginFingerprint{
+		Version: v,
+	}, err
+}
+
+func (p *HostVolumePluginNetmount) Create(ctx context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error) {
+
+	path := filepath.Join(p.VolumesDir, req.ID)
+	log := p.log.With(
+		"operation", "create",
+		"volume_id", req.ID,
+		"path", path)
+	log.Debug("running plugin")
+
+	if p.mounter == nil {
+		return nil, ErrNetmountUnsupported
+	}
+
+	params, err := decodeNetmountParams(req.Parameters)
+	if err != nil {
+		log.Error("error with parameters", "error", err)
+		return nil, err
+	}
+
+	// Create is also called on agent restart and to repair unhealthy volumes,
+	// so leave healthy mounts alone and replace stale ones.
+	mounted, err := p.mounter.Mounted(path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Error("error with path", "error", err)
+		return nil, err
+	}
+	if mounted {
+		if err := p.checkMount(ctx, path); err == nil {
+			return p.createResponse(path), nil
+		}
+		log.Warn("remounting unhealthy volume", "error", err)
+		if err := p.mounter.Unmount(ctx, path); err != nil {
+			log.Error("error unmounting", "error", err)
+			return nil, fmt.Errorf("error unmounting stale volume: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		log.Error("error creating directory", "error", err)
+		return nil, fmt.Errorf("error creating directory: %w", err)
+	}
+
+	source, fsType := params.source()
+	if err := p.mounter.Mount(ctx, source, path, fsType, params.Options); err != nil {
+		log.Error("error mounting", "source", source, "error", err)
+		return nil, fmt.Errorf("error mounting %s: %w", source, err)
+	}
+
+	log.Debug("plugin ran successfully")
+	return p.createResponse(path), nil
+}
+
+// createResponse reports the capacity of the filesystem mounted at path, if
+// it is known.
+func (p *HostVolumePluginNetmount) createResponse(path string) *HostVolumePluginCreateResponse {
+	size, err := p.mounter.Capacity(path)
+	if err != nil {
+		p.log.Debug("could not read volume capacity", "path", path, "error", err)
+	}
+	return &HostVolumePluginCreateResponse{
+		Path:      path,
+		SizeBytes: size,
+	}
+}
+
+func (p *HostVolumePluginNetmount) Delete(ctx context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error {
+	path := filepath.Join(p.VolumesDir, req.ID)
+	log := p.log.With(
+		"operation", "delete",
+		"volume_id", req.ID,
+		"path", path)
+	log.Debug("running plugin")
+
+	if p.mounter == nil {
+		return ErrNetmountUnsupported
+	}
+
+	mounted, err := p.mounter.Mounted(path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Error("error with path", "error", err)
+		return err
+	}
+	if mounted {
+		if err := p.mounter.Unmount(ctx, path); err != nil {
+			log.Error("error unmounting", "error", err)
+			return fmt.Errorf("error unmounting volume: %w", err)
+		}
+	}
+
+	// only remove the empty mount point, never the contents of the remote
+	// filesystem
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Error("error removing mount point", "error", err)
+		return err
+	}
+
+	log.Debug("plugin ran successfully")
+	return nil
+}
+
+// CheckHealth returns an error if the volume is not mounted, or if its root
+// doesn't respond within netmountHealthTimeout, as happens when the server
+// becomes unreachable.
+func (p *HostVolumePluginNetmount) CheckHealth(ctx context.Context, req *cstructs.ClientHostVolumeCreateRequest) error {
+	if p.mounter == nil {
+		return ErrNetmountUnsupported
+	}
+	path := filepath.Join(p.VolumesDir, req.ID)
+	mounted, err := p.mounter.Mounted(path)
+	if err != nil {
+		return err
+	}
+	if !mounted {
+		return fmt.Errorf("volume is not mounted at %s", path)
+	}
+	return p.checkMount(ctx, path)
+}
+
+func (p *HostVolumePluginNetmount) checkMount(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, netmountHealthTimeout)
+	defer cancel()
+
+	// a stat of a hard NFS mount blocks until the server responds, so it
+	// can't be interrupted; leave it running in the background
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := os.ReadDir(path)
+		errCh <- err
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("volume at %s did not respond: %w", path, ctx.Err())
+	}
+}
+
+func decodeNetmountParams(in map[string]string) (HostVolumePluginNetmountParams, error) {
+	var out HostVolumePluginNetmountParams
+
+	for param, val := range in {
+		switch param {
+		case "type":
+			out.Type = val
+		case "server":
+			out.Server = val
+		case "export":
+			out.Export = val
+		case "options":
+			out.Options = val
+		default:
+			return out, fmt.Errorf("unknown netmount parameter: %q", param)
+		}
+	}
+
+	switch out.Type {
+	case NetmountTypeNFS:
+		if !strings.HasPrefix(out.Export, "/") {
+			return out, fmt.Errorf("invalid value for \"export\": NFS export %q must be an absolute path", out.Export)
+		}
+	case NetmountTypeSMB:
+		if strings.Trim(out.Export, "/") == "" {
+			return out, errors.New("missing value for \"export\": SMB share name is required")
+		}
+	default:
+		return out, fmt.Errorf("invalid value for \"type\": must be %q or %q, got %q",
+			NetmountTypeNFS, NetmountTypeSMB, out.Type)
+	}
+	if out.Server == "" {
+		return out, errors.New("missing value for \"server\"")
+	}
+	if strings.ContainsAny(out.Server, "/:") && !strings.HasPrefix(out.Server, "[") {
+		return out, fmt.Errorf("invalid value for \"server\": %q must be a hostname or IP address", out.Server)
+	}
+
+	if out.Options != "" {
+		allowed := netmountAllowedOptions[out.Type]
+		for _, opt := range strings.Split(out.Options, ",") {
+			name, _, _ := strings.Cut(opt, "=")
+			if !allowed.Contains(name) {
+				return out, fmt.Errorf("invalid value for \"options\": option %q is not allowed for %s mounts", name, out.Type)
+			}
+		}
+	}
+
+	return out, nil
+}
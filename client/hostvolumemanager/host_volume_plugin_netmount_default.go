@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package hostvolumemanager
+
+// newNetMounter returns nil because network filesystems are only mounted on
+// linux.
+func newNetMounter() netMounter {
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package hostvolumemanager
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/moby/sys/mountinfo"
+	"golang.org/x/sys/unix"
+)
+
+// newNetMounter returns a netMounter that runs the mount utility, so that the
+// mount helpers of the filesystems (mount.nfs, mount.cifs) resolve server
+// names and handle credentials. It returns nil if mount is not installed.
+func newNetMounter() netMounter {
+	if _, err := exec.LookPath("mount"); err != nil {
+		return nil
+	}
+	return &execMounter{}
+}
+
+type execMounter struct{}
+
+func (m *execMounter) Mount(ctx context.Context, source, target, fsType, options string) error {
+	args := netmountArgs(source, target, fsType, options)
+	out, err := exec.CommandContext(ctx, "mount", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Unmount lazily detaches the mount, so that unmounting a stale mount doesn't
+// block on an unreachable server.
+func (m *execMounter) Unmount(_ context.Context, target string) error {
+	return unix.Unmount(target, unix.MNT_DETACH)
+}
+
+func (m *execMounter) Mounted(target string) (bool, error) {
+	return mountinfo.Mounted(target)
+}
+
+func (m *execMounter) Capacity(target string) (int64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(target, &st); err != nil {
+		return 0, err
+	}
+	return int64(st.Blocks) * st.Bsize, nil
+}
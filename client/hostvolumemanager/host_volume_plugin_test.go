@@ -4,6 +4,8 @@
 package hostvolumemanager
 
 import (
+	"context"
+	"errors"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -344,3 +346,146 @@ func TestHostVolumePluginExternal(t *testing.T) {
 		must.StrContains(t, logged, "delete: it tells you all about it in stderr")
 	})
 }
+
+func TestHostVolumePluginNetmount(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	mounter := &fakeMounter{mounts: map[string]string{}}
+	plug := &HostVolumePluginNetmount{
+		ID:         HostVolumePluginNetmountID,
+		VolumesDir: tmp,
+		mounter:    mounter,
+		log:        testlog.HCLogger(t),
+	}
+
+	_, err := plug.Fingerprint(timeout(t))
+	must.NoError(t, err)
+
+	t.Run("happy nfs", func(t *testing.T) {
+		volID := "happy-nfs"
+		target := filepath.Join(tmp, volID)
+		req := &cstructs.ClientHostVolumeCreateRequest{
+			ID: volID,
+			Parameters: map[string]string{
+				"type":    "nfs",
+				"server":  "files.example.com",
+				"export":  "/srv/data",
+				"options": "vers=4.1",
+			},
+		}
+		// run multiple times, should be idempotent
+		for range 2 {
+			resp, err := plug.Create(timeout(t), req)
+			must.NoError(t, err)
+			must.Eq(t, &HostVolumePluginCreateResponse{
+				Path:      target,
+				SizeBytes: 1024,
+			}, resp)
+			must.DirExists(t, target)
+			must.Eq(t, "nfs files.example.com:/srv/data vers=4.1", mounter.mounts[target])
+			must.Eq(t, 1, mounter.mountCalls)
+		}
+		must.NoError(t, plug.CheckHealth(timeout(t), req))
+
+		// delete should be idempotent, too
+		for range 2 {
+			err = plug.Delete(timeout(t),
+				&cstructs.ClientHostVolumeDeleteRequest{
+					ID: volID,
+				})
+			must.NoError(t, err)
+			must.DirNotExists(t, target)
+			must.MapNotContainsKey(t, mounter.mounts, target)
+		}
+		must.ErrorContains(t, plug.CheckHealth(timeout(t), req), "volume is not mounted")
+	})
+
+	t.Run("happy smb", func(t *testing.T) {
+		volID := "happy-smb"
+		target := filepath.Join(tmp, volID)
+		_, err := plug.Create(timeout(t),
+			&cstructs.ClientHostVolumeCreateRequest{
+				ID: volID,
+				Parameters: map[string]string{
+					"type":   "smb",
+					"server": "10.0.0.5",
+					"export": "share",
+				},
+			})
+		must.NoError(t, err)
+		must.Eq(t, "cifs //10.0.0.5/share ", mounter.mounts[target])
+	})
+
+	t.Run("sad", func(t *testing.T) {
+		for name, params := range map[string]map[string]string{
+			`invalid value for "type"`:              {"type": "ftp", "server": "a", "export": "/b"},
+			`missing value for "server"`:            {"type": "nfs", "export": "/b"},
+			`must be an absolute path`:              {"type": "nfs", "server": "a", "export": "b"},
+			`SMB share name is required`:            {"type": "smb", "server": "a"},
+			`must be a hostname`:                    {"type": "nfs", "server": "a:/b", "export": "/c"},
+			`unknown netmount parameter`:            {"type": "nfs", "server": "a", "export": "/b", "mode": "0700"},
+			`option "suid" is not allowed`:          {"type": "nfs", "server": "a", "export": "/b", "options": "vers=4,suid"},
+			`option "x-mount.mkdir" is not allowed`: {"type": "smb", "server": "a", "export": "b", "options": "x-mount.mkdir=0755"},
+		} {
+			resp, err := plug.Create(timeout(t),
+				&cstructs.ClientHostVolumeCreateRequest{
+					ID:         "sad",
+					Parameters: params,
+				})
+			must.ErrorContains(t, err, name)
+			must.Nil(t, resp)
+		}
+
+		mounter.mountErr = errors.New("connection refused")
+		t.Cleanup(func() { mounter.mountErr = nil })
+		resp, err := plug.Create(timeout(t),
+			&cstructs.ClientHostVolumeCreateRequest{
+				ID: "sad",
+				Parameters: map[string]string{
+					"type": "nfs", "server": "a", "export": "/b",
+				},
+			})
+		must.ErrorContains(t, err, "error mounting a:/b: connection refused")
+		must.Nil(t, resp)
+	})
+}
+
+func TestNetmountArgs(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, []string{"-t", "nfs", "-o", "nosuid,nodev", "--", "a:/b", "/mnt"},
+		netmountArgs("a:/b", "/mnt", "nfs", ""))
+	must.Eq(t, []string{"-t", "cifs", "-o", "nosuid,nodev,vers=3.0", "--", "//a/b", "/mnt"},
+		netmountArgs("//a/b", "/mnt", "cifs", "vers=3.0"))
+}
+
+// fakeMounter records mounts instead of mounting filesystems
+type fakeMounter struct {
+	mounts     map[string]string
+	mountCalls int
+	mountErr   error
+}
+
+func (m *fakeMounter) Mount(_ context.Context, source, target, fsType, options string) error {
+	if m.mountErr != nil {
+		return m.mountErr
+	}
+	m.mountCalls++
+	m.mounts[target] = fsType + " " + source + " " + options
+	return nil
+}
+
+func (m *fakeMounter) Unmount(_ context.Context, target string) error {
+	delete(m.mounts, target)
+	return nil
+}
+
+func (m *fakeMounter) Mounted(target string) (bool, error) {
+	_, ok := m.mounts[target]
+	return ok, nil
+}
+
+func (m *fakeMounter) Capacity(_ string) (int64, error) {
+	return 1024, nil
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
@@ -23,6 +24,10 @@ var (
 	ErrVolumeNameExists    = errors.New("volume name already exists on this node")
 )
 
+// volumeHealthCheckInterval is how often the volumes of plugins that
+// implement HostVolumePluginHealthChecker are checked.
+const volumeHealthCheckInterval = 30 * time.Second
+
 // HostVolumeStateManager manages the lifecycle of volumes in client state.
 type HostVolumeStateManager interface {
 	PutDynamicHostVolume(*cstructs.HostVolumeState) error
@@ -61,11 +66,19 @@ type HostVolumeManager struct {
 	builtIns       map[string]HostVolumePlugin
 	locker         *volLocker
 	log            hclog.Logger
+
+	// unhealthy tracks the IDs of volumes removed from the node after they
+	// failed their health check and couldn't be repaired.
+	unhealthy map[string]struct{}
+
+	shutdownCtx context.Context
+	shutdownFn  context.CancelFunc
 }
 
 // NewHostVolumeManager includes default builtin plugins.
 func NewHostVolumeManager(logger hclog.Logger, config Config) *HostVolumeManager {
 	logger = logger.Named("host_volume_manager")
+	ctx, cancel := context.WithCancel(context.Background())
 	return &HostVolumeManager{
 		pluginDir:      config.PluginDir,
 		volumesDir:     config.VolumesDir,
@@ -78,9 +91,13 @@ func NewHostVolumeManager(logger hclog.Logger, config Config) *HostVolumeManager
 				VolumesDir: config.VolumesDir,
 				log:        logger.With("plugin_id", HostVolumePluginMkdirID),
 			},
+			HostVolumePluginNetmountID: NewHostVolumePluginNetmount(logger, config.VolumesDir),
 		},
-		locker: &volLocker{},
-		log:    logger,
+		locker:      &volLocker{},
+		log:         logger,
+		unhealthy:   make(map[string]struct{}),
+		shutdownCtx: ctx,
+		shutdownFn:  cancel,
 	}
 }
 
@@ -439,3 +439,58 @@ func logRecorder(t *testing.T) (hclog.Logger, func() string) {
 		return string(bts)
 	}
 }
+
+func TestHostVolumeManager_checkVolumesHealth(t *testing.T) {
+	log := testlog.HCLogger(t)
+	state := cstate.NewMemDB(log)
+	node := newFakeNode(t)
+	volsDir := t.TempDir()
+
+	hvm := NewHostVolumeManager(log, Config{
+		StateMgr:       state,
+		UpdateNodeVols: node.updateVol,
+		VolumesDir:     volsDir,
+	})
+	plug := &fakeHealthPlugin{fakePlugin: fakePlugin{volsDir: volsDir}}
+	hvm.builtIns["test-plugin"] = plug
+
+	ctx := timeout(t)
+	req := &cstructs.ClientHostVolumeCreateRequest{
+		Name:     "net-volume",
+		ID:       "vol-id-1",
+		PluginID: "test-plugin",
+	}
+	_, err := hvm.Create(ctx, req)
+	must.NoError(t, err)
+	expect := VolumeMap{"net-volume": genVolConfig(req, filepath.Join(volsDir, "vol-id-1"))}
+	must.Eq(t, expect, node.vols)
+
+	// healthy volumes are left alone
+	plug.created = ""
+	hvm.checkVolumesHealth(ctx)
+	must.Eq(t, "", plug.created)
+	must.Eq(t, expect, node.vols)
+
+	// unhealthy volumes that can't be repaired are removed from the node
+	plug.healthErr = errors.New("stale file handle")
+	plug.createErr = errors.New("server unreachable")
+	hvm.checkVolumesHealth(ctx)
+	must.Eq(t, VolumeMap{}, node.vols)
+	assertLocked(t, hvm, "net-volume")
+
+	// and added back once they are repaired
+	plug.createErr = nil
+	hvm.checkVolumesHealth(ctx)
+	must.Eq(t, "vol-id-1", plug.created)
+	must.Eq(t, expect, node.vols)
+	must.MapEmpty(t, hvm.unhealthy)
+}
+
+type fakeHealthPlugin struct {
+	fakePlugin
+	healthErr error
+}
+
+func (p *fakeHealthPlugin) CheckHealth(_ context.Context, _ *cstructs.ClientHostVolumeCreateRequest) error {
+	return p.healthErr
+}
@@ -5,6 +5,7 @@ package hostvolumemanager
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -65,8 +66,79 @@ func (hvm *HostVolumeManager) WaitForFirstFingerprint(ctx context.Context) <-cha
 	}
 	return ctx.Done()
 }
-func (hvm *HostVolumeManager) Run()      {}
-func (hvm *HostVolumeManager) Shutdown() {}
+
+// Run starts the health checks of volumes, which repair or remove volumes
+// that became unavailable, such as network mounts whose server went away.
+func (hvm *HostVolumeManager) Run() {
+	go hvm.runHealthChecks()
+}
+
+func (hvm *HostVolumeManager) Shutdown() {
+	hvm.shutdownFn()
+}
+
+func (hvm *HostVolumeManager) runHealthChecks() {
+	ticker := time.NewTicker(volumeHealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-hvm.shutdownCtx.Done():
+			return
+		case <-ticker.C:
+			hvm.checkVolumesHealth(hvm.shutdownCtx)
+		}
+	}
+}
+
+// checkVolumesHealth checks the volumes of plugins that implement
+// HostVolumePluginHealthChecker. Unhealthy volumes are created again, and
+// removed from the node until they are repaired, so that the scheduler
+// doesn't place allocations on them.
+func (hvm *HostVolumeManager) checkVolumesHealth(ctx context.Context) {
+	vols, err := hvm.stateMgr.GetDynamicHostVolumes()
+	if err != nil {
+		hvm.log.Error("failed to read volumes from state", "error", err)
+		return
+	}
+
+	for _, vol := range vols {
+		req := vol.CreateReq
+		plug, ok := hvm.builtIns[req.PluginID]
+		if !ok {
+			continue
+		}
+		checker, ok := plug.(HostVolumePluginHealthChecker)
+		if !ok || !hvm.locker.isLocked(req.Name) {
+			continue
+		}
+		log := hvm.log.With("volume_name", req.Name, "volume_id", req.ID)
+
+		err := checker.CheckHealth(ctx, req)
+		if err == nil {
+			if _, ok := hvm.unhealthy[req.ID]; ok {
+				log.Info("volume is healthy again")
+				delete(hvm.unhealthy, req.ID)
+				hvm.updateNodeVols(req.Name, genVolConfig(req, vol.HostPath))
+			}
+			continue
+		}
+
+		log.Warn("volume is unhealthy, creating it again", "error", err)
+		resp, err := plug.Create(ctx, req)
+		if err != nil {
+			log.Error("failed to repair volume", "error", err)
+			if _, ok := hvm.unhealthy[req.ID]; !ok {
+				hvm.unhealthy[req.ID] = struct{}{}
+				hvm.updateNodeVols(req.Name, nil)
+			}
+			continue
+		}
+		if _, ok := hvm.unhealthy[req.ID]; ok {
+			delete(hvm.unhealthy, req.ID)
+			hvm.updateNodeVols(req.Name, genVolConfig(req, resp.Path))
+		}
+	}
+}
 func (hvm *HostVolumeManager) PluginType() string {
 	// "Plugin"Type is misleading, because this is for *volumes* but ok.
 	return "dynamic_host_volume"
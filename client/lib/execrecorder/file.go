@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package execrecorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileSink records each exec session to its own file in a directory. The
+// files are named after the allocation and session IDs, and hold the events
+// of the session as JSON objects separated by newlines.
+type FileSink struct {
+	dir string
+}
+
+// NewFileSink returns a sink recording sessions to files in dir, which is
+// created if it doesn't exist.
+func NewFileSink(dir string) (*FileSink, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create exec session recording dir: %w", err)
+	}
+	return &FileSink{dir: dir}, nil
+}
+
+// Start creates the file the session is recorded to.
+func (s *FileSink) Start(session *Session) (Writer, error) {
+	path := filepath.Join(s.dir, fmt.Sprintf("%s-%s.jsonl", session.AllocID, session.ID))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exec session recording: %w", err)
+	}
+	return &fileWriter{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+type fileWriter struct {
+	f   *os.File
+	enc *json.Encoder
+}
+
+func (w *fileWriter) Write(event *Event) error {
+	return w.enc.Encode(event)
+}
+
+func (w *fileWriter) Close() error {
+	if err := w.f.Sync(); err != nil {
+		w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package execrecorder records the input and output of exec sessions, so that
+// sessions run in tasks can be audited.
+package execrecorder
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// EventTypeStart is the type of the first event of a session, which
+	// describes the session and who started it.
+	EventTypeStart = "start"
+
+	// EventTypeStdin, EventTypeStdout and EventTypeStderr are the types of
+	// the events holding data sent to or received from the command.
+	EventTypeStdin  = "stdin"
+	EventTypeStdout = "stdout"
+	EventTypeStderr = "stderr"
+
+	// EventTypeTTYSize is the type of the events for terminal resizes.
+	EventTypeTTYSize = "tty_size"
+
+	// EventTypeExited is the type of the event sent when the command exits.
+	EventTypeExited = "exited"
+
+	// EventTypeEnd is the type of the last event of a session.
+	EventTypeEnd = "end"
+)
+
+// Session describes an exec session and the identity that started it.
+type Session struct {
+	// ID is the unique ID of the exec session.
+	ID string
+
+	AllocID   string
+	Namespace string
+	JobID     string
+	Task      string
+
+	// Command is the command run in the task, and Action the name of the
+	// job action it was looked up from, if any.
+	Command []string
+	Action  string `json:",omitempty"`
+	Tty     bool
+
+	// AccessorID and TokenName identify the ACL token that started the
+	// session, if any.
+	AccessorID string `json:",omitempty"`
+	TokenName  string `json:",omitempty"`
+
+	// ClaimsAllocID and ClaimsTask identify the workload whose identity
+	// started the session, if any.
+	ClaimsAllocID string `json:",omitempty"`
+	ClaimsTask    string `json:",omitempty"`
+}
+
+// Event is a recorded event of an exec session.
+type Event struct {
+	Time time.Time
+	Type string
+
+	// Session is set on the start event.
+	Session *Session `json:",omitempty"`
+
+	// Data is set on the events of the stdin, stdout and stderr streams.
+	Data []byte `json:",omitempty"`
+
+	// Height and Width are set on terminal resize events.
+	Height int32 `json:",omitempty"`
+	Width  int32 `json:",omitempty"`
+
+	// ExitCode is set on the exited event.
+	ExitCode *int32 `json:",omitempty"`
+
+	// Error is set on the end event of a session that ended with an error.
+	Error string `json:",omitempty"`
+}
+
+// Sink is the destination of the recordings of exec sessions.
+type Sink interface {
+	// Start begins the recording of a session. The returned writer receives
+	// all the events of the session, starting with the start event.
+	Start(session *Session) (Writer, error)
+}
+
+// Writer records the events of a single exec session.
+type Writer interface {
+	// Write records an event. Sessions are ended if an event can't be
+	// recorded.
+	Write(event *Event) error
+
+	// Close is called once the session has ended.
+	Close() error
+}
+
+// Recorder wraps the stream of an exec session and records the messages
+// exchanged with the command.
+type Recorder struct {
+	stream drivers.ExecTaskStream
+	writer Writer
+
+	// l serializes the writes of Send and Recv, which are called concurrently
+	l sync.Mutex
+}
+
+// NewRecorder starts the recording of the session to the sink and returns a
+// recorder wrapping the stream of the session.
+func NewRecorder(sink Sink, session *Session, stream drivers.ExecTaskStream) (*Recorder, error) {
+	w, err := sink.Start(session)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{stream: stream, writer: w}
+	if err := r.write(&Event{Type: EventTypeStart, Session: session}); err != nil {
+		w.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Send records the output of the command before relaying it.
+func (r *Recorder) Send(m *drivers.ExecTaskStreamingResponseMsg) error {
+	if m.Stdout != nil && len(m.Stdout.Data) > 0 {
+		if err := r.write(&Event{Type: EventTypeStdout, Data: m.Stdout.Data}); err != nil {
+			return err
+		}
+	}
+	if m.Stderr != nil && len(m.Stderr.Data) > 0 {
+		if err := r.write(&Event{Type: EventTypeStderr, Data: m.Stderr.Data}); err != nil {
+			return err
+		}
+	}
+	if m.Exited {
+		event := &Event{Type: EventTypeExited}
+		if m.Result != nil {
+			event.ExitCode = &m.Result.ExitCode
+		}
+		if err := r.write(event); err != nil {
+			return err
+		}
+	}
+	return r.stream.Send(m)
+}
+
+// Recv records the input sent to the command after receiving it.
+func (r *Recorder) Recv() (*drivers.ExecTaskStreamingRequestMsg, error) {
+	m, err := r.stream.Recv()
+	if err != nil {
+		return m, err
+	}
+
+	if m.Stdin != nil && len(m.Stdin.Data) > 0 {
+		if err := r.write(&Event{Type: EventTypeStdin, Data: m.Stdin.Data}); err != nil {
+			return nil, err
+		}
+	}
+	if m.TtySize != nil {
+		event := &Event{Type: EventTypeTTYSize, Height: m.TtySize.Height, Width: m.TtySize.Width}
+		if err := r.write(event); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Close records the end of the session and the error it ended with, if any.
+func (r *Recorder) Close(sessionErr error) error {
+	event := &Event{Type: EventTypeEnd}
+	if sessionErr != nil {
+		event.Error = sessionErr.Error()
+	}
+	err := r.write(event)
+
+	if closeErr := r.writer.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (r *Recorder) write(event *Event) error {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	event.Time = time.Now().UTC()
+	return r.writer.Write(event)
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package execrecorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/proto"
+	"github.com/shoenig/test/must"
+)
+
+// testStream is an exec stream receiving the given requests and collecting
+// the responses sent to it.
+type testStream struct {
+	requests  []*drivers.ExecTaskStreamingRequestMsg
+	responses []*drivers.ExecTaskStreamingResponseMsg
+}
+
+func (s *testStream) Send(m *drivers.ExecTaskStreamingResponseMsg) error {
+	s.responses = append(s.responses, m)
+	return nil
+}
+
+func (s *testStream) Recv() (*drivers.ExecTaskStreamingRequestMsg, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	m := s.requests[0]
+	s.requests = s.requests[1:]
+	return m, nil
+}
+
+// testSink collects the events of a session, failing once failAfter events
+// have been written if set.
+type testSink struct {
+	events    []*Event
+	failAfter int
+	closed    bool
+}
+
+func (s *testSink) Start(*Session) (Writer, error) { return s, nil }
+
+func (s *testSink) Write(event *Event) error {
+	if s.failAfter > 0 && len(s.events) >= s.failAfter {
+		return errors.New("sink is full")
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *testSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	ci.Parallel(t)
+
+	stream := &testStream{
+		requests: []*drivers.ExecTaskStreamingRequestMsg{
+			{TtySize: &proto.ExecTaskStreamingRequest_TerminalSize{Height: 40, Width: 80}},
+			{Stdin: &proto.ExecTaskStreamingIOOperation{Data: []byte("ls\n")}},
+		},
+	}
+	sink := &testSink{}
+	session := &Session{ID: "exec-id", AllocID: "alloc-id", Command: []string{"/bin/sh"}}
+
+	r, err := NewRecorder(sink, session, stream)
+	must.NoError(t, err)
+
+	for {
+		_, err := r.Recv()
+		if err == io.EOF {
+			break
+		}
+		must.NoError(t, err)
+	}
+	must.NoError(t, r.Send(&drivers.ExecTaskStreamingResponseMsg{
+		Stdout: &proto.ExecTaskStreamingIOOperation{Data: []byte("local\n")},
+	}))
+	must.NoError(t, r.Send(&drivers.ExecTaskStreamingResponseMsg{
+		Stderr: &proto.ExecTaskStreamingIOOperation{Data: []byte("oops\n")},
+	}))
+	must.NoError(t, r.Send(&drivers.ExecTaskStreamingResponseMsg{
+		Exited: true,
+		Result: &proto.ExitResult{ExitCode: 3},
+	}))
+	must.NoError(t, r.Close(errors.New("boom")))
+
+	// The output was still relayed to the stream
+	must.Len(t, 3, stream.responses)
+
+	must.True(t, sink.closed)
+	must.Len(t, 7, sink.events)
+
+	types := make([]string, 0, len(sink.events))
+	for _, event := range sink.events {
+		must.False(t, event.Time.IsZero())
+		types = append(types, event.Type)
+	}
+	must.Eq(t, []string{
+		EventTypeStart, EventTypeTTYSize, EventTypeStdin,
+		EventTypeStdout, EventTypeStderr, EventTypeExited, EventTypeEnd,
+	}, types)
+
+	must.Eq(t, session, sink.events[0].Session)
+	must.Eq(t, 40, sink.events[1].Height)
+	must.Eq(t, 80, sink.events[1].Width)
+	must.Eq(t, "ls\n", string(sink.events[2].Data))
+	must.Eq(t, "local\n", string(sink.events[3].Data))
+	must.Eq(t, "oops\n", string(sink.events[4].Data))
+	must.NotNil(t, sink.events[5].ExitCode)
+	must.Eq(t, 3, *sink.events[5].ExitCode)
+	must.Eq(t, "boom", sink.events[6].Error)
+}
+
+func TestRecorder_WriteFailure(t *testing.T) {
+	ci.Parallel(t)
+
+	stream := &testStream{}
+	sink := &testSink{failAfter: 1}
+
+	r, err := NewRecorder(sink, &Session{ID: "exec-id"}, stream)
+	must.NoError(t, err)
+
+	// Output that can't be recorded isn't relayed
+	err = r.Send(&drivers.ExecTaskStreamingResponseMsg{
+		Stdout: &proto.ExecTaskStreamingIOOperation{Data: []byte("secret")},
+	})
+	must.EqError(t, err, "sink is full")
+	must.SliceEmpty(t, stream.responses)
+}
+
+func TestFileSink(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := filepath.Join(t.TempDir(), "sessions")
+	sink, err := NewFileSink(dir)
+	must.NoError(t, err)
+
+	session := &Session{ID: "exec-id", AllocID: "alloc-id"}
+	r, err := NewRecorder(sink, session, &testStream{})
+	must.NoError(t, err)
+	must.NoError(t, r.Send(&drivers.ExecTaskStreamingResponseMsg{
+		Stdout: &proto.ExecTaskStreamingIOOperation{Data: []byte("hello")},
+	}))
+	must.NoError(t, r.Close(nil))
+
+	// Sessions are never recorded over
+	_, err = sink.Start(session)
+	must.Error(t, err)
+
+	path := filepath.Join(dir, "alloc-id-exec-id.jsonl")
+	info, err := os.Stat(path)
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0o600), info.Mode().Perm())
+
+	f, err := os.Open(path)
+	must.NoError(t, err)
+	defer f.Close()
+
+	var events []*Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		must.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, &event)
+	}
+	must.NoError(t, scanner.Err())
+
+	must.Len(t, 3, events)
+	must.Eq(t, EventTypeStart, events[0].Type)
+	must.Eq(t, session, events[0].Session)
+	must.Eq(t, EventTypeStdout, events[1].Type)
+	must.Eq(t, "hello", string(events[1].Data))
+	must.Eq(t, EventTypeEnd, events[2].Type)
+	must.Eq(t, "", events[2].Error)
+}
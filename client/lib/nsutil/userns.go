@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nsutil
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// userNSPath is the user namespace of the current process, which only
+	// exists if the kernel supports user namespaces
+	userNSPath = "/proc/self/ns/user"
+
+	// maxUserNamespacesPath is the sysctl limiting the number of user
+	// namespaces each user may create
+	maxUserNamespacesPath = "/proc/sys/user/max_user_namespaces"
+)
+
+// MaxUserNamespaces returns the number of user namespaces each user may
+// create, which is 0 if the kernel doesn't support user namespaces or if they
+// are disabled.
+func MaxUserNamespaces() (int, error) {
+	if _, err := os.Stat(userNSPath); os.IsNotExist(err) {
+		return 0, nil
+	}
+
+	b, err := os.ReadFile(maxUserNamespacesPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", maxUserNamespacesPath, err)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", maxUserNamespacesPath, err)
+	}
+	return n, nil
+}
@@ -29,6 +29,17 @@ func (c *logmonClient) Start(cfg *LogConfig) error {
 		MaxFileSizeMb:  uint32(cfg.MaxFileSizeMB),
 		StdoutFifo:     cfg.StdoutFifo,
 		StderrFifo:     cfg.StderrFifo,
+		Labels:         cfg.Labels,
+		Format:         cfg.Format,
+	}
+	for _, sink := range cfg.Sinks {
+		req.Sinks = append(req.Sinks, &proto.LogSink{
+			Type:     sink.Type,
+			Address:  sink.Address,
+			Path:     sink.Path,
+			Tag:      sink.Tag,
+			Facility: sink.Facility,
+		})
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
 	defer cancel()
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +15,8 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/fifo"
 	"github.com/hashicorp/nomad/client/logmon/logging"
+	"github.com/hashicorp/nomad/client/logmon/logsink"
+	"github.com/hashicorp/nomad/helper"
 )
 
 const (
@@ -44,6 +47,18 @@ type LogConfig struct {
 
 	// MaxFileSizeMB is the max log file size in MB allowed before rotation occures
 	MaxFileSizeMB int
+
+	// Sinks are the remote log sinks the logs are shipped to in addition to
+	// being written to the log files.
+	Sinks []*logsink.Config
+
+	// Labels identify the task in the lines shipped to the sinks and in the
+	// lines written in the JSON format.
+	Labels map[string]string
+
+	// Format is the format of the lines written to the log files, either
+	// logsink.FormatText or logsink.FormatJSON. Defaults to text.
+	Format string
 }
 
 type LogMon interface {
@@ -109,6 +124,9 @@ type TaskLogger struct {
 
 	// rotator for stderr
 	lre *logRotatorWrapper
+
+	// shipper ships the logs to the sinks, if any
+	shipper *logsink.Shipper
 }
 
 // IsRunning will return true as long as one rotator wrapper is still running
@@ -136,11 +154,47 @@ func (tl *TaskLogger) Close() {
 		}()
 	}
 	wg.Wait()
+
+	// The rotators are closed, so no more lines are shipped
+	if tl.shipper != nil {
+		tl.shipper.Close()
+	}
 }
 
 func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	tl := &TaskLogger{config: cfg}
 
+	// Stop shipping if the logger can't be created
+	var err error
+	defer func() {
+		if err != nil && tl.shipper != nil {
+			tl.shipper.Close()
+		}
+	}()
+
+	switch cfg.Format {
+	case "", logsink.FormatText, logsink.FormatJSON:
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	if len(cfg.Sinks) > 0 {
+		// File sinks of jobs are relative to the log dir, and must never be
+		// opened outside of it
+		sinks := helper.CopySlice(cfg.Sinks)
+		for _, sink := range sinks {
+			if sink.Type == logsink.TypeFile && !filepath.IsAbs(sink.Path) {
+				sink.Root = cfg.LogDir
+			}
+		}
+
+		shipper, err := logsink.NewShipper(sinks, cfg.Labels, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log sinks: %v", err)
+		}
+		tl.shipper = shipper
+	}
+
 	logFileSize := int64(cfg.MaxFileSizeMB * 1024 * 1024)
 	lro, err := logging.NewFileRotator(cfg.LogDir, cfg.StdoutLogFile,
 		cfg.MaxFiles, logFileSize, logger)
@@ -148,7 +202,7 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 		return nil, fmt.Errorf("failed to create stdout logfile for %q: %v", cfg.StdoutLogFile, err)
 	}
 
-	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro)
+	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, tl.wrap(lro, "stdout"))
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +215,7 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 		return nil, fmt.Errorf("failed to create stderr logfile for %q: %v", cfg.StderrLogFile, err)
 	}
 
-	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre)
+	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, tl.wrap(lre, "stderr"))
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +226,38 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 
 }
 
+// wrap returns a writer writing to the rotator of the stream in the format of
+// the logs, and shipping the lines as they are logged to the sinks, if any.
+func (tl *TaskLogger) wrap(rotator io.WriteCloser, stream string) io.WriteCloser {
+	w := rotator
+	if tl.config.Format == logsink.FormatJSON {
+		w = logsink.NewJSONWriter(rotator, stream, tl.config.Labels)
+	}
+
+	if tl.shipper == nil {
+		return w
+	}
+	return &teeWriteCloser{WriteCloser: w, tee: tl.shipper.Writer(stream)}
+}
+
+// teeWriteCloser writes everything written to the embedded writer to tee as
+// well. Errors of tee are ignored.
+type teeWriteCloser struct {
+	io.WriteCloser
+	tee io.WriteCloser
+}
+
+func (t *teeWriteCloser) Write(p []byte) (int, error) {
+	n, err := t.WriteCloser.Write(p)
+	t.tee.Write(p[:n])
+	return n, err
+}
+
+func (t *teeWriteCloser) Close() error {
+	t.tee.Close()
+	return t.WriteCloser.Close()
+}
+
 // logRotatorWrapper wraps our log rotator and exposes a pipe that can feed the
 // log rotator data. The processOutWriter should be attached to the process and
 // data will be copied from the reader to the rotator.
@@ -9,10 +9,12 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/client/logmon/logsink"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/testutil"
@@ -76,6 +78,103 @@ func TestLogmon_Start_rotate(t *testing.T) {
 	must.NoError(t, lm.Stop())
 }
 
+func TestLogmon_Start_sinks(t *testing.T) {
+	ci.Parallel(t)
+
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses unix fifos")
+	}
+
+	dir := t.TempDir()
+	sinkPath := filepath.Join(dir, "sink.json")
+	cfg := &LogConfig{
+		LogDir:        dir,
+		StdoutLogFile: "stdout",
+		StdoutFifo:    filepath.Join(dir, "stdout.fifo"),
+		StderrLogFile: "stderr",
+		StderrFifo:    filepath.Join(dir, "stderr.fifo"),
+		MaxFiles:      2,
+		MaxFileSizeMB: 1,
+		Sinks:         []*logsink.Config{{Type: logsink.TypeFile, Path: sinkPath}},
+		Labels:        map[string]string{"task": "web"},
+	}
+
+	lm := NewLogMon(testlog.HCLogger(t))
+	must.NoError(t, lm.Start(cfg))
+
+	stdout, err := fifo.OpenWriter(cfg.StdoutFifo)
+	must.NoError(t, err)
+	_, err = stdout.Write([]byte("hello\n"))
+	must.NoError(t, err)
+
+	// lines are written to the log files and shipped to the sinks
+	testutil.WaitForResult(func() (bool, error) {
+		b, err := os.ReadFile(filepath.Join(dir, "stdout.0"))
+		if err != nil {
+			return false, err
+		}
+		return string(b) == "hello\n", fmt.Errorf("unexpected log file contents %q", b)
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+	testutil.WaitForResult(func() (bool, error) {
+		b, err := os.ReadFile(sinkPath)
+		if err != nil {
+			return false, err
+		}
+		return strings.Contains(string(b), `"message":"hello"`) &&
+			strings.Contains(string(b), `"task":"web"`), fmt.Errorf("unexpected sink contents %q", b)
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+	must.NoError(t, lm.Stop())
+}
+
+func TestLogmon_Start_jsonFormat(t *testing.T) {
+	ci.Parallel(t)
+
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses unix fifos")
+	}
+
+	dir := t.TempDir()
+	cfg := &LogConfig{
+		LogDir:        dir,
+		StdoutLogFile: "stdout",
+		StdoutFifo:    filepath.Join(dir, "stdout.fifo"),
+		StderrLogFile: "stderr",
+		StderrFifo:    filepath.Join(dir, "stderr.fifo"),
+		MaxFiles:      2,
+		MaxFileSizeMB: 1,
+		Labels:        map[string]string{"task": "web"},
+		Format:        logsink.FormatJSON,
+	}
+
+	lm := NewLogMon(testlog.HCLogger(t))
+	must.NoError(t, lm.Start(cfg))
+
+	stderr, err := fifo.OpenWriter(cfg.StderrFifo)
+	must.NoError(t, err)
+	_, err = stderr.Write([]byte("hello\n"))
+	must.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		b, err := os.ReadFile(filepath.Join(dir, "stderr.0"))
+		if err != nil {
+			return false, err
+		}
+		return strings.Contains(string(b), `"message":"hello","stream":"stderr","task":"web"`),
+			fmt.Errorf("unexpected log file contents %q", b)
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+	must.NoError(t, lm.Stop())
+
+	// unknown formats are refused
+	cfg.Format = "xml"
+	must.ErrorContains(t, NewLogMon(testlog.HCLogger(t)).Start(cfg), "unknown log format")
+}
+
 // asserts that calling Start twice restarts the log rotator and that any logs
 // published while the listener was unavailable are received.
 func TestLogmon_Start_restart_flusheslogs(t *testing.T) {
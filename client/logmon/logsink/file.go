@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// fileSink appends records to a file as JSON objects separated by newlines.
+// Each batch is appended with a single write, so that tasks sharing the same
+// file never interleave their lines.
+type fileSink struct {
+	f *os.File
+}
+
+// newFileSink opens the file of the sink. The file is never opened through a
+// symlink, and files relative to a root never resolve outside of it.
+func newFileSink(c *Config) (*fileSink, error) {
+	flags := os.O_WRONLY | os.O_APPEND | os.O_CREATE | openNoFollow
+
+	var f *os.File
+	var err error
+	if c.Root != "" {
+		var root *os.Root
+		root, err = os.OpenRoot(c.Root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log sink directory: %w", err)
+		}
+		defer root.Close()
+		f, err = root.OpenFile(c.Path, flags, 0o640)
+	} else {
+		f, err = os.OpenFile(c.Path, flags, 0o640)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log sink file: %w", err)
+	}
+
+	// refuse fifos or devices created in place of the file
+	if info, err := f.Stat(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to stat log sink file: %w", err)
+	} else if !info.Mode().IsRegular() {
+		f.Close()
+		return nil, fmt.Errorf("log sink file %q is not a regular file", c.Path)
+	}
+	return &fileSink{f: f}, nil
+}
+
+func (s *fileSink) Write(records []*Record) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := encodeJSON(enc, r); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.f.Write(buf.Bytes())
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.f.Close()
+}
@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+
+package logsink
+
+import "syscall"
+
+// openNoFollow refuses to open the files of sinks through a symlink, and to
+// block opening a fifo.
+const openNoFollow = syscall.O_NOFOLLOW | syscall.O_NONBLOCK
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+
+package logsink
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// TestFileSink_Root asserts that file sinks relative to a root are never
+// opened outside of it, through a symlink, or on something else than a file.
+func TestFileSink_Root(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	outside := t.TempDir()
+	target := filepath.Join(outside, "target")
+	must.NoError(t, os.WriteFile(target, nil, 0o600))
+
+	must.NoError(t, os.Symlink(target, filepath.Join(root, "link.json")))
+	must.NoError(t, os.Symlink(outside, filepath.Join(root, "dir")))
+	must.NoError(t, syscall.Mkfifo(filepath.Join(root, "fifo.json"), 0o600))
+
+	sink, err := New(&Config{Type: TypeFile, Path: "logs.json", Root: root})
+	must.NoError(t, err)
+	must.NoError(t, sink.Write(testRecords()))
+	must.NoError(t, sink.Close())
+	must.FileExists(t, filepath.Join(root, "logs.json"))
+
+	for _, path := range []string{"link.json", "dir/logs.json", "fifo.json"} {
+		_, err = New(&Config{Type: TypeFile, Path: path, Root: root})
+		must.Error(t, err, must.Sprintf("path %s", path))
+	}
+
+	b, err := os.ReadFile(target)
+	must.NoError(t, err)
+	must.SliceEmpty(t, b)
+	must.FileNotExists(t, filepath.Join(outside, "logs.json"))
+}
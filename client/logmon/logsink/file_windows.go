@@ -0,0 +1,9 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build windows
+
+package logsink
+
+// openNoFollow is not supported on Windows.
+const openNoFollow = 0
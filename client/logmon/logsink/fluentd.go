@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logsink
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+)
+
+// fluentdDefaultTag is the tag of the records sent to Fluentd when none is
+// configured.
+const fluentdDefaultTag = "nomad"
+
+// fluentdSink sends records to a Fluentd server over TCP in the forward mode
+// of the forward protocol, with one message holding each batch of records.
+type fluentdSink struct {
+	address string
+	tag     string
+	handle  *codec.MsgpackHandle
+
+	conn net.Conn
+}
+
+func newFluentdSink(c *Config) *fluentdSink {
+	tag := c.Tag
+	if tag == "" {
+		tag = fluentdDefaultTag
+	}
+
+	return &fluentdSink{
+		address: c.Address,
+		tag:     tag,
+		handle:  &codec.MsgpackHandle{WriteExt: true},
+	}
+}
+
+func (s *fluentdSink) Write(records []*Record) error {
+	entries := make([]any, 0, len(records))
+	for _, r := range records {
+		entries = append(entries, []any{r.Time.Unix(), r.fields()})
+	}
+	msg := []any{s.tag, entries}
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if s.conn, err = net.DialTimeout("tcp", s.address, sinkDialTimeout); err != nil {
+				s.conn = nil
+				return fmt.Errorf("failed to connect to fluentd server: %w", err)
+			}
+		}
+
+		s.conn.SetWriteDeadline(time.Now().Add(sinkWriteTimeout))
+		w := bufio.NewWriter(s.conn)
+		if err = codec.NewEncoder(w, s.handle).Encode(msg); err == nil {
+			if err = w.Flush(); err == nil {
+				return nil
+			}
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	return fmt.Errorf("failed to write to fluentd server: %w", err)
+}
+
+func (s *fluentdSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
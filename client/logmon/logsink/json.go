@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+const (
+	// FormatText writes the lines of the logs to the log files as they are
+	// logged by the task.
+	FormatText = "text"
+
+	// FormatJSON writes each line of the logs to the log files as a JSON
+	// object with the labels of the task, the time, and the stream of the
+	// line.
+	FormatJSON = "json"
+)
+
+// encodeJSON encodes the record as a JSON object followed by a newline.
+func encodeJSON(enc *json.Encoder, r *Record) error {
+	fields := make(map[string]any, len(r.Labels)+3)
+	for k, v := range r.fields() {
+		fields[k] = v
+	}
+	fields["time"] = r.Time.UTC().Format(time.RFC3339Nano)
+	return enc.Encode(fields)
+}
+
+// NewJSONWriter returns a writer wrapping each line written to it in a JSON
+// object labeled with the labels and stream, before writing it to w. Each
+// object is written with a single write. The writer must be closed to write
+// the last line if it did not end, and closes w.
+func NewJSONWriter(w io.WriteCloser, stream string, labels map[string]string) io.WriteCloser {
+	jw := &jsonWriter{w: w}
+	jw.enc = json.NewEncoder(&jw.buf)
+	jw.lineWriter.emit = func(line []byte) error {
+		jw.buf.Reset()
+		r := &Record{
+			Time:    time.Now(),
+			Stream:  stream,
+			Message: string(line),
+			Labels:  labels,
+		}
+		if err := encodeJSON(jw.enc, r); err != nil {
+			return err
+		}
+		_, err := jw.w.Write(jw.buf.Bytes())
+		return err
+	}
+	return jw
+}
+
+type jsonWriter struct {
+	lineWriter
+
+	w   io.WriteCloser
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+func (w *jsonWriter) Close() error {
+	err := w.lineWriter.Close()
+	if closeErr := w.w.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logsink
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+type testWriteCloser struct {
+	bytes.Buffer
+	writes int
+	closed bool
+}
+
+func (w *testWriteCloser) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func (w *testWriteCloser) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestJSONWriter(t *testing.T) {
+	ci.Parallel(t)
+
+	var out testWriteCloser
+	labels := map[string]string{"alloc_id": "1234", "task": "web"}
+	w := NewJSONWriter(&out, "stderr", labels)
+
+	_, err := w.Write([]byte("one\ntwo \"quoted\"\nthr"))
+	must.NoError(t, err)
+	_, err = w.Write([]byte("ee"))
+	must.NoError(t, err)
+	must.Eq(t, 2, out.writes)
+	must.NoError(t, w.Close())
+	must.Eq(t, 3, out.writes)
+	must.True(t, out.closed)
+
+	var messages []string
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var fields map[string]string
+		must.NoError(t, json.Unmarshal(scanner.Bytes(), &fields))
+		must.Eq(t, "1234", fields["alloc_id"])
+		must.Eq(t, "web", fields["task"])
+		must.Eq(t, "stderr", fields["stream"])
+		_, err := time.Parse(time.RFC3339Nano, fields["time"])
+		must.NoError(t, err)
+		messages = append(messages, fields["message"])
+	}
+	must.Eq(t, []string{"one", `two "quoted"`, "three"}, messages)
+}
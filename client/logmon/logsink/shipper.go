@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logsink
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// queueSize is the number of records queued for a sink before new
+	// records are dropped.
+	queueSize = 4096
+
+	// maxBatchSize is the maximum number of records sent at once.
+	maxBatchSize = 256
+
+	// maxLineSize is the length after which a line that hasn't ended is
+	// shipped anyway.
+	maxLineSize = 16 * 1024
+
+	// closeTimeout is how long the records still queued are sent for when
+	// the shipper is closed.
+	closeTimeout = 5 * time.Second
+)
+
+// Shipper ships the lines of the logs of a task to sinks. Each sink has its
+// own queue and goroutine, so that a slow or unreachable sink never blocks the
+// task or the other sinks. Lines are dropped when a queue is full.
+type Shipper struct {
+	sinks  []*queuedSink
+	labels map[string]string
+	logger hclog.Logger
+}
+
+// NewShipper returns a shipper sending lines to the sinks of the given
+// configs, labeled with the given labels.
+func NewShipper(configs []*Config, labels map[string]string, logger hclog.Logger) (*Shipper, error) {
+	s := &Shipper{
+		labels: labels,
+		logger: logger,
+	}
+
+	for _, c := range configs {
+		sink, err := New(c)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		q := &queuedSink{
+			sink:   sink,
+			queue:  make(chan *Record, queueSize),
+			doneCh: make(chan struct{}),
+			logger: logger.With("sink", c.Type),
+		}
+		go q.run()
+		s.sinks = append(s.sinks, q)
+	}
+	return s, nil
+}
+
+// Writer returns a writer shipping the lines written to it as lines of the
+// stream. The writer must be closed to ship the last line if it did not end.
+func (s *Shipper) Writer(stream string) io.WriteCloser {
+	return &lineWriter{emit: func(line []byte) error {
+		s.ship(stream, line)
+		return nil
+	}}
+}
+
+func (s *Shipper) ship(stream string, line []byte) {
+	r := &Record{
+		Time:    time.Now(),
+		Stream:  stream,
+		Message: string(line),
+		Labels:  s.labels,
+	}
+	for _, q := range s.sinks {
+		q.add(r)
+	}
+}
+
+// Close sends the records still queued, waiting up to closeTimeout, and closes
+// the sinks.
+func (s *Shipper) Close() {
+	var wg sync.WaitGroup
+	for _, q := range s.sinks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.close()
+		}()
+	}
+	wg.Wait()
+}
+
+// queuedSink sends the records queued for a sink in batches.
+type queuedSink struct {
+	sink   Sink
+	queue  chan *Record
+	doneCh chan struct{}
+	logger hclog.Logger
+
+	// l guards closed and dropped
+	l       sync.Mutex
+	closed  bool
+	dropped int
+}
+
+func (q *queuedSink) add(r *Record) {
+	q.l.Lock()
+	defer q.l.Unlock()
+	if q.closed {
+		return
+	}
+
+	select {
+	case q.queue <- r:
+	default:
+		q.dropped++
+	}
+}
+
+func (q *queuedSink) run() {
+	defer close(q.doneCh)
+	defer q.sink.Close()
+
+	batch := make([]*Record, 0, maxBatchSize)
+	for r := range q.queue {
+		batch = append(batch[:0], r)
+	BATCH:
+		for len(batch) < maxBatchSize {
+			select {
+			case r, ok := <-q.queue:
+				if !ok {
+					break BATCH
+				}
+				batch = append(batch, r)
+			default:
+				break BATCH
+			}
+		}
+
+		if err := q.sink.Write(batch); err != nil {
+			q.logger.Warn("failed to ship logs", "lines", len(batch), "error", err)
+		}
+
+		q.l.Lock()
+		dropped := q.dropped
+		q.dropped = 0
+		q.l.Unlock()
+		if dropped > 0 {
+			q.logger.Warn("dropped log lines because the sink is too slow", "lines", dropped)
+		}
+	}
+}
+
+func (q *queuedSink) close() {
+	q.l.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+	q.l.Unlock()
+
+	select {
+	case <-q.doneCh:
+	case <-time.After(closeTimeout):
+		q.logger.Warn("timed out shipping the remaining log lines")
+	}
+}
+
+// lineWriter splits the data written to it into lines it emits, without their
+// trailing newline. Lines longer than maxLineSize are split.
+type lineWriter struct {
+	emit func(line []byte) error
+	buf  []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := bytes.TrimSuffix(w.buf[:i], []byte{'\r'})
+		w.buf = w.buf[i+1:]
+		if err := w.emit(line); err != nil {
+			return 0, err
+		}
+	}
+
+	for len(w.buf) > maxLineSize {
+		line := w.buf[:maxLineSize]
+		w.buf = w.buf[maxLineSize:]
+		if err := w.emit(line); err != nil {
+			return 0, err
+		}
+	}
+	w.buf = bytes.Clone(w.buf)
+	return len(p), nil
+}
+
+// Close emits the last line if it did not end.
+func (w *lineWriter) Close() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := w.buf
+	w.buf = nil
+	return w.emit(line)
+}
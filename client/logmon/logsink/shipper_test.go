@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logsink
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+)
+
+func TestShipper(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "logs.json")
+	labels := map[string]string{"task": "web"}
+	s, err := NewShipper([]*Config{{Type: TypeFile, Path: path}}, labels, testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	stdout := s.Writer("stdout")
+	stderr := s.Writer("stderr")
+	_, err = stdout.Write([]byte("one\r\ntw"))
+	must.NoError(t, err)
+	_, err = stdout.Write([]byte("o\n"))
+	must.NoError(t, err)
+	_, err = stderr.Write([]byte(strings.Repeat("x", maxLineSize+1)))
+	must.NoError(t, err)
+
+	// the partial line is shipped when the writer is closed
+	_, err = stdout.Write([]byte("three"))
+	must.NoError(t, err)
+	must.NoError(t, stdout.Close())
+	must.NoError(t, stderr.Close())
+	s.Close()
+
+	f, err := os.Open(path)
+	must.NoError(t, err)
+	defer f.Close()
+
+	var got []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 2*maxLineSize)
+	for scanner.Scan() {
+		var fields map[string]string
+		must.NoError(t, json.Unmarshal(scanner.Bytes(), &fields))
+		must.Eq(t, "web", fields["task"])
+		got = append(got, fields["stream"]+":"+fields["message"])
+	}
+	must.NoError(t, scanner.Err())
+	must.Eq(t, []string{
+		"stdout:one",
+		"stdout:two",
+		"stderr:" + strings.Repeat("x", maxLineSize),
+		"stdout:three",
+		"stderr:x",
+	}, got)
+}
+
+func TestShipper_InvalidSink(t *testing.T) {
+	ci.Parallel(t)
+
+	_, err := NewShipper([]*Config{{Type: TypeFluentd}}, nil, testlog.HCLogger(t))
+	must.ErrorContains(t, err, "requires an address")
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package logsink ships the lines of task logs to remote log sinks, so that
+// logs can leave the node without running a log shipper alongside each task.
+package logsink
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// TypeSyslog sends the lines to a syslog server in the RFC 5424 format.
+	TypeSyslog = "syslog"
+
+	// TypeFluentd sends the lines to a Fluentd or Fluent Bit server with the
+	// forward protocol.
+	TypeFluentd = "fluentd"
+
+	// TypeFile appends the lines to a file as JSON objects.
+	TypeFile = "file"
+)
+
+// Config is the configuration of a log sink.
+type Config struct {
+	// Type is the type of the sink.
+	Type string
+
+	// Address is the address of the syslog or Fluentd server. Syslog
+	// addresses are URLs with the udp, tcp, unix, or unixgram scheme, and
+	// Fluentd addresses are host:port pairs.
+	Address string
+
+	// Path is the file lines are appended to by file sinks.
+	Path string
+
+	// Root is the directory the Path of file sinks is relative to, if set.
+	// The file is never opened outside of Root.
+	Root string
+
+	// Tag is the syslog app name or the Fluentd tag of the lines.
+	Tag string
+
+	// Facility is the syslog facility of the lines. Defaults to "user".
+	Facility string
+}
+
+// Copy returns a copy of the config.
+func (c *Config) Copy() *Config {
+	if c == nil {
+		return nil
+	}
+	nc := *c
+	return &nc
+}
+
+// Validate returns an error if the config is invalid.
+func (c *Config) Validate() error {
+	switch c.Type {
+	case TypeSyslog:
+		if _, _, err := parseSyslogAddress(c.Address); err != nil {
+			return err
+		}
+		if _, err := syslogFacility(c.Facility); err != nil {
+			return err
+		}
+	case TypeFluentd:
+		if c.Address == "" {
+			return errors.New("fluentd log sink requires an address")
+		}
+	case TypeFile:
+		if c.Path == "" {
+			return errors.New("file log sink requires a path")
+		}
+		if c.Root != "" && !filepath.IsLocal(c.Path) {
+			return fmt.Errorf("file log sink path %q must be relative to %q", c.Path, c.Root)
+		}
+		if c.Root == "" && !filepath.IsAbs(c.Path) {
+			return fmt.Errorf("file log sink path %q must be absolute", c.Path)
+		}
+	default:
+		return fmt.Errorf("unknown log sink type %q, must be one of %s",
+			c.Type, strings.Join([]string{TypeSyslog, TypeFluentd, TypeFile}, ", "))
+	}
+	return nil
+}
+
+// Record is a line of the logs of a task.
+type Record struct {
+	Time time.Time
+
+	// Stream is either "stdout" or "stderr".
+	Stream string
+
+	// Message is the line, without its trailing newline.
+	Message string
+
+	// Labels identify the task the line was logged by.
+	Labels map[string]string
+}
+
+// fields returns the fields of the record sent to structured sinks.
+func (r *Record) fields() map[string]string {
+	fields := make(map[string]string, len(r.Labels)+2)
+	for k, v := range r.Labels {
+		fields[k] = v
+	}
+	fields["stream"] = r.Stream
+	fields["message"] = r.Message
+	return fields
+}
+
+// Sink sends records to a destination.
+type Sink interface {
+	// Write sends a batch of records.
+	Write(records []*Record) error
+
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// New returns the sink for the config.
+func New(c *Config) (Sink, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch c.Type {
+	case TypeSyslog:
+		return newSyslogSink(c)
+	case TypeFluentd:
+		return newFluentdSink(c), nil
+	default:
+		return newFileSink(c)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logsink
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func testRecords() []*Record {
+	labels := map[string]string{"alloc_id": "1234", "task": "web"}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []*Record{
+		{Time: now, Stream: "stdout", Message: "hello", Labels: labels},
+		{Time: now, Stream: "stderr", Message: "oops", Labels: labels},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		config *Config
+		expErr string
+	}{
+		{
+			name:   "syslog udp",
+			config: &Config{Type: TypeSyslog, Address: "udp://127.0.0.1:514"},
+		},
+		{
+			name:   "syslog unix",
+			config: &Config{Type: TypeSyslog, Address: "unixgram:///dev/log", Facility: "local3"},
+		},
+		{
+			name:   "syslog no host",
+			config: &Config{Type: TypeSyslog, Address: "tcp://"},
+			expErr: "must include a host",
+		},
+		{
+			name:   "syslog bad facility",
+			config: &Config{Type: TypeSyslog, Address: "udp://127.0.0.1:514", Facility: "local9"},
+			expErr: "unknown syslog facility",
+		},
+		{
+			name:   "fluentd",
+			config: &Config{Type: TypeFluentd, Address: "127.0.0.1:24224"},
+		},
+		{
+			name:   "fluentd no address",
+			config: &Config{Type: TypeFluentd},
+			expErr: "requires an address",
+		},
+		{
+			name:   "file relative",
+			config: &Config{Type: TypeFile, Path: "logs.json"},
+			expErr: "must be absolute",
+		},
+		{
+			name:   "file outside root",
+			config: &Config{Type: TypeFile, Path: "../logs.json", Root: "/alloc/logs"},
+			expErr: "must be relative to",
+		},
+		{
+			name:   "unknown",
+			config: &Config{Type: "journald"},
+			expErr: "unknown log sink type",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "logs.json")
+	sink, err := New(&Config{Type: TypeFile, Path: path})
+	must.NoError(t, err)
+	must.NoError(t, sink.Write(testRecords()))
+	must.NoError(t, sink.Close())
+
+	b, err := os.ReadFile(path)
+	must.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	must.Len(t, 2, lines)
+
+	var fields map[string]string
+	must.NoError(t, json.Unmarshal([]byte(lines[1]), &fields))
+	must.Eq(t, map[string]string{
+		"alloc_id": "1234",
+		"task":     "web",
+		"stream":   "stderr",
+		"message":  "oops",
+		"time":     "2024-01-02T03:04:05Z",
+	}, fields)
+}
+
+func TestSyslogSink_UDP(t *testing.T) {
+	ci.Parallel(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	must.NoError(t, err)
+	defer conn.Close()
+
+	sink, err := New(&Config{
+		Type:     TypeSyslog,
+		Address:  "udp://" + conn.LocalAddr().String(),
+		Facility: "local0",
+	})
+	must.NoError(t, err)
+	defer sink.Close()
+	must.NoError(t, sink.Write(testRecords()))
+
+	hostname, _ := os.Hostname()
+	buf := make([]byte, 1024)
+	for _, exp := range []string{
+		"<134>1 2024-01-02T03:04:05Z " + hostname + " web - stdout - hello",
+		"<131>1 2024-01-02T03:04:05Z " + hostname + " web - stderr - oops",
+	} {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		must.NoError(t, err)
+		must.Eq(t, exp, string(buf[:n]))
+	}
+}
+
+func TestSyslogSink_TCP(t *testing.T) {
+	ci.Parallel(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	defer l.Close()
+
+	sink, err := New(&Config{Type: TypeSyslog, Address: "tcp://" + l.Addr().String(), Tag: "app"})
+	must.NoError(t, err)
+	defer sink.Close()
+	must.NoError(t, sink.Write(testRecords()))
+
+	conn, err := l.Accept()
+	must.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	r := bufio.NewReader(conn)
+	for _, exp := range []string{" app - stdout - hello\n", " app - stderr - oops\n"} {
+		line, err := r.ReadString('\n')
+		must.NoError(t, err)
+		must.StrHasSuffix(t, exp, line)
+	}
+}
+
+func TestFluentdSink(t *testing.T) {
+	ci.Parallel(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	defer l.Close()
+
+	sink, err := New(&Config{Type: TypeFluentd, Address: l.Addr().String()})
+	must.NoError(t, err)
+	defer sink.Close()
+	must.NoError(t, sink.Write(testRecords()))
+
+	conn, err := l.Accept()
+	must.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	handle := &codec.MsgpackHandle{}
+	handle.RawToString = true
+	var msg []any
+	must.NoError(t, codec.NewDecoder(conn, handle).Decode(&msg))
+	must.Len(t, 2, msg)
+	must.Eq(t, "nomad", msg[0].(string))
+
+	entries := msg[1].([]any)
+	must.Len(t, 2, entries)
+	entry := entries[0].([]any)
+	must.Eq(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).Unix(), entry[0].(int64))
+	fields := entry[1].(map[any]any)
+	must.Eq(t, "hello", fields["message"].(string))
+	must.Eq(t, "stdout", fields["stream"].(string))
+	must.Eq(t, "web", fields["task"].(string))
+}
@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logsink
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// syslogSeverityErr and syslogSeverityInfo are the severities of the lines
+	// logged to stderr and stdout.
+	syslogSeverityErr  = 3
+	syslogSeverityInfo = 6
+
+	// sinkDialTimeout is the timeout to connect to the servers of sinks.
+	sinkDialTimeout = 5 * time.Second
+
+	// sinkWriteTimeout is the timeout to write a batch of records to
+	// the servers of sinks.
+	sinkWriteTimeout = 10 * time.Second
+)
+
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+func syslogFacility(name string) (int, error) {
+	if name == "" {
+		return syslogFacilities["user"], nil
+	}
+	facility, ok := syslogFacilities[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown syslog facility %q", name)
+	}
+	return facility, nil
+}
+
+// parseSyslogAddress returns the network and address to dial for the address
+// of a syslog sink.
+func parseSyslogAddress(address string) (string, string, error) {
+	if address == "" {
+		return "", "", fmt.Errorf("syslog log sink requires an address")
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid syslog address %q: %v", address, err)
+	}
+	switch u.Scheme {
+	case "udp", "tcp":
+		if u.Host == "" {
+			return "", "", fmt.Errorf("syslog address %q must include a host", address)
+		}
+		return u.Scheme, u.Host, nil
+	case "unix", "unixgram":
+		if u.Path == "" {
+			return "", "", fmt.Errorf("syslog address %q must include a path", address)
+		}
+		return u.Scheme, u.Path, nil
+	default:
+		return "", "", fmt.Errorf("syslog address %q must use the udp, tcp, unix, or unixgram scheme", address)
+	}
+}
+
+// syslogSink sends records to a syslog server in the RFC 5424 format. Stream
+// connections separate messages with newlines.
+type syslogSink struct {
+	network  string
+	address  string
+	tag      string
+	facility int
+	hostname string
+
+	conn net.Conn
+}
+
+func newSyslogSink(c *Config) (*syslogSink, error) {
+	network, address, err := parseSyslogAddress(c.Address)
+	if err != nil {
+		return nil, err
+	}
+	facility, err := syslogFacility(c.Facility)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	return &syslogSink{
+		network:  network,
+		address:  address,
+		tag:      c.Tag,
+		facility: facility,
+		hostname: hostname,
+	}, nil
+}
+
+func (s *syslogSink) Write(records []*Record) error {
+	stream := s.network == "tcp" || s.network == "unix"
+
+	// Datagrams hold a single message each
+	var msgs [][]byte
+	var buf bytes.Buffer
+	for _, r := range records {
+		s.format(&buf, r)
+		if stream {
+			buf.WriteByte('\n')
+			continue
+		}
+		msgs = append(msgs, bytes.Clone(buf.Bytes()))
+		buf.Reset()
+	}
+	if stream {
+		msgs = [][]byte{buf.Bytes()}
+	}
+
+	for _, msg := range msgs {
+		if err := s.send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send writes the message to the connection, reconnecting once if the
+// connection was broken.
+func (s *syslogSink) send(msg []byte) error {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if s.conn, err = net.DialTimeout(s.network, s.address, sinkDialTimeout); err != nil {
+				s.conn = nil
+				return fmt.Errorf("failed to connect to syslog server: %w", err)
+			}
+		}
+
+		s.conn.SetWriteDeadline(time.Now().Add(sinkWriteTimeout))
+		if _, err = s.conn.Write(msg); err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	return fmt.Errorf("failed to write to syslog server: %w", err)
+}
+
+func (s *syslogSink) format(buf *bytes.Buffer, r *Record) {
+	severity := syslogSeverityInfo
+	if r.Stream == "stderr" {
+		severity = syslogSeverityErr
+	}
+
+	tag := s.tag
+	if tag == "" {
+		tag = r.Labels["task"]
+	}
+	if tag == "" {
+		tag = "-"
+	}
+
+	fmt.Fprintf(buf, "<%d>1 %s %s %s - %s - %s",
+		s.facility*8+severity,
+		r.Time.UTC().Format(time.RFC3339Nano),
+		s.hostname, tag, r.Stream, r.Message)
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
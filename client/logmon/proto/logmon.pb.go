@@ -25,16 +25,19 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type StartRequest struct {
-	LogDir               string   `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
-	StdoutFileName       string   `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
-	StderrFileName       string   `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
-	MaxFiles             uint32   `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
-	MaxFileSizeMb        uint32   `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
-	StdoutFifo           string   `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
-	StderrFifo           string   `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LogDir               string            `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
+	StdoutFileName       string            `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
+	StderrFileName       string            `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
+	MaxFiles             uint32            `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxFileSizeMb        uint32            `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
+	StdoutFifo           string            `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
+	StderrFifo           string            `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
+	Sinks                []*LogSink        `protobuf:"bytes,8,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Format               string            `protobuf:"bytes,10,opt,name=format,proto3" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *StartRequest) Reset()         { *m = StartRequest{} }
@@ -111,6 +114,27 @@ func (m *StartRequest) GetStderrFifo() string {
 	return ""
 }
 
+func (m *StartRequest) GetSinks() []*LogSink {
+	if m != nil {
+		return m.Sinks
+	}
+	return nil
+}
+
+func (m *StartRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *StartRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
 type StartResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -204,11 +228,84 @@ func (m *StopResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StopResponse proto.InternalMessageInfo
 
+type LogSink struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Path                 string   `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Tag                  string   `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	Facility             string   `protobuf:"bytes,5,opt,name=facility,proto3" json:"facility,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogSink) Reset()         { *m = LogSink{} }
+func (m *LogSink) String() string { return proto.CompactTextString(m) }
+func (*LogSink) ProtoMessage()    {}
+func (*LogSink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{4}
+}
+
+func (m *LogSink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogSink.Unmarshal(m, b)
+}
+func (m *LogSink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogSink.Marshal(b, m, deterministic)
+}
+func (m *LogSink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogSink.Merge(m, src)
+}
+func (m *LogSink) XXX_Size() int {
+	return xxx_messageInfo_LogSink.Size(m)
+}
+func (m *LogSink) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogSink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogSink proto.InternalMessageInfo
+
+func (m *LogSink) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *LogSink) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *LogSink) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *LogSink) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *LogSink) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*StartRequest)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest.LabelsEntry")
 	proto.RegisterType((*StartResponse)(nil), "hashicorp.nomad.client.logmon.proto.StartResponse")
 	proto.RegisterType((*StopRequest)(nil), "hashicorp.nomad.client.logmon.proto.StopRequest")
 	proto.RegisterType((*StopResponse)(nil), "hashicorp.nomad.client.logmon.proto.StopResponse")
+	proto.RegisterType((*LogSink)(nil), "hashicorp.nomad.client.logmon.proto.LogSink")
 }
 
 func init() {
@@ -216,27 +313,37 @@ func init() {
 }
 
 var fileDescriptor_be72d5e24d2ecba6 = []byte{
-	// 320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x90, 0x31, 0x6f, 0xc2, 0x30,
-	0x10, 0x85, 0x1b, 0x0a, 0xa1, 0x1c, 0x0d, 0x45, 0x5e, 0x1a, 0xd1, 0xa1, 0x28, 0x1d, 0xca, 0x14,
-	0x0a, 0xfd, 0x07, 0x55, 0xd5, 0xa9, 0x74, 0x80, 0xad, 0x4b, 0x64, 0xc0, 0x09, 0x96, 0x62, 0x5f,
-	0x6a, 0x1b, 0x09, 0xb1, 0xf6, 0xd7, 0xf6, 0x5f, 0x54, 0x71, 0x4c, 0x94, 0x11, 0x26, 0xeb, 0xee,
-	0x7d, 0x4f, 0xf7, 0xfc, 0x60, 0xbc, 0xc9, 0x39, 0x93, 0x66, 0x9a, 0x63, 0x26, 0x50, 0x4e, 0x0b,
-	0x85, 0x06, 0xdd, 0x10, 0xdb, 0x81, 0x3c, 0xed, 0xa8, 0xde, 0xf1, 0x0d, 0xaa, 0x22, 0x96, 0x28,
-	0xe8, 0x36, 0xae, 0x1c, 0x71, 0x13, 0x8a, 0x7e, 0x5b, 0x70, 0xbb, 0x32, 0x54, 0x99, 0x25, 0xfb,
-	0xd9, 0x33, 0x6d, 0xc8, 0x3d, 0x74, 0x73, 0xcc, 0x92, 0x2d, 0x57, 0xa1, 0x37, 0xf6, 0x26, 0xbd,
-	0xa5, 0x9f, 0x63, 0xf6, 0xce, 0x15, 0x99, 0xc0, 0x50, 0x9b, 0x2d, 0xee, 0x4d, 0x92, 0xf2, 0x9c,
-	0x25, 0x92, 0x0a, 0x16, 0xb6, 0x2c, 0x31, 0xa8, 0xf6, 0x1f, 0x3c, 0x67, 0x5f, 0x54, 0x30, 0x47,
-	0x32, 0xa5, 0x1a, 0xe4, 0x75, 0x4d, 0x32, 0xa5, 0x6a, 0xf2, 0x01, 0x7a, 0x82, 0x1e, 0x2c, 0xa6,
-	0xc3, 0xf6, 0xd8, 0x9b, 0x04, 0xcb, 0x1b, 0x41, 0x0f, 0xa5, 0xae, 0xc9, 0x33, 0x0c, 0x4f, 0x62,
-	0xa2, 0xf9, 0x91, 0x25, 0x62, 0x1d, 0x76, 0x2c, 0x13, 0x38, 0x66, 0xc5, 0x8f, 0x6c, 0xb1, 0x26,
-	0x8f, 0xd0, 0xaf, 0x93, 0xa5, 0x18, 0xfa, 0xf6, 0x14, 0x9c, 0x42, 0xa5, 0xe8, 0x80, 0x2a, 0x50,
-	0x8a, 0x61, 0xb7, 0x06, 0x6c, 0x96, 0x14, 0xa3, 0x3b, 0x08, 0x5c, 0x09, 0xba, 0x40, 0xa9, 0x59,
-	0x14, 0x40, 0x7f, 0x65, 0xb0, 0x70, 0xa5, 0x44, 0x83, 0xb2, 0xa4, 0x72, 0xac, 0xe4, 0xf9, 0x9f,
-	0x07, 0xfe, 0x27, 0x66, 0x0b, 0x94, 0xa4, 0x80, 0x8e, 0xb5, 0x92, 0x59, 0x7c, 0x46, 0xdf, 0x71,
-	0xb3, 0xeb, 0xd1, 0xfc, 0x12, 0x8b, 0x4b, 0x76, 0x45, 0x04, 0xb4, 0xcb, 0x30, 0xe4, 0xe5, 0x4c,
-	0x77, 0xfd, 0x8d, 0xd1, 0xec, 0x02, 0xc7, 0xe9, 0xdc, 0x5b, 0xf7, 0xbb, 0x63, 0xf7, 0x6b, 0xdf,
-	0x3e, 0xaf, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd8, 0xb4, 0x87, 0xfc, 0x7a, 0x02, 0x00, 0x00,
+	// 470 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0x41, 0x8f, 0xd3, 0x3e,
+	0x10, 0xc5, 0xff, 0xdd, 0xb6, 0x49, 0x33, 0xdd, 0xee, 0xbf, 0xb2, 0x10, 0x58, 0xe5, 0x40, 0x55,
+	0x0e, 0xf4, 0x80, 0xb2, 0x6c, 0xb9, 0x00, 0x12, 0x97, 0x15, 0x70, 0xea, 0x72, 0x48, 0xc5, 0x85,
+	0x4b, 0xe4, 0x36, 0x4e, 0x6a, 0xd5, 0xc9, 0x04, 0xdb, 0x45, 0x9b, 0xfd, 0xc6, 0x1c, 0xf9, 0x06,
+	0x28, 0x8e, 0x13, 0xf5, 0xd8, 0x9e, 0x32, 0x2f, 0xfe, 0x8d, 0xe7, 0x79, 0x1e, 0xcc, 0x77, 0x52,
+	0xf0, 0xc2, 0xdc, 0x4a, 0xcc, 0x72, 0x2c, 0x6e, 0x4b, 0x85, 0x06, 0x9d, 0x08, 0xad, 0x20, 0xaf,
+	0xf7, 0x4c, 0xef, 0xc5, 0x0e, 0x55, 0x19, 0x16, 0x98, 0xb3, 0x24, 0x6c, 0x3a, 0xc2, 0x53, 0x68,
+	0xf1, 0xb7, 0x0f, 0xd7, 0x1b, 0xc3, 0x94, 0x89, 0xf8, 0xaf, 0x23, 0xd7, 0x86, 0xbc, 0x00, 0x5f,
+	0x62, 0x16, 0x27, 0x42, 0xd1, 0xde, 0xbc, 0xb7, 0x0c, 0x22, 0x4f, 0x62, 0xf6, 0x45, 0x28, 0xb2,
+	0x84, 0xa9, 0x36, 0x09, 0x1e, 0x4d, 0x9c, 0x0a, 0xc9, 0xe3, 0x82, 0xe5, 0x9c, 0x5e, 0x59, 0xe2,
+	0xa6, 0xf9, 0xff, 0x4d, 0x48, 0xfe, 0x9d, 0xe5, 0xdc, 0x91, 0x5c, 0xa9, 0x13, 0xb2, 0xdf, 0x91,
+	0x5c, 0xa9, 0x8e, 0x7c, 0x09, 0x41, 0xce, 0x1e, 0x2d, 0xa6, 0xe9, 0x60, 0xde, 0x5b, 0x4e, 0xa2,
+	0x51, 0xce, 0x1e, 0xeb, 0x73, 0x4d, 0xde, 0xc0, 0xb4, 0x3d, 0x8c, 0xb5, 0x78, 0xe2, 0x71, 0xbe,
+	0xa5, 0x43, 0xcb, 0x4c, 0x1c, 0xb3, 0x11, 0x4f, 0xfc, 0x61, 0x4b, 0x5e, 0xc1, 0xb8, 0x73, 0x96,
+	0x22, 0xf5, 0xec, 0x28, 0x68, 0x4d, 0xa5, 0xe8, 0x80, 0xc6, 0x50, 0x8a, 0xd4, 0xef, 0x00, 0xeb,
+	0x25, 0x45, 0x72, 0x0f, 0x43, 0x2d, 0x8a, 0x83, 0xa6, 0xa3, 0x79, 0x7f, 0x39, 0x5e, 0xbd, 0x0d,
+	0xcf, 0x58, 0x5d, 0xb8, 0xc6, 0x6c, 0x23, 0x8a, 0x43, 0xd4, 0xb4, 0x92, 0x1f, 0xe0, 0x49, 0xb6,
+	0xe5, 0x52, 0xd3, 0xc0, 0x5e, 0xf2, 0xf9, 0xac, 0x4b, 0x4e, 0x77, 0x1f, 0xae, 0x6d, 0xff, 0xd7,
+	0xc2, 0xa8, 0x2a, 0x72, 0x97, 0x91, 0xe7, 0xe0, 0xa5, 0xa8, 0x72, 0x66, 0x28, 0x34, 0x71, 0x34,
+	0x6a, 0xf6, 0x11, 0xc6, 0x27, 0x38, 0x99, 0x42, 0xff, 0xc0, 0x2b, 0x17, 0x59, 0x5d, 0x92, 0x67,
+	0x30, 0xfc, 0xcd, 0xe4, 0xb1, 0x0d, 0xa9, 0x11, 0x9f, 0xae, 0x3e, 0xf4, 0x16, 0xff, 0xc3, 0xc4,
+	0x8d, 0xd5, 0x25, 0x16, 0x9a, 0x2f, 0x26, 0x30, 0xde, 0x18, 0x2c, 0x9d, 0x8d, 0xc5, 0x0d, 0x5c,
+	0x37, 0xd2, 0x1d, 0x57, 0xe0, 0xbb, 0xb7, 0x12, 0x02, 0x03, 0x53, 0x95, 0xdc, 0xcd, 0xb1, 0x35,
+	0xa1, 0xe0, 0xb3, 0x24, 0x51, 0x5c, 0x6b, 0x37, 0xaa, 0x95, 0x35, 0x5d, 0x32, 0xb3, 0x77, 0xe1,
+	0xdb, 0xba, 0x36, 0x6a, 0x58, 0x66, 0xc3, 0x0e, 0xa2, 0xba, 0x24, 0x33, 0x18, 0xa5, 0x6c, 0x27,
+	0xa4, 0x30, 0x95, 0xcd, 0x37, 0x88, 0x3a, 0xbd, 0xfa, 0xd3, 0x03, 0x6f, 0x8d, 0xd9, 0x03, 0x16,
+	0xa4, 0x84, 0xa1, 0x75, 0x4d, 0xee, 0x2e, 0x5e, 0xec, 0x6c, 0x75, 0x49, 0x8b, 0x7b, 0xf5, 0x7f,
+	0x24, 0x87, 0x41, 0xbd, 0x07, 0xf2, 0xee, 0xcc, 0xee, 0x6e, 0x83, 0xb3, 0xbb, 0x0b, 0x3a, 0xda,
+	0x71, 0xf7, 0xfe, 0xcf, 0xa1, 0xfd, 0xbf, 0xf5, 0xec, 0xe7, 0xfd, 0xbf, 0x01, 0x00, 0xe4, 0x09,
+	0xdd, 0x61, 0xe3, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/client/logmon/logsink"
 	"github.com/hashicorp/nomad/client/logmon/proto"
 )
 
@@ -24,6 +25,17 @@ func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*pro
 		MaxFileSizeMB: int(req.MaxFileSizeMb),
 		StdoutFifo:    req.StdoutFifo,
 		StderrFifo:    req.StderrFifo,
+		Labels:        req.Labels,
+		Format:        req.Format,
+	}
+	for _, sink := range req.Sinks {
+		cfg.Sinks = append(cfg.Sinks, &logsink.Config{
+			Type:     sink.Type,
+			Address:  sink.Address,
+			Path:     sink.Path,
+			Tag:      sink.Tag,
+			Facility: sink.Facility,
+		})
 	}
 
 	err := s.impl.Start(cfg)
@@ -332,6 +332,10 @@ func (c *Client) updateNodeFromDevices(devices []*structs.NodeDeviceResource) {
 func (c *Client) updateNodeFromDevicesLocked(devices []*structs.NodeDeviceResource) bool {
 	if !structs.DevicesEquals(c.config.Node.NodeResources.Devices, devices) {
 		c.logger.Debug("new devices detected", "devices", len(devices))
+		unhealthy := newlyUnhealthyDevices(c.config.Node.NodeResources.Devices, devices)
+		if len(unhealthy) > 0 {
+			go c.handleUnhealthyDevices(c.config.UnhealthyDeviceAction, unhealthy)
+		}
 		newConfig := c.config.Copy()
 		newConfig.Node.NodeResources.Devices = devices
 		c.config = newConfig
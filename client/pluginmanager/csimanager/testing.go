@@ -109,6 +109,9 @@ func (m *MockVolumeManager) HasMount(_ context.Context, mountInfo *MountInfo) (b
 }
 
 func (m *MockVolumeManager) ExpandVolume(_ context.Context, volNS, volID, remoteID, allocID string, usageOpts *UsageOptions, capacity *csi.CapacityRange) (int64, error) {
+	if m.CallCounter != nil {
+		m.CallCounter.Inc("ExpandVolume")
+	}
 	m.LastExpandVolumeCall = &MockExpandVolumeCall{
 		volNS, volID, remoteID, allocID, usageOpts, capacity,
 	}
@@ -0,0 +1,530 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package servicedns implements the DNS server of the client, which answers
+// queries for the services registered with Nomad service discovery.
+//
+// Services are looked up by the name <service>.<namespace>.<zone>, or
+// <service>.<zone> for services in the default namespace. A and AAAA queries
+// are answered with the addresses of the service registrations, and SRV
+// queries with their ports and weights, with targets of the form
+// <hex address>.addr.<zone>.
+package servicedns
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/miekg/dns"
+)
+
+const (
+	// lookupTimeout is how long a query waits for the registrations of a
+	// service which are not cached yet.
+	lookupTimeout = 2 * time.Second
+
+	// cacheIdleTimeout is how long the registrations of a service are kept up
+	// to date after the last query for the service.
+	cacheIdleTimeout = 5 * time.Minute
+
+	// watchWait is the maximum time of the blocking queries for the
+	// registrations of a cached service.
+	watchWait = 1 * time.Minute
+
+	// watchRetryBase and watchRetryLimit bound the backoff between failed
+	// blocking queries.
+	watchRetryBase  = 1 * time.Second
+	watchRetryLimit = 30 * time.Second
+
+	// negativeCacheTTL is how long services without registrations, or which
+	// the client isn't allowed to read, are cached without being watched.
+	negativeCacheTTL = 10 * time.Second
+
+	// maxCachedServices is the number of services in the cache, beyond which
+	// the least recently queried services are evicted.
+	maxCachedServices = 1024
+
+	// addrLabel is the label of the zone for the targets of SRV records.
+	addrLabel = "addr"
+)
+
+var errLookupTimeout = errors.New("timed out looking up service")
+
+// RPCFn is the RPC function used to query the service registrations.
+type RPCFn func(method string, args, resp any) error
+
+// Server is the DNS server of the client.
+type Server struct {
+	logger hclog.Logger
+	cfg    *config.ServiceDNSConfig
+
+	rpc        RPCFn
+	region     string
+	nodeSecret string
+
+	cache     map[serviceKey]*cacheEntry
+	cacheLock sync.Mutex
+
+	udp *dns.Server
+	tcp *dns.Server
+
+	shutdownCh   chan struct{}
+	shutdownOnce sync.Once
+}
+
+// serviceKey identifies a service in the cache.
+type serviceKey struct {
+	namespace string
+	name      string
+}
+
+// cacheEntry holds the registrations of a service, kept up to date with
+// blocking queries while the service is being queried. Services without
+// registrations are cached as negative answers until they expire, without
+// being watched.
+type cacheEntry struct {
+	// ready is closed once the registrations were queried for the first time
+	ready chan struct{}
+
+	// evictCh is closed once the entry is evicted from the cache
+	evictCh chan struct{}
+
+	services []*structs.ServiceRegistration
+	err      error
+	lastUsed time.Time
+
+	// negativeExpiry is when the negative answer of the entry expires, or
+	// zero if the entry is watched
+	negativeExpiry time.Time
+}
+
+// NewServer returns a DNS server. Call Start to listen for queries.
+func NewServer(logger hclog.Logger, cfg *config.ServiceDNSConfig, rpc RPCFn, region, nodeSecret string) *Server {
+	return &Server{
+		logger:     logger.Named("service_dns"),
+		cfg:        cfg,
+		rpc:        rpc,
+		region:     region,
+		nodeSecret: nodeSecret,
+		cache:      make(map[serviceKey]*cacheEntry),
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+// Start listens on the configured address over UDP and TCP. If the address
+// has port 0, TCP listens on the same port that was picked for UDP.
+func (s *Server) Start() error {
+	packetConn, err := net.ListenPacket("udp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", packetConn.LocalAddr().String())
+	if err != nil {
+		packetConn.Close()
+		return err
+	}
+
+	mux := dns.NewServeMux()
+	mux.HandleFunc(s.cfg.Zone, s.handleQuery)
+	mux.HandleFunc(".", s.handleRefused)
+
+	s.udp = &dns.Server{PacketConn: packetConn, Handler: mux}
+	s.tcp = &dns.Server{Listener: listener, Handler: mux}
+
+	for _, server := range []*dns.Server{s.udp, s.tcp} {
+		go func(server *dns.Server) {
+			if err := server.ActivateAndServe(); err != nil {
+				select {
+				case <-s.shutdownCh:
+				default:
+					s.logger.Error("DNS server failed", "error", err)
+				}
+			}
+		}(server)
+	}
+
+	s.logger.Info("started DNS server", "address", packetConn.LocalAddr().String(), "zone", s.cfg.Zone)
+	return nil
+}
+
+// Addr returns the UDP address the server listens on.
+func (s *Server) Addr() string {
+	return s.udp.PacketConn.LocalAddr().String()
+}
+
+// Shutdown stops the server and the blocking queries of the cache.
+func (s *Server) Shutdown() {
+	s.shutdownOnce.Do(func() {
+		close(s.shutdownCh)
+		if s.udp != nil {
+			_ = s.udp.Shutdown()
+		}
+		if s.tcp != nil {
+			_ = s.tcp.Shutdown()
+		}
+	})
+}
+
+// handleRefused refuses queries outside of the zone, as the server is not a
+// recursive resolver.
+func (s *Server) handleRefused(w dns.ResponseWriter, req *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetRcode(req, dns.RcodeRefused)
+	_ = w.WriteMsg(m)
+}
+
+func (s *Server) handleQuery(w dns.ResponseWriter, req *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(req)
+	m.Authoritative = true
+
+	if len(req.Question) != 1 {
+		m.SetRcode(req, dns.RcodeFormatError)
+		_ = w.WriteMsg(m)
+		return
+	}
+	q := req.Question[0]
+
+	if err := s.answer(m, q); err != nil {
+		s.logger.Warn("failed to answer DNS query", "name", q.Name, "error", err)
+		m.SetRcode(req, dns.RcodeServerFailure)
+	}
+	if m.Rcode == dns.RcodeNameError || (m.Rcode == dns.RcodeSuccess && len(m.Answer) == 0) {
+		m.Ns = []dns.RR{s.soa()}
+	}
+
+	// Truncate UDP responses to the size the client advertised
+	size := dns.MinMsgSize
+	if opt := req.IsEdns0(); opt != nil {
+		size = int(opt.UDPSize())
+	}
+	if _, ok := w.RemoteAddr().(*net.TCPAddr); ok {
+		size = dns.MaxMsgSize
+	}
+	m.Truncate(size)
+
+	_ = w.WriteMsg(m)
+}
+
+// answer adds the records for the question to the response.
+func (s *Server) answer(m *dns.Msg, q dns.Question) error {
+	name := strings.ToLower(q.Name)
+	labels := dns.SplitDomainName(strings.TrimSuffix(name, s.cfg.Zone))
+
+	switch {
+	case len(labels) == 0:
+		// the zone apex only has the SOA record
+		if q.Qtype == dns.TypeSOA {
+			m.Answer = append(m.Answer, s.soa())
+		}
+		return nil
+
+	case len(labels) == 2 && labels[1] == addrLabel:
+		ip := decodeAddr(labels[0])
+		if ip == nil {
+			m.Rcode = dns.RcodeNameError
+			return nil
+		}
+		if rr := s.addressRecord(q.Name, q.Qtype, ip); rr != nil {
+			m.Answer = append(m.Answer, rr)
+		}
+		return nil
+
+	case len(labels) > 2:
+		m.Rcode = dns.RcodeNameError
+		return nil
+	}
+
+	key := serviceKey{namespace: structs.DefaultNamespace, name: labels[0]}
+	if len(labels) == 2 {
+		key.namespace = labels[1]
+	}
+
+	services, err := s.lookup(key)
+	if err != nil {
+		return err
+	}
+	if len(services) == 0 {
+		m.Rcode = dns.RcodeNameError
+		return nil
+	}
+
+	// shuffle the registrations to spread the load across them
+	services = append([]*structs.ServiceRegistration(nil), services...)
+	rand.Shuffle(len(services), func(i, j int) {
+		services[i], services[j] = services[j], services[i]
+	})
+
+	switch q.Qtype {
+	case dns.TypeA, dns.TypeAAAA, dns.TypeANY:
+		seen := make(map[string]struct{}, len(services))
+		for _, service := range services {
+			for _, ip := range serviceIPs(service) {
+				if _, ok := seen[ip.String()]; ok {
+					continue
+				}
+				seen[ip.String()] = struct{}{}
+				if rr := s.addressRecord(q.Name, q.Qtype, ip); rr != nil {
+					m.Answer = append(m.Answer, rr)
+				}
+			}
+		}
+
+	case dns.TypeSRV:
+		for _, service := range services {
+			ips := serviceIPs(service)
+			if len(ips) == 0 {
+				continue
+			}
+			target := hexAddr(ips[0]) + "." + addrLabel + "." + s.cfg.Zone
+			m.Answer = append(m.Answer, &dns.SRV{
+				Hdr:      s.header(q.Name, dns.TypeSRV),
+				Priority: 1,
+				Weight:   srvWeight(service.Weight),
+				Port:     uint16(service.Port),
+				Target:   target,
+			})
+			if rr := s.addressRecord(target, dns.TypeANY, ips[0]); rr != nil {
+				m.Extra = append(m.Extra, rr)
+			}
+		}
+	}
+	return nil
+}
+
+// addressRecord returns the A or AAAA record of the IP address, or nil if
+// the address doesn't match the query type.
+func (s *Server) addressRecord(name string, qtype uint16, ip net.IP) dns.RR {
+	if ip4 := ip.To4(); ip4 != nil {
+		if qtype != dns.TypeA && qtype != dns.TypeANY {
+			return nil
+		}
+		return &dns.A{Hdr: s.header(name, dns.TypeA), A: ip4}
+	}
+	if qtype != dns.TypeAAAA && qtype != dns.TypeANY {
+		return nil
+	}
+	return &dns.AAAA{Hdr: s.header(name, dns.TypeAAAA), AAAA: ip}
+}
+
+func (s *Server) header(name string, rrtype uint16) dns.RR_Header {
+	return dns.RR_Header{
+		Name:   name,
+		Rrtype: rrtype,
+		Class:  dns.ClassINET,
+		Ttl:    uint32(s.cfg.TTL / time.Second),
+	}
+}
+
+func (s *Server) soa() dns.RR {
+	return &dns.SOA{
+		Hdr:     s.header(s.cfg.Zone, dns.TypeSOA),
+		Ns:      "ns." + s.cfg.Zone,
+		Mbox:    "hostmaster." + s.cfg.Zone,
+		Serial:  uint32(time.Now().Unix()),
+		Refresh: 3600,
+		Retry:   600,
+		Expire:  86400,
+		Minttl:  uint32(s.cfg.TTL / time.Second),
+	}
+}
+
+// lookup returns the registrations of a service from the cache. Services that
+// are not cached yet are queried, and kept up to date until they haven't
+// been queried for a while.
+func (s *Server) lookup(key serviceKey) ([]*structs.ServiceRegistration, error) {
+	s.cacheLock.Lock()
+	now := time.Now()
+	entry, ok := s.cache[key]
+	if ok && !entry.negativeExpiry.IsZero() && now.After(entry.negativeExpiry) {
+		s.evictLocked(key)
+		ok = false
+	}
+	if !ok {
+		if len(s.cache) >= maxCachedServices {
+			s.evictLRULocked()
+		}
+		entry = &cacheEntry{ready: make(chan struct{}), evictCh: make(chan struct{})}
+		s.cache[key] = entry
+		go s.watch(key, entry)
+	}
+	entry.lastUsed = now
+	s.cacheLock.Unlock()
+
+	timer, stop := helper.NewSafeTimer(lookupTimeout)
+	defer stop()
+
+	select {
+	case <-entry.ready:
+	case <-timer.C:
+		return nil, errLookupTimeout
+	case <-s.shutdownCh:
+		return nil, errLookupTimeout
+	}
+
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+	return entry.services, entry.err
+}
+
+// evictLocked removes the service from the cache, which stops its watch. The
+// cache lock must be held.
+func (s *Server) evictLocked(key serviceKey) {
+	if entry, ok := s.cache[key]; ok {
+		close(entry.evictCh)
+		delete(s.cache, key)
+	}
+}
+
+// evictLRULocked removes the least recently queried service from the cache.
+// The cache lock must be held.
+func (s *Server) evictLRULocked() {
+	var lruKey serviceKey
+	var lru *cacheEntry
+	for key, entry := range s.cache {
+		if lru == nil || entry.lastUsed.Before(lru.lastUsed) {
+			lruKey, lru = key, entry
+		}
+	}
+	if lru != nil {
+		s.evictLocked(lruKey)
+	}
+}
+
+// watch keeps the registrations of a cached service up to date with blocking
+// queries, until the service is no longer being queried or is evicted.
+// Services without registrations are not watched.
+func (s *Server) watch(key serviceKey, entry *cacheEntry) {
+	var index uint64
+	var attempt uint64
+
+	for {
+		args := &structs.ServiceRegistrationByNameRequest{
+			ServiceName: key.name,
+			QueryOptions: structs.QueryOptions{
+				Region:        s.region,
+				Namespace:     key.namespace,
+				AuthToken:     s.nodeSecret,
+				AllowStale:    true,
+				MinQueryIndex: index,
+				MaxQueryTime:  watchWait,
+			},
+		}
+		var reply structs.ServiceRegistrationByNameResponse
+		err := s.rpc(structs.ServiceRegistrationGetServiceRPCMethod, args, &reply)
+
+		// Services in namespaces the client isn't allowed to read are
+		// answered as if they had no registrations.
+		if err != nil && structs.IsErrPermissionDenied(err) {
+			reply.Services, err = nil, nil
+		}
+
+		s.cacheLock.Lock()
+		if index == 0 {
+			entry.services, entry.err = reply.Services, err
+			close(entry.ready)
+		} else if err == nil {
+			entry.services = reply.Services
+		}
+
+		select {
+		case <-entry.evictCh:
+			s.cacheLock.Unlock()
+			return
+		default:
+		}
+
+		// Cache negative answers without watching them.
+		if index == 0 && err == nil && len(reply.Services) == 0 {
+			entry.negativeExpiry = time.Now().Add(negativeCacheTTL)
+			s.cacheLock.Unlock()
+			return
+		}
+
+		// Stop watching services that are no longer queried, or that failed
+		// to be queried at all so that the next query retries.
+		idle := time.Since(entry.lastUsed) > cacheIdleTimeout
+		if idle || (index == 0 && err != nil) {
+			s.evictLocked(key)
+			s.cacheLock.Unlock()
+			return
+		}
+		s.cacheLock.Unlock()
+
+		if err != nil {
+			s.logger.Warn("failed to query service registrations", "service", key.name,
+				"namespace", key.namespace, "error", err)
+			timer, stop := helper.NewSafeTimer(helper.Backoff(watchRetryBase, watchRetryLimit, attempt))
+			select {
+			case <-timer.C:
+			case <-entry.evictCh:
+			case <-s.shutdownCh:
+			}
+			stop()
+			attempt++
+		} else {
+			attempt = 0
+			index = max(reply.Index, 1)
+		}
+
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-entry.evictCh:
+			return
+		default:
+		}
+	}
+}
+
+// serviceIPs returns the IP addresses of a service registration. Addresses
+// which are not IP addresses can't be served and are skipped.
+func serviceIPs(service *structs.ServiceRegistration) []net.IP {
+	var ips []net.IP
+	for _, addr := range []string{service.Address, service.AddressIPv6} {
+		if ip := net.ParseIP(addr); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// hexAddr encodes the IP address for the target of an SRV record, using the
+// 4 byte form of IPv4 addresses and the 16 byte form of IPv6 addresses.
+func hexAddr(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return hex.EncodeToString(ip4)
+	}
+	return hex.EncodeToString(ip.To16())
+}
+
+// decodeAddr decodes the IP address of the target of an SRV record.
+func decodeAddr(label string) net.IP {
+	b, err := hex.DecodeString(label)
+	if err != nil || (len(b) != net.IPv4len && len(b) != net.IPv6len) {
+		return nil
+	}
+	return net.IP(b)
+}
+
+// srvWeight converts the weight of a service registration to the weight of
+// an SRV record. Registrations without a weight have a weight of 1.
+func srvWeight(weight int) uint16 {
+	switch {
+	case weight <= 0:
+		return 1
+	case weight > 65535:
+		return 65535
+	default:
+		return uint16(weight)
+	}
+}
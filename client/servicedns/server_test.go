@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package servicedns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/miekg/dns"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// mockRPC serves a fixed set of service registrations.
+type mockRPC struct {
+	services map[serviceKey][]*structs.ServiceRegistration
+	calls    atomic.Int32
+	watches  atomic.Int32
+
+	err     error
+	errLock sync.Mutex
+}
+
+func (m *mockRPC) setErr(err error) {
+	m.errLock.Lock()
+	defer m.errLock.Unlock()
+	m.err = err
+}
+
+func (m *mockRPC) RPC(method string, args, reply any) error {
+	if method != structs.ServiceRegistrationGetServiceRPCMethod {
+		return errors.New("unexpected method " + method)
+	}
+	req := args.(*structs.ServiceRegistrationByNameRequest)
+	if req.MinQueryIndex > 0 {
+		// there are no changes to block on
+		m.watches.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return errors.New("no changes")
+	}
+	m.calls.Add(1)
+	m.errLock.Lock()
+	err := m.err
+	m.errLock.Unlock()
+	if err != nil {
+		return err
+	}
+	resp := reply.(*structs.ServiceRegistrationByNameResponse)
+	resp.Services = m.services[serviceKey{namespace: req.Namespace, name: req.ServiceName}]
+	resp.Index = 10
+	return nil
+}
+
+func testServer(t *testing.T, rpc *mockRPC) *Server {
+	cfg := &config.ServiceDNSConfig{
+		Addr: "127.0.0.1:0",
+		Zone: "nomad.",
+		TTL:  5 * time.Second,
+	}
+	s := NewServer(testlog.HCLogger(t), cfg, rpc.RPC, "global", "secret")
+	must.NoError(t, s.Start())
+	t.Cleanup(s.Shutdown)
+	return s
+}
+
+func query(t *testing.T, s *Server, name string, qtype uint16) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetQuestion(name, qtype)
+	resp, _, err := new(dns.Client).Exchange(m, s.Addr())
+	must.NoError(t, err)
+	return resp
+}
+
+func TestServer_Query(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := &mockRPC{services: map[serviceKey][]*structs.ServiceRegistration{
+		{namespace: "default", name: "web"}: {
+			{ServiceName: "web", Address: "10.0.0.1", Port: 8080, Weight: 3},
+			{ServiceName: "web", Address: "10.0.0.2", AddressIPv6: "fd00::2", Port: 8081},
+		},
+		{namespace: "platform", name: "db"}: {
+			{ServiceName: "db", Address: "10.0.1.1", Port: 5432},
+		},
+	}}
+	s := testServer(t, rpc)
+
+	t.Run("A", func(t *testing.T) {
+		resp := query(t, s, "web.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.True(t, resp.Authoritative)
+		must.Len(t, 2, resp.Answer)
+		var ips []string
+		for _, rr := range resp.Answer {
+			a := rr.(*dns.A)
+			must.Eq(t, 5, a.Hdr.Ttl)
+			ips = append(ips, a.A.String())
+		}
+		must.SliceContainsAll(t, []string{"10.0.0.1", "10.0.0.2"}, ips)
+	})
+
+	t.Run("AAAA", func(t *testing.T) {
+		resp := query(t, s, "web.nomad.", dns.TypeAAAA)
+		must.Len(t, 1, resp.Answer)
+		must.Eq(t, "fd00::2", resp.Answer[0].(*dns.AAAA).AAAA.String())
+	})
+
+	t.Run("SRV", func(t *testing.T) {
+		resp := query(t, s, "web.nomad.", dns.TypeSRV)
+		must.Len(t, 2, resp.Answer)
+		must.Len(t, 2, resp.Extra)
+		weights := map[uint16]uint16{}
+		for _, rr := range resp.Answer {
+			srv := rr.(*dns.SRV)
+			weights[srv.Port] = srv.Weight
+		}
+		must.Eq(t, map[uint16]uint16{8080: 3, 8081: 1}, weights)
+
+		// the targets resolve to the address of the registration
+		for _, rr := range resp.Answer {
+			srv := rr.(*dns.SRV)
+			target := query(t, s, srv.Target, dns.TypeA)
+			must.Len(t, 1, target.Answer)
+			if srv.Port == 8080 {
+				must.Eq(t, "10.0.0.1", target.Answer[0].(*dns.A).A.String())
+			}
+		}
+	})
+
+	t.Run("namespace", func(t *testing.T) {
+		resp := query(t, s, "DB.Platform.nomad.", dns.TypeA)
+		must.Len(t, 1, resp.Answer)
+		must.Eq(t, "10.0.1.1", resp.Answer[0].(*dns.A).A.String())
+
+		resp = query(t, s, "db.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		resp := query(t, s, "nope.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+		must.Len(t, 1, resp.Ns)
+		must.Eq(t, dns.TypeSOA, resp.Ns[0].Header().Rrtype)
+	})
+
+	t.Run("outside zone", func(t *testing.T) {
+		resp := query(t, s, "example.com.", dns.TypeA)
+		must.Eq(t, dns.RcodeRefused, resp.Rcode)
+	})
+}
+
+func TestServer_Cache(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := &mockRPC{services: map[serviceKey][]*structs.ServiceRegistration{
+		{namespace: "default", name: "web"}: {
+			{ServiceName: "web", Address: "10.0.0.1", Port: 8080},
+		},
+	}}
+	s := testServer(t, rpc)
+
+	// repeated queries are answered from the cache
+	for range 3 {
+		resp := query(t, s, "web.nomad.", dns.TypeA)
+		must.Len(t, 1, resp.Answer)
+	}
+	must.Eq(t, 1, rpc.calls.Load())
+}
+
+func TestServer_QueryError(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := &mockRPC{}
+	rpc.setErr(errors.New("no servers"))
+	s := testServer(t, rpc)
+
+	resp := query(t, s, "web.nomad.", dns.TypeA)
+	must.Eq(t, dns.RcodeServerFailure, resp.Rcode)
+
+	// failed lookups are not cached
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			s.cacheLock.Lock()
+			defer s.cacheLock.Unlock()
+			return len(s.cache) == 0
+		}),
+		wait.Timeout(2*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	rpc.setErr(nil)
+	resp = query(t, s, "web.nomad.", dns.TypeA)
+	must.Eq(t, dns.RcodeNameError, resp.Rcode)
+}
+
+func TestServer_NegativeCache(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := &mockRPC{}
+	s := testServer(t, rpc)
+
+	// services without registrations are cached without being watched
+	for range 3 {
+		resp := query(t, s, "nope.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+	}
+	must.Eq(t, 1, rpc.calls.Load())
+	time.Sleep(50 * time.Millisecond)
+	must.Eq(t, 0, rpc.watches.Load())
+
+	// services the client isn't allowed to read have no registrations
+	rpc.setErr(structs.ErrPermissionDenied)
+	resp := query(t, s, "web.other.nomad.", dns.TypeA)
+	must.Eq(t, dns.RcodeNameError, resp.Rcode)
+
+	// negative answers expire
+	s.cacheLock.Lock()
+	s.cache[serviceKey{namespace: "default", name: "nope"}].negativeExpiry = time.Now().Add(-time.Second)
+	s.cacheLock.Unlock()
+	rpc.setErr(nil)
+	query(t, s, "nope.nomad.", dns.TypeA)
+	must.Eq(t, 3, rpc.calls.Load())
+}
+
+func TestServer_CacheEviction(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := &mockRPC{services: map[serviceKey][]*structs.ServiceRegistration{}}
+	for i := range maxCachedServices + 1 {
+		name := fmt.Sprintf("web%d", i)
+		rpc.services[serviceKey{namespace: "default", name: name}] = []*structs.ServiceRegistration{
+			{ServiceName: name, Address: "10.0.0.1", Port: 8080},
+		}
+	}
+	s := testServer(t, rpc)
+
+	for i := range maxCachedServices + 1 {
+		_, err := s.lookup(serviceKey{namespace: "default", name: fmt.Sprintf("web%d", i)})
+		must.NoError(t, err)
+	}
+
+	// the least recently queried service is evicted
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+	must.MapLen(t, maxCachedServices, s.cache)
+	must.MapNotContainsKey(t, s.cache, serviceKey{namespace: "default", name: "web0"})
+	must.MapContainsKey(t, s.cache, serviceKey{namespace: "default", name: "web1"})
+}
+
+func TestServer_decodeAddr(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, ip := range []string{"10.0.0.1", "fd00::1"} {
+		parsed := net.ParseIP(ip)
+		must.Eq(t, ip, decodeAddr(hexAddr(parsed)).String())
+	}
+	must.Nil(t, decodeAddr("zz"))
+	must.Nil(t, decodeAddr("0a00"))
+}
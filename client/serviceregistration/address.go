@@ -11,6 +11,19 @@ import (
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
+// GetDualStackAddresses returns the IPv4 and IPv6 addresses of the allocation
+// network for services in the alloc address mode, if the network has an
+// address of each family. Otherwise both addresses are empty.
+func GetDualStackAddresses(addressMode string, netStatus *structs.AllocNetworkStatus) (string, string) {
+	if addressMode != structs.AddressModeAlloc || netStatus == nil {
+		return "", ""
+	}
+	if netStatus.Address == "" || netStatus.AddressIPv6 == "" || netStatus.Address == netStatus.AddressIPv6 {
+		return "", ""
+	}
+	return netStatus.Address, netStatus.AddressIPv6
+}
+
 // GetAddress returns the IP (or custom advertise address) and port to use for a
 // service or check registration. If no port label is specified (an empty value)
 // and no custom address is specified, zero values are returned because no address
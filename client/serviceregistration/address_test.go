@@ -426,3 +426,32 @@ func Test_GetAddress(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetDualStackAddresses(t *testing.T) {
+	dualStack := &structs.AllocNetworkStatus{
+		InterfaceName: "eth0",
+		Address:       "172.26.64.10",
+		AddressIPv6:   "fd00::10",
+	}
+	ipv6Only := &structs.AllocNetworkStatus{
+		InterfaceName: "eth0",
+		Address:       "fd00::10",
+		AddressIPv6:   "fd00::10",
+	}
+
+	ipv4, ipv6 := GetDualStackAddresses(structs.AddressModeAlloc, dualStack)
+	require.Equal(t, "172.26.64.10", ipv4)
+	require.Equal(t, "fd00::10", ipv6)
+
+	ipv4, ipv6 = GetDualStackAddresses(structs.AddressModeHost, dualStack)
+	require.Empty(t, ipv4)
+	require.Empty(t, ipv6)
+
+	ipv4, ipv6 = GetDualStackAddresses(structs.AddressModeAlloc, ipv6Only)
+	require.Empty(t, ipv4)
+	require.Empty(t, ipv6)
+
+	ipv4, ipv6 = GetDualStackAddresses(structs.AddressModeAlloc, nil)
+	require.Empty(t, ipv4)
+	require.Empty(t, ipv6)
+}
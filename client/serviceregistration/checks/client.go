@@ -21,6 +21,10 @@ import (
 	"github.com/hashicorp/nomad/client/serviceregistration"
 	"github.com/hashicorp/nomad/helper/useragent"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"oss.indeed.com/go/libtime"
 )
 
@@ -36,7 +40,7 @@ type Checker interface {
 	Do(context.Context, *QueryContext, *Query) *structs.CheckQueryResult
 }
 
-// New creates a new Checker capable of executing HTTP and TCP checks.
+// New creates a new Checker capable of executing HTTP, TCP, and gRPC checks.
 func New(log hclog.Logger) Checker {
 	httpClient := cleanhttp.DefaultPooledClient()
 	httpClient.Timeout = maxTimeoutHTTP
@@ -67,6 +71,8 @@ func (c *checker) Do(ctx context.Context, qc *QueryContext, q *Query) *structs.C
 	switch q.Type {
 	case "http":
 		qr = c.checkHTTP(timeout, qc, q)
+	case "grpc":
+		qr = c.checkGRPC(timeout, qc, q)
 	default:
 		qr = c.checkTCP(timeout, qc, q)
 	}
@@ -131,11 +137,26 @@ func (c *checker) checkTCP(ctx context.Context, qc *QueryContext, q *Query) *str
 		return qr
 	}
 
-	if _, err = new(net.Dialer).DialContext(ctx, "tcp", addr); err != nil {
+	var dialer interface {
+		DialContext(context.Context, string, string) (net.Conn, error)
+	} = new(net.Dialer)
+	if q.UseTLS {
+		tlsConfig, err := q.tlsConfig()
+		if err != nil {
+			qr.Output = fmt.Sprintf("nomad: %s", err.Error())
+			qr.Status = structs.CheckFailure
+			return qr
+		}
+		dialer = &tls.Dialer{Config: tlsConfig}
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
 		qr.Output = err.Error()
 		qr.Status = structs.CheckFailure
 		return qr
 	}
+	_ = conn.Close()
 
 	qr.Output = "nomad: tcp ok"
 	qr.Status = structs.CheckSuccess
@@ -190,17 +211,24 @@ func (c *checker) checkHTTP(ctx context.Context, qc *QueryContext, q *Query) *st
 	request.Body = io.NopCloser(strings.NewReader(q.Body))
 	request = request.WithContext(ctx)
 
-	// Leave this setup until the last as it doesn't generate an error. If the
-	// check has specified TLS skip verify, generate a new round tripper. This
-	// job specification "check.tls_skip_verify" parameter supports in-place
-	// updates, so we must do this on each check iteration.
-	if q.TLSSkipVerify {
+	// If the check has specified TLS parameters, generate a new client with
+	// its own round tripper. The job specification "check" TLS parameters
+	// support in-place updates, so we must do this on each check iteration.
+	httpClient := c.httpClient
+	if q.TLSSkipVerify || q.TLSServerName != "" || q.TLSCACert != "" {
+		tlsConfig, err := q.tlsConfig()
+		if err != nil {
+			qr.Output = fmt.Sprintf("nomad: %s", err.Error())
+			qr.Status = structs.CheckFailure
+			return qr
+		}
 		trans := cleanhttp.DefaultPooledTransport()
-		trans.TLSClientConfig = &tls.Config{InsecureSkipVerify: q.TLSSkipVerify}
-		c.httpClient.Transport = trans
+		trans.TLSClientConfig = tlsConfig
+		defer trans.CloseIdleConnections()
+		httpClient = &http.Client{Transport: trans, Timeout: maxTimeoutHTTP}
 	}
 
-	result, err := c.httpClient.Do(request)
+	result, err := httpClient.Do(request)
 	if err != nil {
 		qr.Output = fmt.Sprintf("nomad: %s", err.Error())
 		qr.Status = structs.CheckFailure
@@ -234,6 +262,66 @@ func (c *checker) checkHTTP(ctx context.Context, qc *QueryContext, q *Query) *st
 	return qr
 }
 
+func (c *checker) checkGRPC(ctx context.Context, qc *QueryContext, q *Query) *structs.CheckQueryResult {
+	qr := &structs.CheckQueryResult{
+		Mode:      q.Mode,
+		Timestamp: c.now(),
+		Status:    structs.CheckPending,
+	}
+
+	addr, err := address(qc, q)
+	if err != nil {
+		qr.Output = err.Error()
+		qr.Status = structs.CheckFailure
+		return qr
+	}
+
+	creds := insecure.NewCredentials()
+	if q.UseTLS {
+		tlsConfig, err := q.tlsConfig()
+		if err != nil {
+			qr.Output = fmt.Sprintf("nomad: %s", err.Error())
+			qr.Status = structs.CheckFailure
+			return qr
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	// use the passthrough resolver since the address is already resolved
+	conn, err := grpc.NewClient("passthrough:///"+addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUserAgent(useragent.String()),
+	)
+	if err != nil {
+		qr.Output = fmt.Sprintf("nomad: %s", err.Error())
+		qr.Status = structs.CheckFailure
+		return qr
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// the grpc.health.v1 protocol reports the serving status of the service,
+	// or of the whole server if the service is empty
+	result, err := healthpb.NewHealthClient(conn).Check(ctx,
+		&healthpb.HealthCheckRequest{Service: q.GRPCService})
+	if err != nil {
+		qr.Output = fmt.Sprintf("nomad: %s", err.Error())
+		qr.Status = structs.CheckFailure
+		return qr
+	}
+
+	if status := result.GetStatus(); status != healthpb.HealthCheckResponse_SERVING {
+		qr.Output = fmt.Sprintf("nomad: grpc status %s", status)
+		qr.Status = structs.CheckFailure
+		return qr
+	}
+
+	qr.Output = "nomad: grpc ok"
+	qr.Status = structs.CheckSuccess
+	return qr
+}
+
 const (
 	// outputSizeLimit is the maximum number of bytes to read and store of an http
 	// check output. Set to 3kb which fits in 1 page with room for other fields.
@@ -5,6 +5,7 @@ package checks
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"maps"
@@ -21,6 +22,10 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"oss.indeed.com/go/libtime/libtimetest"
 )
 
@@ -617,3 +622,157 @@ func tcpServer(t *testing.T, ctx context.Context, port int) {
 		}
 	}()
 }
+
+func TestChecker_Do_TCP_TLS(t *testing.T) {
+	ci.Parallel(t)
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+	addr, port := splitURL(ts.URL)
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}))
+
+	queryContext := &QueryContext{
+		ID:               "abc123",
+		CustomAddress:    addr,
+		ServicePortLabel: port,
+		NetworkStatus:    mock.NewNetworkStatus(addr),
+	}
+
+	testCases := []struct {
+		name   string
+		query  *Query
+		status structs.CheckStatus
+		output string
+	}{
+		{
+			name:   "trusted ca",
+			query:  &Query{TLSCACert: caCert, TLSServerName: "example.com"},
+			status: structs.CheckSuccess,
+			output: "nomad: tcp ok",
+		},
+		{
+			name:   "skip verify",
+			query:  &Query{TLSSkipVerify: true},
+			status: structs.CheckSuccess,
+			output: "nomad: tcp ok",
+		},
+		{
+			name:   "untrusted certificate",
+			query:  &Query{},
+			status: structs.CheckFailure,
+			output: "tls: failed to verify certificate: x509",
+		},
+		{
+			name:   "wrong server name",
+			query:  &Query{TLSCACert: caCert, TLSServerName: "nomad.example"},
+			status: structs.CheckFailure,
+			output: "certificate is valid for example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.query.Mode = structs.Healthiness
+			tc.query.Type = "tcp"
+			tc.query.Timeout = 1 * time.Second
+			tc.query.AddressMode = "auto"
+			tc.query.PortLabel = port
+			tc.query.UseTLS = true
+
+			c := New(testlog.HCLogger(t))
+			result := c.Do(context.Background(), queryContext, tc.query)
+			must.Eq(t, tc.status, result.Status)
+			must.StrContains(t, result.Output, tc.output)
+		})
+	}
+}
+
+func TestChecker_Do_GRPC(t *testing.T) {
+	ci.Parallel(t)
+
+	// serve the grpc server with the certificate of an unstarted httptest
+	// server to get a certificate valid for 127.0.0.1
+	ts := httptest.NewUnstartedServer(nil)
+	ts.StartTLS()
+	ts.Close()
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}))
+
+	startServer := func(t *testing.T, opts ...grpc.ServerOption) (*health.Server, string) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		must.NoError(t, err)
+		srv := grpc.NewServer(opts...)
+		hs := health.NewServer()
+		healthpb.RegisterHealthServer(srv, hs)
+		go func() { _ = srv.Serve(l) }()
+		t.Cleanup(srv.Stop)
+		_, port, _ := net.SplitHostPort(l.Addr().String())
+		return hs, port
+	}
+
+	queryContext := func(port string) *QueryContext {
+		return &QueryContext{
+			ID:               "abc123",
+			CustomAddress:    "127.0.0.1",
+			ServicePortLabel: port,
+			NetworkStatus:    mock.NewNetworkStatus("127.0.0.1"),
+		}
+	}
+	query := func(port, service string) *Query {
+		return &Query{
+			Mode:        structs.Healthiness,
+			Type:        "grpc",
+			Timeout:     1 * time.Second,
+			AddressMode: "auto",
+			PortLabel:   port,
+			GRPCService: service,
+		}
+	}
+
+	t.Run("plaintext", func(t *testing.T) {
+		hs, port := startServer(t)
+		hs.SetServingStatus("api", healthpb.HealthCheckResponse_SERVING)
+		hs.SetServingStatus("db", healthpb.HealthCheckResponse_NOT_SERVING)
+
+		c := New(testlog.HCLogger(t))
+
+		result := c.Do(context.Background(), queryContext(port), query(port, ""))
+		must.Eq(t, structs.CheckSuccess, result.Status)
+		must.Eq(t, "nomad: grpc ok", result.Output)
+
+		result = c.Do(context.Background(), queryContext(port), query(port, "api"))
+		must.Eq(t, structs.CheckSuccess, result.Status)
+
+		result = c.Do(context.Background(), queryContext(port), query(port, "db"))
+		must.Eq(t, structs.CheckFailure, result.Status)
+		must.Eq(t, "nomad: grpc status NOT_SERVING", result.Output)
+
+		result = c.Do(context.Background(), queryContext(port), query(port, "unknown"))
+		must.Eq(t, structs.CheckFailure, result.Status)
+		must.StrContains(t, result.Output, "NotFound")
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		_, port := startServer(t, grpc.Creds(credentials.NewServerTLSFromCert(&ts.TLS.Certificates[0])))
+
+		c := New(testlog.HCLogger(t))
+
+		q := query(port, "")
+		q.UseTLS = true
+		q.TLSCACert = caCert
+		result := c.Do(context.Background(), queryContext(port), q)
+		must.Eq(t, structs.CheckSuccess, result.Status)
+
+		// the server requires tls
+		q = query(port, "")
+		result = c.Do(context.Background(), queryContext(port), q)
+		must.Eq(t, structs.CheckFailure, result.Status)
+	})
+
+	t.Run("not listening", func(t *testing.T) {
+		port := fmt.Sprintf("%d", ci.PortAllocator.One())
+		c := New(testlog.HCLogger(t))
+		result := c.Do(context.Background(), queryContext(port), query(port, ""))
+		must.Eq(t, structs.CheckFailure, result.Status)
+		must.StrContains(t, result.Output, "connection refused")
+	})
+}
@@ -4,6 +4,9 @@
 package checks
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"maps"
 	"net/http"
 	"time"
@@ -17,6 +20,13 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 	if c.Type == "http" && protocol == "" {
 		protocol = "http"
 	}
+	var useTLS bool
+	switch c.Type {
+	case structs.ServiceCheckGRPC:
+		useTLS = c.GRPCUseTLS
+	case structs.ServiceCheckTCP:
+		useTLS = c.TCPUseTLS
+	}
 	return &Query{
 		Mode:          structs.GetCheckMode(c),
 		Type:          c.Type,
@@ -28,7 +38,11 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 		Method:        c.Method,
 		Headers:       maps.Clone(c.Header),
 		Body:          c.Body,
+		GRPCService:   c.GRPCService,
+		UseTLS:        useTLS,
+		TLSServerName: c.TLSServerName,
 		TLSSkipVerify: c.TLSSkipVerify,
+		TLSCACert:     c.TLSCACert,
 	}
 }
 
@@ -36,19 +50,42 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 // amount of information needed to actually execute that check.
 type Query struct {
 	Mode structs.CheckMode // readiness or healthiness
-	Type string            // tcp or http
+	Type string            // tcp, http, or grpc
 
 	Timeout time.Duration // connection / request timeout
 
 	AddressMode string // host, driver, or alloc
 	PortLabel   string // label or value
 
-	Protocol      string      // http checks only (http or https)
-	Path          string      // http checks only
-	Method        string      // http checks only
-	Headers       http.Header // http checks only
-	Body          string      // http checks only
-	TLSSkipVerify bool        // http checks only, https protocol
+	Protocol string      // http checks only (http or https)
+	Path     string      // http checks only
+	Method   string      // http checks only
+	Headers  http.Header // http checks only
+	Body     string      // http checks only
+
+	GRPCService string // grpc checks only
+
+	UseTLS        bool   // tcp and grpc checks only
+	TLSServerName string // https protocol, or UseTLS
+	TLSSkipVerify bool   // https protocol, or UseTLS
+	TLSCACert     string // https protocol, or UseTLS
+}
+
+// tlsConfig returns the TLS configuration used to connect to the check
+// address.
+func (q *Query) tlsConfig() (*tls.Config, error) {
+	conf := &tls.Config{
+		ServerName:         q.TLSServerName,
+		InsecureSkipVerify: q.TLSSkipVerify,
+	}
+	if q.TLSCACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(q.TLSCACert)) {
+			return nil, errors.New("failed to parse tls_ca_cert")
+		}
+		conf.RootCAs = pool
+	}
+	return conf, nil
 }
 
 // A QueryContext contains allocation and service parameters necessary for
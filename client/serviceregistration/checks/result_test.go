@@ -108,6 +108,22 @@ func TestChecks_GetCheckQuery(t *testing.T) {
 	}
 }
 
+func TestChecks_GetCheckQuery_UseTLS(t *testing.T) {
+	grpcCheck := &structs.ServiceCheck{Type: "grpc", GRPCService: "api", GRPCUseTLS: true}
+	query := GetCheckQuery(grpcCheck)
+	must.True(t, query.UseTLS)
+	must.Eq(t, "api", query.GRPCService)
+
+	tcpCheck := &structs.ServiceCheck{Type: "tcp", TCPUseTLS: true, TLSServerName: "db"}
+	query = GetCheckQuery(tcpCheck)
+	must.True(t, query.UseTLS)
+	must.Eq(t, "db", query.TLSServerName)
+
+	// grpc_use_tls only applies to grpc checks
+	tcpCheck = &structs.ServiceCheck{Type: "tcp", GRPCUseTLS: true}
+	must.False(t, GetCheckQuery(tcpCheck).UseTLS)
+}
+
 func TestChecks_Stub(t *testing.T) {
 	now := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC).Unix()
 	result := Stub(
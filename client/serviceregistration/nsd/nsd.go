@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/helper/envoy"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"oss.indeed.com/go/libtime/decay"
 )
@@ -113,17 +114,30 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 	// Collect all errors generating service registrations.
 	var mErr multierror.Error
 
-	registrations := make([]*structs.ServiceRegistration, len(workload.Services))
+	registrations := make([]*structs.ServiceRegistration, 0, len(workload.Services))
 
 	// Iterate over the services and generate a hydrated registration object for
 	// each. All services are part of a single allocation, therefore we cannot
 	// have one failure without all becoming a failure.
-	for i, serviceSpec := range workload.Services {
+	for _, serviceSpec := range workload.Services {
 		serviceRegistration, err := s.generateNomadServiceRegistration(serviceSpec, workload)
 		if err != nil {
 			mErr.Errors = append(mErr.Errors, err)
 		} else if mErr.ErrorOrNil() == nil {
-			registrations[i] = serviceRegistration
+			registrations = append(registrations, serviceRegistration)
+		}
+
+		// Services with a Connect sidecar also register the sidecar, which
+		// upstreams in other allocations connect to.
+		if serviceSpec.Connect.HasSidecar() {
+			sidecarRegistration, err := s.generateNomadServiceRegistration(
+				sidecarServiceSpec(serviceSpec), workload)
+			if err != nil {
+				mErr.Errors = append(mErr.Errors, err)
+			} else if mErr.ErrorOrNil() == nil {
+				sidecarRegistration.ID = sidecarServiceID(workload, serviceSpec)
+				registrations = append(registrations, sidecarRegistration)
+			}
 		}
 	}
 
@@ -194,7 +208,18 @@ func (s *ServiceRegistrationHandler) removeWorkload(
 	}
 
 	// Generate the consistent ID for this service, so we know what to remove.
-	id := serviceregistration.MakeAllocServiceID(workload.AllocInfo.AllocID, workload.Name(), serviceSpec)
+	s.deleteRegistration(workload, serviceregistration.MakeAllocServiceID(
+		workload.AllocInfo.AllocID, workload.Name(), serviceSpec))
+
+	if serviceSpec.Connect.HasSidecar() {
+		s.deleteRegistration(workload, sidecarServiceID(workload, serviceSpec))
+	}
+}
+
+// deleteRegistration removes the service registration with the given ID,
+// retrying until it succeeds or the handler shuts down.
+func (s *ServiceRegistrationHandler) deleteRegistration(
+	workload *serviceregistration.WorkloadServices, id string) {
 
 	deleteArgs := structs.ServiceRegistrationDeleteByIDRequest{
 		ID: id,
@@ -247,6 +272,38 @@ func (s *ServiceRegistrationHandler) removeWorkload(
 	}
 }
 
+// sidecarServiceSpec returns the specification of the service registration of
+// the Connect sidecar proxy of a service, which is registered on the sidecar
+// port with the weights of the service.
+func sidecarServiceSpec(serviceSpec *structs.Service) *structs.Service {
+	sidecar := serviceSpec.Connect.SidecarService
+
+	portLabel := sidecar.Port
+	if portLabel == "" {
+		portLabel = envoy.PortLabel(structs.ConnectProxyPrefix, serviceSpec.Name, "")
+	}
+
+	tags := serviceSpec.Tags
+	if sidecar.Tags != nil {
+		tags = sidecar.Tags
+	}
+
+	return &structs.Service{
+		Name:          structs.MeshSidecarServiceName(serviceSpec.Name),
+		PortLabel:     portLabel,
+		Tags:          tags,
+		Weights:       serviceSpec.Weights,
+		CanaryWeights: serviceSpec.CanaryWeights,
+	}
+}
+
+// sidecarServiceID returns the ID of the service registration of the Connect
+// sidecar proxy of a service.
+func sidecarServiceID(workload *serviceregistration.WorkloadServices, serviceSpec *structs.Service) string {
+	return serviceregistration.MakeAllocServiceID(workload.AllocInfo.AllocID,
+		workload.Name(), serviceSpec) + structs.MeshSidecarServiceSuffix
+}
+
 func (s *ServiceRegistrationHandler) UpdateWorkload(old, new *serviceregistration.WorkloadServices) error {
 
 	// Overwrite the workload with the deduplicated versions.
@@ -365,6 +422,9 @@ func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
 		return nil, fmt.Errorf("unable to get address for service %q: %v", serviceSpec.Name, err)
 	}
 
+	// Dual-stack networks register the IPv6 address as well.
+	_, ipv6 := serviceregistration.GetDualStackAddresses(addrMode, workload.NetworkStatus)
+
 	// Build the tags to use for this registration which is a result of whether
 	// this is a canary, or not.
 	var tags []string
@@ -377,6 +437,13 @@ func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
 		copy(tags, serviceSpec.Tags)
 	}
 
+	// Canaries are weighted by the canary weights, if any, so that only a
+	// fraction of traffic is sent to them until they are promoted.
+	weight := 1
+	if weights := serviceSpec.RegistrationWeights(workload.Canary); weights != nil && weights.Passing > 0 {
+		weight = weights.Passing
+	}
+
 	return &structs.ServiceRegistration{
 		ID:          serviceregistration.MakeAllocServiceID(workload.AllocInfo.AllocID, workload.Name(), serviceSpec),
 		ServiceName: serviceSpec.Name,
@@ -387,6 +454,9 @@ func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
 		Datacenter:  s.cfg.Datacenter,
 		Tags:        tags,
 		Address:     ip,
+		AddressIPv6: ipv6,
 		Port:        port,
+		Weight:      weight,
+		Canary:      workload.Canary,
 	}, nil
 }
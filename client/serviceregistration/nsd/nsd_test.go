@@ -107,6 +107,93 @@ func TestServiceRegistrationHandler_RegisterWorkload(t *testing.T) {
 	}
 }
 
+func TestServiceRegistrationHandler_RegisterWorkload_ConnectSidecar(t *testing.T) {
+	workload := mockWorkload()
+	workload.Services = []*structs.Service{{
+		Name:        "redis-db",
+		AddressMode: structs.AddressModeHost,
+		PortLabel:   "db",
+		Tags:        []string{"primary"},
+		Connect: &structs.ConsulConnect{
+			SidecarService: &structs.ConsulSidecarService{},
+		},
+	}}
+	workload.Ports = append(workload.Ports, structs.AllocatedPortMapping{
+		Label:  "connect-proxy-redis-db",
+		HostIP: "10.10.13.2",
+		Value:  25098,
+	})
+
+	var upserted []*structs.ServiceRegistration
+	var deleted []string
+	h := NewServiceRegistrationHandler(hclog.NewNullLogger(), &ServiceRegistrationHandlerCfg{
+		Enabled:      true,
+		CheckWatcher: new(mockCheckWatcher),
+		RPCFn: func(method string, args, _ any) error {
+			switch method {
+			case structs.ServiceRegistrationUpsertRPCMethod:
+				upserted = args.(*structs.ServiceRegistrationUpsertRequest).Services
+			case structs.ServiceRegistrationDeleteByIDRPCMethod:
+				deleted = append(deleted, args.(*structs.ServiceRegistrationDeleteByIDRequest).ID)
+			}
+			return nil
+		},
+	})
+
+	must.NoError(t, h.RegisterWorkload(workload))
+	must.Len(t, 2, upserted)
+	must.Eq(t, "redis-db", upserted[0].ServiceName)
+	must.Eq(t, 23098, upserted[0].Port)
+
+	sidecar := upserted[1]
+	must.Eq(t, "redis-db-sidecar-proxy", sidecar.ServiceName)
+	must.Eq(t, upserted[0].ID+"-sidecar-proxy", sidecar.ID)
+	must.Eq(t, "10.10.13.2", sidecar.Address)
+	must.Eq(t, 25098, sidecar.Port)
+	must.Eq(t, []string{"primary"}, sidecar.Tags)
+
+	h.RemoveWorkload(workload)
+	must.SliceContainsAll(t, []string{upserted[0].ID, sidecar.ID}, deleted)
+}
+
+func TestServiceRegistrationHandler_RegisterWorkload_Weights(t *testing.T) {
+	workload := mockWorkload()
+	workload.Services = workload.Services[:1]
+	workload.Services[0].Weights = &structs.ServiceWeights{Passing: 10, Warning: 1}
+	workload.Services[0].CanaryWeights = &structs.ServiceWeights{Passing: 1, Warning: 1}
+
+	var upserted []*structs.ServiceRegistration
+	h := NewServiceRegistrationHandler(hclog.NewNullLogger(), &ServiceRegistrationHandlerCfg{
+		Enabled:      true,
+		CheckWatcher: new(mockCheckWatcher),
+		RPCFn: func(method string, args, _ any) error {
+			if method == structs.ServiceRegistrationUpsertRPCMethod {
+				upserted = args.(*structs.ServiceRegistrationUpsertRequest).Services
+			}
+			return nil
+		},
+	})
+
+	must.NoError(t, h.RegisterWorkload(workload))
+	must.Len(t, 1, upserted)
+	must.Eq(t, 10, upserted[0].Weight)
+	must.False(t, upserted[0].Canary)
+
+	// canaries are registered with the canary weights
+	workload.Canary = true
+	must.NoError(t, h.RegisterWorkload(workload))
+	must.Len(t, 1, upserted)
+	must.Eq(t, 1, upserted[0].Weight)
+	must.True(t, upserted[0].Canary)
+
+	// services without weights default to a weight of 1
+	workload.Services[0].Weights = nil
+	workload.Services[0].CanaryWeights = nil
+	workload.Canary = false
+	must.NoError(t, h.RegisterWorkload(workload))
+	must.Eq(t, 1, upserted[0].Weight)
+}
+
 func TestServiceRegistrationHandler_RemoveWorkload(t *testing.T) {
 	testCases := []struct {
 		name                 string
@@ -7,6 +7,7 @@ package structs
 
 import (
 	"errors"
+	"os"
 	"time"
 
 	"github.com/hashicorp/nomad/client/hoststats"
@@ -137,6 +138,31 @@ type FsStreamRequest struct {
 	structs.QueryOptions
 }
 
+// FsPutRequest is the initial request for uploading a file to an allocation
+// directory. It is followed by the contents of the file, sent in chunks as the
+// payloads of StreamErrWrappers. Once all the contents have been received,
+// the result of the upload is sent back as a StreamErrWrapper without a
+// payload.
+type FsPutRequest struct {
+	// AllocID is the allocation to upload the file to
+	AllocID string
+
+	// Path is the path of the file relative to the allocation directory
+	Path string
+
+	// Size is the size of the file in bytes
+	Size int64
+
+	// Checksum is the hex encoded SHA-256 checksum of the file contents. The
+	// file is only written to Path if the uploaded contents match it.
+	Checksum string
+
+	// FileMode is the permission bits of the file
+	FileMode os.FileMode
+
+	structs.QueryOptions
+}
+
 // FsLogsRequest is the initial request for accessing allocation logs.
 type FsLogsRequest struct {
 	// AllocID is the allocation to stream logs from
@@ -161,6 +187,11 @@ type FsLogsRequest struct {
 	// Follow follows logs.
 	Follow bool
 
+	// Filter is a regular expression the lines of the logs must match to be
+	// streamed. Matching is done on the client so unmatched lines are never
+	// sent.
+	Filter string
+
 	structs.QueryOptions
 }
 
@@ -193,6 +224,10 @@ type AllocExecRequest struct {
 	// The name of a predefined command to be executed (optional)
 	Action string
 
+	// RecordingRequired is set by the server to refuse the session if the
+	// client doesn't record it.
+	RecordingRequired bool
+
 	structs.QueryOptions
 }
 
@@ -210,6 +245,51 @@ type AllocChecksResponse struct {
 	Results map[structs.CheckID]*structs.CheckQueryResult
 }
 
+// AllocTemplateDebugRequest is used to render the templates of a task once
+// without writing them to the task directory.
+type AllocTemplateDebugRequest struct {
+	// AllocID is the allocation whose templates are rendered
+	AllocID string
+
+	// Task is the task whose templates are rendered
+	Task string
+
+	// Timeout is how long to wait for the templates' dependencies before
+	// returning the templates that could not be rendered
+	Timeout time.Duration
+
+	structs.QueryOptions
+}
+
+// AllocTemplateDebugResponse is used to return the result of rendering the
+// templates of a task.
+type AllocTemplateDebugResponse struct {
+	Templates []*TemplateDryRunResult
+	structs.QueryMeta
+}
+
+// TemplateDryRunResult is the result of rendering a single template without
+// writing it to the task directory.
+type TemplateDryRunResult struct {
+	// DestPath is the destination of the template as set in the jobspec
+	DestPath string
+
+	// Rendered is true if all the template's dependencies were available
+	// and Contents holds the rendered template
+	Rendered bool
+
+	// Contents is the rendered template
+	Contents string
+
+	// Redacted is true if the template is rendered into the secrets or
+	// private dir of the task, in which case Contents is left empty since
+	// those files can't be read through the allocation filesystem either
+	Redacted bool
+
+	// MissingDeps are the dependencies the template was still waiting on
+	MissingDeps []string
+}
+
 // AllocStatsRequest is used to request the resource usage of a given
 // allocation, potentially filtering by task
 type AllocStatsRequest struct {
@@ -229,6 +309,37 @@ type AllocStatsResponse struct {
 	structs.QueryMeta
 }
 
+// AllocSnapshotChunkRequest is used to download a chunk of the snapshot of
+// the data of an allocation, to migrate it to another node.
+type AllocSnapshotChunkRequest struct {
+	// AllocID is the allocation whose data is migrated
+	AllocID string
+
+	// MigrateToken authorizes the migration of the allocation's data.
+	MigrateToken string
+
+	// Offset is where the chunk starts in the snapshot, so that interrupted
+	// transfers can be resumed.
+	Offset int64
+
+	structs.QueryOptions
+}
+
+// AllocSnapshotChunkResponse is used to return a chunk of the snapshot of the
+// data of an allocation.
+type AllocSnapshotChunkResponse struct {
+	// Data is the chunk of the snapshot, empty once Offset reaches Size.
+	Data []byte
+
+	// Size is the total size of the snapshot.
+	Size int64
+
+	// Checksum is the hex-encoded SHA-256 checksum of the whole snapshot.
+	Checksum string
+
+	structs.QueryMeta
+}
+
 // MemoryStats holds memory usage related stats
 type MemoryStats struct {
 	RSS            uint64
@@ -240,6 +351,10 @@ type MemoryStats struct {
 	KernelUsage    uint64
 	KernelMaxUsage uint64
 
+	// Pressure is the memory pressure stall information of the task, and is
+	// only set on cgroups v2 systems with PSI enabled.
+	Pressure *PressureStats
+
 	// A list of fields whose values were actually sampled
 	Measured []string
 }
@@ -257,6 +372,7 @@ func (ms *MemoryStats) Add(other *MemoryStats) {
 	ms.MaxUsage += other.MaxUsage
 	ms.KernelUsage += other.KernelUsage
 	ms.KernelMaxUsage += other.KernelMaxUsage
+	ms.Pressure = addPressureStats(ms.Pressure, other.Pressure)
 	ms.Measured = joinStringSet(ms.Measured, other.Measured)
 }
 
@@ -269,6 +385,10 @@ type CpuStats struct {
 	ThrottledTime    uint64
 	Percent          float64
 
+	// Pressure is the CPU pressure stall information of the task, and is only
+	// set on cgroups v2 systems with PSI enabled.
+	Pressure *PressureStats
+
 	// A list of fields whose values were actually sampled
 	Measured []string
 }
@@ -284,20 +404,138 @@ func (cs *CpuStats) Add(other *CpuStats) {
 	cs.ThrottledPeriods += other.ThrottledPeriods
 	cs.ThrottledTime += other.ThrottledTime
 	cs.Percent += other.Percent
+	cs.Pressure = addPressureStats(cs.Pressure, other.Pressure)
 	cs.Measured = joinStringSet(cs.Measured, other.Measured)
 }
 
-// ResourceUsage holds information related to cpu and memory stats
+// PressureStats holds the pressure stall information (PSI) of a resource. The
+// Some values account for the time at least one task was stalled waiting on
+// the resource, and the Full values for the time all tasks were stalled at
+// once. The averages are percentages over the last 10, 60 and 300 seconds,
+// and the totals are the stall times in microseconds.
+type PressureStats struct {
+	SomeAvg10  float64
+	SomeAvg60  float64
+	SomeAvg300 float64
+	SomeTotal  uint64
+
+	FullAvg10  float64
+	FullAvg60  float64
+	FullAvg300 float64
+	FullTotal  uint64
+}
+
+func (ps *PressureStats) Add(other *PressureStats) {
+	if other == nil {
+		return
+	}
+
+	ps.SomeAvg10 += other.SomeAvg10
+	ps.SomeAvg60 += other.SomeAvg60
+	ps.SomeAvg300 += other.SomeAvg300
+	ps.SomeTotal += other.SomeTotal
+	ps.FullAvg10 += other.FullAvg10
+	ps.FullAvg60 += other.FullAvg60
+	ps.FullAvg300 += other.FullAvg300
+	ps.FullTotal += other.FullTotal
+}
+
+// addPressureStats returns the sum of the pressure stats, which are only set
+// by the drivers able to measure them.
+func addPressureStats(ps, other *PressureStats) *PressureStats {
+	if other == nil {
+		return ps
+	}
+	if ps == nil {
+		ps = &PressureStats{}
+	}
+	ps.Add(other)
+	return ps
+}
+
+// BlockIOStats holds disk I/O related stats
+type BlockIOStats struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadOps    uint64
+	WriteOps   uint64
+
+	// A list of fields whose values were actually sampled
+	Measured []string
+}
+
+func (bs *BlockIOStats) Add(other *BlockIOStats) {
+	if other == nil {
+		return
+	}
+
+	bs.ReadBytes += other.ReadBytes
+	bs.WriteBytes += other.WriteBytes
+	bs.ReadOps += other.ReadOps
+	bs.WriteOps += other.WriteOps
+	bs.Measured = joinStringSet(bs.Measured, other.Measured)
+}
+
+// NetworkStats holds network I/O related stats
+type NetworkStats struct {
+	RxBytes   uint64
+	RxPackets uint64
+	RxErrors  uint64
+	RxDropped uint64
+	TxBytes   uint64
+	TxPackets uint64
+	TxErrors  uint64
+	TxDropped uint64
+
+	// A list of fields whose values were actually sampled
+	Measured []string
+}
+
+func (ns *NetworkStats) Add(other *NetworkStats) {
+	if other == nil {
+		return
+	}
+
+	ns.RxBytes += other.RxBytes
+	ns.RxPackets += other.RxPackets
+	ns.RxErrors += other.RxErrors
+	ns.RxDropped += other.RxDropped
+	ns.TxBytes += other.TxBytes
+	ns.TxPackets += other.TxPackets
+	ns.TxErrors += other.TxErrors
+	ns.TxDropped += other.TxDropped
+	ns.Measured = joinStringSet(ns.Measured, other.Measured)
+}
+
+// ResourceUsage holds information related to cpu, memory, and I/O stats
 type ResourceUsage struct {
 	MemoryStats *MemoryStats
 	CpuStats    *CpuStats
 	DeviceStats []*device.DeviceGroupStats
+
+	// BlockIOStats and NetworkStats are only set by the drivers able to
+	// measure them.
+	BlockIOStats *BlockIOStats
+	NetworkStats *NetworkStats
 }
 
 func (ru *ResourceUsage) Add(other *ResourceUsage) {
 	ru.MemoryStats.Add(other.MemoryStats)
 	ru.CpuStats.Add(other.CpuStats)
 	ru.DeviceStats = append(ru.DeviceStats, other.DeviceStats...)
+
+	if other.BlockIOStats != nil {
+		if ru.BlockIOStats == nil {
+			ru.BlockIOStats = &BlockIOStats{}
+		}
+		ru.BlockIOStats.Add(other.BlockIOStats)
+	}
+	if other.NetworkStats != nil {
+		if ru.NetworkStats == nil {
+			ru.NetworkStats = &NetworkStats{}
+		}
+		ru.NetworkStats.Add(other.NetworkStats)
+	}
 }
 
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -77,6 +78,14 @@ const (
 	// AllocIndex is the environment variable for passing the allocation index.
 	AllocIndex = "NOMAD_ALLOC_INDEX"
 
+	// ArrayIndex is the environment variable for passing the index of the
+	// allocation in the array of the job.
+	ArrayIndex = "NOMAD_ARRAY_INDEX"
+
+	// ArrayCount is the environment variable for passing the number of
+	// indexes of the array of the job.
+	ArrayCount = "NOMAD_ARRAY_COUNT"
+
 	// Datacenter is the environment variable for passing the datacenter in which the alloc is running.
 	Datacenter = "NOMAD_DC"
 
@@ -111,6 +120,13 @@ const (
 
 	AllocPortPrefix = "NOMAD_ALLOC_PORT_"
 
+	// AllocNetworkInterfacePrefix, AllocNetworkIPPrefix, and
+	// AllocNetworkIPv6Prefix are the prefixes for passing the interface and
+	// addresses attached by an additional CNI network to a task.
+	AllocNetworkInterfacePrefix = "NOMAD_ALLOC_NETWORK_INTERFACE_"
+	AllocNetworkIPPrefix        = "NOMAD_ALLOC_NETWORK_IP_"
+	AllocNetworkIPv6Prefix      = "NOMAD_ALLOC_NETWORK_IPV6_"
+
 	// HostPortPrefix is the prefix for passing the host port when a port
 	// map is specified.
 	HostPortPrefix = "NOMAD_HOST_PORT_"
@@ -412,8 +428,13 @@ type Builder struct {
 	// envvars are custom set environment variables
 	envvars map[string]string
 
-	// templateEnv are env vars set from templates
-	templateEnv map[string]string
+	// templateEnvs are env vars set from templates, keyed by the source that
+	// rendered them so several template managers can set their own env vars
+	templateEnvs map[string]map[string]string
+
+	// templateUnsetEnvs are env vars unset by templates, keyed by the source
+	// that rendered them
+	templateUnsetEnvs map[string][]string
 
 	// hostEnv are environment variables filtered from the host
 	hostEnv map[string]string
@@ -451,6 +472,7 @@ type Builder struct {
 	memMaxLimit          int64
 	taskName             string
 	allocIndex           int
+	arrayCount           int
 	datacenter           string
 	cgroupParent         string
 	namespace            string
@@ -564,6 +586,10 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 	}
 	if b.allocIndex != -1 {
 		envMap[AllocIndex] = strconv.Itoa(b.allocIndex)
+		if b.arrayCount > 0 {
+			envMap[ArrayIndex] = strconv.Itoa(b.allocIndex)
+			envMap[ArrayCount] = strconv.Itoa(b.arrayCount)
+		}
 	}
 	if b.taskName != "" {
 		envMap[TaskName] = b.taskName
@@ -606,6 +632,9 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 	if b.networkStatus != nil && b.allocatedPorts != nil {
 		addNomadAllocNetwork(envMap, b.allocatedPorts, b.networkStatus)
 	}
+	if b.networkStatus != nil {
+		addNomadAllocInterfaces(envMap, b.networkStatus.Interfaces)
+	}
 
 	// Build the Vault Token
 	if b.injectVaultToken && b.vaultToken != "" {
@@ -662,9 +691,15 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 		}
 	}
 
-	// Copy template env vars as they override task env vars
-	for k, v := range b.templateEnv {
-		envMap[k] = v
+	// Copy template env vars as they override task env vars, and remove the
+	// env vars unset by templates wherever they were set
+	for _, source := range slices.Sorted(maps.Keys(b.templateEnvs)) {
+		for k, v := range b.templateEnvs[source] {
+			envMap[k] = v
+		}
+		for _, k := range b.templateUnsetEnvs[source] {
+			delete(envMap, k)
+		}
 	}
 
 	// Clean keys (see #2405)
@@ -770,6 +805,9 @@ func (b *Builder) setAlloc(alloc *structs.Allocation) *Builder {
 	b.allocName = alloc.Name
 	b.groupName = alloc.TaskGroup
 	b.allocIndex = int(alloc.Index())
+	if alloc.Job.Array != nil {
+		b.arrayCount = alloc.Job.Array.Count
+	}
 	b.jobID = alloc.Job.ID
 	b.jobName = alloc.Job.Name
 	b.jobParentID = alloc.Job.ParentID
@@ -1047,6 +1085,20 @@ func addNomadAllocNetwork(envMap map[string]string, p structs.AllocatedPorts, ne
 	}
 }
 
+// addNomadAllocInterfaces builds the env vars of the interfaces attached by the
+// additional CNI networks of the group network.
+func addNomadAllocInterfaces(envMap map[string]string, ifaces []*structs.AllocNetworkInterface) {
+	for _, iface := range ifaces {
+		envMap[helper.CleanEnvVar(AllocNetworkInterfacePrefix+iface.Network, '_')] = iface.InterfaceName
+		if iface.Address != "" {
+			envMap[helper.CleanEnvVar(AllocNetworkIPPrefix+iface.Network, '_')] = iface.Address
+		}
+		if iface.AddressIPv6 != "" {
+			envMap[helper.CleanEnvVar(AllocNetworkIPv6Prefix+iface.Network, '_')] = iface.AddressIPv6
+		}
+	}
+}
+
 // SetPortMapEnvs sets the PortMap related environment variables on the map
 func SetPortMapEnvs(envs map[string]string, ports map[string]int) map[string]string {
 	if envs == nil {
@@ -1088,11 +1140,32 @@ func (b *Builder) SetHostEnvvars(filter []string) *Builder {
 	return b
 }
 
-func (b *Builder) SetTemplateEnv(m map[string]string) *Builder {
+// SetTemplateEnv sets the env vars read from the env templates rendered by
+// source, and the env vars they unset, replacing the ones previously set by
+// the same source. Unset env vars are removed from the task environment even
+// if they were set by the task or other sources. It returns true if the env
+// vars of the source changed.
+func (b *Builder) SetTemplateEnv(source string, m map[string]string, unset []string) bool {
 	b.mu.Lock()
-	b.templateEnv = m
-	b.mu.Unlock()
-	return b
+	defer b.mu.Unlock()
+
+	if old, ok := b.templateEnvs[source]; ok && maps.Equal(old, m) &&
+		slices.Equal(b.templateUnsetEnvs[source], unset) {
+		return false
+	}
+	if b.templateEnvs == nil {
+		b.templateEnvs = make(map[string]map[string]string)
+	}
+	b.templateEnvs[source] = m
+	if len(unset) == 0 {
+		delete(b.templateUnsetEnvs, source)
+	} else {
+		if b.templateUnsetEnvs == nil {
+			b.templateUnsetEnvs = make(map[string][]string)
+		}
+		b.templateUnsetEnvs[source] = unset
+	}
+	return true
 }
 
 func (b *Builder) SetVaultToken(token, namespace string, inject bool) *Builder {
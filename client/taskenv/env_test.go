@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/shoenig/test"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -609,6 +610,45 @@ func TestEnvironment_HookVars(t *testing.T) {
 	}
 }
 
+// TestEnvironment_TemplateEnv asserts env vars from several template sources
+// are merged and that each source only replaces its own vars.
+func TestEnvironment_TemplateEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	n := mock.Node()
+	a := mock.Alloc()
+	task := a.Job.TaskGroups[0].Tasks[0]
+	task.Env = map[string]string{"FOO": "task"}
+	builder := NewBuilder(n, a, task, "global")
+
+	must.True(t, builder.SetTemplateEnv("once.env", map[string]string{"FOO": "once", "ONCE": "1"}, nil))
+	must.True(t, builder.SetTemplateEnv("watch.env", map[string]string{"WATCH": "1"}, nil))
+
+	out := builder.Build().All()
+	must.Eq(t, "once", out["FOO"])
+	must.Eq(t, "1", out["ONCE"])
+	must.Eq(t, "1", out["WATCH"])
+
+	// setting the same vars again is not a change
+	must.False(t, builder.SetTemplateEnv("watch.env", map[string]string{"WATCH": "1"}, nil))
+
+	// updating one source keeps the vars of the other
+	must.True(t, builder.SetTemplateEnv("watch.env", map[string]string{"WATCH": "2"}, nil))
+	out = builder.Build().All()
+	must.Eq(t, "1", out["ONCE"])
+	must.Eq(t, "2", out["WATCH"])
+
+	// unset vars are removed from the task env wherever they were set
+	must.True(t, builder.SetTemplateEnv("watch.env", map[string]string{"WATCH": "2"}, []string{"FOO"}))
+	must.False(t, builder.SetTemplateEnv("watch.env", map[string]string{"WATCH": "2"}, []string{"FOO"}))
+	out = builder.Build().All()
+	must.MapNotContainsKey(t, out, "FOO")
+	must.Eq(t, "2", out["WATCH"])
+
+	must.True(t, builder.SetTemplateEnv("watch.env", map[string]string{"WATCH": "2"}, nil))
+	must.Eq(t, "once", builder.Build().All()["FOO"])
+}
+
 // TestEnvironment_DeviceHookVars asserts device hook env vars are accessible
 // separately.
 func TestEnvironment_DeviceHookVars(t *testing.T) {
@@ -745,6 +785,26 @@ func TestEnvironment_WithTask(t *testing.T) {
 	test.Eq(t, "", newMap2["env"])
 }
 
+// TestEnvironment_Array asserts the array index and count are only set for
+// allocations of an array.
+func TestEnvironment_Array(t *testing.T) {
+	ci.Parallel(t)
+
+	a := mock.Alloc()
+	a.Name = structs.AllocName(a.JobID, a.TaskGroup, 7)
+	task := a.Job.TaskGroups[0].Tasks[0]
+
+	envMap := NewBuilder(mock.Node(), a, task, "global").Build().Map()
+	test.Eq(t, "7", envMap[AllocIndex])
+	test.MapNotContainsKey(t, envMap, ArrayIndex)
+	test.MapNotContainsKey(t, envMap, ArrayCount)
+
+	a.Job.Array = &structs.JobArray{Count: 10}
+	envMap = NewBuilder(mock.Node(), a, task, "global").Build().Map()
+	test.Eq(t, "7", envMap[ArrayIndex])
+	test.Eq(t, "10", envMap[ArrayCount])
+}
+
 // TestEnvironment_InterpolateEmptyOptionalMeta asserts that in a parameterized
 // job, if an optional meta field is not set, it will get interpolated as an
 // empty string.
@@ -766,6 +826,36 @@ func TestEnvironment_InterpolateEmptyOptionalMeta(t *testing.T) {
 
 // TestEnvironment_Upsteams asserts that group.service.upstreams entries are
 // added to the environment.
+func TestEnvironment_AllocNetworkInterfaces(t *testing.T) {
+	ci.Parallel(t)
+
+	a := mock.Alloc()
+	task := a.Job.TaskGroups[0].Tasks[0]
+
+	env := NewBuilder(mock.Node(), a, task, "global").SetNetworkStatus(&structs.AllocNetworkStatus{
+		InterfaceName: "eth0",
+		Address:       "172.26.64.19",
+		Interfaces: []*structs.AllocNetworkInterface{
+			{
+				Network:       "storage-net",
+				InterfaceName: "eth1",
+				Address:       "10.10.0.5",
+				AddressIPv6:   "fd00::5",
+			},
+			{
+				Network:       "sriov",
+				InterfaceName: "eth2",
+			},
+		},
+	}).Build().Map()
+
+	must.Eq(t, "eth1", env["NOMAD_ALLOC_NETWORK_INTERFACE_storage_net"])
+	must.Eq(t, "10.10.0.5", env["NOMAD_ALLOC_NETWORK_IP_storage_net"])
+	must.Eq(t, "fd00::5", env["NOMAD_ALLOC_NETWORK_IPV6_storage_net"])
+	must.Eq(t, "eth2", env["NOMAD_ALLOC_NETWORK_INTERFACE_sriov"])
+	must.MapNotContainsKey(t, env, "NOMAD_ALLOC_NETWORK_IP_sriov")
+}
+
 func TestEnvironment_Upstreams(t *testing.T) {
 	ci.Parallel(t)
 
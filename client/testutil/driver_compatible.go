@@ -127,6 +127,21 @@ func QemuCompatible(t *testing.T) {
 	}
 }
 
+// WasmtimeCompatible skips tests unless:
+// - "wasmtime" executable is detected on $PATH
+// - running as root
+// - running on Linux
+func WasmtimeCompatible(t *testing.T) {
+	_, err := exec.Command("wasmtime", "--version").CombinedOutput()
+	if err != nil {
+		t.Skipf("Test requires wasmtime: %v", err)
+	}
+
+	if runtime.GOOS != "linux" || syscall.Geteuid() != 0 {
+		t.Skip("Test requires root on Linux")
+	}
+}
+
 // MountCompatible skips tests unless:
 // - not running as windows
 // - running as root
@@ -80,6 +80,7 @@ func formatAuthMethod(authMethod *api.ACLAuthMethod) string {
 		fmt.Sprintf("Max Token TTL|%s", authMethod.MaxTokenTTL.String()),
 		fmt.Sprintf("Token Name Format|%s", authMethod.TokenNameFormat),
 		fmt.Sprintf("Default|%t", authMethod.Default),
+		fmt.Sprintf("Prune Tokens|%t", authMethod.PruneTokens),
 		fmt.Sprintf("Create Index|%d", authMethod.CreateIndex),
 		fmt.Sprintf("Modify Index|%d", authMethod.ModifyIndex),
 	}
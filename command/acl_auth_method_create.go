@@ -29,6 +29,7 @@ type ACLAuthMethodCreateCommand struct {
 	tokenNameFormat string
 	maxTokenTTL     time.Duration
 	isDefault       bool
+	pruneTokens     bool
 	config          string
 	json            bool
 	tmpl            string
@@ -72,6 +73,11 @@ ACL Auth Method Create Options:
     Specifies whether this auth method should be treated as a default one in
     case no auth method is explicitly specified for a login command.
 
+  -prune-tokens
+    Specifies whether the roles and policies a user is no longer bound to, such
+    as after being removed from a group of the provider, should be removed from
+    the tokens previously created for the user when the user logs in again.
+
   -config
     Auth method configuration in JSON format. May be prefixed with '@' to
     indicate that the value is a file path to load the config from. '-' may also
@@ -95,6 +101,7 @@ func (a *ACLAuthMethodCreateCommand) AutocompleteFlags() complete.Flags {
 			"-token-locality":    complete.PredictSet("local", "global"),
 			"-token-name-format": complete.PredictNothing,
 			"-default":           complete.PredictSet("true", "false"),
+			"-prune-tokens":      complete.PredictSet("true", "false"),
 			"-config":            complete.PredictNothing,
 			"-json":              complete.PredictNothing,
 			"-t":                 complete.PredictAnything,
@@ -122,6 +129,7 @@ func (a *ACLAuthMethodCreateCommand) Run(args []string) int {
 	flags.StringVar(&a.tokenNameFormat, "token-name-format", "", "")
 	flags.DurationVar(&a.maxTokenTTL, "max-token-ttl", 0, "")
 	flags.BoolVar(&a.isDefault, "default", false, "")
+	flags.BoolVar(&a.pruneTokens, "prune-tokens", false, "")
 	flags.StringVar(&a.config, "config", "", "")
 	flags.BoolVar(&a.json, "json", false, "")
 	flags.StringVar(&a.tmpl, "t", "", "")
@@ -179,6 +187,7 @@ func (a *ACLAuthMethodCreateCommand) Run(args []string) int {
 		TokenNameFormat: a.tokenNameFormat,
 		MaxTokenTTL:     a.maxTokenTTL,
 		Default:         a.isDefault,
+		PruneTokens:     a.pruneTokens,
 		Config:          &configJSON,
 	}
 
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// Ensure ACLAuthMethodTestCommand satisfies the cli.Command interface.
+var _ cli.Command = &ACLAuthMethodTestCommand{}
+
+// ACLAuthMethodTestCommand implements cli.Command.
+type ACLAuthMethodTestCommand struct {
+	Meta
+
+	claims string
+	json   bool
+	tmpl   string
+
+	testStdin io.Reader
+}
+
+// Help satisfies the cli.Command Help function.
+func (a *ACLAuthMethodTestCommand) Help() string {
+	helpText := `
+Usage: nomad acl auth-method test [options] <acl_method_name>
+
+  Test is used to show the roles and policies the binding rules of an ACL auth
+  method bind for a set of claims, without creating a token. The claims are
+  given as the provider includes them in its tokens or user info, which makes
+  it possible to check the claim mappings and binding rules of the auth method
+  before users log in. Requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+ACL Auth Method Test Options:
+
+  -claims
+    The claims of the identity in JSON format. May be prefixed with '@' to
+    indicate that the value is a file path to load the claims from. '-' may
+    also be given to indicate that the claims are available on stdin.
+
+  -json
+    Output the result of the test in a JSON format.
+
+  -t
+    Format and display the result of the test using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (a *ACLAuthMethodTestCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-claims": complete.PredictFiles("*.json"),
+			"-json":   complete.PredictNothing,
+			"-t":      complete.PredictAnything,
+		})
+}
+
+func (a *ACLAuthMethodTestCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Synopsis satisfies the cli.Command Synopsis function.
+func (a *ACLAuthMethodTestCommand) Synopsis() string {
+	return "Test the binding rules of an ACL auth method against a set of claims"
+}
+
+// Name returns the name of this command.
+func (a *ACLAuthMethodTestCommand) Name() string { return "acl auth-method test" }
+
+// Run satisfies the cli.Command Run function.
+func (a *ACLAuthMethodTestCommand) Run(args []string) int {
+	flags := a.Meta.FlagSet(a.Name(), FlagSetClient)
+	flags.Usage = func() { a.Ui.Output(a.Help()) }
+	flags.StringVar(&a.claims, "claims", "", "")
+	flags.BoolVar(&a.json, "json", false, "")
+	flags.StringVar(&a.tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we have exactly one argument.
+	if len(flags.Args()) != 1 {
+		a.Ui.Error("This command takes one argument: <acl_auth_method_name>")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	if len(a.claims) == 0 {
+		a.Ui.Error("Must provide the claims in JSON format using the -claims flag")
+		return 1
+	}
+
+	rawClaims, err := loadDataSource(a.claims, a.testStdin)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error loading claims: %v", err))
+		return 1
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal([]byte(rawClaims), &claims); err != nil {
+		a.Ui.Error(fmt.Sprintf("Unable to parse claims: %v", err))
+		return 1
+	}
+
+	// Get the HTTP client.
+	client, err := a.Meta.Client()
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	methodName := flags.Args()[0]
+
+	result, _, err := client.ACLAuthMethods().Test(methodName, claims, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error testing ACL auth method: %s", err))
+		return 1
+	}
+
+	if a.json || len(a.tmpl) > 0 {
+		out, err := Format(a.json, a.tmpl, result)
+		if err != nil {
+			a.Ui.Error(err.Error())
+			return 1
+		}
+
+		a.Ui.Output(out)
+		return 0
+	}
+
+	a.Ui.Output(formatAuthMethodTest(result))
+	if result.Claims != nil && (len(result.Claims.Value) > 0 || len(result.Claims.List) > 0) {
+		a.Ui.Output(a.Colorize().Color("\n[bold]Mapped Claims[reset]\n"))
+		a.Ui.Output(formatAuthMethodTestClaims(result.Claims))
+	}
+	return 0
+}
+
+// formatAuthMethodTest formats the bindings of an auth method test into a
+// string KV representation suitable for console output.
+func formatAuthMethodTest(result *api.ACLAuthMethodTestResult) string {
+	roles := make([]string, 0, len(result.Roles))
+	for _, link := range result.Roles {
+		roles = append(roles, link.Name)
+	}
+
+	out := []string{
+		fmt.Sprintf("Management|%t", result.Management),
+		fmt.Sprintf("Roles|%s", strings.Join(roles, ",")),
+		fmt.Sprintf("Policies|%s", strings.Join(result.Policies, ",")),
+	}
+	return formatKV(out)
+}
+
+// formatAuthMethodTestClaims formats the mapped claims of an auth method test
+// by the names binding rules use to refer to them.
+func formatAuthMethodTestClaims(claims *api.ACLAuthClaims) string {
+	var out []string
+	for _, k := range slices.Sorted(maps.Keys(claims.Value)) {
+		out = append(out, fmt.Sprintf("value.%s|%s", k, claims.Value[k]))
+	}
+	for _, k := range slices.Sorted(maps.Keys(claims.List)) {
+		out = append(out, fmt.Sprintf("list.%s|%s", k, strings.Join(claims.List[k], ",")))
+	}
+	return formatKV(out)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestACLAuthMethodTestCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	must.NotNil(t, token)
+
+	// Create a test auth method with a binding rule that binds a policy for
+	// each of the groups of the identity
+	method := mock.ACLOIDCAuthMethod()
+	method.Config.ListClaimMappings = map[string]string{"/realm_access/roles": "groups"}
+	must.NoError(t, state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	policy := mock.ACLPolicy()
+	policy.Name = "engineering"
+	must.NoError(t, state.UpsertACLPolicies(structs.MsgTypeTestSetup, 1001, []*structs.ACLPolicy{policy}))
+
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = method.Name
+	rule.Selector = ""
+	rule.BindType = structs.ACLBindingRuleBindTypePolicy
+	rule.BindName = "${list.groups}"
+	must.NoError(t, state.UpsertACLBindingRules(1002, []*structs.ACLBindingRule{rule}, true))
+
+	ui := cli.NewMockUi()
+	cmd := &ACLAuthMethodTestCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+	claims := `-claims={"realm_access": {"roles": ["engineering", "offline_access"]}}`
+
+	// Claims are required
+	code := cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, method.Name})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Must provide the claims")
+
+	ui.ErrorWriter.Reset()
+
+	// Attempt to test without a valid management token
+	invalidToken := mock.ACLToken()
+	code = cmd.Run([]string{"-address=" + url, "-token=" + invalidToken.SecretID, claims, method.Name})
+	must.One(t, code)
+
+	// Test with a valid management token
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, claims, method.Name})
+	must.Zero(t, code)
+
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Management = false")
+	must.StrContains(t, out, "Policies   = engineering")
+	must.StrContains(t, out, "list.groups = engineering,offline_access")
+}
@@ -29,6 +29,7 @@ type ACLAuthMethodUpdateCommand struct {
 	tokenNameFormat string
 	maxTokenTTL     time.Duration
 	isDefault       bool
+	pruneTokens     bool
 	config          string
 	json            bool
 	tmpl            string
@@ -68,6 +69,11 @@ ACL Auth Method Update Options:
     Specifies whether this auth method should be treated as a default one in
     case no auth method is explicitly specified for a login command.
 
+  -prune-tokens
+    Specifies whether the roles and policies a user is no longer bound to, such
+    as after being removed from a group of the provider, should be removed from
+    the tokens previously created for the user when the user logs in again.
+
   -config
     Updates auth method configuration (in JSON format). May be prefixed with
     '@' to indicate that the value is a file path to load the config from. '-'
@@ -91,6 +97,7 @@ func (a *ACLAuthMethodUpdateCommand) AutocompleteFlags() complete.Flags {
 			"-token-locality":    complete.PredictSet("local", "global"),
 			"-token-name-format": complete.PredictNothing,
 			"-default":           complete.PredictSet("true", "false"),
+			"-prune-tokens":      complete.PredictSet("true", "false"),
 			"-config":            complete.PredictNothing,
 			"-json":              complete.PredictNothing,
 			"-t":                 complete.PredictAnything,
@@ -118,6 +125,7 @@ func (a *ACLAuthMethodUpdateCommand) Run(args []string) int {
 	flags.DurationVar(&a.maxTokenTTL, "max-token-ttl", 0, "")
 	flags.StringVar(&a.config, "config", "", "")
 	flags.BoolVar(&a.isDefault, "default", false, "")
+	flags.BoolVar(&a.pruneTokens, "prune-tokens", false, "")
 	flags.BoolVar(&a.json, "json", false, "")
 	flags.StringVar(&a.tmpl, "t", "", "")
 	if err := flags.Parse(args); err != nil {
@@ -149,7 +157,7 @@ func (a *ACLAuthMethodUpdateCommand) Run(args []string) int {
 
 	// Check if any command-specific flags were set
 	setFlags := []string{}
-	for _, f := range []string{"type", "token-locality", "token-name-format", "max-token-ttl", "config", "default"} {
+	for _, f := range []string{"type", "token-locality", "token-name-format", "max-token-ttl", "config", "default", "prune-tokens"} {
 		if flagPassed(flags, f) {
 			setFlags = append(setFlags, f)
 		}
@@ -193,6 +201,10 @@ func (a *ACLAuthMethodUpdateCommand) Run(args []string) int {
 		updatedMethod.Default = a.isDefault
 	}
 
+	if slices.Contains(setFlags, "prune-tokens") {
+		updatedMethod.PruneTokens = a.pruneTokens
+	}
+
 	if len(a.config) != 0 {
 		config, err := loadDataSource(a.config, a.testStdin)
 		if err != nil {
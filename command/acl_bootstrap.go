@@ -37,7 +37,7 @@ Usage: nomad acl bootstrap [options] [<path>]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 Bootstrap Options:
 
@@ -52,7 +52,7 @@ Bootstrap Options:
 }
 
 func (c *ACLBootstrapCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json": complete.PredictNothing,
 			"-t":    complete.PredictAnything,
@@ -77,7 +77,7 @@ func (c *ACLBootstrapCommand) Run(args []string) int {
 		file string
 	)
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -129,8 +129,8 @@ func (c *ACLBootstrapCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, token)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, token)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -192,6 +192,14 @@ func outputACLToken(ui cli.Ui, token *api.ACLToken) {
 		fmt.Sprintf("Modify Index|%d", token.ModifyIndex),
 	}
 
+	// Only output the optional restrictions of the token when they are set.
+	if token.NotBefore != nil && !token.NotBefore.IsZero() {
+		kvOutput = append(kvOutput, fmt.Sprintf("Not Before|%v", token.NotBefore))
+	}
+	if len(token.AllowedCIDRs) > 0 {
+		kvOutput = append(kvOutput, fmt.Sprintf("Allowed CIDRs|%v", token.AllowedCIDRs))
+	}
+
 	// If the token is a management type, make it obvious that it is not
 	// possible to have policies or roles assigned to it and just output the
 	// KV data.
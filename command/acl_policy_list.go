@@ -26,7 +26,7 @@ Usage: nomad acl policy list
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 List Options:
 
@@ -41,7 +41,7 @@ List Options:
 }
 
 func (c *ACLPolicyListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json": complete.PredictNothing,
 			"-t":    complete.PredictAnything,
@@ -62,7 +62,7 @@ func (c *ACLPolicyListCommand) Run(args []string) int {
 	var json bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -93,8 +93,8 @@ func (c *ACLPolicyListCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, policies)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, policies)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
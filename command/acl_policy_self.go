@@ -26,7 +26,7 @@ Usage: nomad acl policy self
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 ACL List Options:
 
@@ -40,7 +40,7 @@ ACL List Options:
 }
 
 func (c *ACLPolicySelfCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json": complete.PredictNothing,
 			"-t":    complete.PredictAnything,
@@ -58,7 +58,7 @@ func (c *ACLPolicySelfCommand) Synopsis() string {
 func (c *ACLPolicySelfCommand) Name() string { return "acl policy self" }
 
 func (c *ACLPolicySelfCommand) Run(args []string) int {
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&c.json, "json", false, "")
 	flags.StringVar(&c.tmpl, "t", "", "")
@@ -90,8 +90,8 @@ func (c *ACLPolicySelfCommand) Run(args []string) int {
 	if len(policies) == 0 {
 		c.Ui.Output("No policies found.")
 	} else {
-		if c.json || len(c.tmpl) > 0 {
-			out, err := Format(c.json, c.tmpl, policies)
+		if c.StructuredOutput(c.json, c.tmpl) {
+			out, err := c.FormatOutput(c.json, c.tmpl, policies)
 			if err != nil {
 				c.Ui.Error(err.Error())
 				return 1
@@ -29,7 +29,7 @@ Usage: nomad acl role list [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 ACL List Options:
 
@@ -44,7 +44,7 @@ ACL List Options:
 }
 
 func (a *ACLRoleListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json": complete.PredictNothing,
 			"-t":    complete.PredictAnything,
@@ -64,7 +64,7 @@ func (a *ACLRoleListCommand) Run(args []string) int {
 	var json bool
 	var tmpl string
 
-	flags := a.Meta.FlagSet(a.Name(), FlagSetClient)
+	flags := a.Meta.FlagSet(a.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { a.Ui.Output(a.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -94,8 +94,8 @@ func (a *ACLRoleListCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, roles)
+	if a.StructuredOutput(json, tmpl) {
+		out, err := a.FormatOutput(json, tmpl, roles)
 		if err != nil {
 			a.Ui.Error(err.Error())
 			return 1
@@ -29,7 +29,7 @@ Usage: nomad acl token create [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 Create Options:
 
@@ -55,7 +55,19 @@ Create Options:
   -ttl
     Specifies the time-to-live of the created ACL token. This takes the form of
     a time duration such as "5m" and "1h". By default, tokens will be created
-    without a TTL and therefore never expire.
+    without a TTL and therefore never expire. If -not-before is set, the TTL
+    starts when the token becomes valid.
+
+  -not-before
+    Specifies the time before which the created ACL token is not accepted. This
+    takes the form of an RFC 3339 timestamp such as "2024-05-01T09:00:00Z", or
+    a time duration from now such as "1h". By default, tokens are valid as soon
+    as they are created.
+
+  -allowed-cidr
+    Specifies a CIDR block, such as "10.0.0.0/8", from which the created ACL
+    token can be used. May be specified multiple times. By default, tokens can
+    be used from any address.
 
   -json
     Output the ACL token information in JSON format.
@@ -67,17 +79,19 @@ Create Options:
 }
 
 func (c *ACLTokenCreateCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
-			"name":      complete.PredictAnything,
-			"type":      complete.PredictAnything,
-			"global":    complete.PredictNothing,
-			"policy":    complete.PredictAnything,
-			"role-id":   complete.PredictAnything,
-			"role-name": complete.PredictAnything,
-			"ttl":       complete.PredictAnything,
-			"-json":     complete.PredictNothing,
-			"-t":        complete.PredictAnything,
+			"name":         complete.PredictAnything,
+			"type":         complete.PredictAnything,
+			"global":       complete.PredictNothing,
+			"policy":       complete.PredictAnything,
+			"role-id":      complete.PredictAnything,
+			"role-name":    complete.PredictAnything,
+			"ttl":          complete.PredictAnything,
+			"not-before":   complete.PredictAnything,
+			"allowed-cidr": complete.PredictAnything,
+			"-json":        complete.PredictNothing,
+			"-t":           complete.PredictAnything,
 		})
 }
 
@@ -92,15 +106,16 @@ func (c *ACLTokenCreateCommand) Synopsis() string {
 func (c *ACLTokenCreateCommand) Name() string { return "acl token create" }
 
 func (c *ACLTokenCreateCommand) Run(args []string) int {
-	var name, tokenType, ttl, tmpl string
+	var name, tokenType, ttl, notBefore, tmpl string
 	var global, json bool
-	var policies []string
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	var policies, allowedCIDRs []string
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&name, "name", "", "")
 	flags.StringVar(&tokenType, "type", "client", "")
 	flags.BoolVar(&global, "global", false, "")
 	flags.StringVar(&ttl, "ttl", "", "")
+	flags.StringVar(&notBefore, "not-before", "", "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
 	flags.Var((funcVar)(func(s string) error {
@@ -115,6 +130,10 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 		c.roleIDs = append(c.roleIDs, s)
 		return nil
 	}), "role-id", "")
+	flags.Var((funcVar)(func(s string) error {
+		allowedCIDRs = append(allowedCIDRs, s)
+		return nil
+	}), "allowed-cidr", "")
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
@@ -129,11 +148,12 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 
 	// Set up the token.
 	tk := &api.ACLToken{
-		Name:     name,
-		Type:     tokenType,
-		Policies: policies,
-		Roles:    generateACLTokenRoleLinks(c.roleNames, c.roleIDs),
-		Global:   global,
+		Name:         name,
+		Type:         tokenType,
+		Policies:     policies,
+		Roles:        generateACLTokenRoleLinks(c.roleNames, c.roleIDs),
+		Global:       global,
+		AllowedCIDRs: allowedCIDRs,
 	}
 
 	// If the user set a TTL flag value, convert this to a time duration and
@@ -147,6 +167,17 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 		tk.ExpirationTTL = ttlDuration
 	}
 
+	// If the user set a not before flag value, accept either an absolute time
+	// or a duration from now.
+	if notBefore != "" {
+		t, err := parseNotBefore(notBefore, time.Now())
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		tk.NotBefore = &t
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -169,8 +200,8 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, token)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, token)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -184,6 +215,19 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 	return 0
 }
 
+// parseNotBefore parses the value of the not before flag, which is either an
+// RFC 3339 timestamp or a duration relative to now.
+func parseNotBefore(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Failed to parse not before as timestamp or time duration: %q", value)
+	}
+	return now.Add(d).UTC(), nil
+}
+
 // generateACLTokenRoleLinks takes the command input role links by ID and name
 // and coverts this to the relevant API object. It handles de-duplicating
 // entries to the best effort, so this doesn't need to be done on the leader.
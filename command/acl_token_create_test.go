@@ -6,6 +6,7 @@ package command
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/nomad/api"
@@ -92,6 +93,40 @@ func TestACLTokenCreateCommand(t *testing.T) {
 		ui.OutputWriter.Reset()
 		ui.ErrorWriter.Reset()
 	}
+
+	// Create a new token that is not valid yet and restricted to a CIDR block.
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-type=management",
+		"-not-before=1h", "-ttl=10m", "-allowed-cidr=10.0.0.0/8", "-allowed-cidr=192.168.0.0/16"})
+	must.Zero(t, code)
+
+	out = ui.OutputWriter.String()
+	must.StrContains(t, out, "Not Before")
+	must.StrContains(t, out, "Allowed CIDRs = [10.0.0.0/8 192.168.0.0/16]")
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Invalid CIDR blocks are rejected.
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-type=management",
+		"-allowed-cidr=10.0.0.0"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "invalid allowed CIDR")
+}
+
+func Test_parseNotBefore(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	out, err := parseNotBefore("2024-05-02T10:00:00+01:00", now)
+	must.NoError(t, err)
+	must.Eq(t, time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC), out)
+
+	out, err = parseNotBefore("2h", now)
+	must.NoError(t, err)
+	must.Eq(t, now.Add(2*time.Hour), out)
+
+	_, err = parseNotBefore("tomorrow", now)
+	must.ErrorContains(t, err, "Failed to parse not before")
 }
 
 func Test_generateACLTokenRoleLinks(t *testing.T) {
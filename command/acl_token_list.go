@@ -24,7 +24,7 @@ Usage: nomad acl token list
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 List Options:
 
@@ -39,7 +39,7 @@ List Options:
 }
 
 func (c *ACLTokenListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json": complete.PredictNothing,
 			"-t":    complete.PredictAnything,
@@ -60,7 +60,7 @@ func (c *ACLTokenListCommand) Run(args []string) int {
 	var json bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -91,8 +91,8 @@ func (c *ACLTokenListCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, tokens)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, tokens)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
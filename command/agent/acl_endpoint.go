@@ -636,6 +636,15 @@ func (s *HTTPServer) ACLAuthMethodSpecificRequest(
 		return nil, CodedError(http.StatusBadRequest, "missing ACL auth-method name")
 	}
 
+	// Auth-method names cannot contain a slash, so the suffix identifies a
+	// test of the named auth-method.
+	if name, ok := strings.CutSuffix(methodName, "/test"); ok {
+		if !(req.Method == http.MethodPut || req.Method == http.MethodPost) {
+			return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+		}
+		return s.aclAuthMethodTestRequest(resp, req, name)
+	}
+
 	// Identify the method which indicates which downstream function should be
 	// called.
 	switch req.Method {
@@ -674,6 +683,30 @@ func (s *HTTPServer) aclAuthMethodGetRequest(
 	return reply.AuthMethod, nil
 }
 
+// aclAuthMethodTestRequest is callable via the /v1/acl/auth-method/:name/test
+// HTTP API and computes the bindings of the named auth-method for the claims in
+// the request body, without creating a token.
+func (s *HTTPServer) aclAuthMethodTestRequest(
+	resp http.ResponseWriter, req *http.Request, methodName string) (interface{}, error) {
+
+	var args structs.ACLAuthMethodTestRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	args.MethodName = methodName
+
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var reply structs.ACLAuthMethodTestResponse
+	if err := s.agent.RPC(structs.ACLTestAuthMethodRPCMethod, &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+	return reply.Result, nil
+}
+
 // aclAuthMethodDeleteRequest is callable via the /v1/acl/auth-method/ HTTP API
 // and is responsible for deleting the named auth-method from state.
 func (s *HTTPServer) aclAuthMethodDeleteRequest(
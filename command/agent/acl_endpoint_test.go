@@ -1332,6 +1332,58 @@ func TestHTTPServer_ACLAuthMethodSpecificRequest(t *testing.T) {
 				must.Nil(t, obj)
 			},
 		},
+		{
+			name: "test auth-method",
+			testFn: func(srv *TestAgent) {
+
+				// Create a mock auth-method with a binding rule that binds
+				// a policy for each group, and put them directly into state.
+				mockACLAuthMethod := mock.ACLOIDCAuthMethod()
+				mockACLAuthMethod.Config.ListClaimMappings = map[string]string{"groups": "groups"}
+				must.NoError(t, srv.server.State().UpsertACLAuthMethods(
+					10, []*structs.ACLAuthMethod{mockACLAuthMethod}))
+
+				mockACLPolicy := mock.ACLPolicy()
+				must.NoError(t, srv.server.State().UpsertACLPolicies(
+					structs.MsgTypeTestSetup, 20, []*structs.ACLPolicy{mockACLPolicy}))
+
+				mockACLBindingRule := mock.ACLBindingRule()
+				mockACLBindingRule.AuthMethod = mockACLAuthMethod.Name
+				mockACLBindingRule.Selector = ""
+				mockACLBindingRule.BindType = structs.ACLBindingRuleBindTypePolicy
+				mockACLBindingRule.BindName = "${list.groups}"
+				must.NoError(t, srv.server.State().UpsertACLBindingRules(
+					30, []*structs.ACLBindingRule{mockACLBindingRule}, true))
+
+				testURL := "/v1/acl/auth-method/" + mockACLAuthMethod.Name + "/test"
+				body := encodeReq(structs.ACLAuthMethodTestRequest{
+					Claims: map[string]any{"groups": []string{mockACLPolicy.Name, "other"}},
+				})
+
+				// The endpoint only supports writes of the claims.
+				req, err := http.NewRequest(http.MethodGet, testURL, nil)
+				must.NoError(t, err)
+				_, err = srv.Server.ACLAuthMethodSpecificRequest(httptest.NewRecorder(), req)
+				must.ErrorContains(t, err, "Invalid method")
+
+				// Build the HTTP request.
+				req, err = http.NewRequest(http.MethodPost, testURL, body)
+				must.NoError(t, err)
+				respW := httptest.NewRecorder()
+
+				// Ensure we have a token set.
+				setToken(req, srv.RootToken)
+
+				// Send the HTTP request.
+				obj, err := srv.Server.ACLAuthMethodSpecificRequest(respW, req)
+				must.NoError(t, err)
+
+				resp := obj.(*structs.ACLAuthMethodTestResult)
+				must.Eq(t, []string{mockACLPolicy.Name}, resp.Policies)
+				must.Eq(t, []string{mockACLPolicy.Name, "other"}, resp.Claims.List["groups"])
+				must.Eq(t, "30", respW.Header().Get("X-Nomad-Index"))
+			},
+		},
 		{
 			name: "get auth-method",
 			testFn: func(srv *TestAgent) {
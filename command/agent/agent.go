@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -24,8 +25,10 @@ import (
 	"github.com/hashicorp/nomad/client"
 	clientconfig "github.com/hashicorp/nomad/client/config"
 	clientconsul "github.com/hashicorp/nomad/client/consul"
+	"github.com/hashicorp/nomad/client/lib/execrecorder"
 	"github.com/hashicorp/nomad/client/lib/idset"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	"github.com/hashicorp/nomad/client/logmon/logsink"
 	"github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/command/agent/event"
@@ -359,8 +362,24 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.JobTrackedVersions = *agentConfig.Server.JobTrackedVersions
 	}
 
+	if agentConfig.Server.VariableTrackedVersions != nil {
+		if *agentConfig.Server.VariableTrackedVersions < 0 {
+			return nil, fmt.Errorf("variable_tracked_versions must not be negative")
+		}
+		conf.VariableTrackedVersions = *agentConfig.Server.VariableTrackedVersions
+	}
+
+	if agentConfig.Server.RequireExecSessionRecording != nil {
+		conf.RequireExecSessionRecording = *agentConfig.Server.RequireExecSessionRecording
+	}
+
 	conf.OIDCIssuer = agentConfig.Server.OIDCIssuer
 
+	if err := agentConfig.Server.WorkloadIdentity.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid workload_identity config: %v", err)
+	}
+	conf.WorkloadIdentityClaims = agentConfig.Server.WorkloadIdentity.Copy()
+
 	// Set up the bind addresses
 	rpcAddr, err := net.ResolveTCPAddr("tcp", agentConfig.normalizedAddrs.RPC)
 	if err != nil {
@@ -494,6 +513,17 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.RootKeyRotationThreshold = dur
 	}
+	if leadTime := agentConfig.Server.RootKeyPrepublishLeadTime; leadTime != "" {
+		dur, err := time.ParseDuration(leadTime)
+		if err != nil {
+			return nil, err
+		}
+		if dur <= 0 || dur >= conf.RootKeyRotationThreshold {
+			return nil, fmt.Errorf("root_key_prepublish_lead_time must be greater than 0 and less than root_key_rotation_threshold (%v)",
+				conf.RootKeyRotationThreshold)
+		}
+		conf.RootKeyPrepublishLeadTime = dur
+	}
 
 	if heartbeatGrace := agentConfig.Server.HeartbeatGrace; heartbeatGrace != 0 {
 		conf.HeartbeatGrace = heartbeatGrace
@@ -585,6 +615,7 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	} else {
 		return nil, fmt.Errorf("deploy_query_rate_limit must be greater than 0")
 	}
+	conf.DeploymentVerifyAllowedHosts = agentConfig.Server.DeploymentVerifyAllowedHosts
 
 	// Set plan rejection tracker configuration.
 	if planRejectConf := agentConfig.Server.PlanRejectionTracker; planRejectConf != nil {
@@ -600,6 +631,18 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 	}
 
+	// Set built-in autoscaler configuration.
+	if autoscalerConf := agentConfig.Server.Autoscaler; autoscalerConf != nil {
+		if autoscalerConf.Enabled != nil {
+			conf.AutoscalerEnabled = *autoscalerConf.Enabled
+		}
+		if autoscalerConf.EvaluationInterval < 0 {
+			return nil, fmt.Errorf("autoscaler.evaluation_interval must be greater than 0")
+		} else if autoscalerConf.EvaluationInterval > 0 {
+			conf.AutoscalerInterval = autoscalerConf.EvaluationInterval
+		}
+	}
+
 	// Add Enterprise license configs
 	conf.LicenseConfig = &nomad.LicenseConfig{
 		BuildDate:         agentConfig.Version.BuildDate,
@@ -788,6 +831,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 		conf.NetworkInterface = agentConfig.Client.NetworkInterface
 	}
 	conf.NodeMaxAllocs = agentConfig.Client.NodeMaxAllocs
+	conf.UnhealthyDeviceAction = agentConfig.Client.UnhealthyDeviceAction
 
 	// handle rpc yamux configuration
 	conf.RPCSessionConfig = yamux.DefaultConfig()
@@ -949,6 +993,14 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.GCMaxAllocs = agentConfig.Client.GCMaxAllocs
 	conf.GCVolumesOnNodeGC = agentConfig.Client.GCVolumesOnNodeGC
 
+	// Set the burstable eviction configs
+	conf.BurstableMemoryHeadroomMB = agentConfig.Client.BurstableMemoryHeadroomMB
+	if agentConfig.Client.BurstableEvictionInterval != 0 {
+		conf.BurstableEvictionInterval = agentConfig.Client.BurstableEvictionInterval
+	}
+	conf.MemoryPressureThreshold = agentConfig.Client.MemoryPressureThreshold
+	conf.MemoryPressureAction = agentConfig.Client.MemoryPressureAction
+
 	if agentConfig.Client.NoHostUUID != nil {
 		conf.NoHostUUID = *agentConfig.Client.NoHostUUID
 	} else {
@@ -990,7 +1042,21 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 		}
 		conf.BridgeNetworkAllocSubnetIPv6 = ipv6Subnet
 	}
+	if agentConfig.Client.BridgeNetworkIPv6Only {
+		if ipv6Subnet == "" {
+			return nil, fmt.Errorf("bridge_network_ipv6_only requires bridge_network_subnet_ipv6")
+		}
+		if ipv4Subnet != "" {
+			return nil, fmt.Errorf("bridge_network_subnet cannot be set with bridge_network_ipv6_only")
+		}
+		conf.BridgeNetworkIPv6Only = true
+	}
 	conf.BridgeNetworkHairpinMode = agentConfig.Client.BridgeNetworkHairpinMode
+	firewallBackend, err := clientconfig.ParseBridgeFirewallBackend(agentConfig.Client.BridgeNetworkFirewallBackend)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bridge_network_firewall_backend: %w", err)
+	}
+	conf.BridgeNetworkFirewallBackend = firewallBackend
 
 	for _, hn := range agentConfig.Client.HostNetworks {
 		conf.HostNetworks[hn.Name] = hn
@@ -1016,6 +1082,47 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 
 	conf.Users = clientconfig.UsersConfigFromAgent(agentConfig.Client.Users)
 
+	conf.AllocHooks, err = clientconfig.AllocHookConfigsFromAgent(agentConfig.Client.AllocHooks)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alloc_hook config: %v", err)
+	}
+
+	if rec := agentConfig.Client.ExecSessionRecording; rec != nil && rec.Enabled != nil && *rec.Enabled {
+		path := filepath.Join(agentConfig.DataDir, "exec_sessions")
+		if rec.Path != nil && *rec.Path != "" {
+			path = *rec.Path
+		}
+		conf.ExecSessionSink, err = execrecorder.NewFileSink(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exec_session_recording config: %v", err)
+		}
+	}
+
+	conf.HostVolumeLimits, err = clientconfig.HostVolumeLimitsFromAgent(agentConfig.Client.HostVolumeLimits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host_volume_limits config: %v", err)
+	}
+
+	conf.ServiceDNS, err = clientconfig.ServiceDNSConfigFromAgent(agentConfig.Client.ServiceDNS)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service_dns config: %v", err)
+	}
+
+	for _, sink := range agentConfig.Client.LogSinks {
+		c := &logsink.Config{
+			Type:     sink.Type,
+			Address:  sink.Address,
+			Path:     sink.Path,
+			Tag:      sink.Tag,
+			Facility: sink.Facility,
+		}
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid log_sink config: %v", err)
+		}
+		conf.LogSinks = append(conf.LogSinks, c)
+	}
+	conf.LogSinkAllowedAddresses = slices.Clone(agentConfig.Client.LogSinkAllowedAddresses)
+
 	return conf, nil
 }
 
@@ -7,6 +7,8 @@
 package agent
 
 import (
+	"fmt"
+
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/nomad/structs/config"
 )
@@ -17,11 +19,23 @@ type EnterpriseAgent struct{}
 
 func (a *Agent) setupEnterpriseAgent(log hclog.Logger) error {
 	// configure eventer
-	a.auditor = &noOpAuditor{}
+	eventer, err := newAuditor(a.config.Audit, a.config.DataDir, log)
+	if err != nil {
+		return fmt.Errorf("failed to setup audit logging: %v", err)
+	}
+	a.auditor = eventer
+
+	// the server audits the RPC requests it serves
+	if a.server != nil {
+		a.server.SetAuditor(eventer)
+	}
 
 	return nil
 }
 
 func (a *Agent) entReloadEventer(cfg *config.AuditConfig) error {
+	if eventer, ok := a.auditor.(*auditor); ok {
+		return eventer.Reload(cfg)
+	}
 	return nil
 }
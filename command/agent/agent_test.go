@@ -15,6 +15,7 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	clientconfig "github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/logmon/logsink"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/testlog"
@@ -654,6 +655,56 @@ func TestAgent_ServerConfig_RaftSnapshotThreshold(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_RootKeyPrepublishLeadTime(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		threshold string
+		leadTime  string
+		expect    time.Duration
+		expectErr string
+	}{
+		{
+			name:   "empty",
+			expect: 0,
+		},
+		{
+			name:     "good",
+			leadTime: "48h",
+			expect:   48 * time.Hour,
+		},
+		{
+			name:      "not less than threshold",
+			threshold: "24h",
+			leadTime:  "24h",
+			expectErr: "root_key_prepublish_lead_time must be greater than 0 and less than root_key_rotation_threshold (24h0m0s)",
+		},
+		{
+			name:      "negative",
+			leadTime:  "-1h",
+			expectErr: "root_key_prepublish_lead_time must be greater than 0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := DevConfig(nil)
+			must.NoError(t, conf.normalizeAddrs())
+
+			conf.Server.RootKeyRotationThreshold = tc.threshold
+			conf.Server.RootKeyPrepublishLeadTime = tc.leadTime
+			nc, err := convertServerConfig(conf)
+			if tc.expectErr != "" {
+				must.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expect, nc.RootKeyPrepublishLeadTime)
+		})
+	}
+}
+
 func TestAgent_ServerConfig_RaftProtocol_3(t *testing.T) {
 	ci.Parallel(t)
 
@@ -736,6 +787,7 @@ func Test_convertServerConfig_errors(t *testing.T) {
 
 func TestConvertClientConfig(t *testing.T) {
 	ci.Parallel(t)
+	recordingDir := t.TempDir()
 	cases := []struct {
 		name string
 		// modConfig modifies the agent config before passing to convertClientConfig()
@@ -796,6 +848,49 @@ func TestConvertClientConfig(t *testing.T) {
 			},
 			expectErr: "invalid bridge_network_subnet_ipv6: not an IPv6 address: 10.0.0.1/24",
 		},
+		{
+			name: "ipv6 only bridge",
+			modConfig: func(c *Config) {
+				c.Client.BridgeNetworkSubnetIPv6 = "fd00:a110:c8::/120"
+				c.Client.BridgeNetworkIPv6Only = true
+			},
+			assert: func(t *testing.T, cc *clientconfig.Config) {
+				must.True(t, cc.BridgeNetworkIPv6Only)
+				must.Eq(t, "fd00:a110:c8::/120", cc.BridgeNetworkAllocSubnetIPv6)
+			},
+		},
+		{
+			name: "ipv6 only bridge without ipv6 subnet",
+			modConfig: func(c *Config) {
+				c.Client.BridgeNetworkIPv6Only = true
+			},
+			expectErr: "bridge_network_ipv6_only requires bridge_network_subnet_ipv6",
+		},
+		{
+			name: "ipv6 only bridge with ipv4 subnet",
+			modConfig: func(c *Config) {
+				c.Client.BridgeNetworkSubnet = "10.0.0.0/24"
+				c.Client.BridgeNetworkSubnetIPv6 = "fd00:a110:c8::/120"
+				c.Client.BridgeNetworkIPv6Only = true
+			},
+			expectErr: "bridge_network_subnet cannot be set with bridge_network_ipv6_only",
+		},
+		{
+			name: "nftables firewall backend",
+			modConfig: func(c *Config) {
+				c.Client.BridgeNetworkFirewallBackend = "nftables"
+			},
+			assert: func(t *testing.T, cc *clientconfig.Config) {
+				must.Eq(t, clientconfig.BridgeFirewallBackendNFTables, cc.BridgeNetworkFirewallBackend)
+			},
+		},
+		{
+			name: "invalid firewall backend",
+			modConfig: func(c *Config) {
+				c.Client.BridgeNetworkFirewallBackend = "ebtables"
+			},
+			expectErr: `invalid bridge_network_firewall_backend: must be one of "auto", "iptables", or "nftables"`,
+		},
 		{
 			name: "hook metrics enabled (default value)",
 			modConfig: func(c *Config) {
@@ -814,6 +909,49 @@ func TestConvertClientConfig(t *testing.T) {
 				must.True(t, cc.DisableAllocationHookMetrics)
 			},
 		},
+		{
+			name: "exec session recording disabled (default value)",
+			assert: func(t *testing.T, cc *clientconfig.Config) {
+				must.Nil(t, cc.ExecSessionSink)
+			},
+		},
+		{
+			name: "exec session recording enabled",
+			modConfig: func(c *Config) {
+				c.Client.ExecSessionRecording = &config.ExecSessionRecordingConfig{
+					Enabled: pointer.Of(true),
+					Path:    pointer.Of(filepath.Join(recordingDir, "sessions")),
+				}
+			},
+			assert: func(t *testing.T, cc *clientconfig.Config) {
+				must.NotNil(t, cc.ExecSessionSink)
+				must.DirExists(t, filepath.Join(recordingDir, "sessions"))
+			},
+		},
+		{
+			name: "log sinks",
+			modConfig: func(c *Config) {
+				c.Client.LogSinks = []*config.LogSinkConfig{
+					{Type: "syslog", Address: "udp://127.0.0.1:514", Facility: "local0"},
+					{Type: "file", Path: "/var/log/nomad/tasks.json"},
+				}
+			},
+			assert: func(t *testing.T, cc *clientconfig.Config) {
+				must.Eq(t, []*logsink.Config{
+					{Type: "syslog", Address: "udp://127.0.0.1:514", Facility: "local0"},
+					{Type: "file", Path: "/var/log/nomad/tasks.json"},
+				}, cc.LogSinks)
+			},
+		},
+		{
+			name: "invalid log sink",
+			modConfig: func(c *Config) {
+				c.Client.LogSinks = []*config.LogSinkConfig{
+					{Type: "file", Path: "tasks.json"},
+				}
+			},
+			expectErr: "invalid log_sink config",
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
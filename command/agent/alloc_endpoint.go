@@ -14,10 +14,12 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/snappy"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/api"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -93,6 +95,8 @@ func (s *HTTPServer) AllocSpecificRequest(resp http.ResponseWriter, req *http.Re
 		return s.allocChecks(allocID, resp, req)
 	case "stop":
 		return s.allocStop(allocID, resp, req)
+	case "resize":
+		return s.allocResize(allocID, resp, req)
 	case "services":
 		return s.allocServiceRegistrations(resp, req, allocID)
 	}
@@ -176,6 +180,52 @@ func (s *HTTPServer) allocStop(allocID string, resp http.ResponseWriter, req *ht
 	return &out, nil
 }
 
+func (s *HTTPServer) allocResize(allocID string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if !(req.Method == http.MethodPost || req.Method == http.MethodPut) {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args api.AllocResizeRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, fmt.Sprintf("Failed to decode body: %v", err))
+	}
+
+	sr := &structs.AllocResizeRequest{
+		AllocID:   allocID,
+		Resources: make(map[string]*structs.Resources, len(args.Resources)),
+	}
+	for task, r := range args.Resources {
+		if r == nil || r.CPU == nil || r.MemoryMB == nil {
+			return nil, CodedError(400, fmt.Sprintf("CPU and MemoryMB of task %q must be set", task))
+		}
+		resources := &structs.Resources{
+			CPU:      *r.CPU,
+			MemoryMB: *r.MemoryMB,
+		}
+		if r.CPUMax != nil {
+			resources.CPUMax = *r.CPUMax
+		}
+		if r.MemoryMaxMB != nil {
+			resources.MemoryMaxMB = *r.MemoryMaxMB
+		}
+		sr.Resources[task] = resources
+	}
+	s.parseWriteRequest(req, &sr.WriteRequest)
+
+	var out structs.AllocResizeResponse
+	rpcErr := s.agent.RPC("Alloc.Resize", &sr, &out)
+
+	if rpcErr != nil {
+		if structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, allocNotFoundErr)
+		}
+		return nil, rpcErr
+	}
+
+	setIndex(resp, out.Index)
+	return &out, nil
+}
+
 // allocServiceRegistrations returns a list of all service registrations
 // assigned to the job identifier. It is callable via the
 // /v1/allocation/:alloc_id/services HTTP API and uses the
@@ -239,6 +289,8 @@ func (s *HTTPServer) ClientAllocRequest(resp http.ResponseWriter, req *http.Requ
 		return s.allocSignal(allocID, resp, req)
 	case "pause":
 		return s.allocPause(allocID, resp, req)
+	case "template-debug":
+		return s.allocTemplateDebug(allocID, resp, req)
 	}
 
 	return nil, CodedError(404, resourceNotFoundErr)
@@ -547,6 +599,59 @@ func (s *HTTPServer) allocStats(allocID string, resp http.ResponseWriter, req *h
 	return reply.Stats, rpcErr
 }
 
+// defaultTemplateDebugTimeout is how long a template debug request waits for
+// template dependencies when the request does not set a timeout
+const defaultTemplateDebugTimeout = 5 * time.Second
+
+func (s *HTTPServer) allocTemplateDebug(allocID string, resp http.ResponseWriter, req *http.Request) (any, error) {
+	task := req.URL.Query().Get("task")
+	if task == "" {
+		return nil, CodedError(400, "must specify task")
+	}
+
+	timeout := defaultTemplateDebugTimeout
+	if raw := req.URL.Query().Get("timeout"); raw != "" {
+		var err error
+		timeout, err = time.ParseDuration(raw)
+		if err != nil || timeout <= 0 {
+			return nil, CodedError(400, fmt.Sprintf("invalid timeout %q", raw))
+		}
+	}
+
+	// Build the request and parse the ACL token
+	args := cstructs.AllocTemplateDebugRequest{
+		AllocID: allocID,
+		Task:    task,
+		Timeout: timeout,
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Determine the handler to use
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForAlloc(allocID)
+
+	// Make the RPC
+	var reply cstructs.AllocTemplateDebugResponse
+	var rpcErr error
+	switch {
+	case useLocalClient:
+		rpcErr = s.agent.Client().ClientRPC("Allocations.TemplateDebug", &args, &reply)
+	case useClientRPC:
+		rpcErr = s.agent.Client().RPC("ClientAllocations.TemplateDebug", &args, &reply)
+	case useServerRPC:
+		rpcErr = s.agent.Server().RPC("ClientAllocations.TemplateDebug", &args, &reply)
+	default:
+		rpcErr = CodedError(400, "No local Node and node_id not provided")
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+	}
+
+	return reply.Templates, rpcErr
+}
+
 func (s *HTTPServer) allocChecks(allocID string, resp http.ResponseWriter, req *http.Request) (any, error) {
 	// Build the request and parse the ACL token
 	args := cstructs.AllocChecksRequest{
@@ -618,6 +723,14 @@ func (s *HTTPServer) allocExec(allocID string, resp http.ResponseWriter, req *ht
 		return nil, err
 	}
 
+	// the token may only be sent in the handshake, after the wrapper checked
+	// the source address of the token of the request
+	if err := s.checkTokenSource(req, args.AuthToken); err != nil {
+		conn.WriteMessage(websocket.CloseMessage,
+			websocket.FormatCloseMessage(toWsCode(403), err.Error()))
+		return nil, err
+	}
+
 	return s.execStream(conn, &args)
 }
 
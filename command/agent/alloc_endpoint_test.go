@@ -22,6 +22,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -444,6 +445,54 @@ func TestHTTP_AllocStop(t *testing.T) {
 	})
 }
 
+func TestHTTP_AllocResize(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		node := mock.Node()
+		alloc := mock.Alloc()
+		alloc.NodeID = node.ID
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 998, node))
+		must.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+		must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+		// Test that the happy path works
+		args := api.AllocResizeRequest{
+			Resources: map[string]*api.Resources{
+				"web": {CPU: pointer.Of(1000), MemoryMB: pointer.Of(512)},
+			},
+		}
+		req, err := http.NewRequest(http.MethodPut, "/v1/allocation/"+alloc.ID+"/resize", encodeReq(args))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.AllocSpecificRequest(respW, req)
+		must.NoError(t, err)
+		resp := obj.(*structs.AllocResizeResponse)
+		headerIndex, _ := strconv.ParseUint(respW.Header().Get("X-Nomad-Index"), 10, 64)
+		must.Eq(t, resp.Index, headerIndex)
+
+		out, err := state.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		must.Eq(t, 1000, out.AllocatedResources.Tasks["web"].Cpu.CpuShares)
+		must.Eq(t, 512, out.AllocatedResources.Tasks["web"].Memory.MemoryMB)
+
+		// Test that the memory of the task is required
+		args.Resources["web"].MemoryMB = nil
+		req, err = http.NewRequest(http.MethodPut, "/v1/allocation/"+alloc.ID+"/resize", encodeReq(args))
+		must.NoError(t, err)
+		_, err = s.Server.AllocSpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "must be set")
+
+		// Test that we 404 when the allocid is invalid
+		args.Resources["web"].MemoryMB = pointer.Of(512)
+		req, err = http.NewRequest(http.MethodPut, "/v1/allocation/"+uuid.Generate()+"/resize", encodeReq(args))
+		must.NoError(t, err)
+		_, err = s.Server.AllocSpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, allocNotFoundErr)
+	})
+}
+
 func TestHTTP_allocServiceRegistrations(t *testing.T) {
 	ci.Parallel(t)
 
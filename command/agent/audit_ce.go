@@ -0,0 +1,521 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+// +build !ent
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	gsyslog "github.com/hashicorp/go-syslog"
+	"github.com/hashicorp/nomad/command/agent/event"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/ryanuber/go-glob"
+)
+
+const (
+	// auditSinkTypeFile, auditSinkTypeSyslog, and auditSinkTypeWebhook are
+	// the types of audit sinks.
+	auditSinkTypeFile    = "file"
+	auditSinkTypeSyslog  = "syslog"
+	auditSinkTypeWebhook = "webhook"
+
+	// auditDeliveryEnforced and auditDeliveryBestEffort are the delivery
+	// guarantees of audit sinks. Requests fail when their events can't be
+	// written to an enforced sink.
+	auditDeliveryEnforced   = "enforced"
+	auditDeliveryBestEffort = "best-effort"
+
+	// auditFormatJSON is the only format of audit sinks.
+	auditFormatJSON = "json"
+
+	// auditDefaultFileMode is the permissions of the files of file sinks that
+	// don't set mode.
+	auditDefaultFileMode os.FileMode = 0600
+
+	// auditDefaultRotateDuration is the rotation period of file sinks that
+	// don't set rotate_duration.
+	auditDefaultRotateDuration = 24 * time.Hour
+
+	// auditWebhookTimeout is how long webhook sinks wait for the webhook to
+	// respond.
+	auditWebhookTimeout = 10 * time.Second
+
+	// auditWebhookQueueSize is the number of events best-effort webhook sinks
+	// buffer before dropping events.
+	auditWebhookQueueSize = 1024
+)
+
+// auditor is the event.Auditor of the agent. It writes the audit events of the
+// HTTP and RPC requests the agent serves to the audit sinks, unless they are
+// excluded by a filter.
+type auditor struct {
+	logger  hclog.Logger
+	dataDir string
+
+	l        sync.RWMutex
+	enabled  bool
+	sinks    []auditSink
+	filters  []*config.AuditFilter
+	enforced bool
+}
+
+// Ensure auditor is an Auditor
+var _ event.Auditor = &auditor{}
+
+// auditSink is the destination of audit events.
+type auditSink interface {
+	// Name is the name of the sink in the audit config.
+	Name() string
+
+	// Enforced returns whether requests fail when their events can't be
+	// written to the sink.
+	Enforced() bool
+
+	// Write writes an encoded audit event to the sink.
+	Write(ctx context.Context, b []byte) error
+
+	// Reopen reopens the files the sink writes to.
+	Reopen() error
+
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// newAuditor returns an auditor for the audit config. The audit log of an
+// agent that enables auditing without configuring any sink is written to the
+// audit directory of the data dir.
+func newAuditor(cfg *config.AuditConfig, dataDir string, logger hclog.Logger) (*auditor, error) {
+	a := &auditor{
+		logger:  logger.Named("audit"),
+		dataDir: dataDir,
+	}
+	if err := a.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Reload replaces the sinks and filters of the auditor with the ones of the
+// audit config.
+func (a *auditor) Reload(cfg *config.AuditConfig) error {
+	enabled := cfg != nil && cfg.Enabled != nil && *cfg.Enabled
+
+	var sinks []auditSink
+	var filters []*config.AuditFilter
+	if enabled {
+		if err := validateAuditFilters(cfg.Filters); err != nil {
+			return err
+		}
+		filters = cfg.Filters
+
+		sinkConfigs := cfg.Sinks
+		if len(sinkConfigs) == 0 {
+			sinkConfigs = []*config.AuditSink{{
+				Name:              "default",
+				Type:              auditSinkTypeFile,
+				DeliveryGuarantee: auditDeliveryEnforced,
+			}}
+		}
+
+		for _, sinkConfig := range sinkConfigs {
+			sink, err := a.newSink(sinkConfig)
+			if err != nil {
+				for _, s := range sinks {
+					s.Close()
+				}
+				return fmt.Errorf("invalid audit sink %q: %v", sinkConfig.Name, err)
+			}
+			sinks = append(sinks, sink)
+		}
+	}
+
+	enforced := false
+	for _, sink := range sinks {
+		enforced = enforced || sink.Enforced()
+	}
+
+	a.l.Lock()
+	oldSinks := a.sinks
+	a.enabled = enabled
+	a.sinks = sinks
+	a.filters = filters
+	a.enforced = enforced
+	a.l.Unlock()
+
+	for _, sink := range oldSinks {
+		if err := sink.Close(); err != nil {
+			a.logger.Warn("failed to close audit sink", "sink", sink.Name(), "error", err)
+		}
+	}
+	return nil
+}
+
+func (a *auditor) newSink(cfg *config.AuditSink) (auditSink, error) {
+	if cfg.Format != "" && cfg.Format != auditFormatJSON {
+		return nil, fmt.Errorf("unsupported format %q", cfg.Format)
+	}
+
+	var enforced bool
+	switch cfg.DeliveryGuarantee {
+	case "", auditDeliveryEnforced:
+		enforced = true
+	case auditDeliveryBestEffort:
+	default:
+		return nil, fmt.Errorf("unsupported delivery guarantee %q", cfg.DeliveryGuarantee)
+	}
+
+	switch cfg.Type {
+	case auditSinkTypeFile:
+		return a.newFileSink(cfg, enforced)
+	case auditSinkTypeSyslog:
+		return newAuditSyslogSink(cfg, enforced)
+	case auditSinkTypeWebhook:
+		return newAuditWebhookSink(cfg, enforced, a.logger)
+	default:
+		return nil, fmt.Errorf("unsupported type %q", cfg.Type)
+	}
+}
+
+func validateAuditFilters(filters []*config.AuditFilter) error {
+	for _, f := range filters {
+		switch f.Type {
+		case event.HTTPEvent, event.RPCEvent:
+		default:
+			return fmt.Errorf("invalid audit filter %q: unsupported type %q", f.Name, f.Type)
+		}
+	}
+	return nil
+}
+
+// Event writes the audit event to the sinks of the auditor, unless a filter
+// excludes it. The error of enforced sinks is returned, while the error of
+// best-effort sinks is only logged.
+func (a *auditor) Event(ctx context.Context, eventType string, payload interface{}) error {
+	ev, ok := payload.(*event.AuditEvent)
+	if !ok {
+		return fmt.Errorf("unexpected audit event payload %T", payload)
+	}
+
+	a.l.RLock()
+	defer a.l.RUnlock()
+
+	if !a.enabled || a.filtered(ev) {
+		return nil
+	}
+
+	b, err := json.Marshal(&event.AuditEntry{
+		CreatedAt: time.Now(),
+		EventType: event.AuditEntryType,
+		Payload:   ev,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %v", err)
+	}
+	b = append(b, '\n')
+
+	var mErr *multierror.Error
+	for _, sink := range a.sinks {
+		if err := sink.Write(ctx, b); err != nil {
+			if sink.Enforced() {
+				mErr = multierror.Append(mErr, fmt.Errorf("audit sink %q: %v", sink.Name(), err))
+			} else {
+				a.logger.Warn("failed to write audit event", "sink", sink.Name(), "error", err)
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// filtered returns whether one of the filters of the auditor excludes the
+// audit event. A filter matches the events of its type that match at least
+// one of the globs of each of its lists, and empty lists match all events.
+// Query parameters are ignored when matching endpoints.
+func (a *auditor) filtered(ev *event.AuditEvent) bool {
+	endpoint, _, _ := strings.Cut(ev.Request.Endpoint, "?")
+	for _, f := range a.filters {
+		if f.Type != ev.Type ||
+			!auditGlobsMatch(f.Stages, ev.Stage) ||
+			!auditGlobsMatch(f.Endpoints, endpoint) ||
+			!auditGlobsMatch(f.Operations, ev.Request.Operation) ||
+			!auditGlobsMatch(f.Actors, ev.Actors()...) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// auditGlobsMatch returns whether one of the globs matches one of the values.
+func auditGlobsMatch(globs []string, values ...string) bool {
+	if len(globs) == 0 {
+		return true
+	}
+	for _, pattern := range globs {
+		for _, v := range values {
+			if glob.Glob(strings.ToLower(pattern), strings.ToLower(v)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Enabled returns whether auditing is enabled.
+func (a *auditor) Enabled() bool {
+	a.l.RLock()
+	defer a.l.RUnlock()
+	return a.enabled
+}
+
+// SetEnabled enables or disables auditing. Enabling auditing has no effect if
+// the agent has no audit sinks.
+func (a *auditor) SetEnabled(enabled bool) {
+	a.l.Lock()
+	defer a.l.Unlock()
+	a.enabled = enabled && len(a.sinks) > 0
+}
+
+// DeliveryEnforced returns whether one of the sinks of the auditor is
+// enforced.
+func (a *auditor) DeliveryEnforced() bool {
+	a.l.RLock()
+	defer a.l.RUnlock()
+	return a.enabled && a.enforced
+}
+
+// Reopen reopens the audit log files, which lets external tools rotate them.
+func (a *auditor) Reopen() error {
+	a.l.RLock()
+	defer a.l.RUnlock()
+
+	var mErr *multierror.Error
+	for _, sink := range a.sinks {
+		if err := sink.Reopen(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("audit sink %q: %v", sink.Name(), err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// auditFileSink writes audit events to a file that is rotated like the agent
+// log file.
+type auditFileSink struct {
+	name     string
+	enforced bool
+	file     *logFile
+}
+
+func (a *auditor) newFileSink(cfg *config.AuditSink, enforced bool) (*auditFileSink, error) {
+	path := cfg.Path
+	if path == "" {
+		if a.dataDir == "" {
+			return nil, errors.New("path is required when the agent has no data_dir")
+		}
+		path = filepath.Join(a.dataDir, "audit", "audit.log")
+	}
+
+	mode := auditDefaultFileMode
+	if cfg.Mode != "" {
+		m, err := strconv.ParseUint(cfg.Mode, 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mode %q: %v", cfg.Mode, err)
+		}
+		mode = os.FileMode(m)
+	}
+
+	dir, fileName := filepath.Split(path)
+	if fileName == "" {
+		fileName = "audit.log"
+	}
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %v", err)
+	}
+
+	duration := cfg.RotateDuration
+	if duration == 0 {
+		duration = auditDefaultRotateDuration
+	}
+
+	return &auditFileSink{
+		name:     cfg.Name,
+		enforced: enforced,
+		file: &logFile{
+			fileName: fileName,
+			logPath:  dir,
+			duration: duration,
+			MaxBytes: cfg.RotateBytes,
+			MaxFiles: cfg.RotateMaxFiles,
+			mode:     mode,
+		},
+	}, nil
+}
+
+func (s *auditFileSink) Name() string   { return s.name }
+func (s *auditFileSink) Enforced() bool { return s.enforced }
+func (s *auditFileSink) Reopen() error  { return s.file.Reopen() }
+func (s *auditFileSink) Close() error   { return s.file.Reopen() }
+
+func (s *auditFileSink) Write(_ context.Context, b []byte) error {
+	_, err := s.file.Write(b)
+	return err
+}
+
+// auditSyslogSink writes audit events to syslog.
+type auditSyslogSink struct {
+	name     string
+	enforced bool
+	logger   gsyslog.Syslogger
+}
+
+func newAuditSyslogSink(cfg *config.AuditSink, enforced bool) (*auditSyslogSink, error) {
+	facility := cfg.Facility
+	if facility == "" {
+		facility = "LOCAL0"
+	}
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "nomad"
+	}
+
+	l, err := gsyslog.NewLogger(gsyslog.LOG_NOTICE, facility, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup syslog: %v", err)
+	}
+	return &auditSyslogSink{
+		name:     cfg.Name,
+		enforced: enforced,
+		logger:   l,
+	}, nil
+}
+
+func (s *auditSyslogSink) Name() string   { return s.name }
+func (s *auditSyslogSink) Enforced() bool { return s.enforced }
+func (s *auditSyslogSink) Reopen() error  { return nil }
+func (s *auditSyslogSink) Close() error   { return s.logger.Close() }
+
+func (s *auditSyslogSink) Write(_ context.Context, b []byte) error {
+	_, err := s.logger.Write(bytes.TrimSuffix(b, []byte("\n")))
+	return err
+}
+
+// auditWebhookSink posts audit events to a webhook. Enforced sinks post the
+// events of requests before they proceed, while best-effort sinks queue them
+// and drop events when the webhook can't keep up.
+type auditWebhookSink struct {
+	name     string
+	enforced bool
+	address  string
+	headers  map[string]string
+	client   *http.Client
+	logger   hclog.Logger
+
+	queue     chan []byte
+	closeOnce sync.Once
+	doneCh    chan struct{}
+}
+
+func newAuditWebhookSink(cfg *config.AuditSink, enforced bool, logger hclog.Logger) (*auditWebhookSink, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("address is required")
+	}
+	u, err := url.Parse(cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid address %q: scheme must be http or https", cfg.Address)
+	}
+
+	s := &auditWebhookSink{
+		name:     cfg.Name,
+		enforced: enforced,
+		address:  cfg.Address,
+		headers:  cfg.Headers,
+		client:   &http.Client{Timeout: auditWebhookTimeout},
+		logger:   logger.With("sink", cfg.Name),
+		doneCh:   make(chan struct{}),
+	}
+	if !enforced {
+		s.queue = make(chan []byte, auditWebhookQueueSize)
+		go s.run()
+	}
+	return s, nil
+}
+
+func (s *auditWebhookSink) Name() string   { return s.name }
+func (s *auditWebhookSink) Enforced() bool { return s.enforced }
+func (s *auditWebhookSink) Reopen() error  { return nil }
+
+func (s *auditWebhookSink) Close() error {
+	s.closeOnce.Do(func() { close(s.doneCh) })
+	return nil
+}
+
+func (s *auditWebhookSink) Write(ctx context.Context, b []byte) error {
+	if s.enforced {
+		return s.post(ctx, b)
+	}
+
+	select {
+	case s.queue <- b:
+		return nil
+	default:
+		return errors.New("webhook queue is full, dropping event")
+	}
+}
+
+// run posts the queued events of best-effort sinks until the sink is closed.
+func (s *auditWebhookSink) run() {
+	for {
+		select {
+		case <-s.doneCh:
+			return
+		case b := <-s.queue:
+			if err := s.post(context.Background(), b); err != nil {
+				s.logger.Warn("failed to write audit event", "error", err)
+			}
+		}
+	}
+}
+
+func (s *auditWebhookSink) post(ctx context.Context, b []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.address, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,383 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+// +build !ent
+
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent/event"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// readAuditEvents returns the audit events of an audit log file.
+func readAuditEvents(t *testing.T, path string) []*event.AuditEvent {
+	t.Helper()
+
+	f, err := os.Open(path)
+	must.NoError(t, err)
+	defer f.Close()
+
+	var events []*event.AuditEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry event.AuditEntry
+		must.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		must.Eq(t, event.AuditEntryType, entry.EventType)
+		events = append(events, entry.Payload)
+	}
+	must.NoError(t, scanner.Err())
+	return events
+}
+
+func testAuditEvent(eventType, endpoint, operation string) *event.AuditEvent {
+	return &event.AuditEvent{
+		ID:        "ev",
+		Type:      eventType,
+		Stage:     event.StageOperationReceived,
+		Timestamp: time.Now().UTC(),
+		Version:   event.AuditEventVersion,
+		Request: &event.AuditRequest{
+			ID:        "req",
+			Operation: operation,
+			Endpoint:  endpoint,
+		},
+	}
+}
+
+func TestAuditor_Filters(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	a, err := newAuditor(&config.AuditConfig{
+		Enabled: pointer.Of(true),
+		Sinks: []*config.AuditSink{
+			{Name: "file", Type: "file", DeliveryGuarantee: "enforced", Path: path},
+		},
+		Filters: []*config.AuditFilter{
+			{
+				Name:      "metrics",
+				Type:      event.HTTPEvent,
+				Endpoints: []string{"/v1/metrics"},
+			},
+			{
+				Name:       "reads",
+				Type:       event.HTTPEvent,
+				Endpoints:  []string{"/v1/job/*"},
+				Operations: []string{"get"},
+			},
+			{
+				Name:   "heartbeats",
+				Type:   event.RPCEvent,
+				Stages: []string{event.StageOperationComplete},
+				Actors: []string{"client-*"},
+			},
+		},
+	}, "", testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.True(t, a.Enabled())
+	must.True(t, a.DeliveryEnforced())
+
+	heartbeat := testAuditEvent(event.RPCEvent, "Node.UpdateStatus", "write")
+	heartbeat.Auth = &event.AuditAuth{ClientID: "client-1"}
+	heartbeatComplete := testAuditEvent(event.RPCEvent, "Node.UpdateStatus", "write")
+	heartbeatComplete.Stage = event.StageOperationComplete
+	heartbeatComplete.Auth = &event.AuditAuth{ClientID: "client-1"}
+
+	events := []*event.AuditEvent{
+		testAuditEvent(event.HTTPEvent, "/v1/metrics?format=prometheus", "GET"),
+		testAuditEvent(event.HTTPEvent, "/v1/job/example", "GET"),
+		testAuditEvent(event.HTTPEvent, "/v1/job/example", "DELETE"),
+		testAuditEvent(event.RPCEvent, "/v1/metrics", "read"),
+		heartbeat,
+		heartbeatComplete,
+		testAuditEvent(event.HTTPEvent, "/v1/jobs", "POST"),
+	}
+	for _, ev := range events {
+		must.NoError(t, a.Event(context.Background(), ev.Type, ev))
+	}
+
+	// the metrics request, the job read, and the completed heartbeat are
+	// filtered out
+	audited := readAuditEvents(t, path)
+	must.Len(t, 4, audited)
+	must.Eq(t, "DELETE", audited[0].Request.Operation)
+	must.Eq(t, event.RPCEvent, audited[1].Type)
+	must.Eq(t, "Node.UpdateStatus", audited[2].Request.Endpoint)
+	must.Eq(t, "/v1/jobs", audited[3].Request.Endpoint)
+}
+
+func TestAuditor_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	a, err := newAuditor(&config.AuditConfig{}, "", testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.False(t, a.Enabled())
+	must.False(t, a.DeliveryEnforced())
+
+	ev := testAuditEvent(event.HTTPEvent, "/v1/jobs", "GET")
+	must.NoError(t, a.Event(context.Background(), ev.Type, ev))
+
+	// auditing can't be enabled without sinks
+	a.SetEnabled(true)
+	must.False(t, a.Enabled())
+}
+
+func TestAuditor_DefaultSink(t *testing.T) {
+	ci.Parallel(t)
+
+	dataDir := t.TempDir()
+	a, err := newAuditor(&config.AuditConfig{Enabled: pointer.Of(true)}, dataDir, testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	ev := testAuditEvent(event.HTTPEvent, "/v1/jobs", "GET")
+	must.NoError(t, a.Event(context.Background(), ev.Type, ev))
+	must.Len(t, 1, readAuditEvents(t, filepath.Join(dataDir, "audit", "audit.log")))
+
+	// agents without a data dir must configure the path of their sinks
+	_, err = newAuditor(&config.AuditConfig{Enabled: pointer.Of(true)}, "", testlog.HCLogger(t))
+	must.ErrorContains(t, err, "path is required")
+}
+
+func TestAuditor_InvalidConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		sink   *config.AuditSink
+		filter *config.AuditFilter
+		err    string
+	}{
+		{
+			name: "unknown sink type",
+			sink: &config.AuditSink{Name: "s", Type: "kafka"},
+			err:  `unsupported type "kafka"`,
+		},
+		{
+			name: "unknown format",
+			sink: &config.AuditSink{Name: "s", Type: "file", Format: "xml"},
+			err:  `unsupported format "xml"`,
+		},
+		{
+			name: "unknown delivery guarantee",
+			sink: &config.AuditSink{Name: "s", Type: "file", DeliveryGuarantee: "maybe"},
+			err:  `unsupported delivery guarantee "maybe"`,
+		},
+		{
+			name: "invalid mode",
+			sink: &config.AuditSink{Name: "s", Type: "file", Mode: "rw"},
+			err:  `invalid mode "rw"`,
+		},
+		{
+			name: "webhook without address",
+			sink: &config.AuditSink{Name: "s", Type: "webhook"},
+			err:  "address is required",
+		},
+		{
+			name: "webhook with invalid scheme",
+			sink: &config.AuditSink{Name: "s", Type: "webhook", Address: "ftp://example.com"},
+			err:  "scheme must be http or https",
+		},
+		{
+			name:   "unknown filter type",
+			filter: &config.AuditFilter{Name: "f", Type: "GRPCEvent"},
+			err:    `invalid audit filter "f": unsupported type "GRPCEvent"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.AuditConfig{Enabled: pointer.Of(true)}
+			if tc.sink != nil {
+				cfg.Sinks = []*config.AuditSink{tc.sink}
+			}
+			if tc.filter != nil {
+				cfg.Filters = []*config.AuditFilter{tc.filter}
+			}
+			_, err := newAuditor(cfg, t.TempDir(), testlog.HCLogger(t))
+			must.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestAuditor_FileSink_Mode(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	a, err := newAuditor(&config.AuditConfig{
+		Enabled: pointer.Of(true),
+		Sinks: []*config.AuditSink{
+			{Name: "file", Type: "file", Path: path, Mode: "0640"},
+		},
+	}, "", testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	ev := testAuditEvent(event.HTTPEvent, "/v1/jobs", "GET")
+	must.NoError(t, a.Event(context.Background(), ev.Type, ev))
+
+	stat, err := os.Stat(path)
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0640), stat.Mode().Perm())
+
+	// reopening lets the file be moved away by external tools
+	must.NoError(t, os.Rename(path, path+".old"))
+	must.NoError(t, a.Reopen())
+	must.NoError(t, a.Event(context.Background(), ev.Type, ev))
+	must.Len(t, 1, readAuditEvents(t, path))
+}
+
+func TestAuditor_WebhookSink(t *testing.T) {
+	ci.Parallel(t)
+
+	var lock sync.Mutex
+	var received []*event.AuditEvent
+	var authHeaders []string
+	requests := 0
+	fail := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests++
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var entry event.AuditEntry
+		if err := json.Unmarshal(body, &entry); err == nil {
+			received = append(received, entry.Payload)
+		}
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+	}))
+	defer ts.Close()
+
+	cfg := &config.AuditConfig{
+		Enabled: pointer.Of(true),
+		Sinks: []*config.AuditSink{
+			{
+				Name:              "webhook",
+				Type:              "webhook",
+				DeliveryGuarantee: "enforced",
+				Address:           ts.URL,
+				Headers:           map[string]string{"Authorization": "Bearer hunter2"},
+			},
+		},
+	}
+	a, err := newAuditor(cfg, "", testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	ev := testAuditEvent(event.HTTPEvent, "/v1/jobs", "GET")
+	must.NoError(t, a.Event(context.Background(), ev.Type, ev))
+
+	lock.Lock()
+	must.Len(t, 1, received)
+	must.Eq(t, "/v1/jobs", received[0].Request.Endpoint)
+	must.Eq(t, []string{"Bearer hunter2"}, authHeaders)
+	fail = true
+	lock.Unlock()
+
+	// enforced sinks fail the events the webhook rejects
+	must.ErrorContains(t, a.Event(context.Background(), ev.Type, ev), "status 503")
+
+	// best-effort sinks only log the failures
+	cfg.Sinks[0].DeliveryGuarantee = "best-effort"
+	must.NoError(t, a.Reload(cfg))
+	must.False(t, a.DeliveryEnforced())
+	must.NoError(t, a.Event(context.Background(), ev.Type, ev))
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return requests == 3
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	lock.Lock()
+	fail = false
+	lock.Unlock()
+
+	must.NoError(t, a.Event(context.Background(), ev.Type, ev))
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(received) == 2
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
+
+func TestHTTP_Audit(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	httpTest(t, func(c *Config) {
+		c.Client.Enabled = false
+		c.Audit = &config.AuditConfig{
+			Enabled: pointer.Of(true),
+			Sinks: []*config.AuditSink{
+				{Name: "file", Type: "file", DeliveryGuarantee: "enforced", Path: path},
+			},
+			Filters: []*config.AuditFilter{
+				// only audit the HTTP requests of the test
+				{Name: "rpc", Type: event.RPCEvent},
+			},
+		}
+	}, func(s *TestAgent) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/agent/self?namespace=default", nil)
+		must.NoError(t, err)
+		req.Header.Set("User-Agent", "audit-test")
+		respW := httptest.NewRecorder()
+		s.Server.wrap(s.Server.AgentSelfRequest)(respW, req)
+		must.Eq(t, http.StatusOK, respW.Code)
+
+		events := readAuditEvents(t, path)
+		must.Len(t, 2, events)
+
+		received, complete := events[0], events[1]
+		must.Eq(t, event.HTTPEvent, received.Type)
+		must.Eq(t, event.StageOperationReceived, received.Stage)
+		must.Eq(t, http.MethodGet, received.Request.Operation)
+		must.Eq(t, "/v1/agent/self?namespace=default", received.Request.Endpoint)
+		must.Eq(t, map[string]string{"id": "default"}, received.Request.Namespace)
+		must.Eq(t, "audit-test", received.Request.RequestMeta["user_agent"])
+		must.Nil(t, received.Response)
+
+		must.Eq(t, event.StageOperationComplete, complete.Stage)
+		must.Eq(t, received.ID, complete.ID)
+		must.Eq(t, http.StatusOK, complete.Response.StatusCode)
+
+		// requests fail when their events can't be written to an enforced
+		// sink
+		must.NoError(t, os.Remove(path))
+		must.NoError(t, os.Mkdir(path, 0700))
+		must.NoError(t, s.Agent.auditor.Reopen())
+
+		respW = httptest.NewRecorder()
+		s.Server.wrap(s.Server.AgentSelfRequest)(respW, req)
+		must.Eq(t, http.StatusInternalServerError, respW.Code)
+		must.StrContains(t, respW.Body.String(), "failed to write audit event")
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/go-metrics/compat/datadog"
 	"github.com/hashicorp/go-metrics/compat/prometheus"
 	gsyslog "github.com/hashicorp/go-syslog"
+	client "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/helper"
 	flaghelper "github.com/hashicorp/nomad/helper/flags"
 	gatedwriter "github.com/hashicorp/nomad/helper/gated-writer"
@@ -468,6 +469,25 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		}
 	}
 
+	if config.Client.TemplateConfig != nil {
+		for _, path := range config.Client.TemplateConfig.SandboxAllowedPaths {
+			if !filepath.IsAbs(path) {
+				c.Ui.Error(fmt.Sprintf("client.template.sandbox_allowed_paths %q must be an absolute path", path))
+				return false
+			}
+		}
+		for name, cmd := range config.Client.TemplateConfig.SourcePlugins {
+			if !client.ValidTemplateSourcePluginName.MatchString(name) {
+				c.Ui.Error(fmt.Sprintf("client.template.source_plugins %q must be a valid template function name", name))
+				return false
+			}
+			if len(cmd) == 0 {
+				c.Ui.Error(fmt.Sprintf("client.template.source_plugins %q must specify a command", name))
+				return false
+			}
+		}
+	}
+
 	if err := config.Client.Artifact.Validate(); err != nil {
 		c.Ui.Error(fmt.Sprintf("client.artifact block invalid: %v", err))
 		return false
@@ -480,6 +500,22 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		)
 		return false
 	}
+	if err := config.Client.UnhealthyDeviceAction.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid client.unhealthy_device_action value: %v", err))
+		return false
+	}
+	if interval := config.Client.BurstableEvictionInterval; interval <= 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid client.burstable_eviction_interval value: %v (must be greater than 0)", interval))
+		return false
+	}
+	if threshold := config.Client.MemoryPressureThreshold; threshold < 0 || threshold > 100 {
+		c.Ui.Error(fmt.Sprintf("Invalid client.memory_pressure_threshold value: %v (must be between 0 and 100)", threshold))
+		return false
+	}
+	if err := config.Client.MemoryPressureAction.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid client.memory_pressure_action value: %v", err))
+		return false
+	}
 	if err := config.RPC.Validate(); err != nil {
 		c.Ui.Error(fmt.Sprintf("rpc block invalid: %v)", err))
 		return false
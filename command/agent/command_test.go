@@ -10,10 +10,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/cli"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/ci"
+	client "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
@@ -331,6 +333,16 @@ func TestIsValidConfig(t *testing.T) {
 			},
 			err: "not allowed",
 		},
+		{
+			name: "NegativeBurstableEvictionInterval",
+			conf: Config{
+				Client: &ClientConfig{
+					Enabled:                   true,
+					BurstableEvictionInterval: -time.Second,
+				},
+			},
+			err: "burstable_eviction_interval",
+		},
 		{
 			name: "NegativeMinDynamicPort",
 			conf: Config{
@@ -432,6 +444,18 @@ func TestIsValidConfig(t *testing.T) {
 			},
 			err: "client.artifact block invalid: http_read_timeout must be > 0",
 		},
+		{
+			name: "BadTemplateSandboxAllowedPaths",
+			conf: Config{
+				Client: &ClientConfig{
+					Enabled: true,
+					TemplateConfig: &client.ClientTemplateConfig{
+						SandboxAllowedPaths: []string{"etc/ssl/certs"},
+					},
+				},
+			},
+			err: `client.template.sandbox_allowed_paths "etc/ssl/certs" must be an absolute path`,
+		},
 		{
 			name: "BadHostVolumeConfig",
 			conf: Config{
@@ -347,6 +347,25 @@ type ClientConfig struct {
 	// you know that a GC'd node can never come back
 	GCVolumesOnNodeGC bool `hcl:"gc_volumes_on_node_gc"`
 
+	// BurstableMemoryHeadroomMB is the available memory of the node in MB
+	// below which the client evicts burstable allocations using more memory
+	// than their minimum. Zero disables eviction.
+	BurstableMemoryHeadroomMB int `hcl:"burstable_memory_headroom"`
+
+	// BurstableEvictionInterval is the time interval at which the client
+	// checks whether burstable allocations must be evicted.
+	BurstableEvictionInterval    time.Duration
+	BurstableEvictionIntervalHCL string `hcl:"burstable_eviction_interval" json:"-"`
+
+	// MemoryPressureThreshold is the percentage of time the tasks of the node
+	// may stall on memory before the client acts on the lowest priority task
+	// using more memory than it reserved. Zero disables it.
+	MemoryPressureThreshold float64 `hcl:"memory_pressure_threshold"`
+
+	// MemoryPressureAction is the action taken on the task once the memory
+	// pressure threshold is exceeded: "reschedule" or "restart".
+	MemoryPressureAction client.MemoryPressureAction `hcl:"memory_pressure_action"`
+
 	// NoHostUUID disables using the host's UUID and will force generation of a
 	// random UUID.
 	NoHostUUID *bool `hcl:"no_host_uuid"`
@@ -386,6 +405,14 @@ type ClientConfig struct {
 	// the host
 	BridgeNetworkSubnetIPv6 string `hcl:"bridge_network_subnet_ipv6"`
 
+	// BridgeNetworkIPv6Only disables IPv4 on the bridge network, so that
+	// allocations only get addresses from BridgeNetworkSubnetIPv6
+	BridgeNetworkIPv6Only bool `hcl:"bridge_network_ipv6_only"`
+
+	// BridgeNetworkFirewallBackend is the firewall backend used to program the
+	// rules of the bridge network: "auto", "iptables", or "nftables"
+	BridgeNetworkFirewallBackend string `hcl:"bridge_network_firewall_backend"`
+
 	// BridgeNetworkHairpinMode is whether or not to enable hairpin mode on the
 	// internal bridge network
 	BridgeNetworkHairpinMode bool `hcl:"bridge_network_hairpin_mode"`
@@ -418,12 +445,38 @@ type ClientConfig struct {
 	// Users is used to configure parameters around operating system users.
 	Users *config.UsersConfig `hcl:"users"`
 
+	// ExecSessionRecording configures the recording of exec sessions.
+	ExecSessionRecording *config.ExecSessionRecordingConfig `hcl:"exec_session_recording"`
+
+	// ServiceDNS configures the DNS server for Nomad native services.
+	ServiceDNS *config.ServiceDNSConfig `hcl:"service_dns"`
+
+	// LogSinks are the remote log sinks the logs of all tasks are shipped to,
+	// derived from multiple `log_sink` blocks.
+	LogSinks []*config.LogSinkConfig `hcl:"-"`
+
+	// LogSinkAllowedAddresses are the syslog and Fluentd addresses the log
+	// sinks of jobs may ship logs to.
+	LogSinkAllowedAddresses []string `hcl:"log_sink_allowed_addresses"`
+
+	// AllocHooks are the commands run on the host before the network and
+	// volumes of each allocation are set up and after they are torn down.
+	AllocHooks []*config.AllocHookConfig `hcl:"alloc_hook"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 
 	// NodeMaxAllocs sets the maximum number of allocations per node
 	// Defaults to 0 and ignored if unset.
 	NodeMaxAllocs int `hcl:"node_max_allocs"`
+
+	// HostVolumeLimits caps the capacity of the dynamic host volumes placed
+	// on the node, in total and per namespace.
+	HostVolumeLimits *config.HostVolumeLimitsConfig `hcl:"host_volume_limits"`
+
+	// UnhealthyDeviceAction is the action taken on the allocations using a
+	// device that became unhealthy: "none", "reschedule", or "drain".
+	UnhealthyDeviceAction client.UnhealthyDeviceAction `hcl:"unhealthy_device_action"`
 }
 
 func (c *ClientConfig) Copy() *ClientConfig {
@@ -446,6 +499,12 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.Artifact = c.Artifact.Copy()
 	nc.Drain = c.Drain.Copy()
 	nc.Users = c.Users.Copy()
+	nc.ExecSessionRecording = c.ExecSessionRecording.Copy()
+	nc.ServiceDNS = c.ServiceDNS.Copy()
+	nc.LogSinks = helper.CopySlice(c.LogSinks)
+	nc.LogSinkAllowedAddresses = slices.Clone(c.LogSinkAllowedAddresses)
+	nc.AllocHooks = helper.CopySlice(c.AllocHooks)
+	nc.HostVolumeLimits = c.HostVolumeLimits.Copy()
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
 	return &nc
 }
@@ -604,6 +663,11 @@ type ServerConfig struct {
 	// collection interval.
 	RootKeyRotationThreshold string `hcl:"root_key_rotation_threshold"`
 
+	// RootKeyPrepublishLeadTime is how long before its rotation the next
+	// encryption key is prepublished. Defaults to half the rotation
+	// threshold.
+	RootKeyPrepublishLeadTime string `hcl:"root_key_prepublish_lead_time"`
+
 	// HeartbeatGrace is the grace period beyond the TTL to account for network,
 	// processing delays and clock skew before marking a node as "down".
 	HeartbeatGrace    time.Duration
@@ -680,6 +744,10 @@ type ServerConfig struct {
 	// detects potentially bad nodes.
 	PlanRejectionTracker *PlanRejectionTracker `hcl:"plan_rejection_tracker"`
 
+	// Autoscaler configures the built-in autoscaler, which scales task
+	// groups with target tracking scaling policies.
+	Autoscaler *Autoscaler `hcl:"autoscaler"`
+
 	// EnableEventBroker configures whether this server's state store
 	// will generate events for its event stream.
 	EnableEventBroker *bool `hcl:"enable_event_broker"`
@@ -710,6 +778,10 @@ type ServerConfig struct {
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64 `hcl:"deploy_query_rate_limit"`
 
+	// DeploymentVerifyAllowedHosts are the hosts, with or without a port, the
+	// verifications of deployments may query.
+	DeploymentVerifyAllowedHosts []string `hcl:"deployment_verify_allowed_hosts"`
+
 	// RaftBoltConfig configures boltdb as used by raft.
 	RaftBoltConfig *RaftBoltConfig `hcl:"raft_boltdb"`
 
@@ -746,11 +818,23 @@ type ServerConfig struct {
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions *int `hcl:"job_tracked_versions"`
 
+	// VariableTrackedVersions is the number of historic versions that are kept
+	// for each variable. If zero, no history is kept.
+	VariableTrackedVersions *int `hcl:"variable_tracked_versions"`
+
+	// RequireExecSessionRecording refuses alloc exec sessions to the clients
+	// that don't record them.
+	RequireExecSessionRecording *bool `hcl:"require_exec_session_recording"`
+
 	// OIDCIssuer if set enables OIDC Discovery and uses this value as the
 	// issuer. Third parties such as AWS IAM OIDC Provider expect the issuer to
 	// be a publicly accessible HTTPS URL signed by a trusted well-known CA.
 	OIDCIssuer string `hcl:"oidc_issuer"`
 
+	// WorkloadIdentity configures custom claims for the workload identities
+	// signed by the server.
+	WorkloadIdentity *config.WorkloadIdentityClaimsConfig `hcl:"workload_identity"`
+
 	// StartTimeout is a time duration such as "30s" or "1h". It is provided to
 	// the server so that it can time out setup and startup process that are
 	// expected to complete before the server is considered healthy. Without
@@ -767,11 +851,13 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.RaftMultiplier = pointer.Copy(s.RaftMultiplier)
 	ns.NumSchedulers = pointer.Copy(s.NumSchedulers)
 	ns.EnabledSchedulers = slices.Clone(s.EnabledSchedulers)
+	ns.DeploymentVerifyAllowedHosts = slices.Clone(s.DeploymentVerifyAllowedHosts)
 	ns.StartJoin = slices.Clone(s.StartJoin)
 	ns.RetryJoin = slices.Clone(s.RetryJoin)
 	ns.ServerJoin = s.ServerJoin.Copy()
 	ns.DefaultSchedulerConfig = s.DefaultSchedulerConfig.Copy()
 	ns.PlanRejectionTracker = s.PlanRejectionTracker.Copy()
+	ns.Autoscaler = s.Autoscaler.Copy()
 	ns.EnableEventBroker = pointer.Copy(s.EnableEventBroker)
 	ns.EventBufferSize = pointer.Copy(s.EventBufferSize)
 	ns.JobMaxSourceSize = pointer.Copy(s.JobMaxSourceSize)
@@ -785,6 +871,9 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.JobDefaultPriority = pointer.Copy(s.JobDefaultPriority)
 	ns.JobMaxPriority = pointer.Copy(s.JobMaxPriority)
 	ns.JobTrackedVersions = pointer.Copy(s.JobTrackedVersions)
+	ns.VariableTrackedVersions = pointer.Copy(s.VariableTrackedVersions)
+	ns.RequireExecSessionRecording = pointer.Copy(s.RequireExecSessionRecording)
+	ns.WorkloadIdentity = s.WorkloadIdentity.Copy()
 	return &ns
 }
 
@@ -972,6 +1061,56 @@ func (p *PlanRejectionTracker) Merge(b *PlanRejectionTracker) *PlanRejectionTrac
 	return &result
 }
 
+// Autoscaler is used in servers to configure the built-in autoscaler.
+type Autoscaler struct {
+	// Enabled controls if the built-in autoscaler is active or not.
+	Enabled *bool `hcl:"enabled"`
+
+	// EvaluationInterval is how often the autoscaler evaluates the target
+	// tracking scaling policies.
+	EvaluationInterval    time.Duration
+	EvaluationIntervalHCL string `hcl:"evaluation_interval" json:"-"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (a *Autoscaler) Copy() *Autoscaler {
+	if a == nil {
+		return nil
+	}
+
+	na := *a
+	na.Enabled = pointer.Copy(a.Enabled)
+	na.ExtraKeysHCL = slices.Clone(a.ExtraKeysHCL)
+	return &na
+}
+
+func (a *Autoscaler) Merge(b *Autoscaler) *Autoscaler {
+	if a == nil {
+		return b
+	}
+
+	result := *a
+
+	if b == nil {
+		return &result
+	}
+
+	if b.Enabled != nil {
+		result.Enabled = b.Enabled
+	}
+
+	if b.EvaluationInterval != 0 {
+		result.EvaluationInterval = b.EvaluationInterval
+	}
+	if b.EvaluationIntervalHCL != "" {
+		result.EvaluationIntervalHCL = b.EvaluationIntervalHCL
+	}
+
+	return &result
+}
+
 // Search is used in servers to configure search API options.
 type Search struct {
 	// FuzzyEnabled toggles whether the FuzzySearch API is enabled. If not
@@ -1538,21 +1677,22 @@ func DefaultConfig() *Config {
 			StreamCloseTimeoutHCL:     "5m",
 		},
 		Client: &ClientConfig{
-			Enabled:               false,
-			NodePool:              structs.NodePoolDefault,
-			MaxKillTimeout:        "30s",
-			ClientMinPort:         14000,
-			ClientMaxPort:         14512,
-			MinDynamicPort:        20000,
-			MaxDynamicPort:        32000,
-			Reserved:              &Resources{},
-			GCInterval:            1 * time.Minute,
-			GCParallelDestroys:    2,
-			GCDiskUsageThreshold:  80,
-			GCInodeUsageThreshold: 70,
-			GCMaxAllocs:           50,
-			NoHostUUID:            pointer.Of(true),
-			DisableRemoteExec:     false,
+			Enabled:                   false,
+			NodePool:                  structs.NodePoolDefault,
+			MaxKillTimeout:            "30s",
+			ClientMinPort:             14000,
+			ClientMaxPort:             14512,
+			MinDynamicPort:            20000,
+			MaxDynamicPort:            32000,
+			Reserved:                  &Resources{},
+			GCInterval:                1 * time.Minute,
+			GCParallelDestroys:        2,
+			GCDiskUsageThreshold:      80,
+			GCInodeUsageThreshold:     70,
+			GCMaxAllocs:               50,
+			BurstableEvictionInterval: 10 * time.Second,
+			NoHostUUID:                pointer.Of(true),
+			DisableRemoteExec:         false,
 			ServerJoin: &ServerJoin{
 				RetryJoin:        []string{},
 				RetryInterval:    30 * time.Second,
@@ -1578,6 +1718,10 @@ func DefaultConfig() *Config {
 				NodeThreshold: 100,
 				NodeWindow:    5 * time.Minute,
 			},
+			Autoscaler: &Autoscaler{
+				Enabled:            pointer.Of(false),
+				EvaluationInterval: 30 * time.Second,
+			},
 			ServerJoin: &ServerJoin{
 				RetryJoin:        []string{},
 				RetryInterval:    30 * time.Second,
@@ -1589,8 +1733,9 @@ func DefaultConfig() *Config {
 				LimitResults:  100,
 				MinTermLength: 2,
 			},
-			JobMaxSourceSize:   pointer.Of("1M"),
-			JobTrackedVersions: pointer.Of(structs.JobDefaultTrackedVersions),
+			JobMaxSourceSize:        pointer.Of("1M"),
+			JobTrackedVersions:      pointer.Of(structs.JobDefaultTrackedVersions),
+			VariableTrackedVersions: pointer.Of(structs.VariableDefaultTrackedVersions),
 		},
 		ACL: &ACLConfig{
 			Enabled:   false,
@@ -2339,6 +2484,9 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.RootKeyRotationThreshold != "" {
 		result.RootKeyRotationThreshold = b.RootKeyRotationThreshold
 	}
+	if b.RootKeyPrepublishLeadTime != "" {
+		result.RootKeyPrepublishLeadTime = b.RootKeyPrepublishLeadTime
+	}
 	if b.HeartbeatGrace != 0 {
 		result.HeartbeatGrace = b.HeartbeatGrace
 	}
@@ -2405,6 +2553,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.PlanRejectionTracker = result.PlanRejectionTracker.Merge(b.PlanRejectionTracker)
 	}
 
+	if b.Autoscaler != nil {
+		result.Autoscaler = result.Autoscaler.Merge(b.Autoscaler)
+	}
+
 	if b.DefaultSchedulerConfig != nil {
 		c := *b.DefaultSchedulerConfig
 		result.DefaultSchedulerConfig = &c
@@ -2414,6 +2566,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.DeploymentQueryRateLimit = b.DeploymentQueryRateLimit
 	}
 
+	if len(b.DeploymentVerifyAllowedHosts) != 0 {
+		result.DeploymentVerifyAllowedHosts = slices.Clone(b.DeploymentVerifyAllowedHosts)
+	}
+
 	if b.Search != nil {
 		result.Search = &Search{FuzzyEnabled: b.Search.FuzzyEnabled}
 		if b.Search.LimitQuery > 0 {
@@ -2449,9 +2605,21 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.JobTrackedVersions = b.JobTrackedVersions
 	}
 
+	if b.VariableTrackedVersions != nil {
+		result.VariableTrackedVersions = b.VariableTrackedVersions
+	}
+
+	if b.RequireExecSessionRecording != nil {
+		result.RequireExecSessionRecording = b.RequireExecSessionRecording
+	}
+
 	if b.OIDCIssuer != "" {
 		result.OIDCIssuer = b.OIDCIssuer
 	}
+
+	if b.WorkloadIdentity != nil {
+		result.WorkloadIdentity = result.WorkloadIdentity.Merge(b.WorkloadIdentity)
+	}
 	if b.StartTimeout != "" {
 		result.StartTimeout = b.StartTimeout
 	}
@@ -2571,6 +2739,21 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.GCVolumesOnNodeGC {
 		result.GCVolumesOnNodeGC = b.GCVolumesOnNodeGC
 	}
+	if b.BurstableMemoryHeadroomMB != 0 {
+		result.BurstableMemoryHeadroomMB = b.BurstableMemoryHeadroomMB
+	}
+	if b.BurstableEvictionInterval != 0 {
+		result.BurstableEvictionInterval = b.BurstableEvictionInterval
+	}
+	if b.BurstableEvictionIntervalHCL != "" {
+		result.BurstableEvictionIntervalHCL = b.BurstableEvictionIntervalHCL
+	}
+	if b.MemoryPressureThreshold != 0 {
+		result.MemoryPressureThreshold = b.MemoryPressureThreshold
+	}
+	if b.MemoryPressureAction != "" {
+		result.MemoryPressureAction = b.MemoryPressureAction
+	}
 	// NoHostUUID defaults to true, merge if false
 	if b.NoHostUUID != nil {
 		result.NoHostUUID = b.NoHostUUID
@@ -2636,6 +2819,12 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.BridgeNetworkSubnetIPv6 != "" {
 		result.BridgeNetworkSubnetIPv6 = b.BridgeNetworkSubnetIPv6
 	}
+	if b.BridgeNetworkIPv6Only {
+		result.BridgeNetworkIPv6Only = true
+	}
+	if b.BridgeNetworkFirewallBackend != "" {
+		result.BridgeNetworkFirewallBackend = b.BridgeNetworkFirewallBackend
+	}
 	if b.BridgeNetworkHairpinMode {
 		result.BridgeNetworkHairpinMode = true
 	}
@@ -2663,10 +2852,33 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	result.Artifact = a.Artifact.Merge(b.Artifact)
 	result.Drain = a.Drain.Merge(b.Drain)
 	result.Users = a.Users.Merge(b.Users)
+	result.ExecSessionRecording = a.ExecSessionRecording.Merge(b.ExecSessionRecording)
+	result.ServiceDNS = a.ServiceDNS.Merge(b.ServiceDNS)
+
+	result.LogSinks = a.LogSinks
+	if len(b.LogSinks) != 0 {
+		result.LogSinks = append(slices.Clone(result.LogSinks), b.LogSinks...)
+	}
+
+	if len(b.LogSinkAllowedAddresses) != 0 {
+		result.LogSinkAllowedAddresses = slices.Clone(b.LogSinkAllowedAddresses)
+	}
+
+	if len(a.AllocHooks) == 0 && len(b.AllocHooks) != 0 {
+		result.AllocHooks = helper.CopySlice(b.AllocHooks)
+	} else if len(b.AllocHooks) != 0 {
+		result.AllocHooks = config.AllocHookSliceMerge(a.AllocHooks, b.AllocHooks)
+	}
 
 	if b.NodeMaxAllocs != 0 {
 		result.NodeMaxAllocs = b.NodeMaxAllocs
 	}
+
+	result.HostVolumeLimits = a.HostVolumeLimits.Merge(b.HostVolumeLimits)
+
+	if b.UnhealthyDeviceAction != "" {
+		result.UnhealthyDeviceAction = b.UnhealthyDeviceAction
+	}
 	return &result
 }
 
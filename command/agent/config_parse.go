@@ -55,6 +55,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		},
 		Server: &ServerConfig{
 			PlanRejectionTracker: &PlanRejectionTracker{},
+			Autoscaler:           &Autoscaler{},
 			ServerJoin:           &ServerJoin{},
 		},
 		ACL:       &ACLConfig{},
@@ -95,6 +96,13 @@ func ParseConfigFile(path string) (*Config, error) {
 		}
 	}
 
+	matches = list.Filter("client")
+	if len(matches.Items) > 0 {
+		if err := parseLogSinks(c, matches); err != nil {
+			return nil, fmt.Errorf("error parsing 'log_sink': %w", err)
+		}
+	}
+
 	matches = list.Filter("keyring")
 	if len(matches.Items) > 0 {
 		if err := parseKeyringConfigs(c, matches); err != nil {
@@ -105,6 +113,7 @@ func ParseConfigFile(path string) (*Config, error) {
 	// convert strings to time.Durations
 	tds := []durationConversionMap{
 		{"gc_interval", &c.Client.GCInterval, &c.Client.GCIntervalHCL, nil},
+		{"burstable_eviction_interval", &c.Client.BurstableEvictionInterval, &c.Client.BurstableEvictionIntervalHCL, nil},
 		{"acl.token_ttl", &c.ACL.TokenTTL, &c.ACL.TokenTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.PolicyTTL, &c.ACL.PolicyTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.RoleTTL, &c.ACL.RoleTTLHCL, nil},
@@ -115,6 +124,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		{"server.min_heartbeat_ttl", &c.Server.MinHeartbeatTTL, &c.Server.MinHeartbeatTTLHCL, nil},
 		{"server.failover_heartbeat_ttl", &c.Server.FailoverHeartbeatTTL, &c.Server.FailoverHeartbeatTTLHCL, nil},
 		{"server.plan_rejection_tracker.node_window", &c.Server.PlanRejectionTracker.NodeWindow, &c.Server.PlanRejectionTracker.NodeWindowHCL, nil},
+		{"server.autoscaler.evaluation_interval", &c.Server.Autoscaler.EvaluationInterval, &c.Server.Autoscaler.EvaluationIntervalHCL, nil},
 		{"server.retry_interval", &c.Server.RetryInterval, &c.Server.RetryIntervalHCL, nil},
 		{"server.server_join.retry_interval", &c.Server.ServerJoin.RetryInterval, &c.Server.ServerJoin.RetryIntervalHCL, nil},
 		{"autopilot.server_stabilization_time", &c.Autopilot.ServerStabilizationTime, &c.Autopilot.ServerStabilizationTimeHCL, nil},
@@ -131,6 +141,7 @@ func ParseConfigFile(path string) (*Config, error) {
 			func(d *time.Duration) {
 				c.Client.TemplateConfig.MaxStale = d
 			}},
+		{"client.template.change_mode_debounce", &c.Client.TemplateConfig.ChangeModeDebounce, &c.Client.TemplateConfig.ChangeModeDebounceHCL, nil},
 		{"client.template.wait.min", nil, &c.Client.TemplateConfig.Wait.MinHCL,
 			func(d *time.Duration) {
 				c.Client.TemplateConfig.Wait.Min = d
@@ -311,6 +322,17 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_volume")
 	}
 
+	// Remove AllocHook extra keys
+	for _, h := range c.Client.AllocHooks {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, h.Name)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "alloc_hook")
+	}
+
+	// Remove LogSink extra keys, the blocks are parsed by hand
+	for range c.Client.LogSinks {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "log_sink")
+	}
+
 	// Remove HostNetwork extra keys
 	for _, hn := range c.Client.HostNetworks {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, hn.Name)
@@ -343,6 +365,18 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, k)
 	}
 
+	// Remove workload identity claim extra keys, the audience labels are left
+	// behind by hcl when parsing JSON
+	if wi := c.Server.WorkloadIdentity; wi != nil {
+		keys := []string{"workload_identity", "extra_claims", "audience"}
+		for _, aud := range wi.Audiences {
+			keys = append(keys, aud.Name)
+		}
+		isClaimsKey := func(s string) bool { return slices.Contains(keys, s) }
+		c.ExtraKeysHCL = slices.DeleteFunc(c.ExtraKeysHCL, isClaimsKey)
+		c.Server.ExtraKeysHCL = slices.DeleteFunc(c.Server.ExtraKeysHCL, isClaimsKey)
+	}
+
 	for _, k := range []string{"datadog_tags"} {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "telemetry")
@@ -465,6 +499,33 @@ func parseVaults(c *Config, list *ast.ObjectList) error {
 	return nil
 }
 
+// parseLogSinks decodes the `log_sink` blocks of the `client` blocks. The
+// hcl.Decode method can't parse these correctly as HCL1 because they don't have
+// labels, which would result in one sink per field.
+func parseLogSinks(c *Config, list *ast.ObjectList) error {
+	for _, obj := range list.Items {
+		ot, ok := obj.Val.(*ast.ObjectType)
+		if !ok {
+			return fmt.Errorf("client should be an object")
+		}
+
+		for _, sinkObj := range ot.List.Filter("log_sink").Items {
+			var m map[string]interface{}
+			if err := hcl.DecodeObject(&m, sinkObj.Val); err != nil {
+				return err
+			}
+
+			sink := &config.LogSinkConfig{}
+			if err := mapstructure.WeakDecode(m, sink); err != nil {
+				return err
+			}
+			c.Client.LogSinks = append(c.Client.LogSinks, sink)
+		}
+	}
+
+	return nil
+}
+
 // parseConsuls decodes the `consul` blocks. The hcl.Decode method can't parse
 // these correctly as HCL1 because they don't have labels, which would result in
 // all the blocks getting merged regardless of name.
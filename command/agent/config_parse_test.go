@@ -82,15 +82,20 @@ var basicConfig = &Config{
 			DiskMB:        10,
 			ReservedPorts: "1,100,10-12",
 		},
-		GCInterval:            6 * time.Second,
-		GCIntervalHCL:         "6s",
-		GCParallelDestroys:    6,
-		GCDiskUsageThreshold:  82,
-		GCInodeUsageThreshold: 91,
-		GCMaxAllocs:           50,
-		GCVolumesOnNodeGC:     true,
-		NoHostUUID:            pointer.Of(false),
-		DisableRemoteExec:     true,
+		GCInterval:                   6 * time.Second,
+		GCIntervalHCL:                "6s",
+		GCParallelDestroys:           6,
+		GCDiskUsageThreshold:         82,
+		GCInodeUsageThreshold:        91,
+		GCMaxAllocs:                  50,
+		GCVolumesOnNodeGC:            true,
+		BurstableMemoryHeadroomMB:    512,
+		BurstableEvictionInterval:    15 * time.Second,
+		BurstableEvictionIntervalHCL: "15s",
+		MemoryPressureThreshold:      40,
+		MemoryPressureAction:         "restart",
+		NoHostUUID:                   pointer.Of(false),
+		DisableRemoteExec:            true,
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
@@ -142,6 +147,11 @@ var basicConfig = &Config{
 			NodeWindow:    41 * time.Minute,
 			NodeWindowHCL: "41m",
 		},
+		Autoscaler: &Autoscaler{
+			Enabled:               pointer.Of(true),
+			EvaluationInterval:    45 * time.Second,
+			EvaluationIntervalHCL: "45s",
+		},
 		ServerJoin: &ServerJoin{
 			RetryJoin:        []string{"1.1.1.1", "2.2.2.2"},
 			RetryInterval:    time.Duration(15) * time.Second,
@@ -154,6 +164,7 @@ var basicConfig = &Config{
 				SystemSchedulerEnabled:  true,
 				BatchSchedulerEnabled:   true,
 				ServiceSchedulerEnabled: true,
+				MinPriorityDelta:        20,
 			},
 		},
 		LicensePath:        "/tmp/nomad.hclic",
@@ -189,6 +200,14 @@ var basicConfig = &Config{
 				RotateBytes:       100,
 				RotateMaxFiles:    10,
 			},
+			{
+				DeliveryGuarantee: "best-effort",
+				Name:              "webhook",
+				Type:              "webhook",
+				Format:            "json",
+				Address:           "https://audit.example.com/nomad",
+				Headers:           map[string]string{"Authorization": "Bearer hunter2"},
+			},
 		},
 		Filters: []*config.AuditFilter{
 			{
@@ -198,6 +217,12 @@ var basicConfig = &Config{
 				Stages:     []string{"*"},
 				Operations: []string{"*"},
 			},
+			{
+				Name:      "heartbeats",
+				Type:      "RPCEvent",
+				Endpoints: []string{"Node.UpdateStatus"},
+				Actors:    []string{"anonymous"},
+			},
 		},
 	},
 	Telemetry: &Telemetry{
@@ -620,6 +645,9 @@ func (c *Config) addDefaults() {
 	if c.Server.PlanRejectionTracker == nil {
 		c.Server.PlanRejectionTracker = &PlanRejectionTracker{}
 	}
+	if c.Server.Autoscaler == nil {
+		c.Server.Autoscaler = &Autoscaler{}
+	}
 	if c.Reporting == nil {
 		c.Reporting = &config.ReportingConfig{
 			License: &config.LicenseReportingConfig{
@@ -712,6 +740,7 @@ var sample0 = &Config{
 			NodeWindow:    31 * time.Minute,
 			NodeWindowHCL: "31m",
 		},
+		Autoscaler: &Autoscaler{},
 	},
 	ACL: &ACLConfig{
 		Enabled: true,
@@ -821,6 +850,7 @@ var sample1 = &Config{
 			NodeWindow:    31 * time.Minute,
 			NodeWindowHCL: "31m",
 		},
+		Autoscaler: &Autoscaler{},
 	},
 	ACL: &ACLConfig{
 		Enabled: true,
@@ -1137,6 +1167,58 @@ func TestConfig_MultipleConsul(t *testing.T) {
 	}
 }
 
+func TestConfig_LogSinks(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, suffix := range []string{"hcl", "json"} {
+		t.Run(suffix, func(t *testing.T) {
+			fc, err := LoadConfig("testdata/log-sinks." + suffix)
+			must.NoError(t, err)
+
+			expected := []*config.LogSinkConfig{
+				{Type: "syslog", Address: "udp://127.0.0.1:514", Facility: "local0"},
+				{Type: "fluentd", Address: "127.0.0.1:24224", Tag: "nomad.tasks"},
+			}
+			must.Eq(t, expected, fc.Client.LogSinks)
+			must.Eq(t, []string{"tcp://10.0.0.1:514"}, fc.Client.LogSinkAllowedAddresses)
+
+			// sinks from multiple files are all kept
+			cfg := DefaultConfig().Merge(fc).Merge(fc)
+			must.Len(t, 4, cfg.Client.LogSinks)
+		})
+	}
+}
+
+func TestConfig_WorkloadIdentityClaims(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, suffix := range []string{"hcl", "json"} {
+		t.Run(suffix, func(t *testing.T) {
+			fc, err := LoadConfig("testdata/workload-identity." + suffix)
+			must.NoError(t, err)
+
+			expected := &config.WorkloadIdentityClaimsConfig{
+				ExtraClaims: map[string]string{"team": "${job.meta.team}"},
+				Audiences: []*config.WorkloadIdentityAudienceConfig{
+					{
+						Name:    "sts.amazonaws.com",
+						Subject: "${job.namespace}:${job.id}",
+					},
+					{
+						Name:        "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/nomad/providers/nomad",
+						ExtraClaims: map[string]string{"zone": "${node.attr.platform.gce.zone}"},
+					},
+				},
+			}
+			must.Eq(t, expected, fc.Server.WorkloadIdentity)
+			must.NoError(t, fc.Server.WorkloadIdentity.Validate())
+
+			cfg := DefaultConfig().Merge(fc)
+			must.Eq(t, expected, cfg.Server.WorkloadIdentity)
+		})
+	}
+}
+
 func TestConfig_Telemetry(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1171,6 +1253,7 @@ func TestConfig_Template(t *testing.T) {
 
 			must.Eq(t, []string{"plugin"}, cfg.Client.TemplateConfig.FunctionDenylist)
 			must.True(t, cfg.Client.TemplateConfig.DisableSandbox)
+			must.Eq(t, []string{"/etc/ssl/certs"}, cfg.Client.TemplateConfig.SandboxAllowedPaths)
 			must.Eq(t, pointer.Of(7600*time.Hour), cfg.Client.TemplateConfig.MaxStale)
 			must.Eq(t, pointer.Of(10*time.Minute), cfg.Client.TemplateConfig.BlockQueryWaitTime)
 
@@ -1196,6 +1279,13 @@ func TestConfig_Template(t *testing.T) {
 			must.Eq(t, 6, *cfg.Client.TemplateConfig.NomadRetry.Attempts)
 			must.Eq(t, pointer.Of(550*time.Millisecond), cfg.Client.TemplateConfig.NomadRetry.Backoff)
 			must.Eq(t, pointer.Of(10*time.Minute), cfg.Client.TemplateConfig.NomadRetry.MaxBackoff)
+
+			must.Eq(t, map[string][]string{"docker": {"nginx", "-s", "reload"}},
+				cfg.Client.TemplateConfig.ReloadCommands)
+			must.Eq(t, map[string][]string{"ssm": {"/usr/local/bin/nomad-ssm", "-region", "eu-west-1"}},
+				cfg.Client.TemplateConfig.SourcePlugins)
+
+			must.Eq(t, 2*time.Second, cfg.Client.TemplateConfig.ChangeModeDebounce)
 		})
 	}
 }
@@ -161,6 +161,10 @@ func TestConfig_Merge(t *testing.T) {
 				NodeThreshold: 100,
 				NodeWindow:    11 * time.Minute,
 			},
+			Autoscaler: &Autoscaler{
+				Enabled:            pointer.Of(false),
+				EvaluationInterval: 30 * time.Second,
+			},
 			OIDCIssuer:   "https://oidc.test.nomadproject.io",
 			StartTimeout: "45s",
 		},
@@ -381,6 +385,10 @@ func TestConfig_Merge(t *testing.T) {
 				NodeThreshold: 100,
 				NodeWindow:    11 * time.Minute,
 			},
+			Autoscaler: &Autoscaler{
+				Enabled:            pointer.Of(true),
+				EvaluationInterval: time.Minute,
+			},
 			JobMaxPriority:     pointer.Of(200),
 			JobDefaultPriority: pointer.Of(100),
 			OIDCIssuer:         "https://oidc.test.nomadproject.io",
@@ -1896,3 +1904,53 @@ func TestConfig_LoadClientNodeMaxAllocs(t *testing.T) {
 	}
 
 }
+
+func TestConfig_ClientUnhealthyDeviceAction(t *testing.T) {
+	ci.Parallel(t)
+
+	a := DefaultConfig()
+	must.Eq(t, "", a.Client.UnhealthyDeviceAction)
+
+	b := &Config{Client: &ClientConfig{UnhealthyDeviceAction: client.UnhealthyDeviceActionReschedule}}
+	result := a.Merge(b)
+	must.Eq(t, client.UnhealthyDeviceActionReschedule, result.Client.UnhealthyDeviceAction)
+
+	// an unset action doesn't override the configured one
+	result = result.Merge(&Config{Client: &ClientConfig{}})
+	must.Eq(t, client.UnhealthyDeviceActionReschedule, result.Client.UnhealthyDeviceAction)
+}
+
+func TestConfig_LoadClientAllocHooks(t *testing.T) {
+	ci.Parallel(t)
+
+	agentConfig, err := LoadConfig("test-resources/client_with_alloc_hooks.hcl")
+	must.NoError(t, err)
+	agentConfig = DefaultConfig().Merge(agentConfig)
+
+	must.Eq(t, []*config.AllocHookConfig{
+		{
+			Name:       "firewall",
+			PreStart:   []string{"/usr/local/bin/firewall", "open"},
+			PostStop:   []string{"/usr/local/bin/firewall", "close"},
+			Timeout:    pointer.Of("10s"),
+			Namespaces: []string{"default", "edge"},
+		},
+		{
+			Name:       "routes",
+			PostStop:   []string{"/usr/local/bin/routes", "flush"},
+			Namespaces: []string{"*"},
+		},
+	}, agentConfig.Client.AllocHooks)
+
+	// hooks with the same name are replaced, others are kept
+	agentConfig = agentConfig.Merge(&Config{Client: &ClientConfig{
+		AllocHooks: []*config.AllocHookConfig{{
+			Name:       "routes",
+			PreStart:   []string{"/usr/local/bin/routes", "add"},
+			Namespaces: []string{"edge"},
+		}},
+	}})
+	must.Len(t, 2, agentConfig.Client.AllocHooks)
+	must.Eq(t, "firewall", agentConfig.Client.AllocHooks[0].Name)
+	must.Eq(t, []string{"/usr/local/bin/routes", "add"}, agentConfig.Client.AllocHooks[1].PreStart)
+}
@@ -1249,6 +1249,9 @@ func (c *ServiceClient) RegisterAgent(role string, services []*structs.Service)
 			if check.Type == structs.ServiceCheckScript {
 				return fmt.Errorf("service %q contains invalid check: agent checks do not support scripts", service.Name)
 			}
+			if check.Type == structs.ServiceCheckDocker {
+				return fmt.Errorf("service %q contains invalid check: agent checks do not support docker checks", service.Name)
+			}
 			checkHost, checkPort := serviceReg.Address, serviceReg.Port
 			if check.PortLabel != "" {
 				// Unlike tasks, agents don't use port labels. Agent ports are
@@ -1342,8 +1345,9 @@ func (c *ServiceClient) serviceRegs(
 	// newConnectGateway returns nil if there's no Connect gateway.
 	gateway := newConnectGateway(service.Connect)
 
-	// newWeights returns nil if there's no Weights.
-	weights := newWeights(service.Weights)
+	// newWeights returns nil if there's no Weights. Canaries use the canary
+	// weights if set.
+	weights := newWeights(service.RegistrationWeights(workload.Canary))
 
 	// Determine whether to use meta or canary_meta
 	var meta map[string]string
@@ -1405,6 +1409,17 @@ func (c *ServiceClient) serviceRegs(
 		return nil, err
 	}
 
+	// Dual-stack networks publish the address of each family, unless the
+	// service sets them in tagged_addresses
+	if ipv4, ipv6 := serviceregistration.GetDualStackAddresses(addrMode, workload.NetworkStatus); ipv6 != "" {
+		if _, ok := taggedAddresses["lan_ipv4"]; !ok {
+			taggedAddresses["lan_ipv4"] = api.ServiceAddress{Address: ipv4, Port: port}
+		}
+		if _, ok := taggedAddresses["lan_ipv6"]; !ok {
+			taggedAddresses["lan_ipv6"] = api.ServiceAddress{Address: ipv6, Port: port}
+		}
+	}
+
 	// Build the Consul Service registration request
 	serviceReg := &api.AgentServiceRegistration{
 		Kind:              kind,
@@ -1458,6 +1473,7 @@ func apiCheckRegistrationToCheck(r *api.AgentCheckRegistration) *api.AgentServic
 		Method:                 r.Method,
 		Body:                   r.Body,
 		TCP:                    r.TCP,
+		TCPUseTLS:              r.TCPUseTLS,
 		Status:                 r.Status,
 		TLSServerName:          r.TLSServerName,
 		TLSSkipVerify:          r.TLSSkipVerify,
@@ -1482,7 +1498,7 @@ func (c *ServiceClient) checkRegs(
 		var ip string
 		var port int
 
-		if check.Type != structs.ServiceCheckScript {
+		if check.Type != structs.ServiceCheckScript && check.Type != structs.ServiceCheckDocker {
 			portLabel := check.PortLabel
 			if portLabel == "" {
 				portLabel = service.PortLabel
@@ -2042,8 +2058,13 @@ func createCheckReg(serviceID, checkID string, check *structs.ServiceCheck, host
 
 	case structs.ServiceCheckTCP:
 		chkReg.TCP = net.JoinHostPort(host, strconv.Itoa(port))
+		if check.TCPUseTLS {
+			chkReg.TCPUseTLS = true
+			chkReg.TLSSkipVerify = check.TLSSkipVerify
+			chkReg.TLSServerName = check.TLSServerName
+		}
 
-	case structs.ServiceCheckScript:
+	case structs.ServiceCheckScript, structs.ServiceCheckDocker:
 		chkReg.TTL = (check.Interval + ttlCheckBuffer).String()
 		// As of Consul 1.0.0 setting TTL and Interval is a 400
 		chkReg.Interval = ""
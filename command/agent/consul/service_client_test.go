@@ -594,6 +594,49 @@ func TestServiceRegistration_CheckOnUpdate(t *testing.T) {
 	}
 }
 
+func TestServiceRegistration_DualStackTaggedAddresses(t *testing.T) {
+	ci.Parallel(t)
+
+	mockAgent := NewMockAgent(ossFeatures)
+	namespacesClient := NewNamespacesClient(NewMockNamespaces(nil), mockAgent)
+	sc := NewServiceClient(mockAgent, namespacesClient, testlog.HCLogger(t), true)
+
+	ws := &serviceregistration.WorkloadServices{
+		AllocInfo: structs.AllocInfo{
+			AllocID: uuid.Generate(),
+			Task:    "taskname",
+		},
+		Services: []*structs.Service{{
+			Name:        "web",
+			PortLabel:   "http",
+			AddressMode: structs.AddressModeAlloc,
+			TaggedAddresses: map[string]string{
+				"lan_ipv6": "[fd00::99]:8080",
+			},
+		}},
+		Ports: structs.AllocatedPorts{
+			{Label: "http", Value: 25000, To: 8080},
+		},
+		NetworkStatus: &structs.AllocNetworkStatus{
+			InterfaceName: "eth0",
+			Address:       "172.26.64.10",
+			AddressIPv6:   "fd00::10",
+		},
+	}
+
+	ops := new(operations)
+	_, err := sc.serviceRegs(ops, ws.Services[0], ws)
+	must.NoError(t, err)
+	must.Len(t, 1, ops.regServices)
+
+	reg := ops.regServices[0]
+	must.Eq(t, "172.26.64.10", reg.Address)
+	must.Eq(t, map[string]api.ServiceAddress{
+		"lan_ipv4": {Address: "172.26.64.10", Port: 8080},
+		"lan_ipv6": {Address: "fd00::99", Port: 8080},
+	}, reg.TaggedAddresses)
+}
+
 func TestSyncLogic_proxyUpstreamsDifferent(t *testing.T) {
 	ci.Parallel(t)
 
@@ -853,6 +853,38 @@ func TestConsul_CanaryMeta_NoMeta(t *testing.T) {
 	require.Len(ctx.FakeConsul.services["default"], 0)
 }
 
+// TestConsul_CanaryWeights asserts CanaryWeights are used when Canary=true
+func TestConsul_CanaryWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx := setupFake(t)
+
+	ctx.Workload.Canary = true
+	ctx.Workload.Services[0].Weights = &structs.ServiceWeights{Passing: 10, Warning: 1}
+	ctx.Workload.Services[0].CanaryWeights = &structs.ServiceWeights{Passing: 1, Warning: 1}
+
+	must.NoError(t, ctx.ServiceClient.RegisterWorkload(ctx.Workload))
+	must.NoError(t, ctx.syncOnce(syncNewOps))
+	must.MapLen(t, 1, ctx.FakeConsul.services["default"])
+	for _, service := range ctx.FakeConsul.services["default"] {
+		must.Eq(t, &api.AgentWeights{Passing: 1, Warning: 1}, service.Weights)
+	}
+
+	// Promote the canary and assert the weights are not the canary weights
+	origWorkload := ctx.Workload.Copy()
+	ctx.Workload.Canary = false
+	must.NoError(t, ctx.ServiceClient.UpdateWorkload(origWorkload, ctx.Workload))
+	must.NoError(t, ctx.syncOnce(syncNewOps))
+	must.MapLen(t, 1, ctx.FakeConsul.services["default"])
+	for _, service := range ctx.FakeConsul.services["default"] {
+		must.Eq(t, &api.AgentWeights{Passing: 10, Warning: 1}, service.Weights)
+	}
+
+	ctx.ServiceClient.RemoveWorkload(ctx.Workload)
+	must.NoError(t, ctx.syncOnce(syncNewOps))
+	must.MapLen(t, 0, ctx.FakeConsul.services["default"])
+}
+
 // TestConsul_PeriodicSync asserts that Nomad periodically reconciles with
 // Consul.
 func TestConsul_PeriodicSync(t *testing.T) {
@@ -992,6 +1024,70 @@ func TestCreateCheckReg_GRPC(t *testing.T) {
 	must.Eq(t, expected, actual)
 }
 
+func TestCreateCheckReg_TCPUseTLS(t *testing.T) {
+	ci.Parallel(t)
+
+	check := &structs.ServiceCheck{
+		Name:          "name",
+		Type:          "tcp",
+		PortLabel:     "label",
+		TCPUseTLS:     true,
+		TLSServerName: "localhost",
+		Timeout:       time.Second,
+		Interval:      time.Minute,
+	}
+
+	serviceID := "testService"
+	checkID := check.Hash(serviceID)
+
+	expected := &api.AgentCheckRegistration{
+		Namespace: "",
+		ID:        checkID,
+		Name:      check.Name,
+		ServiceID: serviceID,
+		AgentServiceCheck: api.AgentServiceCheck{
+			Timeout:       "1s",
+			Interval:      "1m0s",
+			TCP:           "127.0.0.1:8080",
+			TCPUseTLS:     true,
+			TLSServerName: "localhost",
+		},
+	}
+
+	actual, err := createCheckReg(serviceID, checkID, check, "127.0.0.1", 8080, "default")
+	must.NoError(t, err)
+	must.Eq(t, expected, actual)
+}
+
+func TestCreateCheckReg_Docker(t *testing.T) {
+	ci.Parallel(t)
+
+	check := &structs.ServiceCheck{
+		Name:     "name",
+		Type:     "docker",
+		Timeout:  time.Second,
+		Interval: 10 * time.Second,
+	}
+
+	serviceID := "testService"
+	checkID := check.Hash(serviceID)
+
+	expected := &api.AgentCheckRegistration{
+		Namespace: "",
+		ID:        checkID,
+		Name:      check.Name,
+		ServiceID: serviceID,
+		AgentServiceCheck: api.AgentServiceCheck{
+			Timeout: "1s",
+			TTL:     (check.Interval + ttlCheckBuffer).String(),
+		},
+	}
+
+	actual, err := createCheckReg(serviceID, checkID, check, "", 0, "default")
+	must.NoError(t, err)
+	must.Eq(t, expected, actual)
+}
+
 func TestConsul_ServiceName_Duplicates(t *testing.T) {
 	ci.Parallel(t)
 	ctx := setupFake(t)
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"time"
+)
+
+const (
+	// HTTPEvent is the type of the audit events of HTTP API requests.
+	HTTPEvent = "HTTPEvent"
+
+	// RPCEvent is the type of the audit events of RPC requests.
+	RPCEvent = "RPCEvent"
+
+	// AuditEventVersion is the version of the format of audit events.
+	AuditEventVersion = 1
+
+	// AuditEntryType is the event type of the entries of audit logs.
+	AuditEntryType = "audit"
+)
+
+const (
+	// StageOperationReceived is the stage of the audit event sent when the
+	// request is received, before it is handled.
+	StageOperationReceived = "OperationReceived"
+
+	// StageOperationComplete is the stage of the audit event sent once the
+	// request has been handled.
+	StageOperationComplete = "OperationComplete"
+)
+
+// AuditEvent is the payload of the audit events of HTTP and RPC requests. The
+// events of the two stages of a request share their ID.
+type AuditEvent struct {
+	ID        string         `json:"id"`
+	Stage     string         `json:"stage"`
+	Type      string         `json:"type"`
+	Timestamp time.Time      `json:"timestamp"`
+	Version   int            `json:"version"`
+	Auth      *AuditAuth     `json:"auth,omitempty"`
+	Request   *AuditRequest  `json:"request"`
+	Response  *AuditResponse `json:"response,omitempty"`
+}
+
+// AuditAuth details the actor that made the request. It is nil for anonymous
+// requests.
+type AuditAuth struct {
+	AccessorID string    `json:"accessor_id,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Type       string    `json:"type,omitempty"`
+	Policies   []string  `json:"policies,omitempty"`
+	Global     bool      `json:"global,omitempty"`
+	CreateTime time.Time `json:"create_time"`
+	ClientID   string    `json:"client_id,omitempty"`
+	TLSName    string    `json:"tls_name,omitempty"`
+}
+
+// AuditRequest details the request. The operation is the HTTP method of HTTP
+// requests, and "read" or "write" for RPC requests. The endpoint is the URI of
+// HTTP requests and the method name of RPC requests.
+type AuditRequest struct {
+	ID          string            `json:"id"`
+	Operation   string            `json:"operation"`
+	Endpoint    string            `json:"endpoint"`
+	Namespace   map[string]string `json:"namespace,omitempty"`
+	Region      string            `json:"region,omitempty"`
+	Forwarded   bool              `json:"forwarded,omitempty"`
+	RequestMeta map[string]string `json:"request_meta,omitempty"`
+	NodeMeta    map[string]string `json:"node_meta,omitempty"`
+}
+
+// AuditResponse details the result of the request, once it is complete.
+type AuditResponse struct {
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// AuditEntry is the envelope audit events are written to sinks in.
+type AuditEntry struct {
+	CreatedAt time.Time   `json:"created_at"`
+	EventType string      `json:"event_type"`
+	Payload   *AuditEvent `json:"payload"`
+}
+
+// Actors returns the identifiers the actor of the event can be matched by:
+// the accessor ID and name of its ACL token, its client node ID, and its TLS
+// certificate name. Anonymous requests are identified by "anonymous".
+func (e *AuditEvent) Actors() []string {
+	if e.Auth == nil {
+		return []string{"anonymous"}
+	}
+
+	var actors []string
+	for _, actor := range []string{e.Auth.AccessorID, e.Auth.Name, e.Auth.ClientID, e.Auth.TLSName} {
+		if actor != "" {
+			actors = append(actors, actor)
+		}
+	}
+	if len(actors) == 0 {
+		return []string{"anonymous"}
+	}
+	return actors
+}
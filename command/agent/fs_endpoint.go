@@ -10,12 +10,15 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -26,8 +29,13 @@ var (
 	logTypeNotPresentErr  = CodedError(400, "must provide log type (stdout/stderr)")
 	clientNotRunning      = CodedError(400, "node is not running a Nomad Client")
 	invalidOrigin         = CodedError(400, "origin must be start or end")
+	checksumNotPresentErr = CodedError(400, "must provide the SHA-256 checksum of the file")
 )
 
+// fsPutChunkSize is the maximum number of bytes of an uploaded file sent in a
+// single frame.
+const fsPutChunkSize = 64 * 1024
+
 func (s *HTTPServer) FsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	path := strings.TrimPrefix(req.URL.Path, "/v1/client/fs/")
 	switch {
@@ -41,6 +49,8 @@ func (s *HTTPServer) FsRequest(resp http.ResponseWriter, req *http.Request) (int
 		return s.wrapUntrustedContent(s.FileCatRequest)(resp, req)
 	case strings.HasPrefix(path, "stream/"):
 		return s.Stream(resp, req)
+	case strings.HasPrefix(path, "put/"):
+		return s.FilePutRequest(resp, req)
 	case strings.HasPrefix(path, "logs/"):
 		// Logs are *trusted* content because the endpoint
 		// explicitly sets the Content-Type to text/plain or
@@ -257,6 +267,63 @@ func (s *HTTPServer) Stream(resp http.ResponseWriter, req *http.Request) (interf
 	return s.fsStreamImpl(resp, req, "FileSystem.Stream", fsReq, fsReq.AllocID)
 }
 
+// FilePutRequest uploads the request body to a file of an allocation
+// directory. The parameters are:
+//   - path: path of the file to write.
+//   - checksum: hex encoded SHA-256 checksum of the request body.
+//   - size: size of the request body, defaults to its content length.
+//   - mode: octal permission bits of the file, defaults to 0644.
+func (s *HTTPServer) FilePutRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var allocID, path, checksum string
+	var err error
+
+	q := req.URL.Query()
+
+	if allocID = strings.TrimPrefix(req.URL.Path, "/v1/client/fs/put/"); allocID == "" {
+		return nil, allocIDNotPresentErr
+	}
+	if path = q.Get("path"); path == "" {
+		return nil, fileNameNotPresentErr
+	}
+	if checksum = q.Get("checksum"); checksum == "" {
+		return nil, checksumNotPresentErr
+	}
+
+	size := req.ContentLength
+	if sizeStr := q.Get("size"); sizeStr != "" {
+		if size, err = strconv.ParseInt(sizeStr, 10, 64); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("error parsing size: %v", err))
+		}
+	}
+	if size < 0 {
+		return nil, CodedError(http.StatusLengthRequired, "must provide the size of the file")
+	}
+
+	var mode uint64
+	if modeStr := q.Get("mode"); modeStr != "" {
+		if mode, err = strconv.ParseUint(modeStr, 8, 32); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("error parsing mode: %v", err))
+		}
+	}
+
+	// Create the request arguments
+	fsReq := &cstructs.FsPutRequest{
+		AllocID:  allocID,
+		Path:     path,
+		Size:     size,
+		Checksum: checksum,
+		FileMode: os.FileMode(mode).Perm(),
+	}
+	s.parse(resp, req, &fsReq.QueryOptions.Region, &fsReq.QueryOptions)
+
+	// Make the request
+	return s.fsPutImpl(req, "FileSystem.Put", fsReq, fsReq.AllocID, size)
+}
+
 // Logs streams the content of a log blocking on EOF. The parameters are:
 //   - task: task name to stream logs for.
 //   - type: stdout/stderr to stream.
@@ -315,6 +382,13 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 		return nil, invalidOrigin
 	}
 
+	filter := q.Get("filter")
+	if filter != "" {
+		if _, err := regexp.Compile(filter); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse filter: %v", err))
+		}
+	}
+
 	// Create the request arguments
 	fsReq := &cstructs.FsLogsRequest{
 		AllocID:   allocID,
@@ -324,6 +398,7 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 		Origin:    origin,
 		PlainText: plain,
 		Follow:    follow,
+		Filter:    filter,
 	}
 	s.parse(resp, req, &fsReq.QueryOptions.Region, &fsReq.QueryOptions)
 
@@ -346,19 +421,9 @@ func (s *HTTPServer) fsStreamImpl(resp http.ResponseWriter,
 	req *http.Request, method string, args interface{}, allocID string) (interface{}, error) {
 
 	// Get the correct handler
-	localClient, remoteClient, localServer := s.rpcHandlerForAlloc(allocID)
-	var handler structs.StreamingRpcHandler
-	var handlerErr error
-	if localClient {
-		handler, handlerErr = s.agent.Client().StreamingRpcHandler(method)
-	} else if remoteClient {
-		handler, handlerErr = s.agent.Client().RemoteStreamingRpcHandler(method)
-	} else if localServer {
-		handler, handlerErr = s.agent.Server().StreamingRpcHandler(method)
-	}
-
-	if handlerErr != nil {
-		return nil, CodedError(500, handlerErr.Error())
+	handler, err := s.fsStreamingRpcHandler(method, allocID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create a pipe connecting the (possibly remote) handler to the http response
@@ -432,3 +497,93 @@ func (s *HTTPServer) fsStreamImpl(resp http.ResponseWriter,
 	}
 	return nil, codedErr
 }
+
+// fsPutImpl is used to make a streaming filesystem call that serializes the
+// args, then sends size bytes of the request body as StreamErrWrapper payloads
+// and expects a single StreamErrWrapper with the result.
+func (s *HTTPServer) fsPutImpl(req *http.Request, method string, args interface{}, allocID string, size int64) (interface{}, error) {
+	// Get the correct handler
+	handler, err := s.fsStreamingRpcHandler(method, allocID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a pipe connecting the (possibly remote) handler to the request
+	httpPipe, handlerPipe := net.Pipe()
+	decoder := codec.NewDecoder(httpPipe, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(httpPipe, structs.MsgpackHandle)
+
+	// Create a goroutine that closes the pipe if the connection closes.
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		httpPipe.Close()
+	}()
+
+	go handler(handlerPipe)
+
+	// Send the request followed by the contents of the file. The handler may
+	// reject the request before reading all of it, in which case the pipe is
+	// closed and sending stops.
+	go func() {
+		if err := encoder.Encode(args); err != nil {
+			return
+		}
+
+		body := io.LimitReader(req.Body, size)
+		buf := make([]byte, fsPutChunkSize)
+		var sent int64
+		for sent < size {
+			n, err := body.Read(buf)
+			if n > 0 {
+				if err := encoder.Encode(&cstructs.StreamErrWrapper{Payload: buf[:n]}); err != nil {
+					return
+				}
+				sent += int64(n)
+			}
+			if err == io.EOF {
+				err = fmt.Errorf("request body is %d bytes, expected %d", sent, size)
+			}
+			if err != nil {
+				encoder.Encode(&cstructs.StreamErrWrapper{
+					Error: cstructs.NewRpcError(err, pointer.Of(int64(400))),
+				})
+				return
+			}
+		}
+	}()
+
+	var res cstructs.StreamErrWrapper
+	if err := decoder.Decode(&res); err != nil {
+		return nil, CodedError(500, err.Error())
+	}
+	if err := res.Error; err != nil {
+		code := 500
+		if err.Code != nil {
+			code = int(*err.Code)
+		}
+		return nil, CodedError(code, err.Error())
+	}
+	return nil, nil
+}
+
+// fsStreamingRpcHandler returns the handler for the streaming filesystem RPC
+// method for the allocation.
+func (s *HTTPServer) fsStreamingRpcHandler(method, allocID string) (structs.StreamingRpcHandler, error) {
+	localClient, remoteClient, localServer := s.rpcHandlerForAlloc(allocID)
+	var handler structs.StreamingRpcHandler
+	var handlerErr error
+	if localClient {
+		handler, handlerErr = s.agent.Client().StreamingRpcHandler(method)
+	} else if remoteClient {
+		handler, handlerErr = s.agent.Client().RemoteStreamingRpcHandler(method)
+	} else if localServer {
+		handler, handlerErr = s.agent.Server().StreamingRpcHandler(method)
+	}
+
+	if handlerErr != nil {
+		return nil, CodedError(500, handlerErr.Error())
+	}
+	return handler, nil
+}
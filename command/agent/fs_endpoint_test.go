@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
 	"io"
@@ -19,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -223,6 +225,46 @@ func TestHTTP_FS_Stream_MissingParams(t *testing.T) {
 
 // TestHTTP_FS_Logs_MissingParams asserts proper error codes and messages are
 // returned for incorrect parameters (eg missing tasks).
+func TestHTTP_FS_Put_MissingParams(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest(http.MethodGet, "/v1/client/fs/put/foo", nil)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		_, err = s.Server.FilePutRequest(respW, req)
+		must.EqError(t, err, ErrInvalidMethod)
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/client/fs/put/", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.FilePutRequest(respW, req)
+		must.EqError(t, err, allocIDNotPresentErr.Error())
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/client/fs/put/foo", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.FilePutRequest(respW, req)
+		must.EqError(t, err, fileNameNotPresentErr.Error())
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/client/fs/put/foo?path=/path/to/file", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.FilePutRequest(respW, req)
+		must.EqError(t, err, checksumNotPresentErr.Error())
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/client/fs/put/foo?path=/path/to/file&checksum=abc&size=-1", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.FilePutRequest(respW, req)
+		must.EqError(t, err, "must provide the size of the file")
+	})
+}
+
 func TestHTTP_FS_Logs_MissingParams(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -254,6 +296,15 @@ func TestHTTP_FS_Logs_MissingParams(t *testing.T) {
 		require.Equal(respW.Body.String(), logTypeNotPresentErr.Error())
 		require.Equal(400, respW.Code)
 
+		// Invalid filter
+		req, err = http.NewRequest(http.MethodGet, "/v1/client/fs/logs/foo?task=foo&type=stdout&filter=%28", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Contains(respW.Body.String(), "failed to parse filter")
+		require.Equal(400, respW.Code)
+
 		// case where all parameters are set but alloc isn't found
 		req, err = http.NewRequest(http.MethodGet, "/v1/client/fs/logs/foo?task=foo&type=stdout", nil)
 		require.NoError(err)
@@ -378,6 +429,42 @@ func TestHTTP_FS_Cat(t *testing.T) {
 }
 
 // TestHTTP_FS_Cat_XSS asserts that the cat API is safe from XSS.
+func TestHTTP_FS_Put(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		a := mockFSAlloc(s.client.NodeID(), map[string]interface{}{
+			"run_for": "30s",
+		})
+		addAllocToClient(s, a, runningClientAlloc)
+
+		contents := "Hello from the other side"
+		sum := sha256.Sum256([]byte(contents))
+		path := fmt.Sprintf("/v1/client/fs/put/%s?path=alloc/data/test_file&checksum=%x&mode=600", a.ID, sum)
+
+		req, err := http.NewRequest(http.MethodPut, path, strings.NewReader(contents))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.FilePutRequest(respW, req)
+		must.NoError(t, err)
+
+		path = fmt.Sprintf("/v1/client/fs/cat/%s?path=alloc/data/test_file", a.ID)
+		req, err = http.NewRequest(http.MethodGet, path, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.FileCatRequest(respW, req)
+		must.NoError(t, err)
+		must.Eq(t, contents, respW.Body.String())
+
+		// A body that doesn't match the checksum is rejected
+		path = fmt.Sprintf("/v1/client/fs/put/%s?path=alloc/data/test_file&checksum=%x", a.ID, sum)
+		req, err = http.NewRequest(http.MethodPut, path, strings.NewReader("Hello"))
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.FilePutRequest(respW, req)
+		must.ErrorContains(t, err, "checksum mismatch")
+	})
+}
+
 func TestHTTP_FS_Cat_XSS(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -362,6 +362,10 @@ func (s *HTTPServer) ResolveToken(req *http.Request) (*acl.ACL, error) {
 	var secret string
 	s.parseToken(req, &secret)
 
+	if err := s.checkTokenSource(req, secret); err != nil {
+		return nil, err
+	}
+
 	var aclObj *acl.ACL
 	var err error
 
@@ -381,6 +385,72 @@ func (s *HTTPServer) ResolveToken(req *http.Request) (*acl.ACL, error) {
 	return aclObj, nil
 }
 
+// resolveRequestToken returns the ACL token of the request, or nil if ACLs
+// are disabled or the request has no token that resolves.
+func (s *HTTPServer) resolveRequestToken(req *http.Request) *structs.ACLToken {
+	var secret string
+	s.parseToken(req, &secret)
+	return s.resolveSecretToken(secret)
+}
+
+// resolveSecretToken returns the ACL token of the secret ID, or nil if ACLs
+// are disabled or the secret ID doesn't resolve to an ACL token.
+func (s *HTTPServer) resolveSecretToken(secret string) *structs.ACLToken {
+	if !s.agent.GetConfig().ACL.Enabled || secret == "" {
+		return nil
+	}
+
+	var token *structs.ACLToken
+	var err error
+	if srv := s.agent.Server(); srv != nil {
+		token, err = srv.ResolveSecretToken(secret)
+	} else {
+		token, err = s.agent.Client().ResolveSecretToken(secret)
+	}
+	if err != nil {
+		return nil
+	}
+	return token
+}
+
+// checkTokenSource returns ErrPermissionDenied if the ACL token with the
+// secret ID is restricted to CIDR blocks that don't include the address of
+// the HTTP client. Servers check the source of the RPCs they serve as well,
+// but the endpoints served by the agent itself or by its client, such as the
+// allocation filesystem, are only checked here.
+func (s *HTTPServer) checkTokenSource(req *http.Request, secret string) error {
+	token := s.resolveSecretToken(secret)
+	if !token.AllowsSourceIP(sourceIP(req)) {
+		s.logger.Debug("ACL token not allowed from source address",
+			"accessor_id", token.AccessorID, "remote_addr", req.RemoteAddr)
+		return structs.ErrPermissionDenied
+	}
+	return nil
+}
+
+// checkRequestTokenSource is like checkTokenSource for the ACL token of the
+// request.
+func (s *HTTPServer) checkRequestTokenSource(req *http.Request) error {
+	var secret string
+	s.parseToken(req, &secret)
+	return s.checkTokenSource(req, secret)
+}
+
+// sourceIP returns the address of the HTTP client, which ACL tokens restricted
+// to CIDR blocks are checked against. The unspecified address is returned if
+// the client has no IP address, such as for requests over a unix socket, so
+// that those tokens are only allowed if their CIDR blocks include it.
+func sourceIP(req *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return net.IPv4zero
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip
+	}
+	return net.IPv4zero
+}
+
 // registerHandlers is used to attach our handlers to the mux
 func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/jobs", s.wrap(s.JobsRequest))
@@ -393,6 +463,8 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 
 	s.mux.HandleFunc("/v1/node/pools", s.wrap(s.NodePoolsRequest))
 	s.mux.HandleFunc("/v1/node/pool/", s.wrap(s.NodePoolSpecificRequest))
+	s.mux.HandleFunc("/v1/node/maintenance/windows", s.wrap(s.NodeMaintenanceWindowsRequest))
+	s.mux.HandleFunc("/v1/node/maintenance/window/", s.wrap(s.NodeMaintenanceWindowSpecificRequest))
 
 	s.mux.HandleFunc("/v1/allocations", s.wrap(s.AllocsRequest))
 	s.mux.HandleFunc("/v1/allocation/", s.wrap(s.AllocSpecificRequest))
@@ -509,6 +581,11 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/namespace", s.wrap(s.NamespaceCreateRequest))
 	s.mux.HandleFunc("/v1/namespace/", s.wrap(s.NamespaceSpecificRequest))
 
+	s.mux.HandleFunc("/v1/quotas", s.wrap(s.QuotasRequest))
+	s.mux.HandleFunc("/v1/quota-usages", s.wrap(s.QuotaUsagesRequest))
+	s.mux.HandleFunc("/v1/quota", s.wrap(s.QuotaCreateRequest))
+	s.mux.HandleFunc("/v1/quota/", s.wrap(s.QuotaSpecificRequest))
+
 	s.mux.Handle("/v1/vars", wrapCORS(s.wrap(s.VariablesListRequest)))
 	s.mux.Handle("/v1/var/", wrapCORSWithAllowedMethods(s.wrap(s.VariableSpecificRequest), "HEAD", "GET", "PUT", "DELETE"))
 
@@ -722,7 +799,11 @@ func (s *HTTPServer) wrap(handler func(resp http.ResponseWriter, req *http.Reque
 		defer func() {
 			s.logger.Debug("request complete", "method", req.Method, "path", reqURL, "duration", time.Since(start))
 		}()
-		obj, err := s.auditHandler(handler)(resp, req)
+		var obj interface{}
+		err := s.checkRequestTokenSource(req)
+		if err == nil {
+			obj, err = s.auditHandler(handler)(resp, req)
+		}
 
 		// Check for an error
 	HAS_ERR:
@@ -805,7 +886,11 @@ func (s *HTTPServer) wrapNonJSON(handler func(resp http.ResponseWriter, req *htt
 		defer func() {
 			s.logger.Debug("request complete", "method", req.Method, "path", reqURL, "duration", time.Since(start))
 		}()
-		obj, err := s.auditNonJSONHandler(handler)(resp, req)
+		var obj []byte
+		err := s.checkRequestTokenSource(req)
+		if err == nil {
+			obj, err = s.auditNonJSONHandler(handler)(resp, req)
+		}
 
 		// Check for an error
 		if err != nil {
@@ -1028,6 +1113,7 @@ func (s *HTTPServer) parseToken(req *http.Request, token *string) {
 func (s *HTTPServer) parse(resp http.ResponseWriter, req *http.Request, r *string, b *structs.QueryOptions) bool {
 	s.parseRegion(req, r)
 	s.parseToken(req, &b.AuthToken)
+	b.SetSourceIP(sourceIP(req))
 	if err := parseConsistency(resp, req, b); err != nil {
 		return true
 	}
@@ -1113,6 +1199,7 @@ func parseNodeListStubFields(req *http.Request) (*structs.NodeStubFields, error)
 func (s *HTTPServer) parseWriteRequest(req *http.Request, w *structs.WriteRequest) {
 	parseNamespace(req, &w.Namespace)
 	s.parseToken(req, &w.AuthToken)
+	w.SetSourceIP(sourceIP(req))
 	s.parseRegion(req, &w.Region)
 	parseIdempotencyToken(req, &w.IdempotencyToken)
 }
@@ -8,6 +8,10 @@ package agent
 
 import (
 	"net/http"
+	"time"
+
+	"github.com/hashicorp/nomad/command/agent/event"
+	"github.com/hashicorp/nomad/helper/uuid"
 )
 
 // registerEnterpriseHandlers is a no-op for the oss release
@@ -15,11 +19,6 @@ func (s *HTTPServer) registerEnterpriseHandlers() {
 	s.mux.HandleFunc("/v1/sentinel/policies", s.wrap(s.entOnly))
 	s.mux.HandleFunc("/v1/sentinel/policy/", s.wrap(s.entOnly))
 
-	s.mux.HandleFunc("/v1/quotas", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/quota-usages", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/quota/", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/quota", s.wrap(s.entOnly))
-
 	s.mux.HandleFunc("/v1/recommendation", s.wrap(s.entOnly))
 	s.mux.HandleFunc("/v1/recommendations", s.wrap(s.entOnly))
 	s.mux.HandleFunc("/v1/recommendations/apply", s.wrap(s.entOnly))
@@ -30,17 +29,162 @@ func (s *HTTPServer) entOnly(resp http.ResponseWriter, req *http.Request) (inter
 	return nil, CodedError(501, ErrEntOnly)
 }
 
-// auditHandler wraps the passed handlerFn
+// auditHandler wraps the passed handlerFn to audit the requests it serves
 func (s *HTTPServer) auditHandler(h handlerFn) handlerFn {
-	return h
+	return func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+		ev, err := s.auditRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, rspErr := h(resp, req)
+		if err := s.auditResponse(req, ev, rspErr); err != nil {
+			return nil, err
+		}
+		return obj, rspErr
+	}
 }
 
-// auditHTTPHandler wraps  the passed handlerByteFn
+// auditNonJSONHandler wraps the passed handlerByteFn to audit the requests it
+// serves
 func (s *HTTPServer) auditNonJSONHandler(h handlerByteFn) handlerByteFn {
-	return h
+	return func(resp http.ResponseWriter, req *http.Request) ([]byte, error) {
+		ev, err := s.auditRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, rspErr := h(resp, req)
+		if err := s.auditResponse(req, ev, rspErr); err != nil {
+			return nil, err
+		}
+		return obj, rspErr
+	}
 }
 
-// auditHTTPHandler wraps the passed http.Handler
+// auditHTTPHandler wraps the passed http.Handler to audit the requests it
+// serves. The response is written by the handler, so failing to audit its
+// completion is only logged.
 func (s *HTTPServer) auditHTTPHandler(h http.Handler) http.Handler {
-	return h
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		ev, err := s.auditRequest(req)
+		if err != nil {
+			code, errMsg := errCodeFromHandler(err)
+			http.Error(resp, errMsg, code)
+			return
+		}
+		if ev == nil {
+			h.ServeHTTP(resp, req)
+			return
+		}
+
+		rec := &auditResponseWriter{ResponseWriter: resp, status: http.StatusOK}
+		h.ServeHTTP(rec, req)
+		s.auditComplete(req, ev, rec.status, "")
+	})
+}
+
+// auditResponseWriter records the status code of the response of the requests
+// served by http.Handlers.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *auditResponseWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets streaming handlers flush the wrapped response writer.
+func (w *auditResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// auditRequest sends the audit event of a request before it is handled. It
+// returns nil if auditing is disabled, and an error if the event can't be
+// written to an enforced sink, in which case the request must fail.
+func (s *HTTPServer) auditRequest(req *http.Request) (*event.AuditEvent, error) {
+	if s.eventAuditor == nil || !s.eventAuditor.Enabled() {
+		return nil, nil
+	}
+
+	ev := &event.AuditEvent{
+		ID:        uuid.Generate(),
+		Stage:     event.StageOperationReceived,
+		Type:      event.HTTPEvent,
+		Timestamp: time.Now().UTC(),
+		Version:   event.AuditEventVersion,
+		Request: &event.AuditRequest{
+			ID:        uuid.Generate(),
+			Operation: req.Method,
+			Endpoint:  req.URL.RequestURI(),
+			RequestMeta: map[string]string{
+				"remote_address": req.RemoteAddr,
+				"user_agent":     req.UserAgent(),
+			},
+			NodeMeta: map[string]string{
+				"ip": s.Addr,
+			},
+		},
+	}
+	if namespace := req.URL.Query().Get("namespace"); namespace != "" {
+		ev.Request.Namespace = map[string]string{"id": namespace}
+	}
+	s.parseRegion(req, &ev.Request.Region)
+
+	if token := s.resolveRequestToken(req); token != nil {
+		ev.Auth = &event.AuditAuth{
+			AccessorID: token.AccessorID,
+			Name:       token.Name,
+			Type:       token.Type,
+			Policies:   token.Policies,
+			Global:     token.Global,
+			CreateTime: token.CreateTime,
+		}
+	}
+	if req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
+		if ev.Auth == nil {
+			ev.Auth = &event.AuditAuth{}
+		}
+		ev.Auth.TLSName = req.TLS.PeerCertificates[0].Subject.CommonName
+	}
+
+	if err := s.eventAuditor.Event(req.Context(), event.HTTPEvent, ev); err != nil {
+		s.logger.Error("failed to audit request", "method", req.Method, "path", req.URL.Path, "error", err)
+		return nil, CodedError(http.StatusInternalServerError, "failed to write audit event")
+	}
+	return ev, nil
+}
+
+// auditResponse sends the audit event of a request handled by a handlerFn.
+func (s *HTTPServer) auditResponse(req *http.Request, ev *event.AuditEvent, rspErr error) error {
+	if ev == nil {
+		return nil
+	}
+
+	code, errMsg := errCodeFromHandler(rspErr)
+	if code == 0 {
+		code = http.StatusOK
+	}
+	return s.auditComplete(req, ev, code, errMsg)
+}
+
+// auditComplete sends the audit event of a request once it is handled.
+func (s *HTTPServer) auditComplete(req *http.Request, received *event.AuditEvent, code int, errMsg string) error {
+	ev := *received
+	ev.Stage = event.StageOperationComplete
+	ev.Timestamp = time.Now().UTC()
+	ev.Response = &event.AuditResponse{
+		StatusCode: code,
+		Error:      errMsg,
+	}
+
+	if err := s.eventAuditor.Event(req.Context(), event.HTTPEvent, &ev); err != nil {
+		s.logger.Error("failed to audit request", "method", req.Method, "path", req.URL.Path, "error", err)
+		return CodedError(http.StatusInternalServerError, "failed to write audit event")
+	}
+	return nil
 }
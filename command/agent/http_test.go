@@ -1477,6 +1477,112 @@ func TestHTTPServer_ResolveToken(t *testing.T) {
 	})
 }
 
+func TestHTTPServer_TokenAllowedCIDRs(t *testing.T) {
+	ci.Parallel(t)
+
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		token := mock.CreatePolicyAndToken(t, state, 1000, "node",
+			mock.NodePolicy(acl.PolicyWrite)+mock.AgentPolicy(acl.PolicyRead))
+		// the client of the agent resolves the token over RPC from loopback
+		token = token.Copy()
+		token.AllowedCIDRs = []string{"10.0.0.0/8", "127.0.0.0/8"}
+		must.NoError(t, state.UpsertACLTokens(structs.MsgTypeTestSetup, 1001, []*structs.ACLToken{token}))
+
+		// the servers check the address of the HTTP client when the token is
+		// used for RPCs, and the agent for the endpoints it serves itself or
+		// through its client
+		handlers := map[string]func(http.ResponseWriter, *http.Request){
+			"/v1/nodes":        s.Server.wrap(s.Server.NodesRequest),
+			"/v1/agent/self":   s.Server.wrap(s.Server.AgentSelfRequest),
+			"/v1/client/stats": s.Server.wrap(s.Server.ClientStatsRequest),
+		}
+
+		cases := []struct {
+			remoteAddr string
+			expCode    int
+		}{
+			{remoteAddr: "10.1.2.3:4646", expCode: http.StatusOK},
+			{remoteAddr: "192.168.1.1:4646", expCode: http.StatusForbidden},
+			{remoteAddr: "@", expCode: http.StatusForbidden},
+		}
+		for path, handler := range handlers {
+			for _, tc := range cases {
+				t.Run(path+" "+tc.remoteAddr, func(t *testing.T) {
+					req := httptest.NewRequest(http.MethodGet, path, nil)
+					req.RemoteAddr = tc.remoteAddr
+					setToken(req, token)
+					respW := httptest.NewRecorder()
+					handler(respW, req)
+					must.Eq(t, tc.expCode, respW.Code)
+				})
+			}
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/agent/self", nil)
+		req.RemoteAddr = "192.168.1.1:4646"
+		setToken(req, token)
+		_, err := s.Server.ResolveToken(req)
+		must.ErrorIs(t, err, structs.ErrPermissionDenied)
+	})
+}
+
+func TestHTTPServer_TokenAllowedCIDRs_Client(t *testing.T) {
+	ci.Parallel(t)
+
+	serverAgent := NewTestAgent(t, "server", func(c *Config) {
+		c.ACL.Enabled = true
+		c.Client.Enabled = false
+	})
+	defer serverAgent.Shutdown()
+	testutil.WaitForKeyring(t, serverAgent.Agent.RPC, serverAgent.Config.Region)
+
+	// the client agent is only considered started once it can be read
+	// anonymously
+	state := serverAgent.Agent.server.State()
+	mock.CreatePolicy(t, state, 999, "anonymous", mock.AgentPolicy(acl.PolicyRead))
+
+	// the client agent resolves the token over RPC from loopback
+	token := mock.CreatePolicyAndToken(t, state, 1000, "node",
+		mock.NodePolicy(acl.PolicyRead)+mock.AgentPolicy(acl.PolicyRead))
+	token = token.Copy()
+	token.AllowedCIDRs = []string{"10.0.0.0/8", "127.0.0.0/8"}
+	must.NoError(t, state.UpsertACLTokens(structs.MsgTypeTestSetup, 1001, []*structs.ACLToken{token}))
+
+	// client agents resolve the token through the servers but serve these
+	// endpoints themselves, so they must check the address of the HTTP client
+	s := makeHTTPServer(t, func(c *Config) {
+		c.ACL.Enabled = true
+		c.Server.Enabled = false
+		c.Client.Servers = []string{fmt.Sprintf("localhost:%d", serverAgent.Config.Ports.RPC)}
+	})
+	defer s.Shutdown()
+
+	request := func(handler func(http.ResponseWriter, *http.Request), path, remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		setToken(req, token)
+		respW := httptest.NewRecorder()
+		handler(respW, req)
+		return respW.Code
+	}
+
+	for path, handler := range map[string]func(http.ResponseWriter, *http.Request){
+		"/v1/agent/self":   s.Server.wrap(s.Server.AgentSelfRequest),
+		"/v1/client/stats": s.Server.wrap(s.Server.ClientStatsRequest),
+	} {
+		testutil.WaitForResult(func() (bool, error) {
+			if code := request(handler, path, "10.1.2.3:4646"); code != http.StatusOK {
+				return false, fmt.Errorf("%s: expected 200, got %d", path, code)
+			}
+			return true, nil
+		}, func(err error) {
+			t.Fatal(err)
+		})
+		must.Eq(t, http.StatusForbidden, request(handler, path, "192.168.1.1:4646"))
+	}
+}
+
 func Test_IsAPIClientError(t *testing.T) {
 	ci.Parallel(t)
 
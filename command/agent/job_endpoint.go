@@ -12,6 +12,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/snappy"
 	"github.com/gorilla/websocket"
@@ -87,6 +88,12 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/summary"):
 		jobID := strings.TrimSuffix(path, "/summary")
 		return s.jobSummaryRequest(resp, req, jobID)
+	case strings.HasSuffix(path, "/array"):
+		jobID := strings.TrimSuffix(path, "/array")
+		return s.jobArraySummaryRequest(resp, req, jobID)
+	case strings.HasSuffix(path, "/dispatch/queue"):
+		jobID := strings.TrimSuffix(path, "/dispatch/queue")
+		return s.jobDispatchQueueRequest(resp, req, jobID)
 	case strings.HasSuffix(path, "/dispatch"):
 		jobID := strings.TrimSuffix(path, "/dispatch")
 		return s.jobDispatchRequest(resp, req, jobID)
@@ -667,6 +674,21 @@ func (s *HTTPServer) jobDelete(resp http.ResponseWriter, req *http.Request, jobI
 	}
 	args.NoShutdownDelay = noShutdownDelay
 
+	// Identify the shutdown_delay query param and parse.
+	if shutdownDelayStr := req.URL.Query().Get("shutdown_delay"); shutdownDelayStr != "" {
+		shutdownDelay, err := time.ParseDuration(shutdownDelayStr)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse value of %q (%v) as a duration: %v", "shutdown_delay", shutdownDelayStr, err)
+		}
+		if shutdownDelay < 0 {
+			return nil, CodedError(http.StatusBadRequest, "shutdown_delay must not be negative")
+		}
+		if noShutdownDelay && shutdownDelay > 0 {
+			return nil, CodedError(http.StatusBadRequest, "no_shutdown_delay and shutdown_delay cannot be used together")
+		}
+		args.ShutdownDelay = shutdownDelay
+	}
+
 	// Validate the evaluation priority if the user supplied a non-default
 	// value. It's more efficient to do it here, within the agent rather than
 	// sending a bad request for the server to reject.
@@ -884,6 +906,30 @@ func (s *HTTPServer) jobSummaryRequest(resp http.ResponseWriter, req *http.Reque
 	return out.JobSummary, nil
 }
 
+func (s *HTTPServer) jobArraySummaryRequest(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobSpecificRequest{
+		JobID: jobID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobArraySummaryResponse
+	if err := s.agent.RPC("Job.ArraySummary", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Summary == nil {
+		return nil, CodedError(404, "job not found")
+	}
+	return out.Summary, nil
+}
+
 func (s *HTTPServer) jobDispatchRequest(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -909,6 +955,44 @@ func (s *HTTPServer) jobDispatchRequest(resp http.ResponseWriter, req *http.Requ
 	return out, nil
 }
 
+// jobDispatchQueueRequest lists the dispatch queue of a parameterized job on
+// GET, and cancels a queued dispatched job on DELETE.
+func (s *HTTPServer) jobDispatchQueueRequest(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	switch req.Method {
+	case http.MethodGet:
+		args := structs.JobSpecificRequest{
+			JobID: jobID,
+		}
+		if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+			return nil, nil
+		}
+
+		var out structs.JobDispatchQueueResponse
+		if err := s.agent.RPC("Job.DispatchQueue", &args, &out); err != nil {
+			return nil, err
+		}
+
+		setMeta(resp, &out.QueryMeta)
+		return out.Queue, nil
+
+	case http.MethodDelete:
+		args := structs.JobDispatchCancelRequest{
+			JobID: jobID,
+		}
+		s.parseWriteRequest(req, &args.WriteRequest)
+
+		var out structs.JobDispatchCancelResponse
+		if err := s.agent.RPC("Job.DispatchCancel", &args, &out); err != nil {
+			return nil, err
+		}
+		setIndex(resp, out.Index)
+		return nil, nil
+
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
 func (s *HTTPServer) jobDispatchPayloadRequest(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -1040,6 +1124,7 @@ func (s *HTTPServer) apiJobAndRequestToStructs(job *api.Job, req *http.Request,
 	}
 
 	s.parseToken(req, &writeReq.AuthToken)
+	writeReq.SetSourceIP(sourceIP(req))
 
 	queryRegion := req.URL.Query().Get("region")
 	requestRegion, jobRegion := regionForJob(
@@ -1177,10 +1262,12 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 
 	if job.Periodic != nil {
 		j.Periodic = &structs.PeriodicConfig{
-			Enabled:         *job.Periodic.Enabled,
-			SpecType:        *job.Periodic.SpecType,
-			ProhibitOverlap: *job.Periodic.ProhibitOverlap,
-			TimeZone:        *job.Periodic.TimeZone,
+			Enabled:           *job.Periodic.Enabled,
+			SpecType:          *job.Periodic.SpecType,
+			ProhibitOverlap:   *job.Periodic.ProhibitOverlap,
+			ConcurrencyPolicy: *job.Periodic.ConcurrencyPolicy,
+			HistoryLimit:      *job.Periodic.HistoryLimit,
+			TimeZone:          *job.Periodic.TimeZone,
 		}
 
 		if job.Periodic.Spec != nil {
@@ -1190,13 +1277,43 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		if job.Periodic.Specs != nil {
 			j.Periodic.Specs = job.Periodic.Specs
 		}
+
+		if l := len(job.Periodic.Prohibit); l != 0 {
+			j.Periodic.Prohibit = make([]*structs.PeriodicProhibit, l)
+			for i, w := range job.Periodic.Prohibit {
+				j.Periodic.Prohibit[i] = &structs.PeriodicProhibit{
+					Start:    *w.Start,
+					Duration: *w.Duration,
+					Action:   *w.Action,
+				}
+			}
+		}
 	}
 
 	if job.ParameterizedJob != nil {
 		j.ParameterizedJob = &structs.ParameterizedJobConfig{
-			Payload:      job.ParameterizedJob.Payload,
-			MetaRequired: job.ParameterizedJob.MetaRequired,
-			MetaOptional: job.ParameterizedJob.MetaOptional,
+			Payload:       job.ParameterizedJob.Payload,
+			MetaRequired:  job.ParameterizedJob.MetaRequired,
+			MetaOptional:  job.ParameterizedJob.MetaOptional,
+			MaxConcurrent: job.ParameterizedJob.MaxConcurrent,
+			QueuePolicy:   job.ParameterizedJob.QueuePolicy,
+		}
+	}
+
+	if len(job.DependsOn) > 0 {
+		j.DependsOn = make([]*structs.JobDependency, len(job.DependsOn))
+		for i, dep := range job.DependsOn {
+			j.DependsOn[i] = &structs.JobDependency{
+				JobID:  *dep.JobID,
+				Status: *dep.Status,
+			}
+		}
+	}
+
+	if job.Array != nil {
+		j.Array = &structs.JobArray{}
+		if job.Array.Count != nil {
+			j.Array.Count = *job.Array.Count
 		}
 	}
 
@@ -1236,6 +1353,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	tg.Meta = taskGroup.Meta
 	tg.Constraints = ApiConstraintsToStructs(taskGroup.Constraints)
 	tg.Affinities = ApiAffinitiesToStructs(taskGroup.Affinities)
+	tg.JobAffinities = ApiJobAffinitiesToStructs(taskGroup.JobAffinities)
+	if taskGroup.Gang != nil {
+		tg.Gang = *taskGroup.Gang
+	}
 	tg.Networks = ApiNetworkResourceToStructs(taskGroup.Networks)
 	tg.Services = ApiServicesToStructs(taskGroup.Services, true)
 	tg.Consul = apiConsulToStructs(taskGroup.Consul)
@@ -1248,6 +1369,13 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		RenderTemplates: *taskGroup.RestartPolicy.RenderTemplates,
 	}
 
+	if taskGroup.RestartBudget != nil {
+		tg.RestartBudget = &structs.RestartBudget{
+			Attempts: *taskGroup.RestartBudget.Attempts,
+			Interval: *taskGroup.RestartBudget.Interval,
+		}
+	}
+
 	if taskGroup.ShutdownDelay != nil {
 		tg.ShutdownDelay = taskGroup.ShutdownDelay
 	}
@@ -1261,6 +1389,14 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			MaxDelay:      *taskGroup.ReschedulePolicy.MaxDelay,
 			Unlimited:     *taskGroup.ReschedulePolicy.Unlimited,
 		}
+
+		// jitter and avoid_node_window have no default
+		if taskGroup.ReschedulePolicy.Jitter != nil {
+			tg.ReschedulePolicy.Jitter = *taskGroup.ReschedulePolicy.Jitter
+		}
+		if taskGroup.ReschedulePolicy.AvoidNodeWindow != nil {
+			tg.ReschedulePolicy.AvoidNodeWindow = *taskGroup.ReschedulePolicy.AvoidNodeWindow
+		}
 	}
 
 	if taskGroup.Disconnect != nil {
@@ -1345,6 +1481,7 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			HealthyDeadline:  *taskGroup.Update.HealthyDeadline,
 			ProgressDeadline: *taskGroup.Update.ProgressDeadline,
 			Canary:           *taskGroup.Update.Canary,
+			CanarySteps:      slices.Clone(taskGroup.Update.CanarySteps),
 		}
 
 		// boolPtr fields may be nil, others will have pointers to default values via Canonicalize
@@ -1355,6 +1492,27 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		// bake_time has no default
+		if taskGroup.Update.BakeTime != nil {
+			tg.Update.BakeTime = *taskGroup.Update.BakeTime
+		}
+
+		// decommission_delay has no default
+		if taskGroup.Update.DecommissionDelay != nil {
+			tg.Update.DecommissionDelay = *taskGroup.Update.DecommissionDelay
+		}
+
+		if verify := taskGroup.Update.Verify; verify != nil {
+			tg.Update.Verify = &structs.UpdateVerify{
+				Type:     *verify.Type,
+				Address:  *verify.Address,
+				Query:    *verify.Query,
+				Checks:   *verify.Checks,
+				Interval: *verify.Interval,
+				Timeout:  *verify.Timeout,
+			}
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1440,16 +1598,26 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 	if len(apiTask.Artifacts) > 0 {
 		structsTask.Artifacts = []*structs.TaskArtifact{}
 		for _, ta := range apiTask.Artifacts {
-			structsTask.Artifacts = append(structsTask.Artifacts,
-				&structs.TaskArtifact{
-					GetterSource:   *ta.GetterSource,
-					GetterOptions:  maps.Clone(ta.GetterOptions),
-					GetterHeaders:  maps.Clone(ta.GetterHeaders),
-					GetterMode:     *ta.GetterMode,
-					GetterInsecure: *ta.GetterInsecure,
-					RelativeDest:   *ta.RelativeDest,
-					Chown:          ta.Chown,
-				})
+			artifact := &structs.TaskArtifact{
+				GetterSource:   *ta.GetterSource,
+				GetterOptions:  maps.Clone(ta.GetterOptions),
+				GetterHeaders:  maps.Clone(ta.GetterHeaders),
+				GetterMode:     *ta.GetterMode,
+				GetterInsecure: *ta.GetterInsecure,
+				RelativeDest:   *ta.RelativeDest,
+				Chown:          ta.Chown,
+			}
+			if v := ta.Verify; v != nil {
+				artifact.Verify = &structs.TaskArtifactVerify{
+					Type:                  v.Type,
+					Signature:             v.Signature,
+					PublicKey:             v.PublicKey,
+					Bundle:                v.Bundle,
+					CertificateIdentity:   v.CertificateIdentity,
+					CertificateOIDCIssuer: v.CertificateOIDCIssuer,
+				}
+			}
+			structsTask.Artifacts = append(structsTask.Artifacts, artifact)
 		}
 	}
 
@@ -1464,6 +1632,11 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 			ChangeSignal:         *apiTask.Vault.ChangeSignal,
 			AllowTokenExpiration: *apiTask.Vault.AllowTokenExpiration,
 		}
+		if rotation := apiTask.Vault.SecretRotation; rotation != nil {
+			structsTask.Vault.SecretRotation = &structs.VaultSecretRotation{
+				DrainScript: apiChangeScriptToStructsChangeScript(rotation.DrainScript),
+			}
+		}
 	}
 
 	if apiTask.Consul != nil {
@@ -1481,6 +1654,7 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 					ChangeMode:    *template.ChangeMode,
 					ChangeSignal:  *template.ChangeSignal,
 					ChangeScript:  apiChangeScriptToStructsChangeScript(template.ChangeScript),
+					Exec:          apiTemplateExecToStructsTemplateExec(template.Exec),
 					Once:          *template.Once,
 					Splay:         *template.Splay,
 					Perms:         *template.Perms,
@@ -1489,9 +1663,16 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 					LeftDelim:     *template.LeftDelim,
 					RightDelim:    *template.RightDelim,
 					Envvars:       *template.Envvars,
+					EnvPrefix:     template.EnvPrefix,
+					UnsetIfEmpty:  *template.UnsetIfEmpty,
 					VaultGrace:    *template.VaultGrace,
 					Wait:          apiWaitConfigToStructsWaitConfig(template.Wait),
 					ErrMissingKey: *template.ErrMissingKey,
+					VaultRole:     template.VaultRole,
+
+					FirstRenderTimeout:       *template.FirstRenderTimeout,
+					FirstRenderTimeoutAction: *template.FirstRenderTimeoutAction,
+					Checksum:                 template.Checksum,
 				})
 		}
 	}
@@ -1507,6 +1688,9 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 			Hook:    apiTask.Lifecycle.Hook,
 			Sidecar: apiTask.Lifecycle.Sidecar,
 		}
+		if apiTask.Lifecycle.DrainDelay != nil {
+			structsTask.Lifecycle.DrainDelay = *apiTask.Lifecycle.DrainDelay
+		}
 	}
 
 	for _, action := range apiTask.Actions {
@@ -1514,6 +1698,14 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 		structsTask.Actions = append(structsTask.Actions, act)
 	}
 
+	for _, secret := range apiTask.Secrets {
+		structsTask.Secrets = append(structsTask.Secrets, &structs.Secret{
+			Name: secret.Name,
+			Path: secret.Path,
+			Env:  secret.Env,
+		})
+	}
+
 	if apiTask.Schedule != nil {
 		sched := apiScheduleToStructsSchedule(apiTask.Schedule)
 		structsTask.Schedule = sched
@@ -1546,6 +1738,17 @@ func apiChangeScriptToStructsChangeScript(changeScript *api.ChangeScript) *struc
 	}
 }
 
+func apiTemplateExecToStructsTemplateExec(exec *api.TemplateExec) *structs.TemplateExec {
+	if exec == nil {
+		return nil
+	}
+
+	return &structs.TemplateExec{
+		Command: *exec.Command,
+		Args:    exec.Args,
+	}
+}
+
 func ApiCSIPluginConfigToStructsCSIPluginConfig(apiConfig *api.TaskCSIPluginConfig) *structs.TaskCSIPluginConfig {
 	if apiConfig == nil {
 		return nil
@@ -1593,6 +1796,10 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		MemoryMB: *in.MemoryMB,
 	}
 
+	if in.CPUMax != nil {
+		out.CPUMax = *in.CPUMax
+	}
+
 	if in.Cores != nil {
 		out.Cores = *in.Cores
 	}
@@ -1632,6 +1839,22 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		out.SecretsMB = *in.SecretsMB
 	}
 
+	if in.DiskIO != nil {
+		out.DiskIO = &structs.DiskIO{}
+		if in.DiskIO.ReadBPS != nil {
+			out.DiskIO.ReadBPS = *in.DiskIO.ReadBPS
+		}
+		if in.DiskIO.WriteBPS != nil {
+			out.DiskIO.WriteBPS = *in.DiskIO.WriteBPS
+		}
+		if in.DiskIO.ReadIOPS != nil {
+			out.DiskIO.ReadIOPS = *in.DiskIO.ReadIOPS
+		}
+		if in.DiskIO.WriteIOPS != nil {
+			out.DiskIO.WriteIOPS = *in.DiskIO.WriteIOPS
+		}
+	}
+
 	return out
 }
 
@@ -1643,11 +1866,16 @@ func ApiNetworkResourceToStructs(in []*api.NetworkResource) []*structs.NetworkRe
 	out = make([]*structs.NetworkResource, len(in))
 	for i, nw := range in {
 		out[i] = &structs.NetworkResource{
-			Mode:     nw.Mode,
-			CIDR:     nw.CIDR,
-			IP:       nw.IP,
-			Hostname: nw.Hostname,
-			MBits:    nw.Megabits(),
+			Mode:         nw.Mode,
+			CIDR:         nw.CIDR,
+			IP:           nw.IP,
+			Hostname:     nw.Hostname,
+			MBits:        nw.Megabits(),
+			EgressMBits:  nw.EgressMBits,
+			IngressMBits: nw.IngressMBits,
+			CNINetworks:  slices.Clone(nw.CNINetworks),
+			IPv4Address:  nw.IPv4Address,
+			IPv6Address:  nw.IPv6Address,
 		}
 
 		if nw.DNS != nil {
@@ -1738,11 +1966,13 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 					Notes:                  check.Notes,
 					TLSServerName:          check.TLSServerName,
 					TLSSkipVerify:          check.TLSSkipVerify,
+					TLSCACert:              check.TLSCACert,
 					Header:                 check.Header,
 					Method:                 check.Method,
 					Body:                   check.Body,
 					GRPCService:            check.GRPCService,
 					GRPCUseTLS:             check.GRPCUseTLS,
+					TCPUseTLS:              check.TCPUseTLS,
 					SuccessBeforePassing:   check.SuccessBeforePassing,
 					FailuresBeforeCritical: check.FailuresBeforeCritical,
 					FailuresBeforeWarning:  check.FailuresBeforeWarning,
@@ -1773,6 +2003,7 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 		}
 
 		out[i].Weights = apiWorkloadWeightsToStructs(s.Weights)
+		out[i].CanaryWeights = apiWorkloadWeightsToStructs(s.CanaryWeights)
 
 	}
 
@@ -1793,6 +2024,19 @@ func apiWorkloadIdentityToStructs(in *api.WorkloadIdentity) *structs.WorkloadIde
 		Filepath:     in.Filepath,
 		ServiceName:  in.ServiceName,
 		TTL:          in.TTL,
+		AWS:          apiWorkloadIdentityAWSToStructs(in.AWS),
+	}
+}
+
+func apiWorkloadIdentityAWSToStructs(in *api.WorkloadIdentityAWS) *structs.WorkloadIdentityAWS {
+	if in == nil {
+		return nil
+	}
+	return &structs.WorkloadIdentityAWS{
+		RoleARN:  in.RoleARN,
+		Region:   in.Region,
+		Profile:  in.Profile,
+		Duration: in.Duration,
 	}
 }
 
@@ -2178,6 +2422,22 @@ func apiLogConfigToStructs(in *api.LogConfig) *structs.LogConfig {
 		Disabled:      dereferenceBool(in.Disabled),
 		MaxFiles:      dereferenceInt(in.MaxFiles),
 		MaxFileSizeMB: dereferenceInt(in.MaxFileSizeMB),
+		Sink:          apiLogSinkToStructs(in.Sink),
+		Format:        in.Format,
+	}
+}
+
+func apiLogSinkToStructs(in *api.LogSink) *structs.LogSink {
+	if in == nil {
+		return nil
+	}
+
+	return &structs.LogSink{
+		Type:     in.Type,
+		Address:  in.Address,
+		Path:     in.Path,
+		Tag:      in.Tag,
+		Facility: in.Facility,
 	}
 }
 
@@ -2233,6 +2493,24 @@ func ApiAffinitiesToStructs(in []*api.Affinity) []*structs.Affinity {
 	return out
 }
 
+func ApiJobAffinitiesToStructs(in []*api.JobAffinity) []*structs.JobAffinity {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]*structs.JobAffinity, len(in))
+	for i, a := range in {
+		out[i] = &structs.JobAffinity{
+			Job:      a.Job,
+			Service:  a.Service,
+			Weight:   *a.Weight,
+			Required: *a.Required,
+		}
+	}
+
+	return out
+}
+
 func ApiJobUIConfigToStructs(jobUI *api.JobUIConfig) *structs.JobUIConfig {
 	if jobUI == nil {
 		return nil
@@ -2282,6 +2560,9 @@ func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
 	ret.Weight = *a1.Weight
+	if a1.MaxSkew != nil {
+		ret.MaxSkew = *a1.MaxSkew
+	}
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
 		for i, st := range a1.SpreadTarget {
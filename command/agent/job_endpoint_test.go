@@ -1583,6 +1583,46 @@ func TestHTTP_JobAllocations(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobArraySummary(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the job
+		job := mock.BatchJob()
+		job.Array = &structs.JobArray{Count: 2}
+		job.TaskGroups[0].Count = 2
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		must.NoError(t, s.Agent.RPC("Job.Register", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest(http.MethodGet, "/v1/job/"+job.ID+"/array", nil)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		must.NoError(t, err)
+
+		// Check the response
+		summary := obj.(*structs.JobArraySummary)
+		must.Eq(t, job.ID, summary.JobID)
+		must.Eq(t, 2, summary.Count)
+		must.Eq(t, 2, summary.TaskGroups["web"].Pending)
+		must.NotEq(t, "", respW.Result().Header.Get("X-Nomad-Index"))
+
+		// Unknown jobs are not found
+		req, err = http.NewRequest(http.MethodGet, "/v1/job/unknown/array", nil)
+		must.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "job not found")
+	})
+}
+
 func TestHTTP_JobDeployments(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
@@ -2740,17 +2780,37 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			},
 		},
 		Periodic: &api.PeriodicConfig{
-			Enabled:         pointer.Of(true),
-			Spec:            pointer.Of("spec"),
-			Specs:           []string{"spec"},
-			SpecType:        pointer.Of("cron"),
-			ProhibitOverlap: pointer.Of(true),
-			TimeZone:        pointer.Of("test zone"),
+			Enabled:           pointer.Of(true),
+			Spec:              pointer.Of("spec"),
+			Specs:             []string{"spec"},
+			SpecType:          pointer.Of("cron"),
+			ProhibitOverlap:   pointer.Of(true),
+			ConcurrencyPolicy: pointer.Of("forbid"),
+			HistoryLimit:      pointer.Of(5),
+			TimeZone:          pointer.Of("test zone"),
+			Prohibit: []*api.PeriodicProhibit{
+				{
+					Start:    pointer.Of("0 0 * * SAT"),
+					Duration: pointer.Of(48 * time.Hour),
+					Action:   pointer.Of("defer"),
+				},
+			},
 		},
 		ParameterizedJob: &api.ParameterizedJobConfig{
-			Payload:      "payload",
-			MetaRequired: []string{"a", "b"},
-			MetaOptional: []string{"c", "d"},
+			Payload:       "payload",
+			MetaRequired:  []string{"a", "b"},
+			MetaOptional:  []string{"c", "d"},
+			MaxConcurrent: 2,
+			QueuePolicy:   "priority",
+		},
+		Array: &api.JobArray{
+			Count: pointer.Of(3),
+		},
+		DependsOn: []*api.JobDependency{
+			{
+				JobID:  pointer.Of("extract"),
+				Status: pointer.Of("complete"),
+			},
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -2796,13 +2856,19 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					Mode:            pointer.Of("delay"),
 					RenderTemplates: pointer.Of(false),
 				},
+				RestartBudget: &api.RestartBudget{
+					Attempts: pointer.Of(20),
+					Interval: pointer.Of(time.Hour),
+				},
 				ReschedulePolicy: &api.ReschedulePolicy{
-					Interval:      pointer.Of(12 * time.Hour),
-					Attempts:      pointer.Of(5),
-					DelayFunction: pointer.Of("constant"),
-					Delay:         pointer.Of(30 * time.Second),
-					Unlimited:     pointer.Of(true),
-					MaxDelay:      pointer.Of(20 * time.Minute),
+					Interval:        pointer.Of(12 * time.Hour),
+					Attempts:        pointer.Of(5),
+					DelayFunction:   pointer.Of("constant"),
+					Delay:           pointer.Of(30 * time.Second),
+					Unlimited:       pointer.Of(true),
+					MaxDelay:        pointer.Of(20 * time.Minute),
+					Jitter:          pointer.Of(0.25),
+					AvoidNodeWindow: pointer.Of(time.Hour),
 				},
 				Migrate: &api.MigrateStrategy{
 					MaxParallel:     pointer.Of(12),
@@ -2821,18 +2887,37 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							},
 						},
 					},
+					{
+						Attribute: "${meta.rack}",
+						Weight:    pointer.Of(int8(50)),
+						MaxSkew:   pointer.Of(1),
+					},
+				},
+				JobAffinities: []*api.JobAffinity{
+					{
+						Service:  "redis",
+						Weight:   pointer.Of(int8(-50)),
+						Required: pointer.Of(true),
+					},
 				},
+				Gang: pointer.Of("training"),
 				EphemeralDisk: &api.EphemeralDisk{
 					SizeMB:  pointer.Of(100),
 					Sticky:  pointer.Of(true),
 					Migrate: pointer.Of(true),
 				},
 				Update: &api.UpdateStrategy{
-					HealthCheck:      pointer.Of(structs.UpdateStrategyHealthCheck_Checks),
-					MinHealthyTime:   pointer.Of(2 * time.Minute),
-					HealthyDeadline:  pointer.Of(5 * time.Minute),
-					ProgressDeadline: pointer.Of(5 * time.Minute),
-					AutoRevert:       pointer.Of(true),
+					HealthCheck:       pointer.Of(structs.UpdateStrategyHealthCheck_Checks),
+					MinHealthyTime:    pointer.Of(2 * time.Minute),
+					HealthyDeadline:   pointer.Of(5 * time.Minute),
+					ProgressDeadline:  pointer.Of(5 * time.Minute),
+					AutoRevert:        pointer.Of(true),
+					CanarySteps:       []int{25, 50},
+					BakeTime:          pointer.Of(time.Minute),
+					DecommissionDelay: pointer.Of(5 * time.Minute),
+					Verify: &api.UpdateVerify{
+						Address: pointer.Of("http://127.0.0.1:8080/health"),
+					},
 				},
 				Meta: map[string]string{
 					"key": "value",
@@ -2858,6 +2943,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							Passing: 5,
 							Warning: 1,
 						},
+						CanaryWeights: &api.ServiceWeights{
+							Passing: 1,
+							Warning: 1,
+						},
 						CheckRestart: &api.CheckRestart{
 							Limit: 4,
 							Grace: pointer.Of(11 * time.Second),
@@ -2876,6 +2965,8 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								AddressMode:   "driver",
 								GRPCService:   "foo.Bar",
 								GRPCUseTLS:    true,
+								TCPUseTLS:     true,
+								TLSCACert:     "ca",
 								Interval:      4 * time.Second,
 								Timeout:       2 * time.Second,
 								InitialStatus: "ok",
@@ -2933,6 +3024,13 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								Weight:  pointer.Of(int8(50)),
 							},
 						},
+						Secrets: []*api.Secret{
+							{
+								Name: "db",
+								Path: "db/creds",
+								Env:  true,
+							},
+						},
 						Identities: []*api.WorkloadIdentity{
 							{
 								Name:         "aws",
@@ -2941,6 +3039,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								File:         true,
 								ChangeMode:   "signal",
 								ChangeSignal: "SIGHUP",
+								AWS: &api.WorkloadIdentityAWS{
+									RoleARN:  "arn:aws:iam::123456789012:role/s3",
+									Region:   "eu-west-1",
+									Profile:  "default",
+									Duration: time.Hour,
+								},
 							},
 						},
 						VolumeMounts: []*api.VolumeMount{
@@ -2989,6 +3093,8 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 										AddressMode:            "driver",
 										GRPCService:            "foo.Bar",
 										GRPCUseTLS:             true,
+										TCPUseTLS:              true,
+										TLSCACert:              "ca",
 										Interval:               4 * time.Second,
 										Timeout:                2 * time.Second,
 										InitialStatus:          "ok",
@@ -3088,6 +3194,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							DisableFile:  pointer.Of(false),
 							ChangeMode:   pointer.Of("c"),
 							ChangeSignal: pointer.Of("sighup"),
+							SecretRotation: &api.VaultSecretRotation{
+								DrainScript: &api.ChangeScript{
+									Command: pointer.Of("/bin/drain"),
+								},
+							},
 						},
 						Templates: []*api.Template{
 							{
@@ -3102,18 +3213,28 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 									Timeout:     pointer.Of(5 * time.Second),
 									FailOnError: pointer.Of(false),
 								},
-								Splay:      pointer.Of(1 * time.Minute),
-								Perms:      pointer.Of("666"),
-								Uid:        pointer.Of(1000),
-								Gid:        pointer.Of(1000),
-								LeftDelim:  pointer.Of("abc"),
-								RightDelim: pointer.Of("def"),
-								Envvars:    pointer.Of(true),
+								Exec: &api.TemplateExec{
+									Command: pointer.Of("/bin/helper"),
+									Args:    []string{"-v"},
+								},
+								Splay:        pointer.Of(1 * time.Minute),
+								Perms:        pointer.Of("666"),
+								Uid:          pointer.Of(1000),
+								Gid:          pointer.Of(1000),
+								LeftDelim:    pointer.Of("abc"),
+								RightDelim:   pointer.Of("def"),
+								Envvars:      pointer.Of(true),
+								EnvPrefix:    "APP_",
+								UnsetIfEmpty: pointer.Of(true),
 								Wait: &api.WaitConfig{
 									Min: pointer.Of(5 * time.Second),
 									Max: pointer.Of(10 * time.Second),
 								},
 								ErrMissingKey: pointer.Of(true),
+
+								FirstRenderTimeout:       pointer.Of(time.Minute),
+								FirstRenderTimeoutAction: pointer.Of("kill"),
+								Checksum:                 "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
 							},
 						},
 						DispatchPayload: &api.DispatchPayloadConfig{
@@ -3176,17 +3297,37 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			MaxParallel: 5,
 		},
 		Periodic: &structs.PeriodicConfig{
-			Enabled:         true,
-			Spec:            "spec",
-			Specs:           []string{"spec"},
-			SpecType:        "cron",
-			ProhibitOverlap: true,
-			TimeZone:        "test zone",
+			Enabled:           true,
+			Spec:              "spec",
+			Specs:             []string{"spec"},
+			SpecType:          "cron",
+			ProhibitOverlap:   true,
+			ConcurrencyPolicy: "forbid",
+			HistoryLimit:      5,
+			TimeZone:          "test zone",
+			Prohibit: []*structs.PeriodicProhibit{
+				{
+					Start:    "0 0 * * SAT",
+					Duration: 48 * time.Hour,
+					Action:   "defer",
+				},
+			},
 		},
 		ParameterizedJob: &structs.ParameterizedJobConfig{
-			Payload:      "payload",
-			MetaRequired: []string{"a", "b"},
-			MetaOptional: []string{"c", "d"},
+			Payload:       "payload",
+			MetaRequired:  []string{"a", "b"},
+			MetaOptional:  []string{"c", "d"},
+			MaxConcurrent: 2,
+			QueuePolicy:   "priority",
+		},
+		Array: &structs.JobArray{
+			Count: 3,
+		},
+		DependsOn: []*structs.JobDependency{
+			{
+				JobID:  "extract",
+				Status: "complete",
+			},
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -3232,6 +3373,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					Mode:            "delay",
 					RenderTemplates: false,
 				},
+				RestartBudget: &structs.RestartBudget{
+					Attempts: 20,
+					Interval: time.Hour,
+				},
 				Spreads: []*structs.Spread{
 					{
 						Attribute: "${node.datacenter}",
@@ -3243,14 +3388,29 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							},
 						},
 					},
+					{
+						Attribute: "${meta.rack}",
+						Weight:    50,
+						MaxSkew:   1,
+					},
+				},
+				JobAffinities: []*structs.JobAffinity{
+					{
+						Service:  "redis",
+						Weight:   -50,
+						Required: true,
+					},
 				},
+				Gang: "training",
 				ReschedulePolicy: &structs.ReschedulePolicy{
-					Interval:      12 * time.Hour,
-					Attempts:      5,
-					DelayFunction: "constant",
-					Delay:         30 * time.Second,
-					Unlimited:     true,
-					MaxDelay:      20 * time.Minute,
+					Interval:        12 * time.Hour,
+					Attempts:        5,
+					DelayFunction:   "constant",
+					Delay:           30 * time.Second,
+					Unlimited:       true,
+					MaxDelay:        20 * time.Minute,
+					Jitter:          0.25,
+					AvoidNodeWindow: time.Hour,
 				},
 				Migrate: &structs.MigrateStrategy{
 					MaxParallel:     12,
@@ -3264,15 +3424,25 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					Migrate: true,
 				},
 				Update: &structs.UpdateStrategy{
-					Stagger:          1 * time.Second,
-					MaxParallel:      5,
-					HealthCheck:      structs.UpdateStrategyHealthCheck_Checks,
-					MinHealthyTime:   2 * time.Minute,
-					HealthyDeadline:  5 * time.Minute,
-					ProgressDeadline: 5 * time.Minute,
-					AutoRevert:       true,
-					AutoPromote:      false,
-					Canary:           1,
+					Stagger:           1 * time.Second,
+					MaxParallel:       5,
+					HealthCheck:       structs.UpdateStrategyHealthCheck_Checks,
+					MinHealthyTime:    2 * time.Minute,
+					HealthyDeadline:   5 * time.Minute,
+					ProgressDeadline:  5 * time.Minute,
+					AutoRevert:        true,
+					AutoPromote:       false,
+					Canary:            1,
+					CanarySteps:       []int{25, 50},
+					BakeTime:          time.Minute,
+					DecommissionDelay: 5 * time.Minute,
+					Verify: &structs.UpdateVerify{
+						Type:     structs.UpdateVerifyTypeHTTP,
+						Address:  "http://127.0.0.1:8080/health",
+						Checks:   1,
+						Interval: 10 * time.Second,
+						Timeout:  5 * time.Second,
+					},
 				},
 				Meta: map[string]string{
 					"key": "value",
@@ -3302,6 +3472,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							Passing: 5,
 							Warning: 1,
 						},
+						CanaryWeights: &structs.ServiceWeights{
+							Passing: 1,
+							Warning: 1,
+						},
 						OnUpdate: structs.OnUpdateRequireHealthy,
 						Checks: []*structs.ServiceCheck{
 							{
@@ -3317,6 +3491,8 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								AddressMode:   "driver",
 								GRPCService:   "foo.Bar",
 								GRPCUseTLS:    true,
+								TCPUseTLS:     true,
+								TLSCACert:     "ca",
 								Interval:      4 * time.Second,
 								Timeout:       2 * time.Second,
 								InitialStatus: "ok",
@@ -3375,6 +3551,13 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								Weight:  50,
 							},
 						},
+						Secrets: []*structs.Secret{
+							{
+								Name: "db",
+								Path: "db/creds",
+								Env:  true,
+							},
+						},
 						Identities: []*structs.WorkloadIdentity{
 							{
 								Name:         "aws",
@@ -3383,6 +3566,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								File:         true,
 								ChangeMode:   "signal",
 								ChangeSignal: "SIGHUP",
+								AWS: &structs.WorkloadIdentityAWS{
+									RoleARN:  "arn:aws:iam::123456789012:role/s3",
+									Region:   "eu-west-1",
+									Profile:  "default",
+									Duration: time.Hour,
+								},
 							},
 						},
 						Env: map[string]string{
@@ -3438,6 +3627,8 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 										Notes:                  "this is a check",
 										GRPCService:            "foo.Bar",
 										GRPCUseTLS:             true,
+										TCPUseTLS:              true,
+										TLSCACert:              "ca",
 										SuccessBeforePassing:   3,
 										FailuresBeforeCritical: 4,
 										FailuresBeforeWarning:  2,
@@ -3541,6 +3732,13 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							ChangeMode:           "c",
 							ChangeSignal:         "sighup",
 							AllowTokenExpiration: false,
+							SecretRotation: &structs.VaultSecretRotation{
+								DrainScript: &structs.ChangeScript{
+									Command: "/bin/drain",
+									Args:    []string{},
+									Timeout: 5 * time.Second,
+								},
+							},
 						},
 						Templates: []*structs.Template{
 							{
@@ -3555,18 +3753,28 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 									Timeout:     5 * time.Second,
 									FailOnError: false,
 								},
-								Splay:      1 * time.Minute,
-								Perms:      "666",
-								Uid:        pointer.Of(1000),
-								Gid:        pointer.Of(1000),
-								LeftDelim:  "abc",
-								RightDelim: "def",
-								Envvars:    true,
+								Exec: &structs.TemplateExec{
+									Command: "/bin/helper",
+									Args:    []string{"-v"},
+								},
+								Splay:        1 * time.Minute,
+								Perms:        "666",
+								Uid:          pointer.Of(1000),
+								Gid:          pointer.Of(1000),
+								LeftDelim:    "abc",
+								RightDelim:   "def",
+								Envvars:      true,
+								EnvPrefix:    "APP_",
+								UnsetIfEmpty: true,
 								Wait: &structs.WaitConfig{
 									Min: pointer.Of(5 * time.Second),
 									Max: pointer.Of(10 * time.Second),
 								},
 								ErrMissingKey: true,
+
+								FirstRenderTimeout:       time.Minute,
+								FirstRenderTimeoutAction: "kill",
+								Checksum:                 "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
 							},
 						},
 						DispatchPayload: &structs.DispatchPayloadConfig{
@@ -3988,6 +4196,21 @@ func TestConversion_apiLogConfigToStructs(t *testing.T) {
 		MaxFiles:      pointer.Of(2),
 		MaxFileSizeMB: pointer.Of(8),
 	}))
+	must.Eq(t, &structs.LogConfig{
+		Format: structs.LogFormatJSON,
+		Sink: &structs.LogSink{
+			Type:    structs.LogSinkTypeSyslog,
+			Address: "udp://127.0.0.1:514",
+			Tag:     "web",
+		},
+	}, apiLogConfigToStructs(&api.LogConfig{
+		Format: "json",
+		Sink: &api.LogSink{
+			Type:    "syslog",
+			Address: "udp://127.0.0.1:514",
+			Tag:     "web",
+		},
+	}))
 
 	// COMPAT(1.6.0): verify backwards compatibility fixes
 	// Note: we're intentionally ignoring the Enabled: false case
@@ -4050,6 +4273,19 @@ func TestConversion_apiResourcesToStructs(t *testing.T) {
 				MemoryMaxMB: 300,
 			},
 		},
+		{
+			"with cpu max",
+			&api.Resources{
+				CPU:      pointer.Of(100),
+				CPUMax:   pointer.Of(400),
+				MemoryMB: pointer.Of(200),
+			},
+			&structs.Resources{
+				CPU:      100,
+				CPUMax:   400,
+				MemoryMB: 200,
+			},
+		},
 		{
 			"with numa",
 			&api.Resources{
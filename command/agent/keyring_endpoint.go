@@ -140,6 +140,11 @@ func (s *HTTPServer) KeyringRequest(resp http.ResponseWriter, req *http.Request)
 		default:
 			return nil, CodedError(405, ErrInvalidMethod)
 		}
+	case strings.HasPrefix(path, "status"):
+		if req.Method != http.MethodGet {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringStatusRequest(resp, req)
 	default:
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
@@ -164,6 +169,25 @@ func (s *HTTPServer) keyringListRequest(resp http.ResponseWriter, req *http.Requ
 	return out.Keys, nil
 }
 
+func (s *HTTPServer) keyringStatusRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+
+	args := structs.KeyringStatusRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringStatusResponse
+	if err := s.agent.RPC("Keyring.Status", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Status.Pending == nil {
+		out.Status.Pending = make([]*structs.KeyringDecryptStatus, 0)
+	}
+	return out.Status, nil
+}
+
 func (s *HTTPServer) keyringRotateRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 
 	args := structs.KeyringRotateRootKeyRequest{}
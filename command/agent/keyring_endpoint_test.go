@@ -125,6 +125,30 @@ func TestHTTP_Keyring_CRUD(t *testing.T) {
 
 // TestHTTP_Keyring_JWKS asserts the JWKS endpoint is enabled by default and
 // caches relative to the key rotation threshold.
+func TestHTTP_Keyring_Status(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		respW := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/v1/operator/keyring/status", nil)
+		must.NoError(t, err)
+		obj, err := s.Server.KeyringRequest(respW, req)
+		must.NoError(t, err)
+
+		status := obj.(*structs.KeyringStatus)
+		must.Eq(t, s.server.GetConfig().NodeName, status.ServerName)
+		must.True(t, status.Ready)
+		must.Eq(t, 1, status.NumKeys)
+		must.NotNil(t, status.Pending)
+		must.SliceEmpty(t, status.Pending)
+
+		req, err = http.NewRequest(http.MethodPut, "/v1/operator/keyring/status", nil)
+		must.NoError(t, err)
+		_, err = s.Server.KeyringRequest(respW, req)
+		must.EqError(t, err, ErrInvalidMethod)
+	})
+}
+
 func TestHTTP_Keyring_JWKS(t *testing.T) {
 	ci.Parallel(t)
 
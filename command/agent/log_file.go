@@ -45,6 +45,9 @@ type logFile struct {
 	// Max rotated files to keep before removing them.
 	MaxFiles int
 
+	// mode is the permissions of the log files, 0640 when unset
+	mode os.FileMode
+
 	//acquire is the mutex utilized to ensure we have no concurrency issues
 	acquire sync.Mutex
 }
@@ -66,7 +69,11 @@ func (l *logFile) openNew() error {
 	// Try creating or opening the active log file. Since the active log file
 	// always has the same name, append log entries to prevent overwriting
 	// previous log data.
-	filePointer, err := os.OpenFile(newfilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	mode := l.mode
+	if mode == 0 {
+		mode = 0640
+	}
+	filePointer, err := os.OpenFile(newfilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, mode)
 	if err != nil {
 		return err
 	}
@@ -131,6 +138,20 @@ func (l *logFile) pruneFiles() error {
 	return nil
 }
 
+// Reopen closes the current log file so that the next write opens the file
+// again, which lets external tools rotate it.
+func (l *logFile) Reopen() error {
+	l.acquire.Lock()
+	defer l.acquire.Unlock()
+
+	if l.FileInfo == nil {
+		return nil
+	}
+	err := l.FileInfo.Close()
+	l.FileInfo = nil
+	return err
+}
+
 // Write is used to implement io.Writer.
 //
 // Nomad's log file capability is fed by go-hclog which is responsible for
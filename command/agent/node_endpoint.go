@@ -134,8 +134,15 @@ func (s *HTTPServer) nodeToggleDrain(resp http.ResponseWriter, req *http.Request
 			DrainSpec: structs.DrainSpec{
 				Deadline:         drainRequest.DrainSpec.Deadline,
 				IgnoreSystemJobs: drainRequest.DrainSpec.IgnoreSystemJobs,
+				JobTypeDeadlines: drainRequest.DrainSpec.JobTypeDeadlines,
 			},
 		}
+		if policy := drainRequest.DrainSpec.KillPolicy; policy != nil {
+			args.DrainStrategy.KillPolicy = &structs.DrainKillPolicy{
+				Signal:  policy.Signal,
+				Timeout: policy.Timeout,
+			}
+		}
 	}
 	s.parseWriteRequest(req, &args.WriteRequest)
 
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) NodeMaintenanceWindowsRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.nodeMaintenanceWindowList(resp, req)
+	case http.MethodPut, http.MethodPost:
+		return s.nodeMaintenanceWindowUpsert(resp, req, "")
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) NodeMaintenanceWindowSpecificRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	id := strings.TrimPrefix(req.URL.Path, "/v1/node/maintenance/window/")
+	if id == "" {
+		return nil, CodedError(http.StatusBadRequest, "missing maintenance window ID")
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		return s.nodeMaintenanceWindowQuery(resp, req, id)
+	case http.MethodPut, http.MethodPost:
+		return s.nodeMaintenanceWindowUpsert(resp, req, id)
+	case http.MethodDelete:
+		return s.nodeMaintenanceWindowDelete(resp, req, id)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) nodeMaintenanceWindowList(resp http.ResponseWriter, req *http.Request) (any, error) {
+	args := structs.NodeMaintenanceWindowListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.NodeMaintenanceWindowListResponse
+	if err := s.agent.RPC("NodeMaintenance.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Windows == nil {
+		out.Windows = make([]*structs.NodeMaintenanceWindow, 0)
+	}
+	return out.Windows, nil
+}
+
+func (s *HTTPServer) nodeMaintenanceWindowQuery(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	args := structs.NodeMaintenanceWindowSpecificRequest{
+		ID: id,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleNodeMaintenanceWindowResponse
+	if err := s.agent.RPC("NodeMaintenance.GetWindow", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Window == nil {
+		return nil, CodedError(http.StatusNotFound, "maintenance window not found")
+	}
+	return out.Window, nil
+}
+
+func (s *HTTPServer) nodeMaintenanceWindowUpsert(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	var window structs.NodeMaintenanceWindow
+	if err := decodeBody(req, &window); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	if id != "" {
+		if window.ID == "" {
+			window.ID = id
+		} else if window.ID != id {
+			return nil, CodedError(http.StatusBadRequest, "Maintenance window ID does not match request path")
+		}
+	}
+
+	args := structs.NodeMaintenanceWindowUpsertRequest{
+		Windows: []*structs.NodeMaintenanceWindow{&window},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.NodeMaintenanceWindowUpsertResponse
+	if err := s.agent.RPC("NodeMaintenance.UpsertWindows", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	if len(out.Windows) == 0 {
+		return nil, nil
+	}
+	return out.Windows[0], nil
+}
+
+func (s *HTTPServer) nodeMaintenanceWindowDelete(resp http.ResponseWriter, req *http.Request, id string) (any, error) {
+	args := structs.NodeMaintenanceWindowDeleteRequest{
+		IDs: []string{id},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("NodeMaintenance.DeleteWindows", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
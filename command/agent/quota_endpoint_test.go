@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_QuotaCRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create a quota specification
+		qs := mock.QuotaSpec()
+		buf := encodeReq(qs)
+		req, err := http.NewRequest(http.MethodPut, "/v1/quota", buf)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.QuotaCreateRequest(respW, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", respW.Result().Header.Get("X-Nomad-Index"))
+
+		// List the quota specifications
+		req, err = http.NewRequest(http.MethodGet, "/v1/quotas", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err := s.Server.QuotasRequest(respW, req)
+		must.NoError(t, err)
+		must.Len(t, 1, obj.([]*structs.QuotaSpec))
+
+		// Query the quota specification and its usage
+		req, err = http.NewRequest(http.MethodGet, "/v1/quota/"+qs.Name, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.QuotaSpecificRequest(respW, req)
+		must.NoError(t, err)
+		must.Eq(t, qs.Limits[0].RegionLimit, obj.(*structs.QuotaSpec).Limits[0].RegionLimit)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/quota/usage/"+qs.Name, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.QuotaSpecificRequest(respW, req)
+		must.NoError(t, err)
+		must.Eq(t, qs.Name, obj.(*structs.QuotaUsage).Name)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/quota-usages", nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.QuotaUsagesRequest(respW, req)
+		must.NoError(t, err)
+		must.Len(t, 1, obj.([]*structs.QuotaUsage))
+
+		// Delete the quota specification
+		req, err = http.NewRequest(http.MethodDelete, "/v1/quota/"+qs.Name, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.QuotaSpecificRequest(respW, req)
+		must.NoError(t, err)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/quota/"+qs.Name, nil)
+		must.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.QuotaSpecificRequest(respW, req)
+		must.ErrorContains(t, err, "Quota not found")
+	})
+}
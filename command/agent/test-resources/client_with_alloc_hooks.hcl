@@ -0,0 +1,18 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+client {
+  enabled = true
+
+  alloc_hook "firewall" {
+    pre_start  = ["/usr/local/bin/firewall", "open"]
+    post_stop  = ["/usr/local/bin/firewall", "close"]
+    timeout    = "10s"
+    namespaces = ["default", "edge"]
+  }
+
+  alloc_hook "routes" {
+    post_stop  = ["/usr/local/bin/routes", "flush"]
+    namespaces = ["*"]
+  }
+}
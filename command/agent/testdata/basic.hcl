@@ -96,6 +96,11 @@ client {
   gc_inode_usage_threshold = 91
   gc_max_allocs            = 50
   gc_volumes_on_node_gc    = true
+
+  burstable_memory_headroom   = 512
+  burstable_eviction_interval = "15s"
+  memory_pressure_threshold   = 40
+  memory_pressure_action      = "restart"
   no_host_uuid             = false
   disable_remote_exec      = true
 
@@ -152,6 +157,11 @@ server {
     node_window    = "41m"
   }
 
+  autoscaler {
+    enabled             = true
+    evaluation_interval = "45s"
+  }
+
   server_join {
     retry_join     = ["1.1.1.1", "2.2.2.2"]
     retry_max      = 3
@@ -165,6 +175,7 @@ server {
       batch_scheduler_enabled   = true
       system_scheduler_enabled  = true
       service_scheduler_enabled = true
+      min_priority_delta        = 20
     }
   }
 
@@ -194,12 +205,29 @@ audit {
     rotate_max_files   = 10
   }
 
+  sink "webhook" {
+    type               = "webhook"
+    delivery_guarantee = "best-effort"
+    format             = "json"
+    address            = "https://audit.example.com/nomad"
+
+    headers {
+      Authorization = "Bearer hunter2"
+    }
+  }
+
   filter "default" {
     type       = "HTTPEvent"
     endpoints  = ["/v1/metrics"]
     stages     = ["*"]
     operations = ["*"]
   }
+
+  filter "heartbeats" {
+    type      = "RPCEvent"
+    endpoints = ["Node.UpdateStatus"]
+    actors    = ["anonymous"]
+  }
 }
 
 telemetry {
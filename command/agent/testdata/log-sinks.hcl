@@ -0,0 +1,18 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+client {
+  log_sink {
+    type     = "syslog"
+    address  = "udp://127.0.0.1:514"
+    facility = "local0"
+  }
+
+  log_sink {
+    type    = "fluentd"
+    address = "127.0.0.1:24224"
+    tag     = "nomad.tasks"
+  }
+
+  log_sink_allowed_addresses = ["tcp://10.0.0.1:514"]
+}
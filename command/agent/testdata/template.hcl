@@ -3,9 +3,10 @@
 
 client {
   template {
-    function_denylist    = ["plugin"]
-    disable_file_sandbox = true
-    max_stale            = "7600h"
+    function_denylist     = ["plugin"]
+    disable_file_sandbox  = true
+    sandbox_allowed_paths = ["/etc/ssl/certs"]
+    max_stale             = "7600h"
 
     wait {
       min = "10s"
@@ -36,5 +37,15 @@ client {
       backoff     = "550ms"
       max_backoff = "10m"
     }
+
+    reload_commands {
+      docker = ["nginx", "-s", "reload"]
+    }
+
+    source_plugins {
+      ssm = ["/usr/local/bin/nomad-ssm", "-region", "eu-west-1"]
+    }
+
+    change_mode_debounce = "2s"
   }
 }
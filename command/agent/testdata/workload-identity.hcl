@@ -0,0 +1,20 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+server {
+  workload_identity {
+    extra_claims = {
+      team = "${job.meta.team}"
+    }
+
+    audience "sts.amazonaws.com" {
+      subject = "${job.namespace}:${job.id}"
+    }
+
+    audience "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/nomad/providers/nomad" {
+      extra_claims = {
+        zone = "${node.attr.platform.gce.zone}"
+      }
+    }
+  }
+}
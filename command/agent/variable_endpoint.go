@@ -17,6 +17,10 @@ import (
 var (
 	renewLockQueryParam = "lock-renew"
 
+	historyQueryParam  = "history"
+	versionQueryParam  = "version"
+	rollbackQueryParam = "rollback"
+
 	acquireLockQueryParam = string(structs.VarOpLockAcquire)
 	releaseLockQueryParam = string(structs.VarOpLockRelease)
 )
@@ -52,6 +56,9 @@ func (s *HTTPServer) VariableSpecificRequest(resp http.ResponseWriter, req *http
 
 	switch req.Method {
 	case http.MethodGet:
+		if _, ok := req.URL.Query()[historyQueryParam]; ok {
+			return s.variableHistory(resp, req, path)
+		}
 		return s.variableQuery(resp, req, path)
 	case http.MethodPut, http.MethodPost:
 		urlParams := req.URL.Query()
@@ -66,6 +73,13 @@ func (s *HTTPServer) VariableSpecificRequest(resp http.ResponseWriter, req *http
 			return nil, CodedError(http.StatusBadRequest, "CAS can't be used with lock operations")
 		}
 
+		if urlParams.Get(rollbackQueryParam) != "" {
+			if lockOperation != "" {
+				return nil, CodedError(http.StatusBadRequest, "rollback can't be used with lock operations")
+			}
+			return s.variableRollback(resp, req, path)
+		}
+
 		if lockOperation == "" {
 			return s.variableUpsert(resp, req, path)
 		}
@@ -150,6 +164,13 @@ func (s *HTTPServer) variableQuery(resp http.ResponseWriter, req *http.Request,
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, CodedError(http.StatusBadRequest, "failed to parse parameters")
 	}
+	if vq := req.URL.Query().Get(versionQueryParam); vq != "" {
+		version, err := strconv.ParseUint(vq, 10, 64)
+		if err != nil {
+			return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("can not parse version: %v", err))
+		}
+		args.Version = &version
+	}
 	var out structs.VariablesReadResponse
 	if err := s.agent.RPC(structs.VariablesReadRPCMethod, &args, &out); err != nil {
 		return nil, err
@@ -163,6 +184,64 @@ func (s *HTTPServer) variableQuery(resp http.ResponseWriter, req *http.Request,
 	return out.Data, nil
 }
 
+func (s *HTTPServer) variableHistory(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+	args := structs.VariablesHistoryRequest{
+		Path: path,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, CodedError(http.StatusBadRequest, "failed to parse parameters")
+	}
+	var out structs.VariablesHistoryResponse
+	if err := s.agent.RPC(structs.VariablesHistoryRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+
+	if out.Versions == nil {
+		return nil, CodedError(http.StatusNotFound, "variable not found")
+	}
+	return out.Versions, nil
+}
+
+func (s *HTTPServer) variableRollback(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+
+	version, err := strconv.ParseUint(req.URL.Query().Get(rollbackQueryParam), 10, 64)
+	if err != nil {
+		return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("can not parse rollback: %v", err))
+	}
+
+	args := structs.VariablesRollbackRequest{
+		Path:    path,
+		Version: version,
+	}
+
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	if isCas, checkIndex, err := parseCAS(req); err != nil {
+		return nil, err
+	} else if isCas {
+		args.CheckIndex = &checkIndex
+	}
+
+	var out structs.VariablesApplyResponse
+	if err := s.agent.RPC(structs.VariablesRollbackRPCMethod, &args, &out); err != nil {
+		setIndex(resp, out.WriteMeta.Index)
+		return nil, err
+	}
+
+	if out.Conflict != nil {
+		setIndex(resp, out.Conflict.ModifyIndex)
+		resp.WriteHeader(http.StatusConflict)
+		return out.Conflict, nil
+	}
+
+	setIndex(resp, out.WriteMeta.Index)
+	return out.Output, nil
+}
+
 func (s *HTTPServer) variableUpsert(resp http.ResponseWriter, req *http.Request,
 	path string) (interface{}, error) {
 
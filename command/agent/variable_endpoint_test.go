@@ -298,6 +298,9 @@ func TestHTTP_Variables(t *testing.T) {
 				// can use a simple equality check
 				svU.ModifyIndex = out.ModifyIndex
 				svU.ModifyTime = out.ModifyTime
+
+				// Changing the items creates a new version
+				svU.Version = sv.Version + 1
 				must.Eq(t, &svU, out)
 			}
 		})
@@ -377,6 +380,7 @@ func TestHTTP_Variables(t *testing.T) {
 				// can use a simple equality check
 				svU.CreateIndex, svU.ModifyIndex = out.CreateIndex, out.ModifyIndex
 				svU.CreateTime, svU.ModifyTime = out.CreateTime, out.ModifyTime
+				svU.Version = sv.Version + 1
 				must.Eq(t, svU.VariableMetadata, out.VariableMetadata)
 
 				// fmt writes sorted output of maps for testability.
@@ -639,6 +643,45 @@ func TestHTTP_Variables(t *testing.T) {
 			must.Nil(t, sv)
 		})
 
+		t.Run("history_and_rollback", func(t *testing.T) {
+			sv := mock.Variable()
+			sv.Path = "history/path"
+			must.NoError(t, rpcWriteSV(s, sv, sv))
+			svU := sv.Copy()
+			svU.Items["new"] = "new"
+			must.NoError(t, rpcWriteSV(s, &svU, &svU))
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/var/"+sv.Path+"?history", nil)
+			must.NoError(t, err)
+			obj, err := s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+			must.NoError(t, err)
+			versions, ok := obj.([]*structs.VariableMetadata)
+			must.True(t, ok, must.Sprintf("Expected []*structs.VariableMetadata, got %T", obj))
+			must.Len(t, 2, versions)
+			must.Eq(t, 1, versions[0].Version)
+
+			req, err = http.NewRequest(http.MethodGet, "/v1/var/"+sv.Path+"?version=0", nil)
+			must.NoError(t, err)
+			obj, err = s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+			must.NoError(t, err)
+			must.Eq(t, sv.Items, obj.(*structs.VariableDecrypted).Items)
+
+			req, err = http.NewRequest(http.MethodPut, "/v1/var/"+sv.Path+"?rollback=0&lock-acquire", nil)
+			must.NoError(t, err)
+			_, err = s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+			must.ErrorContains(t, err, "rollback can't be used with lock operations")
+
+			req, err = http.NewRequest(http.MethodPut, "/v1/var/"+sv.Path+"?rollback=0", nil)
+			must.NoError(t, err)
+			respW := httptest.NewRecorder()
+			obj, err = s.Server.VariableSpecificRequest(respW, req)
+			must.NoError(t, err)
+			out := obj.(*structs.VariableDecrypted)
+			must.Eq(t, 2, out.Version)
+			must.Eq(t, sv.Items, out.Items)
+			must.Eq(t, fmt.Sprint(out.ModifyIndex), respW.HeaderMap.Get("X-Nomad-Index"))
+		})
+
 		// WIP
 		t.Run("error_parse_lock_acquire", func(t *testing.T) {
 			req, err := http.NewRequest("GET", "/v1/var/does/not/exist?wait=99a&lock=acquire", nil)
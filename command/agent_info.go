@@ -26,7 +26,7 @@ Usage: nomad agent-info [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 Agent Info Options:
 
@@ -44,7 +44,7 @@ func (c *AgentInfoCommand) Synopsis() string {
 }
 
 func (c *AgentInfoCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json": complete.PredictNothing,
 			"-t":    complete.PredictAnything,
@@ -61,7 +61,7 @@ func (c *AgentInfoCommand) Run(args []string) int {
 	var json bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -94,8 +94,8 @@ func (c *AgentInfoCommand) Run(args []string) int {
 	}
 
 	// If output format is specified, format and output the agent info
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, info)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, info)
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error formatting output: %s", err))
 			return 1
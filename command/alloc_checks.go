@@ -27,7 +27,7 @@ Alias: nomad checks
 
 General Options:
 
-` + generalOptionsUsage(usageOptsDefault) + `
+` + generalOptionsUsage(usageOptsOutput) + `
 
 Checks Specific Options:
 
@@ -48,7 +48,7 @@ func (c *AllocChecksCommand) Synopsis() string {
 }
 
 func (c *AllocChecksCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-verbose": complete.PredictNothing,
 			"-json":    complete.PredictNothing,
@@ -78,7 +78,7 @@ func (c *AllocChecksCommand) Run(args []string) int {
 	var json, verbose bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&json, "json", false, "")
@@ -143,8 +143,8 @@ func (c *AllocChecksCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, checks)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, checks)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
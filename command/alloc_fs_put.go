@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocFSPutCommand struct {
+	Meta
+}
+
+func (c *AllocFSPutCommand) Help() string {
+	helpText := `
+Usage: nomad alloc fs put [options] <allocation> <local path> <path>
+
+  Upload a local file to the allocation directory of a running allocation. The
+  path is relative to the root of the alloc dir, or to the task directory if
+  the -task option is given. If the path ends with a slash, the file is
+  uploaded to that directory with the name of the local file. The directory
+  must already exist, and the file is replaced if it exists.
+
+  The file is streamed to the Nomad client in chunks and only written once its
+  size and SHA-256 checksum have been validated by the client, so an upload
+  that fails never leaves a partial file behind. Files can't be uploaded to
+  the secrets directory of a task. Use the "nomad alloc fs" command to
+  download files from an allocation.
+
+  When ACLs are enabled, this command requires a token with the 'alloc-exec',
+  'read-job', and 'list-jobs' capabilities for the allocation's namespace.
+  Uploading files is not possible on clients with remote exec disabled.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Put Specific Options:
+
+  -task <task-name>
+    Make the path relative to the directory of the given task.
+
+  -verbose
+    Show full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocFSPutCommand) Synopsis() string {
+	return "Upload a file to an allocation directory"
+}
+
+func (c *AllocFSPutCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-task":    complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *AllocFSPutCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
+
+func (c *AllocFSPutCommand) Name() string { return "alloc fs put" }
+
+func (c *AllocFSPutCommand) Run(args []string) int {
+	var verbose bool
+	var task string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&task, "task", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly three arguments
+	args = flags.Args()
+	if len(args) != 3 {
+		c.Ui.Error("This command takes three arguments: <allocation> <local path> <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	allocID, localPath, destPath := args[0], args[1], args[2]
+
+	// Open the file and compute its checksum before contacting the cluster
+	file, err := os.Open(localPath)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening file: %s", err))
+		return 1
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading file: %s", err))
+		return 1
+	}
+	if !info.Mode().IsRegular() {
+		c.Ui.Error(fmt.Sprintf("%q is not a regular file", localPath))
+		return 1
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading file: %s", err))
+		return 1
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading file: %s", err))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Query the allocation info
+	if len(allocID) == 1 {
+		c.Ui.Error("Alloc ID must contain at least two characters.")
+		return 1
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %v", err))
+		return 1
+	}
+
+	if len(allocs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No allocation(s) with prefix or id %q found", allocID))
+		return 1
+	}
+
+	if len(allocs) > 1 {
+		// Format the allocs
+		out := formatAllocListStubs(allocs, verbose, length)
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple allocations\n\n%s", out))
+		return 1
+	}
+
+	// Prefix lookup matched a single allocation
+	q := &api.QueryOptions{Namespace: allocs[0].Namespace}
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %s", err))
+		return 1
+	}
+
+	if task != "" {
+		if err := validateTaskExistsInAllocation(task, alloc); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	destPath = allocFSPutPath(task, destPath, filepath.Base(localPath))
+
+	opts := &api.FSPutOptions{
+		Size:     info.Size(),
+		Checksum: hex.EncodeToString(hash.Sum(nil)),
+		FileMode: info.Mode().Perm(),
+	}
+	wq := &api.WriteOptions{Namespace: alloc.Namespace}
+	if _, err := client.AllocFS().Put(alloc, destPath, file, opts, wq); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error uploading file: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Uploaded %s to %q in allocation %q",
+		humanize.IBytes(uint64(info.Size())), destPath, limit(alloc.ID, length)))
+	return 0
+}
+
+// allocFSPutPath returns the path relative to the allocation directory that a
+// file is uploaded to. Paths ending with a slash are directories the file is
+// uploaded to with its local name.
+func allocFSPutPath(task, dest, name string) string {
+	if strings.HasSuffix(dest, "/") {
+		dest = path.Join(dest, name)
+	}
+	if task != "" {
+		dest = path.Join(task, dest)
+	}
+	return dest
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocFSPutCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &AllocFSPutCommand{}
+}
+
+func TestAllocFSPutCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &AllocFSPutCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing local file
+	missing := filepath.Join(t.TempDir(), "missing")
+	code = cmd.Run([]string{"-address=" + url, "foobar", missing, "alloc/"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error opening file")
+	ui.ErrorWriter.Reset()
+
+	// Fails on directories
+	code = cmd.Run([]string{"-address=" + url, "foobar", t.TempDir(), "alloc/"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "is not a regular file")
+	ui.ErrorWriter.Reset()
+
+	local := filepath.Join(t.TempDir(), "file")
+	must.NoError(t, os.WriteFile(local, []byte("hi"), 0o644))
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "foobar", local, "alloc/"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying allocation")
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing alloc
+	code = cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C", local, "alloc/"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No allocation(s) with prefix or id")
+}
+
+func TestAllocFSPutCommand_Path(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		task     string
+		dest     string
+		expected string
+	}{
+		{dest: "alloc/data/foo", expected: "alloc/data/foo"},
+		{dest: "alloc/data/", expected: "alloc/data/file"},
+		{task: "web", dest: "local/foo", expected: "web/local/foo"},
+		{task: "web", dest: "local/", expected: "web/local/file"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expected, func(t *testing.T) {
+			must.Eq(t, tc.expected, allocFSPutPath(tc.task, tc.dest, "file"))
+		})
+	}
+}
+
+func TestAllocFSPutCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Wait for a node to be ready
+	waitForNodes(t, client)
+
+	ui := cli.NewMockUi()
+	cmd := &AllocFSPutCommand{Meta: Meta{Ui: ui}}
+
+	jobID := "job1_sfx"
+	job1 := testJob(jobID)
+	job1.TaskGroups[0].Tasks[0].Config["run_for"] = "30s"
+	resp, _, err := client.Jobs().Register(job1, nil)
+	must.NoError(t, err)
+
+	code := waitForSuccess(ui, client, fullId, t, resp.EvalID)
+	must.Zero(t, code)
+
+	// Get an alloc id
+	allocID := getAllocFromJob(t, client, jobID)
+
+	// Wait for alloc to be running
+	waitForAllocRunning(t, client, allocID)
+
+	local := filepath.Join(t.TempDir(), "file")
+	must.NoError(t, os.WriteFile(local, []byte("hello"), 0o600))
+
+	code = cmd.Run([]string{"-address=" + url, "-task", "task1", allocID, local, "local/"})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), `Uploaded 5 B to "task1/local/file"`)
+
+	alloc, _, err := client.Allocations().Info(allocID, nil)
+	must.NoError(t, err)
+
+	file, _, err := client.AllocFS().Stat(alloc, "task1/local/file", nil)
+	must.NoError(t, err)
+	must.Eq(t, "-rw-------", file.FileMode)
+
+	r, err := client.AllocFS().Cat(alloc, "task1/local/file", &api.QueryOptions{})
+	must.NoError(t, err)
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	must.NoError(t, err)
+	must.Eq(t, "hello", string(b))
+}
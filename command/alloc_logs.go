@@ -4,12 +4,14 @@
 package command
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -26,9 +28,13 @@ type AllocLogsCommand struct {
 	verbose, job, tail, stderr, stdout, follow bool
 	numLines                                   int64
 	numBytes                                   int64
-	task, group                                string
+	task, group, filter                        string
 }
 
+// allTasks is the task name used to stream the logs of all the tasks of an
+// allocation.
+const allTasks = "*"
+
 func (l *AllocLogsCommand) Help() string {
 	helpText := `
 Usage: nomad alloc logs [options] <allocation> <task>
@@ -58,7 +64,14 @@ Logs Specific Options:
 
   -task <task-name>
     Sets the task to view the logs. If task name is given with both an argument
-	and the '-task' option, preference is given to the '-task' option.
+	and the '-task' option, preference is given to the '-task' option. Use "*"
+    to stream the logs of all the started tasks of the allocation at once, with
+    each line prefixed by the name of its task.
+
+  -filter <regex>
+    Only show the lines of the logs matching the given regular expression. The
+    lines are filtered by the Nomad client running the allocation, and the
+    offsets of the "-tail" and "-c" options are applied before filtering.
 
   -group <group-name>
     Specifies the task group with the task when a random allocation is selected.
@@ -102,6 +115,7 @@ func (l *AllocLogsCommand) AutocompleteFlags() complete.Flags {
 			"-stdout":  complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
 			"-task":    complete.PredictAnything,
+			"-filter":  complete.PredictAnything,
 			"-job":     complete.PredictAnything,
 			"-group":   complete.PredictAnything,
 			"-f":       complete.PredictNothing,
@@ -142,6 +156,7 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	flags.Int64Var(&l.numBytes, "c", -1, "")
 	flags.StringVar(&l.task, "task", "", "")
 	flags.StringVar(&l.group, "group", "", "")
+	flags.StringVar(&l.filter, "filter", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -223,7 +238,9 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	// If -task isn't provided fallback to reading the task name
 	// from args.
 	if l.task != "" {
-		err = validateTaskExistsInAllocation(l.task, alloc)
+		if l.task != allTasks {
+			err = validateTaskExistsInAllocation(l.task, alloc)
+		}
 	} else {
 		if len(args) >= 2 {
 			l.task = args[1]
@@ -240,10 +257,23 @@ func (l *AllocLogsCommand) Run(args []string) int {
 		return 1
 	}
 
+	if l.task == allTasks {
+		tasks, err := allocLogsTasks(alloc)
+		if err != nil {
+			l.Ui.Error(err.Error())
+			return 1
+		}
+		if err := l.handleAllTasks(client, alloc, tasks); err != nil {
+			l.Ui.Error(fmt.Sprintf("Failed to read logs: %v", err))
+			return 1
+		}
+		return 0
+	}
+
 	// In order to run the mixed log output, we can only follow the files from
 	// their current positions. There is no way to interleave previous log
 	// lines as there is no timestamp references.
-	if l.follow && !(l.stderr || l.stdout || l.tail || l.numLines > 0 || l.numBytes > 0) {
+	if l.followBoth() {
 		if err := l.tailMultipleFiles(client, alloc); err != nil {
 			l.Ui.Error(fmt.Sprintf("Failed to tail stdout and stderr files: %v", err))
 			return 1
@@ -270,21 +300,45 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	return 0
 }
 
+// followBoth returns whether both stdout and stderr are followed, which is the
+// default when following logs without any offset.
+func (l *AllocLogsCommand) followBoth() bool {
+	return l.follow && !(l.stderr || l.stdout || l.tail || l.numLines > 0 || l.numBytes > 0)
+}
+
 func (l *AllocLogsCommand) handleSingleFile(client *api.Client, alloc *api.Allocation, logType string) error {
+	r, err := l.openFile(client, alloc, l.task, logType)
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+	if _, err := io.Copy(os.Stdout, r); err != nil {
+		return fmt.Errorf("error following logs: %s", err)
+	}
+
+	return nil
+}
+
+// openFile returns a reader of the logs of the task, starting at the offset
+// set by the command flags.
+func (l *AllocLogsCommand) openFile(client *api.Client, alloc *api.Allocation,
+	task, logType string) (io.ReadCloser, error) {
+
 	// We have a file, output it.
 	var r io.ReadCloser
 	var readErr error
 	if !l.tail {
-		r, readErr = l.followFile(client, alloc, logType, api.OriginStart, 0)
+		r, readErr = l.followFile(client, alloc, task, logType, api.OriginStart, 0)
 		if readErr != nil {
-			return fmt.Errorf("error reading file: %v", readErr)
+			return nil, fmt.Errorf("error reading file: %v", readErr)
 		}
 	} else {
 		// Parse the offset
 		var offset = defaultTailLines * bytesToLines
 
 		if nLines, nBytes := l.numLines != -1, l.numBytes != -1; nLines && nBytes {
-			return errors.New("both -n and -c set")
+			return nil, errors.New("both -n and -c set")
 		} else if nLines {
 			offset = l.numLines * bytesToLines
 		} else if nBytes {
@@ -293,33 +347,177 @@ func (l *AllocLogsCommand) handleSingleFile(client *api.Client, alloc *api.Alloc
 			l.numLines = defaultTailLines
 		}
 
-		r, readErr = l.followFile(client, alloc, logType, api.OriginEnd, offset)
+		r, readErr = l.followFile(client, alloc, task, logType, api.OriginEnd, offset)
+		if readErr != nil {
+			return nil, fmt.Errorf("error tailing file: %v", readErr)
+		}
 
 		// If numLines is set, wrap the reader
 		if l.numLines != -1 {
 			r = NewLineLimitReader(r, int(l.numLines), int(l.numLines*bytesToLines), 1*time.Second)
 		}
+	}
 
-		if readErr != nil {
-			return fmt.Errorf("error tailing file: %v", readErr)
+	return r, nil
+}
+
+// handleAllTasks outputs the logs of all the given tasks at once, prefixing
+// each line with the name of its task.
+func (l *AllocLogsCommand) handleAllTasks(client *api.Client, alloc *api.Allocation, tasks []string) error {
+	logTypes := []string{api.FSLogNameStdout}
+	switch {
+	case l.followBoth():
+		logTypes = []string{api.FSLogNameStdout, api.FSLogNameStderr}
+	case l.stderr && l.stdout:
+		return errors.New("unable to support both stdout and stderr")
+	case l.stderr:
+		logTypes = []string{api.FSLogNameStderr}
+	}
+
+	// Open all the streams before outputting anything, so that a task whose
+	// logs can't be read fails the command right away
+	var readers []io.ReadCloser
+	var writers []*prefixedLineWriter
+	var lock sync.Mutex
+	for _, task := range tasks {
+		for _, logType := range logTypes {
+			var r io.ReadCloser
+			var err error
+			if l.followBoth() {
+				r, err = l.followFile(client, alloc, task, logType, api.OriginEnd, 0)
+			} else {
+				r, err = l.openFile(client, alloc, task, logType)
+			}
+			if err != nil {
+				for _, r := range readers {
+					r.Close()
+				}
+				return fmt.Errorf("failed to read %s of task %q: %v", logType, task, err)
+			}
+
+			out := io.Writer(os.Stdout)
+			if logType == api.FSLogNameStderr {
+				out = os.Stderr
+			}
+			readers = append(readers, r)
+			writers = append(writers, newPrefixedLineWriter(out, &lock, fmt.Sprintf("[%s] ", task)))
 		}
 	}
 
-	defer r.Close()
-	if _, err := io.Copy(os.Stdout, r); err != nil {
-		return fmt.Errorf("error following logs: %s", err)
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(readers))
+	for i := range readers {
+		r, w := readers[i], writers[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer r.Close()
+
+			_, err := io.Copy(w, r)
+			if flushErr := w.Flush(); err == nil {
+				err = flushErr
+			}
+			if err != nil {
+				errCh <- fmt.Errorf("error following logs: %v", err)
+			}
+		}()
 	}
+	wg.Wait()
+	close(errCh)
 
-	return nil
+	return <-errCh
+}
+
+// allocLogsTasks returns the tasks of the allocation that have started, in the
+// order of its task group.
+func allocLogsTasks(alloc *api.Allocation) ([]string, error) {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return nil, fmt.Errorf("Could not find allocation task group: %s", alloc.TaskGroup)
+	}
+
+	var tasks []string
+	for _, task := range tg.Tasks {
+		if state := alloc.TaskStates[task.Name]; state != nil && !state.StartedAt.IsZero() {
+			tasks = append(tasks, task.Name)
+		}
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("No task of allocation %q has started yet", limit(alloc.ID, shortId))
+	}
+	return tasks, nil
+}
+
+// prefixedLineWriter writes the lines written to it with a prefix. Each line
+// is held back until it ends, so that the lines of writers sharing the same
+// lock are never interleaved.
+type prefixedLineWriter struct {
+	out    io.Writer
+	lock   *sync.Mutex
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixedLineWriter(out io.Writer, lock *sync.Mutex, prefix string) *prefixedLineWriter {
+	return &prefixedLineWriter{
+		out:    out,
+		lock:   lock,
+		prefix: []byte(prefix),
+	}
+}
+
+func (w *prefixedLineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+
+	err := w.writeLines(w.buf[:i+1])
+	w.buf = bytes.Clone(w.buf[i+1:])
+	return len(p), err
+}
+
+// Flush writes the last line if it didn't end with a newline.
+func (w *prefixedLineWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLines(append(w.buf, '\n'))
+	w.buf = nil
+	return err
+}
+
+func (w *prefixedLineWriter) writeLines(lines []byte) error {
+	var out bytes.Buffer
+	for len(lines) > 0 {
+		i := bytes.IndexByte(lines, '\n')
+		out.Write(w.prefix)
+		out.Write(lines[:i+1])
+		lines = lines[i+1:]
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, err := w.out.Write(out.Bytes())
+	return err
 }
 
 // followFile outputs the contents of the file to stdout relative to the end of
 // the file.
 func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
-	logType, origin string, offset int64) (io.ReadCloser, error) {
+	task, logType, origin string, offset int64) (io.ReadCloser, error) {
 
 	cancel := make(chan struct{})
-	frames, errCh := client.AllocFS().Logs(alloc, l.follow, l.task, logType, origin, offset, cancel, nil)
+	opts := &api.FSLogsOptions{
+		Follow:  l.follow,
+		Task:    task,
+		LogType: logType,
+		Origin:  origin,
+		Offset:  offset,
+		Filter:  l.filter,
+	}
+	frames, errCh := client.AllocFS().LogsWithOptions(alloc, opts, cancel, nil)
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -362,8 +560,13 @@ func (l *AllocLogsCommand) tailMultipleFiles(client *api.Client, alloc *api.Allo
 	// exit.
 	defer close(cancel)
 
-	stdoutFrames, stdoutErrCh := client.AllocFS().Logs(
-		alloc, true, l.task, api.FSLogNameStdout, api.OriginEnd, 0, cancel, nil)
+	stdoutFrames, stdoutErrCh := client.AllocFS().LogsWithOptions(alloc, &api.FSLogsOptions{
+		Follow:  true,
+		Task:    l.task,
+		LogType: api.FSLogNameStdout,
+		Origin:  api.OriginEnd,
+		Filter:  l.filter,
+	}, cancel, nil)
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -373,8 +576,13 @@ func (l *AllocLogsCommand) tailMultipleFiles(client *api.Client, alloc *api.Allo
 	default:
 	}
 
-	stderrFrames, stderrErrCh := client.AllocFS().Logs(
-		alloc, true, l.task, api.FSLogNameStderr, api.OriginEnd, 0, cancel, nil)
+	stderrFrames, stderrErrCh := client.AllocFS().LogsWithOptions(alloc, &api.FSLogsOptions{
+		Follow:  true,
+		Task:    l.task,
+		LogType: api.FSLogNameStderr,
+		Origin:  api.OriginEnd,
+		Filter:  l.filter,
+	}, cancel, nil)
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -4,10 +4,15 @@
 package command
 
 import (
+	"bytes"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/posener/complete"
@@ -90,3 +95,61 @@ func TestLogsCommand_AutocompleteArgs(t *testing.T) {
 	must.Len(t, 1, res)
 	must.Eq(t, a.ID, res[0])
 }
+
+func TestLogsCommand_allocLogsTasks(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &api.Allocation{
+		ID:        "26470238-5CF2-438F-8772-DC67CFB0705C",
+		TaskGroup: "web",
+		Job: &api.Job{
+			TaskGroups: []*api.TaskGroup{{
+				Name: pointer.Of("web"),
+				Tasks: []*api.Task{
+					{Name: "init"},
+					{Name: "server"},
+					{Name: "sidecar"},
+				},
+			}},
+		},
+		TaskStates: map[string]*api.TaskState{
+			"init":    {StartedAt: time.Now()},
+			"server":  {},
+			"sidecar": {StartedAt: time.Now()},
+		},
+	}
+
+	// Only started tasks have logs
+	tasks, err := allocLogsTasks(alloc)
+	must.NoError(t, err)
+	must.Eq(t, []string{"init", "sidecar"}, tasks)
+
+	alloc.TaskStates = nil
+	_, err = allocLogsTasks(alloc)
+	must.ErrorContains(t, err, `No task of allocation "26470238" has started yet`)
+}
+
+func TestLogsCommand_prefixedLineWriter(t *testing.T) {
+	ci.Parallel(t)
+
+	var out bytes.Buffer
+	var lock sync.Mutex
+	web := newPrefixedLineWriter(&out, &lock, "[web] ")
+	db := newPrefixedLineWriter(&out, &lock, "[db] ")
+
+	// Lines are only written once they end
+	_, err := web.Write([]byte("hello\nwor"))
+	must.NoError(t, err)
+	_, err = db.Write([]byte("started\n"))
+	must.NoError(t, err)
+	_, err = web.Write([]byte("ld\n"))
+	must.NoError(t, err)
+
+	// The last line is written on flush
+	_, err = db.Write([]byte("stopping"))
+	must.NoError(t, err)
+	must.NoError(t, db.Flush())
+	must.NoError(t, web.Flush())
+
+	must.Eq(t, "[web] hello\n[db] started\n[web] world\n[db] stopping\n", out.String())
+}
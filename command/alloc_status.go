@@ -36,7 +36,7 @@ Usage: nomad alloc status [options] <allocation>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Alloc Status Options:
 
@@ -44,7 +44,8 @@ Alloc Status Options:
     Display short output. Shows only the most recent task event.
 
   -stats
-    Display detailed resource usage statistics.
+    Display detailed resource usage statistics, including the block I/O and
+    network statistics of the tasks whose driver measures them.
 
   -verbose
     Show full information.
@@ -67,7 +68,7 @@ func (c *AllocStatusCommand) Synopsis() string {
 }
 
 func (c *AllocStatusCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-short":   complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
@@ -98,7 +99,7 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	var short, displayStats, verbose, json, openURL bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&short, "short", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
@@ -122,14 +123,14 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	}
 
 	// If args not specified but output format is specified, format and output the allocations data list
-	if len(args) == 0 && (json || len(tmpl) > 0) {
+	if len(args) == 0 && c.StructuredOutput(json, tmpl) {
 		allocs, _, err := client.Allocations().List(nil)
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error querying allocations: %v", err))
 			return 1
 		}
 
-		out, err := Format(json, tmpl, allocs)
+		out, err := c.FormatOutput(json, tmpl, allocs)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -184,8 +185,8 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	}
 
 	// If output format is specified, format and output the data
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, alloc)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, alloc)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -319,6 +320,10 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 		}
 	}
 
+	if status := initTasksStatus(alloc); status != "" {
+		basic = append(basic, fmt.Sprintf("Init Tasks|%s", status))
+	}
+
 	if alloc.RescheduleTracker != nil && len(alloc.RescheduleTracker.Events) > 0 {
 		attempts, total := alloc.RescheduleInfo(time.Unix(0, alloc.ModifyTime))
 		// Show this section only if the reschedule policy limits the number of attempts
@@ -332,7 +337,7 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 			fmt.Sprintf("Replacement Alloc ID|%s", limit(alloc.NextAllocation, uuidLength)))
 	}
 	if alloc.FollowupEvalID != "" {
-		nextEvalTime := futureEvalTimePretty(alloc.FollowupEvalID, client)
+		nextEvalTime := futureEvalTimePretty(alloc.FollowupEvalID, client, verbose)
 		if nextEvalTime != "" {
 			basic = append(basic,
 				fmt.Sprintf("Reschedule Eligibility|%s", nextEvalTime))
@@ -351,6 +356,41 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 	return formatKV(basic), nil
 }
 
+// initTasksStatus summarizes the outcome of the prestart_init tasks of the
+// allocation, or returns an empty string if it has none.
+func initTasksStatus(alloc *api.Allocation) string {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return ""
+	}
+
+	total, succeeded := 0, 0
+	for _, task := range tg.Tasks {
+		if task.Lifecycle.Empty() || task.Lifecycle.Hook != api.TaskLifecycleHookPrestartInit {
+			continue
+		}
+		total++
+
+		state := alloc.TaskStates[task.Name]
+		switch {
+		case state == nil:
+		case state.Failed:
+			return fmt.Sprintf("failed (task %q)", task.Name)
+		case state.State == "dead":
+			succeeded++
+		}
+	}
+
+	switch {
+	case total == 0:
+		return ""
+	case succeeded == total:
+		return "succeeded"
+	default:
+		return fmt.Sprintf("running (%d/%d succeeded)", succeeded, total)
+	}
+}
+
 func formatAllocNetworkInfo(alloc *api.Allocation) string {
 	nw := alloc.AllocatedResources.Shared.Networks[0]
 	addrs := []string{"Label|Dynamic|Address"}
@@ -404,15 +444,20 @@ func formatAllocNomadServiceChecks(allocID string, client *api.Client) string {
 }
 
 // futureEvalTimePretty returns when the eval is eligible to reschedule
-// relative to current time, based on the WaitUntil field
-func futureEvalTimePretty(evalID string, client *api.Client) string {
+// relative to current time, based on the WaitUntil field. The absolute time is
+// also returned if verbose is set.
+func futureEvalTimePretty(evalID string, client *api.Client, verbose bool) string {
 	evaluation, _, err := client.Evaluations().Info(evalID, nil)
 	// Eval time is not a critical output,
 	// don't return it on errors, if its not set or already in the past
 	if err != nil || evaluation.WaitUntil.IsZero() || time.Now().After(evaluation.WaitUntil) {
 		return ""
 	}
-	return prettyTimeDiff(evaluation.WaitUntil, time.Now())
+	pretty := prettyTimeDiff(evaluation.WaitUntil, time.Now())
+	if verbose {
+		return fmt.Sprintf("%s (%s)", formatTime(evaluation.WaitUntil), pretty)
+	}
+	return pretty
 }
 
 // outputTaskDetails prints task details for each task in the allocation,
@@ -435,10 +480,39 @@ func (c *AllocStatusCommand) outputTaskDetails(alloc *api.Allocation, stats *api
 		c.outputTaskResources(alloc, task, stats, displayStats)
 		c.Ui.Output("")
 		c.outputTaskVolumes(alloc, task, verbose)
+		if verbose {
+			c.outputTaskTemplates(state)
+		}
 		c.outputTaskStatus(state)
 	}
 }
 
+// outputTaskTemplates prints the dependencies of the task's templates, to
+// show which dependency is blocking a template from rendering.
+func (c *AllocStatusCommand) outputTaskTemplates(state *api.TaskState) {
+	if len(state.Templates) == 0 {
+		return
+	}
+
+	deps := []string{"Template|Last Rendered|Dependency|Type|Missing|Last Changed"}
+	for _, tmpl := range state.Templates {
+		if len(tmpl.Dependencies) == 0 {
+			deps = append(deps, fmt.Sprintf("%s|%s|<none>|||",
+				tmpl.DestPath, formatTaskTimes(tmpl.LastRendered)))
+			continue
+		}
+		for _, d := range tmpl.Dependencies {
+			deps = append(deps, fmt.Sprintf("%s|%s|%s|%s|%v|%s",
+				tmpl.DestPath, formatTaskTimes(tmpl.LastRendered),
+				d.Name, d.Type, d.Missing, formatTaskTimes(d.LastChanged)))
+		}
+	}
+
+	c.Ui.Output("Template Dependencies:")
+	c.Ui.Output(formatList(deps))
+	c.Ui.Output("")
+}
+
 func formatTaskTimes(t time.Time) string {
 	if t.IsZero() {
 		return "N/A"
@@ -513,6 +587,12 @@ func buildDisplayMessage(event *api.TaskEvent) string {
 		} else {
 			desc = "Failed to download artifacts"
 		}
+	case api.TaskArtifactVerificationFailed:
+		if event.DownloadError != "" {
+			desc = event.DownloadError
+		} else {
+			desc = "Failed to verify artifacts"
+		}
 	case api.TaskKilling:
 		if event.KillReason != "" {
 			desc = fmt.Sprintf("Killing task: %v", event.KillReason)
@@ -625,6 +705,10 @@ func (c *AllocStatusCommand) outputTaskResources(alloc *api.Allocation, task str
 	// Display the rolled up stats. If possible prefer the live statistics
 	cpuUsage := strconv.Itoa(*resource.CPU)
 	memUsage := humanize.IBytes(uint64(*resource.MemoryMB * bytesPerMegabyte))
+	cpuMax := ""
+	if max := resource.CPUMax; max != nil && *max > *resource.CPU {
+		cpuMax = fmt.Sprintf("Max: %d MHz", *max)
+	}
 	memMax := ""
 	if max := resource.MemoryMaxMB; max != nil && *max != 0 && *max != *resource.MemoryMB {
 		memMax = "Max: " + humanize.IBytes(uint64(*resource.MemoryMaxMB*bytesPerMegabyte))
@@ -653,8 +737,8 @@ func (c *AllocStatusCommand) outputTaskResources(alloc *api.Allocation, task str
 		memUsage,
 		humanize.IBytes(uint64(*alloc.Resources.DiskMB*bytesPerMegabyte)),
 		firstAddr))
-	if memMax != "" || secondAddr != "" {
-		resourcesOutput = append(resourcesOutput, fmt.Sprintf("|%v||%v", memMax, secondAddr))
+	if cpuMax != "" || memMax != "" || secondAddr != "" {
+		resourcesOutput = append(resourcesOutput, fmt.Sprintf("%v|%v||%v", cpuMax, memMax, secondAddr))
 	}
 	for i := 2; i < len(addr); i++ {
 		resourcesOutput = append(resourcesOutput, fmt.Sprintf("|||%v", addr[i]))
@@ -681,6 +765,8 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 	memoryStats := resourceUsage.MemoryStats
 	cpuStats := resourceUsage.CpuStats
 	deviceStats := resourceUsage.DeviceStats
+	ioStats := resourceUsage.BlockIOStats
+	networkStats := resourceUsage.NetworkStats
 
 	if memoryStats != nil && len(memoryStats.Measured) > 0 {
 		c.Ui.Output("Memory Stats")
@@ -705,6 +791,8 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 				measuredStats = append(measuredStats, humanize.IBytes(memoryStats.KernelUsage))
 			case "Kernel Max Usage":
 				measuredStats = append(measuredStats, humanize.IBytes(memoryStats.KernelMaxUsage))
+			case "Pressure":
+				measuredStats = append(measuredStats, formatPressure(memoryStats.Pressure))
 			}
 		}
 
@@ -737,6 +825,8 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 			case "System Mode":
 				percent := strconv.FormatFloat(cpuStats.SystemMode, 'f', 2, 64)
 				measuredStats = append(measuredStats, fmt.Sprintf("%v%%", percent))
+			case "Pressure":
+				measuredStats = append(measuredStats, formatPressure(cpuStats.Pressure))
 			}
 		}
 
@@ -746,6 +836,68 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 		c.Ui.Output(formatList(out))
 	}
 
+	if ioStats != nil && len(ioStats.Measured) > 0 {
+		c.Ui.Output("")
+		c.Ui.Output("Block I/O Stats")
+
+		// Sort the measured stats
+		sort.Strings(ioStats.Measured)
+
+		var measuredStats []string
+		for _, measured := range ioStats.Measured {
+			switch measured {
+			case "Read Bytes":
+				measuredStats = append(measuredStats, humanize.IBytes(ioStats.ReadBytes))
+			case "Write Bytes":
+				measuredStats = append(measuredStats, humanize.IBytes(ioStats.WriteBytes))
+			case "Read Ops":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", ioStats.ReadOps))
+			case "Write Ops":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", ioStats.WriteOps))
+			}
+		}
+
+		out := make([]string, 2)
+		out[0] = strings.Join(ioStats.Measured, "|")
+		out[1] = strings.Join(measuredStats, "|")
+		c.Ui.Output(formatList(out))
+	}
+
+	if networkStats != nil && len(networkStats.Measured) > 0 {
+		c.Ui.Output("")
+		c.Ui.Output("Network Stats")
+
+		// Sort the measured stats
+		sort.Strings(networkStats.Measured)
+
+		var measuredStats []string
+		for _, measured := range networkStats.Measured {
+			switch measured {
+			case "Rx Bytes":
+				measuredStats = append(measuredStats, humanize.IBytes(networkStats.RxBytes))
+			case "Rx Packets":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", networkStats.RxPackets))
+			case "Rx Errors":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", networkStats.RxErrors))
+			case "Rx Dropped":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", networkStats.RxDropped))
+			case "Tx Bytes":
+				measuredStats = append(measuredStats, humanize.IBytes(networkStats.TxBytes))
+			case "Tx Packets":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", networkStats.TxPackets))
+			case "Tx Errors":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", networkStats.TxErrors))
+			case "Tx Dropped":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", networkStats.TxDropped))
+			}
+		}
+
+		out := make([]string, 2)
+		out[0] = strings.Join(networkStats.Measured, "|")
+		out[1] = strings.Join(measuredStats, "|")
+		c.Ui.Output(formatList(out))
+	}
+
 	if len(deviceStats) > 0 {
 		c.Ui.Output("")
 		c.Ui.Output("Device Stats")
@@ -755,6 +907,15 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 }
 
 // shortTaskStatus prints out the current state of each task.
+// formatPressure returns the share of time some of the tasks were stalled on
+// a resource, averaged over the last 10, 60 and 300 seconds.
+func formatPressure(p *api.PressureStats) string {
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.2f%%/%.2f%%/%.2f%%", p.SomeAvg10, p.SomeAvg60, p.SomeAvg300)
+}
+
 func (c *AllocStatusCommand) shortTaskStatus(alloc *api.Allocation) {
 	tasks := make([]string, 0, len(alloc.TaskStates)+1)
 	tasks = append(tasks, "Name|State|Last Event|Time|Lifecycle")
@@ -795,7 +956,7 @@ func (c *AllocStatusCommand) sortedTaskStateIterator(m map[string]*api.TaskState
 	}
 	sort.Strings(keys)
 
-	// display prestart then prestart sidecar then main
+	// display prestart init then prestart then prestart sidecar then main
 	sort.SliceStable(keys, func(i, j int) bool {
 		lci := lifecycles[keys[i]]
 		lcj := lifecycles[keys[j]]
@@ -805,6 +966,10 @@ func (c *AllocStatusCommand) sortedTaskStateIterator(m map[string]*api.TaskState
 			return false
 		case lcj == nil:
 			return true
+		case lci.Hook == api.TaskLifecycleHookPrestartInit:
+			return lcj.Hook != api.TaskLifecycleHookPrestartInit
+		case lcj.Hook == api.TaskLifecycleHookPrestartInit:
+			return false
 		case !lci.Sidecar && lcj.Sidecar:
 			return true
 		default:
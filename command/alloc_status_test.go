@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -134,6 +136,39 @@ func TestAllocStatusCommand_LifecycleInfo(t *testing.T) {
 	must.StrContains(t, out, `Task "web" is "pending"`)
 }
 
+func TestAllocStatusCommand_InitTasksStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &api.Allocation{
+		TaskGroup: "web",
+		Job: &api.Job{
+			TaskGroups: []*api.TaskGroup{{
+				Name: pointer.Of("web"),
+				Tasks: []*api.Task{
+					{Name: "init1", Lifecycle: &api.TaskLifecycle{Hook: api.TaskLifecycleHookPrestartInit}},
+					{Name: "init2", Lifecycle: &api.TaskLifecycle{Hook: api.TaskLifecycleHookPrestartInit}},
+					{Name: "main"},
+				},
+			}},
+		},
+	}
+
+	alloc.TaskStates = map[string]*api.TaskState{
+		"init1": {State: "dead"},
+		"init2": {State: "running"},
+	}
+	must.Eq(t, "running (1/2 succeeded)", initTasksStatus(alloc))
+
+	alloc.TaskStates["init2"] = &api.TaskState{State: "dead"}
+	must.Eq(t, "succeeded", initTasksStatus(alloc))
+
+	alloc.TaskStates["init2"] = &api.TaskState{State: "dead", Failed: true}
+	must.Eq(t, `failed (task "init2")`, initTasksStatus(alloc))
+
+	alloc.Job.TaskGroups[0].Tasks = alloc.Job.TaskGroups[0].Tasks[2:]
+	must.Eq(t, "", initTasksStatus(alloc))
+}
+
 func TestAllocStatusCommand_Run(t *testing.T) {
 	ci.Parallel(t)
 	srv, client, url := testServer(t, true, nil)
@@ -284,6 +319,87 @@ func TestAllocStatusCommand_ScoreMetrics(t *testing.T) {
 	must.StrContains(t, out, "final score")
 }
 
+func TestAllocStatusCommand_TaskTemplates(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &AllocStatusCommand{Meta: Meta{Ui: ui}}
+
+	cmd.outputTaskTemplates(&api.TaskState{})
+	must.Eq(t, "", ui.OutputWriter.String())
+
+	cmd.outputTaskTemplates(&api.TaskState{
+		Templates: []*api.TemplateState{
+			{
+				DestPath: "local/app.conf",
+				Dependencies: []*api.TemplateDependencyState{
+					{
+						Name:        "kv.block(app/config)",
+						Type:        "consul",
+						Missing:     true,
+						LastChanged: time.Now(),
+					},
+				},
+			},
+		},
+	})
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Template Dependencies:")
+	must.RegexMatch(t, regexp.MustCompile(`local/app.conf\s+N/A\s+kv.block\(app/config\)\s+consul\s+true`), out)
+}
+
+func TestAllocStatusCommand_VerboseResourceUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &AllocStatusCommand{Meta: Meta{Ui: ui}}
+
+	cmd.outputVerboseResourceUsage("web", &api.ResourceUsage{
+		BlockIOStats: &api.BlockIOStats{
+			ReadBytes:  2048,
+			WriteBytes: 1024 * 1024,
+			ReadOps:    3,
+			WriteOps:   7,
+			Measured:   []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"},
+		},
+		NetworkStats: &api.NetworkStats{
+			RxBytes:   4096,
+			TxBytes:   512,
+			RxPackets: 10,
+			TxPackets: 5,
+			Measured:  []string{"Rx Bytes", "Rx Packets", "Tx Bytes", "Tx Packets"},
+		},
+	})
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Block I/O Stats")
+	must.RegexMatch(t, regexp.MustCompile(`Read Bytes\s+Read Ops\s+Write Bytes\s+Write Ops\n2.0 KiB\s+3\s+1.0 MiB\s+7`), out)
+	must.StrContains(t, out, "Network Stats")
+	must.RegexMatch(t, regexp.MustCompile(`Rx Bytes\s+Rx Packets\s+Tx Bytes\s+Tx Packets\n4.0 KiB\s+10\s+512 B\s+5`), out)
+}
+
+func TestAllocStatusCommand_VerboseResourceUsage_Pressure(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &AllocStatusCommand{Meta: Meta{Ui: ui}}
+
+	cmd.outputVerboseResourceUsage("web", &api.ResourceUsage{
+		MemoryStats: &api.MemoryStats{
+			RSS:      1024,
+			Pressure: &api.PressureStats{SomeAvg10: 1.5, SomeAvg60: 0.5, SomeAvg300: 0.25},
+			Measured: []string{"RSS", "Pressure"},
+		},
+		CpuStats: &api.CpuStats{
+			ThrottledPeriods: 4,
+			Pressure:         &api.PressureStats{SomeAvg10: 12.25},
+			Measured:         []string{"Throttled Periods", "Pressure"},
+		},
+	})
+	out := ui.OutputWriter.String()
+	must.RegexMatch(t, regexp.MustCompile(`Pressure\s+RSS\n1.50%/0.50%/0.25%\s+1.0 KiB`), out)
+	must.RegexMatch(t, regexp.MustCompile(`Pressure\s+Throttled Periods\n12.25%/0.00%/0.00%\s+4`), out)
+}
+
 func TestAllocStatusCommand_AutocompleteArgs(t *testing.T) {
 	ci.Parallel(t)
 
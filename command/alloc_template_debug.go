@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocTemplateDebugCommand struct {
+	Meta
+}
+
+func (c *AllocTemplateDebugCommand) Help() string {
+	helpText := `
+Usage: nomad alloc template-debug [options] <allocation> <task>
+
+  Render the templates of a task once and output the results, without writing
+  the templates to the task directory or triggering their change modes. This
+  is useful to debug template syntax or find the Consul keys, services, Vault
+  secrets, or Nomad variables a blocked template is waiting on. If the
+  allocation only contains one task, the task name may be omitted.
+
+  When ACLs are enabled, this command requires a token with the 'read-fs',
+  'read-job', and 'list-jobs' capabilities for the allocation's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsOutput) + `
+
+Template Debug Specific Options:
+
+  -timeout <duration>
+    How long to wait for the template dependencies before returning the
+    templates that could not be rendered. Defaults to 5s.
+
+  -verbose
+    Show full information.
+
+  -json
+    Output the template results in a JSON format.
+
+  -t
+    Format and display the template results using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocTemplateDebugCommand) Synopsis() string {
+	return "Render the templates of a task without writing them"
+}
+
+func (c *AllocTemplateDebugCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
+		complete.Flags{
+			"-timeout": complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *AllocTemplateDebugCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return nil
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
+
+func (c *AllocTemplateDebugCommand) Name() string { return "alloc template-debug" }
+
+func (c *AllocTemplateDebugCommand) Run(args []string) int {
+	var json, verbose bool
+	var tmpl string
+	var timeout time.Duration
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.DurationVar(&timeout, "timeout", 5*time.Second, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) < 1 || len(args) > 2 {
+		c.Ui.Error("This command takes one or two arguments: <alloc-id> <task>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if timeout <= 0 {
+		c.Ui.Error("Timeout must be a positive duration")
+		return 1
+	}
+
+	allocID := args[0]
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	if len(allocID) == 1 {
+		c.Ui.Error("Alloc ID must contain at least two characters.")
+		return 1
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %v", err))
+		return 1
+	}
+	if len(allocs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No allocation(s) with prefix or id %q found", allocID))
+		return 1
+	}
+	if len(allocs) > 1 {
+		out := formatAllocListStubs(allocs, verbose, length)
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple allocations\n\n%s", out))
+		return 1
+	}
+
+	q := &api.QueryOptions{Namespace: allocs[0].Namespace}
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %s", err))
+		return 1
+	}
+
+	var task string
+	if len(args) == 2 {
+		task = args[1]
+		if err := validateTaskExistsInAllocation(task, alloc); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	} else {
+		task, err = lookupAllocTask(alloc)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	results, err := client.Allocations().TemplateDebug(alloc, task, timeout, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error rendering templates: %s", err))
+		return 1
+	}
+
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, results)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(results) == 0 {
+		c.Ui.Output(fmt.Sprintf("Task %q has no templates", task))
+		return 0
+	}
+
+	for _, result := range results {
+		switch {
+		case result.Redacted:
+			c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[bold]==> %s (rendered, contents redacted)[reset]", result.DestPath)))
+		case result.Rendered:
+			c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[bold]==> %s (rendered)[reset]", result.DestPath)))
+			c.Ui.Output(result.Contents)
+		default:
+			c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[bold]==> %s (blocked)[reset]", result.DestPath)))
+			if len(result.MissingDeps) > 0 {
+				c.Ui.Output(fmt.Sprintf("Missing: %s", strings.Join(result.MissingDeps, ", ")))
+			}
+		}
+		c.Ui.Output("")
+	}
+	return 0
+}
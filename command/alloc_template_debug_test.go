@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocTemplateDebugCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &AllocTemplateDebugCommand{}
+}
+
+func TestAllocTemplateDebugCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &AllocTemplateDebugCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on lack of alloc ID
+	code := cmd.Run([]string{})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one or two arguments")
+	ui.ErrorWriter.Reset()
+
+	// Fails on misuse
+	code = cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes one or two arguments")
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid timeout
+	code = cmd.Run([]string{"-timeout=-1s", "foobar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Timeout must be a positive duration")
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "foobar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying allocation")
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing alloc
+	code = cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No allocation(s) with prefix or id")
+	ui.ErrorWriter.Reset()
+
+	// Fail on identifier with too few characters
+	code = cmd.Run([]string{"-address=" + url, "2"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "must contain at least two characters.")
+}
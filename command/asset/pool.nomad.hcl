@@ -26,4 +26,14 @@ node_pool "example" {
   #   scheduler_algorithm             = "spread"
   #   memory_oversubscription_enabled = true
   # }
+
+  # overflow blocks define the node pools to place the jobs of this node pool
+  # in when none of its nodes are feasible, such as when the pool is out of
+  # capacity. Node pools with a higher priority, from 0 to 100, are tried
+  # first.
+
+  # overflow {
+  #   pool     = "burst"
+  #   priority = 50
+  # }
 }
@@ -108,6 +108,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"acl auth-method test": func() (cli.Command, error) {
+			return &ACLAuthMethodTestCommand{
+				Meta: meta,
+			}, nil
+		},
 		"acl auth-method update": func() (cli.Command, error) {
 			return &ACLAuthMethodUpdateCommand{
 				Meta: meta,
@@ -278,6 +283,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"alloc fs put": func() (cli.Command, error) {
+			return &AllocFSPutCommand{
+				Meta: meta,
+			}, nil
+		},
 		"alloc logs": func() (cli.Command, error) {
 			return &AllocLogsCommand{
 				Meta: meta,
@@ -293,6 +303,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"alloc template-debug": func() (cli.Command, error) {
+			return &AllocTemplateDebugCommand{
+				Meta: meta,
+			}, nil
+		},
 		"alloc status": func() (cli.Command, error) {
 			return &AllocStatusCommand{
 				Meta: meta,
@@ -504,6 +519,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job rollback": func() (cli.Command, error) {
+			return &JobRollbackCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job run": func() (cli.Command, error) {
 			return &JobRunCommand{
 				Meta: meta,
@@ -634,6 +654,31 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"node maintenance": func() (cli.Command, error) {
+			return &NodeMaintenanceCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node maintenance delete": func() (cli.Command, error) {
+			return &NodeMaintenanceDeleteCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node maintenance list": func() (cli.Command, error) {
+			return &NodeMaintenanceListCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node maintenance schedule": func() (cli.Command, error) {
+			return &NodeMaintenanceScheduleCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node maintenance status": func() (cli.Command, error) {
+			return &NodeMaintenanceStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node meta": func() (cli.Command, error) {
 			return &NodeMetaCommand{
 				Meta: meta,
@@ -862,6 +907,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator root keyring status": func() (cli.Command, error) {
+			return &OperatorRootKeyringStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator snapshot": func() (cli.Command, error) {
 			return &OperatorSnapshotCommand{
 				Meta: meta,
@@ -1194,6 +1244,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"var history": func() (cli.Command, error) {
+			return &VarHistoryCommand{
+				Meta: meta,
+			}, nil
+		},
 		"var purge": func() (cli.Command, error) {
 			return &VarPurgeCommand{
 				Meta: meta,
@@ -1214,6 +1269,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"var rollback": func() (cli.Command, error) {
+			return &VarRollbackCommand{
+				Meta: meta,
+			}, nil
+		},
 		"var lock": func() (cli.Command, error) {
 			return &VarLockCommand{
 				varPutCommand: &VarPutCommand{
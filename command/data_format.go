@@ -10,6 +10,7 @@ import (
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/hashicorp/go-msgpack/v2/codec"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -35,6 +36,11 @@ func DataFormat(format, tmpl string) (DataFormatter, error) {
 		return &JSONFormat{}, nil
 	case "template":
 		return &TemplateFormat{tmpl}, nil
+	case "yaml":
+		if len(tmpl) > 0 {
+			return nil, fmt.Errorf("yaml format does not support template option.")
+		}
+		return &YAMLFormat{}, nil
 	}
 	return nil, fmt.Errorf("Unsupported format is specified.")
 }
@@ -52,6 +58,40 @@ func (p *JSONFormat) TransformData(data interface{}) (string, error) {
 	return buf.String(), nil
 }
 
+type YAMLFormat struct{}
+
+// TransformData returns YAML format string data. The data is encoded as JSON
+// first, so that it has the same field names and order as the json format.
+func (p *YAMLFormat) TransformData(data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := codec.NewEncoder(&buf, jsonHandlePretty).Encode(data); err != nil {
+		return "", err
+	}
+
+	// JSON is valid YAML, so it can be decoded as is; only the flow style of
+	// JSON objects and arrays and the quoting of its strings are dropped
+	var node yaml.Node
+	if err := yaml.Unmarshal(buf.Bytes(), &node); err != nil {
+		return "", err
+	}
+	resetYAMLStyle(&node)
+
+	out, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// resetYAMLStyle resets the style of the node and its children to the
+// default block style.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
 type TemplateFormat struct {
 	tmpl string
 }
@@ -39,6 +39,11 @@ func TestDataFormat(t *testing.T) {
 			template: "",
 			expect:   expectJSON,
 		},
+		"yaml_good": {
+			format:   "yaml",
+			template: "",
+			expect:   "ID: \"1\"\nName: example\nRegion: global\n",
+		},
 		"template_good": {
 			format:   "template",
 			template: "{{.Region}}",
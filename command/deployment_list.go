@@ -26,7 +26,7 @@ Usage: nomad deployment list [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 List Options:
 
@@ -46,7 +46,7 @@ List Options:
 }
 
 func (c *DeploymentListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json":    complete.PredictNothing,
 			"-filter":  complete.PredictAnything,
@@ -69,7 +69,7 @@ func (c *DeploymentListCommand) Run(args []string) int {
 	var json, verbose bool
 	var filter, tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&json, "json", false, "")
@@ -110,8 +110,8 @@ func (c *DeploymentListCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, deploys)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, deploys)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
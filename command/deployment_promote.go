@@ -42,6 +42,11 @@ Promote Options:
     Group may be specified many times and is used to promote that particular
     group. If no specific groups are specified, all groups are promoted.
 
+  -step
+    Move the canaries of the groups with canary steps left to their next
+    step instead of promoting them. Once the canaries of every group reached
+    their last step, the groups are promoted.
+
   -detach
     Return immediately instead of entering monitor mode. After deployment
     resume, the evaluation ID will be printed to the screen, which can be used
@@ -61,6 +66,7 @@ func (c *DeploymentPromoteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-group":   complete.PredictAnything,
+			"-step":    complete.PredictNothing,
 			"-detach":  complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
 		})
@@ -84,13 +90,14 @@ func (c *DeploymentPromoteCommand) AutocompleteArgs() complete.Predictor {
 func (c *DeploymentPromoteCommand) Name() string { return "deployment promote" }
 
 func (c *DeploymentPromoteCommand) Run(args []string) int {
-	var detach, verbose bool
+	var detach, verbose, step bool
 	var groups []string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&step, "step", false, "")
 	flags.Var((*flaghelper.StringFlag)(&groups), "group", "")
 
 	if err := flags.Parse(args); err != nil {
@@ -132,7 +139,9 @@ func (c *DeploymentPromoteCommand) Run(args []string) int {
 	}
 
 	var u *api.DeploymentUpdateResponse
-	if len(groups) == 0 {
+	if step {
+		u, _, err = client.Deployments().PromoteStep(deploy.ID, groups, nil)
+	} else if len(groups) == 0 {
 		u, _, err = client.Deployments().PromoteAll(deploy.ID, nil)
 	} else {
 		u, _, err = client.Deployments().PromoteGroups(deploy.ID, groups, nil)
@@ -38,7 +38,7 @@ Usage: nomad deployment status [options] <deployment id>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Status Options:
 
@@ -69,7 +69,7 @@ func (c *DeploymentStatusCommand) Synopsis() string {
 }
 
 func (c *DeploymentStatusCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-verbose": complete.PredictNothing,
 			"-json":    complete.PredictNothing,
@@ -101,7 +101,7 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 	var wait time.Duration
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&json, "json", false, "")
@@ -114,7 +114,7 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 	}
 
 	// Check that json or tmpl isn't set with monitor
-	if monitor && (json || len(tmpl) > 0) {
+	if monitor && c.StructuredOutput(json, tmpl) {
 		c.Ui.Error("The monitor flag cannot be used with the '-json' or '-t' flags")
 		return 1
 	}
@@ -165,8 +165,8 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, deploy)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, deploy)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -629,7 +629,7 @@ func formatMultiregionDeployment(regions map[string]*api.Deployment, uuidLength
 
 func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	// Detect if we need to add these columns
-	var canaries, autorevert, progressDeadline bool
+	var canaries, canarySteps, autorevert, progressDeadline bool
 	tgNames := make([]string, 0, len(d.TaskGroups))
 	for name, state := range d.TaskGroups {
 		tgNames = append(tgNames, name)
@@ -639,6 +639,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		if state.DesiredCanaries > 0 {
 			canaries = true
 		}
+		if len(state.CanarySteps) != 0 {
+			canarySteps = true
+		}
 		if state.ProgressDeadline != 0 {
 			progressDeadline = true
 		}
@@ -659,6 +662,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	if canaries {
 		rowString += "Canaries|"
 	}
+	if canarySteps {
+		rowString += "Canary Step|"
+	}
 	rowString += "Placed|Healthy|Unhealthy"
 	if progressDeadline {
 		rowString += "|Progress Deadline"
@@ -684,6 +690,13 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		if canaries {
 			row += fmt.Sprintf("%d|", state.DesiredCanaries)
 		}
+		if canarySteps {
+			if len(state.CanarySteps) != 0 {
+				row += fmt.Sprintf("%d/%d (%d%%)|", state.CanaryStep+1, len(state.CanarySteps), state.CanarySteps[state.CanaryStep])
+			} else {
+				row += fmt.Sprintf("%v|", "N/A")
+			}
+		}
 		row += fmt.Sprintf("%d|%d|%d", state.PlacedAllocs, state.HealthyAllocs, state.UnhealthyAllocs)
 		if progressDeadline {
 			if state.RequireProgressBy.IsZero() {
@@ -25,7 +25,7 @@ Usage: nomad eval list [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Eval List Options:
 
@@ -65,7 +65,7 @@ func (c *EvalListCommand) Synopsis() string {
 }
 
 func (c *EvalListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json":       complete.PredictNothing,
 			"-t":          complete.PredictAnything,
@@ -101,7 +101,7 @@ func (c *EvalListCommand) Run(args []string) int {
 	var perPage int
 	var tmpl, pageToken, filter, filterJobID, filterStatus string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&monitor, "monitor", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
@@ -153,8 +153,8 @@ func (c *EvalListCommand) Run(args []string) int {
 
 	// If args not specified but output format is specified, format
 	// and output the evaluations data list
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, evals)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, evals)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
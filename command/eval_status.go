@@ -28,7 +28,7 @@ Usage: nomad eval status [options] <evaluation>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Eval Status Options:
 
@@ -56,7 +56,7 @@ func (c *EvalStatusCommand) Synopsis() string {
 }
 
 func (c *EvalStatusCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json":    complete.PredictNothing,
 			"-monitor": complete.PredictNothing,
@@ -91,7 +91,7 @@ func (c *EvalStatusCommand) Run(args []string) int {
 	var monitor, verbose, json, openURL bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&monitor, "monitor", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
@@ -167,8 +167,8 @@ func (c *EvalStatusCommand) Run(args []string) int {
 	}
 
 	// If output format is specified, format and output the data
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, eval)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, eval)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -9,17 +9,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"maps"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/cli"
+	ctconf "github.com/hashicorp/consul-template/config"
+	"github.com/hashicorp/consul-template/manager"
 	gg "github.com/hashicorp/go-getter"
 	"github.com/hashicorp/nomad/api"
 	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/jobspec2"
 	"github.com/kr/text"
 	"github.com/moby/term"
@@ -403,6 +408,14 @@ type JobGetter struct {
 	Strict   bool
 	JSON     bool
 
+	// VarEnvPrefix is an additional prefix of environment variables that set
+	// HCL variables, like the built-in NOMAD_VAR_ prefix.
+	VarEnvPrefix string
+
+	// PreRender runs HCL jobfiles through consul-template before they are
+	// parsed, see preRenderJobfile.
+	PreRender bool
+
 	// The fields below can be overwritten for tests
 	testStdin io.Reader
 }
@@ -417,6 +430,12 @@ func (j *JobGetter) Validate() error {
 	if len(j.VarFiles) > 0 && j.JSON {
 		return fmt.Errorf("cannot use variables with JSON files.")
 	}
+	if j.VarEnvPrefix != "" && j.JSON {
+		return fmt.Errorf("cannot use variables with JSON files.")
+	}
+	if j.PreRender && j.JSON {
+		return fmt.Errorf("cannot pre-render JSON files.")
+	}
 	return nil
 }
 
@@ -517,9 +536,21 @@ func (j *JobGetter) Get(jpath string) (*api.JobSubmission, *api.Job, error) {
 			return nil, nil, fmt.Errorf("Failed to parse HCL job: %w", err)
 		}
 
+		if j.PreRender {
+			rendered, err := preRenderJobfile(source.Bytes(), preRenderTimeout)
+			if err != nil {
+				return nil, nil, fmt.Errorf("Error pre-rendering job file from %s: %w", jpath, err)
+			}
+			source.Reset()
+			source.Write(rendered)
+		}
+
 		// Perform the environment listing here as it is used twice beyond this
 		// point.
 		osEnv := os.Environ()
+		if j.VarEnvPrefix != "" {
+			osEnv = append(osEnv, renameVarEnvPrefix(osEnv, j.VarEnvPrefix)...)
+		}
 
 		// we are parsing HCL2, whether from a file or stdio
 		jobStruct, err = jobspec2.ParseWithConfig(&jobspec2.ParseConfig{
@@ -567,6 +598,76 @@ func (j *JobGetter) Get(jpath string) (*api.JobSubmission, *api.Job, error) {
 	return jobSubmission, jobStruct, nil
 }
 
+const (
+	// preRenderLeftDelim and preRenderRightDelim delimit the consul-template
+	// actions of a pre-rendered jobfile, so that they don't clash with those
+	// of the template blocks within the job, with HCL interpolation, or with
+	// shell syntax such as [[ ]] in embedded scripts
+	preRenderLeftDelim  = "{%"
+	preRenderRightDelim = "%}"
+
+	// preRenderTimeout is how long to wait for the data a pre-rendered
+	// jobfile depends on
+	preRenderTimeout = time.Minute
+)
+
+// preRenderJobfile renders the source of a jobfile as a consul-template
+// template delimited by preRenderLeftDelim and preRenderRightDelim. Consul and
+// Vault are reached with the configuration and tokens of the environment, such
+// as CONSUL_HTTP_ADDR and VAULT_TOKEN.
+func preRenderJobfile(src []byte, timeout time.Duration) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "jobfile")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	dest := filepath.Join(dir, "rendered")
+
+	conf := ctconf.DefaultConfig()
+	conf.Once = true
+	conf.Vault.RenewToken = pointer.Of(false)
+	conf.Templates = &ctconf.TemplateConfigs{&ctconf.TemplateConfig{
+		Contents:      pointer.Of(string(src)),
+		Destination:   pointer.Of(dest),
+		LeftDelim:     pointer.Of(preRenderLeftDelim),
+		RightDelim:    pointer.Of(preRenderRightDelim),
+		ErrMissingKey: pointer.Of(true),
+	}}
+	conf.Finalize()
+
+	// consul-template logs through the standard logger, which would
+	// otherwise end up in the output of the command
+	logOut := log.Writer()
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(logOut)
+
+	runner, err := manager.NewRunner(conf, false)
+	if err != nil {
+		return nil, err
+	}
+	go runner.Start()
+	defer runner.Stop()
+
+	select {
+	case <-runner.DoneCh:
+	case err := <-runner.ErrCh:
+		return nil, err
+	case <-time.After(timeout):
+		var missing []string
+		for _, event := range runner.RenderEvents() {
+			if event.MissingDeps != nil {
+				for _, d := range event.MissingDeps.List() {
+					missing = append(missing, d.String())
+				}
+			}
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("timed out after %s waiting for: %s", timeout, strings.Join(missing, ", "))
+	}
+
+	return os.ReadFile(dest)
+}
+
 // extractVarFiles concatenates the content of each file in filenames and
 // returns it all as one big content blob
 func extractVarFiles(filenames []string) (string, error) {
@@ -627,6 +728,20 @@ func extractJobSpecEnvVars(envVars []string) map[string]string {
 	return m
 }
 
+// renameVarEnvPrefix returns the environment variables with the given prefix
+// renamed to use the NOMAD_VAR_ prefix, so they set HCL variables. Appended
+// after the OS environment, they take precedence over NOMAD_VAR_ variables of
+// the same name.
+func renameVarEnvPrefix(envVars []string, prefix string) []string {
+	var renamed []string
+	for _, raw := range envVars {
+		if name, ok := strings.CutPrefix(raw, prefix); ok && name != "" {
+			renamed = append(renamed, jobspec2.VarEnvPrefix+name)
+		}
+	}
+	return renamed
+}
+
 // mergeAutocompleteFlags is used to join multiple flag completion sets.
 func mergeAutocompleteFlags(flags ...complete.Flags) complete.Flags {
 	merged := make(map[string]complete.Predictor, len(flags))
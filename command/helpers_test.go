@@ -339,6 +339,92 @@ job "example" {
 	must.Eq(t, expected, j.Datacenters)
 }
 
+func TestJobGetter_HCL2_VarEnvPrefix(t *testing.T) {
+
+	hcl := `
+variables {
+  var1 = "default-val"
+  var2 = "default-val"
+  var3 = "default-val"
+}
+
+job "example" {
+  datacenters = ["${var.var1}", "${var.var2}", "${var.var3}"]
+}
+`
+	t.Setenv("NOMAD_VAR_var2", "from-nomad-envvar")
+	t.Setenv("NOMAD_VAR_var3", "from-nomad-envvar")
+	t.Setenv("CI_VAR_var3", "from-prefix-envvar")
+	t.Setenv("CI_VAR_undefined", "ignored")
+
+	hclf, err := os.CreateTemp("", "hcl")
+	must.NoError(t, err)
+	defer os.Remove(hclf.Name())
+	defer hclf.Close()
+
+	_, err = hclf.WriteString(hcl)
+	must.NoError(t, err)
+
+	jg := &JobGetter{
+		VarEnvPrefix: "CI_VAR_",
+		Strict:       true,
+	}
+
+	sub, j, err := jg.Get(hclf.Name())
+	must.NoError(t, err)
+
+	must.NotNil(t, j)
+	must.Eq(t, []string{"default-val", "from-nomad-envvar", "from-prefix-envvar"}, j.Datacenters)
+	must.Eq(t, "from-prefix-envvar", sub.VariableFlags["var3"])
+}
+
+func TestJobGetter_PreRender(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	dcFile := filepath.Join(dir, "datacenter")
+	must.NoError(t, os.WriteFile(dcFile, []byte("dc-from-file"), 0644))
+
+	hcl := `
+job "example" {
+  datacenters = ["{% file "` + dcFile + `" %}"]
+
+  group "group" {
+    task "task" {
+      driver = "exec"
+
+      template {
+        data        = "{{ key \"service/config\" }}"
+        destination = "local/config"
+      }
+
+      template {
+        data        = "if [[ -f /etc/ready ]]; then echo {% "{%" %}; fi"
+        destination = "local/check.sh"
+      }
+    }
+  }
+}
+`
+	jobPath := filepath.Join(dir, "example.nomad.hcl")
+	must.NoError(t, os.WriteFile(jobPath, []byte(hcl), 0644))
+
+	jg := &JobGetter{PreRender: true, Strict: true}
+	sub, j, err := jg.Get(jobPath)
+	must.NoError(t, err)
+	must.Eq(t, []string{"dc-from-file"}, j.Datacenters)
+	must.StrNotContains(t, sub.Source, "{% file")
+
+	// the actions of the template blocks are left for the clients to render
+	must.Eq(t, `{{ key "service/config" }}`, *j.TaskGroups[0].Tasks[0].Templates[0].EmbeddedTmpl)
+
+	// shell syntax is left alone, and literal delimiters can be output
+	must.Eq(t, `if [[ -f /etc/ready ]]; then echo {%; fi`, *j.TaskGroups[0].Tasks[0].Templates[1].EmbeddedTmpl)
+
+	jg = &JobGetter{PreRender: true, JSON: true}
+	must.ErrorContains(t, jg.Validate(), "cannot pre-render JSON files")
+}
+
 func TestJobGetter_HCL2_Variables_StrictFalse(t *testing.T) {
 
 	hcl := `
@@ -439,6 +525,14 @@ func TestJobGetter_Validate(t *testing.T) {
 			},
 			"variables with JSON files",
 		},
+		{
+			"VarEnvPrefixAndJSON",
+			JobGetter{
+				JSON:         true,
+				VarEnvPrefix: "CI_VAR_",
+			},
+			"variables with JSON files",
+		},
 		{
 			"JSON_OK",
 			JobGetter{
@@ -28,7 +28,7 @@ Usage: nomad job allocs [options] <job>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Allocs Options:
 
@@ -53,7 +53,7 @@ func (c *JobAllocsCommand) Synopsis() string {
 }
 
 func (c *JobAllocsCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json":    complete.PredictNothing,
 			"-t":       complete.PredictAnything,
@@ -83,7 +83,7 @@ func (c *JobAllocsCommand) Run(args []string) int {
 	var json, verbose, all bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&all, "all", false, "")
@@ -125,8 +125,8 @@ func (c *JobAllocsCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, allocs)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, allocs)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -28,7 +28,7 @@ Usage: nomad job deployments [options] <job>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Deployments Options:
 
@@ -56,7 +56,7 @@ func (c *JobDeploymentsCommand) Synopsis() string {
 }
 
 func (c *JobDeploymentsCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json":    complete.PredictNothing,
 			"-t":       complete.PredictAnything,
@@ -87,7 +87,7 @@ func (c *JobDeploymentsCommand) Run(args []string) int {
 	var json, latest, verbose, all bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&latest, "latest", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
@@ -137,8 +137,8 @@ func (c *JobDeploymentsCommand) Run(args []string) int {
 			return 1
 		}
 
-		if json || len(tmpl) > 0 {
-			out, err := Format(json, tmpl, deploy)
+		if c.StructuredOutput(json, tmpl) {
+			out, err := c.FormatOutput(json, tmpl, deploy)
 			if err != nil {
 				c.Ui.Error(err.Error())
 				return 1
@@ -158,8 +158,8 @@ func (c *JobDeploymentsCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, deploys)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, deploys)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
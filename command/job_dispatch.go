@@ -215,8 +215,8 @@ func (c *JobDispatchCommand) Run(args []string) int {
 		return 1
 	}
 
-	// See if an evaluation was created. If the job is periodic there will be no
-	// eval.
+	// See if an evaluation was created. If the job is periodic or queued
+	// there will be no eval.
 	evalCreated := resp.EvalID != ""
 
 	basic := []string{
@@ -226,6 +226,9 @@ func (c *JobDispatchCommand) Run(args []string) int {
 		basic = append(basic, fmt.Sprintf("Evaluation ID|%s", limit(resp.EvalID, length)))
 	}
 	c.Ui.Output(formatKV(basic))
+	if resp.Queued {
+		c.Ui.Output("\nDispatched job is queued until fewer dispatched jobs of the parameterized job are running")
+	}
 
 	// Nothing to do
 	if detach || !evalCreated {
@@ -35,7 +35,7 @@ Usage: nomad job history [options] <job>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 History Options:
 
@@ -73,7 +73,7 @@ func (c *JobHistoryCommand) Synopsis() string {
 }
 
 func (c *JobHistoryCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-p":            complete.PredictNothing,
 			"-full":         complete.PredictNothing,
@@ -107,7 +107,7 @@ func (c *JobHistoryCommand) Run(args []string) int {
 	var tmpl, versionStr, diffTag, diffVersionFlag string
 	var diffVersion *uint64
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&diff, "p", false, "")
 	flags.BoolVar(&full, "full", false, "")
@@ -129,8 +129,8 @@ func (c *JobHistoryCommand) Run(args []string) int {
 		return 1
 	}
 
-	if (json || len(tmpl) != 0) && (diff || full) {
-		c.Ui.Error("-json and -t are exclusive with -p and -full")
+	if c.StructuredOutput(json, tmpl) && (diff || full) {
+		c.Ui.Error("-json, -t and -output are exclusive with -p and -full")
 		return 1
 	}
 
@@ -209,8 +209,8 @@ func (c *JobHistoryCommand) Run(args []string) int {
 			}
 		}
 
-		if json || len(tmpl) > 0 {
-			out, err := Format(json, tmpl, job)
+		if c.StructuredOutput(json, tmpl) {
+			out, err := c.FormatOutput(json, tmpl, job)
 			if err != nil {
 				c.Ui.Error(err.Error())
 				return 1
@@ -226,8 +226,8 @@ func (c *JobHistoryCommand) Run(args []string) int {
 		}
 
 	} else {
-		if json || len(tmpl) > 0 {
-			out, err := Format(json, tmpl, versions)
+		if c.StructuredOutput(json, tmpl) {
+			out, err := c.FormatOutput(json, tmpl, versions)
 			if err != nil {
 				c.Ui.Error(err.Error())
 				return 1
@@ -29,7 +29,7 @@ Alias: nomad inspect
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Inspect Options:
 
@@ -57,7 +57,7 @@ func (c *JobInspectCommand) Synopsis() string {
 }
 
 func (c *JobInspectCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-version":   complete.PredictAnything,
 			"-hcl":       complete.PredictNothing,
@@ -88,7 +88,7 @@ func (c *JobInspectCommand) Run(args []string) int {
 	var json, hcl, withVars bool
 	var tmpl, versionStr string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.BoolVar(&hcl, "hcl", false, "")
@@ -118,14 +118,14 @@ func (c *JobInspectCommand) Run(args []string) int {
 	}
 
 	// If args not specified but output format is specified, format and output the jobs data list
-	if len(args) == 0 && (json || len(tmpl) > 0) {
+	if len(args) == 0 && c.StructuredOutput(json, tmpl) {
 		jobs, _, err := client.Jobs().List(nil)
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error querying jobs: %v", err))
 			return 1
 		}
 
-		out, err := Format(json, tmpl, jobs)
+		out, err := c.FormatOutput(json, tmpl, jobs)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -184,8 +184,8 @@ func (c *JobInspectCommand) Run(args []string) int {
 	}
 
 	// If output format is specified, format and output the data
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, job)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, job)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobRollbackCommand struct {
+	Meta
+}
+
+func (c *JobRollbackCommand) Help() string {
+	helpText := `
+Usage: nomad job rollback [options] <job> [<version|tag>]
+
+  Rollback is used to re-register a previous version of a job. If no version
+  is given, the job is rolled back to the most recent version prior to the
+  current one that was marked as stable by a successful deployment. The
+  available versions can be found using the "nomad job history" command.
+
+  If a version number is specified, the job will be rolled back to the exact
+  version number. If a version tag is specified, the job will be rolled back to
+  the version with the given tag. The rollback only happens if the job has not
+  been updated since its versions were read.
+
+  When ACLs are enabled, this command requires a token with the 'submit-job'
+  and 'read-job' capabilities for the job's namespace. The 'list-jobs'
+  capability is required to run the command with a job prefix instead of the
+  exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Rollback Options:
+
+  -detach
+    Return immediately instead of entering monitor mode. After job rollback,
+    the evaluation ID will be printed to the screen, which can be used to
+    examine the evaluation using the eval-status command.
+
+  -consul-token
+   The Consul token used to verify that the caller has access to the Service
+   Identity policies associated in the targeted version of the job.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobRollbackCommand) Synopsis() string {
+	return "Roll back to a previous version of the job"
+}
+
+func (c *JobRollbackCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-detach":       complete.PredictNothing,
+			"-consul-token": complete.PredictAnything,
+			"-verbose":      complete.PredictNothing,
+		})
+}
+
+func (c *JobRollbackCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobRollbackCommand) Name() string { return "job rollback" }
+
+func (c *JobRollbackCommand) Run(args []string) int {
+	var detach, verbose bool
+	var consulToken string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&consulToken, "consul-token", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Check that we got one or two args
+	args = flags.Args()
+	if l := len(args); l < 1 || l > 2 {
+		c.Ui.Error("This command takes one or two arguments: <job> [<version|tag>]")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Parse the Consul token
+	if consulToken == "" {
+		// Check the environment variable
+		consulToken = os.Getenv("CONSUL_HTTP_TOKEN")
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	// Versions are returned newest first, so the first one is the version
+	// currently registered
+	versions, _, _, err := client.Jobs().Versions(jobID, false, &api.QueryOptions{Namespace: namespace})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving job versions: %s", err))
+		return 1
+	}
+	if len(versions) == 0 {
+		c.Ui.Error(fmt.Sprintf("No versions found for job %q", jobID))
+		return 1
+	}
+	current := *versions[0].Version
+
+	var target *api.Job
+	if len(args) == 2 {
+		target = findJobVersion(versions, args[1])
+		if target == nil {
+			c.Ui.Error(fmt.Sprintf("Job %q has no version or version tag %q", jobID, args[1]))
+			return 1
+		}
+		if *target.Version == current {
+			c.Ui.Error(fmt.Sprintf("Version %d is the current version of job %q", current, jobID))
+			return 1
+		}
+	} else {
+		target = lastStableJobVersion(versions[1:])
+		if target == nil {
+			c.Ui.Error(fmt.Sprintf("Job %q has no stable version prior to version %d; specify the version to roll back to", jobID, current))
+			return 1
+		}
+	}
+
+	c.Ui.Output(fmt.Sprintf("Rolling back job %q from version %d to version %d", jobID, current, *target.Version))
+
+	q := &api.WriteOptions{Namespace: namespace}
+	resp, _, err := client.Jobs().Revert(jobID, *target.Version, &current, q, consulToken, "")
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error rolling back job: %s", err))
+		return 1
+	}
+
+	// Nothing to do
+	if resp.EvalID == "" {
+		return 0
+	}
+
+	if detach {
+		c.Ui.Output("Evaluation ID: " + resp.EvalID)
+		return 0
+	}
+
+	mon := newMonitor(c.Ui, client, length)
+	return mon.monitor(resp.EvalID)
+}
+
+// findJobVersion returns the job version matching the given version number or
+// version tag name, or nil if there is none.
+func findJobVersion(versions []*api.Job, versionOrTag string) *api.Job {
+	version, ok, err := parseVersion(versionOrTag)
+	for _, job := range versions {
+		if ok && err == nil {
+			if *job.Version == version {
+				return job
+			}
+		} else if job.VersionTag != nil && job.VersionTag.Name == versionOrTag {
+			return job
+		}
+	}
+	return nil
+}
+
+// lastStableJobVersion returns the most recent stable version from the job
+// versions, which must be ordered newest first, or nil if none is stable.
+func lastStableJobVersion(versions []*api.Job) *api.Job {
+	for _, job := range versions {
+		if job.Stable != nil && *job.Stable {
+			return job
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobRollbackCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobRollbackCommand{}
+}
+
+func TestJobRollbackCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobRollbackCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "foo"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying job prefix")
+}
+
+func TestJobRollbackCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Register three versions of the job, of which only the first is stable
+	state := srv.Agent.Server().State()
+	job := mock.MinJob()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+	must.NoError(t, state.UpdateJobStability(101, job.Namespace, job.ID, 0, true))
+	for i, version := range []string{"1", "2"} {
+		newJob := job.Copy()
+		newJob.Meta = map[string]string{"version": version}
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, uint64(102+i), nil, newJob))
+	}
+
+	ui := cli.NewMockUi()
+	cmd := &JobRollbackCommand{Meta: Meta{Ui: ui}}
+
+	// The current version can't be rolled back to
+	code := cmd.Run([]string{"-address", url, job.ID, "2"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "is the current version")
+	ui.ErrorWriter.Reset()
+
+	// Without a version the job is rolled back to the last stable version
+	code = cmd.Run([]string{"-address", url, "-detach", job.ID})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "from version 2 to version 0")
+
+	registered, _, err := client.Jobs().Info(job.ID, nil)
+	must.NoError(t, err)
+	must.Eq(t, 3, *registered.Version)
+	must.MapEmpty(t, registered.Meta)
+	ui.OutputWriter.Reset()
+
+	// An explicit version is rolled back to even if it isn't stable
+	code = cmd.Run([]string{"-address", url, "-detach", job.ID, "1"})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "from version 3 to version 1")
+
+	registered, _, err = client.Jobs().Info(job.ID, nil)
+	must.NoError(t, err)
+	must.Eq(t, "1", registered.Meta["version"])
+}
@@ -5,14 +5,23 @@ package command
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad"
 	"github.com/posener/complete"
 )
 
@@ -28,7 +37,7 @@ type JobRunCommand struct {
 
 func (c *JobRunCommand) Help() string {
 	helpText := `
-Usage: nomad job run [options] <path>
+Usage: nomad job run [options] <path> [<path>...]
 Alias: nomad run
 
   Starts running a new job or updates an existing job using
@@ -37,7 +46,13 @@ Alias: nomad run
 
   If the supplied path is "-", the jobfile is read from stdin. Otherwise
   it is read from the file at the supplied path or downloaded and
-  read from URL specified.
+  read from URL specified. If the path is a directory, every jobfile
+  directly within it is run, in name order.
+
+  When several jobs are given, all of them are parsed and validated
+  before any is submitted. The jobs are then submitted in order, stopping
+  at the first job that fails to submit, and their evaluations are
+  monitored in turn.
 
   Upon successful job submission, this command will immediately
   enter an interactive monitor. This is useful to watch Nomad's
@@ -124,8 +139,49 @@ Run Options:
   -var-file=path
     Path to HCL2 file containing user variables.
 
+  -var-env=prefix
+    Set variables from the environment variables whose name starts with
+    prefix, in addition to those starting with NOMAD_VAR_. For example, with
+    -var-env=CI_VAR_ the environment variable CI_VAR_image sets the variable
+    "image". These take precedence over NOMAD_VAR_ environment variables.
+
+  -pre-render
+    Render the jobfile with consul-template before parsing it. Template actions
+    are delimited by {% and %}, so that they don't clash with the template
+    blocks of the job or with shell syntax in embedded scripts, and can read
+    from Consul and Vault using the
+    CONSUL_HTTP_* and VAULT_* environment variables, such as VAULT_TOKEN. The
+    rendered jobfile is stored with the job, including any secrets it contains.
+
   -verbose
     Display full information.
+
+  -diff
+    Plan the job before submitting it, showing the changes it would make and
+    the scheduler dry-run, and ask for confirmation before running it. The job
+    is only run if it has not been changed since it was planned.
+
+  -yes
+    Run the job without asking for confirmation when -diff is set.
+
+  -validate-only
+    Validate the jobs locally and exit without submitting them or contacting
+    the cluster. Each validation error is printed with the position in the
+    jobfile of the job, group, or task block it applies to.
+
+  -strict
+    When used with -validate-only, also run the checks the servers make when a
+    job is submitted, such as the Consul Connect and check expose validation,
+    using the default server configuration, and treat warnings as errors.
+
+  -wait-for=<placed|running|healthy>
+    Sets what the monitor waits for once the evaluation has completed. With
+    "placed" the command exits once the allocations are placed. With "running"
+    it waits for the allocations to be running and exits with a non-zero code
+    if any of them fails. With "healthy" it waits for the deployment to be
+    successful, exiting with a non-zero code if it fails, or for the
+    allocations to be running if the job has no deployment. Defaults to
+    waiting for the deployment, if any. Cannot be used with -detach.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -140,6 +196,11 @@ func (c *JobRunCommand) AutocompleteFlags() complete.Flags {
 			"-check-index":      complete.PredictNothing,
 			"-detach":           complete.PredictNothing,
 			"-verbose":          complete.PredictNothing,
+			"-wait-for":         complete.PredictSet(monitorWaitForPlaced, monitorWaitForRunning, monitorWaitForHealthy),
+			"-diff":             complete.PredictNothing,
+			"-yes":              complete.PredictNothing,
+			"-validate-only":    complete.PredictNothing,
+			"-strict":           complete.PredictNothing,
 			"-consul-namespace": complete.PredictAnything,
 			"-vault-namespace":  complete.PredictAnything,
 			"-output":           complete.PredictNothing,
@@ -149,6 +210,8 @@ func (c *JobRunCommand) AutocompleteFlags() complete.Flags {
 			"-hcl2-strict":      complete.PredictNothing,
 			"-var":              complete.PredictAnything,
 			"-var-file":         complete.PredictFiles("*.var"),
+			"-var-env":          complete.PredictAnything,
+			"-pre-render":       complete.PredictNothing,
 			"-eval-priority":    complete.PredictNothing,
 			"-ui":               complete.PredictNothing,
 		})
@@ -165,8 +228,9 @@ func (c *JobRunCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobRunCommand) Name() string { return "job run" }
 
 func (c *JobRunCommand) Run(args []string) int {
-	var detach, verbose, output, override, preserveCounts, openURL bool
-	var checkIndexStr, consulNamespace, vaultNamespace string
+	var detach, verbose, output, override, preserveCounts, openURL, diff, autoYes bool
+	var validateOnly, strict bool
+	var checkIndexStr, consulNamespace, vaultNamespace, waitFor string
 	var evalPriority int
 
 	flagSet := c.Meta.FlagSet(c.Name(), FlagSetClient)
@@ -183,23 +247,46 @@ func (c *JobRunCommand) Run(args []string) int {
 	flagSet.StringVar(&vaultNamespace, "vault-namespace", "", "")
 	flagSet.Var(&c.JobGetter.Vars, "var", "")
 	flagSet.Var(&c.JobGetter.VarFiles, "var-file", "")
+	flagSet.StringVar(&c.JobGetter.VarEnvPrefix, "var-env", "", "")
+	flagSet.BoolVar(&c.JobGetter.PreRender, "pre-render", false, "")
 	flagSet.IntVar(&evalPriority, "eval-priority", 0, "")
 	flagSet.BoolVar(&openURL, "ui", false, "")
+	flagSet.StringVar(&waitFor, "wait-for", "", "")
+	flagSet.BoolVar(&diff, "diff", false, "")
+	flagSet.BoolVar(&autoYes, "yes", false, "")
+	flagSet.BoolVar(&validateOnly, "validate-only", false, "")
+	flagSet.BoolVar(&strict, "strict", false, "")
 
 	if err := flagSet.Parse(args); err != nil {
 		return 1
 	}
 
+	switch waitFor {
+	case "", monitorWaitForPlaced, monitorWaitForRunning, monitorWaitForHealthy:
+	default:
+		c.Ui.Error(fmt.Sprintf("Invalid -wait-for value %q: must be one of %q, %q, or %q",
+			waitFor, monitorWaitForPlaced, monitorWaitForRunning, monitorWaitForHealthy))
+		return 1
+	}
+	if detach && waitFor != "" {
+		c.Ui.Error("The -detach and -wait-for flags cannot be used together")
+		return 1
+	}
+	if strict && !validateOnly {
+		c.Ui.Error("The -strict flag can only be used with -validate-only")
+		return 1
+	}
+
 	// Truncate the id unless full length is requested
 	length := shortId
 	if verbose {
 		length = fullId
 	}
 
-	// Check that we got exactly one argument
+	// Check that we got at least one argument
 	args = flagSet.Args()
-	if len(args) != 1 {
-		c.Ui.Error("This command takes one argument: <path>")
+	if len(args) == 0 {
+		c.Ui.Error("This command takes at least one argument: <path>")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
@@ -209,13 +296,44 @@ func (c *JobRunCommand) Run(args []string) int {
 		return 1
 	}
 
-	// Get Job struct from Jobfile
-	sub, job, err := c.JobGetter.Get(args[0])
+	paths, err := expandJobPaths(args, c.JobGetter.JSON)
 	if err != nil {
-		c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+		c.Ui.Error(fmt.Sprintf("Error reading job paths: %s", err))
+		return 1
+	}
+	multiple := len(paths) > 1
+	if multiple && checkIndexStr != "" {
+		c.Ui.Error("The -check-index flag can only be used when running a single job")
 		return 1
 	}
 
+	// Parse every jobfile before submitting any job, so that a mistake in one
+	// of them doesn't leave the jobs partially submitted
+	runs := make([]*jobRun, 0, len(paths))
+	for _, path := range paths {
+		sub, job, err := c.JobGetter.Get(path)
+		if err != nil {
+			if multiple {
+				c.Ui.Error(fmt.Sprintf("Error getting job struct from %q: %s", path, err))
+			} else {
+				c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+			}
+			return 1
+		}
+
+		if consulNamespace != "" {
+			job.ConsulNamespace = pointer.Of(consulNamespace)
+		}
+		if vaultNamespace != "" {
+			job.VaultNamespace = pointer.Of(vaultNamespace)
+		}
+		runs = append(runs, &jobRun{path: path, sub: sub, job: job})
+	}
+
+	if validateOnly {
+		return c.validateJobsLocal(runs, strict)
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -223,65 +341,191 @@ func (c *JobRunCommand) Run(args []string) int {
 		return 1
 	}
 
-	// Force the region to be that of the job.
-	if r := job.Region; r != nil {
-		client.SetRegion(*r)
+	if output {
+		for _, run := range runs {
+			req := struct {
+				Job *api.Job
+			}{
+				Job: run.job,
+			}
+			buf, err := json.MarshalIndent(req, "", "    ")
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error converting job: %s", err))
+				return 1
+			}
+
+			c.Ui.Output(string(buf))
+		}
+
+		return 0
 	}
 
-	// Force the namespace to be that of the job.
-	if n := job.Namespace; n != nil {
-		client.SetNamespace(*n)
+	// Parse the check-index
+	checkIndex, enforce, err := parseCheckIndex(checkIndexStr)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing check-index value %q: %v", checkIndexStr, err))
+		return 1
 	}
 
-	// Check if the job is periodic or is a parameterized job
-	periodic := job.IsPeriodic()
-	paramjob := job.IsParameterized()
-	multiregion := job.IsMultiregion()
+	// Validate every job before submitting any of them
+	if multiple {
+		for _, run := range runs {
+			setJobClientScope(client, run.job)
+			resp, _, err := client.Jobs().Validate(run.job, nil)
+			if err == nil && resp.Error != "" {
+				err = errors.New(resp.Error)
+			}
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error validating job from %q: %s", run.path, err))
+				c.Ui.Error("No jobs were submitted")
+				return 1
+			}
+		}
+	}
 
-	if consulNamespace != "" {
-		job.ConsulNamespace = pointer.Of(consulNamespace)
+	// Show the changes the jobs would make and ask before running them
+	if diff {
+		if code, ok := c.planJobs(client, runs, override, verbose, autoYes); !ok {
+			return code
+		}
 	}
 
-	if vaultNamespace != "" {
-		job.VaultNamespace = pointer.Of(vaultNamespace)
+	// Submit the jobs in the order they were given
+	for i, run := range runs {
+		setJobClientScope(client, run.job)
+
+		// Set the register options
+		opts := &api.RegisterOptions{
+			PolicyOverride: override,
+			PreserveCounts: preserveCounts,
+			EvalPriority:   evalPriority,
+			Submission:     run.sub,
+		}
+		if enforce {
+			opts.EnforceIndex = true
+			opts.ModifyIndex = checkIndex
+		} else if run.planned {
+			// Only run the job if it hasn't changed since it was planned
+			opts.EnforceIndex = true
+			opts.ModifyIndex = run.planIndex
+		}
+
+		if multiple {
+			c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[bold]==> Submitting job %q from %q[reset]", *run.job.ID, run.path)))
+		}
+		if code := c.submitJob(client, run, opts, detach, openURL); code != 0 {
+			if multiple && i+1 < len(runs) {
+				c.Ui.Error(fmt.Sprintf("Skipped submitting the remaining %d job(s)", len(runs)-i-1))
+			}
+			return code
+		}
 	}
 
-	if output {
-		req := struct {
-			Job *api.Job
-		}{
-			Job: job,
+	// Monitor the evaluations of the submitted jobs, returning the most
+	// severe exit code
+	code := 0
+	for _, run := range runs {
+		if run.evalID == "" {
+			continue
 		}
-		buf, err := json.MarshalIndent(req, "", "    ")
-		if err != nil {
-			c.Ui.Error(fmt.Sprintf("Error converting job: %s", err))
-			return 1
+		setJobClientScope(client, run.job)
+
+		if multiple {
+			c.Ui.Output(c.Colorize().Color(fmt.Sprintf("\n[bold]==> Monitoring job %q[reset]", *run.job.ID)))
+		}
+		mon := newMonitor(c.Ui, client, length)
+		mon.waitFor = waitFor
+		switch monCode := mon.monitor(run.evalID); {
+		case monCode == 1:
+			code = 1
+		case monCode == 2 && code == 0:
+			code = 2
+		}
+	}
+	return code
+}
+
+// jobRun is a job parsed from a jobfile and the evaluation created when it
+// was submitted, if it should be monitored.
+type jobRun struct {
+	path   string
+	sub    *api.JobSubmission
+	job    *api.Job
+	evalID string
+
+	// planned is set if the job was planned before being submitted, in which
+	// case planIndex is the job modify index returned by the plan
+	planned   bool
+	planIndex uint64
+}
+
+// planJobs plans the jobs, printing the changes running them would make, and
+// asks for confirmation unless autoYes is set. It returns false along with the
+// exit code if the jobs should not be run.
+func (c *JobRunCommand) planJobs(client *api.Client, runs []*jobRun, override, verbose, autoYes bool) (int, bool) {
+	plan := &JobPlanCommand{Meta: c.Meta}
+	for _, run := range runs {
+		setJobClientScope(client, run.job)
+
+		opts := &api.PlanOptions{
+			Diff:           true,
+			PolicyOverride: override,
+		}
+		if len(runs) > 1 {
+			c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[bold]==> Planning job %q from %q[reset]", *run.job.ID, run.path)))
 		}
 
-		c.Ui.Output(string(buf))
+		if run.job.IsMultiregion() {
+			if code := plan.multiregionPlan(client, run.job, opts, true, verbose); code == 255 {
+				return 1, false
+			}
+			continue
+		}
 
-		return 0
+		resp, _, err := client.Jobs().PlanOpts(run.job, opts, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error during plan: %s", err))
+			return 1, false
+		}
+		plan.outputPlannedJob(run.job, resp, true, verbose)
+		run.planned = true
+		run.planIndex = resp.JobModifyIndex
 	}
 
-	// Parse the check-index
-	checkIndex, enforce, err := parseCheckIndex(checkIndexStr)
-	if err != nil {
-		c.Ui.Error(fmt.Sprintf("Error parsing check-index value %q: %v", checkIndexStr, err))
-		return 1
+	if autoYes {
+		return 0, true
 	}
 
-	// Set the register options
-	opts := &api.RegisterOptions{
-		PolicyOverride: override,
-		PreserveCounts: preserveCounts,
-		EvalPriority:   evalPriority,
-		Submission:     sub,
+	question := "Do you want to run this job? [y/N]"
+	if len(runs) > 1 {
+		question = fmt.Sprintf("Do you want to run these %d jobs? [y/N]", len(runs))
 	}
-	if enforce {
-		opts.EnforceIndex = true
-		opts.ModifyIndex = checkIndex
+	answer, err := c.Ui.Ask(question)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse answer: %v", err))
+		return 1, false
 	}
 
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return 0, true
+	default:
+		c.Ui.Output("Cancelling job run")
+		return 0, false
+	}
+}
+
+// submitJob registers the job and sets the ID of its evaluation on the run if
+// the evaluation should be monitored. It returns a non-zero exit code if the
+// job could not be submitted.
+func (c *JobRunCommand) submitJob(client *api.Client, run *jobRun, opts *api.RegisterOptions, detach, openURL bool) int {
+	job := run.job
+
+	// Check if the job is periodic or is a parameterized job
+	periodic := job.IsPeriodic()
+	paramjob := job.IsParameterized()
+	multiregion := job.IsMultiregion()
+
 	// Submit the job
 	resp, _, err := client.Jobs().RegisterOpts(job, opts, nil)
 	if err != nil {
@@ -313,10 +557,15 @@ func (c *JobRunCommand) Run(args []string) int {
 		jobNamespace = "default"
 	}
 
+	// Jobs held by their dependencies have no evaluation to monitor yet
+	held := evalID == "" && len(job.DependsOn) > 0
+
 	// Check if we should enter monitor mode
-	if detach || periodic || paramjob || multiregion {
+	if detach || periodic || paramjob || multiregion || held {
 		c.Ui.Output("Job registration successful")
-		if periodic && !paramjob {
+		if held {
+			c.Ui.Output("Job is waiting on the jobs it depends on")
+		} else if periodic && !paramjob {
 			loc, err := job.Periodic.GetLocation()
 			if err == nil {
 				now := time.Now().In(loc)
@@ -347,7 +596,7 @@ func (c *JobRunCommand) Run(args []string) int {
 		return 0
 	}
 
-	// Detach was not specified, so start monitoring
+	// Detach was not specified, so the evaluation will be monitored
 	hint, _ := c.Meta.showUIPath(UIHintContext{
 		Command: "job run",
 		PathParams: map[string]string{
@@ -362,9 +611,192 @@ func (c *JobRunCommand) Run(args []string) int {
 		c.Ui.Warn("")
 	}
 
-	mon := newMonitor(c.Ui, client, length)
-	return mon.monitor(evalID)
+	run.evalID = evalID
+	return 0
+}
+
+// setJobClientScope forces the region and namespace of the client to be those
+// of the job.
+func setJobClientScope(client *api.Client, job *api.Job) {
+	if r := job.Region; r != nil {
+		client.SetRegion(*r)
+	}
+	if n := job.Namespace; n != nil {
+		client.SetNamespace(*n)
+	}
+}
+
+// expandJobPaths returns the jobfiles to run for the given paths. Directories
+// are expanded to the jobfiles directly within them, sorted by name. Jobfiles
+// are files with a .nomad or .hcl extension, or .json if jsonFiles is set.
+func expandJobPaths(paths []string, jsonFiles bool) ([]string, error) {
+	var expanded []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			// Not a local directory: stdin, a file, or a URL
+			expanded = append(expanded, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		var found bool
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			switch filepath.Ext(entry.Name()) {
+			case ".nomad", ".hcl":
+				if jsonFiles {
+					continue
+				}
+			case ".json":
+				if !jsonFiles {
+					continue
+				}
+			default:
+				continue
+			}
+			expanded = append(expanded, filepath.Join(path, entry.Name()))
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("no jobfiles found in directory %q", path)
+		}
+	}
+	return expanded, nil
+}
+
+// validateJobsLocal validates the jobs without contacting the cluster,
+// printing each error with the position of the block it applies to when the
+// jobfile is HCL. If strict is set, the jobs also go through the admission
+// checks of the servers and warnings are reported as errors.
+func (c *JobRunCommand) validateJobsLocal(runs []*jobRun, strict bool) int {
+	code := 0
+	for _, run := range runs {
+		job := agent.ApiJobToStructJob(run.job)
+
+		var warnings []error
+		var err error
+		if strict {
+			_, warnings, err = nomad.LocalAdmissionControllers(job)
+		} else {
+			job.Canonicalize()
+			err = job.Validate()
+			warnings = flattenErrors(job.Warnings())
+		}
+
+		errs := flattenErrors(err)
+		if strict {
+			errs = append(errs, warnings...)
+			warnings = nil
+		}
 
+		if len(errs) != 0 {
+			code = 1
+			ranges := jobSourceRanges(run.path, run.sub)
+			c.Ui.Error(c.Colorize().Color(
+				fmt.Sprintf("[bold][red]Job validation errors in %q:[reset]", run.path)))
+			for _, err := range errs {
+				if rng, ok := ranges[validationErrorBlock(err)]; ok {
+					c.Ui.Error(fmt.Sprintf("%s: %s", rng, err))
+				} else if rng, ok := ranges[""]; ok {
+					c.Ui.Error(fmt.Sprintf("%s: %s", rng, err))
+				} else {
+					c.Ui.Error(fmt.Sprintf("* %s", err))
+				}
+			}
+			continue
+		}
+
+		if len(warnings) != 0 {
+			c.Ui.Output(c.FormatWarnings("Job", helper.MergeMultierrorWarnings(warnings...)))
+		}
+		c.Ui.Output(c.Colorize().Color(
+			fmt.Sprintf("[bold][green]Job validation of %q successful[reset]", run.path)))
+	}
+	return code
+}
+
+// flattenErrors returns the errors wrapped by err, which may be a nested
+// multierror.
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	merr, ok := err.(*multierror.Error)
+	if !ok {
+		return []error{err}
+	}
+	var out []error
+	for _, err := range merr.Errors {
+		out = append(out, flattenErrors(err)...)
+	}
+	return out
+}
+
+var (
+	// groupValidationRegex and taskValidationRegex extract the names of the
+	// group and task a job validation error applies to
+	groupValidationRegex = regexp.MustCompile(`^Task group (\S+) validation failed`)
+	taskValidationRegex  = regexp.MustCompile(`Task (\S+) validation failed`)
+)
+
+// validationErrorBlock returns the key of the block a job validation error
+// applies to, as used by jobSourceRanges.
+func validationErrorBlock(err error) string {
+	m := groupValidationRegex.FindStringSubmatch(err.Error())
+	if m == nil {
+		return ""
+	}
+	key := "group." + m[1]
+	if m := taskValidationRegex.FindStringSubmatch(err.Error()); m != nil {
+		key += ".task." + m[1]
+	}
+	return key
+}
+
+// jobSourceRanges returns the positions of the job, group and task blocks of
+// an HCL jobfile, keyed by "" for the job, "group.<name>" for a group and
+// "group.<name>.task.<name>" for a task. It returns nil for JSON jobfiles or
+// if the source can't be parsed.
+func jobSourceRanges(path string, sub *api.JobSubmission) map[string]hcl.Range {
+	if sub == nil || sub.Format != formatHCL2 {
+		return nil
+	}
+	file, diags := hclsyntax.ParseConfig([]byte(sub.Source), path, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+
+	ranges := make(map[string]hcl.Range)
+	for _, jobBlock := range body.Blocks {
+		if jobBlock.Type != "job" {
+			continue
+		}
+		ranges[""] = jobBlock.DefRange()
+		for _, group := range jobBlock.Body.Blocks {
+			if group.Type != "group" || len(group.Labels) != 1 {
+				continue
+			}
+			groupKey := "group." + group.Labels[0]
+			ranges[groupKey] = group.DefRange()
+			for _, task := range group.Body.Blocks {
+				if task.Type != "task" || len(task.Labels) != 1 {
+					continue
+				}
+				ranges[groupKey+".task."+task.Labels[0]] = task.DefRange()
+			}
+		}
+	}
+	return ranges
 }
 
 // parseCheckIndex parses the check-index flag and returns the index, whether it
@@ -56,6 +56,169 @@ job "job1" {
 	}
 }
 
+func TestRunCommand_WaitFor_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &JobRunCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-wait-for=started", "example.nomad.hcl"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `Invalid -wait-for value "started"`)
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-detach", "-wait-for=healthy", "example.nomad.hcl"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "cannot be used together")
+}
+
+func TestRunCommand_expandJobPaths(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	for _, name := range []string{"b.nomad.hcl", "a.nomad", "c.json", "README.md"} {
+		must.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	must.NoError(t, os.Mkdir(filepath.Join(dir, "nested.hcl"), 0755))
+
+	paths, err := expandJobPaths([]string{"-", dir, "https://example.com/job.hcl"}, false)
+	must.NoError(t, err)
+	must.Eq(t, []string{
+		"-",
+		filepath.Join(dir, "a.nomad"),
+		filepath.Join(dir, "b.nomad.hcl"),
+		"https://example.com/job.hcl",
+	}, paths)
+
+	paths, err = expandJobPaths([]string{dir}, true)
+	must.NoError(t, err)
+	must.Eq(t, []string{filepath.Join(dir, "c.json")}, paths)
+
+	_, err = expandJobPaths([]string{t.TempDir()}, false)
+	must.ErrorContains(t, err, "no jobfiles found")
+}
+
+func TestRunCommand_Multiple_ParseFailure(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "a.nomad.hcl"), []byte(`
+job "a" {
+  group "group1" {
+    task "task1" {
+      driver = "exec"
+    }
+  }
+}`), 0644))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "b.nomad.hcl"), []byte("nope"), 0644))
+
+	ui := cli.NewMockUi()
+	cmd := &JobRunCommand{Meta: Meta{Ui: ui}}
+
+	// no job is submitted if any of the jobfiles fails to parse; the address
+	// is never reached
+	code := cmd.Run([]string{"-address=nope", dir})
+	must.One(t, code)
+	out := ui.ErrorWriter.String()
+	must.StrContains(t, out, "Error getting job struct from")
+	must.StrContains(t, out, "b.nomad.hcl")
+	must.StrNotContains(t, out, "Error submitting job")
+}
+
+func TestRunCommand_ValidateOnly(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "example.nomad.hcl")
+	must.NoError(t, os.WriteFile(path, []byte(`
+job "example" {
+  datacenters = ["dc1"]
+  priority    = 150
+
+  group "group1" {
+    count = -1
+
+    task "task1" {
+      driver = "exec"
+    }
+  }
+}`), 0644))
+
+	ui := cli.NewMockUi()
+	cmd := &JobRunCommand{Meta: Meta{Ui: ui}}
+
+	// the address is never reached
+	code := cmd.Run([]string{"-address=nope", "-validate-only", path})
+	must.One(t, code)
+	out := ui.ErrorWriter.String()
+	must.StrContains(t, out, path+":6,3-17: Task group group1 validation failed")
+	must.StrContains(t, out, "Task group count can't be negative")
+	must.StrNotContains(t, out, "job priority must be between")
+	ui.ErrorWriter.Reset()
+
+	// fix the group so that only the server admission checks fail
+	must.NoError(t, os.WriteFile(path, []byte(`
+job "example" {
+  datacenters = ["dc1"]
+  priority    = 150
+
+  group "group1" {
+    task "task1" {
+      driver = "exec"
+    }
+  }
+}`), 0644))
+
+	code = cmd.Run([]string{"-address=nope", "-validate-only", path})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "successful")
+
+	code = cmd.Run([]string{"-address=nope", "-validate-only", "-strict", path})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), path+":2,1-14: job priority must be between")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-strict", path})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "can only be used with -validate-only")
+}
+
+func TestRunCommand_Diff(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	t.Run("cancelled", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		ui.InputReader = strings.NewReader("n\n")
+		cmd := &JobRunCommand{Meta: Meta{Ui: ui}}
+
+		code := cmd.Run([]string{"-address=" + url, "-diff", "testdata/example-basic.nomad"})
+		must.Zero(t, code)
+		out := ui.OutputWriter.String()
+		must.StrContains(t, out, `+ Job: "job1"`)
+		must.StrContains(t, out, "Scheduler dry-run:")
+		must.StrContains(t, out, "Cancelling job run")
+
+		_, _, err := client.Jobs().Info("job1", nil)
+		must.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobRunCommand{Meta: Meta{Ui: ui}}
+
+		code := cmd.Run([]string{"-address=" + url, "-diff", "-yes", "-detach", "testdata/example-basic.nomad"})
+		must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+		must.StrContains(t, ui.OutputWriter.String(), "Job registration successful")
+
+		job, _, err := client.Jobs().Info("job1", nil)
+		must.NoError(t, err)
+		must.Eq(t, "job1", *job.ID)
+	})
+}
+
 func TestRunCommand_Fails(t *testing.T) {
 	ci.Parallel(t)
 
@@ -67,7 +230,7 @@ func TestRunCommand_Fails(t *testing.T) {
 	cmd := &JobRunCommand{Meta: Meta{Ui: ui, flagAddress: "http://" + s.HTTPAddr}}
 
 	// Fails on misuse
-	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+	if code := cmd.Run([]string{}); code != 1 {
 		t.Fatalf("expected exit code 1, got: %d", code)
 	}
 	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
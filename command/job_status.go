@@ -57,7 +57,7 @@ Usage: nomad job status [options] <job>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Status Options:
 
@@ -86,7 +86,7 @@ func (c *JobStatusCommand) Synopsis() string {
 }
 
 func (c *JobStatusCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-all-allocs": complete.PredictNothing,
 			"-evals":      complete.PredictNothing,
@@ -116,7 +116,7 @@ func (c *JobStatusCommand) Name() string { return "status" }
 func (c *JobStatusCommand) Run(args []string) int {
 	var short bool
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&short, "short", false, "")
 	flags.BoolVar(&c.evals, "evals", false, "")
@@ -173,7 +173,7 @@ func (c *JobStatusCommand) Run(args []string) int {
 				c.Ui.Warn(hint)
 			}
 		} else {
-			if c.json || len(c.tmpl) > 0 {
+			if c.StructuredOutput(c.json, c.tmpl) {
 				pairs := make([]NamespacedID, len(jobs))
 
 				for i, j := range jobs {
@@ -186,7 +186,7 @@ func (c *JobStatusCommand) Run(args []string) int {
 					return 1
 				}
 
-				out, err := Format(c.json, c.tmpl, jsonJobs)
+				out, err := c.FormatOutput(c.json, c.tmpl, jsonJobs)
 				if err != nil {
 					c.Ui.Error(err.Error())
 					return 1
@@ -231,7 +231,7 @@ func (c *JobStatusCommand) Run(args []string) int {
 		nodePool = *job.NodePool
 	}
 
-	if c.json || len(c.tmpl) > 0 {
+	if c.StructuredOutput(c.json, c.tmpl) {
 		jsonJobs, err := createJsonJobsOutput(client, c.allAllocs,
 			NamespacedID{ID: *job.ID, Namespace: *job.Namespace})
 
@@ -240,7 +240,7 @@ func (c *JobStatusCommand) Run(args []string) int {
 			return 1
 		}
 
-		out, err := Format(c.json, c.tmpl, jsonJobs)
+		out, err := c.FormatOutput(c.json, c.tmpl, jsonJobs)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
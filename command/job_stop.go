@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
@@ -63,6 +64,18 @@ Stop Options:
     Purge is used to stop the job and purge it from the system. If not set, the
     job will still be queryable and will be purged by the garbage collector.
 
+  -shutdown-delay=<duration>
+    Override the group and task shutdown_delay configuration of the
+    allocations being stopped. The services of each allocation are
+    deregistered and the allocation waits for the delay before its tasks are
+    killed, giving load balancers time to drain the connections to it. Cannot
+    be used with -no-shutdown-delay.
+
+  -wait
+    Wait for all the allocations of the job to stop once the evaluation has
+    completed, showing each allocation as it stops. Cannot be used with
+    -detach.
+
   -yes
     Automatic yes to prompts.
 
@@ -84,6 +97,8 @@ func (c *JobStopCommand) AutocompleteFlags() complete.Flags {
 			"-purge":             complete.PredictNothing,
 			"-global":            complete.PredictNothing,
 			"-no-shutdown-delay": complete.PredictNothing,
+			"-shutdown-delay":    complete.PredictAnything,
+			"-wait":              complete.PredictNothing,
 			"-yes":               complete.PredictNothing,
 			"-verbose":           complete.PredictNothing,
 		})
@@ -107,8 +122,9 @@ func (c *JobStopCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobStopCommand) Name() string { return "job stop" }
 
 func (c *JobStopCommand) Run(args []string) int {
-	var detach, purge, verbose, global, autoYes, noShutdownDelay bool
+	var detach, purge, verbose, global, autoYes, noShutdownDelay, wait bool
 	var evalPriority int
+	var shutdownDelay time.Duration
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -119,11 +135,26 @@ func (c *JobStopCommand) Run(args []string) int {
 	flags.BoolVar(&autoYes, "yes", false, "")
 	flags.BoolVar(&purge, "purge", false, "")
 	flags.IntVar(&evalPriority, "eval-priority", 0, "")
+	flags.DurationVar(&shutdownDelay, "shutdown-delay", 0, "")
+	flags.BoolVar(&wait, "wait", false, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 
+	if shutdownDelay < 0 {
+		c.Ui.Error("The -shutdown-delay flag must not be negative")
+		return 1
+	}
+	if noShutdownDelay && shutdownDelay > 0 {
+		c.Ui.Error("The -no-shutdown-delay and -shutdown-delay flags cannot be used together")
+		return 1
+	}
+	if detach && wait {
+		c.Ui.Error("The -detach and -wait flags cannot be used together")
+		return 1
+	}
+
 	// Check that we got exactly one job
 	args = flags.Args()
 	if len(args) < 1 {
@@ -214,8 +245,25 @@ func (c *JobStopCommand) Run(args []string) int {
 				}
 			}
 
+			// Read the allocations to wait for before they are stopped
+			var allocs []*api.AllocationListStub
+			if wait {
+				allocs, _, err = client.Jobs().Allocations(*job.ID, false, &api.QueryOptions{Namespace: *job.Namespace})
+				if err != nil {
+					c.Ui.Error(fmt.Sprintf("Error reading allocations of job with id %s err: %s", jobID, err))
+					statusCh <- 1
+					return
+				}
+			}
+
 			// Invoke the stop
-			opts := &api.DeregisterOptions{Purge: purge, Global: global, EvalPriority: evalPriority, NoShutdownDelay: noShutdownDelay}
+			opts := &api.DeregisterOptions{
+				Purge:           purge,
+				Global:          global,
+				EvalPriority:    evalPriority,
+				NoShutdownDelay: noShutdownDelay,
+				ShutdownDelay:   shutdownDelay,
+			}
 			wq := &api.WriteOptions{Namespace: *job.Namespace}
 			evalID, _, err := client.Jobs().DeregisterOpts(*job.ID, opts, wq)
 			if err != nil {
@@ -240,7 +288,11 @@ func (c *JobStopCommand) Run(args []string) int {
 			// Start monitoring the stop eval
 			// and return result on status channel
 			mon := newMonitor(c.Ui, client, length)
-			statusCh <- mon.monitor(evalID)
+			code := mon.monitor(evalID)
+			if code == 0 && wait {
+				code = c.waitForAllocsStopped(client, allocs, shutdownDelay, length)
+			}
+			statusCh <- code
 		}()
 	}
 	// users will still see
@@ -264,3 +316,51 @@ func (c *JobStopCommand) Run(args []string) int {
 
 	return 0
 }
+
+// waitForAllocsStopped waits for the allocations that haven't yet stopped to
+// reach a terminal client status, reporting each one as it does.
+func (c *JobStopCommand) waitForAllocsStopped(client *api.Client, allocs []*api.AllocationListStub,
+	shutdownDelay time.Duration, length int) int {
+
+	pending := make(map[string]struct{})
+	for _, alloc := range allocs {
+		switch alloc.ClientStatus {
+		case api.AllocClientStatusComplete, api.AllocClientStatusFailed, api.AllocClientStatusLost:
+		default:
+			pending[alloc.ID] = struct{}{}
+		}
+	}
+	if len(pending) == 0 {
+		return 0
+	}
+
+	if shutdownDelay > 0 {
+		c.Ui.Info(fmt.Sprintf("%s: Waiting for %d allocation(s) to stop after a shutdown delay of %s",
+			formatTime(time.Now()), len(pending), shutdownDelay))
+	} else {
+		c.Ui.Info(fmt.Sprintf("%s: Waiting for %d allocation(s) to stop",
+			formatTime(time.Now()), len(pending)))
+	}
+
+	for {
+		for id := range pending {
+			alloc, _, err := client.Allocations().Info(id, nil)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("%s: Error reading allocation %q: %s",
+					formatTime(time.Now()), limit(id, length), err))
+				return 1
+			}
+			if alloc.ClientTerminalStatus() {
+				c.Ui.Output(fmt.Sprintf("%s: Allocation %q is %q",
+					formatTime(time.Now()), limit(id, length), alloc.ClientStatus))
+				delete(pending, id)
+			}
+		}
+
+		if len(pending) == 0 {
+			c.Ui.Info(fmt.Sprintf("%s: All allocations have stopped", formatTime(time.Now())))
+			return 0
+		}
+		time.Sleep(updateWait)
+	}
+}
@@ -96,6 +96,44 @@ func TestStopCommand_multi(t *testing.T) {
 	)
 }
 
+func TestStopCommand_ShutdownDelayWait(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, addr := testServer(t, true, func(c *agent.Config) {
+		c.DevMode = true
+	})
+	defer srv.Shutdown()
+	waitForNodes(t, client)
+
+	jobID := uuid.Generate()
+	job := testJob(jobID)
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = pointer.Of(16)
+	job.TaskGroups[0].Tasks[0].Resources.DiskMB = pointer.Of(32)
+	job.TaskGroups[0].Tasks[0].Resources.CPU = pointer.Of(10)
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "30s",
+	}
+	resp, _, err := client.Jobs().Register(job, nil)
+	must.NoError(t, err)
+	must.Zero(t, waitForSuccess(cli.NewMockUi(), client, fullId, t, resp.EvalID))
+
+	ui := cli.NewMockUi()
+	cmd := &JobStopCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address", addr, "-shutdown-delay=1s", "-wait", jobID})
+	must.Zero(t, code, must.Sprintf("job stop stderr: %s", ui.ErrorWriter.String()))
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "to stop after a shutdown delay of 1s")
+	must.StrContains(t, out, "All allocations have stopped")
+
+	allocs, _, err := client.Jobs().Allocations(jobID, false, nil)
+	must.NoError(t, err)
+	must.SliceNotEmpty(t, allocs)
+	for _, alloc := range allocs {
+		must.Eq(t, api.AllocClientStatusComplete, alloc.ClientStatus)
+	}
+}
+
 func TestStopCommand_Fails(t *testing.T) {
 	ci.Parallel(t)
 	srv, _, url := testServer(t, false, nil)
@@ -113,6 +151,17 @@ func TestStopCommand_Fails(t *testing.T) {
 
 	ui.ErrorWriter.Reset()
 
+	// Fails on conflicting flags
+	code = cmd.Run([]string{"-address=" + url, "-detach", "-wait", "nope"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "-detach and -wait flags cannot be used together")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-no-shutdown-delay", "-shutdown-delay=1s", "nope"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "-no-shutdown-delay and -shutdown-delay flags cannot be used together")
+	ui.ErrorWriter.Reset()
+
 	// Fails on nonexistent job ID
 	code = cmd.Run([]string{"-address=" + url, "nope"})
 	must.One(t, code)
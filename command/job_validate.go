@@ -60,6 +60,19 @@ Validate Options:
 
   -var-file=path
     Path to HCL2 file containing user variables.
+
+  -var-env=prefix
+    Set variables from the environment variables whose name starts with
+    prefix, in addition to those starting with NOMAD_VAR_. For example, with
+    -var-env=CI_VAR_ the environment variable CI_VAR_image sets the variable
+    "image". These take precedence over NOMAD_VAR_ environment variables.
+
+  -pre-render
+    Render the jobfile with consul-template before parsing it. Template actions
+    are delimited by {% and %}, so that they don't clash with the template
+    blocks of the job or with shell syntax in embedded scripts, and can read
+    from Consul and Vault using the
+    CONSUL_HTTP_* and VAULT_* environment variables, such as VAULT_TOKEN.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -74,6 +87,8 @@ func (c *JobValidateCommand) AutocompleteFlags() complete.Flags {
 		"-vault-namespace": complete.PredictAnything,
 		"-var":             complete.PredictAnything,
 		"-var-file":        complete.PredictFiles("*.var"),
+		"-var-env":         complete.PredictAnything,
+		"-pre-render":      complete.PredictNothing,
 	}
 }
 
@@ -97,6 +112,8 @@ func (c *JobValidateCommand) Run(args []string) int {
 	flagSet.StringVar(&vaultNamespace, "vault-namespace", "", "")
 	flagSet.Var(&c.JobGetter.Vars, "var", "")
 	flagSet.Var(&c.JobGetter.VarFiles, "var-file", "")
+	flagSet.StringVar(&c.JobGetter.VarEnvPrefix, "var-env", "", "")
+	flagSet.BoolVar(&c.JobGetter.PreRender, "pre-render", false, "")
 
 	if err := flagSet.Parse(args); err != nil {
 		return 1
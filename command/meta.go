@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -34,6 +35,7 @@ type FlagSetFlags uint
 const (
 	FlagSetNone    FlagSetFlags = 0
 	FlagSetClient  FlagSetFlags = 1 << iota
+	FlagSetOutput  FlagSetFlags = 1 << iota
 	FlagSetDefault              = FlagSetClient
 )
 
@@ -70,6 +72,9 @@ type Meta struct {
 	clientKey     string
 	tlsServerName string
 	insecure      bool
+
+	// output is the format set by the -output flag
+	output outputFormatFlag
 }
 
 // FlagSet returns a FlagSet with the common flags that every
@@ -98,6 +103,12 @@ func (m *Meta) FlagSet(n string, fs FlagSetFlags) *flag.FlagSet {
 
 	}
 
+	// FlagSetOutput is used to enable the -output flag on the commands
+	// that can print their data in a structured format.
+	if fs&FlagSetOutput != 0 {
+		f.Var(&m.output, "output", "")
+	}
+
 	f.SetOutput(&uiErrorWriter{ui: m.Ui})
 
 	return f
@@ -109,7 +120,7 @@ func (m *Meta) AutocompleteFlags(fs FlagSetFlags) complete.Flags {
 		return nil
 	}
 
-	return complete.Flags{
+	flags := complete.Flags{
 		"-address":         complete.PredictAnything,
 		"-region":          complete.PredictAnything,
 		"-namespace":       NamespacePredictor(m.Client, nil),
@@ -124,6 +135,59 @@ func (m *Meta) AutocompleteFlags(fs FlagSetFlags) complete.Flags {
 		"-tls-skip-verify": complete.PredictNothing,
 		"-token":           complete.PredictAnything,
 	}
+	if fs&FlagSetOutput != 0 {
+		flags["-output"] = complete.PredictSet(outputFormats...)
+	}
+	return flags
+}
+
+// StructuredOutput returns whether the command should print its data with
+// FormatOutput rather than as tables, given the values of its -json and -t
+// flags.
+func (m *Meta) StructuredOutput(json bool, tmpl string) bool {
+	return json || tmpl != "" || (m.output != "" && m.output != outputFormatTable)
+}
+
+// FormatOutput formats data according to the -json, -t and -output flags.
+// The -json and -t flags are kept for compatibility and take precedence over
+// -output.
+func (m *Meta) FormatOutput(json bool, tmpl string, data interface{}) (string, error) {
+	if json || tmpl != "" {
+		return Format(json, tmpl, data)
+	}
+
+	f, err := DataFormat(string(m.output), "")
+	if err != nil {
+		return "", err
+	}
+	out, err := f.TransformData(data)
+	if err != nil {
+		return "", fmt.Errorf("Error formatting the data: %w", err)
+	}
+	return out, nil
+}
+
+const (
+	outputFormatTable = "table"
+	outputFormatJSON  = "json"
+	outputFormatYAML  = "yaml"
+)
+
+// outputFormats are the values accepted by the -output flag.
+var outputFormats = []string{outputFormatJSON, outputFormatTable, outputFormatYAML}
+
+// outputFormatFlag is the value of the -output flag, which is validated when
+// the flag is parsed.
+type outputFormatFlag string
+
+func (o *outputFormatFlag) String() string { return string(*o) }
+
+func (o *outputFormatFlag) Set(v string) error {
+	if !slices.Contains(outputFormats, v) {
+		return fmt.Errorf("must be one of %s", strings.Join(outputFormats, ", "))
+	}
+	*o = outputFormatFlag(v)
+	return nil
 }
 
 // askQuestion asks question to user until they provide a valid response.
@@ -391,6 +455,7 @@ type usageOptsFlags uint8
 const (
 	usageOptsDefault     usageOptsFlags = 0
 	usageOptsNoNamespace                = 1 << iota
+	usageOptsOutput
 )
 
 // generalOptionsUsage returns the help string for the global options.
@@ -464,10 +529,21 @@ func generalOptionsUsage(usageOpts usageOptsFlags) string {
     Overrides the NOMAD_TOKEN environment variable if set.
 `
 
+	outputText := `
+  -output=<json|table|yaml>
+    The format to print the data of the command in. The json and yaml formats
+    share the same schema as the -json flag. Defaults to table, the format
+    designed to be read by people.
+`
+
 	if usageOpts&usageOptsNoNamespace == 0 {
 		helpText = helpText + namespaceText
 	}
 
+	if usageOpts&usageOptsOutput != 0 {
+		helpText = helpText + outputText
+	}
+
 	helpText = helpText + remainingText
 	return strings.TrimSpace(helpText)
 }
@@ -48,6 +48,12 @@ func TestMeta_FlagSet(t *testing.T) {
 				"token",
 			},
 		},
+		{
+			FlagSetOutput,
+			[]string{
+				"output",
+			},
+		},
 	}
 
 	for i, tc := range cases {
@@ -68,6 +74,39 @@ func TestMeta_FlagSet(t *testing.T) {
 	}
 }
 
+func TestMeta_FormatOutput(t *testing.T) {
+	ci.Parallel(t)
+
+	data := map[string]string{"name": "foo"}
+
+	m := Meta{Ui: cli.NewMockUi()}
+	fs := m.FlagSet("foo", FlagSetOutput)
+	must.Error(t, fs.Parse([]string{"-output=xml"}))
+
+	// Table output is the default
+	must.NoError(t, fs.Parse([]string{}))
+	must.False(t, m.StructuredOutput(false, ""))
+
+	must.NoError(t, fs.Parse([]string{"-output=table"}))
+	must.False(t, m.StructuredOutput(false, ""))
+	must.True(t, m.StructuredOutput(true, ""))
+
+	must.NoError(t, fs.Parse([]string{"-output=yaml"}))
+	must.True(t, m.StructuredOutput(false, ""))
+	out, err := m.FormatOutput(false, "", data)
+	must.NoError(t, err)
+	must.Eq(t, "name: foo\n", out)
+
+	// The -json and -t flags take precedence over -output
+	out, err = m.FormatOutput(true, "", data)
+	must.NoError(t, err)
+	must.Eq(t, "{\n    \"name\": \"foo\"\n}", out)
+
+	out, err = m.FormatOutput(false, "{{.name}}", data)
+	must.NoError(t, err)
+	must.Eq(t, "foo", out)
+}
+
 func TestMeta_Colorize(t *testing.T) {
 
 	type testCaseSetupFn func(*testing.T, *Meta)
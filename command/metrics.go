@@ -23,7 +23,7 @@ Get Nomad metrics
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 Metrics Specific Options
 
@@ -49,7 +49,7 @@ func (c *OperatorMetricsCommand) Synopsis() string {
 }
 
 func (c *OperatorMetricsCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-pretty": complete.PredictAnything,
 			"-format": complete.PredictAnything,
@@ -64,7 +64,7 @@ func (c *OperatorMetricsCommand) Run(args []string) int {
 	var pretty, json bool
 	var format, tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&pretty, "pretty", false, "")
 	flags.StringVar(&format, "format", "", "")
@@ -103,14 +103,14 @@ func (c *OperatorMetricsCommand) Run(args []string) int {
 		Params: params,
 	}
 
-	if json || len(tmpl) > 0 {
+	if c.StructuredOutput(json, tmpl) {
 		metrics, _, err := client.Operator().MetricsSummary(query)
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error querying metrics: %v", err))
 			return 1
 		}
 
-		out, err := Format(json, tmpl, metrics)
+		out, err := c.FormatOutput(json, tmpl, metrics)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -21,6 +21,20 @@ const (
 	updateWait = time.Second
 )
 
+// The states the monitor can wait for the job's allocations to reach once
+// the evaluation has completed.
+const (
+	// monitorWaitForPlaced waits for the allocations to be placed
+	monitorWaitForPlaced = "placed"
+
+	// monitorWaitForRunning waits for the allocations to be running
+	monitorWaitForRunning = "running"
+
+	// monitorWaitForHealthy waits for the deployment to be successful, or
+	// for the allocations to be running if the job has no deployment
+	monitorWaitForHealthy = "healthy"
+)
+
 // evalState is used to store the current "state of the world"
 // in the context of monitoring an evaluation.
 type evalState struct {
@@ -64,6 +78,11 @@ type monitor struct {
 	// length determines the number of characters for identifiers in the ui.
 	length int
 
+	// waitFor is the state the job's allocations must reach before the
+	// monitor exits, one of the monitorWaitFor constants. If empty, the
+	// monitor waits for the deployment, if any.
+	waitFor string
+
 	sync.Mutex
 }
 
@@ -287,6 +306,26 @@ func (m *monitor) monitor(evalID string) int {
 		break
 	}
 
+	switch m.waitFor {
+	case monitorWaitForPlaced:
+		if schedFailure {
+			return 2
+		}
+		return 0
+	case monitorWaitForRunning:
+		if schedFailure {
+			return 2
+		}
+		return m.monitorAllocsRunning()
+	case monitorWaitForHealthy:
+		if m.state.deployment == "" {
+			if schedFailure {
+				return 2
+			}
+			return m.monitorAllocsRunning()
+		}
+	}
+
 	// Monitor the deployment if it exists
 	dID := m.state.deployment
 	if dID != "" {
@@ -320,6 +359,55 @@ func (m *monitor) monitor(evalID string) int {
 	return 0
 }
 
+// monitorAllocsRunning waits for the allocations placed by the monitored
+// evaluation to be running, or to have completed successfully. The return
+// code will be 1 if any of the allocations fails or is lost.
+func (m *monitor) monitorAllocsRunning() int {
+	pending := make(map[string]struct{})
+	for id, alloc := range m.state.allocs {
+		if alloc.desired == api.AllocDesiredStatusRun {
+			pending[id] = struct{}{}
+		}
+	}
+	if len(pending) == 0 {
+		return 0
+	}
+
+	m.ui.Info(fmt.Sprintf("%s: Waiting for %d allocation(s) to be running",
+		formatTime(time.Now()), len(pending)))
+
+	for {
+		for id := range pending {
+			alloc, _, err := m.client.Allocations().Info(id, nil)
+			if err != nil {
+				m.ui.Error(fmt.Sprintf("%s: Error reading allocation %q: %s",
+					formatTime(time.Now()), limit(id, m.length), err))
+				return 1
+			}
+
+			switch alloc.ClientStatus {
+			case api.AllocClientStatusRunning, api.AllocClientStatusComplete:
+				m.ui.Output(fmt.Sprintf("%s: Allocation %q is %q",
+					formatTime(time.Now()), limit(id, m.length), alloc.ClientStatus))
+				delete(pending, id)
+			case api.AllocClientStatusFailed, api.AllocClientStatusLost:
+				description := ""
+				if alloc.ClientDescription != "" {
+					description = fmt.Sprintf(" (%s)", alloc.ClientDescription)
+				}
+				m.ui.Error(fmt.Sprintf("%s: Allocation %q %s%s",
+					formatTime(time.Now()), limit(id, m.length), alloc.ClientStatus, description))
+				return 1
+			}
+		}
+
+		if len(pending) == 0 {
+			return 0
+		}
+		time.Sleep(updateWait)
+	}
+}
+
 func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix string) string {
 	// Print a helpful message if we have an eligibility problem
 	var out string
@@ -359,6 +447,11 @@ func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix strin
 		out += fmt.Sprintf("%s* Quota limit hit %q\n", prefix, dim)
 	}
 
+	// Print gang info
+	for _, failed := range metrics.GangFailed {
+		out += fmt.Sprintf("%s* Gang placement failed for %s\n", prefix, failed)
+	}
+
 	// Print scores
 	if scores {
 		if len(metrics.ScoreMetaData) > 0 {
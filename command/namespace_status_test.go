@@ -97,10 +97,6 @@ func TestNamespaceStatusCommand_Run_Quota(t *testing.T) {
 	srv, client, url := testServer(t, true, nil)
 	defer srv.Shutdown()
 
-	if !srv.Enterprise {
-		t.Skip("Skipping enterprise-only quota test")
-	}
-
 	ui := cli.NewMockUi()
 	cmd := &NamespaceStatusCommand{Meta: Meta{Ui: ui}}
 
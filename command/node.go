@@ -38,6 +38,12 @@ Usage: nomad node <subcommand> [options] [args]
 
       $ nomad node drain -enable -deadline 4h <node-id>
 
+  Drain the nodes of a node pool every Sunday at 2am for two hours, making them
+  eligible again afterwards:
+
+      $ nomad node maintenance schedule -node-pool <pool> \
+          -recurrence "0 2 * * SUN" -duration 2h
+
   Please see the individual subcommand help for detailed usage information.
 `
 
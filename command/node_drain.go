@@ -54,6 +54,21 @@ Node Drain Options:
     Remaining allocations after the deadline are forced removed from the node.
     If unspecified, a default deadline of one hour is applied.
 
+  -job-type-deadline <type>=<deadline>
+    Set the deadline of the allocations of the given job type in place of
+    -deadline, such as "batch=4h" to give batch jobs longer to complete. The
+    deadline may also be "none" to never force remove the allocations, or
+    "force" to remove them immediately. Can be used multiple times.
+
+  -kill-signal <signal>
+    Signal sent to the tasks of the drained allocations in place of their
+    kill_signal.
+
+  -kill-timeout <duration>
+    Maximum time the tasks of the drained allocations are given to exit after
+    the kill signal, in place of a longer kill_timeout, before they are killed
+    with SIGKILL.
+
   -detach
     Return immediately instead of entering monitor mode.
 
@@ -99,18 +114,21 @@ func (c *NodeDrainCommand) Synopsis() string {
 func (c *NodeDrainCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-disable":         complete.PredictNothing,
-			"-enable":          complete.PredictNothing,
-			"-deadline":        complete.PredictAnything,
-			"-detach":          complete.PredictNothing,
-			"-force":           complete.PredictNothing,
-			"-no-deadline":     complete.PredictNothing,
-			"-ignore-system":   complete.PredictNothing,
-			"-keep-ineligible": complete.PredictNothing,
-			"-m":               complete.PredictNothing,
-			"-meta":            complete.PredictNothing,
-			"-self":            complete.PredictNothing,
-			"-yes":             complete.PredictNothing,
+			"-disable":           complete.PredictNothing,
+			"-enable":            complete.PredictNothing,
+			"-deadline":          complete.PredictAnything,
+			"-job-type-deadline": complete.PredictAnything,
+			"-kill-signal":       complete.PredictAnything,
+			"-kill-timeout":      complete.PredictAnything,
+			"-detach":            complete.PredictNothing,
+			"-force":             complete.PredictNothing,
+			"-no-deadline":       complete.PredictNothing,
+			"-ignore-system":     complete.PredictNothing,
+			"-keep-ineligible":   complete.PredictNothing,
+			"-m":                 complete.PredictNothing,
+			"-meta":              complete.PredictNothing,
+			"-self":              complete.PredictNothing,
+			"-yes":               complete.PredictNothing,
 		})
 }
 
@@ -135,14 +153,17 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	var enable, disable, detach, force,
 		noDeadline, ignoreSystem, keepIneligible,
 		self, autoYes, monitor bool
-	var deadline, message string
-	var metaVars flaghelper.StringFlag
+	var deadline, message, killSignal, killTimeout string
+	var metaVars, jobTypeDeadlineVars flaghelper.StringFlag
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&enable, "enable", false, "Enable drain mode")
 	flags.BoolVar(&disable, "disable", false, "Disable drain mode")
 	flags.StringVar(&deadline, "deadline", "", "Deadline after which allocations are force stopped")
+	flags.Var(&jobTypeDeadlineVars, "job-type-deadline", "Deadline of the allocations of a job type")
+	flags.StringVar(&killSignal, "kill-signal", "", "Signal sent to the tasks of drained allocations")
+	flags.StringVar(&killTimeout, "kill-timeout", "", "Maximum kill timeout of the tasks of drained allocations")
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&force, "force", false, "Force immediate drain")
 	flags.BoolVar(&noDeadline, "no-deadline", false, "Drain node with no deadline")
@@ -181,7 +202,8 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	}
 
 	// Validate a compatible set of flags were set
-	if disable && (deadline != "" || force || noDeadline || ignoreSystem) {
+	if disable && (deadline != "" || force || noDeadline || ignoreSystem ||
+		len(jobTypeDeadlineVars) > 0 || killSignal != "" || killTimeout != "") {
 		c.Ui.Error("-disable can't be combined with flags configuring drain strategy")
 		c.Ui.Error(commandErrorText(c))
 		return 1
@@ -219,6 +241,31 @@ func (c *NodeDrainCommand) Run(args []string) int {
 		d = defaultDrainDuration
 	}
 
+	// Parse the deadlines of job types
+	jobTypeDeadlines, err := parseJobTypeDeadlines(jobTypeDeadlineVars)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	// Parse the kill policy
+	var killPolicy *api.DrainKillPolicy
+	if killSignal != "" || killTimeout != "" {
+		killPolicy = &api.DrainKillPolicy{Signal: killSignal}
+		if killTimeout != "" {
+			dur, err := time.ParseDuration(killTimeout)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Failed to parse kill timeout %q: %v", killTimeout, err))
+				return 1
+			}
+			if dur <= 0 {
+				c.Ui.Error("A positive kill timeout must be given")
+				return 1
+			}
+			killPolicy.Timeout = dur
+		}
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -311,6 +358,8 @@ func (c *NodeDrainCommand) Run(args []string) int {
 		spec = &api.DrainSpec{
 			Deadline:         d,
 			IgnoreSystemJobs: ignoreSystem,
+			JobTypeDeadlines: jobTypeDeadlines,
+			KillPolicy:       killPolicy,
 		}
 	}
 
@@ -367,6 +416,44 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	return 0
 }
 
+// parseJobTypeDeadlines parses the values of the -job-type-deadline flag into
+// the deadlines of the drain spec.
+func parseJobTypeDeadlines(values []string) (map[string]time.Duration, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	deadlines := make(map[string]time.Duration, len(values))
+	for _, value := range values {
+		jobType, deadline, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("Invalid job type deadline %q: expected <type>=<deadline>", value)
+		}
+		switch jobType {
+		case api.JobTypeService, api.JobTypeBatch, api.JobTypeSystem, api.JobTypeSysbatch:
+		default:
+			return nil, fmt.Errorf("Invalid job type %q for deadline", jobType)
+		}
+
+		switch deadline {
+		case "none":
+			deadlines[jobType] = 0
+		case "force":
+			deadlines[jobType] = -1 * time.Second
+		default:
+			dur, err := time.ParseDuration(deadline)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to parse deadline %q of job type %q: %v", deadline, jobType, err)
+			}
+			if dur <= 0 {
+				return nil, fmt.Errorf("A positive drain duration must be given for job type %q", jobType)
+			}
+			deadlines[jobType] = dur
+		}
+	}
+	return deadlines, nil
+}
+
 func (c *NodeDrainCommand) monitorDrain(client *api.Client, ctx context.Context, node *api.Node, index uint64, ignoreSystem bool) {
 	outCh := client.Nodes().MonitorDrain(ctx, node.ID, index, ignoreSystem)
 	for msg := range outCh {
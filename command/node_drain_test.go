@@ -372,7 +372,7 @@ func TestNodeDrainCommand_Fails(t *testing.T) {
 	ui.ErrorWriter.Reset()
 
 	// Fail on disable being used with drain strategy flags
-	for _, flag := range []string{"-force", "-no-deadline", "-ignore-system"} {
+	for _, flag := range []string{"-force", "-no-deadline", "-ignore-system", "-job-type-deadline=batch=1h", "-kill-signal=SIGINT"} {
 		if code := cmd.Run([]string{"-address=" + url, "-disable", flag, "12345678-abcd-efab-cdef-123456789abc"}); code != 1 {
 			t.Fatalf("expected exit 1, got: %d", code)
 		}
@@ -412,6 +412,39 @@ func TestNodeDrainCommand_Fails(t *testing.T) {
 		}
 		ui.ErrorWriter.Reset()
 	}
+
+	// Fail on setting a bad job type deadline or kill timeout
+	for flag, expected := range map[string]string{
+		"-job-type-deadline=batch":      "expected <type>=<deadline>",
+		"-job-type-deadline=core=1h":    `Invalid job type "core"`,
+		"-job-type-deadline=batch=0s":   "positive",
+		"-job-type-deadline=batch=soon": "Failed to parse deadline",
+		"-kill-timeout=-1s":             "positive",
+	} {
+		if code := cmd.Run([]string{"-address=" + url, "-enable", flag, "12345678-abcd-efab-cdef-123456789abc"}); code != 1 {
+			t.Fatalf("expected exit 1, got: %d", code)
+		}
+		if out := ui.ErrorWriter.String(); !strings.Contains(out, expected) {
+			t.Fatalf("got: %s", out)
+		}
+		ui.ErrorWriter.Reset()
+	}
+}
+
+func TestNodeDrainCommand_parseJobTypeDeadlines(t *testing.T) {
+	ci.Parallel(t)
+
+	deadlines, err := parseJobTypeDeadlines(nil)
+	must.NoError(t, err)
+	must.Nil(t, deadlines)
+
+	deadlines, err = parseJobTypeDeadlines([]string{"batch=4h", "system=none", "service=force"})
+	must.NoError(t, err)
+	must.Eq(t, map[string]time.Duration{
+		"batch":   4 * time.Hour,
+		"system":  0,
+		"service": -1 * time.Second,
+	}, deadlines)
 }
 
 func TestNodeDrainCommand_AutocompleteArgs(t *testing.T) {
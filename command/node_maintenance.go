@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+)
+
+type NodeMaintenanceCommand struct {
+	Meta
+}
+
+func (c *NodeMaintenanceCommand) Name() string {
+	return "node maintenance"
+}
+
+func (c *NodeMaintenanceCommand) Synopsis() string {
+	return "Interact with node maintenance windows"
+}
+
+func (c *NodeMaintenanceCommand) Help() string {
+	helpText := `
+Usage: nomad node maintenance <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with node maintenance
+  windows. The nodes selected by a maintenance window are drained when the
+  window starts, held ineligible for scheduling for the duration of the
+  window, and made eligible again when the window ends. Windows may recur on
+  a calendar schedule.
+
+  Schedule a maintenance window:
+
+    $ nomad node maintenance schedule -node-pool batch \
+        -recurrence "0 2 * * SUN" -duration 2h
+
+  List all maintenance windows:
+
+    $ nomad node maintenance list
+
+  Fetch information on an existing maintenance window:
+
+    $ nomad node maintenance status <id>
+
+  Delete a maintenance window:
+
+    $ nomad node maintenance delete <id>
+
+  Please refer to individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMaintenanceCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func formatNodeMaintenanceWindowList(windows []*api.NodeMaintenanceWindow) string {
+	out := make([]string, len(windows)+1)
+	out[0] = "ID|Name|Status|Next Start|Duration|Recurrence|Nodes"
+	for i, w := range windows {
+		nextStart := ""
+		if w.Status != api.NodeMaintenanceWindowStatusComplete {
+			nextStart = formatTime(w.NextStart)
+		}
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s",
+			limit(w.ID, shortId),
+			w.Name,
+			w.Status,
+			nextStart,
+			w.Duration,
+			w.Recurrence,
+			formatNodeMaintenanceSelector(w),
+		)
+	}
+	return formatList(out)
+}
+
+// formatNodeMaintenanceSelector returns a description of the nodes selected
+// by the maintenance window.
+func formatNodeMaintenanceSelector(w *api.NodeMaintenanceWindow) string {
+	var selectors []string
+	if len(w.NodeIDs) != 0 {
+		ids := make([]string, len(w.NodeIDs))
+		for i, id := range w.NodeIDs {
+			ids[i] = limit(id, shortId)
+		}
+		selectors = append(selectors, "ids="+strings.Join(ids, ","))
+	}
+	if w.NodePool != "" {
+		selectors = append(selectors, "pool="+w.NodePool)
+	}
+	if w.Datacenter != "" {
+		selectors = append(selectors, "dc="+w.Datacenter)
+	}
+	return strings.Join(selectors, " ")
+}
+
+// parseNodeMaintenanceStart parses the start time of a maintenance window,
+// either "now", an RFC 3339 time, or a date and time without offset in the
+// given location.
+func parseNodeMaintenanceStart(value string, location *time.Location, now time.Time) (time.Time, error) {
+	if value == "now" {
+		return now, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04", value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"Invalid start time %q: must be \"now\", an RFC 3339 time or YYYY-MM-DDTHH:MM", value)
+	}
+	return t, nil
+}
+
+// nodeMaintenanceWindowByPrefix returns the maintenance window whose ID
+// matches the prefix, or the windows matching the prefix if there are more
+// than one.
+func nodeMaintenanceWindowByPrefix(client *api.Client, prefix string) (*api.NodeMaintenanceWindow, []*api.NodeMaintenanceWindow, error) {
+	windows, _, err := client.NodeMaintenance().PrefixList(sanitizeUUIDPrefix(prefix), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch len(windows) {
+	case 0:
+		return nil, nil, fmt.Errorf("No maintenance window with prefix %q found", prefix)
+	case 1:
+		return windows[0], nil, nil
+	default:
+		for _, window := range windows {
+			if window.ID == prefix {
+				return window, nil, nil
+			}
+		}
+		return nil, windows, nil
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type NodeMaintenanceDeleteCommand struct {
+	Meta
+}
+
+func (c *NodeMaintenanceDeleteCommand) Name() string {
+	return "node maintenance delete"
+}
+
+func (c *NodeMaintenanceDeleteCommand) Synopsis() string {
+	return "Delete a node maintenance window"
+}
+
+func (c *NodeMaintenanceDeleteCommand) Help() string {
+	helpText := `
+Usage: nomad node maintenance delete [options] <id>
+
+  Delete is used to remove a node maintenance window. If the window is active,
+  the drains it started which are still in progress are cancelled and its
+  nodes are made eligible again before it is deleted.
+
+  If ACLs are enabled, this command requires a token with the 'node:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMaintenanceDeleteCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *NodeMaintenanceDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *NodeMaintenanceDeleteCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we only have one argument.
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	window, possible, err := nodeMaintenanceWindowByPrefix(client, args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving maintenance window: %s", err))
+		return 1
+	}
+	if len(possible) != 0 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple maintenance windows\n\n%s",
+			formatNodeMaintenanceWindowList(possible)))
+		return 1
+	}
+
+	if _, err := client.NodeMaintenance().Delete(window.ID, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting maintenance window: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted maintenance window %q!", window.ID))
+	return 0
+}
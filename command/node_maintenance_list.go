@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type NodeMaintenanceListCommand struct {
+	Meta
+}
+
+func (c *NodeMaintenanceListCommand) Name() string {
+	return "node maintenance list"
+}
+
+func (c *NodeMaintenanceListCommand) Synopsis() string {
+	return "List node maintenance windows"
+}
+
+func (c *NodeMaintenanceListCommand) Help() string {
+	helpText := `
+Usage: nomad node maintenance list [options]
+
+  List is used to list the node maintenance windows.
+
+  If ACLs are enabled, this command requires a token with the 'node:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsOutput|usageOptsNoNamespace) + `
+
+List Options:
+
+  -json
+    Output the maintenance windows in JSON format.
+
+  -t
+    Format and display the maintenance windows using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMaintenanceListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *NodeMaintenanceListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *NodeMaintenanceListCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we don't have any arguments.
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	windows, _, err := client.NodeMaintenance().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying maintenance windows: %s", err))
+		return 1
+	}
+
+	// Format output if requested.
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, windows)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting output: %s", err))
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(windows) == 0 {
+		c.Ui.Output("No maintenance windows")
+		return 0
+	}
+	c.Ui.Output(formatNodeMaintenanceWindowList(windows))
+	return 0
+}
@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/posener/complete"
+)
+
+type NodeMaintenanceScheduleCommand struct {
+	Meta
+}
+
+func (c *NodeMaintenanceScheduleCommand) Name() string {
+	return "node maintenance schedule"
+}
+
+func (c *NodeMaintenanceScheduleCommand) Synopsis() string {
+	return "Schedule a node maintenance window"
+}
+
+func (c *NodeMaintenanceScheduleCommand) Help() string {
+	helpText := `
+Usage: nomad node maintenance schedule [options]
+
+  Schedule a maintenance window during which the selected nodes are drained
+  and held ineligible for scheduling. When the window starts the nodes are
+  drained, and when it ends any drain still in progress is cancelled and the
+  nodes are made eligible again. Nodes which are already draining or
+  ineligible when the window starts are left alone.
+
+  The nodes are selected by ID, node pool or datacenter. At least one of the
+  selectors must be given, and the nodes must match all of those given.
+
+  If ACLs are enabled, this command requires a token with the 'node:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Schedule Options:
+
+  -name <name>
+    Name of the maintenance window.
+
+  -description <description>
+    Description of the maintenance window.
+
+  -node <node-id>
+    ID or ID prefix of a node under maintenance. Can be used multiple times.
+
+  -node-pool <node-pool>
+    Node pool of the nodes under maintenance.
+
+  -datacenter <datacenter>
+    Datacenter of the nodes under maintenance.
+
+  -start <time>
+    Start time of the window, either "now", an RFC 3339 time such as
+    "2026-11-01T02:00:00Z", or a date and time such as "2026-11-01T02:00" in
+    the -time-zone. For recurring windows, the time before which the windows
+    don't start. Required unless -recurrence is set.
+
+  -recurrence <cron>
+    Cron expression of the starts of recurring windows, such as "0 2 * * SUN"
+    for every Sunday at 2am. The window only happens once if unset.
+
+  -time-zone <time-zone>
+    Time zone the recurrence and the -start date and time are evaluated in.
+    Defaults to UTC.
+
+  -duration <duration>
+    How long each window lasts. Required.
+
+  -deadline <duration>
+    Deadline by which all allocations must be moved off the nodes when a
+    window starts. Defaults to one hour.
+
+  -no-deadline
+    Drain the allocations off the nodes without a deadline.
+
+  -job-type-deadline <type>=<deadline>
+    Deadline of the allocations of the given job type in place of -deadline,
+    such as "batch=4h". Can be used multiple times.
+
+  -ignore-system
+    Leave the system job allocations on the nodes.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMaintenanceScheduleCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-name":              complete.PredictAnything,
+			"-description":       complete.PredictAnything,
+			"-node":              complete.PredictAnything,
+			"-node-pool":         nodePoolPredictor(c.Client, nil),
+			"-datacenter":        complete.PredictAnything,
+			"-start":             complete.PredictAnything,
+			"-recurrence":        complete.PredictAnything,
+			"-time-zone":         complete.PredictAnything,
+			"-duration":          complete.PredictAnything,
+			"-deadline":          complete.PredictAnything,
+			"-no-deadline":       complete.PredictNothing,
+			"-job-type-deadline": complete.PredictAnything,
+			"-ignore-system":     complete.PredictNothing,
+		})
+}
+
+func (c *NodeMaintenanceScheduleCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *NodeMaintenanceScheduleCommand) Run(args []string) int {
+	var noDeadline, ignoreSystem bool
+	var name, description, nodePool, datacenter, start, recurrence,
+		timeZone, duration, deadline string
+	var nodeIDs, jobTypeDeadlineVars flaghelper.StringFlag
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&name, "name", "", "")
+	flags.StringVar(&description, "description", "", "")
+	flags.Var(&nodeIDs, "node", "")
+	flags.StringVar(&nodePool, "node-pool", "", "")
+	flags.StringVar(&datacenter, "datacenter", "", "")
+	flags.StringVar(&start, "start", "", "")
+	flags.StringVar(&recurrence, "recurrence", "", "")
+	flags.StringVar(&timeZone, "time-zone", "", "")
+	flags.StringVar(&duration, "duration", "", "")
+	flags.StringVar(&deadline, "deadline", "", "")
+	flags.BoolVar(&noDeadline, "no-deadline", false, "")
+	flags.Var(&jobTypeDeadlineVars, "job-type-deadline", "")
+	flags.BoolVar(&ignoreSystem, "ignore-system", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we don't have any arguments.
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if len(nodeIDs) == 0 && nodePool == "" && datacenter == "" {
+		c.Ui.Error("At least one of -node, -node-pool or -datacenter must be set")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if start == "" && recurrence == "" {
+		c.Ui.Error("Either -start or -recurrence must be set")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if duration == "" {
+		c.Ui.Error("The -duration flag must be set")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if deadline != "" && noDeadline {
+		c.Ui.Error("-deadline can't be combined with -no-deadline")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	window := &api.NodeMaintenanceWindow{
+		Name:        name,
+		Description: description,
+		NodePool:    nodePool,
+		Datacenter:  datacenter,
+		Recurrence:  recurrence,
+		TimeZone:    timeZone,
+	}
+
+	var err error
+	if window.Duration, err = time.ParseDuration(duration); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse duration %q: %v", duration, err))
+		return 1
+	}
+
+	if start != "" {
+		location := time.UTC
+		if timeZone != "" {
+			if location, err = time.LoadLocation(timeZone); err != nil {
+				c.Ui.Error(fmt.Sprintf("Invalid time zone %q: %v", timeZone, err))
+				return 1
+			}
+		}
+		if window.Start, err = parseNodeMaintenanceStart(start, location, time.Now()); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	// Parse the drain specification
+	spec := &api.DrainSpec{
+		Deadline:         defaultDrainDuration,
+		IgnoreSystemJobs: ignoreSystem,
+	}
+	if noDeadline {
+		spec.Deadline = 0
+	} else if deadline != "" {
+		dur, err := time.ParseDuration(deadline)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to parse deadline %q: %v", deadline, err))
+			return 1
+		}
+		if dur <= 0 {
+			c.Ui.Error("A positive drain duration must be given")
+			return 1
+		}
+		spec.Deadline = dur
+	}
+	if spec.JobTypeDeadlines, err = parseJobTypeDeadlines(jobTypeDeadlineVars); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	window.DrainSpec = spec
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Resolve the node ID prefixes
+	for _, prefix := range nodeIDs {
+		if len(prefix) == 1 {
+			c.Ui.Error("Identifier must contain at least two characters.")
+			return 1
+		}
+		prefix = sanitizeUUIDPrefix(prefix)
+		nodes, _, err := client.Nodes().PrefixList(prefix)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error querying node: %s", err))
+			return 1
+		}
+		if len(nodes) == 0 {
+			c.Ui.Error(fmt.Sprintf("No node(s) with prefix or id %q found", prefix))
+			return 1
+		}
+		if len(nodes) > 1 {
+			c.Ui.Error(fmt.Sprintf("Prefix %q matched multiple nodes\n\n%s",
+				prefix, formatNodeStubList(nodes, true)))
+			return 1
+		}
+		window.NodeIDs = append(window.NodeIDs, nodes[0].ID)
+	}
+
+	window, _, err = client.NodeMaintenance().Register(window, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error scheduling maintenance window: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully scheduled maintenance window %q, starting %s",
+		window.ID, formatTime(window.NextStart)))
+	return 0
+}
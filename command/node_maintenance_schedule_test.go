@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeMaintenanceScheduleCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &NodeMaintenanceScheduleCommand{}
+}
+
+func TestNodeMaintenanceScheduleCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "arguments",
+			args:        []string{"-node-pool", "batch", "-start", "now", "-duration", "1h", "extra"},
+			expectedErr: "This command takes no arguments",
+		},
+		{
+			name:        "no selector",
+			args:        []string{"-start", "now", "-duration", "1h"},
+			expectedErr: "At least one of -node, -node-pool or -datacenter must be set",
+		},
+		{
+			name:        "no start",
+			args:        []string{"-node-pool", "batch", "-duration", "1h"},
+			expectedErr: "Either -start or -recurrence must be set",
+		},
+		{
+			name:        "no duration",
+			args:        []string{"-node-pool", "batch", "-start", "now"},
+			expectedErr: "The -duration flag must be set",
+		},
+		{
+			name:        "invalid duration",
+			args:        []string{"-node-pool", "batch", "-start", "now", "-duration", "1 hour"},
+			expectedErr: "Failed to parse duration",
+		},
+		{
+			name:        "invalid start",
+			args:        []string{"-node-pool", "batch", "-start", "tomorrow", "-duration", "1h"},
+			expectedErr: "Invalid start time",
+		},
+		{
+			name:        "invalid time zone",
+			args:        []string{"-node-pool", "batch", "-start", "now", "-time-zone", "Mars/Olympus", "-duration", "1h"},
+			expectedErr: "Invalid time zone",
+		},
+		{
+			name:        "deadline and no deadline",
+			args:        []string{"-node-pool", "batch", "-start", "now", "-duration", "1h", "-deadline", "1h", "-no-deadline"},
+			expectedErr: "-deadline can't be combined with -no-deadline",
+		},
+		{
+			name:        "invalid job type deadline",
+			args:        []string{"-node-pool", "batch", "-start", "now", "-duration", "1h", "-job-type-deadline", "cron=1h"},
+			expectedErr: "Invalid job type",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &NodeMaintenanceScheduleCommand{Meta: Meta{Ui: ui}}
+			must.One(t, cmd.Run(tc.args))
+			must.StrContains(t, ui.ErrorWriter.String(), tc.expectedErr)
+		})
+	}
+}
+
+func TestNodeMaintenance_parseNodeMaintenanceStart(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+	paris, err := time.LoadLocation("Europe/Paris")
+	must.NoError(t, err)
+
+	start, err := parseNodeMaintenanceStart("now", paris, now)
+	must.NoError(t, err)
+	must.Eq(t, now, start)
+
+	start, err = parseNodeMaintenanceStart("2026-11-01T02:00:00Z", paris, now)
+	must.NoError(t, err)
+	must.True(t, time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC).Equal(start))
+
+	start, err = parseNodeMaintenanceStart("2026-11-01T02:00", paris, now)
+	must.NoError(t, err)
+	must.True(t, time.Date(2026, 11, 1, 1, 0, 0, 0, time.UTC).Equal(start))
+
+	_, err = parseNodeMaintenanceStart("next sunday", paris, now)
+	must.ErrorContains(t, err, "Invalid start time")
+}
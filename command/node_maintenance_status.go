@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type NodeMaintenanceStatusCommand struct {
+	Meta
+}
+
+func (c *NodeMaintenanceStatusCommand) Name() string {
+	return "node maintenance status"
+}
+
+func (c *NodeMaintenanceStatusCommand) Synopsis() string {
+	return "Display the status of a node maintenance window"
+}
+
+func (c *NodeMaintenanceStatusCommand) Help() string {
+	helpText := `
+Usage: nomad node maintenance status [options] <id>
+
+  Status is used to display the schedule and status of a node maintenance
+  window, and the nodes it holds while it is active.
+
+  If ACLs are enabled, this command requires a token with the 'node:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsOutput|usageOptsNoNamespace) + `
+
+Status Options:
+
+  -json
+    Output the maintenance window in its JSON format.
+
+  -t
+    Format and display the maintenance window using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMaintenanceStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *NodeMaintenanceStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *NodeMaintenanceStatusCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we only have one argument.
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	window, possible, err := nodeMaintenanceWindowByPrefix(client, args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving maintenance window: %s", err))
+		return 1
+	}
+	if len(possible) != 0 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple maintenance windows\n\n%s",
+			formatNodeMaintenanceWindowList(possible)))
+		return 1
+	}
+
+	// Format output if requested.
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, window)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	basic := []string{
+		fmt.Sprintf("ID|%s", window.ID),
+		fmt.Sprintf("Name|%s", window.Name),
+		fmt.Sprintf("Description|%s", window.Description),
+		fmt.Sprintf("Status|%s", window.Status),
+		fmt.Sprintf("Nodes|%s", formatNodeMaintenanceSelector(window)),
+		fmt.Sprintf("Start|%s", formatTime(window.Start)),
+		fmt.Sprintf("Recurrence|%s", window.Recurrence),
+		fmt.Sprintf("Time Zone|%s", window.TimeZone),
+		fmt.Sprintf("Duration|%s", window.Duration),
+	}
+	if window.Status != api.NodeMaintenanceWindowStatusComplete {
+		basic = append(basic,
+			fmt.Sprintf("Next Start|%s", formatTime(window.NextStart)),
+			fmt.Sprintf("Next End|%s", formatTime(window.NextStart.Add(window.Duration))),
+		)
+	}
+	if spec := window.DrainSpec; spec != nil {
+		basic = append(basic,
+			fmt.Sprintf("Drain Deadline|%s", spec.Deadline),
+			fmt.Sprintf("Drain Ignore System Jobs|%v", spec.IgnoreSystemJobs),
+		)
+	}
+	c.Ui.Output(formatKV(basic))
+
+	if window.Status == api.NodeMaintenanceWindowStatusActive {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Held Nodes[reset]"))
+		if len(window.ActiveNodeIDs) == 0 {
+			c.Ui.Output("No nodes")
+		} else {
+			c.Ui.Output(strings.Join(window.ActiveNodeIDs, "\n"))
+		}
+	}
+	return 0
+}
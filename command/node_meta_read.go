@@ -30,7 +30,7 @@ Usage: nomad node meta read [-json] [-node-id ...]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 Node Meta Options:
 
@@ -60,7 +60,7 @@ func (c *NodeMetaReadCommand) Run(args []string) int {
 	var nodeID, tmpl string
 	var json bool
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&nodeID, "node-id", "", "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -92,8 +92,8 @@ func (c *NodeMetaReadCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, meta)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, meta)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -132,7 +132,7 @@ func (c *NodeMetaReadCommand) Run(args []string) int {
 }
 
 func (c *NodeMetaReadCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-node-id": complete.PredictAnything,
 			"-json":    complete.PredictNothing,
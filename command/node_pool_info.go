@@ -34,7 +34,7 @@ Usage: nomad node pool info <node-pool>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Info Options:
 
@@ -49,7 +49,7 @@ Info Options:
 }
 
 func (c *NodePoolInfoCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json": complete.PredictNothing,
 			"-t":    complete.PredictAnything,
@@ -64,7 +64,7 @@ func (c *NodePoolInfoCommand) Run(args []string) int {
 	var json bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -99,8 +99,8 @@ func (c *NodePoolInfoCommand) Run(args []string) int {
 	}
 
 	// Format output if requested.
-	if json || tmpl != "" {
-		out, err := Format(json, tmpl, pool)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, pool)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -144,5 +144,16 @@ func (c *NodePoolInfoCommand) Run(args []string) int {
 		c.Ui.Output("No scheduler configuration")
 	}
 
+	c.Ui.Output(c.Colorize().Color("\n[bold]Overflow[reset]"))
+	if len(pool.Overflow) > 0 {
+		overflow := []string{"Pool|Priority"}
+		for _, o := range pool.Overflow {
+			overflow = append(overflow, fmt.Sprintf("%s|%d", o.Pool, o.Priority))
+		}
+		c.Ui.Output(formatList(overflow))
+	} else {
+		c.Ui.Output("No overflow node pools")
+	}
+
 	return 0
 }
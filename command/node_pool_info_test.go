@@ -56,6 +56,7 @@ No scheduler configuration`
         "env": "test"
     },
     "Name": "dev-1",
+    "Overflow": null,
     "SchedulerConfiguration": null
 }`
 
@@ -37,7 +37,7 @@ Usage: nomad node pool jobs <node-pool>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Node Pool Jobs Options:
 
@@ -63,7 +63,7 @@ Node Pool Jobs Options:
 }
 
 func (c *NodePoolJobsCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-filter":     complete.PredictAnything,
 			"-json":       complete.PredictNothing,
@@ -82,7 +82,7 @@ func (c *NodePoolJobsCommand) Run(args []string) int {
 	var perPage int
 	var pageToken, filter, tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&filter, "filter", "", "")
@@ -138,8 +138,8 @@ func (c *NodePoolJobsCommand) Run(args []string) int {
 	}
 
 	// Format output if requested.
-	if json || tmpl != "" {
-		out, err := Format(json, tmpl, jobs)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, jobs)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -36,7 +36,7 @@ Usage: nomad node pool list [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 List Options:
 
@@ -60,7 +60,7 @@ List Options:
 }
 
 func (c *NodePoolListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-filter":     complete.PredictAnything,
 			"-json":       complete.PredictNothing,
@@ -79,7 +79,7 @@ func (c *NodePoolListCommand) Run(args []string) int {
 	var perPage int
 	var tmpl, pageToken, filter string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&filter, "filter", "", "")
 	flags.BoolVar(&json, "json", false, "")
@@ -117,8 +117,8 @@ func (c *NodePoolListCommand) Run(args []string) int {
 	}
 
 	// Format output if requested.
-	if json || tmpl != "" {
-		out, err := Format(json, tmpl, pools)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, pools)
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error formatting output: %s", err))
 			return 1
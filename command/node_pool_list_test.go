@@ -106,6 +106,7 @@ prod-1  <none>`,
         "Description": "",
         "Meta": null,
         "Name": "prod-1",
+        "Overflow": null,
         "SchedulerConfiguration": null
     }
 ]`,
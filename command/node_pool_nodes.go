@@ -36,7 +36,7 @@ Usage: nomad node pool nodes <node-pool>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 Node Pool Nodes Options:
 
@@ -64,7 +64,7 @@ Node Pool Nodes Options:
 }
 
 func (c *NodePoolNodesCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-filter":     complete.PredictAnything,
 			"-json":       complete.PredictNothing,
@@ -84,7 +84,7 @@ func (c *NodePoolNodesCommand) Run(args []string) int {
 	var perPage int
 	var pageToken, filter, tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&filter, "filter", "", "")
@@ -140,8 +140,8 @@ func (c *NodePoolNodesCommand) Run(args []string) int {
 	}
 
 	// Format output if requested.
-	if json || tmpl != "" {
-		out, err := Format(json, tmpl, nodes)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, nodes)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
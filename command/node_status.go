@@ -64,7 +64,7 @@ Usage: nomad node status [options] <node>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 Node Status Options:
 
@@ -116,7 +116,7 @@ func (c *NodeStatusCommand) Synopsis() string {
 }
 
 func (c *NodeStatusCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-allocs":     complete.PredictNothing,
 			"-filter":     complete.PredictAnything,
@@ -157,7 +157,7 @@ func (c *NodeStatusCommand) Name() string { return "node status" }
 
 func (c *NodeStatusCommand) Run(args []string) int {
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&c.short, "short", false, "")
 	flags.BoolVar(&c.os, "os", false, "")
@@ -227,8 +227,8 @@ func (c *NodeStatusCommand) Run(args []string) int {
 		}
 
 		// If output format is specified, format and output the node data list
-		if c.json || len(c.tmpl) > 0 {
-			out, err := Format(c.json, c.tmpl, nodes)
+		if c.StructuredOutput(c.json, c.tmpl) {
+			out, err := c.FormatOutput(c.json, c.tmpl, nodes)
 			if err != nil {
 				c.Ui.Error(err.Error())
 				return 1
@@ -371,8 +371,8 @@ Results have been paginated. To get the next page run:
 	}
 
 	// If output format is specified, format and output the data
-	if c.json || len(c.tmpl) > 0 {
-		out, err := Format(c.json, c.tmpl, node)
+	if c.StructuredOutput(c.json, c.tmpl) {
+		out, err := c.FormatOutput(c.json, c.tmpl, node)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -474,6 +474,21 @@ func formatDrain(n *api.Node) string {
 			fmt.Fprintf(b, "; %s deadline", formatTime(n.DrainStrategy.ForceDeadline))
 		}
 
+		jobTypes := make([]string, 0, len(n.DrainStrategy.JobTypeDeadlines))
+		for jobType := range n.DrainStrategy.JobTypeDeadlines {
+			jobTypes = append(jobTypes, jobType)
+		}
+		sort.Strings(jobTypes)
+		for _, jobType := range jobTypes {
+			if n.DrainStrategy.JobTypeDeadlines[jobType].Nanoseconds() < 0 {
+				fmt.Fprintf(b, "; %s force drain", jobType)
+			} else if deadline, ok := n.DrainStrategy.JobTypeForceDeadlines[jobType]; !ok {
+				fmt.Fprintf(b, "; %s no deadline", jobType)
+			} else {
+				fmt.Fprintf(b, "; %s %s deadline", jobType, formatTime(deadline))
+			}
+		}
+
 		if n.DrainStrategy.IgnoreSystemJobs {
 			b.WriteString("; ignoring system jobs")
 		}
@@ -28,7 +28,8 @@ Usage: nomad operator root keyring [options]
   identities. This command may be used to examine active encryption keys
   in the cluster, rotate keys, add new keys from backups, or remove unused keys.
 
-  If ACLs are enabled, all subcommands requires a management token.
+  If ACLs are enabled, all subcommands except status require a management
+  token.
 
   Rotate the encryption key:
 
@@ -42,6 +43,10 @@ Usage: nomad operator root keyring [options]
 
       $ nomad operator root keyring remove <key ID>
 
+  Show the decryption progress of the keyring of a server:
+
+      $ nomad operator root keyring status
+
   Please see individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
@@ -75,15 +80,16 @@ func renderVariablesKeysResponse(keys []*api.RootKeyMeta, verbose bool) string {
 		length = 8
 	}
 	out := make([]string, len(keys)+1)
-	out[0] = "Key|State|Create Time|Publish Time"
+	out[0] = "Key|State|Create Time|Publish Time|Providers"
 	i := 1
 	for _, k := range keys {
 		publishTime := ""
 		if k.PublishTime > 0 {
 			publishTime = formatUnixNanoTime(k.PublishTime)
 		}
-		out[i] = fmt.Sprintf("%s|%v|%s|%s",
-			k.KeyID[:length], k.State, formatUnixNanoTime(k.CreateTime), publishTime)
+		out[i] = fmt.Sprintf("%s|%v|%s|%s|%s",
+			k.KeyID[:length], k.State, formatUnixNanoTime(k.CreateTime), publishTime,
+			strings.Join(k.Providers, ","))
 		i = i + 1
 	}
 	return formatList(out)
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// OperatorRootKeyringStatusCommand is a Command implementation that shows the
+// progress of decrypting the keyring of a server.
+type OperatorRootKeyringStatusCommand struct {
+	Meta
+}
+
+func (c *OperatorRootKeyringStatusCommand) Help() string {
+	helpText := `
+Usage: nomad operator root keyring status [options]
+
+  Show the progress of decrypting the keyring of the server that serves the
+  request. A server decrypts the keys after it starts or restores a snapshot,
+  and is not ready until all the keys have been decrypted. The status lists the
+  keys still being decrypted, including the error of the last failed attempt.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Keyring Options:
+
+  -verbose
+    Show full information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorRootKeyringStatusCommand) Synopsis() string {
+	return "Shows the decryption progress of the keyring of a server"
+}
+
+func (c *OperatorRootKeyringStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *OperatorRootKeyringStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorRootKeyringStatusCommand) Name() string {
+	return "root keyring status"
+}
+
+func (c *OperatorRootKeyringStatusCommand) Run(args []string) int {
+	var verbose bool
+
+	flags := c.Meta.FlagSet("root keyring status", FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 0 {
+		c.Ui.Error("This command requires no arguments.")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	status, _, err := client.Keyring().Status(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+	c.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Server|%s", status.ServerName),
+		fmt.Sprintf("Ready|%v", status.Ready),
+		fmt.Sprintf("Decrypted Keys|%d", status.NumKeys),
+	}))
+	if len(status.Pending) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Pending Keys[reset]"))
+		c.Ui.Output(renderKeyringDecryptStatus(status.Pending, verbose))
+	}
+	return 0
+}
+
+// renderKeyringDecryptStatus is a helper for formatting the status of the keys
+// a server is decrypting.
+func renderKeyringDecryptStatus(keys []*api.KeyringDecryptStatus, verbose bool) string {
+	length := fullId
+	if !verbose {
+		length = 8
+	}
+	out := make([]string, len(keys)+1)
+	out[0] = "Key|State|Providers|Queue Time|Start Time|Attempts|Last Error"
+	for i, k := range keys {
+		startTime := ""
+		if k.StartTime > 0 {
+			startTime = formatUnixNanoTime(k.StartTime)
+		}
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%d|%s",
+			k.KeyID[:length], k.State, strings.Join(k.Providers, ","),
+			formatUnixNanoTime(k.QueueTime), startTime, k.Attempts, k.LastError)
+	}
+	return formatList(out)
+}
@@ -31,6 +31,7 @@ type OperatorSchedulerSetConfig struct {
 	preemptServiceScheduler  flagHelper.BoolValue
 	preemptSysBatchScheduler flagHelper.BoolValue
 	preemptSystemScheduler   flagHelper.BoolValue
+	preemptMinPriorityDelta  int
 }
 
 func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
@@ -48,6 +49,7 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
 			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
 			"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+			"-preempt-min-priority-delta": complete.PredictAnything,
 		},
 	)
 }
@@ -72,6 +74,7 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
 	flags.Var(&o.preemptSystemScheduler, "preempt-system-scheduler", "")
+	flags.IntVar(&o.preemptMinPriorityDelta, "preempt-min-priority-delta", -1, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -134,6 +137,9 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	o.preemptServiceScheduler.Merge(&schedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
 	o.preemptSysBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.SysBatchSchedulerEnabled)
 	o.preemptSystemScheduler.Merge(&schedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
+	if o.preemptMinPriorityDelta != -1 {
+		schedulerConfig.PreemptionConfig.MinPriorityDelta = o.preemptMinPriorityDelta
+	}
 
 	// Check-and-set the new configuration.
 	result, _, err := client.Operator().SchedulerCASConfiguration(schedulerConfig, nil)
@@ -208,6 +214,11 @@ Scheduler Set Config Options:
   -preempt-system-scheduler=[true|false]
     Specifies whether preemption for system jobs is enabled. Note that if this
     is set to true, then system jobs can preempt any other jobs.
+
+  -preempt-min-priority-delta=<int>
+    Specifies the minimum difference between the priority of a job and the
+    priority of the jobs whose allocations it can preempt. Setting it to 0
+    restores the default of 10.
 `
 	return strings.TrimSpace(helpText)
 }
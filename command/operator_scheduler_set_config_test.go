@@ -51,6 +51,7 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		"-preempt-service-scheduler=true",
 		"-preempt-sysbatch-scheduler=true",
 		"-preempt-system-scheduler=false",
+		"-preempt-min-priority-delta=20",
 	}
 	must.Zero(t, c.Run(modifyingArgs))
 	s := ui.OutputWriter.String()
@@ -65,6 +66,7 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 			SysBatchSchedulerEnabled: true,
 			BatchSchedulerEnabled:    true,
 			ServiceSchedulerEnabled:  true,
+			MinPriorityDelta:         20,
 		},
 		MemoryOversubscriptionEnabled: true,
 		RejectJobRegistration:         true,
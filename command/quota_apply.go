@@ -272,6 +272,7 @@ func parseQuotaResource(result *api.QuotaResources, list *ast.ObjectList) error
 		"cpu",
 		"memory",
 		"memory_max",
+		"disk",
 		"device",
 		"storage",
 	}
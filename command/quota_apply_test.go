@@ -55,6 +55,7 @@ limit {
     cpu        = 2500
     memory     = 1000
     memory_max = 1000
+    disk       = 10000
     device "nvidia/gpu/1080ti" {
       count = 1
     }
@@ -79,6 +80,7 @@ limit {
 				Cores:       pointer.Of(0),
 				MemoryMB:    pointer.Of(1000),
 				MemoryMaxMB: pointer.Of(1000),
+				DiskMB:      pointer.Of(10000),
 				Devices: []*api.RequestedDevice{{
 					Name:  "nvidia/gpu/1080ti",
 					Count: pointer.Of(uint64(1)),
@@ -123,6 +123,7 @@ limit {
     cpu        = 2500
     memory     = 1000
     memory_max = 1000
+    disk       = 10000
     device "nvidia/gpu/1080ti" {
       count = 1
     }
@@ -146,6 +147,7 @@ var defaultJsonQuotaSpec = strings.TrimSpace(`
         "CPU": 2500,
         "MemoryMB": 1000,
         "MemoryMaxMB": 1000,
+        "DiskMB": 10000,
         "Devices": [
           {
             "Name": "nvidia/gpu/1080ti",
@@ -153,8 +155,8 @@ var defaultJsonQuotaSpec = strings.TrimSpace(`
           }
         ],
         "Storage": {
-          "Variables": 1000,
-          "HostVolumes": 100000
+          "VariablesMB": 1000,
+          "HostVolumesMB": 100000
         }
       }
     }
@@ -32,7 +32,7 @@ Usage: nomad quota inspect [options] <quota>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Inspect Options:
 
@@ -47,7 +47,7 @@ Inspect Options:
 }
 
 func (c *QuotaInspectCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-t":    complete.PredictAnything,
 			"-json": complete.PredictNothing,
@@ -67,7 +67,7 @@ func (c *QuotaInspectCommand) Name() string { return "quota inspect" }
 func (c *QuotaInspectCommand) Run(args []string) int {
 	var json bool
 	var tmpl string
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -105,8 +105,8 @@ func (c *QuotaInspectCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, spec)
+	if c.StructuredOutput(json, tmpl) {
+		out, err := c.FormatOutput(json, tmpl, spec)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
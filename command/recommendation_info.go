@@ -32,7 +32,7 @@ Usage: nomad recommendation info [options] <recommendation_id>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Recommendation Info Options:
 
@@ -51,7 +51,7 @@ func (r *RecommendationInfoCommand) Synopsis() string {
 }
 
 func (r *RecommendationInfoCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(r.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(r.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json": complete.PredictNothing,
 			"-t":    complete.PredictAnything,
@@ -66,7 +66,7 @@ func (r *RecommendationInfoCommand) Run(args []string) int {
 	var json bool
 	var tmpl string
 
-	flags := r.Meta.FlagSet(r.Name(), FlagSetClient)
+	flags := r.Meta.FlagSet(r.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { r.Ui.Output(r.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -99,8 +99,8 @@ func (r *RecommendationInfoCommand) Run(args []string) int {
 	// If the user has specified to output the recommendation as JSON or using
 	// a template then perform this action for the entire object and exit the
 	// command.
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, rec)
+	if r.StructuredOutput(json, tmpl) {
+		out, err := r.FormatOutput(json, tmpl, rec)
 		if err != nil {
 			r.Ui.Error(err.Error())
 			return 1
@@ -34,7 +34,7 @@ Usage: nomad recommendation list [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Recommendation List Options:
 
@@ -64,7 +64,7 @@ func (r *RecommendationListCommand) Synopsis() string {
 }
 
 func (r *RecommendationListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(r.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(r.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-job":   complete.PredictNothing,
 			"-group": complete.PredictNothing,
@@ -82,7 +82,7 @@ func (r *RecommendationListCommand) Run(args []string) int {
 	var json bool
 	var tmpl, job, group, task string
 
-	flags := r.Meta.FlagSet(r.Name(), FlagSetClient)
+	flags := r.Meta.FlagSet(r.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { r.Ui.Output(r.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
@@ -142,8 +142,8 @@ func (r *RecommendationListCommand) Run(args []string) int {
 		return 0
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, recommendations)
+	if r.StructuredOutput(json, tmpl) {
+		out, err := r.FormatOutput(json, tmpl, recommendations)
 		if err != nil {
 			r.Ui.Error(err.Error())
 			return 1
@@ -34,7 +34,7 @@ Usage: nomad scaling policy info [options] <policy_id>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Policy Info Options:
 
@@ -56,7 +56,7 @@ func (s *ScalingPolicyInfoCommand) Synopsis() string {
 }
 
 func (s *ScalingPolicyInfoCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-verbose": complete.PredictNothing,
 			"-json":    complete.PredictNothing,
@@ -87,7 +87,7 @@ func (s *ScalingPolicyInfoCommand) Run(args []string) int {
 	var json, verbose bool
 	var tmpl string
 
-	flags := s.Meta.FlagSet(s.Name(), FlagSetClient)
+	flags := s.Meta.FlagSet(s.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { s.Ui.Output(s.Help()) }
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&json, "json", false, "")
@@ -112,13 +112,13 @@ func (s *ScalingPolicyInfoCommand) Run(args []string) int {
 	args = flags.Args()
 
 	// Formatted list mode if no policy ID
-	if len(args) == 0 && (json || len(tmpl) > 0) {
+	if len(args) == 0 && (s.StructuredOutput(json, tmpl)) {
 		policies, _, err := client.Scaling().ListPolicies(nil)
 		if err != nil {
 			s.Ui.Error(fmt.Sprintf("Error listing scaling policies: %v", err))
 			return 1
 		}
-		out, err := Format(json, tmpl, policies)
+		out, err := s.FormatOutput(json, tmpl, policies)
 		if err != nil {
 			s.Ui.Error(err.Error())
 			return 1
@@ -167,8 +167,8 @@ func (s *ScalingPolicyInfoCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, policy)
+	if s.StructuredOutput(json, tmpl) {
+		out, err := s.FormatOutput(json, tmpl, policy)
 		if err != nil {
 			s.Ui.Error(err.Error())
 			return 1
@@ -35,7 +35,7 @@ Usage: nomad scaling policy list [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Policy Info Options:
 
@@ -63,7 +63,7 @@ func (s *ScalingPolicyListCommand) Synopsis() string {
 }
 
 func (s *ScalingPolicyListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-verbose": complete.PredictNothing,
 			"-job":     complete.PredictNothing,
@@ -81,7 +81,7 @@ func (s *ScalingPolicyListCommand) Run(args []string) int {
 	var json, verbose bool
 	var tmpl, policyType, job string
 
-	flags := s.Meta.FlagSet(s.Name(), FlagSetClient)
+	flags := s.Meta.FlagSet(s.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { s.Ui.Output(s.Help()) }
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&json, "json", false, "")
@@ -126,8 +126,8 @@ func (s *ScalingPolicyListCommand) Run(args []string) int {
 		return 1
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, policies)
+	if s.StructuredOutput(json, tmpl) {
+		out, err := s.FormatOutput(json, tmpl, policies)
 		if err != nil {
 			s.Ui.Error(err.Error())
 			return 1
@@ -31,7 +31,7 @@ Usage: nomad server members [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 Server Members Options:
 
@@ -52,7 +52,7 @@ Server Members Options:
 }
 
 func (c *ServerMembersCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-detailed": complete.PredictNothing,
 			"-verbose":  complete.PredictNothing,
@@ -76,7 +76,7 @@ func (c *ServerMembersCommand) Run(args []string) int {
 	var detailed, verbose, json, openURL bool
 	var tmpl string
 
-	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detailed, "detailed", false, "Show detailed output")
 	flags.BoolVar(&verbose, "verbose", false, "Show detailed output")
@@ -126,11 +126,11 @@ func (c *ServerMembersCommand) Run(args []string) int {
 	// Determine the leaders per region.
 	leaders, leaderErr := regionLeaders(client, srvMembers.Members)
 
-	if json || len(tmpl) > 0 {
+	if c.StructuredOutput(json, tmpl) {
 		for _, member := range srvMembers.Members {
 			member.Tags["Leader"] = fmt.Sprintf("%t", isLeader(member, leaders))
 		}
-		out, err := Format(json, tmpl, srvMembers.Members)
+		out, err := c.FormatOutput(json, tmpl, srvMembers.Members)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
 	"github.com/shoenig/test/must"
+	"gopkg.in/yaml.v3"
 )
 
 func TestServerMembersCommand_Implements(t *testing.T) {
@@ -70,6 +71,18 @@ func TestServerMembersCommand_Run(t *testing.T) {
 	out := ui.OutputWriter.String()
 	must.StrContains(t, out, "alive")
 
+	ui.OutputWriter.Reset()
+
+	// List yaml
+	code = cmd.Run([]string{"-address=" + url, "-output=yaml"})
+	must.Zero(t, code)
+
+	outYaml := []map[string]any{}
+	err = yaml.Unmarshal(ui.OutputWriter.Bytes(), &outYaml)
+	must.NoError(t, err)
+	must.Len(t, 1, outYaml)
+	must.Eq[any](t, name, outYaml[0]["Name"])
+
 	ui.ErrorWriter.Reset()
 }
 
@@ -36,7 +36,7 @@ Usage: nomad service info [options] <service_name>
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Service Info Options:
 
@@ -67,7 +67,7 @@ func (s *ServiceInfoCommand) Synopsis() string {
 }
 
 func (s *ServiceInfoCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json":       complete.PredictNothing,
 			"-filter":     complete.PredictAnything,
@@ -89,7 +89,7 @@ func (s *ServiceInfoCommand) Run(args []string) int {
 		tmpl, filter, pageToken string
 	)
 
-	flags := s.Meta.FlagSet(s.Name(), FlagSetClient)
+	flags := s.Meta.FlagSet(s.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { s.Ui.Output(s.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
@@ -154,8 +154,8 @@ func (s *ServiceInfoCommand) Run(args []string) int {
 		return 0
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, serviceInfo)
+	if s.StructuredOutput(json, tmpl) {
+		out, err := s.FormatOutput(json, tmpl, serviceInfo)
 		if err != nil {
 			s.Ui.Error(err.Error())
 			return 1
@@ -241,8 +241,15 @@ func (s *ServiceInfoCommand) formatVerboseOutput(jobIDs []string, jobServices ma
 				fmt.Sprintf("Node ID|%s", service.NodeID),
 				fmt.Sprintf("Datacenter|%s", service.Datacenter),
 				fmt.Sprintf("Address|%v", fmt.Sprintf("%s:%v", service.Address, service.Port)),
-				fmt.Sprintf("Tags|[%s]\n", strings.Join(service.Tags, ",")),
 			}
+			if service.AddressIPv6 != "" {
+				out = append(out, fmt.Sprintf("IPv6 Address|%s", formatAddress(service.AddressIPv6, service.Port)))
+			}
+			out = append(out,
+				fmt.Sprintf("Weight|%d", service.Weight),
+				fmt.Sprintf("Canary|%t", service.Canary),
+				fmt.Sprintf("Tags|[%s]\n", strings.Join(service.Tags, ",")),
+			)
 			s.Ui.Output(formatKV(out))
 			s.Ui.Output("")
 		}
@@ -34,7 +34,7 @@ Usage: nomad service list [options]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault) + `
+  ` + generalOptionsUsage(usageOptsOutput) + `
 
 Service List Options:
 
@@ -53,7 +53,7 @@ func (s *ServiceListCommand) Synopsis() string {
 }
 
 func (s *ServiceListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(s.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-json": complete.PredictNothing,
 			"-t":    complete.PredictAnything,
@@ -71,7 +71,7 @@ func (s *ServiceListCommand) Run(args []string) int {
 		tmpl, name string
 	)
 
-	flags := s.Meta.FlagSet(s.Name(), FlagSetClient)
+	flags := s.Meta.FlagSet(s.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { s.Ui.Output(s.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&name, "name", "", "")
@@ -103,8 +103,8 @@ func (s *ServiceListCommand) Run(args []string) int {
 		return 0
 	}
 
-	if json || len(tmpl) > 0 {
-		out, err := Format(json, tmpl, list)
+	if s.StructuredOutput(json, tmpl) {
+		out, err := s.FormatOutput(json, tmpl, list)
 		if err != nil {
 			s.Ui.Error(err.Error())
 			return 1
@@ -56,6 +56,14 @@ Usage: nomad var <subcommand> [options] [args]
 
       $ nomad var list <prefix>
 
+  List the versions of a variable:
+
+      $ nomad var history <path>
+
+  Roll back a variable to a previous version:
+
+      $ nomad var rollback <path> <version>
+
   Purge a variable:
 
       $ nomad var purge <path>
@@ -110,6 +118,7 @@ func renderSVAsUiTable(sv *api.Variable, c VarUI) {
 		meta = append(meta, fmt.Sprintf("Modify Time|%v", time.Unix(0, sv.ModifyTime)))
 	}
 	meta = append(meta, fmt.Sprintf("Check Index|%v", sv.ModifyIndex))
+	meta = append(meta, fmt.Sprintf("Version|%v", sv.Version))
 	ui := c.GetConcurrentUI()
 	ui.Output(formatKV(meta))
 	ui.Output(c.Colorize().Color("\n[bold]Items[reset]"))
@@ -328,6 +337,7 @@ const (
 	errInvalidInFormat             = `Invalid value for "-in"; valid values are [hcl, json]`
 	errInvalidOutFormat            = `Invalid value for "-out"; valid values are [go-template, hcl, json, none, table]`
 	errInvalidListOutFormat        = `Invalid value for "-out"; valid values are [go-template, json, table, terse]`
+	errInvalidHistoryOutFormat     = `Invalid value for "-out"; valid values are [go-template, json, table]`
 	errWildcardNamespaceNotAllowed = `The wildcard namespace ("*") is not valid for this command.`
 
 	msgfmtCASMismatch = `
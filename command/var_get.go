@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/cli"
@@ -52,6 +53,10 @@ Get Options:
   -ui
     Open the variable page in the browser.
 
+  -version <version>
+     Get a previous version of the variable, as listed by the
+     'nomad var history' command.
+
 `
 	return strings.TrimSpace(helpText)
 }
@@ -62,6 +67,7 @@ func (c *VarGetCommand) AutocompleteFlags() complete.Flags {
 			"-out":      complete.PredictSet("go-template", "hcl", "json", "none", "table"),
 			"-template": complete.PredictAnything,
 			"-ui":       complete.PredictNothing,
+			"-version":  complete.PredictAnything,
 		},
 	)
 }
@@ -77,7 +83,7 @@ func (c *VarGetCommand) Synopsis() string {
 func (c *VarGetCommand) Name() string { return "var get" }
 
 func (c *VarGetCommand) Run(args []string) int {
-	var out, item string
+	var out, item, versionStr string
 	var openURL bool
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -85,6 +91,7 @@ func (c *VarGetCommand) Run(args []string) int {
 	flags.StringVar(&item, "item", "", "")
 	flags.StringVar(&c.tmpl, "template", "", "")
 	flags.BoolVar(&openURL, "ui", false, "")
+	flags.StringVar(&versionStr, "version", "", "")
 
 	if fileInfo, _ := os.Stdout.Stat(); (fileInfo.Mode() & os.ModeCharDevice) != 0 {
 		flags.StringVar(&c.outFmt, "out", "table", "")
@@ -128,7 +135,17 @@ func (c *VarGetCommand) Run(args []string) int {
 		Namespace: c.Meta.namespace,
 	}
 
-	sv, _, err := client.Variables().Read(path, qo)
+	var sv *api.Variable
+	if versionStr != "" {
+		version, perr := strconv.ParseUint(versionStr, 10, 64)
+		if perr != nil {
+			c.Ui.Error(fmt.Sprintf("Invalid -version value %q: not parsable as uint64", versionStr))
+			return 1
+		}
+		sv, _, err = client.Variables().ReadVersion(path, version, qo)
+	} else {
+		sv, _, err = client.Variables().Read(path, qo)
+	}
 	if err != nil {
 		if err.Error() == "variable not found" {
 			c.Ui.Warn(errVariableNotFound)
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestVarHistoryCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarHistoryCommand{}
+}
+
+func TestVarHistoryCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	t.Run("bad_args", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarHistoryCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"some", "bad", "args"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	})
+	t.Run("bad_address", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarHistoryCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=nope", "foo"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), "retrieving variable history")
+	})
+	t.Run("bad_out", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarHistoryCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-out=hcl", "foo"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), errInvalidHistoryOutFormat)
+	})
+}
+
+func TestVarHistoryCommand_Online(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarHistoryCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "missing"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), errVariableNotFound)
+
+	// Create a variable and update it
+	sv := testVariable()
+	sv, _, err := client.Variables().Create(sv, nil)
+	must.NoError(t, err)
+	sv.Items["keyA"] = "valueC"
+	_, _, err = client.Variables().Update(sv, nil)
+	must.NoError(t, err)
+
+	ui.OutputWriter.Reset()
+	code = cmd.Run([]string{"-address=" + url, "-out=json", sv.Path})
+	must.Zero(t, code)
+
+	var versions []*api.VariableMetadata
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &versions))
+	must.Len(t, 2, versions)
+	must.Eq(t, 1, versions[0].Version)
+	must.Eq(t, 0, versions[1].Version)
+
+	ui.OutputWriter.Reset()
+	code = cmd.Run([]string{"-address=" + url, "-out=table", sv.Path})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "Version  Check Index  Modify Time")
+}
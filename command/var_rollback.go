@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarRollbackCommand struct {
+	Meta
+}
+
+func (c *VarRollbackCommand) Help() string {
+	helpText := `
+Usage: nomad var rollback [options] <path> <version>
+
+  The 'var rollback' command is used to restore the items of a previous
+  version of a variable. The rollback is written as a new version of the
+  variable, so it can be rolled back itself. Use 'nomad var history' to list
+  the versions of a variable.
+
+  If ACLs are enabled, this command requires a token with the 'variables:write'
+  capability for the target variable's namespace and path.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Rollback Options:
+
+  -check-index
+    If set, the variable is only acted upon if the server side version's modify
+    index matches the provided value.
+
+  -verbose
+    Display the rolled back variable.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarRollbackCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-check-index": complete.PredictAnything,
+			"-verbose":     complete.PredictNothing,
+		},
+	)
+}
+
+func (c *VarRollbackCommand) AutocompleteArgs() complete.Predictor {
+	return VariablePathPredictor(c.Meta.Client)
+}
+
+func (c *VarRollbackCommand) Synopsis() string {
+	return "Roll back a variable to a previous version"
+}
+
+func (c *VarRollbackCommand) Name() string { return "var rollback" }
+
+func (c *VarRollbackCommand) Run(args []string) int {
+	var checkIndexStr string
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&checkIndexStr, "check-index", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got two arguments
+	args = flags.Args()
+	if l := len(args); l != 2 {
+		c.Ui.Error("This command takes two arguments: <path> <version>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	version, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid version %q: not parsable as uint64", args[1]))
+		return 1
+	}
+
+	// Parse the check-index
+	checkIndex, enforce, err := parseCheckIndex(checkIndexStr)
+	if err != nil {
+		switch {
+		case errors.Is(err, strconv.ErrRange):
+			c.Ui.Error(fmt.Sprintf("Invalid -check-index value %q: out of range for uint64", checkIndexStr))
+		case errors.Is(err, strconv.ErrSyntax):
+			c.Ui.Error(fmt.Sprintf("Invalid -check-index value %q: not parsable as uint64", checkIndexStr))
+		default:
+			c.Ui.Error(fmt.Sprintf("Error parsing -check-index value %q: %v", checkIndexStr, err))
+		}
+		return 1
+	}
+
+	if c.Meta.namespace == "*" {
+		c.Ui.Error(errWildcardNamespaceNotAllowed)
+		return 1
+	}
+
+	path := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	var sv *api.Variable
+	if enforce {
+		sv, _, err = client.Variables().CheckedRollback(path, version, checkIndex, nil)
+	} else {
+		sv, _, err = client.Variables().Rollback(path, version, nil)
+	}
+
+	if err != nil {
+		if handled := handleCASError(err, c); handled {
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Error rolling back variable: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully rolled back variable %q to version %d!", path, version))
+	if verbose {
+		c.Ui.Output("")
+		renderSVAsUiTable(sv, c)
+	}
+	return 0
+}
+
+func (c *VarRollbackCommand) GetConcurrentUI() cli.ConcurrentUi {
+	return cli.ConcurrentUi{Ui: c.Ui}
+}
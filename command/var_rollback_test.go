@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestVarRollbackCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarRollbackCommand{}
+}
+
+func TestVarRollbackCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	t.Run("bad_args", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarRollbackCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"foo"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	})
+	t.Run("bad_version", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarRollbackCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"foo", "latest"})
+		must.One(t, code)
+		must.Eq(t, `Invalid version "latest": not parsable as uint64`, strings.TrimSpace(ui.ErrorWriter.String()))
+	})
+	t.Run("bad_address", func(t *testing.T) {
+		ci.Parallel(t)
+		ui := cli.NewMockUi()
+		cmd := &VarRollbackCommand{Meta: Meta{Ui: ui}}
+		code := cmd.Run([]string{"-address=nope", "foo", "0"})
+		must.One(t, code)
+		must.StrContains(t, ui.ErrorWriter.String(), "rolling back variable")
+		must.Eq(t, "", ui.OutputWriter.String())
+	})
+}
+
+func TestVarRollbackCommand_Online(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarRollbackCommand{Meta: Meta{Ui: ui}}
+
+	// Create a variable and update it
+	sv := testVariable()
+	sv, _, err := client.Variables().Create(sv, nil)
+	must.NoError(t, err)
+	sv.Items["keyA"] = "valueC"
+	sv, _, err = client.Variables().Update(sv, nil)
+	must.NoError(t, err)
+
+	code := cmd.Run([]string{"-address=" + url, "-check-index=1", sv.Path, "0"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "\nCheck-and-Set conflict\n\n    Your provided check-index (1)")
+
+	code = cmd.Run([]string{"-address=" + url, fmt.Sprintf("-check-index=%v", sv.ModifyIndex), sv.Path, "0"})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), `Successfully rolled back variable "test/var" to version 0!`)
+
+	sv, _, err = client.Variables().Read(sv.Path, nil)
+	must.NoError(t, err)
+	must.Eq(t, 2, sv.Version)
+	must.Eq(t, "valueA", sv.Items["keyA"])
+
+	ui.ErrorWriter.Reset()
+	code = cmd.Run([]string{"-address=" + url, sv.Path, "9"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "variable version doesn't exist")
+}
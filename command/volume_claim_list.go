@@ -36,7 +36,7 @@ Usage: nomad volume claim list [options] [claim_id]
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace|usageOptsOutput) + `
 
 List Options:
 
@@ -61,7 +61,7 @@ List Options:
 }
 
 func (c *VolumeClaimListCommand) AutocompleteFlags() complete.Flags {
-	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient|FlagSetOutput),
 		complete.Flags{
 			"-job":         complete.PredictNothing,
 			"-group":       complete.PredictNothing,
@@ -85,7 +85,7 @@ func (c *VolumeClaimListCommand) Synopsis() string {
 }
 
 func (c *VolumeClaimListCommand) Run(args []string) int {
-	flags := c.FlagSet(c.Name(), FlagSetClient)
+	flags := c.FlagSet(c.Name(), FlagSetClient|FlagSetOutput)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&c.job, "job", "", "")
 	flags.StringVar(&c.taskGroup, "group", "", "")
@@ -138,8 +138,8 @@ func (c *VolumeClaimListCommand) Run(args []string) int {
 		return 1
 	}
 
-	if c.json || len(c.tmpl) > 0 {
-		out, err := Format(c.json, c.tmpl, claims)
+	if c.StructuredOutput(c.json, c.tmpl) {
+		out, err := c.FormatOutput(c.json, c.tmpl, claims)
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
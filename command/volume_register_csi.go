@@ -53,6 +53,7 @@ func csiDecodeVolume(input *ast.File) (*api.CSIVolume, error) {
 	delete(m, "capacity_max")
 	delete(m, "capacity_min")
 	delete(m, "topology_request")
+	delete(m, "snapshot_schedule")
 	delete(m, "type")
 
 	// Decode the rest
@@ -121,6 +122,28 @@ func csiDecodeVolume(input *ast.File) (*api.CSIVolume, error) {
 		}
 	}
 
+	schedObj := list.Filter("snapshot_schedule")
+	if len(schedObj.Items) > 0 {
+
+		for _, o := range schedObj.Elem().Items {
+			valid := []string{"cron", "retain"}
+			if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
+				return nil, err
+			}
+
+			ot, ok := o.Val.(*ast.ObjectType)
+			if !ok {
+				break
+			}
+			var sched *api.CSIVolumeSnapshotSchedule
+			if err := hcl.DecodeObject(&sched, ot.List); err != nil {
+				return nil, err
+			}
+			vol.SnapshotSchedule = sched
+			break
+		}
+	}
+
 	requestedTopos := list.Filter("topology_request")
 	if len(requestedTopos.Items) > 0 {
 
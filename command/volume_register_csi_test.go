@@ -186,6 +186,27 @@ topology_request {
 			Topologies: nil,
 		},
 		err: "",
+	}, {
+		name: "snapshot schedule",
+		hcl: `
+id        = "testvolume"
+type      = "csi"
+plugin_id = "myplugin"
+
+snapshot_schedule {
+  cron   = "0 3 * * *"
+  retain = 7
+}
+`,
+		expected: &api.CSIVolume{
+			ID:       "testvolume",
+			PluginID: "myplugin",
+			SnapshotSchedule: &api.CSIVolumeSnapshotSchedule{
+				Cron:   "0 3 * * *",
+				Retain: 7,
+			},
+		},
+		err: "",
 	},
 	}
 
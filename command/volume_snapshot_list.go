@@ -185,18 +185,25 @@ Results have been paginated. To get the next page run:
 }
 
 func csiFormatSnapshots(snapshots []*api.CSISnapshot, verbose bool) string {
-	rows := []string{"Snapshot ID|Volume ID|Size|Create Time|Ready?"}
+	rows := []string{"Snapshot ID|Volume ID|Size|Create Time|Ready?|Managed By"}
 	length := 12
 	if verbose {
 		length = 30
 	}
 	for _, v := range snapshots {
-		rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%v",
+		// snapshots created by the snapshot schedule of a volume are marked
+		// with the Nomad volume ID
+		managedBy := "<none>"
+		if v.SourceVolumeID != "" {
+			managedBy = v.SourceVolumeID
+		}
+		rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%v|%s",
 			v.ID,
 			limit(v.ExternalSourceVolumeID, length),
 			humanize.IBytes(uint64(v.SizeBytes)),
 			formatUnixNanoTime(v.CreateTime*1e9), // seconds to nanoseconds
 			v.IsReady,
+			managedBy,
 		))
 	}
 	return formatList(rows)
@@ -212,6 +212,19 @@ func (c *VolumeStatusCommand) formatCSIBasic(vol *api.CSIVolume) (string, error)
 		full = append(full, topo)
 	}
 
+	if vol.SnapshotSchedule != nil {
+		banner := c.Colorize().Color("\n[bold]Snapshot Schedule[reset]")
+		full = append(full, banner)
+		full = append(full, formatKV([]string{
+			fmt.Sprintf("Cron|%s", vol.SnapshotSchedule.Cron),
+			fmt.Sprintf("Retain|%d", vol.SnapshotSchedule.Retain),
+		}))
+		if len(vol.ManagedSnapshots) > 0 {
+			full = append(full, "")
+			full = append(full, csiFormatSnapshots(vol.ManagedSnapshots, c.verbose))
+		}
+	}
+
 	banner := c.Colorize().Color("\n[bold]Capabilities[reset]")
 	caps := formatCSIVolumeCapabilities(vol.RequestedCapabilities)
 	full = append(full, banner)
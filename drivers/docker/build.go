@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package docker
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// buildImage creates an image by building the Dockerfile of the build context
+// in the task directory, and tags it with the image name of the task.
+func (d *Driver) buildImage(task *drivers.TaskConfig, driverConfig *TaskConfig) (id string, user string, err error) {
+	contextDir := filepath.Join(task.TaskDir().Dir, driverConfig.Build.Context)
+	if info, err := os.Stat(contextDir); err != nil {
+		return "", "", fmt.Errorf("unable to read build context: %v", err)
+	} else if !info.IsDir() {
+		return "", "", fmt.Errorf("build context %q is not a directory", driverConfig.Build.Context)
+	}
+
+	// builds may take longer than the timeout of the default client
+	dockerClient, err := d.getInfinityClient()
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to create long operations docker client: %v", err)
+	}
+
+	d.eventer.EmitEvent(&drivers.TaskEvent{
+		TaskID:    task.ID,
+		AllocID:   task.AllocID,
+		TaskName:  task.Name,
+		Timestamp: time.Now(),
+		Message:   "Building image",
+		Annotations: map[string]string{
+			"image": driverConfig.Image,
+		},
+	})
+	d.logger.Debug("building image", "image", driverConfig.Image, "context", contextDir)
+
+	buildArgs := make(map[string]*string, len(driverConfig.Build.Args))
+	for k, v := range driverConfig.Build.Args {
+		buildArgs[k] = &v
+	}
+
+	buildCtx := tarBuildContext(contextDir)
+	defer buildCtx.Close()
+
+	resp, err := dockerClient.ImageBuild(d.ctx, buildCtx, types.ImageBuildOptions{
+		Tags:        []string{driverConfig.Image},
+		Dockerfile:  driverConfig.Build.Dockerfile,
+		BuildArgs:   buildArgs,
+		Target:      driverConfig.Build.Target,
+		NoCache:     driverConfig.Build.NoCache,
+		PullParent:  driverConfig.ForcePull,
+		Remove:      true,
+		ForceRemove: true,
+		Version:     types.BuilderV1,
+	})
+	if err != nil {
+		return "", "", recoverableErrTimeouts(fmt.Errorf("failed to build image: %v", err))
+	}
+	defer resp.Body.Close()
+
+	// The build only completes once its output has been read, and the errors
+	// of failing steps are reported in the output
+	if err := jsonmessage.DisplayJSONMessagesStream(resp.Body, io.Discard, 0, false, nil); err != nil {
+		return "", "", fmt.Errorf("failed to build image: %v", err)
+	}
+
+	dockerImage, _, err := dockerClient.ImageInspectWithRaw(d.ctx, driverConfig.Image)
+	if err != nil {
+		return "", "", recoverableErrTimeouts(err)
+	}
+
+	d.coordinator.IncrementImageReference(dockerImage.ID, driverConfig.Image, task.ID)
+	var imageUser string
+	if dockerImage.Config != nil {
+		imageUser = dockerImage.Config.User
+	}
+	return dockerImage.ID, imageUser, nil
+}
+
+// validateBuild checks that the build context is within the task directory.
+func validateBuild(task *drivers.TaskConfig, build *DockerBuild) error {
+	taskDir := task.TaskDir().Dir
+	if escapingfs.PathEscapesSandbox(taskDir, filepath.Join(taskDir, build.Context)) {
+		return fmt.Errorf("build context %q escapes the task directory", build.Context)
+	}
+	return nil
+}
+
+// tarBuildContext returns a reader streaming the content of dir as a tar
+// archive. Symlinks are archived as links and are never followed, and files
+// that aren't regular files, directories, or symlinks are skipped.
+func tarBuildContext(dir string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if path == dir {
+				return nil
+			}
+			return tarBuildContextEntry(tw, dir, path, entry)
+		})
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+func tarBuildContextEntry(tw *tar.Writer, dir, path string, entry fs.DirEntry) error {
+	info, err := entry.Info()
+	if err != nil {
+		return err
+	}
+
+	var link string
+	switch mode := info.Mode(); {
+	case mode.IsRegular(), mode.IsDir():
+	case mode&fs.ModeSymlink != 0:
+		if link, err = os.Readlink(path); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	hdr, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return err
+	}
+	hdr.Name = filepath.ToSlash(rel)
+	if info.IsDir() {
+		hdr.Name += "/"
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(tw, f)
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package docker
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestTarBuildContext(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM busybox\n"), 0o644))
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "pkg"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "src", "pkg", "main.sh"), []byte("echo hi\n"), 0o755))
+
+	r := tarBuildContext(dir)
+	defer r.Close()
+
+	files := map[string]string{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		must.NoError(t, err)
+		b, err := io.ReadAll(tr)
+		must.NoError(t, err)
+		files[hdr.Name] = string(b)
+	}
+
+	must.Eq(t, map[string]string{
+		"Dockerfile":      "FROM busybox\n",
+		"src/":            "",
+		"src/pkg/":        "",
+		"src/pkg/main.sh": "echo hi\n",
+	}, files)
+}
+
+func TestTarBuildContext_Missing(t *testing.T) {
+	ci.Parallel(t)
+
+	r := tarBuildContext(filepath.Join(t.TempDir(), "missing"))
+	defer r.Close()
+
+	_, err := io.ReadAll(r)
+	must.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -321,6 +321,20 @@ var (
 		// ContainerAdmin. If so, exits with an error unless the task config has
 		// privileged=true.
 		"windows_allow_insecure_container_admin": hclspec.NewAttr("windows_allow_insecure_container_admin", "bool", false),
+
+		// image_policy is the policy the images of tasks must comply with,
+		// requiring images to be pinned by digest and/or signed
+		"image_policy": hclspec.NewBlock("image_policy", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"require_digest": hclspec.NewAttr("require_digest", "bool", false),
+			"audit_only":     hclspec.NewAttr("audit_only", "bool", false),
+			"cosign": hclspec.NewBlock("cosign", false, hclspec.NewObject(map[string]*hclspec.Spec{
+				"public_keys": hclspec.NewAttr("public_keys", "list(string)", true),
+			})),
+			"notation": hclspec.NewBlock("notation", false, hclspec.NewObject(map[string]*hclspec.Spec{
+				"trust_store":        hclspec.NewAttr("trust_store", "string", true),
+				"trusted_identities": hclspec.NewAttr("trusted_identities", "list(string)", false),
+			})),
+		})),
 	})
 
 	// mountBodySpec is the hcl specification for the `mount` block
@@ -367,6 +381,13 @@ var (
 			"server_address": hclspec.NewAttr("server_address", "string", false),
 		})),
 		"auth_soft_fail": hclspec.NewAttr("auth_soft_fail", "bool", false),
+		"build": hclspec.NewBlock("build", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"context":    hclspec.NewAttr("context", "string", true),
+			"dockerfile": hclspec.NewAttr("dockerfile", "string", false),
+			"args":       hclspec.NewAttr("args", "list(map(string))", false),
+			"target":     hclspec.NewAttr("target", "string", false),
+			"no_cache":   hclspec.NewAttr("no_cache", "bool", false),
+		})),
 		"cap_add":        hclspec.NewAttr("cap_add", "list(string)", false),
 		"cap_drop":       hclspec.NewAttr("cap_drop", "list(string)", false),
 		"command":        hclspec.NewAttr("command", "string", false),
@@ -398,7 +419,12 @@ var (
 		"ipv6_address":       hclspec.NewAttr("ipv6_address", "string", false),
 		"isolation":          hclspec.NewAttr("isolation", "string", false),
 		"labels":             hclspec.NewAttr("labels", "list(map(string))", false),
-		"load":               hclspec.NewAttr("load", "string", false),
+		"lazy_pull":          hclspec.NewAttr("lazy_pull", "bool", false),
+		"lazy_pull_fallback": hclspec.NewDefault(
+			hclspec.NewAttr("lazy_pull_fallback", "bool", false),
+			hclspec.NewLiteral("true"),
+		),
+		"load": hclspec.NewAttr("load", "string", false),
 		"logging": hclspec.NewBlock("logging", false, hclspec.NewObject(map[string]*hclspec.Spec{
 			"type":   hclspec.NewAttr("type", "string", false),
 			"driver": hclspec.NewAttr("driver", "string", false),
@@ -457,6 +483,7 @@ type TaskConfig struct {
 	Args                    []string           `codec:"args"`
 	Auth                    DockerAuth         `codec:"auth"`
 	AuthSoftFail            bool               `codec:"auth_soft_fail"`
+	Build                   DockerBuild        `codec:"build"`
 	CapAdd                  []string           `codec:"cap_add"`
 	CapDrop                 []string           `codec:"cap_drop"`
 	Command                 string             `codec:"command"`
@@ -481,6 +508,8 @@ type TaskConfig struct {
 	IPv6Address             string             `codec:"ipv6_address"`
 	Isolation               string             `codec:"isolation"`
 	Labels                  hclutils.MapStrStr `codec:"labels"`
+	LazyPull                bool               `codec:"lazy_pull"`
+	LazyPullFallback        bool               `codec:"lazy_pull_fallback"`
 	LoadImage               string             `codec:"load"`
 	Logging                 DockerLogging      `codec:"logging"`
 	MacAddress              string             `codec:"mac_address"`
@@ -520,6 +549,17 @@ type DockerAuth struct {
 	ServerAddr string `codec:"server_address"`
 }
 
+// DockerBuild is the configuration of an image built from a Dockerfile before
+// the task is started.
+type DockerBuild struct {
+	// Context is the path of the build context, relative to the task dir.
+	Context    string             `codec:"context"`
+	Dockerfile string             `codec:"dockerfile"`
+	Args       hclutils.MapStrStr `codec:"args"`
+	Target     string             `codec:"target"`
+	NoCache    bool               `codec:"no_cache"`
+}
+
 type DockerDevice struct {
 	HostPath          string `codec:"host_path"`
 	ContainerPath     string `codec:"container_path"`
@@ -686,6 +726,9 @@ type DriverConfig struct {
 	ExtraLabels                        []string      `codec:"extra_labels"`
 	Logging                            LoggingConfig `codec:"logging"`
 
+	ImagePolicy ImagePolicyConfig `codec:"image_policy"`
+	imagePolicy *imagePolicy      `codec:"-"`
+
 	AllowRuntimesList []string            `codec:"allow_runtimes"`
 	allowRuntimes     map[string]struct{} `codec:"-"`
 
@@ -724,6 +767,32 @@ type LoggingConfig struct {
 	Config map[string]string `codec:"config"`
 }
 
+// ImagePolicyConfig is the policy the images of tasks must comply with.
+type ImagePolicyConfig struct {
+	// RequireDigest requires images to be pinned by digest
+	RequireDigest bool `codec:"require_digest"`
+
+	// AuditOnly records whether images comply with the policy in task
+	// events, without rejecting the tasks whose images don't
+	AuditOnly bool `codec:"audit_only"`
+
+	Cosign   CosignPolicyConfig   `codec:"cosign"`
+	Notation NotationPolicyConfig `codec:"notation"`
+}
+
+// CosignPolicyConfig requires images to be signed with cosign by one of the
+// public keys.
+type CosignPolicyConfig struct {
+	PublicKeys []string `codec:"public_keys"`
+}
+
+// NotationPolicyConfig requires images to be signed with Notation (Notary
+// v2) by a certificate issued by the trust store.
+type NotationPolicyConfig struct {
+	TrustStore        string   `codec:"trust_store"`
+	TrustedIdentities []string `codec:"trusted_identities"`
+}
+
 func (d *Driver) PluginInfo() (*base.PluginInfoResponse, error) {
 	return pluginInfo, nil
 }
@@ -800,6 +869,12 @@ func (d *Driver) SetConfig(c *base.Config) error {
 		}
 	}
 
+	imagePolicy, err := newImagePolicy(&d.config.ImagePolicy)
+	if err != nil {
+		return fmt.Errorf("invalid image_policy: %v", err)
+	}
+	d.config.imagePolicy = imagePolicy
+
 	d.config.allowRuntimes = make(map[string]struct{}, len(d.config.AllowRuntimesList))
 	for _, r := range d.config.AllowRuntimesList {
 		d.config.allowRuntimes[r] = struct{}{}
@@ -838,5 +913,6 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 // features this driver supports.
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
 	driverCapabilities.DisableLogCollection = d.config != nil && d.config.DisableLogCollection
+	driverCapabilities.Checkpoint = d.checkpoint
 	return driverCapabilities, nil
 }
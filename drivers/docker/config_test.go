@@ -28,11 +28,12 @@ func TestConfig_ParseHCL(t *testing.T) {
 				image = "redis:7"
 			}`,
 			&TaskConfig{
-				Image:        "redis:7",
-				Devices:      []DockerDevice{},
-				Mounts:       []DockerMount{},
-				MountsList:   []DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				Devices:          []DockerDevice{},
+				Mounts:           []DockerMount{},
+				MountsList:       []DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 		},
 	}
@@ -63,44 +64,48 @@ func TestConfig_ParseJSON(t *testing.T) {
 			name:  "nil values for blocks are safe",
 			input: `{"Config": {"image": "bash:3", "mounts": null}}`,
 			expected: TaskConfig{
-				Image:        "bash:3",
-				Mounts:       []DockerMount{},
-				MountsList:   []DockerMount{},
-				Devices:      []DockerDevice{},
-				CPUCFSPeriod: 100000,
+				Image:            "bash:3",
+				Mounts:           []DockerMount{},
+				MountsList:       []DockerMount{},
+				Devices:          []DockerDevice{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 		},
 		{
 			name:  "nil values for 'volumes' field are safe",
 			input: `{"Config": {"image": "bash:3", "volumes": null}}`,
 			expected: TaskConfig{
-				Image:        "bash:3",
-				Mounts:       []DockerMount{},
-				MountsList:   []DockerMount{},
-				Devices:      []DockerDevice{},
-				CPUCFSPeriod: 100000,
+				Image:            "bash:3",
+				Mounts:           []DockerMount{},
+				MountsList:       []DockerMount{},
+				Devices:          []DockerDevice{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 		},
 		{
 			name:  "nil values for 'args' field are safe",
 			input: `{"Config": {"image": "bash:3", "args": null}}`,
 			expected: TaskConfig{
-				Image:        "bash:3",
-				Mounts:       []DockerMount{},
-				MountsList:   []DockerMount{},
-				Devices:      []DockerDevice{},
-				CPUCFSPeriod: 100000,
+				Image:            "bash:3",
+				Mounts:           []DockerMount{},
+				MountsList:       []DockerMount{},
+				Devices:          []DockerDevice{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 		},
 		{
 			name:  "nil values for string fields are safe",
 			input: `{"Config": {"image": "bash:3", "command": null}}`,
 			expected: TaskConfig{
-				Image:        "bash:3",
-				Mounts:       []DockerMount{},
-				MountsList:   []DockerMount{},
-				Devices:      []DockerDevice{},
-				CPUCFSPeriod: 100000,
+				Image:            "bash:3",
+				Mounts:           []DockerMount{},
+				MountsList:       []DockerMount{},
+				Devices:          []DockerDevice{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 		},
 	}
@@ -207,6 +212,15 @@ config {
   }
 
   auth_soft_fail = true
+  build {
+    context    = "local/src"
+    dockerfile = "Dockerfile.prod"
+    args {
+      VERSION = "1.2.3"
+    }
+    target   = "release"
+    no_cache = true
+  }
   cap_add = ["CAP_SYS_NICE"]
   cap_drop = ["CAP_SYS_ADMIN", "CAP_SYS_TIME"]
   command = "/bin/bash"
@@ -237,6 +251,8 @@ config {
     owner = "hashicorp-nomad"
     key = "val"
   }
+  lazy_pull = true
+  lazy_pull_fallback = false
   load = "/tmp/image.tar.gz"
   logging {
     driver = "json-file-driver"
@@ -356,7 +372,14 @@ config {
 			Email:      "myemail@example.com",
 			ServerAddr: "https://example.com",
 		},
-		AuthSoftFail:            true,
+		AuthSoftFail: true,
+		Build: DockerBuild{
+			Context:    "local/src",
+			Dockerfile: "Dockerfile.prod",
+			Args:       map[string]string{"VERSION": "1.2.3"},
+			Target:     "release",
+			NoCache:    true,
+		},
 		CapAdd:                  []string{"CAP_SYS_NICE"},
 		CapDrop:                 []string{"CAP_SYS_ADMIN", "CAP_SYS_TIME"},
 		Command:                 "/bin/bash",
@@ -399,6 +422,7 @@ config {
 			"owner": "hashicorp-nomad",
 			"key":   "val",
 		},
+		LazyPull:  true,
 		LoadImage: "/tmp/image.tar.gz",
 		Logging: DockerLogging{
 			Driver: "json-file-driver",
@@ -627,9 +651,10 @@ func TestConfig_Capabilities(t *testing.T) {
 	ci.Parallel(t)
 
 	cases := []struct {
-		name     string
-		config   string
-		expected *drivers.Capabilities
+		name       string
+		config     string
+		checkpoint bool
+		expected   *drivers.Capabilities
 	}{
 		{
 			name:   "pure default",
@@ -658,8 +683,9 @@ func TestConfig_Capabilities(t *testing.T) {
 			},
 		},
 		{
-			name:   "enabled explicitly",
-			config: `{ disable_log_collection = false }`,
+			name:       "enabled explicitly with checkpoint",
+			config:     `{ disable_log_collection = false }`,
+			checkpoint: true,
 			expected: &drivers.Capabilities{
 				SendSignals:          true,
 				Exec:                 true,
@@ -667,6 +693,7 @@ func TestConfig_Capabilities(t *testing.T) {
 				NetIsolationModes:    []drivers.NetIsolationMode{"host", "group", "task"},
 				MustInitiateNetwork:  true,
 				MountConfigs:         0,
+				Checkpoint:           true,
 				DisableLogCollection: false,
 			},
 		},
@@ -677,7 +704,7 @@ func TestConfig_Capabilities(t *testing.T) {
 			var tc DriverConfig
 			hclutils.NewConfigParser(configSpec).ParseHCL(t, "config "+c.config, &tc)
 
-			d := &Driver{config: &tc}
+			d := &Driver{config: &tc, checkpoint: c.checkpoint}
 			caps, err := d.Capabilities()
 			must.NoError(t, err)
 			must.Eq(t, c.expected, caps)
@@ -861,3 +888,38 @@ func TestConfig_DriverConfig_AllowRuntimes(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_DriverConfig_ImagePolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	config := `config {
+  image_policy {
+    require_digest = true
+    audit_only     = true
+    cosign {
+      public_keys = ["/etc/nomad/cosign.pub"]
+    }
+    notation {
+      trust_store        = "/etc/nomad/notation.pem"
+      trusted_identities = ["CN=release,O=Example"]
+    }
+  }
+}`
+
+	var tc DriverConfig
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, config, &tc)
+	must.Eq(t, ImagePolicyConfig{
+		RequireDigest: true,
+		AuditOnly:     true,
+		Cosign:        CosignPolicyConfig{PublicKeys: []string{"/etc/nomad/cosign.pub"}},
+		Notation: NotationPolicyConfig{
+			TrustStore:        "/etc/nomad/notation.pem",
+			TrustedIdentities: []string{"CN=release,O=Example"},
+		},
+	}, tc.ImagePolicy)
+
+	// no policy by default
+	tc = DriverConfig{}
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, "config {}", &tc)
+	must.Eq(t, ImagePolicyConfig{}, tc.ImagePolicy)
+}
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/blkiodev"
+	"github.com/docker/docker/api/types/checkpoint"
 	containerapi "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/mount"
@@ -164,6 +166,9 @@ type Driver struct {
 	// gpuRuntime indicates nvidia-docker runtime availability
 	gpuRuntime bool
 
+	// checkpoint indicates the daemon can checkpoint containers with CRIU
+	checkpoint bool
+
 	// compute contains information about the available cpu compute
 	compute cpustats.Compute
 
@@ -327,6 +332,41 @@ func loggingIsEnabled(driverCfg *DriverConfig, taskCfg *drivers.TaskConfig) bool
 }
 
 func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, containerapi.StartOptions{})
+}
+
+var _ drivers.CheckpointDriver = (*Driver)(nil)
+
+// CheckpointTask checkpoints the container of the task with docker
+// checkpoint, which requires the Docker daemon to run in experimental mode
+// with CRIU installed.
+func (d *Driver) CheckpointTask(taskID string, opts *drivers.CheckpointOptions) error {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	checkpointDir, checkpointID := filepath.Split(filepath.Clean(opts.Dir))
+	return h.dockerClient.CheckpointCreate(d.ctx, h.containerID, checkpoint.CreateOptions{
+		CheckpointID:  checkpointID,
+		CheckpointDir: checkpointDir,
+		Exit:          !opts.LeaveRunning,
+	})
+}
+
+// RestoreTask creates a new container for the task and starts it from the
+// checkpoint in dir, which was written by CheckpointTask.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	checkpointDir, checkpointID := filepath.Split(filepath.Clean(dir))
+	return d.startTask(cfg, containerapi.StartOptions{
+		CheckpointID:  checkpointID,
+		CheckpointDir: checkpointDir,
+	})
+}
+
+// startTask creates and starts the container of the task, from the checkpoint
+// of startOpts if one is set.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, startOpts containerapi.StartOptions) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -341,6 +381,15 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("image name required for docker driver")
 	}
 
+	if driverConfig.Build.Context != "" {
+		if driverConfig.LoadImage != "" {
+			return nil, nil, fmt.Errorf("only one of build and load can be set")
+		}
+		if err := validateBuild(cfg, &driverConfig.Build); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	driverConfig.Image = strings.TrimPrefix(driverConfig.Image, "https://")
 
 	driverConfig.ImagePullTimeout = getValue(driverConfig.ImagePullTimeout, d.config.ImagePullTimeout)
@@ -370,6 +419,10 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, err
 	}
 
+	if err := d.enforceImagePolicy(cfg, &driverConfig, id, dockerClient); err != nil {
+		return nil, nil, err
+	}
+
 	// validate the image user (windows only)
 	if err := validateImageUser(user, cfg.User, &driverConfig, d.config); err != nil {
 		return nil, nil, err
@@ -407,7 +460,7 @@ CREATE:
 
 	if !container.State.Running {
 		// Start the container
-		if err := d.startContainer(*container); err != nil {
+		if err := d.startContainer(*container, startOpts); err != nil {
 			d.logger.Error("failed to start container", "container_id", container.ID, "error", err)
 			dockerClient.ContainerRemove(d.ctx, container.ID, containerapi.RemoveOptions{Force: true})
 			// Some sort of docker race bug, recreating the container usually works
@@ -578,9 +631,9 @@ CREATE:
 	return nil, recoverableErrTimeouts(createErr)
 }
 
-// startContainer starts the passed container. It attempts to handle any
-// transient Docker errors.
-func (d *Driver) startContainer(c types.ContainerJSON) error {
+// startContainer starts the passed container with the given options. It
+// attempts to handle any transient Docker errors.
+func (d *Driver) startContainer(c types.ContainerJSON, opts containerapi.StartOptions) error {
 	dockerClient, err := d.getDockerClient()
 	if err != nil {
 		return err
@@ -590,7 +643,7 @@ func (d *Driver) startContainer(c types.ContainerJSON) error {
 	var backoff time.Duration
 
 START:
-	startErr := dockerClient.ContainerStart(d.ctx, c.ID, containerapi.StartOptions{})
+	startErr := dockerClient.ContainerStart(d.ctx, c.ID, opts)
 	if startErr == nil || errdefs.IsConflict(err) {
 		return nil
 	}
@@ -610,8 +663,8 @@ START:
 	return recoverableErrTimeouts(startErr)
 }
 
-// createImage creates a docker image either by pulling it from a registry or by
-// loading it from the file system
+// createImage creates a docker image either by pulling it from a registry, by
+// loading it from the file system, or by building it from a Dockerfile
 func (d *Driver) createImage(task *drivers.TaskConfig, driverConfig *TaskConfig, client *client.Client) (string, string, error) {
 	image := driverConfig.Image
 	repo, tag, err := parseDockerImage(image)
@@ -619,6 +672,12 @@ func (d *Driver) createImage(task *drivers.TaskConfig, driverConfig *TaskConfig,
 		return "", "", fmt.Errorf("unable to create local docker image %q: %w", image, err)
 	}
 
+	// Images built from the task directory are rebuilt every time, so that an
+	// image with the same name but built from other sources is never reused
+	if driverConfig.Build.Context != "" {
+		return d.buildImage(task, driverConfig)
+	}
+
 	// We're going to check whether the image is already downloaded. If the tag
 	// is "latest", or ForcePull is set, we have to check for a new version every time so we don't
 	// bother to check and cache the id here. We'll download first, then cache.
@@ -660,23 +719,64 @@ func (d *Driver) pullImage(task *drivers.TaskConfig, driverConfig *TaskConfig, r
 		d.logger.Debug("did not find docker auth for repo", "repo", repo)
 	}
 
-	d.eventer.EmitEvent(&drivers.TaskEvent{
-		TaskID:    task.ID,
-		AllocID:   task.AllocID,
-		TaskName:  task.Name,
-		Timestamp: time.Now(),
-		Message:   "Downloading image",
-		Annotations: map[string]string{
-			"image": dockerImageRef(repo, tag),
-		},
-	})
-
 	pullDur, err := time.ParseDuration(driverConfig.ImagePullTimeout)
 	if err != nil {
 		return "", "", fmt.Errorf("Failed to parse image_pull_timeout: %v", err)
 	}
 
-	return d.coordinator.PullImage(driverConfig.Image, authOptions, task.ID, d.emitEventFunc(task), pullDur, d.config.pullActivityTimeoutDuration)
+	message := "Downloading image"
+	annotations := map[string]string{
+		"image": dockerImageRef(repo, tag),
+	}
+
+	var lazy bool
+	if driverConfig.LazyPull {
+		snapshotter, err := d.lazyPullSnapshotter()
+		switch {
+		case err == nil:
+			lazy = true
+			message = "Lazily pulling image"
+			annotations["snapshotter"] = snapshotter
+		case driverConfig.LazyPullFallback:
+			d.logger.Warn("lazy pulling unavailable, downloading image", "image_ref", dockerImageRef(repo, tag), "error", err)
+			message = "Lazy pulling unavailable, downloading image"
+		default:
+			return "", "", err
+		}
+	}
+
+	d.eventer.EmitEvent(&drivers.TaskEvent{
+		TaskID:      task.ID,
+		AllocID:     task.AllocID,
+		TaskName:    task.Name,
+		Timestamp:   time.Now(),
+		Message:     message,
+		Annotations: annotations,
+	})
+
+	id, user, err = d.coordinator.PullImage(driverConfig.Image, authOptions, task.ID, d.emitEventFunc(task), pullDur, d.config.pullActivityTimeoutDuration)
+	if err == nil && lazy {
+		d.emitEventFunc(task)("Image pulled, contents will be fetched on demand", annotations)
+	}
+	return id, user, err
+}
+
+// lazyPullSnapshotter returns the snapshotter of the Docker daemon, or an
+// error if the images it pulls aren't fetched lazily.
+func (d *Driver) lazyPullSnapshotter() (string, error) {
+	dockerClient, err := d.getDockerClient()
+	if err != nil {
+		return "", fmt.Errorf("Failed to create docker client: %v", err)
+	}
+	info, err := dockerClient.Info(d.ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch docker daemon info: %v", err)
+	}
+	if !supportsLazyPull(&info) {
+		return "", fmt.Errorf("lazy pulling requires the Docker daemon to use the containerd image store with one of the %s snapshotters, but it uses %q",
+			strings.Join(lazyPullSnapshotters, ", "), info.Driver)
+	}
+	return info.Driver, nil
 }
 
 func (d *Driver) emitEventFunc(task *drivers.TaskConfig) LogEventFn {
@@ -948,6 +1048,30 @@ func memoryLimits(driverHardLimitMB int64, taskMemory drivers.MemoryResources) (
 	return hard * 1024 * 1024, softBytes
 }
 
+// cpuLimits returns the CFS period and quota of the container of a task, or
+// zeros if its CPU isn't limited.
+func cpuLimits(driverConfig *TaskConfig, resources *drivers.LinuxResources) (period, quota int64, err error) {
+	// Calculate CPU Quota
+	// cfs_quota_us is the time per core, so we must
+	// multiply the time by the number of cores available
+	// See https://access.redhat.com/documentation/en-us/red_hat_enterprise_linux/6/html/resource_management_guide/sec-cpu
+	if driverConfig.CPUHardLimit {
+		numCores := runtime.NumCPU()
+		if driverConfig.CPUCFSPeriod < 0 || driverConfig.CPUCFSPeriod > 1000000 {
+			return 0, 0, fmt.Errorf("invalid value for cpu_cfs_period")
+		}
+		period = driverConfig.CPUCFSPeriod
+		if period == 0 {
+			period = resources.CPUPeriod
+		}
+		return period, int64(resources.PercentTicks*float64(period)) * int64(numCores), nil
+	} else if resources.CPUQuota > 0 {
+		// burstable tasks are capped at their maximum CPU
+		return resources.CPUPeriod, resources.CPUQuota, nil
+	}
+	return 0, 0, nil
+}
+
 func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *TaskConfig,
 	imageID string) (createContainerOptions, error) {
 
@@ -1053,6 +1177,19 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		PidsLimit:         &pidsLimit,
 	}
 
+	if limits := task.Resources.LinuxResources.DiskIO; limits != nil {
+		throttle := func(rate int64) []*blkiodev.ThrottleDevice {
+			if rate <= 0 {
+				return nil
+			}
+			return []*blkiodev.ThrottleDevice{{Path: limits.Device, Rate: uint64(rate)}}
+		}
+		hostConfig.BlkioDeviceReadBps = throttle(limits.ReadBPS)
+		hostConfig.BlkioDeviceWriteBps = throttle(limits.WriteBPS)
+		hostConfig.BlkioDeviceReadIOps = throttle(limits.ReadIOPS)
+		hostConfig.BlkioDeviceWriteIOps = throttle(limits.WriteIOPS)
+	}
+
 	// Setting cpuset_cpus in driver config is no longer supported (it has
 	// not worked correctly since Nomad 0.12)
 	if driverConfig.CPUSetCPUs != "" {
@@ -1064,20 +1201,9 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		hostConfig.Init = &driverConfig.Init
 	}
 
-	// Calculate CPU Quota
-	// cfs_quota_us is the time per core, so we must
-	// multiply the time by the number of cores available
-	// See https://access.redhat.com/documentation/en-us/red_hat_enterprise_linux/6/html/resource_management_guide/sec-cpu
-	if driverConfig.CPUHardLimit {
-		numCores := runtime.NumCPU()
-		if driverConfig.CPUCFSPeriod < 0 || driverConfig.CPUCFSPeriod > 1000000 {
-			return c, fmt.Errorf("invalid value for cpu_cfs_period")
-		}
-		if driverConfig.CPUCFSPeriod == 0 {
-			driverConfig.CPUCFSPeriod = task.Resources.LinuxResources.CPUPeriod
-		}
-		hostConfig.CPUPeriod = driverConfig.CPUCFSPeriod
-		hostConfig.CPUQuota = int64(task.Resources.LinuxResources.PercentTicks*float64(driverConfig.CPUCFSPeriod)) * int64(numCores)
+	hostConfig.CPUPeriod, hostConfig.CPUQuota, err = cpuLimits(driverConfig, task.Resources.LinuxResources)
+	if err != nil {
+		return c, err
 	}
 
 	// Windows does not support MemorySwap/MemorySwappiness #2193
@@ -1147,8 +1273,18 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		hostConfig.ShmSize = driverConfig.ShmSize
 	}
 
-	// Setup devices from Docker-specific config
+	// Setup devices from Docker-specific config. Devices named after CDI
+	// devices are injected by the Docker daemon from their CDI spec.
+	var cdiDevices []string
 	for _, device := range driverConfig.Devices {
+		if isCDIDeviceName(device.HostPath) {
+			if device.ContainerPath != "" || device.CgroupPermissions != "" {
+				return c, fmt.Errorf("container path and cgroup permissions can't be set for CDI device %q", device.HostPath)
+			}
+			cdiDevices = append(cdiDevices, device.HostPath)
+			continue
+		}
+
 		dd, err := device.toDockerDevice()
 		if err != nil {
 			return c, err
@@ -1158,6 +1294,11 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 
 	// Setup devices from Nomad device plugins
 	for _, device := range task.Devices {
+		if device.CDIName != "" {
+			cdiDevices = append(cdiDevices, device.CDIName)
+			continue
+		}
+
 		hostConfig.Devices = append(hostConfig.Devices, containerapi.DeviceMapping{
 			PathOnHost:        device.HostPath,
 			PathInContainer:   device.TaskPath,
@@ -1165,6 +1306,13 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		})
 	}
 
+	if len(cdiDevices) > 0 {
+		hostConfig.DeviceRequests = append(hostConfig.DeviceRequests, containerapi.DeviceRequest{
+			Driver:    "cdi",
+			DeviceIDs: cdiDevices,
+		})
+	}
+
 	// Setup mounts
 	for _, m := range driverConfig.Mounts {
 		hm, err := d.toDockerMount(&m, task)
@@ -1733,6 +1881,14 @@ func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
 		status.State = drivers.TaskStateExited
 	}
 
+	// Report the status of the HEALTHCHECK of the image for docker checks
+	if health := container.State.Health; health != nil && health.Status != containerapi.NoHealthcheck {
+		status.DriverAttributes[drivers.TaskAttrHealthStatus] = health.Status
+		if n := len(health.Log); n > 0 {
+			status.DriverAttributes[drivers.TaskAttrHealthOutput] = strings.TrimSpace(health.Log[n-1].Output)
+		}
+	}
+
 	return status, nil
 }
 
@@ -1766,6 +1922,63 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return h.dockerClient.ContainerKill(d.ctx, h.containerID, signal)
 }
 
+var _ drivers.ResourceUpdateDriver = (*Driver)(nil)
+
+// UpdateTaskResources updates the CPU and memory limits of the container of a
+// running task to its new resources.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+	if resources == nil || resources.NomadResources == nil || resources.LinuxResources == nil {
+		return fmt.Errorf("task resources are empty")
+	}
+
+	var driverConfig TaskConfig
+	if err := h.task.DecodeDriverConfig(&driverConfig); err != nil {
+		return fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	update, err := containerResources(&driverConfig, resources)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Debug("updating container resources",
+		"memory", update.Memory, "memory_reservation", update.MemoryReservation,
+		"cpu_shares", update.CPUShares, "cpu_quota", update.CPUQuota,
+		"cpu_period", update.CPUPeriod)
+
+	_, err = h.dockerClient.ContainerUpdate(d.ctx, h.containerID, containerapi.UpdateConfig{
+		Resources: update,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update container resources: %v", err)
+	}
+	return nil
+}
+
+// containerResources returns the CPU and memory limits of the container of a
+// task that can be updated while it runs.
+func containerResources(driverConfig *TaskConfig, resources *drivers.Resources) (containerapi.Resources, error) {
+	memory, memoryReservation := memoryLimits(driverConfig.MemoryHardLimit, resources.NomadResources.Memory)
+	r := containerapi.Resources{
+		Memory:            memory,
+		MemoryReservation: memoryReservation,
+		CPUShares:         resources.LinuxResources.CPUShares,
+	}
+
+	// the swap limit can't be lower than the memory limit
+	if runtime.GOOS != "windows" {
+		r.MemorySwap = memory
+	}
+
+	var err error
+	r.CPUPeriod, r.CPUQuota, err = cpuLimits(driverConfig, resources.LinuxResources)
+	return r, err
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	h, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/blkiodev"
 	"github.com/docker/docker/api/types/container"
 	containerapi "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
@@ -452,7 +453,7 @@ func TestDockerDriver_ContainerAlreadyExists(t *testing.T) {
 	must.NoError(t, err)
 	defer client.ContainerRemove(ctx, c.ID, containerapi.RemoveOptions{Force: true})
 
-	must.NoError(t, d.startContainer(*c))
+	must.NoError(t, d.startContainer(*c, containerapi.StartOptions{}))
 	_, _, err = d.StartTask(task)
 	must.NoError(t, err)
 	d.DestroyTask(task.ID, true)
@@ -1124,6 +1125,67 @@ func TestDockerDriver_CreateContainerConfig(t *testing.T) {
 	must.Eq(t, containerName, c.Name)
 }
 
+func TestDockerDriver_CreateContainerConfig_DiskIO(t *testing.T) {
+	ci.Parallel(t)
+
+	task, cfg, _ := dockerTask(t)
+	must.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	task.Resources.LinuxResources.DiskIO = &drivers.DiskIOLimits{
+		Device:   "/dev/sda",
+		ReadBPS:  1024,
+		WriteBPS: 2048,
+		ReadIOPS: 100,
+	}
+
+	dh := dockerDriverHarness(t, nil)
+	driver := dh.Impl().(*Driver)
+
+	c, err := driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	must.NoError(t, err)
+
+	must.Eq(t, []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 1024}}, c.Host.BlkioDeviceReadBps)
+	must.Eq(t, []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 2048}}, c.Host.BlkioDeviceWriteBps)
+	must.Eq(t, []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 100}}, c.Host.BlkioDeviceReadIOps)
+	must.Nil(t, c.Host.BlkioDeviceWriteIOps)
+}
+
+func TestDockerDriver_CreateContainerConfig_CDIDevices(t *testing.T) {
+	ci.Parallel(t)
+
+	task, cfg, _ := dockerTask(t)
+
+	cfg.Devices = []DockerDevice{
+		{HostPath: "/dev/null", ContainerPath: "/dev/foo"},
+		{HostPath: "vendor.com/fpga=all"},
+	}
+	task.Devices = []*drivers.DeviceConfig{
+		{HostPath: "/dev/zero", TaskPath: "/dev/bar", Permissions: "rw"},
+		{CDIName: "nvidia.com/gpu=0"},
+	}
+	must.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	dh := dockerDriverHarness(t, nil)
+	driver := dh.Impl().(*Driver)
+
+	c, err := driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	must.NoError(t, err)
+
+	must.Eq(t, []containerapi.DeviceMapping{
+		{PathOnHost: "/dev/null", PathInContainer: "/dev/foo", CgroupPermissions: "rwm"},
+		{PathOnHost: "/dev/zero", PathInContainer: "/dev/bar", CgroupPermissions: "rw"},
+	}, c.Host.Devices)
+	must.Eq(t, []containerapi.DeviceRequest{{
+		Driver:    "cdi",
+		DeviceIDs: []string{"vendor.com/fpga=all", "nvidia.com/gpu=0"},
+	}}, c.Host.DeviceRequests)
+
+	// CDI devices are mounted as described by their spec
+	cfg.Devices = []DockerDevice{{HostPath: "vendor.com/fpga=all", ContainerPath: "/dev/fpga"}}
+	_, err = driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	must.ErrorContains(t, err, "can't be set for CDI device")
+}
+
 func TestDockerDriver_CreateContainerConfig_RuntimeConflict(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2954,8 +3016,8 @@ func TestDockerDriver_CreationIdempotent(t *testing.T) {
 	}
 
 	// now start container twice
-	must.NoError(t, d.startContainer(*c2))
-	must.NoError(t, d.startContainer(*c2))
+	must.NoError(t, d.startContainer(*c2, containerapi.StartOptions{}))
+	must.NoError(t, d.startContainer(*c2, containerapi.StartOptions{}))
 
 	tu.WaitForResult(func() (bool, error) {
 		c, err := client.ContainerInspect(ctx, c2.ID)
@@ -3000,6 +3062,27 @@ func TestDockerDriver_CreateContainerConfig_CPUHardLimit(t *testing.T) {
 	must.NonZero(t, c.Host.CPUPeriod)
 }
 
+// TestDockerDriver_CreateContainerConfig_CPUBandwidth asserts that the CPU
+// quota and period capping burstable tasks are set.
+func TestDockerDriver_CreateContainerConfig_CPUBandwidth(t *testing.T) {
+	ci.Parallel(t)
+
+	task, cfg, _ := dockerTask(t)
+	must.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	task.Resources.LinuxResources.CPUPeriod = 100000
+	task.Resources.LinuxResources.CPUQuota = 200000
+
+	dh := dockerDriverHarness(t, nil)
+	driver := dh.Impl().(*Driver)
+
+	c, err := driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	must.NoError(t, err)
+
+	must.Eq(t, 100000, c.Host.CPUPeriod)
+	must.Eq(t, 200000, c.Host.CPUQuota)
+}
+
 func TestDockerDriver_memoryLimits(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3056,6 +3139,61 @@ func TestDockerDriver_memoryLimits(t *testing.T) {
 	}
 }
 
+func TestDockerDriver_containerResources(t *testing.T) {
+	ci.Parallel(t)
+
+	resources := &drivers.Resources{
+		NomadResources: &structs.AllocatedTaskResources{
+			Memory: structs.AllocatedMemoryResources{MemoryMB: 512, MemoryMaxMB: 1024},
+		},
+		LinuxResources: &drivers.LinuxResources{
+			CPUShares: 1000,
+			CPUPeriod: 100000,
+			CPUQuota:  200000,
+		},
+	}
+
+	r, err := containerResources(&TaskConfig{}, resources)
+	must.NoError(t, err)
+	must.Eq(t, 1024*1024*1024, r.Memory)
+	must.Eq(t, 512*1024*1024, r.MemoryReservation)
+	must.Eq(t, 1000, r.CPUShares)
+	must.Eq(t, 100000, r.CPUPeriod)
+	must.Eq(t, 200000, r.CPUQuota)
+	if runtime.GOOS != "windows" {
+		must.Eq(t, r.Memory, r.MemorySwap)
+	}
+
+	_, err = containerResources(&TaskConfig{CPUHardLimit: true, CPUCFSPeriod: -1}, resources)
+	must.ErrorContains(t, err, "invalid value for cpu_cfs_period")
+}
+
+func TestDockerDriver_UpdateTaskResources(t *testing.T) {
+	ci.Parallel(t)
+	testutil.DockerCompatible(t)
+
+	task, cfg, _ := dockerTask(t)
+	must.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	client, d, handle, cleanup := dockerSetup(t, task, nil)
+	defer cleanup()
+	must.NoError(t, d.WaitUntilStarted(task.ID, 5*time.Second))
+
+	resources := task.Resources.Copy()
+	resources.NomadResources.Memory.MemoryMB = 512
+	resources.LinuxResources.CPUShares = 1024
+
+	driver := d.Impl().(*Driver)
+	must.NoError(t, driver.UpdateTaskResources(task.ID, resources))
+
+	container, err := client.ContainerInspect(context.Background(), handle.containerID)
+	must.NoError(t, err)
+	must.Eq(t, 512*1024*1024, container.HostConfig.Memory)
+	must.Eq(t, 1024, container.HostConfig.CPUShares)
+
+	must.ErrorIs(t, driver.UpdateTaskResources("unknown", resources), drivers.ErrTaskNotFound)
+}
+
 func TestDockerDriver_parseSignal(t *testing.T) {
 	ci.Parallel(t)
 
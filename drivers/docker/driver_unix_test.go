@@ -891,3 +891,83 @@ func Test_dnsConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerDriver_Start_BuildImage(t *testing.T) {
+	ci.Parallel(t)
+	testutil.DockerCompatible(t)
+
+	// load the base image of the Dockerfile, so that it isn't pulled
+	taskCfg := newTaskConfig([]string{"sh", "-c", "cat /greeting > $NOMAD_TASK_DIR/output"})
+	archive, err := os.Open(filepath.Join("./test-resources/docker", taskCfg.LoadImage))
+	must.NoError(t, err)
+	defer archive.Close()
+	resp, err := newTestDockerClient(t).ImageLoad(context.Background(), archive)
+	must.NoError(t, err)
+	resp.Body.Close()
+
+	baseImage := taskCfg.Image
+	taskCfg.Image = "nomad-build-test:" + uuid.Short()
+	taskCfg.LoadImage = ""
+	taskCfg.Build = DockerBuild{
+		Context: "local/src",
+		Args:    map[string]string{"GREETING": "hello"},
+	}
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "build-demo",
+		AllocID:   uuid.Generate(),
+		Resources: basicResources,
+	}
+	must.NoError(t, task.EncodeConcreteDriverConfig(&taskCfg))
+
+	d := dockerDriverHarness(t, nil)
+	cleanup := d.MkAllocDir(task, true)
+	defer cleanup()
+
+	src := filepath.Join(task.TaskDir().LocalDir, "src")
+	must.NoError(t, os.MkdirAll(src, 0o755))
+	dockerfile := fmt.Sprintf("FROM %s\nARG GREETING\nRUN echo $GREETING > /greeting\n", baseImage)
+	must.NoError(t, os.WriteFile(filepath.Join(src, "Dockerfile"), []byte(dockerfile), 0o644))
+
+	_, _, err = d.StartTask(task)
+	must.NoError(t, err)
+	defer d.DestroyTask(task.ID, true)
+
+	waitCh, err := d.WaitTask(context.Background(), task.ID)
+	must.NoError(t, err)
+	select {
+	case res := <-waitCh:
+		must.True(t, res.Successful(), must.Sprintf("ExitResult should be successful: %v", res))
+	case <-time.After(time.Duration(ntestutil.TestMultiplier()*10) * time.Second):
+		t.Fatal("timeout")
+	}
+
+	act, err := os.ReadFile(filepath.Join(task.TaskDir().LocalDir, "output"))
+	must.NoError(t, err)
+	must.Eq(t, "hello", strings.TrimSpace(string(act)))
+}
+
+func TestDockerDriver_Start_BuildImage_EscapesTaskDir(t *testing.T) {
+	ci.Parallel(t)
+	testutil.DockerCompatible(t)
+
+	taskCfg := newTaskConfig([]string{"true"})
+	taskCfg.LoadImage = ""
+	taskCfg.Build = DockerBuild{Context: "../../"}
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "build-demo",
+		AllocID:   uuid.Generate(),
+		Resources: basicResources,
+	}
+	must.NoError(t, task.EncodeConcreteDriverConfig(&taskCfg))
+
+	d := dockerDriverHarness(t, nil)
+	cleanup := d.MkAllocDir(task, true)
+	defer cleanup()
+
+	_, _, err := d.StartTask(task)
+	must.ErrorContains(t, err, "escapes the task directory")
+}
@@ -5,6 +5,7 @@ package docker
 
 import (
 	"context"
+	"os/exec"
 	"runtime"
 	"sort"
 	"strings"
@@ -184,6 +185,26 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 			strings.Join(runtimeNames, ","))
 		fp.Attributes["driver.docker.os_type"] = pstructs.NewStringAttribute(dockerInfo.OSType)
 
+		if snapshotter := containerdSnapshotter(&dockerInfo); snapshotter != "" {
+			fp.Attributes["driver.docker.snapshotter"] = pstructs.NewStringAttribute(snapshotter)
+		}
+		if supportsLazyPull(&dockerInfo) {
+			fp.Attributes["driver.docker.lazy_pull"] = pstructs.NewBoolAttribute(true)
+		}
+
+		// docker checkpoint is only available to experimental daemons, and
+		// requires CRIU to be installed
+		d.checkpoint = dockerInfo.ExperimentalBuild && dockerInfo.OSType == "linux" &&
+			criuInstalled()
+		if d.checkpoint {
+			fp.Attributes["driver.docker.checkpoint"] = pstructs.NewBoolAttribute(true)
+		}
+
+		// the CDI spec dirs are only reported when CDI is enabled
+		if len(dockerInfo.CDISpecDirs) > 0 {
+			fp.Attributes["driver.docker.cdi"] = pstructs.NewBoolAttribute(true)
+		}
+
 		// If this situations arises, we are running in Windows 10 with Linux Containers enabled via VM
 		if runtime.GOOS == "windows" && dockerInfo.OSType == "linux" {
 			if d.fingerprintSuccessful() {
@@ -202,3 +223,10 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 
 	return fp
 }
+
+// criuInstalled returns whether the CRIU binary docker checkpoints containers
+// with is installed.
+func criuInstalled() bool {
+	_, err := exec.LookPath("criu")
+	return err == nil
+}
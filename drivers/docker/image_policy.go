@@ -0,0 +1,686 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package docker
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/client"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// imagePolicyCosign and imagePolicyNotation are the signatures the image
+	// policy verifies
+	imagePolicyCosign   = "cosign"
+	imagePolicyNotation = "notation"
+
+	// cosignSignatureAnnotation is the annotation of the layers of cosign
+	// signature manifests holding the signature of the layer
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// notationArtifactType is the artifact type of Notation signatures
+	notationArtifactType = "application/vnd.cncf.notary.signature"
+
+	// notationMediaTypeJWS is the media type of Notation signature envelopes
+	// in the JWS format
+	notationMediaTypeJWS = "application/jose+json"
+
+	// registryMaxBytes is the maximum size of the manifests and signatures
+	// read from registries
+	registryMaxBytes = 4 << 20
+)
+
+// imagePolicy is the image policy of the driver, parsed from its
+// configuration.
+type imagePolicy struct {
+	requireDigest bool
+	auditOnly     bool
+
+	cosignKeys         []crypto.PublicKey
+	notationRoots      *x509.CertPool
+	notationIdentities []string
+
+	// httpClient is used to fetch signatures from registries
+	httpClient *http.Client
+}
+
+// newImagePolicy returns the image policy of the configuration, or nil if the
+// configuration has no policy.
+func newImagePolicy(c *ImagePolicyConfig) (*imagePolicy, error) {
+	if !c.RequireDigest && len(c.Cosign.PublicKeys) == 0 && c.Notation.TrustStore == "" {
+		return nil, nil
+	}
+
+	p := &imagePolicy{
+		requireDigest:      c.RequireDigest,
+		auditOnly:          c.AuditOnly,
+		notationIdentities: slices.Clone(c.Notation.TrustedIdentities),
+		httpClient:         &http.Client{Timeout: time.Minute},
+	}
+
+	for _, path := range c.Cosign.PublicKeys {
+		key, err := readPublicKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cosign public key %q: %v", path, err)
+		}
+		p.cosignKeys = append(p.cosignKeys, key)
+	}
+
+	if c.Notation.TrustStore != "" {
+		b, err := os.ReadFile(c.Notation.TrustStore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read notation trust store: %v", err)
+		}
+		p.notationRoots = x509.NewCertPool()
+		if !p.notationRoots.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("notation trust store %q has no certificates", c.Notation.TrustStore)
+		}
+	} else if len(c.Notation.TrustedIdentities) > 0 {
+		return nil, errors.New("notation trusted_identities requires a trust_store")
+	}
+
+	return p, nil
+}
+
+// readPublicKey reads the PEM encoded public key at path.
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// verifiesSignatures returns whether the policy requires images to be signed.
+func (p *imagePolicy) verifiesSignatures() bool {
+	return len(p.cosignKeys) > 0 || p.notationRoots != nil
+}
+
+// enforceImagePolicy checks that the image of the task complies with the
+// image policy of the driver, and records the result in a task event. Tasks
+// whose images don't comply are rejected, unless the policy is audit only.
+func (d *Driver) enforceImagePolicy(task *drivers.TaskConfig, driverConfig *TaskConfig, imageID string, dockerClient *client.Client) error {
+	policy := d.config.imagePolicy
+	if policy == nil {
+		return nil
+	}
+
+	annotations := map[string]string{"image": driverConfig.Image}
+	if policy.auditOnly {
+		annotations["mode"] = "audit"
+	} else {
+		annotations["mode"] = "enforce"
+	}
+
+	result, err := d.checkImagePolicy(driverConfig, imageID, dockerClient)
+	if result != nil {
+		annotations["digest"] = result.digest
+		if result.signature != "" {
+			annotations["signature"] = result.signature
+		}
+	}
+	if err == nil {
+		d.emitEventFunc(task)("Image complies with image policy", annotations)
+		return nil
+	}
+
+	annotations["error"] = err.Error()
+	if policy.auditOnly {
+		d.logger.Warn("image does not comply with image policy", "image", driverConfig.Image, "error", err)
+		d.emitEventFunc(task)("Image does not comply with image policy", annotations)
+		return nil
+	}
+
+	d.emitEventFunc(task)("Image rejected by image policy", annotations)
+	d.coordinator.RemoveImage(imageID, task.ID)
+	return nstructs.NewRecoverableError(
+		fmt.Errorf("image %q rejected by image policy: %v", driverConfig.Image, err), false)
+}
+
+// imagePolicyResult is the result of checking an image against the policy.
+type imagePolicyResult struct {
+	// digest is the digest of the manifest of the image
+	digest string
+
+	// signature is the type of the signature which was verified
+	signature string
+}
+
+// checkImagePolicy checks the image against the image policy of the driver.
+func (d *Driver) checkImagePolicy(driverConfig *TaskConfig, imageID string, dockerClient *client.Client) (*imagePolicyResult, error) {
+	policy := d.config.imagePolicy
+
+	named, err := reference.ParseNormalizedNamed(driverConfig.Image)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image reference: %v", err)
+	}
+	canonical, pinned := named.(reference.Canonical)
+	if policy.requireDigest && !pinned {
+		return nil, errors.New("image is not pinned by digest")
+	}
+
+	result := &imagePolicyResult{}
+	if pinned {
+		result.digest = canonical.Digest().String()
+	} else {
+		// the digest of images pulled by tag is only known by the registry,
+		// so images built or loaded from the task directory have none
+		inspect, _, err := dockerClient.ImageInspectWithRaw(d.ctx, imageID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect image: %v", err)
+		}
+		result.digest = repoDigest(named, inspect.RepoDigests)
+		if result.digest == "" && policy.verifiesSignatures() {
+			return nil, errors.New("image has no digest in its registry")
+		}
+	}
+
+	if !policy.verifiesSignatures() {
+		return result, nil
+	}
+
+	repo, _, _ := parseDockerImage(driverConfig.Image)
+	auth, err := d.resolveRegistryAuthentication(driverConfig, repo)
+	if err != nil {
+		d.logger.Debug("failed to find docker auth to verify image signatures", "repo", repo, "error", err)
+	}
+
+	rc := newRegistryClient(d.ctx, policy.httpClient, named, auth)
+	result.signature, err = policy.verify(rc, result.digest)
+	return result, err
+}
+
+// repoDigest returns the digest of the image in the repository of named, out
+// of the repository digests of the image.
+func repoDigest(named reference.Named, repoDigests []string) string {
+	for _, rd := range repoDigests {
+		ref, err := reference.ParseNormalizedNamed(rd)
+		if err != nil {
+			continue
+		}
+		if canonical, ok := ref.(reference.Canonical); ok && ref.Name() == named.Name() {
+			return canonical.Digest().String()
+		}
+	}
+	return ""
+}
+
+// verify verifies that the manifest with the digest was signed as required by
+// the policy, and returns the type of the signature it verified.
+func (p *imagePolicy) verify(rc *registryClient, dgst string) (string, error) {
+	var errs []string
+	if len(p.cosignKeys) > 0 {
+		err := p.verifyCosign(rc, dgst)
+		if err == nil {
+			return imagePolicyCosign, nil
+		}
+		errs = append(errs, fmt.Sprintf("cosign: %v", err))
+	}
+	if p.notationRoots != nil {
+		err := p.verifyNotation(rc, dgst)
+		if err == nil {
+			return imagePolicyNotation, nil
+		}
+		errs = append(errs, fmt.Sprintf("notation: %v", err))
+	}
+	return "", fmt.Errorf("no valid signature: %s", strings.Join(errs, "; "))
+}
+
+// verifyCosign verifies the cosign signatures of the manifest, stored in the
+// repository under the tag derived from its digest.
+func (p *imagePolicy) verifyCosign(rc *registryClient, dgst string) error {
+	tag := strings.Replace(dgst, ":", "-", 1) + ".sig"
+	var manifest ocispec.Manifest
+	if err := rc.getJSON("/manifests/"+tag, ocispec.MediaTypeImageManifest, &manifest); err != nil {
+		return err
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(signature)
+		if err != nil {
+			continue
+		}
+		payload, err := rc.getBlob(layer.Digest.String())
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(p.cosignKeys, func(key crypto.PublicKey) bool {
+			return verifySignature(key, payload, sig) == nil
+		}) {
+			continue
+		}
+
+		// the payload must sign this very manifest
+		var simpleSigning struct {
+			Critical struct {
+				Image struct {
+					DockerManifestDigest string `json:"docker-manifest-digest"`
+				} `json:"image"`
+			} `json:"critical"`
+		}
+		if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+			continue
+		}
+		if simpleSigning.Critical.Image.DockerManifestDigest == dgst {
+			return nil
+		}
+	}
+	return errors.New("no signature by the public keys")
+}
+
+// verifySignature verifies the signature of the SHA-256 digest of payload, as
+// made by cosign.
+func verifySignature(key crypto.PublicKey, payload, sig []byte) error {
+	sum := sha256.Sum256(payload)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, sum[:], sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// verifyNotation verifies the Notation signatures of the manifest, found with
+// the referrers API of the registry.
+func (p *imagePolicy) verifyNotation(rc *registryClient, dgst string) error {
+	referrers, err := rc.referrers(dgst, notationArtifactType)
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, desc := range referrers {
+		var manifest ocispec.Manifest
+		if err := rc.getJSON("/manifests/"+desc.Digest.String(), ocispec.MediaTypeImageManifest, &manifest); err != nil {
+			return err
+		}
+		for _, layer := range manifest.Layers {
+			if layer.MediaType != notationMediaTypeJWS {
+				errs = append(errs, fmt.Sprintf("unsupported signature envelope %q", layer.MediaType))
+				continue
+			}
+			envelope, err := rc.getBlob(layer.Digest.String())
+			if err != nil {
+				return err
+			}
+			if err := p.verifyNotationJWS(envelope, dgst); err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			return nil
+		}
+	}
+	if len(errs) == 0 {
+		return errors.New("no signature")
+	}
+	return errors.New(strings.Join(errs, "; "))
+}
+
+// verifyNotationJWS verifies the Notation signature envelope in the JWS
+// format: its certificate chain must be issued by the trust store, and it
+// must sign the manifest with the digest.
+func (p *imagePolicy) verifyNotationJWS(envelope []byte, dgst string) error {
+	var jws struct {
+		Payload   string `json:"payload"`
+		Protected string `json:"protected"`
+		Header    struct {
+			X5C []string `json:"x5c"`
+		} `json:"header"`
+		Signature string `json:"signature"`
+	}
+	if err := json.Unmarshal(envelope, &jws); err != nil {
+		return fmt.Errorf("invalid signature envelope: %v", err)
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		return fmt.Errorf("invalid protected header: %v", err)
+	}
+	var header struct {
+		Alg         string    `json:"alg"`
+		SigningTime time.Time `json:"io.cncf.notary.signingTime"`
+	}
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return fmt.Errorf("invalid protected header: %v", err)
+	}
+
+	if len(jws.Header.X5C) == 0 {
+		return errors.New("signature has no certificate chain")
+	}
+	var chain []*x509.Certificate
+	for _, c := range jws.Header.X5C {
+		der, err := base64.StdEncoding.DecodeString(c)
+		if err != nil {
+			return fmt.Errorf("invalid certificate: %v", err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("invalid certificate: %v", err)
+		}
+		chain = append(chain, cert)
+	}
+	leaf := chain[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	// signatures stay valid after their certificate expires, as long as
+	// they were made while it was valid
+	verifyTime := header.SigningTime
+	if verifyTime.IsZero() {
+		verifyTime = time.Now()
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         p.notationRoots,
+		Intermediates: intermediates,
+		CurrentTime:   verifyTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("certificate is not issued by the trust store: %v", err)
+	}
+	if len(p.notationIdentities) > 0 && !slices.Contains(p.notationIdentities, leaf.Subject.String()) {
+		return fmt.Errorf("certificate subject %q is not trusted", leaf.Subject.String())
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(jws.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %v", err)
+	}
+	if err := verifyJWS(header.Alg, leaf.PublicKey, []byte(jws.Protected+"."+jws.Payload), sig); err != nil {
+		return err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+	var target struct {
+		TargetArtifact ocispec.Descriptor `json:"targetArtifact"`
+	}
+	if err := json.Unmarshal(payload, &target); err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+	if target.TargetArtifact.Digest.String() != dgst {
+		return fmt.Errorf("signature is for %q", target.TargetArtifact.Digest)
+	}
+	return nil
+}
+
+// verifyJWS verifies the JWS signature of signed made with the algorithm.
+func verifyJWS(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "PS256", "ES256":
+		hash = crypto.SHA256
+	case "PS384", "ES384":
+		hash = crypto.SHA384
+	case "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signature algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write(signed)
+	sum := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "PS") {
+			return fmt.Errorf("signature algorithm %q does not match RSA key", alg)
+		}
+		if err := rsa.VerifyPSS(key, hash, sum, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+			return errors.New("invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") || len(sig)%2 != 0 {
+			return fmt.Errorf("signature algorithm %q does not match ECDSA key", alg)
+		}
+		r := new(big.Int).SetBytes(sig[:len(sig)/2])
+		s := new(big.Int).SetBytes(sig[len(sig)/2:])
+		if !ecdsa.Verify(key, sum, r, s) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	return nil
+}
+
+// registryClient reads the manifests and blobs of a repository with the
+// registry API, authenticating as requested by the registry.
+type registryClient struct {
+	ctx        context.Context
+	httpClient *http.Client
+	endpoint   string
+	repository string
+	auth       *registry.AuthConfig
+	token      string
+	basic      bool
+}
+
+func newRegistryClient(ctx context.Context, httpClient *http.Client, named reference.Named, auth *registry.AuthConfig) *registryClient {
+	domain := reference.Domain(named)
+	if domain == "docker.io" {
+		domain = "registry-1.docker.io"
+	}
+	return &registryClient{
+		ctx:        ctx,
+		httpClient: httpClient,
+		endpoint:   "https://" + domain + "/v2/" + reference.Path(named),
+		repository: reference.Path(named),
+		auth:       auth,
+	}
+}
+
+// getJSON decodes the JSON document at the path of the repository.
+func (c *registryClient) getJSON(path, accept string, v any) error {
+	b, err := c.get(path, accept)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// getBlob returns the blob with the SHA-256 digest, verifying its content.
+func (c *registryClient) getBlob(dgst string) ([]byte, error) {
+	b, err := c.get("/blobs/"+dgst, "")
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	if dgst != "sha256:"+hex.EncodeToString(sum[:]) {
+		return nil, fmt.Errorf("blob does not match digest %q", dgst)
+	}
+	return b, nil
+}
+
+// referrers returns the manifests of the artifact type referring to the
+// manifest with the digest, falling back to the referrers tag schema for
+// registries without the referrers API.
+func (c *registryClient) referrers(dgst, artifactType string) ([]ocispec.Descriptor, error) {
+	var index ocispec.Index
+	err := c.getJSON("/referrers/"+dgst+"?artifactType="+url.QueryEscape(artifactType), ocispec.MediaTypeImageIndex, &index)
+	if errors.Is(err, errRegistryNotFound) {
+		index = ocispec.Index{}
+		err = c.getJSON("/manifests/"+strings.Replace(dgst, ":", "-", 1), ocispec.MediaTypeImageIndex, &index)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ocispec.Descriptor
+	for _, desc := range index.Manifests {
+		if desc.ArtifactType == artifactType {
+			result = append(result, desc)
+		}
+	}
+	return result, nil
+}
+
+// errRegistryNotFound is returned when the registry doesn't have the
+// requested manifest or blob
+var errRegistryNotFound = errors.New("not found")
+
+// get returns the document at the path of the repository in the registry.
+func (c *registryClient) get(path, accept string) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, c.endpoint+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		switch {
+		case c.token != "":
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		case c.basic:
+			req.SetBasicAuth(c.auth.Username, c.auth.Password)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reach registry: %w", err)
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, registryMaxBytes))
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return body, nil
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, fmt.Errorf("%w: %s", errRegistryNotFound, path)
+		case resp.StatusCode == http.StatusUnauthorized && attempt == 0:
+			if err := c.authenticate(resp.Header.Get("WWW-Authenticate")); err != nil {
+				return nil, fmt.Errorf("failed to authenticate to registry: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("registry returned %s for %s", resp.Status, path)
+		}
+	}
+}
+
+// challengeParamRe matches the parameters of WWW-Authenticate challenges
+var challengeParamRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authenticate answers the WWW-Authenticate challenge of the registry, either
+// with basic auth or by requesting a bearer token from the token server.
+func (c *registryClient) authenticate(challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.auth == nil || c.auth.Username == "" {
+			return errors.New("registry requires credentials")
+		}
+		c.basic = true
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	if c.auth != nil && c.auth.RegistryToken != "" {
+		c.token = c.auth.RegistryToken
+		return nil
+	}
+
+	values := make(map[string]string)
+	for _, m := range challengeParamRe.FindAllStringSubmatch(params, -1) {
+		values[m[1]] = m[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Host == "" {
+		return fmt.Errorf("invalid token realm in challenge %q", challenge)
+	}
+	q := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v := values[k]; v != "" {
+			q.Set(k, v)
+		}
+	}
+	if q.Get("scope") == "" {
+		q.Set("scope", "repository:"+c.repository+":pull")
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.auth != nil && c.auth.Username != "" {
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token server returned %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, registryMaxBytes)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode token: %w", err)
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	if c.token == "" {
+		return errors.New("token server returned no token")
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package docker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/hashicorp/nomad/ci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/shoenig/test/must"
+)
+
+func testDigest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// testDescriptor returns the descriptor of the content b.
+func testDescriptor(t *testing.T, mediaType string, b []byte) ocispec.Descriptor {
+	var desc ocispec.Descriptor
+	must.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{"mediaType":%q,"digest":%q,"size":%d}`,
+		mediaType, testDigest(b), len(b))), &desc))
+	return desc
+}
+
+// testImageRegistry is a registry serving the signatures of the image
+// manifest with imageDigest, requiring a bearer token.
+type testImageRegistry struct {
+	srv       *httptest.Server
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	referrers bool
+}
+
+func newTestImageRegistry(t *testing.T) *testImageRegistry {
+	r := &testImageRegistry{
+		manifests: make(map[string][]byte),
+		blobs:     make(map[string][]byte),
+	}
+	r.srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			w.Write([]byte(`{"token":"t0ken"}`))
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer t0ken" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+r.srv.URL+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var b []byte
+		switch path := strings.TrimPrefix(req.URL.Path, "/v2/example/app"); {
+		case strings.HasPrefix(path, "/manifests/"):
+			b = r.manifests[strings.TrimPrefix(path, "/manifests/")]
+		case strings.HasPrefix(path, "/blobs/"):
+			b = r.blobs[strings.TrimPrefix(path, "/blobs/")]
+		case strings.HasPrefix(path, "/referrers/") && r.referrers:
+			b = r.manifests["referrers"]
+		}
+		if b == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	}))
+	t.Cleanup(r.srv.Close)
+	return r
+}
+
+func (r *testImageRegistry) addBlob(t *testing.T, mediaType string, b []byte) ocispec.Descriptor {
+	r.blobs[testDigest(b)] = b
+	return testDescriptor(t, mediaType, b)
+}
+
+func (r *testImageRegistry) addManifest(t *testing.T, tag string, v any) []byte {
+	b, err := json.Marshal(v)
+	must.NoError(t, err)
+	r.manifests[tag] = b
+	r.manifests[testDigest(b)] = b
+	return b
+}
+
+func (r *testImageRegistry) client(t *testing.T) *registryClient {
+	named, err := reference.ParseNormalizedNamed(strings.TrimPrefix(r.srv.URL, "https://") + "/example/app")
+	must.NoError(t, err)
+	return newRegistryClient(context.Background(), r.srv.Client(), named, nil)
+}
+
+func writePEM(t *testing.T, typ string, der []byte) string {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	must.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o644))
+	return path
+}
+
+func TestImagePolicy_Cosign(t *testing.T) {
+	ci.Parallel(t)
+
+	imageDigest := testDigest([]byte("image manifest"))
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	must.NoError(t, err)
+
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"example/app"},"image":{"docker-manifest-digest":"` +
+		imageDigest + `"},"type":"cosign container image signature"},"optional":null}`)
+	sum := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+	must.NoError(t, err)
+
+	registry := newTestImageRegistry(t)
+	layer := registry.addBlob(t, "application/vnd.dev.cosign.simplesigning.v1+json", payload)
+	layer.Annotations = map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)}
+	registry.addManifest(t, strings.Replace(imageDigest, ":", "-", 1)+".sig", ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Layers:    []ocispec.Descriptor{layer},
+	})
+
+	policy, err := newImagePolicy(&ImagePolicyConfig{
+		Cosign: CosignPolicyConfig{PublicKeys: []string{writePEM(t, "PUBLIC KEY", der)}},
+	})
+	must.NoError(t, err)
+
+	signature, err := policy.verify(registry.client(t), imageDigest)
+	must.NoError(t, err)
+	must.Eq(t, imagePolicyCosign, signature)
+
+	// the signature is for another image
+	_, err = policy.verify(registry.client(t), testDigest([]byte("other manifest")))
+	must.ErrorContains(t, err, "no valid signature")
+
+	// signed by another key
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(&other.PublicKey)
+	must.NoError(t, err)
+	policy, err = newImagePolicy(&ImagePolicyConfig{
+		Cosign: CosignPolicyConfig{PublicKeys: []string{writePEM(t, "PUBLIC KEY", der)}},
+	})
+	must.NoError(t, err)
+	_, err = policy.verify(registry.client(t), imageDigest)
+	must.ErrorContains(t, err, "no signature by the public keys")
+}
+
+func TestImagePolicy_Notation(t *testing.T) {
+	ci.Parallel(t)
+
+	imageDigest := testDigest([]byte("image manifest"))
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "example ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	must.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	must.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "release", Organization: []string{"Example"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}, ca, &key.PublicKey, caKey)
+	must.NoError(t, err)
+
+	// JWS envelope signing the image manifest
+	protected := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","cty":"application/vnd.cncf.notary.payload.v1+json","io.cncf.notary.signingTime":"` +
+		time.Now().Format(time.RFC3339) + `"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"targetArtifact":{"mediaType":"` +
+		ocispec.MediaTypeImageManifest + `","digest":"` + imageDigest + `","size":14}}`))
+	sum := sha256.Sum256([]byte(protected + "." + payload))
+	r, s, err := ecdsa.Sign(rand.Reader, key, sum[:])
+	must.NoError(t, err)
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	envelope, err := json.Marshal(map[string]any{
+		"payload":   payload,
+		"protected": protected,
+		"header":    map[string]any{"x5c": []string{base64.StdEncoding.EncodeToString(leafDER)}},
+		"signature": base64.RawURLEncoding.EncodeToString(sig),
+	})
+	must.NoError(t, err)
+
+	registry := newTestImageRegistry(t)
+	layer := registry.addBlob(t, notationMediaTypeJWS, envelope)
+	signatureManifest := registry.addManifest(t, "signature", ocispec.Manifest{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: notationArtifactType,
+		Layers:       []ocispec.Descriptor{layer},
+	})
+	signatureDesc := testDescriptor(t, ocispec.MediaTypeImageManifest, signatureManifest)
+	signatureDesc.ArtifactType = notationArtifactType
+	referrers := ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{signatureDesc},
+	}
+
+	// registries without the referrers API use the referrers tag schema
+	registry.addManifest(t, strings.Replace(imageDigest, ":", "-", 1), referrers)
+
+	trustStore := writePEM(t, "CERTIFICATE", caDER)
+	policy, err := newImagePolicy(&ImagePolicyConfig{
+		Notation: NotationPolicyConfig{
+			TrustStore:        trustStore,
+			TrustedIdentities: []string{"CN=release,O=Example"},
+		},
+	})
+	must.NoError(t, err)
+
+	signature, err := policy.verify(registry.client(t), imageDigest)
+	must.NoError(t, err)
+	must.Eq(t, imagePolicyNotation, signature)
+
+	// with the referrers API
+	registry.referrers = true
+	registry.addManifest(t, "referrers", referrers)
+	signature, err = policy.verify(registry.client(t), imageDigest)
+	must.NoError(t, err)
+	must.Eq(t, imagePolicyNotation, signature)
+
+	// untrusted identity
+	policy.notationIdentities = []string{"CN=someone else"}
+	_, err = policy.verify(registry.client(t), imageDigest)
+	must.ErrorContains(t, err, "is not trusted")
+
+	// not issued by the trust store
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	otherDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &otherKey.PublicKey, otherKey)
+	must.NoError(t, err)
+	policy, err = newImagePolicy(&ImagePolicyConfig{
+		Notation: NotationPolicyConfig{TrustStore: writePEM(t, "CERTIFICATE", otherDER)},
+	})
+	must.NoError(t, err)
+	_, err = policy.verify(registry.client(t), imageDigest)
+	must.ErrorContains(t, err, "not issued by the trust store")
+}
+
+func TestNewImagePolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	// no policy
+	policy, err := newImagePolicy(&ImagePolicyConfig{AuditOnly: true})
+	must.NoError(t, err)
+	must.Nil(t, policy)
+
+	policy, err = newImagePolicy(&ImagePolicyConfig{RequireDigest: true})
+	must.NoError(t, err)
+	must.True(t, policy.requireDigest)
+	must.False(t, policy.verifiesSignatures())
+
+	_, err = newImagePolicy(&ImagePolicyConfig{
+		Cosign: CosignPolicyConfig{PublicKeys: []string{filepath.Join(t.TempDir(), "missing.pub")}},
+	})
+	must.ErrorContains(t, err, "failed to read cosign public key")
+
+	_, err = newImagePolicy(&ImagePolicyConfig{
+		Notation: NotationPolicyConfig{TrustedIdentities: []string{"CN=release"}},
+	})
+	must.ErrorContains(t, err, "requires a trust_store")
+}
+
+func TestRepoDigest(t *testing.T) {
+	ci.Parallel(t)
+
+	named, err := reference.ParseNormalizedNamed("redis:7")
+	must.NoError(t, err)
+
+	dgst := testDigest([]byte("redis"))
+	must.Eq(t, dgst, repoDigest(named, []string{
+		"ghcr.io/example/redis@" + testDigest([]byte("other")),
+		"redis@" + dgst,
+	}))
+	must.Eq(t, "", repoDigest(named, nil))
+}
@@ -38,8 +38,37 @@ func TestDriver_DockerStatsCollector(t *testing.T) {
 	stats.MemoryStats.CommitPeak = 321323
 	stats.MemoryStats.PrivateWorkingSet = 62222
 
+	stats.BlkioStats.IoServiceBytesRecursive = []containerapi.BlkioStatEntry{
+		{Major: 8, Op: "read", Value: 4096},
+		{Major: 8, Op: "write", Value: 1024},
+		{Major: 9, Op: "Read", Value: 4096},
+		{Major: 9, Op: "Total", Value: 4096},
+	}
+	stats.BlkioStats.IoServicedRecursive = []containerapi.BlkioStatEntry{
+		{Major: 8, Op: "read", Value: 2},
+		{Major: 8, Op: "write", Value: 1},
+	}
+	stats.StorageStats.ReadSizeBytes = 8192
+	stats.StorageStats.WriteSizeBytes = 1024
+	stats.StorageStats.ReadCountNormalized = 2
+	stats.StorageStats.WriteCountNormalized = 1
+	stats.Networks = map[string]containerapi.NetworkStats{
+		"eth0": {RxBytes: 100, RxPackets: 2, TxBytes: 50, TxPackets: 1},
+		"eth1": {RxBytes: 10, RxPackets: 1, RxDropped: 1},
+	}
+
 	ru := util.DockerStatsToTaskResourceUsage(stats, cpustats.Compute{})
 
+	must.Eq(t, 8192, ru.ResourceUsage.BlockIOStats.ReadBytes)
+	must.Eq(t, 1024, ru.ResourceUsage.BlockIOStats.WriteBytes)
+	must.Eq(t, 2, ru.ResourceUsage.BlockIOStats.ReadOps)
+	must.Eq(t, 1, ru.ResourceUsage.BlockIOStats.WriteOps)
+	must.Eq(t, 110, ru.ResourceUsage.NetworkStats.RxBytes)
+	must.Eq(t, 3, ru.ResourceUsage.NetworkStats.RxPackets)
+	must.Eq(t, 1, ru.ResourceUsage.NetworkStats.RxDropped)
+	must.Eq(t, 50, ru.ResourceUsage.NetworkStats.TxBytes)
+	must.Eq(t, 1, ru.ResourceUsage.NetworkStats.TxPackets)
+
 	if runtime.GOOS != "windows" {
 		must.Eq(t, stats.MemoryStats.Stats["file_mapped"], ru.ResourceUsage.MemoryStats.MappedFile)
 		must.Eq(t, stats.MemoryStats.Stats["rss"], ru.ResourceUsage.MemoryStats.RSS)
@@ -49,14 +78,20 @@ func TestDriver_DockerStatsCollector(t *testing.T) {
 		must.Eq(t, stats.MemoryStats.MaxUsage, ru.ResourceUsage.MemoryStats.MaxUsage)
 		must.Eq(t, stats.CPUStats.ThrottlingData.ThrottledPeriods, ru.ResourceUsage.CpuStats.ThrottledPeriods)
 		must.Eq(t, stats.CPUStats.ThrottlingData.ThrottledTime, ru.ResourceUsage.CpuStats.ThrottledTime)
+		must.Eq(t, []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"},
+			ru.ResourceUsage.BlockIOStats.Measured)
 	} else {
 		must.Eq(t, stats.MemoryStats.PrivateWorkingSet, ru.ResourceUsage.MemoryStats.RSS)
 		must.Eq(t, stats.MemoryStats.Commit, ru.ResourceUsage.MemoryStats.Usage)
 		must.Eq(t, stats.MemoryStats.CommitPeak, ru.ResourceUsage.MemoryStats.MaxUsage)
 		must.Eq(t, stats.CPUStats.ThrottlingData.ThrottledPeriods, ru.ResourceUsage.CpuStats.ThrottledPeriods)
 		must.Eq(t, stats.CPUStats.ThrottlingData.ThrottledTime, ru.ResourceUsage.CpuStats.ThrottledTime)
-
 	}
+
+	// containers sharing the network of another container have no networks
+	stats.Networks = nil
+	ru = util.DockerStatsToTaskResourceUsage(stats, cpustats.Compute{})
+	must.Nil(t, ru.ResourceUsage.NetworkStats)
 }
 
 // TestDriver_DockerUsageSender asserts that the TaskResourceUsage chan wrapper
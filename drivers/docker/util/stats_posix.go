@@ -6,6 +6,8 @@
 package util
 
 import (
+	"strings"
+
 	containerapi "github.com/docker/docker/api/types/container"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -17,6 +19,11 @@ var (
 	// cgroup-v2 only exposes a subset of memory stats
 	DockerCgroupV1MeasuredMemStats = []string{"RSS", "Cache", "Swap", "Usage", "Max Usage"}
 	DockerCgroupV2MeasuredMemStats = []string{"RSS", "Cache", "Swap", "Usage"}
+
+	DockerMeasuredNetworkStats = []string{
+		"Rx Bytes", "Rx Packets", "Rx Errors", "Rx Dropped",
+		"Tx Bytes", "Tx Packets", "Tx Errors", "Tx Dropped",
+	}
 )
 
 func DockerStatsToTaskResourceUsage(s *containerapi.StatsResponse, compute cpustats.Compute) *cstructs.TaskResourceUsage {
@@ -80,9 +87,46 @@ func DockerStatsToTaskResourceUsage(s *containerapi.StatsResponse, compute cpust
 
 	return &cstructs.TaskResourceUsage{
 		ResourceUsage: &cstructs.ResourceUsage{
-			MemoryStats: ms,
-			CpuStats:    cs,
+			MemoryStats:  ms,
+			CpuStats:     cs,
+			BlockIOStats: blockIOStats(&s.BlkioStats),
+			NetworkStats: NetworkStats(s.Networks, DockerMeasuredNetworkStats),
 		},
 		Timestamp: s.Read.UTC().UnixNano(),
 	}
 }
+
+// blockIOStats sums the bytes and operations of all the block devices of the
+// container. It returns nil if no block I/O was accounted for the container.
+func blockIOStats(s *containerapi.BlkioStats) *cstructs.BlockIOStats {
+	bs := &cstructs.BlockIOStats{}
+
+	// The ops are capitalized with cgroups v1 and lowercase with cgroups v2
+	if len(s.IoServiceBytesRecursive) > 0 {
+		bs.Measured = append(bs.Measured, "Read Bytes", "Write Bytes")
+		for _, entry := range s.IoServiceBytesRecursive {
+			switch strings.ToLower(entry.Op) {
+			case "read":
+				bs.ReadBytes += entry.Value
+			case "write":
+				bs.WriteBytes += entry.Value
+			}
+		}
+	}
+	if len(s.IoServicedRecursive) > 0 {
+		bs.Measured = append(bs.Measured, "Read Ops", "Write Ops")
+		for _, entry := range s.IoServicedRecursive {
+			switch strings.ToLower(entry.Op) {
+			case "read":
+				bs.ReadOps += entry.Value
+			case "write":
+				bs.WriteOps += entry.Value
+			}
+		}
+	}
+
+	if len(bs.Measured) == 0 {
+		return nil
+	}
+	return bs
+}
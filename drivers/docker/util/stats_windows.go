@@ -15,6 +15,13 @@ var (
 	// The statistics the Docker driver exposes
 	DockerMeasuredCPUStats = []string{"Throttled Periods", "Throttled Time", "Percent"}
 	DockerMeasuredMemStats = []string{"RSS", "Usage", "Max Usage"}
+	DockerMeasuredIOStats  = []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"}
+
+	// Windows doesn't count network errors
+	DockerMeasuredNetworkStats = []string{
+		"Rx Bytes", "Rx Packets", "Rx Dropped",
+		"Tx Bytes", "Tx Packets", "Tx Dropped",
+	}
 )
 
 func DockerStatsToTaskResourceUsage(s *containerapi.Stats, compute cpustats.Compute) *cstructs.TaskResourceUsage {
@@ -54,10 +61,20 @@ func DockerStatsToTaskResourceUsage(s *containerapi.Stats, compute cpustats.Comp
 		Measured:         DockerMeasuredCPUStats,
 	}
 
+	bs := &cstructs.BlockIOStats{
+		ReadBytes:  s.StorageStats.ReadSizeBytes,
+		WriteBytes: s.StorageStats.WriteSizeBytes,
+		ReadOps:    s.StorageStats.ReadCountNormalized,
+		WriteOps:   s.StorageStats.WriteCountNormalized,
+		Measured:   DockerMeasuredIOStats,
+	}
+
 	return &cstructs.TaskResourceUsage{
 		ResourceUsage: &cstructs.ResourceUsage{
-			MemoryStats: ms,
-			CpuStats:    cs,
+			MemoryStats:  ms,
+			CpuStats:     cs,
+			BlockIOStats: bs,
+			NetworkStats: NetworkStats(s.Networks, DockerMeasuredNetworkStats),
 		},
 		Timestamp: s.Read.UTC().UnixNano(),
 	}
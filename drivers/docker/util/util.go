@@ -3,6 +3,11 @@
 
 package util
 
+import (
+	containerapi "github.com/docker/docker/api/types/container"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
 func CalculateCPUPercent(newSample, oldSample, newTotal, oldTotal uint64, cores int) float64 {
 	numerator := newSample - oldSample
 	denom := newTotal - oldTotal
@@ -12,3 +17,25 @@ func CalculateCPUPercent(newSample, oldSample, newTotal, oldTotal uint64, cores
 
 	return (float64(numerator) / float64(denom)) * float64(cores) * 100.0
 }
+
+// NetworkStats sums the stats of the networks of a container. It returns nil
+// if the container has no networks of its own, such as containers joining the
+// network namespace of another container.
+func NetworkStats(networks map[string]containerapi.NetworkStats, measured []string) *cstructs.NetworkStats {
+	if len(networks) == 0 {
+		return nil
+	}
+
+	ns := &cstructs.NetworkStats{Measured: measured}
+	for _, n := range networks {
+		ns.RxBytes += n.RxBytes
+		ns.RxPackets += n.RxPackets
+		ns.RxErrors += n.RxErrors
+		ns.RxDropped += n.RxDropped
+		ns.TxBytes += n.TxBytes
+		ns.TxPackets += n.TxPackets
+		ns.TxErrors += n.TxErrors
+		ns.TxDropped += n.TxDropped
+	}
+	return ns
+}
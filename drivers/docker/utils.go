@@ -13,17 +13,27 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 
 	"github.com/distribution/reference"
 	"github.com/docker/cli/cli/config/configfile"
 	"github.com/docker/cli/cli/config/types"
 	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/registry"
 )
 
 var (
 	NoPathInImageErr = errors.New("does not match registry specification")
+
+	// lazyPullSnapshotters are the containerd snapshotters that fetch the
+	// contents of images on demand instead of when images are pulled
+	lazyPullSnapshotters = []string{"stargz", "nydus", "soci"}
+
+	// cdiDeviceNameRe matches the fully qualified names of Container Device
+	// Interface devices, such as nvidia.com/gpu=0
+	cdiDeviceNameRe = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9.-]*[a-zA-Z0-9])?/[a-zA-Z0-9]([a-zA-Z0-9_-]*[a-zA-Z0-9])?=[a-zA-Z0-9]([a-zA-Z0-9_.:-]*[a-zA-Z0-9])?$`)
 )
 
 func parseDockerImage(image string) (string, string, error) {
@@ -358,3 +368,27 @@ func getValue(val, defaultVal string) string {
 	}
 	return val
 }
+
+// containerdSnapshotter returns the containerd snapshotter used by the Docker
+// daemon, or an empty string if the daemon doesn't use the containerd image
+// store.
+func containerdSnapshotter(info *system.Info) string {
+	for _, status := range info.DriverStatus {
+		if status[0] == "driver-type" && status[1] == "io.containerd.snapshotter.v1" {
+			return info.Driver
+		}
+	}
+	return ""
+}
+
+// supportsLazyPull returns whether the images pulled by the Docker daemon are
+// lazily fetched by its containerd snapshotter.
+func supportsLazyPull(info *system.Info) bool {
+	return slices.Contains(lazyPullSnapshotters, containerdSnapshotter(info))
+}
+
+// isCDIDeviceName returns whether name is the fully qualified name of a
+// Container Device Interface device rather than a device path.
+func isCDIDeviceName(name string) bool {
+	return cdiDeviceNameRe.MatchString(name)
+}
@@ -6,6 +6,7 @@ package docker
 import (
 	"testing"
 
+	"github.com/docker/docker/api/types/system"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
@@ -135,3 +136,70 @@ func TestGetValue(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerdSnapshotter(t *testing.T) {
+	ci.Parallel(t)
+
+	containerdStatus := [][2]string{{"driver-type", "io.containerd.snapshotter.v1"}}
+
+	tests := []struct {
+		name        string
+		info        *system.Info
+		snapshotter string
+		lazyPull    bool
+	}{
+		{
+			name: "graph driver",
+			info: &system.Info{Driver: "overlay2", DriverStatus: [][2]string{{"Backing Filesystem", "extfs"}}},
+		},
+		{
+			name:        "overlayfs snapshotter",
+			info:        &system.Info{Driver: "overlayfs", DriverStatus: containerdStatus},
+			snapshotter: "overlayfs",
+		},
+		{
+			name:        "stargz snapshotter",
+			info:        &system.Info{Driver: "stargz", DriverStatus: containerdStatus},
+			snapshotter: "stargz",
+			lazyPull:    true,
+		},
+		{
+			name:        "soci snapshotter",
+			info:        &system.Info{Driver: "soci", DriverStatus: containerdStatus},
+			snapshotter: "soci",
+			lazyPull:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			must.Eq(t, test.snapshotter, containerdSnapshotter(test.info))
+			must.Eq(t, test.lazyPull, supportsLazyPull(test.info))
+		})
+	}
+}
+
+func TestIsCDIDeviceName(t *testing.T) {
+	ci.Parallel(t)
+
+	tests := []struct {
+		name string
+		cdi  bool
+	}{
+		{name: "nvidia.com/gpu=0", cdi: true},
+		{name: "nvidia.com/gpu=all", cdi: true},
+		{name: "vendor.com/class_x=GPU-8a9c3b1e-0d57", cdi: true},
+		{name: "amd.com/gpu=card0:1", cdi: true},
+		{name: "/dev/nvidia0"},
+		{name: "/dev/bus/usb/001"},
+		{name: "nvidia.com/gpu"},
+		{name: "gpu=0"},
+		{name: "nvidia.com/gpu="},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			must.Eq(t, test.cdi, isCDIDeviceName(test.name))
+		})
+	}
+}
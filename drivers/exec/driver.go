@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -16,6 +17,7 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/lib/nsutil"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
@@ -66,6 +68,14 @@ var (
 		Name:              pluginName,
 	}
 
+	// idMappingSpec is the hcl specification of the uid_mapping and
+	// gid_mapping blocks of the plugin config
+	idMappingSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"container_id": hclspec.NewAttr("container_id", "number", true),
+		"host_id":      hclspec.NewAttr("host_id", "number", true),
+		"size":         hclspec.NewAttr("size", "number", true),
+	})
+
 	// configSpec is the hcl specification returned by the ConfigSchema RPC
 	configSpec = hclspec.NewObject(map[string]*hclspec.Spec{
 		"no_pivot_root": hclspec.NewDefault(
@@ -80,6 +90,12 @@ var (
 			hclspec.NewAttr("default_ipc_mode", "string", false),
 			hclspec.NewLiteral(`"private"`),
 		),
+		"default_userns_mode": hclspec.NewDefault(
+			hclspec.NewAttr("default_userns_mode", "string", false),
+			hclspec.NewLiteral(`"host"`),
+		),
+		"uid_mapping": hclspec.NewBlockList("uid_mapping", idMappingSpec),
+		"gid_mapping": hclspec.NewBlockList("gid_mapping", idMappingSpec),
 		"allow_caps": hclspec.NewDefault(
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
@@ -91,13 +107,14 @@ var (
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
-		"work_dir": hclspec.NewAttr("work_dir", "string", false),
+		"command":     hclspec.NewAttr("command", "string", true),
+		"args":        hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":    hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":    hclspec.NewAttr("ipc_mode", "string", false),
+		"userns_mode": hclspec.NewAttr("userns_mode", "string", false),
+		"cap_add":     hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":    hclspec.NewAttr("cap_drop", "list(string)", false),
+		"work_dir":    hclspec.NewAttr("work_dir", "string", false),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -162,6 +179,15 @@ type Config struct {
 	// exec-based task drivers.
 	DefaultModeIPC string `codec:"default_ipc_mode"`
 
+	// DefaultModeUser is the default user namespace isolation set for all
+	// tasks using exec-based task drivers.
+	DefaultModeUser string `codec:"default_userns_mode"`
+
+	// UIDMappings and GIDMappings map the user and group IDs of tasks with a
+	// private user namespace to the IDs of the host.
+	UIDMappings []IDMapping `codec:"uid_mapping"`
+	GIDMappings []IDMapping `codec:"gid_mapping"`
+
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
@@ -170,6 +196,44 @@ type Config struct {
 	DeniedHostGids string `codec:"denied_host_gids"`
 }
 
+// IDMapping maps a range of IDs in the user namespace of tasks to a range of
+// IDs of the host.
+type IDMapping struct {
+	ContainerID uint32 `codec:"container_id"`
+	HostID      uint32 `codec:"host_id"`
+	Size        uint32 `codec:"size"`
+}
+
+// validateIDMappings ensures the ranges of mappings are not empty and never
+// map an ID of the task to the root user or group of the host.
+func validateIDMappings(name string, mappings []IDMapping) error {
+	for _, m := range mappings {
+		if m.Size == 0 {
+			return fmt.Errorf("%s must have a size greater than 0", name)
+		}
+		if m.HostID == 0 {
+			return fmt.Errorf("%s must not map to host ID 0", name)
+		}
+		if uint64(m.ContainerID)+uint64(m.Size) > 1<<32 || uint64(m.HostID)+uint64(m.Size) > 1<<32 {
+			return fmt.Errorf("%s exceeds the range of IDs", name)
+		}
+	}
+	return nil
+}
+
+// executorIDMappings converts mappings of the plugin config to the mappings
+// of the executor.
+func executorIDMappings(mappings []IDMapping) []executor.IDMapping {
+	if len(mappings) == 0 {
+		return nil
+	}
+	out := make([]executor.IDMapping, len(mappings))
+	for i, m := range mappings {
+		out[i] = executor.IDMapping{ContainerID: m.ContainerID, HostID: m.HostID, Size: m.Size}
+	}
+	return out
+}
+
 func (c *Config) validate() error {
 	switch c.DefaultModePID {
 	case executor.IsolationModePrivate, executor.IsolationModeHost:
@@ -183,6 +247,32 @@ func (c *Config) validate() error {
 		return fmt.Errorf("default_ipc_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, c.DefaultModeIPC)
 	}
 
+	// an unset mode is the host user namespace, as before the option existed
+	switch c.DefaultModeUser {
+	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
+	default:
+		return fmt.Errorf("default_userns_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, c.DefaultModeUser)
+	}
+
+	if err := validateIDMappings("uid_mapping", c.UIDMappings); err != nil {
+		return err
+	}
+	if err := validateIDMappings("gid_mapping", c.GIDMappings); err != nil {
+		return err
+	}
+	if (len(c.UIDMappings) == 0) != (len(c.GIDMappings) == 0) {
+		return fmt.Errorf("uid_mapping and gid_mapping must be set together")
+	}
+
+	if c.DefaultModeUser == executor.IsolationModePrivate {
+		if len(c.UIDMappings) == 0 {
+			return fmt.Errorf("default_userns_mode %q requires uid_mapping and gid_mapping", executor.IsolationModePrivate)
+		}
+		if c.DefaultModePID != executor.IsolationModePrivate || c.DefaultModeIPC != executor.IsolationModePrivate {
+			return fmt.Errorf("default_userns_mode %q requires default_pid_mode and default_ipc_mode %q", executor.IsolationModePrivate, executor.IsolationModePrivate)
+		}
+	}
+
 	badCaps := capabilities.Supported().Difference(capabilities.New(c.AllowCaps))
 	if !badCaps.Empty() {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
@@ -207,6 +297,10 @@ type TaskConfig struct {
 	// Must be "private" or "host" if set.
 	ModeIPC string `codec:"ipc_mode"`
 
+	// ModeUser indicates whether user namespace isolation is enabled for the
+	// task. Must be "private" or "host" if set.
+	ModeUser string `codec:"userns_mode"`
+
 	// CapAdd is a set of linux capabilities to enable.
 	CapAdd []string `codec:"cap_add"`
 
@@ -230,6 +324,12 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("ipc_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeIPC)
 	}
 
+	switch tc.ModeUser {
+	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
+	default:
+		return fmt.Errorf("userns_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeUser)
+	}
+
 	supported := capabilities.Supported()
 	badAdds := supported.Difference(capabilities.New(tc.CapAdd))
 	if !badAdds.Empty() {
@@ -342,7 +442,16 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 // Capabilities is returned by the Capabilities RPC and indicates what
 // optional features this driver supports
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
-	return driverCapabilities, nil
+	caps := *driverCapabilities
+	caps.Checkpoint = criuInstalled()
+	return &caps, nil
+}
+
+// criuInstalled returns whether the CRIU binary tasks are checkpointed with is
+// installed.
+func criuInstalled() bool {
+	_, err := exec.LookPath("criu")
+	return err == nil
 }
 
 func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
@@ -396,11 +505,45 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		return fp
 	}
 
+	userns := userNamespacesSupported()
+	if d.config.DefaultModeUser == executor.IsolationModePrivate && !userns {
+		fp.Health = drivers.HealthStateUnhealthy
+		fp.HealthDescription = "default_userns_mode is private but the kernel does not support user namespaces"
+		d.setFingerprintFailure()
+		return fp
+	}
+
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.userns"] = pstructs.NewBoolAttribute(userns)
+	if criuInstalled() {
+		fp.Attributes["driver.exec.checkpoint"] = pstructs.NewBoolAttribute(true)
+	}
 	d.setFingerprintSuccess()
 	return fp
 }
 
+// userNamespacesSupported returns whether the kernel of the client allows
+// creating user namespaces.
+func userNamespacesSupported() bool {
+	max, err := nsutil.MaxUserNamespaces()
+	return err == nil && max > 0
+}
+
+// validateModeUser ensures the user namespace isolation mode of a task can be
+// honored with the plugin config.
+func (d *Driver) validateModeUser(modeUser, modePID, modeIPC string) error {
+	if modeUser != executor.IsolationModePrivate {
+		return nil
+	}
+	if len(d.config.UIDMappings) == 0 || len(d.config.GIDMappings) == 0 {
+		return fmt.Errorf("userns_mode %q requires the uid_mapping and gid_mapping plugin options", executor.IsolationModePrivate)
+	}
+	if modePID != executor.IsolationModePrivate || modeIPC != executor.IsolationModePrivate {
+		return fmt.Errorf("userns_mode %q requires pid_mode and ipc_mode %q", executor.IsolationModePrivate, executor.IsolationModePrivate)
+	}
+	return nil
+}
+
 func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 	if handle == nil {
 		return fmt.Errorf("handle cannot be nil")
@@ -456,7 +599,32 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 	return nil
 }
 
-func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle, network *drivers.DriverNetwork, err error) {
+func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, "")
+}
+
+var _ drivers.CheckpointDriver = (*Driver)(nil)
+
+// RestoreTask starts a task from the checkpoint in dir written by
+// CheckpointTask, instead of launching its command.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, dir)
+}
+
+// CheckpointTask writes the process state of a task to the directory of opts
+// with CRIU.
+func (d *Driver) CheckpointTask(taskID string, opts *drivers.CheckpointOptions) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Checkpoint(opts.Dir, opts.LeaveRunning)
+}
+
+// startTask launches the command of a task, or restores the task from the
+// checkpoint in checkpointDir if set.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, checkpointDir string) (handle *drivers.TaskHandle, network *drivers.DriverNetwork, err error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -470,6 +638,13 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
 
+	modePID := executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID)
+	modeIPC := executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC)
+	modeUser := executor.IsolationMode(d.config.DefaultModeUser, driverConfig.ModeUser)
+	if err := d.validateModeUser(modeUser, modePID, modeIPC); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+
 	if cfg.User == "" {
 		cfg.User = "nobody"
 	}
@@ -537,14 +712,29 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 		Mounts:           cfg.Mounts,
 		Devices:          cfg.Devices,
 		NetworkIsolation: cfg.NetworkIsolation,
-		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
-		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
+		ModePID:          modePID,
+		ModeIPC:          modeIPC,
+		ModeUser:         modeUser,
 		Capabilities:     caps,
 	}
 
-	ps, err := exec.Launch(execCmd)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
+	if modeUser == executor.IsolationModePrivate {
+		execCmd.UIDMappings = executorIDMappings(d.config.UIDMappings)
+		execCmd.GIDMappings = executorIDMappings(d.config.GIDMappings)
+	}
+
+	var ps *executor.ProcessState
+	if checkpointDir != "" {
+		d.logger.Info("restoring task from checkpoint", "dir", checkpointDir)
+		ps, err = exec.Restore(execCmd, checkpointDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to restore command with executor: %v", err)
+		}
+	} else {
+		ps, err = exec.Launch(execCmd)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
+		}
 	}
 
 	h := &taskHandle{
@@ -694,6 +884,19 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return handle.exec.Signal(sig)
 }
 
+var _ drivers.ResourceUpdateDriver = (*Driver)(nil)
+
+// UpdateTaskResources updates the limits of the cgroups of a running task to
+// its new resources.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.UpdateResources(resources)
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	if len(cmd) == 0 {
 		return nil, fmt.Errorf("error cmd must have at least one value")
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -835,6 +836,91 @@ touch: cannot touch '/tmp/task-path-ro/testfile-from-ro': Read-only file system`
 	require.Equal(t, "from-exec", strings.TrimSpace(string(fromRWContent)))
 }
 
+func TestExecDriver_CheckpointTask_NotFound(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := newExecDriverTest(t, ctx).(*Driver)
+	err := d.CheckpointTask(uuid.Generate(), &drivers.CheckpointOptions{Dir: t.TempDir()})
+	must.ErrorIs(t, err, drivers.ErrTaskNotFound)
+}
+
+func TestExecDriver_CheckpointRestore(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+	if _, err := exec.LookPath("criu"); err != nil {
+		t.Skip("criu not found")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := newExecDriverTest(t, ctx)
+	harness := dtestutil.NewDriverHarness(t, d)
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID:   allocID,
+		ID:        uuid.Generate(),
+		Name:      "test",
+		Resources: testResources(allocID, "test"),
+	}
+
+	tc := &TaskConfig{
+		Command: "/bin/bash",
+		Args:    []string{"-c", "i=0; while true; do i=$((i+1)); echo $i > ${NOMAD_TASK_DIR}/count; sleep 0.1; done"},
+	}
+	must.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	_, _, err := harness.StartTask(task)
+	must.NoError(t, err)
+	must.NoError(t, harness.WaitUntilStarted(task.ID, time.Second))
+	time.Sleep(time.Second)
+
+	// checkpointing the task without leaving it running stops it
+	ch, err := harness.WaitTask(context.Background(), task.ID)
+	must.NoError(t, err)
+
+	dir := filepath.Join(task.AllocDir, "checkpoint")
+	must.NoError(t, os.Mkdir(dir, 0o700))
+	cd := harness.DriverPlugin.(drivers.CheckpointDriver)
+	must.NoError(t, cd.CheckpointTask(task.ID, &drivers.CheckpointOptions{Dir: dir}))
+
+	select {
+	case <-ch:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for checkpointed task to exit")
+	}
+	must.NoError(t, harness.DestroyTask(task.ID, true))
+
+	countPath := filepath.Join(task.TaskDir().LocalDir, "count")
+	checkpointed, err := os.ReadFile(countPath)
+	must.NoError(t, err)
+
+	// the restored task resumes counting from where it was checkpointed
+	task.ID = uuid.Generate()
+	_, _, err = cd.RestoreTask(task, dir)
+	must.NoError(t, err)
+	defer harness.DestroyTask(task.ID, true)
+	must.NoError(t, harness.WaitUntilStarted(task.ID, time.Second))
+
+	testutil.WaitForResult(func() (bool, error) {
+		restored, err := os.ReadFile(countPath)
+		if err != nil {
+			return false, err
+		}
+		before, _ := strconv.Atoi(strings.TrimSpace(string(checkpointed)))
+		after, _ := strconv.Atoi(strings.TrimSpace(string(restored)))
+		return after > before, fmt.Errorf("count did not increase from %d, got %d", before, after)
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
 func TestConfig_ParseAllHCL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -843,12 +929,14 @@ config {
   command = "/bin/bash"
   args = ["-c", "echo hello"]
   work_dir = "/root"
+  userns_mode = "private"
 }`
 
 	expected := &TaskConfig{
-		Command: "/bin/bash",
-		Args:    []string{"-c", "echo hello"},
-		WorkDir: "/root",
+		Command:  "/bin/bash",
+		Args:     []string{"-c", "echo hello"},
+		WorkDir:  "/root",
+		ModeUser: "private",
 	}
 
 	var tc *TaskConfig
@@ -856,6 +944,43 @@ config {
 	require.EqualValues(t, expected, tc)
 }
 
+func TestConfig_ParsePluginHCL_IDMappings(t *testing.T) {
+	ci.Parallel(t)
+
+	cfgStr := `
+config {
+  default_userns_mode = "private"
+
+  uid_mapping {
+    container_id = 0
+    host_id      = 100000
+    size         = 65536
+  }
+
+  gid_mapping {
+    container_id = 0
+    host_id      = 100000
+    size         = 65536
+  }
+
+  gid_mapping {
+    container_id = 65536
+    host_id      = 5
+    size         = 1
+  }
+}`
+
+	var c *Config
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, cfgStr, &c)
+	must.Eq(t, "private", c.DefaultModeUser)
+	must.Eq(t, []IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}}, c.UIDMappings)
+	must.Eq(t, []IDMapping{
+		{ContainerID: 0, HostID: 100000, Size: 65536},
+		{ContainerID: 65536, HostID: 5, Size: 1},
+	}, c.GIDMappings)
+	must.NoError(t, c.validate())
+}
+
 func TestExecDriver_NoPivotRoot(t *testing.T) {
 	ci.Parallel(t)
 	ctestutils.ExecCompatible(t)
@@ -1058,6 +1183,54 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("userns", func(t *testing.T) {
+		mappings := []IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}}
+		for _, tc := range []struct {
+			name              string
+			userMode, pidMode string
+			uidMaps, gidMaps  []IDMapping
+			exp               error
+		}{
+			{name: "host", userMode: "host", pidMode: "private", exp: nil},
+			{name: "unset", userMode: "", pidMode: "private", exp: nil},
+			{name: "host with mappings", userMode: "host", pidMode: "private", uidMaps: mappings, gidMaps: mappings, exp: nil},
+			{name: "private", userMode: "private", pidMode: "private", uidMaps: mappings, gidMaps: mappings, exp: nil},
+			{name: "other", userMode: "other", pidMode: "private", exp: errors.New(`default_userns_mode must be "private" or "host", got "other"`)},
+			{name: "private without mappings", userMode: "private", pidMode: "private", exp: errors.New(`default_userns_mode "private" requires uid_mapping and gid_mapping`)},
+			{name: "private with host pid", userMode: "private", pidMode: "host", uidMaps: mappings, gidMaps: mappings, exp: errors.New(`default_userns_mode "private" requires default_pid_mode and default_ipc_mode "private"`)},
+			{name: "uid only", userMode: "host", pidMode: "private", uidMaps: mappings, exp: errors.New("uid_mapping and gid_mapping must be set together")},
+			{name: "empty range", userMode: "host", pidMode: "private", uidMaps: []IDMapping{{HostID: 100000}}, gidMaps: mappings, exp: errors.New("uid_mapping must have a size greater than 0")},
+			{name: "host root", userMode: "host", pidMode: "private", uidMaps: mappings, gidMaps: []IDMapping{{Size: 1}}, exp: errors.New("gid_mapping must not map to host ID 0")},
+			{name: "overflow", userMode: "host", pidMode: "private", uidMaps: []IDMapping{{HostID: 1 << 31, Size: 1<<31 + 1}}, gidMaps: mappings, exp: errors.New("uid_mapping exceeds the range of IDs")},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				must.Eq(t, tc.exp, (&Config{
+					DefaultModePID:  tc.pidMode,
+					DefaultModeIPC:  "private",
+					DefaultModeUser: tc.userMode,
+					UIDMappings:     tc.uidMaps,
+					GIDMappings:     tc.gidMaps,
+				}).validate())
+			})
+		}
+	})
+}
+
+func TestDriver_validateModeUser(t *testing.T) {
+	ci.Parallel(t)
+
+	mappings := []IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}}
+	mapped := &Driver{config: Config{UIDMappings: mappings, GIDMappings: mappings}}
+	unmapped := &Driver{}
+
+	must.NoError(t, mapped.validateModeUser("private", "private", "private"))
+	must.NoError(t, mapped.validateModeUser("host", "host", "host"))
+	must.NoError(t, unmapped.validateModeUser("host", "private", "private"))
+	must.EqError(t, unmapped.validateModeUser("private", "private", "private"),
+		`userns_mode "private" requires the uid_mapping and gid_mapping plugin options`)
+	must.EqError(t, mapped.validateModeUser("private", "host", "private"),
+		`userns_mode "private" requires pid_mode and ipc_mode "private"`)
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -1085,6 +1258,22 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 		}
 	})
 
+	t.Run("userns", func(t *testing.T) {
+		for _, tc := range []struct {
+			userMode string
+			exp      error
+		}{
+			{userMode: "", exp: nil},
+			{userMode: "host", exp: nil},
+			{userMode: "private", exp: nil},
+			{userMode: "other", exp: errors.New(`userns_mode must be "private" or "host", got "other"`)},
+		} {
+			must.Eq(t, tc.exp, (&TaskConfig{
+				ModeUser: tc.userMode,
+			}).validate())
+		}
+	})
+
 	t.Run("cap_add", func(t *testing.T) {
 		for _, tc := range []struct {
 			adds []string
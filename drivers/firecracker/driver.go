@@ -0,0 +1,704 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package firecracker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/drivers/shared/eventer"
+	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+const (
+	// pluginName is the name of the plugin
+	pluginName = "firecracker"
+
+	// fingerprintPeriod is the interval at which the driver will send fingerprint responses
+	fingerprintPeriod = 30 * time.Second
+
+	// The keys populated in Node Attributes to indicate presence of the
+	// Firecracker driver
+	driverAttr        = "driver.firecracker"
+	driverVersionAttr = "driver.firecracker.version"
+	driverJailerAttr  = "driver.firecracker.jailer"
+
+	// kvmDevice is the device Firecracker uses to run microVMs
+	kvmDevice = "/dev/kvm"
+
+	// defaultKernelArgs are the kernel command line arguments of microVMs
+	// whose task doesn't set kernel_args. The serial console is written to
+	// the logs of the task.
+	defaultKernelArgs = "console=ttyS0 reboot=k panic=1 pci=off"
+
+	// taskHandleVersion is the version of task handle which this driver sets
+	// and understands how to decode driver state
+	taskHandleVersion = 1
+)
+
+var (
+	// PluginID is the firecracker plugin metadata registered in the plugin
+	// catalog.
+	PluginID = loader.PluginID{
+		Name:       pluginName,
+		PluginType: base.PluginTypeDriver,
+	}
+
+	// PluginConfig is the firecracker driver factory function registered in
+	// the plugin catalog.
+	PluginConfig = &loader.InternalPluginConfig{
+		Config:  map[string]interface{}{},
+		Factory: func(ctx context.Context, l hclog.Logger) interface{} { return NewFirecrackerDriver(ctx, l) },
+	}
+
+	versionRegex = regexp.MustCompile(`Firecracker v(\d+\.\d+\.\d+)`)
+
+	// pluginInfo is the response returned for the PluginInfo RPC
+	pluginInfo = &base.PluginInfoResponse{
+		Type:              base.PluginTypeDriver,
+		PluginApiVersions: []string{drivers.ApiVersion010},
+		PluginVersion:     "0.1.0",
+		Name:              pluginName,
+	}
+
+	// configSpec is the hcl specification returned by the ConfigSchema RPC
+	configSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"firecracker_path": hclspec.NewDefault(
+			hclspec.NewAttr("firecracker_path", "string", false),
+			hclspec.NewLiteral(`"firecracker"`),
+		),
+		"image_paths": hclspec.NewAttr("image_paths", "list(string)", false),
+		"jailer": hclspec.NewDefault(hclspec.NewBlock("jailer", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"enabled": hclspec.NewDefault(
+				hclspec.NewAttr("enabled", "bool", false),
+				hclspec.NewLiteral("true"),
+			),
+			"path": hclspec.NewDefault(
+				hclspec.NewAttr("path", "string", false),
+				hclspec.NewLiteral(`"jailer"`),
+			),
+			"chroot_base_dir": hclspec.NewDefault(
+				hclspec.NewAttr("chroot_base_dir", "string", false),
+				hclspec.NewLiteral(`"/srv/jailer"`),
+			),
+			"uid": hclspec.NewDefault(
+				hclspec.NewAttr("uid", "number", false),
+				hclspec.NewLiteral("65534"),
+			),
+			"gid": hclspec.NewDefault(
+				hclspec.NewAttr("gid", "number", false),
+				hclspec.NewLiteral("65534"),
+			),
+		})), hclspec.NewLiteral(`{
+			enabled         = true
+			path            = "jailer"
+			chroot_base_dir = "/srv/jailer"
+			uid             = 65534
+			gid             = 65534
+		}`)),
+	})
+
+	// taskConfigSpec is the hcl specification for the driver config section of
+	// a taskConfig within a job. It is returned in the TaskConfigSchema RPC
+	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"kernel_image":     hclspec.NewAttr("kernel_image", "string", true),
+		"kernel_args":      hclspec.NewAttr("kernel_args", "string", false),
+		"initrd":           hclspec.NewAttr("initrd", "string", false),
+		"rootfs":           hclspec.NewAttr("rootfs", "string", true),
+		"rootfs_read_only": hclspec.NewAttr("rootfs_read_only", "bool", false),
+		"drive": hclspec.NewBlockList("drive", hclspec.NewObject(map[string]*hclspec.Spec{
+			"path":      hclspec.NewAttr("path", "string", true),
+			"read_only": hclspec.NewAttr("read_only", "bool", false),
+		})),
+		"vcpu_count": hclspec.NewAttr("vcpu_count", "number", false),
+		"smt":        hclspec.NewAttr("smt", "bool", false),
+		"network_interface": hclspec.NewBlockList("network_interface", hclspec.NewObject(map[string]*hclspec.Spec{
+			"host_dev_name": hclspec.NewAttr("host_dev_name", "string", true),
+			"guest_mac":     hclspec.NewAttr("guest_mac", "string", false),
+		})),
+		"graceful_shutdown": hclspec.NewAttr("graceful_shutdown", "bool", false),
+	})
+
+	// capabilities is returned by the Capabilities RPC and indicates what
+	// optional features this driver supports
+	capabilities = &drivers.Capabilities{
+		SendSignals: false,
+		Exec:        false,
+		FSIsolation: fsisolation.Image,
+		NetIsolationModes: []drivers.NetIsolationMode{
+			drivers.NetIsolationModeHost,
+			drivers.NetIsolationModeGroup,
+		},
+		MountConfigs: drivers.MountConfigSupportNone,
+	}
+
+	_ drivers.DriverPlugin = (*Driver)(nil)
+)
+
+// TaskConfig is the driver configuration of a taskConfig within a job
+type TaskConfig struct {
+	KernelImage       string             `codec:"kernel_image"`
+	KernelArgs        string             `codec:"kernel_args"`
+	Initrd            string             `codec:"initrd"`
+	RootFS            string             `codec:"rootfs"`
+	RootFSReadOnly    bool               `codec:"rootfs_read_only"`
+	Drives            []Drive            `codec:"drive"`
+	VCPUCount         int                `codec:"vcpu_count"`
+	SMT               bool               `codec:"smt"`
+	NetworkInterfaces []NetworkInterface `codec:"network_interface"`
+	GracefulShutdown  bool               `codec:"graceful_shutdown"`
+}
+
+// Drive is an additional block device attached to the microVM
+type Drive struct {
+	Path     string `codec:"path"`
+	ReadOnly bool   `codec:"read_only"`
+}
+
+// NetworkInterface attaches a tap device of the host, or of the network
+// namespace of the allocation, to the microVM
+type NetworkInterface struct {
+	HostDevName string `codec:"host_dev_name"`
+	GuestMAC    string `codec:"guest_mac"`
+}
+
+// TaskState is the state which is encoded in the handle returned in StartTask.
+// This information is needed to rebuild the taskConfig state and handler
+// during recovery.
+type TaskState struct {
+	ReattachConfig *pstructs.ReattachConfig
+	TaskConfig     *drivers.TaskConfig
+	Pid            int
+	StartedAt      time.Time
+
+	// APISocket is the path of the API socket of the microVM, if graceful
+	// shutdown is enabled.
+	APISocket string
+
+	// ChrootDir is the directory the jailer created for the microVM, if the
+	// microVM runs in a jail.
+	ChrootDir string
+}
+
+// Config is the driver configuration set by SetConfig RPC call
+type Config struct {
+	// FirecrackerPath is the path of the firecracker binary
+	FirecrackerPath string `codec:"firecracker_path"`
+
+	// ImagePaths is an allow-list of paths firecracker is allowed to load
+	// kernels and drives from, in addition to the allocation directory
+	ImagePaths []string `codec:"image_paths"`
+
+	// Jailer is the configuration of the jailer the microVMs are started with
+	Jailer JailerConfig `codec:"jailer"`
+}
+
+// JailerConfig configures the jailer, which runs each microVM in a chroot and
+// with the privileges of an unprivileged user
+type JailerConfig struct {
+	Enabled       bool   `codec:"enabled"`
+	Path          string `codec:"path"`
+	ChrootBaseDir string `codec:"chroot_base_dir"`
+	UID           int    `codec:"uid"`
+	GID           int    `codec:"gid"`
+}
+
+// Driver is a driver for running tasks as Firecracker microVMs
+type Driver struct {
+	// eventer is used to handle multiplexing of TaskEvents calls such that an
+	// event can be broadcast to all callers
+	eventer *eventer.Eventer
+
+	// config is the driver configuration set by the SetConfig RPC
+	config Config
+
+	// tasks is the in memory datastore mapping taskIDs to taskHandles
+	tasks *taskStore
+
+	// ctx is the context for the driver. It is passed to other subsystems to
+	// coordinate shutdown
+	ctx context.Context
+
+	// nomadConf is the client agent's configuration
+	nomadConfig *base.ClientDriverConfig
+
+	// logger will log to the Nomad agent
+	logger hclog.Logger
+}
+
+func NewFirecrackerDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
+	logger = logger.Named(pluginName)
+	return &Driver{
+		eventer: eventer.NewEventer(ctx, logger),
+		tasks:   newTaskStore(),
+		ctx:     ctx,
+		logger:  logger,
+	}
+}
+
+func (d *Driver) PluginInfo() (*base.PluginInfoResponse, error) {
+	return pluginInfo, nil
+}
+
+func (d *Driver) ConfigSchema() (*hclspec.Spec, error) {
+	return configSpec, nil
+}
+
+func (d *Driver) SetConfig(cfg *base.Config) error {
+	var config Config
+	if len(cfg.PluginConfig) != 0 {
+		if err := base.MsgPackDecode(cfg.PluginConfig, &config); err != nil {
+			return err
+		}
+	}
+
+	if config.Jailer.Enabled {
+		if !filepath.IsAbs(config.Jailer.ChrootBaseDir) {
+			return fmt.Errorf("jailer chroot_base_dir must be an absolute path")
+		}
+		if config.Jailer.UID < 0 || config.Jailer.GID < 0 {
+			return fmt.Errorf("jailer uid and gid must be positive")
+		}
+	}
+
+	d.config = config
+	if cfg.AgentConfig != nil {
+		d.nomadConfig = cfg.AgentConfig.Driver
+	}
+	return nil
+}
+
+func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
+	return taskConfigSpec, nil
+}
+
+func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
+	return capabilities, nil
+}
+
+func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
+	ch := make(chan *drivers.Fingerprint)
+	go d.handleFingerprint(ctx, ch)
+	return ch, nil
+}
+
+func (d *Driver) handleFingerprint(ctx context.Context, ch chan *drivers.Fingerprint) {
+	ticker := time.NewTimer(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			ticker.Reset(fingerprintPeriod)
+			ch <- d.buildFingerprint()
+		}
+	}
+}
+
+func (d *Driver) buildFingerprint() *drivers.Fingerprint {
+	fingerprint := &drivers.Fingerprint{
+		Attributes:        map[string]*pstructs.Attribute{},
+		Health:            drivers.HealthStateHealthy,
+		HealthDescription: drivers.DriverHealthy,
+	}
+
+	// Firecracker only runs on Linux hosts
+	if runtime.GOOS != "linux" {
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = ""
+		return fingerprint
+	}
+
+	outBytes, err := exec.Command(d.config.FirecrackerPath, "--version").Output()
+	if err != nil {
+		// return no error, as it isn't an error to not find firecracker, it
+		// just means we can't use it.
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = ""
+		return fingerprint
+	}
+	out := strings.TrimSpace(string(outBytes))
+
+	matches := versionRegex.FindStringSubmatch(out)
+	if len(matches) != 2 {
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = fmt.Sprintf("Failed to parse firecracker version from %v", out)
+		return fingerprint
+	}
+
+	if _, err := os.Stat(kvmDevice); err != nil {
+		fingerprint.Health = drivers.HealthStateUnhealthy
+		fingerprint.HealthDescription = fmt.Sprintf("KVM is unavailable: %v", err)
+		return fingerprint
+	}
+
+	if d.config.Jailer.Enabled {
+		if _, err := exec.LookPath(d.config.Jailer.Path); err != nil {
+			fingerprint.Health = drivers.HealthStateUnhealthy
+			fingerprint.HealthDescription = fmt.Sprintf("Failed to find jailer: %v", err)
+			return fingerprint
+		}
+	}
+
+	fingerprint.Attributes[driverAttr] = pstructs.NewBoolAttribute(true)
+	fingerprint.Attributes[driverVersionAttr] = pstructs.NewStringAttribute(matches[1])
+	fingerprint.Attributes[driverJailerAttr] = pstructs.NewBoolAttribute(d.config.Jailer.Enabled)
+	return fingerprint
+}
+
+func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
+	if handle == nil {
+		return fmt.Errorf("error: handle cannot be nil")
+	}
+
+	// If already attached to handle there's nothing to recover.
+	if _, ok := d.tasks.Get(handle.Config.ID); ok {
+		d.logger.Trace("nothing to recover; task already exists",
+			"task_id", handle.Config.ID,
+			"task_name", handle.Config.Name,
+		)
+		return nil
+	}
+
+	var taskState TaskState
+	if err := handle.GetDriverState(&taskState); err != nil {
+		d.logger.Error("failed to decode taskConfig state from handle", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to decode taskConfig state from handle: %v", err)
+	}
+
+	plugRC, err := pstructs.ReattachConfigToGoPlugin(taskState.ReattachConfig)
+	if err != nil {
+		d.logger.Error("failed to build ReattachConfig from taskConfig state", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to build ReattachConfig from taskConfig state: %v", err)
+	}
+
+	execImpl, pluginClient, err := executor.ReattachToExecutor(
+		plugRC,
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
+		d.nomadConfig.Topology.Compute(),
+	)
+	if err != nil {
+		d.logger.Error("failed to reattach to executor", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to reattach to executor: %v", err)
+	}
+
+	h := &taskHandle{
+		exec:         execImpl,
+		pid:          taskState.Pid,
+		apiSocket:    taskState.APISocket,
+		chrootDir:    taskState.ChrootDir,
+		pluginClient: pluginClient,
+		taskConfig:   taskState.TaskConfig,
+		procState:    drivers.TaskStateRunning,
+		startedAt:    taskState.StartedAt,
+		exitResult:   &drivers.ExitResult{},
+		doneCh:       make(chan struct{}),
+		logger:       d.logger,
+	}
+
+	d.tasks.Set(taskState.TaskConfig.ID, h)
+
+	go h.run()
+	return nil
+}
+
+func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if _, ok := d.tasks.Get(cfg.ID); ok {
+		return nil, nil, fmt.Errorf("taskConfig with ID '%s' already started", cfg.ID)
+	}
+
+	var driverConfig TaskConfig
+	if err := cfg.DecodeDriverConfig(&driverConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	handle := drivers.NewTaskHandle(taskHandleVersion)
+	handle.Config = cfg
+
+	vm, err := d.newVM(cfg, &driverConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	firecrackerPath, err := getAbsolutePath(d.config.FirecrackerPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var args []string
+	user := cfg.User
+	if d.config.Jailer.Enabled {
+		jailerPath, err := getAbsolutePath(d.config.Jailer.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := vm.prepareJail(d.config.Jailer, firecrackerPath); err != nil {
+			vm.cleanup()
+			return nil, nil, fmt.Errorf("failed to prepare jail: %v", err)
+		}
+		args = append([]string{jailerPath}, vm.jailerArgs(d.config.Jailer, firecrackerPath)...)
+
+		// The jailer needs to run as root, and drops its privileges before
+		// starting firecracker
+		user = ""
+	} else {
+		if err := vm.prepareTaskDir(); err != nil {
+			return nil, nil, fmt.Errorf("failed to write microVM configuration: %v", err)
+		}
+		args = append([]string{firecrackerPath}, vm.firecrackerArgs()...)
+	}
+	d.logger.Debug("starting firecracker microVM", "args", strings.Join(args, " "))
+
+	pluginLogFile := filepath.Join(cfg.TaskDir().Dir, fmt.Sprintf("%s-executor.out", cfg.Name))
+	executorConfig := &executor.ExecutorConfig{
+		LogFile:  pluginLogFile,
+		LogLevel: "debug",
+		Compute:  d.nomadConfig.Topology.Compute(),
+	}
+
+	execImpl, pluginClient, err := executor.CreateExecutor(
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
+		d.nomadConfig, executorConfig)
+	if err != nil {
+		vm.cleanup()
+		return nil, nil, err
+	}
+
+	execCmd := &executor.ExecCommand{
+		Cmd:              args[0],
+		Args:             args[1:],
+		Env:              cfg.EnvList(),
+		User:             user,
+		TaskDir:          cfg.TaskDir().Dir,
+		StdoutPath:       cfg.StdoutPath,
+		StderrPath:       cfg.StderrPath,
+		NetworkIsolation: cfg.NetworkIsolation,
+		Resources:        cfg.Resources.Copy(),
+	}
+	ps, err := execImpl.Launch(execCmd)
+	if err != nil {
+		pluginClient.Kill()
+		vm.cleanup()
+		return nil, nil, err
+	}
+	d.logger.Debug("started new firecracker microVM", "task_id", cfg.ID)
+
+	h := &taskHandle{
+		exec:         execImpl,
+		pid:          ps.Pid,
+		apiSocket:    vm.apiSocket,
+		chrootDir:    vm.chrootDir,
+		pluginClient: pluginClient,
+		taskConfig:   cfg,
+		procState:    drivers.TaskStateRunning,
+		startedAt:    time.Now().Round(time.Millisecond),
+		doneCh:       make(chan struct{}),
+		logger:       d.logger,
+	}
+
+	driverState := TaskState{
+		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		Pid:            ps.Pid,
+		TaskConfig:     cfg,
+		StartedAt:      h.startedAt,
+		APISocket:      h.apiSocket,
+		ChrootDir:      h.chrootDir,
+	}
+
+	if err := handle.SetDriverState(&driverState); err != nil {
+		d.logger.Error("failed to start task, error setting driver state", "error", err)
+		execImpl.Shutdown("", 0)
+		pluginClient.Kill()
+		vm.cleanup()
+		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
+	}
+
+	d.tasks.Set(cfg.ID, h)
+	go h.run()
+	return handle, nil, nil
+}
+
+func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ch := make(chan *drivers.ExitResult)
+	go d.handleWait(ctx, handle, ch)
+
+	return ch, nil
+}
+
+func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	// Attempt a graceful shutdown only if it was configured in the job, and
+	// give the guest until the timeout to shut down before it is killed
+	grace := timeout
+	if handle.apiSocket != "" {
+		if err := sendCtrlAltDel(handle.apiSocket); err != nil {
+			d.logger.Debug("error sending graceful shutdown", "pid", handle.pid, "error", err)
+		} else {
+			select {
+			case <-handle.doneCh:
+				return nil
+			case <-time.After(timeout):
+			}
+			grace = 0
+		}
+	} else {
+		d.logger.Debug("graceful shutdown is disabled, forcing shutdown")
+	}
+
+	if err := handle.exec.Shutdown(signal, grace); err != nil {
+		if handle.pluginClient.Exited() {
+			return nil
+		}
+		return fmt.Errorf("executor Shutdown failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *Driver) DestroyTask(taskID string, force bool) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if handle.IsRunning() && !force {
+		return fmt.Errorf("cannot destroy running task")
+	}
+
+	if !handle.pluginClient.Exited() {
+		if err := handle.exec.Shutdown("", 0); err != nil {
+			handle.logger.Error("destroying executor failed", "error", err)
+		}
+
+		handle.pluginClient.Kill()
+	}
+
+	if handle.chrootDir != "" {
+		if err := os.RemoveAll(handle.chrootDir); err != nil {
+			handle.logger.Error("failed to remove jail", "path", handle.chrootDir, "error", err)
+		}
+	}
+
+	d.tasks.Delete(taskID)
+	return nil
+}
+
+func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.TaskStatus(), nil
+}
+
+func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Stats(ctx, interval)
+}
+
+func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
+	return d.eventer.TaskEvents(ctx)
+}
+
+func (d *Driver) SignalTask(_ string, _ string) error {
+	return fmt.Errorf("Firecracker driver can't signal commands")
+}
+
+// ExecTask isn't supported: the guest runs its own kernel and init, and there
+// is no agent in the microVM that the driver could run commands with.
+// Capabilities reports Exec as false, so alloc exec is refused before reaching
+// the driver, and job validation rejects template change modes that execute
+// commands. Script checks still call ExecTask and fail with this error.
+func (d *Driver) ExecTask(_ string, _ []string, _ time.Duration) (*drivers.ExecTaskResult, error) {
+	return nil, fmt.Errorf("Firecracker driver can't execute commands")
+}
+
+// getAbsolutePath returns the absolute path of the passed binary by resolving
+// it in the path and following symlinks.
+func getAbsolutePath(bin string) (string, error) {
+	lp, err := exec.LookPath(bin)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path to %q executable: %v", bin, err)
+	}
+
+	return filepath.EvalSymlinks(lp)
+}
+
+func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *drivers.ExitResult) {
+	defer close(ch)
+	var result *drivers.ExitResult
+	ps, err := handle.exec.Wait(ctx)
+	if err != nil {
+		result = &drivers.ExitResult{
+			Err: fmt.Errorf("executor: error waiting on process: %v", err),
+		}
+		// if process state is nil, we've probably been killed, so return a reasonable
+		// exit state to the handlers
+		if ps == nil {
+			result.ExitCode = -1
+			result.OOMKilled = false
+		}
+	} else {
+		result = &drivers.ExitResult{
+			ExitCode:  ps.ExitCode,
+			Signal:    ps.Signal,
+			OOMKilled: ps.OOMKilled,
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-d.ctx.Done():
+	case ch <- result:
+	}
+}
+
+// vcpuCount returns the number of vCPUs of the microVM, which is the number of
+// cores reserved for the task if any.
+func vcpuCount(cfg *drivers.TaskConfig, driverConfig *TaskConfig) (int, error) {
+	count := driverConfig.VCPUCount
+	if cores := len(cfg.Resources.NomadResources.Cpu.ReservedCores); cores > 0 {
+		if count != 0 && count != cores {
+			return 0, fmt.Errorf("vcpu_count must match the %d cores reserved for the task", cores)
+		}
+		count = cores
+	}
+	if count == 0 {
+		count = 1
+	}
+	if count < 1 || count > 32 {
+		return 0, fmt.Errorf("vcpu_count must be between 1 and 32, got %d", count)
+	}
+	return count, nil
+}
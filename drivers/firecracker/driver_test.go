@@ -0,0 +1,362 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package firecracker
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestFirecrackerDriver_Fingerprint_Undetected(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewFirecrackerDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	d.config.FirecrackerPath = filepath.Join(t.TempDir(), "firecracker")
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	fingerCh, err := harness.Fingerprint(context.Background())
+	must.NoError(t, err)
+	select {
+	case finger := <-fingerCh:
+		must.Eq(t, drivers.HealthStateUndetected, finger.Health)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		t.Fatal("timeout receiving fingerprint")
+	}
+}
+
+func TestConfig_ParseAllHCL(t *testing.T) {
+	ci.Parallel(t)
+
+	cfgStr := `
+config {
+  kernel_image     = "local/vmlinux"
+  kernel_args      = "console=ttyS0"
+  initrd           = "local/initrd"
+  rootfs           = "local/rootfs.ext4"
+  rootfs_read_only = true
+  drive {
+    path      = "local/data.ext4"
+    read_only = false
+  }
+  vcpu_count = 2
+  smt        = true
+  network_interface {
+    host_dev_name = "tap0"
+    guest_mac     = "06:00:AC:10:00:02"
+  }
+  graceful_shutdown = true
+}`
+
+	expected := &TaskConfig{
+		KernelImage:    "local/vmlinux",
+		KernelArgs:     "console=ttyS0",
+		Initrd:         "local/initrd",
+		RootFS:         "local/rootfs.ext4",
+		RootFSReadOnly: true,
+		Drives:         []Drive{{Path: "local/data.ext4"}},
+		VCPUCount:      2,
+		SMT:            true,
+		NetworkInterfaces: []NetworkInterface{{
+			HostDevName: "tap0",
+			GuestMAC:    "06:00:AC:10:00:02",
+		}},
+		GracefulShutdown: true,
+	}
+
+	var tc *TaskConfig
+	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+	must.Eq(t, expected, tc)
+}
+
+func TestConfig_PluginDefaults(t *testing.T) {
+	ci.Parallel(t)
+
+	var c *Config
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, `config {}`, &c)
+	must.Eq(t, &Config{
+		FirecrackerPath: "firecracker",
+		Jailer: JailerConfig{
+			Enabled:       true,
+			Path:          "jailer",
+			ChrootBaseDir: "/srv/jailer",
+			UID:           65534,
+			GID:           65534,
+		},
+	}, c)
+}
+
+func TestVCPUCount(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		count    int
+		reserved []uint16
+		exp      int
+		expErr   string
+	}{
+		{name: "default", exp: 1},
+		{name: "set", count: 4, exp: 4},
+		{name: "reserved cores", reserved: []uint16{2, 3}, exp: 2},
+		{name: "matching reserved cores", count: 2, reserved: []uint16{2, 3}, exp: 2},
+		{name: "mismatching reserved cores", count: 4, reserved: []uint16{2, 3}, expErr: "must match the 2 cores"},
+		{name: "too many", count: 33, expErr: "must be between 1 and 32"},
+		{name: "negative", count: -1, expErr: "must be between 1 and 32"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &drivers.TaskConfig{Resources: testResources(128)}
+			cfg.Resources.NomadResources.Cpu.ReservedCores = tc.reserved
+
+			count, err := vcpuCount(cfg, &TaskConfig{VCPUCount: tc.count})
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, count)
+		})
+	}
+}
+
+func TestNewVM(t *testing.T) {
+	ci.Parallel(t)
+
+	imageDir := t.TempDir()
+	d := &Driver{config: Config{ImagePaths: []string{imageDir}}}
+	cfg := testTaskConfig(t, 256)
+	taskDir := cfg.TaskDir().Dir
+
+	v, err := d.newVM(cfg, &TaskConfig{
+		KernelImage:       filepath.Join(imageDir, "vmlinux"),
+		RootFS:            "local/rootfs.ext4",
+		Drives:            []Drive{{Path: filepath.Join(imageDir, "data.ext4"), ReadOnly: true}},
+		VCPUCount:         2,
+		NetworkInterfaces: []NetworkInterface{{HostDevName: "tap0"}},
+	})
+	must.NoError(t, err)
+	must.Eq(t, vmConfig{
+		BootSource: vmBootSource{
+			KernelImagePath: filepath.Join(imageDir, "vmlinux"),
+			BootArgs:        defaultKernelArgs,
+		},
+		Drives: []vmDrive{
+			{
+				DriveID:      "rootfs",
+				PathOnHost:   filepath.Join(taskDir, "local", "rootfs.ext4"),
+				IsRootDevice: true,
+			},
+			{
+				DriveID:    "drive1",
+				PathOnHost: filepath.Join(imageDir, "data.ext4"),
+				IsReadOnly: true,
+			},
+		},
+		MachineConfig: vmMachineConfig{VCPUCount: 2, MemSizeMiB: 256},
+		NetworkInterfaces: []vmNetworkInterface{{
+			IfaceID:     "eth0",
+			HostDevName: "tap0",
+		}},
+	}, v.config)
+
+	must.NoError(t, os.MkdirAll(taskDir, 0o755))
+	must.NoError(t, v.prepareTaskDir())
+	must.Eq(t, []string{
+		"--config-file", filepath.Join(taskDir, vmConfigName),
+		"--no-api",
+	}, v.firecrackerArgs())
+	must.FileExists(t, filepath.Join(taskDir, vmConfigName))
+}
+
+func TestNewVM_Paths(t *testing.T) {
+	ci.Parallel(t)
+
+	imageDir := t.TempDir()
+	d := &Driver{config: Config{ImagePaths: []string{imageDir}}}
+	cfg := testTaskConfig(t, 128)
+
+	cases := []struct {
+		name   string
+		config TaskConfig
+		expErr string
+	}{
+		{
+			name:   "kernel outside allowed paths",
+			config: TaskConfig{KernelImage: "/vmlinux", RootFS: "local/rootfs"},
+			expErr: "invalid kernel_image",
+		},
+		{
+			name:   "kernel escaping the task directory",
+			config: TaskConfig{KernelImage: "../../../vmlinux", RootFS: "local/rootfs"},
+			expErr: "invalid kernel_image",
+		},
+		{
+			name:   "writable rootfs in image paths",
+			config: TaskConfig{KernelImage: "local/vmlinux", RootFS: filepath.Join(imageDir, "rootfs")},
+			expErr: "must be in the allocation directory to be writable",
+		},
+		{
+			name: "writable drive in image paths",
+			config: TaskConfig{
+				KernelImage: "local/vmlinux",
+				RootFS:      "local/rootfs",
+				Drives:      []Drive{{Path: filepath.Join(imageDir, "data")}},
+			},
+			expErr: "must be in the allocation directory to be writable",
+		},
+		{
+			name: "read-only rootfs in image paths",
+			config: TaskConfig{
+				KernelImage:    filepath.Join(imageDir, "vmlinux"),
+				RootFS:         filepath.Join(imageDir, "rootfs"),
+				RootFSReadOnly: true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := d.newVM(cfg, &tc.config)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+		})
+	}
+}
+
+func TestNewVM_Memory(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &Driver{}
+	_, err := d.newVM(testTaskConfig(t, 64), &TaskConfig{
+		KernelImage: "local/vmlinux",
+		RootFS:      "local/rootfs",
+	})
+	must.ErrorContains(t, err, "at least 128 MiB")
+}
+
+func TestVM_PrepareJail(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &Driver{}
+	cfg := testTaskConfig(t, 128)
+	local := filepath.Join(cfg.TaskDir().Dir, "local")
+	must.NoError(t, os.MkdirAll(local, 0o755))
+	for _, f := range []string{"vmlinux", "rootfs", "data"} {
+		must.NoError(t, os.WriteFile(filepath.Join(local, f), []byte(f), 0o644))
+	}
+
+	v, err := d.newVM(cfg, &TaskConfig{
+		KernelImage:      "local/vmlinux",
+		RootFS:           "local/rootfs",
+		Drives:           []Drive{{Path: "local/data"}},
+		GracefulShutdown: true,
+	})
+	must.NoError(t, err)
+
+	// socket paths have a maximum length, so use a shorter directory than
+	// t.TempDir
+	base, err := os.MkdirTemp("", "jail")
+	must.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(base) })
+
+	jailer := JailerConfig{
+		Enabled:       true,
+		ChrootBaseDir: base,
+		UID:           os.Getuid(),
+		GID:           os.Getgid(),
+	}
+	must.NoError(t, v.prepareJail(jailer, "/usr/bin/firecracker"))
+
+	root := filepath.Join(jailer.ChrootBaseDir, "firecracker", v.id, "root")
+	must.Eq(t, filepath.Dir(root), v.chrootDir)
+	must.Eq(t, filepath.Join(root, "run", apiSocketName), v.apiSocket)
+
+	for name, content := range map[string]string{"kernel": "vmlinux", "rootfs": "rootfs", "drive1": "data"} {
+		b, err := os.ReadFile(filepath.Join(root, name))
+		must.NoError(t, err)
+		must.Eq(t, content, string(b))
+	}
+
+	b, err := os.ReadFile(filepath.Join(root, vmConfigName))
+	must.NoError(t, err)
+	var config vmConfig
+	must.NoError(t, json.Unmarshal(b, &config))
+	must.Eq(t, "/kernel", config.BootSource.KernelImagePath)
+	must.Eq(t, "/rootfs", config.Drives[0].PathOnHost)
+	must.Eq(t, "/drive1", config.Drives[1].PathOnHost)
+
+	must.Eq(t, []string{
+		"--id", v.id,
+		"--exec-file", "/usr/bin/firecracker",
+		"--uid", strconv.Itoa(jailer.UID),
+		"--gid", strconv.Itoa(jailer.GID),
+		"--chroot-base-dir", jailer.ChrootBaseDir,
+		"--",
+		"--config-file", "/" + vmConfigName,
+		"--api-sock", "/run/" + apiSocketName,
+	}, v.jailerArgs(jailer, "/usr/bin/firecracker"))
+
+	v.cleanup()
+	must.DirNotExists(t, v.chrootDir)
+}
+
+func TestJailID(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &drivers.TaskConfig{AllocID: uuid.Generate(), ID: uuid.Generate()}
+	id := jailID(cfg)
+	must.LessEq(t, 64, len(id))
+	must.RegexMatch(t, regexp.MustCompile(`^[a-zA-Z0-9-]+$`), id)
+	must.NotEq(t, id, jailID(&drivers.TaskConfig{AllocID: cfg.AllocID, ID: uuid.Generate()}))
+}
+
+func testTaskConfig(t *testing.T, memoryMB int64) *drivers.TaskConfig {
+	return &drivers.TaskConfig{
+		AllocID:   uuid.Generate(),
+		ID:        uuid.Generate(),
+		Name:      "web",
+		AllocDir:  t.TempDir(),
+		Resources: testResources(memoryMB),
+	}
+}
+
+func testResources(memoryMB int64) *drivers.Resources {
+	return &drivers.Resources{
+		NomadResources: &structs.AllocatedTaskResources{
+			Memory: structs.AllocatedMemoryResources{
+				MemoryMB: memoryMB,
+			},
+			Cpu: structs.AllocatedCpuResources{
+				CpuShares: 100,
+			},
+		},
+		LinuxResources: &drivers.LinuxResources{
+			MemoryLimitBytes: memoryMB * 1024 * 1024,
+			CPUShares:        100,
+		},
+	}
+}
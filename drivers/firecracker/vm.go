@@ -0,0 +1,411 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package firecracker
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// vmConfigName is the name of the configuration file of microVMs
+	vmConfigName = "vm.json"
+
+	// apiSocketName is the name of the API socket of microVMs. Use a short
+	// file name since socket paths have a maximum length.
+	apiSocketName = "fc.sock"
+
+	// maxSocketPathLen is the maximum length of unix socket paths
+	// https://man7.org/linux/man-pages/man7/unix.7.html
+	maxSocketPathLen = 108
+
+	// apiTimeout is the timeout of requests to the API of microVMs
+	apiTimeout = 5 * time.Second
+)
+
+// vmConfig is the configuration file firecracker starts a microVM from. Refer
+// to the API specification of Firecracker for the meaning of the fields.
+type vmConfig struct {
+	BootSource        vmBootSource         `json:"boot-source"`
+	Drives            []vmDrive            `json:"drives"`
+	MachineConfig     vmMachineConfig      `json:"machine-config"`
+	NetworkInterfaces []vmNetworkInterface `json:"network-interfaces,omitempty"`
+}
+
+type vmBootSource struct {
+	KernelImagePath string `json:"kernel_image_path"`
+	BootArgs        string `json:"boot_args,omitempty"`
+	InitrdPath      string `json:"initrd_path,omitempty"`
+}
+
+type vmDrive struct {
+	DriveID      string `json:"drive_id"`
+	PathOnHost   string `json:"path_on_host"`
+	IsRootDevice bool   `json:"is_root_device"`
+	IsReadOnly   bool   `json:"is_read_only"`
+}
+
+type vmMachineConfig struct {
+	VCPUCount  int   `json:"vcpu_count"`
+	MemSizeMiB int64 `json:"mem_size_mib"`
+	SMT        bool  `json:"smt"`
+}
+
+type vmNetworkInterface struct {
+	IfaceID     string `json:"iface_id"`
+	GuestMAC    string `json:"guest_mac,omitempty"`
+	HostDevName string `json:"host_dev_name"`
+}
+
+// vm holds the configuration of a microVM being started, and the paths of the
+// files created for it.
+type vm struct {
+	id      string
+	taskDir string
+	config  vmConfig
+
+	// gracefulShutdown enables the API socket of the microVM, which is used
+	// to shut the guest down.
+	gracefulShutdown bool
+
+	// configPath and apiSocket are the paths of the configuration file and of
+	// the API socket of the microVM, as seen by firecracker.
+	configPath    string
+	apiSocketPath string
+
+	// apiSocket is the path of the API socket on the host, and chrootDir the
+	// directory of the jail of the microVM, if any.
+	apiSocket string
+	chrootDir string
+}
+
+// newVM builds the configuration of the microVM of a task. The paths of the
+// kernel and drives are resolved against the task directory, and must be in
+// the allocation directory or in the image paths of the plugin.
+func (d *Driver) newVM(cfg *drivers.TaskConfig, driverConfig *TaskConfig) (*vm, error) {
+	taskDir := cfg.TaskDir().Dir
+
+	kernel, err := d.resolveImagePath(cfg, driverConfig.KernelImage, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kernel_image: %v", err)
+	}
+
+	var initrd string
+	if driverConfig.Initrd != "" {
+		if initrd, err = d.resolveImagePath(cfg, driverConfig.Initrd, true); err != nil {
+			return nil, fmt.Errorf("invalid initrd: %v", err)
+		}
+	}
+
+	kernelArgs := driverConfig.KernelArgs
+	if kernelArgs == "" {
+		kernelArgs = defaultKernelArgs
+	}
+
+	rootfs, err := d.resolveImagePath(cfg, driverConfig.RootFS, driverConfig.RootFSReadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rootfs: %v", err)
+	}
+	drives := []vmDrive{{
+		DriveID:      "rootfs",
+		PathOnHost:   rootfs,
+		IsRootDevice: true,
+		IsReadOnly:   driverConfig.RootFSReadOnly,
+	}}
+	for i, drive := range driverConfig.Drives {
+		path, err := d.resolveImagePath(cfg, drive.Path, drive.ReadOnly)
+		if err != nil {
+			return nil, fmt.Errorf("invalid drive %q: %v", drive.Path, err)
+		}
+		drives = append(drives, vmDrive{
+			DriveID:    "drive" + strconv.Itoa(i+1),
+			PathOnHost: path,
+			IsReadOnly: drive.ReadOnly,
+		})
+	}
+
+	vcpus, err := vcpuCount(cfg, driverConfig)
+	if err != nil {
+		return nil, err
+	}
+	mb := cfg.Resources.NomadResources.Memory.MemoryMB
+	if mb < 128 {
+		return nil, fmt.Errorf("microVMs require at least 128 MiB of memory")
+	}
+
+	var ifaces []vmNetworkInterface
+	for i, iface := range driverConfig.NetworkInterfaces {
+		if iface.HostDevName == "" {
+			return nil, fmt.Errorf("network_interface host_dev_name must be set")
+		}
+		ifaces = append(ifaces, vmNetworkInterface{
+			IfaceID:     "eth" + strconv.Itoa(i),
+			GuestMAC:    iface.GuestMAC,
+			HostDevName: iface.HostDevName,
+		})
+	}
+
+	return &vm{
+		id:      jailID(cfg),
+		taskDir: taskDir,
+		config: vmConfig{
+			BootSource: vmBootSource{
+				KernelImagePath: kernel,
+				BootArgs:        kernelArgs,
+				InitrdPath:      initrd,
+			},
+			Drives: drives,
+			MachineConfig: vmMachineConfig{
+				VCPUCount:  vcpus,
+				MemSizeMiB: mb,
+				SMT:        driverConfig.SMT,
+			},
+			NetworkInterfaces: ifaces,
+		},
+		gracefulShutdown: driverConfig.GracefulShutdown,
+	}, nil
+}
+
+// resolveImagePath returns the absolute path of a file of the microVM. Files
+// the microVM can write to must be in the allocation directory, so that tasks
+// can't modify the images shared by the host.
+func (d *Driver) resolveImagePath(cfg *drivers.TaskConfig, path string, readOnly bool) (string, error) {
+	if path == "" {
+		return "", errors.New("path must be set")
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.TaskDir().Dir, path)
+	}
+	path = filepath.Clean(path)
+
+	if isParent(cfg.AllocDir, path) {
+		return path, nil
+	}
+	if !readOnly {
+		return "", fmt.Errorf("%q must be in the allocation directory to be writable", path)
+	}
+	for _, ap := range d.config.ImagePaths {
+		if isParent(ap, path) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%q is not in the allowed paths", path)
+}
+
+func isParent(parent, path string) bool {
+	rel, err := filepath.Rel(parent, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+// jailID returns the ID of the jail of a task, which may only contain
+// alphanumeric characters and hyphens and is at most 64 characters long.
+func jailID(cfg *drivers.TaskConfig) string {
+	sum := sha256.Sum256([]byte(cfg.ID))
+	return fmt.Sprintf("%s-%x", cfg.AllocID, sum[:8])
+}
+
+// prepareTaskDir writes the configuration file of a microVM started without
+// the jailer to the task directory.
+func (v *vm) prepareTaskDir() error {
+	v.configPath = filepath.Join(v.taskDir, vmConfigName)
+	if v.gracefulShutdown {
+		v.apiSocket = filepath.Join(v.taskDir, apiSocketName)
+		v.apiSocketPath = v.apiSocket
+		if err := validateSocketPath(v.apiSocket); err != nil {
+			return err
+		}
+	}
+	return writeVMConfig(v.configPath, &v.config, -1, -1)
+}
+
+// prepareJail creates the chroot directory of the jail of a microVM, with the
+// kernel, drives, and configuration of the microVM linked or copied into it.
+// The jailer chroots into the directory before starting firecracker.
+func (v *vm) prepareJail(jailer JailerConfig, firecrackerPath string) error {
+	v.chrootDir = filepath.Join(jailer.ChrootBaseDir, filepath.Base(firecrackerPath), v.id)
+	root := filepath.Join(v.chrootDir, "root")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return err
+	}
+
+	jailFile := func(name, path string) (string, error) {
+		dst := filepath.Join(root, name)
+		if err := linkOrCopy(path, dst); err != nil {
+			return "", fmt.Errorf("failed to add %q to jail: %v", path, err)
+		}
+		if err := os.Chown(dst, jailer.UID, jailer.GID); err != nil {
+			return "", err
+		}
+		return "/" + name, nil
+	}
+
+	config := v.config
+	var err error
+	if config.BootSource.KernelImagePath, err = jailFile("kernel", config.BootSource.KernelImagePath); err != nil {
+		return err
+	}
+	if config.BootSource.InitrdPath != "" {
+		if config.BootSource.InitrdPath, err = jailFile("initrd", config.BootSource.InitrdPath); err != nil {
+			return err
+		}
+	}
+	config.Drives = make([]vmDrive, len(v.config.Drives))
+	for i, drive := range v.config.Drives {
+		if drive.PathOnHost, err = jailFile(drive.DriveID, drive.PathOnHost); err != nil {
+			return err
+		}
+		config.Drives[i] = drive
+	}
+
+	if v.gracefulShutdown {
+		runDir := filepath.Join(root, "run")
+		if err := os.MkdirAll(runDir, 0o755); err != nil {
+			return err
+		}
+		if err := os.Chown(runDir, jailer.UID, jailer.GID); err != nil {
+			return err
+		}
+		v.apiSocket = filepath.Join(runDir, apiSocketName)
+		v.apiSocketPath = "/run/" + apiSocketName
+		if err := validateSocketPath(v.apiSocket); err != nil {
+			return err
+		}
+	}
+
+	v.configPath = "/" + vmConfigName
+	return writeVMConfig(filepath.Join(root, vmConfigName), &config, jailer.UID, jailer.GID)
+}
+
+// firecrackerArgs returns the arguments firecracker is started with. The API
+// socket is only enabled for graceful shutdowns.
+func (v *vm) firecrackerArgs() []string {
+	args := []string{"--config-file", v.configPath}
+	if v.apiSocketPath != "" {
+		return append(args, "--api-sock", v.apiSocketPath)
+	}
+	return append(args, "--no-api")
+}
+
+// jailerArgs returns the arguments the jailer is started with, followed by
+// the arguments it starts firecracker with.
+func (v *vm) jailerArgs(jailer JailerConfig, firecrackerPath string) []string {
+	args := []string{
+		"--id", v.id,
+		"--exec-file", firecrackerPath,
+		"--uid", strconv.Itoa(jailer.UID),
+		"--gid", strconv.Itoa(jailer.GID),
+		"--chroot-base-dir", jailer.ChrootBaseDir,
+		"--",
+	}
+	return append(args, v.firecrackerArgs()...)
+}
+
+// cleanup removes the jail of a microVM that failed to start.
+func (v *vm) cleanup() {
+	if v.chrootDir != "" {
+		os.RemoveAll(v.chrootDir)
+	}
+}
+
+// writeVMConfig writes the configuration file of a microVM, and gives its
+// ownership to uid and gid unless they are -1.
+func writeVMConfig(path string, config *vmConfig, uid, gid int) error {
+	b, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return err
+	}
+	if uid == -1 && gid == -1 {
+		return nil
+	}
+	return os.Chown(path, uid, gid)
+}
+
+// linkOrCopy hard links src to dst, and copies it when they are on different
+// file systems.
+func linkOrCopy(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// validateSocketPath ensures the path of an API socket isn't longer than the
+// maximum length of socket paths.
+func validateSocketPath(path string) error {
+	if len(path) > maxSocketPathLen {
+		return fmt.Errorf(
+			"socket path %s is longer than the maximum length allowed (%d), try to reduce the task name or the jailer chroot_base_dir if possible",
+			path, maxSocketPathLen)
+	}
+	return nil
+}
+
+// sendCtrlAltDel asks the guest of a microVM to shut down, by sending it a
+// Ctrl+Alt+Del keystroke through the API socket of the microVM.
+func sendCtrlAltDel(socket string) error {
+	client := &http.Client{
+		Timeout: apiTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+
+	body := []byte(`{"action_type":"SendCtrlAltDel"}`)
+	req, err := http.NewRequest(http.MethodPut, "http://localhost/actions", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected response from firecracker: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -703,6 +703,19 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return handle.exec.Signal(sig)
 }
 
+var _ drivers.ResourceUpdateDriver = (*Driver)(nil)
+
+// UpdateTaskResources updates the limits of the cgroups of a running task to
+// its new resources.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.UpdateResources(resources)
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	if len(cmd) == 0 {
 		return nil, fmt.Errorf("error cmd must have at least one value")
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package api is a minimal client of the libpod REST API of podman, covering
+// the endpoints the podman task driver uses. Refer to the podman API reference
+// for the endpoints: https://docs.podman.io/en/latest/_static/api.html
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// apiPath is the prefix of the libpod endpoints. Podman serves every
+	// version of its API, so use the oldest one the endpoints exist in.
+	apiPath = "http://podman/v1.0.0/libpod"
+
+	// defaultRootfulSocket is the socket of the podman service of root
+	defaultRootfulSocket = "unix:///run/podman/podman.sock"
+)
+
+var (
+	// ErrNotFound is returned when the container, image, or exec session of
+	// a request doesn't exist
+	ErrNotFound = errors.New("no such object")
+)
+
+// ClientConfig configures the client of the podman API
+type ClientConfig struct {
+	// SocketPath is the unix socket podman serves its API on, prefixed with
+	// unix://
+	SocketPath string
+
+	// HTTPTimeout is the timeout of requests, except for streaming requests
+	// such as waits, logs, and image pulls
+	HTTPTimeout time.Duration
+}
+
+// DefaultSocketPath returns the socket of the podman service of the user
+// running Nomad, which is the socket of root or the socket in the runtime
+// directory of the user when running rootless.
+func DefaultSocketPath() string {
+	uid := os.Getuid()
+	if uid == 0 {
+		return defaultRootfulSocket
+	}
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = filepath.Join("/run/user", strconv.Itoa(uid))
+	}
+	return "unix://" + filepath.Join(runtimeDir, "podman", "podman.sock")
+}
+
+// API is a client of the libpod REST API of podman
+type API struct {
+	socket string
+
+	// httpClient is used for regular requests, and httpStreamClient for
+	// streaming requests which have no timeout
+	httpClient       *http.Client
+	httpStreamClient *http.Client
+
+	logger hclog.Logger
+}
+
+// NewClient returns a client of the podman API served on the socket of
+// config.
+func NewClient(logger hclog.Logger, config ClientConfig) (*API, error) {
+	socket, ok := strings.CutPrefix(config.SocketPath, "unix://")
+	if !ok {
+		return nil, fmt.Errorf("socket_path must be a unix socket prefixed with unix://, got %q", config.SocketPath)
+	}
+
+	api := &API{
+		socket: socket,
+		logger: logger.Named("podman_client"),
+	}
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return api.dial(ctx)
+		},
+	}
+	api.httpClient = &http.Client{Timeout: config.HTTPTimeout, Transport: transport}
+	api.httpStreamClient = &http.Client{Transport: transport}
+	return api, nil
+}
+
+// SocketPath returns the path of the socket of the podman API
+func (a *API) SocketPath() string {
+	return a.socket
+}
+
+func (a *API) dial(ctx context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "unix", a.socket)
+}
+
+func (a *API) do(ctx context.Context, client *http.Client, method, path string, body any, header http.Header) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiPath+path, r)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return client.Do(req)
+}
+
+// request sends a request expecting one of the expected status codes, and
+// decodes the JSON response into out unless it is nil.
+func (a *API) request(ctx context.Context, method, path string, body, out any, expected ...int) error {
+	resp, err := a.do(ctx, a.httpClient, method, path, body, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, expected...); err != nil {
+		return err
+	}
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// errorResponse is the body of the responses of failed requests
+type errorResponse struct {
+	Cause    string `json:"cause"`
+	Message  string `json:"message"`
+	Response int    `json:"response"`
+}
+
+// checkStatus returns an error unless the status code of resp is one of the
+// expected ones, with the message of the error of the response if any.
+func checkStatus(resp *http.Response, expected ...int) error {
+	for _, code := range expected {
+		if resp.StatusCode == code {
+			return nil
+		}
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	msg := strings.TrimSpace(string(b))
+	var errResp errorResponse
+	if json.Unmarshal(b, &errResp) == nil && errResp.Message != "" {
+		msg = errResp.Message
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, msg)
+	}
+	return fmt.Errorf("unexpected response from podman: %s: %s", resp.Status, msg)
+}
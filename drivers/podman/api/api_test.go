@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+)
+
+// newTestAPI returns a client of a podman API served by handler.
+func newTestAPI(t *testing.T, handler http.Handler) *API {
+	// unix sockets paths are limited to 108 characters, which test dirs may
+	// exceed
+	dir, err := os.MkdirTemp("", "podman")
+	must.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "podman.sock")
+	l, err := net.Listen("unix", socket)
+	must.NoError(t, err)
+
+	srv := &http.Server{Handler: handler}
+	go srv.Serve(l)
+	t.Cleanup(func() { srv.Close() })
+
+	api, err := NewClient(testlog.HCLogger(t), ClientConfig{
+		SocketPath:  "unix://" + socket,
+		HTTPTimeout: 5 * time.Second,
+	})
+	must.NoError(t, err)
+	return api
+}
+
+func frame(stream byte, payload string) []byte {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, payload...)
+}
+
+func TestNewClient_Socket(t *testing.T) {
+	ci.Parallel(t)
+
+	_, err := NewClient(testlog.HCLogger(t), ClientConfig{SocketPath: "/run/podman/podman.sock"})
+	must.ErrorContains(t, err, "unix://")
+
+	api, err := NewClient(testlog.HCLogger(t), ClientConfig{SocketPath: "unix:///run/podman/podman.sock"})
+	must.NoError(t, err)
+	must.Eq(t, "/run/podman/podman.sock", api.SocketPath())
+}
+
+func TestAPI_SystemInfo(t *testing.T) {
+	ci.Parallel(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1.0.0/libpod/info", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+  "host": {
+    "cgroupManager": "systemd",
+    "cgroupVersion": "v2",
+    "cgroupControllers": ["cpu", "memory", "pids"],
+    "ociRuntime": {"name": "crun"},
+    "security": {"rootless": true}
+  },
+  "version": {"APIVersion": "5.0.0", "Version": "5.0.0"}
+}`))
+	})
+	api := newTestAPI(t, mux)
+
+	info, err := api.SystemInfo(context.Background())
+	must.NoError(t, err)
+	must.Eq(t, "systemd", info.Host.CgroupManager)
+	must.Eq(t, "v2", info.Host.CgroupVersion)
+	must.Eq(t, []string{"cpu", "memory", "pids"}, info.Host.CgroupControllers)
+	must.Eq(t, "crun", info.Host.OCIRuntime.Name)
+	must.True(t, info.Host.Security.Rootless)
+	must.Eq(t, "5.0.0", info.Version.Version)
+}
+
+func TestAPI_Errors(t *testing.T) {
+	ci.Parallel(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1.0.0/libpod/containers/missing/json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"cause": "no such container", "message": "no container with name or ID \"missing\" found", "response": 404}`))
+	})
+	mux.HandleFunc("POST /v1.0.0/libpod/containers/broken/start", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message": "crun: executable file not found", "response": 500}`))
+	})
+	api := newTestAPI(t, mux)
+
+	_, err := api.ContainerInspect(context.Background(), "missing")
+	must.True(t, errors.Is(err, ErrNotFound))
+	must.ErrorContains(t, err, `no container with name or ID "missing" found`)
+
+	err = api.ContainerStart(context.Background(), "broken")
+	must.ErrorContains(t, err, "crun: executable file not found")
+	must.False(t, errors.Is(err, ErrNotFound))
+}
+
+func TestAPI_ContainerCreate(t *testing.T) {
+	ci.Parallel(t)
+
+	var got SpecGenerator
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1.0.0/libpod/containers/create", func(w http.ResponseWriter, r *http.Request) {
+		must.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"Id": "abc", "Warnings": []}`))
+	})
+	api := newTestAPI(t, mux)
+
+	s := &SpecGenerator{
+		Name:  "web-1234",
+		Image: "docker.io/library/busybox:1",
+		NetNS: Namespace{Mode: "path", Value: "/var/run/netns/1234"},
+		PortMappings: []PortMapping{
+			{ContainerPort: 8080, HostPort: 25000, Protocol: "tcp,udp"},
+		},
+	}
+	resp, err := api.ContainerCreate(context.Background(), s)
+	must.NoError(t, err)
+	must.Eq(t, "abc", resp.ID)
+	must.Eq(t, *s, got)
+}
+
+func TestAPI_ContainerWait(t *testing.T) {
+	ci.Parallel(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1.0.0/libpod/containers/abc/wait", func(w http.ResponseWriter, r *http.Request) {
+		must.Eq(t, []string{"stopped", "exited"}, r.URL.Query()["condition"])
+		w.Write([]byte("137"))
+	})
+	api := newTestAPI(t, mux)
+
+	code, err := api.ContainerWait(context.Background(), "abc")
+	must.NoError(t, err)
+	must.Eq(t, 137, code)
+}
+
+func TestAPI_ContainerStats(t *testing.T) {
+	ci.Parallel(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1.0.0/libpod/containers/stats", func(w http.ResponseWriter, r *http.Request) {
+		must.Eq(t, "abc", r.URL.Query().Get("containers"))
+		w.Write([]byte(`{"Error": null, "Stats": [{"ContainerID": "abc", "CPUNano": 2000, "CPUSystemNano": 500, "MemUsage": 4096, "NetInput": 1, "NetOutput": 2, "BlockInput": 3, "BlockOutput": 4}]}`))
+	})
+	api := newTestAPI(t, mux)
+
+	stats, err := api.ContainerStats(context.Background(), "abc")
+	must.NoError(t, err)
+	must.Eq(t, &ContainerStats{
+		ContainerID: "abc",
+		CPUNano:     2000,
+		CPUSystem:   500,
+		MemUsage:    4096,
+		NetInput:    1,
+		NetOutput:   2,
+		BlockInput:  3,
+		BlockOutput: 4,
+	}, stats)
+}
+
+func TestAPI_ImagePull(t *testing.T) {
+	ci.Parallel(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1.0.0/libpod/images/pull", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("reference") {
+		case "busybox:1":
+			must.NotEq(t, "", r.Header.Get("X-Registry-Auth"))
+			w.Write([]byte("{\"stream\": \"Trying to pull busybox:1...\"}\n{\"images\": [\"sha\"], \"id\": \"sha\"}\n"))
+		default:
+			w.Write([]byte(`{"error": "manifest unknown"}` + "\n"))
+		}
+	})
+	api := newTestAPI(t, mux)
+
+	id, err := api.ImagePull(context.Background(), "busybox:1", &ImageAuth{Username: "user", Password: "pass"})
+	must.NoError(t, err)
+	must.Eq(t, "sha", id)
+
+	_, err = api.ImagePull(context.Background(), "missing:1", nil)
+	must.ErrorContains(t, err, "manifest unknown")
+}
+
+func TestAPI_ContainerLogs(t *testing.T) {
+	ci.Parallel(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1.0.0/libpod/containers/abc/logs", func(w http.ResponseWriter, r *http.Request) {
+		must.Eq(t, "true", r.URL.Query().Get("follow"))
+		must.Eq(t, "100", r.URL.Query().Get("since"))
+		w.Write(frame(streamStdout, "hello\n"))
+		w.Write(frame(streamStderr, "oops\n"))
+		w.Write(frame(streamStdout, "world\n"))
+	})
+	api := newTestAPI(t, mux)
+
+	var stdout, stderr bytes.Buffer
+	err := api.ContainerLogs(context.Background(), "abc", time.Unix(100, 0), &stdout, &stderr)
+	must.NoError(t, err)
+	must.Eq(t, "hello\nworld\n", stdout.String())
+	must.Eq(t, "oops\n", stderr.String())
+}
+
+func TestDemuxStream(t *testing.T) {
+	ci.Parallel(t)
+
+	var stream bytes.Buffer
+	stream.Write(frame(streamStdout, "out"))
+	stream.Write(frame(streamStdin, "ignored"))
+	stream.Write(frame(streamStderr, "err"))
+	stream.Write(frame(streamSystem, "exec session exited"))
+	stream.Write(frame(streamStdout, "unread"))
+
+	var stdout, stderr bytes.Buffer
+	err := demuxStream(&stream, &stdout, &stderr)
+	must.ErrorContains(t, err, "exec session exited")
+	must.Eq(t, "out", stdout.String())
+	must.Eq(t, "err", stderr.String())
+
+	// truncated frames are errors
+	truncated := frame(streamStdout, "truncated")
+	err = demuxStream(bytes.NewReader(truncated[:10]), &stdout, &stderr)
+	must.Error(t, err)
+}
+
+func TestAPI_ExecStart(t *testing.T) {
+	ci.Parallel(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1.0.0/libpod/exec/session/start", func(w http.ResponseWriter, r *http.Request) {
+		must.Eq(t, "tcp", r.Header.Get("Upgrade"))
+		conn, buf, err := http.NewResponseController(w).Hijack()
+		must.NoError(t, err)
+		defer conn.Close()
+		buf.WriteString("HTTP/1.1 101 UPGRADED\r\nContent-Type: application/vnd.docker.multiplexed-stream\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+		buf.Write(frame(streamStdout, "id: uid=0\n"))
+		buf.Write(frame(streamStderr, "warning\n"))
+		buf.Flush()
+	})
+	api := newTestAPI(t, mux)
+
+	var stdout, stderr bytes.Buffer
+	err := api.ExecStart(context.Background(), "session", ExecStartOptions{Stdout: &stdout, Stderr: &stderr})
+	must.NoError(t, err)
+	must.Eq(t, "id: uid=0\n", stdout.String())
+	must.Eq(t, "warning\n", stderr.String())
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// Namespace configures a namespace of a container. Mode is one of the
+// namespace modes of podman, such as "host", "path", or "bridge".
+type Namespace struct {
+	Mode  string `json:"nsmode,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// PortMapping publishes a port of a container on the host
+type PortMapping struct {
+	HostIP        string `json:"host_ip,omitempty"`
+	ContainerPort uint16 `json:"container_port"`
+	HostPort      uint16 `json:"host_port,omitempty"`
+	Protocol      string `json:"protocol,omitempty"`
+}
+
+// LogConfig configures how podman stores the logs of a container
+type LogConfig struct {
+	Driver  string            `json:"driver,omitempty"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// SpecGenerator is the subset of the specification of containers the driver
+// sets when creating containers
+type SpecGenerator struct {
+	Name       string            `json:"name,omitempty"`
+	Image      string            `json:"image"`
+	Command    []string          `json:"command,omitempty"`
+	Entrypoint []string          `json:"entrypoint,omitempty"`
+	Env        map[string]string `json:"env,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	WorkDir    string            `json:"work_dir,omitempty"`
+	Hostname   string            `json:"hostname,omitempty"`
+	User       string            `json:"user,omitempty"`
+	Terminal   bool              `json:"terminal,omitempty"`
+	Init       bool              `json:"init,omitempty"`
+
+	Privileged   bool     `json:"privileged,omitempty"`
+	ReadOnlyRoot bool     `json:"read_only_filesystem,omitempty"`
+	CapAdd       []string `json:"cap_add,omitempty"`
+	CapDrop      []string `json:"cap_drop,omitempty"`
+
+	Mounts  []spec.Mount       `json:"mounts,omitempty"`
+	Devices []spec.LinuxDevice `json:"devices,omitempty"`
+
+	NetNS        Namespace     `json:"netns,omitempty"`
+	UserNS       Namespace     `json:"userns,omitempty"`
+	PortMappings []PortMapping `json:"portmappings,omitempty"`
+	DNSServers   []net.IP      `json:"dns_server,omitempty"`
+	DNSSearch    []string      `json:"dns_search,omitempty"`
+	DNSOptions   []string      `json:"dns_option,omitempty"`
+
+	ResourceLimits *spec.LinuxResources `json:"resource_limits,omitempty"`
+	CgroupParent   string               `json:"cgroup_parent,omitempty"`
+
+	LogConfiguration *LogConfig `json:"log_configuration,omitempty"`
+}
+
+// ContainerCreateResponse is the response of a container creation
+type ContainerCreateResponse struct {
+	ID       string   `json:"Id"`
+	Warnings []string `json:"Warnings"`
+}
+
+// ContainerCreate creates a container from a specification
+func (a *API) ContainerCreate(ctx context.Context, s *SpecGenerator) (*ContainerCreateResponse, error) {
+	var resp ContainerCreateResponse
+	if err := a.request(ctx, http.MethodPost, "/containers/create", s, &resp, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ContainerStart starts a container
+func (a *API) ContainerStart(ctx context.Context, id string) error {
+	return a.request(ctx, http.MethodPost, "/containers/"+url.PathEscape(id)+"/start", nil, nil,
+		http.StatusNoContent, http.StatusNotModified)
+}
+
+// ContainerKill sends a signal to the main process of a container
+func (a *API) ContainerKill(ctx context.Context, id, signal string) error {
+	path := "/containers/" + url.PathEscape(id) + "/kill?signal=" + url.QueryEscape(signal)
+	return a.request(ctx, http.MethodPost, path, nil, nil, http.StatusNoContent)
+}
+
+// ContainerStop stops a container, by sending its stop signal and killing it
+// after timeout
+func (a *API) ContainerStop(ctx context.Context, id string, timeout time.Duration) error {
+	path := fmt.Sprintf("/containers/%s/stop?timeout=%d", url.PathEscape(id), int(timeout.Seconds()))
+	return a.request(ctx, http.MethodPost, path, nil, nil, http.StatusNoContent, http.StatusNotModified)
+}
+
+// ContainerDelete removes a container, and its anonymous volumes if volumes
+// is set
+func (a *API) ContainerDelete(ctx context.Context, id string, force, volumes bool) error {
+	path := fmt.Sprintf("/containers/%s?force=%t&v=%t", url.PathEscape(id), force, volumes)
+	return a.request(ctx, http.MethodDelete, path, nil, nil, http.StatusOK, http.StatusNoContent)
+}
+
+// ContainerWait blocks until a container exits, and returns its exit code
+func (a *API) ContainerWait(ctx context.Context, id string) (int, error) {
+	path := "/containers/" + url.PathEscape(id) + "/wait?condition=stopped&condition=exited"
+	resp, err := a.do(ctx, a.httpStreamClient, http.MethodPost, path, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, http.StatusOK); err != nil {
+		return 0, err
+	}
+	var code int
+	if err := json.NewDecoder(resp.Body).Decode(&code); err != nil {
+		return 0, fmt.Errorf("failed to decode exit code: %v", err)
+	}
+	return code, nil
+}
+
+// InspectContainerData is the subset of the description of containers the
+// driver uses
+type InspectContainerData struct {
+	ID     string                 `json:"Id"`
+	Name   string                 `json:"Name"`
+	Image  string                 `json:"Image"`
+	State  InspectContainerState  `json:"State"`
+	Config InspectContainerConfig `json:"Config"`
+}
+
+// InspectContainerState is the state of a container
+type InspectContainerState struct {
+	Status     string    `json:"Status"`
+	Running    bool      `json:"Running"`
+	OOMKilled  bool      `json:"OOMKilled"`
+	Pid        int       `json:"Pid"`
+	ExitCode   int       `json:"ExitCode"`
+	StartedAt  time.Time `json:"StartedAt"`
+	FinishedAt time.Time `json:"FinishedAt"`
+}
+
+// InspectContainerConfig is the configuration of a container
+type InspectContainerConfig struct {
+	Tty bool `json:"Tty"`
+}
+
+// ContainerInspect returns the description of a container
+func (a *API) ContainerInspect(ctx context.Context, id string) (*InspectContainerData, error) {
+	var data InspectContainerData
+	path := "/containers/" + url.PathEscape(id) + "/json"
+	if err := a.request(ctx, http.MethodGet, path, nil, &data, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ContainerStats is the resource usage of a container
+type ContainerStats struct {
+	ContainerID string  `json:"ContainerID"`
+	CPU         float64 `json:"CPU"`
+	CPUNano     uint64  `json:"CPUNano"`
+	CPUSystem   uint64  `json:"CPUSystemNano"`
+	SystemNano  uint64  `json:"SystemNano"`
+	MemUsage    uint64  `json:"MemUsage"`
+	MemLimit    uint64  `json:"MemLimit"`
+	NetInput    uint64  `json:"NetInput"`
+	NetOutput   uint64  `json:"NetOutput"`
+	BlockInput  uint64  `json:"BlockInput"`
+	BlockOutput uint64  `json:"BlockOutput"`
+	PIDs        uint64  `json:"PIDs"`
+}
+
+type containerStatsResponse struct {
+	Error *errorResponse    `json:"Error"`
+	Stats []*ContainerStats `json:"Stats"`
+}
+
+// ContainerStats returns the current resource usage of a container
+func (a *API) ContainerStats(ctx context.Context, id string) (*ContainerStats, error) {
+	var resp containerStatsResponse
+	path := "/containers/stats?stream=false&containers=" + url.QueryEscape(id)
+	if err := a.request(ctx, http.MethodGet, path, nil, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	if resp.Error != nil && resp.Error.Message != "" {
+		return nil, fmt.Errorf("failed to get stats: %s", resp.Error.Message)
+	}
+	for _, stats := range resp.Stats {
+		if stats.ContainerID == id {
+			return stats, nil
+		}
+	}
+	if len(resp.Stats) == 1 {
+		return resp.Stats[0], nil
+	}
+	return nil, fmt.Errorf("%w: no stats for container %s", ErrNotFound, id)
+}
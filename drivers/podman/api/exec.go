@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ExecConfig configures an exec session in a container
+type ExecConfig struct {
+	Cmd          []string `json:"Cmd"`
+	AttachStdin  bool     `json:"AttachStdin"`
+	AttachStdout bool     `json:"AttachStdout"`
+	AttachStderr bool     `json:"AttachStderr"`
+	Tty          bool     `json:"Tty"`
+}
+
+// ExecCreate creates an exec session in a container, and returns the ID of
+// the session
+func (a *API) ExecCreate(ctx context.Context, id string, config ExecConfig) (string, error) {
+	var resp struct {
+		ID string `json:"Id"`
+	}
+	path := "/containers/" + url.PathEscape(id) + "/exec"
+	if err := a.request(ctx, http.MethodPost, path, config, &resp, http.StatusCreated); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// ExecStartOptions are the streams an exec session is attached to
+type ExecStartOptions struct {
+	Tty bool
+
+	// Stdin is copied to the session if set, which requires the session to
+	// attach stdin
+	Stdin io.Reader
+
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// ExecStart starts an exec session, and copies its streams until the session
+// exits or ctx is canceled. The output of sessions with a TTY is only written
+// to stdout.
+func (a *API) ExecStart(ctx context.Context, sessionID string, opts ExecStartOptions) error {
+	body, err := json.Marshal(map[string]bool{"Detach": false, "Tty": opts.Tty})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		apiPath+"/exec/"+url.PathEscape(sessionID)+"/start", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+
+	// podman hijacks the connection to stream the session, so the request is
+	// sent on a connection of its own
+	conn, err := a.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if err := req.Write(conn); err != nil {
+		return err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols && resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return checkStatus(resp)
+	}
+
+	if opts.Stdin != nil {
+		go func() {
+			io.Copy(conn, opts.Stdin)
+			if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+				cw.CloseWrite()
+			}
+		}()
+	}
+
+	if opts.Tty {
+		_, err = io.Copy(opts.Stdout, br)
+	} else {
+		err = demuxStream(br, opts.Stdout, opts.Stderr)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// ExecResize resizes the TTY of an exec session
+func (a *API) ExecResize(ctx context.Context, sessionID string, height, width int) error {
+	path := fmt.Sprintf("/exec/%s/resize?h=%d&w=%d", url.PathEscape(sessionID), height, width)
+	return a.request(ctx, http.MethodPost, path, nil, nil, http.StatusOK, http.StatusCreated, http.StatusNoContent)
+}
+
+// ExecInspectResponse is the state of an exec session
+type ExecInspectResponse struct {
+	ExitCode int  `json:"ExitCode"`
+	Running  bool `json:"Running"`
+}
+
+// ExecInspect returns the state of an exec session
+func (a *API) ExecInspect(ctx context.Context, sessionID string) (*ExecInspectResponse, error) {
+	var resp ExecInspectResponse
+	path := "/exec/" + url.PathEscape(sessionID) + "/json"
+	if err := a.request(ctx, http.MethodGet, path, nil, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ImageAuth are the credentials of the registry images are pulled from
+type ImageAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// ImageExists returns whether an image exists in the local storage of podman
+func (a *API) ImageExists(ctx context.Context, ref string) (bool, error) {
+	err := a.request(ctx, http.MethodGet, "/images/"+url.PathEscape(ref)+"/exists", nil, nil, http.StatusNoContent)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ImageInspectID returns the ID of an image
+func (a *API) ImageInspectID(ctx context.Context, ref string) (string, error) {
+	var image struct {
+		ID string `json:"Id"`
+	}
+	if err := a.request(ctx, http.MethodGet, "/images/"+url.PathEscape(ref)+"/json", nil, &image, http.StatusOK); err != nil {
+		return "", err
+	}
+	return image.ID, nil
+}
+
+// imagePullReport is a line of the response of an image pull
+type imagePullReport struct {
+	Stream string   `json:"stream,omitempty"`
+	Error  string   `json:"error,omitempty"`
+	Images []string `json:"images,omitempty"`
+	ID     string   `json:"id,omitempty"`
+}
+
+// ImagePull pulls an image, with the credentials of auth if set, and returns
+// the ID of the image.
+func (a *API) ImagePull(ctx context.Context, ref string, auth *ImageAuth) (string, error) {
+	header := http.Header{}
+	if auth != nil && (auth.Username != "" || auth.Password != "") {
+		b, err := json.Marshal(auth)
+		if err != nil {
+			return "", err
+		}
+		header.Set("X-Registry-Auth", base64.URLEncoding.EncodeToString(b))
+	}
+
+	path := "/images/pull?quiet=true&policy=always&reference=" + url.QueryEscape(ref)
+	resp, err := a.do(ctx, a.httpStreamClient, http.MethodPost, path, nil, header)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, http.StatusOK); err != nil {
+		return "", err
+	}
+
+	// the progress of the pull is reported with one JSON object per line,
+	// and errors happening once the pull started are reported in the body
+	var id string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var report imagePullReport
+		if err := json.Unmarshal(scanner.Bytes(), &report); err != nil {
+			return "", fmt.Errorf("failed to decode pull report: %v", err)
+		}
+		if report.Error != "" {
+			return "", errors.New(report.Error)
+		}
+		if report.ID != "" {
+			id = report.ID
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", fmt.Errorf("podman didn't report the ID of image %s", ref)
+	}
+	return id, nil
+}
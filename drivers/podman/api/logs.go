@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Stream types of the frames of multiplexed streams
+const (
+	streamStdin  = 0
+	streamStdout = 1
+	streamStderr = 2
+	streamSystem = 3
+)
+
+// ContainerLogs follows the logs of a container from since, and writes them
+// to stdout and stderr until the container exits or ctx is canceled.
+func (a *API) ContainerLogs(ctx context.Context, id string, since time.Time, stdout, stderr io.Writer) error {
+	path := fmt.Sprintf("/containers/%s/logs?follow=true&stdout=true&stderr=true", url.PathEscape(id))
+	if !since.IsZero() {
+		path += "&since=" + strconv.FormatInt(since.Unix(), 10)
+	}
+
+	resp, err := a.do(ctx, a.httpStreamClient, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, http.StatusOK); err != nil {
+		return err
+	}
+	return demuxStream(resp.Body, stdout, stderr)
+}
+
+// demuxStream copies a multiplexed stream of podman to stdout and stderr.
+// Each frame of the stream has an 8 bytes header, made of the stream type of
+// the frame, 3 zero bytes, and the big-endian size of the payload.
+func demuxStream(r io.Reader, stdout, stderr io.Writer) error {
+	var header [8]byte
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[4:]))
+		var w io.Writer
+		switch header[0] {
+		case streamStdout:
+			w = stdout
+		case streamStderr:
+			w = stderr
+		case streamSystem:
+			msg, err := io.ReadAll(io.LimitReader(r, size))
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("error from podman: %s", msg)
+		default:
+			w = io.Discard
+		}
+
+		if _, err := io.CopyN(w, r, size); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+// Info is the subset of the system information of podman the driver uses
+type Info struct {
+	Host    HostInfo    `json:"host"`
+	Version VersionInfo `json:"version"`
+}
+
+// HostInfo describes the host podman runs on
+type HostInfo struct {
+	CgroupManager     string       `json:"cgroupManager"`
+	CgroupVersion     string       `json:"cgroupVersion"`
+	CgroupControllers []string     `json:"cgroupControllers"`
+	OCIRuntime        OCIRuntime   `json:"ociRuntime"`
+	Security          SecurityInfo `json:"security"`
+}
+
+// OCIRuntime is the OCI runtime podman starts containers with
+type OCIRuntime struct {
+	Name string `json:"name"`
+}
+
+// SecurityInfo describes the security features of podman
+type SecurityInfo struct {
+	Rootless bool `json:"rootless"`
+}
+
+// VersionInfo is the version of podman and of its API
+type VersionInfo struct {
+	APIVersion string `json:"APIVersion"`
+	Version    string `json:"Version"`
+}
+
+// SystemInfo returns information about podman and the host it runs on
+func (a *API) SystemInfo(ctx context.Context) (*Info, error) {
+	var info Info
+	if err := a.request(ctx, http.MethodGet, "/info", nil, &info, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Ping checks that the podman API is reachable
+func (a *API) Ping(ctx context.Context) error {
+	return a.request(ctx, http.MethodGet, "/_ping", nil, nil, http.StatusOK)
+}
@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package podman
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+)
+
+var (
+	// PluginID is the podman plugin metadata registered in the plugin catalog.
+	PluginID = loader.PluginID{
+		Name:       pluginName,
+		PluginType: base.PluginTypeDriver,
+	}
+
+	// PluginConfig is the podman driver factory function registered in the
+	// plugin catalog.
+	PluginConfig = &loader.InternalPluginConfig{
+		Config:  map[string]interface{}{},
+		Factory: func(ctx context.Context, l hclog.Logger) interface{} { return NewPodmanDriver(ctx, l) },
+	}
+
+	// pluginInfo is the response returned for the PluginInfo RPC
+	pluginInfo = &base.PluginInfoResponse{
+		Type:              base.PluginTypeDriver,
+		PluginApiVersions: []string{drivers.ApiVersion010},
+		PluginVersion:     "0.1.0",
+		Name:              pluginName,
+	}
+
+	// configSpec is the hcl specification returned by the ConfigSchema RPC
+	configSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"socket_path": hclspec.NewAttr("socket_path", "string", false),
+		"client_http_timeout": hclspec.NewDefault(
+			hclspec.NewAttr("client_http_timeout", "string", false),
+			hclspec.NewLiteral(`"60s"`),
+		),
+		"volumes": hclspec.NewDefault(hclspec.NewBlock("volumes", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"enabled": hclspec.NewDefault(
+				hclspec.NewAttr("enabled", "bool", false),
+				hclspec.NewLiteral("true"),
+			),
+			"selinuxlabel": hclspec.NewAttr("selinuxlabel", "string", false),
+		})), hclspec.NewLiteral(`{
+			enabled = true
+		}`)),
+		"gc": hclspec.NewDefault(hclspec.NewBlock("gc", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"container": hclspec.NewDefault(
+				hclspec.NewAttr("container", "bool", false),
+				hclspec.NewLiteral("true"),
+			),
+		})), hclspec.NewLiteral(`{
+			container = true
+		}`)),
+		"disable_log_collection": hclspec.NewAttr("disable_log_collection", "bool", false),
+	})
+
+	// taskConfigSpec is the hcl specification for the driver config section of
+	// a taskConfig within a job. It is returned in the TaskConfigSchema RPC
+	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"image": hclspec.NewAttr("image", "string", true),
+		"auth": hclspec.NewBlock("auth", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"username": hclspec.NewAttr("username", "string", false),
+			"password": hclspec.NewAttr("password", "string", false),
+		})),
+		"force_pull":      hclspec.NewAttr("force_pull", "bool", false),
+		"command":         hclspec.NewAttr("command", "string", false),
+		"args":            hclspec.NewAttr("args", "list(string)", false),
+		"entrypoint":      hclspec.NewAttr("entrypoint", "list(string)", false),
+		"working_dir":     hclspec.NewAttr("working_dir", "string", false),
+		"hostname":        hclspec.NewAttr("hostname", "string", false),
+		"labels":          hclspec.NewAttr("labels", "list(map(string))", false),
+		"ports":           hclspec.NewAttr("ports", "list(string)", false),
+		"volumes":         hclspec.NewAttr("volumes", "list(string)", false),
+		"network_mode":    hclspec.NewAttr("network_mode", "string", false),
+		"userns":          hclspec.NewAttr("userns", "string", false),
+		"privileged":      hclspec.NewAttr("privileged", "bool", false),
+		"readonly_rootfs": hclspec.NewAttr("readonly_rootfs", "bool", false),
+		"cap_add":         hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":        hclspec.NewAttr("cap_drop", "list(string)", false),
+		"tty":             hclspec.NewAttr("tty", "bool", false),
+		"init":            hclspec.NewAttr("init", "bool", false),
+	})
+
+	// capabilities is returned by the Capabilities RPC and indicates what
+	// optional features this driver supports
+	capabilities = &drivers.Capabilities{
+		SendSignals: true,
+		Exec:        true,
+		FSIsolation: fsisolation.Image,
+		NetIsolationModes: []drivers.NetIsolationMode{
+			drivers.NetIsolationModeHost,
+			drivers.NetIsolationModeGroup,
+		},
+		MountConfigs: drivers.MountConfigSupportAll,
+	}
+)
+
+// Config is the driver configuration set by the SetConfig RPC call
+type Config struct {
+	// SocketPath is the socket of the podman API, which defaults to the
+	// socket of the user running Nomad
+	SocketPath string `codec:"socket_path"`
+
+	// ClientHTTPTimeout is the timeout of the requests to the podman API
+	ClientHTTPTimeout string `codec:"client_http_timeout"`
+
+	// Volumes configures the volumes tasks may mount
+	Volumes VolumeConfig `codec:"volumes"`
+
+	// GC configures the garbage collection of containers
+	GC GCConfig `codec:"gc"`
+
+	// DisableLogCollection disables the collection of the logs of containers
+	DisableLogCollection bool `codec:"disable_log_collection"`
+}
+
+// VolumeConfig configures the volumes tasks may mount
+type VolumeConfig struct {
+	// Enabled allows tasks to mount host paths outside of the allocation
+	// directory
+	Enabled bool `codec:"enabled"`
+
+	// SelinuxLabel is the SELinux label applied to the bind mounts of tasks
+	SelinuxLabel string `codec:"selinuxlabel"`
+}
+
+// GCConfig configures the garbage collection of containers
+type GCConfig struct {
+	// Container removes containers when their task is destroyed
+	Container bool `codec:"container"`
+}
+
+func (c *Config) validate() error {
+	if c.SocketPath != "" && !strings.HasPrefix(c.SocketPath, "unix://") {
+		return fmt.Errorf("socket_path must be a unix socket prefixed with unix://, got %q", c.SocketPath)
+	}
+	if _, err := time.ParseDuration(c.ClientHTTPTimeout); err != nil {
+		return fmt.Errorf("failed to parse client_http_timeout: %v", err)
+	}
+	switch c.Volumes.SelinuxLabel {
+	case "", "z", "Z":
+	default:
+		return fmt.Errorf("volumes selinuxlabel must be %q or %q, got %q", "z", "Z", c.Volumes.SelinuxLabel)
+	}
+	return nil
+}
+
+// TaskConfig is the driver configuration of a taskConfig within a job
+type TaskConfig struct {
+	Image          string             `codec:"image"`
+	Auth           AuthConfig         `codec:"auth"`
+	ForcePull      bool               `codec:"force_pull"`
+	Command        string             `codec:"command"`
+	Args           []string           `codec:"args"`
+	Entrypoint     []string           `codec:"entrypoint"`
+	WorkingDir     string             `codec:"working_dir"`
+	Hostname       string             `codec:"hostname"`
+	Labels         hclutils.MapStrStr `codec:"labels"`
+	Ports          []string           `codec:"ports"`
+	Volumes        []string           `codec:"volumes"`
+	NetworkMode    string             `codec:"network_mode"`
+	UserNS         string             `codec:"userns"`
+	Privileged     bool               `codec:"privileged"`
+	ReadOnlyRootFS bool               `codec:"readonly_rootfs"`
+	CapAdd         []string           `codec:"cap_add"`
+	CapDrop        []string           `codec:"cap_drop"`
+	Tty            bool               `codec:"tty"`
+	Init           bool               `codec:"init"`
+}
+
+// AuthConfig are the credentials of the registry the image is pulled from
+type AuthConfig struct {
+	Username string `codec:"username"`
+	Password string `codec:"password"`
+}
+
+func (tc *TaskConfig) validate() error {
+	switch tc.NetworkMode {
+	case "", "bridge", "host", "none", "slirp4netns", "pasta":
+	default:
+		return fmt.Errorf("network_mode must be one of bridge, host, none, slirp4netns, or pasta, got %q", tc.NetworkMode)
+	}
+	for _, v := range tc.Volumes {
+		if _, _, _, err := parseVolumeSpec(v); err != nil {
+			return fmt.Errorf("invalid volume %q: %v", v, err)
+		}
+	}
+	return nil
+}
+
+// parseVolumeSpec parses a volume of the form "src:dst" or "src:dst:mode",
+// where mode is "ro" or "rw".
+func parseVolumeSpec(volume string) (src, dst string, readOnly bool, err error) {
+	parts := strings.Split(volume, ":")
+	switch len(parts) {
+	case 2:
+	case 3:
+		switch parts[2] {
+		case "ro":
+			readOnly = true
+		case "rw":
+		default:
+			return "", "", false, fmt.Errorf("mode must be ro or rw, got %q", parts[2])
+		}
+	default:
+		return "", "", false, fmt.Errorf("volumes must be of the form src:dst[:mode]")
+	}
+	if parts[0] == "" || parts[1] == "" {
+		return "", "", false, fmt.Errorf("volume source and destination must be set")
+	}
+	return parts[0], parts[1], readOnly, nil
+}
@@ -0,0 +1,843 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package podman
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/drivers/podman/api"
+	"github.com/hashicorp/nomad/drivers/shared/eventer"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// pluginName is the name of the plugin
+	pluginName = "podman"
+
+	// fingerprintPeriod is the interval at which the driver will send fingerprint responses
+	fingerprintPeriod = 30 * time.Second
+
+	// taskHandleVersion is the version of task handle which this driver sets
+	// and understands how to decode driver state
+	taskHandleVersion = 1
+
+	// labelAllocID is the label set on containers with the ID of their
+	// allocation
+	labelAllocID = "com.hashicorp.nomad.alloc_id"
+	labelJobName = "com.hashicorp.nomad.job_name"
+	labelTask    = "com.hashicorp.nomad.task_name"
+)
+
+var _ drivers.DriverPlugin = (*Driver)(nil)
+
+// TaskState is the state which is encoded in the handle returned in StartTask.
+// This information is needed to rebuild the task state and handler during
+// recovery.
+type TaskState struct {
+	TaskConfig  *drivers.TaskConfig
+	ContainerID string
+	StartedAt   time.Time
+}
+
+// Driver is a driver for running OCI containers with podman
+type Driver struct {
+	// eventer is used to handle multiplexing of TaskEvents calls such that an
+	// event can be broadcast to all callers
+	eventer *eventer.Eventer
+
+	// config is the driver configuration set by the SetConfig RPC
+	config *Config
+
+	// podman is the client of the podman API, set by SetConfig
+	podman *api.API
+
+	// tasks is the in memory datastore mapping taskIDs to taskHandles
+	tasks *taskStore
+
+	// ctx is the context for the driver. It is passed to other subsystems to
+	// coordinate shutdown
+	ctx context.Context
+
+	// compute is the cpu compute available to tasks, used to compute the
+	// cpu usage of tasks
+	compute cpustats.Compute
+
+	// rootless is whether podman was running rootless when last fingerprinted
+	rootless     bool
+	rootlessLock sync.RWMutex
+
+	// logger will log to the Nomad agent
+	logger hclog.Logger
+}
+
+// NewPodmanDriver returns a new DriverPlugin implementation
+func NewPodmanDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
+	logger = logger.Named(pluginName)
+	return &Driver{
+		eventer: eventer.NewEventer(ctx, logger),
+		config:  &Config{},
+		tasks:   newTaskStore(),
+		ctx:     ctx,
+		logger:  logger,
+	}
+}
+
+func (d *Driver) PluginInfo() (*base.PluginInfoResponse, error) {
+	return pluginInfo, nil
+}
+
+func (d *Driver) ConfigSchema() (*hclspec.Spec, error) {
+	return configSpec, nil
+}
+
+func (d *Driver) SetConfig(cfg *base.Config) error {
+	var config Config
+	if len(cfg.PluginConfig) != 0 {
+		if err := base.MsgPackDecode(cfg.PluginConfig, &config); err != nil {
+			return err
+		}
+	}
+	if config.ClientHTTPTimeout == "" {
+		config.ClientHTTPTimeout = "60s"
+	}
+	if err := config.validate(); err != nil {
+		return err
+	}
+	if config.SocketPath == "" {
+		config.SocketPath = api.DefaultSocketPath()
+	}
+
+	// validated above
+	timeout, _ := time.ParseDuration(config.ClientHTTPTimeout)
+	podman, err := api.NewClient(d.logger, api.ClientConfig{
+		SocketPath:  config.SocketPath,
+		HTTPTimeout: timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	d.config = &config
+	d.podman = podman
+	d.compute = cfg.AgentConfig.Compute()
+	return nil
+}
+
+func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
+	return taskConfigSpec, nil
+}
+
+func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
+	return capabilities, nil
+}
+
+func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
+	ch := make(chan *drivers.Fingerprint)
+	go d.handleFingerprint(ctx, ch)
+	return ch, nil
+}
+
+func (d *Driver) handleFingerprint(ctx context.Context, ch chan *drivers.Fingerprint) {
+	ticker := time.NewTimer(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			ticker.Reset(fingerprintPeriod)
+			ch <- d.buildFingerprint()
+		}
+	}
+}
+
+func (d *Driver) buildFingerprint() *drivers.Fingerprint {
+	fp := &drivers.Fingerprint{
+		Attributes:        map[string]*pstructs.Attribute{},
+		Health:            drivers.HealthStateHealthy,
+		HealthDescription: drivers.DriverHealthy,
+	}
+
+	// podman only runs Linux containers on Linux hosts
+	if runtime.GOOS != "linux" || d.podman == nil {
+		fp.Health = drivers.HealthStateUndetected
+		fp.HealthDescription = ""
+		return fp
+	}
+
+	ctx, cancel := context.WithTimeout(d.ctx, 10*time.Second)
+	defer cancel()
+	info, err := d.podman.SystemInfo(ctx)
+	if err != nil {
+		// return no error, as it isn't an error to not find podman, it just
+		// means we can't use it.
+		d.logger.Trace("could not connect to podman", "socket", d.podman.SocketPath(), "error", err)
+		fp.Health = drivers.HealthStateUndetected
+		fp.HealthDescription = "Failed to connect to podman"
+		return fp
+	}
+
+	d.rootlessLock.Lock()
+	d.rootless = info.Host.Security.Rootless
+	d.rootlessLock.Unlock()
+
+	fp.Attributes["driver.podman"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.podman.version"] = pstructs.NewStringAttribute(info.Version.Version)
+	fp.Attributes["driver.podman.rootless"] = pstructs.NewBoolAttribute(info.Host.Security.Rootless)
+	fp.Attributes["driver.podman.cgroupVersion"] = pstructs.NewStringAttribute(info.Host.CgroupVersion)
+	fp.Attributes["driver.podman.cgroupManager"] = pstructs.NewStringAttribute(info.Host.CgroupManager)
+	if info.Host.OCIRuntime.Name != "" {
+		fp.Attributes["driver.podman.ociRuntime"] = pstructs.NewStringAttribute(info.Host.OCIRuntime.Name)
+	}
+
+	if err := checkRootlessCgroups(&info.Host); err != nil {
+		fp.Health = drivers.HealthStateUnhealthy
+		fp.HealthDescription = err.Error()
+	}
+	return fp
+}
+
+// checkRootlessCgroups ensures rootless podman can enforce the resource limits
+// of tasks, which requires cgroups v2 and the cpu and memory controllers to be
+// delegated to the user running podman by systemd.
+func checkRootlessCgroups(host *api.HostInfo) error {
+	if !host.Security.Rootless {
+		return nil
+	}
+	if host.CgroupVersion != "v2" {
+		return errors.New("rootless podman requires cgroups v2 to enforce resource limits")
+	}
+	for _, controller := range []string{"cpu", "memory"} {
+		if !slices.Contains(host.CgroupControllers, controller) {
+			return fmt.Errorf("the %s cgroup controller isn't delegated to rootless podman", controller)
+		}
+	}
+	return nil
+}
+
+func (d *Driver) isRootless() bool {
+	d.rootlessLock.RLock()
+	defer d.rootlessLock.RUnlock()
+	return d.rootless
+}
+
+func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
+	if handle == nil {
+		return fmt.Errorf("handle cannot be nil")
+	}
+
+	// If already attached to handle there's nothing to recover.
+	if _, ok := d.tasks.Get(handle.Config.ID); ok {
+		return nil
+	}
+
+	var taskState TaskState
+	if err := handle.GetDriverState(&taskState); err != nil {
+		return fmt.Errorf("failed to decode task state from handle: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(d.ctx, time.Minute)
+	defer cancel()
+	container, err := d.podman.ContainerInspect(ctx, taskState.ContainerID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container %s: %v", taskState.ContainerID, err)
+	}
+
+	h := d.newTaskHandle(taskState.TaskConfig, container.ID, container.Config.Tty)
+	h.startedAt = taskState.StartedAt
+	d.tasks.Set(taskState.TaskConfig.ID, h)
+
+	// only collect the logs written from now on, since the previous logs
+	// were already collected before the client restarted
+	if container.State.Running {
+		go h.collectLogs(time.Now())
+	}
+	go h.run()
+	return nil
+}
+
+func (d *Driver) newTaskHandle(cfg *drivers.TaskConfig, containerID string, tty bool) *taskHandle {
+	return &taskHandle{
+		containerID: containerID,
+		tty:         tty,
+		podman:      d.podman,
+		ctx:         d.ctx,
+		logger:      d.logger.With("container_id", containerID),
+		taskConfig:  cfg,
+		collect:     !d.config.DisableLogCollection,
+		procState:   drivers.TaskStateRunning,
+		startedAt:   time.Now().Round(time.Millisecond),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if _, ok := d.tasks.Get(cfg.ID); ok {
+		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
+	}
+
+	var driverConfig TaskConfig
+	if err := cfg.DecodeDriverConfig(&driverConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode driver config: %v", err)
+	}
+	if err := driverConfig.validate(); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+
+	d.logger.Info("starting podman task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
+	handle := drivers.NewTaskHandle(taskHandleVersion)
+	handle.Config = cfg
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	defer cancel()
+
+	if err := d.ensureImage(ctx, cfg, &driverConfig); err != nil {
+		return nil, nil, err
+	}
+
+	s, err := d.createContainerSpec(cfg, &driverConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create container configuration: %v", err)
+	}
+
+	created, err := d.podman.ContainerCreate(ctx, s)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create container: %v", err)
+	}
+	for _, warning := range created.Warnings {
+		d.logger.Warn("podman warning when creating container", "task_id", cfg.ID, "warning", warning)
+	}
+
+	cleanup := func() {
+		if err := d.podman.ContainerDelete(d.ctx, created.ID, true, true); err != nil {
+			d.logger.Error("failed to remove container", "container_id", created.ID, "error", err)
+		}
+	}
+
+	if err := d.podman.ContainerStart(ctx, created.ID); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to start container: %v", err)
+	}
+
+	container, err := d.podman.ContainerInspect(ctx, created.ID)
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to inspect container: %v", err)
+	}
+
+	h := d.newTaskHandle(cfg, created.ID, driverConfig.Tty)
+	if !container.State.StartedAt.IsZero() {
+		h.startedAt = container.State.StartedAt
+	}
+
+	driverState := TaskState{
+		TaskConfig:  cfg,
+		ContainerID: created.ID,
+		StartedAt:   h.startedAt,
+	}
+	if err := handle.SetDriverState(&driverState); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
+	}
+
+	d.tasks.Set(cfg.ID, h)
+	go h.collectLogs(time.Time{})
+	go h.run()
+
+	return handle, nil, nil
+}
+
+// ensureImage pulls the image of the task unless it already exists locally.
+func (d *Driver) ensureImage(ctx context.Context, cfg *drivers.TaskConfig, driverConfig *TaskConfig) error {
+	if !driverConfig.ForcePull {
+		exists, err := d.podman.ImageExists(ctx, driverConfig.Image)
+		if err != nil {
+			return fmt.Errorf("failed to check if image exists: %v", err)
+		}
+		if exists {
+			return nil
+		}
+	}
+
+	d.eventer.EmitEvent(&drivers.TaskEvent{
+		TaskID:    cfg.ID,
+		AllocID:   cfg.AllocID,
+		TaskName:  cfg.Name,
+		Timestamp: time.Now(),
+		Message:   "Downloading image",
+		Annotations: map[string]string{
+			"image": driverConfig.Image,
+		},
+	})
+
+	auth := &api.ImageAuth{
+		Username: driverConfig.Auth.Username,
+		Password: driverConfig.Auth.Password,
+	}
+	id, err := d.podman.ImagePull(ctx, driverConfig.Image, auth)
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %v", driverConfig.Image, err)
+	}
+	d.logger.Debug("pulled image", "image", driverConfig.Image, "image_id", id)
+	return nil
+}
+
+// createContainerSpec returns the specification of the container of a task.
+func (d *Driver) createContainerSpec(cfg *drivers.TaskConfig, driverConfig *TaskConfig) (*api.SpecGenerator, error) {
+	s := &api.SpecGenerator{
+		Name:         fmt.Sprintf("%s-%s", cfg.Name, cfg.AllocID),
+		Image:        driverConfig.Image,
+		Entrypoint:   driverConfig.Entrypoint,
+		Env:          cfg.Env,
+		WorkDir:      driverConfig.WorkingDir,
+		Hostname:     driverConfig.Hostname,
+		User:         cfg.User,
+		Terminal:     driverConfig.Tty,
+		Init:         driverConfig.Init,
+		Privileged:   driverConfig.Privileged,
+		ReadOnlyRoot: driverConfig.ReadOnlyRootFS,
+		CapAdd:       driverConfig.CapAdd,
+		CapDrop:      driverConfig.CapDrop,
+	}
+	if driverConfig.Command != "" {
+		s.Command = append([]string{driverConfig.Command}, driverConfig.Args...)
+	} else if len(driverConfig.Args) > 0 {
+		return nil, errors.New("args can only be set with command")
+	}
+
+	s.Labels = map[string]string{
+		labelAllocID: cfg.AllocID,
+		labelJobName: cfg.JobName,
+		labelTask:    cfg.Name,
+	}
+	for k, v := range driverConfig.Labels {
+		s.Labels[k] = v
+	}
+
+	s.ResourceLimits = containerResources(cfg.Resources)
+
+	mounts, err := d.containerMounts(cfg, driverConfig)
+	if err != nil {
+		return nil, err
+	}
+	s.Mounts = mounts
+
+	for _, device := range cfg.Devices {
+		path := device.CDIName
+		if path == "" {
+			path = device.HostPath + ":" + device.TaskPath
+			if device.Permissions != "" {
+				path += ":" + device.Permissions
+			}
+		}
+		s.Devices = append(s.Devices, spec.LinuxDevice{Path: path})
+	}
+
+	if cfg.DNS != nil {
+		for _, server := range cfg.DNS.Servers {
+			ip := net.ParseIP(server)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid DNS server %q", server)
+			}
+			s.DNSServers = append(s.DNSServers, ip)
+		}
+		s.DNSSearch = cfg.DNS.Searches
+		s.DNSOptions = cfg.DNS.Options
+	}
+
+	if driverConfig.UserNS != "" {
+		s.UserNS = api.Namespace{Mode: driverConfig.UserNS}
+	}
+
+	if err := d.containerNetwork(s, cfg, driverConfig); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// containerResources returns the resource limits of a container, which are
+// enforced in the cgroup podman creates for the container.
+func containerResources(resources *drivers.Resources) *spec.LinuxResources {
+	if resources == nil || resources.LinuxResources == nil {
+		return nil
+	}
+	lr := resources.LinuxResources
+
+	limits := &spec.LinuxResources{
+		Memory: &spec.LinuxMemory{},
+		CPU:    &spec.LinuxCPU{},
+	}
+
+	memory := lr.MemoryLimitBytes
+	if resources.NomadResources != nil {
+		if max := resources.NomadResources.Memory.MemoryMaxMB; max > 0 {
+			// the memory of the task is a soft limit when memory_max is set
+			limits.Memory.Reservation = pointer.Of(memory)
+			memory = max * 1024 * 1024
+		}
+	}
+	if memory > 0 {
+		limits.Memory.Limit = pointer.Of(memory)
+	}
+
+	if lr.CPUShares > 0 {
+		limits.CPU.Shares = pointer.Of(uint64(lr.CPUShares))
+	}
+	if lr.CPUPeriod > 0 && lr.CPUQuota > 0 {
+		limits.CPU.Period = pointer.Of(uint64(lr.CPUPeriod))
+		limits.CPU.Quota = pointer.Of(lr.CPUQuota)
+	}
+	limits.CPU.Cpus = lr.CpusetCpus
+	return limits
+}
+
+// containerMounts returns the mounts of the container of a task, which are
+// the task directories, the volumes of the task, and the mounts of host and
+// CSI volumes.
+func (d *Driver) containerMounts(cfg *drivers.TaskConfig, driverConfig *TaskConfig) ([]spec.Mount, error) {
+	bind := func(src, dst string, readOnly bool, propagation string) spec.Mount {
+		options := []string{"rbind"}
+		if readOnly {
+			options = append(options, "ro")
+		} else {
+			options = append(options, "rw")
+		}
+		if propagation != "" {
+			options = append(options, propagation)
+		}
+		if d.config.Volumes.SelinuxLabel != "" {
+			options = append(options, d.config.Volumes.SelinuxLabel)
+		}
+		return spec.Mount{Destination: dst, Type: "bind", Source: src, Options: options}
+	}
+
+	taskDir := cfg.TaskDir()
+	mounts := []spec.Mount{
+		bind(taskDir.SharedAllocDir, cfg.Env[taskenv.AllocDir], false, ""),
+		bind(taskDir.LocalDir, cfg.Env[taskenv.TaskLocalDir], false, ""),
+		bind(taskDir.SecretsDir, cfg.Env[taskenv.SecretsDir], false, ""),
+	}
+
+	for _, volume := range driverConfig.Volumes {
+		src, dst, readOnly, err := parseVolumeSpec(volume)
+		if err != nil {
+			return nil, fmt.Errorf("invalid volume %q: %v", volume, err)
+		}
+
+		// relative paths are relative to the task directory, and are always
+		// allowed as long as they stay within the allocation directory
+		if !filepath.IsAbs(src) {
+			src = filepath.Join(taskDir.Dir, src)
+		}
+		src = filepath.Clean(src)
+		if !d.config.Volumes.Enabled && !isParentPath(cfg.AllocDir, src) {
+			return nil, fmt.Errorf("volumes are not enabled; cannot mount host paths: %+q", volume)
+		}
+		mounts = append(mounts, bind(src, dst, readOnly, ""))
+	}
+
+	for _, m := range cfg.Mounts {
+		var propagation string
+		switch m.PropagationMode {
+		case structs.VolumeMountPropagationHostToTask:
+			propagation = "rslave"
+		case structs.VolumeMountPropagationBidirectional:
+			propagation = "rshared"
+		default:
+			propagation = "rprivate"
+		}
+		mounts = append(mounts, bind(m.HostPath, m.TaskPath, m.Readonly, propagation))
+	}
+	return mounts, nil
+}
+
+// containerNetwork configures the network namespace and the published ports
+// of the container of a task.
+func (d *Driver) containerNetwork(s *api.SpecGenerator, cfg *drivers.TaskConfig, driverConfig *TaskConfig) error {
+	if cfg.NetworkIsolation != nil && cfg.NetworkIsolation.Path != "" {
+		if driverConfig.NetworkMode != "" {
+			return fmt.Errorf("network_mode can't be set with group network modes")
+		}
+		if d.isRootless() {
+			return fmt.Errorf("rootless podman can't join the network namespace of the allocation")
+		}
+		// the ports are published by the network namespace of the allocation
+		s.NetNS = api.Namespace{Mode: "path", Value: cfg.NetworkIsolation.Path}
+		return nil
+	}
+
+	if driverConfig.NetworkMode != "" {
+		s.NetNS = api.Namespace{Mode: driverConfig.NetworkMode}
+	}
+	switch driverConfig.NetworkMode {
+	case "host", "none":
+		if len(driverConfig.Ports) > 0 {
+			return fmt.Errorf("ports can't be published with network_mode %q", driverConfig.NetworkMode)
+		}
+		return nil
+	}
+
+	for _, label := range driverConfig.Ports {
+		if cfg.Resources == nil || cfg.Resources.Ports == nil {
+			return fmt.Errorf("Port %q not found, check network block", label)
+		}
+		mapping, ok := cfg.Resources.Ports.Get(label)
+		if !ok {
+			return fmt.Errorf("Port %q not found, check network block", label)
+		}
+		to := mapping.To
+		if to == 0 {
+			to = mapping.Value
+		}
+		s.PortMappings = append(s.PortMappings, api.PortMapping{
+			HostIP:        mapping.HostIP,
+			ContainerPort: uint16(to),
+			HostPort:      uint16(mapping.Value),
+			Protocol:      "tcp,udp",
+		})
+	}
+	return nil
+}
+
+// isParentPath returns true if path is a child or a descendant of parent
+// path. Both inputs need to be absolute paths.
+func isParentPath(parent, path string) bool {
+	rel, err := filepath.Rel(parent, path)
+	return err == nil && rel != ".." && !filepath.IsAbs(rel) && !hasDotDotPrefix(rel)
+}
+
+func hasDotDotPrefix(rel string) bool {
+	return len(rel) >= 3 && rel[:3] == ".."+string(filepath.Separator)
+}
+
+func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ch := make(chan *drivers.ExitResult)
+	go func() {
+		defer close(ch)
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		case <-handle.doneCh:
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-d.ctx.Done():
+		case ch <- handle.exitResultCopy():
+		}
+	}()
+	return ch, nil
+}
+
+func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if signal == "" {
+		signal = "SIGTERM"
+	}
+	if err := handle.kill(signal); err != nil {
+		return err
+	}
+
+	select {
+	case <-handle.doneCh:
+		return nil
+	case <-time.After(timeout):
+	}
+
+	return handle.kill("SIGKILL")
+}
+
+func (d *Driver) DestroyTask(taskID string, force bool) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if handle.IsRunning() && !force {
+		return fmt.Errorf("cannot destroy running task")
+	}
+
+	if handle.IsRunning() {
+		if err := handle.kill("SIGKILL"); err != nil {
+			handle.logger.Error("failed to kill container", "error", err)
+		}
+	}
+
+	handle.shutdown()
+	if d.config.GC.Container {
+		err := d.podman.ContainerDelete(d.ctx, handle.containerID, true, true)
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("failed to remove container: %v", err)
+		}
+	}
+
+	d.tasks.Delete(taskID)
+	return nil
+}
+
+func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.TaskStatus(), nil
+}
+
+func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ch := make(chan *drivers.TaskResourceUsage)
+	go handle.collectStats(ctx, ch, interval, d.compute)
+	return ch, nil
+}
+
+func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
+	return d.eventer.TaskEvents(ctx)
+}
+
+func (d *Driver) SignalTask(taskID string, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return d.podman.ContainerKill(d.ctx, handle.containerID, signal)
+}
+
+func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
+	if len(cmd) == 0 {
+		return nil, fmt.Errorf("cmd must have at least one value")
+	}
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(d.ctx, timeout)
+	defer cancel()
+
+	session, err := d.podman.ExecCreate(ctx, handle.containerID, api.ExecConfig{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exec session: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = d.podman.ExecStart(ctx, session, api.ExecStartOptions{Stdout: &stdout, Stderr: &stderr})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start exec session: %v", err)
+	}
+
+	inspect, err := d.podman.ExecInspect(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect exec session: %v", err)
+	}
+
+	return &drivers.ExecTaskResult{
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+		ExitResult: &drivers.ExitResult{
+			ExitCode: inspect.ExitCode,
+		},
+	}, nil
+}
+
+var _ drivers.ExecTaskStreamingDriver = (*Driver)(nil)
+
+func (d *Driver) ExecTaskStreaming(ctx context.Context, taskID string, opts *drivers.ExecOptions) (*drivers.ExitResult, error) {
+	defer opts.Stdout.Close()
+	defer opts.Stderr.Close()
+
+	if len(opts.Command) == 0 {
+		return nil, fmt.Errorf("command is required but was empty")
+	}
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	session, err := d.podman.ExecCreate(ctx, handle.containerID, api.ExecConfig{
+		Cmd:          opts.Command,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          opts.Tty,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exec session: %v", err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case s, ok := <-opts.ResizeCh:
+				if !ok {
+					return
+				}
+				d.podman.ExecResize(ctx, session, s.Height, s.Width)
+			}
+		}
+	}()
+
+	err = d.podman.ExecStart(ctx, session, api.ExecStartOptions{
+		Tty:    opts.Tty,
+		Stdin:  opts.Stdin,
+		Stdout: opts.Stdout,
+		Stderr: opts.Stderr,
+	})
+	opts.Stdin.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start exec session: %v", err)
+	}
+
+	inspect, err := d.podman.ExecInspect(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect exec session: %v", err)
+	}
+	return &drivers.ExitResult{ExitCode: inspect.ExitCode}, nil
+}
@@ -0,0 +1,467 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package podman
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/drivers/podman/api"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/shoenig/test/must"
+)
+
+// newTestDriver returns a driver whose podman API is served by handler.
+func newTestDriver(t *testing.T, handler http.Handler) *Driver {
+	dir, err := os.MkdirTemp("", "podman")
+	must.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "podman.sock")
+	l, err := net.Listen("unix", socket)
+	must.NoError(t, err)
+	srv := &http.Server{Handler: handler}
+	go srv.Serve(l)
+	t.Cleanup(func() { srv.Close() })
+
+	d := NewPodmanDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	d.config = &Config{Volumes: VolumeConfig{Enabled: true}, GC: GCConfig{Container: true}}
+	d.podman, err = api.NewClient(d.logger, api.ClientConfig{
+		SocketPath:  "unix://" + socket,
+		HTTPTimeout: 5 * time.Second,
+	})
+	must.NoError(t, err)
+	return d
+}
+
+func infoHandler(body string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1.0.0/libpod/info", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+	return mux
+}
+
+func TestPodmanDriver_Fingerprint(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS != "linux" {
+		t.Skip("podman driver only runs on linux")
+	}
+
+	d := newTestDriver(t, infoHandler(`{
+  "host": {
+    "cgroupManager": "systemd",
+    "cgroupVersion": "v2",
+    "cgroupControllers": ["cpu", "memory", "pids"],
+    "ociRuntime": {"name": "crun"},
+    "security": {"rootless": true}
+  },
+  "version": {"Version": "5.2.1"}
+}`))
+
+	fp := d.buildFingerprint()
+	must.Eq(t, drivers.HealthStateHealthy, fp.Health)
+	must.True(t, *fp.Attributes["driver.podman"].Bool)
+	must.Eq(t, "5.2.1", *fp.Attributes["driver.podman.version"].String)
+	must.True(t, *fp.Attributes["driver.podman.rootless"].Bool)
+	must.Eq(t, "v2", *fp.Attributes["driver.podman.cgroupVersion"].String)
+	must.Eq(t, "systemd", *fp.Attributes["driver.podman.cgroupManager"].String)
+	must.Eq(t, "crun", *fp.Attributes["driver.podman.ociRuntime"].String)
+	must.True(t, d.isRootless())
+}
+
+func TestPodmanDriver_Fingerprint_RootlessMissingDelegation(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS != "linux" {
+		t.Skip("podman driver only runs on linux")
+	}
+
+	d := newTestDriver(t, infoHandler(`{
+  "host": {
+    "cgroupManager": "systemd",
+    "cgroupVersion": "v2",
+    "cgroupControllers": ["pids"],
+    "security": {"rootless": true}
+  },
+  "version": {"Version": "4.9.3"}
+}`))
+
+	fp := d.buildFingerprint()
+	must.Eq(t, drivers.HealthStateUnhealthy, fp.Health)
+	must.StrContains(t, fp.HealthDescription, "cpu cgroup controller isn't delegated")
+	must.True(t, *fp.Attributes["driver.podman"].Bool)
+}
+
+func TestPodmanDriver_Fingerprint_Undetected(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewPodmanDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	var err error
+	d.podman, err = api.NewClient(d.logger, api.ClientConfig{
+		SocketPath:  "unix://" + filepath.Join(t.TempDir(), "missing.sock"),
+		HTTPTimeout: time.Second,
+	})
+	must.NoError(t, err)
+
+	fp := d.buildFingerprint()
+	must.Eq(t, drivers.HealthStateUndetected, fp.Health)
+	must.MapNotContainsKey(t, fp.Attributes, "driver.podman")
+}
+
+func TestCheckRootlessCgroups(t *testing.T) {
+	ci.Parallel(t)
+
+	rootful := &api.HostInfo{CgroupVersion: "v1"}
+	must.NoError(t, checkRootlessCgroups(rootful))
+
+	rootless := &api.HostInfo{CgroupVersion: "v1"}
+	rootless.Security.Rootless = true
+	must.ErrorContains(t, checkRootlessCgroups(rootless), "requires cgroups v2")
+
+	rootless.CgroupVersion = "v2"
+	rootless.CgroupControllers = []string{"cpu", "pids"}
+	must.ErrorContains(t, checkRootlessCgroups(rootless), "memory cgroup controller")
+
+	rootless.CgroupControllers = []string{"cpu", "memory", "pids"}
+	must.NoError(t, checkRootlessCgroups(rootless))
+}
+
+func testTaskConfig() *drivers.TaskConfig {
+	return &drivers.TaskConfig{
+		ID:       "1234/web/abcd",
+		Name:     "web",
+		JobName:  "example",
+		AllocID:  "1234",
+		AllocDir: "/var/nomad/alloc/1234",
+		Env: map[string]string{
+			"NOMAD_ALLOC_DIR":   "/alloc",
+			"NOMAD_TASK_DIR":    "/local",
+			"NOMAD_SECRETS_DIR": "/secrets",
+		},
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{MemoryMB: 256},
+			},
+			LinuxResources: &drivers.LinuxResources{
+				CPUShares:        500,
+				MemoryLimitBytes: 256 * 1024 * 1024,
+				CpusetCpus:       "0-1",
+			},
+			Ports: &structs.AllocatedPorts{
+				{Label: "http", Value: 25000, To: 8080},
+				{Label: "admin", Value: 25001},
+			},
+		},
+	}
+}
+
+func TestPodmanDriver_createContainerSpec(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewPodmanDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	d.config = &Config{Volumes: VolumeConfig{Enabled: true, SelinuxLabel: "z"}}
+
+	cfg := testTaskConfig()
+	cfg.Mounts = []*drivers.MountConfig{
+		{TaskPath: "/data", HostPath: "/srv/data", Readonly: true, PropagationMode: structs.VolumeMountPropagationHostToTask},
+	}
+	cfg.Devices = []*drivers.DeviceConfig{
+		{TaskPath: "/dev/fuse", HostPath: "/dev/fuse", Permissions: "rwm"},
+		{CDIName: "nvidia.com/gpu=0"},
+	}
+	cfg.DNS = &drivers.DNSConfig{Servers: []string{"1.1.1.1"}, Searches: []string{"local"}}
+
+	s, err := d.createContainerSpec(cfg, &TaskConfig{
+		Image:   "docker.io/library/nginx:1",
+		Command: "nginx",
+		Args:    []string{"-g", "daemon off;"},
+		Labels:  map[string]string{"team": "web"},
+		Ports:   []string{"http", "admin"},
+		Volumes: []string{"local/conf:/etc/nginx/conf.d:ro"},
+	})
+	must.NoError(t, err)
+
+	must.Eq(t, "web-1234", s.Name)
+	must.Eq(t, []string{"nginx", "-g", "daemon off;"}, s.Command)
+	must.Eq(t, map[string]string{
+		labelAllocID: "1234",
+		labelJobName: "example",
+		labelTask:    "web",
+		"team":       "web",
+	}, s.Labels)
+
+	must.Eq(t, []spec.Mount{
+		{Destination: "/alloc", Type: "bind", Source: "/var/nomad/alloc/1234/alloc", Options: []string{"rbind", "rw", "z"}},
+		{Destination: "/local", Type: "bind", Source: "/var/nomad/alloc/1234/web/local", Options: []string{"rbind", "rw", "z"}},
+		{Destination: "/secrets", Type: "bind", Source: "/var/nomad/alloc/1234/web/secrets", Options: []string{"rbind", "rw", "z"}},
+		{Destination: "/etc/nginx/conf.d", Type: "bind", Source: "/var/nomad/alloc/1234/web/local/conf", Options: []string{"rbind", "ro", "z"}},
+		{Destination: "/data", Type: "bind", Source: "/srv/data", Options: []string{"rbind", "ro", "rslave", "z"}},
+	}, s.Mounts)
+
+	must.Eq(t, []spec.LinuxDevice{
+		{Path: "/dev/fuse:/dev/fuse:rwm"},
+		{Path: "nvidia.com/gpu=0"},
+	}, s.Devices)
+
+	must.Eq(t, []api.PortMapping{
+		{ContainerPort: 8080, HostPort: 25000, Protocol: "tcp,udp"},
+		{ContainerPort: 25001, HostPort: 25001, Protocol: "tcp,udp"},
+	}, s.PortMappings)
+
+	must.Len(t, 1, s.DNSServers)
+	must.Eq(t, "1.1.1.1", s.DNSServers[0].String())
+	must.Eq(t, []string{"local"}, s.DNSSearch)
+
+	must.Eq(t, &spec.LinuxResources{
+		Memory: &spec.LinuxMemory{Limit: pointer.Of(int64(256 * 1024 * 1024))},
+		CPU:    &spec.LinuxCPU{Shares: pointer.Of(uint64(500)), Cpus: "0-1"},
+	}, s.ResourceLimits)
+}
+
+func TestPodmanDriver_createContainerSpec_Errors(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewPodmanDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	d.config = &Config{}
+
+	cases := []struct {
+		name     string
+		config   TaskConfig
+		isolated bool
+		expErr   string
+	}{
+		{
+			name:   "args without command",
+			config: TaskConfig{Args: []string{"-v"}},
+			expErr: "args can only be set with command",
+		},
+		{
+			name:   "volumes disabled",
+			config: TaskConfig{Volumes: []string{"/etc:/host/etc"}},
+			expErr: "volumes are not enabled",
+		},
+		{
+			name:   "escaping alloc dir",
+			config: TaskConfig{Volumes: []string{"../../../etc:/host/etc"}},
+			expErr: "volumes are not enabled",
+		},
+		{
+			name:   "unknown port",
+			config: TaskConfig{Ports: []string{"grpc"}},
+			expErr: `Port "grpc" not found`,
+		},
+		{
+			name:   "ports with host network",
+			config: TaskConfig{NetworkMode: "host", Ports: []string{"http"}},
+			expErr: "ports can't be published",
+		},
+		{
+			name:     "network mode with group network",
+			config:   TaskConfig{NetworkMode: "bridge"},
+			isolated: true,
+			expErr:   "network_mode can't be set",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := testTaskConfig()
+			if tc.isolated {
+				cfg.NetworkIsolation = &drivers.NetworkIsolationSpec{Path: "/var/run/netns/1234"}
+			}
+			_, err := d.createContainerSpec(cfg, &tc.config)
+			must.ErrorContains(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestPodmanDriver_createContainerSpec_GroupNetwork(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewPodmanDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	d.config = &Config{}
+
+	cfg := testTaskConfig()
+	cfg.NetworkIsolation = &drivers.NetworkIsolationSpec{Path: "/var/run/netns/1234"}
+
+	s, err := d.createContainerSpec(cfg, &TaskConfig{Ports: []string{"http"}})
+	must.NoError(t, err)
+	must.Eq(t, api.Namespace{Mode: "path", Value: "/var/run/netns/1234"}, s.NetNS)
+	must.SliceEmpty(t, s.PortMappings)
+
+	// rootless podman can't join network namespaces created by root
+	d.rootless = true
+	_, err = d.createContainerSpec(cfg, &TaskConfig{})
+	must.ErrorContains(t, err, "rootless podman can't join")
+}
+
+func TestContainerResources_MemoryOversubscription(t *testing.T) {
+	ci.Parallel(t)
+
+	resources := testTaskConfig().Resources
+	resources.NomadResources.Memory.MemoryMaxMB = 512
+
+	limits := containerResources(resources)
+	must.Eq(t, &spec.LinuxMemory{
+		Limit:       pointer.Of(int64(512 * 1024 * 1024)),
+		Reservation: pointer.Of(int64(256 * 1024 * 1024)),
+	}, limits.Memory)
+}
+
+func TestParseVolumeSpec(t *testing.T) {
+	ci.Parallel(t)
+
+	src, dst, ro, err := parseVolumeSpec("local/data:/data")
+	must.NoError(t, err)
+	must.Eq(t, "local/data", src)
+	must.Eq(t, "/data", dst)
+	must.False(t, ro)
+
+	_, _, ro, err = parseVolumeSpec("/srv:/srv:ro")
+	must.NoError(t, err)
+	must.True(t, ro)
+
+	_, _, _, err = parseVolumeSpec("/srv")
+	must.ErrorContains(t, err, "src:dst[:mode]")
+
+	_, _, _, err = parseVolumeSpec("/srv:/srv:z")
+	must.ErrorContains(t, err, "mode must be ro or rw")
+
+	_, _, _, err = parseVolumeSpec(":/srv")
+	must.ErrorContains(t, err, "must be set")
+}
+
+func TestConfig_ParseAllHCL(t *testing.T) {
+	ci.Parallel(t)
+
+	cfgStr := `
+config {
+  image = "docker.io/library/redis:7"
+  auth {
+    username = "user"
+    password = "pass"
+  }
+  force_pull      = true
+  command         = "redis-server"
+  args            = ["--port", "6379"]
+  entrypoint      = ["/entrypoint.sh"]
+  working_dir     = "/data"
+  hostname        = "redis"
+  labels {
+    team = "cache"
+  }
+  ports           = ["db"]
+  volumes         = ["local/data:/data"]
+  network_mode    = "slirp4netns"
+  userns          = "keep-id"
+  privileged      = true
+  readonly_rootfs = true
+  cap_add         = ["NET_ADMIN"]
+  cap_drop        = ["ALL"]
+  tty             = true
+  init            = true
+}`
+
+	expected := &TaskConfig{
+		Image:          "docker.io/library/redis:7",
+		Auth:           AuthConfig{Username: "user", Password: "pass"},
+		ForcePull:      true,
+		Command:        "redis-server",
+		Args:           []string{"--port", "6379"},
+		Entrypoint:     []string{"/entrypoint.sh"},
+		WorkingDir:     "/data",
+		Hostname:       "redis",
+		Labels:         map[string]string{"team": "cache"},
+		Ports:          []string{"db"},
+		Volumes:        []string{"local/data:/data"},
+		NetworkMode:    "slirp4netns",
+		UserNS:         "keep-id",
+		Privileged:     true,
+		ReadOnlyRootFS: true,
+		CapAdd:         []string{"NET_ADMIN"},
+		CapDrop:        []string{"ALL"},
+		Tty:            true,
+		Init:           true,
+	}
+
+	var tc *TaskConfig
+	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+	must.Eq(t, expected, tc)
+}
+
+func TestConfig_PluginDefaults(t *testing.T) {
+	ci.Parallel(t)
+
+	var c *Config
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, `config {}`, &c)
+	must.Eq(t, Config{
+		ClientHTTPTimeout: "60s",
+		Volumes:           VolumeConfig{Enabled: true},
+		GC:                GCConfig{Container: true},
+	}, *c)
+	must.NoError(t, c.validate())
+}
+
+func TestConfig_validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		config Config
+		expErr string
+	}{
+		{name: "valid", config: Config{SocketPath: "unix:///run/podman/podman.sock", ClientHTTPTimeout: "30s"}},
+		{name: "not unix", config: Config{SocketPath: "tcp://127.0.0.1:8080", ClientHTTPTimeout: "30s"}, expErr: "socket_path must be a unix socket"},
+		{name: "invalid timeout", config: Config{ClientHTTPTimeout: "soon"}, expErr: "failed to parse client_http_timeout"},
+		{name: "invalid selinuxlabel", config: Config{ClientHTTPTimeout: "30s", Volumes: VolumeConfig{SelinuxLabel: "x"}}, expErr: "selinuxlabel"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.validate()
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+		})
+	}
+}
+
+func TestDriver_TaskConfig_validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		config TaskConfig
+		expErr string
+	}{
+		{name: "valid", config: TaskConfig{NetworkMode: "pasta", Volumes: []string{"a:/b:rw"}}},
+		{name: "invalid network mode", config: TaskConfig{NetworkMode: "container:web"}, expErr: "network_mode must be one of"},
+		{name: "invalid volume", config: TaskConfig{Volumes: []string{"/srv"}}, expErr: `invalid volume "/srv"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.validate()
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package podman
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/client/lib/fifo"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/drivers/podman/api"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+var (
+	measuredCPUStats = []string{"Percent", "System Mode", "User Mode"}
+	measuredMemStats = []string{"Usage"}
+	measuredIOStats  = []string{"Read Bytes", "Write Bytes"}
+	measuredNetStats = []string{"Rx Bytes", "Tx Bytes"}
+)
+
+type taskHandle struct {
+	containerID string
+	tty         bool
+	podman      *api.API
+	ctx         context.Context
+	logger      hclog.Logger
+
+	// collect is whether the logs of the container are written to the log
+	// fifos of the task
+	collect bool
+
+	// logCancel stops the collection of the logs of the container
+	logCancel context.CancelFunc
+
+	// doneCh is closed when the container exits
+	doneCh chan struct{}
+
+	// stateLock syncs access to all fields below
+	stateLock sync.RWMutex
+
+	taskConfig  *drivers.TaskConfig
+	procState   drivers.TaskState
+	startedAt   time.Time
+	completedAt time.Time
+	exitResult  *drivers.ExitResult
+}
+
+func (h *taskHandle) TaskStatus() *drivers.TaskStatus {
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+
+	return &drivers.TaskStatus{
+		ID:          h.taskConfig.ID,
+		Name:        h.taskConfig.Name,
+		State:       h.procState,
+		StartedAt:   h.startedAt,
+		CompletedAt: h.completedAt,
+		ExitResult:  h.exitResult,
+		DriverAttributes: map[string]string{
+			"container_id": h.containerID,
+		},
+	}
+}
+
+func (h *taskHandle) IsRunning() bool {
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+	return h.procState == drivers.TaskStateRunning
+}
+
+func (h *taskHandle) exitResultCopy() *drivers.ExitResult {
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+	return h.exitResult.Copy()
+}
+
+func (h *taskHandle) run() {
+	defer close(h.doneCh)
+
+	h.stateLock.Lock()
+	if h.exitResult == nil {
+		h.exitResult = &drivers.ExitResult{}
+	}
+	h.stateLock.Unlock()
+
+	code, err := h.podman.ContainerWait(h.ctx, h.containerID)
+
+	// the container may be gone once it exited, in which case its exit code
+	// is all we know about it
+	var container *api.InspectContainerData
+	if err == nil {
+		container, _ = h.podman.ContainerInspect(h.ctx, h.containerID)
+	}
+
+	h.stateLock.Lock()
+	defer h.stateLock.Unlock()
+
+	if err != nil {
+		h.exitResult.Err = err
+		h.procState = drivers.TaskStateUnknown
+		h.completedAt = time.Now()
+		return
+	}
+	h.procState = drivers.TaskStateExited
+	h.exitResult.ExitCode = code
+	h.completedAt = time.Now()
+	if container != nil {
+		h.exitResult.OOMKilled = container.State.OOMKilled
+		if !container.State.FinishedAt.IsZero() {
+			h.completedAt = container.State.FinishedAt
+		}
+	}
+}
+
+// kill sends a signal to the container, unless it already exited.
+func (h *taskHandle) kill(signal string) error {
+	if !h.IsRunning() {
+		return nil
+	}
+
+	err := h.podman.ContainerKill(h.ctx, h.containerID, signal)
+	if err == nil {
+		return nil
+	}
+
+	// the container may have exited since it was last seen running
+	if errors.Is(err, api.ErrNotFound) {
+		return nil
+	}
+	if container, ierr := h.podman.ContainerInspect(h.ctx, h.containerID); ierr == nil && !container.State.Running {
+		return nil
+	}
+	return err
+}
+
+// collectLogs writes the logs of the container from since to the log fifos of
+// the task, until the container exits or the handle is shut down.
+func (h *taskHandle) collectLogs(since time.Time) {
+	if !h.collect {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(h.ctx)
+	h.stateLock.Lock()
+	h.logCancel = cancel
+	h.stateLock.Unlock()
+	defer cancel()
+
+	stdout, err := fifo.OpenWriter(h.taskConfig.StdoutPath)
+	if err != nil {
+		h.logger.Error("failed to open stdout fifo", "error", err)
+		return
+	}
+	defer stdout.Close()
+
+	stderr, err := fifo.OpenWriter(h.taskConfig.StderrPath)
+	if err != nil {
+		h.logger.Error("failed to open stderr fifo", "error", err)
+		return
+	}
+	defer stderr.Close()
+
+	err = h.podman.ContainerLogs(ctx, h.containerID, since, stdout, stderr)
+	if err != nil && ctx.Err() == nil {
+		h.logger.Error("failed to collect container logs", "error", err)
+	}
+}
+
+// shutdown stops the collection of the logs of the container.
+func (h *taskHandle) shutdown() {
+	h.stateLock.RLock()
+	defer h.stateLock.RUnlock()
+	if h.logCancel != nil {
+		h.logCancel()
+	}
+}
+
+// collectStats sends the resource usage of the container every interval
+// until ctx is canceled or the container exits.
+func (h *taskHandle) collectStats(ctx context.Context, ch chan<- *drivers.TaskResourceUsage, interval time.Duration, compute cpustats.Compute) {
+	defer close(ch)
+
+	totalCPUStats := cpustats.New(compute)
+	userCPUStats := cpustats.New(compute)
+	systemCPUStats := cpustats.New(compute)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.doneCh:
+			return
+		case <-timer.C:
+			timer.Reset(interval)
+		}
+
+		stats, err := h.podman.ContainerStats(ctx, h.containerID)
+		if err != nil {
+			if ctx.Err() == nil {
+				h.logger.Debug("failed to get container stats", "error", err)
+			}
+			continue
+		}
+
+		totalPercent := totalCPUStats.Percent(float64(stats.CPUNano))
+		systemTime := float64(stats.CPUSystem)
+		userTime := float64(stats.CPUNano) - systemTime
+		if userTime < 0 {
+			userTime = 0
+		}
+
+		usage := &cstructs.TaskResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{
+					Usage:    stats.MemUsage,
+					Measured: measuredMemStats,
+				},
+				CpuStats: &cstructs.CpuStats{
+					Percent:    totalPercent,
+					SystemMode: systemCPUStats.Percent(systemTime),
+					UserMode:   userCPUStats.Percent(userTime),
+					TotalTicks: totalCPUStats.TicksConsumed(totalPercent),
+					Measured:   measuredCPUStats,
+				},
+				BlockIOStats: &cstructs.BlockIOStats{
+					ReadBytes:  stats.BlockInput,
+					WriteBytes: stats.BlockOutput,
+					Measured:   measuredIOStats,
+				},
+				NetworkStats: &cstructs.NetworkStats{
+					RxBytes:  stats.NetInput,
+					TxBytes:  stats.NetOutput,
+					Measured: measuredNetStats,
+				},
+			},
+			Timestamp: time.Now().UTC().UnixNano(),
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case ch <- usage:
+		}
+	}
+}
@@ -5,6 +5,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -51,6 +52,10 @@ var (
 	ExecutorBasicMeasuredCpuStats = []string{"System Mode", "User Mode", "Percent"}
 )
 
+// ErrCheckpointNotSupported is returned by executors which can't checkpoint
+// and restore the user process.
+var ErrCheckpointNotSupported = errors.New("checkpoint and restore are only supported for tasks isolated with libcontainer")
+
 // Executor is the interface which allows a driver to launch and supervise
 // a process
 type Executor interface {
@@ -90,14 +95,22 @@ type Executor interface {
 
 	ExecStreaming(ctx context.Context, cmd []string, tty bool,
 		stream drivers.ExecTaskStream) error
+
+	// Checkpoint writes the process state of the user process to dir with
+	// CRIU. The user process exits unless leaveRunning is set.
+	Checkpoint(dir string, leaveRunning bool) error
+
+	// Restore restores a user process configured by the given ExecCommand
+	// from the checkpoint in dir, instead of launching its command.
+	Restore(launchCmd *ExecCommand, dir string) (*ProcessState, error)
 }
 
 // ExecCommand holds the user command, args, and other isolation related
 // settings.
 //
-// Important (!): when adding fields, make sure to update the RPC methods in
-// grpcExecutorClient.Launch and grpcExecutorServer.Launch. Number of hours
-// spent tracking this down: too many.
+// Important (!): when adding fields, make sure to update launchRequestToProto
+// and launchRequestFromProto, which the Launch and Restore RPCs use. Number of
+// hours spent tracking this down: too many.
 type ExecCommand struct {
 	// Cmd is the command that the user wants to run.
 	Cmd string
@@ -153,6 +166,14 @@ type ExecCommand struct {
 	// ModeIPC is the IPC isolation mode (private or host).
 	ModeIPC string
 
+	// ModeUser is the user namespace isolation mode (private or host).
+	ModeUser string
+
+	// UIDMappings and GIDMappings map the IDs of the user namespace of the task
+	// to IDs of the host, when the user namespace isolation mode is private.
+	UIDMappings []IDMapping
+	GIDMappings []IDMapping
+
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
 
@@ -297,6 +318,14 @@ func (c *ExecCommand) Close() {
 	}
 }
 
+// IDMapping maps a range of Size IDs of the user namespace of a task, starting
+// at ContainerID, to the range of IDs of the host starting at HostID.
+type IDMapping struct {
+	ContainerID uint32
+	HostID      uint32
+	Size        uint32
+}
+
 // ProcessState holds information about the state of a user process.
 type ProcessState struct {
 	Pid       int
@@ -558,8 +587,13 @@ func (e *UniversalExecutor) Wait(ctx context.Context) (*ProcessState, error) {
 	}
 }
 
+// UpdateResources updates the resource isolation of the running task with new
+// CPU and memory limits.
 func (e *UniversalExecutor) UpdateResources(resources *drivers.Resources) error {
-	return nil
+	if e.command == nil || resources == nil || resources.LinuxResources == nil {
+		return nil
+	}
+	return e.updateResourceContainer(resources)
 }
 
 func (e *UniversalExecutor) wait() {
@@ -718,11 +752,13 @@ func (e *UniversalExecutor) handleStats(ch chan *cstructs.TaskResourceUsage, ctx
 		}
 
 		stats := e.processStats.StatProcesses(time.Now())
+		usage := procstats.Aggregate(e.systemCpuStats, stats)
+		e.addCgroupStats(usage.ResourceUsage)
 
 		select {
 		case <-ctx.Done():
 			return
-		case ch <- procstats.Aggregate(e.systemCpuStats, stats):
+		case ch <- usage:
 		}
 	}
 }
@@ -802,3 +838,15 @@ func SupportedCaps(allowNetRaw bool) []string {
 	}
 	return allCaps
 }
+
+// Checkpoint is not supported by the universal executor, which doesn't run
+// the user process in a container.
+func (e *UniversalExecutor) Checkpoint(dir string, leaveRunning bool) error {
+	return ErrCheckpointNotSupported
+}
+
+// Restore is not supported by the universal executor, which doesn't run the
+// user process in a container.
+func (e *UniversalExecutor) Restore(command *ExecCommand, dir string) (*ProcessState, error) {
+	return nil, ErrCheckpointNotSupported
+}
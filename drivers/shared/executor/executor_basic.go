@@ -27,6 +27,10 @@ func (e *UniversalExecutor) configureResourceContainer(_ *ExecCommand, _ int) (f
 	return running, cleanup, nil
 }
 
+func (e *UniversalExecutor) updateResourceContainer(_ *drivers.Resources) error {
+	return nil
+}
+
 func (e *UniversalExecutor) start(command *ExecCommand) error {
 	return e.childCmd.Start()
 }
@@ -42,3 +46,5 @@ func (e *UniversalExecutor) ListProcesses() set.Collection[int] {
 func (e *UniversalExecutor) setSubCmdCgroup(*exec.Cmd, string) (func(), error) {
 	return func() {}, nil
 }
+
+func (e *UniversalExecutor) addCgroupStats(*drivers.ResourceUsage) {}
@@ -15,6 +15,7 @@ import (
 	"os/signal"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"syscall"
@@ -174,6 +175,58 @@ func (l *LibcontainerExecutor) cleanOldProcessesInCGroup(nomadRelativePath strin
 func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
 	l.logger.Trace("preparing to launch command", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
+	// Starts the task
+	return l.start(command, func(container *libcontainer.Container, process *libcontainer.Process) error {
+		return container.Run(process)
+	})
+}
+
+// Restore restores the user process of the container from the checkpoint in
+// dir, instead of running its command.
+func (l *LibcontainerExecutor) Restore(command *ExecCommand, dir string) (*ProcessState, error) {
+	l.logger.Trace("preparing to restore command", "command", command.Cmd, "dir", dir)
+
+	// CRIU restores the process tree as children of the criu process, which
+	// exits once done; the executor must become the reaper of the restored
+	// process in order to wait on it
+	if err := unix.Prctl(unix.PR_SET_CHILD_SUBREAPER, 1, 0, 0, 0); err != nil {
+		return nil, fmt.Errorf("failed to become subreaper: %v", err)
+	}
+
+	return l.start(command, func(container *libcontainer.Container, process *libcontainer.Process) error {
+		return container.Restore(process, criuOpts(dir, false))
+	})
+}
+
+// Checkpoint writes the process state of the container to dir. The container
+// exits unless leaveRunning is set.
+func (l *LibcontainerExecutor) Checkpoint(dir string, leaveRunning bool) error {
+	if l.container == nil {
+		return fmt.Errorf("no container to checkpoint")
+	}
+
+	l.logger.Debug("checkpointing container", "dir", dir, "leave_running", leaveRunning)
+	if err := l.container.Checkpoint(criuOpts(dir, leaveRunning)); err != nil {
+		return fmt.Errorf("failed to checkpoint container(%s): %v", l.id, err)
+	}
+	return nil
+}
+
+// criuOpts returns the CRIU options of the checkpoints of containers written
+// to and restored from dir.
+func criuOpts(dir string, leaveRunning bool) *libcontainer.CriuOpts {
+	return &libcontainer.CriuOpts{
+		ImagesDirectory: dir,
+		LeaveRunning:    leaveRunning,
+		TcpEstablished:  true,
+		FileLocks:       true,
+	}
+}
+
+// start creates the container of command, and starts its user process with
+// run.
+func (l *LibcontainerExecutor) start(command *ExecCommand, run func(*libcontainer.Container, *libcontainer.Process) error) (*ProcessState, error) {
+
 	if command.Resources == nil {
 		command.Resources = &drivers.Resources{
 			NomadResources: &structs.AllocatedTaskResources{},
@@ -247,8 +300,7 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	l.userCpuStats = cpustats.New(l.compute)
 	l.systemCpuStats = cpustats.New(l.compute)
 
-	// Starts the task
-	if err := container.Run(process); err != nil {
+	if err := run(container, process); err != nil {
 		container.Destroy()
 		return nil, err
 	}
@@ -396,6 +448,34 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 
 // UpdateResources updates the resource isolation with new values to be enforced
 func (l *LibcontainerExecutor) UpdateResources(resources *drivers.Resources) error {
+	if l.container == nil || l.command == nil || !l.command.ResourceLimits ||
+		resources == nil || resources.LinuxResources == nil || resources.NomadResources == nil {
+		return nil
+	}
+
+	command := *l.command
+	command.Resources = resources
+	cfg := l.container.Config()
+
+	l.configureCgroupMemory(&cfg, &command)
+
+	cpuShares := uint64(l.clampCpuShares(resources.LinuxResources.CPUShares))
+	switch cgroupslib.GetMode() {
+	case cgroupslib.CG1:
+		cfg.Cgroups.Resources.CpuShares = cpuShares
+	default:
+		cfg.Cgroups.Resources.CpuWeight = cgroups.ConvertCPUSharesToCgroupV2Value(cpuShares)
+	}
+
+	// lift any previous cpu bandwidth limit before setting the new one
+	cfg.Cgroups.Resources.CpuQuota = -1
+	cfg.Cgroups.Resources.CpuPeriod = 100000
+	l.configureCPUBandwidth(&cfg, &command)
+
+	if err := l.container.Set(cfg); err != nil {
+		return fmt.Errorf("failed to update task resources: %w", err)
+	}
+	l.command.Resources = resources
 	return nil
 }
 
@@ -449,6 +529,10 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 
 		// Memory Related Stats
 		swap := stats.MemoryStats.SwapUsage
+		if cgroupslib.GetMode() == cgroupslib.CG2 {
+			// The swap usage includes the memory usage on cgroups v2
+			swap = stats.MemoryStats.SwapOnlyUsage
+		}
 		maxUsage := stats.MemoryStats.Usage.MaxUsage
 
 		cache := stats.MemoryStats.Stats["cache"]
@@ -473,8 +557,12 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 			MaxUsage:       maxUsage,
 			KernelUsage:    stats.MemoryStats.KernelUsage.Usage,
 			KernelMaxUsage: stats.MemoryStats.KernelUsage.MaxUsage,
+			Pressure:       pressureStats(stats.MemoryStats.PSI),
 			Measured:       measurableMemStats,
 		}
+		if ms.Pressure != nil {
+			ms.Measured = append(slices.Clone(ms.Measured), "Pressure")
+		}
 
 		// CPU Related Stats
 		totalProcessCPUUsage := float64(stats.CpuStats.CpuUsage.TotalUsage)
@@ -489,8 +577,12 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 			ThrottledPeriods: stats.CpuStats.ThrottlingData.ThrottledPeriods,
 			ThrottledTime:    stats.CpuStats.ThrottlingData.ThrottledTime,
 			TotalTicks:       l.systemCpuStats.TicksConsumed(totalPercent),
+			Pressure:         pressureStats(stats.CpuStats.PSI),
 			Measured:         ExecutorCgroupMeasuredCpuStats,
 		}
+		if cs.Pressure != nil {
+			cs.Measured = append(slices.Clone(cs.Measured), "Pressure")
+		}
 		taskResUsage := cstructs.TaskResourceUsage{
 			ResourceUsage: &cstructs.ResourceUsage{
 				MemoryStats: ms,
@@ -509,6 +601,24 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 	}
 }
 
+// pressureStats converts the pressure stall information of a cgroup, which is
+// only available on cgroups v2 systems with PSI enabled.
+func pressureStats(psi *cgroups.PSIStats) *cstructs.PressureStats {
+	if psi == nil {
+		return nil
+	}
+	return &cstructs.PressureStats{
+		SomeAvg10:  psi.Some.Avg10,
+		SomeAvg60:  psi.Some.Avg60,
+		SomeAvg300: psi.Some.Avg300,
+		SomeTotal:  psi.Some.Total,
+		FullAvg10:  psi.Full.Avg10,
+		FullAvg60:  psi.Full.Avg60,
+		FullAvg300: psi.Full.Avg300,
+		FullTotal:  psi.Full.Total,
+	}
+}
+
 // Signal sends a signal to the process managed by the executor
 func (l *LibcontainerExecutor) Signal(s os.Signal) error {
 	return l.userProc.Signal(s)
@@ -662,7 +772,7 @@ func configureNamespaces(pidMode, ipcMode string) runc.Namespaces {
 // The process runs in a container configured with the following:
 //
 // * the task directory as the chroot
-// * dedicated mount points namespace, but shares the PID, User, domain, network namespaces with host
+// * dedicated mount points namespace, and dedicated PID, IPC, and user namespaces when their isolation mode is private
 // * small subset of devices (e.g. stdout/stderr/stdin, tty, shm, pts); default to using the same set of devices as Docker
 // * some special filesystems: `/proc`, `/sys`.  Some case is given to avoid exec escaping or setting malicious values through them.
 func configureIsolation(cfg *runc.Config, command *ExecCommand) error {
@@ -754,9 +864,73 @@ func configureIsolation(cfg *runc.Config, command *ExecCommand) error {
 		cfg.Mounts = append(cfg.Mounts, cmdMounts(command.Mounts)...)
 	}
 
+	return configureUserNamespace(cfg, command)
+}
+
+// configureUserNamespace runs the task in a private user namespace with the
+// ID mappings of the command, when the user isolation mode is private. The IDs
+// of the task, including root, are then unprivileged IDs of the host.
+func configureUserNamespace(cfg *runc.Config, command *ExecCommand) error {
+	if command.ModeUser != IsolationModePrivate {
+		return nil
+	}
+
+	if len(command.UIDMappings) == 0 || len(command.GIDMappings) == 0 {
+		return errors.New("user namespace isolation requires uid and gid mappings")
+	}
+
+	// proc and mqueue can only be mounted by the user namespace owning the
+	// PID and IPC namespaces of the task
+	if command.ModePID != IsolationModePrivate || command.ModeIPC != IsolationModePrivate {
+		return errors.New("user namespace isolation requires private PID and IPC isolation modes")
+	}
+
+	cfg.Namespaces = append(cfg.Namespaces, runc.Namespace{Type: runc.NEWUSER})
+	cfg.UIDMappings = runcIDMaps(command.UIDMappings)
+	cfg.GIDMappings = runcIDMaps(command.GIDMappings)
+
+	for _, m := range cfg.Mounts {
+		switch m.Device {
+		case "sysfs":
+			// sysfs can only be mounted by the user namespace owning the
+			// network namespace, which is the host or the allocation network
+			// namespace, so bind mount the sysfs of the host instead
+			m.Source = "/sys"
+			m.Device = "bind"
+			m.Flags |= syscall.MS_BIND | syscall.MS_REC
+		case "devpts":
+			// the tty group is only set if it is mapped in the user namespace
+			if !idMapped(command.GIDMappings, 5) {
+				m.Data = "newinstance,ptmxmode=0666,mode=0620"
+			}
+		}
+	}
 	return nil
 }
 
+func runcIDMaps(mappings []IDMapping) []runc.IDMap {
+	maps := make([]runc.IDMap, len(mappings))
+	for i, m := range mappings {
+		maps[i] = runc.IDMap{
+			ContainerID: int64(m.ContainerID),
+			HostID:      int64(m.HostID),
+			Size:        int64(m.Size),
+		}
+	}
+	return maps
+}
+
+// idMapped returns true if the ID of the user namespace is in one of the
+// mappings.
+func idMapped(mappings []IDMapping, id uint32) bool {
+	for _, m := range mappings {
+		if id >= m.ContainerID && uint64(id) < uint64(m.ContainerID)+uint64(m.Size) {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *LibcontainerExecutor) configureCgroups(cfg *runc.Config, command *ExecCommand) error {
 	// note: an alloc TR hook pre-creates the cgroup(s) in both v1 and v2
 
@@ -776,6 +950,11 @@ func (l *LibcontainerExecutor) configureCgroups(cfg *runc.Config, command *ExecC
 	// set the libcontainer memory limits
 	l.configureCgroupMemory(cfg, command)
 
+	// set the blkio (v1) or io (v2) throttling limits
+	if err := configureCgroupDiskIO(cfg, command); err != nil {
+		return fmt.Errorf("failed to set disk io limits: %w", err)
+	}
+
 	// set cgroup v1/v2 specific attributes (cpu, path)
 	switch cgroupslib.GetMode() {
 	case cgroupslib.CG1:
@@ -809,6 +988,36 @@ func (l *LibcontainerExecutor) configureCgroupMemory(cfg *runc.Config, command *
 	cfg.Cgroups.Resources.MemorySwappiness = cgroupslib.MaybeDisableMemorySwappiness()
 }
 
+func configureCgroupDiskIO(cfg *runc.Config, command *ExecCommand) error {
+	limits := command.Resources.LinuxResources.DiskIO
+	if limits == nil {
+		return nil
+	}
+
+	var st unix.Stat_t
+	if err := unix.Stat(limits.Device, &st); err != nil {
+		return err
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFBLK {
+		return fmt.Errorf("%s is not a block device", limits.Device)
+	}
+	major, minor := int64(unix.Major(st.Rdev)), int64(unix.Minor(st.Rdev))
+
+	throttle := func(rate int64) []*runc.ThrottleDevice {
+		if rate <= 0 {
+			return nil
+		}
+		return []*runc.ThrottleDevice{runc.NewThrottleDevice(major, minor, uint64(rate))}
+	}
+
+	// libcontainer writes these to io.max on cgroups v2
+	cfg.Cgroups.Resources.BlkioThrottleReadBpsDevice = throttle(limits.ReadBPS)
+	cfg.Cgroups.Resources.BlkioThrottleWriteBpsDevice = throttle(limits.WriteBPS)
+	cfg.Cgroups.Resources.BlkioThrottleReadIOPSDevice = throttle(limits.ReadIOPS)
+	cfg.Cgroups.Resources.BlkioThrottleWriteIOPSDevice = throttle(limits.WriteIOPS)
+	return nil
+}
+
 func (l *LibcontainerExecutor) configureCG1(cfg *runc.Config, command *ExecCommand, cgroup string) error {
 
 	cpuShares := l.clampCpuShares(command.Resources.LinuxResources.CPUShares)
@@ -821,6 +1030,7 @@ func (l *LibcontainerExecutor) configureCG1(cfg *runc.Config, command *ExecComma
 
 	// set cpu resources
 	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
+	l.configureCPUBandwidth(cfg, command)
 
 	// we need to manually set the cpuset, because libcontainer will not set
 	// it for our special cpuset cgroup
@@ -855,15 +1065,25 @@ func (l *LibcontainerExecutor) configureCG2(cfg *runc.Config, command *ExecComma
 	cpuWeight := cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
 	cfg.Cgroups.Resources.CpuWeight = cpuWeight
 
+	// sets cpu.max, capping the bandwidth of burstable tasks
+	l.configureCPUBandwidth(cfg, command)
+
 	// finally set the path of the cgroup in which to run the task
 	scope := filepath.Base(cg)
 	cfg.Cgroups.Path = filepath.Join("/", cgroupslib.NomadCgroupParent, partition, scope)
-
-	// todo(shoenig): we will also want to set cpu bandwidth (i.e. cpu_hard_limit)
-	// hopefully for 1.7
 	return nil
 }
 
+// configureCPUBandwidth sets the CFS quota and period of the task, if any.
+func (l *LibcontainerExecutor) configureCPUBandwidth(cfg *runc.Config, command *ExecCommand) {
+	res := command.Resources.LinuxResources
+	if res.CPUQuota <= 0 || res.CPUPeriod <= 0 {
+		return
+	}
+	cfg.Cgroups.Resources.CpuQuota = res.CPUQuota
+	cfg.Cgroups.Resources.CpuPeriod = uint64(res.CPUPeriod)
+}
+
 func (l *LibcontainerExecutor) newLibcontainerConfig(command *ExecCommand) (*runc.Config, error) {
 	cfg := &runc.Config{
 		ParentDeathSignal: 9,
@@ -919,6 +1139,9 @@ func cmdDevices(driverDevices []*drivers.DeviceConfig) ([]*devices.Device, error
 	r := make([]*devices.Device, len(driverDevices))
 
 	for i, d := range driverDevices {
+		if d.CDIName != "" {
+			return nil, fmt.Errorf("CDI device %q is not supported by this driver", d.CDIName)
+		}
 		ed, err := devices.DeviceFromPath(d.HostPath, d.Permissions)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make device out for %s: %v", d.HostPath, err)
@@ -144,6 +144,140 @@ func TestExecutor_configureNamespaces(t *testing.T) {
 	})
 }
 
+func TestExecutor_configureUserNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	newConfig := func(t *testing.T, command *ExecCommand) (*lconfigs.Config, error) {
+		cfg := &lconfigs.Config{Cgroups: &lconfigs.Cgroup{Resources: &lconfigs.Resources{}}}
+		return cfg, configureIsolation(cfg, command)
+	}
+	mount := func(cfg *lconfigs.Config, dst string) *lconfigs.Mount {
+		for _, m := range cfg.Mounts {
+			if m.Destination == dst {
+				return m
+			}
+		}
+		t.Fatalf("no mount at %s", dst)
+		return nil
+	}
+
+	t.Run("host", func(t *testing.T) {
+		cfg, err := newConfig(t, &ExecCommand{
+			TaskDir:  "/tmp/task",
+			ModePID:  IsolationModePrivate,
+			ModeIPC:  IsolationModePrivate,
+			ModeUser: IsolationModeHost,
+		})
+		must.NoError(t, err)
+		must.False(t, cfg.Namespaces.Contains(lconfigs.NEWUSER))
+		must.Nil(t, cfg.UIDMappings)
+		must.Eq(t, "sysfs", mount(cfg, "/sys").Device)
+	})
+
+	t.Run("private", func(t *testing.T) {
+		cfg, err := newConfig(t, &ExecCommand{
+			TaskDir:     "/tmp/task",
+			ModePID:     IsolationModePrivate,
+			ModeIPC:     IsolationModePrivate,
+			ModeUser:    IsolationModePrivate,
+			UIDMappings: []IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+			GIDMappings: []IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		})
+		must.NoError(t, err)
+		must.True(t, cfg.Namespaces.Contains(lconfigs.NEWUSER))
+		must.Eq(t, []lconfigs.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}, cfg.UIDMappings)
+		must.Eq(t, []lconfigs.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}, cfg.GIDMappings)
+
+		sys := mount(cfg, "/sys")
+		must.Eq(t, "bind", sys.Device)
+		must.Eq(t, "/sys", sys.Source)
+		must.True(t, sys.Flags&syscall.MS_BIND != 0)
+		must.True(t, sys.Flags&syscall.MS_RDONLY != 0)
+		must.StrContains(t, mount(cfg, "/dev/pts").Data, "gid=5")
+	})
+
+	t.Run("tty group unmapped", func(t *testing.T) {
+		cfg, err := newConfig(t, &ExecCommand{
+			TaskDir:     "/tmp/task",
+			ModePID:     IsolationModePrivate,
+			ModeIPC:     IsolationModePrivate,
+			ModeUser:    IsolationModePrivate,
+			UIDMappings: []IDMapping{{ContainerID: 1000, HostID: 200000, Size: 1}},
+			GIDMappings: []IDMapping{{ContainerID: 1000, HostID: 200000, Size: 1}},
+		})
+		must.NoError(t, err)
+		must.StrNotContains(t, mount(cfg, "/dev/pts").Data, "gid=5")
+	})
+
+	t.Run("missing mappings", func(t *testing.T) {
+		_, err := newConfig(t, &ExecCommand{
+			TaskDir:     "/tmp/task",
+			ModePID:     IsolationModePrivate,
+			ModeIPC:     IsolationModePrivate,
+			ModeUser:    IsolationModePrivate,
+			UIDMappings: []IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		})
+		must.ErrorContains(t, err, "requires uid and gid mappings")
+	})
+
+	t.Run("host pid", func(t *testing.T) {
+		_, err := newConfig(t, &ExecCommand{
+			TaskDir:     "/tmp/task",
+			ModePID:     IsolationModeHost,
+			ModeIPC:     IsolationModePrivate,
+			ModeUser:    IsolationModePrivate,
+			UIDMappings: []IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+			GIDMappings: []IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		})
+		must.ErrorContains(t, err, "requires private PID and IPC isolation modes")
+	})
+}
+
+func TestExecutor_configureCgroupDiskIO(t *testing.T) {
+	ci.Parallel(t)
+
+	newConfig := func(limits *drivers.DiskIOLimits) (*lconfigs.Config, error) {
+		cfg := &lconfigs.Config{Cgroups: &lconfigs.Cgroup{Resources: &lconfigs.Resources{}}}
+		return cfg, configureCgroupDiskIO(cfg, &ExecCommand{
+			Resources: &drivers.Resources{
+				LinuxResources: &drivers.LinuxResources{DiskIO: limits},
+			},
+		})
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		cfg, err := newConfig(nil)
+		must.NoError(t, err)
+		must.Nil(t, cfg.Cgroups.Resources.BlkioThrottleReadBpsDevice)
+	})
+
+	t.Run("not a block device", func(t *testing.T) {
+		_, err := newConfig(&drivers.DiskIOLimits{Device: "/dev/null", ReadBPS: 1024})
+		must.ErrorContains(t, err, "not a block device")
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		var st unix.Stat_t
+		if err := unix.Stat("/dev/loop0", &st); err != nil {
+			t.Skip("requires /dev/loop0")
+		}
+
+		cfg, err := newConfig(&drivers.DiskIOLimits{
+			Device:    "/dev/loop0",
+			ReadBPS:   1024,
+			WriteIOPS: 100,
+		})
+		must.NoError(t, err)
+
+		major, minor := int64(unix.Major(st.Rdev)), int64(unix.Minor(st.Rdev))
+		res := cfg.Cgroups.Resources
+		must.Eq(t, []*lconfigs.ThrottleDevice{lconfigs.NewThrottleDevice(major, minor, 1024)}, res.BlkioThrottleReadBpsDevice)
+		must.Eq(t, []*lconfigs.ThrottleDevice{lconfigs.NewThrottleDevice(major, minor, 100)}, res.BlkioThrottleWriteIOPSDevice)
+		must.Nil(t, res.BlkioThrottleWriteBpsDevice)
+		must.Nil(t, res.BlkioThrottleReadIOPSDevice)
+	})
+}
+
 func TestExecutor_Isolation_PID_and_IPC_hostMode(t *testing.T) {
 	ci.Parallel(t)
 	r := require.New(t)
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"syscall"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs2"
 	"golang.org/x/sys/unix"
 )
 
@@ -63,6 +65,49 @@ func (e *UniversalExecutor) ListProcesses() set.Collection[procstats.ProcessID]
 	}
 }
 
+// addCgroupStats adds the memory and CPU stats accounted for the cgroup of the
+// task to the stats of its processes, so that the task reports the same stats
+// as the tasks of the exec and docker drivers. The stats are only collected on
+// cgroups v2 systems.
+func (e *UniversalExecutor) addCgroupStats(ru *drivers.ResourceUsage) {
+	cgroup := e.command.StatsCgroup()
+	if cgroupslib.GetMode() != cgroupslib.CG2 || cgroup == "" {
+		return
+	}
+
+	manager, err := fs2.NewManager(nil, cgroup)
+	if err != nil {
+		e.logger.Debug("failed to open task cgroup", "cgroup", cgroup, "error", err)
+		return
+	}
+	stats, err := manager.GetStats()
+	if err != nil {
+		e.logger.Debug("failed to collect cgroup stats", "cgroup", cgroup, "error", err)
+		return
+	}
+
+	ms := ru.MemoryStats
+	ms.RSS = stats.MemoryStats.Stats["anon"]
+	ms.Cache = stats.MemoryStats.Stats["file"]
+	ms.MappedFile = stats.MemoryStats.Stats["file_mapped"]
+	ms.Swap = stats.MemoryStats.SwapOnlyUsage.Usage
+	ms.Usage = stats.MemoryStats.Usage.Usage
+	ms.Pressure = pressureStats(stats.MemoryStats.PSI)
+	ms.Measured = slices.Clone(ExecutorCgroupV2MeasuredMemStats)
+	if ms.Pressure != nil {
+		ms.Measured = append(ms.Measured, "Pressure")
+	}
+
+	cs := ru.CpuStats
+	cs.ThrottledPeriods = stats.CpuStats.ThrottlingData.ThrottledPeriods
+	cs.ThrottledTime = stats.CpuStats.ThrottlingData.ThrottledTime
+	cs.Pressure = pressureStats(stats.CpuStats.PSI)
+	cs.Measured = append(slices.Clone(cs.Measured), "Throttled Periods", "Throttled Time")
+	if cs.Pressure != nil {
+		cs.Measured = append(cs.Measured, "Pressure")
+	}
+}
+
 func (e *UniversalExecutor) statCG(cgroup string) (int, func(), error) {
 	fd, err := unix.Open(cgroup, unix.O_PATH, 0)
 	cleanup := func() {
@@ -176,6 +221,35 @@ func (e *UniversalExecutor) enterCG1(statsCgroup, cpusetCgroup string) (runningF
 	return move, cleanup
 }
 
+// updateResourceContainer rewrites the cgroup limits of the running task with
+// the new resources. Custom cgroups are not managed by Nomad and are left
+// untouched.
+func (e *UniversalExecutor) updateResourceContainer(resources *drivers.Resources) error {
+	if e.usesCustomCgroup() {
+		return nil
+	}
+
+	command := *e.command
+	command.Resources = resources
+	cgroup := command.StatsCgroup()
+
+	// lift any previous cpu bandwidth limit before setting the new one
+	switch cgroupslib.GetMode() {
+	case cgroupslib.OFF:
+		return nil
+	case cgroupslib.CG1:
+		_ = cgroupslib.OpenFromFreezerCG1(cgroup, "cpu").Write("cpu.cfs_quota_us", "-1")
+		if err := e.configureCG1(cgroup, &command); err != nil {
+			return err
+		}
+	default:
+		_ = cgroupslib.OpenPath(cgroup).Write("cpu.max", "max")
+		e.configureCG2(cgroup, &command)
+	}
+	e.command.Resources = resources
+	return nil
+}
+
 func (e *UniversalExecutor) configureCG1(cgroup string, command *ExecCommand) error {
 	// some drivers like qemu entirely own resource management
 	if command.Resources == nil || command.Resources.LinuxResources == nil {
@@ -218,6 +292,12 @@ func (e *UniversalExecutor) configureCG1(cgroup string, command *ExecCommand) er
 	ed = cgroupslib.OpenFromFreezerCG1(cgroup, "cpu")
 	_ = ed.Write("cpu.shares", cpuShares)
 
+	// write cpu bandwidth, if set
+	if quota, period := command.Resources.LinuxResources.CPUQuota, command.Resources.LinuxResources.CPUPeriod; quota > 0 && period > 0 {
+		_ = ed.Write("cpu.cfs_period_us", strconv.FormatInt(period, 10))
+		_ = ed.Write("cpu.cfs_quota_us", strconv.FormatInt(quota, 10))
+	}
+
 	// write cpuset, if set
 	if cpuSet := command.Resources.LinuxResources.CpusetCpus; cpuSet != "" {
 		cpusetPath := command.Resources.LinuxResources.CpusetCgroupPath
@@ -260,6 +340,11 @@ func (e *UniversalExecutor) configureCG2(cgroup string, command *ExecCommand) {
 	ed = cgroupslib.OpenPath(cgroup)
 	_ = ed.Write("cpu.weight", strconv.FormatUint(cpuWeight, 10))
 
+	// write cpu bandwidth cgroup file, if set
+	if quota, period := command.Resources.LinuxResources.CPUQuota, command.Resources.LinuxResources.CPUPeriod; quota > 0 && period > 0 {
+		_ = ed.Write("cpu.max", fmt.Sprintf("%d %d", quota, period))
+	}
+
 	// write cpuset cgroup file, if set
 	cpusetCpus := command.Resources.LinuxResources.CpusetCpus
 	_ = ed.Write("cpuset.cpus", cpusetCpus)
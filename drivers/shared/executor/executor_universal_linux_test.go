@@ -6,12 +6,14 @@
 package executor
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
@@ -162,3 +164,36 @@ func TestUniversalExecutor_cg1_no_executor_pid(t *testing.T) {
 		must.Eq(t, pids[0], strconv.Itoa(p.Pid))
 	}
 }
+
+func TestUniversalExecutor_cg2_stats(t *testing.T) {
+	testutil.CgroupsCompatibleV2(t)
+	ci.Parallel(t)
+
+	factory := universalFactory
+	testExecCmd := testExecutorCommand(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "sleep"
+	execCmd.Args = []string{"infinity"}
+
+	factory.configureExecCmd(t, execCmd)
+	defer allocDir.Destroy()
+	executor := factory.new(testlog.HCLogger(t), compute)
+	defer executor.Shutdown("", 0)
+
+	_, err := executor.Launch(execCmd)
+	must.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := executor.Stats(ctx, time.Second)
+	must.NoError(t, err)
+
+	select {
+	case ru := <-ch:
+		must.SliceContainsAll(t, ru.ResourceUsage.MemoryStats.Measured, ExecutorCgroupV2MeasuredMemStats)
+		must.SliceContainsAll(t, ru.ResourceUsage.CpuStats.Measured, []string{"Throttled Periods", "Throttled Time"})
+		must.Positive(t, ru.ResourceUsage.MemoryStats.Usage)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for stats")
+	}
+}
@@ -35,6 +35,10 @@ func (e *UniversalExecutor) configureResourceContainer(_ *ExecCommand, _ int) (f
 	return running, cleanup, nil
 }
 
+func (e *UniversalExecutor) updateResourceContainer(_ *drivers.Resources) error {
+	return nil
+}
+
 func (e *UniversalExecutor) start(command *ExecCommand) error {
 	return e.childCmd.Start()
 }
@@ -111,6 +115,8 @@ func (e *UniversalExecutor) setSubCmdCgroup(*exec.Cmd, string) (func(), error) {
 	return func() {}, nil
 }
 
+func (e *UniversalExecutor) addCgroupStats(*drivers.ResourceUsage) {}
+
 // configure new process group for child process and creates a JobObject for the
 // executor. Children of the executor will be created in the same JobObject
 // Ref: https://learn.microsoft.com/en-us/windows/win32/procthread/job-objects
@@ -35,28 +35,7 @@ type grpcExecutorClient struct {
 
 func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 	ctx := context.Background()
-	req := &proto.LaunchRequest{
-		Cmd:              cmd.Cmd,
-		Args:             cmd.Args,
-		Resources:        drivers.ResourcesToProto(cmd.Resources),
-		StdoutPath:       cmd.StdoutPath,
-		StderrPath:       cmd.StderrPath,
-		Env:              cmd.Env,
-		User:             cmd.User,
-		TaskDir:          cmd.TaskDir,
-		ResourceLimits:   cmd.ResourceLimits,
-		NoPivotRoot:      cmd.NoPivotRoot,
-		Mounts:           drivers.MountsToProto(cmd.Mounts),
-		Devices:          drivers.DevicesToProto(cmd.Devices),
-		NetworkIsolation: drivers.NetworkIsolationSpecToProto(cmd.NetworkIsolation),
-		DefaultPidMode:   cmd.ModePID,
-		DefaultIpcMode:   cmd.ModeIPC,
-		Capabilities:     cmd.Capabilities,
-		CgroupV2Override: cmd.OverrideCgroupV2,
-		CgroupV1Override: cmd.OverrideCgroupV1,
-		OomScoreAdj:      cmd.OOMScoreAdj,
-		WorkDir:          cmd.WorkDir,
-	}
+	req := launchRequestToProto(cmd)
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
 		return nil, err
@@ -69,6 +48,32 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 	return ps, nil
 }
 
+func (c *grpcExecutorClient) Restore(cmd *ExecCommand, dir string) (*ProcessState, error) {
+	ctx := context.Background()
+	req := &proto.RestoreRequest{
+		Launch: launchRequestToProto(cmd),
+		Dir:    dir,
+	}
+	resp, err := c.client.Restore(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return processStateFromProto(resp.Process)
+}
+
+func (c *grpcExecutorClient) Checkpoint(dir string, leaveRunning bool) error {
+	ctx := context.Background()
+	req := &proto.CheckpointRequest{
+		Dir:          dir,
+		LeaveRunning: leaveRunning,
+	}
+	if _, err := c.client.Checkpoint(ctx, req); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *grpcExecutorClient) Wait(ctx context.Context) (*ProcessState, error) {
 	// Join the passed context and the shutdown context
 	ctx, _ = joincontext.Join(ctx, c.doneCtx)
@@ -24,28 +24,7 @@ type grpcExecutorServer struct {
 }
 
 func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchRequest) (*proto.LaunchResponse, error) {
-	ps, err := s.impl.Launch(&ExecCommand{
-		Cmd:              req.Cmd,
-		Args:             req.Args,
-		Resources:        drivers.ResourcesFromProto(req.Resources),
-		StdoutPath:       req.StdoutPath,
-		StderrPath:       req.StderrPath,
-		Env:              req.Env,
-		User:             req.User,
-		TaskDir:          req.TaskDir,
-		ResourceLimits:   req.ResourceLimits,
-		NoPivotRoot:      req.NoPivotRoot,
-		Mounts:           drivers.MountsFromProto(req.Mounts),
-		Devices:          drivers.DevicesFromProto(req.Devices),
-		NetworkIsolation: drivers.NetworkIsolationSpecFromProto(req.NetworkIsolation),
-		ModePID:          req.DefaultPidMode,
-		ModeIPC:          req.DefaultIpcMode,
-		Capabilities:     req.Capabilities,
-		OverrideCgroupV2: req.CgroupV2Override,
-		OverrideCgroupV1: req.CgroupV1Override,
-		OOMScoreAdj:      req.OomScoreAdj,
-		WorkDir:          req.WorkDir,
-	})
+	ps, err := s.impl.Launch(launchRequestFromProto(req))
 
 	if err != nil {
 		return nil, err
@@ -61,6 +40,30 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 	}, nil
 }
 
+func (s *grpcExecutorServer) Restore(ctx context.Context, req *proto.RestoreRequest) (*proto.RestoreResponse, error) {
+	ps, err := s.impl.Restore(launchRequestFromProto(req.Launch), req.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	process, err := processStateToProto(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RestoreResponse{
+		Process: process,
+	}, nil
+}
+
+func (s *grpcExecutorServer) Checkpoint(ctx context.Context, req *proto.CheckpointRequest) (*proto.CheckpointResponse, error) {
+	if err := s.impl.Checkpoint(req.Dir, req.LeaveRunning); err != nil {
+		return nil, err
+	}
+
+	return &proto.CheckpointResponse{}, nil
+}
+
 func (s *grpcExecutorServer) Wait(ctx context.Context, req *proto.WaitRequest) (*proto.WaitResponse, error) {
 	ps, err := s.impl.Wait(ctx)
 	if err != nil {
@@ -50,6 +50,9 @@ type LaunchRequest struct {
 	CgroupV1Override     map[string]string            `protobuf:"bytes,21,rep,name=cgroup_v1_override,json=cgroupV1Override,proto3" json:"cgroup_v1_override,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	OomScoreAdj          int32                        `protobuf:"varint,22,opt,name=oom_score_adj,json=oomScoreAdj,proto3" json:"oom_score_adj,omitempty"`
 	WorkDir              string                       `protobuf:"bytes,23,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	DefaultUserMode      string                       `protobuf:"bytes,24,opt,name=default_user_mode,json=defaultUserMode,proto3" json:"default_user_mode,omitempty"`
+	UidMappings          []*IDMapping                 `protobuf:"bytes,25,rep,name=uid_mappings,json=uidMappings,proto3" json:"uid_mappings,omitempty"`
+	GidMappings          []*IDMapping                 `protobuf:"bytes,26,rep,name=gid_mappings,json=gidMappings,proto3" json:"gid_mappings,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -242,6 +245,27 @@ func (m *LaunchRequest) GetWorkDir() string {
 	return ""
 }
 
+func (m *LaunchRequest) GetDefaultUserMode() string {
+	if m != nil {
+		return m.DefaultUserMode
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetUidMappings() []*IDMapping {
+	if m != nil {
+		return m.UidMappings
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetGidMappings() []*IDMapping {
+	if m != nil {
+		return m.GidMappings
+	}
+	return nil
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -890,6 +914,227 @@ func (m *ProcessState) GetOomKilled() bool {
 	return false
 }
 
+// IDMapping maps a range of IDs of the user namespace of a task to a range of
+// IDs of the host
+type IDMapping struct {
+	ContainerId          uint32   `protobuf:"varint,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	HostId               uint32   `protobuf:"varint,2,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	Size                 uint32   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IDMapping) Reset()         { *m = IDMapping{} }
+func (m *IDMapping) String() string { return proto.CompactTextString(m) }
+func (*IDMapping) ProtoMessage()    {}
+func (*IDMapping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{17}
+}
+
+func (m *IDMapping) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IDMapping.Unmarshal(m, b)
+}
+func (m *IDMapping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IDMapping.Marshal(b, m, deterministic)
+}
+func (m *IDMapping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IDMapping.Merge(m, src)
+}
+func (m *IDMapping) XXX_Size() int {
+	return xxx_messageInfo_IDMapping.Size(m)
+}
+func (m *IDMapping) XXX_DiscardUnknown() {
+	xxx_messageInfo_IDMapping.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IDMapping proto.InternalMessageInfo
+
+func (m *IDMapping) GetContainerId() uint32 {
+	if m != nil {
+		return m.ContainerId
+	}
+	return 0
+}
+
+func (m *IDMapping) GetHostId() uint32 {
+	if m != nil {
+		return m.HostId
+	}
+	return 0
+}
+
+func (m *IDMapping) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type CheckpointRequest struct {
+	Dir                  string   `protobuf:"bytes,1,opt,name=dir,proto3" json:"dir,omitempty"`
+	LeaveRunning         bool     `protobuf:"varint,2,opt,name=leave_running,json=leaveRunning,proto3" json:"leave_running,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
+func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointRequest) ProtoMessage()    {}
+func (*CheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{18}
+}
+
+func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
+}
+func (m *CheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointRequest.Merge(m, src)
+}
+func (m *CheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointRequest.Size(m)
+}
+func (m *CheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointRequest proto.InternalMessageInfo
+
+func (m *CheckpointRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+func (m *CheckpointRequest) GetLeaveRunning() bool {
+	if m != nil {
+		return m.LeaveRunning
+	}
+	return false
+}
+
+type CheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
+func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointResponse) ProtoMessage()    {}
+func (*CheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{19}
+}
+
+func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
+}
+func (m *CheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointResponse.Merge(m, src)
+}
+func (m *CheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointResponse.Size(m)
+}
+func (m *CheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
+
+type RestoreRequest struct {
+	Launch               *LaunchRequest `protobuf:"bytes,1,opt,name=launch,proto3" json:"launch,omitempty"`
+	Dir                  string         `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{20}
+}
+
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+}
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(m, src)
+}
+func (m *RestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRequest.Size(m)
+}
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
+
+func (m *RestoreRequest) GetLaunch() *LaunchRequest {
+	if m != nil {
+		return m.Launch
+	}
+	return nil
+}
+
+func (m *RestoreRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type RestoreResponse struct {
+	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RestoreResponse) Reset()         { *m = RestoreResponse{} }
+func (m *RestoreResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreResponse) ProtoMessage()    {}
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{21}
+}
+
+func (m *RestoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreResponse.Unmarshal(m, b)
+}
+func (m *RestoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreResponse.Merge(m, src)
+}
+func (m *RestoreResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreResponse.Size(m)
+}
+func (m *RestoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreResponse proto.InternalMessageInfo
+
+func (m *RestoreResponse) GetProcess() *ProcessState {
+	if m != nil {
+		return m.Process
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest.CgroupV1OverrideEntry")
@@ -909,6 +1154,11 @@ func init() {
 	proto.RegisterType((*ExecRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ExecRequest")
 	proto.RegisterType((*ExecResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ExecResponse")
 	proto.RegisterType((*ProcessState)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessState")
+	proto.RegisterType((*IDMapping)(nil), "hashicorp.nomad.plugins.executor.proto.IDMapping")
+	proto.RegisterType((*CheckpointRequest)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointRequest")
+	proto.RegisterType((*CheckpointResponse)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointResponse")
+	proto.RegisterType((*RestoreRequest)(nil), "hashicorp.nomad.plugins.executor.proto.RestoreRequest")
+	proto.RegisterType((*RestoreResponse)(nil), "hashicorp.nomad.plugins.executor.proto.RestoreResponse")
 }
 
 func init() {
@@ -916,83 +1166,97 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1205 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xfb, 0x6e, 0x1b, 0xc5,
-	0x17, 0xee, 0xc6, 0x71, 0x6c, 0x1f, 0xdb, 0x89, 0x3b, 0xbf, 0x36, 0xdd, 0xfa, 0x27, 0xd4, 0xb0,
-	0x48, 0xd4, 0x82, 0xb2, 0x69, 0xd3, 0xab, 0x40, 0xa2, 0xd0, 0xa4, 0xa0, 0xaa, 0x17, 0xa2, 0x4d,
-	0x69, 0x25, 0xfe, 0x60, 0x99, 0xee, 0x4e, 0xed, 0xa9, 0xd7, 0x3b, 0xcb, 0xcc, 0xac, 0x9b, 0x48,
-	0x48, 0xbc, 0x04, 0x48, 0x3c, 0x00, 0xaf, 0xc7, 0x3b, 0xa0, 0xb9, 0x6d, 0xec, 0xb4, 0x54, 0x9b,
-	0x22, 0xfe, 0xca, 0x9e, 0xcf, 0xdf, 0xb9, 0xcc, 0x39, 0x73, 0xbe, 0x09, 0x5c, 0x49, 0x39, 0x9d,
-	0x13, 0x2e, 0xb6, 0xc5, 0x04, 0x73, 0x92, 0x6e, 0x93, 0x43, 0x92, 0x94, 0x92, 0xf1, 0xed, 0x82,
-	0x33, 0xc9, 0x2a, 0x33, 0xd4, 0x26, 0xfa, 0x78, 0x82, 0xc5, 0x84, 0x26, 0x8c, 0x17, 0x61, 0xce,
-	0x66, 0x38, 0x0d, 0x8b, 0xac, 0x1c, 0xd3, 0x5c, 0x84, 0xcb, 0xbc, 0xe1, 0xa5, 0x31, 0x63, 0xe3,
-	0x8c, 0x98, 0x20, 0x2f, 0xca, 0x97, 0xdb, 0x92, 0xce, 0x88, 0x90, 0x78, 0x56, 0x58, 0x42, 0x60,
-	0x1d, 0xb7, 0x5d, 0x7a, 0x93, 0xce, 0x58, 0x86, 0x13, 0xfc, 0xd5, 0x86, 0xfe, 0x23, 0x5c, 0xe6,
-	0xc9, 0x24, 0x22, 0x3f, 0x97, 0x44, 0x48, 0x34, 0x80, 0x46, 0x32, 0x4b, 0x7d, 0x6f, 0xcb, 0x1b,
-	0x75, 0x22, 0xf5, 0x89, 0x10, 0xac, 0x62, 0x3e, 0x16, 0xfe, 0xca, 0x56, 0x63, 0xd4, 0x89, 0xf4,
-	0x37, 0x7a, 0x02, 0x1d, 0x4e, 0x04, 0x2b, 0x79, 0x42, 0x84, 0xdf, 0xd8, 0xf2, 0x46, 0xdd, 0x9d,
-	0xab, 0xe1, 0x3f, 0x15, 0x6e, 0xf3, 0x9b, 0x94, 0x61, 0xe4, 0xfc, 0xa2, 0xe3, 0x10, 0xe8, 0x12,
-	0x74, 0x85, 0x4c, 0x59, 0x29, 0xe3, 0x02, 0xcb, 0x89, 0xbf, 0xaa, 0xb3, 0x83, 0x81, 0xf6, 0xb1,
-	0x9c, 0x58, 0x02, 0xe1, 0xdc, 0x10, 0x9a, 0x15, 0x81, 0x70, 0xae, 0x09, 0x03, 0x68, 0x90, 0x7c,
-	0xee, 0xaf, 0xe9, 0x22, 0xd5, 0xa7, 0xaa, 0xbb, 0x14, 0x84, 0xfb, 0x2d, 0xcd, 0xd5, 0xdf, 0xe8,
-	0x22, 0xb4, 0x25, 0x16, 0xd3, 0x38, 0xa5, 0xdc, 0x6f, 0x6b, 0xbc, 0xa5, 0xec, 0x3d, 0xca, 0xd1,
-	0x65, 0xd8, 0x70, 0xf5, 0xc4, 0x19, 0x9d, 0x51, 0x29, 0xfc, 0xce, 0x96, 0x37, 0x6a, 0x47, 0xeb,
-	0x0e, 0x7e, 0xa4, 0x51, 0x74, 0x03, 0xce, 0xbd, 0xc0, 0x82, 0x26, 0x71, 0xc1, 0x59, 0x42, 0x84,
-	0x88, 0x93, 0x31, 0x67, 0x65, 0xe1, 0x83, 0x62, 0xdf, 0x5b, 0xf1, 0xbd, 0x08, 0xe9, 0xdf, 0xf7,
-	0xcd, 0xcf, 0xbb, 0xfa, 0x57, 0xb4, 0x07, 0x6b, 0x33, 0x56, 0xe6, 0x52, 0xf8, 0xdd, 0xad, 0xc6,
-	0xa8, 0xbb, 0x73, 0xa5, 0x66, 0xbb, 0x1e, 0x2b, 0xa7, 0xc8, 0xfa, 0xa2, 0x6f, 0xa1, 0x95, 0x92,
-	0x39, 0x55, 0x5d, 0xef, 0xe9, 0x30, 0x9f, 0xd5, 0x0c, 0xb3, 0xa7, 0xbd, 0x22, 0xe7, 0x8d, 0x26,
-	0x70, 0x36, 0x27, 0xf2, 0x35, 0xe3, 0xd3, 0x98, 0x0a, 0x96, 0x61, 0x49, 0x59, 0xee, 0xf7, 0xf5,
-	0x20, 0xbf, 0xa8, 0x19, 0xf2, 0x89, 0xf1, 0x7f, 0xe0, 0xdc, 0x0f, 0x0a, 0x92, 0x44, 0x83, 0xfc,
-	0x04, 0x8a, 0x02, 0xe8, 0xe7, 0x2c, 0x2e, 0xe8, 0x9c, 0xc9, 0x98, 0x33, 0x26, 0xfd, 0x75, 0xdd,
-	0xd5, 0x6e, 0xce, 0xf6, 0x15, 0x16, 0x31, 0x26, 0xd1, 0x08, 0x06, 0x29, 0x79, 0x89, 0xcb, 0x4c,
-	0xc6, 0x05, 0x4d, 0xe3, 0x19, 0x4b, 0x89, 0xbf, 0xa1, 0xc7, 0xb3, 0x6e, 0xf1, 0x7d, 0x9a, 0x3e,
-	0x66, 0x29, 0x59, 0x64, 0xd2, 0x22, 0x31, 0xcc, 0xc1, 0x12, 0xf3, 0x41, 0x91, 0x68, 0xe6, 0x47,
-	0xd0, 0x4f, 0x8a, 0x52, 0x10, 0xe9, 0xe6, 0x73, 0x56, 0xd3, 0x7a, 0x06, 0xb4, 0x53, 0xf9, 0x00,
-	0x00, 0x67, 0x19, 0x7b, 0x1d, 0x27, 0xb8, 0x10, 0x3e, 0xd2, 0x97, 0xa7, 0xa3, 0x91, 0x5d, 0x5c,
-	0x08, 0x14, 0x40, 0x2f, 0xc1, 0x05, 0x7e, 0x41, 0x33, 0x2a, 0x29, 0x11, 0xfe, 0xff, 0x34, 0x61,
-	0x09, 0x43, 0x57, 0x00, 0x99, 0x04, 0xf1, 0x7c, 0x27, 0x66, 0x73, 0xc2, 0x39, 0x4d, 0x89, 0x7f,
-	0x4e, 0x27, 0x1b, 0x98, 0x5f, 0x9e, 0xed, 0x7c, 0x67, 0x71, 0x74, 0x74, 0xcc, 0xbe, 0x76, 0xcc,
-	0x3e, 0xaf, 0x67, 0xf9, 0x30, 0xac, 0xb7, 0xfa, 0xe1, 0xd2, 0xc6, 0x86, 0xe6, 0x28, 0xcf, 0xae,
-	0xb9, 0x1c, 0xf7, 0x73, 0xc9, 0x8f, 0xaa, 0xd4, 0x15, 0xac, 0x06, 0xc1, 0xd8, 0x2c, 0x16, 0x09,
-	0xe3, 0x24, 0xc6, 0xe9, 0x2b, 0x7f, 0x73, 0xcb, 0x1b, 0x35, 0xa3, 0x2e, 0x63, 0xb3, 0x03, 0x85,
-	0x7d, 0x9d, 0xbe, 0x52, 0xfb, 0xa1, 0xef, 0x84, 0xda, 0x8f, 0x0b, 0x66, 0x3f, 0x94, 0xbd, 0x47,
-	0xf9, 0x70, 0x17, 0xce, 0xbf, 0x35, 0x93, 0xda, 0xbc, 0x29, 0x39, 0x72, 0x8a, 0x31, 0x25, 0x47,
-	0xe8, 0x1c, 0x34, 0xe7, 0x38, 0x2b, 0x89, 0xbf, 0xa2, 0x31, 0x63, 0x7c, 0xbe, 0x72, 0xc7, 0x0b,
-	0x7e, 0x82, 0x75, 0x57, 0xbc, 0x28, 0x58, 0x2e, 0x08, 0x7a, 0x02, 0x2d, 0xbb, 0x47, 0x3a, 0x42,
-	0x77, 0xe7, 0x46, 0xdd, 0x2e, 0xd8, 0xfd, 0x3a, 0x90, 0x58, 0x92, 0xc8, 0x05, 0x09, 0xfa, 0xd0,
-	0x7d, 0x8e, 0xa9, 0xb4, 0xcd, 0x09, 0x7e, 0x84, 0x9e, 0x31, 0xff, 0xa3, 0x74, 0x8f, 0x60, 0xe3,
-	0x60, 0x52, 0xca, 0x94, 0xbd, 0xce, 0x9d, 0x82, 0x6e, 0xc2, 0x9a, 0xa0, 0xe3, 0x1c, 0x67, 0xb6,
-	0x25, 0xd6, 0x42, 0x1f, 0x42, 0x6f, 0xcc, 0x71, 0x42, 0xe2, 0x82, 0x70, 0xca, 0x52, 0xdd, 0x9c,
-	0x46, 0xd4, 0xd5, 0xd8, 0xbe, 0x86, 0x02, 0x04, 0x83, 0xe3, 0x68, 0xa6, 0xe2, 0x60, 0x02, 0x9b,
-	0xdf, 0x17, 0xa9, 0x4a, 0x5a, 0x09, 0xa7, 0x4d, 0xb4, 0x24, 0xc2, 0xde, 0xbf, 0x16, 0xe1, 0xe0,
-	0x22, 0x5c, 0x78, 0x23, 0x93, 0x2d, 0x62, 0x00, 0xeb, 0xcf, 0x08, 0x17, 0x94, 0xb9, 0x53, 0x06,
-	0x9f, 0xc2, 0x46, 0x85, 0xd8, 0xde, 0xfa, 0xd0, 0x9a, 0x1b, 0xc8, 0x9e, 0xdc, 0x99, 0xc1, 0x27,
-	0xd0, 0x53, 0x7d, 0xab, 0x2a, 0x1f, 0x42, 0x9b, 0xe6, 0x92, 0xf0, 0xb9, 0x6d, 0x52, 0x23, 0xaa,
-	0xec, 0xe0, 0x39, 0xf4, 0x2d, 0xd7, 0x86, 0xfd, 0x06, 0x9a, 0x42, 0x01, 0xa7, 0x3c, 0xe2, 0x53,
-	0x2c, 0xa6, 0x26, 0x90, 0x71, 0x0f, 0x2e, 0x43, 0xff, 0x40, 0x4f, 0xe2, 0xed, 0x83, 0x6a, 0xba,
-	0x41, 0xa9, 0xc3, 0x3a, 0xa2, 0x3d, 0xfe, 0x14, 0xba, 0xf7, 0x0f, 0x49, 0xe2, 0x1c, 0x6f, 0x41,
-	0x3b, 0x25, 0x38, 0xcd, 0x68, 0x4e, 0x6c, 0x51, 0xc3, 0xd0, 0xbc, 0xc6, 0xa1, 0x7b, 0x8d, 0xc3,
-	0xa7, 0xee, 0x35, 0x8e, 0x2a, 0xae, 0x7b, 0x5b, 0x57, 0xde, 0x7c, 0x5b, 0x1b, 0xc7, 0x6f, 0x6b,
-	0xb0, 0x0b, 0x3d, 0x93, 0xcc, 0x9e, 0x7f, 0x13, 0xd6, 0x58, 0x29, 0x8b, 0x52, 0xea, 0x5c, 0xbd,
-	0xc8, 0x5a, 0xe8, 0xff, 0xd0, 0x21, 0x87, 0x54, 0xc6, 0x89, 0xd2, 0xc0, 0x15, 0x7d, 0x82, 0xb6,
-	0x02, 0x76, 0x59, 0x4a, 0x82, 0x3f, 0x3d, 0xe8, 0x2d, 0xde, 0x58, 0x95, 0xbb, 0xa0, 0xa9, 0x3d,
-	0xa9, 0xfa, 0x7c, 0xa7, 0xff, 0x42, 0x6f, 0x1a, 0x8b, 0xbd, 0x41, 0x21, 0xac, 0xaa, 0xff, 0x33,
-	0xf4, 0x0b, 0xfd, 0xee, 0x63, 0x6b, 0x9e, 0x12, 0x58, 0x25, 0x3a, 0x53, 0x9a, 0x65, 0x24, 0xd5,
-	0xcf, 0x76, 0x3b, 0xea, 0x30, 0x36, 0x7b, 0xa8, 0x81, 0x9d, 0xdf, 0x3b, 0xd0, 0xbe, 0x6f, 0xf7,
-	0x0c, 0x1d, 0xc1, 0x9a, 0x11, 0x07, 0x74, 0xf3, 0xbd, 0x94, 0x70, 0x78, 0xeb, 0xb4, 0x6e, 0x76,
-	0xbc, 0x67, 0x90, 0x80, 0x55, 0x25, 0x13, 0xe8, 0x7a, 0xdd, 0x08, 0x0b, 0x1a, 0x33, 0xbc, 0x71,
-	0x3a, 0xa7, 0x2a, 0xe9, 0xaf, 0xd0, 0x76, 0xdb, 0x8e, 0x6e, 0xd7, 0x8d, 0x71, 0x42, 0x6d, 0x86,
-	0x77, 0x4e, 0xef, 0x58, 0x15, 0xf0, 0x9b, 0x07, 0x1b, 0x27, 0x36, 0x1e, 0x7d, 0x59, 0x37, 0xde,
-	0xdb, 0x45, 0x69, 0x78, 0xf7, 0xbd, 0xfd, 0xab, 0xb2, 0x7e, 0x81, 0x96, 0x95, 0x16, 0x54, 0x7b,
-	0xa2, 0xcb, 0xea, 0x34, 0xbc, 0x7d, 0x6a, 0xbf, 0x2a, 0xfb, 0x21, 0x34, 0xb5, 0x6c, 0xa0, 0xda,
-	0x63, 0x5d, 0x94, 0xb6, 0xe1, 0xcd, 0x53, 0x7a, 0xb9, 0xbc, 0x57, 0x3d, 0x75, 0xff, 0x8d, 0xee,
-	0xd4, 0xbf, 0xff, 0x4b, 0x82, 0x56, 0xff, 0xfe, 0x9f, 0x90, 0x37, 0x7d, 0xff, 0xd5, 0x1a, 0xd6,
-	0xbf, 0xff, 0x0b, 0x72, 0x58, 0xff, 0xfe, 0x2f, 0xca, 0x5a, 0x70, 0x06, 0xfd, 0xe1, 0x41, 0x5f,
-	0x41, 0x07, 0x92, 0x13, 0x3c, 0xa3, 0xf9, 0x18, 0xdd, 0xad, 0xa9, 0xed, 0xca, 0xcb, 0xe8, 0xbb,
-	0xf5, 0x74, 0xa5, 0x7c, 0xf5, 0xfe, 0x01, 0x5c, 0x59, 0x23, 0xef, 0xaa, 0x77, 0xaf, 0xf5, 0x43,
-	0xd3, 0x48, 0xda, 0x9a, 0xfe, 0x73, 0xfd, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5b, 0xc0, 0xa1,
-	0x7e, 0xc4, 0x0d, 0x00, 0x00,
+	// 1426 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5b, 0x6f, 0xdb, 0x46,
+	0x16, 0x5e, 0x49, 0xb6, 0x2e, 0x47, 0x92, 0x2d, 0xcf, 0x3a, 0x0e, 0xc3, 0xc5, 0x22, 0x5e, 0x2e,
+	0xb0, 0x11, 0xb2, 0xa9, 0x9c, 0x38, 0xce, 0xa5, 0x29, 0xd0, 0xb4, 0xb1, 0xd3, 0xc2, 0x4d, 0x9c,
+	0x1a, 0x74, 0x2e, 0x40, 0x0b, 0x94, 0x9d, 0x90, 0x13, 0x69, 0x22, 0x8a, 0xc3, 0xcc, 0x0c, 0x15,
+	0xbb, 0x28, 0xd0, 0xa7, 0xfe, 0x83, 0x3e, 0x14, 0xe8, 0x6b, 0xff, 0x4a, 0xff, 0x57, 0x31, 0x17,
+	0xd2, 0x52, 0x92, 0xb6, 0x94, 0x8b, 0x3c, 0x91, 0xf3, 0xcd, 0xf9, 0xce, 0x75, 0xe6, 0x9c, 0x81,
+	0x2b, 0x11, 0xa7, 0x53, 0xc2, 0xc5, 0x96, 0x18, 0x61, 0x4e, 0xa2, 0x2d, 0x72, 0x4c, 0xc2, 0x4c,
+	0x32, 0xbe, 0x95, 0x72, 0x26, 0x59, 0xb1, 0x1c, 0xe8, 0x25, 0xfa, 0xdf, 0x08, 0x8b, 0x11, 0x0d,
+	0x19, 0x4f, 0x07, 0x09, 0x9b, 0xe0, 0x68, 0x90, 0xc6, 0xd9, 0x90, 0x26, 0x62, 0x30, 0x2f, 0xe7,
+	0x5e, 0x1c, 0x32, 0x36, 0x8c, 0x89, 0x51, 0xf2, 0x3c, 0x7b, 0xb1, 0x25, 0xe9, 0x84, 0x08, 0x89,
+	0x27, 0xa9, 0x15, 0xf0, 0x2c, 0x71, 0x2b, 0x37, 0x6f, 0xcc, 0x99, 0x95, 0x91, 0xf1, 0x7e, 0x03,
+	0xe8, 0x3e, 0xc4, 0x59, 0x12, 0x8e, 0x7c, 0xf2, 0x2a, 0x23, 0x42, 0xa2, 0x1e, 0xd4, 0xc2, 0x49,
+	0xe4, 0x54, 0x36, 0x2b, 0xfd, 0x96, 0xaf, 0x7e, 0x11, 0x82, 0x25, 0xcc, 0x87, 0xc2, 0xa9, 0x6e,
+	0xd6, 0xfa, 0x2d, 0x5f, 0xff, 0xa3, 0x47, 0xd0, 0xe2, 0x44, 0xb0, 0x8c, 0x87, 0x44, 0x38, 0xb5,
+	0xcd, 0x4a, 0xbf, 0xbd, 0x7d, 0x75, 0xf0, 0x47, 0x8e, 0x5b, 0xfb, 0xc6, 0xe4, 0xc0, 0xcf, 0x79,
+	0xfe, 0xa9, 0x0a, 0x74, 0x11, 0xda, 0x42, 0x46, 0x2c, 0x93, 0x41, 0x8a, 0xe5, 0xc8, 0x59, 0xd2,
+	0xd6, 0xc1, 0x40, 0x87, 0x58, 0x8e, 0xac, 0x00, 0xe1, 0xdc, 0x08, 0x2c, 0x17, 0x02, 0x84, 0x73,
+	0x2d, 0xd0, 0x83, 0x1a, 0x49, 0xa6, 0x4e, 0x5d, 0x3b, 0xa9, 0x7e, 0x95, 0xdf, 0x99, 0x20, 0xdc,
+	0x69, 0x68, 0x59, 0xfd, 0x8f, 0x2e, 0x40, 0x53, 0x62, 0x31, 0x0e, 0x22, 0xca, 0x9d, 0xa6, 0xc6,
+	0x1b, 0x6a, 0xbd, 0x47, 0x39, 0xba, 0x04, 0xab, 0xb9, 0x3f, 0x41, 0x4c, 0x27, 0x54, 0x0a, 0xa7,
+	0xb5, 0x59, 0xe9, 0x37, 0xfd, 0x95, 0x1c, 0x7e, 0xa8, 0x51, 0xb4, 0x03, 0xeb, 0xcf, 0xb1, 0xa0,
+	0x61, 0x90, 0x72, 0x16, 0x12, 0x21, 0x82, 0x70, 0xc8, 0x59, 0x96, 0x3a, 0xa0, 0xa4, 0xef, 0x55,
+	0x9d, 0x8a, 0x8f, 0xf4, 0xfe, 0xa1, 0xd9, 0xde, 0xd5, 0xbb, 0x68, 0x0f, 0xea, 0x13, 0x96, 0x25,
+	0x52, 0x38, 0xed, 0xcd, 0x5a, 0xbf, 0xbd, 0x7d, 0xa5, 0x64, 0xba, 0x0e, 0x14, 0xc9, 0xb7, 0x5c,
+	0xf4, 0x39, 0x34, 0x22, 0x32, 0xa5, 0x2a, 0xeb, 0x1d, 0xad, 0xe6, 0x83, 0x92, 0x6a, 0xf6, 0x34,
+	0xcb, 0xcf, 0xd9, 0x68, 0x04, 0x6b, 0x09, 0x91, 0xaf, 0x19, 0x1f, 0x07, 0x54, 0xb0, 0x18, 0x4b,
+	0xca, 0x12, 0xa7, 0xab, 0x0b, 0xf9, 0x51, 0x49, 0x95, 0x8f, 0x0c, 0x7f, 0x3f, 0xa7, 0x1f, 0xa5,
+	0x24, 0xf4, 0x7b, 0xc9, 0x1b, 0x28, 0xf2, 0xa0, 0x9b, 0xb0, 0x20, 0xa5, 0x53, 0x26, 0x03, 0xce,
+	0x98, 0x74, 0x56, 0x74, 0x56, 0xdb, 0x09, 0x3b, 0x54, 0x98, 0xcf, 0x98, 0x44, 0x7d, 0xe8, 0x45,
+	0xe4, 0x05, 0xce, 0x62, 0x19, 0xa4, 0x34, 0x0a, 0x26, 0x2c, 0x22, 0xce, 0xaa, 0x2e, 0xcf, 0x8a,
+	0xc5, 0x0f, 0x69, 0x74, 0xc0, 0x22, 0x32, 0x2b, 0x49, 0xd3, 0xd0, 0x48, 0xf6, 0xe6, 0x24, 0xf7,
+	0xd3, 0x50, 0x4b, 0xfe, 0x17, 0xba, 0x61, 0x9a, 0x09, 0x22, 0xf3, 0xfa, 0xac, 0x69, 0xb1, 0x8e,
+	0x01, 0x6d, 0x55, 0xfe, 0x0d, 0x80, 0xe3, 0x98, 0xbd, 0x0e, 0x42, 0x9c, 0x0a, 0x07, 0xe9, 0xc3,
+	0xd3, 0xd2, 0xc8, 0x2e, 0x4e, 0x05, 0xf2, 0xa0, 0x13, 0xe2, 0x14, 0x3f, 0xa7, 0x31, 0x95, 0x94,
+	0x08, 0xe7, 0x9f, 0x5a, 0x60, 0x0e, 0x43, 0x57, 0x00, 0x19, 0x03, 0xc1, 0x74, 0x3b, 0x60, 0x53,
+	0xc2, 0x39, 0x8d, 0x88, 0xb3, 0xae, 0x8d, 0xf5, 0xcc, 0xce, 0xd3, 0xed, 0x2f, 0x2d, 0x8e, 0x4e,
+	0x4e, 0xa5, 0xaf, 0x9d, 0x4a, 0x9f, 0xd3, 0xb5, 0x7c, 0x30, 0x28, 0x77, 0xf5, 0x07, 0x73, 0x37,
+	0x76, 0x60, 0x42, 0x79, 0x7a, 0x2d, 0xb7, 0x71, 0x3f, 0x91, 0xfc, 0xa4, 0x30, 0x5d, 0xc0, 0xaa,
+	0x10, 0x8c, 0x4d, 0x02, 0x11, 0x32, 0x4e, 0x02, 0x1c, 0xbd, 0x74, 0x36, 0x36, 0x2b, 0xfd, 0x65,
+	0xbf, 0xcd, 0xd8, 0xe4, 0x48, 0x61, 0x9f, 0x46, 0x2f, 0xd5, 0xfd, 0xd0, 0x67, 0x42, 0xdd, 0x8f,
+	0xf3, 0xe6, 0x7e, 0xa8, 0xb5, 0xba, 0x1f, 0x97, 0x61, 0x2d, 0xcf, 0xbc, 0xba, 0x4a, 0x26, 0xf5,
+	0x8e, 0x96, 0x59, 0xb5, 0x1b, 0x4f, 0x04, 0xe1, 0x3a, 0xf7, 0x8f, 0xa1, 0x93, 0xa9, 0x3a, 0xe2,
+	0x34, 0xa5, 0xc9, 0x50, 0x38, 0x17, 0x74, 0x7c, 0xd7, 0xca, 0xc6, 0xb7, 0xbf, 0x77, 0x60, 0x98,
+	0x7e, 0x3b, 0xa3, 0x91, 0xfd, 0x17, 0x4a, 0xeb, 0x70, 0x56, 0xab, 0x7b, 0x66, 0xad, 0xc3, 0x53,
+	0xad, 0xee, 0x2e, 0x9c, 0x7b, 0x67, 0x06, 0x55, 0x47, 0x19, 0x93, 0x93, 0xbc, 0x13, 0x8e, 0xc9,
+	0x09, 0x5a, 0x87, 0xe5, 0x29, 0x8e, 0x33, 0xe2, 0x54, 0x35, 0x66, 0x16, 0x77, 0xaa, 0xb7, 0x2b,
+	0xde, 0xb7, 0xb0, 0x92, 0x17, 0x45, 0xa4, 0x2c, 0x11, 0x04, 0x3d, 0x82, 0x86, 0xed, 0x0f, 0x5a,
+	0x43, 0x7b, 0x7b, 0xa7, 0xac, 0x9f, 0xb6, 0x6f, 0x1c, 0x49, 0x2c, 0x89, 0x9f, 0x2b, 0xf1, 0xba,
+	0xd0, 0x7e, 0x86, 0xa9, 0xb4, 0x45, 0xf7, 0xbe, 0x81, 0x8e, 0x59, 0xbe, 0x27, 0x73, 0x0f, 0x61,
+	0xf5, 0x68, 0x94, 0xc9, 0x88, 0xbd, 0x4e, 0xf2, 0xc9, 0xb0, 0x01, 0x75, 0x41, 0x87, 0x09, 0x8e,
+	0x6d, 0x4a, 0xec, 0x0a, 0xfd, 0x07, 0x3a, 0x43, 0x8e, 0x43, 0x12, 0xa4, 0x84, 0x53, 0x16, 0xe9,
+	0xe4, 0xd4, 0xfc, 0xb6, 0xc6, 0x0e, 0x35, 0xe4, 0x21, 0xe8, 0x9d, 0x6a, 0x33, 0x1e, 0x7b, 0x23,
+	0xd8, 0x78, 0x92, 0x46, 0xca, 0x68, 0x31, 0x10, 0xac, 0xa1, 0xb9, 0xe1, 0x52, 0xf9, 0xdb, 0xc3,
+	0xc5, 0xbb, 0x00, 0xe7, 0xdf, 0xb2, 0x64, 0x9d, 0xe8, 0xc1, 0xca, 0x53, 0xc2, 0x05, 0x65, 0x79,
+	0x94, 0xde, 0xff, 0x61, 0xb5, 0x40, 0x6c, 0x6e, 0x1d, 0x68, 0x4c, 0x0d, 0x64, 0x23, 0xcf, 0x97,
+	0xde, 0x65, 0xe8, 0xa8, 0xbc, 0x15, 0x9e, 0xbb, 0xd0, 0xa4, 0x89, 0x24, 0x7c, 0x6a, 0x93, 0x54,
+	0xf3, 0x8b, 0xb5, 0xf7, 0x0c, 0xba, 0x56, 0xd6, 0xaa, 0xfd, 0x0c, 0x96, 0x85, 0x02, 0x16, 0x0c,
+	0xf1, 0x31, 0x16, 0x63, 0xa3, 0xc8, 0xd0, 0xbd, 0x4b, 0xd0, 0x3d, 0xd2, 0x95, 0x78, 0x77, 0xa1,
+	0x96, 0xf3, 0x42, 0xa9, 0x60, 0x73, 0x41, 0x1b, 0xfe, 0x18, 0xda, 0xf7, 0x8f, 0x49, 0x98, 0x13,
+	0x6f, 0x42, 0x33, 0x22, 0x38, 0x8a, 0x69, 0x42, 0xac, 0x53, 0xee, 0xc0, 0xbc, 0x32, 0x06, 0xf9,
+	0x2b, 0x63, 0xf0, 0x38, 0x7f, 0x65, 0xf8, 0x85, 0x6c, 0xfe, 0x66, 0xa8, 0xbe, 0xfd, 0x66, 0xa8,
+	0x9d, 0xbe, 0x19, 0xbc, 0x5d, 0xe8, 0x18, 0x63, 0x36, 0xfe, 0x0d, 0xa8, 0xb3, 0x4c, 0xa6, 0x99,
+	0xd4, 0xb6, 0x3a, 0xbe, 0x5d, 0xa1, 0x7f, 0x41, 0x8b, 0x1c, 0x53, 0x19, 0x84, 0xaa, 0xc1, 0x54,
+	0x75, 0x04, 0x4d, 0x05, 0xec, 0xb2, 0x88, 0x78, 0xbf, 0x56, 0xa0, 0x33, 0x7b, 0x62, 0x95, 0xed,
+	0x94, 0x46, 0x36, 0x52, 0xf5, 0xfb, 0xa7, 0xfc, 0x99, 0xdc, 0xd4, 0x66, 0x73, 0x83, 0x06, 0xb0,
+	0xa4, 0xde, 0x4f, 0xce, 0xd2, 0x5f, 0x86, 0xad, 0xe5, 0xd4, 0xe0, 0x50, 0xcd, 0x74, 0x4c, 0xe3,
+	0x98, 0x44, 0xfa, 0x39, 0xd2, 0xf4, 0x5b, 0x8c, 0x4d, 0x1e, 0x68, 0xc0, 0xfb, 0x1a, 0x5a, 0x45,
+	0xbb, 0x51, 0x17, 0x24, 0x64, 0x89, 0xc4, 0x34, 0x21, 0x3c, 0xb0, 0xbe, 0x76, 0xfd, 0x76, 0x81,
+	0xed, 0x47, 0xe8, 0x3c, 0x34, 0x46, 0x4c, 0x48, 0xb5, 0x5b, 0xd5, 0xbb, 0x75, 0xb5, 0xdc, 0xd7,
+	0x89, 0x14, 0xf4, 0x3b, 0xa2, 0xbd, 0xed, 0xfa, 0xfa, 0xdf, 0xfb, 0x02, 0xd6, 0x76, 0x47, 0x24,
+	0x1c, 0xa7, 0x8c, 0x26, 0x72, 0xe6, 0xdd, 0xa6, 0x9a, 0xb6, 0xed, 0x56, 0x11, 0xe5, 0x6a, 0x00,
+	0xc6, 0x04, 0x4f, 0x49, 0xc0, 0xb3, 0x24, 0xa1, 0xc9, 0x50, 0x6b, 0x6e, 0xfa, 0x1d, 0x0d, 0xfa,
+	0x06, 0xf3, 0xd6, 0x01, 0xcd, 0xea, 0xb2, 0xe7, 0xe2, 0x15, 0xac, 0xf8, 0x44, 0x48, 0xc6, 0x49,
+	0xae, 0xfe, 0x00, 0xea, 0xb1, 0x6e, 0x70, 0xf6, 0x60, 0xdc, 0x38, 0xd3, 0xac, 0xf2, 0xad, 0x92,
+	0xdc, 0xdb, 0x6a, 0xe1, 0xad, 0x87, 0x61, 0xb5, 0x30, 0xf9, 0x7e, 0x7a, 0xda, 0xf6, 0x2f, 0x6d,
+	0x68, 0xde, 0xb7, 0x82, 0xe8, 0x04, 0xea, 0xc6, 0x35, 0x74, 0xb6, 0x50, 0xdc, 0x9b, 0x8b, 0xd2,
+	0x6c, 0x6e, 0xff, 0x81, 0x04, 0x2c, 0xa9, 0xde, 0x8d, 0xae, 0x97, 0xd5, 0x30, 0xd3, 0xf8, 0xdd,
+	0x9d, 0xc5, 0x48, 0x85, 0xd1, 0x1f, 0xa0, 0x99, 0xb7, 0x60, 0x74, 0xab, 0xac, 0x8e, 0x37, 0x46,
+	0x80, 0x7b, 0x7b, 0x71, 0x62, 0xe1, 0xc0, 0x4f, 0x15, 0x58, 0x7d, 0xa3, 0x0d, 0xa3, 0x8f, 0xcb,
+	0xea, 0x7b, 0xf7, 0xa4, 0x70, 0xef, 0x9e, 0x99, 0x5f, 0xb8, 0xf5, 0x3d, 0x34, 0x6c, 0xbf, 0x47,
+	0xa5, 0x2b, 0x3a, 0x3f, 0x32, 0xdc, 0x5b, 0x0b, 0xf3, 0x0a, 0xeb, 0xc7, 0xb0, 0xac, 0x7b, 0x39,
+	0x2a, 0x5d, 0xd6, 0xd9, 0x79, 0xe3, 0xde, 0x58, 0x90, 0x95, 0xdb, 0xbd, 0x5a, 0x51, 0xe7, 0xdf,
+	0x0c, 0x83, 0xf2, 0xe7, 0x7f, 0x6e, 0xca, 0xb8, 0x37, 0x17, 0xa5, 0xcd, 0x9e, 0x7f, 0x75, 0x0d,
+	0xcb, 0x9f, 0xff, 0x99, 0x19, 0xe5, 0xee, 0x2c, 0x46, 0x2a, 0x8c, 0xfe, 0x5c, 0x81, 0xae, 0x82,
+	0x8e, 0x24, 0x27, 0x78, 0xa2, 0xda, 0xf2, 0xdd, 0x92, 0x03, 0x57, 0xb1, 0xcc, 0xd0, 0xb5, 0xcc,
+	0xdc, 0x95, 0x4f, 0xce, 0xae, 0x20, 0x77, 0xab, 0x5f, 0xb9, 0x5a, 0x41, 0x3f, 0x56, 0x00, 0x4e,
+	0x9b, 0x30, 0xfa, 0xb0, 0x6c, 0x84, 0x6f, 0x0d, 0x01, 0xf7, 0xce, 0x59, 0xa8, 0xb3, 0x57, 0xc1,
+	0xb6, 0xe0, 0xf2, 0x57, 0x61, 0x7e, 0x4c, 0xb8, 0xb7, 0x16, 0xe6, 0xe5, 0xd6, 0xef, 0x35, 0xbe,
+	0x5a, 0x36, 0xd3, 0xb6, 0xae, 0x3f, 0xd7, 0x7f, 0x1f, 0x00, 0x14, 0x74, 0x01, 0x75, 0x37, 0x11,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1017,6 +1281,8 @@ type ExecutorClient interface {
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error)
+	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
 }
 
 type executorClient struct {
@@ -1153,6 +1419,24 @@ func (x *executorExecStreamingClient) Recv() (*proto1.ExecTaskStreamingResponse,
 	return m, nil
 }
 
+func (c *executorClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecutorServer is the server API for Executor service.
 type ExecutorServer interface {
 	Launch(context.Context, *LaunchRequest) (*LaunchResponse, error)
@@ -1165,6 +1449,8 @@ type ExecutorServer interface {
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(Executor_ExecStreamingServer) error
+	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
 }
 
 // UnimplementedExecutorServer can be embedded to have forward compatible implementations.
@@ -1198,6 +1484,12 @@ func (*UnimplementedExecutorServer) Exec(ctx context.Context, req *ExecRequest)
 func (*UnimplementedExecutorServer) ExecStreaming(srv Executor_ExecStreamingServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExecStreaming not implemented")
 }
+func (*UnimplementedExecutorServer) Checkpoint(ctx context.Context, req *CheckpointRequest) (*CheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
+}
+func (*UnimplementedExecutorServer) Restore(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
 
 func RegisterExecutorServer(s *grpc.Server, srv ExecutorServer) {
 	s.RegisterService(&_Executor_serviceDesc, srv)
@@ -1376,6 +1668,42 @@ func (x *executorExecStreamingServer) Recv() (*proto1.ExecTaskStreamingRequest,
 	return m, nil
 }
 
+func _Executor_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Checkpoint(ctx, req.(*CheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Executor_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Executor_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.executor.proto.Executor",
 	HandlerType: (*ExecutorServer)(nil),
@@ -1408,6 +1736,14 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Exec",
 			Handler:    _Executor_Exec_Handler,
 		},
+		{
+			MethodName: "Checkpoint",
+			Handler:    _Executor_Checkpoint_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _Executor_Restore_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/nomad/client/lib/cpustats"
 	"github.com/hashicorp/nomad/drivers/shared/executor/proto"
 	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
 const (
@@ -147,6 +148,92 @@ func processStateFromProto(pb *proto.ProcessState) (*ProcessState, error) {
 	}, nil
 }
 
+func launchRequestToProto(cmd *ExecCommand) *proto.LaunchRequest {
+	return &proto.LaunchRequest{
+		Cmd:              cmd.Cmd,
+		Args:             cmd.Args,
+		Resources:        drivers.ResourcesToProto(cmd.Resources),
+		StdoutPath:       cmd.StdoutPath,
+		StderrPath:       cmd.StderrPath,
+		Env:              cmd.Env,
+		User:             cmd.User,
+		TaskDir:          cmd.TaskDir,
+		ResourceLimits:   cmd.ResourceLimits,
+		NoPivotRoot:      cmd.NoPivotRoot,
+		Mounts:           drivers.MountsToProto(cmd.Mounts),
+		Devices:          drivers.DevicesToProto(cmd.Devices),
+		NetworkIsolation: drivers.NetworkIsolationSpecToProto(cmd.NetworkIsolation),
+		DefaultPidMode:   cmd.ModePID,
+		DefaultIpcMode:   cmd.ModeIPC,
+		DefaultUserMode:  cmd.ModeUser,
+		UidMappings:      idMappingsToProto(cmd.UIDMappings),
+		GidMappings:      idMappingsToProto(cmd.GIDMappings),
+		Capabilities:     cmd.Capabilities,
+		CgroupV2Override: cmd.OverrideCgroupV2,
+		CgroupV1Override: cmd.OverrideCgroupV1,
+		OomScoreAdj:      cmd.OOMScoreAdj,
+		WorkDir:          cmd.WorkDir,
+	}
+}
+
+func launchRequestFromProto(req *proto.LaunchRequest) *ExecCommand {
+	return &ExecCommand{
+		Cmd:              req.Cmd,
+		Args:             req.Args,
+		Resources:        drivers.ResourcesFromProto(req.Resources),
+		StdoutPath:       req.StdoutPath,
+		StderrPath:       req.StderrPath,
+		Env:              req.Env,
+		User:             req.User,
+		TaskDir:          req.TaskDir,
+		ResourceLimits:   req.ResourceLimits,
+		NoPivotRoot:      req.NoPivotRoot,
+		Mounts:           drivers.MountsFromProto(req.Mounts),
+		Devices:          drivers.DevicesFromProto(req.Devices),
+		NetworkIsolation: drivers.NetworkIsolationSpecFromProto(req.NetworkIsolation),
+		ModePID:          req.DefaultPidMode,
+		ModeIPC:          req.DefaultIpcMode,
+		ModeUser:         req.DefaultUserMode,
+		UIDMappings:      idMappingsFromProto(req.UidMappings),
+		GIDMappings:      idMappingsFromProto(req.GidMappings),
+		Capabilities:     req.Capabilities,
+		OverrideCgroupV2: req.CgroupV2Override,
+		OverrideCgroupV1: req.CgroupV1Override,
+		OOMScoreAdj:      req.OomScoreAdj,
+		WorkDir:          req.WorkDir,
+	}
+}
+
+func idMappingsToProto(mappings []IDMapping) []*proto.IDMapping {
+	if len(mappings) == 0 {
+		return nil
+	}
+	pb := make([]*proto.IDMapping, len(mappings))
+	for i, m := range mappings {
+		pb[i] = &proto.IDMapping{
+			ContainerId: m.ContainerID,
+			HostId:      m.HostID,
+			Size:        m.Size,
+		}
+	}
+	return pb
+}
+
+func idMappingsFromProto(pb []*proto.IDMapping) []IDMapping {
+	if len(pb) == 0 {
+		return nil
+	}
+	mappings := make([]IDMapping, len(pb))
+	for i, m := range pb {
+		mappings[i] = IDMapping{
+			ContainerID: m.ContainerId,
+			HostID:      m.HostId,
+			Size:        m.Size,
+		}
+	}
+	return mappings
+}
+
 // IsolationMode returns the namespace isolation mode as determined from agent
 // plugin configuration and task driver configuration. The task configuration
 // takes precedence, if it is configured.
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/stretchr/testify/require"
 )
 
@@ -76,3 +77,31 @@ func configureTLogging(t *testing.T, testcmd *testExecCmd) {
 	testcmd.command.stderr = stderrPw
 	return
 }
+
+func TestUtils_launchRequest(t *testing.T) {
+	cmd := &ExecCommand{
+		Cmd:            "/bin/sleep",
+		Args:           []string{"10"},
+		Resources:      &drivers.Resources{},
+		StdoutPath:     "/alloc/logs/.web.stdout.fifo",
+		StderrPath:     "/alloc/logs/.web.stderr.fifo",
+		Env:            []string{"FOO=bar"},
+		User:           "nobody",
+		TaskDir:        "/alloc/web",
+		WorkDir:        "/local",
+		ResourceLimits: true,
+		NoPivotRoot:    true,
+		ModePID:        IsolationModePrivate,
+		ModeIPC:        IsolationModeHost,
+		ModeUser:       IsolationModePrivate,
+		UIDMappings:    []IDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		GIDMappings:    []IDMapping{{ContainerID: 0, HostID: 200000, Size: 65536}},
+		Capabilities:   []string{"CAP_CHOWN"},
+
+		OverrideCgroupV2: "nomad.slice/custom.scope",
+		OverrideCgroupV1: map[string]string{"pids": "/sys/fs/cgroup/pids/custom"},
+		OOMScoreAdj:      500,
+	}
+
+	require.Equal(t, cmd, launchRequestFromProto(launchRequestToProto(cmd)))
+}
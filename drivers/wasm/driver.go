@@ -0,0 +1,691 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package wasm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-template/signals"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/drivers/shared/eventer"
+	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
+	"github.com/hashicorp/nomad/plugins/drivers/utils"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+const (
+	// pluginName is the name of the plugin
+	pluginName = "wasm"
+
+	// fingerprintPeriod is the interval at which the driver will send fingerprint responses
+	fingerprintPeriod = 30 * time.Second
+
+	// The key populated in Node Attributes to indicate presence of the wasm driver
+	driverAttr        = "driver.wasm"
+	driverVersionAttr = "driver.wasm.version"
+	driverRuntimeAttr = "driver.wasm.runtime"
+
+	// wasmtime is the name of the WebAssembly runtime the driver starts
+	// modules with
+	wasmtime = "wasmtime"
+
+	// taskHandleVersion is the version of task handle which this driver sets
+	// and understands how to decode driver state
+	taskHandleVersion = 1
+)
+
+var (
+	// PluginID is the wasm plugin metadata registered in the plugin catalog.
+	PluginID = loader.PluginID{
+		Name:       pluginName,
+		PluginType: base.PluginTypeDriver,
+	}
+
+	// PluginConfig is the wasm driver factory function registered in the
+	// plugin catalog.
+	PluginConfig = &loader.InternalPluginConfig{
+		Config:  map[string]interface{}{},
+		Factory: func(ctx context.Context, l hclog.Logger) interface{} { return NewDriver(ctx, l) },
+	}
+
+	// versionRegex matches the output of "wasmtime --version", which is
+	// "wasmtime-cli 14.0.4" for older releases and "wasmtime 25.0.1 (...)"
+	// for newer ones
+	versionRegex = regexp.MustCompile(`wasmtime(?:-cli)? v?(\d+\.\d+\.\d+)`)
+
+	// minWasmtimeVersion is the first release of wasmtime supporting the
+	// -W and -S options the driver starts modules with
+	minWasmtimeVersion = version.Must(version.NewVersion("14.0.0"))
+
+	// pluginInfo is the response returned for the PluginInfo RPC
+	pluginInfo = &base.PluginInfoResponse{
+		Type:              base.PluginTypeDriver,
+		PluginApiVersions: []string{drivers.ApiVersion010},
+		PluginVersion:     "0.1.0",
+		Name:              pluginName,
+	}
+
+	// configSpec is the hcl specification returned by the ConfigSchema RPC
+	configSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"wasmtime_path": hclspec.NewDefault(
+			hclspec.NewAttr("wasmtime_path", "string", false),
+			hclspec.NewLiteral(`"wasmtime"`),
+		),
+		"allow_network": hclspec.NewDefault(
+			hclspec.NewAttr("allow_network", "bool", false),
+			hclspec.NewLiteral("true"),
+		),
+	})
+
+	// taskConfigSpec is the hcl specification for the driver config section of
+	// a taskConfig within a job. It is returned in the TaskConfigSchema RPC
+	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"module":  hclspec.NewAttr("module", "string", true),
+		"args":    hclspec.NewAttr("args", "list(string)", false),
+		"invoke":  hclspec.NewAttr("invoke", "string", false),
+		"fuel":    hclspec.NewAttr("fuel", "number", false),
+		"timeout": hclspec.NewAttr("timeout", "string", false),
+		"network": hclspec.NewAttr("network", "bool", false),
+	})
+
+	// driverCapabilities is returned by the Capabilities RPC and indicates what
+	// optional features this driver supports
+	driverCapabilities = &drivers.Capabilities{
+		SendSignals: true,
+		Exec:        false,
+		FSIsolation: fsisolation.None,
+		NetIsolationModes: []drivers.NetIsolationMode{
+			drivers.NetIsolationModeHost,
+			drivers.NetIsolationModeGroup,
+		},
+		MountConfigs: drivers.MountConfigSupportNone,
+	}
+
+	_ drivers.DriverPlugin = (*Driver)(nil)
+)
+
+// Config is the driver configuration set by the SetConfig RPC call
+type Config struct {
+	// WasmtimePath is the path of the wasmtime binary
+	WasmtimePath string `codec:"wasmtime_path"`
+
+	// AllowNetwork allows tasks to give modules access to the network
+	AllowNetwork bool `codec:"allow_network"`
+}
+
+// defaultConfig returns the configuration of the plugin used until SetConfig
+// is called, which matches the defaults of configSpec
+func defaultConfig() Config {
+	return Config{
+		WasmtimePath: wasmtime,
+		AllowNetwork: true,
+	}
+}
+
+// TaskConfig is the driver configuration of a taskConfig within a job
+type TaskConfig struct {
+	// Module is the path of the WebAssembly module to run, relative to the
+	// task directory
+	Module string `codec:"module"`
+
+	// Args are the arguments passed to the module
+	Args []string `codec:"args"`
+
+	// Invoke is the name of the function of the module to call instead of
+	// the WASI _start function
+	Invoke string `codec:"invoke"`
+
+	// Fuel is the amount of fuel the module may consume before it traps. Each
+	// WebAssembly instruction consumes fuel, so this limits the total amount
+	// of CPU time of the module.
+	Fuel int64 `codec:"fuel"`
+
+	// Timeout is the maximum duration the module may run for before it is
+	// interrupted.
+	Timeout string `codec:"timeout"`
+
+	// Network gives the module access to the network with WASI sockets
+	Network bool `codec:"network"`
+}
+
+func (tc *TaskConfig) validate() error {
+	if tc.Fuel < 0 {
+		return fmt.Errorf("fuel must not be negative, got %d", tc.Fuel)
+	}
+	if tc.Timeout != "" {
+		d, err := time.ParseDuration(tc.Timeout)
+		if err != nil {
+			return fmt.Errorf("failed to parse timeout: %v", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("timeout must be positive, got %q", tc.Timeout)
+		}
+	}
+	return nil
+}
+
+// TaskState is the state which is encoded in the handle returned in
+// StartTask. This information is needed to rebuild the taskConfig state and handler
+// during recovery.
+type TaskState struct {
+	ReattachConfig *pstructs.ReattachConfig
+	TaskConfig     *drivers.TaskConfig
+	Pid            int
+	StartedAt      time.Time
+}
+
+// Driver is a driver for running WebAssembly modules with wasmtime
+type Driver struct {
+	// eventer is used to handle multiplexing of TaskEvents calls such that an
+	// event can be broadcast to all callers
+	eventer *eventer.Eventer
+
+	// config is the driver configuration set by the SetConfig RPC
+	config Config
+
+	// tasks is the in memory datastore mapping taskIDs to taskHandle
+	tasks *taskStore
+
+	// ctx is the context for the driver. It is passed to other subsystems to
+	// coordinate shutdown
+	ctx context.Context
+
+	// nomadConf is the client agent's configuration
+	nomadConfig *base.ClientDriverConfig
+
+	// logger will log to the Nomad agent
+	logger hclog.Logger
+}
+
+func NewDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
+	logger = logger.Named(pluginName)
+	return &Driver{
+		eventer: eventer.NewEventer(ctx, logger),
+		config:  defaultConfig(),
+		tasks:   newTaskStore(),
+		ctx:     ctx,
+		logger:  logger,
+	}
+}
+
+func (d *Driver) PluginInfo() (*base.PluginInfoResponse, error) {
+	return pluginInfo, nil
+}
+
+func (d *Driver) ConfigSchema() (*hclspec.Spec, error) {
+	return configSpec, nil
+}
+
+func (d *Driver) SetConfig(cfg *base.Config) error {
+	config := defaultConfig()
+	if len(cfg.PluginConfig) != 0 {
+		if err := base.MsgPackDecode(cfg.PluginConfig, &config); err != nil {
+			return err
+		}
+	}
+	if config.WasmtimePath == "" {
+		return fmt.Errorf("wasmtime_path must be set")
+	}
+	d.config = config
+
+	if cfg.AgentConfig != nil {
+		d.nomadConfig = cfg.AgentConfig.Driver
+	}
+	return nil
+}
+
+func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
+	return taskConfigSpec, nil
+}
+
+func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
+	return driverCapabilities, nil
+}
+
+func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
+	ch := make(chan *drivers.Fingerprint)
+	go d.handleFingerprint(ctx, ch)
+	return ch, nil
+}
+
+func (d *Driver) handleFingerprint(ctx context.Context, ch chan *drivers.Fingerprint) {
+	ticker := time.NewTimer(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			ticker.Reset(fingerprintPeriod)
+			ch <- d.buildFingerprint()
+		}
+	}
+}
+
+func (d *Driver) buildFingerprint() *drivers.Fingerprint {
+	fp := &drivers.Fingerprint{
+		Attributes:        map[string]*pstructs.Attribute{},
+		Health:            drivers.HealthStateHealthy,
+		HealthDescription: drivers.DriverHealthy,
+	}
+
+	if runtime.GOOS == "linux" {
+		// Only enable if we are root and cgroups are mounted when running on
+		// linux system, since the resources of modules are limited with
+		// cgroups
+		if !utils.IsUnixRoot() {
+			fp.Health = drivers.HealthStateUndetected
+			fp.HealthDescription = drivers.DriverRequiresRootMessage
+			return fp
+		}
+
+		if cgroupslib.GetMode() == cgroupslib.OFF {
+			fp.Health = drivers.HealthStateUnhealthy
+			fp.HealthDescription = drivers.NoCgroupMountMessage
+			return fp
+		}
+	}
+
+	outBytes, err := exec.Command(d.config.WasmtimePath, "--version").Output()
+	if err != nil {
+		// return no error, as it isn't an error to not find wasmtime, it just
+		// means we can't use it.
+		fp.Health = drivers.HealthStateUndetected
+		fp.HealthDescription = ""
+		return fp
+	}
+
+	v, err := parseWasmtimeVersion(string(outBytes))
+	if err != nil {
+		fp.Health = drivers.HealthStateUndetected
+		fp.HealthDescription = err.Error()
+		return fp
+	}
+	if v.LessThan(minWasmtimeVersion) {
+		fp.Health = drivers.HealthStateUnhealthy
+		fp.HealthDescription = fmt.Sprintf("wasmtime %s or later is required, found %s", minWasmtimeVersion, v)
+		return fp
+	}
+
+	fp.Attributes[driverAttr] = pstructs.NewBoolAttribute(true)
+	fp.Attributes[driverVersionAttr] = pstructs.NewStringAttribute(v.String())
+	fp.Attributes[driverRuntimeAttr] = pstructs.NewStringAttribute(wasmtime)
+	return fp
+}
+
+// parseWasmtimeVersion parses the version of wasmtime from the output of
+// "wasmtime --version".
+func parseWasmtimeVersion(out string) (*version.Version, error) {
+	out = strings.TrimSpace(out)
+	matches := versionRegex.FindStringSubmatch(out)
+	if len(matches) != 2 {
+		return nil, fmt.Errorf("Failed to parse wasmtime version from %v", out)
+	}
+	return version.NewVersion(matches[1])
+}
+
+func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
+	if handle == nil {
+		return fmt.Errorf("handle cannot be nil")
+	}
+
+	// If already attached to handle there's nothing to recover.
+	if _, ok := d.tasks.Get(handle.Config.ID); ok {
+		d.logger.Debug("nothing to recover; task already exists",
+			"task_id", handle.Config.ID,
+			"task_name", handle.Config.Name,
+		)
+		return nil
+	}
+
+	var taskState TaskState
+	if err := handle.GetDriverState(&taskState); err != nil {
+		d.logger.Error("failed to decode taskConfig state from handle", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to decode taskConfig state from handle: %v", err)
+	}
+
+	plugRC, err := pstructs.ReattachConfigToGoPlugin(taskState.ReattachConfig)
+	if err != nil {
+		d.logger.Error("failed to build ReattachConfig from taskConfig state", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to build ReattachConfig from taskConfig state: %v", err)
+	}
+
+	execImpl, pluginClient, err := executor.ReattachToExecutor(
+		plugRC,
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
+		d.nomadConfig.Topology.Compute(),
+	)
+	if err != nil {
+		d.logger.Error("failed to reattach to executor", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to reattach to executor: %v", err)
+	}
+
+	h := &taskHandle{
+		exec:         execImpl,
+		pid:          taskState.Pid,
+		pluginClient: pluginClient,
+		taskConfig:   taskState.TaskConfig,
+		procState:    drivers.TaskStateRunning,
+		startedAt:    taskState.StartedAt,
+		exitResult:   &drivers.ExitResult{},
+		logger:       d.logger,
+	}
+
+	d.tasks.Set(taskState.TaskConfig.ID, h)
+
+	go h.run()
+	return nil
+}
+
+func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle, network *drivers.DriverNetwork, err error) {
+	if _, ok := d.tasks.Get(cfg.ID); ok {
+		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
+	}
+
+	var driverConfig TaskConfig
+	if err := cfg.DecodeDriverConfig(&driverConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	if err := driverConfig.validate(); err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+	if driverConfig.Network && !d.config.AllowNetwork {
+		return nil, nil, fmt.Errorf("network access is disabled by the wasm plugin configuration")
+	}
+
+	absPath, err := getAbsolutePath(d.config.WasmtimePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find wasmtime binary: %s", err)
+	}
+
+	args := wasmtimeArgs(cfg, &driverConfig)
+
+	d.logger.Info("starting wasm task", "driver_cfg", hclog.Fmt("%+v", driverConfig), "args", args)
+
+	handle = drivers.NewTaskHandle(taskHandleVersion)
+	handle.Config = cfg
+
+	pluginLogFile := filepath.Join(cfg.TaskDir().Dir, "executor.out")
+	executorConfig := &executor.ExecutorConfig{
+		LogFile:  pluginLogFile,
+		LogLevel: "debug",
+		Compute:  d.nomadConfig.Topology.Compute(),
+	}
+
+	user := cfg.User
+	if user == "" && runtime.GOOS != "windows" {
+		user = "nobody"
+	}
+
+	exec, pluginClient, err := executor.CreateExecutor(
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
+		d.nomadConfig, executorConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create executor: %v", err)
+	}
+	// prevent leaking executor in error scenarios
+	defer func() {
+		if err != nil {
+			pluginClient.Kill()
+		}
+	}()
+
+	execCmd := &executor.ExecCommand{
+		Cmd:              absPath,
+		Args:             args,
+		Env:              cfg.EnvList(),
+		User:             user,
+		ResourceLimits:   true,
+		Resources:        cfg.Resources,
+		TaskDir:          cfg.TaskDir().Dir,
+		StdoutPath:       cfg.StdoutPath,
+		StderrPath:       cfg.StderrPath,
+		NetworkIsolation: cfg.NetworkIsolation,
+	}
+
+	ps, err := exec.Launch(execCmd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
+	}
+
+	h := &taskHandle{
+		exec:         exec,
+		pid:          ps.Pid,
+		pluginClient: pluginClient,
+		taskConfig:   cfg,
+		procState:    drivers.TaskStateRunning,
+		startedAt:    time.Now().Round(time.Millisecond),
+		logger:       d.logger,
+	}
+
+	driverState := TaskState{
+		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		Pid:            ps.Pid,
+		TaskConfig:     cfg,
+		StartedAt:      h.startedAt,
+	}
+
+	if err := handle.SetDriverState(&driverState); err != nil {
+		d.logger.Error("failed to start task, error setting driver state", "error", err)
+		exec.Shutdown("", 0)
+		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
+	}
+
+	d.tasks.Set(cfg.ID, h)
+	go h.run()
+	return handle, nil, nil
+}
+
+// wasmtimeArgs returns the arguments of "wasmtime run" for a task. The module
+// can only access the task and shared allocation directories, which are
+// mounted at the same paths in the guest as on the host so that the paths in
+// the environment of the task are valid, and the environment variables of the
+// task. The linear memories of the module are limited to the memory of the
+// task.
+func wasmtimeArgs(cfg *drivers.TaskConfig, driverConfig *TaskConfig) []string {
+	args := []string{"run"}
+
+	taskDir := cfg.TaskDir()
+	for _, dir := range []string{taskDir.Dir, taskDir.SharedAllocDir} {
+		args = append(args, "--dir", dir+"::"+dir)
+	}
+
+	envKeys := make([]string, 0, len(cfg.Env))
+	for k := range cfg.Env {
+		envKeys = append(envKeys, k)
+	}
+	slices.Sort(envKeys)
+	for _, k := range envKeys {
+		// without a value, the variable is inherited from the environment of
+		// wasmtime, which the executor sets
+		args = append(args, "--env", k)
+	}
+
+	if mem := memoryLimit(cfg.Resources); mem > 0 {
+		args = append(args, "-W", "max-memory-size="+strconv.FormatInt(mem, 10))
+	}
+	if driverConfig.Fuel > 0 {
+		args = append(args, "-W", "fuel="+strconv.FormatInt(driverConfig.Fuel, 10))
+	}
+	if driverConfig.Timeout != "" {
+		// validated when the task starts
+		timeout, _ := time.ParseDuration(driverConfig.Timeout)
+		args = append(args, "-W", fmt.Sprintf("timeout=%dms", timeout.Milliseconds()))
+	}
+	if driverConfig.Network {
+		args = append(args, "-S", "inherit-network=y")
+	}
+	if driverConfig.Invoke != "" {
+		args = append(args, "--invoke", driverConfig.Invoke)
+	}
+
+	args = append(args, driverConfig.Module)
+	return append(args, driverConfig.Args...)
+}
+
+// memoryLimit returns the maximum size in bytes of the memory of modules,
+// which is the memory_max of the task if set and its memory otherwise.
+func memoryLimit(resources *drivers.Resources) int64 {
+	if resources == nil || resources.NomadResources == nil {
+		return 0
+	}
+	mem := resources.NomadResources.Memory
+	mb := mem.MemoryMB
+	if mem.MemoryMaxMB > mb {
+		mb = mem.MemoryMaxMB
+	}
+	return mb * 1024 * 1024
+}
+
+func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ch := make(chan *drivers.ExitResult)
+	go d.handleWait(ctx, handle, ch)
+
+	return ch, nil
+}
+
+func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *drivers.ExitResult) {
+	defer close(ch)
+	var result *drivers.ExitResult
+	ps, err := handle.exec.Wait(ctx)
+	if err != nil {
+		result = &drivers.ExitResult{
+			Err: fmt.Errorf("executor: error waiting on process: %v", err),
+		}
+		// if process state is nil, we've probably been killed, so return a reasonable
+		// exit state to the handlers
+		if ps == nil {
+			result.ExitCode = -1
+			result.OOMKilled = false
+		}
+	} else {
+		result = &drivers.ExitResult{
+			ExitCode:  ps.ExitCode,
+			Signal:    ps.Signal,
+			OOMKilled: ps.OOMKilled,
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-d.ctx.Done():
+		return
+	case ch <- result:
+	}
+}
+
+func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if err := handle.exec.Shutdown(signal, timeout); err != nil {
+		if handle.pluginClient.Exited() {
+			return nil
+		}
+		return fmt.Errorf("executor Shutdown failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *Driver) DestroyTask(taskID string, force bool) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if handle.IsRunning() && !force {
+		return fmt.Errorf("cannot destroy running task")
+	}
+
+	if !handle.pluginClient.Exited() {
+		if err := handle.exec.Shutdown("", 0); err != nil {
+			handle.logger.Error("destroying executor failed", "error", err)
+		}
+
+		handle.pluginClient.Kill()
+	}
+
+	d.tasks.Delete(taskID)
+	return nil
+}
+
+func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.TaskStatus(), nil
+}
+
+func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Stats(ctx, interval)
+}
+
+func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
+	return d.eventer.TaskEvents(ctx)
+}
+
+func (d *Driver) SignalTask(taskID string, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	sig := os.Interrupt
+	if s, ok := signals.SignalLookup[signal]; ok {
+		sig = s
+	} else {
+		d.logger.Warn("unknown signal to send to task, using SIGINT instead", "signal", signal, "task_id", handle.taskConfig.ID)
+	}
+	return handle.exec.Signal(sig)
+}
+
+func (d *Driver) ExecTask(_ string, _ []string, _ time.Duration) (*drivers.ExecTaskResult, error) {
+	return nil, fmt.Errorf("wasm driver can't execute commands")
+}
+
+// getAbsolutePath returns the absolute path of the passed binary by resolving
+// it in the path and following symlinks.
+func getAbsolutePath(bin string) (string, error) {
+	lp, err := exec.LookPath(bin)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path to %q executable: %v", bin, err)
+	}
+
+	return filepath.EvalSymlinks(lp)
+}
@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package wasm
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/numalib"
+	ctestutil "github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func newWasmDriverTest(t *testing.T, ctx context.Context) drivers.DriverPlugin {
+	topology := numalib.Scan(numalib.PlatformScanners(false))
+	d := NewDriver(ctx, testlog.HCLogger(t))
+	d.(*Driver).nomadConfig = &base.ClientDriverConfig{Topology: topology}
+	return d
+}
+
+func TestWasmDriver_Fingerprint(t *testing.T) {
+	ci.Parallel(t)
+	ctestutil.WasmtimeCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := newWasmDriverTest(t, ctx)
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	fpCh, err := harness.Fingerprint(context.Background())
+	must.NoError(t, err)
+
+	select {
+	case fp := <-fpCh:
+		must.Eq(t, drivers.HealthStateHealthy, fp.Health)
+		detected, _ := fp.Attributes["driver.wasm"].GetBool()
+		must.True(t, detected)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		t.Fatal("timeout receiving fingerprint")
+	}
+}
+
+func TestWasmDriver_Fingerprint_Undetected(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	d.config.WasmtimePath = filepath.Join(t.TempDir(), "wasmtime")
+
+	fp := d.buildFingerprint()
+	must.Eq(t, drivers.HealthStateUndetected, fp.Health)
+	must.MapNotContainsKey(t, fp.Attributes, "driver.wasm")
+}
+
+func TestWasmDriver_Start_Wait(t *testing.T) {
+	ci.Parallel(t)
+	ctestutil.WasmtimeCompatible(t)
+	ctestutil.CgroupsCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := newWasmDriverTest(t, ctx)
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	task := basicTask(t, "hello", &TaskConfig{Module: "hello.wat"})
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+
+	copyFile("./test-resources/hello.wat", filepath.Join(task.TaskDir().Dir, "hello.wat"), t)
+
+	handle, _, err := harness.StartTask(task)
+	must.NoError(t, err)
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	must.NoError(t, err)
+	result := <-ch
+	must.NoError(t, result.Err)
+	must.Zero(t, result.ExitCode)
+
+	stdout, err := os.ReadFile(filepath.Join(task.TaskDir().LogDir, "hello.stdout.0"))
+	must.NoError(t, err)
+	must.StrContains(t, string(stdout), "hello from wasm")
+
+	must.NoError(t, harness.DestroyTask(task.ID, true))
+}
+
+func TestWasmDriver_Fuel(t *testing.T) {
+	ci.Parallel(t)
+	ctestutil.WasmtimeCompatible(t)
+	ctestutil.CgroupsCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := newWasmDriverTest(t, ctx)
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	task := basicTask(t, "loop", &TaskConfig{Module: "loop.wat", Fuel: 100000})
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+
+	copyFile("./test-resources/loop.wat", filepath.Join(task.TaskDir().Dir, "loop.wat"), t)
+
+	handle, _, err := harness.StartTask(task)
+	must.NoError(t, err)
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	must.NoError(t, err)
+
+	select {
+	case result := <-ch:
+		must.NonZero(t, result.ExitCode)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*10) * time.Second):
+		t.Fatal("module should have run out of fuel")
+	}
+
+	must.NoError(t, harness.DestroyTask(task.ID, true))
+}
+
+func TestWasmtimeArgs(t *testing.T) {
+	ci.Parallel(t)
+
+	task := &drivers.TaskConfig{
+		Name:     "web",
+		AllocDir: "/var/nomad/alloc/1234",
+		Env: map[string]string{
+			"NOMAD_TASK_DIR": "/var/nomad/alloc/1234/web/local",
+			"FOO":            "bar",
+		},
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{MemoryMB: 64, MemoryMaxMB: 128},
+			},
+		},
+	}
+
+	must.Eq(t, []string{
+		"run",
+		"--dir", "/var/nomad/alloc/1234/web::/var/nomad/alloc/1234/web",
+		"--dir", "/var/nomad/alloc/1234/alloc::/var/nomad/alloc/1234/alloc",
+		"--env", "FOO",
+		"--env", "NOMAD_TASK_DIR",
+		"-W", "max-memory-size=134217728",
+		"local/app.wasm",
+	}, wasmtimeArgs(task, &TaskConfig{Module: "local/app.wasm"}))
+
+	must.Eq(t, []string{
+		"run",
+		"--dir", "/var/nomad/alloc/1234/web::/var/nomad/alloc/1234/web",
+		"--dir", "/var/nomad/alloc/1234/alloc::/var/nomad/alloc/1234/alloc",
+		"--env", "FOO",
+		"--env", "NOMAD_TASK_DIR",
+		"-W", "max-memory-size=134217728",
+		"-W", "fuel=1000",
+		"-W", "timeout=90000ms",
+		"-S", "inherit-network=y",
+		"--invoke", "main",
+		"local/app.wasm", "-v", "serve",
+	}, wasmtimeArgs(task, &TaskConfig{
+		Module:  "local/app.wasm",
+		Args:    []string{"-v", "serve"},
+		Invoke:  "main",
+		Fuel:    1000,
+		Timeout: "1m30s",
+		Network: true,
+	}))
+}
+
+func TestParseWasmtimeVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := map[string]string{
+		"wasmtime-cli 14.0.4\n":                  "14.0.4",
+		"wasmtime 25.0.1 (b4faad1d3 2024-09-24)": "25.0.1",
+	}
+	for out, exp := range cases {
+		v, err := parseWasmtimeVersion(out)
+		must.NoError(t, err)
+		must.Eq(t, exp, v.String())
+	}
+
+	_, err := parseWasmtimeVersion("wasmer 4.3.0")
+	must.ErrorContains(t, err, "Failed to parse wasmtime version")
+}
+
+func TestConfig_ParseAllHCL(t *testing.T) {
+	ci.Parallel(t)
+
+	cfgStr := `
+config {
+  module  = "local/app.wasm"
+  args    = ["-v", "serve"]
+  invoke  = "main"
+  fuel    = 1000000
+  timeout = "30s"
+  network = true
+}`
+
+	expected := &TaskConfig{
+		Module:  "local/app.wasm",
+		Args:    []string{"-v", "serve"},
+		Invoke:  "main",
+		Fuel:    1000000,
+		Timeout: "30s",
+		Network: true,
+	}
+
+	var tc *TaskConfig
+	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+	must.Eq(t, expected, tc)
+}
+
+func TestConfig_PluginDefaults(t *testing.T) {
+	ci.Parallel(t)
+
+	var c *Config
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, `config {}`, &c)
+	must.Eq(t, defaultConfig(), *c)
+}
+
+func TestDriver_TaskConfig_validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		config TaskConfig
+		expErr string
+	}{
+		{name: "valid", config: TaskConfig{Fuel: 10, Timeout: "1s"}},
+		{name: "negative fuel", config: TaskConfig{Fuel: -1}, expErr: "fuel must not be negative"},
+		{name: "invalid timeout", config: TaskConfig{Timeout: "soon"}, expErr: "failed to parse timeout"},
+		{name: "negative timeout", config: TaskConfig{Timeout: "-1s"}, expErr: "timeout must be positive"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.validate()
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+		})
+	}
+}
+
+func basicTask(t *testing.T, name string, taskConfig *TaskConfig) *drivers.TaskConfig {
+	t.Helper()
+
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID: allocID,
+		ID:      uuid.Generate(),
+		Name:    name,
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{
+					MemoryMB: 128,
+				},
+				Cpu: structs.AllocatedCpuResources{
+					CpuShares: 100,
+				},
+			},
+			LinuxResources: &drivers.LinuxResources{
+				MemoryLimitBytes: 134217728,
+				CPUShares:        100,
+				CpusetCgroupPath: cgroupslib.LinuxResourcesPath(allocID, name, false),
+			},
+		},
+	}
+
+	must.NoError(t, task.EncodeConcreteDriverConfig(&taskConfig))
+	return task
+}
+
+// copyFile moves an existing file to the destination
+func copyFile(src, dst string, t *testing.T) {
+	in, err := os.Open(src)
+	must.NoError(t, err)
+	defer in.Close()
+	out, err := os.Create(dst)
+	must.NoError(t, err)
+	defer func() {
+		must.NoError(t, out.Close())
+	}()
+	_, err = io.Copy(out, in)
+	must.NoError(t, err)
+}
@@ -0,0 +1,5 @@
+;; Loops forever, until it runs out of fuel or is interrupted
+(module
+  (func (export "_start")
+    (loop $loop
+      (br $loop))))
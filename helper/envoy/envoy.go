@@ -48,6 +48,11 @@ const (
 	// used in the meta.connect.sidecar_image variable.
 	VersionVar = "${NOMAD_envoy_version}"
 
+	// NomadMeshVersion is the Envoy version used for the sidecars of services
+	// using the Nomad provider, since there is no Consul agent to report its
+	// preferred version.
+	NomadMeshVersion = "1.32.3"
+
 	// DefaultConnectLogLevel is the log level set in the node meta by default
 	// to be used by Consul Connect sidecar tasks.
 	DefaultConnectLogLevel = "info"
@@ -6,9 +6,12 @@ package catalog
 import (
 	"github.com/hashicorp/nomad/drivers/docker"
 	"github.com/hashicorp/nomad/drivers/exec"
+	"github.com/hashicorp/nomad/drivers/firecracker"
 	"github.com/hashicorp/nomad/drivers/java"
+	"github.com/hashicorp/nomad/drivers/podman"
 	"github.com/hashicorp/nomad/drivers/qemu"
 	"github.com/hashicorp/nomad/drivers/rawexec"
+	"github.com/hashicorp/nomad/drivers/wasm"
 )
 
 // This file is where all builtin plugins should be registered in the catalog.
@@ -18,6 +21,9 @@ func init() {
 	RegisterDeferredConfig(rawexec.PluginID, rawexec.PluginConfig, rawexec.PluginLoader)
 	Register(exec.PluginID, exec.PluginConfig)
 	Register(qemu.PluginID, qemu.PluginConfig)
+	Register(firecracker.PluginID, firecracker.PluginConfig)
+	Register(wasm.PluginID, wasm.PluginConfig)
 	Register(java.PluginID, java.PluginConfig)
+	Register(podman.PluginID, podman.PluginConfig)
 	RegisterDeferredConfig(docker.PluginID, docker.PluginConfig, docker.PluginLoader)
 }
@@ -45,11 +45,12 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 			}`),
 			spec: dockerDecSpec,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -63,11 +64,12 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						}`),
 			spec: dockerDecSpec,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -80,12 +82,13 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						}`),
 			spec: dockerDecSpec,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				PidsLimit:    2,
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				PidsLimit:        2,
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -100,12 +103,13 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						}`),
 			spec: dockerDecSpec,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				PidsLimit:    2,
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				PidsLimit:        2,
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -118,12 +122,13 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						}`),
 			spec: dockerDecSpec,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				PidsLimit:    4,
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				PidsLimit:        4,
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -138,12 +143,13 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						}`),
 			spec: dockerDecSpec,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				PidsLimit:    4,
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				PidsLimit:        4,
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -156,12 +162,13 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						}`),
 			spec: dockerDecSpec,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				Args:         []string{"foo", "bar"},
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				Args:             []string{"foo", "bar"},
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -176,12 +183,13 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						}`),
 			spec: dockerDecSpec,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				Args:         []string{"foo", "bar"},
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				Args:             []string{"foo", "bar"},
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -196,13 +204,14 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 			spec: dockerDecSpec,
 			vars: vars,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				Args:         []string{"world", "2"},
-				PidsLimit:    4,
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				Args:             []string{"world", "2"},
+				PidsLimit:        4,
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -217,12 +226,13 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						}`),
 			spec: dockerDecSpec,
 			expected: &docker.TaskConfig{
-				Image:        "redis:7",
-				Args:         []string{"foo", "bar"},
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Image:            "redis:7",
+				Args:             []string{"foo", "bar"},
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -243,10 +253,11 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 					"foo": 1234,
 					"bar": 5678,
 				},
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -269,10 +280,11 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 					"foo": 1234,
 					"bar": 5678,
 				},
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -307,9 +319,10 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						ContainerPath: "/dev/xvdd",
 					},
 				},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -338,10 +351,11 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						"tag": "driver-test",
 					},
 				},
-				Devices:      []docker.DockerDevice{},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Devices:          []docker.DockerDevice{},
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
@@ -378,9 +392,10 @@ func TestParseHclInterface_Hcl(t *testing.T) {
 						ContainerPath: "/dev/xvdd",
 					},
 				},
-				Mounts:       []docker.DockerMount{},
-				MountsList:   []docker.DockerMount{},
-				CPUCFSPeriod: 100000,
+				Mounts:           []docker.DockerMount{},
+				MountsList:       []docker.DockerMount{},
+				CPUCFSPeriod:     100000,
+				LazyPullFallback: true,
 			},
 			expectedType: &docker.TaskConfig{},
 		},
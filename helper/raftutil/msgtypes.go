@@ -71,4 +71,10 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.HostVolumeRegisterRequestType:                "HostVolumeRegisterRequestType",
 	structs.HostVolumeDeleteRequestType:                  "HostVolumeDeleteRequestType",
 	structs.TaskGroupHostVolumeClaimDeleteRequestType:    "TaskGroupHostVolumeClaimDeleteRequestType",
+	structs.QuotaSpecUpsertRequestType:                   "QuotaSpecUpsertRequestType",
+	structs.QuotaSpecDeleteRequestType:                   "QuotaSpecDeleteRequestType",
+	structs.AllocUpdateResourcesRequestType:              "AllocUpdateResourcesRequestType",
+	structs.CSIVolumeManagedSnapshotsRequestType:         "CSIVolumeManagedSnapshotsRequestType",
+	structs.NodeMaintenanceWindowUpsertRequestType:       "NodeMaintenanceWindowUpsertRequestType",
+	structs.NodeMaintenanceWindowDeleteRequestType:       "NodeMaintenanceWindowDeleteRequestType",
 }
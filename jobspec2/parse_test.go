@@ -1180,3 +1180,326 @@ func TestIdentity(t *testing.T) {
 	must.Eq(t, "sighup", altID.ChangeSignal)
 	must.Eq(t, 2*time.Hour, altID.TTL)
 }
+
+func TestParse_Array(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "render" {
+  type = "batch"
+
+  array {
+    count = 100
+  }
+
+  group "frames" {
+    task "blender" {
+      driver = "exec"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.NotNil(t, job.Array)
+	must.Eq(t, 100, *job.Array.Count)
+
+	// the groups of the array run an allocation per index
+	job.Canonicalize()
+	must.Eq(t, 100, *job.TaskGroups[0].Count)
+}
+
+func TestParse_DependsOn(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "load" {
+  type = "batch"
+
+  depends_on {
+    job_id = "extract"
+  }
+
+  depends_on {
+    job_id = "cleanup"
+    status = "dead"
+  }
+
+  group "load" {
+    task "load" {
+      driver = "exec"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Len(t, 2, job.DependsOn)
+	must.Eq(t, "extract", *job.DependsOn[0].JobID)
+	must.Nil(t, job.DependsOn[0].Status)
+	must.Eq(t, "cleanup", *job.DependsOn[1].JobID)
+	must.Eq(t, "dead", *job.DependsOn[1].Status)
+
+	job.Canonicalize()
+	must.Eq(t, "complete", *job.DependsOn[0].Status)
+}
+
+func TestParse_PeriodicProhibit(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "report" {
+  type = "batch"
+
+  periodic {
+    crons     = ["0 * * * *", "30 6 * * *"]
+    time_zone = "Europe/Paris"
+
+    prohibit {
+      start    = "0 0 * * SAT"
+      duration = "48h"
+    }
+
+    prohibit {
+      start    = "0 2 * * *"
+      duration = "30m"
+      action   = "defer"
+    }
+  }
+
+  group "report" {
+    task "report" {
+      driver = "exec"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Len(t, 2, job.Periodic.Prohibit)
+
+	weekend := job.Periodic.Prohibit[0]
+	must.Eq(t, "0 0 * * SAT", *weekend.Start)
+	must.Eq(t, 48*time.Hour, *weekend.Duration)
+	must.Nil(t, weekend.Action)
+
+	maintenance := job.Periodic.Prohibit[1]
+	must.Eq(t, "0 2 * * *", *maintenance.Start)
+	must.Eq(t, 30*time.Minute, *maintenance.Duration)
+	must.Eq(t, "defer", *maintenance.Action)
+}
+
+func TestParse_PeriodicConcurrency(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "report" {
+  type = "batch"
+
+  periodic {
+    crons              = ["0 * * * *"]
+    concurrency_policy = "replace"
+    history_limit      = 3
+  }
+
+  group "report" {
+    task "report" {
+      driver = "exec"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "replace", *job.Periodic.ConcurrencyPolicy)
+	must.Eq(t, 3, *job.Periodic.HistoryLimit)
+}
+
+func TestParse_ParameterizedQueue(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "render" {
+  type = "batch"
+
+  parameterized {
+    payload        = "required"
+    max_concurrent = 4
+    queue_policy   = "priority"
+  }
+
+  group "render" {
+    task "render" {
+      driver = "exec"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 4, job.ParameterizedJob.MaxConcurrent)
+	must.Eq(t, "priority", job.ParameterizedJob.QueuePolicy)
+}
+
+func TestParse_UpdateVerify(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "web" {
+  update {
+    canary       = 1
+    auto_promote = true
+
+    verify {
+      type     = "prometheus"
+      address  = "http://prometheus.service.consul:9090"
+      query    = "sum(rate(http_errors[1m])) < 1"
+      checks   = 3
+      interval = "30s"
+    }
+  }
+
+  group "web" {
+    task "web" {
+      driver = "docker"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, &api.UpdateVerify{
+		Type:     pointerOf("prometheus"),
+		Address:  pointerOf("http://prometheus.service.consul:9090"),
+		Query:    pointerOf("sum(rate(http_errors[1m])) < 1"),
+		Checks:   pointerOf(3),
+		Interval: pointerOf(30 * time.Second),
+	}, job.Update.Verify)
+}
+
+func TestParse_UpdateCanarySteps(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "web" {
+  group "web" {
+    count = 10
+
+    update {
+      canary_steps = [10, 25, 50]
+      bake_time          = "5m"
+      decommission_delay = "15m"
+      auto_promote       = true
+    }
+
+    task "web" {
+      driver = "docker"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []int{10, 25, 50}, job.TaskGroups[0].Update.CanarySteps)
+	must.Eq(t, pointerOf(5*time.Minute), job.TaskGroups[0].Update.BakeTime)
+	must.Eq(t, pointerOf(15*time.Minute), job.TaskGroups[0].Update.DecommissionDelay)
+}
+
+func TestParse_RescheduleJitter(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "web" {
+  group "web" {
+    reschedule {
+      delay             = "30s"
+      delay_function    = "exponential"
+      max_delay         = "1h"
+      unlimited         = true
+      jitter            = 0.25
+      avoid_node_window = "30m"
+    }
+
+    task "web" {
+      driver = "docker"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, pointerOf(0.25), job.TaskGroups[0].ReschedulePolicy.Jitter)
+	must.Eq(t, pointerOf(30*time.Minute), job.TaskGroups[0].ReschedulePolicy.AvoidNodeWindow)
+}
+
+func TestParse_LifecycleDrainDelay(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "web" {
+  group "web" {
+    task "proxy" {
+      driver = "docker"
+
+      lifecycle {
+        hook        = "prestart"
+        sidecar     = true
+        drain_delay = "30s"
+      }
+    }
+
+    task "web" {
+      driver = "docker"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, pointerOf(30*time.Second), job.TaskGroups[0].Tasks[0].Lifecycle.DrainDelay)
+}
+
+func TestParse_RestartBudget(t *testing.T) {
+	t.Parallel()
+
+	hcl := `job "web" {
+  group "web" {
+    restart_budget {
+      attempts = 10
+      interval = "15m"
+    }
+
+    task "web" {
+      driver = "docker"
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path: "input.hcl",
+		Body: []byte(hcl),
+	})
+	must.NoError(t, err)
+	must.Eq(t, &api.RestartBudget{
+		Attempts: pointerOf(10),
+		Interval: pointerOf(15 * time.Minute),
+	}, job.TaskGroups[0].RestartBudget)
+}
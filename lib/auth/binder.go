@@ -5,6 +5,8 @@ package auth
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-bexpr"
@@ -88,51 +90,138 @@ func (b *Binder) Bind(vlog hclog.Logger, authMethod *structs.ACLAuthMethod, iden
 	// Compute role or policy names by interpolating the identity's claim
 	// mappings into the rule BindName templates.
 	for _, rule := range matchingRules {
-		bindName, valid, err := computeBindName(rule.BindType, rule.BindName, identity.ClaimMappings)
+		bindNames, err := computeBindNames(vlog, rule.BindType, rule.BindName, identity)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, bindName := range bindNames {
+			switch rule.BindType {
+			case structs.ACLBindingRuleBindTypeRole:
+				role, err := b.store.GetACLRoleByName(nil, bindName)
+				if err != nil {
+					return nil, err
+				}
+
+				if role != nil {
+					if !slices.ContainsFunc(bindings.Roles, func(link *structs.ACLTokenRoleLink) bool {
+						return link.ID == role.ID
+					}) {
+						bindings.Roles = append(bindings.Roles, &structs.ACLTokenRoleLink{
+							ID: role.ID,
+						})
+					}
+					vlog.Debug("role found with name matching ACL binding-rule", "name", bindName)
+				} else {
+					vlog.Debug("no role found with name matching ACL binding-rule", "name", bindName)
+				}
+			case structs.ACLBindingRuleBindTypePolicy:
+				policy, err := b.store.ACLPolicyByName(nil, bindName)
+				if err != nil {
+					return nil, err
+				}
+
+				if policy != nil {
+					if !slices.Contains(bindings.Policies, policy.Name) {
+						bindings.Policies = append(bindings.Policies, policy.Name)
+					}
+					vlog.Debug("policy found with name matching ACL binding-rule", "name", bindName)
+				} else {
+					vlog.Debug("no policy found with name matching ACL binding-rule", "name", bindName)
+				}
+			case structs.ACLBindingRuleBindTypeManagement:
+				vlog.Debug("management ACL binding-rule found", "name", bindName)
+				bindings.Management = true
+				bindings.Policies = nil
+				bindings.Roles = nil
+				return &bindings, nil
+			}
+		}
+	}
+
+	return &bindings, nil
+}
+
+// computeBindNames computes the role or policy names a binding rule binds for
+// the identity. A bind name that interpolates a list claim, such as
+// "${list.groups}", is computed once for each value of the claim, so a single
+// rule can bind a role or policy for each group of the identity. Computed
+// names that are not valid for the bind type are skipped in that case, as list
+// claims commonly include groups that have nothing to do with Nomad.
+func computeBindNames(vlog hclog.Logger, bindType, bindName string, identity *Identity) ([]string, error) {
+	listVar, err := bindNameListVariable(bindName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compute %q bind name for bind target: %w", bindType, err)
+	}
+
+	if listVar == "" {
+		name, valid, err := computeBindName(bindType, bindName, identity.ClaimMappings)
 		switch {
 		case err != nil:
-			return nil, fmt.Errorf("cannot compute %q bind name for bind target: %w", rule.BindType, err)
+			return nil, fmt.Errorf("cannot compute %q bind name for bind target: %w", bindType, err)
 		case !valid:
-			return nil, fmt.Errorf("computed %q bind name for bind target is invalid: %q", rule.BindType, bindName)
+			return nil, fmt.Errorf("computed %q bind name for bind target is invalid: %q", bindType, name)
 		}
+		return []string{name}, nil
+	}
 
-		switch rule.BindType {
-		case structs.ACLBindingRuleBindTypeRole:
-			role, err := b.store.GetACLRoleByName(nil, bindName)
-			if err != nil {
-				return nil, err
-			}
+	values, ok := identity.ListClaimMappings[listVar]
+	if !ok {
+		return nil, fmt.Errorf("cannot compute %q bind name for bind target: unknown list claim %q", bindType, listVar)
+	}
 
-			if role != nil {
-				bindings.Roles = append(bindings.Roles, &structs.ACLTokenRoleLink{
-					ID: role.ID,
-				})
-				vlog.Debug("role found with name matching ACL binding-rule", "name", bindName)
-			} else {
-				vlog.Debug("no role found with name matching ACL binding-rule", "name", bindName)
-			}
-		case structs.ACLBindingRuleBindTypePolicy:
-			policy, err := b.store.ACLPolicyByName(nil, bindName)
-			if err != nil {
-				return nil, err
-			}
+	vars := maps.Clone(identity.ClaimMappings)
+	if vars == nil {
+		vars = make(map[string]string)
+	}
 
-			if policy != nil {
-				bindings.Policies = append(bindings.Policies, policy.Name)
-				vlog.Debug("policy found with name matching ACL binding-rule", "name", bindName)
-			} else {
-				vlog.Debug("no policy found with name matching ACL binding-rule", "name", bindName)
-			}
-		case structs.ACLBindingRuleBindTypeManagement:
-			vlog.Debug("management ACL binding-rule found", "name", bindName)
-			bindings.Management = true
-			bindings.Policies = nil
-			bindings.Roles = nil
-			return &bindings, nil
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		vars[listVar] = value
+
+		name, valid, err := computeBindName(bindType, bindName, vars)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compute %q bind name for bind target: %w", bindType, err)
+		}
+		if !valid {
+			vlog.Debug("skipping invalid bind name computed from list claim", "claim", listVar, "name", name)
+			continue
 		}
+		names = append(names, name)
 	}
+	return names, nil
+}
 
-	return &bindings, nil
+// bindNameListVariable returns the list claim variable interpolated by the
+// bind name, if any. A bind name can interpolate at most one list claim.
+func bindNameListVariable(bindName string) (string, error) {
+	if !strings.Contains(bindName, "${") {
+		return "", nil
+	}
+
+	tree, err := hil.Parse(bindName)
+	if err != nil {
+		return "", err
+	}
+
+	var listVars []string
+	tree.Accept(func(n ast.Node) ast.Node {
+		if v, ok := n.(*ast.VariableAccess); ok &&
+			strings.HasPrefix(v.Name, "list.") && !slices.Contains(listVars, v.Name) {
+			listVars = append(listVars, v.Name)
+		}
+		return n
+	})
+
+	switch len(listVars) {
+	case 0:
+		return "", nil
+	case 1:
+		return listVars[0], nil
+	default:
+		return "", fmt.Errorf("bind name interpolates more than one list claim: %s",
+			strings.Join(listVars, ", "))
+	}
 }
 
 // computeBindName processes the HIL for the provided bind type+name using the
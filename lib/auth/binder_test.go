@@ -128,6 +128,82 @@ func TestBinder_Bind(t *testing.T) {
 	}
 }
 
+func TestBinder_Bind_ListClaims(t *testing.T) {
+	ci.Parallel(t)
+
+	testStore := state.TestStateStore(t)
+	testBind := NewBinder(testStore)
+
+	authMethod := mock.ACLOIDCAuthMethod()
+	authMethod.Config.ClaimMappings = map[string]string{"team": "team"}
+	authMethod.Config.ListClaimMappings = map[string]string{"/resource_access/nomad/roles": "groups"}
+	must.NoError(t, testStore.UpsertACLAuthMethods(0, []*structs.ACLAuthMethod{authMethod}))
+
+	engineering := mock.ACLPolicy()
+	engineering.Name = "engineering"
+	ops := mock.ACLPolicy()
+	ops.Name = "ops"
+	must.NoError(t, testStore.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{engineering, ops}))
+
+	opsRole := &structs.ACLRole{
+		ID:       uuid.Generate(),
+		Name:     "platform-ops",
+		Policies: []*structs.ACLRolePolicyLink{{Name: ops.Name}},
+	}
+	must.NoError(t, testStore.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{opsRole}, true,
+	))
+
+	bindingRules := []*structs.ACLBindingRule{
+		{
+			ID:         uuid.Generate(),
+			BindType:   structs.ACLBindingRuleBindTypePolicy,
+			BindName:   "${list.groups}",
+			AuthMethod: authMethod.Name,
+		},
+		{
+			ID:         uuid.Generate(),
+			Selector:   `"ops" in list.groups`,
+			BindType:   structs.ACLBindingRuleBindTypeRole,
+			BindName:   "${value.team}-${list.groups}",
+			AuthMethod: authMethod.Name,
+		},
+	}
+	must.NoError(t, testStore.UpsertACLBindingRules(30, bindingRules, true))
+
+	// the groups are nested within the claims and include duplicates and
+	// names that are not valid policy names
+	claims, err := SelectorData(authMethod, map[string]any{
+		"team": "platform",
+		"resource_access": map[string]any{
+			"nomad": map[string]any{
+				"roles": []any{"engineering", "Domain Users", "ops", "engineering"},
+			},
+		},
+	}, nil)
+	must.NoError(t, err)
+
+	got, err := testBind.Bind(hclog.NewNullLogger(), authMethod, NewIdentity(authMethod.Config, claims))
+	must.NoError(t, err)
+	must.Eq(t, &Bindings{
+		Policies: []string{"engineering", "ops"},
+		Roles:    []*structs.ACLTokenRoleLink{{ID: opsRole.ID}},
+	}, got)
+
+	// removing a group from the claims removes its bindings
+	claims.List["groups"] = []string{"engineering"}
+	got, err = testBind.Bind(hclog.NewNullLogger(), authMethod, NewIdentity(authMethod.Config, claims))
+	must.NoError(t, err)
+	must.Eq(t, &Bindings{Policies: []string{"engineering"}}, got)
+
+	// a bind name can only interpolate a single list claim
+	bindingRules[0].BindName = "${list.groups}-${list.other}"
+	must.NoError(t, testStore.UpsertACLBindingRules(40, bindingRules, true))
+	_, err = testBind.Bind(hclog.NewNullLogger(), authMethod, NewIdentity(authMethod.Config, claims))
+	must.ErrorContains(t, err, "more than one list claim")
+}
+
 func Test_computeBindName(t *testing.T) {
 	ci.Parallel(t)
 	tests := []struct {
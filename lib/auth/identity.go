@@ -15,6 +15,11 @@ type Identity struct {
 	// ClaimMappings is the format of this Identity suitable for interpolation in a
 	// bind name within a binding rule.
 	ClaimMappings map[string]string
+
+	// ListClaimMappings holds the values of the list claims of this Identity.
+	// A bind name that interpolates one of them is computed once for each of
+	// its values.
+	ListClaimMappings map[string][]string
 }
 
 // NewIdentity builds a new Identity that can be used to generate bindings via
@@ -32,8 +37,17 @@ func NewIdentity(
 		claimMappings["value."+k] = val
 	}
 
+	listClaimMappings := make(map[string][]string)
+	for _, k := range authMethodConfig.ListClaimMappings {
+		listClaimMappings["list."+k] = nil
+	}
+	for k, val := range authClaims.List {
+		listClaimMappings["list."+k] = val
+	}
+
 	return &Identity{
-		Claims:        authClaims,
-		ClaimMappings: claimMappings,
+		Claims:            authClaims,
+		ClaimMappings:     claimMappings,
+		ListClaimMappings: listClaimMappings,
 	}
 }
@@ -36,7 +36,8 @@ func Test_NewIdentity(t *testing.T) {
 					Value: map[string]string{"username": "jrasell"},
 					List:  map[string][]string{"roles": {"engineering"}},
 				},
-				ClaimMappings: map[string]string{"value.username": "jrasell"},
+				ClaimMappings:     map[string]string{"value.username": "jrasell"},
+				ListClaimMappings: map[string][]string{"list.roles": {"engineering"}},
 			},
 		},
 		{
@@ -54,7 +55,8 @@ func Test_NewIdentity(t *testing.T) {
 					Value: map[string]string{"username": ""},
 					List:  map[string][]string{"roles": {""}},
 				},
-				ClaimMappings: map[string]string{"value.username": ""},
+				ClaimMappings:     map[string]string{"value.username": ""},
+				ListClaimMappings: map[string][]string{"list.roles": {""}},
 			},
 		},
 	}
@@ -32,6 +32,10 @@ func (s *Server) ResolveToken(secretID string) (*acl.ACL, error) {
 	return s.auth.ResolveToken(secretID)
 }
 
+func (s *Server) ResolveSecretToken(secretID string) (*structs.ACLToken, error) {
+	return s.auth.ResolveSecretToken(secretID)
+}
+
 func (s *Server) ResolvePoliciesForClaims(claims *structs.IdentityClaims) ([]*structs.ACLPolicy, error) {
 	return s.auth.ResolvePoliciesForClaims(claims)
 }
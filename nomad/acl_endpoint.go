@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -2160,6 +2161,92 @@ func (a *ACL) GetAuthMethods(
 	)
 }
 
+// TestAuthMethod computes the roles and policies the binding rules of an auth
+// method bind for the provided claims, without creating a token. It is used to
+// dry-run the claim mappings and binding rules of an auth method.
+func (a *ACL) TestAuthMethod(
+	args *structs.ACLAuthMethodTestRequest,
+	reply *structs.ACLAuthMethodTestResponse) error {
+
+	// Only allow operators to test an auth method when ACLs are enabled.
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+
+	authErr := a.srv.Authenticate(a.ctx, args)
+	if done, err := a.srv.forward(
+		structs.ACLTestAuthMethodRPCMethod, args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("acl", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "test_auth_method"}, time.Now())
+
+	// Resolve the token and ensure it has management permissions, since the
+	// result details the bindings of the auth method.
+	if aclObj, err := a.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	stateSnapshot, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	authMethod, err := stateSnapshot.GetACLAuthMethodByName(nil, args.MethodName)
+	if err != nil {
+		return err
+	}
+	if authMethod == nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "auth-method %q not found", args.MethodName)
+	}
+
+	claims := args.Claims
+	if claims == nil {
+		claims = make(map[string]any)
+	}
+	authClaims, err := auth.SelectorData(authMethod, claims, nil)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid claims: %v", err)
+	}
+
+	bindings, err := auth.NewBinder(stateSnapshot).Bind(
+		hclog.NewNullLogger(), authMethod, auth.NewIdentity(authMethod.Config, authClaims))
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "%v", err)
+	}
+
+	// Include the names of the roles, which are more meaningful to operators
+	// than their IDs.
+	for _, link := range bindings.Roles {
+		role, err := stateSnapshot.GetACLRoleByID(nil, link.ID)
+		if err != nil {
+			return err
+		}
+		if role != nil {
+			link.Name = role.Name
+		}
+	}
+
+	reply.Result = &structs.ACLAuthMethodTestResult{
+		Claims:     authClaims,
+		Management: bindings.Management,
+		Roles:      bindings.Roles,
+		Policies:   bindings.Policies,
+	}
+
+	index, err := stateSnapshot.Index(state.TableACLBindingRules)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
 // WhoAmI is a RPC for debugging authentication. This endpoint returns the same
 // AuthenticatedIdentity that will be used by RPC handlers, but unlike other
 // endpoints will try to authenticate workload identities even if ACLs are
@@ -2825,6 +2912,8 @@ func (a *ACL) OIDCCompleteAuth(
 		Name:          name,
 		Global:        authMethod.TokenLocalityIsGlobal(),
 		ExpirationTTL: authMethod.MaxTokenTTL,
+		AuthMethod:    authMethod.Name,
+		AuthSubject:   loginSubject(idTokenClaims),
 	}
 
 	if tokenBindings.Management {
@@ -2835,24 +2924,10 @@ func (a *ACL) OIDCCompleteAuth(
 		token.Roles = tokenBindings.Roles
 	}
 
-	tokenUpsertRequest := structs.ACLTokenUpsertRequest{
-		Tokens: []*structs.ACLToken{&token},
-		WriteRequest: structs.WriteRequest{
-			Region:    a.srv.Region(),
-			AuthToken: a.srv.getLeaderAcl(),
-		},
-	}
-
-	var tokenUpsertReply structs.ACLTokenUpsertResponse
-
-	if err := a.upsertTokens(&tokenUpsertRequest, &tokenUpsertReply, stateSnapshot); err != nil {
+	reply.ACLToken, err = a.upsertLoginToken(authMethod, tokenBindings, &token, stateSnapshot)
+	if err != nil {
 		return err
 	}
-
-	// The way the UpsertTokens RPC currently works, if we get no error, then
-	// we will have exactly the same number of tokens returned as we sent. It
-	// is therefore safe to assume we have 1 token.
-	reply.ACLToken = tokenUpsertReply.Tokens[0]
 	return nil
 }
 
@@ -3001,6 +3076,8 @@ func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLLoginRespon
 		Name:          name,
 		Global:        authMethod.TokenLocalityIsGlobal(),
 		ExpirationTTL: authMethod.MaxTokenTTL,
+		AuthMethod:    authMethod.Name,
+		AuthSubject:   loginSubject(claims),
 	}
 
 	if tokenBindings.Management {
@@ -3011,26 +3088,142 @@ func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLLoginRespon
 		token.Roles = tokenBindings.Roles
 	}
 
+	reply.ACLToken, err = a.upsertLoginToken(authMethod, tokenBindings, &token, stateSnapshot)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// upsertLoginToken creates the token of a login to the auth method. If the auth
+// method prunes tokens, the roles and policies the identity is no longer bound
+// to are also removed from the tokens previously created for the identity.
+func (a *ACL) upsertLoginToken(
+	authMethod *structs.ACLAuthMethod,
+	bindings *auth.Bindings,
+	token *structs.ACLToken,
+	stateSnapshot *state.StateSnapshot,
+) (*structs.ACLToken, error) {
+
+	// Build our token RPC request. The RPC handler includes a lot of specific
+	// logic, so we do not want to call Raft directly or copy that here.
 	tokenUpsertRequest := structs.ACLTokenUpsertRequest{
-		Tokens: []*structs.ACLToken{&token},
+		Tokens: []*structs.ACLToken{token},
 		WriteRequest: structs.WriteRequest{
 			Region:    a.srv.Region(),
 			AuthToken: a.srv.getLeaderAcl(),
 		},
 	}
 
+	var deleted []string
+	if authMethod.PruneTokens && token.AuthSubject != "" {
+		pruned, toDelete, err := pruneLoginTokens(stateSnapshot, token, bindings)
+		if err != nil {
+			return nil, err
+		}
+		tokenUpsertRequest.Tokens = append(tokenUpsertRequest.Tokens, pruned...)
+		deleted = toDelete
+	}
+
 	var tokenUpsertReply structs.ACLTokenUpsertResponse
 
 	if err := a.upsertTokens(&tokenUpsertRequest, &tokenUpsertReply, stateSnapshot); err != nil {
-		return err
+		return nil, err
+	}
+
+	if len(deleted) > 0 {
+		tokenDeleteRequest := structs.ACLTokenDeleteRequest{
+			AccessorIDs: deleted,
+			WriteRequest: structs.WriteRequest{
+				Region:    a.srv.Region(),
+				AuthToken: a.srv.getLeaderAcl(),
+			},
+		}
+		if _, _, err := a.srv.raftApply(structs.ACLTokenDeleteRequestType, &tokenDeleteRequest); err != nil {
+			return nil, err
+		}
+		a.logger.Debug("deleted tokens no longer bound to any role or policy",
+			"auth_method", authMethod.Name, "num_tokens", len(deleted))
 	}
 
 	// The way the UpsertTokens RPC currently works, if we get no error, then
-	// we will have exactly the same number of tokens returned as we sent. It
-	// is therefore safe to assume we have 1 token.
-	reply.ACLToken = tokenUpsertReply.Tokens[0]
+	// we will have exactly the same number of tokens returned as we sent. The
+	// login token is always the first one.
+	return tokenUpsertReply.Tokens[0], nil
+}
 
-	return nil
+// pruneLoginTokens returns copies of the tokens previously created for the
+// identity of the login token, without the roles and policies the identity is
+// no longer bound to. It also returns the accessor IDs of the tokens left
+// without any roles or policies, which should be deleted.
+func pruneLoginTokens(
+	stateSnapshot *state.StateSnapshot,
+	token *structs.ACLToken,
+	bindings *auth.Bindings,
+) ([]*structs.ACLToken, []string, error) {
+
+	// A management binding grants everything, so there is nothing to prune.
+	if bindings.Management {
+		return nil, nil, nil
+	}
+
+	iter, err := stateSnapshot.ACLTokens(nil, state.SortDefault)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		pruned  []*structs.ACLToken
+		deleted []string
+		now     = time.Now().UTC()
+	)
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		existing := raw.(*structs.ACLToken)
+
+		// Tokens of the other locality are owned by another region, and
+		// expired tokens will be garbage collected anyway.
+		if existing.AuthMethod != token.AuthMethod ||
+			existing.AuthSubject != token.AuthSubject ||
+			existing.Global != token.Global ||
+			existing.IsExpired(now) {
+			continue
+		}
+
+		if existing.Type == structs.ACLManagementToken {
+			deleted = append(deleted, existing.AccessorID)
+			continue
+		}
+
+		policies := slices.DeleteFunc(slices.Clone(existing.Policies), func(name string) bool {
+			return !slices.Contains(bindings.Policies, name)
+		})
+		roles := slices.DeleteFunc(slices.Clone(existing.Roles), func(link *structs.ACLTokenRoleLink) bool {
+			return !slices.ContainsFunc(bindings.Roles, func(bound *structs.ACLTokenRoleLink) bool {
+				return bound.ID == link.ID
+			})
+		})
+
+		switch {
+		case len(policies) == 0 && len(roles) == 0:
+			deleted = append(deleted, existing.AccessorID)
+		case len(policies) != len(existing.Policies) || len(roles) != len(existing.Roles):
+			prunedToken := existing.Copy()
+			prunedToken.Policies = policies
+			prunedToken.Roles = roles
+			pruned = append(pruned, prunedToken)
+		}
+	}
+
+	return pruned, deleted, nil
+}
+
+// loginSubject returns the subject of the identity that logs in, which is used
+// to find the tokens previously created for it.
+func loginSubject(claims map[string]any) string {
+	sub, _ := claims["sub"].(string)
+	return sub
 }
 
 func formatTokenName(format, authType, authName string, claims map[string]string) (string, error) {
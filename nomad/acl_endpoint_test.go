@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/shoenig/test/must"
@@ -4200,6 +4201,184 @@ func TestACL_Login(t *testing.T) {
 	must.Eq(t, mockedAuthMethod.Type+"-"+mockedAuthMethod.Name+"-"+user, completeAuthResp6.ACLToken.Name)
 }
 
+func TestACL_Login_PruneTokens(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, _, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	engineering := mock.ACLPolicy()
+	engineering.Name = "engineering"
+	ops := mock.ACLPolicy()
+	ops.Name = "ops"
+	must.NoError(t, testServer.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{engineering, ops}))
+
+	// signJWT returns a JWT for the subject and groups, along with the public
+	// key to verify it
+	signJWT := func(sub string, groups ...string) (string, string) {
+		token, pubKey, err := mock.SampleJWTokenWithKeys(jwt.MapClaims{
+			"groups": groups,
+			"iat":    time.Now().Unix(),
+			"nbf":    time.Now().Unix(),
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"sub":    sub,
+			"iss":    "nomad test suite",
+			"aud":    []string{"engineering"},
+		}, nil)
+		must.NoError(t, err)
+		return token, pubKey
+	}
+
+	// the key pair is generated for each token, so the auth method is
+	// updated with the public key of the token before each login
+	authMethod := mock.ACLJWTAuthMethod()
+	authMethod.PruneTokens = true
+	authMethod.Config.BoundAudiences = []string{"engineering"}
+	authMethod.Config.BoundIssuer = []string{"nomad test suite"}
+	authMethod.Config.ListClaimMappings = map[string]string{"groups": "groups"}
+
+	bindingRule := mock.ACLBindingRule()
+	bindingRule.AuthMethod = authMethod.Name
+	bindingRule.BindType = structs.ACLBindingRuleBindTypePolicy
+	bindingRule.Selector = ""
+	bindingRule.BindName = "${list.groups}"
+	must.NoError(t, testServer.fsm.State().UpsertACLBindingRules(
+		20, []*structs.ACLBindingRule{bindingRule}, true))
+
+	index := uint64(30)
+	login := func(sub string, groups ...string) (*structs.ACLToken, error) {
+		token, pubKey := signJWT(sub, groups...)
+		authMethod.Config.JWTValidationPubKeys = []string{pubKey}
+		index++
+		must.NoError(t, testServer.fsm.State().UpsertACLAuthMethods(
+			index, []*structs.ACLAuthMethod{authMethod}))
+
+		req := structs.ACLLoginRequest{
+			AuthMethodName: authMethod.Name,
+			LoginToken:     token,
+			WriteRequest:   structs.WriteRequest{Region: DefaultRegion},
+		}
+		var resp structs.ACLLoginResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.ACLLoginRPCMethod, &req, &resp)
+		return resp.ACLToken, err
+	}
+
+	lookup := func(accessorID string) *structs.ACLToken {
+		token, err := testServer.fsm.State().ACLTokenByAccessorID(nil, accessorID)
+		must.NoError(t, err)
+		return token
+	}
+
+	first, err := login("alice", "engineering", "ops")
+	must.NoError(t, err)
+	must.Eq(t, []string{"engineering", "ops"}, first.Policies)
+	must.Eq(t, authMethod.Name, first.AuthMethod)
+	must.Eq(t, "alice", first.AuthSubject)
+
+	other, err := login("bob", "ops")
+	must.NoError(t, err)
+
+	// the ops group was removed from alice, so it is pruned from the previous
+	// token of alice, but not from the token of bob
+	second, err := login("alice", "engineering")
+	must.NoError(t, err)
+	must.Eq(t, []string{"engineering"}, second.Policies)
+	must.Eq(t, []string{"engineering"}, lookup(first.AccessorID).Policies)
+	must.Eq(t, []string{"ops"}, lookup(other.AccessorID).Policies)
+
+	// tokens left without any policies are deleted
+	third, err := login("alice", "ops")
+	must.NoError(t, err)
+	must.Nil(t, lookup(first.AccessorID))
+	must.Nil(t, lookup(second.AccessorID))
+	must.NotNil(t, lookup(third.AccessorID))
+
+	// previous tokens are kept as they are if pruning is disabled
+	authMethod.PruneTokens = false
+	_, err = login("alice", "engineering")
+	must.NoError(t, err)
+	must.Eq(t, []string{"ops"}, lookup(third.AccessorID).Policies)
+}
+
+func TestACL_TestAuthMethod(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, rootToken, testServerCleanupFn := TestACLServer(t, nil)
+	defer testServerCleanupFn()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+
+	engineering := mock.ACLPolicy()
+	engineering.Name = "engineering"
+	must.NoError(t, testServer.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{engineering}))
+
+	role := mock.ACLRole()
+	role.Name = "platform-engineering"
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: engineering.Name}}
+	must.NoError(t, testServer.fsm.State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{role}, true))
+
+	authMethod := mock.ACLOIDCAuthMethod()
+	authMethod.Config.ClaimMappings = map[string]string{"team": "team"}
+	authMethod.Config.ListClaimMappings = map[string]string{"/realm_access/roles": "groups"}
+	must.NoError(t, testServer.fsm.State().UpsertACLAuthMethods(
+		30, []*structs.ACLAuthMethod{authMethod}))
+
+	policyRule := mock.ACLBindingRule()
+	policyRule.AuthMethod = authMethod.Name
+	policyRule.BindType = structs.ACLBindingRuleBindTypePolicy
+	policyRule.Selector = ""
+	policyRule.BindName = "${list.groups}"
+
+	roleRule := mock.ACLBindingRule()
+	roleRule.AuthMethod = authMethod.Name
+	roleRule.BindType = structs.ACLBindingRuleBindTypeRole
+	roleRule.Selector = "value.team == platform"
+	roleRule.BindName = "${value.team}-${list.groups}"
+	must.NoError(t, testServer.fsm.State().UpsertACLBindingRules(
+		40, []*structs.ACLBindingRule{policyRule, roleRule}, true))
+
+	req := structs.ACLAuthMethodTestRequest{
+		MethodName: authMethod.Name,
+		Claims: map[string]any{
+			"team":         "platform",
+			"realm_access": map[string]any{"roles": []any{"engineering", "offline_access"}},
+		},
+		QueryOptions: structs.QueryOptions{Region: DefaultRegion},
+	}
+
+	// the endpoint requires a management token
+	var resp structs.ACLAuthMethodTestResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLTestAuthMethodRPCMethod, &req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = rootToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLTestAuthMethodRPCMethod, &req, &resp))
+	must.Eq(t, map[string]string{"team": "platform"}, resp.Result.Claims.Value)
+	must.Eq(t, map[string][]string{"groups": {"engineering", "offline_access"}}, resp.Result.Claims.List)
+	must.False(t, resp.Result.Management)
+	must.Eq(t, []string{"engineering"}, resp.Result.Policies)
+	must.Eq(t, []*structs.ACLTokenRoleLink{{ID: role.ID, Name: role.Name}}, resp.Result.Roles)
+	must.Eq(t, 40, resp.Index)
+
+	// testing doesn't create any tokens
+	iter, err := testServer.fsm.State().ACLTokens(nil, state.SortDefault)
+	must.NoError(t, err)
+	var numTokens int
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		numTokens++
+	}
+	must.Eq(t, 1, numTokens)
+
+	req.MethodName = "missing"
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLTestAuthMethodRPCMethod, &req, &resp)
+	must.ErrorContains(t, err, `auth-method "missing" not found`)
+}
+
 // cacheOIDCRequest primes the oidc.Request cache, as OIDCAuthURL usually would,
 // to prepare for a subsequent OIDCCompleteAuth call.
 func cacheOIDCRequest(t *testing.T, cache *oidc.RequestCache, req structs.ACLOIDCCompleteAuthRequest, opts ...capOIDC.Option) {
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
 	metrics "github.com/hashicorp/go-metrics/compat"
+	multierror "github.com/hashicorp/go-multierror"
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper/pointer"
@@ -324,6 +325,146 @@ func (a *Alloc) Stop(args *structs.AllocStopRequest, reply *structs.AllocStopRes
 	return nil
 }
 
+// Resize is used to update the CPU and memory resources of the tasks of a
+// running allocation in place, as long as its node and the quota of its
+// namespace have room for them. The scheduler keeps the resized resources when
+// the job of the allocation is updated in place.
+func (a *Alloc) Resize(args *structs.AllocResizeRequest, reply *structs.AllocResizeResponse) error {
+
+	authErr := a.srv.Authenticate(a.ctx, args)
+	if done, err := a.srv.forward("Alloc.Resize", args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("alloc", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "alloc", "resize"}, time.Now())
+
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	alloc, err := getAlloc(snap, args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Resizing an allocation is scaling its job vertically, so it requires
+	// the same permissions as scaling it horizontally.
+	aclObj, err := a.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	hasScaleJob := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityScaleJob)
+	hasSubmitJob := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilitySubmitJob)
+	if !(hasScaleJob || hasSubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if alloc.TerminalStatus() {
+		return fmt.Errorf("can't resize terminal allocation %q", alloc.ID)
+	}
+	if len(args.Resources) == 0 {
+		return fmt.Errorf("missing task resources")
+	}
+
+	_, schedConfig, err := snap.SchedulerConfig()
+	if err != nil {
+		return err
+	}
+	pool, err := snap.NodePoolByName(nil, alloc.Job.NodePool)
+	if err != nil {
+		return err
+	}
+	memoryOversubscription := pool != nil && pool.MemoryOversubscriptionEnabled(schedConfig)
+
+	tasks, err := resizeTaskResources(alloc, args.Resources, memoryOversubscription)
+	if err != nil {
+		return err
+	}
+
+	// The node of the allocation and the quota of its namespace must have room
+	// for the new resources, which is checked when the resize is applied so
+	// that it's serialized with the plans placing allocations
+	req := &structs.AllocUpdateResourcesRequest{
+		AllocID:      alloc.ID,
+		Tasks:        tasks,
+		WriteRequest: args.WriteRequest,
+	}
+	_, index, err := a.srv.raftApply(structs.AllocUpdateResourcesRequestType, req)
+	if err != nil {
+		a.logger.Error("AllocUpdateResourcesRequest failed", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// resizeTaskResources returns the allocated resources of the tasks of the
+// allocation once resized to the requested resources.
+func resizeTaskResources(alloc *structs.Allocation, requested map[string]*structs.Resources,
+	memoryOversubscription bool) (map[string]*structs.AllocatedTaskResources, error) {
+
+	if alloc.AllocatedResources == nil {
+		return nil, fmt.Errorf("allocation %q has no allocated resources", alloc.ID)
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return nil, fmt.Errorf("allocation %q has no task group %q", alloc.ID, alloc.TaskGroup)
+	}
+
+	var mErr multierror.Error
+	tasks := make(map[string]*structs.AllocatedTaskResources, len(requested))
+	for name, r := range requested {
+		current, ok := alloc.AllocatedResources.Tasks[name]
+		task := tg.LookupTask(name)
+		if !ok || task == nil || r == nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("task %q not found in allocation", name))
+			continue
+		}
+		if len(current.Cpu.ReservedCores) > 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("task %q reserves cores and can't be resized", name))
+			continue
+		}
+		if err := r.MeetsMinResources(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("task %q: %v", name, err))
+			continue
+		}
+		if r.CPUMax != 0 && r.CPUMax < r.CPU {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("task %q: CPUMax value (%d) should be larger than CPU value (%d)", name, r.CPUMax, r.CPU))
+			continue
+		}
+		if r.MemoryMaxMB != 0 {
+			if !memoryOversubscription {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("task %q: memory oversubscription is not enabled", name))
+				continue
+			}
+			if r.MemoryMaxMB < r.MemoryMB {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("task %q: MemoryMaxMB value (%d) should be larger than MemoryMB value (%d)", name, r.MemoryMaxMB, r.MemoryMB))
+				continue
+			}
+		}
+
+		// the secrets tmpfs is allocated along with the memory of the task
+		secretsMB := int64(task.Resources.SecretsMB)
+		resized := current.Copy()
+		resized.Cpu.CpuShares = int64(r.CPU)
+		resized.Cpu.CpuMax = int64(r.CPUMax)
+		resized.Memory.MemoryMB = int64(r.MemoryMB) + secretsMB
+		resized.Memory.MemoryMaxMB = 0
+		if r.MemoryMaxMB != 0 {
+			resized.Memory.MemoryMaxMB = int64(r.MemoryMaxMB) + secretsMB
+		}
+		tasks[name] = resized
+	}
+
+	return tasks, mErr.ErrorOrNil()
+}
+
 // UpdateDesiredTransition is used to update the desired transitions of an
 // allocation.
 func (a *Alloc) UpdateDesiredTransition(args *structs.AllocUpdateDesiredTransitionRequest, reply *structs.GenericResponse) error {
@@ -621,6 +762,7 @@ func (a *Alloc) signTasks(
 		if vaultCfg != nil && vaultCfg.DefaultIdentity != nil {
 			builder.WithVault(vaultCfg.DefaultIdentity.ExtraClaims)
 		}
+		builder.WithClaims(a.srv.GetConfig().WorkloadIdentityClaims.ClaimsFor(wid))
 
 		claims := builder.Build(now)
 		err = a.signClaims(claims, idReq, reply)
@@ -638,6 +780,12 @@ func (a *Alloc) signServices(
 ) (widFound bool, err error) {
 	wid := idReq.WIHandle
 
+	node, err := a.srv.State().NodeByID(nil, alloc.NodeID)
+	if err != nil {
+		return false, err
+	}
+	claimsConfig := a.srv.GetConfig().WorkloadIdentityClaims
+
 	// services can be on the level of task groups or tasks
 	for _, tg := range job.TaskGroups {
 		for _, service := range tg.Services {
@@ -646,6 +794,8 @@ func (a *Alloc) signServices(
 					alloc.Job, alloc, &idReq.WIHandle, service.Identity).
 					WithConsul().
 					WithService(service).
+					WithNode(node).
+					WithClaims(claimsConfig.ClaimsFor(service.Identity)).
 					Build(now)
 				return true, a.signClaims(claims, idReq, reply)
 			}
@@ -658,6 +808,8 @@ func (a *Alloc) signServices(
 						WithTask(task).
 						WithConsul().
 						WithService(service).
+						WithNode(node).
+						WithClaims(claimsConfig.ClaimsFor(service.Identity)).
 						Build(now)
 					return true, a.signClaims(claims, idReq, reply)
 				}
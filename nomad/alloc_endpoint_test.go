@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
@@ -1187,6 +1188,122 @@ func TestAllocEndpoint_Stop_ACL(t *testing.T) {
 	require.True(*out2.DesiredTransition.Migrate)
 }
 
+func TestAllocEndpoint_Resize(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	state := s1.fsm.State()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 998, node))
+	must.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	testCases := []struct {
+		name        string
+		resources   map[string]*structs.Resources
+		expectedErr string
+	}{
+		{
+			name:        "unknown task",
+			resources:   map[string]*structs.Resources{"db": {CPU: 1000, MemoryMB: 512}},
+			expectedErr: `task "db" not found`,
+		},
+		{
+			name:        "below minimum",
+			resources:   map[string]*structs.Resources{"web": {CPU: 1000, MemoryMB: 5}},
+			expectedErr: "minimum MemoryMB value",
+		},
+		{
+			name:        "memory max without oversubscription",
+			resources:   map[string]*structs.Resources{"web": {CPU: 1000, MemoryMB: 512, MemoryMaxMB: 1024}},
+			expectedErr: "memory oversubscription is not enabled",
+		},
+		{
+			name:        "no headroom",
+			resources:   map[string]*structs.Resources{"web": {CPU: 100000, MemoryMB: 512}},
+			expectedErr: "insufficient resources to resize allocation: cpu",
+		},
+		{
+			name:      "fits",
+			resources: map[string]*structs.Resources{"web": {CPU: 1000, CPUMax: 2000, MemoryMB: 512}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &structs.AllocResizeRequest{
+				AllocID:   alloc.ID,
+				Resources: tc.resources,
+			}
+			req.Namespace = structs.DefaultNamespace
+			req.Region = alloc.Job.Region
+
+			var resp structs.AllocResizeResponse
+			err := msgpackrpc.CallWithCodec(codec, "Alloc.Resize", req, &resp)
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Positive(t, resp.Index)
+
+			out, err := state.AllocByID(nil, alloc.ID)
+			must.NoError(t, err)
+			must.Eq(t, resp.Index, out.AllocModifyIndex)
+			tr := out.AllocatedResources.Tasks["web"]
+			must.Eq(t, 1000, tr.Cpu.CpuShares)
+			must.Eq(t, 2000, tr.Cpu.CpuMax)
+			must.Eq(t, 512, tr.Memory.MemoryMB)
+			must.Len(t, 1, tr.Networks)
+		})
+	}
+}
+
+func TestAllocEndpoint_Resize_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	state := s1.fsm.State()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 998, node))
+	must.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	req := &structs.AllocResizeRequest{
+		AllocID:   alloc.ID,
+		Resources: map[string]*structs.Resources{"web": {CPU: 1000, MemoryMB: 512}},
+	}
+	req.Namespace = structs.DefaultNamespace
+	req.Region = alloc.Job.Region
+
+	// Try with alloc-lifecycle permissions
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityAllocLifecycle}))
+	req.AuthToken = invalidToken.SecretID
+	var resp structs.AllocResizeResponse
+	err := msgpackrpc.CallWithCodec(codec, "Alloc.Resize", req, &resp)
+	must.True(t, structs.IsErrPermissionDenied(err), must.Sprintf("expected permissions error, got: %v", err))
+
+	// Try with scale-job permissions
+	validToken := mock.CreatePolicyAndToken(t, state, 1002, "valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityScaleJob}))
+	req.AuthToken = validToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Alloc.Resize", req, &resp))
+	must.Positive(t, resp.Index)
+}
+
 func TestAllocEndpoint_List_AllNamespaces_ACL_OSS(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1874,3 +1991,70 @@ func TestAlloc_SignIdentities_Blocking(t *testing.T) {
 		t.Fatalf("result not returned when expected")
 	}
 }
+
+// TestAlloc_SignIdentities_Claims asserts the claim templates configured by
+// the cluster administrator are applied to the signed workload identities.
+func TestAlloc_SignIdentities_Claims(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.WorkloadIdentityClaims = &config.WorkloadIdentityClaimsConfig{
+			ExtraClaims: map[string]string{"team": "${job.meta.team}"},
+			Audiences: []*config.WorkloadIdentityAudienceConfig{{
+				Name:        "sts.amazonaws.com",
+				Subject:     "${job.namespace}:${job.id}",
+				ExtraClaims: map[string]string{"arch": "${node.attr.cpu.arch}"},
+			}},
+		}
+	})
+	t.Cleanup(cleanupS1)
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForKeyring(t, s1.RPC, s1.Region())
+	state := s1.fsm.State()
+
+	node := mock.Node()
+	node.Attributes["cpu.arch"] = "arm64"
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.Job.Meta = map[string]string{"team": "payments"}
+	alloc.Job.TaskGroups[0].Tasks[0].Identities = []*structs.WorkloadIdentity{
+		{Name: "aws", Audience: []string{"sts.amazonaws.com"}},
+		{Name: "other", Audience: []string{"example.com"}},
+	}
+	must.NoError(t, state.UpsertJobSummary(200, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 201, []*structs.Allocation{alloc}))
+
+	sign := func(name string) *structs.IdentityClaims {
+		req := &structs.AllocIdentitiesRequest{
+			Identities: []*structs.WorkloadIdentityRequest{{
+				AllocID: alloc.ID,
+				WIHandle: structs.WIHandle{
+					WorkloadIdentifier: "web",
+					IdentityName:       name,
+				},
+			}},
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+				AuthToken: node.SecretID,
+			},
+		}
+		var resp structs.AllocIdentitiesResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Alloc.SignIdentities", req, &resp))
+		must.Len(t, 1, resp.SignedIdentities)
+		claims, err := s1.encrypter.VerifyClaim(resp.SignedIdentities[0].JWT)
+		must.NoError(t, err)
+		return claims
+	}
+
+	claims := sign("aws")
+	must.Eq(t, "default:"+alloc.JobID, claims.Subject)
+	must.Eq(t, map[string]string{"team": "payments", "arch": "arm64"}, claims.ExtraClaims)
+
+	claims = sign("other")
+	must.StrHasSuffix(t, ":web:other", claims.Subject)
+	must.Eq(t, map[string]string{"team": "payments"}, claims.ExtraClaims)
+}
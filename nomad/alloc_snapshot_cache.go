@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+const (
+	// allocSnapshotCacheDir is the directory under the server data dir where
+	// the snapshots of allocation data relayed between clients are cached.
+	allocSnapshotCacheDir = "alloc_snapshots"
+
+	// allocSnapshotCacheChunkSize is the maximum size of the chunks served
+	// from the cache when the node of an allocation is unreachable.
+	allocSnapshotCacheChunkSize = 1 << 20
+
+	// allocSnapshotCacheTTL is how long a cached snapshot is kept after it
+	// was last written or read.
+	allocSnapshotCacheTTL = 72 * time.Hour
+
+	// allocSnapshotCacheMaxSize is the total size of the snapshots cached.
+	// The least recently used snapshots are removed to make room for new
+	// ones, and larger snapshots aren't cached.
+	allocSnapshotCacheMaxSize = 10 << 30
+)
+
+// cachedAllocSnapshot is a snapshot of the data of an allocation, written as
+// its chunks are relayed from the node of the allocation.
+type cachedAllocSnapshot struct {
+	path     string
+	size     int64
+	checksum string
+
+	// written is the number of bytes of the snapshot written so far
+	written int64
+
+	// complete is set once the whole snapshot is written and verified
+	complete bool
+
+	lastUsed time.Time
+}
+
+// allocSnapshotCache keeps the last snapshot of the data of each allocation
+// relayed through this server, so that sticky alloc dirs can still be
+// migrated once the node they were on becomes unreachable.
+type allocSnapshotCache struct {
+	dir     string
+	maxSize int64
+	logger  hclog.Logger
+
+	snapshots map[string]*cachedAllocSnapshot
+	lock      sync.Mutex
+}
+
+// newAllocSnapshotCache returns an allocSnapshotCache that writes snapshots
+// to dir. Snapshots left over from a previous run of the server are removed.
+func newAllocSnapshotCache(logger hclog.Logger, dir string) *allocSnapshotCache {
+	if err := os.RemoveAll(dir); err != nil {
+		logger.Warn("failed to remove previous alloc snapshots", "error", err)
+	}
+	return &allocSnapshotCache{
+		dir:       dir,
+		maxSize:   allocSnapshotCacheMaxSize,
+		logger:    logger.Named("alloc_snapshot_cache"),
+		snapshots: make(map[string]*cachedAllocSnapshot),
+	}
+}
+
+// store writes a chunk of the snapshot of an allocation relayed from its
+// node. Chunks which don't follow the data already written are ignored, and
+// a new snapshot of the allocation replaces the previous one.
+func (c *allocSnapshotCache) store(allocID string, offset int64, resp *cstructs.AllocSnapshotChunkResponse) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.pruneLocked()
+
+	snap, ok := c.snapshots[allocID]
+	if !ok || snap.checksum != resp.Checksum {
+		if offset != 0 {
+			return
+		}
+		c.removeLocked(allocID)
+		if resp.Size > c.maxSize {
+			c.logger.Debug("alloc snapshot too large to cache", "alloc_id", allocID, "size", resp.Size)
+			return
+		}
+		c.evictLocked(resp.Size)
+		snap = &cachedAllocSnapshot{
+			path:     filepath.Join(c.dir, allocID+".tar"),
+			size:     resp.Size,
+			checksum: resp.Checksum,
+		}
+		if err := os.MkdirAll(c.dir, 0o700); err != nil {
+			c.logger.Warn("failed to create alloc snapshots dir", "error", err)
+			return
+		}
+		if err := os.WriteFile(snap.path, nil, 0o600); err != nil {
+			c.logger.Warn("failed to create alloc snapshot", "alloc_id", allocID, "error", err)
+			return
+		}
+		c.snapshots[allocID] = snap
+	}
+	snap.lastUsed = time.Now()
+
+	if snap.complete || offset != snap.written || len(resp.Data) == 0 {
+		return
+	}
+
+	if err := c.appendLocked(snap, resp.Data); err != nil {
+		c.logger.Warn("failed to write alloc snapshot", "alloc_id", allocID, "error", err)
+		c.removeLocked(allocID)
+		return
+	}
+	if snap.written < snap.size {
+		return
+	}
+
+	if err := snap.verify(); err != nil {
+		c.logger.Warn("failed to verify alloc snapshot", "alloc_id", allocID, "error", err)
+		c.removeLocked(allocID)
+		return
+	}
+	snap.complete = true
+	c.logger.Debug("cached alloc snapshot", "alloc_id", allocID, "size", snap.size)
+}
+
+// appendLocked appends data to the snapshot. The lock must be held.
+func (c *allocSnapshotCache) appendLocked(snap *cachedAllocSnapshot, data []byte) error {
+	if snap.written+int64(len(data)) > snap.size {
+		return fmt.Errorf("data exceeds snapshot size of %d bytes", snap.size)
+	}
+	f, err := os.OpenFile(snap.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	snap.written += int64(len(data))
+	return nil
+}
+
+// read fills reply with the chunk of the cached snapshot of an allocation
+// starting at offset. It returns false if no complete snapshot of the
+// allocation is cached.
+func (c *allocSnapshotCache) read(allocID string, offset int64, reply *cstructs.AllocSnapshotChunkResponse) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	snap, ok := c.snapshots[allocID]
+	if !ok || !snap.complete {
+		return false, nil
+	}
+	snap.lastUsed = time.Now()
+
+	if offset < 0 || offset > snap.size {
+		return true, fmt.Errorf("offset %d is outside of snapshot of %d bytes", offset, snap.size)
+	}
+
+	f, err := os.Open(snap.path)
+	if err != nil {
+		return true, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, min(allocSnapshotCacheChunkSize, snap.size-offset))
+	n, err := f.ReadAt(buf, offset)
+	if err != nil && err != io.EOF {
+		return true, err
+	}
+
+	reply.Data = buf[:n]
+	reply.Size = snap.size
+	reply.Checksum = snap.checksum
+	return true, nil
+}
+
+// pruneLocked removes the snapshots which weren't used within
+// allocSnapshotCacheTTL. The lock must be held.
+func (c *allocSnapshotCache) pruneLocked() {
+	cutoff := time.Now().Add(-allocSnapshotCacheTTL)
+	for allocID, snap := range c.snapshots {
+		if snap.lastUsed.Before(cutoff) {
+			c.removeLocked(allocID)
+		}
+	}
+}
+
+// evictLocked removes the least recently used snapshots until a snapshot of
+// the given size fits in the cache. The lock must be held.
+func (c *allocSnapshotCache) evictLocked(size int64) {
+	var used int64
+	for _, snap := range c.snapshots {
+		used += snap.size
+	}
+	for used+size > c.maxSize && len(c.snapshots) != 0 {
+		var oldestID string
+		var oldest *cachedAllocSnapshot
+		for allocID, snap := range c.snapshots {
+			if oldest == nil || snap.lastUsed.Before(oldest.lastUsed) {
+				oldestID, oldest = allocID, snap
+			}
+		}
+		used -= oldest.size
+		c.removeLocked(oldestID)
+	}
+}
+
+// removeLocked deletes the cached snapshot of the allocation, if any. The
+// lock must be held.
+func (c *allocSnapshotCache) removeLocked(allocID string) {
+	snap, ok := c.snapshots[allocID]
+	if !ok {
+		return
+	}
+	delete(c.snapshots, allocID)
+	if err := os.Remove(snap.path); err != nil && !os.IsNotExist(err) {
+		c.logger.Warn("failed to remove alloc snapshot", "alloc_id", allocID, "error", err)
+	}
+}
+
+// verify checks the snapshot against its checksum.
+func (snap *cachedAllocSnapshot) verify() error {
+	f, err := os.Open(snap.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != snap.checksum {
+		return fmt.Errorf("expected checksum %s, got %s", snap.checksum, actual)
+	}
+	return nil
+}
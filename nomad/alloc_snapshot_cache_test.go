@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/shoenig/test/must"
+)
+
+func TestAllocSnapshotCache(t *testing.T) {
+	ci.Parallel(t)
+
+	cache := newAllocSnapshotCache(testlog.HCLogger(t), t.TempDir())
+	allocID := uuid.Generate()
+
+	data := []byte("snapshot of the alloc dir")
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	chunk := func(offset, end int) *cstructs.AllocSnapshotChunkResponse {
+		return &cstructs.AllocSnapshotChunkResponse{
+			Data:     data[offset:end],
+			Size:     int64(len(data)),
+			Checksum: checksum,
+		}
+	}
+
+	// nothing is served until the snapshot is complete
+	cache.store(allocID, 0, chunk(0, 10))
+	var reply cstructs.AllocSnapshotChunkResponse
+	ok, err := cache.read(allocID, 0, &reply)
+	must.NoError(t, err)
+	must.False(t, ok)
+
+	// chunks which don't follow the data written are ignored
+	cache.store(allocID, 15, chunk(15, len(data)))
+	ok, _ = cache.read(allocID, 0, &reply)
+	must.False(t, ok)
+
+	cache.store(allocID, 10, chunk(10, len(data)))
+	ok, err = cache.read(allocID, 0, &reply)
+	must.NoError(t, err)
+	must.True(t, ok)
+	must.Eq(t, data, reply.Data)
+	must.Eq(t, checksum, reply.Checksum)
+
+	ok, err = cache.read(allocID, 9, &reply)
+	must.NoError(t, err)
+	must.True(t, ok)
+	must.Eq(t, data[9:], reply.Data)
+
+	// a snapshot failing verification is dropped
+	otherID := uuid.Generate()
+	corrupt := chunk(0, len(data))
+	corrupt.Data = []byte("snapshot of another dir!!")
+	cache.store(otherID, 0, corrupt)
+	ok, _ = cache.read(otherID, 0, &reply)
+	must.False(t, ok)
+
+	// a new snapshot of the alloc replaces the previous one
+	cache.store(allocID, 0, &cstructs.AllocSnapshotChunkResponse{
+		Data:     data[:5],
+		Size:     int64(len(data)),
+		Checksum: "other",
+	})
+	ok, _ = cache.read(allocID, 0, &reply)
+	must.False(t, ok)
+}
+
+func TestAllocSnapshotCache_MaxSize(t *testing.T) {
+	ci.Parallel(t)
+
+	cache := newAllocSnapshotCache(testlog.HCLogger(t), t.TempDir())
+	cache.maxSize = 50
+
+	storeSnapshot := func(data []byte) string {
+		allocID := uuid.Generate()
+		sum := sha256.Sum256(data)
+		cache.store(allocID, 0, &cstructs.AllocSnapshotChunkResponse{
+			Data:     data,
+			Size:     int64(len(data)),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+		return allocID
+	}
+	cached := func(allocID string) bool {
+		var reply cstructs.AllocSnapshotChunkResponse
+		ok, err := cache.read(allocID, 0, &reply)
+		must.NoError(t, err)
+		return ok
+	}
+
+	// snapshots larger than the cache aren't cached
+	must.False(t, cached(storeSnapshot(make([]byte, 51))))
+
+	// the least recently used snapshots make room for new ones
+	first := storeSnapshot(make([]byte, 20))
+	second := storeSnapshot(make([]byte, 20))
+	must.True(t, cached(first))
+	third := storeSnapshot(make([]byte, 20))
+	must.True(t, cached(first))
+	must.False(t, cached(second))
+	must.True(t, cached(third))
+
+	// data exceeding the size of the snapshot drops it
+	allocID := uuid.Generate()
+	cache.store(allocID, 0, &cstructs.AllocSnapshotChunkResponse{
+		Data:     make([]byte, 10),
+		Size:     5,
+		Checksum: "checksum",
+	})
+	must.MapNotContainsKey(t, cache.snapshots, allocID)
+}
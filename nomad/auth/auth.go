@@ -121,10 +121,14 @@ func (s *Authenticator) Authenticate(ctx RPCContext, args structs.RequestWithIde
 	case err == nil:
 		// ACLs are enabled and we have a non-anonymous token, so set that as
 		// our identity and return
+		if err := s.checkTokenSource(ctx, args, aclToken); err != nil {
+			return err
+		}
 		args.SetIdentity(&structs.AuthenticatedIdentity{ACLToken: aclToken})
 		return nil
 
-	case errors.Is(err, structs.ErrTokenExpired):
+	case errors.Is(err, structs.ErrTokenExpired),
+		errors.Is(err, structs.ErrTokenNotYetValid):
 		return err
 
 	case errors.Is(err, structs.ErrTokenInvalid):
@@ -204,6 +208,58 @@ func (s *Authenticator) Authenticate(ctx RPCContext, args structs.RequestWithIde
 	return nil
 }
 
+// checkTokenSource returns ErrPermissionDenied if the ACL token is restricted
+// to CIDR blocks that don't include the source address of the request. That's
+// the remote address of the RPC connection, or the address of the HTTP client
+// for RPCs made in-process by the HTTP API of the server. RPCs forwarded by
+// other servers have been checked by the server that first received them.
+func (s *Authenticator) checkTokenSource(ctx RPCContext, args structs.RequestWithIdentity, aclToken *structs.ACLToken) error {
+	if len(aclToken.AllowedCIDRs) == 0 {
+		return nil
+	}
+
+	var remoteIP net.IP
+	if ctx.IsStatic() {
+		src, ok := args.(interface{ GetSourceIP() net.IP })
+		if !ok || src.GetSourceIP() == nil {
+			// made internally by the agent rather than for an HTTP client
+			return nil
+		}
+		remoteIP = src.GetSourceIP()
+	} else {
+		if fwd, ok := args.(interface{ IsForwarded() bool }); ok && fwd.IsForwarded() && s.isServerConn(ctx) {
+			return nil
+		}
+		var err error
+		remoteIP, err = ctx.GetRemoteIP()
+		if err != nil {
+			s.logger.Error("could not determine remote address", "error", err)
+		}
+	}
+
+	if !aclToken.AllowsSourceIP(remoteIP) {
+		s.logger.Debug("ACL token not allowed from source address",
+			"accessor_id", aclToken.AccessorID, "remote_ip", remoteIP)
+		return structs.ErrPermissionDenied
+	}
+	return nil
+}
+
+// isServerConn returns whether the RPC connection is from a server. Without
+// mTLS any connection can claim to be a server, which is documented in the
+// Security Model.
+func (s *Authenticator) isServerConn(ctx RPCContext) bool {
+	if !s.verifyTLS {
+		return true
+	}
+	tlsCert := ctx.Certificate()
+	if tlsCert == nil {
+		return false
+	}
+	_, err := validateCertificateForNames(tlsCert, s.validServerCertNames)
+	return err == nil
+}
+
 // ResolveACL is an authentication wrapper which handles resolving ACL tokens,
 // Workload Identities, or client secrets into acl.ACL objects. Exclusively
 // server-to-server or client-to-server requests should be using
@@ -419,6 +475,14 @@ func (s *Authenticator) ResolveToken(secretID string) (*acl.ACL, error) {
 	return resolveTokenFromSnapshotCache(snap, s.aclCache, secretID)
 }
 
+// ResolveSecretToken translates an ACL token secret ID into the ACL token, the
+// anonymous token, or an error. The HTTP API uses it to check the restrictions
+// of the token that are not part of its ACL object, such as the allowed source
+// addresses.
+func (s *Authenticator) ResolveSecretToken(secretID string) (*structs.ACLToken, error) {
+	return s.resolveSecretToken(secretID)
+}
+
 // VerifyClaim asserts that the token is valid and that the resulting allocation
 // ID belongs to a non-terminal allocation. This should usually not be called by
 // RPC handlers, and exists only to support the ACL.WhoAmI endpoint.
@@ -486,6 +550,9 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *structs.ACL
 		if token.IsExpired(time.Now().UTC()) {
 			return nil, structs.ErrTokenExpired
 		}
+		if token.IsNotYetValid(time.Now().UTC()) {
+			return nil, structs.ErrTokenNotYetValid
+		}
 	}
 
 	return resolveACLFromToken(snap, cache, token)
@@ -594,6 +661,9 @@ func (s *Authenticator) resolveSecretToken(secretID string) (*structs.ACLToken,
 	if token.IsExpired(time.Now().UTC()) {
 		return nil, structs.ErrTokenExpired
 	}
+	if token.IsNotYetValid(time.Now().UTC()) {
+		return nil, structs.ErrTokenNotYetValid
+	}
 
 	return token, nil
 }
@@ -219,6 +219,78 @@ func TestAuthenticateDefault(t *testing.T) {
 				must.False(t, aclObj.AllowAgentRead())
 			},
 		},
+		{
+			name: "mTLS and ACLs with not yet valid ACL token",
+			testFn: func(t *testing.T, store *state.StateStore) {
+				token2 := mock.ACLToken()
+				token2.NotBefore = pointer.Of(time.Now().UTC().Add(time.Hour))
+				store.UpsertACLTokens(structs.MsgTypeTestSetup, 100, []*structs.ACLToken{
+					token2,
+				})
+
+				ctx := newTestContext(t, "cli.nomad.global", "192.168.1.1")
+				args := &structs.GenericRequest{}
+				args.AuthToken = token2.SecretID
+
+				auth := testAuthenticator(t, store, true, true)
+
+				err := auth.Authenticate(ctx, args)
+				must.ErrorIs(t, err, structs.ErrTokenNotYetValid)
+				must.Eq(t, "unauthenticated", args.GetIdentity().String())
+
+				_, err = auth.ResolveToken(token2.SecretID)
+				must.ErrorIs(t, err, structs.ErrTokenNotYetValid)
+			},
+		},
+		{
+			name: "mTLS and ACLs with ACL token restricted to CIDRs",
+			testFn: func(t *testing.T, store *state.StateStore) {
+				token := mock.ACLToken()
+				token.AllowedCIDRs = []string{"10.0.0.0/8"}
+				store.UpsertACLTokens(structs.MsgTypeTestSetup, 100, []*structs.ACLToken{
+					token,
+				})
+
+				auth := testAuthenticator(t, store, true, true)
+
+				// the remote address of the connection is checked
+				ctx := newTestContext(t, "cli.nomad.global", "192.168.1.1")
+				args := &structs.GenericRequest{}
+				args.AuthToken = token.SecretID
+				err := auth.Authenticate(ctx, args)
+				must.ErrorIs(t, err, structs.ErrPermissionDenied)
+
+				ctx = newTestContext(t, "cli.nomad.global", "10.1.2.3")
+				err = auth.Authenticate(ctx, args)
+				must.NoError(t, err)
+				must.Eq(t, "token:"+token.AccessorID, args.GetIdentity().String())
+
+				// requests forwarded by servers were checked by them, but
+				// clients can't claim to forward requests
+				ctx = newTestContext(t, "server.global.nomad", "192.168.1.1")
+				args = &structs.GenericRequest{}
+				args.AuthToken = token.SecretID
+				args.SetForwarded()
+				err = auth.Authenticate(ctx, args)
+				must.NoError(t, err)
+
+				ctx = newTestContext(t, "cli.nomad.global", "192.168.1.1")
+				err = auth.Authenticate(ctx, args)
+				must.ErrorIs(t, err, structs.ErrPermissionDenied)
+
+				// in-process requests check the address of the HTTP client
+				var staticCtx *testContext
+				args = &structs.GenericRequest{}
+				args.AuthToken = token.SecretID
+				args.SetSourceIP(net.ParseIP("192.168.1.1"))
+				err = auth.Authenticate(staticCtx, args)
+				must.ErrorIs(t, err, structs.ErrPermissionDenied)
+
+				args.SetSourceIP(net.ParseIP("10.1.2.3"))
+				err = auth.Authenticate(staticCtx, args)
+				must.NoError(t, err)
+			},
+		},
 		{
 			name: "mTLS but no ACLs with valid ACL token",
 			testFn: func(t *testing.T, store *state.StateStore) {
@@ -0,0 +1,308 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// autoscalerTolerance is how far from its target the utilization of a group
+// may be, as a ratio of the target, before the built-in autoscaler scales it.
+// It avoids scaling groups back and forth around their target.
+const autoscalerTolerance = 0.1
+
+const (
+	// autoscalerStatsParallelism is the number of allocations of a group the
+	// built-in autoscaler fetches the resource usage of concurrently.
+	autoscalerStatsParallelism = 8
+
+	// autoscalerStatsTimeout is how long the built-in autoscaler waits for the
+	// resource usage of an allocation, so that unresponsive clients don't
+	// stall the autoscaling of every group.
+	autoscalerStatsTimeout = 5 * time.Second
+)
+
+// runBuiltinAutoscaler periodically scales the task groups with a target
+// tracking scaling policy, so that the average utilization of their
+// allocations stays close to the targets. It is only run on the leader.
+func (s *Server) runBuiltinAutoscaler(stopCh chan struct{}) {
+	ticker := time.NewTicker(s.config.AutoscalerInterval)
+	defer ticker.Stop()
+
+	logger := s.logger.Named("autoscaler")
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		snap, err := s.State().Snapshot()
+		if err != nil {
+			logger.Error("failed to get state", "error", err)
+			continue
+		}
+		iter, err := snap.ScalingPolicies(memdb.NewWatchSet())
+		if err != nil {
+			logger.Error("failed to get scaling policies", "error", err)
+			continue
+		}
+
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			policy := raw.(*structs.ScalingPolicy)
+			if !policy.Enabled || policy.Type != structs.ScalingPolicyTypeHorizontal {
+				continue
+			}
+			tt, err := policy.TargetTracking()
+			if err != nil || tt == nil {
+				continue
+			}
+
+			if err := s.autoscaleGroup(snap, policy, tt); err != nil {
+				logger.Warn("failed to autoscale task group",
+					"namespace", policy.Target[structs.ScalingTargetNamespace],
+					"job", policy.Target[structs.ScalingTargetJob],
+					"group", policy.Target[structs.ScalingTargetGroup],
+					"error", err)
+			}
+		}
+	}
+}
+
+// autoscaleGroup scales the target group of the policy to the count tracking
+// the CPU and memory utilization targets, unless it was scaled during the
+// cooldown of the policy.
+func (s *Server) autoscaleGroup(snap *state.StateSnapshot, policy *structs.ScalingPolicy, tt *structs.ScalingTargetTracking) error {
+	namespace := policy.Target[structs.ScalingTargetNamespace]
+	jobID := policy.Target[structs.ScalingTargetJob]
+	group := policy.Target[structs.ScalingTargetGroup]
+
+	job, err := snap.JobByID(nil, namespace, jobID)
+	if err != nil {
+		return err
+	}
+	if job == nil || job.Stopped() {
+		return nil
+	}
+	tg := job.LookupTaskGroup(group)
+	if tg == nil || tg.Count == 0 {
+		return nil
+	}
+
+	events, _, err := snap.ScalingEventsByJob(nil, namespace, jobID)
+	if err != nil {
+		return err
+	}
+	for _, event := range events[group] {
+		if time.Since(time.Unix(0, event.Time)) < tt.Cooldown {
+			return nil
+		}
+	}
+
+	allocs, err := snap.AllocsByJob(nil, namespace, jobID, false)
+	if err != nil {
+		return err
+	}
+	var running []*structs.Allocation
+	for _, alloc := range allocs {
+		if alloc.TaskGroup == group && !alloc.TerminalStatus() &&
+			alloc.ClientStatus == structs.AllocClientStatusRunning {
+			running = append(running, alloc)
+		}
+	}
+	usages := fetchAllocResourceUsages(running, autoscalerStatsParallelism, autoscalerStatsTimeout,
+		func(alloc *structs.Allocation) (*cstructs.AllocResourceUsage, error) {
+			usage, err := s.allocResourceUsage(alloc)
+			if err != nil {
+				s.logger.Named("autoscaler").Debug("failed to get allocation resource usage",
+					"alloc_id", alloc.ID, "error", err)
+			}
+			return usage, err
+		})
+
+	var cpu, memory []float64
+	for i, alloc := range running {
+		if usages[i] == nil {
+			continue
+		}
+		c, m := allocUtilization(alloc, usages[i])
+		cpu = append(cpu, c)
+		memory = append(memory, m)
+	}
+	if len(cpu) == 0 {
+		return nil
+	}
+
+	current := int64(tg.Count)
+	cpuUtilization, memoryUtilization := mean(cpu), mean(memory)
+
+	// scale to the largest count required by the tracked targets
+	var counts []int64
+	if tt.CPU > 0 {
+		counts = append(counts, targetTrackingCount(current, cpuUtilization, tt.CPU))
+	}
+	if tt.Memory > 0 {
+		counts = append(counts, targetTrackingCount(current, memoryUtilization, tt.Memory))
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	count := min(max(slices.Max(counts), policy.Min), policy.Max)
+	if count == current {
+		return nil
+	}
+
+	s.logger.Named("autoscaler").Info("scaling task group", "namespace", namespace,
+		"job", jobID, "group", group, "from", current, "to", count,
+		"cpu_utilization", cpuUtilization, "memory_utilization", memoryUtilization)
+
+	req := &structs.JobScaleRequest{
+		JobID:  jobID,
+		Target: map[string]string{structs.ScalingTargetGroup: group},
+		Count:  &count,
+		Message: fmt.Sprintf("Scaled by the built-in autoscaler, CPU utilization %.0f%%, memory utilization %.0f%%",
+			cpuUtilization, memoryUtilization),
+		Meta: map[string]interface{}{
+			"cpu_utilization":    cpuUtilization,
+			"memory_utilization": memoryUtilization,
+		},
+		EnforceIndex:   true,
+		JobModifyIndex: job.JobModifyIndex,
+		WriteRequest: structs.WriteRequest{
+			Region:    s.Region(),
+			Namespace: namespace,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+	var resp structs.JobRegisterResponse
+	return s.RPC("Job.Scale", req, &resp)
+}
+
+// allocResourceUsage returns the latest resource usage of the allocation, as
+// collected by its client.
+func (s *Server) allocResourceUsage(alloc *structs.Allocation) (*cstructs.AllocResourceUsage, error) {
+	req := &cstructs.AllocStatsRequest{
+		AllocID: alloc.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:     s.Region(),
+			Namespace:  alloc.Namespace,
+			AuthToken:  s.getLeaderAcl(),
+			AllowStale: true,
+		},
+	}
+	var resp cstructs.AllocStatsResponse
+	if err := s.RPC("ClientAllocations.Stats", req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Stats == nil {
+		return nil, fmt.Errorf("no resource usage reported")
+	}
+	return resp.Stats, nil
+}
+
+// fetchAllocResourceUsages returns the resource usage of each allocation,
+// fetched by up to parallelism concurrent calls to fetch. The usage of the
+// allocations that fail to be fetched within the timeout is nil.
+func fetchAllocResourceUsages(allocs []*structs.Allocation, parallelism int, timeout time.Duration,
+	fetch func(*structs.Allocation) (*cstructs.AllocResourceUsage, error)) []*cstructs.AllocResourceUsage {
+
+	usages := make([]*cstructs.AllocResourceUsage, len(allocs))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, alloc := range allocs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			// the fetch is abandoned once timed out, so it reports to a
+			// buffered channel
+			resultCh := make(chan *cstructs.AllocResourceUsage, 1)
+			go func() {
+				usage, err := fetch(alloc)
+				if err != nil {
+					usage = nil
+				}
+				resultCh <- usage
+			}()
+
+			timer, stop := helper.NewSafeTimer(timeout)
+			defer stop()
+			select {
+			case usages[i] = <-resultCh:
+			case <-timer.C:
+			}
+		}()
+	}
+	wg.Wait()
+	return usages
+}
+
+// allocUtilization returns the CPU and memory used by the tasks of the
+// allocation, as percentages of the CPU and memory they reserve.
+func allocUtilization(alloc *structs.Allocation, usage *cstructs.AllocResourceUsage) (float64, float64) {
+	if alloc.AllocatedResources == nil {
+		return 0, 0
+	}
+
+	var cpuUsed, cpuReserved, memoryUsed, memoryReserved float64
+	for name, resources := range alloc.AllocatedResources.Tasks {
+		cpuReserved += float64(resources.Cpu.CpuShares)
+		memoryReserved += float64(resources.Memory.MemoryMB * structs.BytesInMegabyte)
+
+		taskUsage, ok := usage.Tasks[name]
+		if !ok || taskUsage == nil || taskUsage.ResourceUsage == nil {
+			continue
+		}
+		if stats := taskUsage.ResourceUsage.CpuStats; stats != nil {
+			cpuUsed += stats.TotalTicks
+		}
+		if stats := taskUsage.ResourceUsage.MemoryStats; stats != nil {
+			used := stats.Usage
+			if used == 0 {
+				used = stats.RSS
+			}
+			memoryUsed += float64(used)
+		}
+	}
+
+	var cpu, memory float64
+	if cpuReserved > 0 {
+		cpu = cpuUsed / cpuReserved * 100
+	}
+	if memoryReserved > 0 {
+		memory = memoryUsed / memoryReserved * 100
+	}
+	return cpu, memory
+}
+
+// targetTrackingCount returns the count of a group bringing its utilization
+// to the target, or its current count if the utilization is within the
+// tolerance of the target.
+func targetTrackingCount(current int64, utilization, target float64) int64 {
+	ratio := utilization / target
+	if math.Abs(ratio-1) <= autoscalerTolerance {
+		return current
+	}
+	return int64(math.Ceil(float64(current) * ratio))
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
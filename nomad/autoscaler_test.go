@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestAutoscaler_targetTrackingCount(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		current     int64
+		utilization float64
+		target      float64
+		expected    int64
+	}{
+		{name: "on target", current: 4, utilization: 50, target: 50, expected: 4},
+		{name: "within tolerance", current: 4, utilization: 54, target: 50, expected: 4},
+		{name: "scale out", current: 4, utilization: 90, target: 60, expected: 6},
+		{name: "scale out rounds up", current: 3, utilization: 70, target: 50, expected: 5},
+		{name: "scale in", current: 10, utilization: 20, target: 80, expected: 3},
+		{name: "idle", current: 5, utilization: 0, target: 50, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, targetTrackingCount(tc.current, tc.utilization, tc.target))
+		})
+	}
+}
+
+func TestAutoscaler_allocUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Tasks = map[string]*structs.AllocatedTaskResources{
+		"web": {
+			Cpu:    structs.AllocatedCpuResources{CpuShares: 500},
+			Memory: structs.AllocatedMemoryResources{MemoryMB: 256},
+		},
+		"sidecar": {
+			Cpu:    structs.AllocatedCpuResources{CpuShares: 500},
+			Memory: structs.AllocatedMemoryResources{MemoryMB: 256},
+		},
+	}
+
+	usage := &cstructs.AllocResourceUsage{
+		Tasks: map[string]*cstructs.TaskResourceUsage{
+			"web": {
+				ResourceUsage: &cstructs.ResourceUsage{
+					CpuStats:    &cstructs.CpuStats{TotalTicks: 600},
+					MemoryStats: &cstructs.MemoryStats{Usage: 256 * 1024 * 1024},
+				},
+			},
+			// the RSS is used when the usage isn't measured
+			"sidecar": {
+				ResourceUsage: &cstructs.ResourceUsage{
+					CpuStats:    &cstructs.CpuStats{TotalTicks: 200},
+					MemoryStats: &cstructs.MemoryStats{RSS: 128 * 1024 * 1024},
+				},
+			},
+		},
+	}
+
+	cpu, memory := allocUtilization(alloc, usage)
+	must.Eq(t, 80, cpu)
+	must.Eq(t, 75, memory)
+
+	// tasks without usage count as idle
+	delete(usage.Tasks, "sidecar")
+	cpu, memory = allocUtilization(alloc, usage)
+	must.Eq(t, 60, cpu)
+	must.Eq(t, 50, memory)
+}
+
+func TestAutoscaler_fetchAllocResourceUsages(t *testing.T) {
+	ci.Parallel(t)
+
+	allocs := []*structs.Allocation{mock.Alloc(), mock.Alloc(), mock.Alloc(), mock.Alloc()}
+	hung := allocs[1].ID
+	failed := allocs[2].ID
+	unblockCh := make(chan struct{})
+	t.Cleanup(func() { close(unblockCh) })
+
+	var inflight, maxInflight atomic.Int32
+	fetch := func(alloc *structs.Allocation) (*cstructs.AllocResourceUsage, error) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			m := maxInflight.Load()
+			if n <= m || maxInflight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		switch alloc.ID {
+		case hung:
+			<-unblockCh
+		case failed:
+			return nil, errors.New("client unreachable")
+		}
+		time.Sleep(10 * time.Millisecond)
+		return &cstructs.AllocResourceUsage{Timestamp: 1}, nil
+	}
+
+	// allocations whose usage fails or times out to be fetched have no usage,
+	// and a hung client doesn't block the other fetches
+	usages := fetchAllocResourceUsages(allocs, 2, 100*time.Millisecond, fetch)
+	must.Len(t, 4, usages)
+	must.NotNil(t, usages[0])
+	must.Nil(t, usages[1])
+	must.Nil(t, usages[2])
+	must.NotNil(t, usages[3])
+	must.LessEq(t, 2, maxInflight.Load())
+}
@@ -9,6 +9,8 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -475,6 +477,140 @@ func (a *ClientAllocations) Checks(args *cstructs.AllocChecksRequest, reply *cst
 	return NodeRpc(state.Session, "Allocations.Checks", args, reply)
 }
 
+// TemplateDebug is the server implementation of the allocation template
+// debug RPC. The templates are rendered by the node running the allocation.
+func (a *ClientAllocations) TemplateDebug(args *cstructs.AllocTemplateDebugRequest, reply *cstructs.AllocTemplateDebugResponse) error {
+
+	// We only allow stale reads since the only potentially stale information
+	// is the Node registration and the cost is fairly high for adding another
+	// hop in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	authErr := a.srv.Authenticate(nil, args)
+
+	// Potentially forward to a different region.
+	if done, err := a.srv.forward("ClientAllocations.TemplateDebug", args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("client_allocations", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_allocations", "template_debug"}, time.Now())
+
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	alloc, err := getAlloc(snap, args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Rendered templates may contain secrets, so check for the same
+	// permission required to read them from the allocation filesystem.
+	if aclObj, err := a.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadFS) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Make sure Node is valid and new enough to support RPC.
+	if _, err = getNodeForRpc(snap, alloc.NodeID); err != nil {
+		return err
+	}
+
+	// Get the connection to the client.
+	state, ok := a.srv.getNodeConn(alloc.NodeID)
+	if !ok {
+		return findNodeConnAndForward(a.srv, alloc.NodeID, "ClientAllocations.TemplateDebug", args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, "Allocations.TemplateDebug", args, reply)
+}
+
+// SnapshotChunk is used by clients to download a chunk of the snapshot of the
+// data of an allocation from the node it ran on, so that sticky alloc dirs
+// are migrated through the servers rather than between clients.
+func (a *ClientAllocations) SnapshotChunk(args *cstructs.AllocSnapshotChunkRequest, reply *cstructs.AllocSnapshotChunkResponse) error {
+	// We only allow stale reads since the only potentially stale information
+	// is the Node registration and the cost is fairly high for adding another
+	// hop in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	authErr := a.srv.Authenticate(nil, args)
+
+	// Potentially forward to a different region.
+	if done, err := a.srv.forward("ClientAllocations.SnapshotChunk", args, args, reply); done {
+		return err
+	}
+	a.srv.MeasureRPCRate("client_allocations", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_allocations", "snapshot_chunk"}, time.Now())
+
+	// Only clients migrate allocation data, with the migrate token of the
+	// allocation.
+	if aclObj, err := a.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowClientOp() {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	alloc, err := getAlloc(snap, args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Make sure Node is valid and new enough to support RPC
+	node, err := getNodeForRpc(snap, alloc.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// Check the migrate token here too, as the cached snapshot is served
+	// without reaching the node
+	if !structs.CompareMigrateToken(alloc.ID, node.SecretID, args.MigrateToken) {
+		return structs.ErrPermissionDenied
+	}
+
+	// If the node is unreachable, serve the last snapshot of the allocation
+	// relayed through this server, if any
+	if node.Status == structs.NodeStatusDown || node.Status == structs.NodeStatusDisconnected {
+		if ok, err := a.srv.allocSnapshots.read(args.AllocID, args.Offset, reply); ok {
+			return err
+		}
+		return fmt.Errorf("%w: node %s is %s", structs.ErrNoNodeConn, node.ID, node.Status)
+	}
+
+	// Get the connection to the client
+	state, ok := a.srv.getNodeConn(alloc.NodeID)
+	if !ok {
+		err = findNodeConnAndForward(a.srv, alloc.NodeID, "ClientAllocations.SnapshotChunk", args, reply)
+	} else {
+		err = NodeRpc(state.Session, "Allocations.SnapshotChunk", args, reply)
+	}
+	if err != nil {
+		if structs.IsErrNoNodeConn(err) {
+			if ok, err := a.srv.allocSnapshots.read(args.AllocID, args.Offset, reply); ok {
+				return err
+			}
+		}
+		return err
+	}
+
+	a.srv.allocSnapshots.store(args.AllocID, args.Offset, reply)
+	return nil
+}
+
 // exec is used to execute command in a running task
 func (a *ClientAllocations) exec(conn io.ReadWriteCloser) {
 	defer conn.Close()
@@ -588,6 +724,32 @@ func (a *ClientAllocations) exec(conn io.ReadWriteCloser) {
 		return
 	}
 
+	// Refuse the session if it must be recorded and the node doesn't record
+	// exec sessions. The client refuses it as well in case its config changed
+	// since it fingerprinted.
+	recorded := node.Attributes["nomad.exec_session_recording"] == "true"
+	if a.srv.config.RequireExecSessionRecording {
+		if !recorded {
+			handleStreamResultError(
+				fmt.Errorf("exec sessions must be recorded but node %s doesn't record them", nodeID),
+				pointer.Of(int64(http.StatusBadRequest)), encoder)
+			return
+		}
+		args.RecordingRequired = true
+	}
+
+	if err := a.srv.auditStreamingRPC(conn, "Allocations.Exec", &args, map[string]string{
+		"alloc_id":  alloc.ID,
+		"node_id":   nodeID,
+		"task":      args.Task,
+		"command":   strings.Join(args.Cmd, " "),
+		"action":    args.Action,
+		"recording": strconv.FormatBool(recorded),
+	}); err != nil {
+		handleStreamResultError(err, pointer.Of(int64(http.StatusInternalServerError)), encoder)
+		return
+	}
+
 	// Get the connection to the client either by forwarding to another server
 	// or creating a direct stream
 	var clientConn net.Conn
@@ -4,6 +4,8 @@
 package nomad
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -1309,6 +1311,89 @@ OUTER:
 	}
 }
 
+// TestAlloc_ExecStreaming_RecordingRequired asserts that servers requiring exec
+// session recording refuse the sessions to nodes that don't record them, and
+// audit the sessions they forward.
+func TestAlloc_ExecStreaming_RecordingRequired(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, func(c *Config) {
+		c.RequireExecSessionRecording = true
+	})
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	auditor := &testAuditor{endpoint: "Allocations.Exec"}
+	s.SetAuditor(auditor)
+
+	node := mock.Node()
+	node.Attributes["nomad.version"] = "1.10.0"
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.ClientStatus = nstructs.AllocClientStatusRunning
+
+	state := s.State()
+	must.NoError(t, state.UpsertNode(nstructs.MsgTypeTestSetup, 1000, node))
+	must.NoError(t, state.UpsertJob(nstructs.MsgTypeTestSetup, 1001, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(nstructs.MsgTypeTestSetup, 1002, []*nstructs.Allocation{alloc}))
+
+	exec := func() error {
+		req := &cstructs.AllocExecRequest{
+			AllocID:      alloc.ID,
+			Task:         alloc.Job.TaskGroups[0].Tasks[0].Name,
+			Cmd:          []string{"/bin/sh"},
+			QueryOptions: nstructs.QueryOptions{Region: "global"},
+		}
+
+		handler, err := s.StreamingRpcHandler("Allocations.Exec")
+		must.NoError(t, err)
+
+		p1, p2 := net.Pipe()
+		defer p1.Close()
+		defer p2.Close()
+
+		errCh := make(chan error)
+		frames := make(chan *drivers.ExecTaskStreamingResponseMsg)
+		go handler(p2)
+		go decodeFrames(t, p1, frames, errCh)
+
+		encoder := codec.NewEncoder(p1, nstructs.MsgpackHandle)
+		must.NoError(t, encoder.Encode(req))
+
+		timeout := time.NewTimer(3 * time.Second)
+		defer timeout.Stop()
+		for {
+			select {
+			case <-timeout.C:
+				t.Fatal("timed out before getting an error")
+			case err := <-errCh:
+				return err
+			case <-frames:
+			}
+		}
+	}
+
+	// the node doesn't record exec sessions
+	must.ErrorContains(t, exec(), "exec sessions must be recorded")
+	auditor.lock.Lock()
+	must.Len(t, 0, auditor.events)
+	auditor.lock.Unlock()
+
+	// the node records exec sessions but isn't connected
+	node = node.Copy()
+	node.Attributes["nomad.exec_session_recording"] = "true"
+	must.NoError(t, state.UpsertNode(nstructs.MsgTypeTestSetup, 1003, node))
+	must.ErrorContains(t, exec(), "No path to node")
+
+	auditor.lock.Lock()
+	defer auditor.lock.Unlock()
+	must.Len(t, 1, auditor.events)
+	meta := auditor.events[0].Request.RequestMeta
+	must.Eq(t, alloc.ID, meta["alloc_id"])
+	must.Eq(t, "/bin/sh", meta["command"])
+	must.Eq(t, "true", meta["recording"])
+}
+
 func decodeFrames(t *testing.T, p1 net.Conn, frames chan<- *drivers.ExecTaskStreamingResponseMsg, errCh chan<- error) {
 	// Start the decoder
 	decoder := codec.NewDecoder(p1, nstructs.MsgpackHandle)
@@ -1336,3 +1421,46 @@ func decodeFrames(t *testing.T, p1 net.Conn, frames chan<- *drivers.ExecTaskStre
 		frames <- &frame
 	}
 }
+
+func TestClientAllocations_SnapshotChunk_MigrateToken(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	state := s.State()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// The node of the allocation is down, so the cached snapshot is served
+	node := mock.Node()
+	node.Attributes["nomad.version"] = "1.10.0"
+	node.Status = nstructs.NodeStatusDown
+	must.NoError(t, state.UpsertNode(nstructs.MsgTypeTestSetup, 1005, node))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	must.NoError(t, state.UpsertAllocs(nstructs.MsgTypeTestSetup, 1006, []*nstructs.Allocation{alloc}))
+
+	data := []byte("snapshot of the alloc dir")
+	sum := sha256.Sum256(data)
+	s.allocSnapshots.store(alloc.ID, 0, &cstructs.AllocSnapshotChunkResponse{
+		Data:     data,
+		Size:     int64(len(data)),
+		Checksum: hex.EncodeToString(sum[:]),
+	})
+
+	req := &cstructs.AllocSnapshotChunkRequest{
+		AllocID:      alloc.ID,
+		MigrateToken: "invalid",
+		QueryOptions: nstructs.QueryOptions{Region: "global"},
+	}
+	var resp cstructs.AllocSnapshotChunkResponse
+	err := msgpackrpc.CallWithCodec(codec, "ClientAllocations.SnapshotChunk", req, &resp)
+	must.EqError(t, err, nstructs.ErrPermissionDenied.Error())
+	must.Nil(t, resp.Data)
+
+	req.MigrateToken, err = nstructs.GenerateMigrateToken(alloc.ID, node.SecretID)
+	must.NoError(t, err)
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "ClientAllocations.SnapshotChunk", req, &resp))
+	must.Eq(t, data, resp.Data)
+}
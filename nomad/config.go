@@ -233,6 +233,11 @@ type Config struct {
 	// before it's rotated
 	RootKeyRotationThreshold time.Duration
 
+	// RootKeyPrepublishLeadTime is how long before the rotation of the active
+	// key its replacement is prepublished. If zero, the replacement is
+	// prepublished at half the RootKeyRotationThreshold.
+	RootKeyPrepublishLeadTime time.Duration
+
 	// VariablesRekeyInterval is how often we dispatch a job to
 	// rekey any variables associated with a key in the Rekeying state
 	VariablesRekeyInterval time.Duration
@@ -290,6 +295,14 @@ type Config struct {
 	// rejections for nodes.
 	NodePlanRejectionWindow time.Duration
 
+	// AutoscalerEnabled controls if the built-in autoscaler is enabled. It
+	// scales the task groups with a target tracking scaling policy.
+	AutoscalerEnabled bool
+
+	// AutoscalerInterval is how often the built-in autoscaler evaluates the
+	// target tracking scaling policies.
+	AutoscalerInterval time.Duration
+
 	// MinHeartbeatTTL is the minimum time between heartbeats.
 	// This is used as a floor to prevent excessive updates.
 	MinHeartbeatTTL time.Duration
@@ -424,6 +437,10 @@ type Config struct {
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64
 
+	// DeploymentVerifyAllowedHosts are the hosts, with or without a port, the
+	// verifications of deployments may query.
+	DeploymentVerifyAllowedHosts []string
+
 	// JobDefaultPriority is the default Job priority if not specified.
 	JobDefaultPriority int
 
@@ -433,6 +450,14 @@ type Config struct {
 	// JobTrackedVersions is the number of historic Job versions that are kept.
 	JobTrackedVersions int
 
+	// VariableTrackedVersions is the number of historic versions that are kept
+	// for each Variable. If zero, no history is kept.
+	VariableTrackedVersions int
+
+	// RequireExecSessionRecording refuses alloc exec sessions to the clients
+	// that don't record them.
+	RequireExecSessionRecording bool
+
 	Reporting *config.ReportingConfig
 
 	// OIDCIssuer is the URL for the OIDC Issuer field in Workload Identity JWTs.
@@ -440,6 +465,10 @@ type Config struct {
 	// will not be available.
 	OIDCIssuer string
 
+	// WorkloadIdentityClaims are the custom claims added to the workload
+	// identities signed for tasks and services.
+	WorkloadIdentityClaims *config.WorkloadIdentityClaimsConfig
+
 	// KEKProviders are used to wrap the Nomad keyring
 	KEKProviderConfigs []*structs.KEKProviderConfig
 
@@ -469,6 +498,7 @@ func (c *Config) Copy() *Config {
 	nc.RaftConfig = pointer.Copy(c.RaftConfig)
 	nc.SerfConfig = pointer.Copy(c.SerfConfig)
 	nc.EnabledSchedulers = slices.Clone(c.EnabledSchedulers)
+	nc.DeploymentVerifyAllowedHosts = slices.Clone(c.DeploymentVerifyAllowedHosts)
 	nc.ConsulConfigs = helper.DeepCopyMap(c.ConsulConfigs)
 	nc.VaultConfigs = helper.DeepCopyMap(c.VaultConfigs)
 	nc.TLSConfig = c.TLSConfig.Copy()
@@ -620,6 +650,8 @@ func DefaultConfig() *Config {
 		NodePlanRejectionEnabled:         false,
 		NodePlanRejectionThreshold:       15,
 		NodePlanRejectionWindow:          10 * time.Minute,
+		AutoscalerEnabled:                false,
+		AutoscalerInterval:               30 * time.Second,
 		ConsulConfigs: map[string]*config.ConsulConfig{
 			structs.ConsulDefaultCluster: config.DefaultConsulConfig()},
 		VaultConfigs: map[string]*config.VaultConfig{
@@ -656,6 +688,7 @@ func DefaultConfig() *Config {
 		JobDefaultPriority:       structs.JobDefaultPriority,
 		JobMaxPriority:           structs.JobDefaultMaxPriority,
 		JobTrackedVersions:       structs.JobDefaultTrackedVersions,
+		VariableTrackedVersions:  structs.VariableDefaultTrackedVersions,
 		StartTimeout:             30 * time.Second,
 	}
 
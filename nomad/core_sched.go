@@ -12,6 +12,7 @@ import (
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
 	version "github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -986,6 +987,16 @@ func (c *CoreScheduler) rootKeyRotateOrGC(eval *structs.Evaluation) error {
 		return nil
 	}
 
+	// rewrapping sends updates to the leader as well, so we pick up the
+	// remaining work at the next interval
+	stateChanged, err = c.rootKeyRewrap(eval)
+	if err != nil {
+		return err
+	}
+	if stateChanged {
+		return nil
+	}
+
 	// a rotation will be sent to the leader so our view of state
 	// is no longer valid. we ack this core job and will pick up
 	// the GC work on the next interval
@@ -1056,6 +1067,7 @@ func (c *CoreScheduler) rootKeyGC(eval *structs.Evaluation, now time.Time) error
 			c.logger.Error("root key delete failed", "error", err)
 			return err
 		}
+		metrics.IncrCounter([]string{"nomad", "keyring", "gc"}, 1)
 	}
 
 	return nil
@@ -1108,6 +1120,61 @@ func (c *CoreScheduler) rootKeyMigrate(eval *structs.Evaluation) (bool, error) {
 	return stateChanged, nil
 }
 
+// rootKeyRewrap wraps the root keys again with the active KEK providers when
+// they aren't wrapped by one of these providers, such as after the server
+// configuration switches to another provider, or when the external KMS key
+// that wrapped them has been rotated. It returns true if any of the keys were
+// rewrapped, because the caller should now treat the snapshot as invalid.
+func (c *CoreScheduler) rootKeyRewrap(eval *structs.Evaluation) (bool, error) {
+	if !ServersMeetMinimumVersion(
+		c.srv.serf.Members(), c.srv.Region(), minVersionKeyringInRaft, true) {
+		return false, nil
+	}
+
+	ws := memdb.NewWatchSet()
+	iter, err := c.snap.RootKeys(ws)
+	if err != nil {
+		return false, err
+	}
+	stateChanged := false
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		wrappedKeys := raw.(*structs.RootKey)
+		required, err := c.srv.encrypter.RewrapRequired(wrappedKeys)
+		if err != nil {
+			// an unavailable KMS shouldn't block rotation and GC, so we'll
+			// retry at the next interval
+			c.logger.Warn("could not check if root key needs rewrapping",
+				"error", err, "key_id", wrappedKeys.KeyID)
+			continue
+		}
+		if !required {
+			continue
+		}
+		rootKey, err := c.srv.encrypter.GetKey(wrappedKeys.KeyID)
+		if err != nil {
+			return stateChanged, err
+		}
+		req := &structs.KeyringUpdateRootKeyRequest{
+			RootKey: rootKey,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.config.Region,
+				AuthToken: eval.LeaderACL,
+			},
+		}
+
+		c.logger.Info("rewrapping root key", "key_id", wrappedKeys.KeyID)
+		if err := c.srv.RPC("Keyring.Update",
+			req, &structs.KeyringUpdateRootKeyResponse{}); err != nil {
+			c.logger.Error("rewrapping root key failed",
+				"error", err, "key_id", wrappedKeys.KeyID)
+			return stateChanged, err
+		}
+		stateChanged = true
+	}
+
+	return stateChanged, nil
+}
+
 // rootKeyRotate checks if the active key is old enough that we need to kick off
 // a rotation. It prepublishes a key first and only promotes that prepublished
 // key to active once the rotation threshold has expired
@@ -1170,6 +1237,7 @@ func (c *CoreScheduler) rootKeyRotate(eval *structs.Evaluation, now time.Time) (
 			c.logger.Error("setting prepublished key active failed", "error", err)
 			return false, err
 		}
+		metrics.IncrCounter([]string{"nomad", "keyring", "rotation", "promote"}, 1)
 		return true, nil
 	}
 
@@ -1180,8 +1248,13 @@ func (c *CoreScheduler) rootKeyRotate(eval *structs.Evaluation, now time.Time) (
 		return false, nil
 	}
 
-	// we rotate at half the rotation threshold because we want to prepublish a key
-	rotationThreshold := now.Add(-1 * c.srv.config.RootKeyRotationThreshold / 2)
+	// we rotate ahead of the rotation threshold because we want to prepublish
+	// a key, by default at half the rotation threshold
+	leadTime := c.srv.config.RootKeyPrepublishLeadTime
+	if leadTime == 0 {
+		leadTime = c.srv.config.RootKeyRotationThreshold / 2
+	}
+	rotationThreshold := now.Add(leadTime - c.srv.config.RootKeyRotationThreshold)
 
 	c.logger.Trace("checking active key eligibility for rotation",
 		"create_time", activeKey.CreateTime, "threshold", rotationThreshold.UnixNano())
@@ -1190,8 +1263,8 @@ func (c *CoreScheduler) rootKeyRotate(eval *structs.Evaluation, now time.Time) (
 		return false, nil // key is too new
 	}
 
-	// this eval may be processed up to RootKeyGCInterval after the halfway
-	// mark, so use the CreateTime of the previous key rather than the wall
+	// this eval may be processed up to RootKeyGCInterval after the lead
+	// time, so use the CreateTime of the previous key rather than the wall
 	// clock to set the publish time
 	publishTime := activeKey.CreateTime + c.srv.config.RootKeyRotationThreshold.Nanoseconds()
 
@@ -1207,6 +1280,7 @@ func (c *CoreScheduler) rootKeyRotate(eval *structs.Evaluation, now time.Time) (
 		c.logger.Error("root key rotation failed", "error", err)
 		return false, err
 	}
+	metrics.IncrCounter([]string{"nomad", "keyring", "rotation", "prepublish"}, 1)
 
 	return true, nil
 }
@@ -2668,6 +2668,99 @@ func TestCoreScheduler_RootKeyRotate(t *testing.T) {
 	}
 }
 
+// TestCoreScheduler_RootKeyRewrap exercises rewrapping root keys that aren't
+// wrapped by the active KEK provider
+func TestCoreScheduler_RootKeyRewrap(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanup := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanup()
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+
+	store := srv.fsm.State()
+	key0, err := store.GetActiveRootKey(nil)
+	must.NoError(t, err)
+	must.NotNil(t, key0)
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+	eval := srv.coreJobEval(structs.CoreJobRootKeyRotateOrGC, key0.ModifyIndex+1)
+	c := core.(*CoreScheduler)
+
+	rewrapped, err := c.rootKeyRewrap(eval)
+	must.NoError(t, err)
+	must.False(t, rewrapped, must.Sprint("key wrapped by active provider should not be rewrapped"))
+
+	// fake a key wrapped by a provider that's no longer active
+	key0 = key0.Copy()
+	key0.WrappedKeys[0] = key0.WrappedKeys[0].Copy()
+	key0.WrappedKeys[0].ProviderID = "aead.previous"
+	must.NoError(t, store.UpsertRootKey(key0.ModifyIndex+1, key0, false))
+
+	c.snap, _ = store.Snapshot()
+	rewrapped, err = c.rootKeyRewrap(eval)
+	must.NoError(t, err)
+	must.True(t, rewrapped, must.Sprint("key should be rewrapped"))
+
+	key1, err := store.RootKeyByID(nil, key0.KeyID)
+	must.NoError(t, err)
+	must.Eq(t, []string{"aead"}, key1.Meta().Providers)
+	must.True(t, key1.IsActive())
+}
+
+// TestCoreScheduler_RootKeyRotate_PrepublishLeadTime asserts the replacement
+// key is prepublished ahead of the rotation by the configured lead time
+func TestCoreScheduler_RootKeyRotate_PrepublishLeadTime(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanup := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.RootKeyRotationThreshold = time.Hour
+		c.RootKeyPrepublishLeadTime = 10 * time.Minute
+	})
+	defer cleanup()
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+
+	store := srv.fsm.State()
+	key0, err := store.GetActiveRootKey(nil)
+	must.NoError(t, err)
+	must.NotNil(t, key0)
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+	eval := srv.coreJobEval(structs.CoreJobRootKeyRotateOrGC, key0.ModifyIndex+1)
+	c := core.(*CoreScheduler)
+
+	// past half the threshold but before the lead time
+	now := time.Unix(0, key0.CreateTime+(40*time.Minute).Nanoseconds())
+	rotated, err := c.rootKeyRotate(eval, now)
+	must.NoError(t, err)
+	must.False(t, rotated, must.Sprint("key should not rotate before the lead time"))
+
+	now = time.Unix(0, key0.CreateTime+(51*time.Minute).Nanoseconds())
+	rotated, err = c.rootKeyRotate(eval, now)
+	must.NoError(t, err)
+	must.True(t, rotated, must.Sprint("key should rotate within the lead time"))
+
+	iter, err := store.RootKeys(nil)
+	must.NoError(t, err)
+	var prepublished int
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		k := raw.(*structs.RootKey)
+		if k.KeyID == key0.KeyID {
+			continue
+		}
+		prepublished++
+		must.True(t, k.IsPrepublished())
+		must.Eq(t, key0.CreateTime+time.Hour.Nanoseconds(), k.PublishTime)
+	}
+	must.Eq(t, 1, prepublished)
+}
+
 // TestCoreScheduler_RootKeyGC exercises root key GC
 func TestCoreScheduler_RootKeyGC(t *testing.T) {
 	ci.Parallel(t)
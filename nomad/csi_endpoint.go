@@ -313,6 +313,7 @@ func (v *CSIVolume) Register(args *structs.CSIVolumeRegisterRequest, reply *stru
 		if err = vol.Validate(); err != nil {
 			return err
 		}
+		vol.ManagedSnapshots = nil // only set by the snapshot schedule
 
 		existingVol, err := snap.CSIVolumeByID(nil, vol.Namespace, vol.ID)
 		if err != nil {
@@ -1073,6 +1074,7 @@ func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.
 		if err = vol.Validate(); err != nil {
 			return err
 		}
+		vol.ManagedSnapshots = nil // only set by the snapshot schedule
 		plugin, err := v.pluginValidateVolume(vol)
 		if err != nil {
 			return err
@@ -1269,6 +1271,7 @@ func (v *CSIVolume) expandVolume(vol *structs.CSIVolume, plugin *structs.CSIPlug
 		return fmt.Errorf("unable to expand volume: %w", err)
 	}
 	vol.Capacity = cResp.CapacityBytes
+	vol.NodeExpansionRequired = cResp.NodeExpansionRequired
 	logger.Info("controller done expanding volume")
 
 	if cResp.NodeExpansionRequired {
@@ -1703,6 +1706,25 @@ func (v *CSIVolume) ListSnapshots(args *structs.CSISnapshotListRequest, reply *s
 	}
 	reply.NextToken = cResp.NextToken
 
+	// Mark the snapshots managed by the snapshot schedule of the volumes in
+	// the namespace with the volume they were created from
+	iter, err := snap.CSIVolumesByPluginID(nil, args.RequestNamespace(), "", plugin.ID)
+	if err != nil {
+		return err
+	}
+	managed := map[string]string{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vol := raw.(*structs.CSIVolume)
+		for _, s := range vol.ManagedSnapshots {
+			managed[s.ID] = vol.ID
+		}
+	}
+	for _, s := range reply.Snapshots {
+		if volID, ok := managed[s.ID]; ok {
+			s.SourceVolumeID = volID
+		}
+	}
+
 	return nil
 }
 
@@ -1991,6 +1991,10 @@ func TestCSIVolume_expandVolume(t *testing.T) {
 			RequiredBytes: 2000,
 		})
 		test.ErrorContains(t, err, expect)
+
+		// the volume is still recorded to be expanded by the clients that
+		// mount it later
+		test.True(t, vol.NodeExpansionRequired)
 	})
 
 }
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// csiSnapshotScheduleInterval is the interval at which the leader checks
+// whether the snapshots of the volumes with a snapshot schedule are due.
+var csiSnapshotScheduleInterval = time.Minute
+
+// runCSISnapshotSchedule creates the snapshots of the volumes with a snapshot
+// schedule once they are due, and deletes the snapshots beyond the retention
+// of the schedule. It is only run on the leader.
+func (s *Server) runCSISnapshotSchedule(stopCh chan struct{}) {
+	logger := s.logger.Named("csi_snapshot_schedule")
+	endpoint := NewCSIVolumeEndpoint(s, nil)
+
+	ticker := time.NewTicker(csiSnapshotScheduleInterval)
+	defer ticker.Stop()
+
+	since := time.Now()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case now := <-ticker.C:
+			s.snapshotScheduledVolumes(logger, endpoint, since, now)
+			since = now
+		}
+	}
+}
+
+// snapshotScheduledVolumes snapshots the volumes whose snapshots are due.
+func (s *Server) snapshotScheduledVolumes(logger log.Logger, endpoint *CSIVolume, since, now time.Time) {
+	iter, err := s.State().CSIVolumes(nil)
+	if err != nil {
+		logger.Error("failed to list volumes", "error", err)
+		return
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vol := raw.(*structs.CSIVolume)
+		if !csiSnapshotDue(vol, since, now) {
+			continue
+		}
+		if err := s.snapshotScheduledVolume(endpoint, vol, now); err != nil {
+			logger.Error("failed to snapshot volume",
+				"namespace", vol.Namespace, "volume_id", vol.ID, "error", err)
+			continue
+		}
+		logger.Debug("snapshotted volume", "namespace", vol.Namespace, "volume_id", vol.ID)
+	}
+}
+
+// csiSnapshotDue returns whether a snapshot of the volume is due. The next
+// snapshot time follows the last managed snapshot, or the last check if there
+// is none, so that a new leader creates at most one of the missed snapshots.
+func csiSnapshotDue(vol *structs.CSIVolume, since, now time.Time) bool {
+	if vol.SnapshotSchedule == nil {
+		return false
+	}
+	from := vol.LastManagedSnapshotTime()
+	if from.IsZero() {
+		from = since
+	}
+	next := vol.SnapshotSchedule.Next(from)
+	return !next.IsZero() && !next.After(now)
+}
+
+// snapshotScheduledVolume creates a snapshot of the volume, deletes its oldest
+// managed snapshots beyond the retention of its schedule, and records the
+// remaining managed snapshots. Snapshots which can't be deleted remain
+// managed so that they are deleted later.
+func (s *Server) snapshotScheduledVolume(endpoint *CSIVolume, vol *structs.CSIVolume, now time.Time) error {
+	writeReq := structs.WriteRequest{
+		Region:    s.config.Region,
+		Namespace: vol.Namespace,
+		AuthToken: s.getLeaderAcl(),
+	}
+
+	createReq := &structs.CSISnapshotCreateRequest{
+		Snapshots: []*structs.CSISnapshot{{
+			SourceVolumeID: vol.ID,
+			PluginID:       vol.PluginID,
+			Name:           fmt.Sprintf("%s-%d", vol.ID, now.Unix()),
+		}},
+		WriteRequest: writeReq,
+	}
+	var createResp structs.CSISnapshotCreateResponse
+	if err := endpoint.CreateSnapshot(createReq, &createResp); err != nil {
+		return err
+	}
+
+	snapshots := helper.CopySlice(vol.ManagedSnapshots)
+	for _, snap := range createResp.Snapshots {
+		snap.SourceVolumeID = vol.ID
+		snap.PluginID = vol.PluginID
+		if snap.CreateTime == 0 {
+			// the schedule follows the creation time of the last snapshot
+			snap.CreateTime = now.Unix()
+		}
+		snapshots = append(snapshots, snap)
+	}
+
+	var mErr multierror.Error
+	if excess := len(snapshots) - vol.SnapshotSchedule.Retain; excess > 0 {
+		var kept []*structs.CSISnapshot
+		for _, snap := range snapshots[:excess] {
+			deleteReq := &structs.CSISnapshotDeleteRequest{
+				Snapshots:    []*structs.CSISnapshot{snap},
+				WriteRequest: writeReq,
+			}
+			var deleteResp structs.CSISnapshotDeleteResponse
+			if err := endpoint.DeleteSnapshot(deleteReq, &deleteResp); err != nil {
+				mErr.Errors = append(mErr.Errors, err)
+				kept = append(kept, snap)
+			}
+		}
+		snapshots = append(kept, snapshots[excess:]...)
+	}
+
+	req := &structs.CSIVolumeManagedSnapshotsRequest{
+		Namespace:    vol.Namespace,
+		VolumeID:     vol.ID,
+		Snapshots:    snapshots,
+		WriteRequest: structs.WriteRequest{Region: s.config.Region},
+	}
+	if _, _, err := s.raftApply(structs.CSIVolumeManagedSnapshotsRequestType, req); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	return mErr.ErrorOrNil()
+}
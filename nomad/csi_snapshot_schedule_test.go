@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestCSISnapshotDue(t *testing.T) {
+	ci.Parallel(t)
+
+	since := time.Date(2024, 1, 1, 2, 59, 0, 0, time.UTC)
+	now := since.Add(time.Minute)
+	schedule := &structs.CSIVolumeSnapshotSchedule{Cron: "0 3 * * *", Retain: 2}
+
+	// Without a schedule
+	must.False(t, csiSnapshotDue(&structs.CSIVolume{}, since, now))
+
+	// Without snapshots, the schedule follows the last check
+	vol := &structs.CSIVolume{SnapshotSchedule: schedule}
+	must.True(t, csiSnapshotDue(vol, since, now))
+	must.False(t, csiSnapshotDue(vol, now, now.Add(time.Minute)))
+
+	// With snapshots, the schedule follows the last one
+	vol.ManagedSnapshots = []*structs.CSISnapshot{{ID: "snap", CreateTime: now.Unix()}}
+	must.False(t, csiSnapshotDue(vol, since, now.Add(23*time.Hour)))
+	must.True(t, csiSnapshotDue(vol, since, now.Add(24*time.Hour)))
+
+	// A missed snapshot is created on the first check
+	must.True(t, csiSnapshotDue(vol, now.Add(72*time.Hour), now.Add(73*time.Hour)))
+}
+
+func TestCSISnapshotSchedule_SnapshotVolume(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+
+	_, fake, plugID, volID := testClientWithCSI(t, srv)
+	store := srv.fsm.State()
+
+	vol, err := store.CSIVolumeByID(nil, structs.DefaultNamespace, volID)
+	must.NoError(t, err)
+	vol = vol.Copy()
+	vol.SnapshotSchedule = &structs.CSIVolumeSnapshotSchedule{Cron: "0 3 * * *", Retain: 2}
+	must.NoError(t, store.UpsertCSIVolume(1000, []*structs.CSIVolume{vol}))
+
+	endpoint := NewCSIVolumeEndpoint(srv, nil)
+
+	// snapshot creates a snapshot with the schedule, and returns the IDs of
+	// the managed snapshots of the volume afterwards
+	snapshot := func(id string, createTime int64) ([]string, error) {
+		t.Helper()
+		fake.NextCreateSnapshotResponse = &cstructs.ClientCSIControllerCreateSnapshotResponse{
+			ID:                     id,
+			ExternalSourceVolumeID: "fake-csi-external-id",
+			CreateTime:             createTime,
+			IsReady:                true,
+		}
+		vol, err := store.CSIVolumeByID(nil, structs.DefaultNamespace, volID)
+		must.NoError(t, err)
+		snapErr := srv.snapshotScheduledVolume(endpoint, vol, time.Unix(createTime, 0))
+
+		vol, err = store.CSIVolumeByID(nil, structs.DefaultNamespace, volID)
+		must.NoError(t, err)
+		var ids []string
+		for _, snap := range vol.ManagedSnapshots {
+			must.Eq(t, volID, snap.SourceVolumeID)
+			must.Eq(t, plugID, snap.PluginID)
+			ids = append(ids, snap.ID)
+		}
+		return ids, snapErr
+	}
+
+	ids, err := snapshot("snap-1", 1000)
+	must.NoError(t, err)
+	must.Eq(t, []string{"snap-1"}, ids)
+
+	ids, err = snapshot("snap-2", 2000)
+	must.NoError(t, err)
+	must.Eq(t, []string{"snap-1", "snap-2"}, ids)
+
+	// The oldest snapshot beyond the retention is deleted
+	ids, err = snapshot("snap-3", 3000)
+	must.NoError(t, err)
+	must.Eq(t, []string{"snap-2", "snap-3"}, ids)
+
+	// Snapshots which can't be deleted remain managed
+	fake.NextDeleteSnapshotError = errors.New("sad delete")
+	ids, err = snapshot("snap-4", 4000)
+	must.ErrorContains(t, err, "sad delete")
+	must.Eq(t, []string{"snap-2", "snap-3", "snap-4"}, ids)
+	fake.NextDeleteSnapshotError = nil
+
+	// Creation failures leave the managed snapshots untouched
+	fake.NextCreateSnapshotError = errors.New("sad create")
+	_, err = snapshot("snap-5", 5000)
+	must.ErrorContains(t, err, "sad create")
+	fake.NextCreateSnapshotError = nil
+
+	vol, err = store.CSIVolumeByID(nil, structs.DefaultNamespace, volID)
+	must.NoError(t, err)
+	must.Len(t, 3, vol.ManagedSnapshots)
+	must.Eq(t, time.Unix(4000, 0), vol.LastManagedSnapshotTime())
+
+	// Listing the snapshots of the plugin marks the managed ones
+	fake.NextListExternalSnapshotsResponse = &cstructs.ClientCSIControllerListSnapshotsResponse{
+		Entries: []*structs.CSISnapshot{
+			{ID: "snap-3", ExternalSourceVolumeID: "fake-csi-external-id"},
+			{ID: "other", ExternalSourceVolumeID: "fake-csi-external-id"},
+		},
+	}
+	listReq := &structs.CSISnapshotListRequest{
+		PluginID: plugID,
+		QueryOptions: structs.QueryOptions{
+			Region:    srv.Region(),
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var listResp structs.CSISnapshotListResponse
+	must.NoError(t, srv.RPC("CSIVolume.ListSnapshots", listReq, &listResp))
+	must.Len(t, 2, listResp.Snapshots)
+	must.Eq(t, volID, listResp.Snapshots[0].SourceVolumeID)
+	must.Eq(t, "", listResp.Snapshots[1].SourceVolumeID)
+}
@@ -101,6 +101,20 @@ type deploymentWatcher struct {
 	// by holding the lock or using the setter and getter methods.
 	latestEval uint64
 
+	// verifyCh receives the result of the verification of the deployment
+	// while it is running, and verified marks whether it passed. They are
+	// only accessed by the watch loop.
+	verifyCh chan error
+	verified bool
+
+	// bakeTimer fires once the healthy canaries baked and the deployment can
+	// be automatically promoted. It is only accessed by the watch loop.
+	bakeTimer *time.Timer
+
+	// verifyAllowedHosts are the hosts the verifications of the deployment
+	// may query.
+	verifyAllowedHosts []string
+
 	logger log.Logger
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -112,7 +126,8 @@ type deploymentWatcher struct {
 func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	logger log.Logger, state *state.StateStore, d *structs.Deployment,
 	j *structs.Job, triggers deploymentTriggers,
-	deploymentRPC DeploymentRPC, jobRPC JobRPC) *deploymentWatcher {
+	deploymentRPC DeploymentRPC, jobRPC JobRPC,
+	verifyAllowedHosts []string) *deploymentWatcher {
 
 	ctx, exitFn := context.WithCancel(parent)
 	w := &deploymentWatcher{
@@ -125,6 +140,7 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 		deploymentTriggers: triggers,
 		DeploymentRPC:      deploymentRPC,
 		JobRPC:             jobRPC,
+		verifyAllowedHosts: verifyAllowedHosts,
 		logger:             logger.With("deployment_id", d.ID, "job", j.NamespacedID()),
 		ctx:                ctx,
 		exitFn:             exitFn,
@@ -286,9 +302,14 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 		return nil
 	}
 
+	// bake is how long the canaries must still stay healthy, and step
+	// whether the canaries move to their next step instead of being promoted
+	var bake time.Duration
+	step := false
+
 	// AutoPromote iff every task group with canaries is marked auto_promote and is healthy. The whole
 	// job version has been incremented, so we promote together. See also AutoRevert
-	for _, dstate := range d.TaskGroups {
+	for name, dstate := range d.TaskGroups {
 
 		// skip auto promote canary validation if the task group has no canaries
 		// to prevent auto promote hanging on mixed canary/non-canary taskgroup deploys
@@ -301,24 +322,83 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 		}
 
 		healthyCanaries := 0
+		var healthyAt time.Time
 		// Find the health status of each canary
 		for _, c := range dstate.PlacedCanaries {
 			for _, a := range allocs {
 				if c == a.ID && a.DeploymentStatus.IsHealthy() {
 					healthyCanaries += 1
+					if a.DeploymentStatus.Timestamp.After(healthyAt) {
+						healthyAt = a.DeploymentStatus.Timestamp
+					}
 				}
 			}
 		}
 		if healthyCanaries != dstate.DesiredCanaries {
 			return nil
 		}
+
+		if tg := w.j.LookupTaskGroup(name); tg != nil && tg.Update != nil {
+			bake = max(bake, time.Until(healthyAt.Add(tg.Update.BakeTime)))
+		}
+		step = step || dstate.HasNextCanaryStep()
+	}
+
+	// Wait for the canaries to bake before promoting them
+	if bake > 0 {
+		w.logger.Trace("waiting for canaries to bake", "remaining", bake)
+		if w.bakeTimer != nil {
+			w.bakeTimer.Stop()
+		}
+		w.bakeTimer = time.NewTimer(bake)
+		return nil
+	}
+
+	// Verify the deployment before promoting it
+	if !w.verified {
+		if verifications := deploymentVerifications(d, w.j); len(verifications) != 0 {
+			return w.startVerification(verifications)
+		}
 	}
 
 	// Send the request
 	_, err := w.upsertDeploymentPromotion(&structs.ApplyDeploymentPromoteRequest{
-		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{DeploymentID: d.GetID(), All: true},
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{DeploymentID: d.GetID(), All: true, Step: step},
 		Eval:                     w.getEval(),
 	})
+	if err == nil && step {
+		// verify the deployment again at the next step
+		w.verified = false
+	}
+	return err
+}
+
+// bakeCh returns the channel of the bake timer, or nil if the canaries
+// aren't baking.
+func (w *deploymentWatcher) bakeCh() <-chan time.Time {
+	if w.bakeTimer == nil {
+		return nil
+	}
+	return w.bakeTimer.C
+}
+
+// startVerification runs the verifications of the deployment in the
+// background, unless they are already running, and reports that the
+// deployment waits for them.
+func (w *deploymentWatcher) startVerification(verifications []*structs.UpdateVerify) error {
+	if w.verifyCh != nil {
+		return nil
+	}
+
+	w.logger.Debug("verifying deployment", "verifications", len(verifications))
+	w.verifyCh = make(chan error, 1)
+	go func(ch chan<- error) {
+		ch <- runVerifications(w.ctx, w.verifyAllowedHosts, verifications)
+	}(w.verifyCh)
+
+	update := w.getDeploymentStatusUpdate(structs.DeploymentStatusRunning,
+		structs.DeploymentStatusDescriptionRunningVerification)
+	_, err := w.upsertDeploymentStatusUpdate(update, nil, nil)
 	return err
 }
 
@@ -434,6 +514,7 @@ func (w *deploymentWatcher) watch() {
 	var updates *allocUpdates
 
 	rollback, deadlineHit := false, false
+	var verifyErr error
 
 FAIL:
 	for {
@@ -502,6 +583,40 @@ FAIL:
 				break FAIL
 			}
 
+		case <-w.bakeCh():
+			// The canaries baked, so the deployment may be promoted
+			w.bakeTimer = nil
+			if updates != nil {
+				if err := w.autoPromoteDeployment(updates.allocs); err != nil {
+					w.logger.Error("failed to auto promote deployment", "error", err)
+				}
+			}
+
+		case err := <-w.verifyCh:
+			// The verification of the deployment completed, so either
+			// promote it or fail it and roll back the job
+			w.verifyCh = nil
+			if err != nil {
+				if w.ctx.Err() == context.Canceled {
+					return
+				}
+
+				w.logger.Warn("deployment verification failed", "error", err)
+				verifyErr, rollback = err, true
+				if err := w.nextRegion(structs.DeploymentStatusFailed); err != nil {
+					w.logger.Error("multiregion deployment error", "error", err)
+				}
+				break FAIL
+			}
+
+			w.logger.Debug("deployment verified")
+			w.verified = true
+			if updates != nil {
+				if err := w.autoPromoteDeployment(updates.allocs); err != nil {
+					w.logger.Error("failed to auto promote deployment", "error", err)
+				}
+			}
+
 		case updates = <-allocsCh:
 			if err := updates.err; err != nil {
 				if err == context.Canceled || w.ctx.Err() == context.Canceled {
@@ -554,7 +669,9 @@ FAIL:
 
 	// Change the deployments status to failed
 	desc := structs.DeploymentStatusDescriptionFailedAllocations
-	if deadlineHit {
+	if verifyErr != nil {
+		desc = fmt.Sprintf("%s: %v", structs.DeploymentStatusDescriptionFailedVerification, verifyErr)
+	} else if deadlineHit {
 		desc = structs.DeploymentStatusDescriptionProgressDeadline
 	}
 
@@ -84,6 +84,10 @@ type Watcher struct {
 	// server interface for Job RPCs
 	jobRPC JobRPC
 
+	// verifyAllowedHosts are the hosts the verifications of the deployments
+	// may query.
+	verifyAllowedHosts []string
+
 	// watchers is the set of active watchers, one per deployment
 	watchers map[string]*deploymentWatcher
 
@@ -105,6 +109,7 @@ func NewDeploymentsWatcher(logger log.Logger,
 	deploymentRPC DeploymentRPC, jobRPC JobRPC,
 	stateQueriesPerSecond float64,
 	updateBatchDuration time.Duration,
+	verifyAllowedHosts []string,
 ) *Watcher {
 
 	return &Watcher{
@@ -113,6 +118,7 @@ func NewDeploymentsWatcher(logger log.Logger,
 		jobRPC:              jobRPC,
 		queryLimiter:        rate.NewLimiter(rate.Limit(stateQueriesPerSecond), 100),
 		updateBatchDuration: updateBatchDuration,
+		verifyAllowedHosts:  verifyAllowedHosts,
 		logger:              logger.Named("deployments_watcher"),
 	}
 }
@@ -301,7 +307,7 @@ func (w *Watcher) addLocked(d *structs.Deployment) (*deploymentWatcher, error) {
 	}
 
 	watcher := newDeploymentWatcher(w.ctx, w.queryLimiter, w.logger, w.state, d, job,
-		w, w.deploymentRPC, w.jobRPC)
+		w, w.deploymentRPC, w.jobRPC, w.verifyAllowedHosts)
 	w.watchers[d.ID] = watcher
 	return watcher, nil
 }
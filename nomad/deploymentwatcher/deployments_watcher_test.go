@@ -5,6 +5,8 @@ package deploymentwatcher
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -25,7 +27,7 @@ import (
 
 func testDeploymentWatcher(t *testing.T, qps float64, batchDur time.Duration) (*Watcher, *mockBackend) {
 	m := newMockBackend(t)
-	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, qps, batchDur)
+	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, qps, batchDur, nil)
 	return w, m
 }
 
@@ -838,6 +840,234 @@ func TestWatcher_AutoPromoteDeployment_UnhealthyCanaries(t *testing.T) {
 }
 
 // Test pausing a deployment that is running
+func TestWatcher_AutoPromoteDeployment_Verify(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		status int
+	}{
+		{name: "passed", status: http.StatusOK},
+		{name: "failed", status: http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer ts.Close()
+
+			w, m := defaultTestDeploymentWatcher(t)
+			w.verifyAllowedHosts = []string{"127.0.0.1"}
+			m.On("UpdateDeploymentStatus", mocker.Anything).Return(nil).Maybe()
+			m.On("UpdateDeploymentAllocHealth", mocker.Anything).Return(nil).Maybe()
+			m.On("UpdateDeploymentPromotion", mocker.Anything).Return(nil).Maybe()
+			m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+			// Create a stable job version to roll back to
+			j := mock.Job()
+			j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+			j.TaskGroups[0].Update.Canary = 1
+			j.TaskGroups[0].Update.AutoPromote = true
+			j.TaskGroups[0].Update.ProgressDeadline = 0
+			j.TaskGroups[0].Update.Verify = &structs.UpdateVerify{
+				Type:     structs.UpdateVerifyTypeHTTP,
+				Address:  ts.URL,
+				Checks:   2,
+				Interval: 10 * time.Millisecond,
+				Timeout:  time.Second,
+			}
+			j.Stable = true
+			must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+
+			j2 := j.Copy()
+			j2.Stable = false
+			j2.Meta["foo"] = "bar"
+			must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j2))
+
+			d := mock.Deployment()
+			d.JobID = j.ID
+			d.JobVersion = 1
+			d.TaskGroups["web"].AutoPromote = true
+			d.TaskGroups["web"].DesiredCanaries = 1
+			d.TaskGroups["web"].DesiredTotal = 1
+
+			canary := mock.Alloc()
+			canary.DeploymentID = d.ID
+			canary.DeploymentStatus = &structs.AllocDeploymentStatus{Canary: true}
+			d.TaskGroups["web"].PlacedCanaries = []string{canary.ID}
+			must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+			must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{canary}))
+
+			w.SetEnabled(true, m.state)
+			must.Wait(t, wait.InitialSuccess(
+				wait.BoolFunc(func() bool { return watchersCount(w) == 1 }),
+				wait.Timeout(5*time.Second),
+				wait.Gap(10*time.Millisecond),
+			))
+
+			// Mark the canary healthy to start the verification
+			req := &structs.DeploymentAllocHealthRequest{
+				DeploymentID:         d.ID,
+				HealthyAllocationIDs: []string{canary.ID},
+			}
+			var resp structs.DeploymentUpdateResponse
+			must.NoError(t, w.SetAllocHealth(req, &resp))
+
+			must.Wait(t, wait.InitialSuccess(
+				wait.ErrorFunc(func() error {
+					out, err := m.state.DeploymentByID(nil, d.ID)
+					if err != nil {
+						return err
+					}
+					switch tc.status {
+					case http.StatusOK:
+						if !out.TaskGroups["web"].Promoted {
+							return fmt.Errorf("deployment not promoted: %s", out.StatusDescription)
+						}
+					default:
+						if out.Status != structs.DeploymentStatusFailed {
+							return fmt.Errorf("deployment not failed: %s", out.StatusDescription)
+						}
+					}
+					return nil
+				}),
+				wait.Timeout(5*time.Second),
+				wait.Gap(10*time.Millisecond),
+			))
+
+			out, err := m.state.DeploymentByID(nil, d.ID)
+			must.NoError(t, err)
+			job, err := m.state.JobByID(nil, j.Namespace, j.ID)
+			must.NoError(t, err)
+			if tc.status == http.StatusOK {
+				must.Eq(t, structs.DeploymentStatusRunning, out.Status)
+				must.Eq(t, uint64(1), job.Version)
+				return
+			}
+
+			// The job is rolled back to its stable version
+			must.StrContains(t, out.StatusDescription, structs.DeploymentStatusDescriptionFailedVerification)
+			must.StrContains(t, out.StatusDescription, "unexpected response code 503")
+			must.False(t, out.TaskGroups["web"].Promoted)
+			must.Eq(t, uint64(2), job.Version)
+			must.MapNotContainsKey(t, job.Meta, "foo")
+		})
+	}
+}
+
+func TestWatcher_AutoPromoteDeployment_CanarySteps(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+	m.On("UpdateDeploymentStatus", mocker.Anything).Return(nil).Maybe()
+	m.On("UpdateDeploymentAllocHealth", mocker.Anything).Return(nil).Maybe()
+	m.On("UpdateDeploymentPromotion", mocker.Anything).Return(nil).Maybe()
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	bakeTime := 200 * time.Millisecond
+	j := mock.Job()
+	j.TaskGroups[0].Count = 4
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.CanarySteps = []int{25, 50}
+	j.TaskGroups[0].Update.BakeTime = bakeTime
+	j.TaskGroups[0].Update.AutoPromote = true
+	j.TaskGroups[0].Update.ProgressDeadline = 0
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.JobVersion = j.Version
+	d.TaskGroups["web"].AutoPromote = true
+	d.TaskGroups["web"].CanarySteps = []int{25, 50}
+	d.TaskGroups["web"].DesiredCanaries = 1
+	d.TaskGroups["web"].DesiredTotal = 4
+
+	newCanary := func() *structs.Allocation {
+		canary := mock.Alloc()
+		canary.JobID = j.ID
+		canary.DeploymentID = d.ID
+		canary.DeploymentStatus = &structs.AllocDeploymentStatus{Canary: true}
+		return canary
+	}
+	canary1 := newCanary()
+	d.TaskGroups["web"].PlacedCanaries = []string{canary1.ID}
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+	must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{canary1}))
+
+	w.SetEnabled(true, m.state)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return watchersCount(w) == 1 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	setHealthy := func(id string) {
+		req := &structs.DeploymentAllocHealthRequest{
+			DeploymentID:         d.ID,
+			HealthyAllocationIDs: []string{id},
+		}
+		var resp structs.DeploymentUpdateResponse
+		must.NoError(t, w.SetAllocHealth(req, &resp))
+	}
+	waitForDeployment := func(fn func(*structs.DeploymentState) error) {
+		must.Wait(t, wait.InitialSuccess(
+			wait.ErrorFunc(func() error {
+				out, err := m.state.DeploymentByID(nil, d.ID)
+				if err != nil {
+					return err
+				}
+				return fn(out.TaskGroups["web"])
+			}),
+			wait.Timeout(5*time.Second),
+			wait.Gap(10*time.Millisecond),
+		))
+	}
+
+	// The canaries move to the next step once they baked
+	start := time.Now()
+	setHealthy(canary1.ID)
+	waitForDeployment(func(dstate *structs.DeploymentState) error {
+		if dstate.CanaryStep != 1 {
+			return fmt.Errorf("expected canary step 1, got %d", dstate.CanaryStep)
+		}
+		return nil
+	})
+	must.Greater(t, bakeTime, time.Since(start))
+
+	out, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, 2, out.TaskGroups["web"].DesiredCanaries)
+	must.False(t, out.TaskGroups["web"].Promoted)
+
+	alloc, err := m.state.AllocByID(nil, canary1.ID)
+	must.NoError(t, err)
+	must.True(t, alloc.DeploymentStatus.Canary)
+
+	// The canaries are promoted once the canaries of the last step baked
+	canary2 := newCanary()
+	d = out.Copy()
+	d.TaskGroups["web"].PlacedCanaries = append(d.TaskGroups["web"].PlacedCanaries, canary2.ID)
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+	must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{canary2}))
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			w.l.RLock()
+			defer w.l.RUnlock()
+			return len(w.watchers[d.ID].getDeployment().TaskGroups["web"].PlacedCanaries) == 2
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	setHealthy(canary2.ID)
+	waitForDeployment(func(dstate *structs.DeploymentState) error {
+		if !dstate.Promoted {
+			return fmt.Errorf("deployment not promoted")
+		}
+		return nil
+	})
+}
+
 func TestWatcher_PauseDeployment_Pause_Running(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// verifyResponseLimit is the maximum size of the responses read from the
+// endpoints queried to verify deployments.
+const verifyResponseLimit = 1 << 20
+
+// deploymentVerifications returns the verifications of the task groups of the
+// job with canaries in the deployment, without duplicates.
+func deploymentVerifications(d *structs.Deployment, j *structs.Job) []*structs.UpdateVerify {
+	var verifications []*structs.UpdateVerify
+GROUPS:
+	for _, tg := range j.TaskGroups {
+		dstate, ok := d.TaskGroups[tg.Name]
+		if !ok || dstate.DesiredCanaries < 1 || tg.Update == nil || tg.Update.Verify == nil {
+			continue
+		}
+		for _, v := range verifications {
+			if v.Equal(tg.Update.Verify) {
+				continue GROUPS
+			}
+		}
+		verifications = append(verifications, tg.Update.Verify)
+	}
+	return verifications
+}
+
+// runVerifications runs the verifications, and returns an error describing
+// the first verification which failed. The verifications may only query the
+// allowed hosts.
+func runVerifications(ctx context.Context, allowedHosts []string, verifications []*structs.UpdateVerify) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, len(verifications))
+	for _, v := range verifications {
+		go func(v *structs.UpdateVerify) {
+			errCh <- runVerification(ctx, allowedHosts, v)
+		}(v)
+	}
+
+	for range verifications {
+		if err := <-errCh; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runVerification runs the checks of the verification every interval, and
+// returns an error once a check fails. Redirects aren't followed, so the
+// checks can't reach other hosts than the allowed ones.
+func runVerification(ctx context.Context, allowedHosts []string, v *structs.UpdateVerify) error {
+	if err := verifyAddressAllowed(allowedHosts, v.Address); err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: v.Timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	for i := 0; i < v.Checks; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(v.Interval):
+			}
+		}
+
+		var err error
+		switch v.Type {
+		case structs.UpdateVerifyTypePrometheus:
+			err = checkPrometheus(ctx, client, v)
+		default:
+			err = checkHTTP(ctx, client, v.Address)
+		}
+		if err != nil {
+			return fmt.Errorf("%s check %d of %d failed: %w", v.Type, i+1, v.Checks, err)
+		}
+	}
+	return nil
+}
+
+// verifyAddressAllowed returns an error unless the host of the address is one
+// of the hosts the servers allow verifications to query. A host is allowed
+// either by its name, for any port, or by its name and port.
+func verifyAddressAllowed(allowedHosts []string, addr string) error {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return err
+	}
+	for _, allowed := range allowedHosts {
+		if strings.EqualFold(allowed, u.Host) || strings.EqualFold(allowed, u.Hostname()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %q of address %q isn't allowed by the servers' deployment_verify_allowed_hosts", u.Host, addr)
+}
+
+// checkHTTP passes if the endpoint responds with a 2xx status code.
+func checkHTTP(ctx context.Context, client *http.Client, addr string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, verifyResponseLimit))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// prometheusResponse is the response of the Prometheus instant query API.
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// checkPrometheus passes if the query returns at least one value and all its
+// values are non-zero.
+func checkPrometheus(ctx context.Context, client *http.Client, v *structs.UpdateVerify) error {
+	u, err := url.Parse(strings.TrimSuffix(v.Address, "/") + "/api/v1/query")
+	if err != nil {
+		return err
+	}
+	u.RawQuery = url.Values{"query": []string{v.Query}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var out prometheusResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, verifyResponseLimit)).Decode(&out); err != nil {
+		return fmt.Errorf("failed to decode response with code %d: %w", resp.StatusCode, err)
+	}
+	if out.Status != "success" {
+		return fmt.Errorf("query failed: %s", out.Error)
+	}
+
+	values, err := prometheusValues(out.Data.ResultType, out.Data.Result)
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("query %q returned no value", v.Query)
+	}
+	for _, value := range values {
+		if value == 0 || math.IsNaN(value) {
+			return fmt.Errorf("query %q returned %v", v.Query, value)
+		}
+	}
+	return nil
+}
+
+// prometheusValues returns the values of the result of a Prometheus instant
+// query, which are encoded as [<timestamp>, "<value>"] pairs.
+func prometheusValues(resultType string, result json.RawMessage) ([]float64, error) {
+	var pairs [][]interface{}
+	switch resultType {
+	case "scalar":
+		var pair []interface{}
+		if err := json.Unmarshal(result, &pair); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	case "vector":
+		var samples []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(result, &samples); err != nil {
+			return nil, err
+		}
+		for _, sample := range samples {
+			pairs = append(pairs, sample.Value)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", resultType)
+	}
+
+	values := make([]float64, 0, len(pairs))
+	for _, pair := range pairs {
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("invalid value %v", pair)
+		}
+		raw, ok := pair[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value %v", pair)
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
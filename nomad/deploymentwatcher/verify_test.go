@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestRunVerification_Prometheus(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		result string
+		expErr string
+	}{
+		{
+			name:   "vector",
+			result: `{"resultType":"vector","result":[{"metric":{},"value":[1730920906.1,"1"]},{"metric":{},"value":[1730920906.1,"0.5"]}]}`,
+		},
+		{
+			name:   "scalar",
+			result: `{"resultType":"scalar","result":[1730920906.1,"1"]}`,
+		},
+		{
+			name:   "zero",
+			result: `{"resultType":"vector","result":[{"metric":{},"value":[1730920906.1,"1"]},{"metric":{},"value":[1730920906.1,"0"]}]}`,
+			expErr: "returned 0",
+		},
+		{
+			name:   "empty",
+			result: `{"resultType":"vector","result":[]}`,
+			expErr: "returned no value",
+		},
+		{
+			name:   "matrix",
+			result: `{"resultType":"matrix","result":[]}`,
+			expErr: `unsupported result type "matrix"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var l sync.Mutex
+			var queries []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				l.Lock()
+				defer l.Unlock()
+				queries = append(queries, r.URL.Path+"?"+r.URL.Query().Get("query"))
+				fmt.Fprintf(w, `{"status":"success","data":%s}`, tc.result)
+			}))
+			defer ts.Close()
+
+			v := &structs.UpdateVerify{
+				Type:     structs.UpdateVerifyTypePrometheus,
+				Address:  ts.URL + "/",
+				Query:    `sum(rate(http_errors[1m])) < 1`,
+				Checks:   2,
+				Interval: time.Millisecond,
+				Timeout:  time.Second,
+			}
+			err := runVerification(context.Background(), []string{ts.Listener.Addr().String()}, v)
+
+			l.Lock()
+			defer l.Unlock()
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				must.ErrorContains(t, err, "prometheus check 1 of 2 failed")
+				must.Len(t, 1, queries)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, []string{
+				"/api/v1/query?sum(rate(http_errors[1m])) < 1",
+				"/api/v1/query?sum(rate(http_errors[1m])) < 1",
+			}, queries)
+		})
+	}
+}
+
+func TestRunVerification_AllowedHosts(t *testing.T) {
+	ci.Parallel(t)
+
+	var l sync.Mutex
+	var hits []string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.Lock()
+		defer l.Unlock()
+		hits = append(hits, "other")
+	}))
+	defer other.Close()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.Lock()
+		defer l.Unlock()
+		hits = append(hits, r.URL.Path)
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, other.URL, http.StatusFound)
+		}
+	}))
+	defer ts.Close()
+
+	testCases := []struct {
+		name    string
+		allowed []string
+		path    string
+		expErr  string
+		expHits []string
+	}{
+		{
+			name:    "no allowed hosts",
+			path:    "/health",
+			expErr:  "isn't allowed",
+			expHits: nil,
+		},
+		{
+			name:    "other host",
+			allowed: []string{"example.com", "127.0.0.1:1"},
+			path:    "/health",
+			expErr:  "isn't allowed",
+			expHits: nil,
+		},
+		{
+			name:    "host and port",
+			allowed: []string{ts.Listener.Addr().String()},
+			path:    "/health",
+			expHits: []string{"/health"},
+		},
+		{
+			name:    "host",
+			allowed: []string{"127.0.0.1"},
+			path:    "/health",
+			expHits: []string{"/health"},
+		},
+		{
+			name:    "redirect",
+			allowed: []string{"127.0.0.1"},
+			path:    "/redirect",
+			expErr:  "unexpected response code 302",
+			expHits: []string{"/redirect"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l.Lock()
+			hits = nil
+			l.Unlock()
+
+			v := &structs.UpdateVerify{
+				Type:     structs.UpdateVerifyTypeHTTP,
+				Address:  ts.URL + tc.path,
+				Checks:   1,
+				Interval: time.Millisecond,
+				Timeout:  time.Second,
+			}
+			err := runVerification(context.Background(), tc.allowed, v)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+			} else {
+				must.NoError(t, err)
+			}
+
+			l.Lock()
+			defer l.Unlock()
+			must.Eq(t, tc.expHits, hits)
+		})
+	}
+}
+
+func TestDeploymentVerifications(t *testing.T) {
+	ci.Parallel(t)
+
+	verify := &structs.UpdateVerify{
+		Type:     structs.UpdateVerifyTypeHTTP,
+		Address:  "http://127.0.0.1:8080/health",
+		Checks:   1,
+		Interval: time.Second,
+		Timeout:  time.Second,
+	}
+
+	j := &structs.Job{TaskGroups: []*structs.TaskGroup{
+		{Name: "web", Update: &structs.UpdateStrategy{Verify: verify}},
+		{Name: "api", Update: &structs.UpdateStrategy{Verify: verify.Copy()}},
+		{Name: "cache", Update: &structs.UpdateStrategy{Verify: &structs.UpdateVerify{Address: "http://127.0.0.1:9090"}}},
+		{Name: "db"},
+	}}
+	d := &structs.Deployment{TaskGroups: map[string]*structs.DeploymentState{
+		"web":   {DesiredCanaries: 1},
+		"api":   {DesiredCanaries: 2},
+		"cache": {DesiredCanaries: 0},
+		"db":    {DesiredCanaries: 1},
+	}}
+
+	// The identical verifications of web and api are run once, and cache has
+	// no canaries
+	must.Eq(t, []*structs.UpdateVerify{verify}, deploymentVerifications(d, j))
+}
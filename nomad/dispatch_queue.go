@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// dispatchQueue returns the number of running dispatched jobs of the
+// parameterized job, and its queued dispatched jobs in the order they run. A
+// dispatched job is running until it is dead, unless it is queued.
+func dispatchQueue(ws memdb.WatchSet, store *state.StateStore, parent *structs.Job) (int, []*structs.Job, error) {
+	prefix := parent.ID + structs.DispatchLaunchSuffix
+	iter, err := store.JobsByIDPrefix(ws, parent.Namespace, prefix, state.SortDefault)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var running int
+	var queued []*structs.Job
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		child := raw.(*structs.Job)
+		if child.ParentID != parent.ID || child.Status == structs.JobStatusDead {
+			continue
+		}
+
+		isQueued, err := dispatchQueued(ws, store, child)
+		if err != nil {
+			return 0, nil, err
+		}
+		if isQueued {
+			queued = append(queued, child)
+		} else if !child.Stop {
+			running++
+		}
+	}
+
+	structs.SortDispatchQueue(parent.ParameterizedJob.QueuePolicy, queued)
+	return running, queued, nil
+}
+
+// dispatchQueued returns whether the dispatched job waits in the queue of its
+// parameterized job. A dispatched job is queued while it is held and has no
+// allocations.
+func dispatchQueued(ws memdb.WatchSet, store *state.StateStore, child *structs.Job) (bool, error) {
+	if !child.Held || child.Stop {
+		return false, nil
+	}
+	allocs, err := store.AllocsByJob(ws, child.Namespace, child.ID, true)
+	if err != nil {
+		return false, err
+	}
+	return len(allocs) == 0, nil
+}
+
+// dispatchQueueFull returns whether a job dispatched from the parameterized
+// job must be queued, because as many dispatched jobs as it allows are
+// running or others are already queued.
+func dispatchQueueFull(store *state.StateStore, parent *structs.Job) (bool, error) {
+	maxConcurrent := parent.ParameterizedJob.MaxConcurrent
+	if maxConcurrent == 0 {
+		return false, nil
+	}
+	running, queued, err := dispatchQueue(nil, store, parent)
+	if err != nil {
+		return false, err
+	}
+	return running >= maxConcurrent || len(queued) != 0, nil
+}
+
+// runDispatchQueue creates the evaluations of the queued dispatched jobs of
+// parameterized jobs once fewer dispatched jobs than they allow are running.
+// It is only run on the leader.
+func (s *Server) runDispatchQueue(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	logger := s.logger.Named("dispatch_queue")
+	index := uint64(1)
+	for {
+		_, newIndex, err := s.State().BlockingQuery(readyQueuedDispatches, index, ctx)
+		if err == nil {
+			index = newIndex
+			err = s.dequeueDispatches()
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("failed to dequeue dispatched jobs", "error", err)
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
+// dequeueDispatches evaluates the queued dispatched jobs which can run. The
+// queues are read again with the lock held, so that a concurrent dispatch
+// can't exceed the number of dispatched jobs allowed to run.
+func (s *Server) dequeueDispatches() error {
+	s.dispatchQueueLock.Lock()
+	defer s.dispatchQueueLock.Unlock()
+
+	ready, _, err := readyQueuedDispatches(nil, s.State())
+	if err != nil {
+		return err
+	}
+	for _, job := range ready.([]*structs.Job) {
+		if err := s.evaluateHeldJob(job, structs.EvalTriggerDispatchQueue); err != nil {
+			return err
+		}
+		s.logger.Named("dispatch_queue").Debug("dequeued dispatched job",
+			"namespace", job.Namespace, "job_id", job.ID)
+	}
+	return nil
+}
+
+// readyQueuedDispatches returns the queued dispatched jobs which can run
+// because fewer dispatched jobs than their parameterized job allows are
+// running.
+func readyQueuedDispatches(ws memdb.WatchSet, store *state.StateStore) (interface{}, uint64, error) {
+	iter, err := store.JobsByHeld(ws, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Only the parameterized jobs with held dispatched jobs have a queue
+	parents := make(map[structs.NamespacedID]struct{})
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		child := raw.(*structs.Job)
+		if child.Dispatched && child.ParentID != "" {
+			parents[structs.NamespacedID{ID: child.ParentID, Namespace: child.Namespace}] = struct{}{}
+		}
+	}
+
+	var ready []*structs.Job
+	for id := range parents {
+		parent, err := store.JobByID(ws, id.Namespace, id.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if parent == nil || !parent.IsParameterized() || parent.ParameterizedJob.MaxConcurrent == 0 {
+			continue
+		}
+
+		running, queued, err := dispatchQueue(ws, store, parent)
+		if err != nil {
+			return nil, 0, err
+		}
+		free := min(parent.ParameterizedJob.MaxConcurrent-running, len(queued))
+		if free > 0 {
+			ready = append(ready, queued[:free]...)
+		}
+	}
+
+	// Use the last index that affected the jobs or allocs tables
+	jobsIndex, err := store.Index("jobs")
+	if err != nil {
+		return nil, 0, err
+	}
+	allocsIndex, err := store.Index("allocs")
+	if err != nil {
+		return nil, 0, err
+	}
+	return ready, max(jobsIndex, allocsIndex), nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestJobEndpoint_Dispatch_Queue(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	// Register a parameterized job running one dispatched job at a time
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{MaxConcurrent: 1}
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	dispatch := func() *structs.JobDispatchResponse {
+		req := &structs.JobDispatchRequest{
+			JobID: job.ID,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobDispatchResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp))
+		return &resp
+	}
+	cancel := func(id string) error {
+		req := &structs.JobDispatchCancelRequest{
+			JobID: id,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobDispatchCancelResponse
+		return msgpackrpc.CallWithCodec(codec, "Job.DispatchCancel", req, &resp)
+	}
+
+	// The first dispatched job runs while the others are queued
+	first := dispatch()
+	must.False(t, first.Queued)
+	must.NotEq(t, "", first.EvalID)
+
+	second := dispatch()
+	must.True(t, second.Queued)
+	must.Eq(t, "", second.EvalID)
+	third := dispatch()
+	must.True(t, third.Queued)
+
+	queueReq := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var queueResp structs.JobDispatchQueueResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.DispatchQueue", queueReq, &queueResp))
+	must.Eq(t, 1, queueResp.Queue.MaxConcurrent)
+	must.Eq(t, 1, queueResp.Queue.Running)
+	must.Len(t, 2, queueResp.Queue.Queued)
+	must.Eq(t, second.DispatchedJobID, queueResp.Queue.Queued[0].ID)
+	must.Eq(t, 1, queueResp.Queue.Queued[0].Position)
+
+	// Running dispatched jobs can't be cancelled, queued ones are purged
+	must.ErrorContains(t, cancel(first.DispatchedJobID), "is not a queued dispatched job")
+	must.NoError(t, cancel(third.DispatchedJobID))
+	out, err := store.JobByID(nil, job.Namespace, third.DispatchedJobID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+
+	// Complete the first dispatched job
+	firstJob, err := store.JobByID(nil, job.Namespace, first.DispatchedJobID)
+	must.NoError(t, err)
+	firstEval, err := store.EvalByID(nil, first.EvalID)
+	must.NoError(t, err)
+	alloc := mock.Alloc()
+	alloc.Job = firstJob
+	alloc.JobID = firstJob.ID
+	alloc.EvalID = firstEval.ID
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 2000, []*structs.Allocation{alloc}))
+	firstEval = firstEval.Copy()
+	firstEval.Status = structs.EvalStatusComplete
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 2001, []*structs.Evaluation{firstEval}))
+
+	// The queued dispatched job is evaluated once the first one is dead
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			evals, err := store.EvalsByJob(nil, job.Namespace, second.DispatchedJobID)
+			if err != nil {
+				return err
+			}
+			if len(evals) != 1 {
+				return fmt.Errorf("expected 1 evaluation, got %d", len(evals))
+			}
+			if evals[0].TriggeredBy != structs.EvalTriggerDispatchQueue {
+				return fmt.Errorf("unexpected trigger %q", evals[0].TriggeredBy)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(50*time.Millisecond),
+	))
+
+	// The dequeued dispatched job keeps running, and can't be cancelled, once
+	// its evaluation is garbage collected
+	secondJob, err := store.JobByID(nil, job.Namespace, second.DispatchedJobID)
+	must.NoError(t, err)
+	evals, err := store.EvalsByJob(nil, job.Namespace, second.DispatchedJobID)
+	must.NoError(t, err)
+	alloc = mock.Alloc()
+	alloc.Job = secondJob
+	alloc.JobID = secondJob.ID
+	alloc.EvalID = evals[0].ID
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 3000, []*structs.Allocation{alloc}))
+	must.NoError(t, store.DeleteEval(3001, []string{evals[0].ID}, nil, false))
+	must.ErrorContains(t, cancel(second.DispatchedJobID), "is not a queued dispatched job")
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.DispatchQueue", queueReq, &queueResp))
+	must.Eq(t, 1, queueResp.Queue.Running)
+	must.Len(t, 0, queueResp.Queue.Queued)
+}
@@ -241,11 +241,14 @@ func (n *NodeDrainer) run(ctx context.Context) {
 }
 
 // handleDeadlinedNodes handles a set of nodes reaching their drain deadline.
-// The handler detects the remaining allocations on the nodes and immediately
-// marks them for migration.
+// The handler detects the remaining allocations on the nodes whose deadline
+// has been reached and immediately marks them for migration. Nodes left with
+// allocations of job types with a later deadline keep draining.
 func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 	// Retrieve the set of allocations that will be force stopped.
 	var forceStop []*structs.Allocation
+	var deadlined []string
+	now := time.Now()
 	n.l.RLock()
 	for _, node := range nodes {
 		draining, ok := n.nodes[node]
@@ -254,7 +257,7 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 			continue
 		}
 
-		allocs, err := draining.RemainingAllocs()
+		allocs, pending, next, err := draining.DeadlinedAllocs(now)
 		if err != nil {
 			n.logger.Error("failed to retrieve allocs on deadlined node", "node_id", node, "error", err)
 			continue
@@ -262,6 +265,14 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 
 		n.logger.Debug("node deadlined causing allocs to be force stopped", "node_id", node, "num_allocs", len(allocs))
 		forceStop = append(forceStop, allocs...)
+
+		switch {
+		case !pending:
+			deadlined = append(deadlined, node)
+		case !next.IsZero():
+			n.logger.Debug("node has allocs with a later drain deadline", "node_id", node, "deadline", next)
+			n.deadlineNotifier.Watch(node, next)
+		}
 	}
 	n.l.RUnlock()
 	n.batchDrainAllocs(forceStop)
@@ -274,7 +285,7 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 
 	// Submit the node transitions in a sharded form to ensure a reasonable
 	// Raft transaction size.
-	for _, nodes := range partitionIds(defaultMaxIdsPerTxn, nodes) {
+	for _, nodes := range partitionIds(defaultMaxIdsPerTxn, deadlined) {
 		if _, err := n.raft.NodesDrainComplete(nodes, event); err != nil {
 			n.logger.Error("failed to unset drain for nodes", "error", err)
 		}
@@ -397,10 +408,28 @@ func (n *NodeDrainer) drainAllocs(future *structs.BatchFuture, allocs []*structs
 	// Compute the effected jobs and make the transition map
 	jobs := make(map[structs.NamespacedID]*structs.Job, 4)
 	transitions := make(map[string]*structs.DesiredTransition, len(allocs))
+	killPolicies := make(map[string]*structs.DrainKillPolicy)
 	for _, alloc := range allocs {
-		transitions[alloc.ID] = &structs.DesiredTransition{
+		transition := &structs.DesiredTransition{
 			Migrate: pointer.Of(true),
 		}
+
+		// Pass on the kill policy of the drain of the node
+		policy, ok := killPolicies[alloc.NodeID]
+		if !ok {
+			policy = n.nodeKillPolicy(alloc.NodeID)
+			killPolicies[alloc.NodeID] = policy
+		}
+		if policy != nil {
+			if policy.Signal != "" {
+				transition.KillSignal = pointer.Of(policy.Signal)
+			}
+			if policy.Timeout > 0 {
+				transition.KillTimeout = pointer.Of(policy.Timeout)
+			}
+		}
+
+		transitions[alloc.ID] = transition
 		jobs[alloc.JobNamespacedID()] = alloc.Job
 	}
 
@@ -433,3 +462,16 @@ func (n *NodeDrainer) drainAllocs(future *structs.BatchFuture, allocs []*structs
 
 	future.Respond(finalIndex, nil)
 }
+
+// nodeKillPolicy returns the kill policy of the drain of the node, if any.
+func (n *NodeDrainer) nodeKillPolicy(nodeID string) *structs.DrainKillPolicy {
+	node, err := n.state.NodeByID(nil, nodeID)
+	if err != nil {
+		n.logger.Error("failed to retrieve draining node", "node_id", nodeID, "error", err)
+		return nil
+	}
+	if node == nil || node.DrainStrategy == nil {
+		return nil
+	}
+	return node.DrainStrategy.KillPolicy
+}
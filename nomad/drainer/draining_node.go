@@ -37,17 +37,79 @@ func (n *drainingNode) Update(node *structs.Node) {
 	n.node = node
 }
 
-// DeadlineTime returns if the node has a deadline and if so what it is
-func (n *drainingNode) DeadlineTime() (bool, time.Time) {
+// DeadlineTime returns if the node has a deadline and if so what it is. When
+// job types have their own deadline, it is the earliest deadline of the
+// allocations remaining on the node.
+func (n *drainingNode) DeadlineTime() (bool, time.Time, error) {
 	n.l.RLock()
-	defer n.l.RUnlock()
+	node := n.node
+	n.l.RUnlock()
 
 	// Should never happen
-	if n.node == nil || n.node.DrainStrategy == nil {
-		return false, time.Time{}
+	if node == nil || node.DrainStrategy == nil {
+		return false, time.Time{}, nil
+	}
+
+	strategy := node.DrainStrategy
+	if len(strategy.JobTypeDeadlines) == 0 {
+		inf, deadline := strategy.DeadlineTime()
+		return inf, deadline, nil
+	}
+
+	allocs, err := n.RemainingAllocs()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	if len(allocs) == 0 {
+		inf, deadline := strategy.DeadlineTime()
+		return inf, deadline, nil
 	}
 
-	return n.node.DrainStrategy.DeadlineTime()
+	infinite, next := true, time.Time{}
+	for _, alloc := range allocs {
+		inf, deadline := strategy.JobTypeDeadlineTime(alloc.Job.Type)
+		if inf {
+			continue
+		}
+		if infinite || deadline.Before(next) {
+			infinite, next = false, deadline
+		}
+	}
+	return infinite, next, nil
+}
+
+// DeadlinedAllocs returns the allocations remaining on the node whose drain
+// deadline has been reached at the given time. It also returns whether other
+// allocations remain to be drained, and the earliest deadline of those
+// allocations, which is zero if they have none.
+func (n *drainingNode) DeadlinedAllocs(now time.Time) ([]*structs.Allocation, bool, time.Time, error) {
+	allocs, err := n.RemainingAllocs()
+	if err != nil {
+		return nil, false, time.Time{}, err
+	}
+
+	n.l.RLock()
+	strategy := n.node.DrainStrategy
+	n.l.RUnlock()
+
+	var deadlined []*structs.Allocation
+	var pending bool
+	var next time.Time
+	for _, alloc := range allocs {
+		inf, deadline := strategy.JobTypeDeadlineTime(alloc.Job.Type)
+		switch {
+		case inf:
+			pending = true
+		case !deadline.After(now):
+			deadlined = append(deadlined, alloc)
+		default:
+			pending = true
+			if next.IsZero() || deadline.Before(next) {
+				next = deadline
+			}
+		}
+	}
+	return deadlined, pending, next, nil
 }
 
 // IsDone returns if the node is done draining batch and service allocs. System
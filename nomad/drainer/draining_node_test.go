@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -219,3 +220,80 @@ func TestDrainingNode_Table(t *testing.T) {
 		})
 	}
 }
+
+func allocIDs(allocs []*structs.Allocation) []string {
+	ids := make([]string, 0, len(allocs))
+	for _, alloc := range allocs {
+		ids = append(ids, alloc.ID)
+	}
+	return ids
+}
+
+func TestDrainingNode_JobTypeDeadlines(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	now := time.Now()
+	node := mock.Node()
+	node.DrainStrategy = &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline: time.Hour,
+			JobTypeDeadlines: map[string]time.Duration{
+				structs.JobTypeBatch:  3 * time.Hour,
+				structs.JobTypeSystem: -1,
+			},
+		},
+	}
+	node.DrainStrategy.SetForceDeadlines(now)
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+	dn := NewDrainingNode(node, store)
+
+	// Without allocs, the deadline of the node applies
+	inf, deadline, err := dn.DeadlineTime()
+	must.NoError(t, err)
+	must.False(t, inf)
+	must.Eq(t, now.Add(time.Hour), deadline)
+
+	service, batch, system := mock.Alloc(), mock.BatchAlloc(), mock.SystemAlloc()
+	allocs := []*structs.Allocation{service, batch, system}
+	for _, a := range allocs {
+		a.NodeID = node.ID
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, a.Job))
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 102, allocs))
+
+	// System allocs are force drained
+	inf, deadline, err = dn.DeadlineTime()
+	must.NoError(t, err)
+	must.False(t, inf)
+	must.True(t, deadline.IsZero())
+
+	deadlined, pending, next, err := dn.DeadlinedAllocs(now)
+	must.NoError(t, err)
+	must.SliceContainsAll(t, []string{system.ID}, allocIDs(deadlined))
+	must.True(t, pending)
+	must.Eq(t, now.Add(time.Hour), next)
+
+	// Service allocs are drained after the deadline of the node, while batch
+	// allocs have longer to complete
+	deadlined, pending, next, err = dn.DeadlinedAllocs(now.Add(2 * time.Hour))
+	must.NoError(t, err)
+	must.SliceContainsAll(t, []string{service.ID, system.ID}, allocIDs(deadlined))
+	must.True(t, pending)
+	must.Eq(t, now.Add(3*time.Hour), next)
+
+	deadlined, pending, _, err = dn.DeadlinedAllocs(now.Add(3 * time.Hour))
+	must.NoError(t, err)
+	must.Len(t, 3, deadlined)
+	must.False(t, pending)
+
+	// Batch allocs without a deadline keep the node draining
+	node = node.Copy()
+	node.DrainStrategy.JobTypeDeadlines[structs.JobTypeBatch] = 0
+	dn.Update(node)
+	deadlined, pending, next, err = dn.DeadlinedAllocs(now.Add(3 * time.Hour))
+	must.NoError(t, err)
+	must.Len(t, 2, deadlined)
+	must.True(t, pending)
+	must.True(t, next.IsZero())
+}
@@ -60,7 +60,9 @@ func (n *NodeDrainer) Update(node *structs.Node) {
 		draining.Update(node)
 	}
 
-	if inf, deadline := node.DrainStrategy.DeadlineTime(); !inf {
+	if inf, deadline, err := draining.DeadlineTime(); err != nil {
+		n.logger.Error("error retrieving drain deadline of node", "node_id", node.ID, "error", err)
+	} else if !inf {
 		n.deadlineNotifier.Watch(node.ID, deadline)
 	} else {
 		// There is an infinite deadline so it shouldn't be tracked for
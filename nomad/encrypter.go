@@ -4,6 +4,7 @@
 package nomad
 
 import (
+	"cmp"
 	"context"
 	"crypto/aes"
 	"crypto/cipher"
@@ -16,6 +17,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -39,7 +41,15 @@ import (
 	"golang.org/x/time/rate"
 )
 
-const nomadKeystoreExtension = ".nks.json"
+const (
+	nomadKeystoreExtension = ".nks.json"
+
+	// decryptWorkers is the number of keys the encrypter decrypts
+	// concurrently. Decrypting a key with an external KMS requires a request
+	// to the KMS, so this bounds the number of requests in flight when a
+	// server restores a large keyring.
+	decryptWorkers = 8
+)
 
 type claimSigner interface {
 	SignClaims(*structs.IdentityClaims) (string, string, error)
@@ -72,8 +82,26 @@ type Encrypter struct {
 	// The nature and design of the encrypter as well as other Nomad systems
 	// means we can have more than 1 task attempting to decrypt the same key. In
 	// this case, we adopt first-past-the-post.
-	decryptTasks     map[string]struct{}
+	decryptTasks     map[string]*decryptTask
 	decryptTasksLock sync.RWMutex
+
+	// decryptWorkerCh bounds the number of concurrent KMS decrypt requests. A
+	// task sends to the channel before each request and receives from it once
+	// the request is done, so that retries don't hold up other keys.
+	decryptWorkerCh chan struct{}
+}
+
+// decryptTask tracks the progress of decrypting a key, which is reported by
+// the Keyring.Status RPC.
+type decryptTask struct {
+	providers []string
+	queueTime time.Time
+	startTime time.Time // zero while waiting for a worker
+	attempts  int
+	lastErr   error
+
+	// waiters is the number of AddWrappedKey calls waiting on the task
+	waiters int
 }
 
 // cipherSet contains the key material for variable encryption and workload
@@ -99,7 +127,8 @@ func NewEncrypter(srv *Server, keystorePath string) (*Encrypter, error) {
 		keyring:         make(map[string]*cipherSet),
 		issuer:          srv.GetConfig().OIDCIssuer,
 		providerConfigs: map[string]*structs.KEKProviderConfig{},
-		decryptTasks:    map[string]struct{}{},
+		decryptTasks:    map[string]*decryptTask{},
+		decryptWorkerCh: make(chan struct{}, decryptWorkers),
 	}
 
 	providerConfigs, err := getProviderConfigs(srv)
@@ -460,9 +489,18 @@ func (e *Encrypter) AddWrappedKey(ctx context.Context, wrappedKeys *structs.Root
 	cipherSetCh := make(chan *cipherSet)
 
 	// We will use the key ID to track the decrypt tasks for this key. Doing
-	// this here means we can do this once per function call.
+	// this here means we can do this once per function call. A concurrent
+	// call for the same key keeps the progress of the existing task.
 	e.decryptTasksLock.Lock()
-	e.decryptTasks[wrappedKeys.KeyID] = struct{}{}
+	task, ok := e.decryptTasks[wrappedKeys.KeyID]
+	if !ok {
+		task = &decryptTask{
+			providers: wrappedKeys.Providers(),
+			queueTime: time.Now(),
+		}
+		e.decryptTasks[wrappedKeys.KeyID] = task
+	}
+	task.waiters++
 	e.decryptTasksLock.Unlock()
 
 	for _, wrappedKey := range wrappedKeys.WrappedKeys {
@@ -520,9 +558,15 @@ func (e *Encrypter) AddWrappedKey(ctx context.Context, wrappedKeys *structs.Root
 	select {
 	case <-completeCtx.Done():
 
-		// In this event, the server is shutting down and the agent process will
-		// exit. This means the decrypter state will be lost, so there is no
-		// need to tidy the decryption task state.
+		// In this event, the server is shutting down or the caller gave up.
+		// Stop tracking the task unless another call for the same key is
+		// still waiting on it, so it isn't reported as pending forever.
+		e.decryptTasksLock.Lock()
+		task.waiters--
+		if task.waiters == 0 && e.decryptTasks[wrappedKeys.KeyID] == task {
+			delete(e.decryptTasks, wrappedKeys.KeyID)
+		}
+		e.decryptTasksLock.Unlock()
 		return completeCtx.Err()
 
 	case generatedCipher := <-cipherSetCh:
@@ -570,10 +614,14 @@ func (e *Encrypter) decryptWrappedKeyTask(
 	err := helper.WithBackoffFunc(ctx, minBackoff, maxBackoff, func() error {
 		wrappedDEK := wrappedKey.WrappedDataEncryptionKey
 		var err error
-		key, err = wrapper.Decrypt(e.srv.shutdownCtx, wrappedDEK)
+		key, err = e.decryptWithWorker(ctx, wrapper, meta.KeyID, wrappedDEK)
+		if err != nil && ctx.Err() != nil {
+			return err // another task completed or the server is shutting down
+		}
 		if err != nil {
 			err := fmt.Errorf("%w (root key): %w", ErrDecryptFailed, err)
 			e.log.Error(err.Error(), "key_id", meta.KeyID)
+			e.recordDecryptFailure(meta.KeyID, err)
 			return err
 		}
 		return nil
@@ -589,10 +637,14 @@ func (e *Encrypter) decryptWrappedKeyTask(
 		// 1.7 an ed25519 key derived from the root key was used instead of an RSA
 		// key.
 		if wrappedKey.WrappedRSAKey != nil && len(wrappedKey.WrappedRSAKey.Ciphertext) > 0 {
-			rsaKey, err = wrapper.Decrypt(e.srv.shutdownCtx, wrappedKey.WrappedRSAKey)
+			rsaKey, err = e.decryptWithWorker(ctx, wrapper, meta.KeyID, wrappedKey.WrappedRSAKey)
+			if err != nil && ctx.Err() != nil {
+				return err
+			}
 			if err != nil {
 				err := fmt.Errorf("%w (rsa key): %w", ErrDecryptFailed, err)
 				e.log.Error(err.Error(), "key_id", meta.KeyID)
+				e.recordDecryptFailure(meta.KeyID, err)
 			}
 		}
 		return nil
@@ -614,6 +666,7 @@ func (e *Encrypter) decryptWrappedKeyTask(
 		if err != nil {
 			err := fmt.Errorf("could not add cipher: %w", err)
 			e.log.Error(err.Error(), "key_id", meta.KeyID)
+			e.recordDecryptFailure(meta.KeyID, err)
 			return err
 		}
 		return nil
@@ -636,6 +689,74 @@ func (e *Encrypter) decryptWrappedKeyTask(
 	return nil
 }
 
+// decryptWithWorker decrypts a blob with the KMS once a worker is available,
+// so that restoring a large keyring doesn't send a request for every key to
+// the KMS at once. The worker is only held for the request itself, and the
+// task of the key is marked as started once it gets a worker.
+func (e *Encrypter) decryptWithWorker(ctx context.Context, wrapper kms.Wrapper,
+	keyID string, blob *kms.BlobInfo) ([]byte, error) {
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case e.decryptWorkerCh <- struct{}{}:
+	}
+	defer func() { <-e.decryptWorkerCh }()
+
+	e.decryptTasksLock.Lock()
+	if task, ok := e.decryptTasks[keyID]; ok && task.startTime.IsZero() {
+		task.startTime = time.Now()
+	}
+	e.decryptTasksLock.Unlock()
+
+	return wrapper.Decrypt(e.srv.shutdownCtx, blob)
+}
+
+// recordDecryptFailure records a failed attempt of a decrypt task, so that an
+// operator can identify keys that cannot be decrypted.
+func (e *Encrypter) recordDecryptFailure(keyID string, err error) {
+	e.decryptTasksLock.Lock()
+	defer e.decryptTasksLock.Unlock()
+	if task, ok := e.decryptTasks[keyID]; ok {
+		task.attempts++
+		task.lastErr = err
+	}
+}
+
+// DecryptStatus returns the number of keys in the keyring and the status of
+// the keys still being decrypted, sorted by the time they were queued.
+func (e *Encrypter) DecryptStatus() (int, []*structs.KeyringDecryptStatus) {
+	e.keyringLock.RLock()
+	numKeys := len(e.keyring)
+	e.keyringLock.RUnlock()
+
+	e.decryptTasksLock.RLock()
+	defer e.decryptTasksLock.RUnlock()
+
+	pending := make([]*structs.KeyringDecryptStatus, 0, len(e.decryptTasks))
+	for keyID, task := range e.decryptTasks {
+		status := &structs.KeyringDecryptStatus{
+			KeyID:     keyID,
+			State:     structs.KeyringDecryptStateQueued,
+			Providers: slices.Clone(task.providers),
+			QueueTime: task.queueTime.UnixNano(),
+			Attempts:  task.attempts,
+		}
+		if !task.startTime.IsZero() {
+			status.State = structs.KeyringDecryptStateDecrypting
+			status.StartTime = task.startTime.UnixNano()
+		}
+		if task.lastErr != nil {
+			status.LastError = task.lastErr.Error()
+		}
+		pending = append(pending, status)
+	}
+	slices.SortFunc(pending, func(a, b *structs.KeyringDecryptStatus) int {
+		return cmp.Or(cmp.Compare(a.QueueTime, b.QueueTime), strings.Compare(a.KeyID, b.KeyID))
+	})
+	return numKeys, pending
+}
+
 // addCipher creates a new cipherSet for the key and stores them in the keyring
 func (e *Encrypter) addCipher(rootKey *structs.UnwrappedRootKey) error {
 
@@ -822,6 +943,44 @@ func (e *Encrypter) wrapRootKey(rootKey *structs.UnwrappedRootKey, isUpgraded bo
 	return wrappedKeys, nil
 }
 
+// RewrapRequired returns true if the root key needs to be wrapped again
+// because an active KEK provider hasn't wrapped it, or because the external
+// KMS key that wrapped it has since been rotated. The current KMS key ID is
+// only returned by the KMS on encryption, so this encrypts a throwaway
+// plaintext with each external provider.
+func (e *Encrypter) RewrapRequired(rootKey *structs.RootKey) (bool, error) {
+	for _, provider := range e.providerConfigs {
+		if !provider.Active {
+			continue
+		}
+		idx := slices.IndexFunc(rootKey.WrappedKeys, func(wrappedKey *structs.WrappedKey) bool {
+			return wrappedKey.ProviderID == provider.ID()
+		})
+		if idx == -1 {
+			return true, nil
+		}
+		if provider.Provider == string(structs.KEKProviderAEAD) || provider.Provider == "" {
+			continue // the KEK is unique to the key and never rotated
+		}
+
+		wrapper, err := e.newKMSWrapper(provider, rootKey.KeyID, nil)
+		if err != nil {
+			return false, fmt.Errorf("unable to create key wrapper: %w", err)
+		}
+		blob, err := wrapper.Encrypt(e.srv.shutdownCtx, []byte(rootKey.KeyID))
+		if err != nil {
+			return false, fmt.Errorf("failed to query KMS key for provider %q: %w",
+				provider.ID(), err)
+		}
+		currentKeyID := blob.GetKeyInfo().GetKeyId()
+		wrappedKeyID := rootKey.WrappedKeys[idx].WrappedDataEncryptionKey.GetKeyInfo().GetKeyId()
+		if currentKeyID != "" && currentKeyID != wrappedKeyID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // encryptDEK encrypts the DEKs (one for encryption and one for signing) with
 // the KMS provider and returns a WrappedKey built from the provider's
 // kms.BlobInfo. This includes the cleartext KEK for the AEAD provider.
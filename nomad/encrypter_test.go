@@ -869,7 +869,7 @@ func TestEncrypter_IsReady_eventuallyReady(t *testing.T) {
 	// Add an initial decryption task to the encrypter. This simulates a key
 	// restored from the Raft state (snapshot or trailing logs) as the server is
 	// starting.
-	encrypter.decryptTasks["id1"] = struct{}{}
+	encrypter.decryptTasks["id1"] = &decryptTask{}
 
 	// Generate a timeout value that will be used to create the context passed
 	// to the encrypter. Changing this value should not impact the test except
@@ -904,7 +904,7 @@ func TestEncrypter_IsReady_eventuallyReady(t *testing.T) {
 	select {
 	case <-taskAddTimer.C:
 		encrypter.decryptTasksLock.Lock()
-		encrypter.decryptTasks["id2"] = struct{}{}
+		encrypter.decryptTasks["id2"] = &decryptTask{}
 		encrypter.decryptTasksLock.Unlock()
 	case <-taskDeleteTimer.C:
 		encrypter.decryptTasksLock.Lock()
@@ -931,8 +931,8 @@ func TestEncrypter_IsReady_timeout(t *testing.T) {
 	must.NoError(t, err)
 
 	// Add some tasks to the encrypter that we will never remove.
-	encrypter.decryptTasks["id1"] = struct{}{}
-	encrypter.decryptTasks["id2"] = struct{}{}
+	encrypter.decryptTasks["id1"] = &decryptTask{}
+	encrypter.decryptTasks["id2"] = &decryptTask{}
 
 	// Generate a timeout context that allows the backoff to trigger a few times
 	// before being canceled.
@@ -1245,3 +1245,144 @@ func TestEncrypter_AddWrappedKey_noWrappedKeys(t *testing.T) {
 	must.NoError(t, encrypter.IsReady(timeoutCtx))
 	must.MapLen(t, 0, encrypter.decryptTasks)
 }
+
+func TestEncrypter_RewrapRequired(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := &Server{
+		logger: testlog.HCLogger(t),
+		config: &Config{},
+	}
+
+	encrypter, err := NewEncrypter(srv, t.TempDir())
+	must.NoError(t, err)
+
+	key, err := structs.NewUnwrappedRootKey(structs.EncryptionAlgorithmAES256GCM)
+	must.NoError(t, err)
+	wrappedKeys, err := encrypter.AddUnwrappedKey(key, true)
+	must.NoError(t, err)
+	must.Eq(t, []string{"aead"}, wrappedKeys.Meta().Providers)
+
+	required, err := encrypter.RewrapRequired(wrappedKeys)
+	must.NoError(t, err)
+	must.False(t, required)
+
+	// switching the active provider requires rewrapping the key with it
+	encrypter.providerConfigs["aead"].Active = false
+	encrypter.providerConfigs["aead.next"] = &structs.KEKProviderConfig{
+		Provider: string(structs.KEKProviderAEAD),
+		Name:     "next",
+		Active:   true,
+	}
+	required, err = encrypter.RewrapRequired(wrappedKeys)
+	must.NoError(t, err)
+	must.True(t, required)
+
+	wrappedKeys, err = encrypter.AddUnwrappedKey(key, true)
+	must.NoError(t, err)
+	must.Eq(t, []string{"aead.next"}, wrappedKeys.Meta().Providers)
+
+	required, err = encrypter.RewrapRequired(wrappedKeys)
+	must.NoError(t, err)
+	must.False(t, required)
+}
+
+func TestEncrypter_DecryptStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := &Server{
+		logger:      testlog.HCLogger(t),
+		config:      &Config{},
+		shutdownCtx: context.Background(),
+	}
+
+	encrypter, err := NewEncrypter(srv, t.TempDir())
+	must.NoError(t, err)
+
+	key, err := structs.NewUnwrappedRootKey(structs.EncryptionAlgorithmAES256GCM)
+	must.NoError(t, err)
+	wrappedKeys, err := encrypter.wrapRootKey(key, true)
+	must.NoError(t, err)
+
+	// corrupt the wrapped key so that decryption is retried until canceled
+	badKeys := wrappedKeys.Copy()
+	badKeys.WrappedKeys[0] = badKeys.WrappedKeys[0].Copy()
+	badKeys.WrappedKeys[0].KeyEncryptionKey = make([]byte, 32)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// occupy every worker so that the key has to wait
+	for range decryptWorkers {
+		encrypter.decryptWorkerCh <- struct{}{}
+	}
+
+	go encrypter.AddWrappedKey(ctx, badKeys)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			_, pending := encrypter.DecryptStatus()
+			return len(pending) == 1
+		}),
+		wait.Timeout(3*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	numKeys, pending := encrypter.DecryptStatus()
+	must.Zero(t, numKeys)
+	must.Eq(t, badKeys.KeyID, pending[0].KeyID)
+	must.Eq(t, structs.KeyringDecryptStateQueued, pending[0].State)
+	must.Eq(t, []string{"aead"}, pending[0].Providers)
+	must.Zero(t, pending[0].StartTime)
+	must.Zero(t, pending[0].Attempts)
+
+	// freeing a worker starts decrypting the queued key
+	<-encrypter.decryptWorkerCh
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			_, pending := encrypter.DecryptStatus()
+			if len(pending) != 1 || pending[0].Attempts == 0 {
+				return fmt.Errorf("expected failed attempt, got %#v", pending)
+			}
+			return nil
+		}),
+		wait.Timeout(3*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	_, pending = encrypter.DecryptStatus()
+	must.Eq(t, structs.KeyringDecryptStateDecrypting, pending[0].State)
+	must.Positive(t, pending[0].StartTime)
+	must.StrContains(t, pending[0].LastError, ErrDecryptFailed.Error())
+
+	// the worker is released between retries, so other keys are decrypted
+	// while the failing key is retried
+	otherKey, err := structs.NewUnwrappedRootKey(structs.EncryptionAlgorithmAES256GCM)
+	must.NoError(t, err)
+	otherKeys, err := encrypter.wrapRootKey(otherKey, true)
+	must.NoError(t, err)
+
+	go encrypter.AddWrappedKey(ctx, otherKeys)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			numKeys, pending := encrypter.DecryptStatus()
+			return numKeys == 1 && len(pending) == 1 && pending[0].KeyID == badKeys.KeyID
+		}),
+		wait.Timeout(3*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// the failing key is no longer reported once its caller gives up
+	cancel()
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			_, pending := encrypter.DecryptStatus()
+			return len(pending) == 0
+		}),
+		wait.Timeout(3*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
@@ -283,7 +283,7 @@ func validateNsOp(namespace string, topics map[structs.Topic][]string, aclObj *a
 			if ok := aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob); !ok {
 				return structs.ErrPermissionDenied
 			}
-		case structs.TopicNode:
+		case structs.TopicNode, structs.TopicNodeMaintenance:
 			if ok := aclObj.AllowNodeRead(); !ok {
 				return structs.ErrPermissionDenied
 			}
@@ -297,7 +297,13 @@ func validateNsOp(namespace string, topics map[structs.Topic][]string, aclObj *a
 			if ok := aclObj.AllowOperatorRead(); !ok {
 				return structs.ErrPermissionDenied
 			}
-		default: // including TopicAll
+		case structs.TopicVariable:
+			// Events can't be filtered by the variable paths the token has
+			// access to, so require listing all the paths of the namespace.
+			if ok := aclObj.AllowVariableOperation(namespace, "*", acl.VariablesCapabilityList, nil); !ok {
+				return structs.ErrPermissionDenied
+			}
+		default: // including TopicAll and TopicRootKey
 			if ok := aclObj.IsManagement(); !ok {
 				return structs.ErrPermissionDenied
 			}
@@ -469,6 +469,28 @@ func TestEventStream_validateNsOp(t *testing.T) {
 			Management:  false,
 			ExpectedErr: structs.ErrPermissionDenied,
 		},
+		{
+			Name: "read variables - list all paths",
+			Topics: map[structs.Topic][]string{
+				structs.TopicVariable: {"*"},
+			},
+			Policy: mock.NamespacePolicyWithVariables("foo", "", nil,
+				map[string][]string{"*": {acl.VariablesCapabilityList}}),
+			Namespace:   "foo",
+			Management:  false,
+			ExpectedErr: nil,
+		},
+		{
+			Name: "read variables - list some paths",
+			Topics: map[structs.Topic][]string{
+				structs.TopicVariable: {"*"},
+			},
+			Policy: mock.NamespacePolicyWithVariables("foo", "", nil,
+				map[string][]string{"app/*": {acl.VariablesCapabilityList}}),
+			Namespace:   "foo",
+			Management:  false,
+			ExpectedErr: structs.ErrPermissionDenied,
+		},
 	}
 
 	for _, tc := range cases {
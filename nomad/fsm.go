@@ -58,6 +58,10 @@ const (
 	JobSubmissionSnapshot                SnapshotType = 29
 	RootKeySnapshot                      SnapshotType = 30
 	HostVolumeSnapshot                   SnapshotType = 31
+	VariablesHistorySnapshot             SnapshotType = 32
+	QuotaSpecSnapshot                    SnapshotType = 33
+	QuotaUsageSnapshot                   SnapshotType = 34
+	NodeMaintenanceWindowSnapshot        SnapshotType = 35
 
 	// TimeTableSnapshot
 	// Deprecated: Nomad no longer supports TimeTable snapshots since 1.9.2
@@ -109,6 +113,10 @@ var snapshotTypeStrings = map[SnapshotType]string{
 	JobSubmissionSnapshot:                "JobSubmission",
 	RootKeySnapshot:                      "WrappedRootKeys",
 	HostVolumeSnapshot:                   "HostVolumeSnapshot",
+	VariablesHistorySnapshot:             "VariablesHistory",
+	QuotaSpecSnapshot:                    "QuotaSpec",
+	QuotaUsageSnapshot:                   "QuotaUsage",
+	NodeMaintenanceWindowSnapshot:        "NodeMaintenanceWindow",
 	NamespaceSnapshot:                    "Namespace",
 }
 
@@ -195,17 +203,22 @@ type FSMConfig struct {
 
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
+
+	// VariableTrackedVersions is the number of historic versions that are kept
+	// for each variable.
+	VariableTrackedVersions int
 }
 
 // NewFSM is used to construct a new FSM with a blank state.
 func NewFSM(config *FSMConfig) (*nomadFSM, error) {
 	// Create a state store
 	sconfig := &state.StateStoreConfig{
-		Logger:             config.Logger,
-		Region:             config.Region,
-		EnablePublisher:    config.EnableEventBroker,
-		EventBufferSize:    config.EventBufferSize,
-		JobTrackedVersions: config.JobTrackedVersions,
+		Logger:                  config.Logger,
+		Region:                  config.Region,
+		EnablePublisher:         config.EnableEventBroker,
+		EventBufferSize:         config.EventBufferSize,
+		JobTrackedVersions:      config.JobTrackedVersions,
+		VariableTrackedVersions: config.VariableTrackedVersions,
 	}
 	state, err := state.NewStateStore(sconfig)
 	if err != nil {
@@ -272,6 +285,10 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyNodePoolUpsert(msgType, buf[1:], log.Index)
 	case structs.NodePoolDeleteRequestType:
 		return n.applyNodePoolDelete(msgType, buf[1:], log.Index)
+	case structs.NodeMaintenanceWindowUpsertRequestType:
+		return n.applyNodeMaintenanceWindowUpsert(msgType, buf[1:], log.Index)
+	case structs.NodeMaintenanceWindowDeleteRequestType:
+		return n.applyNodeMaintenanceWindowDelete(msgType, buf[1:], log.Index)
 	case structs.JobRegisterRequestType:
 		return n.applyUpsertJob(msgType, buf[1:], log.Index)
 	case structs.JobDeregisterRequestType:
@@ -396,6 +413,14 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyHostVolumeDelete(msgType, buf[1:], log.Index)
 	case structs.TaskGroupHostVolumeClaimDeleteRequestType:
 		return n.applyTaskGroupHostVolumeClaimDelete(buf[1:], log.Index)
+	case structs.QuotaSpecUpsertRequestType:
+		return n.applyQuotaSpecUpsert(buf[1:], log.Index)
+	case structs.QuotaSpecDeleteRequestType:
+		return n.applyQuotaSpecDelete(buf[1:], log.Index)
+	case structs.AllocUpdateResourcesRequestType:
+		return n.applyAllocUpdateResources(msgType, buf[1:], log.Index)
+	case structs.CSIVolumeManagedSnapshotsRequestType:
+		return n.applyCSIVolumeManagedSnapshots(buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -608,6 +633,36 @@ func (n *nomadFSM) applyNodePoolDelete(msgType structs.MessageType, buf []byte,
 	return nil
 }
 
+func (n *nomadFSM) applyNodeMaintenanceWindowUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_node_maintenance_window_upsert"}, time.Now())
+	var req structs.NodeMaintenanceWindowUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertNodeMaintenanceWindows(msgType, index, req.Windows); err != nil {
+		n.logger.Error("UpsertNodeMaintenanceWindows failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyNodeMaintenanceWindowDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_node_maintenance_window_delete"}, time.Now())
+	var req structs.NodeMaintenanceWindowDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteNodeMaintenanceWindows(msgType, index, req.IDs); err != nil {
+		n.logger.Error("DeleteNodeMaintenanceWindows failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) applyUpsertJob(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "register_job"}, time.Now())
 	var req structs.JobRegisterRequest
@@ -749,7 +804,7 @@ func (n *nomadFSM) applyDeregisterJob(msgType structs.MessageType, buf []byte, i
 	}
 
 	err := n.state.WithWriteTransaction(msgType, index, func(tx state.Txn) error {
-		err := n.handleJobDeregister(index, req.JobID, req.Namespace, req.Purge, req.SubmitTime, req.NoShutdownDelay, tx)
+		err := n.handleJobDeregister(index, req.JobID, req.Namespace, req.Purge, req.SubmitTime, req.NoShutdownDelay, req.ShutdownDelay, tx)
 
 		if err != nil {
 			n.logger.Error("deregistering job failed",
@@ -788,7 +843,7 @@ func (n *nomadFSM) applyBatchDeregisterJob(msgType structs.MessageType, buf []by
 	// store readers.
 	return n.state.WithWriteTransaction(msgType, index, func(tx state.Txn) error {
 		for jobNS, options := range req.Jobs {
-			if err := n.handleJobDeregister(index, jobNS.ID, jobNS.Namespace, options.Purge, req.SubmitTime, false, tx); err != nil {
+			if err := n.handleJobDeregister(index, jobNS.ID, jobNS.Namespace, options.Purge, req.SubmitTime, false, 0, tx); err != nil {
 				n.logger.Error("deregistering job failed", "job", jobNS.ID, "error", err)
 				return err
 			}
@@ -799,19 +854,24 @@ func (n *nomadFSM) applyBatchDeregisterJob(msgType structs.MessageType, buf []by
 
 // handleJobDeregister is used to deregister a job. Leaves error logging up to
 // caller.
-func (n *nomadFSM) handleJobDeregister(index uint64, jobID, namespace string, purge bool, submitTime int64, noShutdownDelay bool, tx state.Txn) error {
+func (n *nomadFSM) handleJobDeregister(index uint64, jobID, namespace string, purge bool, submitTime int64, noShutdownDelay bool, shutdownDelay time.Duration, tx state.Txn) error {
 	// If it is periodic remove it from the dispatcher
 	if err := n.periodicDispatcher.Remove(namespace, jobID); err != nil {
 		return fmt.Errorf("periodicDispatcher.Remove failed: %w", err)
 	}
 
-	if noShutdownDelay {
+	if noShutdownDelay || shutdownDelay > 0 {
 		ws := memdb.NewWatchSet()
 		allocs, err := n.state.AllocsByJob(ws, namespace, jobID, false)
 		if err != nil {
 			return err
 		}
-		transition := &structs.DesiredTransition{NoShutdownDelay: pointer.Of(true)}
+		transition := &structs.DesiredTransition{}
+		if noShutdownDelay {
+			transition.NoShutdownDelay = pointer.Of(true)
+		} else {
+			transition.ShutdownDelay = pointer.Of(shutdownDelay)
+		}
 		for _, alloc := range allocs {
 			err := n.state.UpdateAllocDesiredTransitionTxn(tx, index, alloc.ID, transition)
 			if err != nil {
@@ -1015,6 +1075,22 @@ func (n *nomadFSM) applyAllocUpdateDesiredTransition(msgType structs.MessageType
 	return nil
 }
 
+// applyAllocUpdateResources is used to update the allocated resources of the
+// tasks of an allocation resized in place.
+func (n *nomadFSM) applyAllocUpdateResources(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "alloc_update_resources"}, time.Now())
+	var req structs.AllocUpdateResourcesRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateAllocResources(msgType, index, req.AllocID, req.Tasks); err != nil {
+		n.logger.Error("UpdateAllocResources failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 // applyReconcileSummaries reconciles summaries for all the jobs
 func (n *nomadFSM) applyReconcileSummaries(buf []byte, index uint64) interface{} {
 	if err := n.state.ReconcileJobSummaries(index); err != nil {
@@ -1329,6 +1405,21 @@ func (n *nomadFSM) applyCSIVolumeRegister(buf []byte, index uint64) interface{}
 	return nil
 }
 
+func (n *nomadFSM) applyCSIVolumeManagedSnapshots(buf []byte, index uint64) interface{} {
+	var req structs.CSIVolumeManagedSnapshotsRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_csi_volume_managed_snapshots"}, time.Now())
+
+	if err := n.state.UpdateCSIVolumeManagedSnapshots(index, req.Namespace, req.VolumeID, req.Snapshots); err != nil {
+		n.logger.Error("CSIVolumeManagedSnapshots failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) applyCSIVolumeDeregister(buf []byte, index uint64) interface{} {
 	var req structs.CSIVolumeDeregisterRequest
 	if err := structs.Decode(buf, &req); err != nil {
@@ -1446,6 +1537,59 @@ func (n *nomadFSM) applyNamespaceDelete(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyQuotaSpecUpsert is used to upsert a set of quota specifications
+func (n *nomadFSM) applyQuotaSpecUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_quota_spec_upsert"}, time.Now())
+	var req structs.QuotaSpecUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertQuotaSpecs(index, req.Quotas); err != nil {
+		n.logger.Error("UpsertQuotaSpecs failed", "error", err)
+		return err
+	}
+
+	// The limits may have been raised, so unblock the evaluations that were
+	// blocked on the quotas
+	for _, quota := range req.Quotas {
+		n.blockedEvals.UnblockQuota(quota.Name, index)
+	}
+
+	return nil
+}
+
+// applyQuotaSpecDelete is used to delete a set of quota specifications
+func (n *nomadFSM) applyQuotaSpecDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_quota_spec_delete"}, time.Now())
+	var req structs.QuotaSpecDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteQuotaSpecs(index, req.Names); err != nil {
+		n.logger.Error("DeleteQuotaSpecs failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// allocQuota returns the quota of the namespace of the allocation, if it has
+// one.
+func (n *nomadFSM) allocQuota(allocID string) (string, error) {
+	alloc, err := n.state.AllocByID(nil, allocID)
+	if err != nil || alloc == nil {
+		return "", err
+	}
+
+	ns, err := n.state.NamespaceByName(nil, alloc.Namespace)
+	if err != nil || ns == nil {
+		return "", err
+	}
+	return ns.Quota, nil
+}
+
 func (n *nomadFSM) Snapshot() (raft.FSMSnapshot, error) {
 	// Create a new snapshot
 	snap, err := n.state.Snapshot()
@@ -1477,11 +1621,12 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 
 	// Create a new state store
 	config := &state.StateStoreConfig{
-		Logger:             n.config.Logger,
-		Region:             n.config.Region,
-		EnablePublisher:    n.config.EnableEventBroker,
-		EventBufferSize:    n.config.EventBufferSize,
-		JobTrackedVersions: n.config.JobTrackedVersions,
+		Logger:                  n.config.Logger,
+		Region:                  n.config.Region,
+		EnablePublisher:         n.config.EnableEventBroker,
+		EventBufferSize:         n.config.EventBufferSize,
+		JobTrackedVersions:      n.config.JobTrackedVersions,
+		VariableTrackedVersions: n.config.VariableTrackedVersions,
 	}
 	newState, err := state.NewStateStore(config)
 	if err != nil {
@@ -1747,6 +1892,24 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case QuotaSpecSnapshot:
+			spec := new(structs.QuotaSpec)
+			if err := dec.Decode(spec); err != nil {
+				return err
+			}
+			if err := restore.QuotaSpecRestore(spec); err != nil {
+				return err
+			}
+
+		case QuotaUsageSnapshot:
+			usage := new(structs.QuotaUsage)
+			if err := dec.Decode(usage); err != nil {
+				return err
+			}
+			if err := restore.QuotaUsageRestore(usage); err != nil {
+				return err
+			}
+
 		// DEPRECATED: EventSinkSnapshot type only available in pre-1.0 Nomad
 		case EventSinkSnapshot:
 			return fmt.Errorf(
@@ -1774,6 +1937,16 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case VariablesHistorySnapshot:
+			variable := new(structs.VariableEncrypted)
+			if err := dec.Decode(variable); err != nil {
+				return err
+			}
+
+			if err := restore.VariablesHistoryRestore(variable); err != nil {
+				return err
+			}
+
 		case VariablesQuotaSnapshot:
 			quota := new(structs.VariablesQuota)
 			if err := dec.Decode(quota); err != nil {
@@ -1869,6 +2042,15 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case NodeMaintenanceWindowSnapshot:
+			window := new(structs.NodeMaintenanceWindow)
+			if err := dec.Decode(window); err != nil {
+				return err
+			}
+			if err := restore.NodeMaintenanceWindowRestore(window); err != nil {
+				return err
+			}
+
 		case JobSubmissionSnapshot:
 			jobSubmissions := new(structs.JobSubmission)
 
@@ -2430,6 +2612,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistNodeMaintenanceWindows(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistJobs(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -2486,6 +2672,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistQuotas(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistEnterpriseTables(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -2506,6 +2696,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistVariablesHistory(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistVariablesQuotas(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -2613,6 +2807,25 @@ func (s *nomadSnapshot) persistNodePools(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistNodeMaintenanceWindows(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	ws := memdb.NewWatchSet()
+	windows, err := s.snap.NodeMaintenanceWindows(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := windows.Next(); raw != nil; raw = windows.Next() {
+		window := raw.(*structs.NodeMaintenanceWindow)
+
+		sink.Write([]byte{byte(NodeMaintenanceWindowSnapshot)})
+		if err := encoder.Encode(window); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistJobs(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	// Get all the jobs
@@ -2890,6 +3103,51 @@ func (s *nomadSnapshot) persistNamespaces(sink raft.SnapshotSink, encoder *codec
 	return nil
 }
 
+// persistQuotas persists all the quota specifications and their usages.
+func (s *nomadSnapshot) persistQuotas(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	ws := memdb.NewWatchSet()
+	specs, err := s.snap.QuotaSpecs(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := specs.Next()
+		if raw == nil {
+			break
+		}
+
+		// Write out a quota specification registration
+		spec := raw.(*structs.QuotaSpec)
+		sink.Write([]byte{byte(QuotaSpecSnapshot)})
+		if err := encoder.Encode(spec); err != nil {
+			return err
+		}
+	}
+
+	usages, err := s.snap.QuotaUsages(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := usages.Next()
+		if raw == nil {
+			break
+		}
+
+		// Write out a quota usage
+		usage := raw.(*structs.QuotaUsage)
+		sink.Write([]byte{byte(QuotaUsageSnapshot)})
+		if err := encoder.Encode(usage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistSchedulerConfig(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	// Get scheduler config
@@ -3094,6 +3352,29 @@ func (s *nomadSnapshot) persistVariables(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistVariablesHistory(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	ws := memdb.NewWatchSet()
+	variables, err := s.snap.VariablesHistory(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		raw := variables.Next()
+		if raw == nil {
+			break
+		}
+		variable := raw.(*structs.VariableEncrypted)
+		sink.Write([]byte{byte(VariablesHistorySnapshot)})
+		if err := encoder.Encode(variable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistVariablesQuotas(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 
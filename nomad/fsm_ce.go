@@ -6,12 +6,6 @@
 
 package nomad
 
-// allocQuota returns the quota object associated with the allocation. In
-// anything but Premium this will always be empty
-func (n *nomadFSM) allocQuota(_ string) (string, error) {
-	return "", nil
-}
-
 // enterpriseSnapshotType is a no-op for community edition.
 func enterpriseSnapshotType(s SnapshotType) (string, bool) {
 	return "", false
@@ -59,14 +59,15 @@ func testFSM(t *testing.T) *nomadFSM {
 	dispatcher, _ := testPeriodicDispatcher(t)
 	logger := testlog.HCLogger(t)
 	fsmConfig := &FSMConfig{
-		EvalBroker:         broker,
-		Periodic:           dispatcher,
-		Blocked:            NewBlockedEvals(broker, logger),
-		Logger:             logger,
-		Region:             "global",
-		EnableEventBroker:  true,
-		EventBufferSize:    100,
-		JobTrackedVersions: structs.JobDefaultTrackedVersions,
+		EvalBroker:              broker,
+		Periodic:                dispatcher,
+		Blocked:                 NewBlockedEvals(broker, logger),
+		Logger:                  logger,
+		Region:                  "global",
+		EnableEventBroker:       true,
+		EventBufferSize:         100,
+		JobTrackedVersions:      structs.JobDefaultTrackedVersions,
+		VariableTrackedVersions: structs.VariableDefaultTrackedVersions,
 	}
 	fsm, err := NewFSM(fsmConfig)
 	if err != nil {
@@ -2273,6 +2274,32 @@ func TestFSM_SnapshotRestore_NodePools(t *testing.T) {
 	must.Eq(t, pool, out)
 }
 
+func TestFSM_SnapshotRestore_NodeMaintenanceWindows(t *testing.T) {
+	ci.Parallel(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	window := &structs.NodeMaintenanceWindow{
+		ID:         uuid.Generate(),
+		NodePool:   "batch",
+		Recurrence: "0 2 * * SUN",
+		Duration:   time.Hour,
+		DrainSpec:  &structs.DrainSpec{Deadline: time.Hour},
+		Status:     structs.NodeMaintenanceWindowStatusScheduled,
+		NextStart:  time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC),
+	}
+	must.NoError(t, state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1000,
+		[]*structs.NodeMaintenanceWindow{window}))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, err := state2.NodeMaintenanceWindowByID(nil, window.ID)
+	must.NoError(t, err)
+	must.Eq(t, window, out)
+}
+
 func TestFSM_SnapshotRestore_Jobs(t *testing.T) {
 	ci.Parallel(t)
 	// Add some state
@@ -3188,6 +3215,26 @@ func TestFSM_SnapshotRestore_Namespaces(t *testing.T) {
 	}
 }
 
+func TestFSM_SnapshotRestore_Quotas(t *testing.T) {
+	ci.Parallel(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	qs := mock.QuotaSpec()
+	must.NoError(t, state.UpsertQuotaSpecs(1000, []*structs.QuotaSpec{qs}))
+	usage, err := state.QuotaUsageByName(nil, qs.Name)
+	must.NoError(t, err)
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, _ := state2.QuotaSpecByName(nil, qs.Name)
+	must.Eq(t, qs, out)
+	outUsage, _ := state2.QuotaUsageByName(nil, qs.Name)
+	must.Eq(t, usage, outUsage)
+}
+
 func TestFSM_UpsertServiceRegistrations(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -3321,6 +3368,37 @@ func TestFSM_SnapshotRestore_Variables(t *testing.T) {
 	require.ElementsMatch(t, restoredSVs, svs)
 }
 
+func TestFSM_SnapshotRestore_VariablesHistory(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	// Write a variable twice so its first version is kept in the history.
+	for i, data := range []string{"v0", "v1"} {
+		sv := mock.VariableEncrypted()
+		sv.Data = []byte(data)
+		setResp := testState.VarSet(uint64(10+i), &structs.VarApplyStateRequest{
+			Op:  structs.VarOpSet,
+			Var: sv,
+		})
+		must.NoError(t, setResp.Error)
+	}
+	sv := mock.VariableEncrypted()
+	versions, err := testState.GetVariableVersions(nil, sv.Namespace, sv.Path)
+	must.NoError(t, err)
+	must.Len(t, 1, versions)
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	restored, err := restoredState.GetVariableVersions(nil, sv.Namespace, sv.Path)
+	must.NoError(t, err)
+	must.Eq(t, versions, restored)
+}
+
 func TestFSM_ApplyACLRolesUpsert(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
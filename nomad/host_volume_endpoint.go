@@ -249,6 +249,12 @@ func (v *HostVolume) Create(args *structs.HostVolumeCreateRequest, reply *struct
 		return err
 	}
 
+	// reject volumes over the quota of their namespace before the plugin
+	// creates them on the client
+	if err := snap.EnforceHostVolumeQuota(vol, existing); err != nil {
+		return err
+	}
+
 	// serialize client RPC and raft write per volume ID
 	index, err := v.serializeCall(vol.ID, "create", func() (uint64, error) {
 		// Attempt to create the volume on the client.
@@ -515,6 +521,9 @@ func (v *HostVolume) placeHostVolume(snap *state.StateSnapshot, vol *structs.Hos
 		if node == nil {
 			return nil, fmt.Errorf("no such node %s", vol.NodeID)
 		}
+		if err := snap.NodeHostVolumeCapacityFits(node, vol); err != nil {
+			return nil, err
+		}
 		vol.NodePool = node.NodePool
 		return node, nil
 	}
@@ -555,6 +564,7 @@ func (v *HostVolume) placeHostVolume(snap *state.StateSnapshot, vol *structs.Hos
 		filteredByExisting    int
 		filteredByGovernance  int
 		filteredByFeasibility int
+		filteredByCapacity    int
 	)
 
 	for {
@@ -586,6 +596,11 @@ func (v *HostVolume) placeHostVolume(snap *state.StateSnapshot, vol *structs.Hos
 			}
 		}
 
+		if err := snap.NodeHostVolumeCapacityFits(candidate, vol); err != nil {
+			filteredByCapacity++
+			continue
+		}
+
 		vol.NodeID = candidate.ID
 		vol.NodePool = candidate.NodePool
 		return candidate, nil
@@ -593,8 +608,8 @@ func (v *HostVolume) placeHostVolume(snap *state.StateSnapshot, vol *structs.Hos
 	}
 
 	return nil, fmt.Errorf(
-		"no node meets constraints: %d nodes had existing volume, %d nodes filtered by node pool governance, %d nodes were infeasible",
-		filteredByExisting, filteredByGovernance, filteredByFeasibility)
+		"no node meets constraints: %d nodes had existing volume, %d nodes filtered by node pool governance, %d nodes were infeasible, %d nodes had insufficient host volume capacity",
+		filteredByExisting, filteredByGovernance, filteredByFeasibility, filteredByCapacity)
 }
 
 // placementContext implements the scheduler.ConstraintContext interface, a
@@ -186,12 +186,12 @@ func TestHostVolumeEndpoint_CreateRegisterGetDelete(t *testing.T) {
 		var resp structs.HostVolumeCreateResponse
 		req.AuthToken = token
 		err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
-		must.EqError(t, err, `could not place volume "example1": no node meets constraints: 0 nodes had existing volume, 0 nodes filtered by node pool governance, 1 nodes were infeasible`)
+		must.EqError(t, err, `could not place volume "example1": no node meets constraints: 0 nodes had existing volume, 0 nodes filtered by node pool governance, 1 nodes were infeasible, 0 nodes had insufficient host volume capacity`)
 
 		req.Volume = vol2.Copy()
 		resp = structs.HostVolumeCreateResponse{}
 		err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
-		must.EqError(t, err, `could not place volume "example2": no node meets constraints: 0 nodes had existing volume, 0 nodes filtered by node pool governance, 1 nodes were infeasible`)
+		must.EqError(t, err, `could not place volume "example2": no node meets constraints: 0 nodes had existing volume, 0 nodes filtered by node pool governance, 1 nodes were infeasible, 0 nodes had insufficient host volume capacity`)
 	})
 
 	t.Run("valid create", func(t *testing.T) {
@@ -705,6 +705,7 @@ func TestHostVolumeEndpoint_placeVolume(t *testing.T) {
 
 	node2.NodePool = "prod"
 	node2.Attributes["plugins.host_volume.mkdir.version"] = "0.0.1"
+	node2.Attributes["plugins.host_volume.mkdir.capacity_bytes"] = "100000"
 
 	node3.NodePool = "prod"
 	node3.Meta["rack"] = "r3"
@@ -763,12 +764,12 @@ func TestHostVolumeEndpoint_placeVolume(t *testing.T) {
 						Operand: "=",
 					},
 				}},
-			expectErr: "no node meets constraints: 0 nodes had existing volume, 0 nodes filtered by node pool governance, 4 nodes were infeasible",
+			expectErr: "no node meets constraints: 0 nodes had existing volume, 0 nodes filtered by node pool governance, 4 nodes were infeasible, 0 nodes had insufficient host volume capacity",
 		},
 		{
 			name:      "no matching plugin",
 			vol:       &structs.HostVolume{PluginID: "not-mkdir"},
-			expectErr: "no node meets constraints: 0 nodes had existing volume, 0 nodes filtered by node pool governance, 4 nodes were infeasible",
+			expectErr: "no node meets constraints: 0 nodes had existing volume, 0 nodes filtered by node pool governance, 4 nodes were infeasible, 0 nodes had insufficient host volume capacity",
 		},
 		{
 			name: "match already has a volume with the same name",
@@ -782,7 +783,17 @@ func TestHostVolumeEndpoint_placeVolume(t *testing.T) {
 						Operand: "=",
 					},
 				}},
-			expectErr: "no node meets constraints: 1 nodes had existing volume, 0 nodes filtered by node pool governance, 3 nodes were infeasible",
+			expectErr: "no node meets constraints: 1 nodes had existing volume, 0 nodes filtered by node pool governance, 3 nodes were infeasible, 0 nodes had insufficient host volume capacity",
+		},
+		{
+			name: "only one available in pool lacks capacity",
+			vol: &structs.HostVolume{
+				NodePool:                  "prod",
+				Name:                      "example",
+				PluginID:                  "mkdir",
+				RequestedCapacityMinBytes: 100001,
+			},
+			expectErr: "no node meets constraints: 1 nodes had existing volume, 0 nodes filtered by node pool governance, 0 nodes were infeasible, 1 nodes had insufficient host volume capacity",
 		},
 	}
 
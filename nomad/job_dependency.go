@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// jobDependenciesMet returns whether all the jobs the job depends on reached
+// the status of its dependencies.
+func jobDependenciesMet(ws memdb.WatchSet, store *state.StateStore, job *structs.Job) (bool, error) {
+	for _, dep := range job.DependsOn {
+		upstream, err := store.JobByID(ws, job.Namespace, dep.JobID)
+		if err != nil {
+			return false, err
+		}
+		var allocs []*structs.Allocation
+		if upstream != nil {
+			allocs, err = store.AllocsByJob(ws, job.Namespace, dep.JobID, false)
+			if err != nil {
+				return false, err
+			}
+		}
+		if !structs.JobDependencyMet(dep, upstream, allocs) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// runJobDependencyWatcher creates the evaluations of the jobs held by their
+// dependencies once the jobs they depend on reach the desired status. It is
+// only run on the leader.
+func (s *Server) runJobDependencyWatcher(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	logger := s.logger.Named("job_dependency_watcher")
+	index := uint64(1)
+	for {
+		resp, newIndex, err := s.State().BlockingQuery(readyDependentJobs, index, ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error("failed to get jobs waiting on dependencies", "error", err)
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		index = newIndex
+
+		for _, job := range resp.([]*structs.Job) {
+			if err := s.evaluateHeldJob(job, structs.EvalTriggerJobDependency); err != nil {
+				logger.Error("failed to evaluate job after its dependencies were met",
+					"namespace", job.Namespace, "job_id", job.ID, "error", err)
+				continue
+			}
+			logger.Debug("dependencies of job met", "namespace", job.Namespace, "job_id", job.ID)
+		}
+	}
+}
+
+// readyDependentJobs returns the jobs held by their dependencies which can be
+// evaluated. A job is held if the evaluation of its current version wasn't
+// created because its dependencies weren't met when it was registered.
+func readyDependentJobs(ws memdb.WatchSet, store *state.StateStore) (interface{}, uint64, error) {
+	iter, err := store.JobsByHeld(ws, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var ready []*structs.Job
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if len(job.DependsOn) == 0 || job.Stopped() {
+			continue
+		}
+
+		met, err := jobDependenciesMet(ws, store, job)
+		if err != nil {
+			return nil, 0, err
+		}
+		if met {
+			ready = append(ready, job)
+		}
+	}
+
+	// Use the last index that affected the jobs or allocs tables
+	jobsIndex, err := store.Index("jobs")
+	if err != nil {
+		return nil, 0, err
+	}
+	allocsIndex, err := store.Index("allocs")
+	if err != nil {
+		return nil, 0, err
+	}
+	return ready, max(jobsIndex, allocsIndex), nil
+}
+
+// evaluateHeldJob creates the evaluation of a held job once it can run.
+func (s *Server) evaluateHeldJob(job *structs.Job, triggeredBy string) error {
+	now := time.Now().UTC().UnixNano()
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    triggeredBy,
+		JobID:          job.ID,
+		JobModifyIndex: job.JobModifyIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+	update := &structs.EvalUpdateRequest{
+		Evals:        []*structs.Evaluation{eval},
+		WriteRequest: structs.WriteRequest{Region: s.config.Region},
+	}
+	_, _, err := s.raftApply(structs.EvalUpdateRequestType, update)
+	return err
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestJobDependencyWatcher(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	register := func(job *structs.Job) *structs.JobRegisterResponse {
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+		return &resp
+	}
+
+	upstream := mock.BatchJob()
+	upstreamResp := register(upstream)
+	must.NotEq(t, "", upstreamResp.EvalID)
+
+	// The downstream job is held without an evaluation
+	downstream := mock.BatchJob()
+	downstream.DependsOn = []*structs.JobDependency{{JobID: upstream.ID}}
+	downstreamResp := register(downstream)
+	must.Eq(t, "", downstreamResp.EvalID)
+
+	evals, err := store.EvalsByJob(nil, downstream.Namespace, downstream.ID)
+	must.NoError(t, err)
+	must.SliceEmpty(t, evals)
+
+	downstream, err = store.JobByID(nil, downstream.Namespace, downstream.ID)
+	must.NoError(t, err)
+	must.True(t, downstream.Held)
+
+	// Complete the upstream job
+	upstreamEval, err := store.EvalByID(nil, upstreamResp.EvalID)
+	must.NoError(t, err)
+	upstream, err = store.JobByID(nil, upstream.Namespace, upstream.ID)
+	must.NoError(t, err)
+	alloc := mock.Alloc()
+	alloc.Job = upstream
+	alloc.JobID = upstream.ID
+	alloc.EvalID = upstreamEval.ID
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	alloc.DesiredStatus = structs.AllocDesiredStatusRun
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 2000, []*structs.Allocation{alloc}))
+	upstreamEval = upstreamEval.Copy()
+	upstreamEval.Status = structs.EvalStatusComplete
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 2001, []*structs.Evaluation{upstreamEval}))
+
+	// The downstream job is evaluated once the upstream job completed
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			evals, err := store.EvalsByJob(nil, downstream.Namespace, downstream.ID)
+			if err != nil {
+				return err
+			}
+			if len(evals) != 1 {
+				return fmt.Errorf("expected 1 evaluation, got %d", len(evals))
+			}
+			if evals[0].TriggeredBy != structs.EvalTriggerJobDependency {
+				return fmt.Errorf("unexpected trigger %q", evals[0].TriggeredBy)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(50*time.Millisecond),
+	))
+
+	// The downstream job is released, and isn't held again once its
+	// evaluations are garbage collected
+	downstream, err = store.JobByID(nil, downstream.Namespace, downstream.ID)
+	must.NoError(t, err)
+	must.False(t, downstream.Held)
+
+	evals, err = store.EvalsByJob(nil, downstream.Namespace, downstream.ID)
+	must.NoError(t, err)
+	must.NoError(t, store.DeleteEval(3000, []string{evals[0].ID}, nil, false))
+	ready, _, err := readyDependentJobs(nil, store)
+	must.NoError(t, err)
+	must.SliceEmpty(t, ready.([]*structs.Job))
+
+	// Jobs registered once their dependencies are met are evaluated directly
+	other := mock.BatchJob()
+	other.DependsOn = []*structs.JobDependency{{JobID: upstream.ID, Status: structs.JobDependencyStatusDead}}
+	must.NotEq(t, "", register(other).EvalID)
+}
+
+func TestJobEndpoint_Register_DependsOnCycle(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	first := mock.BatchJob()
+	second := mock.BatchJob()
+	first.DependsOn = []*structs.JobDependency{{JobID: second.ID}}
+	second.DependsOn = []*structs.JobDependency{{JobID: first.ID}}
+
+	// The job the first job depends on doesn't exist yet
+	req := &structs.JobRegisterRequest{
+		Job: first,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: first.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	must.StrContains(t, resp.Warnings, "which doesn't exist")
+
+	req.Job = second
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	must.ErrorContains(t, err, "form a cycle")
+}
@@ -86,6 +86,7 @@ func NewJobEndpoints(s *Server, ctx *RPCContext) *Job {
 			jobNamespaceConstraintCheckHook{srv: s},
 			jobNodePoolValidatingHook{srv: s},
 			&jobValidate{srv: s},
+			jobDependsOnHook{srv: s},
 			&memoryOversubscriptionValidate{srv: s},
 			jobNumaHook{},
 			&jobSchedHook{},
@@ -152,6 +153,11 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return structs.ErrPermissionDenied
 	}
 
+	// Validate Task Driver Permissions
+	if !allowJobDrivers(aclObj, args.RequestNamespace(), args.Job) {
+		return structs.ErrPermissionDenied
+	}
+
 	// Validate Volume Permissions
 	for _, tg := range args.Job.TaskGroups {
 		for _, vol := range tg.Volumes {
@@ -317,8 +323,14 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	// Set the submit time
 	args.Job.SubmitTime = now
 
-	// If the job is periodic or parameterized, we don't create an eval.
-	if !(args.Job.IsPeriodic() || args.Job.IsParameterized()) {
+	// If the job is periodic or parameterized, we don't create an eval. Jobs
+	// depending on other jobs are held without an eval until the dependency
+	// watcher finds their dependencies met.
+	dependenciesMet, err := jobDependenciesMet(nil, j.srv.State(), args.Job)
+	if err != nil {
+		return err
+	}
+	if !(args.Job.IsPeriodic() || args.Job.IsParameterized()) && dependenciesMet {
 
 		// Initially set the eval priority to that of the job priority. If the
 		// user supplied an eval priority override, we subsequently use this.
@@ -340,6 +352,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		}
 		reply.EvalID = eval.ID
 	}
+	args.Job.Held = !(args.Job.IsPeriodic() || args.Job.IsParameterized()) && !dependenciesMet
 
 	// Check if the job has changed at all
 	specChanged, err := j.multiregionSpecChanged(existingJob, args)
@@ -514,6 +527,70 @@ func (j *Job) Summary(args *structs.JobSummaryRequest, reply *structs.JobSummary
 	return j.srv.blockingRPC(&opts)
 }
 
+// ArraySummary is used to get the state of each index of the array of a job.
+func (j *Job) ArraySummary(args *structs.JobSpecificRequest, reply *structs.JobArraySummaryResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.ArraySummary", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "array_summary"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID")
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			job, err := state.JobByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			if job != nil && job.Array == nil {
+				return structs.NewErrRPCCoded(http.StatusBadRequest,
+					fmt.Sprintf("job %q is not an array", args.JobID))
+			}
+
+			reply.Summary = nil
+			if job != nil {
+				allocs, err := state.AllocsByJob(ws, args.RequestNamespace(), args.JobID, true)
+				if err != nil {
+					return err
+				}
+				reply.Summary = structs.NewJobArraySummary(job, allocs)
+			}
+
+			// Use the last index that affected the jobs or allocs tables
+			index, err := state.Index("allocs")
+			if err != nil {
+				return err
+			}
+			jobsIndex, err := state.Index("jobs")
+			if err != nil {
+				return err
+			}
+			reply.Index = max(index, jobsIndex)
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // Validate validates a job.
 //
 // Must forward to the leader, because only the leader will have a live Vault
@@ -1001,6 +1078,10 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 		return structs.NewErrRPCCoded(http.StatusBadRequest, `jobs of type "system" can only be scaled between 0 and 1`)
 	}
 
+	if job.Array != nil && args.Count != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "the count of the task groups of an array is set by its array block")
+	}
+
 	// Since job is going to be mutated we must copy it since state store methods
 	// return a shared pointer.
 	job = job.Copy()
@@ -1398,6 +1479,19 @@ func registrationsAreAllowed(aclObj *acl.ACL, state *state.StateStore) (bool, er
 	return false, nil
 }
 
+// allowJobDrivers checks that the policy of the namespace allows the task
+// drivers of all the tasks of the job.
+func allowJobDrivers(aclObj *acl.ACL, namespace string, job *structs.Job) bool {
+	for _, tg := range job.TaskGroups {
+		for _, task := range tg.Tasks {
+			if !aclObj.AllowNamespaceDriver(namespace, task.Driver) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // List is used to list the jobs registered in the system
 func (j *Job) List(args *structs.JobListRequest, reply *structs.JobListResponse) error {
 	authErr := j.srv.Authenticate(j.ctx, args)
@@ -1797,6 +1891,9 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
 			return structs.ErrPermissionDenied
 		}
+		if !allowJobDrivers(aclObj, args.RequestNamespace(), args.Job) {
+			return structs.ErrPermissionDenied
+		}
 		// Check if override is set and we do not have permissions
 		if args.PolicyOverride {
 			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
@@ -2106,6 +2203,20 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	// Compress the payload
 	dispatchJob.Payload = snappy.Encode(nil, args.Payload)
 
+	// Queue the dispatched job if the parameterized job can't run more
+	// dispatched jobs. The queue is checked and the job committed with the
+	// lock held, so that concurrent dispatches can't exceed the jobs allowed
+	// to run.
+	if parameterizedJob.ParameterizedJob.MaxConcurrent > 0 {
+		j.srv.dispatchQueueLock.Lock()
+		defer j.srv.dispatchQueueLock.Unlock()
+	}
+	queued, err := dispatchQueueFull(j.srv.State(), parameterizedJob)
+	if err != nil {
+		return err
+	}
+
+	dispatchJob.Held = queued && !dispatchJob.IsPeriodic()
 	regReq := &structs.JobRegisterRequest{
 		Job:          dispatchJob,
 		WriteRequest: args.WriteRequest,
@@ -2121,9 +2232,11 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	reply.JobCreateIndex = jobCreateIndex
 	reply.DispatchedJobID = dispatchJob.ID
 	reply.Index = jobCreateIndex
+	reply.Queued = queued
 
-	// If the job is periodic, we don't create an eval.
-	if !dispatchJob.IsPeriodic() {
+	// If the job is periodic or queued, we don't create an eval. The queued
+	// jobs are evaluated by the leader once they can run.
+	if !dispatchJob.IsPeriodic() && !queued {
 		// Create a new evaluation
 		now := time.Now().UnixNano()
 		eval := &structs.Evaluation{
@@ -2159,6 +2272,130 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	return nil
 }
 
+// DispatchQueue is used to list the queued dispatched jobs of a parameterized
+// job.
+func (j *Job) DispatchQueue(args *structs.JobSpecificRequest, reply *structs.JobDispatchQueueResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.DispatchQueue", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "dispatch_queue"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return fmt.Errorf("missing parameterized job ID")
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			job, err := state.JobByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			if job == nil {
+				return structs.NewErrRPCCoded(http.StatusNotFound, "parameterized job not found")
+			}
+			if !job.IsParameterized() {
+				return structs.NewErrRPCCoded(http.StatusBadRequest,
+					fmt.Sprintf("Specified job %q is not a parameterized job", args.JobID))
+			}
+
+			running, queued, err := dispatchQueue(ws, state, job)
+			if err != nil {
+				return err
+			}
+			reply.Queue = structs.NewJobDispatchQueue(job, running, queued)
+
+			// Use the last index that affected the jobs or evals tables
+			index, err := state.Index("jobs")
+			if err != nil {
+				return err
+			}
+			evalsIndex, err := state.Index("evals")
+			if err != nil {
+				return err
+			}
+			reply.Index = max(index, evalsIndex)
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
+// DispatchCancel is used to cancel a dispatched job waiting in the dispatch
+// queue of its parameterized job. The queued job is purged, as it never ran.
+func (j *Job) DispatchCancel(args *structs.JobDispatchCancelRequest, reply *structs.JobDispatchCancelResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.DispatchCancel", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "dispatch_cancel"}, time.Now())
+
+	// Check for dispatch-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityDispatchJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return fmt.Errorf("missing dispatched job ID")
+	}
+
+	// Hold the lock so that the job isn't dequeued while it is cancelled
+	j.srv.dispatchQueueLock.Lock()
+	defer j.srv.dispatchQueueLock.Unlock()
+
+	store := j.srv.State()
+	job, err := store.JobByID(nil, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return structs.NewErrRPCCoded(http.StatusNotFound, "dispatched job not found")
+	}
+	queued, err := dispatchQueued(nil, store, job)
+	if err != nil {
+		return err
+	}
+	if !job.Dispatched || !queued || job.Status == structs.JobStatusDead {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			fmt.Sprintf("Specified job %q is not a queued dispatched job", args.JobID))
+	}
+
+	req := &structs.JobDeregisterRequest{
+		JobID:        job.ID,
+		Purge:        true,
+		WriteRequest: args.WriteRequest,
+	}
+	_, index, err := j.srv.raftApply(structs.JobDeregisterRequestType, req)
+	if err != nil {
+		j.logger.Error("queued dispatched job cancel failed", "error", err)
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
 // validateDispatchRequest returns whether the request is valid given the
 // parameterized job.
 func validateDispatchRequest(req *structs.JobDispatchRequest, job *structs.Job, config *Config) error {
@@ -296,6 +296,12 @@ func groupConnectHook(job *structs.Job, g *structs.TaskGroup) error {
 				cluster := service.GetConsulClusterName(g)
 				task = newConnectSidecarTask(service.Name, driver, cluster)
 
+				// sidecars of Nomad services are bootstrapped by the client
+				// without Consul
+				if service.Provider == structs.ServiceProviderNomad {
+					task.Constraints = nil
+				}
+
 				// If there happens to be a task defined with the same name
 				// append an UUID fragment to the task name
 				for _, t := range g.Tasks {
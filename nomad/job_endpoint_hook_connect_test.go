@@ -180,6 +180,33 @@ func TestJobEndpointConnect_groupConnectHook(t *testing.T) {
 	require.Exactly(t, tgExp, job.TaskGroups[0])
 }
 
+func TestJobEndpointConnect_groupConnectHook_NomadProvider(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0] = &structs.TaskGroup{
+		Networks: structs.Networks{{
+			Mode: "bridge",
+		}},
+		Services: []*structs.Service{{
+			Name:      "backend",
+			PortLabel: "8080",
+			Provider:  structs.ServiceProviderNomad,
+			Connect: &structs.ConsulConnect{
+				SidecarService: &structs.ConsulSidecarService{},
+			},
+		}},
+	}
+
+	must.NoError(t, groupConnectHook(job, job.TaskGroups[0]))
+	must.Len(t, 1, job.TaskGroups[0].Tasks)
+
+	// sidecars of Nomad services don't require Consul on the client
+	task := job.TaskGroups[0].Tasks[0]
+	must.Eq(t, "connect-proxy-backend", task.Name)
+	must.Len(t, 0, task.Constraints)
+}
+
 func TestJobEndpointConnect_groupConnectHook_IngressGateway_BridgeNetwork(t *testing.T) {
 	ci.Parallel(t)
 
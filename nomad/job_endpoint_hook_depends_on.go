@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// jobDependsOnHook is an admission hook that rejects jobs whose dependencies
+// form a cycle, which would hold their evaluations forever, and warns about
+// dependencies on jobs that don't exist.
+type jobDependsOnHook struct {
+	srv *Server
+}
+
+func (jobDependsOnHook) Name() string {
+	return "depends_on"
+}
+
+func (h jobDependsOnHook) Validate(job *structs.Job) ([]error, error) {
+	if len(job.DependsOn) == 0 {
+		return nil, nil
+	}
+
+	store := h.srv.State()
+	var warnings []error
+	for _, dep := range job.DependsOn {
+		upstream, err := store.JobByID(nil, job.Namespace, dep.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if upstream == nil {
+			warnings = append(warnings, fmt.Errorf(
+				"Job %q depends on job %q which doesn't exist, it won't be evaluated until that job is registered and %s",
+				job.ID, dep.JobID, dep.Status))
+		}
+	}
+
+	// walk the jobs the job depends on, directly or not, looking for the job
+	visited := make(map[string]struct{})
+	queue := make([]string, 0, len(job.DependsOn))
+	for _, dep := range job.DependsOn {
+		queue = append(queue, dep.JobID)
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == job.ID {
+			return warnings, fmt.Errorf("dependencies of job %q form a cycle", job.ID)
+		}
+		if _, ok := visited[id]; ok {
+			continue
+		}
+		visited[id] = struct{}{}
+
+		upstream, err := store.JobByID(nil, job.Namespace, id)
+		if err != nil {
+			return nil, err
+		}
+		if upstream == nil {
+			continue
+		}
+		for _, dep := range upstream.DependsOn {
+			queue = append(queue, dep.JobID)
+		}
+	}
+
+	return warnings, nil
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/lib/lang"
@@ -27,6 +28,7 @@ const (
 	attrLoopbackCNI       = `${attr.plugins.cni.version.loopback}`
 	attrPortMapCNI        = `${attr.plugins.cni.version.portmap}`
 	attrConsulCNI         = `${attr.plugins.cni.version.consul-cni}`
+	attrBandwidthCNI      = `${attr.plugins.cni.version.bandwidth}`
 )
 
 // cniMinVersion is the version expression for the minimum CNI version supported
@@ -136,6 +138,14 @@ var (
 		Operand: structs.ConstraintSemver,
 	}
 
+	// cniBandwidthConstraint is an implicit constraint added to jobs making use
+	// of bandwidth limits, which are enforced by this CNI plugin.
+	cniBandwidthConstraint = &structs.Constraint{
+		LTarget: attrBandwidthCNI,
+		RTarget: cniMinVersion,
+		Operand: structs.ConstraintSemver,
+	}
+
 	// cniConsulConstraint is an implicit constraint added to jobs making use of
 	// transparent proxy mode.
 	cniConsulConstraint = &structs.Constraint{
@@ -152,6 +162,14 @@ var (
 		Operand: structs.ConstraintSemver,
 	}
 
+	// diskIOConstraint is an implicit constraint added to task groups with
+	// tasks limiting their disk I/O, which requires the client to have
+	// fingerprinted the block device of its allocation directory.
+	diskIOConstraint = &structs.Constraint{
+		LTarget: "${attr.storage.block_device}",
+		Operand: structs.ConstraintAttributeIsSet,
+	}
+
 	// taskScheduleConstraint is an implicit constraint added to jobs that have
 	// tasks with a schedule{} block for time based task execution (Enterprise)
 	taskScheduleConstraint = &structs.Constraint{
@@ -228,6 +246,38 @@ func (j *Job) admissionValidators(origJob *structs.Job) ([]error, error) {
 
 }
 
+// LocalAdmissionControllers runs a job through the admission controllers that
+// don't depend on the state of a running server, so that a job can be checked
+// the way a server would check it before it is submitted. The default server
+// configuration is used in place of the server's own.
+func LocalAdmissionControllers(job *structs.Job) (*structs.Job, []error, error) {
+	srv := &Server{
+		config: &Config{
+			JobDefaultPriority: structs.JobDefaultPriority,
+			JobMaxPriority:     structs.JobDefaultMaxPriority,
+		},
+	}
+	j := &Job{
+		srv:    srv,
+		logger: hclog.NewNullLogger(),
+		mutators: []jobMutator{
+			&jobCanonicalizer{srv: srv},
+			jobConnectHook{},
+			jobExposeCheckHook{},
+			jobImpliedConstraints{},
+			jobNumaHook{},
+		},
+		validators: []jobValidator{
+			jobConnectHook{},
+			jobExposeCheckHook{},
+			&jobValidate{srv: srv},
+			jobNumaHook{},
+			&jobSchedHook{},
+		},
+	}
+	return j.admissionControllers(job)
+}
+
 // jobCanonicalizer calls job.Canonicalize (sets defaults and initializes
 // fields) and returns any errors as warnings.
 type jobCanonicalizer struct {
@@ -275,18 +325,22 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 
 	bridgeNetworkingTaskGroups := j.RequiredBridgeNetwork()
 
+	networkShapingTaskGroups := j.RequiredNetworkShaping()
+
 	transparentProxyTaskGroups := j.RequiredTransparentProxy()
 
 	taskScheduleTaskGroups := j.RequiredScheduleTask()
 
+	diskIOTaskGroups := j.RequiredDiskIO()
+
 	// Hot path where none of our things require constraints.
 	//
 	// [UPDATE THIS] if you are adding a new constraint thing!
 	if len(signals) == 0 && len(vaultBlocks) == 0 &&
 		nativeServiceDisco.Empty() && len(consulServiceDisco) == 0 &&
 		numaTaskGroups.Empty() && bridgeNetworkingTaskGroups.Empty() &&
-		transparentProxyTaskGroups.Empty() &&
-		taskScheduleTaskGroups.Empty() {
+		networkShapingTaskGroups.Empty() && transparentProxyTaskGroups.Empty() &&
+		taskScheduleTaskGroups.Empty() && diskIOTaskGroups.Empty() {
 		return j, nil, nil
 	}
 
@@ -308,6 +362,11 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 			mutateConstraint(constraintMatcherFull, tg, numaKernelConstraint)
 		}
 
+		// If the task group limits disk I/O, run the mutator.
+		if diskIOTaskGroups.Contains(tg.Name) {
+			mutateConstraint(constraintMatcherFull, tg, diskIOConstraint)
+		}
+
 		// Check whether the task group is using signals. In the case that it
 		// is, we flatten the signals and build a constraint, then run the
 		// mutator.
@@ -352,6 +411,10 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 			mutateConstraint(constraintMatcherLeft, tg, cniPortMapConstraint)
 		}
 
+		if networkShapingTaskGroups.Contains(tg.Name) {
+			mutateConstraint(constraintMatcherLeft, tg, cniBandwidthConstraint)
+		}
+
 		if transparentProxyTaskGroups.Contains(tg.Name) {
 			mutateConstraint(constraintMatcherLeft, tg, cniConsulConstraint)
 			mutateConstraint(constraintMatcherLeft, tg, tproxyConstraint)
@@ -1157,6 +1157,99 @@ func Test_jobImpliedConstraints_Mutate(t *testing.T) {
 			expectedOutputWarnings: nil,
 			expectedOutputError:    nil,
 		},
+		{
+			name: "task group with disk io limits",
+			inputJob: &structs.Job{
+				Name: "disk-io",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group1",
+						Tasks: []*structs.Task{
+							{
+								Resources: &structs.Resources{
+									DiskIO: &structs.DiskIO{WriteBPS: 10 << 20},
+								},
+							},
+						},
+					},
+					{
+						Name: "group2",
+						Tasks: []*structs.Task{
+							{
+								Resources: &structs.Resources{
+									DiskIO: &structs.DiskIO{},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedOutputJob: &structs.Job{
+				Name: "disk-io",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group1",
+						Constraints: []*structs.Constraint{
+							diskIOConstraint,
+						},
+						Tasks: []*structs.Task{
+							{
+								Resources: &structs.Resources{
+									DiskIO: &structs.DiskIO{WriteBPS: 10 << 20},
+								},
+							},
+						},
+					},
+					{
+						Name: "group2",
+						Tasks: []*structs.Task{
+							{
+								Resources: &structs.Resources{
+									DiskIO: &structs.DiskIO{},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedOutputWarnings: nil,
+			expectedOutputError:    nil,
+		},
+		{
+			name: "task group with bandwidth limits",
+			inputJob: &structs.Job{
+				Name: "bandwidth",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group1",
+						Networks: []*structs.NetworkResource{
+							{Mode: "bridge", EgressMBits: 100},
+						},
+					},
+				},
+			},
+			expectedOutputJob: &structs.Job{
+				Name: "bandwidth",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group1",
+						Networks: []*structs.NetworkResource{
+							{Mode: "bridge", EgressMBits: 100},
+						},
+						Constraints: []*structs.Constraint{
+							cniBridgeConstraint,
+							cniFirewallConstraint,
+							cniHostLocalConstraint,
+							cniLoopbackConstraint,
+							cniPortMapConstraint,
+							cniBandwidthConstraint,
+						},
+					},
+				},
+			},
+			expectedOutputWarnings: nil,
+			expectedOutputError:    nil,
+		},
 		{
 			inputJob: &structs.Job{
 				Name: "example",
@@ -1341,6 +1434,27 @@ func Test_jobCanonicalizer_Mutate(t *testing.T) {
 	}
 }
 
+func TestLocalAdmissionControllers(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("valid", func(t *testing.T) {
+		job := mock.Job()
+		job.Priority = 0
+
+		out, _, err := LocalAdmissionControllers(job)
+		must.NoError(t, err)
+		must.Eq(t, structs.JobDefaultPriority, out.Priority)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		job := mock.Job()
+		job.Priority = structs.JobDefaultMaxPriority + 1
+
+		_, _, err := LocalAdmissionControllers(job)
+		must.ErrorContains(t, err, "job priority must be between")
+	})
+}
+
 func TestJob_submissionController(t *testing.T) {
 	ci.Parallel(t)
 	args := &structs.JobRegisterRequest{
@@ -2145,6 +2145,65 @@ func TestJobEndpoint_Register_ACL_Namespace(t *testing.T) {
 	assert.NotNil(out, "expected job")
 }
 
+func TestJobEndpoint_Register_ACL_Drivers(t *testing.T) {
+	ci.Parallel(t)
+	s1, _, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := `namespace "default" {
+		policy          = "write"
+		allowed_drivers = ["exec", "docker"]
+		denied_drivers  = ["docker"]
+	}`
+	token := mock.CreatePolicyAndToken(t, s1.State(), 1001, "drivers", policy)
+
+	cases := []struct {
+		driver string
+		expErr string
+	}{
+		{driver: "exec"},
+		{driver: "docker", expErr: structs.ErrPermissionDenied.Error()},
+		{driver: "raw_exec", expErr: structs.ErrPermissionDenied.Error()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.driver, func(t *testing.T) {
+			job := mock.Job()
+			job.TaskGroups[0].Tasks[0].Driver = tc.driver
+
+			req := &structs.JobRegisterRequest{
+				Job: job,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: job.Namespace,
+					AuthToken: token.SecretID,
+				},
+			}
+			var resp structs.JobRegisterResponse
+			err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+
+			planReq := &structs.JobPlanRequest{
+				Job:          job,
+				WriteRequest: req.WriteRequest,
+			}
+			var planResp structs.JobPlanResponse
+			planErr := msgpackrpc.CallWithCodec(codec, "Job.Plan", planReq, &planResp)
+
+			if tc.expErr == "" {
+				must.NoError(t, err)
+				must.NoError(t, planErr)
+			} else {
+				must.EqError(t, err, tc.expErr)
+				must.EqError(t, planErr, tc.expErr)
+			}
+		})
+	}
+}
+
 func TestJobRegister_ACL_RejectedBySchedulerConfig(t *testing.T) {
 	ci.Parallel(t)
 	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
@@ -3553,6 +3612,58 @@ func TestJobEndpoint_Deregister_NoShutdownDelay(t *testing.T) {
 
 }
 
+func TestJobEndpoint_Deregister_ShutdownDelay(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register a job with an allocation
+	job := mock.Job()
+	reg := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp0 structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", reg, &resp0))
+
+	state := s1.fsm.State()
+	registered, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	alloc := mock.Alloc()
+	alloc.Job = registered
+	alloc.JobID = job.ID
+	alloc.Namespace = job.Namespace
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, resp0.Index+1, []*structs.Allocation{alloc}))
+
+	// Deregister with a shutdown delay override
+	dereg := &structs.JobDeregisterRequest{
+		JobID:         job.ID,
+		ShutdownDelay: 30 * time.Second,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp1 structs.JobDeregisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Deregister", dereg, &resp1))
+	must.NonZero(t, resp1.Index)
+
+	out, err := state.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	delay, ok := out.DesiredTransition.ShutdownDelayOverride()
+	must.True(t, ok)
+	must.Eq(t, 30*time.Second, delay)
+	must.False(t, out.DesiredTransition.ShouldIgnoreShutdownDelay())
+}
+
 func TestJobEndpoint_BatchDeregister(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -4622,6 +4733,81 @@ func TestJobEndpoint_Summary_ACL(t *testing.T) {
 	require.Equal(expectedJobSummary, authResp.JobSummary)
 }
 
+func TestJobEndpoint_ArraySummary(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.BatchJob()
+	job.Array = &structs.JobArray{Count: 3}
+	job.TaskGroups[0].Count = 3
+	reg := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: root.SecretID,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", reg, &regResp))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.TaskGroup = job.TaskGroups[0].Name
+	alloc.Name = structs.AllocName(job.ID, alloc.TaskGroup, 1)
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	req := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Expect failure for request without a token
+	var resp structs.JobArraySummaryResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.ArraySummary", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Expect success with a read-job token
+	validToken := mock.CreatePolicyAndToken(t, state, 1001, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	req.AuthToken = validToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.ArraySummary", req, &resp))
+	must.Eq(t, uint64(1000), resp.Index)
+	must.NotNil(t, resp.Summary)
+	must.Eq(t, 3, resp.Summary.Count)
+
+	tgSummary := resp.Summary.TaskGroups["web"]
+	must.NotNil(t, tgSummary)
+	must.Eq(t, 1, tgSummary.Complete)
+	must.Eq(t, 2, tgSummary.Pending)
+	must.Eq(t, alloc.ID, tgSummary.Indexes[1].AllocID)
+
+	// Jobs which are not an array have no array summary
+	other := mock.BatchJob()
+	reg.Job = other
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", reg, &regResp))
+	req.JobID = other.ID
+	err = msgpackrpc.CallWithCodec(codec, "Job.ArraySummary", req, &resp)
+	must.ErrorContains(t, err, "is not an array")
+
+	// Unknown jobs have no summary
+	req.JobID = "unknown"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.ArraySummary", req, &resp))
+	must.Nil(t, resp.Summary)
+}
+
 func TestJobEndpoint_GetJobSummary_Blocking(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7820,6 +8006,37 @@ func TestJobEndpoint_Scale_SystemJob(t *testing.T) {
 		`400,jobs of type "system" can only be scaled between 0 and 1`)
 }
 
+func TestJobEndpoint_Scale_Array(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, testServerCleanup := TestServer(t, nil)
+	defer testServerCleanup()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+	state := testServer.fsm.State()
+
+	job := mock.BatchJob()
+	job.Array = &structs.JobArray{Count: 3}
+	job.TaskGroups[0].Count = 3
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	scaleReq := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count: pointer.Of(int64(5)),
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			Namespace: job.Namespace,
+		},
+	}
+
+	resp := structs.JobRegisterResponse{}
+	must.ErrorContains(t, msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp),
+		`400,the count of the task groups of an array is set by its array block`)
+}
+
 func TestJobEndpoint_Scale_BatchJob(t *testing.T) {
 	ci.Parallel(t)
 
@@ -431,6 +431,40 @@ func (k *Keyring) ListPublic(args *structs.GenericRequest, reply *structs.Keyrin
 	return k.srv.blockingRPC(&opts)
 }
 
+// Status reports the progress of decrypting the keyring of the server that
+// serves the request. The keyring is decrypted by every server, so the RPC is
+// never forwarded to the leader.
+func (k *Keyring) Status(args *structs.KeyringStatusRequest, reply *structs.KeyringStatusResponse) error {
+
+	authErr := k.srv.Authenticate(k.ctx, args)
+	args.AllowStale = true
+	if done, err := k.srv.forward("Keyring.Status", args, args, reply); done {
+		return err
+	}
+	k.srv.MeasureRPCRate("keyring", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "status"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	numKeys, pending := k.encrypter.DecryptStatus()
+	reply.Status = &structs.KeyringStatus{
+		ServerName: k.srv.config.NodeName,
+		Ready:      len(pending) == 0,
+		NumKeys:    numKeys,
+		Pending:    pending,
+	}
+	k.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 // GetConfig for workload identities. This RPC is used to back an OIDC
 // Discovery endpoint.
 //
@@ -402,6 +402,41 @@ func TestKeyringEndpoint_ListPublic(t *testing.T) {
 
 // TestKeyringEndpoint_GetConfig_Issuer asserts that GetConfig returns OIDC
 // Discovery Configuration if an issuer is configured.
+func TestKeyringEndpoint_Status(t *testing.T) {
+
+	ci.Parallel(t)
+	srv, rootToken, shutdown := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+	codec := rpcClient(t, srv)
+
+	req := &structs.KeyringStatusRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.KeyringStatusResponse
+	err := msgpackrpc.CallWithCodec(codec, "Keyring.Status", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	token := mock.CreatePolicyAndToken(t, srv.fsm.State(), 1000, "plugin", mock.PluginPolicy("read"))
+	req.AuthToken = token.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Status", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	token = mock.CreatePolicyAndToken(t, srv.fsm.State(), 1001, "operator", `operator { policy = "read" }`)
+	req.AuthToken = token.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Status", req, &resp))
+	must.Eq(t, srv.config.NodeName, resp.Status.ServerName)
+	must.True(t, resp.Status.Ready)
+	must.Eq(t, 1, resp.Status.NumKeys)
+	must.SliceEmpty(t, resp.Status.Pending)
+
+	req.AuthToken = rootToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Status", req, &resp))
+	must.True(t, resp.Status.Ready)
+}
+
 func TestKeyringEndpoint_GetConfig_Issuer(t *testing.T) {
 	ci.Parallel(t)
 
@@ -450,6 +450,23 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically publish job status metrics
 	go s.publishJobStatusMetrics(stopCh)
 
+	// Evaluate the jobs held by their dependencies once they are met
+	go s.runJobDependencyWatcher(stopCh)
+
+	// Evaluate the queued dispatched jobs once they can run
+	go s.runDispatchQueue(stopCh)
+
+	// Snapshot the CSI volumes with a snapshot schedule
+	go s.runCSISnapshotSchedule(stopCh)
+
+	// Drain and restore the nodes of the node maintenance windows
+	go s.runNodeMaintenance(stopCh)
+
+	// Scale the task groups with target tracking scaling policies
+	if s.config.AutoscalerEnabled {
+		go s.runBuiltinAutoscaler(stopCh)
+	}
+
 	// Populate the variable lock TTL timers, so we can start tracking renewals
 	// and expirations.
 	if err := s.restoreLockTTLTimers(); err != nil {
@@ -482,7 +499,7 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 
 		// The authoritative region is responsible for garbage collecting
 		// expired global tokens. Otherwise, non-authoritative regions need to
-		// replicate policies, tokens, and namespaces.
+		// replicate policies, tokens, quotas, and namespaces.
 		switch s.config.AuthoritativeRegion {
 		case s.config.Region:
 			go s.schedulePeriodicAuthoritative(stopCh)
@@ -492,6 +509,7 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 			go s.replicateACLRoles(stopCh)
 			go s.replicateACLAuthMethods(stopCh)
 			go s.replicateACLBindingRules(stopCh)
+			go s.replicateQuotaSpecs(stopCh)
 			go s.replicateNamespaces(stopCh)
 			go s.replicateNodePools(stopCh)
 		}
@@ -599,6 +617,123 @@ ERR_WAIT:
 	}
 }
 
+// replicateQuotaSpecs is used to replicate quota specifications from the
+// authoritative region to this region.
+func (s *Server) replicateQuotaSpecs(stopCh chan struct{}) {
+	req := structs.QuotaSpecListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting quota specification replication from authoritative region", "region", req.Region)
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		// Rate limit how often we attempt replication
+		limiter.Wait(context.Background())
+
+		var resp structs.QuotaSpecListResponse
+		req.AuthToken = s.ReplicationToken()
+		err := s.forwardRegion(s.config.AuthoritativeRegion, "Quota.ListQuotaSpecs", &req, &resp)
+		if err != nil {
+			s.logger.Error("failed to fetch quota specifications from authoritative region", "error", err)
+			if s.replicationBackoffContinue(stopCh) {
+				continue
+			} else {
+				return
+			}
+		}
+
+		// Perform a two-way diff
+		delete, update := diffQuotaSpecs(s.State(), req.MinQueryIndex, resp.Quotas)
+
+		// Delete quota specifications that should not exist. This fails
+		// until the namespaces using them are replicated.
+		if len(delete) > 0 {
+			args := &structs.QuotaSpecDeleteRequest{
+				Names: delete,
+			}
+			_, _, err := s.raftApply(structs.QuotaSpecDeleteRequestType, args)
+			if err != nil {
+				s.logger.Error("failed to delete quota specifications", "error", err)
+				if s.replicationBackoffContinue(stopCh) {
+					continue
+				} else {
+					return
+				}
+			}
+		}
+
+		// Update local quota specifications
+		if len(update) > 0 {
+			args := &structs.QuotaSpecUpsertRequest{
+				Quotas: update,
+			}
+			_, _, err := s.raftApply(structs.QuotaSpecUpsertRequestType, args)
+			if err != nil {
+				s.logger.Error("failed to update quota specifications", "error", err)
+				if s.replicationBackoffContinue(stopCh) {
+					continue
+				} else {
+					return
+				}
+			}
+		}
+
+		// Update the minimum query index, blocks until there is a change.
+		req.MinQueryIndex = resp.Index
+	}
+}
+
+// diffQuotaSpecs is used to perform a two-way diff between the local quota
+// specifications and the remote quota specifications to determine which quota
+// specifications need to be deleted or updated.
+func diffQuotaSpecs(store *state.StateStore, minIndex uint64, remoteList []*structs.QuotaSpec) (delete []string, update []*structs.QuotaSpec) {
+	// Construct a set of the local and remote quota specifications
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local quota specifications
+	iter, err := store.QuotaSpecs(nil)
+	if err != nil {
+		panic("failed to iterate local quota specifications")
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		spec := raw.(*structs.QuotaSpec)
+		local[spec.Name] = spec.Hash
+	}
+
+	for _, rspec := range remoteList {
+		remote[rspec.Name] = struct{}{}
+
+		if localHash, ok := local[rspec.Name]; !ok {
+			// Quota specifications that are missing locally should be added
+			update = append(update, rspec)
+
+		} else if rspec.ModifyIndex > minIndex && !bytes.Equal(localHash, rspec.Hash) {
+			// Quota specifications that have been updated more recently than
+			// the last index we saw, and have a hash mismatch with what we
+			// have locally, should be updated.
+			update = append(update, rspec)
+		}
+	}
+
+	// Quota specifications that don't exist on the remote should be deleted
+	for lspec := range local {
+		if _, ok := remote[lspec]; !ok {
+			delete = append(delete, lspec)
+		}
+	}
+	return
+}
+
 func (s *Server) handlePausableWorkers(isLeader bool) {
 	for _, w := range s.pausableWorkers() {
 		if isLeader {
@@ -905,9 +1040,11 @@ func (s *Server) restorePeriodicDispatcher() error {
 
 // cronJobOverlapAllowed checks if the job allows for overlap and if there are already
 // instances of the job running in order to determine if a new evaluation needs to
-// be created upon periodic dispatcher restore
+// be created upon periodic dispatcher restore. The running instances of jobs
+// replacing them are stopped.
 func (s *Server) cronJobOverlapAllowed(job *structs.Job) (bool, error) {
-	if job.Periodic.ProhibitOverlap {
+	switch job.Periodic.Concurrency() {
+	case structs.PeriodicConcurrencyForbid:
 		running, err := s.periodicDispatcher.dispatcher.RunningChildren(job)
 		if err != nil {
 			return false, fmt.Errorf("failed to determine if periodic job has running children %q error %q", job.NamespacedID(), err)
@@ -916,6 +1053,11 @@ func (s *Server) cronJobOverlapAllowed(job *structs.Job) (bool, error) {
 		if running {
 			return false, nil
 		}
+
+	case structs.PeriodicConcurrencyReplace:
+		if err := s.periodicDispatcher.dispatcher.StopRunningChildren(job); err != nil {
+			return false, fmt.Errorf("failed to stop running children of periodic job %q error %q", job.NamespacedID(), err)
+		}
 	}
 
 	return true, nil
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"bytes"
+	"cmp"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// meshCAValidity is how long the CA certificate of a root key is valid
+	// from the creation of the key. Keys are rotated far more often than
+	// this, so in practice the CA never expires while its key is in use.
+	meshCAValidity = 10 * 365 * 24 * time.Hour
+
+	// meshLeafValidity is how long leaf certificates of sidecar proxies are
+	// valid. Clients renew them well before they expire.
+	meshLeafValidity = 72 * time.Hour
+
+	// meshLeafBackdate is subtracted from the NotBefore time of leaf
+	// certificates to tolerate clock skew between servers and clients.
+	meshLeafBackdate = time.Minute
+)
+
+// meshCACertificate returns the self-signed CA certificate of a root key. The
+// certificate is generated from the key material and metadata only, so that
+// every server generates the same certificate for the same key without
+// having to replicate it through raft.
+func meshCACertificate(cs *cipherSet) (*x509.Certificate, crypto.Signer, error) {
+	var signer crypto.Signer = cs.eddsaPrivateKey
+	if cs.rsaPrivateKey != nil {
+		signer = cs.rsaPrivateKey
+	}
+
+	keyID := cs.rootKey.Meta.KeyID
+	serial, ok := new(big.Int).SetString(strings.ReplaceAll(keyID, "-", ""), 16)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid root key ID %q", keyID)
+	}
+
+	notBefore := time.Unix(0, cs.rootKey.Meta.CreateTime).UTC().Truncate(time.Second)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "Nomad Mesh CA " + keyID},
+		URIs:                  []*url.URL{{Scheme: "spiffe", Host: structs.MeshTrustDomain}},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(meshCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	// PKCS #1 v1.5 and Ed25519 signatures are deterministic, so reading from
+	// rand here doesn't change the resulting certificate
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create mesh CA certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse mesh CA certificate: %w", err)
+	}
+	return cert, signer, nil
+}
+
+// MeshCABundle returns the PEM encoded CA certificates of every root key in
+// the keyring, oldest first, so that sidecars keep trusting certificates
+// signed with a key that was rotated out.
+func (e *Encrypter) MeshCABundle() ([]byte, error) {
+	e.keyringLock.RLock()
+	cipherSets := make([]*cipherSet, 0, len(e.keyring))
+	for _, cs := range e.keyring {
+		cipherSets = append(cipherSets, cs)
+	}
+	e.keyringLock.RUnlock()
+
+	slices.SortFunc(cipherSets, func(a, b *cipherSet) int {
+		return cmp.Compare(a.rootKey.Meta.CreateTime, b.rootKey.Meta.CreateTime)
+	})
+
+	var buf bytes.Buffer
+	for _, cs := range cipherSets {
+		cert, _, err := meshCACertificate(cs)
+		if err != nil {
+			return nil, err
+		}
+		if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// SignMeshCertificate signs a leaf certificate for the public key of the CSR
+// with the CA of the active root key. The certificate identifies the workload
+// by the given SPIFFE ID only, the subject and extensions of the CSR are
+// ignored.
+func (e *Encrypter) SignMeshCertificate(csrPEM []byte, spiffeID *url.URL) ([]byte, error) {
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, errors.New("CSR must be a PEM encoded certificate request")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSR: %w", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid CSR signature: %w", err)
+	}
+
+	cs, err := e.activeCipherSet()
+	if err != nil {
+		return nil, err
+	}
+	ca, signer, err := meshCACertificate(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	serial := make([]byte, 16)
+	if _, err := rand.Read(serial); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	template := &x509.Certificate{
+		SerialNumber: new(big.Int).SetBytes(serial),
+		Subject:      pkix.Name{CommonName: hex.EncodeToString(serial[:8])},
+		URIs:         []*url.URL{spiffeID},
+		NotBefore:    now.Add(-meshLeafBackdate),
+		NotAfter:     now.Add(meshLeafValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, csr.PublicKey, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign mesh certificate: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Mesh endpoint serves RPCs for the Connect sidecar proxies of services using
+// the Nomad provider.
+type Mesh struct {
+	srv *Server
+	ctx *RPCContext
+
+	encrypter *Encrypter
+}
+
+func NewMeshEndpoint(srv *Server, ctx *RPCContext, enc *Encrypter) *Mesh {
+	return &Mesh{srv: srv, ctx: ctx, encrypter: enc}
+}
+
+// SignCertificate signs the leaf certificate of a Connect sidecar proxy with
+// the keyring CA. The request must be authenticated with the workload identity
+// of the sidecar task, and the certificate identifies the service the sidecar
+// proxies for.
+func (m *Mesh) SignCertificate(args *structs.MeshCertificateRequest, reply *structs.MeshCertificateResponse) error {
+
+	authErr := m.srv.Authenticate(m.ctx, args)
+	if done, err := m.srv.forward(structs.MeshSignCertificateRPCMethod, args, args, reply); done {
+		return err
+	}
+	m.srv.MeasureRPCRate("mesh", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "mesh", "sign_certificate"}, time.Now())
+
+	if !m.srv.config.ACLEnabled {
+		// Authenticate never verifies claims when ACLs are disabled, but the
+		// certificate identity is derived from the workload identity, so it
+		// must always be verified.
+		if claims, _ := m.srv.VerifyClaim(args.AuthToken); claims != nil {
+			args.SetIdentity(&structs.AuthenticatedIdentity{Claims: claims})
+		}
+	}
+
+	claims := args.GetIdentity().GetClaims()
+	if claims == nil || claims.TaskName == "" {
+		return structs.ErrPermissionDenied
+	}
+
+	alloc, err := m.srv.State().AllocByID(nil, claims.AllocationID)
+	if err != nil {
+		return err
+	}
+	if alloc == nil || alloc.Job == nil || alloc.ClientTerminalStatus() {
+		return structs.ErrPermissionDenied
+	}
+
+	service, err := meshSidecarService(alloc, claims.TaskName)
+	if err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	cert, err := m.encrypter.SignMeshCertificate(args.CSR, structs.MeshSpiffeID(alloc.Namespace, service))
+	if err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+	bundle, err := m.encrypter.MeshCABundle()
+	if err != nil {
+		return err
+	}
+
+	reply.Certificate = cert
+	reply.CABundle = bundle
+	reply.Index, _ = m.srv.State().LatestIndex()
+	return nil
+}
+
+// meshSidecarService returns the name of the service that the task of the
+// allocation is the Connect sidecar proxy of.
+func meshSidecarService(alloc *structs.Allocation, taskName string) (string, error) {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return "", fmt.Errorf("task group %q not found", alloc.TaskGroup)
+	}
+	task := tg.LookupTask(taskName)
+	if task == nil || !task.Kind.IsConnectProxy() {
+		return "", fmt.Errorf("task %q is not a Connect sidecar proxy", taskName)
+	}
+
+	serviceName := task.Kind.Value()
+	for _, service := range tg.Services {
+		if service.Name == serviceName &&
+			service.Provider == structs.ServiceProviderNomad &&
+			service.Connect.HasSidecar() {
+			return serviceName, nil
+		}
+	}
+	return "", fmt.Errorf("task %q is not the sidecar proxy of a Nomad service", taskName)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestMeshEndpoint_SignCertificate(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, nil)
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+	codec := rpcClient(t, srv)
+
+	alloc := mock.ConnectAlloc()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	tg := alloc.Job.TaskGroups[0]
+	tg.Services[0].Provider = structs.ServiceProviderNomad
+	sidecar := &structs.Task{
+		Name: "connect-proxy-testconnect",
+		Kind: structs.NewTaskKind(structs.ConnectProxyPrefix, "testconnect"),
+	}
+	tg.Tasks = append(tg.Tasks, sidecar)
+	must.NoError(t, srv.fsm.State().UpsertAllocs(
+		structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	signToken := func(task *structs.Task) string {
+		claims := structs.NewIdentityClaimsBuilder(alloc.Job, alloc,
+			&structs.WIHandle{
+				WorkloadIdentifier: task.Name,
+				WorkloadType:       structs.WorkloadTypeTask,
+			},
+			structs.DefaultWorkloadIdentity()).
+			WithTask(task).
+			Build(time.Now())
+		token, _, err := srv.encrypter.SignClaims(claims)
+		must.NoError(t, err)
+		return token
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must.NoError(t, err)
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	must.NoError(t, err)
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})
+
+	t.Run("sidecar task", func(t *testing.T) {
+		req := &structs.MeshCertificateRequest{
+			CSR: csrPEM,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				AuthToken: signToken(sidecar),
+			},
+		}
+		var resp structs.MeshCertificateResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.MeshSignCertificateRPCMethod, req, &resp))
+
+		block, _ := pem.Decode(resp.Certificate)
+		must.NotNil(t, block)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		must.NoError(t, err)
+		must.Len(t, 1, cert.URIs)
+		must.Eq(t, "spiffe://nomad/ns/default/svc/testconnect", cert.URIs[0].String())
+
+		roots := x509.NewCertPool()
+		must.True(t, roots.AppendCertsFromPEM(resp.CABundle))
+		_, err = cert.Verify(x509.VerifyOptions{
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		must.NoError(t, err)
+	})
+
+	t.Run("application task", func(t *testing.T) {
+		req := &structs.MeshCertificateRequest{
+			CSR: csrPEM,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				AuthToken: signToken(tg.Tasks[0]),
+			},
+		}
+		var resp structs.MeshCertificateResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.MeshSignCertificateRPCMethod, req, &resp)
+		must.ErrorContains(t, err, `task "web" is not a Connect sidecar proxy`)
+	})
+
+	t.Run("no identity", func(t *testing.T) {
+		req := &structs.MeshCertificateRequest{
+			CSR:          csrPEM,
+			QueryOptions: structs.QueryOptions{Region: "global"},
+		}
+		var resp structs.MeshCertificateResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.MeshSignCertificateRPCMethod, req, &resp)
+		must.EqError(t, err, structs.ErrPermissionDenied.Error())
+	})
+}
+
+func TestMeshCA_Deterministic(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, nil)
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+
+	// every server must generate the same CA certificate for the same key
+	bundle1, err := srv.encrypter.MeshCABundle()
+	must.NoError(t, err)
+	bundle2, err := srv.encrypter.MeshCABundle()
+	must.NoError(t, err)
+	must.Eq(t, bundle1, bundle2)
+}
@@ -244,6 +244,24 @@ func Namespace() *structs.Namespace {
 	return ns
 }
 
+func QuotaSpec() *structs.QuotaSpec {
+	qs := &structs.QuotaSpec{
+		Name:        fmt.Sprintf("quota-%s", uuid.Short()),
+		Description: "test quota",
+		Limits: []*structs.QuotaLimit{
+			{
+				Region: "global",
+				RegionLimit: &structs.QuotaResources{
+					CPU:      2000,
+					MemoryMB: 2000,
+				},
+			},
+		},
+	}
+	qs.SetHash()
+	return qs
+}
+
 func NodePool() *structs.NodePool {
 	pool := &structs.NodePool{
 		Name:        fmt.Sprintf("pool-%s", uuid.Short()),
@@ -707,6 +707,11 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 	if args.NodeEvent != nil {
 		return fmt.Errorf("node event must not be set")
 	}
+	if args.DrainStrategy != nil {
+		if err := args.DrainStrategy.Validate(); err != nil {
+			return err
+		}
+	}
 
 	// The AuthenticatedIdentity is unexported so won't be written via
 	// Raft. Record the identity string so it can be written to LastDrain
@@ -738,10 +743,8 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 			args.DrainStrategy.StartedAt = node.DrainStrategy.StartedAt
 		}
 
-		// Mark the deadline time
-		if args.DrainStrategy.Deadline.Nanoseconds() > 0 {
-			args.DrainStrategy.ForceDeadline = now.Add(args.DrainStrategy.Deadline)
-		}
+		// Mark the deadline times
+		args.DrainStrategy.SetForceDeadlines(now)
 	}
 
 	// Construct the node event
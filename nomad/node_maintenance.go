@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// nodeMaintenanceInterval is the interval at which the leader checks whether
+// node maintenance windows start or end.
+var nodeMaintenanceInterval = 15 * time.Second
+
+// runNodeMaintenance drains the nodes of the maintenance windows when the
+// windows start, holds them ineligible during the windows, and makes them
+// eligible again when the windows end. It is only run on the leader.
+func (s *Server) runNodeMaintenance(stopCh chan struct{}) {
+	logger := s.logger.Named("node_maintenance")
+
+	ticker := time.NewTicker(nodeMaintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case now := <-ticker.C:
+			s.updateNodeMaintenanceWindows(logger, now)
+		}
+	}
+}
+
+// updateNodeMaintenanceWindows moves the maintenance windows along their
+// schedule.
+func (s *Server) updateNodeMaintenanceWindows(logger log.Logger, now time.Time) {
+	s.nodeMaintenanceLock.Lock()
+	defer s.nodeMaintenanceLock.Unlock()
+
+	iter, err := s.State().NodeMaintenanceWindows(nil)
+	if err != nil {
+		logger.Error("failed to list maintenance windows", "error", err)
+		return
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		window := raw.(*structs.NodeMaintenanceWindow)
+		if err := s.updateNodeMaintenanceWindow(window, now); err != nil {
+			logger.Error("failed to update maintenance window",
+				"window_id", window.ID, "name", window.Name, "error", err)
+		}
+	}
+}
+
+// updateNodeMaintenanceWindow starts the window once its start time is
+// reached, holds its nodes while it is active, and ends it once its end time
+// is reached. Windows missed entirely, for example while there was no
+// leader, are skipped.
+func (s *Server) updateNodeMaintenanceWindow(window *structs.NodeMaintenanceWindow, now time.Time) error {
+	updated := window.Copy()
+
+	switch window.Status {
+	case structs.NodeMaintenanceWindowStatusScheduled:
+		if now.Before(window.NextStart) {
+			return nil
+		}
+		if !now.Before(window.End()) {
+			return s.scheduleNodeMaintenanceWindow(updated, now)
+		}
+
+		// The window is recorded as active even if some of its nodes failed
+		// to drain, so that the nodes that did drain are restored at the end
+		// of the window.
+		updated.Status = structs.NodeMaintenanceWindowStatusActive
+		var mErr multierror.Error
+		if err := s.holdNodeMaintenanceNodes(updated); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+		if err := s.upsertNodeMaintenanceWindow(updated); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+		return mErr.ErrorOrNil()
+
+	case structs.NodeMaintenanceWindowStatusActive:
+		if now.Before(window.End()) {
+			err := s.holdNodeMaintenanceNodes(updated)
+			if slices.Equal(updated.ActiveNodeIDs, window.ActiveNodeIDs) {
+				return err
+			}
+			if upsertErr := s.upsertNodeMaintenanceWindow(updated); upsertErr != nil {
+				return upsertErr
+			}
+			return err
+		}
+
+		// The window remains active until all of its nodes are restored.
+		if err := s.restoreNodeMaintenanceNodes(updated); err != nil {
+			return err
+		}
+		updated.ActiveNodeIDs = nil
+		return s.scheduleNodeMaintenanceWindow(updated, now)
+	}
+	return nil
+}
+
+// scheduleNodeMaintenanceWindow schedules the next window ending after now,
+// or completes the window if it doesn't recur.
+func (s *Server) scheduleNodeMaintenanceWindow(window *structs.NodeMaintenanceWindow, now time.Time) error {
+	next, err := window.NextWindow(now)
+	if err != nil {
+		return err
+	}
+	if next.IsZero() {
+		window.Status = structs.NodeMaintenanceWindowStatusComplete
+	} else {
+		window.Status = structs.NodeMaintenanceWindowStatusScheduled
+		window.NextStart = next
+	}
+	return s.upsertNodeMaintenanceWindow(window)
+}
+
+func (s *Server) upsertNodeMaintenanceWindow(window *structs.NodeMaintenanceWindow) error {
+	req := &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:      []*structs.NodeMaintenanceWindow{window},
+		WriteRequest: structs.WriteRequest{Region: s.config.Region},
+	}
+	_, _, err := s.raftApply(structs.NodeMaintenanceWindowUpsertRequestType, req)
+	return err
+}
+
+// holdNodeMaintenanceNodes drains the eligible nodes selected by the active
+// window that it doesn't hold yet, and marks the nodes it holds ineligible
+// again if they were made eligible during the window. Nodes which are already
+// draining or ineligible when the window starts are left alone, so that they
+// aren't made eligible at the end of the window.
+func (s *Server) holdNodeMaintenanceNodes(window *structs.NodeMaintenanceWindow) error {
+	iter, err := s.State().Nodes(nil)
+	if err != nil {
+		return err
+	}
+	endpoint := NewNodeEndpoint(s, nil)
+
+	var mErr multierror.Error
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if node.DrainStrategy != nil || node.SchedulingEligibility != structs.NodeSchedulingEligible {
+			continue
+		}
+
+		if slices.Contains(window.ActiveNodeIDs, node.ID) {
+			if err := s.setNodeMaintenanceEligibility(endpoint, node.ID, structs.NodeSchedulingIneligible); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to mark node %s ineligible: %w", node.ID, err))
+			}
+			continue
+		}
+		if !window.Selects(node) {
+			continue
+		}
+
+		req := &structs.NodeUpdateDrainRequest{
+			NodeID:        node.ID,
+			DrainStrategy: &structs.DrainStrategy{DrainSpec: *window.DrainSpec.Copy()},
+			Meta:          nodeMaintenanceDrainMeta(window),
+			WriteRequest:  s.nodeMaintenanceWriteRequest(),
+		}
+		var resp structs.NodeDrainUpdateResponse
+		if err := endpoint.UpdateDrain(req, &resp); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to drain node %s: %w", node.ID, err))
+			continue
+		}
+		window.ActiveNodeIDs = append(window.ActiveNodeIDs, node.ID)
+	}
+	return mErr.ErrorOrNil()
+}
+
+// restoreNodeMaintenanceNodes cancels the drains of the nodes held by the
+// window which are still in progress and makes the nodes eligible again.
+// Nodes drained by someone else since the window drained them are left
+// alone.
+func (s *Server) restoreNodeMaintenanceNodes(window *structs.NodeMaintenanceWindow) error {
+	snap, err := s.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	endpoint := NewNodeEndpoint(s, nil)
+
+	var mErr multierror.Error
+	for _, nodeID := range window.ActiveNodeIDs {
+		node, err := snap.NodeByID(nil, nodeID)
+		if err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+			continue
+		}
+		if node == nil || node.LastDrain == nil ||
+			node.LastDrain.Meta[structs.NodeMaintenanceWindowMetaKey] != window.ID {
+			continue
+		}
+
+		switch {
+		case node.DrainStrategy != nil:
+			req := &structs.NodeUpdateDrainRequest{
+				NodeID:       node.ID,
+				MarkEligible: true,
+				Meta:         nodeMaintenanceDrainMeta(window),
+				WriteRequest: s.nodeMaintenanceWriteRequest(),
+			}
+			var resp structs.NodeDrainUpdateResponse
+			if err := endpoint.UpdateDrain(req, &resp); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to cancel drain of node %s: %w", node.ID, err))
+			}
+		case node.SchedulingEligibility == structs.NodeSchedulingIneligible:
+			if err := s.setNodeMaintenanceEligibility(endpoint, node.ID, structs.NodeSchedulingEligible); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to mark node %s eligible: %w", node.ID, err))
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+func (s *Server) setNodeMaintenanceEligibility(endpoint *Node, nodeID, eligibility string) error {
+	req := &structs.NodeUpdateEligibilityRequest{
+		NodeID:       nodeID,
+		Eligibility:  eligibility,
+		WriteRequest: s.nodeMaintenanceWriteRequest(),
+	}
+	var resp structs.NodeEligibilityUpdateResponse
+	return endpoint.UpdateEligibility(req, &resp)
+}
+
+func (s *Server) nodeMaintenanceWriteRequest() structs.WriteRequest {
+	return structs.WriteRequest{
+		Region:    s.config.Region,
+		AuthToken: s.getLeaderAcl(),
+	}
+}
+
+// nodeMaintenanceDrainMeta returns the drain metadata identifying the drains
+// of the maintenance window.
+func nodeMaintenanceDrainMeta(window *structs.NodeMaintenanceWindow) map[string]string {
+	name := window.Name
+	if name == "" {
+		name = window.ID
+	}
+	return map[string]string{
+		"message":                            fmt.Sprintf("Maintenance window %q", name),
+		structs.NodeMaintenanceWindowMetaKey: window.ID,
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// NodeMaintenance endpoint is used for the management of node maintenance
+// windows.
+type NodeMaintenance struct {
+	srv *Server
+	ctx *RPCContext
+}
+
+func NewNodeMaintenanceEndpoint(srv *Server, ctx *RPCContext) *NodeMaintenance {
+	return &NodeMaintenance{srv: srv, ctx: ctx}
+}
+
+// List is used to retrieve the maintenance windows. It supports prefix
+// listing by ID.
+func (n *NodeMaintenance) List(args *structs.NodeMaintenanceWindowListRequest, reply *structs.NodeMaintenanceWindowListResponse) error {
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward("NodeMaintenance.List", args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node_maintenance", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_maintenance", "list"}, time.Now())
+
+	if aclObj, err := n.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			var err error
+			var iter memdb.ResultIterator
+
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = store.NodeMaintenanceWindowsByIDPrefix(ws, prefix)
+			} else {
+				iter, err = store.NodeMaintenanceWindows(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			windows := []*structs.NodeMaintenanceWindow{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				windows = append(windows, raw.(*structs.NodeMaintenanceWindow))
+			}
+			reply.Windows = windows
+
+			index, err := store.Index(state.TableNodeMaintenanceWindows)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+
+			n.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return n.srv.blockingRPC(&opts)
+}
+
+// GetWindow returns the maintenance window requested or nil if it doesn't
+// exist.
+func (n *NodeMaintenance) GetWindow(args *structs.NodeMaintenanceWindowSpecificRequest, reply *structs.SingleNodeMaintenanceWindowResponse) error {
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward("NodeMaintenance.GetWindow", args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node_maintenance", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_maintenance", "get_window"}, time.Now())
+
+	if aclObj, err := n.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			window, err := store.NodeMaintenanceWindowByID(ws, args.ID)
+			if err != nil {
+				return err
+			}
+
+			reply.Window = window
+			if window != nil {
+				reply.Index = window.ModifyIndex
+			} else {
+				index, err := store.Index(state.TableNodeMaintenanceWindows)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+			return nil
+		}}
+	return n.srv.blockingRPC(&opts)
+}
+
+// UpsertWindows creates or updates the given maintenance windows and
+// schedules their next window. Active windows cannot be updated.
+func (n *NodeMaintenance) UpsertWindows(args *structs.NodeMaintenanceWindowUpsertRequest, reply *structs.NodeMaintenanceWindowUpsertResponse) error {
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward("NodeMaintenance.UpsertWindows", args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node_maintenance", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_maintenance", "upsert_windows"}, time.Now())
+
+	if aclObj, err := n.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.Windows) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one maintenance window")
+	}
+
+	n.srv.nodeMaintenanceLock.Lock()
+	defer n.srv.nodeMaintenanceLock.Unlock()
+
+	snap, err := n.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, window := range args.Windows {
+		window.Canonicalize()
+		if err := window.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid maintenance window %q: %v", window.Name, err)
+		}
+
+		if window.ID == "" {
+			window.ID = uuid.Generate()
+		} else {
+			existing, err := snap.NodeMaintenanceWindowByID(nil, window.ID)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return structs.NewErrRPCCodedf(http.StatusNotFound, "maintenance window %s not found", window.ID)
+			}
+			if existing.Status == structs.NodeMaintenanceWindowStatusActive {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest,
+					"maintenance window %s is active and cannot be updated", window.ID)
+			}
+		}
+
+		next, err := window.NextWindow(now)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid maintenance window %q: %v", window.Name, err)
+		}
+		if next.IsZero() {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "maintenance window %q ends in the past", window.Name)
+		}
+		window.Status = structs.NodeMaintenanceWindowStatusScheduled
+		window.NextStart = next
+		window.ActiveNodeIDs = nil
+	}
+
+	_, index, err := n.srv.raftApply(structs.NodeMaintenanceWindowUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	snap, err = n.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, window := range args.Windows {
+		stored, err := snap.NodeMaintenanceWindowByID(nil, window.ID)
+		if err != nil {
+			return err
+		}
+		reply.Windows = append(reply.Windows, stored)
+	}
+	reply.Index = index
+	return nil
+}
+
+// DeleteWindows deletes the given maintenance windows. The nodes held by
+// active windows are made eligible again before the windows are deleted.
+func (n *NodeMaintenance) DeleteWindows(args *structs.NodeMaintenanceWindowDeleteRequest, reply *structs.GenericResponse) error {
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward("NodeMaintenance.DeleteWindows", args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node_maintenance", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_maintenance", "delete_windows"}, time.Now())
+
+	if aclObj, err := n.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.IDs) == 0 {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "must specify at least one maintenance window to delete")
+	}
+
+	n.srv.nodeMaintenanceLock.Lock()
+	defer n.srv.nodeMaintenanceLock.Unlock()
+
+	snap, err := n.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, id := range args.IDs {
+		window, err := snap.NodeMaintenanceWindowByID(nil, id)
+		if err != nil {
+			return err
+		}
+		if window == nil {
+			return structs.NewErrRPCCodedf(http.StatusNotFound, "maintenance window %s not found", id)
+		}
+		if window.Status == structs.NodeMaintenanceWindowStatusActive {
+			if err := n.srv.restoreNodeMaintenanceNodes(window); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, index, err := n.srv.raftApply(structs.NodeMaintenanceWindowDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
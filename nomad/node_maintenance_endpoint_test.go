@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeMaintenanceEndpoint_CRUD(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Invalid windows are rejected.
+	upsertReq := &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:      []*structs.NodeMaintenanceWindow{{Name: "invalid", Duration: time.Hour}},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.NodeMaintenanceWindowUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, "NodeMaintenance.UpsertWindows", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "must select nodes")
+
+	// Windows ending in the past are rejected.
+	upsertReq.Windows = []*structs.NodeMaintenanceWindow{{
+		Name:     "past",
+		NodePool: "batch",
+		Start:    time.Now().Add(-2 * time.Hour),
+		Duration: time.Hour,
+	}}
+	err = msgpackrpc.CallWithCodec(codec, "NodeMaintenance.UpsertWindows", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "ends in the past")
+
+	// Valid windows are scheduled.
+	upsertReq.Windows = []*structs.NodeMaintenanceWindow{{
+		Name:       "weekly",
+		NodePool:   "batch",
+		Recurrence: "0 2 * * SUN",
+		Duration:   time.Hour,
+	}}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodeMaintenance.UpsertWindows", upsertReq, &upsertResp))
+	must.Len(t, 1, upsertResp.Windows)
+
+	window := upsertResp.Windows[0]
+	must.UUIDv4(t, window.ID)
+	must.Eq(t, structs.NodeMaintenanceWindowStatusScheduled, window.Status)
+	must.Eq(t, "UTC", window.TimeZone)
+	must.Eq(t, time.Hour, window.DrainSpec.Deadline)
+	must.True(t, window.NextStart.After(time.Now()))
+	must.Eq(t, time.Sunday, window.NextStart.Weekday())
+
+	// List and read the window.
+	listReq := &structs.NodeMaintenanceWindowListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global", Prefix: window.ID[:4]},
+	}
+	var listResp structs.NodeMaintenanceWindowListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodeMaintenance.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Windows)
+	must.Eq(t, window.ID, listResp.Windows[0].ID)
+
+	getReq := &structs.NodeMaintenanceWindowSpecificRequest{
+		ID:           window.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleNodeMaintenanceWindowResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodeMaintenance.GetWindow", getReq, &getResp))
+	must.Eq(t, window.ID, getResp.Window.ID)
+	must.Eq(t, window.ModifyIndex, getResp.Index)
+
+	// Active windows can't be updated.
+	active := window.Copy()
+	active.Status = structs.NodeMaintenanceWindowStatusActive
+	must.NoError(t, s.fsm.State().UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 2000,
+		[]*structs.NodeMaintenanceWindow{active}))
+
+	upsertReq.Windows = []*structs.NodeMaintenanceWindow{window.Copy()}
+	err = msgpackrpc.CallWithCodec(codec, "NodeMaintenance.UpsertWindows", upsertReq, &upsertResp)
+	must.ErrorContains(t, err, "is active and cannot be updated")
+
+	// Delete the window.
+	deleteReq := &structs.NodeMaintenanceWindowDeleteRequest{
+		IDs:          []string{window.ID},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var deleteResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodeMaintenance.DeleteWindows", deleteReq, &deleteResp))
+
+	getResp = structs.SingleNodeMaintenanceWindowResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodeMaintenance.GetWindow", getReq, &getResp))
+	must.Nil(t, getResp.Window)
+
+	err = msgpackrpc.CallWithCodec(codec, "NodeMaintenance.DeleteWindows", deleteReq, &deleteResp)
+	must.ErrorContains(t, err, "not found")
+}
+
+func TestNodeMaintenanceEndpoint_DeleteActive(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.fsm.State()
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	upsertReq := &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows: []*structs.NodeMaintenanceWindow{{
+			NodeIDs:  []string{node.ID},
+			Start:    time.Now(),
+			Duration: time.Hour,
+		}},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.NodeMaintenanceWindowUpsertResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodeMaintenance.UpsertWindows", upsertReq, &upsertResp))
+	window := upsertResp.Windows[0]
+
+	// Start the window.
+	s.updateNodeMaintenanceWindows(s.logger, time.Now())
+	out, err := store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeSchedulingIneligible, out.SchedulingEligibility)
+
+	// Deleting the active window makes its nodes eligible again.
+	deleteReq := &structs.NodeMaintenanceWindowDeleteRequest{
+		IDs:          []string{window.ID},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var deleteResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "NodeMaintenance.DeleteWindows", deleteReq, &deleteResp))
+
+	out, err = store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Nil(t, out.DrainStrategy)
+	must.Eq(t, structs.NodeSchedulingEligible, out.SchedulingEligibility)
+}
+
+func TestNodeMaintenanceEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.fsm.State()
+
+	readToken := mock.CreatePolicyAndToken(t, store, 1001, "node-read", mock.NodePolicy(acl.PolicyRead))
+	writeToken := mock.CreatePolicyAndToken(t, store, 1003, "node-write", mock.NodePolicy(acl.PolicyWrite))
+
+	upsert := func(token string) error {
+		req := &structs.NodeMaintenanceWindowUpsertRequest{
+			Windows: []*structs.NodeMaintenanceWindow{{
+				NodePool: "batch",
+				Start:    time.Now().Add(time.Hour),
+				Duration: time.Hour,
+			}},
+			WriteRequest: structs.WriteRequest{Region: "global", AuthToken: token},
+		}
+		var resp structs.NodeMaintenanceWindowUpsertResponse
+		return msgpackrpc.CallWithCodec(codec, "NodeMaintenance.UpsertWindows", req, &resp)
+	}
+	list := func(token string) error {
+		req := &structs.NodeMaintenanceWindowListRequest{
+			QueryOptions: structs.QueryOptions{Region: "global", AuthToken: token},
+		}
+		var resp structs.NodeMaintenanceWindowListResponse
+		return msgpackrpc.CallWithCodec(codec, "NodeMaintenance.List", req, &resp)
+	}
+
+	must.EqError(t, upsert(""), structs.ErrPermissionDenied.Error())
+	must.EqError(t, upsert(readToken.SecretID), structs.ErrPermissionDenied.Error())
+	must.NoError(t, upsert(writeToken.SecretID))
+	must.NoError(t, upsert(root.SecretID))
+
+	must.EqError(t, list(""), structs.ErrPermissionDenied.Error())
+	must.NoError(t, list(readToken.SecretID))
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeMaintenance_UpdateWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, nil)
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+	store := srv.fsm.State()
+
+	// A node under maintenance, a node in another datacenter, and a node
+	// which is already ineligible.
+	node := mock.Node()
+	otherNode := mock.Node()
+	otherNode.Datacenter = "dc2"
+	ineligibleNode := mock.Node()
+	ineligibleNode.SchedulingEligibility = structs.NodeSchedulingIneligible
+	for i, n := range []*structs.Node{node, otherNode, ineligibleNode} {
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(1000+i), n))
+	}
+
+	now := time.Now().UTC()
+	window := &structs.NodeMaintenanceWindow{
+		ID:         uuid.Generate(),
+		Name:       "patching",
+		Datacenter: "dc1",
+		Start:      now.Add(time.Minute),
+		Duration:   time.Hour,
+		DrainSpec:  &structs.DrainSpec{Deadline: time.Hour},
+		Status:     structs.NodeMaintenanceWindowStatusScheduled,
+		NextStart:  now.Add(time.Minute),
+	}
+	must.NoError(t, store.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1010,
+		[]*structs.NodeMaintenanceWindow{window}))
+
+	getWindow := func() *structs.NodeMaintenanceWindow {
+		t.Helper()
+		out, err := store.NodeMaintenanceWindowByID(nil, window.ID)
+		must.NoError(t, err)
+		return out
+	}
+	getNode := func(id string) *structs.Node {
+		t.Helper()
+		out, err := store.NodeByID(nil, id)
+		must.NoError(t, err)
+		return out
+	}
+
+	// Nothing happens before the window starts.
+	srv.updateNodeMaintenanceWindows(srv.logger, now)
+	must.Eq(t, structs.NodeMaintenanceWindowStatusScheduled, getWindow().Status)
+	must.Eq(t, structs.NodeSchedulingEligible, getNode(node.ID).SchedulingEligibility)
+
+	// The selected eligible nodes are drained when the window starts.
+	srv.updateNodeMaintenanceWindows(srv.logger, now.Add(2*time.Minute))
+	must.Eq(t, structs.NodeMaintenanceWindowStatusActive, getWindow().Status)
+	must.Eq(t, []string{node.ID}, getWindow().ActiveNodeIDs)
+
+	drained := getNode(node.ID)
+	must.Eq(t, structs.NodeSchedulingIneligible, drained.SchedulingEligibility)
+	must.NotNil(t, drained.LastDrain)
+	must.Eq(t, window.ID, drained.LastDrain.Meta[structs.NodeMaintenanceWindowMetaKey])
+	must.Nil(t, getNode(otherNode.ID).DrainStrategy)
+	must.Nil(t, getNode(ineligibleNode.ID).DrainStrategy)
+
+	// The nodes are held ineligible during the window.
+	endpoint := NewNodeEndpoint(srv, nil)
+	cancelReq := &structs.NodeUpdateDrainRequest{
+		NodeID:       node.ID,
+		MarkEligible: true,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	must.NoError(t, endpoint.UpdateDrain(cancelReq, &structs.NodeDrainUpdateResponse{}))
+	must.Eq(t, structs.NodeSchedulingEligible, getNode(node.ID).SchedulingEligibility)
+
+	srv.updateNodeMaintenanceWindows(srv.logger, now.Add(30*time.Minute))
+	must.Eq(t, structs.NodeSchedulingIneligible, getNode(node.ID).SchedulingEligibility)
+
+	// The nodes are made eligible again when the window ends, and the window
+	// completes as it doesn't recur.
+	srv.updateNodeMaintenanceWindows(srv.logger, now.Add(2*time.Hour))
+	must.Eq(t, structs.NodeMaintenanceWindowStatusComplete, getWindow().Status)
+	must.SliceEmpty(t, getWindow().ActiveNodeIDs)
+
+	restored := getNode(node.ID)
+	must.Nil(t, restored.DrainStrategy)
+	must.Eq(t, structs.NodeSchedulingEligible, restored.SchedulingEligibility)
+	must.Eq(t, structs.NodeSchedulingIneligible, getNode(ineligibleNode.ID).SchedulingEligibility)
+}
+
+func TestNodeMaintenance_UpdateWindow_Missed(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, nil)
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+	store := srv.fsm.State()
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	now := time.Date(2026, 11, 4, 12, 0, 0, 0, time.UTC)
+	window := &structs.NodeMaintenanceWindow{
+		ID:         uuid.Generate(),
+		NodeIDs:    []string{node.ID},
+		Recurrence: "0 2 * * *",
+		TimeZone:   "UTC",
+		Duration:   time.Hour,
+		DrainSpec:  &structs.DrainSpec{Deadline: time.Hour},
+		Status:     structs.NodeMaintenanceWindowStatusScheduled,
+		NextStart:  time.Date(2026, 11, 2, 2, 0, 0, 0, time.UTC),
+	}
+	must.NoError(t, store.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1001,
+		[]*structs.NodeMaintenanceWindow{window}))
+
+	// Windows missed entirely are skipped without draining the nodes.
+	srv.updateNodeMaintenanceWindows(srv.logger, now)
+
+	out, err := store.NodeMaintenanceWindowByID(nil, window.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeMaintenanceWindowStatusScheduled, out.Status)
+	must.Eq(t, time.Date(2026, 11, 5, 2, 0, 0, 0, time.UTC), out.NextStart.UTC())
+
+	outNode, err := store.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Nil(t, outNode.DrainStrategy)
+	must.Eq(t, structs.NodeSchedulingEligible, outNode.SchedulingEligibility)
+}
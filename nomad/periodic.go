@@ -7,6 +7,7 @@ import (
 	"container/heap"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -45,6 +46,13 @@ type JobEvalDispatcher interface {
 
 	// RunningChildren returns whether the passed job has any running children.
 	RunningChildren(job *structs.Job) (bool, error)
+
+	// StopRunningChildren stops the running children of the passed job.
+	StopRunningChildren(job *structs.Job) error
+
+	// PurgeChildren purges the dead children of the passed job beyond its
+	// history limit.
+	PurgeChildren(job *structs.Job) error
 }
 
 // DispatchJob creates an evaluation for the passed job and commits both the
@@ -85,49 +93,194 @@ func (s *Server) DispatchJob(job *structs.Job) (*structs.Evaluation, error) {
 
 // RunningChildren checks whether the passed job has any running children.
 func (s *Server) RunningChildren(job *structs.Job) (bool, error) {
-	snap, err := s.fsm.State().Snapshot()
+	running, err := s.runningChildren(job, true)
 	if err != nil {
 		return false, err
 	}
+	return len(running) != 0, nil
+}
+
+// StopRunningChildren deregisters the running children of the passed job, so
+// that they are replaced by its next launch.
+func (s *Server) StopRunningChildren(job *structs.Job) error {
+	running, err := s.runningChildren(job, false)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range running {
+		if child.Stop {
+			continue
+		}
+		req := &structs.JobDeregisterRequest{
+			JobID: child.ID,
+			WriteRequest: structs.WriteRequest{
+				Region:    s.Region(),
+				Namespace: child.Namespace,
+				AuthToken: s.getLeaderAcl(),
+			},
+		}
+		var resp structs.JobDeregisterResponse
+		if err := s.RPC("Job.Deregister", req, &resp); err != nil {
+			return fmt.Errorf("failed to stop child job %q: %v", child.ID, err)
+		}
+	}
+	return nil
+}
+
+// runningChildren returns the children of the passed job which have active
+// evaluations or running allocations. If first is set, it returns as soon as
+// it found one.
+func (s *Server) runningChildren(job *structs.Job, first bool) ([]*structs.Job, error) {
+	snap, err := s.fsm.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
 
 	ws := memdb.NewWatchSet()
+	children, err := periodicChildren(ws, snap, job)
+	if err != nil {
+		return nil, err
+	}
+
+	var running []*structs.Job
+	for _, child := range children {
+		ok, err := childRunning(ws, snap, child)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		running = append(running, child)
+		if first {
+			break
+		}
+	}
+	return running, nil
+}
+
+// PurgeChildren purges the dead children of the passed job beyond its history
+// limit, keeping the most recent successful and failed children.
+func (s *Server) PurgeChildren(job *structs.Job) error {
+	limit := job.Periodic.HistoryLimit
+	if limit == 0 {
+		return nil
+	}
+
+	snap, err := s.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+	children, err := periodicChildren(ws, snap, job)
+	if err != nil {
+		return err
+	}
+
+	// Sort the children from the most recent launch
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].CreateIndex > children[j].CreateIndex
+	})
+
+	var successful, failed int
+	purge := make(map[structs.NamespacedID]*structs.JobDeregisterOptions)
+	for _, child := range children {
+		if child.Status != structs.JobStatusDead {
+			continue
+		}
+		allocs, err := snap.AllocsByJob(ws, child.Namespace, child.ID, false)
+		if err != nil {
+			return err
+		}
+
+		count := &successful
+		if childFailed(child, allocs) {
+			count = &failed
+		}
+		*count++
+		if *count > limit {
+			purge[child.NamespacedID()] = &structs.JobDeregisterOptions{Purge: true}
+		}
+	}
+	if len(purge) == 0 {
+		return nil
+	}
+
+	req := &structs.JobBatchDeregisterRequest{
+		Jobs: purge,
+		WriteRequest: structs.WriteRequest{
+			Region:    s.Region(),
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+	var resp structs.JobBatchDeregisterResponse
+	return s.RPC(structs.JobBatchDeregisterRPCMethod, req, &resp)
+}
+
+// periodicChildren returns the children launched by the passed periodic job.
+func periodicChildren(ws memdb.WatchSet, snap *state.StateSnapshot, job *structs.Job) ([]*structs.Job, error) {
 	prefix := fmt.Sprintf("%s%s", job.ID, structs.PeriodicLaunchSuffix)
 	iter, err := snap.JobsByIDPrefix(ws, job.Namespace, prefix, state.SortDefault)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	var child *structs.Job
+	var children []*structs.Job
 	for i := iter.Next(); i != nil; i = iter.Next() {
-		child = i.(*structs.Job)
+		child := i.(*structs.Job)
 
 		// Ensure the job is actually a child.
 		if child.ParentID != job.ID {
 			continue
 		}
+		children = append(children, child)
+	}
+	return children, nil
+}
+
+// childFailed returns whether the dead child job was stopped or has allocations
+// which failed or were lost without being replaced.
+func childFailed(child *structs.Job, allocs []*structs.Allocation) bool {
+	if child.Stop {
+		return true
+	}
+	for _, alloc := range allocs {
+		switch alloc.ClientStatus {
+		case structs.AllocClientStatusFailed, structs.AllocClientStatusLost:
+			if alloc.NextAllocation == "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// childRunning returns whether the child job has evaluations which are active
+// or have running allocations.
+func childRunning(ws memdb.WatchSet, snap *state.StateSnapshot, child *structs.Job) (bool, error) {
+	// Get the childs evaluations.
+	evals, err := snap.EvalsByJob(ws, child.Namespace, child.ID)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if any of the evals are active or have running allocations.
+	for _, eval := range evals {
+		if !eval.TerminalStatus() {
+			return true, nil
+		}
 
-		// Get the childs evaluations.
-		evals, err := snap.EvalsByJob(ws, child.Namespace, child.ID)
+		allocs, err := snap.AllocsByEval(ws, eval.ID)
 		if err != nil {
 			return false, err
 		}
 
-		// Check if any of the evals are active or have running allocations.
-		for _, eval := range evals {
-			if !eval.TerminalStatus() {
+		for _, alloc := range allocs {
+			if !alloc.TerminalStatus() {
 				return true, nil
 			}
-
-			allocs, err := snap.AllocsByEval(ws, eval.ID)
-			if err != nil {
-				return false, err
-			}
-
-			for _, alloc := range allocs {
-				if !alloc.TerminalStatus() {
-					return true, nil
-				}
-			}
 		}
 	}
 
@@ -347,9 +500,10 @@ func (p *PeriodicDispatch) dispatch(job *structs.Job, launchTime time.Time) {
 		p.logger.Error("failed to update next launch of periodic job", "job", job.NamespacedID(), "error", err)
 	}
 
-	// If the job prohibits overlapping and there are running children, we skip
-	// the launch.
-	if job.Periodic.ProhibitOverlap {
+	// If the job forbids concurrent children and there are running
+	// children, we skip the launch.
+	concurrency := job.Periodic.Concurrency()
+	if concurrency == structs.PeriodicConcurrencyForbid {
 		running, err := p.dispatcher.RunningChildren(job)
 		if err != nil {
 			p.logger.Error("failed to determine if periodic job has running children", "job", job.NamespacedID(), "error", err)
@@ -366,7 +520,23 @@ func (p *PeriodicDispatch) dispatch(job *structs.Job, launchTime time.Time) {
 
 	p.logger.Debug(" launching job", "job", job.NamespacedID(), "launch_time", launchTime)
 	p.l.Unlock()
-	p.createEval(job, launchTime)
+
+	// Stopping the children updates the dispatcher, so it must be done
+	// without the lock held.
+	if concurrency == structs.PeriodicConcurrencyReplace {
+		if err := p.dispatcher.StopRunningChildren(job); err != nil {
+			p.logger.Error("failed to stop running children of periodic job", "job", job.NamespacedID(), "error", err)
+			return
+		}
+	}
+
+	if _, err := p.createEval(job, launchTime); err != nil {
+		return
+	}
+	if err := p.dispatcher.PurgeChildren(job); err != nil {
+		p.logger.Error("failed to purge children of periodic job beyond its history limit",
+			"job", job.NamespacedID(), "error", err)
+	}
 }
 
 // nextLaunch returns the next job to launch and when it should be launched. If
@@ -55,6 +55,21 @@ func (m *MockJobEvalDispatcher) RunningChildren(parent *structs.Job) (bool, erro
 	return false, nil
 }
 
+func (m *MockJobEvalDispatcher) StopRunningChildren(parent *structs.Job) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, job := range m.Jobs {
+		if job.ParentID == parent.ID && job.Namespace == parent.Namespace {
+			job.Stop = true
+		}
+	}
+	return nil
+}
+
+func (m *MockJobEvalDispatcher) PurgeChildren(parent *structs.Job) error {
+	return nil
+}
+
 // LaunchTimes returns the launch times of child jobs in sorted order.
 func (m *MockJobEvalDispatcher) LaunchTimes(p *PeriodicDispatch, namespace, parentID string) ([]time.Time, error) {
 	m.lock.Lock()
@@ -428,6 +443,32 @@ func TestPeriodicDispatch_Run_DisallowOverlaps(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_Run_ReplaceRunning(t *testing.T) {
+	ci.Parallel(t)
+	p, m := testPeriodicDispatcher(t)
+
+	// Create a job that will trigger two launches and replaces the running
+	// children.
+	launch1 := time.Now().Round(1 * time.Second).Add(1 * time.Second)
+	launch2 := time.Now().Round(1 * time.Second).Add(2 * time.Second)
+	job := testPeriodicJob(launch1, launch2)
+	job.Periodic.ConcurrencyPolicy = structs.PeriodicConcurrencyReplace
+	must.NoError(t, p.Add(job))
+
+	time.Sleep(3 * time.Second)
+
+	// Check that both jobs were launched and the first one was stopped.
+	times, err := m.LaunchTimes(p, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{launch1, launch2}, times)
+
+	for _, child := range m.dispatchedJobs(job) {
+		launch, err := p.LaunchTime(child.ID)
+		must.NoError(t, err)
+		must.Eq(t, launch.Equal(launch1), child.Stop, must.Sprintf("child %s", child.ID))
+	}
+}
+
 func TestPeriodicDispatch_Run_Multiple(t *testing.T) {
 	ci.Parallel(t)
 	p, m := testPeriodicDispatcher(t)
@@ -773,6 +814,102 @@ func TestPeriodicDispatch_RunningChildren_ActiveAllocs(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_StopRunningChildren(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Insert periodic job and a running child.
+	store := s1.fsm.State()
+	job := mock.PeriodicJob()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	childjob := deriveChildJob(job)
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, childjob))
+
+	eval := mock.Eval()
+	eval.JobID = childjob.ID
+	eval.Status = structs.EvalStatusPending
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 1002, []*structs.Evaluation{eval}))
+
+	must.NoError(t, s1.StopRunningChildren(job))
+
+	out, err := store.JobByID(nil, childjob.Namespace, childjob.ID)
+	must.NoError(t, err)
+	must.True(t, out.Stop)
+
+	// The periodic job itself is left untouched
+	out, err = store.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.False(t, out.Stop)
+}
+
+func TestPeriodicDispatch_PurgeChildren(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	job := mock.PeriodicJob()
+	job.Periodic.HistoryLimit = 1
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	// Insert dead children, the failed ones having a failed allocation.
+	index := uint64(1001)
+	statuses := []string{
+		structs.AllocClientStatusComplete,
+		structs.AllocClientStatusFailed,
+		structs.AllocClientStatusComplete,
+		structs.AllocClientStatusFailed,
+		structs.AllocClientStatusComplete,
+	}
+	var children []string
+	for i, status := range statuses {
+		childjob := mock.BatchJob()
+		childjob.ParentID = job.ID
+		childjob.ID = fmt.Sprintf("%s%s%d", job.ID, structs.PeriodicLaunchSuffix, i)
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index, nil, childjob))
+		childjob, err := store.JobByID(nil, childjob.Namespace, childjob.ID)
+		must.NoError(t, err)
+		children = append(children, childjob.ID)
+
+		eval := mock.Eval()
+		eval.JobID = childjob.ID
+		eval.Status = structs.EvalStatusComplete
+		must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, index+1, []*structs.Evaluation{eval}))
+
+		alloc := mock.Alloc()
+		alloc.Job = childjob
+		alloc.JobID = childjob.ID
+		alloc.EvalID = eval.ID
+		alloc.TaskGroup = childjob.TaskGroups[0].Name
+		alloc.ClientStatus = status
+		must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index+2, []*structs.Allocation{alloc}))
+		index += 3
+	}
+
+	must.NoError(t, s1.PurgeChildren(job))
+
+	// Only the most recent successful and failed children are kept
+	var kept []string
+	for _, id := range children {
+		out, err := store.JobByID(nil, job.Namespace, id)
+		must.NoError(t, err)
+		if out != nil {
+			kept = append(kept, id)
+		}
+	}
+	must.Eq(t, []string{children[3], children[4]}, kept)
+}
+
 // TestPeriodicDispatch_JobEmptyStatus asserts that dispatched
 // job will always has an empty status
 func TestPeriodicDispatch_JobEmptyStatus(t *testing.T) {
@@ -506,6 +506,52 @@ func evaluatePlan(pool *EvaluatePool, snap *state.StateSnapshot, plan *structs.P
 	return evaluatePlanPlacements(pool, snap, plan, logger)
 }
 
+// evaluatePlanQuota returns whether the plan would take the namespace of its
+// job over the limit of its quota in the local region. Plans that don't
+// increase the usage of any exhausted dimension are allowed, so that a
+// namespace over its quota can always stop or shrink its allocations.
+func evaluatePlanQuota(snap *state.StateSnapshot, plan *structs.Plan) (bool, error) {
+	if plan.Job == nil {
+		return false, nil
+	}
+
+	ns, err := snap.NamespaceByName(nil, plan.Job.Namespace)
+	if err != nil || ns == nil || ns.Quota == "" {
+		return false, err
+	}
+	spec, err := snap.QuotaSpecByName(nil, ns.Quota)
+	if err != nil || spec == nil {
+		return false, err
+	}
+	limit := spec.LimitForRegion(snap.Config().Region)
+	if limit == nil {
+		return false, nil
+	}
+	usage, err := snap.QuotaUsageByName(nil, ns.Quota)
+	if err != nil || usage == nil {
+		return false, err
+	}
+	used, ok := usage.Used[limit.HashKey()]
+	if !ok || used.RegionLimit == nil {
+		return false, nil
+	}
+
+	before, after, err := plan.QuotaUsageChange(limit, ns.Name, func(id string) (*structs.Allocation, error) {
+		return snap.AllocByID(nil, id)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	delta := after.Copy()
+	delta.Subtract(before)
+	proposed := used.RegionLimit.Copy()
+	proposed.Subtract(before)
+	proposed.Add(after)
+
+	return len(limit.Exhausted(proposed, delta)) != 0, nil
+}
+
 // evaluatePlanPlacements is used to determine what portions of a plan can be
 // applied if any, looking for node over commitment. Returns if there should be
 // a plan application which may be partial or if there was an error
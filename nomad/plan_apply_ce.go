@@ -8,7 +8,6 @@ package nomad
 
 import (
 	"github.com/hashicorp/nomad/nomad/state"
-	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 // refreshIndex returns the index the scheduler should refresh to as the maximum
@@ -24,8 +23,3 @@ func refreshIndex(snap *state.StateSnapshot) (uint64, error) {
 	}
 	return maxUint64(nodeIndex, allocIndex), nil
 }
-
-// evaluatePlanQuota returns whether the plan would be over quota
-func evaluatePlanQuota(_ *state.StateSnapshot, _ *structs.Plan) (bool, error) {
-	return false, nil
-}
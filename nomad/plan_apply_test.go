@@ -530,6 +530,59 @@ func TestPlanApply_EvalPlan_Simple(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalPlan_Quota(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	qs := mock.QuotaSpec()
+	qs.Limits[0].RegionLimit.CPU = 600
+	must.NoError(t, state.UpsertQuotaSpecs(1001, []*structs.QuotaSpec{qs}))
+	ns := mock.Namespace()
+	ns.Quota = qs.Name
+	must.NoError(t, state.UpsertNamespaces(1002, []*structs.Namespace{ns}))
+
+	existing := mock.Alloc()
+	existing.Namespace = ns.Name
+	existing.Job.Namespace = ns.Name
+	existing.NodeID = node.ID
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1003, []*structs.Allocation{existing}))
+	snap, err := state.Snapshot()
+	must.NoError(t, err)
+
+	pool := NewEvaluatePool(workerPoolSize, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	// placing another allocation exceeds the cpu limit, so the plan is
+	// rejected and the scheduler forced to refresh its state
+	alloc := mock.Alloc()
+	alloc.Namespace = ns.Name
+	alloc.Job = existing.Job
+	alloc.JobID = existing.JobID
+	plan := &structs.Plan{
+		Job: existing.Job,
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {alloc},
+		},
+	}
+	result, err := evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.MapEmpty(t, result.NodeAllocation)
+	must.Eq(t, 1003, result.RefreshIndex)
+
+	// replacing the existing allocation keeps the namespace within its quota
+	stop := existing.Copy()
+	stop.DesiredStatus = structs.AllocDesiredStatusStop
+	plan.NodeUpdate = map[string][]*structs.Allocation{
+		node.ID: {stop},
+	}
+	result, err = evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.Eq(t, plan.NodeAllocation, result.NodeAllocation)
+	must.Zero(t, result.RefreshIndex)
+}
+
 func TestPlanApply_EvalPlan_Preemption(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
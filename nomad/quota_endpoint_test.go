@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestQuotaEndpoint_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	qs1, qs2 := mock.QuotaSpec(), mock.QuotaSpec()
+	upsert := &structs.QuotaSpecUpsertRequest{
+		Quotas:       []*structs.QuotaSpec{qs1, qs2},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsert, &upsertResp))
+	must.NotEq(t, 0, upsertResp.Index)
+
+	// invalid quota specifications are rejected
+	invalid := mock.QuotaSpec()
+	invalid.Limits[0].RegionLimit = nil
+	upsert.Quotas = []*structs.QuotaSpec{invalid}
+	err := msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsert, &upsertResp)
+	must.ErrorContains(t, err, "missing region limit")
+
+	get := &structs.QuotaSpecSpecificRequest{
+		Name:         qs1.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleQuotaSpecResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaSpec", get, &getResp))
+	must.NotNil(t, getResp.Quota)
+	must.Eq(t, qs1.Limits, getResp.Quota.Limits)
+
+	list := &structs.QuotaSpecListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.QuotaSpecListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.ListQuotaSpecs", list, &listResp))
+	must.Len(t, 2, listResp.Quotas)
+
+	list.Prefix = qs1.Name
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.ListQuotaSpecs", list, &listResp))
+	must.Len(t, 1, listResp.Quotas)
+
+	// usages track the allocations of the namespaces using the quota
+	ns := mock.Namespace()
+	ns.Quota = qs1.Name
+	must.NoError(t, s1.fsm.State().UpsertNamespaces(upsertResp.Index+1, []*structs.Namespace{ns}))
+	alloc := mock.Alloc()
+	alloc.Namespace = ns.Name
+	must.NoError(t, s1.fsm.State().UpsertAllocs(structs.MsgTypeTestSetup, upsertResp.Index+2, []*structs.Allocation{alloc}))
+
+	var usageResp structs.SingleQuotaUsageResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaUsage", get, &usageResp))
+	must.NotNil(t, usageResp.Usage)
+	used := usageResp.Usage.Used[qs1.Limits[0].HashKey()]
+	must.Eq(t, 500, used.RegionLimit.CPU)
+	must.NotNil(t, used.RegionLimit.Storage)
+
+	list.Prefix = ""
+	var usagesResp structs.QuotaUsageListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.ListQuotaUsages", list, &usagesResp))
+	must.Len(t, 2, usagesResp.Usages)
+
+	// quota specifications used by namespaces can't be deleted
+	del := &structs.QuotaSpecDeleteRequest{
+		Names:        []string{qs1.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var delResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "Quota.DeleteQuotaSpecs", del, &delResp)
+	must.ErrorContains(t, err, "is used by namespace")
+
+	del.Names = []string{qs2.Name}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.DeleteQuotaSpecs", del, &delResp))
+
+	get.Name = qs2.Name
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaSpec", get, &getResp))
+	must.Nil(t, getResp.Quota)
+}
+
+func TestQuotaEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "quota-read",
+		mock.QuotaPolicy(acl.PolicyRead))
+	writeToken := mock.CreatePolicyAndToken(t, state, 1002, "quota-write",
+		mock.QuotaPolicy(acl.PolicyWrite))
+
+	qs := mock.QuotaSpec()
+	upsert := &structs.QuotaSpecUpsertRequest{
+		Quotas:       []*structs.QuotaSpec{qs},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.GenericResponse
+
+	// writes require quota write permissions
+	err := msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsert, &upsertResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsert.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsert, &upsertResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsert.AuthToken = writeToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsert, &upsertResp))
+
+	// reads require quota read permissions
+	get := &structs.QuotaSpecSpecificRequest{
+		Name:         qs.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleQuotaSpecResponse
+	err = msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaSpec", get, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	for _, token := range []string{readToken.SecretID, root.SecretID} {
+		get.AuthToken = token
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaSpec", get, &getResp))
+		must.NotNil(t, getResp.Quota)
+	}
+}
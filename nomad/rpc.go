@@ -445,7 +445,7 @@ func (r *rpcHandler) handleMultiplex(ctx context.Context, conn net.Conn, rpcCtx
 // handleNomadConn is used to service a single Nomad RPC connection
 func (r *rpcHandler) handleNomadConn(ctx context.Context, conn net.Conn, server *rpc.Server) {
 	defer conn.Close()
-	rpcCodec := pool.NewServerCodec(conn)
+	rpcCodec := newAuditCodec(r.srv, pool.NewServerCodec(conn), conn.RemoteAddr().String())
 	for {
 		select {
 		case <-ctx.Done():
@@ -457,6 +457,11 @@ func (r *rpcHandler) handleNomadConn(ctx context.Context, conn net.Conn, server
 		}
 
 		if err := server.ServeRequest(rpcCodec); err != nil {
+			// requests failed by auditing are answered, so keep serving
+			// the connection
+			if errors.Is(err, errAuditFailed) {
+				continue
+			}
 			if err != io.EOF && !strings.Contains(err.Error(), "closed") {
 				r.logger.Error("RPC error", "error", err, "connection", conn)
 				metrics.IncrCounter([]string{"nomad", "rpc", "request_error"}, 1)
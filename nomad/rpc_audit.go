@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/rpc"
+	"time"
+
+	"github.com/hashicorp/nomad/command/agent/event"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// errAuditFailed is returned to RPC requests whose audit events can't be
+// written to an enforced audit sink.
+var errAuditFailed = errors.New("failed to write audit event")
+
+// SetAuditor sets the auditor the server sends the audit events of the RPC
+// requests it serves to.
+func (s *Server) SetAuditor(auditor event.Auditor) {
+	s.auditorLock.Lock()
+	defer s.auditorLock.Unlock()
+	s.auditor = auditor
+}
+
+// getAuditor returns the auditor of the server if auditing is enabled.
+func (s *Server) getAuditor() event.Auditor {
+	s.auditorLock.RLock()
+	defer s.auditorLock.RUnlock()
+	if s.auditor == nil || !s.auditor.Enabled() {
+		return nil
+	}
+	return s.auditor
+}
+
+// auditStreamingRPC sends the audit event of a streaming RPC request, which
+// isn't served through an auditCodec. The meta is added to the request meta of
+// the event. Requests whose event can't be written to an enforced sink must
+// fail.
+func (s *Server) auditStreamingRPC(conn io.ReadWriteCloser, method string, args any, meta map[string]string) error {
+	auditor := s.getAuditor()
+	if auditor == nil {
+		return nil
+	}
+
+	remoteAddr := ""
+	if nc, ok := conn.(net.Conn); ok && nc.RemoteAddr() != nil {
+		remoteAddr = nc.RemoteAddr().String()
+	}
+	c := &auditCodec{srv: s, method: method, remoteAddr: remoteAddr}
+	ev := c.newEvent(args)
+	for k, v := range meta {
+		ev.Request.RequestMeta[k] = v
+	}
+
+	if err := auditor.Event(context.Background(), event.RPCEvent, ev); err != nil {
+		s.logger.Error("failed to audit RPC request", "method", method, "error", err)
+		return errAuditFailed
+	}
+	return nil
+}
+
+// auditCodec wraps the codec of RPC requests to send their audit events to the
+// auditor of the server. net/rpc serves the requests of a codec one at a time,
+// so the codec only tracks the request being served.
+type auditCodec struct {
+	rpc.ServerCodec
+
+	srv        *Server
+	remoteAddr string
+
+	method string
+	ev     *event.AuditEvent
+	args   any
+}
+
+func newAuditCodec(srv *Server, codec rpc.ServerCodec, remoteAddr string) *auditCodec {
+	return &auditCodec{
+		ServerCodec: codec,
+		srv:         srv,
+		remoteAddr:  remoteAddr,
+	}
+}
+
+func (c *auditCodec) ReadRequestHeader(r *rpc.Request) error {
+	c.method, c.ev, c.args = "", nil, nil
+	if err := c.ServerCodec.ReadRequestHeader(r); err != nil {
+		return err
+	}
+	c.method = r.ServiceMethod
+	return nil
+}
+
+// ReadRequestBody sends the audit event of the request once its arguments are
+// decoded. Requests whose event can't be written to an enforced sink fail
+// without being handled.
+func (c *auditCodec) ReadRequestBody(args any) error {
+	if err := c.ServerCodec.ReadRequestBody(args); err != nil || args == nil {
+		return err
+	}
+
+	auditor := c.srv.getAuditor()
+	if auditor == nil {
+		return nil
+	}
+
+	c.args = args
+	c.ev = c.newEvent(args)
+	if err := auditor.Event(context.Background(), event.RPCEvent, c.ev); err != nil {
+		c.srv.logger.Error("failed to audit RPC request", "method", c.method, "error", err)
+		c.ev = nil
+		return errAuditFailed
+	}
+	return nil
+}
+
+// WriteResponse sends the audit event of the completed request before the
+// response is written.
+func (c *auditCodec) WriteResponse(r *rpc.Response, body any) error {
+	if c.ev != nil {
+		if auditor := c.srv.getAuditor(); auditor != nil {
+			ev := *c.ev
+			ev.Stage = event.StageOperationComplete
+			ev.Timestamp = time.Now().UTC()
+			ev.Response = &event.AuditResponse{Error: r.Error}
+
+			// the identity is only known once the endpoint authenticated the
+			// request
+			if req, ok := c.args.(structs.RequestWithIdentity); ok && req.GetIdentity() != nil {
+				ev.Auth = auditIdentity(req.GetIdentity())
+			}
+
+			if err := auditor.Event(context.Background(), event.RPCEvent, &ev); err != nil {
+				c.srv.logger.Error("failed to audit RPC request", "method", c.method, "error", err)
+				if r.Error == "" {
+					r.Error = errAuditFailed.Error()
+					body = struct{}{}
+				}
+			}
+		}
+		c.ev, c.args = nil, nil
+	}
+	return c.ServerCodec.WriteResponse(r, body)
+}
+
+func (c *auditCodec) newEvent(args any) *event.AuditEvent {
+	ev := &event.AuditEvent{
+		ID:        uuid.Generate(),
+		Stage:     event.StageOperationReceived,
+		Type:      event.RPCEvent,
+		Timestamp: time.Now().UTC(),
+		Version:   event.AuditEventVersion,
+		Request: &event.AuditRequest{
+			ID:       uuid.Generate(),
+			Endpoint: c.method,
+			RequestMeta: map[string]string{
+				"remote_address": c.remoteAddr,
+			},
+			NodeMeta: map[string]string{
+				"ip": c.srv.config.RPCAddr.String(),
+			},
+		},
+	}
+
+	if info, ok := args.(structs.RPCInfo); ok {
+		ev.Request.Region = info.RequestRegion()
+		ev.Request.Forwarded = info.IsForwarded()
+		if info.IsRead() {
+			ev.Request.Operation = "read"
+		} else {
+			ev.Request.Operation = "write"
+		}
+	}
+	if req, ok := args.(interface{ RequestNamespace() string }); ok && req.RequestNamespace() != "" {
+		ev.Request.Namespace = map[string]string{"id": req.RequestNamespace()}
+	}
+
+	if req, ok := args.(structs.RequestWithIdentity); ok {
+		if identity := req.GetIdentity(); identity != nil {
+			ev.Auth = auditIdentity(identity)
+		} else if secret := req.GetAuthToken(); secret != "" && c.srv.config.ACLEnabled {
+			if token, err := c.srv.ResolveSecretToken(secret); err == nil && token != nil {
+				ev.Auth = auditToken(token)
+			}
+		}
+	}
+	return ev
+}
+
+// auditIdentity returns the audit details of an authenticated identity.
+func auditIdentity(identity *structs.AuthenticatedIdentity) *event.AuditAuth {
+	auth := &event.AuditAuth{
+		ClientID: identity.ClientID,
+		TLSName:  identity.TLSName,
+	}
+	switch {
+	case identity.ACLToken != nil:
+		tokenAuth := auditToken(identity.ACLToken)
+		tokenAuth.ClientID, tokenAuth.TLSName = auth.ClientID, auth.TLSName
+		return tokenAuth
+	case identity.Claims != nil:
+		auth.Type = "workload-identity"
+		auth.Name = identity.Claims.Subject
+	case identity.ClientID != "":
+		auth.Type = "client"
+	}
+	return auth
+}
+
+// auditToken returns the audit details of an ACL token.
+func auditToken(token *structs.ACLToken) *event.AuditAuth {
+	return &event.AuditAuth{
+		AccessorID: token.AccessorID,
+		Name:       token.Name,
+		Type:       token.Type,
+		Policies:   token.Policies,
+		Global:     token.Global,
+		CreateTime: token.CreateTime,
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent/event"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+// testAuditor records the RPC audit events of a server for an endpoint and
+// fails them while fail is set.
+type testAuditor struct {
+	lock     sync.Mutex
+	endpoint string
+	events   []*event.AuditEvent
+	fail     bool
+}
+
+func (a *testAuditor) Event(_ context.Context, eventType string, payload interface{}) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.fail {
+		return errors.New("sink unavailable")
+	}
+	if ev := payload.(*event.AuditEvent); ev.Request.Endpoint == a.endpoint {
+		a.events = append(a.events, ev)
+	}
+	return nil
+}
+
+func (a *testAuditor) Enabled() bool           { return true }
+func (a *testAuditor) Reopen() error           { return nil }
+func (a *testAuditor) SetEnabled(enabled bool) {}
+func (a *testAuditor) DeliveryEnforced() bool  { return true }
+
+func (a *testAuditor) setFail(fail bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.fail = fail
+}
+
+func TestRPC_Audit(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	auditor := &testAuditor{endpoint: "Status.Ping"}
+	s1.SetAuditor(auditor)
+
+	codec := rpcClient(t, s1)
+	arg := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{Region: "global", Namespace: "default"},
+	}
+	var out struct{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Status.Ping", arg, &out))
+
+	auditor.lock.Lock()
+	must.Len(t, 2, auditor.events)
+	received, complete := auditor.events[0], auditor.events[1]
+	auditor.lock.Unlock()
+
+	must.Eq(t, event.RPCEvent, received.Type)
+	must.Eq(t, event.StageOperationReceived, received.Stage)
+	must.Eq(t, "read", received.Request.Operation)
+	must.Eq(t, "global", received.Request.Region)
+	must.Eq(t, map[string]string{"id": "default"}, received.Request.Namespace)
+	must.NotEq(t, "", received.Request.RequestMeta["remote_address"])
+	must.Eq(t, event.StageOperationComplete, complete.Stage)
+	must.Eq(t, received.ID, complete.ID)
+	must.Eq(t, "", complete.Response.Error)
+
+	// requests fail when their events can't be written, without closing the
+	// connection
+	auditor.setFail(true)
+	err := msgpackrpc.CallWithCodec(codec, "Status.Ping", arg, &out)
+	must.EqError(t, err, errAuditFailed.Error())
+	must.EqError(t, s1.RPC("Status.Ping", arg, &out), errAuditFailed.Error())
+
+	auditor.setFail(false)
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Status.Ping", arg, &out))
+	must.NoError(t, s1.RPC("Status.Ping", arg, &out))
+
+	auditor.lock.Lock()
+	defer auditor.lock.Unlock()
+	must.Len(t, 6, auditor.events)
+	must.Eq(t, "local", auditor.events[4].Request.RequestMeta["remote_address"])
+}
@@ -42,6 +42,7 @@ var (
 		structs.Variables,
 		structs.Namespaces,
 		structs.HostVolumes,
+		structs.Quotas,
 	}
 )
 
@@ -91,6 +92,8 @@ func (s *Search) getPrefixMatches(iter memdb.ResultIterator, prefix string) ([]s
 			id = t.ID
 		case *structs.Namespace:
 			id = t.Name
+		case *structs.QuotaSpec:
+			id = t.Name
 		case *structs.VariableEncrypted:
 			id = t.Path
 		default:
@@ -416,6 +419,8 @@ func getResourceIter(context structs.Context, aclObj *acl.ACL, namespace, prefix
 			return nil, err
 		}
 		return memdb.NewFilterIterator(iter, nsCapFilter(aclObj)), nil
+	case structs.Quotas:
+		return store.QuotaSpecsByNamePrefix(ws, prefix)
 	case structs.Variables:
 		iter, err := store.GetVariablesByPrefix(ws, prefix)
 		if err != nil {
@@ -946,6 +951,10 @@ func filteredSearchContexts(aclObj *acl.ACL, namespace string, context structs.C
 			if aclObj.AllowPluginList() {
 				available = append(available, c)
 			}
+		case structs.Quotas:
+			if aclObj.AllowQuotaRead() {
+				available = append(available, c)
+			}
 		default:
 			if ok := filteredSearchContextsEnt(aclObj, namespace, c); ok {
 				available = append(available, c)
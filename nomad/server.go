@@ -33,6 +33,7 @@ import (
 	"go.etcd.io/bbolt"
 
 	"github.com/hashicorp/nomad/command/agent/consul"
+	"github.com/hashicorp/nomad/command/agent/event"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/codec"
 	"github.com/hashicorp/nomad/helper/goruntime"
@@ -239,9 +240,18 @@ type Server struct {
 	// workload identities
 	encrypter *Encrypter
 
+	// allocSnapshots caches the snapshots of allocation data relayed between
+	// clients, so that it can be migrated from unreachable nodes
+	allocSnapshots *allocSnapshotCache
+
 	// periodicDispatcher is used to track and create evaluations for periodic jobs.
 	periodicDispatcher *PeriodicDispatch
 
+	// dispatchQueueLock serializes the evaluation of the dispatched jobs of
+	// parameterized jobs with a dispatch queue, so that no more of them run
+	// than their parameterized job allows.
+	dispatchQueueLock sync.Mutex
+
 	// planner is used to mange the submitted allocation plans that are waiting
 	// to be accessed by the leader
 	*planner
@@ -287,6 +297,10 @@ type Server struct {
 	leaderAcl     string
 	leaderAclLock sync.Mutex
 
+	// nodeMaintenanceLock serializes the updates of the node maintenance
+	// windows made by the leader and by the NodeMaintenance endpoint.
+	nodeMaintenanceLock sync.Mutex
+
 	// clusterIDLock ensures the server does not try to concurrently establish
 	// a cluster ID, racing against itself in calls of ClusterID
 	clusterIDLock sync.Mutex
@@ -308,6 +322,11 @@ type Server struct {
 	// MAY BE nil! Issuer must be explicitly configured by the end user.
 	oidcDisco *structs.OIDCDiscoveryConfig
 
+	// auditor receives the audit events of the RPC requests the server
+	// serves. It is set by the agent once the server is created.
+	auditor     event.Auditor
+	auditorLock sync.RWMutex
+
 	// EnterpriseState is used to fill in state for Pro/Ent builds
 	EnterpriseState
 
@@ -424,6 +443,16 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	}
 	s.encrypter = encrypter
 
+	// Set up the cache of alloc snapshots
+	allocSnapshotsPath := filepath.Join(s.config.DataDir, allocSnapshotCacheDir)
+	if s.config.DevMode && s.config.DataDir == "" {
+		allocSnapshotsPath, err = os.MkdirTemp("", "nomad-alloc-snapshots")
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create alloc snapshots tempdir")
+		}
+	}
+	s.allocSnapshots = newAllocSnapshotCache(s.logger, allocSnapshotsPath)
+
 	// Set up the OIDC discovery configuration required by third parties, such as
 	// AWS's IAM OIDC Provider, to authenticate workload identity JWTs.
 	if iss := config.OIDCIssuer; iss != "" {
@@ -1150,6 +1179,7 @@ func (s *Server) setupDeploymentWatcher() error {
 		NewJobEndpoints(s, nil),
 		s.config.DeploymentQueryRateLimit,
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration,
+		s.config.DeploymentVerifyAllowedHosts,
 	)
 
 	return nil
@@ -1299,11 +1329,14 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	_ = server.Register(NewEvalEndpoint(s, ctx))
 	_ = server.Register(NewJobEndpoints(s, ctx))
 	_ = server.Register(NewKeyringEndpoint(s, ctx, s.encrypter))
+	_ = server.Register(NewMeshEndpoint(s, ctx, s.encrypter))
 	_ = server.Register(NewNamespaceEndpoint(s, ctx))
 	_ = server.Register(NewNodeEndpoint(s, ctx))
+	_ = server.Register(NewNodeMaintenanceEndpoint(s, ctx))
 	_ = server.Register(NewNodePoolEndpoint(s, ctx))
 	_ = server.Register(NewPeriodicEndpoint(s, ctx))
 	_ = server.Register(NewPlanEndpoint(s, ctx))
+	_ = server.Register(NewQuotaEndpoint(s, ctx))
 	_ = server.Register(NewRegionEndpoint(s, ctx))
 	_ = server.Register(NewScalingEndpoint(s, ctx))
 	_ = server.Register(NewSearchEndpoint(s, ctx))
@@ -1335,15 +1368,16 @@ func (s *Server) setupRaft() error {
 
 	// Create the FSM
 	fsmConfig := &FSMConfig{
-		EvalBroker:         s.evalBroker,
-		Periodic:           s.periodicDispatcher,
-		Blocked:            s.blockedEvals,
-		Encrypter:          s.encrypter,
-		Logger:             s.logger,
-		Region:             s.Region(),
-		EnableEventBroker:  s.config.EnableEventBroker,
-		EventBufferSize:    s.config.EventBufferSize,
-		JobTrackedVersions: s.config.JobTrackedVersions,
+		EvalBroker:              s.evalBroker,
+		Periodic:                s.periodicDispatcher,
+		Blocked:                 s.blockedEvals,
+		Encrypter:               s.encrypter,
+		Logger:                  s.logger,
+		Region:                  s.Region(),
+		EnableEventBroker:       s.config.EnableEventBroker,
+		EventBufferSize:         s.config.EventBufferSize,
+		JobTrackedVersions:      s.config.JobTrackedVersions,
+		VariableTrackedVersions: s.config.VariableTrackedVersions,
 	}
 
 	var err error
@@ -1991,7 +2025,7 @@ func (s *Server) RPC(method string, args interface{}, reply interface{}) error {
 		Args:   args,
 		Reply:  reply,
 	}
-	if err := s.rpcServer.ServeRequest(codec); err != nil {
+	if err := s.rpcServer.ServeRequest(newAuditCodec(s, codec, "local")); err != nil {
 		return err
 	}
 	return codec.Err
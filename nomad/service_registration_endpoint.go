@@ -344,9 +344,15 @@ func (s *ServiceRegistration) GetService(
 	if err != nil {
 		return structs.ErrPermissionDenied
 	}
+	// Clients read services to answer the queries of their DNS server, but
+	// only in the namespaces of the allocations running on them.
+	var clientNodeID string
 	if !aclObj.AllowServiceRegistrationReadList(args.RequestNamespace(),
 		args.GetIdentity().Claims != nil) {
-		return structs.ErrPermissionDenied
+		if !aclObj.AllowClientOp() {
+			return structs.ErrPermissionDenied
+		}
+		clientNodeID = args.GetIdentity().ClientID
 	}
 
 	// Set up the blocking query.
@@ -355,6 +361,16 @@ func (s *ServiceRegistration) GetService(
 		queryMeta: &reply.QueryMeta,
 		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
 
+			if clientNodeID != "" {
+				ok, err := nodeRunsNamespace(ws, stateStore, clientNodeID, args.RequestNamespace())
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return structs.ErrPermissionDenied
+				}
+			}
+
 			// Perform the state query to get an iterator.
 			iter, err := stateStore.GetServiceRegistrationByName(ws, args.RequestNamespace(), args.ServiceName)
 			if err != nil {
@@ -396,6 +412,21 @@ func (s *ServiceRegistration) GetService(
 	})
 }
 
+// nodeRunsNamespace returns whether the node runs allocations of the
+// namespace.
+func nodeRunsNamespace(ws memdb.WatchSet, stateStore *state.StateStore, nodeID, namespace string) (bool, error) {
+	allocs, err := stateStore.AllocsByNode(ws, nodeID)
+	if err != nil {
+		return false, err
+	}
+	for _, alloc := range allocs {
+		if alloc.Namespace == namespace && !alloc.ClientTerminalStatus() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // choose uses rendezvous hashing to make a stable selection of a subset of services
 // to return.
 //
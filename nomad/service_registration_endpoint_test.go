@@ -1130,6 +1130,30 @@ func TestServiceRegistration_GetService(t *testing.T) {
 				err = msgpackrpc.CallWithCodec(
 					codec, structs.ServiceRegistrationListRPCMethod, serviceRegReq, &serviceRegResp)
 				must.EqError(t, err, "Permission denied")
+
+				// Clients can look up services by name for their DNS server,
+				// in the namespaces of the allocations running on them.
+				getServiceReq := &structs.ServiceRegistrationByNameRequest{
+					ServiceName: services[0].ServiceName,
+					QueryOptions: structs.QueryOptions{
+						Namespace: services[0].Namespace,
+						Region:    DefaultRegion,
+						AuthToken: node.SecretID,
+					},
+				}
+				var getServiceResp structs.ServiceRegistrationByNameResponse
+				err = msgpackrpc.CallWithCodec(
+					codec, structs.ServiceRegistrationGetServiceRPCMethod, getServiceReq, &getServiceResp)
+				must.EqError(t, err, "Permission denied")
+
+				alloc := mock.Alloc()
+				alloc.NodeID = node.ID
+				alloc.Namespace = services[0].Namespace
+				must.NoError(t, s.State().UpsertAllocs(structs.MsgTypeTestSetup, 40, []*structs.Allocation{alloc}))
+
+				must.NoError(t, msgpackrpc.CallWithCodec(
+					codec, structs.ServiceRegistrationGetServiceRPCMethod, getServiceReq, &getServiceResp))
+				must.Len(t, 1, getServiceResp.Services)
 			},
 			name: "ACLs enabled using node secret",
 		},
@@ -14,6 +14,8 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.UpsertNodeEventsType:                         structs.TypeNodeEvent,
 	structs.NodePoolUpsertRequestType:                    structs.TypeNodePoolUpserted,
 	structs.NodePoolDeleteRequestType:                    structs.TypeNodePoolDeleted,
+	structs.NodeMaintenanceWindowUpsertRequestType:       structs.TypeNodeMaintenanceWindowUpserted,
+	structs.NodeMaintenanceWindowDeleteRequestType:       structs.TypeNodeMaintenanceWindowDeleted,
 	structs.EvalUpdateRequestType:                        structs.TypeEvalUpdated,
 	structs.AllocClientUpdateRequestType:                 structs.TypeAllocationUpdated,
 	structs.JobRegisterRequestType:                       structs.TypeJobRegistered,
@@ -46,6 +48,9 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.CSIVolumeRegisterRequestType:                 structs.TypeCSIVolumeRegistered,
 	structs.CSIVolumeDeregisterRequestType:               structs.TypeCSIVolumeDeregistered,
 	structs.CSIVolumeClaimRequestType:                    structs.TypeCSIVolumeClaim,
+	structs.VarApplyStateRequestType:                     structs.TypeVariableUpserted,
+	structs.WrappedRootKeysUpsertRequestType:             structs.TypeRootKeyUpserted,
+	structs.WrappedRootKeysDeleteRequestType:             structs.TypeRootKeyDeleted,
 }
 
 func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
@@ -60,7 +65,11 @@ func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
 	var events []structs.Event
 	for _, change := range changes.Changes {
 		if event, ok := eventFromChange(change); ok {
-			event.Type = eventType
+			// Some request types both upsert and delete objects, in which
+			// case the event sets its own type.
+			if event.Type == "" {
+				event.Type = eventType
+			}
 			event.Index = changes.Index
 			events = append(events, event)
 		}
@@ -173,6 +182,17 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 					NodePool: before,
 				},
 			}, true
+		case TableNodeMaintenanceWindows:
+			before, ok := change.Before.(*structs.NodeMaintenanceWindow)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic:   structs.TopicNodeMaintenance,
+				Type:    structs.TypeNodeMaintenanceWindowDeleted,
+				Key:     before.ID,
+				Payload: &structs.NodeMaintenanceWindowEvent{Window: before},
+			}, true
 		case TableServiceRegistrations:
 			before, ok := change.Before.(*structs.ServiceRegistration)
 			if !ok {
@@ -241,6 +261,29 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 					Plugin: before,
 				},
 			}, true
+		case TableVariables:
+			before, ok := change.Before.(*structs.VariableEncrypted)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic:     structs.TopicVariable,
+				Type:      structs.TypeVariableDeleted,
+				Key:       before.Path,
+				Namespace: before.Namespace,
+				Payload:   structs.NewVariableEvent(&before.VariableMetadata),
+			}, true
+		case TableRootKeys:
+			before, ok := change.Before.(*structs.RootKey)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic:   structs.TopicRootKey,
+				Type:    structs.TypeRootKeyDeleted,
+				Key:     before.KeyID,
+				Payload: &structs.RootKeyEvent{RootKey: before.Meta()},
+			}, true
 		default:
 			return enterpriseEventFromChangeDeleted(change)
 		}
@@ -341,8 +384,16 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 		// remove job info to help keep size of alloc event down
 		alloc.Job = nil
 
+		// Evictions of preempted allocations have their own event type so
+		// they can be told apart from the other allocations of the plan.
+		var eventType string
+		if before, ok := change.Before.(*structs.Allocation); ok && isPreemption(before, after) {
+			eventType = structs.TypeAllocationPreempted
+		}
+
 		return structs.Event{
 			Topic:      structs.TopicAllocation,
+			Type:       eventType,
 			Key:        after.ID,
 			FilterKeys: filterKeys,
 			Namespace:  after.Namespace,
@@ -389,6 +440,19 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 				NodePool: after,
 			},
 		}, true
+	case TableNodeMaintenanceWindows:
+		after, ok := change.After.(*structs.NodeMaintenanceWindow)
+		if !ok {
+			return structs.Event{}, false
+		}
+		before, _ := change.Before.(*structs.NodeMaintenanceWindow)
+		eventType, payload := structs.NewNodeMaintenanceWindowEvent(before, after)
+		return structs.Event{
+			Topic:   structs.TopicNodeMaintenance,
+			Type:    eventType,
+			Key:     after.ID,
+			Payload: payload,
+		}, true
 	case "deployment":
 		after, ok := change.After.(*structs.Deployment)
 		if !ok {
@@ -472,7 +536,39 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 				Plugin: after,
 			},
 		}, true
+	case TableVariables:
+		after, ok := change.After.(*structs.VariableEncrypted)
+		if !ok {
+			return structs.Event{}, false
+		}
+		return structs.Event{
+			Topic:     structs.TopicVariable,
+			Key:       after.Path,
+			Namespace: after.Namespace,
+			Payload:   structs.NewVariableEvent(&after.VariableMetadata),
+		}, true
+	case TableRootKeys:
+		after, ok := change.After.(*structs.RootKey)
+		if !ok {
+			return structs.Event{}, false
+		}
+		before, _ := change.Before.(*structs.RootKey)
+		eventType, payload := structs.NewRootKeyEvent(before, after)
+		return structs.Event{
+			Topic:   structs.TopicRootKey,
+			Type:    eventType,
+			Key:     after.KeyID,
+			Payload: payload,
+		}, true
 	default:
 		return enterpriseEventFromChange(change)
 	}
 }
+
+// isPreemption returns whether the allocation update evicts an allocation
+// preempted by the scheduler.
+func isPreemption(before, after *structs.Allocation) bool {
+	return after.PreemptedByAllocation != "" &&
+		after.DesiredStatus == structs.AllocDesiredStatusEvict &&
+		before.DesiredStatus != structs.AllocDesiredStatusEvict
+}
@@ -540,6 +540,53 @@ func TestEventsFromChanges_ApplyPlanResultsRequestType(t *testing.T) {
 	must.Len(t, 1, deploys)
 }
 
+func TestEventsFromChanges_ApplyPlanResultsRequestType_Preemption(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	// setup
+	preempted := mock.Alloc()
+	must.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 9, nil, preempted.Job))
+	must.NoError(t, s.UpsertAllocs(structs.MsgTypeTestSetup, 10, []*structs.Allocation{preempted}))
+
+	alloc := mock.Alloc()
+	alloc.PreemptedAllocations = []string{preempted.ID}
+	job := alloc.Job
+	alloc.Job = nil
+	must.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 11, nil, job))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	must.NoError(t, s.UpsertEvals(structs.MsgTypeTestSetup, 12, []*structs.Evaluation{eval}))
+
+	req := &structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{
+			AllocsUpdated: []*structs.Allocation{alloc},
+			Job:           job,
+		},
+		AllocsPreempted: []*structs.AllocationDiff{{
+			ID:                    preempted.ID,
+			PreemptedByAllocation: alloc.ID,
+		}},
+		EvalID: eval.ID,
+	}
+	must.NoError(t, s.UpsertPlanResults(structs.ApplyPlanResultsRequestType, 100, req))
+
+	events := WaitForEvents(t, s, 100, 1, 1*time.Second)
+
+	types := map[string]string{}
+	for _, e := range events {
+		if e.Topic == structs.TopicAllocation {
+			types[e.Key] = e.Type
+		}
+	}
+	must.Eq(t, map[string]string{
+		alloc.ID:     structs.TypePlanResult,
+		preempted.ID: structs.TypeAllocationPreempted,
+	}, types)
+}
+
 func TestEventsFromChanges_BatchNodeUpdateDrainRequestType(t *testing.T) {
 	ci.Parallel(t)
 	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
@@ -1334,6 +1381,94 @@ func TestEvents_CSIPlugins(t *testing.T) {
 	must.Eq(t, "NodeRegistration", events[2].Type)
 }
 
+func TestEvents_Variables(t *testing.T) {
+	ci.Parallel(t)
+	store := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer store.StopEventBroker()
+
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+
+	sv := mock.VariableEncrypted()
+	index++
+	resp := store.VarSet(index, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+	must.NoError(t, resp.Error)
+
+	lock := sv.Copy()
+	lock.Lock = &structs.VariableLock{ID: uuid.Generate(), TTL: 15 * time.Second}
+	index++
+	resp = store.VarLockAcquire(index, &structs.VarApplyStateRequest{Op: structs.VarOpLockAcquire, Var: &lock})
+	must.NoError(t, resp.Error)
+
+	index++
+	resp = store.VarLockRelease(index, &structs.VarApplyStateRequest{Op: structs.VarOpLockRelease, Var: &lock})
+	must.NoError(t, resp.Error)
+
+	index++
+	resp = store.VarDelete(index, &structs.VarApplyStateRequest{Op: structs.VarOpDelete, Var: sv})
+	must.NoError(t, resp.Error)
+
+	events := WaitForEvents(t, store, 0, 4, 1*time.Second)
+	must.Len(t, 4, events)
+	must.Eq(t, "Variable", events[0].Topic)
+	must.Eq(t, "VariableUpserted", events[0].Type)
+	must.Eq(t, sv.Path, events[0].Key)
+	must.Eq(t, sv.Namespace, events[0].Namespace)
+	must.Eq(t, "VariableUpserted", events[1].Type)
+	must.Eq(t, "VariableUpserted", events[2].Type)
+	must.Eq(t, "VariableDeleted", events[3].Type)
+
+	// neither the encrypted items nor the lock ID are published
+	payload := events[1].Payload.(*structs.VariableEvent)
+	must.Eq(t, sv.Path, payload.Variable.Path)
+	must.Nil(t, payload.Variable.Lock)
+}
+
+func TestEvents_RootKeys(t *testing.T) {
+	ci.Parallel(t)
+	store := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer store.StopEventBroker()
+
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+
+	key0 := structs.NewRootKey(structs.NewRootKeyMeta()).MakeActive()
+	index++
+	must.NoError(t, store.UpsertRootKey(index, key0, false))
+
+	key1 := structs.NewRootKey(structs.NewRootKeyMeta()).MakePrepublished(time.Now().UnixNano())
+	index++
+	must.NoError(t, store.UpsertRootKey(index, key1, false))
+
+	// promoting the prepublished key deactivates the previous key
+	index++
+	must.NoError(t, store.UpsertRootKey(index, key1.MakeActive(), false))
+
+	index++
+	must.NoError(t, store.DeleteRootKey(index, key0.KeyID))
+
+	events := WaitForEvents(t, store, 0, 5, 1*time.Second)
+	must.Len(t, 5, events)
+	for _, event := range events {
+		must.Eq(t, "RootKey", event.Topic)
+	}
+	must.Eq(t, "RootKeyActivated", events[0].Type)
+	must.Eq(t, key0.KeyID, events[0].Key)
+	must.Eq(t, "RootKeyPrepublished", events[1].Type)
+	must.Eq(t, key1.KeyID, events[1].Key)
+
+	promotion := map[string]string{events[2].Key: events[2].Type, events[3].Key: events[3].Type}
+	must.Eq(t, map[string]string{
+		key0.KeyID: "RootKeyDeactivated",
+		key1.KeyID: "RootKeyActivated",
+	}, promotion)
+
+	must.Eq(t, "RootKeyDeleted", events[4].Type)
+	must.Eq(t, key0.KeyID, events[4].Key)
+	payload := events[4].Payload.(*structs.RootKeyEvent)
+	must.Eq(t, structs.RootKeyStateInactive, payload.RootKey.State)
+}
+
 func requireNodeRegistrationEventEqual(t *testing.T, want, got structs.Event) {
 	t.Helper()
 
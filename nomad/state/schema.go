@@ -17,9 +17,11 @@ const (
 
 	TableNamespaces               = "namespaces"
 	TableNodePools                = "node_pools"
+	TableNodeMaintenanceWindows   = "node_maintenance_windows"
 	TableServiceRegistrations     = "service_registrations"
 	TableVariables                = "variables"
 	TableVariablesQuotas          = "variables_quota"
+	TableVariablesHistory         = "variables_history"
 	TableRootKeys                 = "root_keys"
 	TableACLRoles                 = "acl_roles"
 	TableACLAuthMethods           = "acl_auth_methods"
@@ -30,6 +32,8 @@ const (
 	TableCSIVolumes               = "csi_volumes"
 	TableCSIPlugins               = "csi_plugins"
 	TableTaskGroupHostVolumeClaim = "task_volume"
+	TableQuotaSpec                = "quota_spec"
+	TableQuotaUsage               = "quota_usage"
 )
 
 const (
@@ -75,6 +79,7 @@ func init() {
 		indexTableSchema,
 		nodeTableSchema,
 		nodePoolTableSchema,
+		nodeMaintenanceWindowTableSchema,
 		jobTableSchema,
 		jobSummarySchema,
 		jobVersionSchema,
@@ -98,12 +103,15 @@ func init() {
 		serviceRegistrationsTableSchema,
 		variablesTableSchema,
 		variablesQuotasTableSchema,
+		variablesHistoryTableSchema,
 		wrappedRootKeySchema,
 		aclRolesTableSchema,
 		aclAuthMethodsTableSchema,
 		bindingRulesTableSchema,
 		hostVolumeTableSchema,
 		taskGroupHostVolumeClaimSchema,
+		quotaSpecTableSchema,
+		quotaUsageTableSchema,
 	}...)
 }
 
@@ -200,6 +208,24 @@ func nodePoolTableSchema() *memdb.TableSchema {
 	}
 }
 
+// nodeMaintenanceWindowTableSchema returns the MemDB schema for the node
+// maintenance windows table.
+func nodeMaintenanceWindowTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableNodeMaintenanceWindows,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.UUIDFieldIndex{
+					Field: "ID",
+				},
+			},
+		},
+	}
+}
+
 // jobTableSchema returns the MemDB schema for the jobs table.
 // This table is used to store all the jobs that have been submitted.
 func jobTableSchema() *memdb.TableSchema {
@@ -253,6 +279,14 @@ func jobTableSchema() *memdb.TableSchema {
 					Conditional: jobIsPeriodic,
 				},
 			},
+			"held": {
+				Name:         "held",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.ConditionalIndex{
+					Conditional: jobIsHeld,
+				},
+			},
 			"pool": {
 				Name:         "pool",
 				AllowMissing: false,
@@ -429,6 +463,17 @@ func jobIsPeriodic(obj interface{}) (bool, error) {
 	return false, nil
 }
 
+// jobIsHeld satisfies the ConditionalIndexFunc interface and creates an index
+// on whether the current version of a job waits to be evaluated.
+func jobIsHeld(obj interface{}) (bool, error) {
+	j, ok := obj.(*structs.Job)
+	if !ok {
+		return false, fmt.Errorf("Unexpected type: %v", obj)
+	}
+
+	return j.Held, nil
+}
+
 // deploymentSchema returns the MemDB schema tracking a job's deployments
 func deploymentSchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
@@ -1354,6 +1399,41 @@ func namespaceTableSchema() *memdb.TableSchema {
 	}
 }
 
+// quotaSpecTableSchema returns the MemDB schema for the quota specification
+// table.
+func quotaSpecTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableQuotaSpec,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// quotaUsageTableSchema returns the MemDB schema for the quota usage table.
+func quotaUsageTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableQuotaUsage,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
 // serviceRegistrationsTableSchema returns the MemDB schema for Nomad native
 // service registrations.
 func serviceRegistrationsTableSchema() *memdb.TableSchema {
@@ -1468,6 +1548,42 @@ func variablesTableSchema() *memdb.TableSchema {
 	}
 }
 
+// variablesHistoryTableSchema returns the MemDB schema for the historic
+// versions of Nomad variables.
+func variablesHistoryTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableVariablesHistory,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, Path,
+				// Version) is uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "Path",
+						},
+						&memdb.UintFieldIndex{
+							Field: "Version",
+						},
+					},
+				},
+			},
+			indexKeyID: {
+				Name:         indexKeyID,
+				AllowMissing: false,
+				Indexer:      &variableKeyIDFieldIndexer{},
+			},
+		},
+	}
+}
+
 type variableKeyIDFieldIndexer struct{}
 
 // FromArgs implements go-memdb/Indexer and is used to build an exact
@@ -91,12 +91,19 @@ type StateStoreConfig struct {
 
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
+
+	// VariableTrackedVersions is the number of historic versions that are kept
+	// for each variable. If zero, no history is kept.
+	VariableTrackedVersions int
 }
 
 func (c *StateStoreConfig) Validate() error {
 	if c.JobTrackedVersions <= 0 {
 		return fmt.Errorf("JobTrackedVersions must be positive; got: %d", c.JobTrackedVersions)
 	}
+	if c.VariableTrackedVersions < 0 {
+		return fmt.Errorf("VariableTrackedVersions must not be negative; got: %d", c.VariableTrackedVersions)
+	}
 	return nil
 }
 
@@ -2455,6 +2462,21 @@ func (s *StateStore) JobsByPeriodic(ws memdb.WatchSet, periodic bool) (memdb.Res
 	return iter, nil
 }
 
+// JobsByHeld returns an iterator over all the jobs whose current version is
+// held or not.
+func (s *StateStore) JobsByHeld(ws memdb.WatchSet, held bool) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("jobs", "held", held)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // JobsByScheduler returns an iterator over all the jobs with the specific
 // scheduler type.
 func (s *StateStore) JobsByScheduler(ws memdb.WatchSet, schedulerType string) (memdb.ResultIterator, error) {
@@ -2858,6 +2880,43 @@ func (s *StateStore) CSIVolumeClaim(index uint64, now int64, namespace, id strin
 	return txn.Commit()
 }
 
+// UpdateCSIVolumeManagedSnapshots replaces the snapshots created by the
+// snapshot schedule of a volume.
+func (s *StateStore) UpdateCSIVolumeManagedSnapshots(index uint64, namespace, id string, snapshots []*structs.CSISnapshot) error {
+	txn := s.db.WriteTxnMsgT(structs.CSIVolumeManagedSnapshotsRequestType, index)
+	defer txn.Abort()
+
+	row, err := txn.First(TableCSIVolumes, "id", namespace, id)
+	if err != nil {
+		return fmt.Errorf("volume lookup failed: %s: %v", id, err)
+	}
+	if row == nil {
+		return fmt.Errorf("volume not found: %s", id)
+	}
+
+	volume := row.(*structs.CSIVolume).Copy()
+	volume.ManagedSnapshots = snapshots
+	volume.ModifyIndex = index
+
+	// Allocations are copy on write, so we don't store them, as in
+	// UpsertCSIVolume
+	for allocID := range volume.ReadAllocs {
+		volume.ReadAllocs[allocID] = nil
+	}
+	for allocID := range volume.WriteAllocs {
+		volume.WriteAllocs[allocID] = nil
+	}
+
+	if err = txn.Insert(TableCSIVolumes, volume); err != nil {
+		return fmt.Errorf("volume update failed: %s: %v", id, err)
+	}
+	if err = txn.Insert("index", &IndexEntry{TableCSIVolumes, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 // CSIVolumeDeregister removes the volume from the server
 func (s *StateStore) CSIVolumeDeregister(index uint64, namespace string, ids []string, force bool) error {
 	txn := s.db.WriteTxnMsgT(structs.CSIVolumeDeregisterRequestType, index)
@@ -3476,7 +3535,35 @@ func (s *StateStore) nestedUpsertEval(txn *txn, index uint64, eval *structs.Eval
 	if err := txn.Insert("index", &IndexEntry{"evals", index}); err != nil {
 		return fmt.Errorf("index update failed: %v", err)
 	}
-	return nil
+
+	return s.releaseHeldJob(txn, index, eval)
+}
+
+// releaseHeldJob clears the held flag of the job of the evaluation once the
+// evaluation is for its current version.
+func (s *StateStore) releaseHeldJob(txn *txn, index uint64, eval *structs.Evaluation) error {
+	raw, err := txn.First("jobs", "id", eval.Namespace, eval.JobID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+	if raw == nil {
+		return nil
+	}
+	job := raw.(*structs.Job)
+	if !job.Held || eval.JobModifyIndex < job.JobModifyIndex {
+		return nil
+	}
+
+	updated := job.Copy()
+	updated.Held = false
+	updated.ModifyIndex = index
+	if err := txn.Insert("jobs", updated); err != nil {
+		return fmt.Errorf("job insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return s.upsertJobVersion(index, updated, txn)
 }
 
 // updateEvalModifyIndex is used to update the modify index of an evaluation that has been
@@ -4307,6 +4394,94 @@ func (s *StateStore) UpdateAllocsDesiredTransitions(msgType structs.MessageType,
 	return txn.Commit()
 }
 
+// UpdateAllocResources is used to update the allocated resources of the tasks
+// of an allocation resized in place.
+func (s *StateStore) UpdateAllocResources(msgType structs.MessageType, index uint64,
+	allocID string, tasks map[string]*structs.AllocatedTaskResources) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First("allocs", "id", allocID)
+	if err != nil {
+		return fmt.Errorf("alloc lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("alloc %q not found", allocID)
+	}
+	exist := existing.(*structs.Allocation)
+	if exist.AllocatedResources == nil {
+		return fmt.Errorf("alloc %q has no allocated resources", allocID)
+	}
+
+	copyAlloc := exist.Copy()
+	for name, resources := range tasks {
+		if _, ok := copyAlloc.AllocatedResources.Tasks[name]; !ok {
+			return fmt.Errorf("alloc %q has no task %q", allocID, name)
+		}
+		copyAlloc.AllocatedResources.Tasks[name] = resources.Copy()
+	}
+
+	// The node and quota are checked when the resize is applied rather than
+	// only when it's requested, so that placements applied in between can't
+	// be overcommitted by it
+	if err := allocResizeFitsTxn(txn, copyAlloc); err != nil {
+		return err
+	}
+	if err := s.enforceAllocQuota(txn, copyAlloc, exist); err != nil {
+		return err
+	}
+
+	// Update the modify indexes so the client picks up the new resources
+	copyAlloc.ModifyIndex = index
+	copyAlloc.AllocModifyIndex = index
+
+	if err := s.updateEntWithAlloc(index, copyAlloc, exist, txn); err != nil {
+		return err
+	}
+	if err := txn.Insert("allocs", copyAlloc); err != nil {
+		return fmt.Errorf("alloc insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"allocs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// allocResizeFitsTxn returns an error if the node of the resized allocation
+// doesn't have room for it along with its other non-terminal allocations.
+func allocResizeFitsTxn(txn ReadTxn, resized *structs.Allocation) error {
+	raw, err := txn.First("nodes", "id", resized.NodeID)
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	}
+	if raw == nil {
+		return fmt.Errorf("node %q of allocation not found", resized.NodeID)
+	}
+	node := raw.(*structs.Node)
+
+	nodeAllocs, err := allocsByNodeTxn(txn, nil, node.ID)
+	if err != nil {
+		return fmt.Errorf("alloc lookup failed: %v", err)
+	}
+	proposed := []*structs.Allocation{resized}
+	for _, alloc := range nodeAllocs {
+		if alloc.ID != resized.ID && !alloc.ClientTerminalStatus() {
+			proposed = append(proposed, alloc)
+		}
+	}
+
+	fit, dimension, _, err := structs.AllocsFit(node, proposed, nil, false)
+	if err != nil {
+		return err
+	}
+	if !fit {
+		return fmt.Errorf("node %q has insufficient resources to resize allocation: %s", node.ID, dimension)
+	}
+	return nil
+}
+
 // UpdateAllocDesiredTransitionTxn is used to nest an update of an
 // allocations desired transition
 func (s *StateStore) UpdateAllocDesiredTransitionTxn(
@@ -4852,6 +5027,28 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		groupIndex[g] = struct{}{}
 	}
 
+	// stepping is the set of groups whose canaries move to their next step
+	// instead of being promoted. The groups are only promoted once all the
+	// canaries reached their last step.
+	stepping := make(map[string]struct{})
+	var job *structs.Job
+	if req.Step {
+		job, err = s.jobByIDAndVersionImpl(ws, deployment.Namespace, deployment.JobID, deployment.JobVersion, txn)
+		if err != nil {
+			return err
+		} else if job == nil {
+			return fmt.Errorf("Job %q version %d of deployment %q does not exist", deployment.JobID, deployment.JobVersion, deployment.ID)
+		}
+		for tg, dstate := range deployment.TaskGroups {
+			if _, ok := groupIndex[tg]; !req.All && !ok {
+				continue
+			}
+			if dstate.HasNextCanaryStep() && !dstate.Promoted {
+				stepping[tg] = struct{}{}
+			}
+		}
+	}
+
 	// canaryIndex is the set of placed canaries in the deployment
 	canaryIndex := make(map[string]struct{}, len(deployment.TaskGroups))
 	for _, dstate := range deployment.TaskGroups {
@@ -4924,10 +5121,24 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 			continue
 		}
 
+		// move the canaries to their next step, or promote them once no
+		// group has steps left
+		_, step := stepping[tg]
+		if len(stepping) != 0 && !step {
+			continue
+		}
+
 		// reset the progress deadline
 		if status.ProgressDeadline > 0 && !status.RequireProgressBy.IsZero() {
 			status.RequireProgressBy = time.Now().Add(status.ProgressDeadline)
 		}
+		if step {
+			status.CanaryStep++
+			if group := job.LookupTaskGroup(tg); group != nil {
+				status.DesiredCanaries = group.Update.CanaryCount(group.Count, status.CanaryStep)
+			}
+			continue
+		}
 		status.Promoted = true
 	}
 
@@ -4951,6 +5162,11 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		}
 	}
 
+	// Canaries moving to their next step stay canaries
+	if len(stepping) != 0 {
+		promotable = nil
+	}
+
 	// For each promotable allocation remove the canary field
 	for _, alloc := range promotable {
 		promoted := alloc.Copy()
@@ -6963,6 +7179,11 @@ func (s *StateStore) DeleteNamespaces(index uint64, names []string) error {
 		if err := txn.Delete(TableNamespaces, existing); err != nil {
 			return fmt.Errorf("namespace deletion failed: %v", err)
 		}
+
+		// Release the usage of the namespace from its quota
+		if err := s.quotaReconcile(index, txn, "", ns.Quota); err != nil {
+			return err
+		}
 	}
 
 	if err := txn.Insert("index", &IndexEntry{TableNamespaces, index}); err != nil {
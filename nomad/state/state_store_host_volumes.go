@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	memdb "github.com/hashicorp/go-memdb"
@@ -156,11 +157,6 @@ func (s *StateStore) deleteHostVolumeTxn(txn *txn, index uint64, ns string, id s
 			}
 		}
 
-		err = s.subtractVolumeFromQuotaUsageTxn(txn, index, vol)
-		if err != nil {
-			return err
-		}
-
 		err = txn.Delete(TableHostVolumes, vol)
 		if err != nil {
 			return fmt.Errorf("host volume delete: %w", err)
@@ -345,3 +341,64 @@ func (s *StateStore) NodeHasHostVolume(nodeID, volName string) bool {
 	}
 	return false
 }
+
+// NodeHostVolumeCapacityFits returns an error if placing the host volume on
+// the node would exceed the capacity its plugin reports for the node, or the
+// host volume limits of the node. Any existing volume with the same ID is
+// replaced by vol, so that updates only account for their change in size.
+func (s *StateStore) NodeHostVolumeCapacityFits(node *structs.Node, vol *structs.HostVolume) error {
+	size := hostVolumeQuotaSize(vol)
+	if size == 0 {
+		return nil
+	}
+
+	var pluginCapacity int64
+	if raw, ok := node.Attributes["plugins.host_volume."+vol.PluginID+".capacity_bytes"]; ok {
+		pluginCapacity, _ = strconv.ParseInt(raw, 10, 64)
+	}
+	limits := node.HostVolumeLimits
+	if pluginCapacity <= 0 && limits == nil {
+		return nil
+	}
+
+	iter, err := s.HostVolumesByNodeID(nil, node.ID, SortDefault)
+	if err != nil {
+		return err
+	}
+	var pluginUsed, nodeUsed, namespaceUsed int64
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		other := raw.(*structs.HostVolume)
+		if other.ID == vol.ID && other.Namespace == vol.Namespace {
+			continue
+		}
+		otherSize := hostVolumeQuotaSize(other)
+		nodeUsed += otherSize
+		if other.PluginID == vol.PluginID {
+			pluginUsed += otherSize
+		}
+		if other.Namespace == vol.Namespace {
+			namespaceUsed += otherSize
+		}
+	}
+
+	if pluginCapacity > 0 && pluginUsed+size > pluginCapacity {
+		return fmt.Errorf("plugin %q capacity exhausted: volumes would use %d bytes of %d",
+			vol.PluginID, pluginUsed+size, pluginCapacity)
+	}
+	if limits == nil {
+		return nil
+	}
+	if limits.MaxBytes > 0 && nodeUsed+size > limits.MaxBytes {
+		return fmt.Errorf("node host volume limit exhausted: volumes would use %d bytes of %d",
+			nodeUsed+size, limits.MaxBytes)
+	}
+	if nsMax := limits.NamespaceMaxBytes[vol.Namespace]; nsMax > 0 && namespaceUsed+size > nsMax {
+		return fmt.Errorf("node host volume limit for namespace %q exhausted: volumes would use %d bytes of %d",
+			vol.Namespace, namespaceUsed+size, nsMax)
+	}
+	return nil
+}
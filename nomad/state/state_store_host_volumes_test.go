@@ -371,3 +371,48 @@ func TestStateStore_UpdateHostVolumesFromFingerprint(t *testing.T) {
 		must.Sprint("ready node should update unavailable volume"))
 	must.Eq(t, structs.HostVolumeStateReady, vol0.State)
 }
+
+func TestStateStore_NodeHostVolumeCapacityFits(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup,
+		1000, node, NodeUpsertWithNodePool))
+
+	// existing volume uses 150000 bytes
+	existing := mock.HostVolume()
+	existing.NodeID = node.ID
+	must.NoError(t, store.UpsertHostVolume(1001, existing))
+
+	newVol := func(ns, plugin string, size int64) *structs.HostVolume {
+		vol := mock.HostVolumeRequest(ns)
+		vol.PluginID = plugin
+		vol.RequestedCapacityMinBytes = size
+		return vol
+	}
+
+	// no capacity or limits
+	must.NoError(t, store.NodeHostVolumeCapacityFits(node, newVol("default", "mkdir", 1<<40)))
+
+	node.Attributes["plugins.host_volume.mkdir.capacity_bytes"] = "200000"
+	must.NoError(t, store.NodeHostVolumeCapacityFits(node, newVol("default", "mkdir", 50000)))
+	must.ErrorContains(t, store.NodeHostVolumeCapacityFits(node,
+		newVol("default", "mkdir", 50001)), `plugin "mkdir" capacity exhausted`)
+	must.NoError(t, store.NodeHostVolumeCapacityFits(node, newVol("default", "other", 50001)))
+
+	// updates only account for their change in size
+	update := existing.Copy()
+	update.RequestedCapacityMinBytes = 200000
+	must.NoError(t, store.NodeHostVolumeCapacityFits(node, update))
+
+	node.HostVolumeLimits = &structs.HostVolumeLimits{
+		MaxBytes:          250000,
+		NamespaceMaxBytes: map[string]int64{"prod": 60000},
+	}
+	must.NoError(t, store.NodeHostVolumeCapacityFits(node, newVol("default", "other", 100000)))
+	must.ErrorContains(t, store.NodeHostVolumeCapacityFits(node,
+		newVol("default", "other", 100001)), "node host volume limit exhausted")
+	must.ErrorContains(t, store.NodeHostVolumeCapacityFits(node,
+		newVol("prod", "other", 60001)), `node host volume limit for namespace "prod" exhausted`)
+}
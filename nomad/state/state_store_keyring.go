@@ -12,7 +12,7 @@ import (
 
 // UpsertRootKey saves a root key or updates it in place.
 func (s *StateStore) UpsertRootKey(index uint64, rootKey *structs.RootKey, rekey bool) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.WrappedRootKeysUpsertRequestType, index)
 	defer txn.Abort()
 
 	// get any existing key for updating
@@ -93,7 +93,7 @@ func (s *StateStore) UpsertRootKey(index uint64, rootKey *structs.RootKey, rekey
 // DeleteRootKey deletes a single wrapped root key set, or returns an
 // error if it doesn't exist.
 func (s *StateStore) DeleteRootKey(index uint64, keyID string) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.WrappedRootKeysDeleteRequestType, index)
 	defer txn.Abort()
 
 	// find the old key
@@ -168,7 +168,8 @@ func (s *StateStore) GetActiveRootKey(ws memdb.WatchSet) (*structs.RootKey, erro
 }
 
 // IsRootKeyInUse determines whether a key has been used to sign a workload
-// identity for a live allocation or encrypt any variables
+// identity for a live allocation or encrypt any variables or their historic
+// versions
 func (s *StateStore) IsRootKeyInUse(keyID string) (bool, error) {
 	txn := s.db.ReadTxn()
 
@@ -190,5 +191,16 @@ func (s *StateStore) IsRootKeyInUse(keyID string) (bool, error) {
 		return true, nil
 	}
 
+	// Historic versions of variables are not rekeyed, so their key remains in
+	// use until they are removed from the history.
+	iter, err = txn.Get(TableVariablesHistory, indexKeyID, keyID)
+	if err != nil {
+		return false, err
+	}
+	variable = iter.Next()
+	if variable != nil {
+		return true, nil
+	}
+
 	return false, nil
 }
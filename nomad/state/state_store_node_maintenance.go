@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// NodeMaintenanceWindows returns an iterator over all node maintenance
+// windows.
+func (s *StateStore) NodeMaintenanceWindows(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNodeMaintenanceWindows, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("node maintenance windows lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// NodeMaintenanceWindowsByIDPrefix returns an iterator over all node
+// maintenance windows whose ID matches the given prefix.
+func (s *StateStore) NodeMaintenanceWindowsByIDPrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNodeMaintenanceWindows, indexID+"_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("node maintenance windows prefix lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// NodeMaintenanceWindowByID returns the node maintenance window with the
+// given ID or nil if there is no match.
+func (s *StateStore) NodeMaintenanceWindowByID(ws memdb.WatchSet, id string) (*structs.NodeMaintenanceWindow, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableNodeMaintenanceWindows, indexID, id)
+	if err != nil {
+		return nil, fmt.Errorf("node maintenance window lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.NodeMaintenanceWindow), nil
+}
+
+// UpsertNodeMaintenanceWindows inserts or updates the given set of node
+// maintenance windows.
+func (s *StateStore) UpsertNodeMaintenanceWindows(msgType structs.MessageType, index uint64, windows []*structs.NodeMaintenanceWindow) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, window := range windows {
+		existing, err := txn.First(TableNodeMaintenanceWindows, indexID, window.ID)
+		if err != nil {
+			return fmt.Errorf("node maintenance window lookup failed: %w", err)
+		}
+
+		if existing != nil {
+			window.CreateIndex = existing.(*structs.NodeMaintenanceWindow).CreateIndex
+		} else {
+			window.CreateIndex = index
+		}
+		window.ModifyIndex = index
+
+		if err := txn.Insert(TableNodeMaintenanceWindows, window); err != nil {
+			return fmt.Errorf("node maintenance window insert failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodeMaintenanceWindows, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteNodeMaintenanceWindows removes the given set of node maintenance
+// windows.
+func (s *StateStore) DeleteNodeMaintenanceWindows(msgType structs.MessageType, index uint64, ids []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		existing, err := txn.First(TableNodeMaintenanceWindows, indexID, id)
+		if err != nil {
+			return fmt.Errorf("node maintenance window lookup failed: %w", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("node maintenance window %s not found", id)
+		}
+
+		if err := txn.Delete(TableNodeMaintenanceWindows, existing); err != nil {
+			return fmt.Errorf("node maintenance window deletion failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodeMaintenanceWindows, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
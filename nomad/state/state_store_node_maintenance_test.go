@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_NodeMaintenanceWindows(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	window1 := &structs.NodeMaintenanceWindow{
+		ID:       "aaaa" + uuid.Generate()[4:],
+		NodePool: "batch",
+		Start:    time.Now(),
+		Duration: time.Hour,
+	}
+	window2 := &structs.NodeMaintenanceWindow{
+		ID:         "bbbb" + uuid.Generate()[4:],
+		Datacenter: "dc1",
+		Recurrence: "0 2 * * SUN",
+		Duration:   time.Hour,
+	}
+	must.NoError(t, state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1000,
+		[]*structs.NodeMaintenanceWindow{window1, window2}))
+
+	ws := memdb.NewWatchSet()
+	got, err := state.NodeMaintenanceWindowByID(ws, window1.ID)
+	must.NoError(t, err)
+	must.Eq(t, window1, got)
+	must.Eq(t, 1000, got.CreateIndex)
+	must.Eq(t, 1000, got.ModifyIndex)
+
+	windowIDs := func(iter memdb.ResultIterator) []string {
+		var ids []string
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			ids = append(ids, raw.(*structs.NodeMaintenanceWindow).ID)
+		}
+		return ids
+	}
+
+	iter, err := state.NodeMaintenanceWindows(ws)
+	must.NoError(t, err)
+	must.Eq(t, []string{window1.ID, window2.ID}, windowIDs(iter))
+
+	iter, err = state.NodeMaintenanceWindowsByIDPrefix(ws, "bbbb")
+	must.NoError(t, err)
+	must.Eq(t, []string{window2.ID}, windowIDs(iter))
+
+	// Updates keep the create index and fire the watch set.
+	update := window1.Copy()
+	update.Status = structs.NodeMaintenanceWindowStatusActive
+	must.NoError(t, state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1001,
+		[]*structs.NodeMaintenanceWindow{update}))
+	must.True(t, watchFired(ws))
+
+	got, err = state.NodeMaintenanceWindowByID(nil, window1.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.NodeMaintenanceWindowStatusActive, got.Status)
+	must.Eq(t, 1000, got.CreateIndex)
+	must.Eq(t, 1001, got.ModifyIndex)
+
+	index, err := state.Index(TableNodeMaintenanceWindows)
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+
+	// Delete the windows.
+	must.NoError(t, state.DeleteNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1002,
+		[]string{window1.ID, window2.ID}))
+	got, err = state.NodeMaintenanceWindowByID(nil, window1.ID)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	err = state.DeleteNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1003, []string{window1.ID})
+	must.ErrorContains(t, err, "not found")
+}
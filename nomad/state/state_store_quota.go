@@ -0,0 +1,536 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertQuotaSpecs is used to register or update a set of quota
+// specifications. The usage of each specification is reconciled with the
+// allocations of the namespaces that account against it.
+func (s *StateStore) UpsertQuotaSpecs(index uint64, specs []*structs.QuotaSpec) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	for _, spec := range specs {
+		if err := s.upsertQuotaSpecImpl(index, txn, spec); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaSpec, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// upsertQuotaSpecImpl is used to upsert a quota specification
+func (s *StateStore) upsertQuotaSpecImpl(index uint64, txn *txn, spec *structs.QuotaSpec) error {
+	// Ensure the hashes are set. This should be done outside the state store
+	// for performance reasons, but we check here for defense in depth.
+	if len(spec.Hash) == 0 {
+		spec.SetHash()
+	}
+
+	existing, err := txn.First(TableQuotaSpec, indexID, spec.Name)
+	if err != nil {
+		return fmt.Errorf("quota specification lookup failed: %v", err)
+	}
+
+	if existing != nil {
+		spec.CreateIndex = existing.(*structs.QuotaSpec).CreateIndex
+	} else {
+		spec.CreateIndex = index
+	}
+	spec.ModifyIndex = index
+
+	if err := txn.Insert(TableQuotaSpec, spec); err != nil {
+		return fmt.Errorf("quota specification insert failed: %v", err)
+	}
+
+	// The limits of the specification may have changed, so its usage is
+	// recomputed rather than updated
+	return s.reconcileQuotaUsage(index, txn, spec)
+}
+
+// DeleteQuotaSpecs is used to delete a set of quota specifications and their
+// usages. Quota specifications that namespaces account against can't be
+// deleted.
+func (s *StateStore) DeleteQuotaSpecs(index uint64, names []string) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	for _, name := range names {
+		existing, err := txn.First(TableQuotaSpec, indexID, name)
+		if err != nil {
+			return fmt.Errorf("quota specification lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("quota specification %q not found", name)
+		}
+
+		iter, err := txn.Get(TableNamespaces, "quota", name)
+		if err != nil {
+			return fmt.Errorf("namespace lookup failed: %v", err)
+		}
+		if raw := iter.Next(); raw != nil {
+			return fmt.Errorf("quota specification %q is used by namespace %q",
+				name, raw.(*structs.Namespace).Name)
+		}
+
+		if err := txn.Delete(TableQuotaSpec, existing); err != nil {
+			return fmt.Errorf("quota specification deletion failed: %v", err)
+		}
+		if _, err := txn.DeleteAll(TableQuotaUsage, indexID, name); err != nil {
+			return fmt.Errorf("quota usage deletion failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaSpec, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaUsage, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// QuotaSpecByName is used to lookup a quota specification by name
+func (s *StateStore) QuotaSpecByName(ws memdb.WatchSet, name string) (*structs.QuotaSpec, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableQuotaSpec, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("quota specification lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.QuotaSpec), nil
+	}
+	return nil, nil
+}
+
+// QuotaSpecs returns an iterator over all the quota specifications
+func (s *StateStore) QuotaSpecs(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaSpec, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("quota specifications lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// QuotaSpecsByNamePrefix is used to lookup quota specifications by name prefix
+func (s *StateStore) QuotaSpecsByNamePrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaSpec, "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("quota specifications lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// QuotaUsageByName is used to lookup the usage of a quota specification by
+// name. The usage only includes the resources of allocations; use
+// QuotaUsageWithStorage for the usage of variables and host volumes.
+func (s *StateStore) QuotaUsageByName(ws memdb.WatchSet, name string) (*structs.QuotaUsage, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableQuotaUsage, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("quota usage lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.QuotaUsage), nil
+	}
+	return nil, nil
+}
+
+// QuotaUsages returns an iterator over all the quota usages
+func (s *StateStore) QuotaUsages(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaUsage, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("quota usages lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// QuotaUsagesByNamePrefix is used to lookup quota usages by name prefix
+func (s *StateStore) QuotaUsagesByNamePrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaUsage, "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("quota usages lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// QuotaUsageWithStorage returns a copy of the quota usage that includes the
+// storage used by the variables and host volumes of the namespaces that
+// account against the quota. Storage is tracked by the variables and host
+// volumes tables, so it is computed when the usage is read.
+func (s *StateStore) QuotaUsageWithStorage(ws memdb.WatchSet, usage *structs.QuotaUsage) (*structs.QuotaUsage, error) {
+	txn := s.db.ReadTxn()
+
+	variables, hostVolumes, err := s.quotaStorageUsage(ws, txn, usage.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	usage = usage.Copy()
+	for _, used := range usage.Used {
+		variablesMB := structs.BytesToQuotaMB(variables)
+		used.VariablesLimit = &variablesMB
+		if used.RegionLimit == nil {
+			used.RegionLimit = &structs.QuotaResources{}
+		}
+		used.RegionLimit.Storage = &structs.QuotaStorageResources{
+			VariablesMB:   variablesMB,
+			HostVolumesMB: structs.BytesToQuotaMB(hostVolumes),
+		}
+	}
+	return usage, nil
+}
+
+// quotaSpecExists returns whether the quota exists
+func (s *StateStore) quotaSpecExists(txn *txn, name string) (bool, error) {
+	existing, err := txn.First(TableQuotaSpec, indexID, name)
+	if err != nil {
+		return false, err
+	}
+	return existing != nil, nil
+}
+
+// quotaReconcile recomputes the usage of the quotas a namespace accounted
+// against before and after it was updated.
+func (s *StateStore) quotaReconcile(index uint64, txn *txn, newQuota, oldQuota string) error {
+	if newQuota == oldQuota {
+		return nil
+	}
+
+	for _, quota := range []string{newQuota, oldQuota} {
+		if quota == "" {
+			continue
+		}
+
+		raw, err := txn.First(TableQuotaSpec, indexID, quota)
+		if err != nil {
+			return fmt.Errorf("quota specification lookup failed: %v", err)
+		}
+		if raw == nil {
+			continue
+		}
+		if err := s.reconcileQuotaUsage(index, txn, raw.(*structs.QuotaSpec)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileQuotaUsage recomputes the usage of the quota specification from the
+// non-terminal allocations of the namespaces that account against it. Only the
+// limit of the local region is tracked.
+func (s *StateStore) reconcileQuotaUsage(index uint64, txn *txn, spec *structs.QuotaSpec) error {
+	usage := &structs.QuotaUsage{
+		Name:        spec.Name,
+		Used:        make(map[string]*structs.QuotaLimit),
+		CreateIndex: index,
+		ModifyIndex: index,
+	}
+
+	existing, err := txn.First(TableQuotaUsage, indexID, spec.Name)
+	if err != nil {
+		return fmt.Errorf("quota usage lookup failed: %v", err)
+	}
+	if existing != nil {
+		usage.CreateIndex = existing.(*structs.QuotaUsage).CreateIndex
+	}
+
+	if limit := spec.LimitForRegion(s.config.Region); limit != nil {
+		used := limit.NewUsage()
+
+		iter, err := txn.Get(TableNamespaces, "quota", spec.Name)
+		if err != nil {
+			return fmt.Errorf("namespace lookup failed: %v", err)
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			ns := raw.(*structs.Namespace)
+
+			allocs, err := s.allocsByNamespaceImpl(nil, txn, ns.Name)
+			if err != nil {
+				return fmt.Errorf("alloc lookup failed: %v", err)
+			}
+			for raw := allocs.Next(); raw != nil; raw = allocs.Next() {
+				if alloc := raw.(*structs.Allocation); !alloc.TerminalStatus() {
+					used.AddAllocation(alloc)
+				}
+			}
+		}
+
+		usage.Used[limit.HashKey()] = &structs.QuotaLimit{
+			Region:      limit.Region,
+			RegionLimit: used,
+			Hash:        slices.Clone(limit.Hash),
+		}
+	}
+
+	if err := txn.Insert(TableQuotaUsage, usage); err != nil {
+		return fmt.Errorf("quota usage insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaUsage, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// updateEntWithAlloc is used to update the usage of the quota of the namespace
+// of an allocation when it is added/modified/deleted
+func (s *StateStore) updateEntWithAlloc(index uint64, new, existing *structs.Allocation, txn *txn) error {
+	alloc := new
+	if alloc == nil {
+		alloc = existing
+	}
+	if alloc == nil {
+		return nil
+	}
+
+	quota, limit, err := s.namespaceQuotaLimit(txn, alloc.Namespace)
+	if err != nil || limit == nil {
+		return err
+	}
+
+	raw, err := txn.First(TableQuotaUsage, indexID, quota)
+	if err != nil {
+		return fmt.Errorf("quota usage lookup failed: %v", err)
+	}
+	if raw == nil {
+		return nil
+	}
+
+	before, after := limit.NewUsage(), limit.NewUsage()
+	if existing != nil && !existing.TerminalStatus() {
+		before.AddAllocation(existing)
+	}
+	if new != nil && !new.TerminalStatus() {
+		after.AddAllocation(new)
+	}
+	if before.Equal(after) {
+		return nil
+	}
+
+	usage := raw.(*structs.QuotaUsage).Copy()
+	used, ok := usage.Used[limit.HashKey()]
+	if !ok || used.RegionLimit == nil {
+		return nil
+	}
+	used.RegionLimit.Subtract(before)
+	used.RegionLimit.Add(after)
+	usage.ModifyIndex = index
+
+	if err := txn.Insert(TableQuotaUsage, usage); err != nil {
+		return fmt.Errorf("quota usage insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaUsage, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// enforceAllocQuota returns an error if replacing the existing allocation with
+// the updated one would take the quota of its namespace over its limit. Updates
+// that don't increase the usage of any exhausted dimension are allowed.
+func (s *StateStore) enforceAllocQuota(txn *txn, updated, existing *structs.Allocation) error {
+	quota, limit, err := s.namespaceQuotaLimit(txn, updated.Namespace)
+	if err != nil || limit == nil {
+		return err
+	}
+
+	raw, err := txn.First(TableQuotaUsage, indexID, quota)
+	if err != nil {
+		return fmt.Errorf("quota usage lookup failed: %v", err)
+	}
+	if raw == nil {
+		return nil
+	}
+	used, ok := raw.(*structs.QuotaUsage).Used[limit.HashKey()]
+	if !ok || used.RegionLimit == nil {
+		return nil
+	}
+
+	before, after := limit.NewUsage(), limit.NewUsage()
+	if !existing.TerminalStatus() {
+		before.AddAllocation(existing)
+	}
+	if !updated.TerminalStatus() {
+		after.AddAllocation(updated)
+	}
+	delta := after.Copy()
+	delta.Subtract(before)
+	proposed := used.RegionLimit.Copy()
+	proposed.Subtract(before)
+	proposed.Add(after)
+
+	if exhausted := limit.Exhausted(proposed, delta); len(exhausted) != 0 {
+		return fmt.Errorf("quota %q exhausted: %s", quota, strings.Join(exhausted, ", "))
+	}
+	return nil
+}
+
+// enforceVariablesQuota returns an error if changing the size of the variables
+// of the namespace by change bytes would exceed the variables limit of its
+// quota. Writes that shrink the variables of a namespace are always allowed.
+func (s *StateStore) enforceVariablesQuota(_ uint64, txn WriteTxn, namespace string, change int64) error {
+	if change < 0 {
+		return nil
+	}
+
+	quota, limit, err := s.namespaceQuotaLimit(txn, namespace)
+	if err != nil || limit == nil {
+		return err
+	}
+	limitMB := limit.VariablesLimitMB()
+	if limitMB == 0 {
+		return nil
+	}
+
+	used, _, err := s.quotaStorageUsage(nil, txn, quota)
+	if err != nil {
+		return err
+	}
+	if used+change > max(structs.QuotaMBToBytes(limitMB), 0) {
+		return fmt.Errorf("quota %q exhausted: variables would use %d bytes, exceeding the limit of %d MB",
+			quota, used+change, max(limitMB, 0))
+	}
+	return nil
+}
+
+// EnforceHostVolumeQuota returns an error if creating or updating the host
+// volume would exceed the host volumes limit of the quota of its namespace.
+func (s *StateStore) EnforceHostVolumeQuota(vol, existing *structs.HostVolume) error {
+	txn := s.db.ReadTxn()
+	defer txn.Abort()
+	return s.enforceHostVolumeQuotaTxn(txn, 0, vol, existing, true)
+}
+
+// enforceHostVolumeQuotaTxn returns an error if replacing the existing host
+// volume with vol would exceed the host volumes limit of the quota of its
+// namespace. Changes that shrink host volumes are always allowed.
+func (s *StateStore) enforceHostVolumeQuotaTxn(txn Txn, _ uint64, vol, existing *structs.HostVolume, enforce bool) error {
+	if !enforce {
+		return nil
+	}
+
+	change := hostVolumeQuotaSize(vol) - hostVolumeQuotaSize(existing)
+	if change <= 0 {
+		return nil
+	}
+
+	quota, limit, err := s.namespaceQuotaLimit(txn, vol.Namespace)
+	if err != nil || limit == nil {
+		return err
+	}
+	limitMB := limit.HostVolumesLimitMB()
+	if limitMB == 0 {
+		return nil
+	}
+
+	_, used, err := s.quotaStorageUsage(nil, txn, quota)
+	if err != nil {
+		return err
+	}
+	if used+change > max(structs.QuotaMBToBytes(limitMB), 0) {
+		return fmt.Errorf("quota %q exhausted: host volumes would use %d bytes, exceeding the limit of %d MB",
+			quota, used+change, max(limitMB, 0))
+	}
+	return nil
+}
+
+// hostVolumeQuotaSize returns the size a host volume accounts for in quotas:
+// its capacity once provisioned and its minimum requested capacity before.
+func hostVolumeQuotaSize(vol *structs.HostVolume) int64 {
+	if vol == nil {
+		return 0
+	}
+	return max(vol.CapacityBytes, vol.RequestedCapacityMinBytes)
+}
+
+// namespaceQuotaLimit returns the quota of the namespace and its limit for the
+// local region, if it has one.
+func (s *StateStore) namespaceQuotaLimit(txn ReadTxn, namespace string) (string, *structs.QuotaLimit, error) {
+	raw, err := txn.First(TableNamespaces, indexID, namespace)
+	if err != nil {
+		return "", nil, fmt.Errorf("namespace lookup failed: %v", err)
+	}
+	if raw == nil || raw.(*structs.Namespace).Quota == "" {
+		return "", nil, nil
+	}
+	quota := raw.(*structs.Namespace).Quota
+
+	raw, err = txn.First(TableQuotaSpec, indexID, quota)
+	if err != nil {
+		return "", nil, fmt.Errorf("quota specification lookup failed: %v", err)
+	}
+	if raw == nil {
+		return "", nil, nil
+	}
+	return quota, raw.(*structs.QuotaSpec).LimitForRegion(s.config.Region), nil
+}
+
+// quotaStorageUsage returns the bytes used by the variables and host volumes of
+// the namespaces that account against the quota.
+func (s *StateStore) quotaStorageUsage(ws memdb.WatchSet, txn ReadTxn, quota string) (int64, int64, error) {
+	iter, err := txn.Get(TableNamespaces, "quota", quota)
+	if err != nil {
+		return 0, 0, fmt.Errorf("namespace lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var variables, hostVolumes int64
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ns := raw.(*structs.Namespace)
+
+		watchCh, raw, err := txn.FirstWatch(TableVariablesQuotas, indexID, ns.Name)
+		if err != nil {
+			return 0, 0, fmt.Errorf("variable quota lookup failed: %v", err)
+		}
+		ws.Add(watchCh)
+		if raw != nil {
+			variables += raw.(*structs.VariablesQuota).Size
+		}
+
+		volumes, err := txn.Get(TableHostVolumes, "id_prefix", ns.Name, "")
+		if err != nil {
+			return 0, 0, fmt.Errorf("host volume lookup failed: %v", err)
+		}
+		ws.Add(volumes.WatchCh())
+		for raw := volumes.Next(); raw != nil; raw = volumes.Next() {
+			hostVolumes += hostVolumeQuotaSize(raw.(*structs.HostVolume))
+		}
+	}
+	return variables, hostVolumes, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_QuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+
+	qs1, qs2 := mock.QuotaSpec(), mock.QuotaSpec()
+	must.NoError(t, state.UpsertQuotaSpecs(100, []*structs.QuotaSpec{qs1, qs2}))
+
+	ws := memdb.NewWatchSet()
+	out, err := state.QuotaSpecByName(ws, qs1.Name)
+	must.NoError(t, err)
+	must.Eq(t, qs1, out)
+	must.Eq(t, 100, out.CreateIndex)
+
+	// every quota specification has a usage for the local region
+	usage, err := state.QuotaUsageByName(ws, qs1.Name)
+	must.NoError(t, err)
+	must.NotNil(t, usage)
+	must.MapContainsKey(t, usage.Used, qs1.Limits[0].HashKey())
+
+	iter, err := state.QuotaSpecsByNamePrefix(nil, "quota-")
+	must.NoError(t, err)
+	var names []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		names = append(names, raw.(*structs.QuotaSpec).Name)
+	}
+	must.SliceContainsAll(t, []string{qs1.Name, qs2.Name}, names)
+
+	// updates keep the create index
+	update := qs1.Copy()
+	update.Description = "updated"
+	update.SetHash()
+	must.NoError(t, state.UpsertQuotaSpecs(200, []*structs.QuotaSpec{update}))
+	must.True(t, watchFired(ws))
+
+	out, err = state.QuotaSpecByName(nil, qs1.Name)
+	must.NoError(t, err)
+	must.Eq(t, 100, out.CreateIndex)
+	must.Eq(t, 200, out.ModifyIndex)
+
+	// quota specifications used by namespaces can't be deleted
+	ns := mock.Namespace()
+	ns.Quota = qs1.Name
+	must.NoError(t, state.UpsertNamespaces(300, []*structs.Namespace{ns}))
+	must.ErrorContains(t, state.DeleteQuotaSpecs(400, []string{qs1.Name}), "is used by namespace")
+	must.ErrorContains(t, state.DeleteQuotaSpecs(400, []string{"missing"}), "not found")
+
+	must.NoError(t, state.DeleteQuotaSpecs(400, []string{qs2.Name}))
+	out, err = state.QuotaSpecByName(nil, qs2.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+	usage, err = state.QuotaUsageByName(nil, qs2.Name)
+	must.NoError(t, err)
+	must.Nil(t, usage)
+
+	index, err := state.Index(TableQuotaSpec)
+	must.NoError(t, err)
+	must.Eq(t, 400, index)
+}
+
+func TestStateStore_QuotaUsage_Allocs(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+
+	qs := mock.QuotaSpec()
+	must.NoError(t, state.UpsertQuotaSpecs(100, []*structs.QuotaSpec{qs}))
+	key := qs.Limits[0].HashKey()
+
+	// allocations placed before the namespace accounts against the quota are
+	// counted once it does
+	ns := mock.Namespace()
+	must.NoError(t, state.UpsertNamespaces(200, []*structs.Namespace{ns}))
+
+	alloc1, alloc2 := mock.Alloc(), mock.Alloc()
+	alloc1.Namespace = ns.Name
+	alloc2.Namespace = ns.Name
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 300, []*structs.Allocation{alloc1}))
+
+	ns = ns.Copy()
+	ns.Quota = qs.Name
+	must.NoError(t, state.UpsertNamespaces(400, []*structs.Namespace{ns}))
+
+	usage, err := state.QuotaUsageByName(nil, qs.Name)
+	must.NoError(t, err)
+	must.Eq(t, 500, usage.Used[key].RegionLimit.CPU)
+	must.Eq(t, 256, usage.Used[key].RegionLimit.MemoryMB)
+	must.Eq(t, 150, usage.Used[key].RegionLimit.DiskMB)
+
+	// new allocations are added to the usage
+	ws := memdb.NewWatchSet()
+	_, err = state.QuotaUsageByName(ws, qs.Name)
+	must.NoError(t, err)
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 500, []*structs.Allocation{alloc2}))
+	must.True(t, watchFired(ws))
+
+	usage, err = state.QuotaUsageByName(nil, qs.Name)
+	must.NoError(t, err)
+	must.Eq(t, 1000, usage.Used[key].RegionLimit.CPU)
+	must.Eq(t, 500, usage.ModifyIndex)
+
+	// terminal allocations are removed from the usage
+	stopped := alloc1.Copy()
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 600, []*structs.Allocation{stopped}))
+
+	usage, err = state.QuotaUsageByName(nil, qs.Name)
+	must.NoError(t, err)
+	must.Eq(t, 500, usage.Used[key].RegionLimit.CPU)
+
+	// the usage is released when the namespace stops accounting against the
+	// quota
+	ns = ns.Copy()
+	ns.Quota = ""
+	must.NoError(t, state.UpsertNamespaces(700, []*structs.Namespace{ns}))
+
+	usage, err = state.QuotaUsageByName(nil, qs.Name)
+	must.NoError(t, err)
+	must.Eq(t, 0, usage.Used[key].RegionLimit.CPU)
+}
+
+func TestStateStore_QuotaUsage_Storage(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+
+	qs := mock.QuotaSpec()
+	qs.Limits[0].RegionLimit.Storage = &structs.QuotaStorageResources{
+		VariablesMB:   1,
+		HostVolumesMB: 1,
+	}
+	qs.SetHash()
+	must.NoError(t, state.UpsertQuotaSpecs(100, []*structs.QuotaSpec{qs}))
+
+	ns := mock.Namespace()
+	ns.Quota = qs.Name
+	must.NoError(t, state.UpsertNamespaces(200, []*structs.Namespace{ns}))
+
+	// variables are limited to their quota
+	sv := mock.VariableEncrypted()
+	sv.Namespace = ns.Name
+	sv.Data = make([]byte, 1<<19)
+	resp := state.VarSet(300, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+	must.NoError(t, resp.Error)
+
+	big := mock.VariableEncrypted()
+	big.Namespace = ns.Name
+	big.Path = "/example/big"
+	big.Data = make([]byte, 1<<19+1)
+	resp = state.VarSet(400, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: big})
+	must.ErrorContains(t, resp.Error, "exhausted")
+
+	// host volumes are limited to their quota
+	vol := mock.HostVolume()
+	vol.Namespace = ns.Name
+	vol.CapacityBytes = 1 << 20
+	must.NoError(t, state.EnforceHostVolumeQuota(vol, nil))
+
+	vol.CapacityBytes = 1<<20 + 1
+	must.ErrorContains(t, state.EnforceHostVolumeQuota(vol, nil), "exhausted")
+
+	usage, err := state.QuotaUsageByName(nil, qs.Name)
+	must.NoError(t, err)
+	usage, err = state.QuotaUsageWithStorage(nil, usage)
+	must.NoError(t, err)
+
+	used := usage.Used[qs.Limits[0].HashKey()]
+	must.Eq(t, &structs.QuotaStorageResources{VariablesMB: 1}, used.RegionLimit.Storage)
+	must.Eq(t, 1, *used.VariablesLimit)
+}
@@ -42,6 +42,14 @@ func (r *StateRestore) NodePoolRestore(pool *structs.NodePool) error {
 	return nil
 }
 
+// NodeMaintenanceWindowRestore is used to restore a node maintenance window
+func (r *StateRestore) NodeMaintenanceWindowRestore(window *structs.NodeMaintenanceWindow) error {
+	if err := r.txn.Insert(TableNodeMaintenanceWindows, window); err != nil {
+		return fmt.Errorf("node maintenance window insert failed: %v", err)
+	}
+	return nil
+}
+
 // JobRestore is used to restore a job
 func (r *StateRestore) JobRestore(job *structs.Job) error {
 
@@ -197,6 +205,22 @@ func (r *StateRestore) NamespaceRestore(ns *structs.Namespace) error {
 	return nil
 }
 
+// QuotaSpecRestore is used to restore a quota specification
+func (r *StateRestore) QuotaSpecRestore(spec *structs.QuotaSpec) error {
+	if err := r.txn.Insert(TableQuotaSpec, spec); err != nil {
+		return fmt.Errorf("quota specification insert failed: %v", err)
+	}
+	return nil
+}
+
+// QuotaUsageRestore is used to restore a quota usage
+func (r *StateRestore) QuotaUsageRestore(usage *structs.QuotaUsage) error {
+	if err := r.txn.Insert(TableQuotaUsage, usage); err != nil {
+		return fmt.Errorf("quota usage insert failed: %v", err)
+	}
+	return nil
+}
+
 // ServiceRegistrationRestore is used to restore a single service registration
 // into the service_registrations table.
 func (r *StateRestore) ServiceRegistrationRestore(service *structs.ServiceRegistration) error {
@@ -215,6 +239,15 @@ func (r *StateRestore) VariablesRestore(variable *structs.VariableEncrypted) err
 	return nil
 }
 
+// VariablesHistoryRestore is used to restore a single historic version of a
+// variable into the variables_history table.
+func (r *StateRestore) VariablesHistoryRestore(variable *structs.VariableEncrypted) error {
+	if err := r.txn.Insert(TableVariablesHistory, variable); err != nil {
+		return fmt.Errorf("variable version insert failed: %v", err)
+	}
+	return nil
+}
+
 // VariablesQuotaRestore is used to restore a single variable quota into the
 // variables_quota table.
 func (r *StateRestore) VariablesQuotaRestore(quota *structs.VariablesQuota) error {
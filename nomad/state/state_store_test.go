@@ -3703,6 +3703,41 @@ func TestStateStore_CSIVolume(t *testing.T) {
 	must.Eq(t, 1, len(vs))
 }
 
+func TestStateStore_UpdateCSIVolumeManagedSnapshots(t *testing.T) {
+	ci.Parallel(t)
+
+	store := testStateStore(t)
+	index := uint64(1000)
+
+	vol := structs.NewCSIVolume("foo", index)
+	vol.ID = uuid.Generate()
+	vol.Namespace = structs.DefaultNamespace
+	vol.PluginID = "minnie"
+	vol.SnapshotSchedule = &structs.CSIVolumeSnapshotSchedule{Cron: "0 3 * * *", Retain: 2}
+	must.NoError(t, store.UpsertCSIVolume(index, []*structs.CSIVolume{vol}))
+
+	index++
+	err := store.UpdateCSIVolumeManagedSnapshots(index, vol.Namespace, "missing", nil)
+	must.EqError(t, err, "volume not found: missing")
+
+	index++
+	snapshots := []*structs.CSISnapshot{{ID: "snap-1", SourceVolumeID: vol.ID, PluginID: "minnie"}}
+	must.NoError(t, store.UpdateCSIVolumeManagedSnapshots(index, vol.Namespace, vol.ID, snapshots))
+
+	got, err := store.CSIVolumeByID(nil, vol.Namespace, vol.ID)
+	must.NoError(t, err)
+	must.Eq(t, snapshots, got.ManagedSnapshots)
+	must.Eq(t, index, got.ModifyIndex)
+	must.NotNil(t, got.SnapshotSchedule)
+
+	// Registering the volume again keeps its managed snapshots
+	index++
+	must.NoError(t, store.UpsertCSIVolume(index, []*structs.CSIVolume{got.Copy()}))
+	got, err = store.CSIVolumeByID(nil, vol.Namespace, vol.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, got.ManagedSnapshots)
+}
+
 func TestStateStore_CSIPlugin_Lifecycle(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6119,6 +6154,86 @@ func TestStateStore_UpdateAllocDesiredTransition(t *testing.T) {
 	must.Nil(t, state.UpdateAllocsDesiredTransitions(structs.MsgTypeTestSetup, 1003, m, evals))
 }
 
+func TestStateStore_UpdateAllocResources(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 998, node))
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	resized := alloc.AllocatedResources.Tasks["web"].Copy()
+	resized.Cpu.CpuShares = 1000
+	resized.Memory.MemoryMB = 512
+	tasks := map[string]*structs.AllocatedTaskResources{"web": resized}
+	must.NoError(t, state.UpdateAllocResources(structs.MsgTypeTestSetup, 1001, alloc.ID, tasks))
+
+	out, err := state.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, resized, out.AllocatedResources.Tasks["web"])
+	must.Eq(t, 1001, out.ModifyIndex)
+	must.Eq(t, 1001, out.AllocModifyIndex)
+
+	index, err := state.Index("allocs")
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+
+	// Try with a bogus task and a bogus alloc id
+	tasks = map[string]*structs.AllocatedTaskResources{"db": resized}
+	must.ErrorContains(t, state.UpdateAllocResources(structs.MsgTypeTestSetup, 1002, alloc.ID, tasks), "no task")
+	must.ErrorContains(t, state.UpdateAllocResources(structs.MsgTypeTestSetup, 1002, uuid.Generate(), tasks), "not found")
+
+	// The node must have room for the resized allocation
+	tooBig := resized.Copy()
+	tooBig.Memory.MemoryMB = node.NodeResources.Memory.MemoryMB + 1
+	tasks = map[string]*structs.AllocatedTaskResources{"web": tooBig}
+	must.ErrorContains(t, state.UpdateAllocResources(structs.MsgTypeTestSetup, 1002, alloc.ID, tasks), "insufficient resources")
+}
+
+func TestStateStore_UpdateAllocResources_Quota(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	qs := mock.QuotaSpec()
+	must.NoError(t, state.UpsertQuotaSpecs(100, []*structs.QuotaSpec{qs}))
+	key := qs.Limits[0].HashKey()
+	ns := mock.Namespace()
+	ns.Quota = qs.Name
+	must.NoError(t, state.UpsertNamespaces(200, []*structs.Namespace{ns}))
+
+	node := mock.Node()
+	alloc := mock.Alloc()
+	alloc.Namespace = ns.Name
+	alloc.Job.Namespace = ns.Name
+	alloc.NodeID = node.ID
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 300, node))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 400, []*structs.Allocation{alloc}))
+
+	// resizes are accounted against the quota
+	resized := alloc.AllocatedResources.Tasks["web"].Copy()
+	resized.Cpu.CpuShares = 1000
+	tasks := map[string]*structs.AllocatedTaskResources{"web": resized}
+	must.NoError(t, state.UpdateAllocResources(structs.MsgTypeTestSetup, 500, alloc.ID, tasks))
+
+	usage, err := state.QuotaUsageByName(nil, qs.Name)
+	must.NoError(t, err)
+	must.Eq(t, 1000, usage.Used[key].RegionLimit.CPU)
+
+	// resizes beyond the quota are rejected
+	resized = resized.Copy()
+	resized.Cpu.CpuShares = 2001
+	tasks = map[string]*structs.AllocatedTaskResources{"web": resized}
+	must.ErrorContains(t, state.UpdateAllocResources(structs.MsgTypeTestSetup, 600, alloc.ID, tasks), "exhausted")
+
+	usage, err = state.QuotaUsageByName(nil, qs.Name)
+	must.NoError(t, err)
+	must.Eq(t, 1000, usage.Used[key].RegionLimit.CPU)
+}
+
 func TestStateStore_JobSummary(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7849,6 +7964,96 @@ func TestStateStore_UpsertDeploymentPromotion_Subset(t *testing.T) {
 	must.True(t, aout3.DeploymentStatus.Canary)
 }
 
+// Test promoting canaries through their steps
+func TestStateStore_UpsertDeploymentPromotion_Step(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	// Create a job with canary steps
+	j := mock.Job()
+	j.TaskGroups[0].Count = 10
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.CanarySteps = []int{10, 50}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1, nil, j))
+
+	// Create a deployment at the first step
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.JobVersion = j.Version
+	d.TaskGroups = map[string]*structs.DeploymentState{
+		"web": {
+			DesiredTotal:    10,
+			DesiredCanaries: 1,
+			CanarySteps:     []int{10, 50},
+		},
+	}
+	must.NoError(t, store.UpsertDeployment(2, d))
+
+	c1 := mock.Alloc()
+	c1.JobID = j.ID
+	c1.DeploymentID = d.ID
+	d.TaskGroups[c1.TaskGroup].PlacedCanaries = append(d.TaskGroups[c1.TaskGroup].PlacedCanaries, c1.ID)
+	c1.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: pointer.Of(true),
+		Canary:  true,
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 3, []*structs.Allocation{c1}))
+
+	// Move the canaries to the next step
+	req := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+			Step:         true,
+		},
+		Eval: mock.Eval(),
+	}
+	must.NoError(t, store.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 4, req))
+
+	dout, err := store.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	dout = dout.Copy()
+	must.False(t, dout.TaskGroups["web"].Promoted)
+	must.Eq(t, 1, dout.TaskGroups["web"].CanaryStep)
+	must.Eq(t, 5, dout.TaskGroups["web"].DesiredCanaries)
+
+	aout, err := store.AllocByID(nil, c1.ID)
+	must.NoError(t, err)
+	must.True(t, aout.DeploymentStatus.Canary)
+
+	// The canaries of the step must be healthy before being promoted
+	req.Eval = mock.Eval()
+	err = store.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 5, req)
+	must.ErrorContains(t, err, `Task group "web" has 1/5 healthy allocations`)
+
+	var canaries []*structs.Allocation
+	for i := 0; i < 4; i++ {
+		c := mock.Alloc()
+		c.JobID = j.ID
+		c.DeploymentID = d.ID
+		c.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: pointer.Of(true),
+			Canary:  true,
+		}
+		canaries = append(canaries, c)
+		dout.TaskGroups[c.TaskGroup].PlacedCanaries = append(dout.TaskGroups[c.TaskGroup].PlacedCanaries, c.ID)
+	}
+	must.NoError(t, store.UpsertDeployment(6, dout))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 7, canaries))
+
+	// The canaries are promoted once they reached their last step
+	must.NoError(t, store.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 8, req))
+
+	dout, err = store.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.True(t, dout.TaskGroups["web"].Promoted)
+	must.Eq(t, 1, dout.TaskGroups["web"].CanaryStep)
+
+	aout, err = store.AllocByID(nil, c1.ID)
+	must.NoError(t, err)
+	must.False(t, aout.DeploymentStatus.Canary)
+}
+
 // Test that allocation health can't be set against a nonexistent deployment
 func TestStateStore_UpsertDeploymentAllocHealth_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -122,7 +123,7 @@ func (s *StateStore) GetVariable(
 
 // VarSet is used to store a variable object.
 func (s *StateStore) VarSet(idx uint64, sv *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Perform the actual set.
@@ -141,7 +142,7 @@ func (s *StateStore) VarSet(idx uint64, sv *structs.VarApplyStateRequest) *struc
 // variable. The ModifyIndex in the provided entry is used to determine if
 // we should write the entry to the state store or not.
 func (s *StateStore) VarSetCAS(idx uint64, sv *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	resp := s.varSetCASTxn(tx, idx, sv)
@@ -228,6 +229,7 @@ func (s *StateStore) varSetTxn(tx WriteTxn, idx uint64, req *structs.VarApplySta
 
 		sv.CreateIndex = existing.CreateIndex
 		sv.CreateTime = existing.CreateTime
+		sv.Version = existing.Version
 
 		if existing.Equal(*sv) {
 			// Skip further writing in the state store if the entry is not actually
@@ -239,9 +241,22 @@ func (s *StateStore) varSetTxn(tx WriteTxn, idx uint64, req *structs.VarApplySta
 		}
 		sv.ModifyIndex = idx
 		quotaChange = int64(len(sv.Data) - len(existing.Data))
+
+		// Writes that change the items of the variable create a new version,
+		// and the replaced version is kept in the history.
+		// The versions in the history count against the quota as well.
+		if req.UnchangedIndex == 0 || req.UnchangedIndex != existing.ModifyIndex {
+			sv.Version++
+			historyChange, err := s.varHistoryInsertTxn(tx, idx, existing)
+			if err != nil {
+				return req.ErrorResponse(idx, err)
+			}
+			quotaChange += historyChange
+		}
 	} else {
 		sv.CreateIndex = idx
 		sv.ModifyIndex = idx
+		sv.Version = 0
 		quotaChange = int64(len(sv.Data))
 	}
 
@@ -300,7 +315,7 @@ func (s *StateStore) varSetTxn(tx WriteTxn, idx uint64, req *structs.VarApplySta
 // VarDelete is used to delete a single variable in the
 // the state store.
 func (s *StateStore) VarDelete(idx uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Perform the actual delete
@@ -322,7 +337,7 @@ func (s *StateStore) VarDelete(idx uint64, req *structs.VarApplyStateRequest) *s
 // last observed index for the given variable, then the call is a noop,
 // otherwise a normal delete is invoked.
 func (s *StateStore) VarDeleteCAS(idx uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	resp := s.svDeleteCASTxn(tx, idx, req)
@@ -413,6 +428,13 @@ func (s *StateStore) svDeleteTxn(tx WriteTxn, idx uint64, req *structs.VarApplyS
 		return req.ConflictResponse(idx, zeroVal)
 	}
 
+	// The history of a deleted variable is not kept, so a new variable at the
+	// same path starts again from the first version.
+	historySize, err := s.varHistoryDeleteTxn(tx, idx, sv.Namespace, sv.Path)
+	if err != nil {
+		return req.ErrorResponse(idx, err)
+	}
+
 	existingQuota, err := tx.First(TableVariablesQuotas, indexID, req.Var.Namespace)
 	if err != nil {
 		return req.ErrorResponse(idx, fmt.Errorf("variable quota lookup failed: %v", err))
@@ -422,7 +444,7 @@ func (s *StateStore) svDeleteTxn(tx WriteTxn, idx uint64, req *structs.VarApplyS
 	if existingQuota != nil {
 		quotaUsed := existingQuota.(*structs.VariablesQuota)
 		quotaUsed = quotaUsed.Copy()
-		quotaUsed.Size -= min(quotaUsed.Size, int64(len(sv.Data)))
+		quotaUsed.Size -= min(quotaUsed.Size, int64(len(sv.Data))+historySize)
 		quotaUsed.ModifyIndex = idx
 		if err := tx.Insert(TableVariablesQuotas, quotaUsed); err != nil {
 			return req.ErrorResponse(idx, fmt.Errorf("variable quota insert failed: %v", err))
@@ -441,6 +463,140 @@ func (s *StateStore) svDeleteTxn(tx WriteTxn, idx uint64, req *structs.VarApplyS
 	return req.SuccessResponse(idx, nil)
 }
 
+// VariablesHistory queries the historic versions of all variables and is used
+// only for snapshot/restore and key rotation.
+func (s *StateStore) VariablesHistory(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableVariablesHistory, indexID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// GetVariableVersions returns the historic versions of the variable at a
+// given namespace and path, with the highest version first. The current
+// version of the variable is not included.
+func (s *StateStore) GetVariableVersions(
+	ws memdb.WatchSet, namespace, path string) ([]*structs.VariableEncrypted, error) {
+	txn := s.db.ReadTxn()
+	return s.varHistoryTxn(txn, ws, namespace, path)
+}
+
+// GetVariableVersion returns a single version of the variable at a given
+// namespace and path, which may be the current version.
+func (s *StateStore) GetVariableVersion(
+	ws memdb.WatchSet, namespace, path string, version uint64) (*structs.VariableEncrypted, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, raw, err := txn.FirstWatch(TableVariables, indexID, namespace, path)
+	if err != nil {
+		return nil, fmt.Errorf("variable lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+	if raw == nil {
+		return nil, nil
+	}
+	if sv := raw.(*structs.VariableEncrypted); sv.Version == version {
+		return sv, nil
+	}
+
+	watchCh, raw, err = txn.FirstWatch(TableVariablesHistory, indexID, namespace, path, version)
+	if err != nil {
+		return nil, fmt.Errorf("variable version lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+	if raw == nil {
+		return nil, nil
+	}
+	return raw.(*structs.VariableEncrypted), nil
+}
+
+func (s *StateStore) varHistoryTxn(
+	txn ReadTxn, ws memdb.WatchSet, namespace, path string) ([]*structs.VariableEncrypted, error) {
+	iter, err := txn.Get(TableVariablesHistory, indexID+"_prefix", namespace, path)
+	if err != nil {
+		return nil, fmt.Errorf("variable version lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var all []*structs.VariableEncrypted
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		// Ensure the path is an exact match
+		sv := raw.(*structs.VariableEncrypted)
+		if sv.Path != path {
+			continue
+		}
+		all = append(all, sv)
+	}
+
+	// Sort in reverse order so that the highest version is first
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Version > all[j].Version
+	})
+
+	return all, nil
+}
+
+// varHistoryInsertTxn records a version of a variable that is being replaced
+// in the history, and removes the oldest versions beyond the number of tracked
+// versions. It returns the change in size of the history, which counts
+// against the variables quota of the namespace.
+func (s *StateStore) varHistoryInsertTxn(tx WriteTxn, idx uint64, sv *structs.VariableEncrypted) (int64, error) {
+	var sizeChange int64
+	if s.config.VariableTrackedVersions > 0 {
+		if err := tx.Insert(TableVariablesHistory, sv); err != nil {
+			return 0, fmt.Errorf("failed inserting variable version: %w", err)
+		}
+		sizeChange += int64(len(sv.Data))
+	}
+
+	all, err := s.varHistoryTxn(tx, nil, sv.Namespace, sv.Path)
+	if err != nil {
+		return 0, err
+	}
+	for _, old := range all[min(len(all), s.config.VariableTrackedVersions):] {
+		if err := tx.Delete(TableVariablesHistory, old); err != nil {
+			return 0, fmt.Errorf("failed deleting variable version: %w", err)
+		}
+		sizeChange -= int64(len(old.Data))
+	}
+
+	if err := tx.Insert(tableIndex,
+		&IndexEntry{TableVariablesHistory, idx}); err != nil {
+		return 0, fmt.Errorf("failed updating variable history index: %w", err)
+	}
+	return sizeChange, nil
+}
+
+// varHistoryDeleteTxn removes all the historic versions of a variable, and
+// returns their total size.
+func (s *StateStore) varHistoryDeleteTxn(tx WriteTxn, idx uint64, namespace, path string) (int64, error) {
+	all, err := s.varHistoryTxn(tx, nil, namespace, path)
+	if err != nil {
+		return 0, err
+	}
+	if len(all) == 0 {
+		return 0, nil
+	}
+	var size int64
+	for _, old := range all {
+		if err := tx.Delete(TableVariablesHistory, old); err != nil {
+			return 0, fmt.Errorf("failed deleting variable version: %w", err)
+		}
+		size += int64(len(old.Data))
+	}
+
+	if err := tx.Insert(tableIndex,
+		&IndexEntry{TableVariablesHistory, idx}); err != nil {
+		return 0, fmt.Errorf("failed updating variable history index: %w", err)
+	}
+	return size, nil
+}
+
 // WriteTxn is implemented by memdb.Txn to perform write operations.
 type WriteTxn interface {
 	ReadTxn
@@ -486,7 +642,7 @@ func (s *StateStore) VariablesQuotaByNamespace(ws memdb.WatchSet, namespace stri
 // IMPORTANT: this method overwrites the variable, data included.
 func (s *StateStore) VarLockAcquire(idx uint64,
 	req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Try to fetch the variable.
@@ -523,7 +679,7 @@ func (s *StateStore) VarLockAcquire(idx uint64,
 
 func (s *StateStore) VarLockRelease(idx uint64,
 	req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Look up the entry in the state store.
@@ -128,6 +128,10 @@ func TestStateStore_UpsertVariables(t *testing.T) {
 		buf[len(buf)-1] = 'x'
 		sv1Update.Data = buf
 
+		// the replaced version is kept in the history, which counts against
+		// the quota
+		expectedQuotaSize += 1 + int64(len(svs[0].Data))
+
 		update1Index := uint64(40)
 
 		resp := testState.VarSet(update1Index, &structs.VarApplyStateRequest{
@@ -154,7 +158,7 @@ func TestStateStore_UpsertVariables(t *testing.T) {
 
 		quotaUsed, err := testState.VariablesQuotaByNamespace(ws, structs.DefaultNamespace)
 		must.NoError(t, err)
-		must.Eq(t, expectedQuotaSize+1, quotaUsed.Size)
+		must.Eq(t, expectedQuotaSize, quotaUsed.Size)
 	})
 
 	// Modify the second variable but send an upsert request that
@@ -164,6 +168,7 @@ func TestStateStore_UpsertVariables(t *testing.T) {
 		sv2 := svs[1].Copy()
 		sv2.KeyID = "sv2-update"
 		sv2.ModifyIndex = update2Index
+		expectedQuotaSize += int64(len(sv2.Data))
 
 		resp := testState.VarSet(update2Index, &structs.VarApplyStateRequest{
 			Op:  structs.VarOpSet,
@@ -196,7 +201,7 @@ func TestStateStore_UpsertVariables(t *testing.T) {
 
 		quotaUsed, err := testState.VariablesQuotaByNamespace(ws, structs.DefaultNamespace)
 		must.NoError(t, err)
-		must.Eq(t, expectedQuotaSize+1, quotaUsed.Size)
+		must.Eq(t, expectedQuotaSize, quotaUsed.Size)
 
 	})
 
@@ -1218,3 +1223,102 @@ func getAllVariables(ss *StateStore, ws memdb.WatchSet) ([]*structs.VariableEncr
 
 	return got, nil
 }
+
+func TestStateStore_VariablesHistory(t *testing.T) {
+	ci.Parallel(t)
+
+	testState := testStateStore(t)
+	testState.config.VariableTrackedVersions = 2
+
+	write := func(idx uint64, data string, unchangedIndex uint64) *structs.VariableEncrypted {
+		t.Helper()
+		sv := mock.VariableEncrypted()
+		sv.Data = []byte(data)
+		resp := testState.VarSet(idx, &structs.VarApplyStateRequest{
+			Op:             structs.VarOpSet,
+			Var:            sv,
+			UnchangedIndex: unchangedIndex,
+		})
+		must.NoError(t, resp.Error)
+		return sv
+	}
+
+	quotaSize := func(namespace string) int64 {
+		t.Helper()
+		quota, err := testState.VariablesQuotaByNamespace(nil, namespace)
+		must.NoError(t, err)
+		must.NotNil(t, quota)
+		return quota.Size
+	}
+
+	sv := write(100, "v0", 0)
+	must.Eq(t, 0, sv.Version)
+	write(110, "v1", 0)
+	write(120, "v2", 0)
+	sv = write(130, "v3", 0)
+	must.Eq(t, 3, sv.Version)
+
+	// the historic versions count against the quota
+	must.Eq(t, 6, quotaSize(sv.Namespace))
+
+	// only the tracked number of historic versions are kept
+	versions, err := testState.GetVariableVersions(nil, sv.Namespace, sv.Path)
+	must.NoError(t, err)
+	must.Len(t, 2, versions)
+	must.Eq(t, 2, versions[0].Version)
+	must.Eq(t, "v2", string(versions[0].Data))
+	must.Eq(t, 1, versions[1].Version)
+
+	got, err := testState.GetVariableVersion(nil, sv.Namespace, sv.Path, 3)
+	must.NoError(t, err)
+	must.Eq(t, "v3", string(got.Data))
+	got, err = testState.GetVariableVersion(nil, sv.Namespace, sv.Path, 1)
+	must.NoError(t, err)
+	must.Eq(t, "v1", string(got.Data))
+	got, err = testState.GetVariableVersion(nil, sv.Namespace, sv.Path, 0)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	// writes that don't change the items keep the version
+	sv = write(140, "v3-rekeyed", 130)
+	must.Eq(t, 3, sv.Version)
+	versions, err = testState.GetVariableVersions(nil, sv.Namespace, sv.Path)
+	must.NoError(t, err)
+	must.Eq(t, 2, versions[0].Version)
+	must.Eq(t, 14, quotaSize(sv.Namespace))
+
+	// unless the variable was modified since
+	sv = write(150, "v4", 130)
+	must.Eq(t, 4, sv.Version)
+
+	// versions removed from the history are released from the quota
+	must.Eq(t, 14, quotaSize(sv.Namespace))
+
+	// lock operations don't change the version
+	lockVar := sv.Copy()
+	lockReq := &structs.VarApplyStateRequest{
+		Op:             structs.VarOpLockAcquire,
+		Var:            &lockVar,
+		UnchangedIndex: 150,
+	}
+	lockReq.Var.Lock = &structs.VariableLock{ID: uuid.Generate()}
+	must.NoError(t, testState.VarLockAcquire(160, lockReq).Error)
+	must.NoError(t, testState.VarLockRelease(170, lockReq).Error)
+	got, err = testState.GetVariable(nil, sv.Namespace, sv.Path)
+	must.NoError(t, err)
+	must.Eq(t, 4, got.Version)
+
+	// the history is deleted with the variable
+	resp := testState.VarDelete(180, &structs.VarApplyStateRequest{
+		Op:  structs.VarOpDelete,
+		Var: sv,
+	})
+	must.NoError(t, resp.Error)
+	versions, err = testState.GetVariableVersions(nil, sv.Namespace, sv.Path)
+	must.NoError(t, err)
+	must.Len(t, 0, versions)
+	must.Eq(t, 0, quotaSize(sv.Namespace))
+
+	sv = write(190, "new", 0)
+	must.Eq(t, 0, sv.Version)
+}
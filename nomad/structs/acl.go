@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net"
 	"path"
 	"regexp"
 	"slices"
@@ -125,6 +126,14 @@ const (
 	// Reply: ACLAuthMethodsGetResponse
 	ACLGetAuthMethodsRPCMethod = "ACL.GetAuthMethods"
 
+	// ACLTestAuthMethodRPCMethod is the RPC method for computing the roles
+	// and policies an auth method binds for a set of claims, without creating
+	// a token.
+	//
+	// Args: ACLAuthMethodTestRequest
+	// Reply: ACLAuthMethodTestResponse
+	ACLTestAuthMethodRPCMethod = "ACL.TestAuthMethod"
+
 	// ACLUpsertBindingRulesRPCMethod is the RPC method for batch creating or
 	// modifying binding rules.
 	//
@@ -299,13 +308,23 @@ func (a *ACLToken) Canonicalize() {
 		a.CreateTime = time.Now().UTC()
 
 		// If the user has not set the expiration time, but has provided a TTL, we
-		// calculate and populate the former filed.
+		// calculate and populate the former filed. The TTL starts when the token
+		// becomes valid.
 		if a.ExpirationTime == nil && a.ExpirationTTL != 0 {
-			a.ExpirationTime = pointer.Of(a.CreateTime.Add(a.ExpirationTTL))
+			a.ExpirationTime = pointer.Of(a.validFrom().Add(a.ExpirationTTL))
 		}
 	}
 }
 
+// validFrom returns the time from which the token is accepted, which is the
+// later of its create time and NotBefore time.
+func (a *ACLToken) validFrom() time.Time {
+	if a.NotBefore != nil && a.NotBefore.After(a.CreateTime) {
+		return *a.NotBefore
+	}
+	return a.CreateTime
+}
+
 // Validate is used to check a token for reasonableness
 func (a *ACLToken) Validate(minTTL, maxTTL time.Duration, existing *ACLToken) error {
 	var mErr multierror.Error
@@ -346,9 +365,13 @@ func (a *ACLToken) Validate(minTTL, maxTTL time.Duration, existing *ACLToken) er
 				mErr.Errors = append(mErr.Errors, errors.New("expiration time cannot be before create time"))
 			}
 
+			if a.NotBefore != nil && !a.NotBefore.Before(*a.ExpirationTime) {
+				mErr.Errors = append(mErr.Errors, errors.New("not before time must be before expiration time"))
+			}
+
 			// Create a time duration which details the time-til-expiry, so we can
 			// check this against the regions max and min values.
-			expiresIn := a.ExpirationTime.Sub(a.CreateTime)
+			expiresIn := a.ExpirationTime.Sub(a.validFrom())
 			if expiresIn > maxTTL {
 				mErr.Errors = append(mErr.Errors,
 					fmt.Errorf("expiration time cannot be more than %s in the future (was %s)",
@@ -372,9 +395,20 @@ func (a *ACLToken) Validate(minTTL, maxTTL time.Duration, existing *ACLToken) er
 				mErr.Errors = append(mErr.Errors, errors.New("cannot update expiration time"))
 			}
 		}
+		if a.NotBefore != nil {
+			if existing.NotBefore == nil || !existing.NotBefore.Equal(*a.NotBefore) {
+				mErr.Errors = append(mErr.Errors, errors.New("cannot update not before time"))
+			}
+		}
 
 	}
 
+	for _, cidr := range a.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid allowed CIDR %q: %v", cidr, err))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -407,6 +441,34 @@ func (a *ACLToken) IsExpired(t time.Time) bool {
 	return a.ExpirationTime.Before(t) || t.IsZero()
 }
 
+// IsNotYetValid compares the ACLToken.NotBefore against the passed t to
+// identify whether the token can't be used yet. The function can be called
+// without checking whether the ACL token has a NotBefore time.
+func (a *ACLToken) IsNotYetValid(t time.Time) bool {
+	if a == nil || a.NotBefore == nil || a.NotBefore.IsZero() {
+		return false
+	}
+	return a.NotBefore.After(t.UTC())
+}
+
+// AllowsSourceIP checks whether the token can be used by requests from ip. It
+// returns false if the token is restricted to CIDR blocks and ip is nil.
+func (a *ACLToken) AllowsSourceIP(ip net.IP) bool {
+	if a == nil || len(a.AllowedCIDRs) == 0 {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range a.AllowedCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err == nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // HasRoles checks if a given set of role IDs are assigned to the ACL token. It
 // does not account for management tokens, therefore it is the responsibility
 // of the caller to perform this check, if required.
@@ -762,7 +824,14 @@ type ACLAuthMethod struct {
 	TokenNameFormat string
 	MaxTokenTTL     time.Duration
 	Default         bool
-	Config          *ACLAuthMethodConfig
+
+	// PruneTokens removes the roles and policies an identity is no longer
+	// bound to from the tokens previously created for the same identity by
+	// the auth method, each time the identity logs in. Tokens left without
+	// any roles or policies are deleted.
+	PruneTokens bool
+
+	Config *ACLAuthMethodConfig
 
 	Hash []byte
 
@@ -789,6 +858,7 @@ func (a *ACLAuthMethod) SetHash() []byte {
 	_, _ = hash.Write([]byte(a.TokenNameFormat))
 	_, _ = hash.Write([]byte(a.MaxTokenTTL.String()))
 	_, _ = hash.Write([]byte(strconv.FormatBool(a.Default)))
+	_, _ = hash.Write([]byte(strconv.FormatBool(a.PruneTokens)))
 
 	if a.Config != nil {
 		_, _ = hash.Write([]byte(a.Config.JWKSURL))
@@ -1501,6 +1571,39 @@ type ACLAuthMethodGetResponse struct {
 	QueryMeta
 }
 
+// ACLAuthMethodTestRequest is used to test which roles and policies the
+// binding rules of an auth method bind for a set of claims, without creating
+// a token.
+type ACLAuthMethodTestRequest struct {
+	MethodName string
+
+	// Claims are the claims of the identity, as they would be found in the
+	// token or user info of the provider.
+	Claims map[string]any
+	QueryOptions
+}
+
+// ACLAuthMethodTestResponse is used to return the result of an auth method
+// test.
+type ACLAuthMethodTestResponse struct {
+	Result *ACLAuthMethodTestResult
+	QueryMeta
+}
+
+// ACLAuthMethodTestResult details the claims and bindings the auth method
+// computed for the tested identity.
+type ACLAuthMethodTestResult struct {
+	// Claims are the claims of the identity after they have been mapped by
+	// the auth method, which the binding rules are evaluated against.
+	Claims *ACLAuthClaims
+
+	// Management, Roles, and Policies are the bindings a token created for
+	// the identity would get. The roles include their names.
+	Management bool
+	Roles      []*ACLTokenRoleLink
+	Policies   []string
+}
+
 // ACLAuthMethodsGetRequest is used to query a set of auth methods
 type ACLAuthMethodsGetRequest struct {
 	Names []string
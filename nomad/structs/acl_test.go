@@ -6,6 +6,7 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -78,6 +79,22 @@ func TestACLToken_Canonicalize(t *testing.T) {
 				require.NotEmpty(t, mockToken.ExpirationTime)
 			},
 		},
+		{
+			name: "token with ttl and not before without accessor",
+			testFn: func() {
+				notBefore := time.Now().UTC().Add(time.Hour)
+				mockToken := &ACLToken{
+					Name:          "my cool token " + uuid.Generate(),
+					Type:          "client",
+					Policies:      []string{"foo", "bar"},
+					ExpirationTTL: 10 * time.Hour,
+					NotBefore:     &notBefore,
+				}
+
+				mockToken.Canonicalize()
+				require.Equal(t, notBefore.Add(10*time.Hour), *mockToken.ExpirationTime)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -170,6 +187,53 @@ func TestACLTokenValidate(t *testing.T) {
 			inputExistingACLToken: nil,
 			expectedErrorContains: "expiration time cannot be more than",
 		},
+		{
+			name: "TTL measured from not before",
+			inputACLToken: &ACLToken{
+				Type:           ACLManagementToken,
+				Name:           "foo",
+				CreateTime:     time.Date(2022, time.July, 11, 16, 23, 0, 0, time.UTC),
+				NotBefore:      pointer.Of(time.Date(2022, time.July, 14, 16, 23, 0, 0, time.UTC)),
+				ExpirationTime: pointer.Of(time.Date(2022, time.July, 14, 17, 23, 0, 0, time.UTC)),
+			},
+			inputExistingACLToken: nil,
+			expectedErrorContains: "",
+		},
+		{
+			name: "not before after expiration",
+			inputACLToken: &ACLToken{
+				Type:           ACLManagementToken,
+				Name:           "foo",
+				CreateTime:     time.Date(2022, time.July, 11, 16, 23, 0, 0, time.UTC),
+				NotBefore:      pointer.Of(time.Date(2022, time.July, 11, 18, 23, 0, 0, time.UTC)),
+				ExpirationTime: pointer.Of(time.Date(2022, time.July, 11, 17, 23, 0, 0, time.UTC)),
+			},
+			inputExistingACLToken: nil,
+			expectedErrorContains: "not before time must be before expiration time",
+		},
+		{
+			name: "update not before",
+			inputACLToken: &ACLToken{
+				Type:      ACLManagementToken,
+				Name:      "foo",
+				NotBefore: pointer.Of(time.Date(2022, time.July, 11, 18, 23, 0, 0, time.UTC)),
+			},
+			inputExistingACLToken: &ACLToken{
+				Type: ACLManagementToken,
+				Name: "foo",
+			},
+			expectedErrorContains: "cannot update not before time",
+		},
+		{
+			name: "invalid allowed CIDR",
+			inputACLToken: &ACLToken{
+				Type:         ACLManagementToken,
+				Name:         "foo",
+				AllowedCIDRs: []string{"10.0.0.0/8", "10.0.0.1"},
+			},
+			inputExistingACLToken: nil,
+			expectedErrorContains: `invalid allowed CIDR "10.0.0.1"`,
+		},
 		{
 			name: "valid management",
 			inputACLToken: &ACLToken{
@@ -303,6 +367,29 @@ func TestACLToken_IsExpired(t *testing.T) {
 	}
 }
 
+func TestACLToken_IsNotYetValid(t *testing.T) {
+	notBefore := time.Date(2022, time.May, 9, 10, 27, 0, 0, time.UTC)
+
+	must.False(t, (*ACLToken)(nil).IsNotYetValid(notBefore))
+	must.False(t, (&ACLToken{}).IsNotYetValid(notBefore))
+
+	token := &ACLToken{NotBefore: &notBefore}
+	must.True(t, token.IsNotYetValid(notBefore.Add(-time.Minute)))
+	must.False(t, token.IsNotYetValid(notBefore))
+	must.False(t, token.IsNotYetValid(notBefore.Add(time.Minute)))
+}
+
+func TestACLToken_AllowsSourceIP(t *testing.T) {
+	must.True(t, (&ACLToken{}).AllowsSourceIP(net.ParseIP("192.168.1.1")))
+	must.True(t, (&ACLToken{}).AllowsSourceIP(nil))
+
+	token := &ACLToken{AllowedCIDRs: []string{"10.0.0.0/8", "2001:db8::/32"}}
+	must.True(t, token.AllowsSourceIP(net.ParseIP("10.1.2.3")))
+	must.True(t, token.AllowsSourceIP(net.ParseIP("2001:db8::1")))
+	must.False(t, token.AllowsSourceIP(net.ParseIP("192.168.1.1")))
+	must.False(t, token.AllowsSourceIP(nil))
+}
+
 func TestACLToken_HasRoles(t *testing.T) {
 	testCases := []struct {
 		name           string
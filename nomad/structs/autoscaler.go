@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// ScalingPolicyTargetTracking is the key of the target tracking block in
+	// the policy of a scaling policy, which is handled by the built-in
+	// autoscaler of the servers.
+	ScalingPolicyTargetTracking = "target_tracking"
+
+	// ScalingPolicyCooldown is the key of the cooldown in the policy of a
+	// scaling policy. It is shared with the external Nomad Autoscaler.
+	ScalingPolicyCooldown = "cooldown"
+
+	// DefaultScalingCooldown is the time the built-in autoscaler waits after
+	// a group was scaled before scaling it again, if the policy doesn't set a
+	// cooldown.
+	DefaultScalingCooldown = 5 * time.Minute
+)
+
+// ScalingTargetTracking is a target tracking policy of the built-in
+// autoscaler. The autoscaler adjusts the count of the task group so that the
+// average CPU and memory utilization of its allocations stays close to the
+// targets.
+type ScalingTargetTracking struct {
+	// CPU is the target CPU utilization of the allocations of the group, as a
+	// percentage of the CPU they reserve. Zero disables CPU tracking.
+	CPU float64
+
+	// Memory is the target memory utilization of the allocations of the
+	// group, as a percentage of the memory they reserve. Zero disables memory
+	// tracking.
+	Memory float64
+
+	// Cooldown is the time to wait after the group was scaled before scaling
+	// it again.
+	Cooldown time.Duration
+}
+
+// TargetTracking returns the target tracking policy declared in the policy of
+// the scaling policy, or nil if there is none.
+func (p *ScalingPolicy) TargetTracking() (*ScalingTargetTracking, error) {
+	if p == nil || p.Policy == nil {
+		return nil, nil
+	}
+	raw, ok := p.Policy[ScalingPolicyTargetTracking]
+	if !ok {
+		return nil, nil
+	}
+
+	block, err := scalingPolicyBlock(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var mErr multierror.Error
+	tt := &ScalingTargetTracking{Cooldown: DefaultScalingCooldown}
+	for key, value := range block {
+		target, ok := scalingPolicyNumber(value)
+		if !ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("target_tracking %s must be a number", key))
+			continue
+		}
+		switch key {
+		case "cpu":
+			tt.CPU = target
+		case "memory":
+			tt.Memory = target
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid target_tracking key %q", key))
+			continue
+		}
+		if target <= 0 || target > 100 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("target_tracking %s must be within the range (0,100]", key))
+		}
+	}
+	if tt.CPU == 0 && tt.Memory == 0 && len(mErr.Errors) == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("target_tracking requires a cpu or memory target"))
+	}
+
+	if raw, ok := p.Policy[ScalingPolicyCooldown]; ok {
+		cooldown, isString := raw.(string)
+		d, err := time.ParseDuration(cooldown)
+		if !isString || err != nil || d < 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("cooldown must be a positive duration"))
+		}
+		tt.Cooldown = d
+	}
+
+	if err := mErr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return tt, nil
+}
+
+// scalingPolicyBlock returns the attributes of a block of the opaque policy,
+// which is decoded as a list of maps from HCL, JSON, and msgpack.
+func scalingPolicyBlock(raw interface{}) (map[string]interface{}, error) {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		return v, nil
+	case []map[string]interface{}:
+		if len(v) == 1 {
+			return v[0], nil
+		}
+	case []interface{}:
+		if len(v) == 1 {
+			if m, ok := v[0].(map[string]interface{}); ok {
+				return m, nil
+			}
+		}
+	}
+	return nil, errors.New("only one target_tracking block is allowed")
+}
+
+// scalingPolicyNumber returns the number of the opaque policy as a float.
+func scalingPolicyNumber(raw interface{}) (float64, bool) {
+	switch v := raw.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
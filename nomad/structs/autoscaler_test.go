@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestScalingPolicy_TargetTracking(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		policy      map[string]interface{}
+		expected    *ScalingTargetTracking
+		expectedErr string
+	}{
+		{
+			name:   "no policy",
+			policy: nil,
+		},
+		{
+			name:   "external autoscaler policy",
+			policy: map[string]interface{}{"evaluation_interval": "10s"},
+		},
+		{
+			name: "cpu target",
+			policy: map[string]interface{}{
+				"target_tracking": []interface{}{map[string]interface{}{"cpu": 70}},
+			},
+			expected: &ScalingTargetTracking{CPU: 70, Cooldown: DefaultScalingCooldown},
+		},
+		{
+			name: "cpu and memory targets with cooldown",
+			policy: map[string]interface{}{
+				"cooldown": "2m",
+				"target_tracking": []map[string]interface{}{
+					{"cpu": float64(60), "memory": int64(80)},
+				},
+			},
+			expected: &ScalingTargetTracking{CPU: 60, Memory: 80, Cooldown: 2 * time.Minute},
+		},
+		{
+			name: "no target",
+			policy: map[string]interface{}{
+				"target_tracking": map[string]interface{}{},
+			},
+			expectedErr: "requires a cpu or memory target",
+		},
+		{
+			name: "target out of range",
+			policy: map[string]interface{}{
+				"target_tracking": map[string]interface{}{"memory": 120},
+			},
+			expectedErr: "target_tracking memory must be within the range",
+		},
+		{
+			name: "invalid key",
+			policy: map[string]interface{}{
+				"target_tracking": map[string]interface{}{"cpu": 50, "disk": 50},
+			},
+			expectedErr: `invalid target_tracking key "disk"`,
+		},
+		{
+			name: "target not a number",
+			policy: map[string]interface{}{
+				"target_tracking": map[string]interface{}{"cpu": "50"},
+			},
+			expectedErr: "target_tracking cpu must be a number",
+		},
+		{
+			name: "multiple blocks",
+			policy: map[string]interface{}{
+				"target_tracking": []interface{}{
+					map[string]interface{}{"cpu": 50},
+					map[string]interface{}{"memory": 50},
+				},
+			},
+			expectedErr: "only one target_tracking block is allowed",
+		},
+		{
+			name: "invalid cooldown",
+			policy: map[string]interface{}{
+				"cooldown":        "soon",
+				"target_tracking": map[string]interface{}{"cpu": 50},
+			},
+			expectedErr: "cooldown must be a positive duration",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &ScalingPolicy{Type: ScalingPolicyTypeHorizontal, Policy: tc.policy}
+			tt, err := p.TargetTracking()
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, tt)
+		})
+	}
+}
+
+func TestScalingPolicy_Validate_TargetTracking(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &ScalingPolicy{
+		Type:    ScalingPolicyTypeHorizontal,
+		Min:     1,
+		Max:     5,
+		Enabled: true,
+		Policy: map[string]interface{}{
+			"target_tracking": map[string]interface{}{"cpu": 150},
+		},
+		Target: map[string]string{
+			ScalingTargetNamespace: "default",
+			ScalingTargetJob:       "example",
+			ScalingTargetGroup:     "web",
+		},
+	}
+	must.ErrorContains(t, p.Validate(), "target_tracking cpu must be within the range")
+
+	p.Policy["target_tracking"] = map[string]interface{}{"cpu": 50}
+	must.NoError(t, p.Validate())
+}
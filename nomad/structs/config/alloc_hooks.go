@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"slices"
+
+	"github.com/hashicorp/nomad/helper/pointer"
+)
+
+// AllocHookConfig describes commands the client runs on the host before the
+// network and volumes of an allocation are set up, and after they are torn
+// down.
+type AllocHookConfig struct {
+	// Name is the label of the hook, used in logs and task events.
+	Name string `hcl:",key"`
+
+	// PreStart is the command and arguments run before the network and
+	// volumes of the allocation are set up.
+	PreStart []string `hcl:"pre_start"`
+
+	// PostStop is the command and arguments run after the network and volumes
+	// of the allocation are torn down.
+	PostStop []string `hcl:"post_stop"`
+
+	// Timeout is the duration after which a command is killed.
+	Timeout *string `hcl:"timeout"`
+
+	// Namespaces are the namespaces of the allocations the hook runs for.
+	// The wildcard "*" allows every namespace.
+	Namespaces []string `hcl:"namespaces"`
+}
+
+func (h *AllocHookConfig) Copy() *AllocHookConfig {
+	if h == nil {
+		return nil
+	}
+
+	nh := new(AllocHookConfig)
+	*nh = *h
+	nh.PreStart = slices.Clone(h.PreStart)
+	nh.PostStop = slices.Clone(h.PostStop)
+	nh.Timeout = pointer.Copy(h.Timeout)
+	nh.Namespaces = slices.Clone(h.Namespaces)
+	return nh
+}
+
+// AllocHookSliceMerge merges two slices of alloc hooks, where the hooks of b
+// replace the hooks of a with the same name.
+func AllocHookSliceMerge(a, b []*AllocHookConfig) []*AllocHookConfig {
+	n := make([]*AllocHookConfig, 0, len(a)+len(b))
+	seen := make(map[string]int, len(a))
+	for _, h := range a {
+		seen[h.Name] = len(n)
+		n = append(n, h.Copy())
+	}
+	for _, h := range b {
+		if i, ok := seen[h.Name]; ok {
+			n[i] = h.Copy()
+			continue
+		}
+		seen[h.Name] = len(n)
+		n = append(n, h.Copy())
+	}
+	return n
+}
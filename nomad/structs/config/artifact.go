@@ -66,6 +66,28 @@ type ArtifactConfig struct {
 	// variable names to inherit from the Nomad Client and set in the artifact
 	// download sandbox process.
 	SetEnvironmentVariables *string `hcl:"set_environment_variables"`
+
+	// OCIAuthConfig is the path to a Docker config file holding the registry
+	// auths used to pull OCI artifacts. Credential helpers and stores
+	// configured in the file are used as well.
+	OCIAuthConfig *string `hcl:"oci_auth_config"`
+
+	// CacheMaxSize is the maximum size of the cache of artifacts shared by
+	// the allocations of the client, keyed by their checksum. Setting it to
+	// 0 disables the cache.
+	//
+	// Default is 10GB.
+	CacheMaxSize *string `hcl:"cache_max_size"`
+
+	// FulcioRoots is the path to a PEM file with the Fulcio root and
+	// intermediate certificates used to verify keyless cosign signatures of
+	// artifacts.
+	FulcioRoots *string `hcl:"fulcio_roots"`
+
+	// RekorPublicKeys is the path to a PEM file with the public keys of the
+	// Rekor transparency logs that keyless cosign signatures of artifacts must
+	// be recorded in.
+	RekorPublicKeys *string `hcl:"rekor_public_keys"`
 }
 
 func (a *ArtifactConfig) Copy() *ArtifactConfig {
@@ -84,6 +106,10 @@ func (a *ArtifactConfig) Copy() *ArtifactConfig {
 		DisableFilesystemIsolation:    pointer.Copy(a.DisableFilesystemIsolation),
 		FilesystemIsolationExtraPaths: slices.Clone(a.FilesystemIsolationExtraPaths),
 		SetEnvironmentVariables:       pointer.Copy(a.SetEnvironmentVariables),
+		OCIAuthConfig:                 pointer.Copy(a.OCIAuthConfig),
+		CacheMaxSize:                  pointer.Copy(a.CacheMaxSize),
+		FulcioRoots:                   pointer.Copy(a.FulcioRoots),
+		RekorPublicKeys:               pointer.Copy(a.RekorPublicKeys),
 	}
 }
 
@@ -105,6 +131,10 @@ func (a *ArtifactConfig) Merge(o *ArtifactConfig) *ArtifactConfig {
 			DecompressionSizeLimit:      pointer.Merge(a.DecompressionSizeLimit, o.DecompressionSizeLimit),
 			DisableFilesystemIsolation:  pointer.Merge(a.DisableFilesystemIsolation, o.DisableFilesystemIsolation),
 			SetEnvironmentVariables:     pointer.Merge(a.SetEnvironmentVariables, o.SetEnvironmentVariables),
+			OCIAuthConfig:               pointer.Merge(a.OCIAuthConfig, o.OCIAuthConfig),
+			CacheMaxSize:                pointer.Merge(a.CacheMaxSize, o.CacheMaxSize),
+			FulcioRoots:                 pointer.Merge(a.FulcioRoots, o.FulcioRoots),
+			RekorPublicKeys:             pointer.Merge(a.RekorPublicKeys, o.RekorPublicKeys),
 		}
 
 		if o.FilesystemIsolationExtraPaths != nil {
@@ -144,6 +174,14 @@ func (a *ArtifactConfig) Equal(o *ArtifactConfig) bool {
 		return false
 	case !pointer.Eq(a.SetEnvironmentVariables, o.SetEnvironmentVariables):
 		return false
+	case !pointer.Eq(a.OCIAuthConfig, o.OCIAuthConfig):
+		return false
+	case !pointer.Eq(a.CacheMaxSize, o.CacheMaxSize):
+		return false
+	case !pointer.Eq(a.FulcioRoots, o.FulcioRoots):
+		return false
+	case !pointer.Eq(a.RekorPublicKeys, o.RekorPublicKeys):
+		return false
 	}
 	return true
 }
@@ -237,6 +275,27 @@ func (a *ArtifactConfig) Validate() error {
 		return fmt.Errorf("set_environment_variables must be set")
 	}
 
+	if a.OCIAuthConfig == nil {
+		return fmt.Errorf("oci_auth_config must be set")
+	}
+
+	if a.CacheMaxSize == nil {
+		return fmt.Errorf("cache_max_size must be set")
+	}
+	if v, err := humanize.ParseBytes(*a.CacheMaxSize); err != nil {
+		return fmt.Errorf("cache_max_size is not a valid size: %w", err)
+	} else if v > math.MaxInt64 {
+		return fmt.Errorf("cache_max_size must be < %d but found %d", int64(math.MaxInt64), v)
+	}
+
+	if a.FulcioRoots == nil {
+		return fmt.Errorf("fulcio_roots must be set")
+	}
+
+	if a.RekorPublicKeys == nil {
+		return fmt.Errorf("rekor_public_keys must be set")
+	}
+
 	return nil
 }
 
@@ -283,5 +342,17 @@ func DefaultArtifactConfig() *ArtifactConfig {
 
 		// No environment variables are inherited from Client by default.
 		SetEnvironmentVariables: pointer.Of(""),
+
+		// No registry auths for OCI artifacts by default.
+		OCIAuthConfig: pointer.Of(""),
+
+		// Cache up to 10GB of artifacts shared by allocations.
+		CacheMaxSize: pointer.Of("10GB"),
+
+		// No Fulcio roots for keyless cosign signatures by default.
+		FulcioRoots: pointer.Of(""),
+
+		// No Rekor public keys for keyless cosign signatures by default.
+		RekorPublicKeys: pointer.Of(""),
 	}
 }
@@ -64,6 +64,10 @@ func TestArtifactConfig_Merge(t *testing.T) {
 					"d:r:/tmp/stash",
 				},
 				SetEnvironmentVariables: pointer.Of(""),
+				OCIAuthConfig:           pointer.Of("/etc/nomad/docker.json"),
+				CacheMaxSize:            pointer.Of("10GB"),
+				FulcioRoots:             pointer.Of(""),
+				RekorPublicKeys:         pointer.Of(""),
 			},
 			other: &ArtifactConfig{
 				HTTPReadTimeout:             pointer.Of("5m"),
@@ -80,6 +84,10 @@ func TestArtifactConfig_Merge(t *testing.T) {
 					"f:rx:/opt/bin/runme",
 				},
 				SetEnvironmentVariables: pointer.Of("FOO,BAR"),
+				OCIAuthConfig:           pointer.Of("/etc/nomad/oci.json"),
+				CacheMaxSize:            pointer.Of("1GB"),
+				FulcioRoots:             pointer.Of("/etc/nomad/fulcio.pem"),
+				RekorPublicKeys:         pointer.Of("/etc/nomad/rekor.pem"),
 			},
 			expected: &ArtifactConfig{
 				HTTPReadTimeout:             pointer.Of("5m"),
@@ -96,6 +104,10 @@ func TestArtifactConfig_Merge(t *testing.T) {
 					"f:rx:/opt/bin/runme",
 				},
 				SetEnvironmentVariables: pointer.Of("FOO,BAR"),
+				OCIAuthConfig:           pointer.Of("/etc/nomad/oci.json"),
+				CacheMaxSize:            pointer.Of("1GB"),
+				FulcioRoots:             pointer.Of("/etc/nomad/fulcio.pem"),
+				RekorPublicKeys:         pointer.Of("/etc/nomad/rekor.pem"),
 			},
 		},
 		{
@@ -499,6 +511,47 @@ func TestArtifactConfig_Validate(t *testing.T) {
 			},
 			expErr: "set_environment_variables must be set",
 		},
+		{
+			name: "oci auth config not set",
+			config: func(a *ArtifactConfig) {
+				a.OCIAuthConfig = nil
+			},
+			expErr: "oci_auth_config must be set",
+		},
+		{
+			name: "cache max size not set",
+			config: func(a *ArtifactConfig) {
+				a.CacheMaxSize = nil
+			},
+			expErr: "cache_max_size must be set",
+		},
+		{
+			name: "cache max size invalid",
+			config: func(a *ArtifactConfig) {
+				a.CacheMaxSize = pointer.Of("huge")
+			},
+			expErr: "cache_max_size is not a valid size",
+		},
+		{
+			name: "cache disabled",
+			config: func(a *ArtifactConfig) {
+				a.CacheMaxSize = pointer.Of("0")
+			},
+		},
+		{
+			name: "fulcio roots not set",
+			config: func(a *ArtifactConfig) {
+				a.FulcioRoots = nil
+			},
+			expErr: "fulcio_roots must be set",
+		},
+		{
+			name: "rekor public keys not set",
+			config: func(a *ArtifactConfig) {
+				a.RekorPublicKeys = nil
+			},
+			expErr: "rekor_public_keys must be set",
+		},
 	}
 
 	for _, tc := range testCases {
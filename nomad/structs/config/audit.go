@@ -4,6 +4,7 @@
 package config
 
 import (
+	"maps"
 	"slices"
 	"time"
 
@@ -34,7 +35,7 @@ type AuditSink struct {
 	// be met in order to successfully make requests
 	DeliveryGuarantee string `hcl:"delivery_guarantee"`
 
-	// Type is the sink type to configure. (file)
+	// Type is the sink type to configure. (file, syslog, webhook)
 	Type string `hcl:"type"`
 
 	// Format is the sink output format. (json)
@@ -56,6 +57,19 @@ type AuditSink struct {
 
 	// Mode is the octal formatted permissions for the audit log files.
 	Mode string `hcl:"mode"`
+
+	// Facility is the syslog facility syslog sinks write to.
+	Facility string `hcl:"facility"`
+
+	// Tag is the tag of the messages syslog sinks write.
+	Tag string `hcl:"tag"`
+
+	// Address is the URL webhook sinks post audit events to.
+	Address string `hcl:"address"`
+
+	// Headers are the HTTP headers webhook sinks set on their requests, such
+	// as an Authorization header.
+	Headers map[string]string `hcl:"headers"`
 }
 
 // AuditFilter is the configuration for a Audit Log Filter
@@ -74,6 +88,11 @@ type AuditFilter struct {
 
 	// Operations is the type of operation to filter, such as GET, DELETE
 	Operations []string `hcl:"operations"`
+
+	// Actors is the list of actors to filter, identified by the accessor ID
+	// or name of their ACL token, their client node ID, or their TLS
+	// certificate name
+	Actors []string `hcl:"actors"`
 }
 
 // Copy returns a new copy of an AuditConfig
@@ -130,6 +149,8 @@ func (a *AuditSink) Copy() *AuditSink {
 	nc := new(AuditSink)
 	*nc = *a
 
+	nc.Headers = maps.Clone(nc.Headers)
+
 	return nc
 }
 
@@ -145,6 +166,7 @@ func (a *AuditFilter) Copy() *AuditFilter {
 	nc.Endpoints = slices.Clone(nc.Endpoints)
 	nc.Stages = slices.Clone(nc.Stages)
 	nc.Operations = slices.Clone(nc.Operations)
+	nc.Actors = slices.Clone(nc.Actors)
 
 	return nc
 }
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "github.com/hashicorp/nomad/helper/pointer"
+
+// ExecSessionRecordingConfig describes the recording of the exec sessions run
+// in the allocations of a client.
+type ExecSessionRecordingConfig struct {
+	// Enabled records the input and output of every exec session. Sessions
+	// are refused if they can't be recorded.
+	Enabled *bool `hcl:"enabled"`
+
+	// Path is the directory the sessions are recorded to. Defaults to the
+	// "exec_sessions" directory of the data dir.
+	Path *string `hcl:"path"`
+}
+
+func (e *ExecSessionRecordingConfig) Copy() *ExecSessionRecordingConfig {
+	if e == nil {
+		return nil
+	}
+
+	ne := new(ExecSessionRecordingConfig)
+	*ne = *e
+	return ne
+}
+
+func (e *ExecSessionRecordingConfig) Merge(o *ExecSessionRecordingConfig) *ExecSessionRecordingConfig {
+	switch {
+	case e == nil:
+		return o.Copy()
+	case o == nil:
+		return e.Copy()
+	default:
+		ne := e.Copy()
+		if o.Enabled != nil {
+			ne.Enabled = pointer.Copy(o.Enabled)
+		}
+		if o.Path != nil {
+			ne.Path = pointer.Copy(o.Path)
+		}
+		return ne
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestExecSessionRecordingConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		a        *ExecSessionRecordingConfig
+		b        *ExecSessionRecordingConfig
+		expected *ExecSessionRecordingConfig
+	}{
+		{
+			name:     "nil configs",
+			expected: nil,
+		},
+		{
+			name:     "nil a",
+			b:        &ExecSessionRecordingConfig{Enabled: pointer.Of(true)},
+			expected: &ExecSessionRecordingConfig{Enabled: pointer.Of(true)},
+		},
+		{
+			name:     "nil b",
+			a:        &ExecSessionRecordingConfig{Path: pointer.Of("/tmp")},
+			expected: &ExecSessionRecordingConfig{Path: pointer.Of("/tmp")},
+		},
+		{
+			name: "b overrides a",
+			a: &ExecSessionRecordingConfig{
+				Enabled: pointer.Of(true),
+				Path:    pointer.Of("/tmp"),
+			},
+			b: &ExecSessionRecordingConfig{
+				Enabled: pointer.Of(false),
+			},
+			expected: &ExecSessionRecordingConfig{
+				Enabled: pointer.Of(false),
+				Path:    pointer.Of("/tmp"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.a.Merge(tc.b))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"maps"
+
+	"github.com/hashicorp/nomad/helper/pointer"
+)
+
+// HostVolumeLimitsConfig caps the capacity of the dynamic host volumes the
+// servers place on a client.
+type HostVolumeLimitsConfig struct {
+	// MaxSize is the total capacity of the dynamic host volumes on the
+	// client, e.g. "100GiB". Unlimited if unset.
+	MaxSize *string `hcl:"max_size"`
+
+	// NamespaceMaxSize is the capacity of the dynamic host volumes on the
+	// client per namespace. Namespaces not listed are only limited by
+	// MaxSize.
+	NamespaceMaxSize map[string]string `hcl:"namespace_max_size"`
+}
+
+func (h *HostVolumeLimitsConfig) Copy() *HostVolumeLimitsConfig {
+	if h == nil {
+		return nil
+	}
+
+	nh := new(HostVolumeLimitsConfig)
+	*nh = *h
+	nh.NamespaceMaxSize = maps.Clone(h.NamespaceMaxSize)
+	return nh
+}
+
+func (h *HostVolumeLimitsConfig) Merge(o *HostVolumeLimitsConfig) *HostVolumeLimitsConfig {
+	switch {
+	case h == nil:
+		return o.Copy()
+	case o == nil:
+		return h.Copy()
+	default:
+		nh := h.Copy()
+		if o.MaxSize != nil {
+			nh.MaxSize = pointer.Copy(o.MaxSize)
+		}
+		if len(o.NamespaceMaxSize) != 0 {
+			if nh.NamespaceMaxSize == nil {
+				nh.NamespaceMaxSize = make(map[string]string, len(o.NamespaceMaxSize))
+			}
+			maps.Copy(nh.NamespaceMaxSize, o.NamespaceMaxSize)
+		}
+		return nh
+	}
+}
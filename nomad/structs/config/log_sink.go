@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+// LogSinkConfig describes a remote log sink the logs of all the tasks of a
+// client are shipped to.
+type LogSinkConfig struct {
+	// Type is either "syslog", "fluentd", or "file".
+	Type string `hcl:"type"`
+
+	// Address is the address of the syslog server, as a URL with the udp,
+	// tcp, unix, or unixgram scheme, or the host:port of the Fluentd server.
+	Address string `hcl:"address"`
+
+	// Path is the absolute path of the file lines are appended to as JSON by
+	// file sinks.
+	Path string `hcl:"path"`
+
+	// Tag is the syslog app name or the Fluentd tag of the lines. Defaults to
+	// the task name for syslog and "nomad" for Fluentd.
+	Tag string `hcl:"tag"`
+
+	// Facility is the syslog facility of the lines. Defaults to "user".
+	Facility string `hcl:"facility"`
+}
+
+func (l *LogSinkConfig) Copy() *LogSinkConfig {
+	if l == nil {
+		return nil
+	}
+
+	nl := new(LogSinkConfig)
+	*nl = *l
+	return nl
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "github.com/hashicorp/nomad/helper/pointer"
+
+// ServiceDNSConfig describes the DNS server of a client, which answers queries
+// for the services registered with Nomad service discovery.
+type ServiceDNSConfig struct {
+	// Enabled runs the DNS server on the client.
+	Enabled *bool `hcl:"enabled"`
+
+	// Address is the address the DNS server listens on. Defaults to
+	// 127.0.0.1.
+	Address *string `hcl:"address"`
+
+	// Port is the UDP and TCP port the DNS server listens on. Defaults to
+	// 4653.
+	Port *int `hcl:"port"`
+
+	// Zone is the DNS zone the DNS server is authoritative for. Defaults to
+	// "nomad".
+	Zone *string `hcl:"zone"`
+
+	// TTL is the time to live of the records in responses. Defaults to 0s.
+	TTL *string `hcl:"ttl"`
+}
+
+func (s *ServiceDNSConfig) Copy() *ServiceDNSConfig {
+	if s == nil {
+		return nil
+	}
+
+	ns := new(ServiceDNSConfig)
+	*ns = *s
+	return ns
+}
+
+func (s *ServiceDNSConfig) Merge(o *ServiceDNSConfig) *ServiceDNSConfig {
+	switch {
+	case s == nil:
+		return o.Copy()
+	case o == nil:
+		return s.Copy()
+	default:
+		ns := s.Copy()
+		if o.Enabled != nil {
+			ns.Enabled = pointer.Copy(o.Enabled)
+		}
+		if o.Address != nil {
+			ns.Address = pointer.Copy(o.Address)
+		}
+		if o.Port != nil {
+			ns.Port = pointer.Copy(o.Port)
+		}
+		if o.Zone != nil {
+			ns.Zone = pointer.Copy(o.Zone)
+		}
+		if o.TTL != nil {
+			ns.TTL = pointer.Copy(o.TTL)
+		}
+		return ns
+	}
+}
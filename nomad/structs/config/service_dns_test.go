@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestServiceDNSConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		a        *ServiceDNSConfig
+		b        *ServiceDNSConfig
+		expected *ServiceDNSConfig
+	}{
+		{
+			name:     "nil configs",
+			expected: nil,
+		},
+		{
+			name:     "nil a",
+			b:        &ServiceDNSConfig{Enabled: pointer.Of(true)},
+			expected: &ServiceDNSConfig{Enabled: pointer.Of(true)},
+		},
+		{
+			name:     "nil b",
+			a:        &ServiceDNSConfig{Port: pointer.Of(53)},
+			expected: &ServiceDNSConfig{Port: pointer.Of(53)},
+		},
+		{
+			name: "b overrides a",
+			a: &ServiceDNSConfig{
+				Enabled: pointer.Of(true),
+				Address: pointer.Of("127.0.0.1"),
+				Zone:    pointer.Of("nomad"),
+			},
+			b: &ServiceDNSConfig{
+				Address: pointer.Of("0.0.0.0"),
+				Port:    pointer.Of(53),
+				TTL:     pointer.Of("5s"),
+			},
+			expected: &ServiceDNSConfig{
+				Enabled: pointer.Of(true),
+				Address: pointer.Of("0.0.0.0"),
+				Port:    pointer.Of(53),
+				Zone:    pointer.Of("nomad"),
+				TTL:     pointer.Of("5s"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.a.Merge(tc.b))
+		})
+	}
+}
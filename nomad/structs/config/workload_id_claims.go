@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// WorkloadIdentityClaimsConfig is the server configuration block used to add
+// custom claims to the workload identities signed by the server. The claims
+// may be interpolated from the job, group, task and node, which allows
+// federating workload identities with third parties that expect specific
+// claims, such as cloud provider identity federation.
+type WorkloadIdentityClaimsConfig struct {
+	// ExtraClaims are added to the extra_claims object of every workload
+	// identity signed for a task or service.
+	ExtraClaims map[string]string `hcl:"extra_claims"`
+
+	// Audiences are claim templates applied to the workload identities with a
+	// matching audience.
+	Audiences []*WorkloadIdentityAudienceConfig `hcl:"audience"`
+}
+
+// WorkloadIdentityAudienceConfig is a claim template for the workload
+// identities with a specific audience.
+type WorkloadIdentityAudienceConfig struct {
+	// Name is the audience (the "aud" JWT claim) the template applies to.
+	Name string `hcl:",key"`
+
+	// Subject overrides the "sub" claim of the identities. Some third parties
+	// can only grant access based on the subject, so this allows shaping it
+	// to the policies configured there.
+	Subject string `hcl:"subject"`
+
+	// ExtraClaims are added to the extra_claims object of the identities and
+	// override the claims of the same name set for all identities.
+	ExtraClaims map[string]string `hcl:"extra_claims"`
+}
+
+func (c *WorkloadIdentityClaimsConfig) Copy() *WorkloadIdentityClaimsConfig {
+	if c == nil {
+		return nil
+	}
+	nc := &WorkloadIdentityClaimsConfig{
+		ExtraClaims: maps.Clone(c.ExtraClaims),
+	}
+	for _, aud := range c.Audiences {
+		nc.Audiences = append(nc.Audiences, aud.Copy())
+	}
+	return nc
+}
+
+func (c *WorkloadIdentityClaimsConfig) Merge(o *WorkloadIdentityClaimsConfig) *WorkloadIdentityClaimsConfig {
+	switch {
+	case c == nil:
+		return o.Copy()
+	case o == nil:
+		return c.Copy()
+	}
+
+	result := c.Copy()
+	if len(o.ExtraClaims) > 0 && result.ExtraClaims == nil {
+		result.ExtraClaims = map[string]string{}
+	}
+	maps.Copy(result.ExtraClaims, o.ExtraClaims)
+
+	for _, aud := range o.Audiences {
+		idx := slices.IndexFunc(result.Audiences, func(a *WorkloadIdentityAudienceConfig) bool {
+			return a.Name == aud.Name
+		})
+		if idx < 0 {
+			result.Audiences = append(result.Audiences, aud.Copy())
+			continue
+		}
+		result.Audiences[idx] = result.Audiences[idx].Merge(aud)
+	}
+	return result
+}
+
+// Validate returns an error if the claim templates are invalid.
+func (c *WorkloadIdentityClaimsConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	for k := range c.ExtraClaims {
+		if k == "" {
+			mErr = multierror.Append(mErr, errors.New("extra_claims must not contain empty claim names"))
+		}
+	}
+
+	seen := map[string]struct{}{}
+	for _, aud := range c.Audiences {
+		if aud.Name == "" {
+			mErr = multierror.Append(mErr, errors.New("audience must not be empty"))
+			continue
+		}
+		if _, ok := seen[aud.Name]; ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("audience %q is defined more than once", aud.Name))
+		}
+		seen[aud.Name] = struct{}{}
+		for k := range aud.ExtraClaims {
+			if k == "" {
+				mErr = multierror.Append(mErr, fmt.Errorf("audience %q extra_claims must not contain empty claim names", aud.Name))
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ClaimsFor returns the subject template and extra claim templates for a
+// workload identity. Audience templates are applied in the order of the
+// identity's audiences, so the first audience with a subject template
+// determines the subject.
+func (c *WorkloadIdentityClaimsConfig) ClaimsFor(wid *structs.WorkloadIdentity) (string, map[string]string) {
+	if c == nil {
+		return "", nil
+	}
+
+	var subject string
+	var audiences []string
+	if wid != nil {
+		audiences = wid.Audience
+	}
+	extras := maps.Clone(c.ExtraClaims)
+	for _, name := range audiences {
+		idx := slices.IndexFunc(c.Audiences, func(a *WorkloadIdentityAudienceConfig) bool {
+			return a.Name == name
+		})
+		if idx < 0 {
+			continue
+		}
+		aud := c.Audiences[idx]
+		if subject == "" {
+			subject = aud.Subject
+		}
+		if len(aud.ExtraClaims) > 0 && extras == nil {
+			extras = map[string]string{}
+		}
+		maps.Copy(extras, aud.ExtraClaims)
+	}
+	return subject, extras
+}
+
+func (a *WorkloadIdentityAudienceConfig) Copy() *WorkloadIdentityAudienceConfig {
+	if a == nil {
+		return nil
+	}
+	na := *a
+	na.ExtraClaims = maps.Clone(a.ExtraClaims)
+	return &na
+}
+
+func (a *WorkloadIdentityAudienceConfig) Merge(o *WorkloadIdentityAudienceConfig) *WorkloadIdentityAudienceConfig {
+	result := a.Copy()
+	if o.Subject != "" {
+		result.Subject = o.Subject
+	}
+	if len(o.ExtraClaims) > 0 && result.ExtraClaims == nil {
+		result.ExtraClaims = map[string]string{}
+	}
+	maps.Copy(result.ExtraClaims, o.ExtraClaims)
+	return result
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestWorkloadIdentityClaimsConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	a := &WorkloadIdentityClaimsConfig{
+		ExtraClaims: map[string]string{"team": "${job.meta.team}"},
+		Audiences: []*WorkloadIdentityAudienceConfig{
+			{Name: "sts.amazonaws.com", Subject: "${job.id}"},
+		},
+	}
+	b := &WorkloadIdentityClaimsConfig{
+		ExtraClaims: map[string]string{"zone": "${node.datacenter}"},
+		Audiences: []*WorkloadIdentityAudienceConfig{
+			{Name: "sts.amazonaws.com", ExtraClaims: map[string]string{"app": "${task.name}"}},
+			{Name: "example.com", Subject: "${alloc.id}"},
+		},
+	}
+
+	result := a.Merge(b)
+	must.Eq(t, &WorkloadIdentityClaimsConfig{
+		ExtraClaims: map[string]string{"team": "${job.meta.team}", "zone": "${node.datacenter}"},
+		Audiences: []*WorkloadIdentityAudienceConfig{
+			{Name: "sts.amazonaws.com", Subject: "${job.id}", ExtraClaims: map[string]string{"app": "${task.name}"}},
+			{Name: "example.com", Subject: "${alloc.id}"},
+		},
+	}, result)
+
+	// Merging does not mutate the inputs.
+	must.MapLen(t, 1, a.ExtraClaims)
+	must.Len(t, 1, a.Audiences)
+	must.MapEmpty(t, a.Audiences[0].ExtraClaims)
+
+	var nilConfig *WorkloadIdentityClaimsConfig
+	must.Eq(t, b, nilConfig.Merge(b))
+	must.Eq(t, a, a.Merge(nil))
+}
+
+func TestWorkloadIdentityClaimsConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *WorkloadIdentityClaimsConfig
+	must.NoError(t, nilConfig.Validate())
+
+	must.NoError(t, (&WorkloadIdentityClaimsConfig{
+		ExtraClaims: map[string]string{"team": "${job.meta.team}"},
+		Audiences:   []*WorkloadIdentityAudienceConfig{{Name: "sts.amazonaws.com"}},
+	}).Validate())
+
+	err := (&WorkloadIdentityClaimsConfig{
+		ExtraClaims: map[string]string{"": "x"},
+		Audiences: []*WorkloadIdentityAudienceConfig{
+			{Name: ""},
+			{Name: "a", ExtraClaims: map[string]string{"": "x"}},
+			{Name: "a"},
+		},
+	}).Validate()
+	must.ErrorContains(t, err, "extra_claims must not contain empty claim names")
+	must.ErrorContains(t, err, "audience must not be empty")
+	must.ErrorContains(t, err, `audience "a" extra_claims must not contain empty claim names`)
+	must.ErrorContains(t, err, `audience "a" is defined more than once`)
+}
+
+func TestWorkloadIdentityClaimsConfig_ClaimsFor(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *WorkloadIdentityClaimsConfig
+	subject, extras := nilConfig.ClaimsFor(&structs.WorkloadIdentity{Audience: []string{"a"}})
+	must.Eq(t, "", subject)
+	must.Nil(t, extras)
+
+	c := &WorkloadIdentityClaimsConfig{
+		ExtraClaims: map[string]string{"team": "all", "tier": "all"},
+		Audiences: []*WorkloadIdentityAudienceConfig{
+			{Name: "a", Subject: "sub-a", ExtraClaims: map[string]string{"tier": "a"}},
+			{Name: "b", Subject: "sub-b", ExtraClaims: map[string]string{"zone": "b"}},
+		},
+	}
+
+	subject, extras = c.ClaimsFor(nil)
+	must.Eq(t, "", subject)
+	must.Eq(t, map[string]string{"team": "all", "tier": "all"}, extras)
+
+	subject, extras = c.ClaimsFor(&structs.WorkloadIdentity{Audience: []string{"other"}})
+	must.Eq(t, "", subject)
+	must.Eq(t, map[string]string{"team": "all", "tier": "all"}, extras)
+
+	// the first matching audience determines the subject
+	subject, extras = c.ClaimsFor(&structs.WorkloadIdentity{Audience: []string{"b", "a"}})
+	must.Eq(t, "sub-b", subject)
+	must.Eq(t, map[string]string{"team": "all", "tier": "a", "zone": "b"}, extras)
+
+	// the returned claims are a copy
+	extras["team"] = "changed"
+	must.Eq(t, "all", c.ExtraClaims["team"])
+}
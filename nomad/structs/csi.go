@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/cronexpr"
 	multierror "github.com/hashicorp/go-multierror"
 
 	"github.com/hashicorp/nomad/helper"
@@ -274,6 +275,12 @@ type CSIVolume struct {
 	Context    map[string]string
 	Capacity   int64 // bytes
 
+	// NodeExpansionRequired is set when the controller plugin expanded the
+	// volume but the node plugins must expand it as well. Clients expand the
+	// volume when they mount it, in case it wasn't claimed on their node at
+	// the time of the expansion.
+	NodeExpansionRequired bool
+
 	// These values are used only on volume creation but we record them
 	// so that we can diff the volume later
 	RequestedCapacityMin  int64 // bytes
@@ -282,6 +289,13 @@ type CSIVolume struct {
 	CloneID               string
 	SnapshotID            string
 
+	// SnapshotSchedule configures the snapshots of the volume the leader
+	// creates periodically. ManagedSnapshots are the snapshots it created
+	// and which haven't been pruned yet, from oldest to newest. This value
+	// cannot be set by the user.
+	SnapshotSchedule *CSIVolumeSnapshotSchedule
+	ManagedSnapshots []*CSISnapshot
+
 	// Allocations, tracking claim status
 	ReadAllocs  map[string]*Allocation // AllocID -> Allocation
 	WriteAllocs map[string]*Allocation // AllocID -> Allocation
@@ -536,6 +550,8 @@ func (v *CSIVolume) Copy() *CSIVolume {
 	for k, v := range v.Context {
 		out.Context[k] = v
 	}
+	out.SnapshotSchedule = v.SnapshotSchedule.Copy()
+	out.ManagedSnapshots = helper.CopySlice(v.ManagedSnapshots)
 
 	for k, alloc := range v.ReadAllocs {
 		out.ReadAllocs[k] = alloc.Copy()
@@ -749,6 +765,11 @@ func (v *CSIVolume) Validate() error {
 			}
 		}
 	}
+	if v.SnapshotSchedule != nil {
+		if err := v.SnapshotSchedule.Validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
 	if len(errs) > 0 {
 		return fmt.Errorf("validation: %s", strings.Join(errs, ", "))
 	}
@@ -845,9 +866,67 @@ func (v *CSIVolume) Merge(other *CSIVolume) error {
 	if len(other.Context) != 0 {
 		v.Context = other.Context
 	}
+
+	// The snapshot schedule can be updated freely, but the snapshots it
+	// created remain managed by Nomad
+	v.SnapshotSchedule = other.SnapshotSchedule
 	return errs.ErrorOrNil()
 }
 
+// CSIVolumeSnapshotSchedule configures the snapshots of a volume which are
+// created periodically by the leader.
+type CSIVolumeSnapshotSchedule struct {
+	// Cron is the cron expression of the snapshot times, in UTC.
+	Cron string
+
+	// Retain is the number of snapshots to keep. Once a snapshot is
+	// created, the oldest snapshots beyond Retain are deleted.
+	Retain int
+}
+
+func (s *CSIVolumeSnapshotSchedule) Copy() *CSIVolumeSnapshotSchedule {
+	if s == nil {
+		return nil
+	}
+	out := *s
+	return &out
+}
+
+func (s *CSIVolumeSnapshotSchedule) Validate() error {
+	errs := []string{}
+	if s.Cron == "" {
+		errs = append(errs, "snapshot schedule is missing cron expression")
+	} else if _, err := cronexpr.Parse(s.Cron); err != nil {
+		errs = append(errs, fmt.Sprintf("invalid snapshot schedule cron expression %q: %v", s.Cron, err))
+	}
+	if s.Retain < 1 {
+		errs = append(errs, fmt.Sprintf("snapshot schedule must retain at least 1 snapshot (got %d)", s.Retain))
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// Next returns the next snapshot time after the given time, or the zero time
+// if the cron expression never matches again.
+func (s *CSIVolumeSnapshotSchedule) Next(from time.Time) time.Time {
+	e, err := cronexpr.Parse(s.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return e.Next(from.UTC())
+}
+
+// LastManagedSnapshotTime returns the creation time of the newest snapshot
+// created by the snapshot schedule, or the zero time if there's none.
+func (v *CSIVolume) LastManagedSnapshotTime() time.Time {
+	if len(v.ManagedSnapshots) == 0 {
+		return time.Time{}
+	}
+	return time.Unix(v.ManagedSnapshots[len(v.ManagedSnapshots)-1].CreateTime, 0)
+}
+
 // Request and response wrappers
 type CSIVolumeRegisterRequest struct {
 	Volumes   []*CSIVolume
@@ -1047,6 +1126,25 @@ type CSISnapshot struct {
 	Parameters map[string]string
 }
 
+func (s *CSISnapshot) Copy() *CSISnapshot {
+	if s == nil {
+		return nil
+	}
+	out := *s
+	out.Secrets = maps.Clone(s.Secrets)
+	out.Parameters = maps.Clone(s.Parameters)
+	return &out
+}
+
+// CSIVolumeManagedSnapshotsRequest replaces the managed snapshots of a volume
+// once the leader created or pruned snapshots for its snapshot schedule.
+type CSIVolumeManagedSnapshotsRequest struct {
+	Namespace string
+	VolumeID  string
+	Snapshots []*CSISnapshot
+	WriteRequest
+}
+
 type CSISnapshotCreateRequest struct {
 	Snapshots []*CSISnapshot
 	WriteRequest
@@ -586,6 +586,54 @@ func TestCSIVolume_Validate(t *testing.T) {
 
 }
 
+func TestCSIVolumeSnapshotSchedule_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	vol := &CSIVolume{
+		ID:        "test",
+		PluginID:  "test",
+		Namespace: "default",
+		RequestedCapabilities: []*CSIVolumeCapability{{
+			AccessMode:     CSIVolumeAccessModeSingleNodeWriter,
+			AttachmentMode: CSIVolumeAttachmentModeFilesystem,
+		}},
+		SnapshotSchedule: &CSIVolumeSnapshotSchedule{Cron: "0 3 * * *", Retain: 7},
+	}
+	must.NoError(t, vol.Validate())
+
+	vol.SnapshotSchedule = &CSIVolumeSnapshotSchedule{}
+	must.EqError(t, vol.Validate(), "validation: snapshot schedule is missing cron expression, snapshot schedule must retain at least 1 snapshot (got 0)")
+
+	vol.SnapshotSchedule = &CSIVolumeSnapshotSchedule{Cron: "not cron", Retain: 1}
+	must.ErrorContains(t, vol.Validate(), `invalid snapshot schedule cron expression "not cron"`)
+}
+
+func TestCSIVolumeSnapshotSchedule_Next(t *testing.T) {
+	ci.Parallel(t)
+
+	s := &CSIVolumeSnapshotSchedule{Cron: "0 3 * * *", Retain: 1}
+	from := time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)
+	must.Eq(t, time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC), s.Next(from))
+
+	// The managed snapshots are kept on merge, but not the schedule
+	vol := &CSIVolume{
+		SnapshotSchedule: s,
+		ManagedSnapshots: []*CSISnapshot{{ID: "snap", CreateTime: from.Unix()}},
+	}
+	must.Eq(t, from, vol.LastManagedSnapshotTime().UTC())
+	must.NoError(t, vol.Merge(&CSIVolume{}))
+	must.Nil(t, vol.SnapshotSchedule)
+	must.Len(t, 1, vol.ManagedSnapshots)
+
+	// Copies don't share the managed snapshots
+	vol.SnapshotSchedule = s
+	out := vol.Copy()
+	out.ManagedSnapshots[0].ID = "other"
+	out.SnapshotSchedule.Retain = 2
+	must.Eq(t, "snap", vol.ManagedSnapshots[0].ID)
+	must.Eq(t, 1, vol.SnapshotSchedule.Retain)
+}
+
 func TestCSIVolume_Merge(t *testing.T) {
 	ci.Parallel(t)
 
@@ -74,7 +74,7 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	// See agent.ApiJobToStructJob Update is a default for TaskGroups
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
-	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
+	filter := []string{"ID", "Status", "StatusDescription", "Held", "Version", "Stable", "CreateIndex",
 		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID", "VaultToken"}
 
 	if j == nil && other == nil {
@@ -129,6 +129,17 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Dependencies diff
+	dependsOnDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.DependsOn),
+		interfaceSlice(other.DependsOn),
+		nil,
+		"DependsOn",
+		contextual)
+	if dependsOnDiff != nil {
+		diff.Objects = append(diff.Objects, dependsOnDiff...)
+	}
+
 	// Task groups diff
 	tgs, err := taskGroupDiffs(j.TaskGroups, other.TaskGroups, contextual)
 	if err != nil {
@@ -146,6 +157,11 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, cDiff)
 	}
 
+	// Array diff
+	if aDiff := primitiveObjectDiff(j.Array, other.Array, nil, "Array", contextual); aDiff != nil {
+		diff.Objects = append(diff.Objects, aDiff)
+	}
+
 	// Multiregion diff
 	if mrDiff := multiregionDiff(j.Multiregion, other.Multiregion, contextual); mrDiff != nil {
 		diff.Objects = append(diff.Objects, mrDiff)
@@ -287,12 +303,29 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Job affinities diff
+	jobAffinitiesDiff := primitiveObjectSetDiff(
+		interfaceSlice(tg.JobAffinities),
+		interfaceSlice(other.JobAffinities),
+		[]string{"str"},
+		"JobAffinity",
+		contextual)
+	if jobAffinitiesDiff != nil {
+		diff.Objects = append(diff.Objects, jobAffinitiesDiff...)
+	}
+
 	// Restart policy diff
 	rDiff := primitiveObjectDiff(tg.RestartPolicy, other.RestartPolicy, nil, "RestartPolicy", contextual)
 	if rDiff != nil {
 		diff.Objects = append(diff.Objects, rDiff)
 	}
 
+	// Restart budget diff
+	budgetDiff := primitiveObjectDiff(tg.RestartBudget, other.RestartBudget, nil, "RestartBudget", contextual)
+	if budgetDiff != nil {
+		diff.Objects = append(diff.Objects, budgetDiff)
+	}
+
 	// Migrate block diff.
 	migrateDiff := primitiveObjectDiff(tg.Migrate, other.Migrate, nil, "Migrate", contextual)
 	if migrateDiff != nil {
@@ -317,8 +350,7 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 	}
 
 	// Update diff
-	// COMPAT: Remove "Stagger" in 0.7.0.
-	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
+	if uDiff := updateStrategyDiff(tg.Update, other.Update, contextual); uDiff != nil {
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
@@ -504,7 +536,7 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(t.LogConfig, other.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(t.LogConfig, other.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -565,6 +597,11 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 		diff.Objects = append(diff.Objects, aDiffs...)
 	}
 
+	// Secrets diff
+	if sDiffs := secretDiffs(t.Secrets, other.Secrets, contextual); sDiffs != nil {
+		diff.Objects = append(diff.Objects, sDiffs...)
+	}
+
 	// volume_mount diff
 	if vDiffs := volumeMountsDiffs(t.VolumeMounts, other.VolumeMounts, contextual); vDiffs != nil {
 		diff.Objects = append(diff.Objects, vDiffs...)
@@ -609,6 +646,38 @@ func actionDiff(old, new *Action, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// secretDiffs diffs a set of secrets by name. If contextual diff is enabled,
+// unchanged fields within the secrets will be returned.
+func secretDiffs(old, new []*Secret, contextual bool) []*ObjectDiff {
+	oldMap := make(map[string]*Secret, len(old))
+	newMap := make(map[string]*Secret, len(new))
+	for _, s := range old {
+		oldMap[s.Name] = s
+	}
+	for _, s := range new {
+		newMap[s.Name] = s
+	}
+
+	var diffs []*ObjectDiff
+	for name, oldSecret := range oldMap {
+		// Diff the same, deleted and edited
+		if diff := primitiveObjectDiff(oldSecret, newMap[name], nil, "Secret", contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for name, newSecret := range newMap {
+		// Diff the added
+		if _, ok := oldMap[name]; !ok {
+			if diff := primitiveObjectDiff(nil, newSecret, nil, "Secret", contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
 // actionDiffs diffs a set of actions. If contextual diff is enabled, unchanged
 // fields within objects nested in the actions will be returned.
 func actionDiffs(old, new []*Action, contextual bool) []*ObjectDiff {
@@ -826,7 +895,12 @@ func serviceDiff(old, new *Service, contextual bool) *ObjectDiff {
 	}
 
 	// Weights diffs
-	if weightsDiffs := weightsDiff(old.Weights, new.Weights, contextual); weightsDiffs != nil {
+	if weightsDiffs := weightsDiff("Weights", old.Weights, new.Weights, contextual); weightsDiffs != nil {
+		diff.Objects = append(diff.Objects, weightsDiffs)
+	}
+
+	// Canary weights diffs
+	if weightsDiffs := weightsDiff("CanaryWeights", old.CanaryWeights, new.CanaryWeights, contextual); weightsDiffs != nil {
 		diff.Objects = append(diff.Objects, weightsDiffs)
 	}
 
@@ -1704,7 +1778,7 @@ func sidecarTaskDiff(old, new *SidecarTask, contextual bool) *ObjectDiff {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(old.LogConfig, new.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(old.LogConfig, new.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -1712,6 +1786,28 @@ func sidecarTaskDiff(old, new *SidecarTask, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// logConfigDiff returns the diff of two LogConfig objects, including the diff
+// of their sinks.
+func logConfigDiff(old, new *LogConfig, contextual bool) *ObjectDiff {
+	diff := primitiveObjectDiff(old, new, nil, "LogConfig", contextual)
+
+	var oldSink, newSink *LogSink
+	if old != nil {
+		oldSink = old.Sink
+	}
+	if new != nil {
+		newSink = new.Sink
+	}
+
+	if sDiff := primitiveObjectDiff(oldSink, newSink, nil, "Sink", contextual); sDiff != nil {
+		if diff == nil {
+			diff = &ObjectDiff{Type: DiffTypeEdited, Name: "LogConfig"}
+		}
+		diff.Objects = append(diff.Objects, sDiff)
+	}
+	return diff
+}
+
 // consulProxyDiff returns the diff of two ConsulProxy objects.
 // If contextual diff is enabled, all fields will be returned, even if no diff occurred.
 func consulProxyDiff(old, new *ConsulProxy, contextual bool) *ObjectDiff {
@@ -1968,6 +2064,44 @@ func vaultDiff(old, new *Vault, contextual bool) *ObjectDiff {
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
 
+	// SecretRotation diffs
+	var oldRotation, newRotation *VaultSecretRotation
+	if old != nil {
+		oldRotation = old.SecretRotation
+	}
+	if new != nil {
+		newRotation = new.SecretRotation
+	}
+	if rotationDiff := vaultSecretRotationDiff(oldRotation, newRotation, contextual); rotationDiff != nil {
+		diff.Objects = append(diff.Objects, rotationDiff)
+	}
+
+	return diff
+}
+
+// vaultSecretRotationDiff returns the diff of two VaultSecretRotation objects.
+// If contextual diff is enabled, all fields will be returned, even if no diff
+// occurred.
+func vaultSecretRotationDiff(old, new *VaultSecretRotation, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "SecretRotation"}
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &VaultSecretRotation{}
+		diff.Type = DiffTypeAdded
+	} else if new == nil {
+		new = &VaultSecretRotation{}
+		diff.Type = DiffTypeDeleted
+	} else {
+		diff.Type = DiffTypeEdited
+	}
+
+	if scriptDiff := changeScriptDiff(old.DrainScript, new.DrainScript, contextual); scriptDiff != nil {
+		scriptDiff.Name = "DrainScript"
+		diff.Objects = append(diff.Objects, scriptDiff)
+	}
+
 	return diff
 }
 
@@ -2030,6 +2164,39 @@ func changeScriptDiff(old, new *ChangeScript, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// templateExecDiff returns the diff of two TemplateExec objects. If contextual
+// diff is enabled, all fields will be returned, even if no diff occurred.
+func templateExecDiff(old, new *TemplateExec, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Exec"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &TemplateExec{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &TemplateExec{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Args diffs
+	if setDiff := stringSetDiff(old.Args, new.Args, "Args", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
+	return diff
+}
+
 // templateDiff returns the diff of two Consul Template objects. If contextual diff is
 // enabled, all fields will be returned, even if no diff occurred.
 func templateDiff(old, new *Template, contextual bool) *ObjectDiff {
@@ -2085,6 +2252,11 @@ func templateDiff(old, new *Template, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, changeScriptDiffs)
 	}
 
+	// Exec diffs
+	if execDiffs := templateExecDiff(old.Exec, new.Exec, contextual); execDiffs != nil {
+		diff.Objects = append(diff.Objects, execDiffs)
+	}
+
 	return diff
 }
 
@@ -2596,6 +2768,11 @@ func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, nDiff)
 	}
 
+	// Disk I/O limits diff
+	if dDiff := r.DiskIO.Diff(other.DiskIO, contextual); dDiff != nil {
+		diff.Objects = append(diff.Objects, dDiff)
+	}
+
 	return diff
 }
 
@@ -2604,7 +2781,8 @@ func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
 func (n *NetworkResource) Diff(other *NetworkResource, contextual bool) *ObjectDiff {
 	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Network"}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
-	filter := []string{"_struct", "Device", "CIDR", "IP", "MBits"}
+	filter := []string{"_struct", "Device", "CIDR", "IP", "MBits", "EgressMBits", "IngressMBits",
+		"IPv4Address", "IPv6Address"}
 
 	if reflect.DeepEqual(n, other) {
 		return nil
@@ -2622,6 +2800,31 @@ func (n *NetworkResource) Diff(other *NetworkResource, contextual bool) *ObjectD
 		newPrimitiveFlat = flatmap.Flatten(other, filter, true)
 	}
 
+	// Only diff the bandwidth limits of shaped networks, so that the zero
+	// values of unshaped networks don't show up in every diff
+	if n.Shaped() || other.Shaped() {
+		if diff.Type != DiffTypeAdded {
+			oldPrimitiveFlat["EgressMBits"] = strconv.Itoa(n.EgressMBits)
+			oldPrimitiveFlat["IngressMBits"] = strconv.Itoa(n.IngressMBits)
+		}
+		if diff.Type != DiffTypeDeleted {
+			newPrimitiveFlat["EgressMBits"] = strconv.Itoa(other.EgressMBits)
+			newPrimitiveFlat["IngressMBits"] = strconv.Itoa(other.IngressMBits)
+		}
+	}
+
+	// Likewise only diff the static addresses of networks that set one
+	if n.HasStaticAddress() || other.HasStaticAddress() {
+		if diff.Type != DiffTypeAdded {
+			oldPrimitiveFlat["IPv4Address"] = n.IPv4Address
+			oldPrimitiveFlat["IPv6Address"] = n.IPv6Address
+		}
+		if diff.Type != DiffTypeDeleted {
+			newPrimitiveFlat["IPv4Address"] = other.IPv4Address
+			newPrimitiveFlat["IPv6Address"] = other.IPv6Address
+		}
+	}
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
 
@@ -2643,6 +2846,10 @@ func (n *NetworkResource) Diff(other *NetworkResource, contextual bool) *ObjectD
 		diff.Objects = append(diff.Objects, cniDiff)
 	}
 
+	if setDiff := stringSetDiff(n.CNINetworks, other.CNINetworks, "CNINetworks", contextual); setDiff != nil && setDiff.Type != DiffTypeNone {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
 	return diff
 }
 
@@ -2846,6 +3053,30 @@ func (r *NUMA) Diff(other *NUMA, contextual bool) *ObjectDiff {
 	return diff
 }
 
+func (d *DiskIO) Diff(other *DiskIO, contextual bool) *ObjectDiff {
+	if d.Equal(other) {
+		return nil
+	}
+
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "DiskIO"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if d == nil {
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	} else if other == nil {
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(d, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(d, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	}
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	return diff
+}
+
 // Diff returns a diff of two requested devices. If contextual diff is enabled,
 // non-changed fields will still be returned.
 func (r *RequestedDevice) Diff(other *RequestedDevice, contextual bool) *ObjectDiff {
@@ -2962,7 +3193,7 @@ func idSliceDiffs(old, new []*WorkloadIdentity, contextual bool) []*ObjectDiff {
 	return diffs
 }
 
-func weightsDiff(oldWeights *ServiceWeights, newWeights *ServiceWeights, contextual bool) *ObjectDiff {
+func weightsDiff(name string, oldWeights *ServiceWeights, newWeights *ServiceWeights, contextual bool) *ObjectDiff {
 	if reflect.DeepEqual(oldWeights, newWeights) {
 		return nil
 	}
@@ -2978,7 +3209,7 @@ func weightsDiff(oldWeights *ServiceWeights, newWeights *ServiceWeights, context
 		return m
 	}
 
-	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Weights"}
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: name}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	if oldWeights == nil {
 		diff.Type = DiffTypeAdded
@@ -3025,6 +3256,10 @@ func idDiff(oldWI, newWI *WorkloadIdentity, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, audDiff)
 	}
 
+	if awsDiff := primitiveObjectDiff(oldWI.AWS, newWI.AWS, nil, "AWS", contextual); awsDiff != nil {
+		diff.Objects = append(diff.Objects, awsDiff)
+	}
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
 
@@ -3279,10 +3514,83 @@ func periodicDiff(old, new *PeriodicConfig, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, setDiff)
 	}
 
+	// Prohibit windows diff
+	prohibitDiff := primitiveObjectSetDiff(
+		interfaceSlice(old.Prohibit),
+		interfaceSlice(new.Prohibit),
+		nil,
+		"Prohibit",
+		contextual)
+	if prohibitDiff != nil {
+		diff.Objects = append(diff.Objects, prohibitDiff...)
+	}
+
 	sort.Sort(FieldDiffs(diff.Fields))
 	return diff
 }
 
+// updateStrategyDiff returns the diff of two update strategies, including
+// their canary steps and verifications.
+func updateStrategyDiff(old, new *UpdateStrategy, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Update"}
+	var oldUpdateFlat, newUpdateFlat map[string]string
+
+	// COMPAT: Remove "Stagger" in 0.7.0.
+	filter := []string{"Stagger"}
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &UpdateStrategy{}
+		diff.Type = DiffTypeAdded
+		newUpdateFlat = flatmap.Flatten(new, filter, true)
+	} else if new == nil {
+		new = &UpdateStrategy{}
+		diff.Type = DiffTypeDeleted
+		oldUpdateFlat = flatmap.Flatten(old, filter, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldUpdateFlat = flatmap.Flatten(old, filter, true)
+		newUpdateFlat = flatmap.Flatten(new, filter, true)
+	}
+
+	// The canary steps are ordered, so diff them as a single field
+	if len(old.CanarySteps) != 0 {
+		oldUpdateFlat["CanarySteps"] = canaryStepsString(old.CanarySteps)
+	}
+	if len(new.CanarySteps) != 0 {
+		newUpdateFlat["CanarySteps"] = canaryStepsString(new.CanarySteps)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldUpdateFlat, newUpdateFlat, contextual)
+
+	if vDiff := primitiveObjectDiff(old.Verify, new.Verify, nil, "Verify", contextual); vDiff != nil {
+		diff.Objects = append(diff.Objects, vDiff)
+	}
+
+	// Ignore the changes of the filtered fields
+	if diff.Type == DiffTypeEdited && len(diff.Objects) == 0 {
+		edited := false
+		for _, f := range diff.Fields {
+			edited = edited || f.Type != DiffTypeNone
+		}
+		if !edited {
+			return nil
+		}
+	}
+
+	sort.Sort(FieldDiffs(diff.Fields))
+	return diff
+}
+
+func canaryStepsString(steps []int) string {
+	s := make([]string, len(steps))
+	for i, step := range steps {
+		s[i] = strconv.Itoa(step)
+	}
+	return strings.Join(s, ",")
+}
+
 // primitiveObjectDiff returns a diff of the passed objects' primitive fields.
 // The filter field can be used to exclude fields from the diff. The name is the
 // name of the objects. If contextual is set, non-changed fields will also be
@@ -509,6 +509,69 @@ func TestJobDiff(t *testing.T) {
 				Type: DiffTypeNone,
 			},
 		},
+		{
+			// Array edited
+			Old: &Job{
+				Array: &JobArray{Count: 10},
+			},
+			New: &Job{
+				Array: &JobArray{Count: 20},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Array",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Count",
+								Old:  "10",
+								New:  "20",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			// DependsOn added
+			Old: &Job{
+				DependsOn: []*JobDependency{
+					{JobID: "extract", Status: JobDependencyStatusComplete},
+				},
+			},
+			New: &Job{
+				DependsOn: []*JobDependency{
+					{JobID: "extract", Status: JobDependencyStatusComplete},
+					{JobID: "cleanup", Status: JobDependencyStatusDead},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "DependsOn",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "JobID",
+								Old:  "",
+								New:  "cleanup",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Status",
+								Old:  "",
+								New:  "dead",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Periodic added
 			Old: &Job{},
@@ -534,6 +597,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "HistoryLimit",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ProhibitOverlap",
@@ -588,6 +657,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "HistoryLimit",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ProhibitOverlap",
@@ -656,6 +731,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "HistoryLimit",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "ProhibitOverlap",
@@ -747,6 +828,61 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Periodic prohibit window added
+			Old: &Job{
+				Periodic: &PeriodicConfig{
+					Enabled:  true,
+					Spec:     "@hourly",
+					SpecType: "cron",
+				},
+			},
+			New: &Job{
+				Periodic: &PeriodicConfig{
+					Enabled:  true,
+					Spec:     "@hourly",
+					SpecType: "cron",
+					Prohibit: []*PeriodicProhibit{
+						{Start: "0 0 * * SAT", Duration: time.Hour, Action: "defer"},
+					},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Periodic",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Prohibit",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Action",
+										Old:  "",
+										New:  "defer",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Duration",
+										Old:  "",
+										New:  "3600000000000",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Start",
+										Old:  "",
+										New:  "0 0 * * SAT",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Periodic single to multiple times
 			Old: &Job{
@@ -1027,12 +1163,24 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "ConcurrencyPolicy",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Enabled",
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "HistoryLimit",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "ProhibitOverlap",
@@ -1263,6 +1411,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxConcurrent",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Payload",
@@ -1317,6 +1471,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxConcurrent",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Payload",
@@ -1450,12 +1610,24 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "MaxConcurrent",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Payload",
 								Old:  DispatchPayloadRequired,
 								New:  DispatchPayloadOptional,
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "QueuePolicy",
+								Old:  "",
+								New:  "",
+							},
 						},
 						Objects: []*ObjectDiff{
 							{
@@ -2448,6 +2620,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "AvoidNodeWindow",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Delay",
@@ -2466,6 +2644,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "15000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Jitter",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "MaxDelay",
@@ -2509,6 +2693,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "AvoidNodeWindow",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Delay",
@@ -2527,6 +2717,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "15000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Jitter",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "MaxDelay",
@@ -2636,6 +2832,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "AvoidNodeWindow",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Delay",
@@ -2654,6 +2856,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "2000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Jitter",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "MaxDelay",
@@ -2698,12 +2906,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "true",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "BakeTime",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Canary",
 								Old:  "0",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "DecommissionDelay",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "HealthyDeadline",
@@ -2760,12 +2980,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "BakeTime",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Canary",
 								Old:  "",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "DecommissionDelay",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "HealthyDeadline",
@@ -2795,6 +3027,110 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestCase: "Update strategy verify added",
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					Canary: 1,
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					Canary: 1,
+					Verify: &UpdateVerify{
+						Type:     UpdateVerifyTypeHTTP,
+						Address:  "http://127.0.0.1:8080/health",
+						Checks:   2,
+						Interval: 10 * time.Second,
+						Timeout:  5 * time.Second,
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Verify",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Address",
+										Old:  "",
+										New:  "http://127.0.0.1:8080/health",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Checks",
+										Old:  "",
+										New:  "2",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Interval",
+										Old:  "",
+										New:  "10000000000",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Timeout",
+										Old:  "",
+										New:  "5000000000",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Type",
+										Old:  "",
+										New:  "http",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "Update strategy canary steps edited",
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					CanarySteps: []int{10, 50},
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					CanarySteps: []int{10, 25, 50},
+					BakeTime:    time.Minute,
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "BakeTime",
+								Old:  "0",
+								New:  "60000000000",
+							},
+							{
+								Type: DiffTypeEdited,
+								Name: "CanarySteps",
+								Old:  "10,50",
+								New:  "10,25,50",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			TestCase: "Update strategy edited",
 			Old: &TaskGroup{
@@ -2927,12 +3263,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "true",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "BakeTime",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Canary",
 								Old:  "2",
 								New:  "2",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "DecommissionDelay",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "HealthCheck",
@@ -3736,6 +4084,18 @@ func TestTaskGroupDiff(t *testing.T) {
 										Old:  "3",
 										New:  "5",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "TCPUseTLS",
+										Old:  "false",
+										New:  "false",
+									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSCACert",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeNone,
 										Name: "TLSServerName",
@@ -5067,6 +5427,131 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{TestCase: "TaskGroup network bandwidth added",
+			Contextual: false,
+			Old: &TaskGroup{
+				Networks: Networks{},
+			},
+			New: &TaskGroup{
+				Networks: Networks{
+					{
+						Mode:        "bridge",
+						EgressMBits: 100,
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "Network",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "EgressMBits",
+								Old:  "",
+								New:  "100",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "IngressMBits",
+								Old:  "",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Mode",
+								Old:  "",
+								New:  "bridge",
+							},
+						},
+					},
+				},
+			},
+		},
+		{TestCase: "TaskGroup CNI networks added",
+			Contextual: false,
+			Old: &TaskGroup{
+				Networks: Networks{},
+			},
+			New: &TaskGroup{
+				Networks: Networks{
+					{
+						Mode:        "bridge",
+						CNINetworks: []string{"storage"},
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "Network",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Mode",
+								Old:  "",
+								New:  "bridge",
+							},
+						},
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "CNINetworks",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "CNINetworks",
+										Old:  "",
+										New:  "storage",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{TestCase: "TaskGroup static address added",
+			Contextual: false,
+			Old: &TaskGroup{
+				Networks: Networks{},
+			},
+			New: &TaskGroup{
+				Networks: Networks{
+					{
+						Mode:        "bridge",
+						IPv4Address: "172.26.64.10",
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "Network",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "IPv4Address",
+								Old:  "",
+								New:  "172.26.64.10",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Mode",
+								Old:  "",
+								New:  "bridge",
+							},
+						},
+					},
+				},
+			},
+		},
 		{TestCase: "TaskGroup CNI deleted",
 			Contextual: false,
 			Old: &TaskGroup{
@@ -5846,20 +6331,72 @@ func TestTaskDiff(t *testing.T) {
 			},
 		},
 		{
-			Name:       "LogConfig edited with context",
-			Contextual: true,
+			Name:       "LogConfig edited with context",
+			Contextual: true,
+			Old: &Task{
+				LogConfig: &LogConfig{
+					MaxFiles:      1,
+					MaxFileSizeMB: 10,
+					Disabled:      false,
+				},
+			},
+			New: &Task{
+				LogConfig: &LogConfig{
+					MaxFiles:      1,
+					MaxFileSizeMB: 20,
+					Disabled:      true,
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "LogConfig",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Disabled",
+								Old:  "false",
+								New:  "true",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "Format",
+							},
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxFileSizeMB",
+								Old:  "10",
+								New:  "20",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxFiles",
+								Old:  "1",
+								New:  "1",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "LogConfig sink added",
 			Old: &Task{
 				LogConfig: &LogConfig{
 					MaxFiles:      1,
 					MaxFileSizeMB: 10,
-					Disabled:      false,
 				},
 			},
 			New: &Task{
 				LogConfig: &LogConfig{
 					MaxFiles:      1,
-					MaxFileSizeMB: 20,
-					Disabled:      true,
+					MaxFileSizeMB: 10,
+					Sink: &LogSink{
+						Type:    LogSinkTypeFluentd,
+						Address: "127.0.0.1:24224",
+					},
 				},
 			},
 			Expected: &TaskDiff{
@@ -5868,24 +6405,24 @@ func TestTaskDiff(t *testing.T) {
 					{
 						Type: DiffTypeEdited,
 						Name: "LogConfig",
-						Fields: []*FieldDiff{
-							{
-								Type: DiffTypeEdited,
-								Name: "Disabled",
-								Old:  "false",
-								New:  "true",
-							},
-							{
-								Type: DiffTypeEdited,
-								Name: "MaxFileSizeMB",
-								Old:  "10",
-								New:  "20",
-							},
+						Objects: []*ObjectDiff{
 							{
-								Type: DiffTypeNone,
-								Name: "MaxFiles",
-								Old:  "1",
-								New:  "1",
+								Type: DiffTypeAdded,
+								Name: "Sink",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Address",
+										Old:  "",
+										New:  "127.0.0.1:24224",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Type",
+										Old:  "",
+										New:  "fluentd",
+									},
+								},
 							},
 						},
 					},
@@ -6144,6 +6681,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "200",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "CPUMax",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Cores",
@@ -6253,6 +6796,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "100",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "CPUMax",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Cores",
@@ -6620,6 +7169,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "100",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "CPUMax",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Cores",
@@ -7569,6 +8124,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "",
 										New:  "2",
 									},
+									{
+										Type: DiffTypeAdded,
+										Name: "TCPUseTLS",
+										Old:  "",
+										New:  "false",
+									},
 									{
 										Type: DiffTypeAdded,
 										Name: "TLSSkipVerify",
@@ -7653,6 +8214,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "1",
 										New:  "",
 									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "TCPUseTLS",
+										Old:  "false",
+										New:  "",
+									},
 									{
 										Type: DiffTypeDeleted,
 										Name: "TLSSkipVerify",
@@ -7929,6 +8496,18 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "4",
 										New:  "4",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "TCPUseTLS",
+										Old:  "false",
+										New:  "false",
+									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSCACert",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeNone,
 										Name: "TLSServerName",
@@ -8380,6 +8959,66 @@ func TestTaskDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Vault secret rotation added",
+			Old: &Task{
+				Vault: &Vault{
+					ChangeMode: "restart",
+				},
+			},
+			New: &Task{
+				Vault: &Vault{
+					ChangeMode: "restart",
+					SecretRotation: &VaultSecretRotation{
+						DrainScript: &ChangeScript{
+							Command: "/bin/drain",
+							Timeout: 5,
+						},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Vault",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "SecretRotation",
+								Objects: []*ObjectDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "DrainScript",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeAdded,
+												Name: "Command",
+												Old:  "",
+												New:  "/bin/drain",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "FailOnError",
+												Old:  "",
+												New:  "false",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "Timeout",
+												Old:  "",
+												New:  "5",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "Template edited",
 			Old: &Task{
@@ -8549,6 +9188,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "FirstRenderTimeout",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Gid",
@@ -8585,6 +9230,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "1002",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "UnsetIfEmpty",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "VaultGrace",
@@ -8691,6 +9342,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "FirstRenderTimeout",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Gid",
@@ -8727,6 +9384,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "UnsetIfEmpty",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "VaultGrace",
@@ -9248,6 +9911,136 @@ func TestTaskDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Secrets edited",
+			Old: &Task{
+				Secrets: []*Secret{
+					{Name: "db", Path: "db/creds"},
+					{Name: "tls", Path: "tls"},
+				},
+			},
+			New: &Task{
+				Secrets: []*Secret{
+					{Name: "db", Path: "db/creds", Env: true},
+					{Name: "api", Path: "api/key"},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Secret",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Env",
+								Old:  "false",
+								New:  "true",
+							},
+						},
+					},
+					{
+						Type: DiffTypeAdded,
+						Name: "Secret",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Env",
+								Old:  "",
+								New:  "false",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Name",
+								Old:  "",
+								New:  "api",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Path",
+								Old:  "",
+								New:  "api/key",
+							},
+						},
+					},
+					{
+						Type: DiffTypeDeleted,
+						Name: "Secret",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Env",
+								Old:  "false",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Name",
+								Old:  "tls",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Path",
+								Old:  "tls",
+								New:  "",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "Identity aws edited",
+			Old: &Task{
+				Identities: []*WorkloadIdentity{
+					{
+						Name: "s3",
+						AWS: &WorkloadIdentityAWS{
+							RoleARN: "arn:aws:iam::123456789012:role/s3",
+							Region:  "us-east-1",
+							Profile: "default",
+						},
+					},
+				},
+			},
+			New: &Task{
+				Identities: []*WorkloadIdentity{
+					{
+						Name: "s3",
+						AWS: &WorkloadIdentityAWS{
+							RoleARN: "arn:aws:iam::123456789012:role/s3",
+							Region:  "eu-west-1",
+							Profile: "default",
+						},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Identity",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "AWS",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Region",
+										Old:  "us-east-1",
+										New:  "eu-west-1",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "Actions added",
 			Old:  &Task{},
@@ -9516,6 +10309,10 @@ func TestServicesDiff(t *testing.T) {
 						Passing: 5,
 						Warning: 1,
 					},
+					CanaryWeights: &ServiceWeights{
+						Passing: 1,
+						Warning: 1,
+					},
 				},
 			},
 			Expected: []*ObjectDiff{
@@ -9628,6 +10425,22 @@ func TestServicesDiff(t *testing.T) {
 								},
 							},
 						},
+						{
+							Type: DiffTypeAdded,
+							Name: "CanaryWeights",
+							Fields: []*FieldDiff{
+								{
+									Type: DiffTypeAdded,
+									Name: "Passing",
+									New:  "1",
+								},
+								{
+									Type: DiffTypeAdded,
+									Name: "Warning",
+									New:  "1",
+								},
+							},
+						},
 					},
 				},
 			},
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// DiskIO is the disk I/O limits of a task on the block device of the
+// allocation directory. A zero limit means the task is unlimited.
+type DiskIO struct {
+	// msgpack omit empty fields during serialization
+	_struct bool `codec:",omitempty"` // nolint: structcheck
+
+	// ReadBPS and WriteBPS are the limits in bytes per second.
+	ReadBPS  int64
+	WriteBPS int64
+
+	// ReadIOPS and WriteIOPS are the limits in I/O operations per second.
+	ReadIOPS  int64
+	WriteIOPS int64
+}
+
+func (d *DiskIO) Copy() *DiskIO {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	return &c
+}
+
+func (d *DiskIO) Equal(o *DiskIO) bool {
+	if d == nil || o == nil {
+		return d == o
+	}
+	return *d == *o
+}
+
+func (d *DiskIO) Validate() error {
+	if d == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if d.ReadBPS < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("disk_io read_bps cannot be negative"))
+	}
+	if d.WriteBPS < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("disk_io write_bps cannot be negative"))
+	}
+	if d.ReadIOPS < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("disk_io read_iops cannot be negative"))
+	}
+	if d.WriteIOPS < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("disk_io write_iops cannot be negative"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Limited returns true if any of the disk I/O limits is set.
+func (d *DiskIO) Limited() bool {
+	if d == nil {
+		return false
+	}
+	return d.ReadBPS > 0 || d.WriteBPS > 0 || d.ReadIOPS > 0 || d.WriteIOPS > 0
+}
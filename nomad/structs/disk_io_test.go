@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestDiskIO_Equal(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Equal[*DiskIO](t, nil, nil)
+	must.NotEqual[*DiskIO](t, nil, new(DiskIO))
+	must.Equal(t, &DiskIO{ReadBPS: 1}, &DiskIO{ReadBPS: 1})
+	must.NotEqual(t, &DiskIO{ReadBPS: 1}, &DiskIO{WriteBPS: 1})
+}
+
+func TestDiskIO_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	var d *DiskIO
+	must.NoError(t, d.Validate())
+	must.NoError(t, (&DiskIO{ReadBPS: 1024, WriteIOPS: 10}).Validate())
+
+	err := (&DiskIO{ReadBPS: -1, WriteIOPS: -1}).Validate()
+	must.ErrorContains(t, err, "read_bps cannot be negative")
+	must.ErrorContains(t, err, "write_iops cannot be negative")
+}
+
+func TestDiskIO_Limited(t *testing.T) {
+	ci.Parallel(t)
+
+	var d *DiskIO
+	must.False(t, d.Limited())
+	must.False(t, new(DiskIO).Limited())
+	must.True(t, (&DiskIO{WriteBPS: 1}).Limited())
+}
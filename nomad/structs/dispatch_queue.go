@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"cmp"
+	"slices"
+)
+
+// QueuedDispatch is a dispatched job waiting in the dispatch queue of its
+// parameterized job.
+type QueuedDispatch struct {
+	ID          string
+	Namespace   string
+	Priority    int
+	SubmitTime  int64
+	CreateIndex uint64
+
+	// Position is the position of the job in the queue, starting at 1 for the
+	// next job to run.
+	Position int
+}
+
+// JobDispatchQueue is the dispatch queue of a parameterized job.
+type JobDispatchQueue struct {
+	JobID     string
+	Namespace string
+
+	// MaxConcurrent is the number of dispatched jobs which may run at the
+	// same time.
+	MaxConcurrent int
+
+	// Running is the number of dispatched jobs which aren't queued nor dead.
+	Running int
+
+	// Queued are the queued dispatched jobs, in the order they run.
+	Queued []*QueuedDispatch
+}
+
+// JobDispatchQueueResponse is used to return the dispatch queue of a
+// parameterized job.
+type JobDispatchQueueResponse struct {
+	Queue *JobDispatchQueue
+	QueryMeta
+}
+
+// JobDispatchCancelRequest is used to cancel a dispatched job waiting in the
+// dispatch queue of its parameterized job.
+type JobDispatchCancelRequest struct {
+	// JobID is the ID of the queued dispatched job to cancel.
+	JobID string
+	WriteRequest
+}
+
+// JobDispatchCancelResponse is used to respond to a dispatch cancel request.
+type JobDispatchCancelResponse struct {
+	WriteMeta
+}
+
+// SortDispatchQueue sorts the queued dispatched jobs in the order they run
+// according to the queue policy of their parameterized job.
+func SortDispatchQueue(policy string, jobs []*Job) {
+	slices.SortStableFunc(jobs, func(a, b *Job) int {
+		if policy == DispatchQueuePolicyPriority && a.Priority != b.Priority {
+			return cmp.Compare(b.Priority, a.Priority)
+		}
+		return cmp.Compare(a.CreateIndex, b.CreateIndex)
+	})
+}
+
+// NewJobDispatchQueue returns the dispatch queue of the parameterized job from
+// the number of its running dispatched jobs and its sorted queued dispatched
+// jobs.
+func NewJobDispatchQueue(parent *Job, running int, jobs []*Job) *JobDispatchQueue {
+	queued := make([]*QueuedDispatch, len(jobs))
+	for i, job := range jobs {
+		queued[i] = &QueuedDispatch{
+			ID:          job.ID,
+			Namespace:   job.Namespace,
+			Priority:    job.Priority,
+			SubmitTime:  job.SubmitTime,
+			CreateIndex: job.CreateIndex,
+			Position:    i + 1,
+		}
+	}
+	return &JobDispatchQueue{
+		JobID:         parent.ID,
+		Namespace:     parent.Namespace,
+		MaxConcurrent: parent.ParameterizedJob.MaxConcurrent,
+		Running:       running,
+		Queued:        queued,
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSortDispatchQueue(t *testing.T) {
+	ci.Parallel(t)
+
+	jobs := func() []*Job {
+		return []*Job{
+			{ID: "a", Priority: 50, CreateIndex: 10},
+			{ID: "b", Priority: 70, CreateIndex: 11},
+			{ID: "c", Priority: 50, CreateIndex: 9},
+			{ID: "d", Priority: 70, CreateIndex: 12},
+		}
+	}
+	ids := func(jobs []*Job) []string {
+		var ids []string
+		for _, job := range jobs {
+			ids = append(ids, job.ID)
+		}
+		return ids
+	}
+
+	fifo := jobs()
+	SortDispatchQueue(DispatchQueuePolicyFIFO, fifo)
+	must.Eq(t, []string{"c", "a", "b", "d"}, ids(fifo))
+
+	priority := jobs()
+	SortDispatchQueue(DispatchQueuePolicyPriority, priority)
+	must.Eq(t, []string{"b", "d", "c", "a"}, ids(priority))
+
+	parent := &Job{ID: "p", ParameterizedJob: &ParameterizedJobConfig{MaxConcurrent: 2}}
+	queue := NewJobDispatchQueue(parent, 2, priority)
+	must.Eq(t, 2, queue.MaxConcurrent)
+	must.Len(t, 4, queue.Queued)
+	must.Eq(t, "b", queue.Queued[0].ID)
+	must.Eq(t, 4, queue.Queued[3].Position)
+}
+
+func TestParameterizedJobConfig_Validate_Queue(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &ParameterizedJobConfig{MaxConcurrent: 2}
+	d.Canonicalize()
+	must.Eq(t, DispatchQueuePolicyFIFO, d.QueuePolicy)
+	must.NoError(t, d.Validate())
+
+	d = &ParameterizedJobConfig{Payload: DispatchPayloadOptional, MaxConcurrent: -1, QueuePolicy: "lifo"}
+	err := d.Validate()
+	must.ErrorContains(t, err, "Max concurrent must not be negative")
+	must.ErrorContains(t, err, `Unknown queue policy: "lifo"`)
+}
@@ -16,6 +16,7 @@ const (
 	errNoRegionPath               = "No path to region"
 	errTokenNotFound              = "ACL token not found"
 	errTokenExpired               = "ACL token expired"
+	errTokenNotYetValid           = "ACL token is not yet valid"
 	errTokenInvalid               = "ACL token is invalid" // not a UUID
 	errPermissionDenied           = "Permission denied"
 	errJobRegistrationDisabled    = "Job registration, dispatch, and scale are disabled by the scheduler configuration"
@@ -54,6 +55,7 @@ var (
 	ErrNoRegionPath               = errors.New(errNoRegionPath)
 	ErrTokenNotFound              = errors.New(errTokenNotFound)
 	ErrTokenExpired               = errors.New(errTokenExpired)
+	ErrTokenNotYetValid           = errors.New(errTokenNotYetValid)
 	ErrTokenInvalid               = errors.New(errTokenInvalid)
 	ErrPermissionDenied           = errors.New(errPermissionDenied)
 	ErrJobRegistrationDisabled    = errors.New(errJobRegistrationDisabled)
@@ -19,23 +19,26 @@ type EventStreamWrapper struct {
 type Topic string
 
 const (
-	TopicDeployment     Topic = "Deployment"
-	TopicEvaluation     Topic = "Evaluation"
-	TopicAllocation     Topic = "Allocation"
-	TopicJob            Topic = "Job"
-	TopicNode           Topic = "Node"
-	TopicNodePool       Topic = "NodePool"
-	TopicACLPolicy      Topic = "ACLPolicy"
-	TopicACLToken       Topic = "ACLToken"
-	TopicACLRole        Topic = "ACLRole"
-	TopicACLAuthMethod  Topic = "ACLAuthMethod"
-	TopicACLBindingRule Topic = "ACLBindingRule"
-	TopicService        Topic = "Service"
-	TopicHostVolume     Topic = "HostVolume"
-	TopicCSIVolume      Topic = "CSIVolume"
-	TopicCSIPlugin      Topic = "CSIPlugin"
-	TopicOperator       Topic = "Operator"
-	TopicAll            Topic = "*"
+	TopicDeployment      Topic = "Deployment"
+	TopicEvaluation      Topic = "Evaluation"
+	TopicAllocation      Topic = "Allocation"
+	TopicJob             Topic = "Job"
+	TopicNode            Topic = "Node"
+	TopicNodePool        Topic = "NodePool"
+	TopicNodeMaintenance Topic = "NodeMaintenance"
+	TopicACLPolicy       Topic = "ACLPolicy"
+	TopicACLToken        Topic = "ACLToken"
+	TopicACLRole         Topic = "ACLRole"
+	TopicACLAuthMethod   Topic = "ACLAuthMethod"
+	TopicACLBindingRule  Topic = "ACLBindingRule"
+	TopicService         Topic = "Service"
+	TopicHostVolume      Topic = "HostVolume"
+	TopicCSIVolume       Topic = "CSIVolume"
+	TopicCSIPlugin       Topic = "CSIPlugin"
+	TopicOperator        Topic = "Operator"
+	TopicVariable        Topic = "Variable"
+	TopicRootKey         Topic = "RootKey"
+	TopicAll             Topic = "*"
 
 	TypeNodeRegistration              = "NodeRegistration"
 	TypeNodeDeregistration            = "NodeDeregistration"
@@ -44,12 +47,17 @@ const (
 	TypeNodeEvent                     = "NodeStreamEvent"
 	TypeNodePoolUpserted              = "NodePoolUpserted"
 	TypeNodePoolDeleted               = "NodePoolDeleted"
+	TypeNodeMaintenanceWindowUpserted = "NodeMaintenanceWindowUpserted"
+	TypeNodeMaintenanceWindowDeleted  = "NodeMaintenanceWindowDeleted"
+	TypeNodeMaintenanceWindowStarted  = "NodeMaintenanceWindowStarted"
+	TypeNodeMaintenanceWindowEnded    = "NodeMaintenanceWindowEnded"
 	TypeDeploymentUpdate              = "DeploymentStatusUpdate"
 	TypeDeploymentPromotion           = "DeploymentPromotion"
 	TypeDeploymentAllocHealth         = "DeploymentAllocHealth"
 	TypeAllocationCreated             = "AllocationCreated"
 	TypeAllocationUpdated             = "AllocationUpdated"
 	TypeAllocationUpdateDesiredStatus = "AllocationUpdateDesiredStatus"
+	TypeAllocationPreempted           = "AllocationPreempted"
 	TypeEvalUpdated                   = "EvaluationUpdated"
 	TypeJobRegistered                 = "JobRegistered"
 	TypeJobDeregistered               = "JobDeregistered"
@@ -73,6 +81,13 @@ const (
 	TypeCSIVolumeDeregistered         = "CSIVolumeDeregistered"
 	TypeCSIVolumeClaim                = "CSIVolumeClaim"
 	TypeUtilizationSnapshotUpserted   = "UtilizationSnapshotUpserted"
+	TypeVariableUpserted              = "VariableUpserted"
+	TypeVariableDeleted               = "VariableDeleted"
+	TypeRootKeyUpserted               = "RootKeyUpserted"
+	TypeRootKeyPrepublished           = "RootKeyPrepublished"
+	TypeRootKeyActivated              = "RootKeyActivated"
+	TypeRootKeyDeactivated            = "RootKeyDeactivated"
+	TypeRootKeyDeleted                = "RootKeyDeleted"
 )
 
 // Event represents a change in Nomads state.
@@ -151,6 +166,30 @@ type NodePoolEvent struct {
 	NodePool *NodePool
 }
 
+// NodeMaintenanceWindowEvent holds a newly updated or deleted node
+// maintenance window.
+type NodeMaintenanceWindowEvent struct {
+	Window *NodeMaintenanceWindow
+}
+
+// NewNodeMaintenanceWindowEvent creates a new NodeMaintenanceWindowEvent for a
+// maintenance window moving from the before window, which may be nil, to the
+// after window. The event type reflects the start or end of the window.
+func NewNodeMaintenanceWindowEvent(before, after *NodeMaintenanceWindow) (string, *NodeMaintenanceWindowEvent) {
+	eventType := TypeNodeMaintenanceWindowUpserted
+	if before != nil {
+		switch {
+		case before.Status != NodeMaintenanceWindowStatusActive &&
+			after.Status == NodeMaintenanceWindowStatusActive:
+			eventType = TypeNodeMaintenanceWindowStarted
+		case before.Status == NodeMaintenanceWindowStatusActive &&
+			after.Status != NodeMaintenanceWindowStatusActive:
+			eventType = TypeNodeMaintenanceWindowEnded
+		}
+	}
+	return eventType, &NodeMaintenanceWindowEvent{Window: after}
+}
+
 type ACLTokenEvent struct {
 	ACLToken *ACLToken
 	secretID string
@@ -177,6 +216,44 @@ func (a *ACLTokenEvent) SecretID() string {
 	return a.secretID
 }
 
+// VariableEvent holds the metadata of a newly updated or deleted variable.
+// The encrypted items of the variable are never included in the event.
+type VariableEvent struct {
+	Variable *VariableMetadata
+}
+
+// NewVariableEvent takes the metadata of a variable and creates a new
+// VariableEvent. It removes the lock, whose ID allows releasing the lock.
+func NewVariableEvent(meta *VariableMetadata) *VariableEvent {
+	c := meta.Copy()
+	c.Lock = nil
+	return &VariableEvent{Variable: c}
+}
+
+// RootKeyEvent holds the metadata of a newly updated or deleted root key. The
+// wrapped key material is never included in the event.
+type RootKeyEvent struct {
+	RootKey *RootKeyMeta
+}
+
+// NewRootKeyEvent creates a new RootKeyEvent for a root key moving from the
+// state of the before key, which may be nil, to the state of the after key.
+// The event type reflects the transition between the states.
+func NewRootKeyEvent(before, after *RootKey) (string, *RootKeyEvent) {
+	eventType := TypeRootKeyUpserted
+	if before == nil || before.State != after.State {
+		switch after.State {
+		case RootKeyStatePrepublished:
+			eventType = TypeRootKeyPrepublished
+		case RootKeyStateActive:
+			eventType = TypeRootKeyActivated
+		case RootKeyStateInactive:
+			eventType = TypeRootKeyDeactivated
+		}
+	}
+	return eventType, &RootKeyEvent{RootKey: after.Meta()}
+}
+
 type ACLPolicyEvent struct {
 	ACLPolicy *ACLPolicy
 }
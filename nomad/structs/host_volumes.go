@@ -345,6 +345,23 @@ const (
 	HostVolumeAccessModeSingleNodeMultiWriter  VolumeAccessMode = "single-node-multi-writer"
 )
 
+// HostVolumeLimits caps the capacity of the dynamic host volumes on a node, in
+// total and per namespace. The capacity of a volume is its actual capacity, or
+// its requested minimum capacity if greater.
+type HostVolumeLimits struct {
+	MaxBytes          int64
+	NamespaceMaxBytes map[string]int64
+}
+
+func (l *HostVolumeLimits) Copy() *HostVolumeLimits {
+	if l == nil {
+		return nil
+	}
+	out := *l
+	out.NamespaceMaxBytes = maps.Clone(l.NamespaceMaxBytes)
+	return &out
+}
+
 // HostVolumeStub is used for responses for the list volumes endpoint
 type HostVolumeStub struct {
 	Namespace     string
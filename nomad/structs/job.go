@@ -250,6 +250,21 @@ func (j *Job) RequiredNUMA() set.Collection[string] {
 	return result
 }
 
+// RequiredDiskIO identifies which task groups, if any, within the job contain
+// tasks with disk I/O limits.
+func (j *Job) RequiredDiskIO() set.Collection[string] {
+	result := set.New[string](len(j.TaskGroups))
+	for _, tg := range j.TaskGroups {
+		for _, task := range tg.Tasks {
+			if task.Resources != nil && task.Resources.DiskIO.Limited() {
+				result.Insert(tg.Name)
+				break
+			}
+		}
+	}
+	return result
+}
+
 // RequiredBridgeNetwork identifies which task groups, if any, within the job
 // contain networks requesting bridge networking.
 func (j *Job) RequiredBridgeNetwork() set.Collection[string] {
@@ -262,6 +277,21 @@ func (j *Job) RequiredBridgeNetwork() set.Collection[string] {
 	return result
 }
 
+// RequiredNetworkShaping identifies which task groups, if any, within the job
+// contain networks with bandwidth limits.
+func (j *Job) RequiredNetworkShaping() set.Collection[string] {
+	result := set.New[string](len(j.TaskGroups))
+	for _, tg := range j.TaskGroups {
+		for _, net := range tg.Networks {
+			if net.Shaped() {
+				result.Insert(tg.Name)
+				break
+			}
+		}
+	}
+	return result
+}
+
 // RequiredTransparentProxy identifies which task groups, if any, within the job
 // contain Connect blocks using transparent proxy
 func (j *Job) RequiredTransparentProxy() set.Collection[string] {
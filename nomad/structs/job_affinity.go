@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// JobAffinity is used to place allocations of a task group on, or away from,
+// nodes running allocations of another job or of another service in the same
+// namespace.
+type JobAffinity struct {
+	// Job is the ID of the job whose allocations are matched.
+	Job string
+
+	// Service is the name of the service whose allocations are matched.
+	Service string
+
+	// Weight is the weight of the affinity when scoring nodes. A negative
+	// weight expresses an anti-affinity.
+	Weight int8
+
+	// Required makes the affinity a feasibility requirement instead of a
+	// scoring preference. Nodes that don't run a matching allocation are
+	// infeasible for a positive weight, while nodes running one are
+	// infeasible for a negative weight.
+	Required bool
+
+	// Memoized string representation
+	str string
+}
+
+// Copy returns a copy of the job affinity.
+func (a *JobAffinity) Copy() *JobAffinity {
+	if a == nil {
+		return nil
+	}
+	na := new(JobAffinity)
+	*na = *a
+	return na
+}
+
+// CopySliceJobAffinities returns a copy of the job affinities.
+func CopySliceJobAffinities(s []*JobAffinity) []*JobAffinity {
+	l := len(s)
+	if l == 0 {
+		return nil
+	}
+
+	c := make([]*JobAffinity, l)
+	for i, v := range s {
+		c[i] = v.Copy()
+	}
+	return c
+}
+
+// Equal returns whether both job affinities are the same.
+func (a *JobAffinity) Equal(o *JobAffinity) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	return a.Job == o.Job &&
+		a.Service == o.Service &&
+		a.Weight == o.Weight &&
+		a.Required == o.Required
+}
+
+// IsAntiAffinity returns whether the job affinity keeps allocations away from
+// nodes running matching allocations.
+func (a *JobAffinity) IsAntiAffinity() bool {
+	return a.Weight < 0
+}
+
+func (a *JobAffinity) String() string {
+	if a.str != "" {
+		return a.str
+	}
+
+	kind := "job_affinity"
+	if a.IsAntiAffinity() {
+		kind = "job_anti_affinity"
+	}
+	target := fmt.Sprintf("job %q", a.Job)
+	if a.Service != "" {
+		target = fmt.Sprintf("service %q", a.Service)
+	}
+	a.str = fmt.Sprintf("%s %s %v", kind, target, a.Weight)
+	return a.str
+}
+
+// Validate returns an error if the job affinity is invalid.
+func (a *JobAffinity) Validate() error {
+	var mErr multierror.Error
+
+	switch {
+	case a.Job == "" && a.Service == "":
+		mErr.Errors = append(mErr.Errors, errors.New("Job affinity requires a job or a service"))
+	case a.Job != "" && a.Service != "":
+		mErr.Errors = append(mErr.Errors, errors.New("Job affinity can't match both a job and a service"))
+	}
+
+	if a.Weight == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Job affinity weight cannot be zero"))
+	}
+	if a.Weight > 100 || a.Weight < -100 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Job affinity weight must be within the range [-100,100]"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Matches returns whether the allocation is matched by the job affinity of a
+// job in the given namespace.
+func (a *JobAffinity) Matches(namespace string, alloc *Allocation) bool {
+	if alloc == nil || alloc.Namespace != namespace || alloc.TerminalStatus() {
+		return false
+	}
+
+	if a.Job != "" {
+		return alloc.JobID == a.Job
+	}
+
+	if alloc.Job == nil {
+		return false
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return false
+	}
+	for _, service := range tg.Services {
+		if service.Name == a.Service {
+			return true
+		}
+	}
+	for _, task := range tg.Tasks {
+		for _, service := range task.Services {
+			if service.Name == a.Service {
+				return true
+			}
+		}
+	}
+	return false
+}
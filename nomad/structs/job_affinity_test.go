@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobAffinity_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		affinity    *JobAffinity
+		expectedErr string
+	}{
+		{
+			name:     "valid job",
+			affinity: &JobAffinity{Job: "cache", Weight: 50},
+		},
+		{
+			name:     "valid service",
+			affinity: &JobAffinity{Service: "redis", Weight: -100, Required: true},
+		},
+		{
+			name:        "missing target",
+			affinity:    &JobAffinity{Weight: 50},
+			expectedErr: "requires a job or a service",
+		},
+		{
+			name:        "both targets",
+			affinity:    &JobAffinity{Job: "cache", Service: "redis", Weight: 50},
+			expectedErr: "can't match both",
+		},
+		{
+			name:        "zero weight",
+			affinity:    &JobAffinity{Job: "cache"},
+			expectedErr: "weight cannot be zero",
+		},
+		{
+			name:        "weight out of range",
+			affinity:    &JobAffinity{Job: "cache", Weight: 110},
+			expectedErr: "within the range",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.affinity.Validate()
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestJobAffinity_Matches(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &Job{
+		ID:        "cache",
+		Namespace: DefaultNamespace,
+		TaskGroups: []*TaskGroup{{
+			Name:     "redis",
+			Services: []*Service{{Name: "redis"}},
+			Tasks: []*Task{{
+				Name:     "exporter",
+				Services: []*Service{{Name: "redis-metrics"}},
+			}},
+		}},
+	}
+	alloc := &Allocation{
+		Namespace:     DefaultNamespace,
+		JobID:         job.ID,
+		Job:           job,
+		TaskGroup:     "redis",
+		DesiredStatus: AllocDesiredStatusRun,
+		ClientStatus:  AllocClientStatusRunning,
+	}
+
+	must.True(t, (&JobAffinity{Job: "cache"}).Matches(DefaultNamespace, alloc))
+	must.False(t, (&JobAffinity{Job: "web"}).Matches(DefaultNamespace, alloc))
+	must.True(t, (&JobAffinity{Service: "redis"}).Matches(DefaultNamespace, alloc))
+	must.True(t, (&JobAffinity{Service: "redis-metrics"}).Matches(DefaultNamespace, alloc))
+	must.False(t, (&JobAffinity{Service: "web"}).Matches(DefaultNamespace, alloc))
+
+	// allocations of other namespaces are not matched
+	must.False(t, (&JobAffinity{Job: "cache"}).Matches("prod", alloc))
+
+	// terminal allocations are not matched
+	alloc.DesiredStatus = AllocDesiredStatusStop
+	must.False(t, (&JobAffinity{Job: "cache"}).Matches(DefaultNamespace, alloc))
+}
+
+func TestTaskGroup_Validate_JobAffinities(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &Job{Type: JobTypeSystem}
+	tg := &TaskGroup{
+		JobAffinities: []*JobAffinity{
+			{Job: "cache", Weight: -50, Required: true},
+			{Job: "web", Weight: 50},
+		},
+	}
+
+	err := tg.Validate(job)
+	must.ErrorContains(t, err, "Job affinity 2 must be required for system jobs")
+	must.StrNotContains(t, err.Error(), "Job affinity 1")
+}
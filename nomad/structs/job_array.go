@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// JobArray expands the task groups of a batch job into a fixed number of
+// indexed allocations. Each allocation exposes its index to its tasks with
+// the NOMAD_ARRAY_INDEX environment variable, and keeps it when it is
+// rescheduled, so that every index runs to completion independently.
+type JobArray struct {
+	// Count is the number of indexes of the array. It sets the count of
+	// every task group of the job.
+	Count int
+}
+
+func (a *JobArray) Copy() *JobArray {
+	if a == nil {
+		return nil
+	}
+	na := new(JobArray)
+	*na = *a
+	return na
+}
+
+// Validate checks the array of the job, including the groups it expands.
+func (a *JobArray) Validate(job *Job) error {
+	if a == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if job.Type != JobTypeBatch {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Array can only be used with %q scheduler", JobTypeBatch))
+	}
+	if a.Count < 1 {
+		mErr.Errors = append(mErr.Errors, errors.New("Array count must be greater than zero"))
+	}
+	for _, tg := range job.TaskGroups {
+		if tg.Count != a.Count {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf(
+				"Task group %s has count %d, the count of the groups of an array is set by the array", tg.Name, tg.Count))
+		}
+		if tg.Scaling != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Task group %s of an array can't have a scaling policy", tg.Name))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// JobArraySummary is the state of each index of the array of a job.
+type JobArraySummary struct {
+	JobID     string
+	Namespace string
+	Count     int
+
+	// TaskGroups is the state of the indexes of each task group
+	TaskGroups map[string]*TaskGroupArraySummary
+}
+
+// TaskGroupArraySummary is the state of the indexes of the array of a task
+// group, and the number of indexes in each state.
+type TaskGroupArraySummary struct {
+	Pending  int
+	Running  int
+	Complete int
+	Failed   int
+	Lost     int
+	Unknown  int
+
+	Indexes []*JobArrayIndex
+}
+
+// JobArrayIndex is the state of an index of the array of a task group, as
+// reported by its latest allocation.
+type JobArrayIndex struct {
+	Index uint
+
+	// Status is the client status of the latest allocation of the index, or
+	// pending if it wasn't placed yet.
+	Status string
+
+	// AllocID is the ID of the latest allocation of the index.
+	AllocID string
+
+	// Attempts is the number of allocations which ran the index, including
+	// its rescheduled allocations.
+	Attempts int
+}
+
+// NewJobArraySummary returns the state of the indexes of the array of the job
+// from its allocations, or nil if the job isn't an array.
+func NewJobArraySummary(job *Job, allocs []*Allocation) *JobArraySummary {
+	if job == nil || job.Array == nil {
+		return nil
+	}
+
+	summary := &JobArraySummary{
+		JobID:      job.ID,
+		Namespace:  job.Namespace,
+		Count:      job.Array.Count,
+		TaskGroups: make(map[string]*TaskGroupArraySummary, len(job.TaskGroups)),
+	}
+
+	latest := make(map[string][]*Allocation, len(job.TaskGroups))
+	attempts := make(map[string][]int, len(job.TaskGroups))
+	for _, tg := range job.TaskGroups {
+		latest[tg.Name] = make([]*Allocation, job.Array.Count)
+		attempts[tg.Name] = make([]int, job.Array.Count)
+	}
+	for _, alloc := range allocs {
+		indexes, ok := latest[alloc.TaskGroup]
+		idx := alloc.Index()
+		if !ok || idx >= uint(len(indexes)) {
+			continue
+		}
+		attempts[alloc.TaskGroup][idx]++
+		if prev := indexes[idx]; prev == nil || alloc.CreateIndex > prev.CreateIndex {
+			indexes[idx] = alloc
+		}
+	}
+
+	for name, indexes := range latest {
+		tgSummary := &TaskGroupArraySummary{
+			Indexes: make([]*JobArrayIndex, len(indexes)),
+		}
+		for i, alloc := range indexes {
+			index := &JobArrayIndex{
+				Index:    uint(i),
+				Status:   AllocClientStatusPending,
+				Attempts: attempts[name][i],
+			}
+			if alloc != nil {
+				index.Status = alloc.ClientStatus
+				index.AllocID = alloc.ID
+			}
+			tgSummary.Indexes[i] = index
+
+			switch index.Status {
+			case AllocClientStatusPending:
+				tgSummary.Pending++
+			case AllocClientStatusRunning:
+				tgSummary.Running++
+			case AllocClientStatusComplete:
+				tgSummary.Complete++
+			case AllocClientStatusFailed:
+				tgSummary.Failed++
+			case AllocClientStatusLost:
+				tgSummary.Lost++
+			case AllocClientStatusUnknown:
+				tgSummary.Unknown++
+			}
+		}
+		summary.TaskGroups[name] = tgSummary
+	}
+	return summary
+}
+
+// JobArraySummaryResponse is used to return the array summary of a job
+type JobArraySummaryResponse struct {
+	Summary *JobArraySummary
+	QueryMeta
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobArray_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		jobType     string
+		array       *JobArray
+		count       int
+		scaling     *ScalingPolicy
+		expectedErr string
+	}{
+		{
+			name:    "valid",
+			jobType: JobTypeBatch,
+			array:   &JobArray{Count: 3},
+			count:   3,
+		},
+		{
+			name:        "service job",
+			jobType:     JobTypeService,
+			array:       &JobArray{Count: 3},
+			count:       3,
+			expectedErr: `Array can only be used with "batch" scheduler`,
+		},
+		{
+			name:        "zero count",
+			jobType:     JobTypeBatch,
+			array:       &JobArray{},
+			expectedErr: "Array count must be greater than zero",
+		},
+		{
+			name:        "group count",
+			jobType:     JobTypeBatch,
+			array:       &JobArray{Count: 3},
+			count:       2,
+			expectedErr: "Task group web has count 2",
+		},
+		{
+			name:        "scaling policy",
+			jobType:     JobTypeBatch,
+			array:       &JobArray{Count: 3},
+			count:       3,
+			scaling:     &ScalingPolicy{Max: 5},
+			expectedErr: "can't have a scaling policy",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &Job{
+				Type:  tc.jobType,
+				Array: tc.array,
+				TaskGroups: []*TaskGroup{
+					{Name: "web", Count: tc.count, Scaling: tc.scaling},
+				},
+			}
+			err := job.Array.Validate(job)
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewJobArraySummary(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &Job{
+		ID:         "render",
+		Namespace:  DefaultNamespace,
+		Type:       JobTypeBatch,
+		Array:      &JobArray{Count: 4},
+		TaskGroups: []*TaskGroup{{Name: "frames", Count: 4}},
+	}
+	alloc := func(index uint, status string, createIndex uint64) *Allocation {
+		return &Allocation{
+			ID:           uuid.Generate(),
+			JobID:        job.ID,
+			TaskGroup:    "frames",
+			Name:         AllocName(job.ID, "frames", index),
+			ClientStatus: status,
+			CreateIndex:  createIndex,
+		}
+	}
+
+	failed := alloc(1, AllocClientStatusFailed, 10)
+	rescheduled := alloc(1, AllocClientStatusRunning, 20)
+	complete := alloc(0, AllocClientStatusComplete, 10)
+	lost := alloc(2, AllocClientStatusLost, 10)
+	allocs := []*Allocation{
+		rescheduled, failed, complete, lost,
+		// allocations of indexes beyond the array are ignored
+		alloc(7, AllocClientStatusRunning, 10),
+	}
+
+	summary := NewJobArraySummary(job, allocs)
+	must.Eq(t, 4, summary.Count)
+	tgSummary := summary.TaskGroups["frames"]
+	must.NotNil(t, tgSummary)
+	must.Eq(t, []*JobArrayIndex{
+		{Index: 0, Status: AllocClientStatusComplete, AllocID: complete.ID, Attempts: 1},
+		{Index: 1, Status: AllocClientStatusRunning, AllocID: rescheduled.ID, Attempts: 2},
+		{Index: 2, Status: AllocClientStatusLost, AllocID: lost.ID, Attempts: 1},
+		{Index: 3, Status: AllocClientStatusPending},
+	}, tgSummary.Indexes)
+	must.Eq(t, 1, tgSummary.Complete)
+	must.Eq(t, 1, tgSummary.Running)
+	must.Eq(t, 1, tgSummary.Lost)
+	must.Eq(t, 1, tgSummary.Pending)
+	must.Eq(t, 0, tgSummary.Failed)
+
+	// jobs without an array have no summary
+	job.Array = nil
+	must.Nil(t, NewJobArraySummary(job, allocs))
+}
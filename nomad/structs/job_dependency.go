@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// JobDependencyStatusComplete is met when all the allocations of the
+	// upstream job completed successfully.
+	JobDependencyStatusComplete = "complete"
+
+	// JobDependencyStatusFailed is met when the upstream job is dead and at
+	// least one of its allocations failed or was lost without being replaced.
+	JobDependencyStatusFailed = "failed"
+
+	// JobDependencyStatusDead is met when the upstream job is dead, whatever
+	// the outcome of its allocations.
+	JobDependencyStatusDead = "dead"
+)
+
+// JobDependency holds the evaluation of a batch job until another job of the
+// same namespace reaches a terminal status.
+type JobDependency struct {
+	// JobID is the ID of the upstream job.
+	JobID string
+
+	// Status is the terminal status the upstream job must reach.
+	Status string
+}
+
+func (d *JobDependency) Copy() *JobDependency {
+	if d == nil {
+		return nil
+	}
+	nd := new(JobDependency)
+	*nd = *d
+	return nd
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.Status == "" {
+		d.Status = JobDependencyStatusComplete
+	}
+}
+
+func (d *JobDependency) Validate() error {
+	var mErr multierror.Error
+	if d.JobID == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing job ID"))
+	}
+	switch d.Status {
+	case JobDependencyStatusComplete, JobDependencyStatusFailed, JobDependencyStatusDead:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid status %q, must be one of %q, %q, or %q", d.Status,
+			JobDependencyStatusComplete, JobDependencyStatusFailed, JobDependencyStatusDead))
+	}
+	return mErr.ErrorOrNil()
+}
+
+func (d *JobDependency) String() string {
+	return fmt.Sprintf("%s (%s)", d.JobID, d.Status)
+}
+
+// CopySliceJobDependencies returns a deep copy of the dependencies.
+func CopySliceJobDependencies(s []*JobDependency) []*JobDependency {
+	if s == nil {
+		return nil
+	}
+	c := make([]*JobDependency, len(s))
+	for i, d := range s {
+		c[i] = d.Copy()
+	}
+	return c
+}
+
+// validateJobDependencies checks the dependencies of the job.
+func validateJobDependencies(job *Job) []error {
+	if len(job.DependsOn) == 0 {
+		return nil
+	}
+
+	var mErr []error
+	if job.Type != JobTypeBatch {
+		mErr = append(mErr, fmt.Errorf("Depends on can only be used with %q scheduler", JobTypeBatch))
+	}
+	if job.IsPeriodic() || job.IsParameterized() {
+		mErr = append(mErr, errors.New("Depends on can't be used with periodic or parameterized jobs"))
+	}
+
+	seen := make([]string, 0, len(job.DependsOn))
+	for idx, dep := range job.DependsOn {
+		if err := dep.Validate(); err != nil {
+			mErr = append(mErr, fmt.Errorf("Dependency %d validation failed: %v", idx+1, err))
+			continue
+		}
+		if dep.JobID == job.ID {
+			mErr = append(mErr, fmt.Errorf("Dependency %d can't be the job itself", idx+1))
+		}
+		if slices.Contains(seen, dep.JobID) {
+			mErr = append(mErr, fmt.Errorf("Dependency %d redefines job %q", idx+1, dep.JobID))
+		}
+		seen = append(seen, dep.JobID)
+	}
+	return mErr
+}
+
+// JobDependencyMet returns whether the upstream job and its allocations meet
+// the status of the dependency. The allocations must be those of the current
+// instance of the upstream job.
+func JobDependencyMet(dep *JobDependency, upstream *Job, allocs []*Allocation) bool {
+	if upstream == nil || upstream.Status != JobStatusDead {
+		return false
+	}
+	if dep.Status == JobDependencyStatusDead {
+		return true
+	}
+
+	// a stopped job neither completed nor failed
+	if upstream.Stop {
+		return false
+	}
+
+	var failed bool
+	for _, alloc := range allocs {
+		switch alloc.ClientStatus {
+		case AllocClientStatusFailed, AllocClientStatusLost:
+			if alloc.NextAllocation == "" {
+				failed = true
+			}
+		}
+	}
+
+	switch dep.Status {
+	case JobDependencyStatusComplete:
+		return !failed && len(allocs) > 0
+	case JobDependencyStatusFailed:
+		return failed
+	}
+	return false
+}
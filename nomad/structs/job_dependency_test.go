@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestJob_Validate_DependsOn(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		jobType     string
+		periodic    bool
+		dependsOn   []*JobDependency
+		expectedErr string
+	}{
+		{
+			name:    "valid",
+			jobType: JobTypeBatch,
+			dependsOn: []*JobDependency{
+				{JobID: "extract", Status: JobDependencyStatusComplete},
+				{JobID: "cleanup", Status: JobDependencyStatusDead},
+			},
+		},
+		{
+			name:        "service job",
+			jobType:     JobTypeService,
+			dependsOn:   []*JobDependency{{JobID: "extract", Status: JobDependencyStatusComplete}},
+			expectedErr: `Depends on can only be used with "batch" scheduler`,
+		},
+		{
+			name:        "periodic job",
+			jobType:     JobTypeBatch,
+			periodic:    true,
+			dependsOn:   []*JobDependency{{JobID: "extract", Status: JobDependencyStatusComplete}},
+			expectedErr: "can't be used with periodic or parameterized jobs",
+		},
+		{
+			name:        "missing job ID",
+			jobType:     JobTypeBatch,
+			dependsOn:   []*JobDependency{{Status: JobDependencyStatusComplete}},
+			expectedErr: "Dependency 1 validation failed",
+		},
+		{
+			name:        "invalid status",
+			jobType:     JobTypeBatch,
+			dependsOn:   []*JobDependency{{JobID: "extract", Status: "running"}},
+			expectedErr: `Invalid status "running"`,
+		},
+		{
+			name:        "itself",
+			jobType:     JobTypeBatch,
+			dependsOn:   []*JobDependency{{JobID: "load", Status: JobDependencyStatusComplete}},
+			expectedErr: "Dependency 1 can't be the job itself",
+		},
+		{
+			name:    "duplicate",
+			jobType: JobTypeBatch,
+			dependsOn: []*JobDependency{
+				{JobID: "extract", Status: JobDependencyStatusComplete},
+				{JobID: "extract", Status: JobDependencyStatusFailed},
+			},
+			expectedErr: `Dependency 2 redefines job "extract"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &Job{ID: "load", Type: tc.jobType, DependsOn: tc.dependsOn}
+			if tc.periodic {
+				job.Periodic = &PeriodicConfig{Enabled: true}
+			}
+			errs := validateJobDependencies(job)
+			if tc.expectedErr == "" {
+				must.SliceEmpty(t, errs)
+				return
+			}
+			must.ErrorContains(t, errors.Join(errs...), tc.expectedErr)
+		})
+	}
+}
+
+func TestJobDependencyMet(t *testing.T) {
+	ci.Parallel(t)
+
+	dead := &Job{Status: JobStatusDead}
+	running := &Job{Status: JobStatusRunning}
+	stopped := &Job{Status: JobStatusDead, Stop: true}
+
+	complete := []*Allocation{{ClientStatus: AllocClientStatusComplete}}
+	replaced := []*Allocation{
+		{ClientStatus: AllocClientStatusFailed, NextAllocation: "next"},
+		{ClientStatus: AllocClientStatusComplete},
+	}
+	failed := []*Allocation{
+		{ClientStatus: AllocClientStatusComplete},
+		{ClientStatus: AllocClientStatusLost},
+	}
+
+	testCases := []struct {
+		name     string
+		status   string
+		upstream *Job
+		allocs   []*Allocation
+		expected bool
+	}{
+		{name: "missing job", status: JobDependencyStatusDead},
+		{name: "running job", status: JobDependencyStatusDead, upstream: running, allocs: complete},
+		{name: "dead", status: JobDependencyStatusDead, upstream: stopped, expected: true},
+		{name: "complete", status: JobDependencyStatusComplete, upstream: dead, allocs: complete, expected: true},
+		{name: "complete after reschedule", status: JobDependencyStatusComplete, upstream: dead, allocs: replaced, expected: true},
+		{name: "complete without allocs", status: JobDependencyStatusComplete, upstream: dead},
+		{name: "complete but failed", status: JobDependencyStatusComplete, upstream: dead, allocs: failed},
+		{name: "complete but stopped", status: JobDependencyStatusComplete, upstream: stopped, allocs: complete},
+		{name: "failed", status: JobDependencyStatusFailed, upstream: dead, allocs: failed, expected: true},
+		{name: "failed but complete", status: JobDependencyStatusFailed, upstream: dead, allocs: replaced},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dep := &JobDependency{JobID: "upstream", Status: tc.status}
+			must.Eq(t, tc.expected, JobDependencyMet(dep, tc.upstream, tc.allocs))
+		})
+	}
+}
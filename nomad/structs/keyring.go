@@ -147,9 +147,22 @@ func (k *RootKey) Meta() *RootKeyMeta {
 		ModifyIndex: k.ModifyIndex,
 		State:       k.State,
 		PublishTime: k.PublishTime,
+		Providers:   k.Providers(),
 	}
 }
 
+// Providers returns the sorted IDs of the KEK providers that wrapped the key.
+func (k *RootKey) Providers() []string {
+	var providers []string
+	for _, wrappedKey := range k.WrappedKeys {
+		if !slices.Contains(providers, wrappedKey.ProviderID) {
+			providers = append(providers, wrappedKey.ProviderID)
+		}
+	}
+	slices.Sort(providers)
+	return providers
+}
+
 func (k *RootKey) Copy() *RootKey {
 	if k == nil {
 		return nil
@@ -269,6 +282,9 @@ type RootKeyMeta struct {
 	ModifyIndex uint64
 	State       RootKeyState
 	PublishTime int64
+
+	// Providers are the IDs of the KEK providers that wrapped the key
+	Providers []string
 }
 
 // KEKProviderName enum are the built-in KEK providers.
@@ -373,6 +389,7 @@ func (rkm *RootKeyMeta) Copy() *RootKeyMeta {
 		return nil
 	}
 	out := *rkm
+	out.Providers = slices.Clone(rkm.Providers)
 	return &out
 }
 
@@ -570,6 +587,64 @@ func (pubKey *KeyringPublicKey) GetPublicKey() (any, error) {
 	}
 }
 
+// KeyringStatusRequest is used to get the decryption status of the keyring of
+// the server that serves the request.
+type KeyringStatusRequest struct {
+	QueryOptions
+}
+
+// KeyringStatusResponse is the response for Keyring.Status RPCs.
+type KeyringStatusResponse struct {
+	Status *KeyringStatus
+	QueryMeta
+}
+
+// KeyringStatus reports the progress of decrypting the keyring of a server,
+// which isn't ready until all keys have been decrypted.
+type KeyringStatus struct {
+	// ServerName is the name of the server that served the request.
+	ServerName string
+
+	// Ready is true once the server has decrypted all the keys.
+	Ready bool
+
+	// NumKeys is the number of keys the server has decrypted.
+	NumKeys int
+
+	// Pending are the keys the server is still decrypting.
+	Pending []*KeyringDecryptStatus
+}
+
+// KeyringDecryptState enum describes the progress of decrypting a key.
+type KeyringDecryptState string
+
+const (
+	// KeyringDecryptStateQueued is the state of a key waiting for a
+	// decryption worker.
+	KeyringDecryptStateQueued KeyringDecryptState = "queued"
+
+	// KeyringDecryptStateDecrypting is the state of a key being decrypted,
+	// including retries after failed attempts.
+	KeyringDecryptStateDecrypting KeyringDecryptState = "decrypting"
+)
+
+// KeyringDecryptStatus is the decryption status of a key.
+type KeyringDecryptStatus struct {
+	KeyID string
+	State KeyringDecryptState
+
+	// Providers are the IDs of the KEK providers that wrapped the key.
+	Providers []string
+
+	QueueTime int64
+	StartTime int64
+
+	// Attempts is the number of failed attempts to decrypt the key, and
+	// LastError the error of the last of these attempts.
+	Attempts  int
+	LastError string
+}
+
 // KeyringGetConfigResponse is the response for Keyring.GetConfig RPCs.
 type KeyringGetConfigResponse struct {
 	OIDCDiscovery *OIDCDiscoveryConfig
@@ -587,6 +662,7 @@ type OIDCDiscoveryConfig struct {
 	IDTokenAlgs   []string `json:"id_token_signing_alg_values_supported"`
 	ResponseTypes []string `json:"response_types_supported"`
 	Subjects      []string `json:"subject_types_supported"`
+	Claims        []string `json:"claims_supported"`
 }
 
 // NewOIDCDiscoveryConfig returns a populated OIDCDiscoveryConfig or an error.
@@ -614,6 +690,16 @@ func NewOIDCDiscoveryConfig(issuer string) (*OIDCDiscoveryConfig, error) {
 
 		ResponseTypes: []string{"code"},
 		Subjects:      []string{"public"},
+
+		// Custom claims configured by the cluster administrator are nested
+		// under extra_claims so they can never shadow the registered claims.
+		Claims: []string{
+			"aud", "exp", "iat", "iss", "jti", "nbf", "sub",
+			"nomad_namespace", "nomad_job_id", "nomad_allocation_id",
+			"nomad_task", "nomad_service",
+			"consul_namespace", "vault_namespace", "vault_role",
+			"extra_claims",
+		},
 	}
 
 	return disc, nil
@@ -30,4 +30,5 @@ func TestKeyring_OIDCDiscoveryConfig(t *testing.T) {
 	must.SliceNotEmpty(t, c.IDTokenAlgs)
 	must.SliceNotEmpty(t, c.ResponseTypes)
 	must.SliceNotEmpty(t, c.Subjects)
+	must.SliceContainsSubset(t, c.Claims, []string{"sub", "aud", "extra_claims"})
 }
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"net/url"
+	"path"
+)
+
+const (
+	// MeshSignCertificateRPCMethod is the RPC method for signing the leaf
+	// certificate of a Connect sidecar proxy of a Nomad service.
+	//
+	// Args: MeshCertificateRequest
+	// Reply: MeshCertificateResponse
+	MeshSignCertificateRPCMethod = "Mesh.SignCertificate"
+
+	// MeshTrustDomain is the SPIFFE trust domain of the Nomad service mesh.
+	// The keyring is replicated across federated regions, so every region
+	// shares the same trust domain.
+	MeshTrustDomain = "nomad"
+
+	// MeshSidecarServiceSuffix is appended to the name of a Nomad service to
+	// form the name of the service registration of its sidecar proxy.
+	MeshSidecarServiceSuffix = "-sidecar-proxy"
+)
+
+// MeshCertificateRequest is the RPC arguments for signing the leaf certificate
+// of a Connect sidecar proxy. The request must be authenticated with the
+// workload identity of the sidecar task.
+type MeshCertificateRequest struct {
+	// CSR is the PEM encoded certificate signing request. The subject and
+	// extensions of the request are ignored, only its public key is used.
+	CSR []byte
+
+	QueryOptions
+}
+
+// MeshCertificateResponse is the RPC response for a signed leaf certificate.
+type MeshCertificateResponse struct {
+	// Certificate is the PEM encoded leaf certificate.
+	Certificate []byte
+
+	// CABundle is the PEM encoded certificates of the keyring CA, one per
+	// root key, which sidecars use to verify their peers.
+	CABundle []byte
+
+	QueryMeta
+}
+
+// MeshSpiffeID returns the SPIFFE ID of a Nomad service in the mesh, which is
+// set as the URI SAN of the leaf certificates of its sidecar proxies.
+func MeshSpiffeID(namespace, service string) *url.URL {
+	return &url.URL{
+		Scheme: "spiffe",
+		Host:   MeshTrustDomain,
+		Path:   path.Join("/ns", namespace, "svc", service),
+	}
+}
+
+// MeshSidecarServiceName returns the name of the service registration of the
+// sidecar proxy of a Nomad service.
+func MeshSidecarServiceName(service string) string {
+	return service + MeshSidecarServiceSuffix
+}
@@ -67,6 +67,18 @@ type NetworkIndex struct {
 	AvailBandwidth map[string]int // Bandwidth by device
 	UsedBandwidth  map[string]int // Bandwidth by device
 
+	// AvailShapedBandwidth is the speed in MBits of the node interface that
+	// bridge networks egress through. Group networks with bandwidth limits
+	// can use up to this bandwidth in each direction.
+	AvailShapedBandwidth int
+	UsedEgressBandwidth  int
+	UsedIngressBandwidth int
+
+	// UsedStaticAddresses tracks the static addresses of the group networks
+	// of allocations on the node. It maps the network mode to the addresses
+	// used in that mode, each mapped to the ID of the allocation using it.
+	UsedStaticAddresses map[string]map[string]string
+
 	MinDynamicPort int // The smallest dynamic port generated
 	MaxDynamicPort int // The largest dynamic port generated
 }
@@ -126,6 +138,12 @@ func (idx *NetworkIndex) Copy() *NetworkIndex {
 	} else {
 		c.UsedBandwidth = maps.Clone(idx.UsedBandwidth)
 	}
+	if len(idx.UsedStaticAddresses) > 0 {
+		c.UsedStaticAddresses = make(map[string]map[string]string, len(idx.UsedStaticAddresses))
+		for mode, addrs := range idx.UsedStaticAddresses {
+			c.UsedStaticAddresses[mode] = maps.Clone(addrs)
+		}
+	}
 
 	return c
 }
@@ -175,11 +193,15 @@ func copyAvailAddresses(a map[string][]NodeNetworkAddress) map[string][]NodeNetw
 }
 
 // Release is called when the network index is no longer needed
-// to attempt to re-use some of the memory it has allocated
+// to attempt to re-use some of the memory it has allocated. It also resets
+// the shaped bandwidth and static address usage recorded by AddAllocs.
 func (idx *NetworkIndex) Release() {
 	for _, b := range idx.UsedPorts {
 		bitmapPool.Put(b)
 	}
+	idx.UsedEgressBandwidth = 0
+	idx.UsedIngressBandwidth = 0
+	idx.UsedStaticAddresses = nil
 }
 
 // Overcommitted checks if the network is overcommitted
@@ -191,7 +213,14 @@ func (idx *NetworkIndex) Overcommitted() bool {
 			return true
 		}
 	}*/
-	return false
+
+	// Shaped bandwidth can't be overcommitted on a node whose bandwidth is
+	// unknown, as no shaped network asks are placed on it.
+	if idx.AvailShapedBandwidth == 0 {
+		return false
+	}
+	return idx.UsedEgressBandwidth > idx.AvailShapedBandwidth ||
+		idx.UsedIngressBandwidth > idx.AvailShapedBandwidth
 }
 
 // SetNode is used to initialize a node's network index with available IPs,
@@ -281,6 +310,9 @@ func (idx *NetworkIndex) SetNode(node *Node) error {
 		if n.Device != "" {
 			idx.TaskNetworks = append(idx.TaskNetworks, n)
 			idx.AvailBandwidth[n.Device] = n.MBits
+			if idx.AvailShapedBandwidth == 0 {
+				idx.AvailShapedBandwidth = n.MBits
+			}
 
 			// Reserve ports
 			used := idx.getUsedPortsFor(n.IP)
@@ -297,6 +329,10 @@ func (idx *NetworkIndex) SetNode(node *Node) error {
 	}
 
 	for _, n := range nodeNetworks {
+		if idx.AvailShapedBandwidth == 0 && n.Mode == "host" {
+			idx.AvailShapedBandwidth = n.Speed
+		}
+
 		for _, a := range n.Addresses {
 			// Index host networks by their unique alias for asks
 			// with group.network.port.host_network set.
@@ -345,8 +381,9 @@ func (idx *NetworkIndex) SetNode(node *Node) error {
 //
 // AddAllocs may be called multiple times for the same NetworkIndex with
 // UsedPorts cleared between calls (by Release). Therefore AddAllocs must be
-// determistic and must not manipulate state outside of UsedPorts as that state
-// would persist between Release calls.
+// determistic and must not manipulate state outside of UsedPorts, the shaped
+// bandwidth counters, and UsedStaticAddresses, as any other state would
+// persist between Release calls.
 func (idx *NetworkIndex) AddAllocs(allocs []*Allocation) (collide bool, reason string) {
 	for _, alloc := range allocs {
 		// Do not consider the resource impact of terminal allocations
@@ -355,6 +392,16 @@ func (idx *NetworkIndex) AddAllocs(allocs []*Allocation) (collide bool, reason s
 		}
 
 		if alloc.AllocatedResources != nil {
+			for _, network := range alloc.AllocatedResources.Shared.Networks {
+				idx.UsedEgressBandwidth += network.EgressMBits
+				idx.UsedIngressBandwidth += network.IngressMBits
+
+				if c, r := idx.addStaticAddresses(network, alloc.ID); c {
+					collide = true
+					reason = fmt.Sprintf("collision when reserving static address for alloc %s: %v", alloc.ID, r)
+				}
+			}
+
 			// Only look at AllocatedPorts if populated, otherwise use pre 0.12 logic
 			// COMPAT(1.0): Remove when network resources struct is removed.
 			if len(alloc.AllocatedResources.Shared.Ports) > 0 {
@@ -401,6 +448,32 @@ func (idx *NetworkIndex) AddAllocs(allocs []*Allocation) (collide bool, reason s
 	return
 }
 
+// addStaticAddresses marks the static addresses of a group network as used by
+// the allocation, returning true if another allocation already uses one.
+func (idx *NetworkIndex) addStaticAddresses(n *NetworkResource, allocID string) (collide bool, reason string) {
+	if !n.HasStaticAddress() {
+		return false, ""
+	}
+	if idx.UsedStaticAddresses == nil {
+		idx.UsedStaticAddresses = make(map[string]map[string]string)
+	}
+	used, ok := idx.UsedStaticAddresses[n.Mode]
+	if !ok {
+		used = make(map[string]string)
+		idx.UsedStaticAddresses[n.Mode] = used
+	}
+
+	for _, addr := range n.StaticAddresses() {
+		if other, ok := used[addr]; ok && other != allocID {
+			collide = true
+			reason = fmt.Sprintf("address %s already in use by alloc %s", addr, other)
+			continue
+		}
+		used[addr] = allocID
+	}
+	return
+}
+
 // AddReserved is used to add a reserved network usage, returns true
 // if there is a port collision
 func (idx *NetworkIndex) AddReserved(n *NetworkResource) (collide bool, reasons []string) {
@@ -506,6 +579,13 @@ func incIP(ip net.IP) {
 //
 // AssignTaskNetwork supports the deprecated task.resources.network block.
 func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, error) {
+	if err := idx.checkShapedBandwidth(ask); err != nil {
+		return nil, err
+	}
+	if err := idx.checkStaticAddresses(ask); err != nil {
+		return nil, err
+	}
+
 	var offer AllocatedPorts
 	var portsInOffer []int
 
@@ -604,6 +684,39 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 	return offer, nil
 }
 
+// checkShapedBandwidth returns an error if the bandwidth limits of the ask
+// exceed the bandwidth of the node not yet used by other shaped networks.
+func (idx *NetworkIndex) checkShapedBandwidth(ask *NetworkResource) error {
+	if !ask.Shaped() {
+		return nil
+	}
+	if idx.AvailShapedBandwidth == 0 {
+		return fmt.Errorf("node bandwidth unknown, cannot shape network")
+	}
+	if idx.UsedEgressBandwidth+ask.EgressMBits > idx.AvailShapedBandwidth {
+		return fmt.Errorf("egress bandwidth exceeded")
+	}
+	if idx.UsedIngressBandwidth+ask.IngressMBits > idx.AvailShapedBandwidth {
+		return fmt.Errorf("ingress bandwidth exceeded")
+	}
+	return nil
+}
+
+// checkStaticAddresses returns an error if the static addresses of the ask
+// are already used by another allocation in the same network mode.
+func (idx *NetworkIndex) checkStaticAddresses(ask *NetworkResource) error {
+	if !ask.HasStaticAddress() {
+		return nil
+	}
+	used := idx.UsedStaticAddresses[ask.Mode]
+	for _, addr := range ask.StaticAddresses() {
+		if _, ok := used[addr]; ok {
+			return fmt.Errorf("static address %s already in use", addr)
+		}
+	}
+	return nil
+}
+
 // AssignTaskNetwork is used to offer network resources given a
 // task.resources.network ask.  If the ask cannot be satisfied, returns nil
 //
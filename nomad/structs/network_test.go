@@ -424,6 +424,173 @@ func TestNetworkIndex_AssignPorts(t *testing.T) {
 	must.Between(t, idx.MaxDynamicPort-1, adminPortMapping.Value, idx.MaxDynamicPort)
 }
 
+// TestNetworkIndex_AssignPorts_Bandwidth asserts group networks with bandwidth
+// limits are only assigned while the node has the bandwidth to shape them.
+func TestNetworkIndex_AssignPorts_Bandwidth(t *testing.T) {
+	ci.Parallel(t)
+
+	n := &Node{
+		NodeResources: &NodeResources{
+			NodeNetworks: []*NodeNetworkResource{
+				{
+					Mode:   "host",
+					Device: "eth0",
+					Speed:  1000,
+					Addresses: []NodeNetworkAddress{
+						{
+							Alias:   "default",
+							Address: "192.168.0.100",
+							Family:  NodeNetworkAF_IPv4,
+						},
+					},
+				},
+			},
+		},
+	}
+	alloc := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Shared: AllocatedSharedResources{
+				Networks: []*NetworkResource{{Mode: "bridge", EgressMBits: 600, IngressMBits: 100}},
+			},
+		},
+	}
+
+	idx := NewNetworkIndex()
+	must.NoError(t, idx.SetNode(n))
+	collide, _ := idx.AddAllocs([]*Allocation{alloc})
+	must.False(t, collide)
+	must.Eq(t, 1000, idx.AvailShapedBandwidth)
+	must.False(t, idx.Overcommitted())
+
+	_, err := idx.AssignPorts(&NetworkResource{Mode: "bridge", EgressMBits: 400, IngressMBits: 900})
+	must.NoError(t, err)
+
+	_, err = idx.AssignPorts(&NetworkResource{Mode: "bridge", EgressMBits: 500})
+	must.EqError(t, err, "egress bandwidth exceeded")
+
+	_, err = idx.AssignPorts(&NetworkResource{Mode: "bridge", IngressMBits: 1000})
+	must.EqError(t, err, "ingress bandwidth exceeded")
+
+	collide, _ = idx.AddAllocs([]*Allocation{alloc})
+	must.False(t, collide)
+	must.True(t, idx.Overcommitted())
+}
+
+// TestNetworkIndex_AssignPorts_BandwidthUnknown asserts shaped group networks
+// are rejected on nodes whose bandwidth has not been fingerprinted.
+func TestNetworkIndex_AssignPorts_BandwidthUnknown(t *testing.T) {
+	ci.Parallel(t)
+
+	n := &Node{
+		NodeResources: &NodeResources{
+			NodeNetworks: []*NodeNetworkResource{
+				{
+					Mode:   "host",
+					Device: "eth0",
+					Addresses: []NodeNetworkAddress{
+						{
+							Alias:   "default",
+							Address: "192.168.0.100",
+							Family:  NodeNetworkAF_IPv4,
+						},
+					},
+				},
+			},
+		},
+	}
+	alloc := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Shared: AllocatedSharedResources{
+				Networks: []*NetworkResource{{Mode: "bridge", EgressMBits: 100}},
+			},
+		},
+	}
+
+	idx := NewNetworkIndex()
+	must.NoError(t, idx.SetNode(n))
+	collide, _ := idx.AddAllocs([]*Allocation{alloc})
+	must.False(t, collide)
+	must.Zero(t, idx.AvailShapedBandwidth)
+	must.False(t, idx.Overcommitted())
+
+	_, err := idx.AssignPorts(&NetworkResource{Mode: "bridge"})
+	must.NoError(t, err)
+
+	_, err = idx.AssignPorts(&NetworkResource{Mode: "bridge", EgressMBits: 100})
+	must.EqError(t, err, "node bandwidth unknown, cannot shape network")
+}
+
+// TestNetworkIndex_Release_Bandwidth asserts Release resets the shaped
+// bandwidth used by allocations so it isn't counted twice when the index is
+// rebuilt.
+func TestNetworkIndex_Release_Bandwidth(t *testing.T) {
+	ci.Parallel(t)
+
+	idx := NewNetworkIndex()
+	idx.AvailShapedBandwidth = 1000
+	alloc := &Allocation{
+		ID: "a",
+		AllocatedResources: &AllocatedResources{
+			Shared: AllocatedSharedResources{
+				Networks: []*NetworkResource{{
+					Mode:         "bridge",
+					EgressMBits:  600,
+					IngressMBits: 600,
+				}},
+			},
+		},
+	}
+
+	collide, _ := idx.AddAllocs([]*Allocation{alloc})
+	must.False(t, collide)
+	must.Eq(t, 600, idx.UsedEgressBandwidth)
+	must.Eq(t, 600, idx.UsedIngressBandwidth)
+
+	idx.Release()
+	must.Zero(t, idx.UsedEgressBandwidth)
+	must.Zero(t, idx.UsedIngressBandwidth)
+	must.Nil(t, idx.UsedStaticAddresses)
+
+	collide, _ = idx.AddAllocs([]*Allocation{alloc})
+	must.False(t, collide)
+	must.Eq(t, 600, idx.UsedEgressBandwidth)
+	must.False(t, idx.Overcommitted())
+}
+
+func TestNetworkIndex_AssignPorts_StaticAddress(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &Allocation{
+		ID: "alloc1",
+		AllocatedResources: &AllocatedResources{
+			Shared: AllocatedSharedResources{
+				Networks: []*NetworkResource{{Mode: "bridge", IPv4Address: "172.26.64.10"}},
+			},
+		},
+	}
+
+	idx := NewNetworkIndex()
+	must.NoError(t, idx.SetNode(&Node{NodeResources: &NodeResources{}}))
+	collide, _ := idx.AddAllocs([]*Allocation{alloc})
+	must.False(t, collide)
+
+	_, err := idx.AssignPorts(&NetworkResource{Mode: "bridge", IPv4Address: "172.26.64.10"})
+	must.EqError(t, err, "static address 172.26.64.10 already in use")
+
+	// the same address is free in other network modes
+	_, err = idx.AssignPorts(&NetworkResource{Mode: "cni/mynet", IPv4Address: "172.26.64.10"})
+	must.NoError(t, err)
+
+	_, err = idx.AssignPorts(&NetworkResource{Mode: "bridge", IPv4Address: "172.26.64.11"})
+	must.NoError(t, err)
+
+	other := alloc.Copy()
+	other.ID = "alloc2"
+	collide, reason := idx.AddAllocs([]*Allocation{other})
+	must.True(t, collide)
+	must.StrContains(t, reason, "address 172.26.64.10 already in use by alloc alloc1")
+}
+
 // TestNetworkIndex_AssignPorts_SmallRange exercises assigning ports on group
 // networks with small dynamic port ranges configured
 func TestNetworkIndex_AssignPortss_SmallRange(t *testing.T) {
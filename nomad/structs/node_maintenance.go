@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// NodeMaintenanceWindowStatusScheduled is the status of a maintenance
+	// window waiting for its next start.
+	NodeMaintenanceWindowStatusScheduled = "scheduled"
+
+	// NodeMaintenanceWindowStatusActive is the status of a maintenance window
+	// holding its nodes drained and ineligible.
+	NodeMaintenanceWindowStatusActive = "active"
+
+	// NodeMaintenanceWindowStatusComplete is the status of a maintenance
+	// window that won't start again.
+	NodeMaintenanceWindowStatusComplete = "complete"
+
+	// NodeMaintenanceWindowMetaKey is the drain metadata key set to the ID of
+	// the maintenance window draining the node.
+	NodeMaintenanceWindowMetaKey = "maintenance_window"
+
+	// maxNodeMaintenanceWindowNameLength is the maximum length allowed for
+	// the name of a maintenance window.
+	maxNodeMaintenanceWindowNameLength = 128
+
+	// maxNodeMaintenanceWindowDescriptionLength is the maximum length allowed
+	// for the description of a maintenance window.
+	maxNodeMaintenanceWindowDescriptionLength = 256
+
+	// defaultNodeMaintenanceDrainDeadline is the drain deadline of maintenance
+	// windows that don't specify how their nodes are drained.
+	defaultNodeMaintenanceDrainDeadline = time.Hour
+)
+
+// NodeMaintenanceWindow is a scheduled period during which a set of nodes is
+// drained and held ineligible for scheduling, before being made eligible
+// again at the end of the window. Windows may recur on a calendar schedule.
+type NodeMaintenanceWindow struct {
+	// ID is the UUID of the maintenance window.
+	ID string
+
+	// Name is the human-friendly name of the maintenance window.
+	Name string
+
+	// Description is the human-friendly description of the maintenance
+	// window.
+	Description string
+
+	// NodeIDs, NodePool and Datacenter select the nodes under maintenance. At
+	// least one of them must be set, and the nodes must match all of those
+	// that are set.
+	NodeIDs    []string
+	NodePool   string
+	Datacenter string
+
+	// Start is the start time of the window. For recurring windows it is the
+	// time before which the windows don't start, and may be left unset.
+	Start time.Time
+
+	// Recurrence is the cron expression of the starts of recurring windows,
+	// evaluated in TimeZone. The window only happens once if it is empty.
+	Recurrence string
+
+	// TimeZone is the time zone the recurrence is evaluated in. It defaults
+	// to UTC.
+	TimeZone string
+
+	// Duration is how long each window lasts.
+	Duration time.Duration
+
+	// DrainSpec is how the nodes are drained at the start of each window.
+	DrainSpec *DrainSpec
+
+	// Status is the status of the window, either scheduled, active or
+	// complete. It is managed by the leader.
+	Status string
+
+	// NextStart is the start time of the current window if it is active, or
+	// of the next window if it is scheduled.
+	NextStart time.Time
+
+	// ActiveNodeIDs are the IDs of the nodes drained by the active window,
+	// which are made eligible again at the end of the window.
+	ActiveNodeIDs []string
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func (w *NodeMaintenanceWindow) Copy() *NodeMaintenanceWindow {
+	if w == nil {
+		return nil
+	}
+
+	nw := new(NodeMaintenanceWindow)
+	*nw = *w
+	nw.NodeIDs = slices.Clone(w.NodeIDs)
+	nw.ActiveNodeIDs = slices.Clone(w.ActiveNodeIDs)
+	nw.DrainSpec = w.DrainSpec.Copy()
+	return nw
+}
+
+// Canonicalize sets the default values of the maintenance window.
+func (w *NodeMaintenanceWindow) Canonicalize() {
+	if w.TimeZone == "" {
+		w.TimeZone = "UTC"
+	}
+	if w.DrainSpec == nil {
+		w.DrainSpec = &DrainSpec{Deadline: defaultNodeMaintenanceDrainDeadline}
+	}
+}
+
+// Validate returns an error if the maintenance window is invalid.
+func (w *NodeMaintenanceWindow) Validate() error {
+	var mErr multierror.Error
+
+	if len(w.Name) > maxNodeMaintenanceWindowNameLength {
+		_ = multierror.Append(&mErr, fmt.Errorf("name longer than %d", maxNodeMaintenanceWindowNameLength))
+	}
+	if len(w.Description) > maxNodeMaintenanceWindowDescriptionLength {
+		_ = multierror.Append(&mErr, fmt.Errorf("description longer than %d", maxNodeMaintenanceWindowDescriptionLength))
+	}
+	if len(w.NodeIDs) == 0 && w.NodePool == "" && w.Datacenter == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("must select nodes by ID, node pool or datacenter"))
+	}
+	if w.NodePool != "" {
+		if err := ValidateNodePoolName(w.NodePool); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("invalid node pool: %v", err))
+		}
+	}
+
+	if w.Recurrence == "" {
+		if w.Start.IsZero() {
+			_ = multierror.Append(&mErr, fmt.Errorf("must specify a start time or a recurrence"))
+		}
+	} else if _, err := cronexpr.Parse(w.Recurrence); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("invalid recurrence cron spec %q: %v", w.Recurrence, err))
+	}
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("invalid time zone %q: %v", w.TimeZone, err))
+		}
+	}
+	if w.Duration <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("duration must be greater than zero"))
+	}
+
+	if w.DrainSpec != nil {
+		if err := w.DrainSpec.Validate(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// NextWindow returns the start time of the first window of the maintenance
+// window ending after the passed time, which may already have started. It
+// returns the zero value of time.Time if no window ends after that time.
+func (w *NodeMaintenanceWindow) NextWindow(after time.Time) (time.Time, error) {
+	if w.Recurrence == "" {
+		if w.Start.Add(w.Duration).After(after) {
+			return w.Start, nil
+		}
+		return time.Time{}, nil
+	}
+
+	location := time.UTC
+	if w.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(w.TimeZone); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	// The first window starting after the passed time minus the duration of
+	// the windows ends after it, unless it starts before Start.
+	from := after.Add(-w.Duration)
+	if !w.Start.IsZero() && from.Before(w.Start) {
+		from = w.Start.Add(-time.Nanosecond)
+	}
+	return CronParseNext(from.In(location), w.Recurrence)
+}
+
+// End returns the end time of the current or next window.
+func (w *NodeMaintenanceWindow) End() time.Time {
+	return w.NextStart.Add(w.Duration)
+}
+
+// Selects returns true if the node is under maintenance during the window.
+func (w *NodeMaintenanceWindow) Selects(node *Node) bool {
+	if len(w.NodeIDs) != 0 && !slices.Contains(w.NodeIDs, node.ID) {
+		return false
+	}
+	if w.NodePool != "" && w.NodePool != node.NodePool {
+		return false
+	}
+	if w.Datacenter != "" && w.Datacenter != node.Datacenter {
+		return false
+	}
+	return true
+}
+
+// NodeMaintenanceWindowListRequest is used to list maintenance windows.
+type NodeMaintenanceWindowListRequest struct {
+	QueryOptions
+}
+
+// NodeMaintenanceWindowListResponse is the response to maintenance windows
+// list request.
+type NodeMaintenanceWindowListResponse struct {
+	Windows []*NodeMaintenanceWindow
+	QueryMeta
+}
+
+// NodeMaintenanceWindowSpecificRequest is used to make a request for a
+// specific maintenance window.
+type NodeMaintenanceWindowSpecificRequest struct {
+	ID string
+	QueryOptions
+}
+
+// SingleNodeMaintenanceWindowResponse is the response to a specific
+// maintenance window request.
+type SingleNodeMaintenanceWindowResponse struct {
+	Window *NodeMaintenanceWindow
+	QueryMeta
+}
+
+// NodeMaintenanceWindowUpsertRequest is used to make a request to insert or
+// update maintenance windows.
+type NodeMaintenanceWindowUpsertRequest struct {
+	Windows []*NodeMaintenanceWindow
+	WriteRequest
+}
+
+// NodeMaintenanceWindowUpsertResponse is the response to a maintenance
+// windows upsert request, with the windows as they were stored.
+type NodeMaintenanceWindowUpsertResponse struct {
+	Windows []*NodeMaintenanceWindow
+	WriteMeta
+}
+
+// NodeMaintenanceWindowDeleteRequest is used to make a request to delete
+// maintenance windows.
+type NodeMaintenanceWindowDeleteRequest struct {
+	IDs []string
+	WriteRequest
+}
@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeMaintenanceWindow_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		window      *NodeMaintenanceWindow
+		expectedErr string
+	}{
+		{
+			name: "valid one-off",
+			window: &NodeMaintenanceWindow{
+				NodePool: "batch",
+				Start:    time.Now(),
+				Duration: time.Hour,
+			},
+		},
+		{
+			name: "valid recurring",
+			window: &NodeMaintenanceWindow{
+				Datacenter: "dc1",
+				Recurrence: "0 2 * * SUN",
+				TimeZone:   "Europe/Paris",
+				Duration:   time.Hour,
+			},
+		},
+		{
+			name: "no selector",
+			window: &NodeMaintenanceWindow{
+				Start:    time.Now(),
+				Duration: time.Hour,
+			},
+			expectedErr: "must select nodes",
+		},
+		{
+			name: "no start",
+			window: &NodeMaintenanceWindow{
+				NodeIDs:  []string{"node"},
+				Duration: time.Hour,
+			},
+			expectedErr: "must specify a start time or a recurrence",
+		},
+		{
+			name: "invalid recurrence",
+			window: &NodeMaintenanceWindow{
+				NodePool:   "batch",
+				Recurrence: "every sunday",
+				Duration:   time.Hour,
+			},
+			expectedErr: "invalid recurrence",
+		},
+		{
+			name: "invalid time zone",
+			window: &NodeMaintenanceWindow{
+				NodePool:   "batch",
+				Recurrence: "0 2 * * SUN",
+				TimeZone:   "Mars/Olympus",
+				Duration:   time.Hour,
+			},
+			expectedErr: "invalid time zone",
+		},
+		{
+			name: "no duration",
+			window: &NodeMaintenanceWindow{
+				NodePool: "batch",
+				Start:    time.Now(),
+			},
+			expectedErr: "duration must be greater than zero",
+		},
+		{
+			name: "invalid drain spec",
+			window: &NodeMaintenanceWindow{
+				NodePool:  "batch",
+				Start:     time.Now(),
+				Duration:  time.Hour,
+				DrainSpec: &DrainSpec{JobTypeDeadlines: map[string]time.Duration{"cron": time.Hour}},
+			},
+			expectedErr: "invalid job type",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.window.Validate()
+			if tc.expectedErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestNodeMaintenanceWindow_NextWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	start := time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC)
+
+	// One-off windows only happen until they end
+	window := &NodeMaintenanceWindow{Start: start, Duration: time.Hour}
+	for _, after := range []time.Time{start.Add(-time.Hour), start, start.Add(59 * time.Minute)} {
+		next, err := window.NextWindow(after)
+		must.NoError(t, err)
+		must.Eq(t, start, next)
+	}
+	next, err := window.NextWindow(start.Add(time.Hour))
+	must.NoError(t, err)
+	must.True(t, next.IsZero())
+
+	// Recurring windows in progress are returned until they end, and don't
+	// start before the start time
+	window = &NodeMaintenanceWindow{
+		Start:      start,
+		Recurrence: "0 2 * * *",
+		TimeZone:   "Europe/Paris",
+		Duration:   2 * time.Hour,
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	must.NoError(t, err)
+
+	next, err = window.NextWindow(start.Add(-48 * time.Hour))
+	must.NoError(t, err)
+	must.Eq(t, time.Date(2026, 11, 2, 2, 0, 0, 0, paris), next)
+
+	next, err = window.NextWindow(time.Date(2026, 11, 2, 3, 0, 0, 0, paris))
+	must.NoError(t, err)
+	must.Eq(t, time.Date(2026, 11, 2, 2, 0, 0, 0, paris), next)
+
+	next, err = window.NextWindow(time.Date(2026, 11, 2, 4, 0, 0, 0, paris))
+	must.NoError(t, err)
+	must.Eq(t, time.Date(2026, 11, 3, 2, 0, 0, 0, paris), next)
+}
+
+func TestNodeMaintenanceWindow_Selects(t *testing.T) {
+	ci.Parallel(t)
+
+	node := &Node{ID: "node-1", NodePool: "batch", Datacenter: "dc1"}
+
+	must.True(t, (&NodeMaintenanceWindow{NodePool: "batch"}).Selects(node))
+	must.True(t, (&NodeMaintenanceWindow{NodePool: "batch", Datacenter: "dc1"}).Selects(node))
+	must.True(t, (&NodeMaintenanceWindow{NodeIDs: []string{"node-0", "node-1"}}).Selects(node))
+	must.False(t, (&NodeMaintenanceWindow{NodePool: "batch", Datacenter: "dc2"}).Selects(node))
+	must.False(t, (&NodeMaintenanceWindow{NodeIDs: []string{"node-0"}, NodePool: "batch"}).Selects(node))
+}
+
+func TestNewNodeMaintenanceWindowEvent(t *testing.T) {
+	ci.Parallel(t)
+
+	scheduled := &NodeMaintenanceWindow{Status: NodeMaintenanceWindowStatusScheduled}
+	active := &NodeMaintenanceWindow{Status: NodeMaintenanceWindowStatusActive}
+	complete := &NodeMaintenanceWindow{Status: NodeMaintenanceWindowStatusComplete}
+
+	eventType, _ := NewNodeMaintenanceWindowEvent(nil, scheduled)
+	must.Eq(t, TypeNodeMaintenanceWindowUpserted, eventType)
+	eventType, _ = NewNodeMaintenanceWindowEvent(scheduled, scheduled)
+	must.Eq(t, TypeNodeMaintenanceWindowUpserted, eventType)
+	eventType, _ = NewNodeMaintenanceWindowEvent(scheduled, active)
+	must.Eq(t, TypeNodeMaintenanceWindowStarted, eventType)
+	eventType, _ = NewNodeMaintenanceWindowEvent(active, scheduled)
+	must.Eq(t, TypeNodeMaintenanceWindowEnded, eventType)
+	eventType, payload := NewNodeMaintenanceWindowEvent(active, complete)
+	must.Eq(t, TypeNodeMaintenanceWindowEnded, eventType)
+	must.Eq(t, complete, payload.Window)
+}
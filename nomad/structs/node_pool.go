@@ -4,9 +4,12 @@
 package structs
 
 import (
+	"cmp"
+	"encoding/binary"
 	"fmt"
 	"maps"
 	"regexp"
+	"slices"
 	"sort"
 
 	"github.com/hashicorp/go-multierror"
@@ -28,6 +31,10 @@ const (
 	// maxNodePoolDescriptionLength is the maximum length allowed for a node
 	// pool description.
 	maxNodePoolDescriptionLength = 256
+
+	// maxNodePoolOverflowPriority is the highest priority an overflow node
+	// pool can have.
+	maxNodePoolOverflowPriority = 100
 )
 
 var (
@@ -58,6 +65,10 @@ type NodePool struct {
 	// node pool.
 	SchedulerConfiguration *NodePoolSchedulerConfiguration
 
+	// Overflow is the set of node pools that jobs of the node pool are placed
+	// in when none of the nodes of the node pool are feasible for them.
+	Overflow []*NodePoolOverflow
+
 	// Hash is the hash of the node pool which is used to efficiently diff when
 	// we replicate pools across regions.
 	Hash []byte
@@ -84,6 +95,24 @@ func (n *NodePool) Validate() error {
 
 	mErr = multierror.Append(mErr, n.SchedulerConfiguration.Validate())
 
+	seen := make(map[string]struct{}, len(n.Overflow))
+	for i, o := range n.Overflow {
+		if o == nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("overflow %d is empty", i))
+			continue
+		}
+		if err := o.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("overflow %d: %v", i, err))
+		}
+		if o.Pool == n.Name {
+			mErr = multierror.Append(mErr, fmt.Errorf("overflow %d: node pool can't overflow into itself", i))
+		}
+		if _, ok := seen[o.Pool]; ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("overflow %d: duplicate node pool %q", i, o.Pool))
+		}
+		seen[o.Pool] = struct{}{}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -97,6 +126,12 @@ func (n *NodePool) Copy() *NodePool {
 	*nc = *n
 	nc.Meta = maps.Clone(nc.Meta)
 	nc.SchedulerConfiguration = nc.SchedulerConfiguration.Copy()
+	if n.Overflow != nil {
+		nc.Overflow = make([]*NodePoolOverflow, len(n.Overflow))
+		for i, o := range n.Overflow {
+			nc.Overflow[i] = o.Copy()
+		}
+	}
 
 	nc.Hash = make([]byte, len(n.Hash))
 	copy(nc.Hash, n.Hash)
@@ -135,6 +170,26 @@ func (n *NodePool) MemoryOversubscriptionEnabled(global *SchedulerConfiguration)
 	return memOversubEnabled
 }
 
+// OverflowPools returns the names of the node pools that jobs of the node pool
+// overflow into, from the highest to the lowest priority. Node pools with the
+// same priority are returned in the order they are defined.
+func (n *NodePool) OverflowPools() []string {
+	if n == nil || len(n.Overflow) == 0 {
+		return nil
+	}
+
+	overflow := slices.Clone(n.Overflow)
+	slices.SortStableFunc(overflow, func(a, b *NodePoolOverflow) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+
+	pools := make([]string, len(overflow))
+	for i, o := range overflow {
+		pools[i] = o.Pool
+	}
+	return pools
+}
+
 // Stub implements support for pagination
 func (n *NodePool) Stub() (*NodePool, error) {
 	return n, nil
@@ -164,6 +219,11 @@ func (n *NodePool) SetHash() []byte {
 		}
 	}
 
+	for _, o := range n.Overflow {
+		_, _ = hash.Write([]byte(o.Pool))
+		_ = binary.Write(hash, binary.LittleEndian, int64(o.Priority))
+	}
+
 	// sort keys to ensure hash stability when meta is stored later
 	var keys []string
 	for k := range n.Meta {
@@ -216,6 +276,41 @@ func (n *NodePoolSchedulerConfiguration) Copy() *NodePoolSchedulerConfiguration
 	return nc
 }
 
+// NodePoolOverflow is a node pool that the jobs of a node pool overflow into
+// when none of the nodes of their node pool are feasible.
+type NodePoolOverflow struct {
+	// Pool is the name of the node pool to overflow into.
+	Pool string `hcl:"pool"`
+
+	// Priority orders the node pools jobs overflow into. Node pools with a
+	// higher priority are tried first.
+	Priority int `hcl:"priority"`
+}
+
+// Copy returns a copy of the node pool overflow.
+func (o *NodePoolOverflow) Copy() *NodePoolOverflow {
+	if o == nil {
+		return nil
+	}
+	oc := *o
+	return &oc
+}
+
+// Validate returns an error if the node pool overflow is invalid.
+func (o *NodePoolOverflow) Validate() error {
+	var mErr *multierror.Error
+
+	mErr = multierror.Append(mErr, ValidateNodePoolName(o.Pool))
+	if o.Pool == NodePoolAll {
+		mErr = multierror.Append(mErr, fmt.Errorf("can't overflow into the %q node pool", NodePoolAll))
+	}
+	if o.Priority < 0 || o.Priority > maxNodePoolOverflowPriority {
+		mErr = multierror.Append(mErr, fmt.Errorf("priority must be between 0 and %d", maxNodePoolOverflowPriority))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
 // NodePoolListRequest is used to list node pools.
 type NodePoolListRequest struct {
 	QueryOptions
@@ -74,6 +74,51 @@ func TestNodePool_Validate(t *testing.T) {
 			},
 			expectedErr: "description longer",
 		},
+		{
+			name: "valid overflow",
+			pool: &NodePool{
+				Name: "valid",
+				Overflow: []*NodePoolOverflow{
+					{Pool: "burst", Priority: 50},
+					{Pool: "spot"},
+				},
+			},
+		},
+		{
+			name: "overflow into itself",
+			pool: &NodePool{
+				Name:     "valid",
+				Overflow: []*NodePoolOverflow{{Pool: "valid"}},
+			},
+			expectedErr: "can't overflow into itself",
+		},
+		{
+			name: "overflow into all",
+			pool: &NodePool{
+				Name:     "valid",
+				Overflow: []*NodePoolOverflow{{Pool: NodePoolAll}},
+			},
+			expectedErr: "can't overflow into",
+		},
+		{
+			name: "duplicate overflow",
+			pool: &NodePool{
+				Name: "valid",
+				Overflow: []*NodePoolOverflow{
+					{Pool: "burst"},
+					{Pool: "burst", Priority: 10},
+				},
+			},
+			expectedErr: `duplicate node pool "burst"`,
+		},
+		{
+			name: "invalid overflow priority",
+			pool: &NodePool{
+				Name:     "valid",
+				Overflow: []*NodePoolOverflow{{Pool: "burst", Priority: 101}},
+			},
+			expectedErr: "priority",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -194,3 +239,27 @@ func TestNodePool_MemoryOversubscriptionEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestNodePool_OverflowPools(t *testing.T) {
+	ci.Parallel(t)
+
+	pool := &NodePool{
+		Name: "prod",
+		Overflow: []*NodePoolOverflow{
+			{Pool: "spot"},
+			{Pool: "burst", Priority: 50},
+			{Pool: "dev", Priority: 10},
+			{Pool: "test"},
+		},
+	}
+	must.Eq(t, []string{"burst", "dev", "spot", "test"}, pool.OverflowPools())
+
+	// overflow priorities are part of the hash and copies are deep
+	hash := pool.SetHash()
+	poolCopy := pool.Copy()
+	poolCopy.Overflow[1].Priority = 5
+	must.NotEq(t, hash, poolCopy.SetHash())
+	must.Eq(t, 50, pool.Overflow[1].Priority)
+
+	must.Nil(t, (&NodePool{Name: "empty"}).OverflowPools())
+}
@@ -292,6 +292,10 @@ func (s *SchedulerConfiguration) Validate() error {
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	if delta := s.PreemptionConfig.MinPriorityDelta; delta < 0 {
+		return fmt.Errorf("invalid preemption minimum priority delta: %d", delta)
+	}
+
 	return nil
 }
 
@@ -326,6 +330,25 @@ type PreemptionConfig struct {
 
 	// ServiceSchedulerEnabled specifies if preemption is enabled for service jobs
 	ServiceSchedulerEnabled bool `hcl:"service_scheduler_enabled"`
+
+	// MinPriorityDelta is the minimum difference between the priority of a
+	// job and the priority of the jobs whose allocations it can preempt. A
+	// zero value uses DefaultPreemptionMinPriorityDelta.
+	MinPriorityDelta int `hcl:"min_priority_delta"`
+}
+
+// DefaultPreemptionMinPriorityDelta is the minimum priority difference between
+// jobs required for preemption when it is not configured. It prevents a
+// cascade of preemptions between jobs close in priority.
+const DefaultPreemptionMinPriorityDelta = 10
+
+// EffectiveMinPriorityDelta returns the minimum priority difference between
+// jobs required for preemption.
+func (p PreemptionConfig) EffectiveMinPriorityDelta() int {
+	if p.MinPriorityDelta == 0 {
+		return DefaultPreemptionMinPriorityDelta
+	}
+	return p.MinPriorityDelta
 }
 
 // SchedulerSetConfigRequest is used by the Operator endpoint to update the
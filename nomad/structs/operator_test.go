@@ -106,3 +106,21 @@ func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulerConfiguration_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	schedConfig := &SchedulerConfiguration{SchedulerAlgorithm: SchedulerAlgorithmSpread}
+	must.NoError(t, schedConfig.Validate())
+	must.Eq(t, DefaultPreemptionMinPriorityDelta, schedConfig.PreemptionConfig.EffectiveMinPriorityDelta())
+
+	schedConfig.PreemptionConfig.MinPriorityDelta = 20
+	must.NoError(t, schedConfig.Validate())
+	must.Eq(t, 20, schedConfig.PreemptionConfig.EffectiveMinPriorityDelta())
+
+	schedConfig.PreemptionConfig.MinPriorityDelta = -1
+	must.ErrorContains(t, schedConfig.Validate(), "invalid preemption minimum priority delta")
+
+	schedConfig = &SchedulerConfiguration{SchedulerAlgorithm: "random"}
+	must.ErrorContains(t, schedConfig.Validate(), "invalid scheduler algorithm")
+}
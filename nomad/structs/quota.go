@@ -0,0 +1,643 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// maxQuotaDescriptionLength limits a quota specification description
+	// length
+	maxQuotaDescriptionLength = 256
+
+	// bytesPerMB is the number of bytes in the megabytes storage limits are
+	// expressed in.
+	bytesPerMB = 1 << 20
+)
+
+// QuotaSpec specifies the allowed resource usage of the namespaces that
+// account against it, per region.
+type QuotaSpec struct {
+	// Name is the name for the quota object
+	Name string
+
+	// Description is an optional description for the quota object
+	Description string
+
+	// Limits is the set of quota limits encapsulated by this quota object.
+	// Each limit applies quota in a particular region.
+	Limits []*QuotaLimit
+
+	// Hash is the hash of the object and is used to make replication
+	// efficient.
+	Hash []byte
+
+	// Raft indexes to track creation and modification
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// QuotaLimit describes the resource limit in a particular region.
+type QuotaLimit struct {
+	// Region is the region in which this limit has affect
+	Region string
+
+	// RegionLimit is the quota limit that applies to any allocation within a
+	// referencing namespace in the region. A value of zero is treated as
+	// unlimited and a negative value is treated as fully disallowed.
+	RegionLimit *QuotaResources
+
+	// VariablesLimit is the maximum total size of all variables
+	// Variable.EncryptedData, in megabytes. A value of zero is treated as
+	// unlimited and a negative value is treated as fully disallowed.
+	//
+	// Deprecated: use RegionLimit.Storage.VariablesMB instead.
+	VariablesLimit *int
+
+	// Hash is the hash of the object and is used to key the usage of the
+	// limit.
+	Hash []byte
+}
+
+// QuotaResources is the set of resources a quota limit restricts. When used to
+// describe the usage of a limit, it holds the resources the allocations,
+// variables, and host volumes of the namespaces of the quota use.
+type QuotaResources struct {
+	CPU         int
+	Cores       int
+	MemoryMB    int
+	MemoryMaxMB int
+	DiskMB      int
+	Devices     []*RequestedDevice
+	NUMA        *NUMA
+	SecretsMB   int
+	Storage     *QuotaStorageResources
+}
+
+// QuotaStorageResources is the set of storage resources a quota limit
+// restricts, in megabytes.
+type QuotaStorageResources struct {
+	// VariablesMB is the maximum total size of all variables
+	// Variable.EncryptedData.
+	VariablesMB int
+
+	// HostVolumesMB is the maximum provisioned size of all dynamic host
+	// volumes.
+	HostVolumesMB int
+}
+
+// QuotaUsage is the resource usage of the namespaces of a quota in the local
+// region. Used is keyed by the base64 encoded hash of the limit it tracks the
+// usage of.
+type QuotaUsage struct {
+	Name        string
+	Used        map[string]*QuotaLimit
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Copy returns a deep copy of the quota specification.
+func (q *QuotaSpec) Copy() *QuotaSpec {
+	if q == nil {
+		return nil
+	}
+
+	nq := new(QuotaSpec)
+	*nq = *q
+	nq.Hash = slices.Clone(q.Hash)
+	if q.Limits != nil {
+		nq.Limits = make([]*QuotaLimit, len(q.Limits))
+		for i, l := range q.Limits {
+			nq.Limits[i] = l.Copy()
+		}
+	}
+	return nq
+}
+
+// Validate returns an error if the quota specification is invalid.
+func (q *QuotaSpec) Validate() error {
+	var mErr multierror.Error
+
+	if !validNamespaceName.MatchString(q.Name) {
+		err := fmt.Errorf("invalid name %q. Must match regex %s", q.Name, validNamespaceName)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(q.Description) > maxQuotaDescriptionLength {
+		err := fmt.Errorf("description longer than %d", maxQuotaDescriptionLength)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	regions := make(map[string]struct{}, len(q.Limits))
+	for i, l := range q.Limits {
+		if l == nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("limit %d is empty", i))
+			continue
+		}
+		if _, ok := regions[l.Region]; ok {
+			err := fmt.Errorf("limit %d: duplicate limit for region %q", i, l.Region)
+			mErr.Errors = append(mErr.Errors, err)
+		}
+		regions[l.Region] = struct{}{}
+
+		if err := l.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("limit %d: %v", i, err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// SetHash sets the hash of the quota specification and of each of its limits,
+// and returns the hash of the specification.
+func (q *QuotaSpec) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	_, _ = hash.Write([]byte(q.Name))
+	_, _ = hash.Write([]byte(q.Description))
+	for _, l := range q.Limits {
+		_, _ = hash.Write(l.SetHash())
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	q.Hash = hashVal
+	return hashVal
+}
+
+// LimitForRegion returns the limit of the quota specification that applies to
+// the region, or nil if the region is not limited.
+func (q *QuotaSpec) LimitForRegion(region string) *QuotaLimit {
+	if q == nil {
+		return nil
+	}
+	for _, l := range q.Limits {
+		if l.Region == region {
+			return l
+		}
+	}
+	return nil
+}
+
+// Copy returns a deep copy of the quota limit.
+func (l *QuotaLimit) Copy() *QuotaLimit {
+	if l == nil {
+		return nil
+	}
+
+	nl := new(QuotaLimit)
+	*nl = *l
+	nl.RegionLimit = l.RegionLimit.Copy()
+	if l.VariablesLimit != nil {
+		limit := *l.VariablesLimit
+		nl.VariablesLimit = &limit
+	}
+	nl.Hash = slices.Clone(l.Hash)
+	return nl
+}
+
+// Validate returns an error if the quota limit is invalid.
+func (l *QuotaLimit) Validate() error {
+	var mErr multierror.Error
+
+	if l.Region == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing region"))
+	}
+	if l.RegionLimit == nil {
+		mErr.Errors = append(mErr.Errors, errors.New("missing region limit"))
+	} else if err := l.RegionLimit.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// SetHash sets and returns the hash of the quota limit.
+func (l *QuotaLimit) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	writeInt := func(v int) {
+		_ = binary.Write(hash, binary.LittleEndian, int64(v))
+	}
+
+	_, _ = hash.Write([]byte(l.Region))
+	if l.VariablesLimit != nil {
+		writeInt(*l.VariablesLimit)
+	}
+	if r := l.RegionLimit; r != nil {
+		writeInt(r.CPU)
+		writeInt(r.Cores)
+		writeInt(r.MemoryMB)
+		writeInt(r.MemoryMaxMB)
+		writeInt(r.DiskMB)
+		writeInt(r.SecretsMB)
+		for _, d := range r.Devices {
+			_, _ = hash.Write([]byte(d.Name))
+			_ = binary.Write(hash, binary.LittleEndian, d.Count)
+		}
+		if r.Storage != nil {
+			writeInt(r.Storage.VariablesMB)
+			writeInt(r.Storage.HostVolumesMB)
+		}
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	l.Hash = hashVal
+	return hashVal
+}
+
+// HashKey returns the key the usage of the limit is stored under.
+func (l *QuotaLimit) HashKey() string {
+	return base64.StdEncoding.EncodeToString(l.Hash)
+}
+
+// NewUsage returns the empty usage of the limit, which tracks each of the
+// devices the limit restricts.
+func (l *QuotaLimit) NewUsage() *QuotaResources {
+	usage := &QuotaResources{}
+	if l.RegionLimit != nil {
+		for _, d := range l.RegionLimit.Devices {
+			usage.Devices = append(usage.Devices, &RequestedDevice{Name: d.Name})
+		}
+	}
+	return usage
+}
+
+// VariablesLimitMB returns the limit of the total size of variables, in
+// megabytes.
+func (l *QuotaLimit) VariablesLimitMB() int {
+	if l.RegionLimit != nil && l.RegionLimit.Storage != nil && l.RegionLimit.Storage.VariablesMB != 0 {
+		return l.RegionLimit.Storage.VariablesMB
+	}
+	if l.VariablesLimit != nil {
+		return *l.VariablesLimit
+	}
+	return 0
+}
+
+// HostVolumesLimitMB returns the limit of the total size of host volumes, in
+// megabytes.
+func (l *QuotaLimit) HostVolumesLimitMB() int {
+	if l.RegionLimit != nil && l.RegionLimit.Storage != nil {
+		return l.RegionLimit.Storage.HostVolumesMB
+	}
+	return 0
+}
+
+// Exhausted returns the dimensions of the limit that the used resources exceed.
+// When delta is set, only the dimensions it increases are returned, so that
+// changes that reduce the usage of a quota that is already over its limit are
+// still allowed.
+func (l *QuotaLimit) Exhausted(used, delta *QuotaResources) []string {
+	limit := l.RegionLimit
+	if limit == nil || used == nil {
+		return nil
+	}
+
+	var exhausted []string
+	check := func(dimension string, limit, used, delta int) {
+		if delta <= 0 {
+			return
+		}
+		if limit < 0 && used > 0 || limit > 0 && used > limit {
+			exhausted = append(exhausted,
+				fmt.Sprintf("%s exhausted (%d needed > %d limit)", dimension, used, max(limit, 0)))
+		}
+	}
+
+	var d QuotaResources
+	if delta != nil {
+		d = *delta
+	} else {
+		// without a delta, all the dimensions are checked
+		d = QuotaResources{CPU: 1, Cores: 1, MemoryMB: 1, MemoryMaxMB: 1, DiskMB: 1}
+	}
+	check("cpu", limit.CPU, used.CPU, d.CPU)
+	check("cores", limit.Cores, used.Cores, d.Cores)
+	check("memory", limit.MemoryMB, used.MemoryMB, d.MemoryMB)
+	check("memory_max", limit.MemoryMaxMB, used.MemoryMaxMB, d.MemoryMaxMB)
+	check("disk", limit.DiskMB, used.DiskMB, d.DiskMB)
+
+	for _, device := range limit.Devices {
+		deviceDelta := 1
+		if delta != nil {
+			deviceDelta = int(delta.deviceCount(device.Name))
+		}
+		check("devices "+device.Name, int(device.Count), int(used.deviceCount(device.Name)), deviceDelta)
+	}
+
+	return exhausted
+}
+
+// Copy returns a deep copy of the quota resources.
+func (r *QuotaResources) Copy() *QuotaResources {
+	if r == nil {
+		return nil
+	}
+
+	nr := new(QuotaResources)
+	*nr = *r
+	if r.Devices != nil {
+		nr.Devices = make([]*RequestedDevice, len(r.Devices))
+		for i, d := range r.Devices {
+			nr.Devices[i] = d.Copy()
+		}
+	}
+	nr.NUMA = r.NUMA.Copy()
+	if r.Storage != nil {
+		storage := *r.Storage
+		nr.Storage = &storage
+	}
+	return nr
+}
+
+// Validate returns an error if the quota resources are invalid or restrict
+// resources that quotas can't enforce.
+func (r *QuotaResources) Validate() error {
+	var mErr multierror.Error
+
+	names := make(map[string]struct{}, len(r.Devices))
+	for i, d := range r.Devices {
+		if d == nil || d.Name == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("device %d requires a name", i))
+			continue
+		}
+		if _, ok := names[d.Name]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("duplicate device %q", d.Name))
+		}
+		names[d.Name] = struct{}{}
+	}
+	if r.NUMA != nil {
+		mErr.Errors = append(mErr.Errors, errors.New("numa limits are not supported"))
+	}
+	if r.SecretsMB != 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("secrets limits are not supported"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Equal returns whether the quota resources are the same.
+func (r *QuotaResources) Equal(o *QuotaResources) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+	if r.CPU != o.CPU || r.Cores != o.Cores || r.MemoryMB != o.MemoryMB ||
+		r.MemoryMaxMB != o.MemoryMaxMB || r.DiskMB != o.DiskMB || r.SecretsMB != o.SecretsMB {
+		return false
+	}
+	if !slices.EqualFunc(r.Devices, o.Devices, func(a, b *RequestedDevice) bool {
+		return a.Name == b.Name && a.Count == b.Count
+	}) {
+		return false
+	}
+	if r.Storage == nil || o.Storage == nil {
+		return r.Storage == o.Storage
+	}
+	return *r.Storage == *o.Storage
+}
+
+// Add adds the usage of delta, for the devices r tracks.
+func (r *QuotaResources) Add(delta *QuotaResources) {
+	if delta == nil {
+		return
+	}
+
+	r.CPU += delta.CPU
+	r.Cores += delta.Cores
+	r.MemoryMB += delta.MemoryMB
+	r.MemoryMaxMB += delta.MemoryMaxMB
+	r.DiskMB += delta.DiskMB
+	for _, d := range r.Devices {
+		d.Count += delta.deviceCount(d.Name)
+	}
+}
+
+// Subtract subtracts the usage of delta, for the devices r tracks.
+func (r *QuotaResources) Subtract(delta *QuotaResources) {
+	if delta == nil {
+		return
+	}
+
+	r.CPU -= delta.CPU
+	r.Cores -= delta.Cores
+	r.MemoryMB -= delta.MemoryMB
+	r.MemoryMaxMB -= delta.MemoryMaxMB
+	r.DiskMB -= delta.DiskMB
+	for _, d := range r.Devices {
+		d.Count -= min(d.Count, delta.deviceCount(d.Name))
+	}
+}
+
+// AddAllocation adds the resources allocated to the allocation. Devices are
+// added to each of the tracked devices whose name matches their vendor, type,
+// and model.
+func (r *QuotaResources) AddAllocation(alloc *Allocation) {
+	if alloc == nil || alloc.AllocatedResources == nil {
+		return
+	}
+
+	resources := alloc.AllocatedResources
+	for _, task := range resources.Tasks {
+		if cores := len(task.Cpu.ReservedCores); cores > 0 {
+			r.Cores += cores
+		} else {
+			r.CPU += int(task.Cpu.CpuShares)
+		}
+		r.MemoryMB += int(task.Memory.MemoryMB)
+		r.MemoryMaxMB += int(max(task.Memory.MemoryMaxMB, task.Memory.MemoryMB))
+
+		for _, device := range task.Devices {
+			r.addDevices(device.ID(), uint64(len(device.DeviceIDs)))
+		}
+	}
+	r.DiskMB += int(resources.Shared.DiskMB)
+}
+
+// AddTaskGroup adds the resources requested by a single allocation of the task
+// group. Devices are added to each of the tracked devices whose name matches
+// the requested vendor, type, and model.
+func (r *QuotaResources) AddTaskGroup(tg *TaskGroup) {
+	if tg == nil {
+		return
+	}
+
+	for _, task := range tg.Tasks {
+		if task.Resources == nil {
+			continue
+		}
+		if task.Resources.Cores > 0 {
+			r.Cores += task.Resources.Cores
+		} else {
+			r.CPU += task.Resources.CPU
+		}
+		r.MemoryMB += task.Resources.MemoryMB
+		r.MemoryMaxMB += max(task.Resources.MemoryMaxMB, task.Resources.MemoryMB)
+
+		for _, device := range task.Resources.Devices {
+			r.addDevices(device.ID(), device.Count)
+		}
+	}
+	if tg.EphemeralDisk != nil {
+		r.DiskMB += tg.EphemeralDisk.SizeMB
+	}
+}
+
+// addDevices adds count devices to the tracked devices the device ID matches.
+func (r *QuotaResources) addDevices(id *DeviceIdTuple, count uint64) {
+	if id == nil {
+		return
+	}
+	for _, d := range r.Devices {
+		if id.Matches(d.ID()) {
+			d.Count += count
+		}
+	}
+}
+
+// deviceCount returns the tracked count of the named device.
+func (r *QuotaResources) deviceCount(name string) uint64 {
+	for _, d := range r.Devices {
+		if d.Name == name {
+			return d.Count
+		}
+	}
+	return 0
+}
+
+// QuotaUsageChange returns the usage of the allocations of the namespace that
+// the plan stops, preempts, or replaces, and the usage of the allocations it
+// places or updates, for the devices the limit tracks. The lookup function
+// returns the existing allocation with the given ID, if any.
+func (p *Plan) QuotaUsageChange(limit *QuotaLimit, namespace string,
+	lookup func(string) (*Allocation, error)) (*QuotaResources, *QuotaResources, error) {
+
+	before, after := limit.NewUsage(), limit.NewUsage()
+	for _, allocs := range []map[string][]*Allocation{p.NodeUpdate, p.NodePreemptions, p.NodeAllocation} {
+		for _, nodeAllocs := range allocs {
+			for _, alloc := range nodeAllocs {
+				if alloc.Namespace != namespace {
+					continue
+				}
+
+				// the allocations of the plan are already marked as stopped,
+				// so the usage they replace is the one of the existing
+				// allocations
+				existing, err := lookup(alloc.ID)
+				if err != nil {
+					return nil, nil, err
+				}
+				if existing != nil && !existing.TerminalStatus() {
+					before.AddAllocation(existing)
+				}
+			}
+		}
+	}
+	for _, nodeAllocs := range p.NodeAllocation {
+		for _, alloc := range nodeAllocs {
+			if alloc.Namespace == namespace && !alloc.TerminalStatus() {
+				after.AddAllocation(alloc)
+			}
+		}
+	}
+	return before, after, nil
+}
+
+// BytesToQuotaMB returns the storage size in megabytes quota usages report,
+// rounding up partial megabytes.
+func BytesToQuotaMB(size int64) int {
+	return int((size + bytesPerMB - 1) / bytesPerMB)
+}
+
+// QuotaMBToBytes returns the number of bytes of a storage size in megabytes.
+func QuotaMBToBytes(size int) int64 {
+	return int64(size) * bytesPerMB
+}
+
+// Copy returns a deep copy of the quota usage.
+func (q *QuotaUsage) Copy() *QuotaUsage {
+	if q == nil {
+		return nil
+	}
+
+	nq := new(QuotaUsage)
+	*nq = *q
+	if q.Used != nil {
+		nq.Used = make(map[string]*QuotaLimit, len(q.Used))
+		for k, v := range q.Used {
+			nq.Used[k] = v.Copy()
+		}
+	}
+	return nq
+}
+
+// QuotaSpecUpsertRequest is used to upsert a set of quota specifications
+type QuotaSpecUpsertRequest struct {
+	Quotas []*QuotaSpec
+	WriteRequest
+}
+
+// QuotaSpecDeleteRequest is used to delete a set of quota specifications
+type QuotaSpecDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
+
+// QuotaSpecListRequest is used to request a list of quota specifications or
+// usages
+type QuotaSpecListRequest struct {
+	QueryOptions
+}
+
+// QuotaSpecListResponse is used for a list request
+type QuotaSpecListResponse struct {
+	Quotas []*QuotaSpec
+	QueryMeta
+}
+
+// QuotaSpecSpecificRequest is used to query a specific quota specification or
+// usage
+type QuotaSpecSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleQuotaSpecResponse is used to return a single quota specification
+type SingleQuotaSpecResponse struct {
+	Quota *QuotaSpec
+	QueryMeta
+}
+
+// QuotaUsageListResponse is used for a quota usage list request
+type QuotaUsageListResponse struct {
+	Usages []*QuotaUsage
+	QueryMeta
+}
+
+// SingleQuotaUsageResponse is used to return a single quota usage
+type SingleQuotaUsageResponse struct {
+	Usage *QuotaUsage
+	QueryMeta
+}
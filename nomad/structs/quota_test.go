@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestQuotaSpec_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	limit := func(region string) *QuotaLimit {
+		return &QuotaLimit{
+			Region:      region,
+			RegionLimit: &QuotaResources{CPU: 1000},
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		spec      *QuotaSpec
+		expectErr string
+	}{
+		{
+			name: "valid",
+			spec: &QuotaSpec{
+				Name:   "valid",
+				Limits: []*QuotaLimit{limit("global"), limit("west")},
+			},
+		},
+		{
+			name:      "invalid name",
+			spec:      &QuotaSpec{Name: "not@valid"},
+			expectErr: "invalid name",
+		},
+		{
+			name: "description too long",
+			spec: &QuotaSpec{
+				Name:        "long",
+				Description: strings.Repeat("a", maxQuotaDescriptionLength+1),
+			},
+			expectErr: "description longer than",
+		},
+		{
+			name: "duplicate region",
+			spec: &QuotaSpec{
+				Name:   "duplicate",
+				Limits: []*QuotaLimit{limit("global"), limit("global")},
+			},
+			expectErr: `duplicate limit for region "global"`,
+		},
+		{
+			name: "missing region limit",
+			spec: &QuotaSpec{
+				Name:   "missing",
+				Limits: []*QuotaLimit{{Region: "global"}},
+			},
+			expectErr: "missing region limit",
+		},
+		{
+			name: "unnamed device",
+			spec: &QuotaSpec{
+				Name: "device",
+				Limits: []*QuotaLimit{{
+					Region: "global",
+					RegionLimit: &QuotaResources{
+						Devices: []*RequestedDevice{{Count: 1}},
+					},
+				}},
+			},
+			expectErr: "device 0 requires a name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spec.Validate()
+			if tc.expectErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestQuotaSpec_SetHash(t *testing.T) {
+	ci.Parallel(t)
+
+	spec := &QuotaSpec{
+		Name: "hash",
+		Limits: []*QuotaLimit{{
+			Region:      "global",
+			RegionLimit: &QuotaResources{CPU: 1000},
+		}},
+	}
+	hash := spec.SetHash()
+	must.NotNil(t, spec.Limits[0].Hash)
+
+	specCopy := spec.Copy()
+	must.Eq(t, hash, specCopy.SetHash())
+
+	specCopy.Limits[0].RegionLimit.DiskMB = 100
+	must.NotEq(t, hash, specCopy.SetHash())
+	must.NotEq(t, spec.Limits[0].HashKey(), specCopy.Limits[0].HashKey())
+}
+
+func TestQuotaLimit_Exhausted(t *testing.T) {
+	ci.Parallel(t)
+
+	limit := &QuotaLimit{
+		Region: "global",
+		RegionLimit: &QuotaResources{
+			CPU:      1000,
+			MemoryMB: -1,
+			DiskMB:   0,
+			Devices:  []*RequestedDevice{{Name: "nvidia/gpu", Count: 2}},
+		},
+	}
+
+	used := &QuotaResources{
+		CPU:      1500,
+		MemoryMB: 256,
+		DiskMB:   1 << 20,
+		Devices:  []*RequestedDevice{{Name: "nvidia/gpu", Count: 3}},
+	}
+
+	// without a delta all the dimensions are checked, and an unlimited disk
+	// is never exhausted
+	must.Eq(t, []string{
+		"cpu exhausted (1500 needed > 1000 limit)",
+		"memory exhausted (256 needed > 0 limit)",
+		"devices nvidia/gpu exhausted (3 needed > 2 limit)",
+	}, limit.Exhausted(used, nil))
+
+	// only the dimensions the delta increases are checked
+	delta := &QuotaResources{
+		CPU:      -500,
+		MemoryMB: 128,
+		Devices:  []*RequestedDevice{{Name: "nvidia/gpu"}},
+	}
+	must.Eq(t, []string{
+		"memory exhausted (256 needed > 0 limit)",
+	}, limit.Exhausted(used, delta))
+
+	// reducing the usage of an exhausted quota is allowed
+	must.Len(t, 0, limit.Exhausted(used, &QuotaResources{CPU: -500}))
+}
+
+func TestQuotaResources_AddAllocation(t *testing.T) {
+	ci.Parallel(t)
+
+	limit := &QuotaLimit{
+		RegionLimit: &QuotaResources{
+			Devices: []*RequestedDevice{{Name: "nvidia/gpu"}, {Name: "intel/gpu"}},
+		},
+	}
+
+	alloc := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Cpu:    AllocatedCpuResources{CpuShares: 500},
+					Memory: AllocatedMemoryResources{MemoryMB: 256, MemoryMaxMB: 512},
+					Devices: []*AllocatedDeviceResource{{
+						Vendor:    "nvidia",
+						Type:      "gpu",
+						Name:      "1080ti",
+						DeviceIDs: []string{"a", "b"},
+					}},
+				},
+				"sidecar": {
+					Cpu:    AllocatedCpuResources{CpuShares: 2000, ReservedCores: []uint16{0, 1}},
+					Memory: AllocatedMemoryResources{MemoryMB: 128},
+				},
+			},
+			Shared: AllocatedSharedResources{DiskMB: 150},
+		},
+	}
+
+	usage := limit.NewUsage()
+	usage.AddAllocation(alloc)
+	must.Eq(t, &QuotaResources{
+		CPU:         500,
+		Cores:       2,
+		MemoryMB:    384,
+		MemoryMaxMB: 640,
+		DiskMB:      150,
+		Devices: []*RequestedDevice{
+			{Name: "nvidia/gpu", Count: 2},
+			{Name: "intel/gpu"},
+		},
+	}, usage)
+
+	usage.Subtract(usage.Copy())
+	must.Eq(t, limit.NewUsage(), usage)
+}
+
+func TestQuotaResources_AddTaskGroup(t *testing.T) {
+	ci.Parallel(t)
+
+	limit := &QuotaLimit{
+		RegionLimit: &QuotaResources{
+			Devices: []*RequestedDevice{{Name: "nvidia/gpu"}},
+		},
+	}
+
+	tg := &TaskGroup{
+		EphemeralDisk: &EphemeralDisk{SizeMB: 300},
+		Tasks: []*Task{
+			{
+				Resources: &Resources{
+					CPU:      500,
+					MemoryMB: 256,
+					Devices:  []*RequestedDevice{{Name: "nvidia/gpu/1080ti", Count: 1}},
+				},
+			},
+			{
+				Resources: &Resources{
+					Cores:       1,
+					MemoryMB:    128,
+					MemoryMaxMB: 256,
+				},
+			},
+		},
+	}
+
+	usage := limit.NewUsage()
+	usage.AddTaskGroup(tg)
+	must.Eq(t, &QuotaResources{
+		CPU:         500,
+		Cores:       1,
+		MemoryMB:    384,
+		MemoryMaxMB: 512,
+		DiskMB:      300,
+		Devices:     []*RequestedDevice{{Name: "nvidia/gpu", Count: 1}},
+	}, usage)
+}
+
+func TestPlan_QuotaUsageChange(t *testing.T) {
+	ci.Parallel(t)
+
+	newAlloc := func(id, namespace string, cpu int64) *Allocation {
+		return &Allocation{
+			ID:            id,
+			Namespace:     namespace,
+			DesiredStatus: AllocDesiredStatusRun,
+			ClientStatus:  AllocClientStatusRunning,
+			AllocatedResources: &AllocatedResources{
+				Tasks: map[string]*AllocatedTaskResources{
+					"web": {Cpu: AllocatedCpuResources{CpuShares: cpu}},
+				},
+			},
+		}
+	}
+
+	existing := map[string]*Allocation{
+		"stopped":   newAlloc("stopped", "prod", 100),
+		"updated":   newAlloc("updated", "prod", 200),
+		"preempted": newAlloc("preempted", "prod", 400),
+		"other":     newAlloc("other", "dev", 800),
+	}
+
+	stopped := existing["stopped"].Copy()
+	stopped.DesiredStatus = AllocDesiredStatusStop
+	plan := &Plan{
+		NodeUpdate: map[string][]*Allocation{
+			"node1": {stopped, existing["other"]},
+		},
+		NodePreemptions: map[string][]*Allocation{
+			"node1": {existing["preempted"]},
+		},
+		NodeAllocation: map[string][]*Allocation{
+			"node2": {newAlloc("updated", "prod", 300), newAlloc("new", "prod", 1000)},
+		},
+	}
+
+	before, after, err := plan.QuotaUsageChange(&QuotaLimit{}, "prod", func(id string) (*Allocation, error) {
+		return existing[id], nil
+	})
+	must.NoError(t, err)
+	must.Eq(t, 700, before.CPU)
+	must.Eq(t, 1300, after.CPU)
+}
+
+func TestBytesToQuotaMB(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, 0, BytesToQuotaMB(0))
+	must.Eq(t, 1, BytesToQuotaMB(1))
+	must.Eq(t, 1, BytesToQuotaMB(1<<20))
+	must.Eq(t, 2, BytesToQuotaMB(1<<20+1))
+	must.Eq(t, int64(3<<20), QuotaMBToBytes(3))
+}
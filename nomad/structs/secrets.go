@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Secrets are Nomad Variables that are written to the secrets directory of a
+// task before it starts, which allows small clusters to provide secrets to
+// tasks without running Vault. Variables are encrypted by the keyring of the
+// servers, so no additional secret storage is needed.
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// validSecretName is used to validate a secret name. The name is used as the
+// directory the secret is written to, so it must be a safe path component.
+var validSecretName = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+
+type Secret struct {
+	// Name of the secret, which is the directory inside the task's secrets
+	// directory the items of the variable are written to.
+	Name string
+
+	// Path of the Nomad Variable in the namespace of the job.
+	Path string
+
+	// Env exposes the items of the variable as environment variables of the
+	// task, in addition to writing them to files.
+	Env bool
+}
+
+func (s *Secret) Copy() *Secret {
+	if s == nil {
+		return nil
+	}
+	ns := new(Secret)
+	*ns = *s
+	return ns
+}
+
+func (s *Secret) Equal(o *Secret) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	return *s == *o
+}
+
+func (s *Secret) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	if !validSecretName.MatchString(s.Name) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid name %q. Must match regex %s", s.Name, validSecretName))
+	}
+	if s.Path == "" {
+		mErr = multierror.Append(mErr, errors.New("path cannot be empty"))
+	} else if err := ValidatePath(s.Path); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
+	return mErr.ErrorOrNil()
+}
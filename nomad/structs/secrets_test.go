@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSecret_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	var s *Secret
+	must.Nil(t, s.Copy())
+
+	s = &Secret{Name: "db", Path: "db/creds", Env: true}
+	c := s.Copy()
+	must.Equal(t, s, c)
+
+	c.Path = "other"
+	must.NotEqual(t, s, c)
+}
+
+func TestSecret_Equal(t *testing.T) {
+	ci.Parallel(t)
+
+	var s *Secret
+	must.True(t, s.Equal(nil))
+	must.False(t, s.Equal(&Secret{}))
+
+	s = &Secret{Name: "db", Path: "db/creds"}
+	must.True(t, s.Equal(&Secret{Name: "db", Path: "db/creds"}))
+	must.False(t, s.Equal(&Secret{Name: "db", Path: "db/creds", Env: true}))
+}
+
+func TestSecret_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		secret *Secret
+		err    string
+	}{
+		{
+			name:   "nil",
+			secret: nil,
+		},
+		{
+			name:   "valid",
+			secret: &Secret{Name: "db_creds-1", Path: "nomad/jobs/example/db"},
+		},
+		{
+			name:   "invalid name",
+			secret: &Secret{Name: "../db", Path: "db"},
+			err:    `invalid name "../db"`,
+		},
+		{
+			name:   "empty path",
+			secret: &Secret{Name: "db"},
+			err:    "path cannot be empty",
+		},
+		{
+			name:   "invalid path",
+			secret: &Secret{Name: "db", Path: "db.creds"},
+			err:    `invalid path "db.creds"`,
+		},
+		{
+			name:   "reserved path",
+			secret: &Secret{Name: "db", Path: "nomad/db"},
+			err:    "only paths at",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.secret.Validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+				return
+			}
+			must.ErrorContains(t, err, tc.err)
+		})
+	}
+}
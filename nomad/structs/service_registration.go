@@ -87,10 +87,23 @@ type ServiceRegistration struct {
 	// on cluster network topology.
 	Address string
 
+	// AddressIPv6 is the IPv6 address of service registrations in dual-stack
+	// allocation networks, in which case Address is the IPv4 address.
+	AddressIPv6 string
+
 	// Port is the port number on which this service registration is bound. It
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// Weight is the relative weight of this service registration to send
+	// traffic to, determined from the passing weight of either
+	// Service.Weights or Service.CanaryWeights. It defaults to 1.
+	Weight int
+
+	// Canary indicates the allocation of this service registration is a
+	// canary of a deployment which has not been promoted yet.
+	Canary bool
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -139,12 +152,21 @@ func (s *ServiceRegistration) Equal(o *ServiceRegistration) bool {
 	if s.Address != o.Address {
 		return false
 	}
+	if s.AddressIPv6 != o.AddressIPv6 {
+		return false
+	}
 	if s.Port != o.Port {
 		return false
 	}
 	if !helper.SliceSetEq(s.Tags, o.Tags) {
 		return false
 	}
+	if s.Weight != o.Weight {
+		return false
+	}
+	if s.Canary != o.Canary {
+		return false
+	}
 	return true
 }
 
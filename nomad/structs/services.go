@@ -5,6 +5,7 @@ package structs
 
 import (
 	"crypto/sha1"
+	"crypto/x509"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -37,6 +38,11 @@ const (
 	ServiceCheckScript = "script"
 	ServiceCheckGRPC   = "grpc"
 
+	// ServiceCheckDocker checks report the status of the health check run by
+	// the task driver, such as the HEALTHCHECK of a Docker image. Like script
+	// checks, they are registered as TTL checks updated by the client.
+	ServiceCheckDocker = "docker"
+
 	OnUpdateRequireHealthy = "require_healthy"
 	OnUpdateIgnoreWarn     = "ignore_warnings"
 	OnUpdateIgnore         = "ignore"
@@ -71,11 +77,13 @@ type ServiceCheck struct {
 	Notes                  string              // Specifies arbitrary information for humans. This is not used by Consul internally
 	TLSServerName          string              // ServerName to use for SNI and TLS verification when (Type=https and Protocol=https) or (Type=grpc and GRPCUseTLS=true)
 	TLSSkipVerify          bool                // Skip TLS verification when (type=https and Protocol=https) or (type=grpc and grpc_use_tls=true)
+	TLSCACert              string              // PEM encoded CA certificates used for TLS verification (Nomad checks only)
 	Method                 string              // HTTP Method to use (GET by default)
 	Header                 map[string][]string // HTTP Headers for Consul to set when making HTTP checks
 	CheckRestart           *CheckRestart       // If and when a task should be restarted based on checks
 	GRPCService            string              // Service for GRPC checks
 	GRPCUseTLS             bool                // Whether or not to use TLS for GRPC checks
+	TCPUseTLS              bool                // Whether or not to use TLS for TCP checks
 	TaskName               string              // What task to execute this check in
 	SuccessBeforePassing   int                 // Number of consecutive successes required before considered healthy
 	FailuresBeforeCritical int                 // Number of consecutive failures required before considered unhealthy
@@ -198,6 +206,14 @@ func (sc *ServiceCheck) Equal(o *ServiceCheck) bool {
 		return false
 	}
 
+	if sc.TLSCACert != o.TLSCACert {
+		return false
+	}
+
+	if sc.TCPUseTLS != o.TCPUseTLS {
+		return false
+	}
+
 	if sc.Timeout != o.Timeout {
 		return false
 	}
@@ -279,6 +295,10 @@ func (sc *ServiceCheck) validateCommon(allowableTypes []string) error {
 		}
 	}
 
+	if sc.TCPUseTLS && checkType != ServiceCheckTCP {
+		return fmt.Errorf("tcp_use_tls may only be set for tcp checks")
+	}
+
 	// validate interval
 	if sc.Interval == 0 {
 		return fmt.Errorf("missing required value interval. Interval cannot be less than %v", minCheckInterval)
@@ -347,11 +367,17 @@ func (sc *ServiceCheck) validateCommon(allowableTypes []string) error {
 
 // validate a Service's ServiceCheck in the context of the Nomad provider.
 func (sc *ServiceCheck) validateNomad() error {
-	allowable := []string{ServiceCheckTCP, ServiceCheckHTTP}
+	allowable := []string{ServiceCheckGRPC, ServiceCheckTCP, ServiceCheckHTTP}
 	if err := sc.validateCommon(allowable); err != nil {
 		return err
 	}
 
+	if sc.TLSCACert != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(sc.TLSCACert)) {
+			return errors.New("tls_ca_cert must contain PEM encoded certificates")
+		}
+	}
+
 	// expose is connect (consul) specific
 	if sc.Expose {
 		return errors.New("expose may only be set for Consul service checks")
@@ -398,23 +424,24 @@ func (sc *ServiceCheck) validateNomad() error {
 		return errors.New("failures_before_warning may only be set for Consul service checks")
 	}
 
-	// tls_server_name is consul only
-	if sc.TLSServerName != "" {
-		return errors.New("tls_server_name may only be set for Consul service checks")
-	}
-
 	return nil
 }
 
 // validate a Service's ServiceCheck in the context of the Consul provider.
 func (sc *ServiceCheck) validateConsul() error {
-	allowable := []string{ServiceCheckGRPC, ServiceCheckTCP, ServiceCheckHTTP, ServiceCheckScript}
+	allowable := []string{ServiceCheckGRPC, ServiceCheckTCP, ServiceCheckHTTP, ServiceCheckScript, ServiceCheckDocker}
 	if err := sc.validateCommon(allowable); err != nil {
 		return err
 	}
 
 	checkType := strings.ToLower(sc.Type)
 
+	// tls_ca_cert is nomad only, as Consul verifies checks with the CA of
+	// the Consul agent
+	if sc.TLSCACert != "" {
+		return errors.New("tls_ca_cert may only be set for Nomad service checks")
+	}
+
 	// Note that we cannot completely validate the Expose field yet - we do not
 	// know whether this ServiceCheck belongs to a connect-enabled group-service.
 	// Instead, such validation will happen in a job admission controller.
@@ -517,6 +544,12 @@ func (sc *ServiceCheck) Hash(serviceID string) string {
 	// use name "true" to maintain ID stability
 	hashBool(h, sc.GRPCUseTLS, "true")
 
+	// Only include TCP TLS settings if set to maintain ID stability
+	if sc.TCPUseTLS {
+		hashString(h, "TCPUseTLS")
+	}
+	hashStringIfNonEmpty(h, sc.TLSCACert)
+
 	// Only include pass/fail if non-zero to maintain ID stability with Nomad < 0.12
 	hashIntIfNonZero(h, "success", sc.SuccessBeforePassing)
 	hashIntIfNonZero(h, "failures", sc.FailuresBeforeCritical)
@@ -620,6 +653,10 @@ type Service struct {
 	CanaryMeta map[string]string // Consul service meta when it is a canary
 	Weights    *ServiceWeights   // Service weights for DNS SRV request
 
+	// CanaryWeights are the service weights when it is a canary, so that
+	// a fraction of traffic can be sent to canaries until they are promoted.
+	CanaryWeights *ServiceWeights
+
 	// The values to set for tagged_addresses in Consul service registration.
 	// Does not affect Nomad networking, these are for Consul service discovery.
 	TaggedAddresses map[string]string
@@ -673,6 +710,7 @@ func (s *Service) Copy() *Service {
 	ns.TaggedAddresses = maps.Clone(s.TaggedAddresses)
 
 	ns.Weights = s.Weights.Copy()
+	ns.CanaryWeights = s.CanaryWeights.Copy()
 	ns.Identity = s.Identity.Copy()
 
 	return ns
@@ -897,9 +935,12 @@ func (s *Service) validateNomadService(mErr *multierror.Error) {
 		}
 	}
 
-	// Services using the Nomad provider do not support Consul connect.
+	// Services using the Nomad provider only support Connect sidecar proxies,
+	// which Nomad bootstraps without Consul.
 	if s.Connect != nil {
-		mErr.Errors = append(mErr.Errors, errors.New("Service with provider nomad cannot include Connect blocks"))
+		if err := s.Connect.validateNomadProvider(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
 	}
 }
 
@@ -914,6 +955,10 @@ func (s *Service) validateIdentity() error {
 		return fmt.Errorf("Service identity must provide at least one target aud value")
 	}
 
+	if s.Identity.AWS != nil {
+		return fmt.Errorf("Service identity does not support aws")
+	}
+
 	return nil
 }
 
@@ -957,6 +1002,10 @@ func (s *Service) Hash(allocID, taskName string, canary bool) string {
 	hashString(h, s.Namespace)
 	hashIdentity(h, s.Identity)
 	hashWeights(h, s.Weights)
+	if s.CanaryWeights != nil {
+		hashString(h, "CanaryWeights")
+		hashWeights(h, s.CanaryWeights)
+	}
 
 	// Don't hash the provider parameter, so we don't cause churn of all
 	// registered services when upgrading Nomad versions. The provider is not
@@ -1137,6 +1186,10 @@ func (s *Service) Equal(o *Service) bool {
 		return false
 	}
 
+	if !s.CanaryWeights.Equal(o.CanaryWeights) {
+		return false
+	}
+
 	return true
 }
 
@@ -1144,6 +1197,16 @@ func (s *Service) IsConsul() bool {
 	return s.Provider == ServiceProviderConsul || s.Provider == ""
 }
 
+// RegistrationWeights returns the weights of the service registration of an
+// allocation, which are the canary weights if the allocation is a canary and
+// they are set. Returns nil if the service has no weights.
+func (s *Service) RegistrationWeights(canary bool) *ServiceWeights {
+	if canary && s.CanaryWeights != nil {
+		return s.CanaryWeights
+	}
+	return s.Weights
+}
+
 // ServiceWeights represents the weights for a service block.
 type ServiceWeights struct {
 	Passing int
@@ -1323,6 +1386,43 @@ func (c *ConsulConnect) Validate() error {
 	return nil
 }
 
+// validateNomadProvider returns an error if the Connect block of a service
+// using the Nomad provider uses features that require Consul.
+func (c *ConsulConnect) validateNomadProvider() error {
+	switch {
+	case c.IsNative():
+		return errors.New("Service with provider nomad cannot be Connect Native")
+	case c.IsGateway():
+		return errors.New("Service with provider nomad cannot be a Connect gateway")
+	case !c.HasSidecar():
+		return errors.New("Service with provider nomad must include a Connect sidecar_service block")
+	}
+
+	proxy := c.SidecarService.Proxy
+	if proxy == nil {
+		return nil
+	}
+	if proxy.TransparentProxy != nil {
+		return errors.New("Service with provider nomad cannot use transparent proxy")
+	}
+	if proxy.Expose != nil {
+		return errors.New("Service with provider nomad cannot use expose paths")
+	}
+	for _, up := range proxy.Upstreams {
+		switch {
+		case up.DestinationType == "prepared_query":
+			return fmt.Errorf("Upstream %q of service with provider nomad cannot be a prepared query", up.DestinationName)
+		case up.DestinationPeer != "", up.DestinationPartition != "", up.Datacenter != "":
+			return fmt.Errorf("Upstream %q of service with provider nomad cannot set a datacenter, peer, or partition", up.DestinationName)
+		case up.DestinationNamespace != "":
+			return fmt.Errorf("Upstream %q of service with provider nomad cannot set destination_namespace", up.DestinationName)
+		case up.LocalBindSocketPath != "":
+			return fmt.Errorf("Upstream %q of service with provider nomad cannot bind to a unix socket", up.DestinationName)
+		}
+	}
+	return nil
+}
+
 // ConsulSidecarService represents a Consul Connect SidecarService jobspec
 // block.
 type ConsulSidecarService struct {
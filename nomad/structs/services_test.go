@@ -5,6 +5,7 @@ package structs
 
 import (
 	"errors"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -63,6 +64,14 @@ func TestServiceCheck_Hash(t *testing.T) {
 	t.Run("failures_before_warning", func(t *testing.T) {
 		try(t, func(s *sc) { s.FailuresBeforeWarning = 99 })
 	})
+
+	t.Run("tcp_use_tls", func(t *testing.T) {
+		try(t, func(s *sc) { s.TCPUseTLS = true })
+	})
+
+	t.Run("tls_ca_cert", func(t *testing.T) {
+		try(t, func(s *sc) { s.TLSCACert = "ca" })
+	})
 }
 
 func TestServiceCheck_Canonicalize(t *testing.T) {
@@ -100,6 +109,20 @@ func TestServiceCheck_Canonicalize(t *testing.T) {
 	})
 }
 
+func TestServiceCheck_validateConsul_TLSCACert(t *testing.T) {
+	ci.Parallel(t)
+
+	err := (&ServiceCheck{
+		Name:      "check",
+		Type:      ServiceCheckTCP,
+		Interval:  1 * time.Second,
+		Timeout:   2 * time.Second,
+		TCPUseTLS: true,
+		TLSCACert: "ca",
+	}).validateConsul()
+	must.EqError(t, err, `tls_ca_cert may only be set for Nomad service checks`)
+}
+
 func TestServiceCheck_validate_PassingTypes(t *testing.T) {
 	ci.Parallel(t)
 
@@ -245,18 +268,79 @@ func TestServiceCheck_validate_OnUpdate_CheckRestart_Conflict(t *testing.T) {
 		}).validateConsul()
 		require.NoError(t, err)
 	})
+
+	t.Run("docker", func(t *testing.T) {
+		err := (&ServiceCheck{
+			Name:     "check",
+			Type:     "docker",
+			Interval: 10 * time.Second,
+			Timeout:  2 * time.Second,
+			CheckRestart: &CheckRestart{
+				Limit: 3,
+				Grace: 30 * time.Second,
+			},
+		}).validateConsul()
+		must.NoError(t, err)
+	})
 }
 
 func TestServiceCheck_validateNomad(t *testing.T) {
 	ci.Parallel(t)
 
+	caCert, err := os.ReadFile("../../helper/tlsutil/testdata/nomad-agent-ca.pem")
+	must.NoError(t, err)
+
 	testCases := []struct {
 		name string
 		sc   *ServiceCheck
 		exp  string
 	}{
-		{name: "grpc", sc: &ServiceCheck{Type: ServiceCheckGRPC}, exp: `invalid check type ("grpc"), must be one of tcp, http`},
-		{name: "script", sc: &ServiceCheck{Type: ServiceCheckScript}, exp: `invalid check type ("script"), must be one of tcp, http`},
+		{
+			name: "grpc",
+			sc: &ServiceCheck{
+				Type:          ServiceCheckGRPC,
+				Interval:      3 * time.Second,
+				Timeout:       1 * time.Second,
+				GRPCService:   "api",
+				GRPCUseTLS:    true,
+				TLSServerName: "api.service",
+			},
+		},
+		{
+			name: "tcp with tls",
+			sc: &ServiceCheck{
+				Type:          ServiceCheckTCP,
+				Interval:      3 * time.Second,
+				Timeout:       1 * time.Second,
+				TCPUseTLS:     true,
+				TLSServerName: "db.service",
+				TLSCACert:     string(caCert),
+			},
+		},
+		{
+			name: "tcp_use_tls on http",
+			sc: &ServiceCheck{
+				Type:      ServiceCheckHTTP,
+				Interval:  3 * time.Second,
+				Timeout:   1 * time.Second,
+				Path:      "/health",
+				TCPUseTLS: true,
+			},
+			exp: `tcp_use_tls may only be set for tcp checks`,
+		},
+		{
+			name: "invalid tls_ca_cert",
+			sc: &ServiceCheck{
+				Type:      ServiceCheckTCP,
+				Interval:  3 * time.Second,
+				Timeout:   1 * time.Second,
+				TCPUseTLS: true,
+				TLSCACert: "not a certificate",
+			},
+			exp: `tls_ca_cert must contain PEM encoded certificates`,
+		},
+		{name: "script", sc: &ServiceCheck{Type: ServiceCheckScript}, exp: `invalid check type ("script"), must be one of grpc, tcp, http`},
+		{name: "docker", sc: &ServiceCheck{Type: ServiceCheckDocker}, exp: `invalid check type ("docker"), must be one of grpc, tcp, http`},
 		{
 			name: "expose",
 			sc: &ServiceCheck{
@@ -392,7 +476,6 @@ func TestServiceCheck_validateNomad(t *testing.T) {
 				Path:          "/health",
 				TLSServerName: "foo",
 			},
-			exp: `tls_server_name may only be set for Consul service checks`,
 		},
 	}
 
@@ -587,6 +670,41 @@ func TestService_Hash(t *testing.T) {
 			s.Connect.SidecarService.Proxy.TransparentProxy.NoDNS = false
 		})
 	})
+
+	t.Run("mod weights", func(t *testing.T) {
+		try(t, func(s *svc) { s.Weights = &ServiceWeights{Passing: 5, Warning: 1} })
+	})
+
+	t.Run("mod canary weights", func(t *testing.T) {
+		try(t, func(s *svc) { s.CanaryWeights = &ServiceWeights{Passing: 5, Warning: 1} })
+	})
+
+	t.Run("weights moved to canary weights", func(t *testing.T) {
+		weighted := original.Copy()
+		weighted.Weights = &ServiceWeights{Passing: 5, Warning: 1}
+		canaryWeighted := original.Copy()
+		canaryWeighted.CanaryWeights = &ServiceWeights{Passing: 5, Warning: 1}
+		must.NotEq(t, hash(weighted, true), hash(canaryWeighted, true))
+	})
+}
+
+func TestService_RegistrationWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	weights := &ServiceWeights{Passing: 10, Warning: 1}
+	canaryWeights := &ServiceWeights{Passing: 1, Warning: 1}
+
+	s := &Service{}
+	must.Nil(t, s.RegistrationWeights(false))
+	must.Nil(t, s.RegistrationWeights(true))
+
+	s.Weights = weights
+	must.Eq(t, weights, s.RegistrationWeights(false))
+	must.Eq(t, weights, s.RegistrationWeights(true))
+
+	s.CanaryWeights = canaryWeights
+	must.Eq(t, weights, s.RegistrationWeights(false))
+	must.Eq(t, canaryWeights, s.RegistrationWeights(true))
 }
 
 func TestConsulConnect_Validate(t *testing.T) {
@@ -2009,7 +2127,54 @@ func TestService_Validate(t *testing.T) {
 				},
 			},
 			expErr:    true,
-			expErrStr: "Service with provider nomad cannot include Connect blocks",
+			expErrStr: "Service with provider nomad cannot be Connect Native",
+		},
+		{
+			name: "provider nomad with connect sidecar",
+			input: &Service{
+				Name:     "testservice",
+				Provider: "nomad",
+				Connect: &ConsulConnect{
+					SidecarService: &ConsulSidecarService{
+						Proxy: &ConsulProxy{
+							Upstreams: []ConsulUpstream{{DestinationName: "db", LocalBindPort: 5432}},
+						},
+					},
+				},
+			},
+			expErr: false,
+		},
+		{
+			name: "provider nomad with connect transparent proxy",
+			input: &Service{
+				Name:     "testservice",
+				Provider: "nomad",
+				Connect: &ConsulConnect{
+					SidecarService: &ConsulSidecarService{
+						Proxy: &ConsulProxy{
+							TransparentProxy: &ConsulTransparentProxy{},
+						},
+					},
+				},
+			},
+			expErr:    true,
+			expErrStr: "Service with provider nomad cannot use transparent proxy",
+		},
+		{
+			name: "provider nomad with connect upstream in datacenter",
+			input: &Service{
+				Name:     "testservice",
+				Provider: "nomad",
+				Connect: &ConsulConnect{
+					SidecarService: &ConsulSidecarService{
+						Proxy: &ConsulProxy{
+							Upstreams: []ConsulUpstream{{DestinationName: "db", LocalBindPort: 5432, Datacenter: "dc2"}},
+						},
+					},
+				},
+			},
+			expErr:    true,
+			expErrStr: `Upstream "db" of service with provider nomad cannot set a datacenter, peer, or partition`,
 		},
 		{
 			name: "provider nomad valid",
@@ -2139,6 +2304,12 @@ func TestService_Equal(t *testing.T) {
 
 	o.TaggedAddresses = map[string]string{"foo": "bar"}
 	assertDiff()
+
+	o.Weights = &ServiceWeights{Passing: 5, Warning: 1}
+	assertDiff()
+
+	o.CanaryWeights = &ServiceWeights{Passing: 1, Warning: 1}
+	assertDiff()
 }
 
 func TestService_validateNomadService(t *testing.T) {
@@ -2191,7 +2362,7 @@ func TestService_validateNomadService(t *testing.T) {
 				},
 			},
 			inputErr:             &multierror.Error{},
-			expectedOutputErrors: []error{errors.New("Service with provider nomad cannot include Connect blocks")},
+			expectedOutputErrors: []error{errors.New("Service with provider nomad cannot be Connect Native")},
 			name:                 "invalid service due to connect",
 		},
 		{
@@ -2206,7 +2377,7 @@ func TestService_validateNomadService(t *testing.T) {
 			},
 			inputErr: &multierror.Error{},
 			expectedOutputErrors: []error{
-				errors.New(`invalid check type (""), must be one of tcp, http`),
+				errors.New(`invalid check type (""), must be one of grpc, tcp, http`),
 			},
 			name: "bad nomad check",
 		},
@@ -17,10 +17,13 @@ import (
 	"fmt"
 	"hash"
 	"hash/crc32"
+	"hash/fnv"
 	"maps"
 	"math"
 	"net"
+	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"slices"
@@ -29,6 +32,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/consul-template/signals"
 	"github.com/hashicorp/cronexpr"
 	"github.com/hashicorp/go-msgpack/v2/codec"
 	"github.com/hashicorp/go-multierror"
@@ -136,6 +140,12 @@ const (
 	HostVolumeRegisterRequestType             MessageType = 75
 	HostVolumeDeleteRequestType               MessageType = 76
 	TaskGroupHostVolumeClaimDeleteRequestType MessageType = 77
+	QuotaSpecUpsertRequestType                MessageType = 78
+	QuotaSpecDeleteRequestType                MessageType = 79
+	AllocUpdateResourcesRequestType           MessageType = 80
+	CSIVolumeManagedSnapshotsRequestType      MessageType = 81
+	NodeMaintenanceWindowUpsertRequestType    MessageType = 82
+	NodeMaintenanceWindowDeleteRequestType    MessageType = 83
 
 	// NOTE: MessageTypes are shared between CE and ENT. If you need to add a
 	// new type, check that ENT is not already using that value.
@@ -163,6 +173,9 @@ const (
 	GetterModeFile = "file"
 	GetterModeDir  = "dir"
 
+	ArtifactVerifyTypeGPG    = "gpg"
+	ArtifactVerifyTypeCosign = "cosign"
+
 	// maxPolicyDescriptionLength limits a policy description length
 	maxPolicyDescriptionLength = 256
 
@@ -220,6 +233,9 @@ const (
 var (
 	// validNamespaceName is used to validate a namespace name
 	validNamespaceName = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
+
+	// validTemplateEnvPrefix is used to validate a template's env_prefix
+	validTemplateEnvPrefix = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
 )
 
 // NamespacedID is a tuple of an ID and a namespace
@@ -322,6 +338,7 @@ type QueryOptions struct {
 	Reverse bool
 
 	identity *AuthenticatedIdentity
+	sourceIP net.IP
 
 	InternalRpcInfo
 }
@@ -381,6 +398,17 @@ func (q QueryOptions) GetIdentity() *AuthenticatedIdentity {
 	return q.identity
 }
 
+// SetSourceIP records the address of the HTTP client the request was made on
+// behalf of. It isn't sent over the wire, so it's only used by servers
+// authenticating the request when it's made in-process by their HTTP API.
+func (q *QueryOptions) SetSourceIP(ip net.IP) {
+	q.sourceIP = ip
+}
+
+func (q QueryOptions) GetSourceIP() net.IP {
+	return q.sourceIP
+}
+
 // AgentPprofRequest is used to request a pprof report for a given node.
 type AgentPprofRequest struct {
 	// ReqType specifies the profile to use
@@ -442,6 +470,7 @@ type WriteRequest struct {
 	IdempotencyToken string
 
 	identity *AuthenticatedIdentity
+	sourceIP net.IP
 
 	InternalRpcInfo
 }
@@ -491,6 +520,17 @@ func (w WriteRequest) GetIdentity() *AuthenticatedIdentity {
 	return w.identity
 }
 
+// SetSourceIP records the address of the HTTP client the request was made on
+// behalf of. It isn't sent over the wire, so it's only used by servers
+// authenticating the request when it's made in-process by their HTTP API.
+func (w *WriteRequest) SetSourceIP(ip net.IP) {
+	w.sourceIP = ip
+}
+
+func (w WriteRequest) GetSourceIP() net.IP {
+	return w.sourceIP
+}
+
 // AuthenticatedIdentity is returned by the Authenticate method on server to
 // return a wrapper around the various elements that can be resolved as an
 // identity. RPC handlers will use the relevant fields for performing
@@ -791,6 +831,11 @@ type JobDeregisterRequest struct {
 	// allocations stopped as a result of this Deregister call.
 	NoShutdownDelay bool
 
+	// ShutdownDelay, if set, will override the group and task shutdown_delay
+	// configuration of any allocations stopped as a result of this
+	// Deregister call.
+	ShutdownDelay time.Duration
+
 	// Eval is the evaluation to create that's associated with job deregister
 	Eval *Evaluation
 
@@ -1166,6 +1211,35 @@ type AllocStopResponse struct {
 	WriteMeta
 }
 
+// AllocResizeRequest is used to update the resources of the tasks of a running
+// allocation in place, without rescheduling it.
+type AllocResizeRequest struct {
+	AllocID string
+
+	// Resources are the new resources of the tasks to resize, keyed by task
+	// name. Only the CPU, CPUMax, MemoryMB, and MemoryMaxMB fields are used.
+	Resources map[string]*Resources
+
+	WriteRequest
+}
+
+// AllocResizeResponse is the response to an AllocResizeRequest.
+type AllocResizeResponse struct {
+	WriteMeta
+}
+
+// AllocUpdateResourcesRequest is used to update the allocated resources of the
+// tasks of an allocation via Raft.
+type AllocUpdateResourcesRequest struct {
+	AllocID string
+
+	// Tasks are the new allocated resources of the resized tasks, keyed by
+	// task name.
+	Tasks map[string]*AllocatedTaskResources
+
+	WriteRequest
+}
+
 // AllocListRequest is used to request a list of allocations
 type AllocListRequest struct {
 	QueryOptions
@@ -1348,6 +1422,11 @@ type DeploymentPromoteRequest struct {
 	// Groups is used to set the promotion status per task group
 	Groups []string
 
+	// Step moves the canaries of the task groups with canary steps left to
+	// their next step instead of promoting them. The task groups are only
+	// promoted once all their canaries reached their last step.
+	Step bool
+
 	// PromotedAt is the timestamp stored as Unix nano
 	PromotedAt int64
 
@@ -1610,6 +1689,10 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+
+	// Queued is set if the dispatched job waits in the dispatch queue of the
+	// parameterized job, in which case no evaluation was created.
+	Queued bool
 	WriteMeta
 }
 
@@ -1943,6 +2026,76 @@ type DrainSpec struct {
 	// IgnoreSystemJobs allows systems jobs to remain on the node even though it
 	// has been marked for draining.
 	IgnoreSystemJobs bool
+
+	// JobTypeDeadlines overrides Deadline for the allocations of the given
+	// job types, so that for example batch allocations are given longer to
+	// complete than service allocations. The values follow the semantics of
+	// Deadline.
+	JobTypeDeadlines map[string]time.Duration
+
+	// KillPolicy, if set, overrides how the tasks of the allocations stopped
+	// by the drain are killed.
+	KillPolicy *DrainKillPolicy
+}
+
+// DrainKillPolicy describes how the tasks of allocations stopped by a drain
+// are killed.
+type DrainKillPolicy struct {
+	// Signal, if set, is sent to the tasks in place of their kill_signal.
+	Signal string
+
+	// Timeout, if set, caps the time the tasks are given to exit after the
+	// signal before they are forcefully killed, in place of their
+	// kill_timeout.
+	Timeout time.Duration
+}
+
+func (p *DrainKillPolicy) Copy() *DrainKillPolicy {
+	if p == nil {
+		return nil
+	}
+	np := *p
+	return &np
+}
+
+func (p *DrainKillPolicy) Equal(o *DrainKillPolicy) bool {
+	if p == nil || o == nil {
+		return p == o
+	}
+	return *p == *o
+}
+
+func (d *DrainSpec) Copy() *DrainSpec {
+	if d == nil {
+		return nil
+	}
+
+	nd := new(DrainSpec)
+	*nd = *d
+	nd.JobTypeDeadlines = maps.Clone(d.JobTypeDeadlines)
+	nd.KillPolicy = d.KillPolicy.Copy()
+	return nd
+}
+
+// Validate returns an error if the drain specification is invalid.
+func (d *DrainSpec) Validate() error {
+	var mErr multierror.Error
+	for jobType := range d.JobTypeDeadlines {
+		switch jobType {
+		case JobTypeService, JobTypeBatch, JobTypeSystem, JobTypeSysBatch:
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid job type %q for drain deadline", jobType))
+		}
+	}
+	if p := d.KillPolicy; p != nil {
+		if _, ok := signals.SignalLookup[p.Signal]; p.Signal != "" && !ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid drain kill signal %q", p.Signal))
+		}
+		if p.Timeout < 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("drain kill timeout must not be negative"))
+		}
+	}
+	return mErr.ErrorOrNil()
 }
 
 // DrainStrategy describes a Node's drain behavior.
@@ -1954,6 +2107,10 @@ type DrainStrategy struct {
 	// be forced
 	ForceDeadline time.Time
 
+	// JobTypeForceDeadlines are the deadline times for the drain of the
+	// allocations of the job types with their own deadline.
+	JobTypeForceDeadlines map[string]time.Time
+
 	// StartedAt is the time the drain process started
 	StartedAt time.Time
 }
@@ -1965,9 +2122,31 @@ func (d *DrainStrategy) Copy() *DrainStrategy {
 
 	nd := new(DrainStrategy)
 	*nd = *d
+	nd.JobTypeDeadlines = maps.Clone(d.JobTypeDeadlines)
+	nd.JobTypeForceDeadlines = maps.Clone(d.JobTypeForceDeadlines)
+	nd.KillPolicy = d.KillPolicy.Copy()
 	return nd
 }
 
+// SetForceDeadlines marks the deadline times of the drain, and of the job
+// types with their own deadline, from now.
+func (d *DrainStrategy) SetForceDeadlines(now time.Time) {
+	if d.Deadline.Nanoseconds() > 0 {
+		d.ForceDeadline = now.Add(d.Deadline)
+	}
+
+	d.JobTypeForceDeadlines = nil
+	for jobType, deadline := range d.JobTypeDeadlines {
+		if deadline.Nanoseconds() <= 0 {
+			continue
+		}
+		if d.JobTypeForceDeadlines == nil {
+			d.JobTypeForceDeadlines = make(map[string]time.Time, len(d.JobTypeDeadlines))
+		}
+		d.JobTypeForceDeadlines[jobType] = now.Add(deadline)
+	}
+}
+
 // DeadlineTime returns a boolean whether the drain strategy allows an infinite
 // duration or otherwise the deadline time. The force drain is captured by the
 // deadline time being in the past.
@@ -1990,6 +2169,34 @@ func (d *DrainStrategy) DeadlineTime() (infinite bool, deadline time.Time) {
 	}
 }
 
+// JobTypeDeadlineTime is like DeadlineTime but for the allocations of the
+// given job type, which may have their own deadline. Drains whose force
+// deadlines were marked before job types had their own deadline fall back to
+// the deadline of the drain.
+func (d *DrainStrategy) JobTypeDeadlineTime(jobType string) (infinite bool, deadline time.Time) {
+	if d == nil {
+		return false, time.Time{}
+	}
+
+	jobTypeDeadline, ok := d.JobTypeDeadlines[jobType]
+	if !ok {
+		return d.DeadlineTime()
+	}
+
+	ns := jobTypeDeadline.Nanoseconds()
+	switch {
+	case ns < 0: // Force
+		return false, time.Time{}
+	case ns == 0: // Infinite
+		return true, time.Time{}
+	default:
+		if deadline, ok := d.JobTypeForceDeadlines[jobType]; ok {
+			return false, deadline
+		}
+		return false, d.ForceDeadline
+	}
+}
+
 func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 	if d == nil && o == nil {
 		return true
@@ -2006,6 +2213,12 @@ func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 		return false
 	} else if d.IgnoreSystemJobs != o.IgnoreSystemJobs {
 		return false
+	} else if !maps.Equal(d.JobTypeDeadlines, o.JobTypeDeadlines) {
+		return false
+	} else if !maps.EqualFunc(d.JobTypeForceDeadlines, o.JobTypeForceDeadlines, time.Time.Equal) {
+		return false
+	} else if !d.KillPolicy.Equal(o.KillPolicy) {
+		return false
 	}
 
 	return true
@@ -2171,6 +2384,10 @@ type Node struct {
 	// NodeMaxAllocs defaults to 0 unless set in the client config
 	NodeMaxAllocs int
 
+	// HostVolumeLimits caps the capacity of the dynamic host volumes placed
+	// on the node. It is nil unless set in the client config.
+	HostVolumeLimits *HostVolumeLimits
+
 	// LastMissedHeartbeatIndex stores the Raft index when the node last missed
 	// a heartbeat. It resets to zero once the node is marked as ready again.
 	LastMissedHeartbeatIndex uint64
@@ -2281,6 +2498,7 @@ func (n *Node) Copy() *Node {
 	nn.HostVolumes = helper.DeepCopyMap(n.HostVolumes)
 	nn.HostNetworks = helper.DeepCopyMap(n.HostNetworks)
 	nn.LastDrain = nn.LastDrain.Copy()
+	nn.HostVolumeLimits = nn.HostVolumeLimits.Copy()
 	return &nn
 }
 
@@ -2402,9 +2620,11 @@ type NodeStubFields struct {
 }
 
 // Resources is used to define the resources available
-// on a client
+// on a client. Optional fields are omitted when empty to keep the size of the
+// allocations in plan results down.
 type Resources struct {
 	CPU         int
+	CPUMax      int `codec:",omitempty"`
 	Cores       int
 	MemoryMB    int
 	MemoryMaxMB int
@@ -2414,6 +2634,7 @@ type Resources struct {
 	Devices     ResourceDevices
 	NUMA        *NUMA
 	SecretsMB   int
+	DiskIO      *DiskIO `codec:",omitempty"`
 }
 
 const (
@@ -2463,6 +2684,16 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, errors.New("Task can only ask for 'cpu' or 'cores' resource, not both."))
 	}
 
+	// Ensure cpu_max is greater than cpu, unless it is set to 0 which means
+	// cpu is not capped
+	if r.CPUMax != 0 {
+		if r.Cores > 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Task can't ask for 'cpu_max' with 'cores' resource."))
+		} else if r.CPUMax < r.CPU {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("CPUMax value (%d) should be larger than CPU value (%d)", r.CPUMax, r.CPU))
+		}
+	}
+
 	if err := r.MeetsMinResources(); err != nil {
 		mErr.Errors = append(mErr.Errors, err)
 	}
@@ -2508,6 +2739,10 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("SecretsMB value (%d) cannot be negative", r.SecretsMB))
 	}
 
+	if err := r.DiskIO.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -2517,6 +2752,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.CPU != 0 {
 		r.CPU = other.CPU
 	}
+	if other.CPUMax != 0 {
+		r.CPUMax = other.CPUMax
+	}
 	if other.Cores != 0 {
 		r.Cores = other.Cores
 	}
@@ -2538,6 +2776,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.SecretsMB != 0 {
 		r.SecretsMB = other.SecretsMB
 	}
+	if other.DiskIO != nil {
+		r.DiskIO = other.DiskIO
+	}
 }
 
 // Equal Resources.
@@ -2551,6 +2792,7 @@ func (r *Resources) Equal(o *Resources) bool {
 		return false
 	}
 	return r.CPU == o.CPU &&
+		r.CPUMax == o.CPUMax &&
 		r.Cores == o.Cores &&
 		r.MemoryMB == o.MemoryMB &&
 		r.MemoryMaxMB == o.MemoryMaxMB &&
@@ -2558,7 +2800,8 @@ func (r *Resources) Equal(o *Resources) bool {
 		r.IOPS == o.IOPS &&
 		r.Networks.Equal(&o.Networks) &&
 		r.Devices.Equal(&o.Devices) &&
-		r.SecretsMB == o.SecretsMB
+		r.SecretsMB == o.SecretsMB &&
+		r.DiskIO.Equal(o.DiskIO)
 }
 
 // ResourceDevices are part of Resources.
@@ -2649,6 +2892,7 @@ func (r *Resources) Copy() *Resources {
 	}
 	return &Resources{
 		CPU:         r.CPU,
+		CPUMax:      r.CPUMax,
 		Cores:       r.Cores,
 		MemoryMB:    r.MemoryMB,
 		MemoryMaxMB: r.MemoryMaxMB,
@@ -2658,9 +2902,17 @@ func (r *Resources) Copy() *Resources {
 		Devices:     r.Devices.Copy(),
 		NUMA:        r.NUMA.Copy(),
 		SecretsMB:   r.SecretsMB,
+		DiskIO:      r.DiskIO.Copy(),
 	}
 }
 
+// Burstable returns whether the resources allow the task to use more CPU or
+// memory than it reserves, making it eligible for eviction under node memory
+// pressure.
+func (r *Resources) Burstable() bool {
+	return r.CPUMax > r.CPU || r.MemoryMaxMB > r.MemoryMB || r.MemoryMaxMB == memoryNoLimit
+}
+
 // NetIndex finds the matching net index using device name
 // COMPAT(0.10): Remove in 0.10
 func (r *Resources) NetIndex(n *NetworkResource) int {
@@ -2921,15 +3173,29 @@ type NetworkResource struct {
 	IP            string     // Host IP address
 	Hostname      string     `json:",omitempty"` // Hostname of the network namespace
 	MBits         int        // Throughput
+	EgressMBits   int        // Shaped egress bandwidth of bridge networks
+	IngressMBits  int        // Shaped ingress bandwidth of bridge networks
 	DNS           *DNSConfig // DNS Configuration
 	ReservedPorts []Port     // Host Reserved ports
 	DynamicPorts  []Port     // Host Dynamically assigned ports
 	CNI           *CNIConfig // CNIConfig Configuration
+	CNINetworks   []string   // Additional CNI networks attached to the network namespace
+	IPv4Address   string     `json:",omitempty"` // Static IPv4 address of bridge and CNI networks
+	IPv6Address   string     `json:",omitempty"` // Static IPv6 address of bridge and CNI networks
 }
 
 func (n *NetworkResource) Hash() uint32 {
 	var data []byte
 	data = append(data, []byte(fmt.Sprintf("%s%s%s%s%s%d", n.Mode, n.Device, n.CIDR, n.IP, n.Hostname, n.MBits))...)
+	if n.Shaped() {
+		data = append(data, []byte(fmt.Sprintf("e%di%d", n.EgressMBits, n.IngressMBits))...)
+	}
+	for i, name := range n.CNINetworks {
+		data = append(data, []byte(fmt.Sprintf("c%d%s", i, name))...)
+	}
+	if n.HasStaticAddress() {
+		data = append(data, []byte(fmt.Sprintf("4%s6%s", n.IPv4Address, n.IPv6Address))...)
+	}
 
 	for i, port := range n.ReservedPorts {
 		data = append(data, []byte(fmt.Sprintf("r%d%s%d%d", i, port.Label, port.Value, port.To))...)
@@ -2946,6 +3212,29 @@ func (n *NetworkResource) Equal(other *NetworkResource) bool {
 	return n.Hash() == other.Hash()
 }
 
+// Shaped returns true if the bandwidth of the network is limited.
+func (n *NetworkResource) Shaped() bool {
+	return n.EgressMBits > 0 || n.IngressMBits > 0
+}
+
+// HasStaticAddress returns true if the network requests a static address for
+// the network namespace of the allocation.
+func (n *NetworkResource) HasStaticAddress() bool {
+	return n.IPv4Address != "" || n.IPv6Address != ""
+}
+
+// StaticAddresses returns the static addresses requested by the network.
+func (n *NetworkResource) StaticAddresses() []string {
+	var addrs []string
+	if n.IPv4Address != "" {
+		addrs = append(addrs, n.IPv4Address)
+	}
+	if n.IPv6Address != "" {
+		addrs = append(addrs, n.IPv6Address)
+	}
+	return addrs
+}
+
 func (n *NetworkResource) Canonicalize() {
 	// Ensure that an empty and nil slices are treated the same to avoid scheduling
 	// problems since we use reflect DeepEquals.
@@ -2984,6 +3273,7 @@ func (n *NetworkResource) Copy() *NetworkResource {
 		newR.DynamicPorts = make([]Port, len(n.DynamicPorts))
 		copy(newR.DynamicPorts, n.DynamicPorts)
 	}
+	newR.CNINetworks = slices.Clone(n.CNINetworks)
 	return newR
 }
 
@@ -3831,6 +4121,7 @@ func (a *AllocatedResources) Comparable() *ComparableResources {
 	}
 
 	// The lifecycle in which a task could run
+	initLifecycle := &AllocatedTaskResources{}
 	prestartLifecycle := &AllocatedTaskResources{}
 	mainLifecycle := &AllocatedTaskResources{}
 	stopLifecycle := &AllocatedTaskResources{}
@@ -3848,6 +4139,10 @@ func (a *AllocatedResources) Comparable() *ComparableResources {
 
 		if taskLifecycle == nil {
 			mainLifecycle.Add(fungibleTaskResources)
+		} else if taskLifecycle.Hook == TaskLifecycleHookPrestartInit {
+			// Init tasks run one at a time, before any other task starts,
+			// so only the largest one needs to fit
+			initLifecycle.Max(fungibleTaskResources)
 		} else if taskLifecycle.Hook == TaskLifecycleHookPrestart {
 			if taskLifecycle.Sidecar {
 				// These tasks span both the prestart and main lifecycle
@@ -3864,6 +4159,7 @@ func (a *AllocatedResources) Comparable() *ComparableResources {
 	}
 
 	// Update the main lifecycle to reflect the largest fungible resource set
+	mainLifecycle.Max(initLifecycle)
 	mainLifecycle.Max(prestartLifecycle)
 	mainLifecycle.Max(stopLifecycle)
 
@@ -3888,6 +4184,7 @@ func (a *AllocatedResources) OldTaskResources() map[string]*Resources {
 		m[name] = &Resources{
 			Cores:       len(res.Cpu.ReservedCores),
 			CPU:         int(res.Cpu.CpuShares),
+			CPUMax:      int(res.Cpu.CpuMax),
 			MemoryMB:    int(res.Memory.MemoryMB),
 			MemoryMaxMB: int(res.Memory.MemoryMaxMB),
 			Networks:    res.Networks,
@@ -3920,6 +4217,10 @@ type AllocatedTaskResources struct {
 	Memory   AllocatedMemoryResources
 	Networks Networks
 	Devices  []*AllocatedDeviceResource
+
+	// DiskIO is omitted when empty to keep the size of the allocations in
+	// plan results down.
+	DiskIO *DiskIO `codec:",omitempty"`
 }
 
 func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
@@ -3932,6 +4233,9 @@ func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
 	// Copy the networks
 	newA.Networks = a.Networks.Copy()
 
+	// Copy the disk I/O limits
+	newA.DiskIO = a.DiskIO.Copy()
+
 	// Copy the devices
 	if newA.Devices != nil {
 		n := len(a.Devices)
@@ -3944,6 +4248,17 @@ func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
 	return newA
 }
 
+// Burstable returns whether the task may use more CPU or memory than it
+// reserved on the node.
+func (a *AllocatedTaskResources) Burstable() bool {
+	if a == nil {
+		return false
+	}
+	return a.Cpu.CpuMax > a.Cpu.CpuShares ||
+		a.Memory.MemoryMaxMB > a.Memory.MemoryMB ||
+		a.Memory.MemoryMaxMB == memoryNoLimit
+}
+
 // NetIndex finds the matching net index using device name
 func (a *AllocatedTaskResources) NetIndex(n *NetworkResource) int {
 	return a.Networks.NetIndex(n)
@@ -4101,6 +4416,11 @@ func (a *AllocatedSharedResources) Canonicalize() {
 type AllocatedCpuResources struct {
 	CpuShares     int64
 	ReservedCores []uint16
+
+	// CpuMax is the CPU bandwidth in MHz the task is capped at, or 0 if it
+	// isn't capped. It's omitted when empty to keep the size of the
+	// allocations in plan results down.
+	CpuMax int64 `codec:",omitempty"`
 }
 
 func (a *AllocatedCpuResources) Add(delta *AllocatedCpuResources) {
@@ -4505,6 +4825,14 @@ type Job struct {
 	// for dispatching.
 	ParameterizedJob *ParameterizedJobConfig
 
+	// Array is used to expand the task groups of a batch job into indexed
+	// allocations.
+	Array *JobArray
+
+	// DependsOn holds the evaluation of a batch job until the jobs it depends
+	// on reach a terminal status.
+	DependsOn []*JobDependency
+
 	// Dispatched is used to identify if the Job has been dispatched from a
 	// parameterized job.
 	Dispatched bool
@@ -4536,6 +4864,12 @@ type Job struct {
 	// StatusDescription is meant to provide more human useful information
 	StatusDescription string
 
+	// Held is set by the servers when this version of the job was registered
+	// without an evaluation, because it waits on its dependencies or in the
+	// dispatch queue of its parameterized job. It is cleared once the version
+	// is evaluated.
+	Held bool
+
 	// Stable marks a job as stable. Stability is only defined on "service" and
 	// "system" jobs. The stability of a job will be set automatically as part
 	// of a deployment and can be manually set via APIs. This field is updated
@@ -4737,6 +5071,13 @@ func (j *Job) Canonicalize() {
 	if j.Periodic != nil {
 		j.Periodic.Canonicalize()
 	}
+
+	if len(j.DependsOn) == 0 {
+		j.DependsOn = nil
+	}
+	for _, dep := range j.DependsOn {
+		dep.Canonicalize()
+	}
 }
 
 // Copy returns a deep copy of the Job. It is expected that callers use recover.
@@ -4765,6 +5106,8 @@ func (j *Job) Copy() *Job {
 	nj.Periodic = j.Periodic.Copy()
 	nj.Meta = maps.Clone(j.Meta)
 	nj.ParameterizedJob = j.ParameterizedJob.Copy()
+	nj.Array = j.Array.Copy()
+	nj.DependsOn = CopySliceJobDependencies(j.DependsOn)
 	return nj
 }
 
@@ -4917,6 +5260,12 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if err := j.Array.Validate(j); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	mErr.Errors = append(mErr.Errors, validateJobDependencies(j)...)
+
 	return mErr.ErrorOrNil()
 }
 
@@ -4938,7 +5287,7 @@ func (j *Job) Warnings() error {
 			hasAutoPromote = hasAutoPromote || u.AutoPromote
 
 			// Having no canaries implies auto-promotion since there are no canaries to promote.
-			allAutoPromote = allAutoPromote && (u.Canary == 0 || u.AutoPromote)
+			allAutoPromote = allAutoPromote && (!u.HasCanaries() || u.AutoPromote)
 		}
 	}
 
@@ -5166,7 +5515,7 @@ func (j *Job) RequiredSignals() map[string]map[string][]string {
 
 			// Check if any template change mode uses signals
 			for _, t := range task.Templates {
-				if t.ChangeMode != TemplateChangeModeSignal {
+				if t.ChangeMode != TemplateChangeModeSignal && t.ChangeMode != TemplateChangeModeReload {
 					continue
 				}
 
@@ -5211,6 +5560,7 @@ func (j *Job) SpecChanged(new *Job) bool {
 	// Update the new job so we can do a reflect
 	c.Status = j.Status
 	c.StatusDescription = j.StatusDescription
+	c.Held = j.Held
 	c.Stable = j.Stable
 	c.Version = j.Version
 	c.CreateIndex = j.CreateIndex
@@ -5387,6 +5737,26 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// CanarySteps are the percentages of the count of the task group which
+	// run as canaries at each step of a deployment. The canaries move to the
+	// next step when the deployment is promoted by step, and are promoted
+	// once they reach the last step. The number of canaries is set by the
+	// steps instead of Canary.
+	CanarySteps []int
+
+	// BakeTime is the time the canaries must stay healthy before the
+	// deployment automatically moves to its next step or is promoted.
+	BakeTime time.Duration
+
+	// DecommissionDelay is the time the allocations replaced by the canaries
+	// keep running, deregistered from services, after the promotion of a
+	// deployment so that the job can be rolled back instantly.
+	DecommissionDelay time.Duration
+
+	// Verify is the verification run once the canaries are healthy, before
+	// the deployment is automatically promoted.
+	Verify *UpdateVerify
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -5396,6 +5766,8 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 
 	c := new(UpdateStrategy)
 	*c = *u
+	c.CanarySteps = slices.Clone(u.CanarySteps)
+	c.Verify = u.Verify.Copy()
 	return c
 }
 
@@ -5417,9 +5789,31 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Canary < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Canary count can not be less than zero: %d < 0", u.Canary))
 	}
-	if u.Canary == 0 && u.AutoPromote {
+	if !u.HasCanaries() && u.AutoPromote {
 		_ = multierror.Append(&mErr, fmt.Errorf("Auto Promote requires a Canary count greater than zero"))
 	}
+	if len(u.CanarySteps) != 0 {
+		if u.Canary != 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Canary count can't be set with canary steps, which set the number of canaries"))
+		}
+		for i, step := range u.CanarySteps {
+			if step < 1 || step > 99 {
+				_ = multierror.Append(&mErr, fmt.Errorf("Canary step %d must be a percentage between 1 and 99: %d", i+1, step))
+			} else if i > 0 && step <= u.CanarySteps[i-1] {
+				_ = multierror.Append(&mErr, fmt.Errorf("Canary step %d must be greater than the previous step: %d <= %d", i+1, step, u.CanarySteps[i-1]))
+			}
+		}
+	}
+	if u.BakeTime < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Bake time may not be less than zero: %v", u.BakeTime))
+	} else if u.BakeTime > 0 && !u.HasCanaries() {
+		_ = multierror.Append(&mErr, fmt.Errorf("Bake time requires canaries"))
+	}
+	if u.DecommissionDelay < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Decommission delay may not be less than zero: %v", u.DecommissionDelay))
+	} else if u.DecommissionDelay > 0 && !u.HasCanaries() {
+		_ = multierror.Append(&mErr, fmt.Errorf("Decommission delay requires canaries"))
+	}
 	if u.MinHealthyTime < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Minimum healthy time may not be less than zero: %v", u.MinHealthyTime))
 	}
@@ -5438,6 +5832,14 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Stagger <= 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Stagger must be greater than zero: %v", u.Stagger))
 	}
+	if u.Verify != nil {
+		if !u.AutoPromote {
+			_ = multierror.Append(&mErr, fmt.Errorf("Verify requires Auto Promote"))
+		}
+		if err := u.Verify.Validate(); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Verify validation failed: %v", err))
+		}
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -5456,6 +5858,114 @@ func (u *UpdateStrategy) IsEmpty() bool {
 	return u.MaxParallel == 0
 }
 
+// HasCanaries returns whether the deployments of the task group place
+// canaries.
+func (u *UpdateStrategy) HasCanaries() bool {
+	return u != nil && (u.Canary > 0 || len(u.CanarySteps) != 0)
+}
+
+// CanaryCount returns the number of canaries of a task group with the count
+// at the step of its deployment.
+func (u *UpdateStrategy) CanaryCount(count, step int) int {
+	if u == nil {
+		return 0
+	}
+	if len(u.CanarySteps) == 0 {
+		return u.Canary
+	}
+	step = min(max(step, 0), len(u.CanarySteps)-1)
+	return canaryStepCount(count, u.CanarySteps[step])
+}
+
+// canaryStepCount returns the number of canaries of a task group with the
+// count at a canary step, a percentage of its count. At least one canary is
+// placed at each step.
+func canaryStepCount(count, percent int) int {
+	return max(1, int(math.Ceil(float64(count*percent)/100)))
+}
+
+const (
+	// UpdateVerifyTypeHTTP verifies a deployment by querying an HTTP
+	// endpoint, and passes if it responds with a 2xx status code.
+	UpdateVerifyTypeHTTP = "http"
+
+	// UpdateVerifyTypePrometheus verifies a deployment by running a query
+	// against a Prometheus server, and passes if the query returns at least
+	// one value and all its values are non-zero.
+	UpdateVerifyTypePrometheus = "prometheus"
+)
+
+// UpdateVerify is used to verify a deployment against an external signal
+// once its canaries are healthy. The deployment is only promoted if the
+// verification passes, and is failed and rolled back to the latest stable
+// version of the job otherwise.
+type UpdateVerify struct {
+	// Type is the kind of endpoint queried to verify the deployment.
+	Type string
+
+	// Address is the URL of the HTTP endpoint, or the address of the
+	// Prometheus server.
+	Address string
+
+	// Query is the Prometheus query run to verify the deployment.
+	Query string
+
+	// Checks is the number of times the endpoint is queried, all of which
+	// must pass for the verification to pass.
+	Checks int
+
+	// Interval is the time between two checks.
+	Interval time.Duration
+
+	// Timeout is the time after which a check fails.
+	Timeout time.Duration
+}
+
+func (v *UpdateVerify) Copy() *UpdateVerify {
+	if v == nil {
+		return nil
+	}
+	nv := new(UpdateVerify)
+	*nv = *v
+	return nv
+}
+
+func (v *UpdateVerify) Equal(o *UpdateVerify) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	return *v == *o
+}
+
+func (v *UpdateVerify) Validate() error {
+	var mErr multierror.Error
+	switch v.Type {
+	case UpdateVerifyTypeHTTP:
+	case UpdateVerifyTypePrometheus:
+		if v.Query == "" {
+			_ = multierror.Append(&mErr, fmt.Errorf("Query must be set for %q verifications", UpdateVerifyTypePrometheus))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid type %q, must be one of %q or %q", v.Type,
+			UpdateVerifyTypeHTTP, UpdateVerifyTypePrometheus))
+	}
+	if v.Address == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Missing address"))
+	} else if u, err := url.Parse(v.Address); err != nil || u.Scheme == "" || u.Host == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Address must be an absolute URL: %q", v.Address))
+	}
+	if v.Checks < 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Checks must be greater than zero: %d", v.Checks))
+	}
+	if v.Interval <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Interval must be greater than zero: %v", v.Interval))
+	}
+	if v.Timeout <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Timeout must be greater than zero: %v", v.Timeout))
+	}
+	return mErr.ErrorOrNil()
+}
+
 // Rolling returns if a rolling strategy should be used.
 // TODO(alexdadgar): Remove once no longer used by the scheduler.
 func (u *UpdateStrategy) Rolling() bool {
@@ -5826,6 +6336,15 @@ type PeriodicConfig struct {
 	// ProhibitOverlap enforces that spawned jobs do not run in parallel.
 	ProhibitOverlap bool
 
+	// ConcurrencyPolicy is what happens to a launch while the previous
+	// children are still running, either allow, forbid or replace.
+	ConcurrencyPolicy string
+
+	// HistoryLimit is the number of successful and of failed children kept
+	// once they are dead. Older children are purged. Zero keeps all the
+	// children until they are garbage collected.
+	HistoryLimit int
+
 	// TimeZone is the user specified string that determines the time zone to
 	// launch against. The time zones must be specified from IANA Time Zone
 	// database, such as "America/New_York".
@@ -5833,6 +6352,10 @@ type PeriodicConfig struct {
 	// Reference: https://www.iana.org/time-zones
 	TimeZone string
 
+	// Prohibit are the windows during which the job isn't launched. They are
+	// evaluated in the time zone of the job.
+	Prohibit []*PeriodicProhibit
+
 	// location is the time zone to evaluate the launch time against
 	location *time.Location
 }
@@ -5843,6 +6366,12 @@ func (p *PeriodicConfig) Copy() *PeriodicConfig {
 	}
 	np := new(PeriodicConfig)
 	*np = *p
+	if p.Prohibit != nil {
+		np.Prohibit = make([]*PeriodicProhibit, len(p.Prohibit))
+		for i, w := range p.Prohibit {
+			np.Prohibit[i] = w.Copy()
+		}
+	}
 	return np
 }
 
@@ -5887,6 +6416,27 @@ func (p *PeriodicConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Unknown periodic specification type %q", p.SpecType))
 	}
 
+	switch p.ConcurrencyPolicy {
+	case PeriodicConcurrencyAllow, PeriodicConcurrencyReplace:
+		if p.ProhibitOverlap {
+			_ = multierror.Append(&mErr, fmt.Errorf("Prohibit overlap can only be used with the %q concurrency policy",
+				PeriodicConcurrencyForbid))
+		}
+	case "", PeriodicConcurrencyForbid:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid concurrency policy %q, must be one of %q, %q, or %q",
+			p.ConcurrencyPolicy, PeriodicConcurrencyAllow, PeriodicConcurrencyForbid, PeriodicConcurrencyReplace))
+	}
+	if p.HistoryLimit < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("History limit must not be negative"))
+	}
+
+	for i, w := range p.Prohibit {
+		if err := w.Validate(); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Prohibit window %d validation failed: %v", i+1, err))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -5898,6 +6448,17 @@ func (p *PeriodicConfig) Canonicalize() {
 	}
 
 	p.location = l
+
+	if p.ConcurrencyPolicy == "" {
+		p.ConcurrencyPolicy = p.Concurrency()
+	}
+	if p.ConcurrencyPolicy == PeriodicConcurrencyForbid {
+		p.ProhibitOverlap = true
+	}
+
+	for _, w := range p.Prohibit {
+		w.Canonicalize()
+	}
 }
 
 // CronParseNext is a helper that parses the next time for the given expression
@@ -5917,10 +6478,60 @@ func CronParseNext(fromTime time.Time, spec string) (t time.Time, err error) {
 }
 
 // Next returns the closest time instant matching the spec that is after the
-// passed time. If no matching instance exists, the zero value of time.Time is
-// returned. The `time.Location` of the returned value matches that of the
-// passed time.
+// passed time and outside of the prohibit windows. Launches falling in a
+// window are either skipped or deferred to the end of the window. If no
+// matching instance exists, the zero value of time.Time is returned. The
+// `time.Location` of the returned value matches that of the passed time.
 func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
+	next, err := p.nextSpec(fromTime)
+	if err != nil || next.IsZero() || len(p.Prohibit) == 0 {
+		return next, err
+	}
+
+	for i := 0; i < periodicProhibitMaxIterations; i++ {
+		window, end, err := p.prohibitedUntil(next)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if window == nil {
+			return next, nil
+		}
+
+		switch window.Action {
+		case PeriodicProhibitActionDefer:
+			next = end
+		default:
+			// The next launch at or after the end of the window
+			next, err = p.nextSpec(end.Add(-time.Nanosecond))
+			if err != nil || next.IsZero() {
+				return next, err
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("no launch outside of the prohibit windows after %d attempts",
+		periodicProhibitMaxIterations)
+}
+
+// prohibitedUntil returns the prohibit window containing the launch time and
+// the end of the window, or nil if the launch time isn't prohibited.
+func (p *PeriodicConfig) prohibitedUntil(launch time.Time) (*PeriodicProhibit, time.Time, error) {
+	for _, w := range p.Prohibit {
+		// The first window starting after the launch time minus the duration
+		// of the windows contains the launch time if it starts before it.
+		start, err := CronParseNext(launch.Add(-w.Duration), w.Start)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		if !start.IsZero() && !start.After(launch) {
+			return w, start.Add(w.Duration), nil
+		}
+	}
+	return nil, time.Time{}, nil
+}
+
+// nextSpec returns the closest time instant matching the spec that is after
+// the passed time, regardless of the prohibit windows.
+func (p *PeriodicConfig) nextSpec(fromTime time.Time) (time.Time, error) {
 	switch p.SpecType {
 	case PeriodicSpecCron:
 		// Single spec parsing
@@ -5969,6 +6580,19 @@ func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
 	return time.Time{}, nil
 }
 
+// Concurrency returns the concurrency policy of the periodic job. Jobs
+// registered before the concurrency policy was introduced forbid concurrent
+// children if they prohibit overlap.
+func (p *PeriodicConfig) Concurrency() string {
+	if p.ConcurrencyPolicy != "" {
+		return p.ConcurrencyPolicy
+	}
+	if p.ProhibitOverlap {
+		return PeriodicConcurrencyForbid
+	}
+	return PeriodicConcurrencyAllow
+}
+
 // GetLocation returns the location to use for determining the time zone to run
 // the periodic job against.
 func (p *PeriodicConfig) GetLocation() *time.Location {
@@ -5980,6 +6604,83 @@ func (p *PeriodicConfig) GetLocation() *time.Location {
 	return time.UTC
 }
 
+const (
+	// PeriodicConcurrencyAllow launches the periodic job even if its previous
+	// children are still running.
+	PeriodicConcurrencyAllow = "allow"
+
+	// PeriodicConcurrencyForbid skips the launches of the periodic job while
+	// its previous children are still running.
+	PeriodicConcurrencyForbid = "forbid"
+
+	// PeriodicConcurrencyReplace stops the running children of the periodic
+	// job before launching it.
+	PeriodicConcurrencyReplace = "replace"
+)
+
+const (
+	// PeriodicProhibitActionSkip skips the launches of a periodic job falling
+	// in a prohibit window.
+	PeriodicProhibitActionSkip = "skip"
+
+	// PeriodicProhibitActionDefer defers the launches of a periodic job
+	// falling in a prohibit window to the end of the window. The launches of
+	// a window are coalesced into a single launch.
+	PeriodicProhibitActionDefer = "defer"
+
+	// periodicProhibitMaxIterations is the number of prohibit windows the
+	// next launch of a periodic job can be moved by before giving up.
+	periodicProhibitMaxIterations = 1000
+)
+
+// PeriodicProhibit is a recurring window during which a periodic job isn't
+// launched, such as weekends or maintenance windows.
+type PeriodicProhibit struct {
+	// Start is the cron expression of the start of the windows.
+	Start string
+
+	// Duration is how long each window lasts.
+	Duration time.Duration
+
+	// Action is what happens to the launches falling in a window, either
+	// skip or defer.
+	Action string
+}
+
+func (w *PeriodicProhibit) Copy() *PeriodicProhibit {
+	if w == nil {
+		return nil
+	}
+	nw := new(PeriodicProhibit)
+	*nw = *w
+	return nw
+}
+
+func (w *PeriodicProhibit) Canonicalize() {
+	if w.Action == "" {
+		w.Action = PeriodicProhibitActionSkip
+	}
+}
+
+func (w *PeriodicProhibit) Validate() error {
+	var mErr multierror.Error
+	if w.Start == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Must specify a start"))
+	} else if _, err := cronexpr.Parse(w.Start); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid start cron spec %q: %v", w.Start, err))
+	}
+	if w.Duration <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Duration must be greater than zero"))
+	}
+	switch w.Action {
+	case PeriodicProhibitActionSkip, PeriodicProhibitActionDefer:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid action %q, must be %q or %q",
+			w.Action, PeriodicProhibitActionSkip, PeriodicProhibitActionDefer))
+	}
+	return mErr.ErrorOrNil()
+}
+
 const (
 	// PeriodicLaunchSuffix is the string appended to the periodic jobs ID
 	// when launching derived instances of it.
@@ -6002,7 +6703,16 @@ const (
 	DispatchPayloadOptional  = "optional"
 	DispatchPayloadRequired  = "required"
 
-	// DispatchLaunchSuffix is the string appended to the parameterized job's ID
+	// DispatchQueuePolicyFIFO runs the queued dispatched jobs in the order
+	// they were dispatched.
+	DispatchQueuePolicyFIFO = "fifo"
+
+	// DispatchQueuePolicyPriority runs the queued dispatched jobs with the
+	// highest priority first, and in the order they were dispatched among
+	// jobs of the same priority.
+	DispatchQueuePolicyPriority = "priority"
+
+	// DispatchLaunchSuffix is the string appended to the parameterized job's ID
 	// when dispatching instances of it.
 	DispatchLaunchSuffix = "/dispatch-"
 )
@@ -6017,6 +6727,15 @@ type ParameterizedJobConfig struct {
 
 	// MetaOptional is metadata keys that may be specified by the dispatcher
 	MetaOptional []string
+
+	// MaxConcurrent is the number of dispatched jobs which may run at the
+	// same time. The jobs dispatched beyond it are queued until a running
+	// dispatched job is dead. Zero disables the queue.
+	MaxConcurrent int
+
+	// QueuePolicy is the order in which the queued dispatched jobs run,
+	// either fifo or priority.
+	QueuePolicy string
 }
 
 func (d *ParameterizedJobConfig) Validate() error {
@@ -6033,6 +6752,15 @@ func (d *ParameterizedJobConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Required and optional meta keys should be disjoint. Following keys exist in both: %v", offending))
 	}
 
+	if d.MaxConcurrent < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max concurrent must not be negative"))
+	}
+	switch d.QueuePolicy {
+	case DispatchQueuePolicyFIFO, DispatchQueuePolicyPriority:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Unknown queue policy: %q", d.QueuePolicy))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -6040,6 +6768,9 @@ func (d *ParameterizedJobConfig) Canonicalize() {
 	if d.Payload == "" {
 		d.Payload = DispatchPayloadOptional
 	}
+	if d.QueuePolicy == "" {
+		d.QueuePolicy = DispatchQueuePolicyFIFO
+	}
 }
 
 func (d *ParameterizedJobConfig) Copy() *ParameterizedJobConfig {
@@ -6093,14 +6824,19 @@ func (d *DispatchPayloadConfig) Validate() error {
 }
 
 const (
-	TaskLifecycleHookPrestart  = "prestart"
-	TaskLifecycleHookPoststart = "poststart"
-	TaskLifecycleHookPoststop  = "poststop"
+	TaskLifecycleHookPrestartInit = "prestart_init"
+	TaskLifecycleHookPrestart     = "prestart"
+	TaskLifecycleHookPoststart    = "poststart"
+	TaskLifecycleHookPoststop     = "poststop"
 )
 
 type TaskLifecycleConfig struct {
 	Hook    string
 	Sidecar bool
+
+	// DrainDelay is how long a sidecar task keeps running once all the main
+	// tasks of the group completed, before it is gracefully stopped.
+	DrainDelay time.Duration
 }
 
 func (d *TaskLifecycleConfig) Copy() *TaskLifecycleConfig {
@@ -6118,6 +6854,10 @@ func (d *TaskLifecycleConfig) Validate() error {
 	}
 
 	switch d.Hook {
+	case TaskLifecycleHookPrestartInit:
+		if d.Sidecar {
+			return fmt.Errorf("%s tasks cannot be sidecars", TaskLifecycleHookPrestartInit)
+		}
 	case TaskLifecycleHookPrestart:
 	case TaskLifecycleHookPoststart:
 	case TaskLifecycleHookPoststop:
@@ -6127,6 +6867,13 @@ func (d *TaskLifecycleConfig) Validate() error {
 		return fmt.Errorf("invalid hook: %v", d.Hook)
 	}
 
+	if d.DrainDelay < 0 {
+		return fmt.Errorf("drain delay may not be less than zero: %v", d.DrainDelay)
+	}
+	if d.DrainDelay > 0 && !d.Sidecar {
+		return fmt.Errorf("drain delay can only be set on sidecar tasks")
+	}
+
 	return nil
 }
 
@@ -6392,6 +7139,14 @@ func (p *ScalingPolicy) Validate() error {
 			fmt.Errorf("minimum count must be specified and non-negative"))
 	}
 
+	// Check the target tracking policy of the built-in autoscaler
+	if tt, err := p.TargetTracking(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	} else if tt != nil && p.Type != ScalingPolicyTypeHorizontal {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("target_tracking is only supported by horizontal scaling policies"))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -6539,6 +7294,38 @@ func NewRestartPolicy(jobType string) *RestartPolicy {
 	return nil
 }
 
+// RestartBudget limits the number of restarts of all the tasks of a task
+// group. Once the budget is exceeded the allocation is failed so that it can
+// be rescheduled.
+type RestartBudget struct {
+	// Attempts is the number of restarts allowed within the interval, across
+	// all the tasks of the group.
+	Attempts int
+
+	// Interval is the sliding window the restarts are counted in.
+	Interval time.Duration
+}
+
+func (r *RestartBudget) Copy() *RestartBudget {
+	if r == nil {
+		return nil
+	}
+	nr := new(RestartBudget)
+	*nr = *r
+	return nr
+}
+
+func (r *RestartBudget) Validate() error {
+	var mErr multierror.Error
+	if r.Attempts < 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Restart budget attempts must be greater than zero (got %d)", r.Attempts))
+	}
+	if r.Interval < RestartPolicyMinInterval {
+		_ = multierror.Append(&mErr, fmt.Errorf("Restart budget interval can not be less than %v (got %v)", RestartPolicyMinInterval, r.Interval))
+	}
+	return mErr.ErrorOrNil()
+}
+
 const ReschedulePolicyMinInterval = 15 * time.Second
 const ReschedulePolicyMinDelay = 5 * time.Second
 
@@ -6566,6 +7353,16 @@ type ReschedulePolicy struct {
 	// Unlimited allows infinite rescheduling attempts. Only allowed when delay is set
 	// between reschedule attempts.
 	Unlimited bool
+
+	// Jitter is the fraction of the delay, between 0 and 1, by which each
+	// reschedule delay is randomly shortened. It spreads the reschedules of
+	// allocations which failed at the same time.
+	Jitter float64
+
+	// AvoidNodeWindow is the duration during which the nodes an allocation
+	// failed on are excluded from its rescheduling, unless no other node is
+	// feasible.
+	AvoidNodeWindow time.Duration
 }
 
 func (r *ReschedulePolicy) Copy() *ReschedulePolicy {
@@ -6610,6 +7407,13 @@ func (r *ReschedulePolicy) Validate() error {
 		delayPreCheck = false
 	}
 
+	if r.Jitter < 0 || r.Jitter >= 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Jitter must be between 0 and 1 (got %v)", r.Jitter))
+	}
+	if r.AvoidNodeWindow < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Avoid node window may not be less than zero: %v", r.AvoidNodeWindow))
+	}
+
 	// Must use a valid delay function
 	if !isValidDelayFunction(r.DelayFunction) {
 		_ = multierror.Append(&mErr, fmt.Errorf("Invalid delay function %q, must be one of %q", r.DelayFunction, RescheduleDelayFunctions))
@@ -6655,6 +7459,39 @@ func isValidDelayFunction(delayFunc string) bool {
 	return false
 }
 
+// jitterDelay shortens the delay by a fraction of the jitter derived from the
+// seed, so that the same allocation is always given the same delay.
+func (r *ReschedulePolicy) jitterDelay(delay time.Duration, seed string) time.Duration {
+	if r.Jitter <= 0 || delay <= 0 {
+		return delay
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(seed))
+	frac := float64(h.Sum64()%1000) / 1000
+	return delay - time.Duration(float64(delay)*r.Jitter*frac)
+}
+
+// AvoidedNodes returns the nodes the allocation failed on within the avoid
+// node window of the policy.
+func (r *ReschedulePolicy) AvoidedNodes(prev *Allocation, now time.Time) map[string]struct{} {
+	if r == nil || r.AvoidNodeWindow <= 0 || prev == nil {
+		return nil
+	}
+	nodes := make(map[string]struct{})
+	if prev.ClientStatus == AllocClientStatusFailed {
+		nodes[prev.NodeID] = struct{}{}
+	}
+	if prev.RescheduleTracker != nil {
+		since := now.Add(-r.AvoidNodeWindow).UnixNano()
+		for _, event := range prev.RescheduleTracker.Events {
+			if event.RescheduleTime >= since {
+				nodes[event.PrevNodeID] = struct{}{}
+			}
+		}
+	}
+	return nodes
+}
+
 func (r *ReschedulePolicy) validateDelayParams() error {
 	ok, possibleAttempts, recommendedInterval := r.viableAttempts()
 	if ok {
@@ -6835,6 +7672,9 @@ type TaskGroup struct {
 	// RestartPolicy of a TaskGroup
 	RestartPolicy *RestartPolicy
 
+	// RestartBudget limits the restarts of all the tasks of the group
+	RestartBudget *RestartBudget
+
 	// Disconnect strategy defines how both clients and server should behave in case of
 	// disconnection between them.
 	Disconnect *DisconnectStrategy
@@ -6857,10 +7697,19 @@ type TaskGroup struct {
 	// scheduling preferences.
 	Affinities []*Affinity
 
+	// JobAffinities can be specified at the task group level to place
+	// allocations on, or away from, nodes running allocations of other jobs
+	// or services.
+	JobAffinities []*JobAffinity
+
 	// Spread can be specified at the task group level to express spreading
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// Gang is a label shared by task groups whose allocations must be placed
+	// all together or not at all.
+	Gang string
+
 	// Networks are the network configuration for the task group. This can be
 	// overridden in the task.
 	Networks Networks
@@ -6904,9 +7753,11 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.Update = ntg.Update.Copy()
 	ntg.Constraints = CopySliceConstraints(ntg.Constraints)
 	ntg.RestartPolicy = ntg.RestartPolicy.Copy()
+	ntg.RestartBudget = ntg.RestartBudget.Copy()
 	ntg.Disconnect = ntg.Disconnect.Copy()
 	ntg.ReschedulePolicy = ntg.ReschedulePolicy.Copy()
 	ntg.Affinities = CopySliceAffinities(ntg.Affinities)
+	ntg.JobAffinities = CopySliceJobAffinities(ntg.JobAffinities)
 	ntg.Spreads = CopySliceSpreads(ntg.Spreads)
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
 	ntg.Scaling = ntg.Scaling.Copy()
@@ -6965,6 +7816,10 @@ func (tg *TaskGroup) Canonicalize(job *Job) {
 		tg.Affinities = nil
 	}
 
+	if len(tg.JobAffinities) == 0 {
+		tg.JobAffinities = nil
+	}
+
 	if len(tg.Spreads) == 0 {
 		tg.Spreads = nil
 	}
@@ -7084,6 +7939,20 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	for idx, affinity := range tg.JobAffinities {
+		if err := affinity.Validate(); err != nil {
+			outer := fmt.Errorf("Job affinity %d validation failed: %s", idx+1, err)
+			mErr = multierror.Append(mErr, outer)
+		}
+		if (j.Type == JobTypeSystem || j.Type == JobTypeSysBatch) && !affinity.Required {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job affinity %d must be required for %s jobs", idx+1, j.Type))
+		}
+	}
+
+	if tg.Gang != "" && (j.Type == JobTypeSystem || j.Type == JobTypeSysBatch) {
+		mErr = multierror.Append(mErr, fmt.Errorf("Gang scheduling is not supported for %s jobs", j.Type))
+	}
+
 	if tg.RestartPolicy != nil {
 		if err := tg.RestartPolicy.Validate(); err != nil {
 			mErr = multierror.Append(mErr, err)
@@ -7092,6 +7961,12 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr = multierror.Append(mErr, fmt.Errorf("Task Group %v should have a restart policy", tg.Name))
 	}
 
+	if tg.RestartBudget != nil {
+		if err := tg.RestartBudget.Validate(); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+
 	if j.Type == JobTypeSystem {
 		if tg.Spreads != nil {
 			mErr = multierror.Append(mErr, fmt.Errorf("System jobs may not have a spread block"))
@@ -7177,7 +8052,7 @@ func (tg *TaskGroup) Validate(j *Job) error {
 	// Validate the volume requests
 	var canaries int
 	if tg.Update != nil {
-		canaries = tg.Update.Canary
+		canaries = tg.Update.CanaryCount(tg.Count, len(tg.Update.CanarySteps)-1)
 	}
 	for name, volReq := range tg.Volumes {
 		if err := volReq.Validate(j.Type, tg.Count, canaries); err != nil {
@@ -7299,6 +8174,25 @@ func (tg *TaskGroup) validateNetworks() error {
 			}
 		}
 
+		if net.EgressMBits < 0 || net.IngressMBits < 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Bandwidth limits cannot be negative"))
+		} else if net.Shaped() && net.Mode != "bridge" {
+			err := fmt.Errorf("Bandwidth limits are only supported in bridge network mode, not %q", net.Mode)
+			mErr.Errors = append(mErr.Errors, err)
+		}
+
+		if len(net.CNINetworks) > 0 {
+			if err := validateCNINetworks(net); err != nil {
+				mErr.Errors = append(mErr.Errors, err)
+			}
+		}
+
+		if net.HasStaticAddress() {
+			if err := validateStaticAddresses(net); err != nil {
+				mErr.Errors = append(mErr.Errors, err)
+			}
+		}
+
 		// Validate the hostname field to be a valid DNS name. If the parameter
 		// looks like it includes an interpolation value, we skip this. It
 		// would be nice to validate additional parameters, but this isn't the
@@ -7348,6 +8242,53 @@ func (tg *TaskGroup) validateNetworks() error {
 	return mErr.ErrorOrNil()
 }
 
+// validateCNINetworks validates the additional CNI networks of a network,
+// which are attached to the network namespace created by its mode.
+func validateCNINetworks(net *NetworkResource) error {
+	var mErr multierror.Error
+
+	if net.Mode != "bridge" && !strings.HasPrefix(net.Mode, "cni/") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf(
+			"Additional CNI networks are only supported in bridge and cni network modes, not %q", net.Mode))
+	}
+
+	seen := set.New[string](len(net.CNINetworks))
+	for _, name := range net.CNINetworks {
+		switch {
+		case name == "":
+			mErr.Errors = append(mErr.Errors, errors.New("Additional CNI network names cannot be empty"))
+		case "cni/"+name == net.Mode:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Additional CNI network %q is already the network mode", name))
+		case !seen.Insert(name):
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Additional CNI network %q is defined more than once", name))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateStaticAddresses validates the static addresses of a network, which
+// are assigned to the network namespace created by its mode.
+func validateStaticAddresses(nw *NetworkResource) error {
+	var mErr multierror.Error
+
+	if nw.Mode != "bridge" && !strings.HasPrefix(nw.Mode, "cni/") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf(
+			"Static addresses are only supported in bridge and cni network modes, not %q", nw.Mode))
+	}
+
+	if nw.IPv4Address != "" {
+		if ip := net.ParseIP(nw.IPv4Address); ip == nil || ip.To4() == nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid IPv4 address %q", nw.IPv4Address))
+		}
+	}
+	if nw.IPv6Address != "" {
+		if ip := net.ParseIP(nw.IPv6Address); ip == nil || ip.To4() != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid IPv6 address %q", nw.IPv6Address))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
 // validateServices runs Service.Validate() on group-level services, checks
 // group service checks that refer to tasks only refer to tasks that exist.
 func (tg *TaskGroup) validateServices() error {
@@ -7480,14 +8421,14 @@ func (tg *TaskGroup) validateServices() error {
 }
 
 // validateScriptChecksInGroupServices ensures group-level services with script
-// checks know what task driver to use. Either the service.task or service.check.task
-// parameter must be configured.
+// or docker checks know what task driver to use. Either the service.task or
+// service.check.task parameter must be configured.
 func (tg *TaskGroup) validateScriptChecksInGroupServices() error {
 	var mErr multierror.Error
 	for _, service := range tg.Services {
 		if service.TaskName == "" {
 			for _, check := range service.Checks {
-				if check.Type == "script" && check.TaskName == "" {
+				if (check.Type == ServiceCheckScript || check.Type == ServiceCheckDocker) && check.TaskName == "" {
 					mErr.Errors = append(mErr.Errors,
 						fmt.Errorf("Service [%s]->%s or Check %s must specify task parameter",
 							tg.Name, service.Name, check.Name,
@@ -7728,6 +8669,14 @@ type LogConfig struct {
 	MaxFiles      int
 	MaxFileSizeMB int
 	Disabled      bool
+
+	// Sink is a remote log sink the logs are shipped to by the client, in
+	// addition to the sinks configured on the client.
+	Sink *LogSink
+
+	// Format is the format of the lines written to the log files, either
+	// "text" or "json". Defaults to text.
+	Format string
 }
 
 func (l *LogConfig) Equal(o *LogConfig) bool {
@@ -7747,6 +8696,14 @@ func (l *LogConfig) Equal(o *LogConfig) bool {
 		return false
 	}
 
+	if !l.Sink.Equal(o.Sink) {
+		return false
+	}
+
+	if l.Format != o.Format {
+		return false
+	}
+
 	return true
 }
 
@@ -7758,6 +8715,8 @@ func (l *LogConfig) Copy() *LogConfig {
 		MaxFiles:      l.MaxFiles,
 		MaxFileSizeMB: l.MaxFileSizeMB,
 		Disabled:      l.Disabled,
+		Sink:          l.Sink.Copy(),
+		Format:        l.Format,
 	}
 }
 
@@ -7789,9 +8748,94 @@ func (l *LogConfig) Validate(disk *EphemeralDisk) error {
 					logUsage, disk.SizeMB))
 		}
 	}
+	switch l.Format {
+	case "", LogFormatText, LogFormatJSON:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("log format %q must be %q or %q",
+			l.Format, LogFormatText, LogFormatJSON))
+	}
+	if l.Sink != nil {
+		if err := l.Sink.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid log sink: %v", err))
+		}
+	}
 	return mErr.ErrorOrNil()
 }
 
+const (
+	// LogFormatText and LogFormatJSON are the formats of the log files. Lines
+	// are written as they are logged by the task in the text format, and
+	// wrapped in JSON objects with the time, stream, and task of the line in
+	// the JSON format.
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+const (
+	LogSinkTypeSyslog  = "syslog"
+	LogSinkTypeFluentd = "fluentd"
+	LogSinkTypeFile    = "file"
+)
+
+// LogSink is a remote log sink the lines of the logs of a task are shipped to
+// by the logmon process of the task.
+type LogSink struct {
+	// Type is either "syslog", "fluentd", or "file".
+	Type string
+
+	// Address is the address of the syslog server, as a URL with the udp,
+	// tcp, unix, or unixgram scheme, or the host:port of the Fluentd server.
+	Address string
+
+	// Path is the file lines are appended to as JSON by file sinks, relative
+	// to the log directory of the allocation.
+	Path string
+
+	// Tag is the syslog app name or the Fluentd tag of the lines.
+	Tag string
+
+	// Facility is the syslog facility of the lines.
+	Facility string
+}
+
+func (l *LogSink) Equal(o *LogSink) bool {
+	if l == nil || o == nil {
+		return l == o
+	}
+	return *l == *o
+}
+
+func (l *LogSink) Copy() *LogSink {
+	if l == nil {
+		return nil
+	}
+	nl := *l
+	return &nl
+}
+
+// Validate returns an error if the sink is invalid. The address and facility
+// are fully validated by the client.
+func (l *LogSink) Validate() error {
+	switch l.Type {
+	case LogSinkTypeSyslog:
+		if !slices.ContainsFunc([]string{"udp://", "tcp://", "unix://", "unixgram://"},
+			func(scheme string) bool { return strings.HasPrefix(l.Address, scheme) }) {
+			return fmt.Errorf("syslog address %q must use the udp, tcp, unix, or unixgram scheme", l.Address)
+		}
+	case LogSinkTypeFluentd:
+		if l.Address == "" {
+			return errors.New("fluentd log sink requires an address")
+		}
+	case LogSinkTypeFile:
+		if !filepath.IsLocal(l.Path) {
+			return fmt.Errorf("file log sink path %q must be relative to the log directory", l.Path)
+		}
+	default:
+		return fmt.Errorf("unknown log sink type %q", l.Type)
+	}
+	return nil
+}
+
 // Task is a single process typically that is executed as part of a task group.
 type Task struct {
 	// Name of the task
@@ -7894,6 +8938,9 @@ type Task struct {
 	// Alloc-exec-like runnable commands
 	Actions []*Action
 
+	// Secrets are Nomad Variables written to the task's secrets directory.
+	Secrets []*Secret
+
 	// Schedule for pausing tasks. Enterprise only.
 	Schedule *TaskSchedule
 }
@@ -7914,6 +8961,11 @@ func (t *Task) UsesConnectSidecar() bool {
 	return t.Kind.IsConnectProxy() || t.Kind.IsAnyConnectGateway()
 }
 
+func (t *Task) IsPrestartInit() bool {
+	return t != nil && t.Lifecycle != nil &&
+		t.Lifecycle.Hook == TaskLifecycleHookPrestartInit
+}
+
 func (t *Task) IsPrestart() bool {
 	return t != nil && t.Lifecycle != nil &&
 		t.Lifecycle.Hook == TaskLifecycleHookPrestart
@@ -7992,6 +9044,7 @@ func (t *Task) Copy() *Task {
 	nt.Identity = nt.Identity.Copy()
 	nt.Identities = helper.CopySlice(nt.Identities)
 	nt.Actions = helper.CopySlice(nt.Actions)
+	nt.Secrets = helper.CopySlice(nt.Secrets)
 
 	if t.Artifacts != nil {
 		artifacts := make([]*TaskArtifact, 0, len(t.Artifacts))
@@ -8217,6 +9270,19 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 		} else {
 			destinations[tmpl.DestPath] = idx + 1
 		}
+
+		// The firecracker driver can neither signal tasks nor execute
+		// commands in them, which all other change modes rely on.
+		if t.Driver == "firecracker" &&
+			tmpl.ChangeMode != TemplateChangeModeNoop && tmpl.ChangeMode != TemplateChangeModeRestart {
+			outer := fmt.Errorf("Template %d change mode %q is not supported by the firecracker driver; use restart or noop", idx+1, tmpl.ChangeMode)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+
+		if tmpl.VaultRole != "" && t.Vault == nil {
+			outer := fmt.Errorf("Template %d sets a Vault role but the task has no Vault block", idx+1)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
 	}
 
 	// Validate actions.
@@ -8235,6 +9301,22 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 		actions[action.Name] = false
 	}
 
+	// Validate secrets.
+	secrets := make(map[string]bool)
+	for _, secret := range t.Secrets {
+		if err := secret.Validate(); err != nil {
+			outer := fmt.Errorf("Secret %s validation failed: %s", secret.Name, err)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+
+		if handled, seen := secrets[secret.Name]; seen && !handled {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Secret %s defined multiple times", secret.Name))
+			secrets[secret.Name] = true
+			continue
+		}
+		secrets[secret.Name] = false
+	}
+
 	// Validate the dispatch payload block if there
 	if t.DispatchPayload != nil {
 		if err := t.DispatchPayload.Validate(); err != nil {
@@ -8304,6 +9386,7 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 	}
 
 	// Validate Identities
+	awsProfiles := map[string]string{}
 	for _, wid := range t.Identities {
 		// Task.Canonicalize should move the default identity out of the Identities
 		// slice, so if one is found that means it is a duplicate.
@@ -8314,6 +9397,15 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 		if err := wid.Validate(); err != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Identity %q is invalid: %w", wid.Name, err))
 		}
+
+		// Every AWS identity writes its own profile of the credentials file
+		if wid.AWS != nil {
+			if other, ok := awsProfiles[wid.AWS.Profile]; ok {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Identities %q and %q use the same aws profile %q",
+					other, wid.Name, wid.AWS.Profile))
+			}
+			awsProfiles[wid.AWS.Profile] = wid.Name
+		}
 	}
 
 	return mErr.ErrorOrNil()
@@ -8655,15 +9747,34 @@ const (
 	// template is re-rendered
 	TemplateChangeModeRestart = "restart"
 
+	// TemplateChangeModeReload marks that the task driver should reload the
+	// task if the template is re-rendered
+	TemplateChangeModeReload = "reload"
+
 	// TemplateChangeModeScript marks that the task should trigger a script if
 	// the template is re-rendered
 	TemplateChangeModeScript = "script"
+
+	// TemplateChangeModeExecRestart marks that the process supervised by the
+	// template should be restarted if the template is re-rendered
+	TemplateChangeModeExecRestart = "exec_restart"
+)
+
+const (
+	// TemplateFirstRenderActionKill marks that the task should be killed if
+	// the template has not rendered within its first_render_timeout
+	TemplateFirstRenderActionKill = "kill"
+
+	// TemplateFirstRenderActionFail marks that the task should be killed and
+	// marked as failed if the template has not rendered within its
+	// first_render_timeout
+	TemplateFirstRenderActionFail = "fail"
 )
 
 var (
 	// TemplateChangeModeInvalidError is the error for when an invalid change
 	// mode is given
-	TemplateChangeModeInvalidError = errors.New("Invalid change mode. Must be one of the following: noop, signal, script, restart")
+	TemplateChangeModeInvalidError = errors.New("Invalid change mode. Must be one of the following: noop, signal, script, restart, reload, exec_restart")
 )
 
 // Template represents a template configuration to be rendered for a given task
@@ -8689,6 +9800,10 @@ type Template struct {
 	// ChangeMode is set to script.
 	ChangeScript *ChangeScript
 
+	// Exec is the configuration of the process supervised by the template
+	// inside the task. It's required if ChangeMode is set to exec_restart.
+	Exec *TemplateExec
+
 	// Once will wait for the templates to render and then exit without
 	// watching for changes.
 	Once bool
@@ -8721,6 +9836,16 @@ type Template struct {
 	// escaping issues #s within lines will not be treated as comments.
 	Envvars bool
 
+	// EnvPrefix is prepended to the name of every env var read from an
+	// Envvars template, to avoid collisions with the env vars of other
+	// templates.
+	EnvPrefix string
+
+	// UnsetIfEmpty causes env vars with an empty value in an Envvars template
+	// to be unset instead of set to an empty string, removing any value set
+	// by an earlier template.
+	UnsetIfEmpty bool
+
 	// VaultGrace is the grace duration between lease renewal and reacquiring a
 	// secret. If the lease of a secret is less than the grace, a new secret is
 	// acquired.
@@ -8733,6 +9858,24 @@ type Template struct {
 	// ErrMissingKey is used to control how the template behaves when attempting
 	// to index a struct or map key that does not exist.
 	ErrMissingKey bool
+
+	// VaultRole is the Vault role used to derive a dedicated Vault token for
+	// this template. If empty, the template uses the task's Vault token.
+	VaultRole string
+
+	// FirstRenderTimeout is the maximum amount of time the task may be blocked
+	// waiting for the template to render for the first time. Zero disables
+	// the timeout.
+	FirstRenderTimeout time.Duration
+
+	// FirstRenderTimeoutAction is the action taken when FirstRenderTimeout
+	// expires. It must be one of the TemplateFirstRenderAction constants.
+	FirstRenderTimeoutAction string
+
+	// Checksum is the expected checksum of the template contents, given as
+	// "type:value". The template is not rendered if its source or embedded
+	// data does not match it.
+	Checksum string
 }
 
 // DefaultTemplate returns a default template.
@@ -8761,6 +9904,8 @@ func (t *Template) Equal(o *Template) bool {
 		return false
 	case !t.ChangeScript.Equal(o.ChangeScript):
 		return false
+	case !t.Exec.Equal(o.Exec):
+		return false
 	case t.Once != o.Once:
 		return false
 	case t.Splay != o.Splay:
@@ -8777,12 +9922,24 @@ func (t *Template) Equal(o *Template) bool {
 		return false
 	case t.Envvars != o.Envvars:
 		return false
+	case t.EnvPrefix != o.EnvPrefix:
+		return false
+	case t.UnsetIfEmpty != o.UnsetIfEmpty:
+		return false
 	case t.VaultGrace != o.VaultGrace:
 		return false
 	case !t.Wait.Equal(o.Wait):
 		return false
 	case t.ErrMissingKey != o.ErrMissingKey:
 		return false
+	case t.VaultRole != o.VaultRole:
+		return false
+	case t.FirstRenderTimeout != o.FirstRenderTimeout:
+		return false
+	case t.FirstRenderTimeoutAction != o.FirstRenderTimeoutAction:
+		return false
+	case t.Checksum != o.Checksum:
+		return false
 	}
 	return true
 }
@@ -8795,6 +9952,7 @@ func (t *Template) Copy() *Template {
 	*nt = *t
 
 	nt.ChangeScript = t.ChangeScript.Copy()
+	nt.Exec = t.Exec.Copy()
 	nt.Wait = t.Wait.Copy()
 
 	return nt
@@ -8837,6 +9995,13 @@ func (t *Template) Validate() error {
 		if t.Envvars {
 			_ = multierror.Append(&mErr, fmt.Errorf("cannot use signals with env var templates"))
 		}
+	case TemplateChangeModeReload:
+		if t.ChangeSignal == "" {
+			_ = multierror.Append(&mErr, fmt.Errorf("Must specify signal value when change mode is reload"))
+		}
+		if t.Envvars {
+			_ = multierror.Append(&mErr, fmt.Errorf("cannot use reload with env var templates"))
+		}
 	case TemplateChangeModeScript:
 		if t.ChangeScript == nil {
 			_ = multierror.Append(&mErr, fmt.Errorf("must specify change script configuration value when change mode is script"))
@@ -8845,6 +10010,20 @@ func (t *Template) Validate() error {
 		if err = t.ChangeScript.Validate(); err != nil {
 			_ = multierror.Append(&mErr, err)
 		}
+	case TemplateChangeModeExecRestart:
+		if t.Exec == nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("must specify exec configuration value when change mode is exec_restart"))
+		}
+		if t.Envvars {
+			_ = multierror.Append(&mErr, fmt.Errorf("cannot use exec_restart with env var templates"))
+		}
+		if t.Once {
+			_ = multierror.Append(&mErr, fmt.Errorf("cannot use exec_restart with once templates"))
+		}
+
+		if err = t.Exec.Validate(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
 	default:
 		_ = multierror.Append(&mErr, TemplateChangeModeInvalidError)
 	}
@@ -8865,9 +10044,55 @@ func (t *Template) Validate() error {
 		_ = multierror.Append(&mErr, err)
 	}
 
+	// Verify the env var options are only set on env var templates
+	if t.EnvPrefix != "" {
+		if !t.Envvars {
+			_ = multierror.Append(&mErr, fmt.Errorf("env_prefix can only be used with env var templates"))
+		} else if !validTemplateEnvPrefix.MatchString(t.EnvPrefix) {
+			_ = multierror.Append(&mErr, fmt.Errorf("Invalid env_prefix %q: must contain only letters, digits, and underscores and must not start with a digit", t.EnvPrefix))
+		}
+	}
+	if t.UnsetIfEmpty && !t.Envvars {
+		_ = multierror.Append(&mErr, fmt.Errorf("unset_if_empty can only be used with env var templates"))
+	}
+
+	// Verify the first render timeout
+	if t.FirstRenderTimeout < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Must specify positive first_render_timeout value"))
+	}
+	switch t.FirstRenderTimeoutAction {
+	case "", TemplateFirstRenderActionKill, TemplateFirstRenderActionFail:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid first_render_timeout_action %q. Must be one of the following: kill, fail", t.FirstRenderTimeoutAction))
+	}
+
+	if t.Checksum != "" {
+		if _, _, err := parseChecksum(t.Checksum); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
+// VerifyChecksum returns an error if the template has a checksum that does not
+// match the given template contents.
+func (t *Template) VerifyChecksum(contents []byte) error {
+	if t.Checksum == "" {
+		return nil
+	}
+
+	h, expected, err := parseChecksum(t.Checksum)
+	if err != nil {
+		return err
+	}
+	h.Write(contents)
+	if actual := h.Sum(nil); !bytes.Equal(actual, expected) {
+		return fmt.Errorf("checksum mismatch: expected %x, got %x", expected, actual)
+	}
+	return nil
+}
+
 func (t *Template) Warnings() error {
 	var mErr multierror.Error
 
@@ -8879,6 +10104,12 @@ func (t *Template) Warnings() error {
 	return mErr.ErrorOrNil()
 }
 
+// HasRemoteSource returns whether the template source is a go-getter URL that
+// must be downloaded before the template is rendered.
+func (t *Template) HasRemoteSource() bool {
+	return strings.Contains(t.SourcePath, "://") || strings.Contains(t.SourcePath, "::")
+}
+
 // DiffID fulfills the DiffableWithID interface.
 func (t *Template) DiffID() string {
 	return t.DestPath
@@ -8940,6 +10171,52 @@ func (cs *ChangeScript) Validate() error {
 	return nil
 }
 
+// TemplateExec is a long lived process run inside the task by a template with
+// change_mode exec_restart. The process is restarted when the template is
+// re-rendered.
+type TemplateExec struct {
+	// Command is the full path to the command
+	Command string
+	// Args is a slice of arguments passed to the command
+	Args []string
+}
+
+func (te *TemplateExec) Equal(o *TemplateExec) bool {
+	if te == nil || o == nil {
+		return te == o
+	}
+	switch {
+	case te.Command != o.Command:
+		return false
+	case !slices.Equal(te.Args, o.Args):
+		return false
+	}
+	return true
+}
+
+func (te *TemplateExec) Copy() *TemplateExec {
+	if te == nil {
+		return nil
+	}
+	return &TemplateExec{
+		Command: te.Command,
+		Args:    slices.Clone(te.Args),
+	}
+}
+
+// Validate makes sure all the required fields of TemplateExec are present
+func (te *TemplateExec) Validate() error {
+	if te == nil {
+		return nil
+	}
+
+	if te.Command == "" {
+		return fmt.Errorf("must specify exec command value when change mode is exec_restart")
+	}
+
+	return nil
+}
+
 // WaitConfig is the Min/Max duration used by the Consul Template Watcher. Consul
 // Template relies on pointer based business logic. This struct uses pointers so
 // that we tell the different between zero values and unset values.
@@ -9045,6 +10322,10 @@ type TaskState struct {
 	// Series of task events that transition the state of the task.
 	Events []*TaskEvent
 
+	// Templates is the render state of the task's templates and their
+	// dependencies.
+	Templates []*TemplateState
+
 	// // Experimental -  TaskHandle is based on drivers.TaskHandle and used
 	// // by remote task drivers to migrate task handles between allocations.
 	// TaskHandle *TaskHandle
@@ -9083,6 +10364,13 @@ func (ts *TaskState) Copy() *TaskState {
 		}
 	}
 
+	if ts.Templates != nil {
+		newTS.Templates = make([]*TemplateState, len(ts.Templates))
+		for i, t := range ts.Templates {
+			newTS.Templates[i] = t.Copy()
+		}
+	}
+
 	return newTS
 }
 
@@ -9117,10 +10405,95 @@ func (ts *TaskState) Equal(o *TaskState) bool {
 	}) {
 		return false
 	}
+	if !slices.EqualFunc(ts.Templates, o.Templates, func(ts, o *TemplateState) bool {
+		return ts.Equal(o)
+	}) {
+		return false
+	}
 
 	return true
 }
 
+// TemplateState is the render state of a task's template, used to find out
+// which dependency is blocking a template from rendering.
+type TemplateState struct {
+	// DestPath is the destination of the template
+	DestPath string
+
+	// LastRendered is the last time the template was rendered to disk
+	LastRendered time.Time
+
+	// Dependencies are the dependencies used by the template, sorted by name
+	Dependencies []*TemplateDependencyState
+}
+
+func (t *TemplateState) Copy() *TemplateState {
+	if t == nil {
+		return nil
+	}
+	nt := new(TemplateState)
+	*nt = *t
+
+	if t.Dependencies != nil {
+		nt.Dependencies = make([]*TemplateDependencyState, len(t.Dependencies))
+		for i, d := range t.Dependencies {
+			nt.Dependencies[i] = d.Copy()
+		}
+	}
+	return nt
+}
+
+func (t *TemplateState) Equal(o *TemplateState) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	if t.DestPath != o.DestPath {
+		return false
+	}
+	if !t.LastRendered.Equal(o.LastRendered) {
+		return false
+	}
+	return slices.EqualFunc(t.Dependencies, o.Dependencies, func(t, o *TemplateDependencyState) bool {
+		return t.Equal(o)
+	})
+}
+
+// TemplateDependencyState is the state of a single dependency of a template,
+// such as a Consul service or a Vault secret.
+type TemplateDependencyState struct {
+	// Name identifies the dependency, for example "kv.block(app/config)"
+	Name string
+
+	// Type is the system the dependency is read from: consul, vault, nomad
+	// or local
+	Type string
+
+	// Missing is true if the template is waiting for data for the dependency
+	Missing bool
+
+	// LastChanged is the last time the dependency became missing or received
+	// its data
+	LastChanged time.Time
+}
+
+func (d *TemplateDependencyState) Copy() *TemplateDependencyState {
+	if d == nil {
+		return nil
+	}
+	nd := *d
+	return &nd
+}
+
+func (d *TemplateDependencyState) Equal(o *TemplateDependencyState) bool {
+	if d == nil || o == nil {
+		return d == o
+	}
+	return d.Name == o.Name &&
+		d.Type == o.Type &&
+		d.Missing == o.Missing &&
+		d.LastChanged.Equal(o.LastChanged)
+}
+
 const (
 	// TaskSetupFailure indicates that the task could not be started due to a
 	// a setup failure.
@@ -9169,6 +10542,11 @@ const (
 	// TaskSignaling indicates that the task is being signalled.
 	TaskSignaling = "Signaling"
 
+	// TaskVaultSecretRotation indicates that the Vault secrets of the task
+	// were rotated and the task is about to be restarted before the leases of
+	// the previous secrets expire.
+	TaskVaultSecretRotation = "Vault Secret Rotation"
+
 	// TaskDownloadingArtifacts means the task is downloading the artifacts
 	// specified in the task.
 	TaskDownloadingArtifacts = "Downloading Artifacts"
@@ -9177,6 +10555,10 @@ const (
 	// failed.
 	TaskArtifactDownloadFailed = "Failed Artifact Download"
 
+	// TaskArtifactVerificationFailed indicates that the signature of an
+	// artifact failed verification.
+	TaskArtifactVerificationFailed = "Failed Artifact Verification"
+
 	// TaskBuildingTaskDir indicates that the task directory/chroot is being
 	// built.
 	TaskBuildingTaskDir = "Building Task Directory"
@@ -9203,6 +10585,14 @@ const (
 	// TaskMainDead indicates that the main tasks have dead
 	TaskMainDead = "Main Tasks Dead"
 
+	// TaskRestartBudgetExceeded indicates that the tasks of the group
+	// restarted more than allowed by its restart budget.
+	TaskRestartBudgetExceeded = "Restart Budget Exceeded"
+
+	// TaskSidecarDraining indicates that the main tasks are dead and that
+	// the sidecar task will be stopped once its drain delay passed.
+	TaskSidecarDraining = "Sidecar Draining"
+
 	// TaskHookFailed indicates that one of the hooks for a task failed.
 	TaskHookFailed = "Task hook failed"
 
@@ -9223,6 +10613,22 @@ const (
 	// TaskClientReconnected indicates that the client running the task reconnected.
 	TaskClientReconnected = "Reconnected"
 
+	// TaskDeviceUnhealthy indicates that a device allocated to the task
+	// became unhealthy.
+	TaskDeviceUnhealthy = "Device Unhealthy"
+
+	// TaskEvicted indicates that the client killed a burstable task to relieve
+	// node memory pressure.
+	TaskEvicted = "Evicted"
+
+	// TaskMemoryPressure indicates that the client restarted or failed a task
+	// using more memory than it reserved because of node memory pressure.
+	TaskMemoryPressure = "Memory Pressure"
+
+	// TaskResized indicates that the resources of the running task were
+	// updated in place.
+	TaskResized = "Resized"
+
 	// TaskWaitingShuttingDownDelay indicates that the task is waiting for
 	// shutdown delay before being TaskKilled
 	TaskWaitingShuttingDownDelay = "Waiting for shutdown delay"
@@ -9375,6 +10781,12 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		} else {
 			desc = "Failed to download artifacts"
 		}
+	case TaskArtifactVerificationFailed:
+		if e.DownloadError != "" {
+			desc = e.DownloadError
+		} else {
+			desc = "Failed to verify artifacts"
+		}
 	case TaskKilling:
 		if e.KillReason != "" {
 			desc = e.KillReason
@@ -9445,6 +10857,10 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		desc = "Leader Task in Group dead"
 	case TaskMainDead:
 		desc = "Main tasks in the group died"
+	case TaskSidecarDraining:
+		desc = "Main tasks in the group died, draining sidecar tasks"
+	case TaskRestartBudgetExceeded:
+		desc = "Tasks in the group exceeded their restart budget"
 	case TaskClientReconnected:
 		desc = "Client reconnected"
 	default:
@@ -9686,6 +11102,10 @@ type TaskArtifact struct {
 	//
 	// Defaults to false.
 	Chown bool
+
+	// Verify configures the verification of the signature of the artifact
+	// before it is made available to the task.
+	Verify *TaskArtifactVerify
 }
 
 func (ta *TaskArtifact) Equal(o *TaskArtifact) bool {
@@ -9707,6 +11127,8 @@ func (ta *TaskArtifact) Equal(o *TaskArtifact) bool {
 		return false
 	case ta.Chown != o.Chown:
 		return false
+	case !ta.Verify.Equal(o.Verify):
+		return false
 	}
 	return true
 }
@@ -9723,6 +11145,7 @@ func (ta *TaskArtifact) Copy() *TaskArtifact {
 		GetterInsecure: ta.GetterInsecure,
 		RelativeDest:   ta.RelativeDest,
 		Chown:          ta.Chown,
+		Verify:         ta.Verify.Copy(),
 	}
 }
 
@@ -9765,6 +11188,17 @@ func (ta *TaskArtifact) Hash() string {
 	_, _ = h.Write([]byte(strconv.FormatBool(ta.GetterInsecure)))
 	_, _ = h.Write([]byte(ta.RelativeDest))
 	_, _ = h.Write([]byte(strconv.FormatBool(ta.Chown)))
+
+	// only hash the verification if set, so that the hashes of existing
+	// artifacts don't change
+	if v := ta.Verify; v != nil {
+		_, _ = h.Write([]byte(v.Type))
+		_, _ = h.Write([]byte(v.Signature))
+		_, _ = h.Write([]byte(v.PublicKey))
+		_, _ = h.Write([]byte(v.Bundle))
+		_, _ = h.Write([]byte(v.CertificateIdentity))
+		_, _ = h.Write([]byte(v.CertificateOIDCIssuer))
+	}
 	return base64.RawStdEncoding.EncodeToString(h.Sum(nil))
 }
 
@@ -9797,6 +11231,83 @@ func (ta *TaskArtifact) Validate() error {
 		mErr.Errors = append(mErr.Errors, err)
 	}
 
+	if ta.Verify != nil {
+		if err := ta.Verify.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid verify block: %v", err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// TaskArtifactVerify configures the verification of the detached signature
+// of an artifact, before the artifact is made available to the task.
+type TaskArtifactVerify struct {
+	// Type is the type of the signature, gpg or cosign.
+	Type string
+
+	// Signature is the URL of the detached signature of the artifact.
+	Signature string
+
+	// PublicKey is the public key the signature is verified against, as an
+	// armored GPG key ring or a PEM encoded cosign key.
+	PublicKey string
+
+	// Bundle is the URL of the cosign bundle of a keyless cosign signature,
+	// holding the signature, its certificate issued by one of the Fulcio roots
+	// of the client, and the transparency log entry of the signature.
+	Bundle string
+
+	// CertificateIdentity and CertificateOIDCIssuer are the identity and the
+	// OIDC issuer the certificate of a keyless cosign signature must be
+	// issued for.
+	CertificateIdentity   string
+	CertificateOIDCIssuer string
+}
+
+func (v *TaskArtifactVerify) Copy() *TaskArtifactVerify {
+	if v == nil {
+		return nil
+	}
+	nv := *v
+	return &nv
+}
+
+func (v *TaskArtifactVerify) Equal(o *TaskArtifactVerify) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	return *v == *o
+}
+
+func (v *TaskArtifactVerify) Validate() error {
+	var mErr multierror.Error
+	keyless := v.Bundle != "" || v.CertificateIdentity != "" || v.CertificateOIDCIssuer != ""
+	switch v.Type {
+	case ArtifactVerifyTypeGPG:
+		if v.Signature == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("signature must be specified"))
+		}
+		if v.PublicKey == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("public_key must be specified for gpg signatures"))
+		}
+		if keyless {
+			mErr.Errors = append(mErr.Errors, errors.New("bundles are only supported for cosign signatures"))
+		}
+	case ArtifactVerifyTypeCosign:
+		switch {
+		case (v.PublicKey != "" || v.Signature != "") && keyless:
+			mErr.Errors = append(mErr.Errors, errors.New("signature and public_key are mutually exclusive with bundle"))
+		case v.PublicKey != "" && v.Signature == "":
+			mErr.Errors = append(mErr.Errors, errors.New("signature must be specified"))
+		case v.PublicKey == "" && (v.Bundle == "" || v.CertificateIdentity == "" || v.CertificateOIDCIssuer == ""):
+			mErr.Errors = append(mErr.Errors, errors.New(
+				"either signature and public_key or bundle, certificate_identity and certificate_oidc_issuer must be specified"))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid type %q; must be one of: %s, %s",
+			v.Type, ArtifactVerifyTypeGPG, ArtifactVerifyTypeCosign))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -9813,42 +11324,49 @@ func (ta *TaskArtifact) validateChecksum() error {
 		return nil
 	}
 
+	_, _, err := parseChecksum(check)
+	return err
+}
+
+// parseChecksum parses a checksum given as "type:value" and returns the hash
+// used to compute it along with the expected sum.
+func parseChecksum(check string) (hash.Hash, []byte, error) {
 	check = strings.TrimSpace(check)
 	if check == "" {
-		return fmt.Errorf("checksum value cannot be empty")
+		return nil, nil, fmt.Errorf("checksum value cannot be empty")
 	}
 
 	parts := strings.Split(check, ":")
 	if l := len(parts); l != 2 {
-		return fmt.Errorf(`checksum must be given as "type:value"; got %q`, check)
+		return nil, nil, fmt.Errorf(`checksum must be given as "type:value"; got %q`, check)
 	}
 
 	checksumVal := parts[1]
 	checksumBytes, err := hex.DecodeString(checksumVal)
 	if err != nil {
-		return fmt.Errorf("invalid checksum: %v", err)
+		return nil, nil, fmt.Errorf("invalid checksum: %v", err)
 	}
 
 	checksumType := parts[0]
-	expectedLength := 0
+	var h hash.Hash
 	switch checksumType {
 	case "md5":
-		expectedLength = md5.Size
+		h = md5.New()
 	case "sha1":
-		expectedLength = sha1.Size
+		h = sha1.New()
 	case "sha256":
-		expectedLength = sha256.Size
+		h = sha256.New()
 	case "sha512":
-		expectedLength = sha512.Size
+		h = sha512.New()
 	default:
-		return fmt.Errorf("unsupported checksum type: %s", checksumType)
+		return nil, nil, fmt.Errorf("unsupported checksum type: %s", checksumType)
 	}
 
-	if len(checksumBytes) != expectedLength {
-		return fmt.Errorf("invalid %s checksum: %v", checksumType, checksumVal)
+	if len(checksumBytes) != h.Size() {
+		return nil, nil, fmt.Errorf("invalid %s checksum: %v", checksumType, checksumVal)
 	}
 
-	return nil
+	return h, checksumBytes, nil
 }
 
 const (
@@ -10082,6 +11600,11 @@ type Spread struct {
 	// SpreadTarget is used to describe desired percentages for each attribute value
 	SpreadTarget []*SpreadTarget
 
+	// MaxSkew is the maximum difference allowed between the number of
+	// allocations placed on the attribute value with the most allocations and
+	// the one with the fewest. Zero doesn't bound the difference.
+	MaxSkew int
+
 	// Memoized string representation
 	str string
 }
@@ -10095,6 +11618,8 @@ func (s *Spread) Equal(o *Spread) bool {
 		return false
 	case s.Weight != o.Weight:
 		return false
+	case s.MaxSkew != o.MaxSkew:
+		return false
 	case !slices.EqualFunc(s.SpreadTarget, o.SpreadTarget, func(a, b *SpreadTarget) bool { return a.Equal(b) }):
 		return false
 	}
@@ -10153,6 +11678,12 @@ func (s *Spread) Validate() error {
 	if s.Weight <= 0 || s.Weight > 100 {
 		mErr.Errors = append(mErr.Errors, errors.New("Spread block must have a positive weight from 0 to 100"))
 	}
+	if s.MaxSkew < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Spread max_skew must not be negative"))
+	}
+	if s.MaxSkew > 0 && len(s.SpreadTarget) > 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Spread max_skew can't be used with spread targets"))
+	}
 	seen := make(map[string]struct{})
 	sumPercent := uint32(0)
 
@@ -10317,6 +11848,49 @@ type Vault struct {
 
 	// AllowTokenExpiration disables the Vault token refresh loop on the client
 	AllowTokenExpiration bool
+
+	// SecretRotation configures the task to be restarted when the Vault
+	// secrets read by its templates are rotated, before the leases of the
+	// previous secrets expire.
+	SecretRotation *VaultSecretRotation
+}
+
+// VaultSecretRotation configures how a task rotates the Vault secrets read by
+// its templates. When a template re-renders because a Vault secret was
+// renewed or replaced before its lease expired, the task is notified, its
+// drain script is run, and the task is restarted with the new secrets.
+type VaultSecretRotation struct {
+	// DrainScript is an optional script run inside the task before it's
+	// restarted, for example to drain connections using the old secrets.
+	DrainScript *ChangeScript
+}
+
+func (r *VaultSecretRotation) Equal(o *VaultSecretRotation) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+	return r.DrainScript.Equal(o.DrainScript)
+}
+
+func (r *VaultSecretRotation) Copy() *VaultSecretRotation {
+	if r == nil {
+		return nil
+	}
+	return &VaultSecretRotation{DrainScript: r.DrainScript.Copy()}
+}
+
+// Validate returns if the VaultSecretRotation block is valid.
+func (r *VaultSecretRotation) Validate() error {
+	if r == nil || r.DrainScript == nil {
+		return nil
+	}
+	if r.DrainScript.Command == "" {
+		return errors.New("drain_script must specify a command")
+	}
+	if r.DrainScript.Timeout < 0 {
+		return errors.New("drain_script timeout must not be negative")
+	}
+	return nil
 }
 
 // IdentityName returns the name of the workload identity to be used to access
@@ -10346,6 +11920,8 @@ func (v *Vault) Equal(o *Vault) bool {
 		return false
 	case v.AllowTokenExpiration != o.AllowTokenExpiration:
 		return false
+	case !v.SecretRotation.Equal(o.SecretRotation):
+		return false
 	}
 	return true
 }
@@ -10358,6 +11934,7 @@ func (v *Vault) Copy() *Vault {
 
 	nv := new(Vault)
 	*nv = *v
+	nv.SecretRotation = v.SecretRotation.Copy()
 	return nv
 }
 
@@ -10392,6 +11969,10 @@ func (v *Vault) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Unknown change mode %q", v.ChangeMode))
 	}
 
+	if err := v.SecretRotation.Validate(); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid secret_rotation: %v", err))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -10414,6 +11995,7 @@ const (
 	DeploymentStatusDescriptionRunning               = "Deployment is running"
 	DeploymentStatusDescriptionRunningNeedsPromotion = "Deployment is running but requires manual promotion"
 	DeploymentStatusDescriptionRunningAutoPromotion  = "Deployment is running pending automatic promotion"
+	DeploymentStatusDescriptionRunningVerification   = "Deployment is running pending post-deployment verification"
 	DeploymentStatusDescriptionPaused                = "Deployment is paused"
 	DeploymentStatusDescriptionSuccessful            = "Deployment completed successfully"
 	DeploymentStatusDescriptionStoppedJob            = "Cancelled because job is stopped"
@@ -10421,6 +12003,7 @@ const (
 	DeploymentStatusDescriptionFailedAllocations     = "Failed due to unhealthy allocations"
 	DeploymentStatusDescriptionProgressDeadline      = "Failed due to progress deadline"
 	DeploymentStatusDescriptionFailedByUser          = "Deployment marked as failed"
+	DeploymentStatusDescriptionFailedVerification    = "Failed post-deployment verification"
 
 	// used only in multiregion deployments
 	DeploymentStatusDescriptionFailedByPeer   = "Failed because of an error in peer region"
@@ -10659,6 +12242,14 @@ type DeploymentState struct {
 	// DesiredCanaries is the number of canaries that should be created.
 	DesiredCanaries int
 
+	// CanarySteps are the percentages of the desired total which run as
+	// canaries at each step, copied from the TaskGroup UpdateStrategy in
+	// scheduler.reconcile.
+	CanarySteps []int
+
+	// CanaryStep is the index of the current step of the canaries.
+	CanaryStep int
+
 	// DesiredTotal is the total number of allocations that should be created as
 	// part of the deployment.
 	DesiredTotal int
@@ -10683,13 +12274,23 @@ func (d *DeploymentState) GoString() string {
 	base += fmt.Sprintf("\n\tUnhealthy: %d", d.UnhealthyAllocs)
 	base += fmt.Sprintf("\n\tAutoRevert: %v", d.AutoRevert)
 	base += fmt.Sprintf("\n\tAutoPromote: %v", d.AutoPromote)
+	if len(d.CanarySteps) != 0 {
+		base += fmt.Sprintf("\n\tCanary Step: %d/%d", d.CanaryStep+1, len(d.CanarySteps))
+	}
 	return base
 }
 
+// HasNextCanaryStep returns whether the canaries of the task group have a
+// step left before being promoted.
+func (d *DeploymentState) HasNextCanaryStep() bool {
+	return d.CanaryStep < len(d.CanarySteps)-1
+}
+
 func (d *DeploymentState) Copy() *DeploymentState {
 	c := &DeploymentState{}
 	*c = *d
 	c.PlacedCanaries = slices.Clone(d.PlacedCanaries)
+	c.CanarySteps = slices.Clone(d.CanarySteps)
 	return c
 }
 
@@ -10833,6 +12434,28 @@ type DesiredTransition struct {
 	// task shutdown_delay configuration and ignore the delay for any
 	// allocations stopped as a result of this Deregister call.
 	NoShutdownDelay *bool
+
+	// ShutdownDelay, if set, will override the group and task shutdown_delay
+	// configuration for any allocations stopped as a result of this
+	// Deregister call.
+	ShutdownDelay *time.Duration
+
+	// Decommission is used to indicate that this allocation was replaced by
+	// the canaries of a promoted deployment, and keeps running deregistered
+	// from services until its decommission delay passed.
+	Decommission *bool
+
+	// DecommissionDeadline is the time after which the allocation being
+	// decommissioned is stopped.
+	DecommissionDeadline *time.Time
+
+	// KillSignal, if set, will override the kill_signal of the tasks of
+	// allocations stopped by the drain of their node.
+	KillSignal *string
+
+	// KillTimeout, if set, will cap the kill_timeout of the tasks of
+	// allocations stopped by the drain of their node.
+	KillTimeout *time.Duration
 }
 
 // Merge merges the two desired transitions, preferring the values from the
@@ -10853,6 +12476,26 @@ func (d *DesiredTransition) Merge(o *DesiredTransition) {
 	if o.NoShutdownDelay != nil {
 		d.NoShutdownDelay = o.NoShutdownDelay
 	}
+
+	if o.ShutdownDelay != nil {
+		d.ShutdownDelay = o.ShutdownDelay
+	}
+
+	if o.Decommission != nil {
+		d.Decommission = o.Decommission
+	}
+
+	if o.DecommissionDeadline != nil {
+		d.DecommissionDeadline = o.DecommissionDeadline
+	}
+
+	if o.KillSignal != nil {
+		d.KillSignal = o.KillSignal
+	}
+
+	if o.KillTimeout != nil {
+		d.KillTimeout = o.KillTimeout
+	}
 }
 
 // ShouldMigrate returns whether the transition object dictates a migration.
@@ -10884,6 +12527,42 @@ func (d *DesiredTransition) ShouldIgnoreShutdownDelay() bool {
 	return d.NoShutdownDelay != nil && *d.NoShutdownDelay
 }
 
+// ShouldDecommission returns whether the transition object dictates that the
+// allocation is being decommissioned.
+func (d *DesiredTransition) ShouldDecommission() bool {
+	if d == nil {
+		return false
+	}
+	return d.Decommission != nil && *d.Decommission
+}
+
+// ShutdownDelayOverride returns the shutdown delay the transition object
+// dictates in place of the group and task shutdown_delay, if any.
+func (d *DesiredTransition) ShutdownDelayOverride() (time.Duration, bool) {
+	if d == nil || d.ShutdownDelay == nil {
+		return 0, false
+	}
+	return *d.ShutdownDelay, true
+}
+
+// KillSignalOverride returns the signal the transition object dictates in
+// place of the task kill_signal, if any.
+func (d *DesiredTransition) KillSignalOverride() (string, bool) {
+	if d == nil || d.KillSignal == nil || *d.KillSignal == "" {
+		return "", false
+	}
+	return *d.KillSignal, true
+}
+
+// KillTimeoutOverride returns the kill timeout the transition object dictates
+// as a cap of the task kill_timeout, if any.
+func (d *DesiredTransition) KillTimeoutOverride() (time.Duration, bool) {
+	if d == nil || d.KillTimeout == nil {
+		return 0, false
+	}
+	return *d.KillTimeout, true
+}
+
 const (
 	AllocDesiredStatusRun   = "run"   // Allocation should run
 	AllocDesiredStatusStop  = "stop"  // Allocation should stop
@@ -11330,7 +13009,7 @@ func (a *Allocation) NextRescheduleTime() (time.Time, bool) {
 
 func (a *Allocation) nextRescheduleTime(failTime time.Time, reschedulePolicy *ReschedulePolicy) (time.Time, bool) {
 	nextDelay := a.NextDelay()
-	nextRescheduleTime := failTime.Add(nextDelay)
+	nextRescheduleTime := failTime.Add(reschedulePolicy.jitterDelay(nextDelay, a.ID))
 	rescheduleEligible := reschedulePolicy.Unlimited || (reschedulePolicy.Attempts > 0 && a.RescheduleTracker == nil)
 	if reschedulePolicy.Attempts > 0 && a.RescheduleTracker != nil && a.RescheduleTracker.Events != nil {
 		// Check for eligibility based on the interval if max attempts is set
@@ -11872,6 +13551,10 @@ type AllocMetric struct {
 	// QuotaExhausted provides the exhausted dimensions
 	QuotaExhausted []string
 
+	// GangFailed provides the task groups of the gang that failed to be
+	// placed, preventing the placement of this task group
+	GangFailed []string
+
 	// ResourcesExhausted provides the amount of resources exhausted by task
 	// during the allocation placement
 	ResourcesExhausted map[string]*Resources
@@ -11915,6 +13598,7 @@ func (a *AllocMetric) Copy() *AllocMetric {
 	na.ClassExhausted = maps.Clone(na.ClassExhausted)
 	na.DimensionExhausted = maps.Clone(na.DimensionExhausted)
 	na.QuotaExhausted = slices.Clone(na.QuotaExhausted)
+	na.GangFailed = slices.Clone(na.GangFailed)
 	na.Scores = maps.Clone(na.Scores)
 	na.ScoreMetaData = CopySliceNodeScoreMeta(na.ScoreMetaData)
 	return na
@@ -12083,6 +13767,10 @@ type AllocNetworkStatus struct {
 	Address       string
 	AddressIPv6   string
 	DNS           *DNSConfig
+
+	// Interfaces are the interfaces attached by the additional CNI networks
+	// of the group network, in the order the networks are defined.
+	Interfaces []*AllocNetworkInterface
 }
 
 func (a *AllocNetworkStatus) Copy() *AllocNetworkStatus {
@@ -12094,6 +13782,7 @@ func (a *AllocNetworkStatus) Copy() *AllocNetworkStatus {
 		Address:       a.Address,
 		AddressIPv6:   a.AddressIPv6,
 		DNS:           a.DNS.Copy(),
+		Interfaces:    helper.CopySlice(a.Interfaces),
 	}
 }
 
@@ -12117,6 +13806,8 @@ func (a *AllocNetworkStatus) Equal(o *AllocNetworkStatus) bool {
 		return false
 	case !a.DNS.Equal(o.DNS):
 		return false
+	case !slices.EqualFunc(a.Interfaces, o.Interfaces, (*AllocNetworkInterface).Equal):
+		return false
 	}
 	return true
 }
@@ -12134,6 +13825,31 @@ func (a *AllocNetworkStatus) IsZero() bool {
 	return true
 }
 
+// AllocNetworkInterface is the status of an interface attached to the network
+// namespace of an allocation by one of the additional CNI networks of its
+// group network.
+type AllocNetworkInterface struct {
+	Network       string
+	InterfaceName string
+	Address       string
+	AddressIPv6   string
+}
+
+func (i *AllocNetworkInterface) Copy() *AllocNetworkInterface {
+	if i == nil {
+		return nil
+	}
+	ni := *i
+	return &ni
+}
+
+func (i *AllocNetworkInterface) Equal(o *AllocNetworkInterface) bool {
+	if i == nil || o == nil {
+		return i == o
+	}
+	return *i == *o
+}
+
 // NetworkStatus is an interface satisfied by alloc runner, for acquiring the
 // network status of an allocation.
 type NetworkStatus interface {
@@ -12254,6 +13970,9 @@ const (
 	EvalTriggerScaling              = "job-scaling"
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
+	EvalTriggerJobDependency        = "job-dependency"
+	EvalTriggerDispatchQueue        = "dispatch-queue"
+	EvalTriggerDecommissionDelay    = "decommission-delay"
 )
 
 const (
@@ -13374,10 +15093,26 @@ type ACLToken struct {
 	ExpirationTime *time.Time
 
 	// ExpirationTTL is a convenience field for helping set ExpirationTime to a
-	// value of CreateTime+ExpirationTTL. This can only be set during token
-	// creation. This is a string version of a time.Duration like "2m".
+	// value of CreateTime+ExpirationTTL, or NotBefore+ExpirationTTL if the
+	// token has a NotBefore time. This can only be set during token creation.
+	// This is a string version of a time.Duration like "2m".
 	ExpirationTTL time.Duration
 
+	// NotBefore represents the point before which the token should not be
+	// accepted. This time should always use UTC and can only be set during
+	// token creation.
+	NotBefore *time.Time
+
+	// AllowedCIDRs restricts the source addresses of the HTTP API requests
+	// that use the token. The token can be used from any address if empty.
+	AllowedCIDRs []string
+
+	// AuthMethod and AuthSubject identify the auth method and the subject of
+	// the identity that logged in to create the token. They are empty for
+	// tokens created directly.
+	AuthMethod  string
+	AuthSubject string
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -13412,6 +15147,8 @@ func (a *ACLToken) Copy() *ACLToken {
 	c.Roles = make([]*ACLTokenRoleLink, len(a.Roles))
 	copy(c.Roles, a.Roles)
 
+	c.AllowedCIDRs = slices.Clone(a.AllowedCIDRs)
+
 	return c
 }
 
@@ -13453,6 +15190,8 @@ type ACLTokenListStub struct {
 	Hash           []byte
 	CreateTime     time.Time
 	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	AllowedCIDRs   []string
 	CreateIndex    uint64
 	ModifyIndex    uint64
 }
@@ -13486,6 +15225,10 @@ func (a *ACLToken) SetHash() []byte {
 		_, _ = hash.Write([]byte(roleLink.ID))
 	}
 
+	for _, cidr := range a.AllowedCIDRs {
+		_, _ = hash.Write([]byte(cidr))
+	}
+
 	// Finalize the hash
 	hashVal := hash.Sum(nil)
 
@@ -13505,6 +15248,8 @@ func (a *ACLToken) Stub() (*ACLTokenListStub, error) {
 		Hash:           a.Hash,
 		CreateTime:     a.CreateTime,
 		ExpirationTime: a.ExpirationTime,
+		NotBefore:      a.NotBefore,
+		AllowedCIDRs:   a.AllowedCIDRs,
 		CreateIndex:    a.CreateIndex,
 		ModifyIndex:    a.ModifyIndex,
 	}, nil
@@ -1754,6 +1754,17 @@ func TestTaskGroup_Validate(t *testing.T) {
 			},
 			jobType: JobTypeService,
 		},
+		{
+			name: "gang in system job",
+			tg: &TaskGroup{
+				Name: "group-a",
+				Gang: "training",
+			},
+			expErr: []string{
+				"Gang scheduling is not supported for system jobs",
+			},
+			jobType: JobTypeSystem,
+		},
 	}
 
 	for _, tc := range tests {
@@ -1778,6 +1789,102 @@ func TestTaskGroupNetwork_Validate(t *testing.T) {
 		TG          *TaskGroup
 		ErrContains string
 	}{
+		{
+			TG: &TaskGroup{
+				Name: "group-bandwidth-ok",
+				Networks: Networks{
+					&NetworkResource{Mode: "bridge", EgressMBits: 100, IngressMBits: 50},
+				},
+			},
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-bandwidth-host-mode",
+				Networks: Networks{
+					&NetworkResource{Mode: "host", EgressMBits: 100},
+				},
+			},
+			ErrContains: "only supported in bridge network mode",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-bandwidth-negative",
+				Networks: Networks{
+					&NetworkResource{Mode: "bridge", IngressMBits: -1},
+				},
+			},
+			ErrContains: "Bandwidth limits cannot be negative",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-cni-networks-ok",
+				Networks: Networks{
+					&NetworkResource{Mode: "cni/primary", CNINetworks: []string{"storage", "sriov"}},
+				},
+			},
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-cni-networks-host-mode",
+				Networks: Networks{
+					&NetworkResource{Mode: "host", CNINetworks: []string{"storage"}},
+				},
+			},
+			ErrContains: "only supported in bridge and cni network modes",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-cni-networks-duplicate",
+				Networks: Networks{
+					&NetworkResource{Mode: "bridge", CNINetworks: []string{"storage", "storage"}},
+				},
+			},
+			ErrContains: `Additional CNI network "storage" is defined more than once`,
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-cni-networks-primary",
+				Networks: Networks{
+					&NetworkResource{Mode: "cni/storage", CNINetworks: []string{"storage"}},
+				},
+			},
+			ErrContains: `Additional CNI network "storage" is already the network mode`,
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-static-address-ok",
+				Networks: Networks{
+					&NetworkResource{Mode: "bridge", IPv4Address: "172.26.64.10", IPv6Address: "fd00::10"},
+				},
+			},
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-static-address-host-mode",
+				Networks: Networks{
+					&NetworkResource{Mode: "host", IPv4Address: "172.26.64.10"},
+				},
+			},
+			ErrContains: "Static addresses are only supported in bridge and cni network modes",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-static-address-wrong-family",
+				Networks: Networks{
+					&NetworkResource{Mode: "cni/mynet", IPv4Address: "fd00::10"},
+				},
+			},
+			ErrContains: `Invalid IPv4 address "fd00::10"`,
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-static-address-invalid",
+				Networks: Networks{
+					&NetworkResource{Mode: "bridge", IPv6Address: "fd00::10/64"},
+				},
+			},
+			ErrContains: `Invalid IPv6 address "fd00::10/64"`,
+		},
 		{
 			TG: &TaskGroup{
 				Name: "group-static-value-ok",
@@ -2211,6 +2318,28 @@ func TestTask_Validate(t *testing.T) {
 		"task level: distinct_hosts",
 		"task level: distinct_property",
 	)
+	task.Constraints = nil
+
+	task.Identities = []*WorkloadIdentity{
+		{Name: "s3", AWS: &WorkloadIdentityAWS{RoleARN: "arn:aws:iam::123456789012:role/s3", Profile: "default"}},
+		{Name: "sqs", AWS: &WorkloadIdentityAWS{RoleARN: "arn:aws:iam::123456789012:role/sqs", Profile: "default"}},
+	}
+	err = task.Validate(JobTypeBatch, tg)
+	requireErrors(t, err,
+		`Identities "s3" and "sqs" use the same aws profile "default"`,
+	)
+	task.Identities = nil
+
+	task.Secrets = []*Secret{
+		{Name: "db", Path: "db/creds"},
+		{Name: "db", Path: "db/other"},
+		{Name: "tls"},
+	}
+	err = task.Validate(JobTypeBatch, tg)
+	requireErrors(t, err,
+		"Secret db defined multiple times",
+		"Secret tls validation failed",
+	)
 }
 
 func TestTask_Validate_Resources(t *testing.T) {
@@ -2302,6 +2431,24 @@ func TestTask_Validate_Resources(t *testing.T) {
 				MemoryMaxMB: -1,
 			},
 		},
+		{
+			name: "too little cpu max",
+			res: &Resources{
+				CPU:      100,
+				CPUMax:   50,
+				MemoryMB: 200,
+			},
+			err: "CPUMax value (50) should be larger than CPU value (100)",
+		},
+		{
+			name: "cpu max with cores",
+			res: &Resources{
+				Cores:    2,
+				CPUMax:   500,
+				MemoryMB: 200,
+			},
+			err: "Task can't ask for 'cpu_max' with 'cores' resource.",
+		},
 		{
 			name: "numa devices do not match",
 			res: &Resources{
@@ -3160,6 +3307,21 @@ func TestTask_Validate_LogConfig(t *testing.T) {
 	require.Error(t, err, "log storage")
 }
 
+func TestLogConfig_Validate_Format(t *testing.T) {
+	ci.Parallel(t)
+
+	disk := &EphemeralDisk{SizeMB: 300}
+	for _, format := range []string{"", LogFormatText, LogFormatJSON} {
+		l := DefaultLogConfig()
+		l.Format = format
+		must.NoError(t, l.Validate(disk))
+	}
+
+	l := DefaultLogConfig()
+	l.Format = "xml"
+	must.ErrorContains(t, l.Validate(disk), `log format "xml" must be "text" or "json"`)
+}
+
 func TestLogConfig_Equals(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3194,6 +3356,60 @@ func TestLogConfig_Equals(t *testing.T) {
 	})
 }
 
+func TestLogSink_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		sink   *LogSink
+		expErr string
+	}{
+		{
+			name: "syslog",
+			sink: &LogSink{Type: LogSinkTypeSyslog, Address: "udp://127.0.0.1:514"},
+		},
+		{
+			name:   "syslog bad scheme",
+			sink:   &LogSink{Type: LogSinkTypeSyslog, Address: "127.0.0.1:514"},
+			expErr: "must use the udp, tcp, unix, or unixgram scheme",
+		},
+		{
+			name: "fluentd",
+			sink: &LogSink{Type: LogSinkTypeFluentd, Address: "127.0.0.1:24224"},
+		},
+		{
+			name:   "fluentd no address",
+			sink:   &LogSink{Type: LogSinkTypeFluentd},
+			expErr: "requires an address",
+		},
+		{
+			name: "file",
+			sink: &LogSink{Type: LogSinkTypeFile, Path: "web.json"},
+		},
+		{
+			name:   "file escapes log dir",
+			sink:   &LogSink{Type: LogSinkTypeFile, Path: "../web.json"},
+			expErr: "must be relative to the log directory",
+		},
+		{
+			name:   "unknown type",
+			sink:   &LogSink{Type: "journald"},
+			expErr: "unknown log sink type",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.sink.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
 func TestTask_Validate_CSIPluginConfig(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3300,6 +3516,26 @@ func TestTask_Validate_Template(t *testing.T) {
 	if expected := "cannot use signals"; !strings.Contains(err.Error(), expected) {
 		t.Errorf("expected to find %q but found %v", expected, err)
 	}
+
+	// The firecracker driver only supports change modes that don't signal
+	// the task or execute commands in it
+	task.Driver = "firecracker"
+	for _, mode := range []string{TemplateChangeModeSignal, TemplateChangeModeScript} {
+		task.Templates = []*Template{
+			{
+				DestPath:     "local/foo",
+				ChangeMode:   mode,
+				ChangeSignal: "SIGHUP",
+				ChangeScript: &ChangeScript{Command: "/bin/foo"},
+			},
+		}
+		err = task.Validate(JobTypeService, tg)
+		must.ErrorContains(t, err, "is not supported by the firecracker driver")
+	}
+
+	task.Templates = []*Template{{DestPath: "local/foo", ChangeMode: TemplateChangeModeRestart}}
+	err = task.Validate(JobTypeService, tg)
+	must.StrNotContains(t, err.Error(), "firecracker driver")
 }
 
 func TestTemplate_Copy(t *testing.T) {
@@ -3409,6 +3645,144 @@ func TestTemplate_Validate(t *testing.T) {
 				"specify signal value",
 			},
 		},
+		{
+			Tmpl: &Template{
+				ChangeMode: "reload",
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"specify signal value when change mode is reload",
+			},
+		},
+		{
+			Tmpl: &Template{
+				SourcePath:   "foo",
+				DestPath:     "local/foo",
+				ChangeMode:   "reload",
+				ChangeSignal: "SIGHUP",
+			},
+			Fail: false,
+		},
+		{
+			Tmpl: &Template{
+				SourcePath:               "foo",
+				DestPath:                 "local/foo",
+				ChangeMode:               "noop",
+				FirstRenderTimeout:       -1 * time.Second,
+				FirstRenderTimeoutAction: "explode",
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"positive first_render_timeout",
+				"Invalid first_render_timeout_action",
+			},
+		},
+		{
+			Tmpl: &Template{
+				SourcePath:               "foo",
+				DestPath:                 "local/foo",
+				ChangeMode:               "noop",
+				FirstRenderTimeout:       time.Minute,
+				FirstRenderTimeoutAction: "kill",
+			},
+			Fail: false,
+		},
+		{
+			Tmpl: &Template{
+				SourcePath: "foo",
+				DestPath:   "local/foo",
+				ChangeMode: "noop",
+				Checksum:   "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			},
+			Fail: false,
+		},
+		{
+			Tmpl: &Template{
+				SourcePath: "foo",
+				DestPath:   "local/foo",
+				ChangeMode: "noop",
+				Checksum:   "sha256:abcd",
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"invalid sha256 checksum",
+			},
+		},
+		{
+			Tmpl: &Template{
+				SourcePath: "foo",
+				DestPath:   "local/foo",
+				ChangeMode: "exec_restart",
+				Exec: &TemplateExec{
+					Command: "/bin/helper",
+					Args:    []string{"-config", "local/foo"},
+				},
+			},
+			Fail: false,
+		},
+		{
+			Tmpl: &Template{
+				SourcePath: "foo",
+				DestPath:   "local/foo",
+				ChangeMode: "exec_restart",
+				Envvars:    true,
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"must specify exec configuration",
+				"cannot use exec_restart with env var templates",
+			},
+		},
+		{
+			Tmpl: &Template{
+				SourcePath: "foo",
+				DestPath:   "local/foo",
+				ChangeMode: "exec_restart",
+				Exec:       &TemplateExec{},
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"must specify exec command",
+			},
+		},
+		{
+			Tmpl: &Template{
+				SourcePath:   "foo",
+				DestPath:     "local/foo",
+				ChangeMode:   "noop",
+				EnvPrefix:    "APP_",
+				UnsetIfEmpty: true,
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"env_prefix can only be used with env var templates",
+				"unset_if_empty can only be used with env var templates",
+			},
+		},
+		{
+			Tmpl: &Template{
+				SourcePath: "foo",
+				DestPath:   "local/foo",
+				ChangeMode: "noop",
+				Envvars:    true,
+				EnvPrefix:  "1APP-",
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"Invalid env_prefix",
+			},
+		},
+		{
+			Tmpl: &Template{
+				SourcePath:   "foo",
+				DestPath:     "local/foo",
+				ChangeMode:   "restart",
+				Envvars:      true,
+				EnvPrefix:    "APP_",
+				UnsetIfEmpty: true,
+			},
+			Fail: false,
+		},
 		{
 			Tmpl: &Template{
 				SourcePath: "foo",
@@ -3536,6 +3910,21 @@ func TestTemplate_Validate(t *testing.T) {
 	}
 }
 
+func TestTemplate_VerifyChecksum(t *testing.T) {
+	ci.Parallel(t)
+
+	tmpl := &Template{}
+	must.NoError(t, tmpl.VerifyChecksum([]byte("anything")))
+
+	// sha256 of "hello"
+	tmpl.Checksum = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	must.NoError(t, tmpl.VerifyChecksum([]byte("hello")))
+	must.ErrorContains(t, tmpl.VerifyChecksum([]byte("goodbye")), "checksum mismatch")
+
+	tmpl.Checksum = "crc32:1234"
+	must.ErrorContains(t, tmpl.VerifyChecksum([]byte("hello")), "unsupported checksum type")
+}
+
 func TestTaskWaitConfig_Equals(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3800,6 +4189,210 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 	)
 }
 
+func TestUpdateStrategy_Validate_Verify(t *testing.T) {
+	ci.Parallel(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.Verify = &UpdateVerify{
+		Type:     UpdateVerifyTypePrometheus,
+		Address:  "prometheus.service.consul:9090",
+		Interval: -1,
+	}
+
+	err := u.Validate()
+	requireErrors(t, err,
+		"Verify requires Auto Promote",
+		"Query must be set",
+		"Address must be an absolute URL",
+		"Checks must be greater than zero",
+		"Interval must be greater than zero",
+		"Timeout must be greater than zero",
+	)
+
+	u.Canary = 1
+	u.AutoPromote = true
+	u.Verify = &UpdateVerify{
+		Type:     UpdateVerifyTypePrometheus,
+		Address:  "http://prometheus.service.consul:9090",
+		Query:    "sum(rate(http_errors[1m])) < 1",
+		Checks:   3,
+		Interval: 10 * time.Second,
+		Timeout:  5 * time.Second,
+	}
+	must.NoError(t, u.Validate())
+
+	c := u.Copy()
+	c.Verify.Checks = 1
+	must.Eq(t, 3, u.Verify.Checks)
+	must.False(t, c.Verify.Equal(u.Verify))
+}
+
+func TestUpdateStrategy_Validate_CanarySteps(t *testing.T) {
+	ci.Parallel(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.Canary = 1
+	u.CanarySteps = []int{0, 50, 50, 100}
+	u.BakeTime = -1
+
+	err := u.Validate()
+	requireErrors(t, err,
+		"Canary count can't be set with canary steps",
+		"Canary step 1 must be a percentage between 1 and 99",
+		"Canary step 3 must be greater than the previous step",
+		"Canary step 4 must be a percentage between 1 and 99",
+		"Bake time may not be less than zero",
+	)
+
+	u = DefaultUpdateStrategy.Copy()
+	u.BakeTime = time.Minute
+	requireErrors(t, u.Validate(), "Bake time requires canaries")
+
+	u.CanarySteps = []int{10, 25, 50}
+	u.AutoPromote = true
+	must.NoError(t, u.Validate())
+
+	c := u.Copy()
+	c.CanarySteps[0] = 5
+	must.Eq(t, 10, u.CanarySteps[0])
+}
+
+func TestUpdateStrategy_Validate_DecommissionDelay(t *testing.T) {
+	ci.Parallel(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.DecommissionDelay = -1
+	requireErrors(t, u.Validate(), "Decommission delay may not be less than zero")
+
+	u.DecommissionDelay = 10 * time.Minute
+	requireErrors(t, u.Validate(), "Decommission delay requires canaries")
+
+	u.Canary = 3
+	must.NoError(t, u.Validate())
+}
+
+func TestDesiredTransition_ShouldDecommission(t *testing.T) {
+	ci.Parallel(t)
+
+	var d DesiredTransition
+	must.False(t, d.ShouldDecommission())
+
+	d.Merge(&DesiredTransition{Decommission: pointer.Of(true)})
+	must.True(t, d.ShouldDecommission())
+
+	d.Merge(&DesiredTransition{Migrate: pointer.Of(true)})
+	must.True(t, d.ShouldDecommission())
+
+	d.Merge(&DesiredTransition{Decommission: pointer.Of(false)})
+	must.False(t, d.ShouldDecommission())
+}
+
+func TestDesiredTransition_KillOverrides(t *testing.T) {
+	ci.Parallel(t)
+
+	var d DesiredTransition
+	_, ok := d.KillSignalOverride()
+	must.False(t, ok)
+	_, ok = d.KillTimeoutOverride()
+	must.False(t, ok)
+
+	d.Merge(&DesiredTransition{
+		KillSignal:  pointer.Of("SIGINT"),
+		KillTimeout: pointer.Of(10 * time.Second),
+	})
+	d.Merge(&DesiredTransition{Migrate: pointer.Of(true)})
+
+	signal, ok := d.KillSignalOverride()
+	must.True(t, ok)
+	must.Eq(t, "SIGINT", signal)
+	timeout, ok := d.KillTimeoutOverride()
+	must.True(t, ok)
+	must.Eq(t, 10*time.Second, timeout)
+}
+
+func TestDrainStrategy_JobTypeDeadlineTime(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	d := &DrainStrategy{
+		DrainSpec: DrainSpec{
+			Deadline: time.Hour,
+			JobTypeDeadlines: map[string]time.Duration{
+				JobTypeBatch:   2 * time.Hour,
+				JobTypeSystem:  0,
+				JobTypeService: -1,
+			},
+			KillPolicy: &DrainKillPolicy{Signal: "SIGINT", Timeout: time.Minute},
+		},
+	}
+	d.SetForceDeadlines(now)
+
+	inf, deadline := d.JobTypeDeadlineTime(JobTypeSysBatch)
+	must.False(t, inf)
+	must.Eq(t, now.Add(time.Hour), deadline)
+
+	inf, deadline = d.JobTypeDeadlineTime(JobTypeBatch)
+	must.False(t, inf)
+	must.Eq(t, now.Add(2*time.Hour), deadline)
+
+	inf, _ = d.JobTypeDeadlineTime(JobTypeSystem)
+	must.True(t, inf)
+
+	inf, deadline = d.JobTypeDeadlineTime(JobTypeService)
+	must.False(t, inf)
+	must.True(t, deadline.IsZero())
+
+	// job types without a force deadline fall back to the drain's
+	withoutForce := d.Copy()
+	withoutForce.JobTypeForceDeadlines = nil
+	inf, deadline = withoutForce.JobTypeDeadlineTime(JobTypeBatch)
+	must.False(t, inf)
+	must.Eq(t, now.Add(time.Hour), deadline)
+
+	c := d.Copy()
+	must.True(t, d.Equal(c))
+	c.JobTypeDeadlines[JobTypeBatch] = 3 * time.Hour
+	c.KillPolicy.Timeout = 0
+	must.Eq(t, 2*time.Hour, d.JobTypeDeadlines[JobTypeBatch])
+	must.Eq(t, time.Minute, d.KillPolicy.Timeout)
+	must.False(t, d.Equal(c))
+}
+
+func TestDrainSpec_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &DrainSpec{
+		JobTypeDeadlines: map[string]time.Duration{JobTypeBatch: time.Hour},
+		KillPolicy:       &DrainKillPolicy{Signal: "SIGINT", Timeout: time.Minute},
+	}
+	must.NoError(t, d.Validate())
+
+	d.JobTypeDeadlines["unknown"] = time.Hour
+	d.KillPolicy.Signal = "SIGNOPE"
+	d.KillPolicy.Timeout = -1
+	err := d.Validate()
+	must.ErrorContains(t, err, `invalid job type "unknown"`)
+	must.ErrorContains(t, err, `invalid drain kill signal "SIGNOPE"`)
+	must.ErrorContains(t, err, "kill timeout must not be negative")
+}
+
+func TestUpdateStrategy_CanaryCount(t *testing.T) {
+	ci.Parallel(t)
+
+	var u *UpdateStrategy
+	must.Eq(t, 0, u.CanaryCount(10, 0))
+
+	u = &UpdateStrategy{Canary: 2}
+	must.Eq(t, 2, u.CanaryCount(10, 3))
+
+	// Each step places at least one canary, rounding up
+	u = &UpdateStrategy{CanarySteps: []int{5, 25, 50}}
+	must.Eq(t, 1, u.CanaryCount(10, 0))
+	must.Eq(t, 3, u.CanaryCount(10, 1))
+	must.Eq(t, 5, u.CanaryCount(10, 2))
+	must.Eq(t, 5, u.CanaryCount(10, 3))
+}
+
 func TestResource_NetIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4023,6 +4616,25 @@ func TestMemoryResources_Add(t *testing.T) {
 	}, r)
 }
 
+func TestResources_Burstable(t *testing.T) {
+	ci.Parallel(t)
+
+	must.False(t, (&Resources{CPU: 100, MemoryMB: 100}).Burstable())
+	must.True(t, (&Resources{CPU: 100, CPUMax: 200, MemoryMB: 100}).Burstable())
+	must.True(t, (&Resources{CPU: 100, MemoryMB: 100, MemoryMaxMB: 200}).Burstable())
+	must.True(t, (&Resources{CPU: 100, MemoryMB: 100, MemoryMaxMB: -1}).Burstable())
+
+	var allocated *AllocatedTaskResources
+	must.False(t, allocated.Burstable())
+	allocated = &AllocatedTaskResources{
+		Cpu:    AllocatedCpuResources{CpuShares: 100},
+		Memory: AllocatedMemoryResources{MemoryMB: 100},
+	}
+	must.False(t, allocated.Burstable())
+	allocated.Cpu.CpuMax = 200
+	must.True(t, allocated.Burstable())
+}
+
 func TestNodeNetworkResource_Copy(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4595,6 +5207,158 @@ func TestPeriodicConfig_DST(t *testing.T) {
 	require.Equal(e2, n2.UTC())
 }
 
+func TestPeriodicConfig_Concurrency(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name            string
+		policy          string
+		prohibitOverlap bool
+		expected        string
+		err             string
+	}{
+		{name: "default", expected: PeriodicConcurrencyAllow},
+		{name: "prohibit overlap", prohibitOverlap: true, expected: PeriodicConcurrencyForbid},
+		{name: "replace", policy: PeriodicConcurrencyReplace, expected: PeriodicConcurrencyReplace},
+		{
+			name:            "conflicting prohibit overlap",
+			policy:          PeriodicConcurrencyReplace,
+			prohibitOverlap: true,
+			err:             "Prohibit overlap can only be used",
+		},
+		{name: "invalid", policy: "queue", err: `Invalid concurrency policy "queue"`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &PeriodicConfig{
+				Enabled:           true,
+				SpecType:          PeriodicSpecCron,
+				Spec:              "@hourly",
+				ConcurrencyPolicy: c.policy,
+				ProhibitOverlap:   c.prohibitOverlap,
+			}
+			p.Canonicalize()
+			err := p.Validate()
+			if c.err != "" {
+				must.ErrorContains(t, err, c.err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, c.expected, p.ConcurrencyPolicy)
+			must.Eq(t, c.expected == PeriodicConcurrencyForbid, p.ProhibitOverlap)
+		})
+	}
+
+	// Jobs registered before the concurrency policy forbid overlapping
+	// children if they prohibit overlap
+	p := &PeriodicConfig{ProhibitOverlap: true}
+	must.Eq(t, PeriodicConcurrencyForbid, p.Concurrency())
+
+	p = &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "@hourly", HistoryLimit: -1}
+	must.ErrorContains(t, p.Validate(), "History limit must not be negative")
+}
+
+func TestPeriodicConfig_Prohibit(t *testing.T) {
+	ci.Parallel(t)
+
+	// Weekends in Paris, from Saturday midnight to Monday midnight
+	weekend := &PeriodicProhibit{Start: "0 0 * * SAT", Duration: 48 * time.Hour}
+	paris, err := time.LoadLocation("Europe/Paris")
+	must.NoError(t, err)
+
+	// Friday 23:30 in Paris
+	from := time.Date(2024, time.March, 8, 23, 30, 0, 0, paris)
+
+	cases := []struct {
+		name     string
+		specs    []string
+		prohibit []*PeriodicProhibit
+		next     time.Time
+	}{
+		{
+			name:  "no windows",
+			specs: []string{"0 * * * *"},
+			next:  time.Date(2024, time.March, 9, 0, 0, 0, 0, paris),
+		},
+		{
+			name:     "skip",
+			specs:    []string{"0 * * * *"},
+			prohibit: []*PeriodicProhibit{weekend},
+			next:     time.Date(2024, time.March, 11, 0, 0, 0, 0, paris),
+		},
+		{
+			name:  "defer",
+			specs: []string{"30 6 * * SAT", "0 12 * * MON"},
+			prohibit: []*PeriodicProhibit{{
+				Start: "0 0 * * SAT", Duration: 48 * time.Hour, Action: PeriodicProhibitActionDefer,
+			}},
+			next: time.Date(2024, time.March, 11, 0, 0, 0, 0, paris),
+		},
+		{
+			name:     "skip to next cron",
+			specs:    []string{"30 6 * * SAT", "0 12 * * MON"},
+			prohibit: []*PeriodicProhibit{weekend},
+			next:     time.Date(2024, time.March, 11, 12, 0, 0, 0, paris),
+		},
+		{
+			name:  "overlapping windows",
+			specs: []string{"0 * * * *"},
+			prohibit: []*PeriodicProhibit{
+				weekend,
+				{Start: "0 0 * * MON", Duration: 2 * time.Hour, Action: PeriodicProhibitActionDefer},
+			},
+			next: time.Date(2024, time.March, 11, 2, 0, 0, 0, paris),
+		},
+		{
+			name:     "outside of windows",
+			specs:    []string{"45 23 * * *"},
+			prohibit: []*PeriodicProhibit{weekend},
+			next:     time.Date(2024, time.March, 8, 23, 45, 0, 0, paris),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &PeriodicConfig{
+				Enabled:  true,
+				SpecType: PeriodicSpecCron,
+				Specs:    c.specs,
+				TimeZone: "Europe/Paris",
+				Prohibit: c.prohibit,
+			}
+			p.Canonicalize()
+			must.NoError(t, p.Validate())
+
+			next, err := p.Next(from)
+			must.NoError(t, err)
+			must.Eq(t, c.next, next)
+		})
+	}
+}
+
+func TestPeriodicConfig_Prohibit_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "@hourly",
+		Prohibit: []*PeriodicProhibit{
+			{Start: "0 0 * * SAT", Duration: time.Hour},
+			{Start: "foo", Action: "postpone"},
+		},
+	}
+	p.Canonicalize()
+	must.Eq(t, PeriodicProhibitActionSkip, p.Prohibit[0].Action)
+
+	err := p.Validate()
+	must.ErrorContains(t, err, "Prohibit window 2 validation failed")
+	must.ErrorContains(t, err, `Invalid start cron spec "foo"`)
+	must.ErrorContains(t, err, "Duration must be greater than zero")
+	must.ErrorContains(t, err, `Invalid action "postpone"`)
+	must.StrNotContains(t, err.Error(), "Prohibit window 1")
+}
+
 func TestTaskLifecycleConfig_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4619,6 +5383,21 @@ func TestTaskLifecycleConfig_Validate(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "prestart init",
+			tlc: &TaskLifecycleConfig{
+				Hook: "prestart_init",
+			},
+			err: nil,
+		},
+		{
+			name: "prestart init sidecar",
+			tlc: &TaskLifecycleConfig{
+				Hook:    "prestart_init",
+				Sidecar: true,
+			},
+			err: fmt.Errorf("prestart_init tasks cannot be sidecars"),
+		},
 		{
 			name: "no hook",
 			tlc: &TaskLifecycleConfig{
@@ -4626,6 +5405,32 @@ func TestTaskLifecycleConfig_Validate(t *testing.T) {
 			},
 			err: fmt.Errorf("no lifecycle hook provided"),
 		},
+		{
+			name: "sidecar drain delay",
+			tlc: &TaskLifecycleConfig{
+				Hook:       "poststart",
+				Sidecar:    true,
+				DrainDelay: 10 * time.Second,
+			},
+			err: nil,
+		},
+		{
+			name: "drain delay without sidecar",
+			tlc: &TaskLifecycleConfig{
+				Hook:       "prestart",
+				DrainDelay: 10 * time.Second,
+			},
+			err: fmt.Errorf("drain delay can only be set on sidecar tasks"),
+		},
+		{
+			name: "negative drain delay",
+			tlc: &TaskLifecycleConfig{
+				Hook:       "prestart",
+				Sidecar:    true,
+				DrainDelay: -1,
+			},
+			err: fmt.Errorf("drain delay may not be less than zero"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4642,6 +5447,19 @@ func TestTaskLifecycleConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestRestartBudget_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	b := &RestartBudget{Attempts: 10, Interval: 10 * time.Minute}
+	must.NoError(t, b.Validate())
+
+	b = &RestartBudget{Interval: time.Second}
+	requireErrors(t, b.Validate(),
+		"Restart budget attempts must be greater than zero",
+		"Restart budget interval can not be less than",
+	)
+}
+
 func TestRestartPolicy_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4873,6 +5691,32 @@ func TestReschedulePolicy_Validate(t *testing.T) {
 				MaxDelay:      1 * time.Hour,
 			},
 		},
+		{
+			desc: "Valid jitter and avoid node window",
+			ReschedulePolicy: &ReschedulePolicy{
+				Unlimited:       true,
+				DelayFunction:   "exponential",
+				Delay:           5 * time.Second,
+				MaxDelay:        1 * time.Hour,
+				Jitter:          0.5,
+				AvoidNodeWindow: 30 * time.Minute,
+			},
+		},
+		{
+			desc: "Invalid jitter and avoid node window",
+			ReschedulePolicy: &ReschedulePolicy{
+				Unlimited:       true,
+				DelayFunction:   "exponential",
+				Delay:           5 * time.Second,
+				MaxDelay:        1 * time.Hour,
+				Jitter:          1,
+				AvoidNodeWindow: -1,
+			},
+			errors: []error{
+				fmt.Errorf("Jitter must be between 0 and 1 (got %v)", 1),
+				fmt.Errorf("Avoid node window may not be less than zero: %v", time.Duration(-1)),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4891,6 +5735,48 @@ func TestReschedulePolicy_Validate(t *testing.T) {
 	}
 }
 
+func TestReschedulePolicy_Jitter(t *testing.T) {
+	ci.Parallel(t)
+
+	policy := &ReschedulePolicy{Delay: time.Minute}
+	must.Eq(t, time.Minute, policy.jitterDelay(time.Minute, "foo"))
+
+	// The delay is shortened by at most the jitter, and is stable for the
+	// same allocation
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		id := fmt.Sprintf("alloc-%d", i)
+		delay := policy.jitterDelay(time.Minute, id)
+		must.Between(t, 30*time.Second, delay, time.Minute)
+		must.Eq(t, delay, policy.jitterDelay(time.Minute, id))
+	}
+}
+
+func TestReschedulePolicy_AvoidedNodes(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	alloc := &Allocation{
+		NodeID:       "node3",
+		ClientStatus: AllocClientStatusFailed,
+		RescheduleTracker: &RescheduleTracker{
+			Events: []*RescheduleEvent{
+				{RescheduleTime: now.Add(-2 * time.Hour).UnixNano(), PrevNodeID: "node1"},
+				{RescheduleTime: now.Add(-10 * time.Minute).UnixNano(), PrevNodeID: "node2"},
+			},
+		},
+	}
+
+	var policy *ReschedulePolicy
+	must.MapEmpty(t, policy.AvoidedNodes(alloc, now))
+
+	policy = &ReschedulePolicy{}
+	must.MapEmpty(t, policy.AvoidedNodes(alloc, now))
+
+	policy.AvoidNodeWindow = time.Hour
+	must.Eq(t, map[string]struct{}{"node2": {}, "node3": {}}, policy.AvoidedNodes(alloc, now))
+}
+
 func TestAllocation_ReservedCores(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4980,6 +5866,114 @@ func TestTaskArtifact_Validate_Dest(t *testing.T) {
 	}
 }
 
+func TestTaskArtifact_Validate_Verify(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		verify *TaskArtifactVerify
+		expErr string
+	}{
+		{
+			name: "gpg",
+			verify: &TaskArtifactVerify{
+				Type:      ArtifactVerifyTypeGPG,
+				Signature: "https://example.com/app.tar.gz.asc",
+				PublicKey: "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+			},
+		},
+		{
+			name: "cosign",
+			verify: &TaskArtifactVerify{
+				Type:      ArtifactVerifyTypeCosign,
+				Signature: "https://example.com/app.tar.gz.sig",
+				PublicKey: "-----BEGIN PUBLIC KEY-----",
+			},
+		},
+		{
+			name: "cosign keyless",
+			verify: &TaskArtifactVerify{
+				Type:                  ArtifactVerifyTypeCosign,
+				Bundle:                "https://example.com/app.tar.gz.bundle",
+				CertificateIdentity:   "release@example.com",
+				CertificateOIDCIssuer: "https://accounts.google.com",
+			},
+		},
+		{
+			name: "no signature",
+			verify: &TaskArtifactVerify{
+				Type:      ArtifactVerifyTypeGPG,
+				PublicKey: "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+			},
+			expErr: "signature must be specified",
+		},
+		{
+			name: "cosign without signature",
+			verify: &TaskArtifactVerify{
+				Type:      ArtifactVerifyTypeCosign,
+				PublicKey: "-----BEGIN PUBLIC KEY-----",
+			},
+			expErr: "signature must be specified",
+		},
+		{
+			name: "cosign without key or bundle",
+			verify: &TaskArtifactVerify{
+				Type:      ArtifactVerifyTypeCosign,
+				Signature: "https://example.com/app.tar.gz.sig",
+			},
+			expErr: "either signature and public_key or bundle",
+		},
+		{
+			name: "cosign keyless without identity",
+			verify: &TaskArtifactVerify{
+				Type:   ArtifactVerifyTypeCosign,
+				Bundle: "https://example.com/app.tar.gz.bundle",
+			},
+			expErr: "either signature and public_key or bundle",
+		},
+		{
+			name: "cosign with key and bundle",
+			verify: &TaskArtifactVerify{
+				Type:      ArtifactVerifyTypeCosign,
+				Signature: "https://example.com/app.tar.gz.sig",
+				PublicKey: "-----BEGIN PUBLIC KEY-----",
+				Bundle:    "https://example.com/app.tar.gz.bundle",
+			},
+			expErr: "mutually exclusive",
+		},
+		{
+			name: "gpg with bundle",
+			verify: &TaskArtifactVerify{
+				Type:      ArtifactVerifyTypeGPG,
+				Signature: "https://example.com/app.tar.gz.asc",
+				PublicKey: "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+				Bundle:    "https://example.com/app.tar.gz.bundle",
+			},
+			expErr: "only supported for cosign signatures",
+		},
+		{
+			name: "invalid type",
+			verify: &TaskArtifactVerify{
+				Type:      "minisign",
+				Signature: "https://example.com/app.tar.gz.minisig",
+			},
+			expErr: "invalid type",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			artifact := &TaskArtifact{GetterSource: "https://example.com/app.tar.gz", Verify: tc.verify}
+			err := artifact.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
 // TestTaskArtifact_Hash asserts an artifact's hash changes when any of the
 // fields change.
 func TestTaskArtifact_Hash(t *testing.T) {
@@ -6407,6 +7401,25 @@ func TestVault_Validate(t *testing.T) {
 	}
 }
 
+func TestVault_Validate_SecretRotation(t *testing.T) {
+	ci.Parallel(t)
+
+	v := &Vault{
+		ChangeMode:     VaultChangeModeRestart,
+		SecretRotation: &VaultSecretRotation{},
+	}
+	must.NoError(t, v.Validate())
+
+	v.SecretRotation.DrainScript = &ChangeScript{Command: "/bin/drain", Timeout: 5 * time.Second}
+	must.NoError(t, v.Validate())
+
+	v.SecretRotation.DrainScript.Timeout = -1
+	must.ErrorContains(t, v.Validate(), "drain_script timeout must not be negative")
+
+	v.SecretRotation.DrainScript.Command = ""
+	must.ErrorContains(t, v.Validate(), "drain_script must specify a command")
+}
+
 func TestVault_Copy(t *testing.T) {
 	v := &Vault{
 		Namespace:    "ns1",
@@ -6423,6 +7436,14 @@ func TestVault_Copy(t *testing.T) {
 	vc.ChangeSignal = "SIGHUP"
 
 	require.NotEqual(t, v, vc)
+
+	// The secret rotation block is deep copied.
+	v.SecretRotation = &VaultSecretRotation{
+		DrainScript: &ChangeScript{Command: "/bin/drain", Args: []string{"-a"}},
+	}
+	vc = v.Copy()
+	vc.SecretRotation.DrainScript.Args[0] = "-b"
+	must.Eq(t, "-a", v.SecretRotation.DrainScript.Args[0])
 }
 
 func TestVault_Canonicalize(t *testing.T) {
@@ -7243,6 +8264,39 @@ func TestSpread_Validate(t *testing.T) {
 			err:  nil,
 			name: "Valid spread",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   -1,
+			},
+			err:  fmt.Errorf("Spread max_skew must not be negative"),
+			name: "Invalid max skew",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+				SpreadTarget: []*SpreadTarget{
+					{
+						Value:   "dc1",
+						Percent: 25,
+					},
+				},
+			},
+			err:  fmt.Errorf("Spread max_skew can't be used with spread targets"),
+			name: "Max skew with spread targets",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+			},
+			err:  nil,
+			name: "Valid max skew",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -7645,6 +8699,14 @@ func TestTaskGroup_validateScriptChecksInGroupServices(t *testing.T) {
 					Type:     "script",
 					TaskName: "", // unset
 				}},
+			}, {
+				Name:     "service4",
+				TaskName: "", // unset
+				Checks: []*ServiceCheck{{
+					Name:     "check1",
+					Type:     "docker",
+					TaskName: "", // unset
+				}},
 			}},
 		}
 
@@ -7652,6 +8714,7 @@ func TestTaskGroup_validateScriptChecksInGroupServices(t *testing.T) {
 		require.Contains(t, errStr, "Service [group1]->service1 or Check check1 must specify task parameter")
 		require.Contains(t, errStr, "Service [group1]->service1 or Check check3 must specify task parameter")
 		require.Contains(t, errStr, "Service [group1]->service3 or Check check1 must specify task parameter")
+		require.Contains(t, errStr, "Service [group1]->service4 or Check check1 must specify task parameter")
 	})
 
 	t.Run("service task set", func(t *testing.T) {
@@ -7678,6 +8741,37 @@ func TestTaskGroup_validateScriptChecksInGroupServices(t *testing.T) {
 	})
 }
 
+func TestAllocatedResources_Comparable_PrestartInit(t *testing.T) {
+	ci.Parallel(t)
+
+	allocationResources := AllocatedResources{
+		TaskLifecycles: map[string]*TaskLifecycleConfig{
+			"init-1": {Hook: TaskLifecycleHookPrestartInit},
+			"init-2": {Hook: TaskLifecycleHookPrestartInit},
+		},
+		Tasks: map[string]*AllocatedTaskResources{
+			"init-1": {
+				Cpu:    AllocatedCpuResources{CpuShares: 3000},
+				Memory: AllocatedMemoryResources{MemoryMB: 256},
+			},
+			"init-2": {
+				Cpu:    AllocatedCpuResources{CpuShares: 1000},
+				Memory: AllocatedMemoryResources{MemoryMB: 1024},
+			},
+			"main-task": {
+				Cpu:    AllocatedCpuResources{CpuShares: 2000},
+				Memory: AllocatedMemoryResources{MemoryMB: 512},
+			},
+		},
+	}
+
+	// Init tasks run one at a time and before the main task, so the init
+	// phase only needs the largest of each of their resources
+	flattened := allocationResources.Comparable().Flattened
+	must.Eq(t, 3000, flattened.Cpu.CpuShares)
+	must.Eq(t, 1024, flattened.Memory.MemoryMB)
+}
+
 func TestComparableResources_Superset(t *testing.T) {
 	ci.Parallel(t)
 
@@ -8012,6 +9106,47 @@ func TestChangeScript_Equal(t *testing.T) {
 	}})
 }
 
+func TestTemplateExec_Equal(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Equal[*TemplateExec](t, nil, nil)
+	must.NotEqual[*TemplateExec](t, nil, new(TemplateExec))
+
+	must.StructEqual(t, &TemplateExec{
+		Command: "/bin/helper",
+		Args:    []string{"-config", "local/helper.conf"},
+	}, []must.Tweak[*TemplateExec]{{
+		Field: "Command",
+		Apply: func(te *TemplateExec) { te.Command = "/bin/other" },
+	}, {
+		Field: "Args",
+		Apply: func(te *TemplateExec) { te.Args = []string{"-verbose"} },
+	}})
+}
+
+func TestTemplateState_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	ts := &TaskState{
+		Templates: []*TemplateState{{
+			DestPath:     "local/app.conf",
+			LastRendered: time.Now(),
+			Dependencies: []*TemplateDependencyState{{
+				Name:        "kv.block(app/config)",
+				Type:        "consul",
+				LastChanged: time.Now(),
+			}},
+		}},
+	}
+
+	c := ts.Copy()
+	must.True(t, ts.Equal(c))
+
+	c.Templates[0].Dependencies[0].Missing = true
+	must.False(t, ts.Templates[0].Dependencies[0].Missing)
+	must.False(t, ts.Equal(c))
+}
+
 func TestWaitConfig_Equal(t *testing.T) {
 	ci.Parallel(t)
 
@@ -8091,6 +9226,9 @@ func TestVault_Equal(t *testing.T) {
 	}, {
 		Field: "ChangeSignal",
 		Apply: func(v *Vault) { v.ChangeSignal = "SIGTERM" },
+	}, {
+		Field: "SecretRotation",
+		Apply: func(v *Vault) { v.SecretRotation = &VaultSecretRotation{} },
 	}})
 }
 
@@ -8112,6 +9250,10 @@ func TestTemplate_Equal(t *testing.T) {
 			Timeout:     1 * time.Second,
 			FailOnError: true,
 		},
+		Exec: &TemplateExec{
+			Command: "/bin/helper",
+			Args:    []string{"-config", "local/helper.conf"},
+		},
 		Splay:      1,
 		Perms:      "perms",
 		Uid:        pointer.Of(1000),
@@ -8150,6 +9292,9 @@ func TestTemplate_Equal(t *testing.T) {
 				FailOnError: true,
 			}
 		},
+	}, {
+		Field: "Exec",
+		Apply: func(t *Template) { t.Exec = &TemplateExec{Command: "/bin/other"} },
 	}, {
 		Field: "Splay",
 		Apply: func(t *Template) { t.Splay = 2 },
@@ -46,6 +46,24 @@ const (
 	// Reply: VariablesRenewLockResponse
 	VariablesRenewLockRPCMethod = "Variables.RenewLock"
 
+	// VariablesHistoryRPCMethod is the RPC method for listing the versions of
+	// a variable according to its namespace and path.
+	//
+	// Args: VariablesHistoryRequest
+	// Reply: VariablesHistoryResponse
+	VariablesHistoryRPCMethod = "Variables.History"
+
+	// VariablesRollbackRPCMethod is the RPC method for restoring a historic
+	// version of a variable according to its namespace and path.
+	//
+	// Args: VariablesRollbackRequest
+	// Reply: VariablesApplyResponse
+	VariablesRollbackRPCMethod = "Variables.Rollback"
+
+	// VariableDefaultTrackedVersions is the number of historic versions that
+	// are kept for each variable.
+	VariableDefaultTrackedVersions = 5
+
 	// maxVariableSize is the maximum size of the unencrypted contents of a
 	// variable. This size is deliberately set low and is not configurable, to
 	// discourage DoS'ing the cluster
@@ -90,6 +108,10 @@ type VariableMetadata struct {
 	CreateTime  int64
 	ModifyIndex uint64
 	ModifyTime  int64
+
+	// Version is incremented each time the items of the variable change.
+	// Previous versions are kept in the variable history.
+	Version uint64
 }
 
 // VariableEncrypted structs are returned from the Encrypter's encrypt
@@ -303,6 +325,9 @@ func (sv VariableMetadata) Equal(vm2 VariableMetadata) bool {
 	if sv.ModifyTime != vm2.ModifyTime {
 		return false
 	}
+	if sv.Version != vm2.Version {
+		return false
+	}
 	return sv.Lock.Equal(vm2.Lock)
 }
 
@@ -591,6 +616,13 @@ func (r *VariablesApplyResponse) IsRedacted() bool {
 type VarApplyStateRequest struct {
 	Op  VarOp              // Which operation are we performing
 	Var *VariableEncrypted // Which directory entry
+
+	// UnchangedIndex is set by the RPC layer to the ModifyIndex of the
+	// existing variable when the write does not change its items, such as
+	// when the variable is rekeyed or locked. If the variable has not been
+	// modified since, the write keeps its version and no history is recorded.
+	UnchangedIndex uint64
+
 	WriteRequest
 }
 
@@ -662,6 +694,10 @@ type VariablesListResponse struct {
 
 type VariablesReadRequest struct {
 	Path string
+
+	// Version reads a historic version of the variable if set.
+	Version *uint64
+
 	QueryOptions
 }
 
@@ -670,6 +706,32 @@ type VariablesReadResponse struct {
 	QueryMeta
 }
 
+// VariablesHistoryRequest is used to list the versions of a variable.
+type VariablesHistoryRequest struct {
+	Path string
+	QueryOptions
+}
+
+// VariablesHistoryResponse lists the metadata of each version of a variable,
+// with the current version first. It is empty if the variable doesn't exist.
+type VariablesHistoryResponse struct {
+	Versions []*VariableMetadata
+	QueryMeta
+}
+
+// VariablesRollbackRequest is used to restore the items of a historic version
+// of a variable. The rollback is written as a new version.
+type VariablesRollbackRequest struct {
+	Path    string
+	Version uint64
+
+	// CheckIndex is the ModifyIndex the variable must have for the rollback to
+	// be applied, if set.
+	CheckIndex *uint64
+
+	WriteRequest
+}
+
 // VariablesRenewLockRequest is used to renew the lease on a lock. This request
 // behaves like a write because the renewal needs to be forwarded to the leader
 // where the timers and lock work is kept.
@@ -49,6 +49,10 @@ const (
 
 	// WIChangeModeRestart restarts the task when a new token is retrieved.
 	WIChangeModeRestart = "restart"
+
+	// WorkloadIdentityAWSDefaultProfile is the profile of the AWS shared
+	// credentials file used when an identity's aws block doesn't set one.
+	WorkloadIdentityAWSDefaultProfile = "default"
 )
 
 var (
@@ -56,6 +60,11 @@ var (
 	// be safe to use in filenames.
 	validIdentityName = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
 
+	// claimMetaInterpolation matches the claim template references to the
+	// meta of the job, group, task or node and to the node attributes, whose
+	// keys are not known in advance.
+	claimMetaInterpolation = regexp.MustCompile(`\$\{(job\.meta|group\.meta|task\.meta|node\.meta|node\.attr)\.([^}]+)\}`)
+
 	// MinNomadVersionVaultWID is the minimum version of Nomad that supports
 	// workload identities for Vault.
 	// "-a" is used here so that it is "less than" all pre-release versions of
@@ -98,6 +107,7 @@ type IdentityClaimsBuilder struct {
 	consul      *Consul
 	vault       *Vault
 	node        *Node
+	subject     string
 	extras      map[string]string
 }
 
@@ -175,6 +185,19 @@ func (b *IdentityClaimsBuilder) WithService(service *Service) *IdentityClaimsBui
 	return b
 }
 
+// WithClaims adds the claim templates configured by the cluster administrator
+// to the builder context. A non-empty subject template replaces the standard
+// subject of the identity.
+func (b *IdentityClaimsBuilder) WithClaims(subject string, extraClaims map[string]string) *IdentityClaimsBuilder {
+	if subject != "" {
+		b.subject = subject
+	}
+	for k, v := range extraClaims {
+		b.extras[k] = v
+	}
+	return b
+}
+
 // WithNode add the allocation's node to the builder context.
 func (b *IdentityClaimsBuilder) WithNode(node *Node) *IdentityClaimsBuilder {
 	b.node = node
@@ -213,6 +236,9 @@ func (b *IdentityClaimsBuilder) Build(now time.Time) *IdentityClaims {
 
 	claims.Audience = slices.Clone(b.wid.Audience)
 	claims.setSubject(b.job, b.alloc.TaskGroup, b.wihandle.WorkloadIdentifier, b.wid.Name)
+	if b.subject != "" {
+		claims.Subject = b.subject
+	}
 	claims.setExp(now, b.wid)
 
 	claims.ID = uuid.Generate()
@@ -228,7 +254,7 @@ func strAttrGet[T any](x *T, fn func(x *T) string) string {
 }
 
 func (b *IdentityClaimsBuilder) interpolate() {
-	if len(b.extras) == 0 {
+	if len(b.extras) == 0 && b.subject == "" {
 		return
 	}
 
@@ -251,9 +277,39 @@ func (b *IdentityClaimsBuilder) interpolate() {
 		"${vault.namespace}", strAttrGet(b.vault, func(v *Vault) string { return v.Namespace }),
 		"${vault.role}", strAttrGet(b.vault, func(v *Vault) string { return v.Role }),
 	)
+	replace := func(v string) string {
+		return r.Replace(claimMetaInterpolation.ReplaceAllStringFunc(v, b.lookupMeta))
+	}
 	for k, v := range b.extras {
-		b.extras[k] = r.Replace(v)
+		b.extras[k] = replace(v)
 	}
+	b.subject = replace(b.subject)
+}
+
+// lookupMeta returns the value of a meta or node attribute reference matched
+// by claimMetaInterpolation, or an empty string if it isn't set.
+func (b *IdentityClaimsBuilder) lookupMeta(ref string) string {
+	m := claimMetaInterpolation.FindStringSubmatch(ref)
+	key := m[2]
+	switch m[1] {
+	case "job.meta":
+		return b.job.Meta[key]
+	case "group.meta":
+		return b.tg.Meta[key]
+	case "task.meta":
+		if b.task != nil {
+			return b.task.Meta[key]
+		}
+	case "node.meta":
+		if b.node != nil {
+			return b.node.Meta[key]
+		}
+	case "node.attr":
+		if b.node != nil {
+			return b.node.Attributes[key]
+		}
+	}
+	return ""
 }
 
 // setSubject creates the standard subject claim for workload identities.
@@ -318,11 +374,65 @@ type WorkloadIdentity struct {
 	// this identity (eg the JWT "exp" claim).
 	TTL time.Duration
 
+	// AWS configures exchanging the identity for AWS credentials which are
+	// written to a shared credentials file in the task's secrets directory.
+	AWS *WorkloadIdentityAWS
+
 	// Note: ExtraClaims is available on config/WorkloadIdentity but not
 	// available here on jobspecs because that might allow a job author to
 	// escalate their privileges if they know what claim mappings to expect.
 }
 
+// WorkloadIdentityAWS is the identity block used to exchange a task's
+// workload identity for AWS credentials with AssumeRoleWithWebIdentity.
+type WorkloadIdentityAWS struct {
+	// RoleARN is the ARN of the IAM role to assume.
+	RoleARN string
+
+	// Region is the region of the STS endpoint. The regional endpoint of
+	// us-east-1 is used if unset.
+	Region string
+
+	// Profile is the profile of the shared credentials file the credentials
+	// are written to, and defaults to "default".
+	Profile string
+
+	// Duration is the duration of the role session. The role's maximum
+	// session duration is used by AWS if unset.
+	Duration time.Duration
+}
+
+func (a *WorkloadIdentityAWS) Copy() *WorkloadIdentityAWS {
+	if a == nil {
+		return nil
+	}
+	na := *a
+	return &na
+}
+
+func (a *WorkloadIdentityAWS) Equal(o *WorkloadIdentityAWS) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	return *a == *o
+}
+
+func (a *WorkloadIdentityAWS) Validate() error {
+	var mErr multierror.Error
+
+	if !strings.HasPrefix(a.RoleARN, "arn:") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("aws role_arn must be an IAM role ARN, got %q", a.RoleARN))
+	}
+	if a.Duration != 0 && (a.Duration < 15*time.Minute || a.Duration > 12*time.Hour) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("aws duration must be between 15m and 12h"))
+	}
+	if strings.ContainsAny(a.Profile, "[]\n") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid aws profile %q", a.Profile))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
 func DefaultWorkloadIdentity() *WorkloadIdentity {
 	return &WorkloadIdentity{
 		Name:     WorkloadIdentityDefaultName,
@@ -363,6 +473,7 @@ func (wi *WorkloadIdentity) Copy() *WorkloadIdentity {
 		Filepath:     wi.Filepath,
 		ServiceName:  wi.ServiceName,
 		TTL:          wi.TTL,
+		AWS:          wi.AWS.Copy(),
 	}
 }
 
@@ -407,6 +518,10 @@ func (wi *WorkloadIdentity) Equal(other *WorkloadIdentity) bool {
 		return false
 	}
 
+	if !wi.AWS.Equal(other.AWS) {
+		return false
+	}
+
 	return true
 }
 
@@ -427,6 +542,10 @@ func (wi *WorkloadIdentity) Canonicalize() {
 	if wi.ChangeSignal != "" {
 		wi.ChangeSignal = strings.ToUpper(wi.ChangeSignal)
 	}
+
+	if wi.AWS != nil && wi.AWS.Profile == "" {
+		wi.AWS.Profile = WorkloadIdentityAWSDefaultProfile
+	}
 }
 
 func (wi *WorkloadIdentity) Validate() error {
@@ -475,6 +594,15 @@ func (wi *WorkloadIdentity) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("file parameter must be true in order to specify filepath"))
 	}
 
+	if wi.AWS != nil {
+		if wi.Name == "" || wi.Name == WorkloadIdentityDefaultName {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("aws is not supported for the default identity"))
+		}
+		if err := wi.AWS.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -537,6 +537,63 @@ func TestNewIdentityClaims(t *testing.T) {
 	}
 }
 
+func TestIdentityClaimsBuilder_WithClaims(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &Job{
+		ID:        "job",
+		Namespace: "default",
+		Region:    "global",
+		Meta:      map[string]string{"team": "payments"},
+		TaskGroups: []*TaskGroup{{
+			Name: "group",
+			Meta: map[string]string{"tier": "backend"},
+			Tasks: []*Task{{
+				Name: "task",
+				Meta: map[string]string{"app": "api"},
+			}},
+		}},
+	}
+	tg := job.TaskGroups[0]
+	task := tg.Tasks[0]
+	wid := &WorkloadIdentity{Name: "aws", Audience: []string{"sts.amazonaws.com"}}
+	alloc := &Allocation{ID: uuid.Generate(), Namespace: job.Namespace, JobID: job.ID, TaskGroup: tg.Name}
+	node := &Node{
+		ID:         "node",
+		Meta:       map[string]string{"rack": "r1"},
+		Attributes: map[string]string{"platform.aws.placement.availability-zone": "us-east-1a"},
+	}
+
+	claims := NewIdentityClaimsBuilder(job, alloc, task.IdentityHandle(wid), wid).
+		WithTask(task).
+		WithNode(node).
+		WithClaims("${job.namespace}:${job.id}:${task.meta.app}", map[string]string{
+			"team":    "${job.meta.team}",
+			"tier":    "${group.meta.tier}",
+			"rack":    "${node.meta.rack}",
+			"zone":    "${node.attr.platform.aws.placement.availability-zone}",
+			"missing": "${job.meta.missing}",
+		}).
+		Build(time.Now())
+
+	must.Eq(t, "default:job:api", claims.Subject)
+	must.Eq(t, map[string]string{
+		"team":    "payments",
+		"tier":    "backend",
+		"rack":    "r1",
+		"zone":    "us-east-1a",
+		"missing": "",
+	}, claims.ExtraClaims)
+
+	// Without a subject template the standard subject is kept and references
+	// to a missing task or node are empty.
+	claims = NewIdentityClaimsBuilder(job, alloc, task.IdentityHandle(wid), wid).
+		WithClaims("", map[string]string{"app": "${task.meta.app}", "rack": "${node.meta.rack}"}).
+		Build(time.Now())
+	must.Eq(t, "global:default:job:group:task:aws", claims.Subject)
+	must.Eq(t, map[string]string{"app": "", "rack": ""}, claims.ExtraClaims)
+}
+
 func TestWorkloadIdentity_Equal(t *testing.T) {
 	ci.Parallel(t)
 
@@ -595,6 +652,16 @@ func TestWorkloadIdentity_Equal(t *testing.T) {
 
 	newWI.TTL = 123 * time.Hour
 	must.NotEqual(t, orig, newWI)
+
+	newWI.TTL = orig.TTL
+	newWI.AWS = &WorkloadIdentityAWS{RoleARN: "arn:aws:iam::123456789012:role/s3"}
+	must.NotEqual(t, orig, newWI)
+
+	orig.AWS = newWI.AWS.Copy()
+	must.Equal(t, orig, newWI)
+
+	newWI.AWS.Profile = "s3"
+	must.NotEqual(t, orig, newWI)
 }
 
 // TestWorkloadIdentity_Validate asserts that canonicalized workload identities
@@ -783,6 +850,66 @@ func TestWorkloadIdentity_Validate(t *testing.T) {
 			},
 			Err: "file parameter must be true in order to specify filepath",
 		},
+		{
+			Desc: "AWS default profile",
+			In: WorkloadIdentity{
+				Name:     "s3",
+				Audience: []string{"sts.amazonaws.com"},
+				TTL:      time.Hour,
+				AWS: &WorkloadIdentityAWS{
+					RoleARN:  "arn:aws:iam::123456789012:role/s3",
+					Duration: time.Hour,
+				},
+			},
+			Exp: WorkloadIdentity{
+				Name:     "s3",
+				Audience: []string{"sts.amazonaws.com"},
+				TTL:      time.Hour,
+				AWS: &WorkloadIdentityAWS{
+					RoleARN:  "arn:aws:iam::123456789012:role/s3",
+					Profile:  WorkloadIdentityAWSDefaultProfile,
+					Duration: time.Hour,
+				},
+			},
+		},
+		{
+			Desc: "AWS bad role",
+			In: WorkloadIdentity{
+				Name: "s3",
+				AWS:  &WorkloadIdentityAWS{RoleARN: "s3"},
+			},
+			Err: "aws role_arn must be an IAM role ARN",
+		},
+		{
+			Desc: "AWS bad duration",
+			In: WorkloadIdentity{
+				Name: "s3",
+				AWS: &WorkloadIdentityAWS{
+					RoleARN:  "arn:aws:iam::123456789012:role/s3",
+					Duration: time.Minute,
+				},
+			},
+			Err: "aws duration must be between 15m and 12h",
+		},
+		{
+			Desc: "AWS bad profile",
+			In: WorkloadIdentity{
+				Name: "s3",
+				AWS: &WorkloadIdentityAWS{
+					RoleARN: "arn:aws:iam::123456789012:role/s3",
+					Profile: "[default]",
+				},
+			},
+			Err: "invalid aws profile",
+		},
+		{
+			Desc: "AWS default identity",
+			In: WorkloadIdentity{
+				Name: WorkloadIdentityDefaultName,
+				AWS:  &WorkloadIdentityAWS{RoleARN: "arn:aws:iam::123456789012:role/s3"},
+			},
+			Err: "aws is not supported for the default identity",
+		},
 	}
 
 	for _, tc := range cases {
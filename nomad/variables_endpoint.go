@@ -6,6 +6,7 @@ package nomad
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"strings"
 	"time"
@@ -34,6 +35,7 @@ var (
 	errLockOnVarCreation = structs.NewErrRPCCoded(http.StatusBadRequest, "variable should not contain lock definition")
 	errItemsOnRelease    = structs.NewErrRPCCoded(http.StatusBadRequest, "lock release operation doesn't take variable items")
 	errNoPath            = structs.NewErrRPCCoded(http.StatusBadRequest, "delete requires a Path")
+	errVersionNotFound   = structs.NewErrRPCCoded(http.StatusNotFound, "variable version doesn't exist")
 )
 
 type variableTimers interface {
@@ -96,7 +98,13 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 	if err != nil {
 		return err
 	}
-	err = hasOperationPermissions(aclObj, args.Var.Namespace, args.Var.Path, args.Op)
+
+	return sv.apply(args, aclObj, reply)
+}
+
+// apply performs an authenticated apply request on the leader.
+func (sv *Variables) apply(args *structs.VariablesApplyRequest, aclObj *acl.ACL, reply *structs.VariablesApplyResponse) error {
+	err := hasOperationPermissions(aclObj, args.Var.Namespace, args.Var.Path, args.Op)
 	if err != nil {
 		return err
 	}
@@ -136,6 +144,14 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 		WriteRequest: args.WriteRequest,
 	}
 
+	switch args.Op {
+	case structs.VarOpSet, structs.VarOpCAS, structs.VarOpLockAcquire:
+		sveArgs.UnchangedIndex, err = sv.unchangedIndex(args.Var)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Apply the update.
 	o, index, err := sv.srv.raftApply(structs.VarApplyStateRequestType, sveArgs)
 	if err != nil {
@@ -165,6 +181,26 @@ func (sv *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.V
 	return nil
 }
 
+// unchangedIndex returns the ModifyIndex of the existing variable if its items
+// are the same as those of the given variable, so writes that don't change the
+// items, like rekeying or locking, don't create a new version of the variable.
+func (sv *Variables) unchangedIndex(v *structs.VariableDecrypted) (uint64, error) {
+	existing, err := sv.srv.State().GetVariable(nil, v.Namespace, v.Path)
+	if err != nil {
+		return 0, err
+	}
+	if existing == nil {
+		return 0, nil
+	}
+	// If the existing items can't be decrypted the write is treated as a
+	// change, rather than failing it.
+	dv, err := sv.decrypt(existing)
+	if err != nil || !maps.Equal(dv.Items, v.Items) {
+		return 0, nil
+	}
+	return existing.ModifyIndex, nil
+}
+
 func hasReadPermission(aclObj *acl.ACL, namespace, path string) bool {
 	return aclObj.AllowVariableOperation(namespace,
 		path, acl.VariablesCapabilityRead, nil)
@@ -353,7 +389,13 @@ func (sv *Variables) Read(args *structs.VariablesReadRequest, reply *structs.Var
 		queryOpts: &args.QueryOptions,
 		queryMeta: &reply.QueryMeta,
 		run: func(ws memdb.WatchSet, s *state.StateStore) error {
-			out, err := s.GetVariable(ws, args.RequestNamespace(), args.Path)
+			var out *structs.VariableEncrypted
+			var err error
+			if args.Version != nil {
+				out, err = s.GetVariableVersion(ws, args.RequestNamespace(), args.Path, *args.Version)
+			} else {
+				out, err = s.GetVariable(ws, args.RequestNamespace(), args.Path)
+			}
 			if err != nil {
 				return err
 			}
@@ -382,6 +424,134 @@ func (sv *Variables) Read(args *structs.VariablesReadRequest, reply *structs.Var
 	return sv.srv.blockingRPC(&opts)
 }
 
+// History is used to list the versions of a variable
+func (sv *Variables) History(args *structs.VariablesHistoryRequest, reply *structs.VariablesHistoryResponse) error {
+
+	authErr := sv.srv.Authenticate(sv.ctx, args)
+	if done, err := sv.srv.forward(structs.VariablesHistoryRPCMethod, args, args, reply); done {
+		return err
+	}
+	sv.srv.MeasureRPCRate("variables", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "variables", "history"}, time.Now())
+
+	aclObj, err := sv.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowVariableOperation(args.RequestNamespace(), args.Path, acl.PolicyRead,
+		auth.IdentityToACLClaim(args.GetIdentity(), sv.srv.State())) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			out, err := s.GetVariable(ws, args.RequestNamespace(), args.Path)
+			if err != nil {
+				return err
+			}
+
+			reply.Versions = nil
+			if out == nil {
+				return sv.srv.setReplyQueryMeta(s, state.TableVariables, &reply.QueryMeta)
+			}
+
+			history, err := s.GetVariableVersions(ws, args.RequestNamespace(), args.Path)
+			if err != nil {
+				return err
+			}
+
+			reply.Versions = make([]*structs.VariableMetadata, 0, len(history)+1)
+			for _, v := range append([]*structs.VariableEncrypted{out}, history...) {
+				meta := v.VariableMetadata
+				if !aclObj.IsManagement() {
+					meta.Lock = nil
+				}
+				reply.Versions = append(reply.Versions, &meta)
+			}
+			reply.Index = out.ModifyIndex
+			return nil
+		}}
+	return sv.srv.blockingRPC(&opts)
+}
+
+// Rollback is used to restore the items of a historic version of a variable
+func (sv *Variables) Rollback(args *structs.VariablesRollbackRequest, reply *structs.VariablesApplyResponse) error {
+
+	authErr := sv.srv.Authenticate(sv.ctx, args)
+	if done, err := sv.srv.forward(structs.VariablesRollbackRPCMethod, args, args, reply); done {
+		return err
+	}
+	sv.srv.MeasureRPCRate("variables", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "variables", "rollback"}, time.Now())
+
+	if !ServersMeetMinimumVersion(
+		sv.srv.serf.Members(), sv.srv.Region(), minVersionKeyring, true) {
+		return fmt.Errorf("all servers must be running version %v or later to apply variables", minVersionKeyring)
+	}
+
+	aclObj, err := sv.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowVariableOperation(args.RequestNamespace(), args.Path,
+		acl.VariablesCapabilityWrite, nil) {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := sv.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	current, err := snap.GetVariable(nil, args.RequestNamespace(), args.Path)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return errVarNotFound
+	}
+	version, err := snap.GetVariableVersion(nil, args.RequestNamespace(), args.Path, args.Version)
+	if err != nil {
+		return err
+	}
+	if version == nil {
+		return errVersionNotFound
+	}
+
+	dv, err := sv.decrypt(version)
+	if err != nil {
+		return fmt.Errorf("variable error: decrypt: %w", err)
+	}
+
+	// The rollback is a check-and-set of the current variable with the items
+	// of the version, so it conflicts with concurrent writes. The lock is not
+	// carried over, so locked variables can't be rolled back.
+	dv.VariableMetadata = structs.VariableMetadata{
+		Namespace:   current.Namespace,
+		Path:        current.Path,
+		ModifyIndex: current.ModifyIndex,
+	}
+	if args.CheckIndex != nil {
+		dv.ModifyIndex = *args.CheckIndex
+	}
+
+	return sv.apply(&structs.VariablesApplyRequest{
+		Op:           structs.VarOpCAS,
+		Var:          dv,
+		WriteRequest: args.WriteRequest,
+	}, aclObj, reply)
+}
+
 // List is used to list variables held within state. It supports single
 // and wildcard namespace listings.
 func (sv *Variables) List(
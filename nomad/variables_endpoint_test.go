@@ -16,6 +16,7 @@ import (
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/auth"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -1684,3 +1685,85 @@ func TestVariablesEndpoint_RenewLock(t *testing.T) {
 		must.NoError(t, err)
 	})
 }
+
+func TestVariablesEndpoint_HistoryAndRollback(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanup := TestServer(t, nil)
+	t.Cleanup(cleanup)
+	testutil.WaitForKeyring(t, srv.RPC, "global")
+	codec := rpcClient(t, srv)
+
+	writeItems := func(items structs.VariableItems) *structs.VariableDecrypted {
+		t.Helper()
+		req := &structs.VariablesApplyRequest{
+			Op: structs.VarOpSet,
+			Var: &structs.VariableDecrypted{
+				VariableMetadata: structs.VariableMetadata{Path: "app/config"},
+				Items:            items,
+			},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.VariablesApplyResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, req, &resp))
+		must.True(t, resp.IsOk())
+		return resp.Output
+	}
+
+	history := func() []uint64 {
+		t.Helper()
+		req := &structs.VariablesHistoryRequest{
+			Path:         "app/config",
+			QueryOptions: structs.QueryOptions{Region: "global"},
+		}
+		var resp structs.VariablesHistoryResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesHistoryRPCMethod, req, &resp))
+		var versions []uint64
+		for _, v := range resp.Versions {
+			versions = append(versions, v.Version)
+		}
+		return versions
+	}
+
+	must.Eq(t, 0, writeItems(structs.VariableItems{"port": "80"}).Version)
+	must.Eq(t, 1, writeItems(structs.VariableItems{"port": "8080"}).Version)
+
+	// writing the same items doesn't create a new version
+	current := writeItems(structs.VariableItems{"port": "8080"})
+	must.Eq(t, 1, current.Version)
+	must.Eq(t, []uint64{1, 0}, history())
+
+	readReq := &structs.VariablesReadRequest{
+		Path:         "app/config",
+		Version:      pointer.Of(uint64(0)),
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var readResp structs.VariablesReadResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+	must.Eq(t, structs.VariableItems{"port": "80"}, readResp.Data.Items)
+
+	rollbackReq := &structs.VariablesRollbackRequest{
+		Path:         "app/config",
+		Version:      0,
+		CheckIndex:   pointer.Of(current.ModifyIndex - 1),
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// rollbacks are checked against the index of the variable
+	var rollbackResp structs.VariablesApplyResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesRollbackRPCMethod, rollbackReq, &rollbackResp))
+	must.True(t, rollbackResp.IsConflict())
+	must.Eq(t, current.ModifyIndex, rollbackResp.Conflict.ModifyIndex)
+
+	rollbackReq.CheckIndex = nil
+	rollbackResp = structs.VariablesApplyResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesRollbackRPCMethod, rollbackReq, &rollbackResp))
+	must.True(t, rollbackResp.IsOk())
+	must.Eq(t, 2, rollbackResp.Output.Version)
+	must.Eq(t, structs.VariableItems{"port": "80"}, rollbackResp.Output.Items)
+	must.Eq(t, []uint64{2, 1, 0}, history())
+
+	rollbackReq.Version = 9
+	err := msgpackrpc.CallWithCodec(codec, structs.VariablesRollbackRPCMethod, rollbackReq, &rollbackResp)
+	must.ErrorContains(t, err, "variable version doesn't exist")
+}
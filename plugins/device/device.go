@@ -186,6 +186,12 @@ type DeviceSpec struct {
 
 	// CgroupPerms defines the permissions to use when mounting the device.
 	CgroupPerms string
+
+	// CDIName is the fully qualified name of a Container Device Interface
+	// device, such as "nvidia.com/gpu=0". If set, drivers supporting CDI
+	// inject the device as described by its CDI spec on the host, and the
+	// paths and permissions are ignored.
+	CDIName string
 }
 
 // StatsResponse returns statistics for each device group.
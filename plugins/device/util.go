@@ -142,6 +142,7 @@ func convertProtoDeviceSpec(in *proto.DeviceSpec) *DeviceSpec {
 		TaskPath:    in.TaskPath,
 		HostPath:    in.HostPath,
 		CgroupPerms: in.Permissions,
+		CDIName:     in.CdiName,
 	}
 }
 
@@ -278,6 +279,7 @@ func convertStructDeviceSpec(in *DeviceSpec) *proto.DeviceSpec {
 		TaskPath:    in.TaskPath,
 		HostPath:    in.HostPath,
 		Permissions: in.CgroupPerms,
+		CdiName:     in.CDIName,
 	}
 }
 
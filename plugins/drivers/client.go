@@ -81,6 +81,7 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 		caps.MountConfigs = MountConfigSupport(resp.Capabilities.MountConfigs)
 		caps.DisableLogCollection = resp.Capabilities.DisableLogCollection
 		caps.DynamicWorkloadUsers = resp.Capabilities.DynamicWorkloadUsers
+		caps.Checkpoint = resp.Capabilities.Checkpoint
 	}
 
 	return caps, nil
@@ -152,28 +153,22 @@ func (d *driverPluginClient) StartTask(c *TaskConfig) (*TaskHandle, *DriverNetwo
 
 	resp, err := d.client.StartTask(d.doneCtx, req)
 	if err != nil {
-		st := status.Convert(err)
-		if len(st.Details()) > 0 {
-			if rec, ok := st.Details()[0].(*sproto.RecoverableError); ok {
-				return nil, nil, structs.NewRecoverableError(err, rec.Recoverable)
-			}
-		}
-		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+		return nil, nil, d.recoverableErrorFromGrpc(err)
 	}
 
-	var net *DriverNetwork
-	if resp.NetworkOverride != nil {
-		net = &DriverNetwork{
-			PortMap:       map[string]int{},
-			IP:            resp.NetworkOverride.Addr,
-			AutoAdvertise: resp.NetworkOverride.AutoAdvertise,
-		}
-		for k, v := range resp.NetworkOverride.PortMap {
-			net.PortMap[k] = int(v)
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
+}
+
+// recoverableErrorFromGrpc returns a recoverable error if the grpc status of
+// err has the details of one.
+func (d *driverPluginClient) recoverableErrorFromGrpc(err error) error {
+	st := status.Convert(err)
+	if len(st.Details()) > 0 {
+		if rec, ok := st.Details()[0].(*sproto.RecoverableError); ok {
+			return structs.NewRecoverableError(err, rec.Recoverable)
 		}
 	}
-
-	return taskHandleFromProto(resp.Handle), net, nil
+	return grpcutils.HandleGrpcErr(err, d.doneCtx)
 }
 
 // WaitTask returns a channel that will have an ExitResult pushed to it once when the task
@@ -509,3 +504,50 @@ func (d *driverPluginClient) DestroyNetwork(allocID string, spec *NetworkIsolati
 
 	return nil
 }
+
+var _ CheckpointDriver = (*driverPluginClient)(nil)
+
+func (d *driverPluginClient) CheckpointTask(taskID string, opts *CheckpointOptions) error {
+	req := &proto.CheckpointTaskRequest{
+		TaskId:       taskID,
+		Dir:          opts.Dir,
+		LeaveRunning: opts.LeaveRunning,
+	}
+
+	_, err := d.client.CheckpointTask(d.doneCtx, req)
+	if err != nil {
+		return grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return nil
+}
+
+func (d *driverPluginClient) RestoreTask(c *TaskConfig, dir string) (*TaskHandle, *DriverNetwork, error) {
+	req := &proto.RestoreTaskRequest{
+		Task: taskConfigToProto(c),
+		Dir:  dir,
+	}
+
+	resp, err := d.client.RestoreTask(d.doneCtx, req)
+	if err != nil {
+		return nil, nil, d.recoverableErrorFromGrpc(err)
+	}
+
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
+}
+
+var _ ResourceUpdateDriver = (*driverPluginClient)(nil)
+
+func (d *driverPluginClient) UpdateTaskResources(taskID string, resources *Resources) error {
+	req := &proto.UpdateTaskResourcesRequest{
+		TaskId:    taskID,
+		Resources: ResourcesToProto(resources),
+	}
+
+	_, err := d.client.UpdateTaskResources(d.doneCtx, req)
+	if err != nil {
+		return grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return nil
+}
@@ -17,6 +17,15 @@ type MemoryStats = cstructs.MemoryStats
 // CpuStats holds cpu usage related stats
 type CpuStats = cstructs.CpuStats
 
+// PressureStats holds the pressure stall information of a resource
+type PressureStats = cstructs.PressureStats
+
+// BlockIOStats holds disk I/O related stats
+type BlockIOStats = cstructs.BlockIOStats
+
+// NetworkStats holds network I/O related stats
+type NetworkStats = cstructs.NetworkStats
+
 // ResourceUsage holds information related to cpu and memory stats
 type ResourceUsage = cstructs.ResourceUsage
 
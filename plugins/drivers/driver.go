@@ -98,6 +98,39 @@ type DriverNetworkManager interface {
 	DestroyNetwork(allocID string, spec *NetworkIsolationSpec) error
 }
 
+// CheckpointDriver is the interface which exposes functions for checkpointing
+// the process state of running tasks to disk and restoring tasks from these
+// checkpoints, such as with CRIU. This only needs to be implemented if the
+// driver sets the Checkpoint capability.
+type CheckpointDriver interface {
+	// CheckpointTask writes the process state of a running task to the
+	// directory of opts.
+	CheckpointTask(taskID string, opts *CheckpointOptions) error
+
+	// RestoreTask starts a task from the checkpoint in dir instead of running
+	// its command. It returns the same values as StartTask.
+	RestoreTask(cfg *TaskConfig, dir string) (*TaskHandle, *DriverNetwork, error)
+}
+
+// CheckpointOptions configures a checkpoint of a task.
+type CheckpointOptions struct {
+	// Dir is the directory the checkpoint is written to. It must exist.
+	Dir string
+
+	// LeaveRunning keeps the task running once checkpointed. Otherwise the
+	// task exits, as if it was killed.
+	LeaveRunning bool
+}
+
+// ResourceUpdateDriver is the interface which exposes a function for updating
+// the resources of running tasks in place, such as by updating the limits of
+// their cgroups. This only needs to be implemented if the driver can resize
+// tasks without restarting them.
+type ResourceUpdateDriver interface {
+	// UpdateTaskResources applies the resources to the running task.
+	UpdateTaskResources(taskID string, resources *Resources) error
+}
+
 // DriverSignalTaskNotSupported can be embedded by drivers which don't support
 // the SignalTask RPC. This satisfies the SignalTask func requirement for the
 // DriverPlugin interface.
@@ -177,6 +210,12 @@ type Capabilities struct {
 	// The allocation of a unique, not-in-use UID/GID is managed by Nomad client
 	// ensuring no overlap.
 	DynamicWorkloadUsers bool
+
+	// Checkpoint indicates this driver implements CheckpointDriver and can
+	// checkpoint running tasks and restore tasks from checkpoints on this
+	// client. The client checkpoints tasks of allocations migrated with their
+	// ephemeral disk instead of killing them.
+	Checkpoint bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -419,18 +458,43 @@ type LinuxResources struct {
 	// specific options are deprecated in favor of exposes CPUPeriod and
 	// CPUQuota at the task resource block.
 	PercentTicks float64
+
+	// DiskIO are the disk I/O limits of the task, nil if the task isn't
+	// limited.
+	DiskIO *DiskIOLimits
 }
 
 func (r *LinuxResources) Copy() *LinuxResources {
 	res := new(LinuxResources)
 	*res = *r
+	if r.DiskIO != nil {
+		diskIO := *r.DiskIO
+		res.DiskIO = &diskIO
+	}
 	return res
 }
 
+// DiskIOLimits are the disk I/O limits of a task on a block device. A zero
+// limit means the task is unlimited.
+type DiskIOLimits struct {
+	// Device is the path of the block device, such as /dev/sda.
+	Device string
+
+	ReadBPS   int64
+	WriteBPS  int64
+	ReadIOPS  int64
+	WriteIOPS int64
+}
+
 type DeviceConfig struct {
 	TaskPath    string
 	HostPath    string
 	Permissions string
+
+	// CDIName is the fully qualified name of a Container Device Interface
+	// device to inject instead of mounting HostPath. Only drivers supporting
+	// CDI can start tasks with such devices.
+	CDIName string
 }
 
 func (d *DeviceConfig) Copy() *DeviceConfig {
@@ -508,6 +572,24 @@ type TaskStatus struct {
 	NetworkOverride  *DriverNetwork
 }
 
+const (
+	// TaskAttrHealthStatus is the key of the DriverAttributes of a
+	// TaskStatus holding the status of the health check run for the task by
+	// the driver, such as the HEALTHCHECK of a Docker image. It is only set
+	// by drivers that run health checks for some of their tasks.
+	TaskAttrHealthStatus = "health_status"
+
+	// TaskAttrHealthOutput is the key of the DriverAttributes of a
+	// TaskStatus holding the output of the last health check of the task.
+	TaskAttrHealthOutput = "health_output"
+
+	// TaskHealthStarting, TaskHealthHealthy, and TaskHealthUnhealthy are the
+	// values of the TaskAttrHealthStatus attribute
+	TaskHealthStarting  = "starting"
+	TaskHealthHealthy   = "healthy"
+	TaskHealthUnhealthy = "unhealthy"
+)
+
 type TaskEvent struct {
 	TaskID      string
 	TaskName    string
@@ -211,6 +211,7 @@ const (
 	CPUUsage_THROTTLED_PERIODS CPUUsage_Fields = 3
 	CPUUsage_THROTTLED_TIME    CPUUsage_Fields = 4
 	CPUUsage_PERCENT           CPUUsage_Fields = 5
+	CPUUsage_PRESSURE          CPUUsage_Fields = 6
 )
 
 var CPUUsage_Fields_name = map[int32]string{
@@ -220,6 +221,7 @@ var CPUUsage_Fields_name = map[int32]string{
 	3: "THROTTLED_PERIODS",
 	4: "THROTTLED_TIME",
 	5: "PERCENT",
+	6: "PRESSURE",
 }
 
 var CPUUsage_Fields_value = map[string]int32{
@@ -229,6 +231,7 @@ var CPUUsage_Fields_value = map[string]int32{
 	"THROTTLED_PERIODS": 3,
 	"THROTTLED_TIME":    4,
 	"PERCENT":           5,
+	"PRESSURE":          6,
 }
 
 func (x CPUUsage_Fields) String() string {
@@ -249,6 +252,7 @@ const (
 	MemoryUsage_KERNEL_MAX_USAGE MemoryUsage_Fields = 4
 	MemoryUsage_USAGE            MemoryUsage_Fields = 5
 	MemoryUsage_SWAP             MemoryUsage_Fields = 6
+	MemoryUsage_PRESSURE         MemoryUsage_Fields = 7
 )
 
 var MemoryUsage_Fields_name = map[int32]string{
@@ -259,6 +263,7 @@ var MemoryUsage_Fields_name = map[int32]string{
 	4: "KERNEL_MAX_USAGE",
 	5: "USAGE",
 	6: "SWAP",
+	7: "PRESSURE",
 }
 
 var MemoryUsage_Fields_value = map[string]int32{
@@ -269,6 +274,7 @@ var MemoryUsage_Fields_value = map[string]int32{
 	"KERNEL_MAX_USAGE": 4,
 	"USAGE":            5,
 	"SWAP":             6,
+	"PRESSURE":         7,
 }
 
 func (x MemoryUsage_Fields) String() string {
@@ -1854,7 +1860,10 @@ type DriverCapabilities struct {
 	DisableLogCollection bool `protobuf:"varint,8,opt,name=disable_log_collection,json=disableLogCollection,proto3" json:"disable_log_collection,omitempty"`
 	// dynamic_workload_users indicates the task is capable of using UID/GID
 	// assigned from the Nomad client as user credentials for the task.
-	DynamicWorkloadUsers bool     `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	DynamicWorkloadUsers bool `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	// checkpoint indicates the driver implements the CheckpointTask and
+	// RestoreTask RPCs.
+	Checkpoint           bool     `protobuf:"varint,10,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1941,6 +1950,13 @@ func (m *DriverCapabilities) GetDynamicWorkloadUsers() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -2748,10 +2764,12 @@ type LinuxResources struct {
 	CpusetCgroup string `protobuf:"bytes,9,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	// PercentTicks is a compatibility option for docker and should not be used
 	// buf:lint:ignore FIELD_LOWER_SNAKE_CASE
-	PercentTicks         float64  `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PercentTicks float64 `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
+	// DiskIO are the disk I/O limits of the task. Default: nil (not limited)
+	DiskIo               *DiskIOLimits `protobuf:"bytes,10,opt,name=disk_io,json=diskIo,proto3" json:"disk_io,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *LinuxResources) Reset()         { *m = LinuxResources{} }
@@ -2835,6 +2853,13 @@ func (m *LinuxResources) GetPercentTicks() float64 {
 	return 0
 }
 
+func (m *LinuxResources) GetDiskIo() *DiskIOLimits {
+	if m != nil {
+		return m.DiskIo
+	}
+	return nil
+}
+
 type Mount struct {
 	// TaskPath is the file path within the task directory to mount to
 	TaskPath string `protobuf:"bytes,1,opt,name=task_path,json=taskPath,proto3" json:"task_path,omitempty"`
@@ -2923,7 +2948,12 @@ type Device struct {
 	//   - m - allows the task to create device files that do not yet exist.
 	//
 	// Example: "rw"
-	CgroupPermissions    string   `protobuf:"bytes,3,opt,name=cgroup_permissions,json=cgroupPermissions,proto3" json:"cgroup_permissions,omitempty"`
+	CgroupPermissions string `protobuf:"bytes,3,opt,name=cgroup_permissions,json=cgroupPermissions,proto3" json:"cgroup_permissions,omitempty"`
+	// CdiName is the fully qualified name of a Container Device Interface
+	// (CDI) device to inject into the task instead of mounting HostPath.
+	//
+	// Example: "nvidia.com/gpu=0"
+	CdiName              string   `protobuf:"bytes,4,opt,name=cdi_name,json=cdiName,proto3" json:"cdi_name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2975,6 +3005,13 @@ func (m *Device) GetCgroupPermissions() string {
 	return ""
 }
 
+func (m *Device) GetCdiName() string {
+	if m != nil {
+		return m.CdiName
+	}
+	return ""
+}
+
 // TaskHandle is created when starting a task and is used to recover task
 type TaskHandle struct {
 	// Version is used by the driver to version the DriverState schema.
@@ -3361,10 +3398,14 @@ type TaskResourceUsage struct {
 	// CPU usage stats
 	Cpu *CPUUsage `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
 	// Memory usage stats
-	Memory               *MemoryUsage `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Memory *MemoryUsage `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Block I/O usage stats, only set by the drivers able to measure them
+	BlockIo *BlockIOUsage `protobuf:"bytes,3,opt,name=block_io,json=blockIo,proto3" json:"block_io,omitempty"`
+	// Network usage stats, only set by the drivers able to measure them
+	Network              *NetworkUsage `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *TaskResourceUsage) Reset()         { *m = TaskResourceUsage{} }
@@ -3406,6 +3447,20 @@ func (m *TaskResourceUsage) GetMemory() *MemoryUsage {
 	return nil
 }
 
+func (m *TaskResourceUsage) GetBlockIo() *BlockIOUsage {
+	if m != nil {
+		return m.BlockIo
+	}
+	return nil
+}
+
+func (m *TaskResourceUsage) GetNetwork() *NetworkUsage {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
 type CPUUsage struct {
 	SystemMode       float64 `protobuf:"fixed64,1,opt,name=system_mode,json=systemMode,proto3" json:"system_mode,omitempty"`
 	UserMode         float64 `protobuf:"fixed64,2,opt,name=user_mode,json=userMode,proto3" json:"user_mode,omitempty"`
@@ -3413,6 +3468,8 @@ type CPUUsage struct {
 	ThrottledPeriods uint64  `protobuf:"varint,4,opt,name=throttled_periods,json=throttledPeriods,proto3" json:"throttled_periods,omitempty"`
 	ThrottledTime    uint64  `protobuf:"varint,5,opt,name=throttled_time,json=throttledTime,proto3" json:"throttled_time,omitempty"`
 	Percent          float64 `protobuf:"fixed64,6,opt,name=percent,proto3" json:"percent,omitempty"`
+	// Pressure is the CPU pressure stall information of the task
+	Pressure *Pressure `protobuf:"bytes,8,opt,name=pressure,proto3" json:"pressure,omitempty"`
 	// MeasuredFields indicates which fields were actually sampled
 	MeasuredFields       []CPUUsage_Fields `protobuf:"varint,7,rep,packed,name=measured_fields,json=measuredFields,proto3,enum=hashicorp.nomad.plugins.drivers.proto.CPUUsage_Fields" json:"measured_fields,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
@@ -3487,6 +3544,13 @@ func (m *CPUUsage) GetPercent() float64 {
 	return 0
 }
 
+func (m *CPUUsage) GetPressure() *Pressure {
+	if m != nil {
+		return m.Pressure
+	}
+	return nil
+}
+
 func (m *CPUUsage) GetMeasuredFields() []CPUUsage_Fields {
 	if m != nil {
 		return m.MeasuredFields
@@ -3502,6 +3566,8 @@ type MemoryUsage struct {
 	KernelMaxUsage uint64 `protobuf:"varint,5,opt,name=kernel_max_usage,json=kernelMaxUsage,proto3" json:"kernel_max_usage,omitempty"`
 	Usage          uint64 `protobuf:"varint,7,opt,name=usage,proto3" json:"usage,omitempty"`
 	Swap           uint64 `protobuf:"varint,8,opt,name=swap,proto3" json:"swap,omitempty"`
+	// Pressure is the memory pressure stall information of the task
+	Pressure *Pressure `protobuf:"bytes,9,opt,name=pressure,proto3" json:"pressure,omitempty"`
 	// MeasuredFields indicates which fields were actually sampled
 	MeasuredFields       []MemoryUsage_Fields `protobuf:"varint,6,rep,packed,name=measured_fields,json=measuredFields,proto3,enum=hashicorp.nomad.plugins.drivers.proto.MemoryUsage_Fields" json:"measured_fields,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -3583,6 +3649,13 @@ func (m *MemoryUsage) GetSwap() uint64 {
 	return 0
 }
 
+func (m *MemoryUsage) GetPressure() *Pressure {
+	if m != nil {
+		return m.Pressure
+	}
+	return nil
+}
+
 func (m *MemoryUsage) GetMeasuredFields() []MemoryUsage_Fields {
 	if m != nil {
 		return m.MeasuredFields
@@ -3675,6 +3748,622 @@ func (m *DriverTaskEvent) GetAnnotations() map[string]string {
 	return nil
 }
 
+// Pressure holds the pressure stall information (PSI) of a resource
+type Pressure struct {
+	SomeAvg10            float64  `protobuf:"fixed64,1,opt,name=some_avg10,json=someAvg10,proto3" json:"some_avg10,omitempty"`
+	SomeAvg60            float64  `protobuf:"fixed64,2,opt,name=some_avg60,json=someAvg60,proto3" json:"some_avg60,omitempty"`
+	SomeAvg300           float64  `protobuf:"fixed64,3,opt,name=some_avg300,json=someAvg300,proto3" json:"some_avg300,omitempty"`
+	SomeTotal            uint64   `protobuf:"varint,4,opt,name=some_total,json=someTotal,proto3" json:"some_total,omitempty"`
+	FullAvg10            float64  `protobuf:"fixed64,5,opt,name=full_avg10,json=fullAvg10,proto3" json:"full_avg10,omitempty"`
+	FullAvg60            float64  `protobuf:"fixed64,6,opt,name=full_avg60,json=fullAvg60,proto3" json:"full_avg60,omitempty"`
+	FullAvg300           float64  `protobuf:"fixed64,7,opt,name=full_avg300,json=fullAvg300,proto3" json:"full_avg300,omitempty"`
+	FullTotal            uint64   `protobuf:"varint,8,opt,name=full_total,json=fullTotal,proto3" json:"full_total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Pressure) Reset()         { *m = Pressure{} }
+func (m *Pressure) String() string { return proto.CompactTextString(m) }
+func (*Pressure) ProtoMessage()    {}
+func (*Pressure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{57}
+}
+
+func (m *Pressure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pressure.Unmarshal(m, b)
+}
+func (m *Pressure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pressure.Marshal(b, m, deterministic)
+}
+func (m *Pressure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pressure.Merge(m, src)
+}
+func (m *Pressure) XXX_Size() int {
+	return xxx_messageInfo_Pressure.Size(m)
+}
+func (m *Pressure) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pressure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pressure proto.InternalMessageInfo
+
+func (m *Pressure) GetSomeAvg10() float64 {
+	if m != nil {
+		return m.SomeAvg10
+	}
+	return 0
+}
+
+func (m *Pressure) GetSomeAvg60() float64 {
+	if m != nil {
+		return m.SomeAvg60
+	}
+	return 0
+}
+
+func (m *Pressure) GetSomeAvg300() float64 {
+	if m != nil {
+		return m.SomeAvg300
+	}
+	return 0
+}
+
+func (m *Pressure) GetSomeTotal() uint64 {
+	if m != nil {
+		return m.SomeTotal
+	}
+	return 0
+}
+
+func (m *Pressure) GetFullAvg10() float64 {
+	if m != nil {
+		return m.FullAvg10
+	}
+	return 0
+}
+
+func (m *Pressure) GetFullAvg60() float64 {
+	if m != nil {
+		return m.FullAvg60
+	}
+	return 0
+}
+
+func (m *Pressure) GetFullAvg300() float64 {
+	if m != nil {
+		return m.FullAvg300
+	}
+	return 0
+}
+
+func (m *Pressure) GetFullTotal() uint64 {
+	if m != nil {
+		return m.FullTotal
+	}
+	return 0
+}
+
+type CheckpointTaskRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Dir is the directory the checkpoint of the task is written to
+	Dir string `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	// LeaveRunning keeps the task running once checkpointed, otherwise the
+	// task exits
+	LeaveRunning         bool     `protobuf:"varint,3,opt,name=leave_running,json=leaveRunning,proto3" json:"leave_running,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskRequest) Reset()         { *m = CheckpointTaskRequest{} }
+func (m *CheckpointTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskRequest) ProtoMessage()    {}
+func (*CheckpointTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{58}
+}
+
+func (m *CheckpointTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskRequest.Unmarshal(m, b)
+}
+func (m *CheckpointTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskRequest.Merge(m, src)
+}
+func (m *CheckpointTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskRequest.Size(m)
+}
+func (m *CheckpointTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskRequest proto.InternalMessageInfo
+
+func (m *CheckpointTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *CheckpointTaskRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+func (m *CheckpointTaskRequest) GetLeaveRunning() bool {
+	if m != nil {
+		return m.LeaveRunning
+	}
+	return false
+}
+
+type CheckpointTaskResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskResponse) Reset()         { *m = CheckpointTaskResponse{} }
+func (m *CheckpointTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskResponse) ProtoMessage()    {}
+func (*CheckpointTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{59}
+}
+
+func (m *CheckpointTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskResponse.Unmarshal(m, b)
+}
+func (m *CheckpointTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskResponse.Merge(m, src)
+}
+func (m *CheckpointTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskResponse.Size(m)
+}
+func (m *CheckpointTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskResponse proto.InternalMessageInfo
+
+type RestoreTaskRequest struct {
+	// Task configuration to restore
+	Task *TaskConfig `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Dir is the directory of the checkpoint the task is restored from
+	Dir                  string   `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTaskRequest) Reset()         { *m = RestoreTaskRequest{} }
+func (m *RestoreTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskRequest) ProtoMessage()    {}
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{60}
+}
+
+func (m *RestoreTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskRequest.Unmarshal(m, b)
+}
+func (m *RestoreTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskRequest.Merge(m, src)
+}
+func (m *RestoreTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskRequest.Size(m)
+}
+func (m *RestoreTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskRequest proto.InternalMessageInfo
+
+func (m *RestoreTaskRequest) GetTask() *TaskConfig {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *RestoreTaskRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type RestoreTaskResponse struct {
+	// Handle is opaque to the client, but must be stored in order to recover
+	// the task.
+	Handle *TaskHandle `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	// NetworkOverride is set if the driver sets network settings and the service ip/port
+	// needs to be set differently.
+	NetworkOverride      *NetworkOverride `protobuf:"bytes,2,opt,name=network_override,json=networkOverride,proto3" json:"network_override,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RestoreTaskResponse) Reset()         { *m = RestoreTaskResponse{} }
+func (m *RestoreTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskResponse) ProtoMessage()    {}
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{61}
+}
+
+func (m *RestoreTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskResponse.Unmarshal(m, b)
+}
+func (m *RestoreTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskResponse.Merge(m, src)
+}
+func (m *RestoreTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskResponse.Size(m)
+}
+func (m *RestoreTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskResponse proto.InternalMessageInfo
+
+func (m *RestoreTaskResponse) GetHandle() *TaskHandle {
+	if m != nil {
+		return m.Handle
+	}
+	return nil
+}
+
+func (m *RestoreTaskResponse) GetNetworkOverride() *NetworkOverride {
+	if m != nil {
+		return m.NetworkOverride
+	}
+	return nil
+}
+
+type DiskIOLimits struct {
+	// Device is the path of the block device the limits apply to
+	Device string `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	// ReadBps and WriteBps are the limits in bytes per second
+	ReadBps  int64 `protobuf:"varint,2,opt,name=read_bps,json=readBps,proto3" json:"read_bps,omitempty"`
+	WriteBps int64 `protobuf:"varint,3,opt,name=write_bps,json=writeBps,proto3" json:"write_bps,omitempty"`
+	// ReadIops and WriteIops are the limits in I/O operations per second
+	ReadIops             int64    `protobuf:"varint,4,opt,name=read_iops,json=readIops,proto3" json:"read_iops,omitempty"`
+	WriteIops            int64    `protobuf:"varint,5,opt,name=write_iops,json=writeIops,proto3" json:"write_iops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiskIOLimits) Reset()         { *m = DiskIOLimits{} }
+func (m *DiskIOLimits) String() string { return proto.CompactTextString(m) }
+func (*DiskIOLimits) ProtoMessage()    {}
+func (*DiskIOLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{62}
+}
+
+func (m *DiskIOLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskIOLimits.Unmarshal(m, b)
+}
+func (m *DiskIOLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskIOLimits.Marshal(b, m, deterministic)
+}
+func (m *DiskIOLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskIOLimits.Merge(m, src)
+}
+func (m *DiskIOLimits) XXX_Size() int {
+	return xxx_messageInfo_DiskIOLimits.Size(m)
+}
+func (m *DiskIOLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskIOLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiskIOLimits proto.InternalMessageInfo
+
+func (m *DiskIOLimits) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *DiskIOLimits) GetReadBps() int64 {
+	if m != nil {
+		return m.ReadBps
+	}
+	return 0
+}
+
+func (m *DiskIOLimits) GetWriteBps() int64 {
+	if m != nil {
+		return m.WriteBps
+	}
+	return 0
+}
+
+func (m *DiskIOLimits) GetReadIops() int64 {
+	if m != nil {
+		return m.ReadIops
+	}
+	return 0
+}
+
+func (m *DiskIOLimits) GetWriteIops() int64 {
+	if m != nil {
+		return m.WriteIops
+	}
+	return 0
+}
+
+type UpdateTaskResourcesRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Resources are the new resources of the task
+	Resources            *Resources `protobuf:"bytes,2,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateTaskResourcesRequest) Reset()         { *m = UpdateTaskResourcesRequest{} }
+func (m *UpdateTaskResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesRequest) ProtoMessage()    {}
+func (*UpdateTaskResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{63}
+}
+
+func (m *UpdateTaskResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesRequest.Merge(m, src)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Size(m)
+}
+func (m *UpdateTaskResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesRequest proto.InternalMessageInfo
+
+func (m *UpdateTaskResourcesRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *UpdateTaskResourcesRequest) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type UpdateTaskResourcesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTaskResourcesResponse) Reset()         { *m = UpdateTaskResourcesResponse{} }
+func (m *UpdateTaskResourcesResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesResponse) ProtoMessage()    {}
+func (*UpdateTaskResourcesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{64}
+}
+
+func (m *UpdateTaskResourcesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesResponse.Merge(m, src)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Size(m)
+}
+func (m *UpdateTaskResourcesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesResponse proto.InternalMessageInfo
+
+type BlockIOUsage struct {
+	ReadBytes  uint64 `protobuf:"varint,1,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteBytes uint64 `protobuf:"varint,2,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	ReadOps    uint64 `protobuf:"varint,3,opt,name=read_ops,json=readOps,proto3" json:"read_ops,omitempty"`
+	WriteOps   uint64 `protobuf:"varint,4,opt,name=write_ops,json=writeOps,proto3" json:"write_ops,omitempty"`
+	// MeasuredFields indicates which fields were actually sampled
+	MeasuredFields       []string `protobuf:"bytes,5,rep,name=measured_fields,json=measuredFields,proto3" json:"measured_fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockIOUsage) Reset()         { *m = BlockIOUsage{} }
+func (m *BlockIOUsage) String() string { return proto.CompactTextString(m) }
+func (*BlockIOUsage) ProtoMessage()    {}
+func (*BlockIOUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{65}
+}
+
+func (m *BlockIOUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockIOUsage.Unmarshal(m, b)
+}
+func (m *BlockIOUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockIOUsage.Marshal(b, m, deterministic)
+}
+func (m *BlockIOUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockIOUsage.Merge(m, src)
+}
+func (m *BlockIOUsage) XXX_Size() int {
+	return xxx_messageInfo_BlockIOUsage.Size(m)
+}
+func (m *BlockIOUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockIOUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockIOUsage proto.InternalMessageInfo
+
+func (m *BlockIOUsage) GetReadBytes() uint64 {
+	if m != nil {
+		return m.ReadBytes
+	}
+	return 0
+}
+
+func (m *BlockIOUsage) GetWriteBytes() uint64 {
+	if m != nil {
+		return m.WriteBytes
+	}
+	return 0
+}
+
+func (m *BlockIOUsage) GetReadOps() uint64 {
+	if m != nil {
+		return m.ReadOps
+	}
+	return 0
+}
+
+func (m *BlockIOUsage) GetWriteOps() uint64 {
+	if m != nil {
+		return m.WriteOps
+	}
+	return 0
+}
+
+func (m *BlockIOUsage) GetMeasuredFields() []string {
+	if m != nil {
+		return m.MeasuredFields
+	}
+	return nil
+}
+
+type NetworkUsage struct {
+	RxBytes   uint64 `protobuf:"varint,1,opt,name=rx_bytes,json=rxBytes,proto3" json:"rx_bytes,omitempty"`
+	RxPackets uint64 `protobuf:"varint,2,opt,name=rx_packets,json=rxPackets,proto3" json:"rx_packets,omitempty"`
+	RxErrors  uint64 `protobuf:"varint,3,opt,name=rx_errors,json=rxErrors,proto3" json:"rx_errors,omitempty"`
+	RxDropped uint64 `protobuf:"varint,4,opt,name=rx_dropped,json=rxDropped,proto3" json:"rx_dropped,omitempty"`
+	TxBytes   uint64 `protobuf:"varint,5,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	TxPackets uint64 `protobuf:"varint,6,opt,name=tx_packets,json=txPackets,proto3" json:"tx_packets,omitempty"`
+	TxErrors  uint64 `protobuf:"varint,7,opt,name=tx_errors,json=txErrors,proto3" json:"tx_errors,omitempty"`
+	TxDropped uint64 `protobuf:"varint,8,opt,name=tx_dropped,json=txDropped,proto3" json:"tx_dropped,omitempty"`
+	// MeasuredFields indicates which fields were actually sampled
+	MeasuredFields       []string `protobuf:"bytes,9,rep,name=measured_fields,json=measuredFields,proto3" json:"measured_fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkUsage) Reset()         { *m = NetworkUsage{} }
+func (m *NetworkUsage) String() string { return proto.CompactTextString(m) }
+func (*NetworkUsage) ProtoMessage()    {}
+func (*NetworkUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{66}
+}
+
+func (m *NetworkUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkUsage.Unmarshal(m, b)
+}
+func (m *NetworkUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkUsage.Marshal(b, m, deterministic)
+}
+func (m *NetworkUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkUsage.Merge(m, src)
+}
+func (m *NetworkUsage) XXX_Size() int {
+	return xxx_messageInfo_NetworkUsage.Size(m)
+}
+func (m *NetworkUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkUsage proto.InternalMessageInfo
+
+func (m *NetworkUsage) GetRxBytes() uint64 {
+	if m != nil {
+		return m.RxBytes
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetRxPackets() uint64 {
+	if m != nil {
+		return m.RxPackets
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetRxErrors() uint64 {
+	if m != nil {
+		return m.RxErrors
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetRxDropped() uint64 {
+	if m != nil {
+		return m.RxDropped
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetTxBytes() uint64 {
+	if m != nil {
+		return m.TxBytes
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetTxPackets() uint64 {
+	if m != nil {
+		return m.TxPackets
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetTxErrors() uint64 {
+	if m != nil {
+		return m.TxErrors
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetTxDropped() uint64 {
+	if m != nil {
+		return m.TxDropped
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetMeasuredFields() []string {
+	if m != nil {
+		return m.MeasuredFields
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.TaskState", TaskState_name, TaskState_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.FingerprintResponse_HealthState", FingerprintResponse_HealthState_name, FingerprintResponse_HealthState_value)
@@ -3751,6 +4440,16 @@ func init() {
 	proto.RegisterType((*MemoryUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.MemoryUsage")
 	proto.RegisterType((*DriverTaskEvent)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent.AnnotationsEntry")
+	proto.RegisterType((*Pressure)(nil), "hashicorp.nomad.plugins.drivers.proto.Pressure")
+	proto.RegisterType((*CheckpointTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskRequest")
+	proto.RegisterType((*CheckpointTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskResponse")
+	proto.RegisterType((*RestoreTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskRequest")
+	proto.RegisterType((*RestoreTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskResponse")
+	proto.RegisterType((*DiskIOLimits)(nil), "hashicorp.nomad.plugins.drivers.proto.DiskIOLimits")
+	proto.RegisterType((*UpdateTaskResourcesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesRequest")
+	proto.RegisterType((*UpdateTaskResourcesResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesResponse")
+	proto.RegisterType((*BlockIOUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.BlockIOUsage")
+	proto.RegisterType((*NetworkUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkUsage")
 }
 
 func init() {
@@ -3758,253 +4457,291 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3938 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x73, 0xdb, 0x48,
-	0x76, 0x17, 0xf8, 0x4f, 0xe4, 0x23, 0x45, 0x41, 0x2d, 0xc9, 0xa6, 0x39, 0x9b, 0x8c, 0x07, 0x5b,
-	0x93, 0x52, 0x76, 0x67, 0xe8, 0x59, 0x6d, 0x32, 0x1e, 0x7b, 0x3d, 0xeb, 0xe1, 0x50, 0xb4, 0x45,
-	0x5b, 0xa2, 0x94, 0x26, 0x15, 0xaf, 0xe3, 0x64, 0x10, 0x08, 0x68, 0x53, 0xb0, 0x48, 0x00, 0x83,
-	0x06, 0x65, 0x69, 0x53, 0xa9, 0xa4, 0x36, 0x55, 0xa9, 0x4d, 0x55, 0x52, 0xc9, 0x65, 0xb2, 0x97,
-	0x9c, 0xb6, 0x2a, 0xa7, 0x54, 0xee, 0xa9, 0x4d, 0xed, 0x29, 0x87, 0x7c, 0x89, 0x1c, 0x92, 0x5b,
-	0xae, 0xf9, 0x04, 0xd9, 0xea, 0x3f, 0x00, 0x01, 0x92, 0x1e, 0x83, 0x94, 0x4f, 0xe4, 0x7b, 0xdd,
-	0xfd, 0xeb, 0x87, 0xf7, 0x5e, 0xbf, 0x7e, 0xdd, 0xfd, 0x40, 0xf3, 0x86, 0xe3, 0x81, 0xed, 0xd0,
-	0x3b, 0x96, 0x6f, 0x5f, 0x10, 0x9f, 0xde, 0xf1, 0x7c, 0x37, 0x70, 0x25, 0xd5, 0xe0, 0x04, 0xfa,
-	0xf0, 0xcc, 0xa0, 0x67, 0xb6, 0xe9, 0xfa, 0x5e, 0xc3, 0x71, 0x47, 0x86, 0xd5, 0x90, 0x63, 0x1a,
-	0x72, 0x8c, 0xe8, 0x56, 0xff, 0xed, 0x81, 0xeb, 0x0e, 0x86, 0x44, 0x20, 0x9c, 0x8e, 0x5f, 0xde,
-	0xb1, 0xc6, 0xbe, 0x11, 0xd8, 0xae, 0x23, 0xdb, 0xdf, 0x9f, 0x6e, 0x0f, 0xec, 0x11, 0xa1, 0x81,
-	0x31, 0xf2, 0x64, 0x87, 0x0f, 0x43, 0x59, 0xe8, 0x99, 0xe1, 0x13, 0xeb, 0xce, 0x99, 0x39, 0xa4,
-	0x1e, 0x31, 0xd9, 0xaf, 0xce, 0xfe, 0xc8, 0x6e, 0x1f, 0x4d, 0x75, 0xa3, 0x81, 0x3f, 0x36, 0x83,
-	0x50, 0x72, 0x23, 0x08, 0x7c, 0xfb, 0x74, 0x1c, 0x10, 0xd1, 0x5b, 0xbb, 0x05, 0x37, 0xfb, 0x06,
-	0x3d, 0x6f, 0xb9, 0xce, 0x4b, 0x7b, 0xd0, 0x33, 0xcf, 0xc8, 0xc8, 0xc0, 0xe4, 0xeb, 0x31, 0xa1,
-	0x81, 0xf6, 0xc7, 0x50, 0x9b, 0x6d, 0xa2, 0x9e, 0xeb, 0x50, 0x82, 0xbe, 0x80, 0x1c, 0x9b, 0xb2,
-	0xa6, 0xdc, 0x56, 0x76, 0xca, 0xbb, 0x1f, 0x35, 0xde, 0xa4, 0x02, 0x21, 0x43, 0x43, 0x8a, 0xda,
-	0xe8, 0x79, 0xc4, 0xc4, 0x7c, 0xa4, 0xb6, 0x0d, 0x9b, 0x2d, 0xc3, 0x33, 0x4e, 0xed, 0xa1, 0x1d,
-	0xd8, 0x84, 0x86, 0x93, 0x8e, 0x61, 0x2b, 0xc9, 0x96, 0x13, 0xfe, 0x09, 0x54, 0xcc, 0x18, 0x5f,
-	0x4e, 0x7c, 0xaf, 0x91, 0x4a, 0xf7, 0x8d, 0x3d, 0x4e, 0x25, 0x80, 0x13, 0x70, 0xda, 0x16, 0xa0,
-	0x47, 0xb6, 0x33, 0x20, 0xbe, 0xe7, 0xdb, 0x4e, 0x10, 0x0a, 0xf3, 0xeb, 0x2c, 0x6c, 0x26, 0xd8,
-	0x52, 0x98, 0x57, 0x00, 0x91, 0x1e, 0x99, 0x28, 0xd9, 0x9d, 0xf2, 0xee, 0x93, 0x94, 0xa2, 0xcc,
-	0xc1, 0x6b, 0x34, 0x23, 0xb0, 0xb6, 0x13, 0xf8, 0x57, 0x38, 0x86, 0x8e, 0xbe, 0x82, 0xc2, 0x19,
-	0x31, 0x86, 0xc1, 0x59, 0x2d, 0x73, 0x5b, 0xd9, 0xa9, 0xee, 0x3e, 0xba, 0xc6, 0x3c, 0xfb, 0x1c,
-	0xa8, 0x17, 0x18, 0x01, 0xc1, 0x12, 0x15, 0x7d, 0x0c, 0x48, 0xfc, 0xd3, 0x2d, 0x42, 0x4d, 0xdf,
-	0xf6, 0x98, 0x4b, 0xd6, 0xb2, 0xb7, 0x95, 0x9d, 0x12, 0xde, 0x10, 0x2d, 0x7b, 0x93, 0x86, 0xba,
-	0x07, 0xeb, 0x53, 0xd2, 0x22, 0x15, 0xb2, 0xe7, 0xe4, 0x8a, 0x5b, 0xa4, 0x84, 0xd9, 0x5f, 0xf4,
-	0x18, 0xf2, 0x17, 0xc6, 0x70, 0x4c, 0xb8, 0xc8, 0xe5, 0xdd, 0x1f, 0xbc, 0xcd, 0x3d, 0xa4, 0x8b,
-	0x4e, 0xf4, 0x80, 0xc5, 0xf8, 0xfb, 0x99, 0xcf, 0x14, 0xed, 0x1e, 0x94, 0x63, 0x72, 0xa3, 0x2a,
-	0xc0, 0x49, 0x77, 0xaf, 0xdd, 0x6f, 0xb7, 0xfa, 0xed, 0x3d, 0x75, 0x05, 0xad, 0x41, 0xe9, 0xa4,
-	0xbb, 0xdf, 0x6e, 0x1e, 0xf4, 0xf7, 0x9f, 0xab, 0x0a, 0x2a, 0xc3, 0x6a, 0x48, 0x64, 0xb4, 0x4b,
-	0x40, 0x98, 0x98, 0xee, 0x05, 0xf1, 0x99, 0x23, 0x4b, 0xab, 0xa2, 0x9b, 0xb0, 0x1a, 0x18, 0xf4,
-	0x5c, 0xb7, 0x2d, 0x29, 0x73, 0x81, 0x91, 0x1d, 0x0b, 0x75, 0xa0, 0x70, 0x66, 0x38, 0xd6, 0xf0,
-	0xed, 0x72, 0x27, 0x55, 0xcd, 0xc0, 0xf7, 0xf9, 0x40, 0x2c, 0x01, 0x98, 0x77, 0x27, 0x66, 0x16,
-	0x06, 0xd0, 0x9e, 0x83, 0xda, 0x0b, 0x0c, 0x3f, 0x88, 0x8b, 0xd3, 0x86, 0x1c, 0x9b, 0x5f, 0x7a,
-	0xf4, 0x22, 0x73, 0x8a, 0x95, 0x89, 0xf9, 0x70, 0xed, 0xff, 0x32, 0xb0, 0x11, 0xc3, 0x96, 0x9e,
-	0xfa, 0x0c, 0x0a, 0x3e, 0xa1, 0xe3, 0x61, 0xc0, 0xe1, 0xab, 0xbb, 0x0f, 0x53, 0xc2, 0xcf, 0x20,
-	0x35, 0x30, 0x87, 0xc1, 0x12, 0x0e, 0xed, 0x80, 0x2a, 0x46, 0xe8, 0xc4, 0xf7, 0x5d, 0x5f, 0x1f,
-	0xd1, 0x01, 0xd7, 0x5a, 0x09, 0x57, 0x05, 0xbf, 0xcd, 0xd8, 0x87, 0x74, 0x10, 0xd3, 0x6a, 0xf6,
-	0x9a, 0x5a, 0x45, 0x06, 0xa8, 0x0e, 0x09, 0x5e, 0xbb, 0xfe, 0xb9, 0xce, 0x54, 0xeb, 0xdb, 0x16,
-	0xa9, 0xe5, 0x38, 0xe8, 0xa7, 0x29, 0x41, 0xbb, 0x62, 0xf8, 0x91, 0x1c, 0x8d, 0xd7, 0x9d, 0x24,
-	0x43, 0xfb, 0x3e, 0x14, 0xc4, 0x97, 0x32, 0x4f, 0xea, 0x9d, 0xb4, 0x5a, 0xed, 0x5e, 0x4f, 0x5d,
-	0x41, 0x25, 0xc8, 0xe3, 0x76, 0x1f, 0x33, 0x0f, 0x2b, 0x41, 0xfe, 0x51, 0xb3, 0xdf, 0x3c, 0x50,
-	0x33, 0xda, 0xf7, 0x60, 0xfd, 0x99, 0x61, 0x07, 0x69, 0x9c, 0x4b, 0x73, 0x41, 0x9d, 0xf4, 0x95,
-	0xd6, 0xe9, 0x24, 0xac, 0x93, 0x5e, 0x35, 0xed, 0x4b, 0x3b, 0x98, 0xb2, 0x87, 0x0a, 0x59, 0xe2,
-	0xfb, 0xd2, 0x04, 0xec, 0xaf, 0xf6, 0x1a, 0xd6, 0x7b, 0x81, 0xeb, 0xa5, 0xf2, 0xfc, 0x1f, 0xc2,
-	0x2a, 0xdb, 0x6d, 0xdc, 0x71, 0x20, 0x5d, 0xff, 0x56, 0x43, 0xec, 0x46, 0x8d, 0x70, 0x37, 0x6a,
-	0xec, 0xc9, 0xdd, 0x0a, 0x87, 0x3d, 0xd1, 0x0d, 0x28, 0x50, 0x7b, 0xe0, 0x18, 0x43, 0x19, 0x2d,
-	0x24, 0xa5, 0x21, 0xe6, 0xe4, 0xe1, 0xc4, 0xd2, 0xf1, 0x5b, 0x80, 0xf6, 0x08, 0x0d, 0x7c, 0xf7,
-	0x2a, 0x95, 0x3c, 0x5b, 0x90, 0x7f, 0xe9, 0xfa, 0xa6, 0x58, 0x88, 0x45, 0x2c, 0x08, 0xb6, 0xa8,
-	0x12, 0x20, 0x12, 0xfb, 0x63, 0x40, 0x1d, 0x87, 0xed, 0x29, 0xe9, 0x0c, 0xf1, 0x0f, 0x19, 0xd8,
-	0x4c, 0xf4, 0x97, 0xc6, 0x58, 0x7e, 0x1d, 0xb2, 0xc0, 0x34, 0xa6, 0x62, 0x1d, 0xa2, 0x23, 0x28,
-	0x88, 0x1e, 0x52, 0x93, 0x77, 0x17, 0x00, 0x12, 0xdb, 0x94, 0x84, 0x93, 0x30, 0x73, 0x9d, 0x3e,
-	0xfb, 0x6e, 0x9d, 0xfe, 0x35, 0xa8, 0xe1, 0x77, 0xd0, 0xb7, 0xda, 0xe6, 0x09, 0x6c, 0x9a, 0xee,
-	0x70, 0x48, 0x4c, 0xe6, 0x0d, 0xba, 0xed, 0x04, 0xc4, 0xbf, 0x30, 0x86, 0x6f, 0xf7, 0x1b, 0x34,
-	0x19, 0xd5, 0x91, 0x83, 0xb4, 0x17, 0xb0, 0x11, 0x9b, 0x58, 0x1a, 0xe2, 0x11, 0xe4, 0x29, 0x63,
-	0x48, 0x4b, 0x7c, 0xb2, 0xa0, 0x25, 0x28, 0x16, 0xc3, 0xb5, 0x4d, 0x01, 0xde, 0xbe, 0x20, 0x4e,
-	0xf4, 0x59, 0xda, 0x1e, 0x6c, 0xf4, 0xb8, 0x9b, 0xa6, 0xf2, 0xc3, 0x89, 0x8b, 0x67, 0x12, 0x2e,
-	0xbe, 0x05, 0x28, 0x8e, 0x22, 0x1d, 0xf1, 0x0a, 0xd6, 0xdb, 0x97, 0xc4, 0x4c, 0x85, 0x5c, 0x83,
-	0x55, 0xd3, 0x1d, 0x8d, 0x0c, 0xc7, 0xaa, 0x65, 0x6e, 0x67, 0x77, 0x4a, 0x38, 0x24, 0xe3, 0x6b,
-	0x31, 0x9b, 0x76, 0x2d, 0x6a, 0x7f, 0xa7, 0x80, 0x3a, 0x99, 0x5b, 0x2a, 0x92, 0x49, 0x1f, 0x58,
-	0x0c, 0x88, 0xcd, 0x5d, 0xc1, 0x92, 0x92, 0xfc, 0x30, 0x5c, 0x08, 0x3e, 0xf1, 0xfd, 0x58, 0x38,
-	0xca, 0x5e, 0x33, 0x1c, 0x69, 0xfb, 0xf0, 0x9d, 0x50, 0x9c, 0x5e, 0xe0, 0x13, 0x63, 0x64, 0x3b,
-	0x83, 0xce, 0xd1, 0x91, 0x47, 0x84, 0xe0, 0x08, 0x41, 0xce, 0x32, 0x02, 0x43, 0x0a, 0xc6, 0xff,
-	0xb3, 0x45, 0x6f, 0x0e, 0x5d, 0x1a, 0x2d, 0x7a, 0x4e, 0x68, 0xff, 0x99, 0x85, 0xda, 0x0c, 0x54,
-	0xa8, 0xde, 0x17, 0x90, 0xa7, 0x24, 0x18, 0x7b, 0xd2, 0x55, 0xda, 0xa9, 0x05, 0x9e, 0x8f, 0xd7,
-	0xe8, 0x31, 0x30, 0x2c, 0x30, 0xd1, 0x00, 0x8a, 0x41, 0x70, 0xa5, 0x53, 0xfb, 0xa7, 0x61, 0x42,
-	0x70, 0x70, 0x5d, 0xfc, 0x3e, 0xf1, 0x47, 0xb6, 0x63, 0x0c, 0x7b, 0xf6, 0x4f, 0x09, 0x5e, 0x0d,
-	0x82, 0x2b, 0xf6, 0x07, 0x3d, 0x67, 0x0e, 0x6f, 0xd9, 0x8e, 0x54, 0x7b, 0x6b, 0xd9, 0x59, 0x62,
-	0x0a, 0xc6, 0x02, 0xb1, 0x7e, 0x00, 0x79, 0xfe, 0x4d, 0xcb, 0x38, 0xa2, 0x0a, 0xd9, 0x20, 0xb8,
-	0xe2, 0x42, 0x15, 0x31, 0xfb, 0x5b, 0x7f, 0x00, 0x95, 0xf8, 0x17, 0x30, 0x47, 0x3a, 0x23, 0xf6,
-	0xe0, 0x4c, 0x38, 0x58, 0x1e, 0x4b, 0x8a, 0x59, 0xf2, 0xb5, 0x6d, 0xc9, 0x94, 0x35, 0x8f, 0x05,
-	0xa1, 0xfd, 0x5b, 0x06, 0x6e, 0xcd, 0xd1, 0x8c, 0x74, 0xd6, 0x17, 0x09, 0x67, 0x7d, 0x47, 0x5a,
-	0x08, 0x3d, 0xfe, 0x45, 0xc2, 0xe3, 0xdf, 0x21, 0x38, 0x5b, 0x36, 0x37, 0xa0, 0x40, 0x2e, 0xed,
-	0x80, 0x58, 0x52, 0x55, 0x92, 0x8a, 0x2d, 0xa7, 0xdc, 0x75, 0x97, 0xd3, 0x21, 0x6c, 0xb5, 0x7c,
-	0x62, 0x04, 0x44, 0x86, 0xf2, 0xd0, 0xff, 0x6f, 0x41, 0xd1, 0x18, 0x0e, 0x5d, 0x73, 0x62, 0xd6,
-	0x55, 0x4e, 0x77, 0x2c, 0x54, 0x87, 0xe2, 0x99, 0x4b, 0x03, 0xc7, 0x18, 0x11, 0x19, 0xbc, 0x22,
-	0x5a, 0xfb, 0x46, 0x81, 0xed, 0x29, 0x3c, 0x69, 0x85, 0x53, 0xa8, 0xda, 0xd4, 0x1d, 0xf2, 0x0f,
-	0xd4, 0x63, 0x27, 0xbc, 0x1f, 0x2d, 0xb6, 0xd5, 0x74, 0x42, 0x0c, 0x7e, 0xe0, 0x5b, 0xb3, 0xe3,
-	0x24, 0xf7, 0x38, 0x3e, 0xb9, 0x25, 0x57, 0x7a, 0x48, 0x6a, 0xff, 0xa8, 0xc0, 0xb6, 0xdc, 0xe1,
-	0xd3, 0x7f, 0xe8, 0xac, 0xc8, 0x99, 0x77, 0x2d, 0xb2, 0x56, 0x83, 0x1b, 0xd3, 0x72, 0xc9, 0x98,
-	0xff, 0xdf, 0x79, 0x40, 0xb3, 0xa7, 0x4b, 0xf4, 0x01, 0x54, 0x28, 0x71, 0x2c, 0x5d, 0xec, 0x17,
-	0x62, 0x2b, 0x2b, 0xe2, 0x32, 0xe3, 0x89, 0x8d, 0x83, 0xb2, 0x10, 0x48, 0x2e, 0xa5, 0xb4, 0x45,
-	0xcc, 0xff, 0xa3, 0x33, 0xa8, 0xbc, 0xa4, 0x7a, 0x34, 0x37, 0x77, 0xa8, 0x6a, 0xea, 0xb0, 0x36,
-	0x2b, 0x47, 0xe3, 0x51, 0x2f, 0xfa, 0x2e, 0x5c, 0x7e, 0x49, 0x23, 0x02, 0xfd, 0x5c, 0x81, 0x9b,
-	0x61, 0x5a, 0x31, 0x51, 0xdf, 0xc8, 0xb5, 0x08, 0xad, 0xe5, 0x6e, 0x67, 0x77, 0xaa, 0xbb, 0xc7,
-	0xd7, 0xd0, 0xdf, 0x0c, 0xf3, 0xd0, 0xb5, 0x08, 0xde, 0x76, 0xe6, 0x70, 0x29, 0x6a, 0xc0, 0xe6,
-	0x68, 0x4c, 0x03, 0x5d, 0x78, 0x81, 0x2e, 0x3b, 0xd5, 0xf2, 0x5c, 0x2f, 0x1b, 0xac, 0x29, 0xe1,
-	0xab, 0xe8, 0x1c, 0xd6, 0x46, 0xee, 0xd8, 0x09, 0x74, 0x93, 0x9f, 0x7f, 0x68, 0xad, 0xb0, 0xd0,
-	0xc1, 0x78, 0x8e, 0x96, 0x0e, 0x19, 0x9c, 0x38, 0x4d, 0x51, 0x5c, 0x19, 0xc5, 0x28, 0xf4, 0x7b,
-	0x70, 0xc3, 0xb2, 0xa9, 0x71, 0x3a, 0x24, 0xfa, 0xd0, 0x1d, 0xe8, 0x93, 0x1c, 0xa6, 0x56, 0xe4,
-	0xf2, 0x6d, 0xc9, 0xd6, 0x03, 0x77, 0xd0, 0x8a, 0xda, 0xf8, 0xa8, 0x2b, 0xc7, 0x18, 0xd9, 0xa6,
-	0xce, 0x44, 0x1e, 0xba, 0x86, 0xa5, 0x8f, 0x29, 0xf1, 0x69, 0xad, 0x24, 0x47, 0x89, 0xd6, 0x67,
-	0xb2, 0xf1, 0x84, 0xb5, 0x69, 0xf7, 0xa1, 0x1c, 0xb3, 0x17, 0x2a, 0x42, 0xae, 0x7b, 0xd4, 0x6d,
-	0xab, 0x2b, 0x08, 0xa0, 0xd0, 0xda, 0xc7, 0x47, 0x47, 0x7d, 0x71, 0xfc, 0xe8, 0x1c, 0x36, 0x1f,
-	0xb7, 0xd5, 0x0c, 0x63, 0x9f, 0x74, 0xff, 0xb0, 0xdd, 0x39, 0x50, 0xb3, 0x5a, 0x1b, 0x2a, 0xf1,
-	0xaf, 0x40, 0x08, 0xaa, 0x27, 0xdd, 0xa7, 0xdd, 0xa3, 0x67, 0x5d, 0xfd, 0xf0, 0xe8, 0xa4, 0xdb,
-	0x67, 0x87, 0x98, 0x2a, 0x40, 0xb3, 0xfb, 0x7c, 0x42, 0xaf, 0x41, 0xa9, 0x7b, 0x14, 0x92, 0x4a,
-	0x3d, 0xa3, 0x2a, 0x4f, 0x72, 0xc5, 0x55, 0xb5, 0x88, 0x2b, 0x3e, 0x19, 0xb9, 0x01, 0xd1, 0xd9,
-	0x16, 0x41, 0xb5, 0xff, 0xc8, 0xc2, 0xd6, 0x3c, 0x23, 0x23, 0x0b, 0x72, 0xcc, 0x61, 0xe4, 0xd1,
-	0xf2, 0xdd, 0xfb, 0x0b, 0x47, 0x67, 0xeb, 0xc4, 0x33, 0xe4, 0x5e, 0x52, 0xc2, 0xfc, 0x3f, 0xd2,
-	0xa1, 0x30, 0x34, 0x4e, 0xc9, 0x90, 0xd6, 0xb2, 0xfc, 0xf2, 0xe5, 0xf1, 0x75, 0xe6, 0x3e, 0xe0,
-	0x48, 0xe2, 0xe6, 0x45, 0xc2, 0xa2, 0x3e, 0x94, 0x59, 0xb4, 0xa4, 0x42, 0x9d, 0x32, 0x80, 0xef,
-	0xa6, 0x9c, 0x65, 0x7f, 0x32, 0x12, 0xc7, 0x61, 0xea, 0xf7, 0xa0, 0x1c, 0x9b, 0x6c, 0xce, 0xc5,
-	0xc9, 0x56, 0xfc, 0xe2, 0xa4, 0x14, 0xbf, 0x05, 0x79, 0x38, 0x6b, 0x03, 0xa6, 0x23, 0xe6, 0x24,
-	0xfb, 0x47, 0xbd, 0xbe, 0x38, 0xa2, 0x3e, 0xc6, 0x47, 0x27, 0xc7, 0xaa, 0xc2, 0x98, 0xfd, 0x66,
-	0xef, 0xa9, 0x9a, 0x89, 0x7c, 0x28, 0xab, 0xb5, 0xa0, 0x1c, 0x93, 0x2b, 0xb1, 0x3d, 0x28, 0xc9,
-	0xed, 0x81, 0x05, 0x68, 0xc3, 0xb2, 0x7c, 0x42, 0xa9, 0x94, 0x23, 0x24, 0xb5, 0x17, 0x50, 0xda,
-	0xeb, 0xf6, 0x24, 0x44, 0x0d, 0x56, 0x29, 0xf1, 0xd9, 0x77, 0xf3, 0x2b, 0xb0, 0x12, 0x0e, 0x49,
-	0x06, 0x4e, 0x89, 0xe1, 0x9b, 0x67, 0x84, 0xca, 0xa4, 0x22, 0xa2, 0xd9, 0x28, 0x97, 0x5f, 0x25,
-	0x09, 0xdb, 0x95, 0x70, 0x48, 0x6a, 0xff, 0x5f, 0x04, 0x98, 0x5c, 0x6b, 0xa0, 0x2a, 0x64, 0xa2,
-	0x60, 0x9f, 0xb1, 0x2d, 0xe6, 0x07, 0xb1, 0xcd, 0x8c, 0xff, 0x47, 0xbb, 0xb0, 0x3d, 0xa2, 0x03,
-	0xcf, 0x30, 0xcf, 0x75, 0x79, 0x1b, 0x21, 0x62, 0x02, 0x0f, 0x9c, 0x15, 0xbc, 0x29, 0x1b, 0xe5,
-	0x92, 0x17, 0xb8, 0x07, 0x90, 0x25, 0xce, 0x05, 0x0f, 0x72, 0xe5, 0xdd, 0xfb, 0x0b, 0x5f, 0xb7,
-	0x34, 0xda, 0xce, 0x85, 0xf0, 0x15, 0x06, 0x83, 0x74, 0x00, 0x8b, 0x5c, 0xd8, 0x26, 0xd1, 0x19,
-	0x68, 0x9e, 0x83, 0x7e, 0xb1, 0x38, 0xe8, 0x1e, 0xc7, 0x88, 0xa0, 0x4b, 0x56, 0x48, 0xa3, 0x2e,
-	0x94, 0x7c, 0x42, 0xdd, 0xb1, 0x6f, 0x12, 0x11, 0xe9, 0xd2, 0x9f, 0x88, 0x70, 0x38, 0x0e, 0x4f,
-	0x20, 0xd0, 0x1e, 0x14, 0x78, 0x80, 0xa3, 0xb5, 0x55, 0x2e, 0xec, 0x47, 0x29, 0xc1, 0x78, 0x74,
-	0xc1, 0x72, 0x2c, 0x7a, 0x0c, 0xab, 0x42, 0x44, 0x5a, 0x2b, 0x72, 0x98, 0x8f, 0xd3, 0x46, 0x5f,
-	0x3e, 0x0a, 0x87, 0xa3, 0x99, 0x55, 0x59, 0x60, 0xe4, 0x71, 0xb1, 0x84, 0xf9, 0x7f, 0xf4, 0x1e,
-	0x94, 0xc4, 0x66, 0x6f, 0xd9, 0x7e, 0x0d, 0x84, 0x73, 0x72, 0xc6, 0x9e, 0xed, 0xa3, 0xf7, 0xa1,
-	0x2c, 0x92, 0x3a, 0x9d, 0x47, 0x85, 0x32, 0x6f, 0x06, 0xc1, 0x3a, 0x66, 0xb1, 0x41, 0x74, 0x20,
-	0xbe, 0x2f, 0x3a, 0x54, 0xa2, 0x0e, 0xc4, 0xf7, 0x79, 0x87, 0xdf, 0x81, 0x75, 0x9e, 0x0a, 0x0f,
-	0x7c, 0x77, 0xec, 0xe9, 0xdc, 0xa7, 0xd6, 0x78, 0xa7, 0x35, 0xc6, 0x7e, 0xcc, 0xb8, 0x5d, 0xe6,
-	0x5c, 0xb7, 0xa0, 0xf8, 0xca, 0x3d, 0x15, 0x1d, 0xaa, 0x62, 0x1d, 0xbc, 0x72, 0x4f, 0xc3, 0xa6,
-	0x28, 0x1d, 0x59, 0x4f, 0xa6, 0x23, 0x5f, 0xc3, 0x8d, 0xd9, 0x7d, 0x95, 0xa7, 0x25, 0xea, 0xf5,
-	0xd3, 0x92, 0x2d, 0x67, 0x5e, 0x1c, 0xfe, 0x12, 0xb2, 0x96, 0x43, 0x6b, 0x1b, 0x0b, 0x39, 0x47,
-	0xb4, 0x8e, 0x31, 0x1b, 0x8c, 0xb6, 0xa1, 0xc0, 0x3e, 0xd6, 0xb6, 0x6a, 0x48, 0x84, 0x9e, 0x57,
-	0xee, 0x69, 0xc7, 0x42, 0xdf, 0x81, 0x12, 0xfb, 0x7e, 0xea, 0x19, 0x26, 0xa9, 0x6d, 0xf2, 0x96,
-	0x09, 0x83, 0x19, 0xca, 0x71, 0x2d, 0x22, 0x54, 0xb4, 0x25, 0x0c, 0xc5, 0x18, 0x5c, 0x47, 0x37,
-	0x61, 0x95, 0x37, 0xda, 0x56, 0x6d, 0x5b, 0x9c, 0x38, 0x18, 0xd9, 0xb1, 0x90, 0x06, 0x6b, 0x9e,
-	0xe1, 0x13, 0x27, 0xd0, 0xe5, 0x8c, 0x37, 0x78, 0x73, 0x59, 0x30, 0x9f, 0xb0, 0x79, 0xeb, 0x9f,
-	0x42, 0x31, 0x5c, 0x0c, 0x8b, 0x84, 0xc9, 0xfa, 0x03, 0xa8, 0x26, 0x97, 0xd2, 0x42, 0x41, 0xf6,
-	0x9f, 0x33, 0x50, 0x8a, 0x16, 0x0d, 0x72, 0x60, 0x93, 0x1b, 0x95, 0xa5, 0xa6, 0xfa, 0x64, 0x0d,
-	0x8a, 0x84, 0xf8, 0xf3, 0x94, 0x6a, 0x6e, 0x86, 0x08, 0xf2, 0x64, 0x2e, 0x17, 0x24, 0x8a, 0x90,
-	0x27, 0xf3, 0x7d, 0x05, 0xeb, 0x43, 0xdb, 0x19, 0x5f, 0xc6, 0xe6, 0x12, 0x99, 0xec, 0xef, 0xa7,
-	0x9c, 0xeb, 0x80, 0x8d, 0x9e, 0xcc, 0x51, 0x1d, 0x26, 0x68, 0xb4, 0x0f, 0x79, 0xcf, 0xf5, 0x83,
-	0x70, 0xcf, 0x4c, 0xbb, 0x9b, 0x1d, 0xbb, 0x7e, 0x70, 0x68, 0x78, 0x1e, 0x3b, 0xac, 0x09, 0x00,
-	0xed, 0x9b, 0x0c, 0xdc, 0x98, 0xff, 0x61, 0xa8, 0x0b, 0x59, 0xd3, 0x1b, 0x4b, 0x25, 0x3d, 0x58,
-	0x54, 0x49, 0x2d, 0x6f, 0x3c, 0x91, 0x9f, 0x01, 0xa1, 0x67, 0x50, 0x18, 0x91, 0x91, 0xeb, 0x5f,
-	0x49, 0x5d, 0x3c, 0x5c, 0x14, 0xf2, 0x90, 0x8f, 0x9e, 0xa0, 0x4a, 0x38, 0x84, 0xa1, 0x28, 0x17,
-	0x13, 0x95, 0x61, 0x7b, 0xc1, 0xeb, 0xb4, 0x10, 0x12, 0x47, 0x38, 0xda, 0xa7, 0xb0, 0x3d, 0xf7,
-	0x53, 0xd0, 0x6f, 0x01, 0x98, 0xde, 0x58, 0xe7, 0xcf, 0x1d, 0xc2, 0x83, 0xb2, 0xb8, 0x64, 0x7a,
-	0xe3, 0x1e, 0x67, 0x68, 0x2f, 0xa0, 0xf6, 0x26, 0x79, 0xd9, 0x1a, 0x13, 0x12, 0xeb, 0xa3, 0x53,
-	0xae, 0x83, 0x2c, 0x2e, 0x0a, 0xc6, 0xe1, 0x29, 0x5b, 0x4a, 0x61, 0xa3, 0x71, 0xc9, 0x3a, 0x64,
-	0x79, 0x87, 0xb2, 0xec, 0x60, 0x5c, 0x1e, 0x9e, 0x6a, 0xbf, 0xc8, 0xc0, 0xfa, 0x94, 0xc8, 0xec,
-	0xc8, 0x2a, 0x02, 0x70, 0x78, 0x19, 0x20, 0x28, 0x16, 0x8d, 0x4d, 0xdb, 0x0a, 0xaf, 0x91, 0xf9,
-	0x7f, 0xbe, 0x0f, 0x7b, 0xf2, 0x8a, 0x37, 0x63, 0x7b, 0x6c, 0xf9, 0x8c, 0x4e, 0xed, 0x80, 0xf2,
-	0xa4, 0x28, 0x8f, 0x05, 0x81, 0x9e, 0x43, 0xd5, 0x27, 0x7c, 0xff, 0xb7, 0x74, 0xe1, 0x65, 0xf9,
-	0x85, 0xbc, 0x4c, 0x4a, 0xc8, 0x9c, 0x0d, 0xaf, 0x85, 0x48, 0x8c, 0xa2, 0xe8, 0x19, 0xac, 0x85,
-	0xc9, 0xb4, 0x40, 0x2e, 0x2c, 0x8d, 0x5c, 0x91, 0x40, 0x1c, 0x58, 0xbb, 0x07, 0xe5, 0x58, 0x23,
-	0xfb, 0x30, 0x9e, 0xfd, 0x49, 0x9d, 0x08, 0x22, 0x19, 0x2d, 0xf2, 0x32, 0x5a, 0x68, 0xa7, 0x50,
-	0x8e, 0xad, 0x8b, 0x45, 0x86, 0x32, 0x7d, 0x06, 0x2e, 0xd7, 0x67, 0x1e, 0x67, 0x02, 0x97, 0xc5,
-	0x49, 0x96, 0x79, 0xe9, 0xb6, 0xc7, 0x35, 0x5a, 0xc2, 0x05, 0x46, 0x76, 0x3c, 0xed, 0x57, 0x19,
-	0xa8, 0x26, 0x97, 0x74, 0xe8, 0x47, 0x1e, 0xf1, 0x6d, 0xd7, 0x8a, 0xf9, 0xd1, 0x31, 0x67, 0x30,
-	0x5f, 0x61, 0xcd, 0x5f, 0x8f, 0xdd, 0xc0, 0x08, 0x7d, 0xc5, 0xf4, 0xc6, 0x7f, 0xc0, 0xe8, 0x29,
-	0x1f, 0xcc, 0x4e, 0xf9, 0x20, 0xfa, 0x08, 0x90, 0x74, 0xa5, 0xa1, 0x3d, 0xb2, 0x03, 0xfd, 0xf4,
-	0x2a, 0x20, 0xc2, 0xc6, 0x59, 0xac, 0x8a, 0x96, 0x03, 0xd6, 0xf0, 0x25, 0xe3, 0x33, 0xc7, 0x73,
-	0xdd, 0x91, 0x4e, 0x4d, 0xd7, 0x27, 0xba, 0x61, 0xbd, 0xe2, 0xa7, 0xb5, 0x2c, 0x2e, 0xbb, 0xee,
-	0xa8, 0xc7, 0x78, 0x4d, 0xeb, 0x15, 0xdb, 0x88, 0x4d, 0x6f, 0x4c, 0x49, 0xa0, 0xb3, 0x1f, 0x9e,
-	0xbb, 0x94, 0x30, 0x08, 0x56, 0xcb, 0x1b, 0x53, 0xf4, 0x5d, 0x58, 0x0b, 0x3b, 0xf0, 0xbd, 0x58,
-	0x26, 0x01, 0x15, 0xd9, 0x85, 0xf3, 0x90, 0x06, 0x95, 0x63, 0xe2, 0x9b, 0xc4, 0x09, 0xfa, 0xb6,
-	0x79, 0x4e, 0xf9, 0xb1, 0x4b, 0xc1, 0x09, 0x9e, 0x3c, 0xb5, 0x84, 0xb3, 0x8d, 0xc8, 0x88, 0x6a,
-	0xff, 0xaa, 0x40, 0x9e, 0xa7, 0x2c, 0x4c, 0x29, 0x7c, 0xbb, 0xe7, 0xd9, 0x80, 0x4c, 0x75, 0x19,
-	0x83, 0xe7, 0x02, 0xef, 0x41, 0x89, 0x2b, 0x3f, 0x76, 0xc2, 0xe0, 0x79, 0x30, 0x6f, 0xac, 0x43,
-	0xd1, 0x27, 0x86, 0xe5, 0x3a, 0xc3, 0xf0, 0x16, 0x2c, 0xa2, 0xd1, 0xef, 0x82, 0xea, 0xf9, 0xae,
-	0x67, 0x0c, 0x26, 0x07, 0x67, 0x69, 0xbe, 0xf5, 0x18, 0x9f, 0xa7, 0xe8, 0xdf, 0x85, 0x35, 0x4a,
-	0x44, 0x64, 0x17, 0x4e, 0x92, 0x17, 0x9f, 0x29, 0x99, 0xfc, 0x44, 0xa0, 0x7d, 0x0d, 0x05, 0xb1,
-	0x71, 0x5d, 0x43, 0xde, 0x8f, 0x01, 0x09, 0x45, 0x32, 0x07, 0x19, 0xd9, 0x94, 0xca, 0x2c, 0x9b,
-	0x3f, 0xe5, 0x8a, 0x96, 0xe3, 0x49, 0x83, 0xf6, 0x5f, 0x8a, 0xc8, 0xb7, 0xc5, 0x23, 0x1b, 0x4b,
-	0xcc, 0xd9, 0xaa, 0x61, 0x47, 0x5b, 0x71, 0x9b, 0x17, 0x92, 0xa8, 0x03, 0x05, 0x99, 0x56, 0x67,
-	0x96, 0x7d, 0xa3, 0x94, 0x00, 0xe1, 0xdd, 0x3e, 0x91, 0x37, 0x1b, 0x8b, 0xde, 0xed, 0x13, 0x71,
-	0xb7, 0x4f, 0xd0, 0x07, 0x50, 0x91, 0x09, 0xbf, 0x80, 0xcb, 0xf1, 0x7c, 0xbf, 0x6c, 0x45, 0x0f,
-	0x28, 0x44, 0xfb, 0x5f, 0x25, 0x8a, 0x7b, 0xe1, 0x43, 0x07, 0xfa, 0x0a, 0x8a, 0x2c, 0x84, 0xe8,
-	0x23, 0xc3, 0x93, 0xcf, 0xf6, 0xad, 0xe5, 0xde, 0x50, 0xc2, 0x5d, 0x51, 0xa4, 0xeb, 0xab, 0x9e,
-	0xa0, 0x58, 0xfc, 0x64, 0x47, 0xa5, 0x30, 0x7e, 0xb2, 0xff, 0xe8, 0x43, 0xa8, 0x1a, 0xe3, 0xc0,
-	0xd5, 0x0d, 0xeb, 0x82, 0xf8, 0x81, 0x4d, 0x89, 0xf4, 0xa5, 0x35, 0xc6, 0x6d, 0x86, 0xcc, 0xfa,
-	0x7d, 0xa8, 0xc4, 0x31, 0xdf, 0x96, 0xb7, 0xe4, 0xe3, 0x79, 0xcb, 0x9f, 0x02, 0x4c, 0x2e, 0x0d,
-	0x99, 0x8f, 0x90, 0x4b, 0x3b, 0xd0, 0xcd, 0xf0, 0x6c, 0x9e, 0xc7, 0x45, 0xc6, 0x68, 0x31, 0x67,
-	0x4c, 0xbe, 0x68, 0xe4, 0xc3, 0x17, 0x0d, 0x16, 0x1d, 0xd8, 0x82, 0x3e, 0xb7, 0x87, 0xc3, 0xe8,
-	0x22, 0xb3, 0xe4, 0xba, 0xa3, 0xa7, 0x9c, 0xa1, 0xfd, 0x3a, 0x23, 0x7c, 0x45, 0xbc, 0x4d, 0xa5,
-	0x3a, 0x9b, 0xbd, 0x2b, 0x53, 0xdf, 0x03, 0xa0, 0x81, 0xe1, 0xb3, 0x24, 0xcc, 0x08, 0xaf, 0x52,
-	0xeb, 0x33, 0x4f, 0x22, 0xfd, 0xb0, 0x58, 0x06, 0x97, 0x64, 0xef, 0x66, 0x80, 0x3e, 0x87, 0x8a,
-	0xe9, 0x8e, 0xbc, 0x21, 0x91, 0x83, 0xf3, 0x6f, 0x1d, 0x5c, 0x8e, 0xfa, 0x37, 0x83, 0xd8, 0x05,
-	0x6e, 0xe1, 0xba, 0x17, 0xb8, 0xbf, 0x52, 0xc4, 0x13, 0x5b, 0xfc, 0x85, 0x0f, 0x0d, 0xe6, 0x94,
-	0x91, 0x3c, 0x5e, 0xf2, 0xb9, 0xf0, 0xdb, 0x6a, 0x48, 0xea, 0x9f, 0xa7, 0x29, 0xda, 0x78, 0x73,
-	0x5a, 0xfc, 0xef, 0x59, 0x28, 0x45, 0xaf, 0x6b, 0x33, 0xb6, 0xff, 0x0c, 0x4a, 0x51, 0xa5, 0x92,
-	0x0c, 0x10, 0xdf, 0x6a, 0x9e, 0xa8, 0x33, 0x7a, 0x09, 0xc8, 0x18, 0x0c, 0xa2, 0x74, 0x57, 0x1f,
-	0x53, 0x63, 0x10, 0xbe, 0x6d, 0x7e, 0xb6, 0x80, 0x1e, 0xc2, 0xfd, 0xf1, 0x84, 0x8d, 0xc7, 0xaa,
-	0x31, 0x18, 0x24, 0x38, 0xe8, 0xcf, 0x60, 0x3b, 0x39, 0x87, 0x7e, 0x7a, 0xa5, 0x7b, 0xb6, 0x25,
-	0xef, 0x00, 0xf6, 0x17, 0x7d, 0x60, 0x6c, 0x24, 0xe0, 0xbf, 0xbc, 0x3a, 0xb6, 0x2d, 0xa1, 0x73,
-	0xe4, 0xcf, 0x34, 0xd4, 0xff, 0x02, 0x6e, 0xbe, 0xa1, 0xfb, 0x1c, 0x1b, 0x74, 0x93, 0x85, 0x33,
-	0xcb, 0x2b, 0x21, 0x66, 0xbd, 0x5f, 0x2a, 0xe2, 0x1d, 0x34, 0xa9, 0x93, 0x66, 0x3c, 0x4f, 0xbf,
-	0x93, 0x72, 0x9e, 0xd6, 0xf1, 0x89, 0x80, 0xe7, 0xa9, 0xf9, 0x93, 0xa9, 0xd4, 0x3c, 0x6d, 0x42,
-	0x26, 0x32, 0x5c, 0x01, 0x24, 0x11, 0xb4, 0x7f, 0xc9, 0x42, 0x31, 0x44, 0xe7, 0x27, 0xf8, 0x2b,
-	0x1a, 0x90, 0x91, 0x1e, 0x5d, 0x2f, 0x2a, 0x18, 0x04, 0x8b, 0xef, 0xa8, 0xef, 0x41, 0x69, 0x4c,
-	0x89, 0x2f, 0x9a, 0x33, 0xbc, 0xb9, 0xc8, 0x18, 0xbc, 0xf1, 0x7d, 0x28, 0x07, 0x6e, 0x60, 0x0c,
-	0xf5, 0x80, 0xe7, 0x0b, 0x59, 0x31, 0x9a, 0xb3, 0x78, 0xb6, 0x80, 0xbe, 0x0f, 0x1b, 0xc1, 0x99,
-	0xef, 0x06, 0xc1, 0x90, 0xe5, 0xaa, 0x3c, 0x73, 0x12, 0x89, 0x4e, 0x0e, 0xab, 0x51, 0x83, 0xc8,
-	0xa8, 0x28, 0x8b, 0xde, 0x93, 0xce, 0xcc, 0x75, 0x79, 0x10, 0xc9, 0xe1, 0xb5, 0x88, 0xcb, 0x5c,
-	0x9b, 0x6d, 0x9e, 0x9e, 0xc8, 0x48, 0x78, 0xac, 0x50, 0x70, 0x48, 0x22, 0x1d, 0xd6, 0x47, 0xc4,
-	0xa0, 0x63, 0x9f, 0x58, 0xfa, 0x4b, 0x9b, 0x0c, 0x2d, 0x71, 0xf1, 0x52, 0x4d, 0x7d, 0xdc, 0x08,
-	0xd5, 0xd2, 0x78, 0xc4, 0x47, 0xe3, 0x6a, 0x08, 0x27, 0x68, 0x96, 0x39, 0x88, 0x7f, 0x68, 0x1d,
-	0xca, 0xbd, 0xe7, 0xbd, 0x7e, 0xfb, 0x50, 0x3f, 0x3c, 0xda, 0x6b, 0xcb, 0xda, 0xa8, 0x5e, 0x1b,
-	0x0b, 0x52, 0x61, 0xed, 0xfd, 0xa3, 0x7e, 0xf3, 0x40, 0xef, 0x77, 0x5a, 0x4f, 0x7b, 0x6a, 0x06,
-	0x6d, 0xc3, 0x46, 0x7f, 0x1f, 0x1f, 0xf5, 0xfb, 0x07, 0xed, 0x3d, 0xfd, 0xb8, 0x8d, 0x3b, 0x47,
-	0x7b, 0x3d, 0x35, 0x8b, 0x10, 0x54, 0x27, 0xec, 0x7e, 0xe7, 0xb0, 0xad, 0xe6, 0x50, 0x19, 0x56,
-	0x8f, 0xdb, 0xb8, 0xd5, 0xee, 0xf6, 0xd5, 0xbc, 0xf6, 0x8b, 0x2c, 0x94, 0x63, 0x56, 0x64, 0x8e,
-	0xec, 0x53, 0x71, 0xae, 0xc9, 0x61, 0xf6, 0x97, 0xbf, 0xe5, 0x1a, 0xe6, 0x99, 0xb0, 0x4e, 0x0e,
-	0x0b, 0x82, 0x9f, 0x65, 0x8c, 0xcb, 0xd8, 0x3a, 0xcf, 0xe1, 0xe2, 0xc8, 0xb8, 0x14, 0x20, 0x1f,
-	0x40, 0xe5, 0x9c, 0xf8, 0x0e, 0x19, 0xca, 0x76, 0x61, 0x91, 0xb2, 0xe0, 0x89, 0x2e, 0x3b, 0xa0,
-	0xca, 0x2e, 0x13, 0x18, 0x61, 0x8e, 0xaa, 0xe0, 0x1f, 0x86, 0x60, 0x5b, 0x90, 0x17, 0xcd, 0xab,
-	0x62, 0x7e, 0x4e, 0xb0, 0x6d, 0x8a, 0xbe, 0x36, 0x3c, 0x9e, 0x43, 0xe6, 0x30, 0xff, 0x8f, 0x4e,
-	0x67, 0xed, 0x53, 0xe0, 0xf6, 0xb9, 0xb7, 0xb8, 0x3b, 0xbf, 0xc9, 0x44, 0x67, 0x91, 0x89, 0x56,
-	0x21, 0x8b, 0xc3, 0x82, 0xa2, 0x56, 0xb3, 0xb5, 0xcf, 0xcc, 0xb2, 0x06, 0xa5, 0xc3, 0xe6, 0x4f,
-	0xf4, 0x93, 0x9e, 0xb8, 0xd5, 0x57, 0xa1, 0xf2, 0xb4, 0x8d, 0xbb, 0xed, 0x03, 0xc9, 0xc9, 0xa2,
-	0x2d, 0x50, 0x25, 0x67, 0xd2, 0x2f, 0xc7, 0x10, 0xc4, 0xdf, 0x3c, 0x2a, 0x42, 0xae, 0xf7, 0xac,
-	0x79, 0xac, 0x16, 0xb4, 0xff, 0xc9, 0xc0, 0xba, 0xd8, 0x16, 0xa2, 0xd2, 0x87, 0x37, 0x3f, 0xfd,
-	0xc6, 0x6f, 0xb1, 0x32, 0xc9, 0x5b, 0xac, 0x30, 0x09, 0xe5, 0xbb, 0x7a, 0x76, 0x92, 0x84, 0xf2,
-	0x9b, 0x9d, 0x44, 0xc4, 0xcf, 0x2d, 0x12, 0xf1, 0x6b, 0xb0, 0x3a, 0x22, 0x34, 0xb2, 0x5b, 0x09,
-	0x87, 0x24, 0xb2, 0xa1, 0x6c, 0x38, 0x8e, 0x1b, 0x18, 0xe2, 0x6a, 0xb8, 0xb0, 0xd0, 0x66, 0x38,
-	0xf5, 0xc5, 0x8d, 0xe6, 0x04, 0x49, 0x04, 0xe6, 0x38, 0x76, 0xfd, 0xc7, 0xa0, 0x4e, 0x77, 0x58,
-	0x64, 0x3b, 0xfc, 0xde, 0x0f, 0x26, 0xbb, 0x21, 0x61, 0xeb, 0x42, 0xbe, 0xb3, 0xa8, 0x2b, 0x8c,
-	0xc0, 0x27, 0xdd, 0x6e, 0xa7, 0xfb, 0x58, 0x55, 0x10, 0x40, 0xa1, 0xfd, 0x93, 0x4e, 0xbf, 0xbd,
-	0xa7, 0x66, 0x76, 0x7f, 0xb9, 0x01, 0x05, 0x21, 0x24, 0xfa, 0x46, 0x66, 0x02, 0xf1, 0xb2, 0x5a,
-	0xf4, 0xe3, 0x85, 0x33, 0xea, 0x44, 0xa9, 0x6e, 0xfd, 0xe1, 0xd2, 0xe3, 0xe5, 0x33, 0xe6, 0x0a,
-	0xfa, 0x1b, 0x05, 0x2a, 0x89, 0x27, 0xcc, 0xb4, 0x57, 0xe3, 0x73, 0xaa, 0x78, 0xeb, 0x3f, 0x5a,
-	0x6a, 0x6c, 0x24, 0xcb, 0xcf, 0x15, 0x28, 0xc7, 0xea, 0x57, 0xd1, 0xbd, 0x65, 0x6a, 0x5e, 0x85,
-	0x24, 0xf7, 0x97, 0x2f, 0x97, 0xd5, 0x56, 0x3e, 0x51, 0xd0, 0x5f, 0x2b, 0x50, 0x8e, 0x55, 0x72,
-	0xa6, 0x16, 0x65, 0xb6, 0xee, 0x34, 0xb5, 0x28, 0xf3, 0x0a, 0x47, 0x57, 0xd0, 0x5f, 0x2a, 0x50,
-	0x8a, 0xaa, 0x32, 0xd1, 0xdd, 0xc5, 0xeb, 0x38, 0x85, 0x10, 0x9f, 0x2d, 0x5b, 0x00, 0xaa, 0xad,
-	0xa0, 0x3f, 0x87, 0x62, 0x58, 0xc2, 0x88, 0xd2, 0xee, 0x5e, 0x53, 0xf5, 0x91, 0xf5, 0xbb, 0x0b,
-	0x8f, 0x8b, 0x4f, 0x1f, 0xd6, 0x15, 0xa6, 0x9e, 0x7e, 0xaa, 0x02, 0xb2, 0x7e, 0x77, 0xe1, 0x71,
-	0xd1, 0xf4, 0xcc, 0x13, 0x62, 0xe5, 0x87, 0xa9, 0x3d, 0x61, 0xb6, 0xee, 0x31, 0xb5, 0x27, 0xcc,
-	0xab, 0x76, 0x14, 0x82, 0xc4, 0x0a, 0x18, 0x53, 0x0b, 0x32, 0x5b, 0x24, 0x99, 0x5a, 0x90, 0x39,
-	0xf5, 0x92, 0xda, 0x0a, 0xfa, 0x99, 0x12, 0x3f, 0x17, 0xdc, 0x5d, 0xb8, 0x4e, 0x6f, 0x41, 0x97,
-	0x9c, 0xa9, 0x14, 0xe4, 0x0b, 0xf4, 0x67, 0xf2, 0x16, 0x43, 0x94, 0xf9, 0xa1, 0x45, 0xc0, 0x12,
-	0x95, 0x81, 0xf5, 0x4f, 0x97, 0xdb, 0x6c, 0xb8, 0x10, 0x7f, 0xa5, 0x00, 0x4c, 0x0a, 0x02, 0x53,
-	0x0b, 0x31, 0x53, 0x89, 0x58, 0xbf, 0xb7, 0xc4, 0xc8, 0xf8, 0x02, 0x09, 0x0b, 0x96, 0x52, 0x2f,
-	0x90, 0xa9, 0x82, 0xc5, 0xd4, 0x0b, 0x64, 0xba, 0xd8, 0x50, 0x5b, 0x41, 0xff, 0xa4, 0xc0, 0xc6,
-	0x4c, 0xc1, 0x14, 0x7a, 0x78, 0xcd, 0x9a, 0xb9, 0xfa, 0x17, 0xcb, 0x03, 0x84, 0xa2, 0xed, 0x28,
-	0x9f, 0x28, 0xe8, 0x6f, 0x15, 0x58, 0x4b, 0x16, 0x92, 0xa4, 0xde, 0xa5, 0xe6, 0x94, 0x5e, 0xd5,
-	0x1f, 0x2c, 0x37, 0x38, 0xd2, 0xd6, 0xdf, 0x2b, 0x50, 0x4d, 0xd6, 0x14, 0xa1, 0x07, 0x8b, 0x85,
-	0x85, 0x29, 0x81, 0x3e, 0x5f, 0x72, 0x74, 0x28, 0xd1, 0x97, 0xab, 0x7f, 0x94, 0x17, 0xd9, 0x5b,
-	0x81, 0xff, 0xfc, 0xf0, 0x37, 0x01, 0x00, 0x00, 0xff, 0xff, 0x4f, 0xda, 0xef, 0xe8, 0xfd, 0x34,
+	// 4546 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x7b, 0xcd, 0x73, 0x1b, 0xc9,
+	0x75, 0xb8, 0x06, 0xdf, 0x78, 0x00, 0x49, 0xa8, 0x49, 0x6a, 0x21, 0xac, 0xed, 0x95, 0xc7, 0xb5,
+	0xbf, 0x9f, 0x62, 0xef, 0x62, 0xb5, 0xdc, 0x44, 0x5a, 0xc9, 0xbb, 0xde, 0x85, 0x40, 0x48, 0x84,
+	0x44, 0x82, 0x4c, 0x03, 0x8c, 0xac, 0x28, 0xd9, 0xc9, 0x10, 0xd3, 0x02, 0x47, 0x04, 0x66, 0x66,
+	0xa7, 0x1b, 0x14, 0xe9, 0x54, 0xca, 0x2e, 0xa7, 0xb2, 0xb5, 0xa9, 0x72, 0x2a, 0xb9, 0x6c, 0x52,
+	0x95, 0xca, 0x2d, 0x95, 0x63, 0x2a, 0xd7, 0x54, 0x52, 0x3e, 0xa4, 0x7c, 0xc8, 0x3f, 0xe1, 0x4b,
+	0x6e, 0xb9, 0x26, 0xff, 0x40, 0x52, 0xfd, 0x35, 0x98, 0x21, 0x20, 0x0b, 0x00, 0x95, 0x13, 0xd0,
+	0xef, 0xf5, 0xfb, 0x98, 0xd7, 0xaf, 0x5f, 0xbf, 0x7e, 0xdd, 0x0d, 0x66, 0x30, 0x1c, 0x0f, 0x5c,
+	0x8f, 0x7e, 0xe0, 0x84, 0xee, 0x29, 0x09, 0xe9, 0x07, 0x41, 0xe8, 0x33, 0x5f, 0xb5, 0xea, 0xa2,
+	0x81, 0xde, 0x3d, 0xb6, 0xe9, 0xb1, 0xdb, 0xf7, 0xc3, 0xa0, 0xee, 0xf9, 0x23, 0xdb, 0xa9, 0x2b,
+	0x9a, 0xba, 0xa2, 0x91, 0xdd, 0x6a, 0xdf, 0x19, 0xf8, 0xfe, 0x60, 0x48, 0x24, 0x87, 0xa3, 0xf1,
+	0xf3, 0x0f, 0x9c, 0x71, 0x68, 0x33, 0xd7, 0xf7, 0x14, 0xfe, 0x9d, 0x8b, 0x78, 0xe6, 0x8e, 0x08,
+	0x65, 0xf6, 0x28, 0x50, 0x1d, 0xde, 0xd5, 0xba, 0xd0, 0x63, 0x3b, 0x24, 0xce, 0x07, 0xc7, 0xfd,
+	0x21, 0x0d, 0x48, 0x9f, 0xff, 0x5a, 0xfc, 0x8f, 0xea, 0xf6, 0xde, 0x85, 0x6e, 0x94, 0x85, 0xe3,
+	0x3e, 0xd3, 0x9a, 0xdb, 0x8c, 0x85, 0xee, 0xd1, 0x98, 0x11, 0xd9, 0xdb, 0xbc, 0x0e, 0x6f, 0xf5,
+	0x6c, 0x7a, 0xd2, 0xf4, 0xbd, 0xe7, 0xee, 0xa0, 0xdb, 0x3f, 0x26, 0x23, 0x1b, 0x93, 0x2f, 0xc7,
+	0x84, 0x32, 0xf3, 0x0f, 0xa0, 0x3a, 0x8d, 0xa2, 0x81, 0xef, 0x51, 0x82, 0x3e, 0x87, 0x0c, 0x17,
+	0x59, 0x35, 0x6e, 0x18, 0x37, 0x4b, 0x5b, 0xef, 0xd5, 0x5f, 0x65, 0x02, 0xa9, 0x43, 0x5d, 0xa9,
+	0x5a, 0xef, 0x06, 0xa4, 0x8f, 0x05, 0xa5, 0xb9, 0x09, 0xeb, 0x4d, 0x3b, 0xb0, 0x8f, 0xdc, 0xa1,
+	0xcb, 0x5c, 0x42, 0xb5, 0xd0, 0x31, 0x6c, 0x24, 0xc1, 0x4a, 0xe0, 0x1f, 0x42, 0xb9, 0x1f, 0x83,
+	0x2b, 0xc1, 0x77, 0xeb, 0x73, 0xd9, 0xbe, 0xbe, 0x2d, 0x5a, 0x09, 0xc6, 0x09, 0x76, 0xe6, 0x06,
+	0xa0, 0x07, 0xae, 0x37, 0x20, 0x61, 0x10, 0xba, 0x1e, 0xd3, 0xca, 0xfc, 0x32, 0x0d, 0xeb, 0x09,
+	0xb0, 0x52, 0xe6, 0x05, 0x40, 0x64, 0x47, 0xae, 0x4a, 0xfa, 0x66, 0x69, 0xeb, 0xd1, 0x9c, 0xaa,
+	0xcc, 0xe0, 0x57, 0x6f, 0x44, 0xcc, 0x5a, 0x1e, 0x0b, 0xcf, 0x71, 0x8c, 0x3b, 0xfa, 0x02, 0x72,
+	0xc7, 0xc4, 0x1e, 0xb2, 0xe3, 0x6a, 0xea, 0x86, 0x71, 0x73, 0x75, 0xeb, 0xc1, 0x25, 0xe4, 0xec,
+	0x08, 0x46, 0x5d, 0x66, 0x33, 0x82, 0x15, 0x57, 0xf4, 0x3e, 0x20, 0xf9, 0xcf, 0x72, 0x08, 0xed,
+	0x87, 0x6e, 0xc0, 0x5d, 0xb2, 0x9a, 0xbe, 0x61, 0xdc, 0x2c, 0xe2, 0xab, 0x12, 0xb3, 0x3d, 0x41,
+	0xd4, 0x02, 0x58, 0xbb, 0xa0, 0x2d, 0xaa, 0x40, 0xfa, 0x84, 0x9c, 0x8b, 0x11, 0x29, 0x62, 0xfe,
+	0x17, 0x3d, 0x84, 0xec, 0xa9, 0x3d, 0x1c, 0x13, 0xa1, 0x72, 0x69, 0xeb, 0xc3, 0xd7, 0xb9, 0x87,
+	0x72, 0xd1, 0x89, 0x1d, 0xb0, 0xa4, 0xbf, 0x97, 0xfa, 0xd8, 0x30, 0xef, 0x42, 0x29, 0xa6, 0x37,
+	0x5a, 0x05, 0x38, 0xec, 0x6c, 0xb7, 0x7a, 0xad, 0x66, 0xaf, 0xb5, 0x5d, 0xb9, 0x82, 0x56, 0xa0,
+	0x78, 0xd8, 0xd9, 0x69, 0x35, 0x76, 0x7b, 0x3b, 0x4f, 0x2b, 0x06, 0x2a, 0x41, 0x5e, 0x37, 0x52,
+	0xe6, 0x19, 0x20, 0x4c, 0xfa, 0xfe, 0x29, 0x09, 0xb9, 0x23, 0xab, 0x51, 0x45, 0x6f, 0x41, 0x9e,
+	0xd9, 0xf4, 0xc4, 0x72, 0x1d, 0xa5, 0x73, 0x8e, 0x37, 0xdb, 0x0e, 0x6a, 0x43, 0xee, 0xd8, 0xf6,
+	0x9c, 0xe1, 0xeb, 0xf5, 0x4e, 0x9a, 0x9a, 0x33, 0xdf, 0x11, 0x84, 0x58, 0x31, 0xe0, 0xde, 0x9d,
+	0x90, 0x2c, 0x07, 0xc0, 0x7c, 0x0a, 0x95, 0x2e, 0xb3, 0x43, 0x16, 0x57, 0xa7, 0x05, 0x19, 0x2e,
+	0xbf, 0x6a, 0x2c, 0x2c, 0x53, 0xce, 0x4c, 0x2c, 0xc8, 0xcd, 0xff, 0x4a, 0xc1, 0xd5, 0x18, 0x6f,
+	0xe5, 0xa9, 0x4f, 0x20, 0x17, 0x12, 0x3a, 0x1e, 0x32, 0xc1, 0x7e, 0x75, 0xeb, 0xb3, 0x39, 0xd9,
+	0x4f, 0x71, 0xaa, 0x63, 0xc1, 0x06, 0x2b, 0x76, 0xe8, 0x26, 0x54, 0x24, 0x85, 0x45, 0xc2, 0xd0,
+	0x0f, 0xad, 0x11, 0x1d, 0x08, 0xab, 0x15, 0xf1, 0xaa, 0x84, 0xb7, 0x38, 0x78, 0x8f, 0x0e, 0x62,
+	0x56, 0x4d, 0x5f, 0xd2, 0xaa, 0xc8, 0x86, 0x8a, 0x47, 0xd8, 0x4b, 0x3f, 0x3c, 0xb1, 0xb8, 0x69,
+	0x43, 0xd7, 0x21, 0xd5, 0x8c, 0x60, 0x7a, 0x7b, 0x4e, 0xa6, 0x1d, 0x49, 0xbe, 0xaf, 0xa8, 0xf1,
+	0x9a, 0x97, 0x04, 0x98, 0x3f, 0x80, 0x9c, 0xfc, 0x52, 0xee, 0x49, 0xdd, 0xc3, 0x66, 0xb3, 0xd5,
+	0xed, 0x56, 0xae, 0xa0, 0x22, 0x64, 0x71, 0xab, 0x87, 0xb9, 0x87, 0x15, 0x21, 0xfb, 0xa0, 0xd1,
+	0x6b, 0xec, 0x56, 0x52, 0xe6, 0xf7, 0x61, 0xed, 0x89, 0xed, 0xb2, 0x79, 0x9c, 0xcb, 0xf4, 0xa1,
+	0x32, 0xe9, 0xab, 0x46, 0xa7, 0x9d, 0x18, 0x9d, 0xf9, 0x4d, 0xd3, 0x3a, 0x73, 0xd9, 0x85, 0xf1,
+	0xa8, 0x40, 0x9a, 0x84, 0xa1, 0x1a, 0x02, 0xfe, 0xd7, 0x7c, 0x09, 0x6b, 0x5d, 0xe6, 0x07, 0x73,
+	0x79, 0xfe, 0x47, 0x90, 0xe7, 0xab, 0x8d, 0x3f, 0x66, 0xca, 0xf5, 0xaf, 0xd7, 0xe5, 0x6a, 0x54,
+	0xd7, 0xab, 0x51, 0x7d, 0x5b, 0xad, 0x56, 0x58, 0xf7, 0x44, 0xd7, 0x20, 0x47, 0xdd, 0x81, 0x67,
+	0x0f, 0x55, 0xb4, 0x50, 0x2d, 0x13, 0x41, 0x65, 0x22, 0x58, 0x39, 0x7e, 0x13, 0xd0, 0x36, 0xa1,
+	0x2c, 0xf4, 0xcf, 0xe7, 0xd2, 0x67, 0x03, 0xb2, 0xcf, 0xfd, 0xb0, 0x2f, 0x27, 0x62, 0x01, 0xcb,
+	0x06, 0x9f, 0x54, 0x09, 0x26, 0x8a, 0xf7, 0xfb, 0x80, 0xda, 0x1e, 0x5f, 0x53, 0xe6, 0x1b, 0x88,
+	0xbf, 0x4a, 0xc1, 0x7a, 0xa2, 0xbf, 0x1a, 0x8c, 0xe5, 0xe7, 0x21, 0x0f, 0x4c, 0x63, 0x2a, 0xe7,
+	0x21, 0xda, 0x87, 0x9c, 0xec, 0xa1, 0x2c, 0x79, 0x67, 0x01, 0x46, 0x72, 0x99, 0x52, 0xec, 0x14,
+	0x9b, 0x99, 0x4e, 0x9f, 0x7e, 0xb3, 0x4e, 0xff, 0x12, 0x2a, 0xfa, 0x3b, 0xe8, 0x6b, 0xc7, 0xe6,
+	0x11, 0xac, 0xf7, 0xfd, 0xe1, 0x90, 0xf4, 0xb9, 0x37, 0x58, 0xae, 0xc7, 0x48, 0x78, 0x6a, 0x0f,
+	0x5f, 0xef, 0x37, 0x68, 0x42, 0xd5, 0x56, 0x44, 0xe6, 0x33, 0xb8, 0x1a, 0x13, 0xac, 0x06, 0xe2,
+	0x01, 0x64, 0x29, 0x07, 0xa8, 0x91, 0xb8, 0xb5, 0xe0, 0x48, 0x50, 0x2c, 0xc9, 0xcd, 0x75, 0xc9,
+	0xbc, 0x75, 0x4a, 0xbc, 0xe8, 0xb3, 0xcc, 0x6d, 0xb8, 0xda, 0x15, 0x6e, 0x3a, 0x97, 0x1f, 0x4e,
+	0x5c, 0x3c, 0x95, 0x70, 0xf1, 0x0d, 0x40, 0x71, 0x2e, 0xca, 0x11, 0xcf, 0x61, 0xad, 0x75, 0x46,
+	0xfa, 0x73, 0x71, 0xae, 0x42, 0xbe, 0xef, 0x8f, 0x46, 0xb6, 0xe7, 0x54, 0x53, 0x37, 0xd2, 0x37,
+	0x8b, 0x58, 0x37, 0xe3, 0x73, 0x31, 0x3d, 0xef, 0x5c, 0x34, 0xff, 0xc2, 0x80, 0xca, 0x44, 0xb6,
+	0x32, 0x24, 0xd7, 0x9e, 0x39, 0x9c, 0x11, 0x97, 0x5d, 0xc6, 0xaa, 0xa5, 0xe0, 0x3a, 0x5c, 0x48,
+	0x38, 0x09, 0xc3, 0x58, 0x38, 0x4a, 0x5f, 0x32, 0x1c, 0x99, 0x3b, 0xf0, 0x2d, 0xad, 0x4e, 0x97,
+	0x85, 0xc4, 0x1e, 0xb9, 0xde, 0xa0, 0xbd, 0xbf, 0x1f, 0x10, 0xa9, 0x38, 0x42, 0x90, 0x71, 0x6c,
+	0x66, 0x2b, 0xc5, 0xc4, 0x7f, 0x3e, 0xe9, 0xfb, 0x43, 0x9f, 0x46, 0x93, 0x5e, 0x34, 0xcc, 0x7f,
+	0x4f, 0x43, 0x75, 0x8a, 0x95, 0x36, 0xef, 0x33, 0xc8, 0x52, 0xc2, 0xc6, 0x81, 0x72, 0x95, 0xd6,
+	0xdc, 0x0a, 0xcf, 0xe6, 0x57, 0xef, 0x72, 0x66, 0x58, 0xf2, 0x44, 0x03, 0x28, 0x30, 0x76, 0x6e,
+	0x51, 0xf7, 0x27, 0x3a, 0x21, 0xd8, 0xbd, 0x2c, 0xff, 0x1e, 0x09, 0x47, 0xae, 0x67, 0x0f, 0xbb,
+	0xee, 0x4f, 0x08, 0xce, 0x33, 0x76, 0xce, 0xff, 0xa0, 0xa7, 0xdc, 0xe1, 0x1d, 0xd7, 0x53, 0x66,
+	0x6f, 0x2e, 0x2b, 0x25, 0x66, 0x60, 0x2c, 0x39, 0xd6, 0x76, 0x21, 0x2b, 0xbe, 0x69, 0x19, 0x47,
+	0xac, 0x40, 0x9a, 0xb1, 0x73, 0xa1, 0x54, 0x01, 0xf3, 0xbf, 0xb5, 0x4f, 0xa0, 0x1c, 0xff, 0x02,
+	0xee, 0x48, 0xc7, 0xc4, 0x1d, 0x1c, 0x4b, 0x07, 0xcb, 0x62, 0xd5, 0xe2, 0x23, 0xf9, 0xd2, 0x75,
+	0x54, 0xca, 0x9a, 0xc5, 0xb2, 0x61, 0xfe, 0x73, 0x0a, 0xae, 0xcf, 0xb0, 0x8c, 0x72, 0xd6, 0x67,
+	0x09, 0x67, 0x7d, 0x43, 0x56, 0xd0, 0x1e, 0xff, 0x2c, 0xe1, 0xf1, 0x6f, 0x90, 0x39, 0x9f, 0x36,
+	0xd7, 0x20, 0x47, 0xce, 0x5c, 0x46, 0x1c, 0x65, 0x2a, 0xd5, 0x8a, 0x4d, 0xa7, 0xcc, 0x65, 0xa7,
+	0xd3, 0x1e, 0x6c, 0x34, 0x43, 0x62, 0x33, 0xa2, 0x42, 0xb9, 0xf6, 0xff, 0xeb, 0x50, 0xb0, 0x87,
+	0x43, 0xbf, 0x3f, 0x19, 0xd6, 0xbc, 0x68, 0xb7, 0x1d, 0x54, 0x83, 0xc2, 0xb1, 0x4f, 0x99, 0x67,
+	0x8f, 0x88, 0x0a, 0x5e, 0x51, 0xdb, 0xfc, 0xc6, 0x80, 0xcd, 0x0b, 0xfc, 0xd4, 0x28, 0x1c, 0xc1,
+	0xaa, 0x4b, 0xfd, 0xa1, 0xf8, 0x40, 0x2b, 0xb6, 0xc3, 0xfb, 0xe1, 0x62, 0x4b, 0x4d, 0x5b, 0xf3,
+	0x10, 0x1b, 0xbe, 0x15, 0x37, 0xde, 0x14, 0x1e, 0x27, 0x84, 0x3b, 0x6a, 0xa6, 0xeb, 0xa6, 0xf9,
+	0xd7, 0x06, 0x6c, 0xaa, 0x15, 0x7e, 0xfe, 0x0f, 0x9d, 0x56, 0x39, 0xf5, 0xa6, 0x55, 0x36, 0xab,
+	0x70, 0xed, 0xa2, 0x5e, 0x2a, 0xe6, 0xff, 0x2c, 0x07, 0x68, 0x7a, 0x77, 0x89, 0xbe, 0x0b, 0x65,
+	0x4a, 0x3c, 0xc7, 0x92, 0xeb, 0x85, 0x5c, 0xca, 0x0a, 0xb8, 0xc4, 0x61, 0x72, 0xe1, 0xa0, 0x3c,
+	0x04, 0x92, 0x33, 0xa5, 0x6d, 0x01, 0x8b, 0xff, 0xe8, 0x18, 0xca, 0xcf, 0xa9, 0x15, 0xc9, 0x16,
+	0x0e, 0xb5, 0x3a, 0x77, 0x58, 0x9b, 0xd6, 0xa3, 0xfe, 0xa0, 0x1b, 0x7d, 0x17, 0x2e, 0x3d, 0xa7,
+	0x51, 0x03, 0x7d, 0x6d, 0xc0, 0x5b, 0x3a, 0xad, 0x98, 0x98, 0x6f, 0xe4, 0x3b, 0x84, 0x56, 0x33,
+	0x37, 0xd2, 0x37, 0x57, 0xb7, 0x0e, 0x2e, 0x61, 0xbf, 0x29, 0xe0, 0x9e, 0xef, 0x10, 0xbc, 0xe9,
+	0xcd, 0x80, 0x52, 0x54, 0x87, 0xf5, 0xd1, 0x98, 0x32, 0x4b, 0x7a, 0x81, 0xa5, 0x3a, 0x55, 0xb3,
+	0xc2, 0x2e, 0x57, 0x39, 0x2a, 0xe1, 0xab, 0xe8, 0x04, 0x56, 0x46, 0xfe, 0xd8, 0x63, 0x56, 0x5f,
+	0xec, 0x7f, 0x68, 0x35, 0xb7, 0xd0, 0xc6, 0x78, 0x86, 0x95, 0xf6, 0x38, 0x3b, 0xb9, 0x9b, 0xa2,
+	0xb8, 0x3c, 0x8a, 0xb5, 0xd0, 0x6f, 0xc3, 0x35, 0xc7, 0xa5, 0xf6, 0xd1, 0x90, 0x58, 0x43, 0x7f,
+	0x60, 0x4d, 0x72, 0x98, 0x6a, 0x41, 0xe8, 0xb7, 0xa1, 0xb0, 0xbb, 0xfe, 0xa0, 0x19, 0xe1, 0x04,
+	0xd5, 0xb9, 0x67, 0x8f, 0xdc, 0xbe, 0xc5, 0x55, 0x1e, 0xfa, 0xb6, 0x63, 0x8d, 0x29, 0x09, 0x69,
+	0xb5, 0xa8, 0xa8, 0x24, 0xf6, 0x89, 0x42, 0x1e, 0x72, 0x1c, 0xfa, 0x0e, 0x40, 0xff, 0x98, 0xf4,
+	0x4f, 0x02, 0xdf, 0xf5, 0x58, 0x15, 0x44, 0xcf, 0x18, 0xc4, 0xbc, 0x07, 0xa5, 0xd8, 0x78, 0xa2,
+	0x02, 0x64, 0x3a, 0xfb, 0x9d, 0x56, 0xe5, 0x0a, 0x02, 0xc8, 0x35, 0x77, 0xf0, 0xfe, 0x7e, 0x4f,
+	0x6e, 0x4f, 0xda, 0x7b, 0x8d, 0x87, 0xad, 0x4a, 0x8a, 0x83, 0x0f, 0x3b, 0xbf, 0xd7, 0x6a, 0xef,
+	0x56, 0xd2, 0x66, 0x0b, 0xca, 0xf1, 0xaf, 0x44, 0x08, 0x56, 0x0f, 0x3b, 0x8f, 0x3b, 0xfb, 0x4f,
+	0x3a, 0xd6, 0xde, 0xfe, 0x61, 0xa7, 0xc7, 0x37, 0x39, 0xab, 0x00, 0x8d, 0xce, 0xd3, 0x49, 0x7b,
+	0x05, 0x8a, 0x9d, 0x7d, 0xdd, 0x34, 0x6a, 0xa9, 0x8a, 0xf1, 0x28, 0x53, 0xc8, 0x57, 0x0a, 0xb8,
+	0x1c, 0x92, 0x91, 0xcf, 0x88, 0xc5, 0x97, 0x10, 0x6a, 0xfe, 0x2a, 0x0d, 0x1b, 0xb3, 0x9c, 0x00,
+	0x39, 0x90, 0xe1, 0x0e, 0xa5, 0xb6, 0x9e, 0x6f, 0xde, 0x9f, 0x04, 0x77, 0x3e, 0x8f, 0x02, 0x5b,
+	0xad, 0x35, 0x45, 0x2c, 0xfe, 0x23, 0x0b, 0x72, 0x43, 0xfb, 0x88, 0x0c, 0x69, 0x35, 0x2d, 0x8a,
+	0x33, 0x0f, 0x2f, 0x23, 0x7b, 0x57, 0x70, 0x92, 0x95, 0x19, 0xc5, 0x16, 0xf5, 0xa0, 0xc4, 0xa3,
+	0x29, 0x95, 0xe6, 0x54, 0x01, 0x7e, 0x6b, 0x4e, 0x29, 0x3b, 0x13, 0x4a, 0x1c, 0x67, 0x53, 0xbb,
+	0x0b, 0xa5, 0x98, 0xb0, 0x19, 0x85, 0x95, 0x8d, 0x78, 0x61, 0xa5, 0x18, 0xaf, 0x92, 0x7c, 0x06,
+	0x1b, 0xb3, 0x6c, 0xc4, 0x9d, 0x64, 0x67, 0xbf, 0xdb, 0x93, 0x5b, 0xd8, 0x87, 0x78, 0xff, 0xf0,
+	0xa0, 0x62, 0x70, 0x60, 0xaf, 0xd1, 0x7d, 0x5c, 0x49, 0x45, 0x3e, 0x94, 0x36, 0x9b, 0x50, 0x8a,
+	0xe9, 0x95, 0x58, 0x3e, 0x8c, 0xe4, 0xf2, 0xc1, 0x03, 0xb8, 0xed, 0x38, 0x21, 0xa1, 0x54, 0xe9,
+	0xa1, 0x9b, 0xe6, 0x33, 0x28, 0x6e, 0x77, 0xba, 0x8a, 0x45, 0x15, 0xf2, 0x94, 0x84, 0xfc, 0xbb,
+	0x45, 0x89, 0xac, 0x88, 0x75, 0x93, 0x33, 0xa7, 0xc4, 0x0e, 0xfb, 0xc7, 0x84, 0xaa, 0xa4, 0x23,
+	0x6a, 0x73, 0x2a, 0x5f, 0x94, 0x9a, 0xe4, 0xd8, 0x15, 0xb1, 0x6e, 0x9a, 0xff, 0x53, 0x00, 0x98,
+	0x94, 0x3d, 0xd0, 0x2a, 0xa4, 0xa2, 0xc5, 0x20, 0xe5, 0x3a, 0xdc, 0x0f, 0x62, 0x8b, 0x9d, 0xf8,
+	0x8f, 0xb6, 0x60, 0x73, 0x44, 0x07, 0x81, 0xdd, 0x3f, 0xb1, 0x54, 0xb5, 0x42, 0xc6, 0x0c, 0x11,
+	0x58, 0xcb, 0x78, 0x5d, 0x21, 0x55, 0x48, 0x90, 0x7c, 0x77, 0x21, 0x4d, 0xbc, 0x53, 0x11, 0x04,
+	0x4b, 0x5b, 0xf7, 0x16, 0x2e, 0xc7, 0xd4, 0x5b, 0xde, 0xa9, 0xf4, 0x15, 0xce, 0x06, 0x59, 0x00,
+	0x0e, 0x39, 0x75, 0xfb, 0xc4, 0xe2, 0x4c, 0xb3, 0x82, 0xe9, 0xe7, 0x8b, 0x33, 0xdd, 0x16, 0x3c,
+	0x22, 0xd6, 0x45, 0x47, 0xb7, 0x51, 0x07, 0x8a, 0x21, 0xa1, 0xfe, 0x38, 0xec, 0x13, 0x19, 0x09,
+	0xe7, 0xdf, 0x31, 0x61, 0x4d, 0x87, 0x27, 0x2c, 0xd0, 0x36, 0xe4, 0x44, 0x00, 0xa4, 0xd5, 0xfc,
+	0x8d, 0xf4, 0x6f, 0xac, 0xed, 0x26, 0x99, 0x89, 0xe8, 0x82, 0x15, 0x2d, 0x7a, 0x08, 0x79, 0xa9,
+	0x22, 0xad, 0x16, 0x04, 0x9b, 0xf7, 0xe7, 0x8d, 0xce, 0x82, 0x0a, 0x6b, 0x6a, 0x3e, 0xaa, 0x3c,
+	0x70, 0x8a, 0xb8, 0x59, 0xc4, 0xe2, 0x3f, 0x7a, 0x1b, 0x8a, 0x32, 0x19, 0x70, 0xdc, 0x50, 0x84,
+	0xc9, 0x22, 0x96, 0xd9, 0xc1, 0xb6, 0x1b, 0xa2, 0x77, 0xa0, 0x24, 0x93, 0x3e, 0x4b, 0x44, 0x85,
+	0x92, 0x40, 0x83, 0x04, 0x1d, 0xf0, 0xd8, 0x20, 0x3b, 0x90, 0x30, 0x94, 0x1d, 0xca, 0x51, 0x07,
+	0x12, 0x86, 0xa2, 0xc3, 0xff, 0x83, 0x35, 0x91, 0x2a, 0x0f, 0x42, 0x7f, 0x1c, 0x58, 0xc2, 0xa7,
+	0x56, 0x44, 0xa7, 0x15, 0x0e, 0x7e, 0xc8, 0xa1, 0x1d, 0xee, 0x5c, 0xd7, 0xa1, 0xf0, 0xc2, 0x3f,
+	0x92, 0x1d, 0x56, 0xe5, 0x3c, 0x78, 0xe1, 0x1f, 0x69, 0x54, 0x94, 0xae, 0xac, 0x25, 0xd3, 0x95,
+	0x2f, 0xe1, 0xda, 0xf4, 0xba, 0x2b, 0xd2, 0x96, 0xca, 0xe5, 0xd3, 0x96, 0x0d, 0x6f, 0x06, 0x14,
+	0xdd, 0x87, 0xb4, 0xe3, 0xd1, 0xea, 0xd5, 0x85, 0x9c, 0x23, 0x9a, 0xc7, 0x98, 0x13, 0xa3, 0x4d,
+	0xc8, 0xf1, 0x8f, 0x75, 0x9d, 0x2a, 0x92, 0xa1, 0xe7, 0x85, 0x7f, 0xd4, 0x76, 0xd0, 0xb7, 0xa0,
+	0xc8, 0xbf, 0x9f, 0x06, 0x76, 0x9f, 0x54, 0xd7, 0x05, 0x66, 0x02, 0xe0, 0x03, 0xe5, 0xf9, 0x0e,
+	0x91, 0x26, 0xda, 0x90, 0x03, 0xc5, 0x01, 0xc2, 0x46, 0x6f, 0x41, 0x5e, 0x20, 0x5d, 0xa7, 0xba,
+	0x29, 0x50, 0x39, 0xde, 0x6c, 0x3b, 0xc8, 0x84, 0x95, 0xc0, 0x0e, 0x89, 0xc7, 0x2c, 0x25, 0xf1,
+	0x9a, 0x40, 0x97, 0x24, 0xf0, 0x11, 0x97, 0x5b, 0xbb, 0x0d, 0x05, 0x3d, 0x19, 0x16, 0x09, 0x93,
+	0xb5, 0x4f, 0x60, 0x35, 0x39, 0x95, 0x16, 0x0a, 0xb2, 0xff, 0x90, 0x82, 0x62, 0x34, 0x69, 0x90,
+	0x07, 0xeb, 0x62, 0x50, 0x6d, 0x46, 0x1c, 0x6b, 0x32, 0x07, 0x65, 0xc2, 0xfc, 0xe9, 0x9c, 0x66,
+	0x6e, 0x68, 0x0e, 0x6a, 0xe7, 0xae, 0x26, 0x24, 0x8a, 0x38, 0x4f, 0xe4, 0x7d, 0x01, 0x6b, 0x43,
+	0xd7, 0x1b, 0x9f, 0xc5, 0x64, 0xc9, 0x4c, 0xf7, 0x77, 0xe6, 0x94, 0xb5, 0xcb, 0xa9, 0x27, 0x32,
+	0x56, 0x87, 0x89, 0x36, 0xda, 0x81, 0x6c, 0xe0, 0x87, 0x4c, 0xaf, 0x99, 0xf3, 0xae, 0x66, 0x07,
+	0x7e, 0xc8, 0xf6, 0xec, 0x20, 0xe0, 0x9b, 0x39, 0xc9, 0xc0, 0xfc, 0x26, 0x05, 0xd7, 0x66, 0x7f,
+	0x18, 0xea, 0x40, 0xba, 0x1f, 0x8c, 0x95, 0x91, 0x3e, 0x59, 0xd4, 0x48, 0xcd, 0x60, 0x3c, 0xd1,
+	0x9f, 0x33, 0xe2, 0x05, 0xee, 0x11, 0x19, 0xf9, 0xe1, 0xb9, 0xb2, 0xc5, 0x67, 0x8b, 0xb2, 0xdc,
+	0x13, 0xd4, 0x13, 0xae, 0x8a, 0x1d, 0xc2, 0x50, 0x50, 0x93, 0x89, 0xaa, 0xb0, 0xbd, 0x60, 0xb9,
+	0x4d, 0xb3, 0xc4, 0x11, 0x1f, 0xf3, 0x36, 0x6c, 0xce, 0xfc, 0x14, 0xf4, 0x6d, 0x80, 0x7e, 0x30,
+	0xb6, 0xc4, 0x71, 0x88, 0xf4, 0xa0, 0x34, 0x2e, 0xf6, 0x83, 0x71, 0x57, 0x00, 0xcc, 0x67, 0x50,
+	0x7d, 0x95, 0xbe, 0x7c, 0x8e, 0x49, 0x8d, 0xad, 0xd1, 0x91, 0xb0, 0x41, 0x1a, 0x17, 0x24, 0x60,
+	0xef, 0x88, 0x4f, 0x25, 0x8d, 0xb4, 0xcf, 0x78, 0x87, 0xb4, 0xe8, 0x50, 0x52, 0x1d, 0xec, 0xb3,
+	0xbd, 0x23, 0xf3, 0x6f, 0x52, 0xb0, 0x76, 0x41, 0x65, 0xbe, 0xa5, 0x95, 0x01, 0x58, 0x17, 0x0b,
+	0x64, 0x8b, 0x47, 0xe3, 0xbe, 0xeb, 0xe8, 0x32, 0xb3, 0xf8, 0x2f, 0xd6, 0xe1, 0x40, 0x95, 0x80,
+	0x53, 0x6e, 0xc0, 0xa7, 0xcf, 0xe8, 0xc8, 0x65, 0x54, 0x24, 0x45, 0x59, 0x2c, 0x1b, 0xe8, 0x29,
+	0xac, 0x86, 0x44, 0xac, 0xff, 0x8e, 0x25, 0xbd, 0x2c, 0xbb, 0x90, 0x97, 0x29, 0x0d, 0xb9, 0xb3,
+	0xe1, 0x15, 0xcd, 0x89, 0xb7, 0x28, 0x7a, 0x02, 0x2b, 0x3a, 0xd9, 0x96, 0x9c, 0x73, 0x4b, 0x73,
+	0x2e, 0x2b, 0x46, 0x82, 0x31, 0x3f, 0x79, 0x8a, 0x21, 0xf9, 0x87, 0x89, 0xec, 0x4f, 0xd9, 0x44,
+	0x36, 0x92, 0xd1, 0x22, 0xab, 0xa2, 0x85, 0x79, 0x04, 0xa5, 0xd8, 0xbc, 0x58, 0x84, 0x94, 0xdb,
+	0x93, 0xf9, 0xc2, 0x9e, 0x59, 0x9c, 0x62, 0x3e, 0x8f, 0x93, 0x3c, 0xf3, 0xb2, 0xdc, 0x40, 0x58,
+	0xb4, 0x88, 0x73, 0xbc, 0xd9, 0x0e, 0xcc, 0xaf, 0xd3, 0xb0, 0x9a, 0x9c, 0xd2, 0xda, 0x8f, 0x02,
+	0x12, 0xba, 0xbe, 0x13, 0xf3, 0xa3, 0x03, 0x01, 0xe0, 0xbe, 0xc2, 0xd1, 0x5f, 0x8e, 0x7d, 0x66,
+	0x6b, 0x5f, 0xe9, 0x07, 0xe3, 0xdf, 0xe5, 0xed, 0x0b, 0x3e, 0x98, 0xbe, 0xe0, 0x83, 0xe8, 0x3d,
+	0x40, 0xca, 0x95, 0x86, 0xee, 0xc8, 0x65, 0xd6, 0xd1, 0x39, 0x23, 0x72, 0x8c, 0xd3, 0xb8, 0x22,
+	0x31, 0xbb, 0x1c, 0x71, 0x9f, 0xc3, 0xb9, 0xe3, 0xf9, 0xfe, 0xc8, 0xa2, 0x7d, 0x3f, 0x24, 0x96,
+	0xed, 0xbc, 0x10, 0xbb, 0xb9, 0x34, 0x2e, 0xf9, 0xfe, 0xa8, 0xcb, 0x61, 0x0d, 0xe7, 0x05, 0x5f,
+	0x88, 0xfb, 0xc1, 0x98, 0x12, 0x66, 0xf1, 0x1f, 0x91, 0xbb, 0x14, 0x31, 0x48, 0x50, 0x33, 0x18,
+	0x53, 0xf4, 0x3d, 0x58, 0xd1, 0x1d, 0xc4, 0x5a, 0xac, 0x92, 0x80, 0xb2, 0xea, 0x22, 0x60, 0xc8,
+	0x84, 0xf2, 0x01, 0x09, 0xfb, 0xc4, 0x63, 0x3d, 0xb7, 0x7f, 0x42, 0xc5, 0xb6, 0xcc, 0xc0, 0x09,
+	0x18, 0xda, 0x85, 0xbc, 0xe3, 0xf2, 0xe2, 0x97, 0x2f, 0xd2, 0x85, 0xd2, 0xd6, 0x47, 0xf3, 0x2e,
+	0x82, 0x2e, 0x3d, 0x69, 0xef, 0x8b, 0xef, 0xe2, 0x05, 0x79, 0xde, 0xf2, 0xd5, 0x1e, 0x48, 0xeb,
+	0x3e, 0x22, 0x23, 0x6a, 0xfe, 0xa3, 0x01, 0x59, 0x91, 0x00, 0x71, 0x13, 0x8b, 0xe4, 0x41, 0xe4,
+	0x16, 0x2a, 0x71, 0xe6, 0x00, 0x91, 0x59, 0xbc, 0x0d, 0x45, 0x31, 0x94, 0xb1, 0xfd, 0x8a, 0xc8,
+	0xaa, 0x05, 0xb2, 0x06, 0x85, 0x90, 0xd8, 0x8e, 0xef, 0x0d, 0x75, 0xcd, 0x2d, 0x6a, 0xa3, 0xdf,
+	0x82, 0x4a, 0x10, 0xfa, 0x81, 0x3d, 0x98, 0x6c, 0xd3, 0x95, 0x33, 0xac, 0xc5, 0xe0, 0x22, 0xe1,
+	0xff, 0x1e, 0xac, 0x50, 0x22, 0xd7, 0x09, 0xe9, 0x72, 0x59, 0x69, 0x34, 0x05, 0x14, 0xfb, 0x0b,
+	0xf3, 0x17, 0x06, 0xe4, 0xe4, 0x3a, 0x78, 0x09, 0x85, 0xdf, 0x07, 0x24, 0xc7, 0x85, 0xfb, 0xdb,
+	0xc8, 0xa5, 0x54, 0x25, 0xed, 0xe2, 0xe4, 0x58, 0x62, 0x0e, 0x26, 0x08, 0x9e, 0x13, 0xf5, 0x1d,
+	0x57, 0xe6, 0x02, 0x52, 0xf7, 0x7c, 0xdf, 0x71, 0x79, 0x2a, 0x60, 0xfe, 0xda, 0x00, 0x98, 0x1c,
+	0xf7, 0xf1, 0x2d, 0x00, 0x1f, 0x03, 0xbe, 0xc9, 0x96, 0x75, 0x45, 0xdd, 0xe4, 0x25, 0x35, 0x95,
+	0xc0, 0xa7, 0x96, 0x3d, 0x2d, 0x55, 0x0c, 0xf4, 0x29, 0x03, 0x51, 0x35, 0x96, 0x45, 0x4f, 0x19,
+	0x88, 0x3c, 0x65, 0x20, 0xbc, 0xd2, 0xa3, 0xb6, 0x16, 0x92, 0x5d, 0x46, 0xec, 0x2c, 0x4a, 0x4e,
+	0x74, 0x94, 0x43, 0xcc, 0xff, 0x34, 0xa2, 0x08, 0xab, 0x8f, 0x5c, 0xd0, 0x17, 0x50, 0xe0, 0xc1,
+	0xca, 0x1a, 0xd9, 0x81, 0xba, 0x40, 0xd0, 0x5c, 0xee, 0x34, 0x47, 0xaf, 0xbf, 0x72, 0x63, 0x90,
+	0x0f, 0x64, 0x8b, 0x47, 0x6a, 0xbe, 0x29, 0xd3, 0x91, 0x9a, 0xff, 0x47, 0xef, 0xc2, 0xaa, 0x3d,
+	0x66, 0xbe, 0x65, 0x3b, 0xa7, 0x24, 0x64, 0x2e, 0x25, 0xca, 0xcf, 0x56, 0x38, 0xb4, 0xa1, 0x81,
+	0xb5, 0x7b, 0x50, 0x8e, 0xf3, 0x7c, 0x5d, 0x86, 0x94, 0x8d, 0x67, 0x48, 0x7f, 0x04, 0x30, 0x29,
+	0x5f, 0x72, 0xf7, 0xe1, 0xb5, 0x50, 0xab, 0xaf, 0xab, 0x00, 0x59, 0x5c, 0xe0, 0x80, 0x26, 0x77,
+	0xd4, 0xe4, 0xd9, 0x4a, 0x56, 0x9f, 0xad, 0xf0, 0x38, 0xc4, 0x43, 0xc7, 0x89, 0x3b, 0x1c, 0x46,
+	0x25, 0xd5, 0xa2, 0xef, 0x8f, 0x1e, 0x0b, 0x80, 0xf9, 0xcb, 0x94, 0xf4, 0x15, 0x79, 0x4a, 0x36,
+	0xd7, 0x2e, 0xf0, 0x4d, 0x0d, 0xf5, 0x5d, 0x00, 0xca, 0xec, 0x90, 0xa7, 0x7b, 0xb6, 0x2e, 0xea,
+	0xd6, 0xa6, 0x0e, 0x67, 0x7a, 0xfa, 0xda, 0x0e, 0x2e, 0xaa, 0xde, 0x0d, 0x86, 0x3e, 0x85, 0x72,
+	0xdf, 0x1f, 0x05, 0x43, 0xa2, 0x88, 0xb3, 0xaf, 0x25, 0x2e, 0x45, 0xfd, 0x1b, 0x2c, 0x56, 0x4a,
+	0xce, 0x5d, 0xb6, 0x94, 0xfc, 0x2f, 0x86, 0x3c, 0xec, 0x8b, 0x9f, 0x35, 0xa2, 0xc1, 0x8c, 0x0b,
+	0x2d, 0x0f, 0x97, 0x3c, 0xb8, 0xfc, 0x4d, 0xb7, 0x59, 0x6a, 0x9f, 0xce, 0x73, 0x7d, 0xe4, 0xd5,
+	0x09, 0xf8, 0xbf, 0xa6, 0xa1, 0xa8, 0x87, 0x65, 0x7a, 0xec, 0x3f, 0x86, 0x62, 0x74, 0x67, 0xaa,
+	0x9a, 0x7a, 0xad, 0x85, 0x27, 0x9d, 0xd1, 0x73, 0x40, 0xf6, 0x60, 0x10, 0x25, 0xd6, 0xd6, 0x98,
+	0xda, 0x03, 0x7d, 0xca, 0xfa, 0xf1, 0x02, 0x76, 0xd0, 0x2b, 0xf1, 0x21, 0xa7, 0xc7, 0x15, 0x7b,
+	0x30, 0x48, 0x40, 0xd0, 0x1f, 0xc3, 0x66, 0x52, 0x86, 0x75, 0x74, 0x6e, 0x05, 0xae, 0xa3, 0xaa,
+	0x0d, 0x3b, 0x8b, 0x1e, 0x75, 0xd6, 0x13, 0xec, 0xef, 0x9f, 0x1f, 0xb8, 0x8e, 0xb4, 0x39, 0x0a,
+	0xa7, 0x10, 0xb5, 0x9f, 0xc2, 0x5b, 0xaf, 0xe8, 0x3e, 0x63, 0x0c, 0x3a, 0xc9, 0x2b, 0x3c, 0xcb,
+	0x1b, 0x21, 0x36, 0x7a, 0xff, 0x96, 0x82, 0xab, 0x53, 0x1d, 0x50, 0x23, 0xbe, 0x23, 0xf8, 0x60,
+	0x4e, 0x39, 0xcd, 0x83, 0x43, 0xc9, 0x9e, 0xd3, 0xa2, 0x47, 0x17, 0x36, 0x01, 0xf3, 0xa6, 0x7e,
+	0x32, 0x97, 0x96, 0x8c, 0x74, 0xde, 0xdf, 0x81, 0xc2, 0xd1, 0xd0, 0xef, 0x8b, 0x64, 0x21, 0xbd,
+	0x50, 0xb2, 0x70, 0x9f, 0x93, 0xb5, 0xf7, 0x25, 0xbb, 0xbc, 0x60, 0xd2, 0xf6, 0xd1, 0x1e, 0xe4,
+	0x75, 0x45, 0x3b, 0xb3, 0x10, 0x3b, 0x15, 0xe7, 0x15, 0x3b, 0xc5, 0xc3, 0xfc, 0x2a, 0x03, 0x05,
+	0xfd, 0xf1, 0xa2, 0x94, 0x71, 0x4e, 0x19, 0x19, 0x59, 0x51, 0x9d, 0xd5, 0xc0, 0x20, 0x41, 0x22,
+	0x19, 0x78, 0x1b, 0x8a, 0x63, 0x4a, 0x42, 0x89, 0x4e, 0x09, 0x74, 0x81, 0x03, 0x04, 0xf2, 0x1d,
+	0x28, 0x31, 0x9f, 0xd9, 0x43, 0x8b, 0x89, 0xc4, 0x29, 0x2d, 0xa9, 0x05, 0x48, 0xa6, 0x4d, 0x3f,
+	0x80, 0xab, 0xec, 0x38, 0xf4, 0x19, 0x1b, 0xf2, 0xa4, 0x5d, 0xa4, 0x90, 0x32, 0xe3, 0xcb, 0xe0,
+	0x4a, 0x84, 0x90, 0xa9, 0x25, 0xe5, 0x8b, 0xcb, 0xa4, 0x33, 0x9f, 0x59, 0x22, 0xc6, 0x65, 0xf0,
+	0x4a, 0x04, 0xe5, 0x33, 0x8f, 0xaf, 0xed, 0x81, 0x4c, 0xcd, 0x44, 0x28, 0x33, 0xb0, 0x6e, 0x22,
+	0x0b, 0xd6, 0x46, 0xc4, 0xa6, 0xe3, 0x90, 0x38, 0xd6, 0x73, 0x97, 0x0c, 0x1d, 0x59, 0x81, 0x5a,
+	0x9d, 0x7b, 0xdf, 0xa5, 0xcd, 0x52, 0x7f, 0x20, 0xa8, 0xf1, 0xaa, 0x66, 0x27, 0xdb, 0xe8, 0x31,
+	0x14, 0x82, 0x90, 0x50, 0x0e, 0xaa, 0x16, 0x16, 0xf2, 0xb6, 0x03, 0x45, 0x86, 0x23, 0x06, 0xe6,
+	0x4f, 0x21, 0xa7, 0xd8, 0xae, 0x41, 0xa9, 0xfb, 0xb4, 0xdb, 0x6b, 0xed, 0x59, 0x7b, 0xfb, 0xdb,
+	0x2d, 0x75, 0x23, 0xad, 0xdb, 0xc2, 0xb2, 0x69, 0x70, 0x7c, 0x6f, 0xbf, 0xd7, 0xd8, 0xb5, 0x7a,
+	0xed, 0xe6, 0xe3, 0x6e, 0x25, 0x85, 0x36, 0xe1, 0x6a, 0x6f, 0x07, 0xef, 0xf7, 0x7a, 0xbb, 0xad,
+	0x6d, 0xeb, 0xa0, 0x85, 0xdb, 0xfb, 0xdb, 0xdd, 0x4a, 0x9a, 0x57, 0xe4, 0x27, 0xe0, 0x5e, 0x7b,
+	0xaf, 0x55, 0xc9, 0xf0, 0x3b, 0x48, 0x07, 0x2d, 0xdc, 0x6c, 0x75, 0x7a, 0x95, 0x2c, 0x2a, 0x43,
+	0xe1, 0x00, 0xb7, 0xba, 0xdd, 0x43, 0xdc, 0xaa, 0xe4, 0xcc, 0xff, 0x4e, 0x43, 0x29, 0xe6, 0xbf,
+	0x7c, 0x0a, 0x87, 0x54, 0xee, 0x1d, 0x33, 0x98, 0xff, 0x15, 0xe7, 0xe9, 0x76, 0xff, 0x58, 0x0e,
+	0x7c, 0x06, 0xcb, 0x86, 0xd8, 0x2f, 0xda, 0x67, 0xb1, 0x08, 0x97, 0xc1, 0x85, 0x91, 0x7d, 0x26,
+	0x99, 0x7c, 0x17, 0xca, 0x27, 0x24, 0xf4, 0xc8, 0x50, 0xe1, 0xe5, 0x60, 0x97, 0x24, 0x4c, 0x76,
+	0xb9, 0x09, 0x15, 0xd5, 0x65, 0xc2, 0x46, 0x8e, 0xf4, 0xaa, 0x84, 0xef, 0x69, 0x66, 0x1b, 0x90,
+	0x95, 0xe8, 0xbc, 0x94, 0x2f, 0x1a, 0x7c, 0x81, 0xa6, 0x2f, 0xed, 0x40, 0x8c, 0x40, 0x06, 0x8b,
+	0xff, 0xe8, 0x68, 0x7a, 0xe8, 0x73, 0x62, 0xe8, 0xef, 0x2e, 0x3e, 0x91, 0xe7, 0x19, 0xfd, 0xe2,
+	0x65, 0x47, 0xff, 0x34, 0x1a, 0xfd, 0x3c, 0xa4, 0xb1, 0xbe, 0x21, 0xd6, 0x6c, 0x34, 0x77, 0xf8,
+	0x88, 0xaf, 0x40, 0x71, 0xaf, 0xf1, 0x63, 0xeb, 0xb0, 0x2b, 0x8f, 0x61, 0x2a, 0x50, 0x7e, 0xdc,
+	0xc2, 0x9d, 0xd6, 0xae, 0x82, 0xa4, 0xd1, 0x06, 0x54, 0x14, 0x64, 0xd2, 0x2f, 0xc3, 0x39, 0xc8,
+	0xbf, 0x59, 0x5e, 0x96, 0xef, 0x3e, 0x69, 0x1c, 0x54, 0x72, 0x89, 0x41, 0xcf, 0x9b, 0xff, 0x91,
+	0x82, 0x35, 0xb9, 0xd6, 0x46, 0x37, 0x5b, 0x5e, 0x7d, 0xb2, 0x1f, 0x2f, 0x42, 0xa6, 0x92, 0x45,
+	0x48, 0x9d, 0xf4, 0x8b, 0x54, 0x29, 0x3d, 0x49, 0xfa, 0x45, 0x61, 0x2e, 0xb1, 0x8c, 0x66, 0x16,
+	0x59, 0x46, 0xab, 0x90, 0x1f, 0x11, 0x1a, 0xb9, 0x44, 0x11, 0xeb, 0x26, 0x72, 0xa1, 0x64, 0x7b,
+	0x9e, 0xcf, 0x6c, 0x59, 0xd9, 0xcf, 0x2d, 0x94, 0x61, 0x5c, 0xf8, 0xe2, 0x7a, 0x63, 0xc2, 0x49,
+	0xae, 0x76, 0x71, 0xde, 0xb5, 0x1f, 0x41, 0xe5, 0x62, 0x87, 0x85, 0x72, 0x8c, 0xaf, 0x52, 0x50,
+	0xd0, 0x43, 0xce, 0x93, 0x51, 0xea, 0x8f, 0x88, 0x65, 0x9f, 0x0e, 0x3e, 0xbc, 0xa5, 0x02, 0x6c,
+	0x91, 0x43, 0x1a, 0x1c, 0x10, 0x47, 0xdf, 0xbe, 0x55, 0x4d, 0x25, 0xd0, 0xb7, 0x6f, 0x89, 0xf8,
+	0xac, 0xd0, 0x1f, 0xdd, 0xba, 0xa5, 0x23, 0xac, 0xc2, 0x7f, 0x74, 0x6b, 0x42, 0x2f, 0x82, 0xae,
+	0x9a, 0x6d, 0x82, 0xbe, 0xc7, 0x01, 0x1c, 0xfd, 0x7c, 0x3c, 0x1c, 0x2a, 0xe9, 0x59, 0xc9, 0x9e,
+	0x43, 0x22, 0xe9, 0x1a, 0x7d, 0xfb, 0x56, 0x35, 0x97, 0x40, 0x4b, 0xe9, 0x1a, 0xcd, 0xa5, 0xe7,
+	0xa5, 0x74, 0x85, 0x57, 0xd2, 0x45, 0x07, 0x29, 0x5d, 0x4e, 0x48, 0x41, 0x2f, 0xa4, 0x9b, 0x03,
+	0xd8, 0x6c, 0x46, 0x87, 0x8f, 0x73, 0x5d, 0x6a, 0xaa, 0x40, 0x9a, 0x97, 0xe4, 0xd5, 0x25, 0x44,
+	0xc7, 0x0d, 0xf9, 0x6e, 0x74, 0x48, 0xec, 0x53, 0x62, 0x85, 0x63, 0xcf, 0x73, 0xbd, 0x81, 0xca,
+	0xe7, 0xcb, 0x02, 0x88, 0x25, 0x8c, 0x9f, 0xae, 0x5f, 0x14, 0xa4, 0x4e, 0xd7, 0x47, 0xfc, 0x02,
+	0x2f, 0x65, 0x7e, 0x48, 0xde, 0xfc, 0x8d, 0xd9, 0x69, 0x6d, 0xcd, 0x5f, 0x19, 0xb0, 0x9e, 0x90,
+	0x37, 0xb9, 0xa7, 0xa9, 0xae, 0xb0, 0x1a, 0xff, 0x17, 0x57, 0x58, 0x53, 0x6f, 0xf6, 0x36, 0xdf,
+	0xdf, 0x1a, 0x50, 0x8e, 0x17, 0x2e, 0x5e, 0x59, 0xcd, 0xbb, 0x2e, 0x2b, 0x0e, 0xd6, 0x51, 0x40,
+	0x55, 0x35, 0x28, 0xcf, 0xdb, 0xf7, 0x03, 0x51, 0x55, 0x7c, 0x19, 0xba, 0x8c, 0x08, 0x9c, 0xac,
+	0x05, 0x15, 0x04, 0x40, 0x21, 0x05, 0x9d, 0xeb, 0x07, 0xba, 0x02, 0x24, 0x18, 0xb5, 0xfd, 0x40,
+	0x94, 0xa0, 0x24, 0xa5, 0xc0, 0xca, 0xb2, 0x8f, 0xe4, 0xc5, 0xd1, 0xe6, 0x9f, 0x19, 0x50, 0x3b,
+	0x0c, 0x1c, 0x9b, 0x91, 0x64, 0xc1, 0xfb, 0x75, 0xae, 0x95, 0x38, 0xe6, 0x4a, 0x5d, 0xfa, 0x98,
+	0xcb, 0xfc, 0x36, 0xbc, 0x3d, 0x53, 0x0d, 0xe5, 0x78, 0xff, 0x64, 0x40, 0x39, 0x9e, 0xcf, 0xf1,
+	0xcf, 0x92, 0xb6, 0x3a, 0x67, 0x44, 0xaf, 0xb2, 0xc2, 0x0a, 0xb2, 0xde, 0xf5, 0x0e, 0x94, 0x94,
+	0xbd, 0xce, 0x99, 0x52, 0x30, 0x83, 0xa5, 0x21, 0x64, 0x07, 0x6d, 0x6b, 0x5f, 0xd9, 0x33, 0x23,
+	0x6d, 0xbd, 0x1f, 0xb7, 0xb5, 0x36, 0x67, 0x46, 0xd9, 0x9a, 0x23, 0xff, 0xff, 0xf4, 0xd2, 0x98,
+	0x15, 0xc7, 0xa2, 0x17, 0xd6, 0x37, 0xf3, 0xef, 0x53, 0x50, 0x8e, 0xa7, 0x8c, 0x42, 0xe2, 0x59,
+	0x42, 0xdf, 0x7c, 0x78, 0x26, 0x95, 0xe1, 0x1f, 0x73, 0x66, 0xf1, 0x93, 0x4f, 0xc2, 0xb4, 0xb2,
+	0xc5, 0xf0, 0xec, 0x40, 0x02, 0xc4, 0xf8, 0x9e, 0xc9, 0x7b, 0xdd, 0x5a, 0xd9, 0x42, 0x78, 0x26,
+	0x2e, 0x74, 0x6b, 0x5a, 0x27, 0xf4, 0x83, 0x80, 0x38, 0x3a, 0x64, 0x85, 0x67, 0xdb, 0x12, 0xc0,
+	0xa5, 0x32, 0x2d, 0x55, 0xa6, 0x05, 0x79, 0x36, 0x91, 0xca, 0x26, 0x52, 0x73, 0x92, 0x92, 0xc5,
+	0xa5, 0xb2, 0x48, 0xaa, 0x4c, 0x19, 0x0a, 0x2c, 0x26, 0x95, 0x4d, 0xa4, 0x16, 0x34, 0xad, 0x96,
+	0x3a, 0xc3, 0x4a, 0xc5, 0x59, 0x56, 0xfa, 0xfe, 0x87, 0x93, 0xfd, 0x23, 0xe1, 0xd9, 0x95, 0xba,
+	0x03, 0x51, 0xb9, 0xc2, 0x1b, 0xf8, 0xb0, 0xd3, 0x69, 0x77, 0x1e, 0x56, 0x0c, 0x7e, 0x73, 0xa2,
+	0xf5, 0xe3, 0x36, 0x7f, 0x60, 0x90, 0xda, 0xfa, 0xf5, 0x06, 0xe4, 0xe4, 0x0a, 0x84, 0xbe, 0x51,
+	0x7b, 0xe7, 0xf8, 0x93, 0x18, 0xf4, 0xa3, 0x85, 0xe3, 0x4f, 0xe2, 0x99, 0x4d, 0xed, 0xb3, 0xa5,
+	0xe9, 0x95, 0xaf, 0x5e, 0x41, 0x7f, 0x6e, 0x40, 0x39, 0x71, 0xfd, 0x68, 0xde, 0x63, 0xeb, 0x19,
+	0x2f, 0x70, 0x6a, 0x3f, 0x5c, 0x8a, 0x36, 0xd2, 0xe5, 0x6b, 0x03, 0x4a, 0xb1, 0xb7, 0x27, 0xe8,
+	0xee, 0x32, 0xef, 0x55, 0xa4, 0x26, 0xf7, 0x96, 0x7f, 0xea, 0x62, 0x5e, 0xb9, 0x65, 0xa0, 0xaf,
+	0x0c, 0x28, 0xc5, 0x5e, 0x61, 0xcc, 0xad, 0xca, 0xf4, 0x9b, 0x91, 0xda, 0xbd, 0x65, 0x48, 0x23,
+	0x9b, 0xfc, 0xcc, 0x80, 0x62, 0xf4, 0xa2, 0x02, 0xdd, 0x59, 0xfc, 0x0d, 0x86, 0x54, 0xe2, 0xe3,
+	0x65, 0x1f, 0x6f, 0x98, 0x57, 0xd0, 0x9f, 0x40, 0x41, 0x3f, 0x3f, 0x40, 0xf3, 0xae, 0x34, 0x17,
+	0xde, 0x36, 0xd4, 0xee, 0x2c, 0x4c, 0x17, 0x17, 0xaf, 0xdf, 0x04, 0xcc, 0x2d, 0xfe, 0xc2, 0xeb,
+	0x85, 0xda, 0x9d, 0x85, 0xe9, 0x22, 0xf1, 0xdc, 0x13, 0x62, 0x4f, 0x07, 0xe6, 0xf6, 0x84, 0xe9,
+	0x37, 0x0b, 0xb5, 0x7b, 0xcb, 0x90, 0x26, 0x14, 0x89, 0x3d, 0x3e, 0x98, 0x5b, 0x91, 0xe9, 0x07,
+	0x0e, 0xb5, 0x7b, 0xcb, 0x90, 0x46, 0x8a, 0xfc, 0xdc, 0x88, 0x57, 0xd2, 0xee, 0x2c, 0x7c, 0xc7,
+	0x7e, 0x41, 0x97, 0x9c, 0xba, 0xe5, 0x2f, 0x26, 0xe8, 0xcf, 0x55, 0xdd, 0x5f, 0x5e, 0xd1, 0x47,
+	0x8b, 0x30, 0x4b, 0xdc, 0xea, 0xaf, 0xdd, 0x5e, 0x6e, 0x27, 0x21, 0x94, 0xf8, 0x53, 0x03, 0x60,
+	0x72, 0x99, 0x7f, 0x6e, 0x25, 0xa6, 0x5e, 0x11, 0xd4, 0xee, 0x2e, 0x41, 0x19, 0x9f, 0x20, 0xfa,
+	0xb2, 0xf1, 0xdc, 0x13, 0xe4, 0xc2, 0x63, 0x83, 0xda, 0x9d, 0x85, 0xe9, 0x22, 0xf1, 0x7f, 0x67,
+	0xc0, 0xd5, 0xa9, 0xcb, 0xce, 0xe8, 0xb3, 0x4b, 0xde, 0x77, 0xaf, 0x7d, 0xbe, 0x3c, 0x03, 0xad,
+	0xda, 0x4d, 0xe3, 0x96, 0x81, 0x7e, 0x61, 0xc0, 0x4a, 0xf2, 0x12, 0xe8, 0xdc, 0xab, 0xd4, 0x8c,
+	0x6b, 0xd3, 0xb5, 0x4f, 0x96, 0x23, 0x8e, 0xac, 0xf5, 0x97, 0x06, 0xac, 0xaa, 0xf9, 0xad, 0xf5,
+	0xf9, 0x64, 0xb1, 0xb0, 0x70, 0x41, 0xa1, 0x4f, 0x97, 0xa4, 0x4e, 0x68, 0x94, 0xdc, 0x43, 0xcd,
+	0xad, 0xd1, 0xcc, 0x3d, 0x5e, 0xed, 0xd3, 0x25, 0xa9, 0x13, 0x91, 0x2e, 0xb6, 0x97, 0x5a, 0x60,
+	0xf1, 0xbd, 0xb8, 0xdf, 0xab, 0xdd, 0x5b, 0x86, 0x34, 0xee, 0xda, 0xeb, 0x33, 0x52, 0x7d, 0xd4,
+	0x98, 0x93, 0xeb, 0xab, 0x77, 0x2b, 0xb5, 0xfb, 0x97, 0x61, 0x21, 0x15, 0xbc, 0x9f, 0xff, 0xfd,
+	0xac, 0x2c, 0xaa, 0xe4, 0xc4, 0xcf, 0x47, 0xff, 0x3b, 0x00, 0xe6, 0x88, 0xe8, 0x7b, 0x73, 0x3e,
 	0x00, 0x00,
 }
 
@@ -4073,6 +4810,18 @@ type DriverClient interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(ctx context.Context, in *DestroyNetworkRequest, opts ...grpc.CallOption) (*DestroyNetworkResponse, error)
+	// CheckpointTask writes the process state of a running task to a
+	// directory, so the task can later be restored from it. This rpc is only
+	// implemented if the driver sets the checkpoint capability.
+	CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from the process state written by
+	// CheckpointTask instead of running its command. This rpc is only
+	// implemented if the driver sets the checkpoint capability.
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error)
+	// UpdateTaskResources updates the resources of a running task in place,
+	// without restarting it. This rpc is only implemented if the driver can
+	// resize running tasks.
+	UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error)
 }
 
 type driverClient struct {
@@ -4318,6 +5067,33 @@ func (c *driverClient) DestroyNetwork(ctx context.Context, in *DestroyNetworkReq
 	return out, nil
 }
 
+func (c *driverClient) CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error) {
+	out := new(CheckpointTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error) {
+	out := new(RestoreTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error) {
+	out := new(UpdateTaskResourcesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DriverServer is the server API for Driver service.
 type DriverServer interface {
 	// TaskConfigSchema returns the schema for parsing the driver
@@ -4373,6 +5149,18 @@ type DriverServer interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(context.Context, *DestroyNetworkRequest) (*DestroyNetworkResponse, error)
+	// CheckpointTask writes the process state of a running task to a
+	// directory, so the task can later be restored from it. This rpc is only
+	// implemented if the driver sets the checkpoint capability.
+	CheckpointTask(context.Context, *CheckpointTaskRequest) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from the process state written by
+	// CheckpointTask instead of running its command. This rpc is only
+	// implemented if the driver sets the checkpoint capability.
+	RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error)
+	// UpdateTaskResources updates the resources of a running task in place,
+	// without restarting it. This rpc is only implemented if the driver can
+	// resize running tasks.
+	UpdateTaskResources(context.Context, *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error)
 }
 
 // UnimplementedDriverServer can be embedded to have forward compatible implementations.
@@ -4427,6 +5215,15 @@ func (*UnimplementedDriverServer) CreateNetwork(ctx context.Context, req *Create
 func (*UnimplementedDriverServer) DestroyNetwork(ctx context.Context, req *DestroyNetworkRequest) (*DestroyNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyNetwork not implemented")
 }
+func (*UnimplementedDriverServer) CheckpointTask(ctx context.Context, req *CheckpointTaskRequest) (*CheckpointTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointTask not implemented")
+}
+func (*UnimplementedDriverServer) RestoreTask(ctx context.Context, req *RestoreTaskRequest) (*RestoreTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
+func (*UnimplementedDriverServer) UpdateTaskResources(ctx context.Context, req *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskResources not implemented")
+}
 
 func RegisterDriverServer(s *grpc.Server, srv DriverServer) {
 	s.RegisterService(&_Driver_serviceDesc, srv)
@@ -4737,6 +5534,60 @@ func _Driver_DestroyNetwork_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_CheckpointTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).CheckpointTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).CheckpointTask(ctx, req.(*CheckpointTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_UpdateTaskResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).UpdateTaskResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).UpdateTaskResources(ctx, req.(*UpdateTaskResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Driver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.drivers.proto.Driver",
 	HandlerType: (*DriverServer)(nil),
@@ -4789,6 +5640,18 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyNetwork",
 			Handler:    _Driver_DestroyNetwork_Handler,
 		},
+		{
+			MethodName: "CheckpointTask",
+			Handler:    _Driver_CheckpointTask_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _Driver_RestoreTask_Handler,
+		},
+		{
+			MethodName: "UpdateTaskResources",
+			Handler:    _Driver_UpdateTaskResources_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
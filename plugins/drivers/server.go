@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/go-plugin"
@@ -50,6 +49,7 @@ func (b *driverPluginServer) Capabilities(ctx context.Context, req *proto.Capabi
 			MustCreateNetwork:     caps.MustInitiateNetwork,
 			NetworkIsolationModes: []proto.NetworkIsolationSpec_NetworkIsolationMode{},
 			DynamicWorkloadUsers:  caps.DynamicWorkloadUsers,
+			Checkpoint:            caps.Checkpoint,
 		},
 	}
 
@@ -113,31 +113,12 @@ func (b *driverPluginServer) RecoverTask(ctx context.Context, req *proto.Recover
 func (b *driverPluginServer) StartTask(ctx context.Context, req *proto.StartTaskRequest) (*proto.StartTaskResponse, error) {
 	handle, net, err := b.impl.StartTask(taskConfigFromProto(req.Task))
 	if err != nil {
-		if rec, ok := err.(structs.Recoverable); ok {
-			st := status.New(codes.FailedPrecondition, rec.Error())
-			st, err := st.WithDetails(&sproto.RecoverableError{Recoverable: rec.IsRecoverable()})
-			if err != nil {
-				// If this error, it will always error
-				panic(err)
-			}
-			return nil, st.Err()
-		}
-		return nil, err
+		return nil, recoverableErrorToGrpc(err)
 	}
 
-	var pbNet *proto.NetworkOverride
-	if net != nil {
-		pbNet = &proto.NetworkOverride{
-			PortMap:       map[string]int32{},
-			Addr:          net.IP,
-			AutoAdvertise: net.AutoAdvertise,
-		}
-		for k, v := range net.PortMap {
-			if v > math.MaxInt32 {
-				return nil, fmt.Errorf("port map out of bounds")
-			}
-			pbNet.PortMap[k] = int32(v)
-		}
+	pbNet, err := networkOverrideToProto(net)
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &proto.StartTaskResponse{
@@ -148,6 +129,22 @@ func (b *driverPluginServer) StartTask(ctx context.Context, req *proto.StartTask
 	return resp, nil
 }
 
+// recoverableErrorToGrpc returns a grpc status error with the details of err
+// if it is recoverable, so the client can tell whether to retry.
+func recoverableErrorToGrpc(err error) error {
+	rec, ok := err.(structs.Recoverable)
+	if !ok {
+		return err
+	}
+	st := status.New(codes.FailedPrecondition, rec.Error())
+	st, err = st.WithDetails(&sproto.RecoverableError{Recoverable: rec.IsRecoverable()})
+	if err != nil {
+		// If this error, it will always error
+		panic(err)
+	}
+	return st.Err()
+}
+
 func (b *driverPluginServer) WaitTask(ctx context.Context, req *proto.WaitTaskRequest) (*proto.WaitTaskResponse, error) {
 	ch, err := b.impl.WaitTask(ctx, req.TaskId)
 	if err != nil {
@@ -423,3 +420,56 @@ func (b *driverPluginServer) DestroyNetwork(ctx context.Context, req *proto.Dest
 
 	return &proto.DestroyNetworkResponse{}, nil
 }
+
+func (b *driverPluginServer) CheckpointTask(ctx context.Context, req *proto.CheckpointTaskRequest) (*proto.CheckpointTaskResponse, error) {
+	cd, ok := b.impl.(CheckpointDriver)
+	if !ok {
+		return nil, fmt.Errorf("CheckpointTask RPC not supported by driver")
+	}
+
+	err := cd.CheckpointTask(req.TaskId, &CheckpointOptions{
+		Dir:          req.Dir,
+		LeaveRunning: req.LeaveRunning,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.CheckpointTaskResponse{}, nil
+}
+
+func (b *driverPluginServer) RestoreTask(ctx context.Context, req *proto.RestoreTaskRequest) (*proto.RestoreTaskResponse, error) {
+	cd, ok := b.impl.(CheckpointDriver)
+	if !ok {
+		return nil, fmt.Errorf("RestoreTask RPC not supported by driver")
+	}
+
+	handle, net, err := cd.RestoreTask(taskConfigFromProto(req.Task), req.Dir)
+	if err != nil {
+		return nil, recoverableErrorToGrpc(err)
+	}
+
+	pbNet, err := networkOverrideToProto(net)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RestoreTaskResponse{
+		Handle:          taskHandleToProto(handle),
+		NetworkOverride: pbNet,
+	}, nil
+}
+
+func (b *driverPluginServer) UpdateTaskResources(ctx context.Context, req *proto.UpdateTaskResourcesRequest) (*proto.UpdateTaskResourcesResponse, error) {
+	rd, ok := b.impl.(ResourceUpdateDriver)
+	if !ok {
+		return nil, fmt.Errorf("UpdateTaskResources RPC not supported by driver")
+	}
+
+	err := rd.UpdateTaskResources(req.TaskId, ResourcesFromProto(req.Resources))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.UpdateTaskResourcesResponse{}, nil
+}
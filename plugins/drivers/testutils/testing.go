@@ -202,6 +202,8 @@ type MockDriver struct {
 	SignalTaskF        func(string, string) error
 	ExecTaskF          func(string, []string, time.Duration) (*drivers.ExecTaskResult, error)
 	ExecTaskStreamingF func(context.Context, string, *drivers.ExecOptions) (*drivers.ExitResult, error)
+	CheckpointTaskF    func(string, *drivers.CheckpointOptions) error
+	RestoreTaskF       func(*drivers.TaskConfig, string) (*drivers.TaskHandle, *drivers.DriverNetwork, error)
 	MockNetworkManager
 }
 
@@ -255,6 +257,14 @@ func (d *MockDriver) ExecTaskStreaming(ctx context.Context, taskID string, execO
 	return d.ExecTaskStreamingF(ctx, taskID, execOpts)
 }
 
+func (d *MockDriver) CheckpointTask(taskID string, opts *drivers.CheckpointOptions) error {
+	return d.CheckpointTaskF(taskID, opts)
+}
+
+func (d *MockDriver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.RestoreTaskF(cfg, dir)
+}
+
 // SetEnvvars sets path and host env vars depending on the FS isolation used.
 func SetEnvvars(envBuilder *taskenv.Builder, fsmode fsisolation.Mode, taskDir *allocdir.TaskDir) {
 
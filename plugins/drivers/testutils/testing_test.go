@@ -6,6 +6,7 @@ package testutils
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -164,6 +165,70 @@ func TestBaseDriver_StartTask(t *testing.T) {
 
 }
 
+func TestBaseDriver_CheckpointTask(t *testing.T) {
+	ci.Parallel(t)
+
+	var gotOpts *drivers.CheckpointOptions
+	impl := &MockDriver{
+		CheckpointTaskF: func(taskID string, opts *drivers.CheckpointOptions) error {
+			if taskID != "foo" {
+				return fmt.Errorf("task %q not found", taskID)
+			}
+			gotOpts = opts
+			return nil
+		},
+	}
+
+	harness := NewDriverHarness(t, impl)
+	defer harness.Kill()
+
+	cd, ok := harness.DriverPlugin.(drivers.CheckpointDriver)
+	must.True(t, ok)
+
+	opts := &drivers.CheckpointOptions{Dir: "/tmp/checkpoint", LeaveRunning: true}
+	must.NoError(t, cd.CheckpointTask("foo", opts))
+	must.Eq(t, opts, gotOpts)
+	must.ErrorContains(t, cd.CheckpointTask("bar", opts), `task "bar" not found`)
+}
+
+func TestBaseDriver_RestoreTask(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &drivers.TaskConfig{
+		ID: "foo",
+	}
+	impl := &MockDriver{
+		RestoreTaskF: func(c *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+			if dir != "/tmp/checkpoint" {
+				return nil, nil, structs.NewRecoverableError(fmt.Errorf("no checkpoint in %s", dir), true)
+			}
+			handle := drivers.NewTaskHandle(1)
+			handle.Config = c
+			handle.State = drivers.TaskStateRunning
+			return handle, &drivers.DriverNetwork{IP: "10.0.0.1", PortMap: map[string]int{"http": 80}}, nil
+		},
+	}
+
+	harness := NewDriverHarness(t, impl)
+	defer harness.Kill()
+
+	cd, ok := harness.DriverPlugin.(drivers.CheckpointDriver)
+	must.True(t, ok)
+
+	handle, net, err := cd.RestoreTask(cfg, "/tmp/checkpoint")
+	must.NoError(t, err)
+	must.Eq(t, cfg.ID, handle.Config.ID)
+	must.Eq(t, drivers.TaskStateRunning, handle.State)
+	must.Eq(t, "10.0.0.1", net.IP)
+	must.Eq(t, map[string]int{"http": 80}, net.PortMap)
+
+	_, _, err = cd.RestoreTask(cfg, "/tmp/missing")
+	must.ErrorContains(t, err, "no checkpoint in /tmp/missing")
+	rec, ok := err.(structs.Recoverable)
+	must.True(t, ok)
+	must.True(t, rec.IsRecoverable())
+}
+
 func TestBaseDriver_WaitTask(t *testing.T) {
 	ci.Parallel(t)
 
@@ -275,6 +340,7 @@ func TestBaseDriver_Capabilities(t *testing.T) {
 		SendSignals:         true,
 		Exec:                true,
 		FSIsolation:         drivers.FSIsolationNone,
+		Checkpoint:          true,
 	}
 	d := &MockDriver{
 		CapabilitiesF: func() (*drivers.Capabilities, error) {
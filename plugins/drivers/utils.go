@@ -4,6 +4,8 @@
 package drivers
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -157,6 +159,15 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 			CpusetCgroupPath: pb.LinuxResources.CpusetCgroup,
 			PercentTicks:     pb.LinuxResources.PercentTicks,
 		}
+		if diskIO := pb.LinuxResources.DiskIo; diskIO != nil {
+			r.LinuxResources.DiskIO = &DiskIOLimits{
+				Device:    diskIO.Device,
+				ReadBPS:   diskIO.ReadBps,
+				WriteBPS:  diskIO.WriteBps,
+				ReadIOPS:  diskIO.ReadIops,
+				WriteIOPS: diskIO.WriteIops,
+			}
+		}
 	}
 
 	if pb.Ports != nil {
@@ -227,6 +238,15 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 			CpusetCgroup:     r.LinuxResources.CpusetCgroupPath,
 			PercentTicks:     r.LinuxResources.PercentTicks,
 		}
+		if diskIO := r.LinuxResources.DiskIO; diskIO != nil {
+			pb.LinuxResources.DiskIo = &proto.DiskIOLimits{
+				Device:    diskIO.Device,
+				ReadBps:   diskIO.ReadBPS,
+				WriteBps:  diskIO.WriteBPS,
+				ReadIops:  diskIO.ReadIOPS,
+				WriteIops: diskIO.WriteIOPS,
+			}
+		}
 	}
 
 	if r.Ports != nil {
@@ -268,6 +288,7 @@ func DeviceFromProto(device *proto.Device) *DeviceConfig {
 		TaskPath:    device.TaskPath,
 		HostPath:    device.HostPath,
 		Permissions: device.CgroupPermissions,
+		CDIName:     device.CdiName,
 	}
 }
 
@@ -320,6 +341,7 @@ func DeviceToProto(device *DeviceConfig) *proto.Device {
 		TaskPath:          device.TaskPath,
 		HostPath:          device.HostPath,
 		CgroupPermissions: device.Permissions,
+		CdiName:           device.CDIName,
 	}
 }
 
@@ -350,6 +372,41 @@ func MountToProto(mount *MountConfig) *proto.Mount {
 	}
 }
 
+// networkOverrideToProto returns the network override of the task network set
+// by the driver, if any.
+func networkOverrideToProto(net *DriverNetwork) (*proto.NetworkOverride, error) {
+	if net == nil {
+		return nil, nil
+	}
+	pb := &proto.NetworkOverride{
+		PortMap:       map[string]int32{},
+		Addr:          net.IP,
+		AutoAdvertise: net.AutoAdvertise,
+	}
+	for k, v := range net.PortMap {
+		if v > math.MaxInt32 {
+			return nil, fmt.Errorf("port map out of bounds")
+		}
+		pb.PortMap[k] = int32(v)
+	}
+	return pb, nil
+}
+
+func networkOverrideFromProto(pb *proto.NetworkOverride) *DriverNetwork {
+	if pb == nil {
+		return nil
+	}
+	net := &DriverNetwork{
+		PortMap:       map[string]int{},
+		IP:            pb.Addr,
+		AutoAdvertise: pb.AutoAdvertise,
+	}
+	for k, v := range pb.PortMap {
+		net.PortMap[k] = int(v)
+	}
+	return net
+}
+
 func taskHandleFromProto(pb *proto.TaskHandle) *TaskHandle {
 	if pb == nil {
 		return &TaskHandle{}
@@ -477,6 +534,7 @@ func resourceUsageToProto(ru *ResourceUsage) *proto.TaskResourceUsage {
 		ThrottledPeriods: ru.CpuStats.ThrottledPeriods,
 		ThrottledTime:    ru.CpuStats.ThrottledTime,
 		Percent:          ru.CpuStats.Percent,
+		Pressure:         pressureToProto(ru.CpuStats.Pressure),
 	}
 
 	memory := &proto.MemoryUsage{
@@ -488,11 +546,14 @@ func resourceUsageToProto(ru *ResourceUsage) *proto.TaskResourceUsage {
 		MaxUsage:       ru.MemoryStats.MaxUsage,
 		KernelUsage:    ru.MemoryStats.KernelUsage,
 		KernelMaxUsage: ru.MemoryStats.KernelMaxUsage,
+		Pressure:       pressureToProto(ru.MemoryStats.Pressure),
 	}
 
 	return &proto.TaskResourceUsage{
-		Cpu:    cpu,
-		Memory: memory,
+		Cpu:     cpu,
+		Memory:  memory,
+		BlockIo: blockIOStatsToProto(ru.BlockIOStats),
+		Network: networkStatsToProto(ru.NetworkStats),
 	}
 }
 
@@ -507,6 +568,7 @@ func resourceUsageFromProto(pb *proto.TaskResourceUsage) *ResourceUsage {
 			ThrottledPeriods: pb.Cpu.ThrottledPeriods,
 			ThrottledTime:    pb.Cpu.ThrottledTime,
 			Percent:          pb.Cpu.Percent,
+			Pressure:         pressureFromProto(pb.Cpu.Pressure),
 		}
 	}
 
@@ -521,12 +583,113 @@ func resourceUsageFromProto(pb *proto.TaskResourceUsage) *ResourceUsage {
 			MaxUsage:       pb.Memory.MaxUsage,
 			KernelUsage:    pb.Memory.KernelUsage,
 			KernelMaxUsage: pb.Memory.KernelMaxUsage,
+			Pressure:       pressureFromProto(pb.Memory.Pressure),
 		}
 	}
 
 	return &ResourceUsage{
-		CpuStats:    &cpu,
-		MemoryStats: &memory,
+		CpuStats:     &cpu,
+		MemoryStats:  &memory,
+		BlockIOStats: blockIOStatsFromProto(pb.BlockIo),
+		NetworkStats: networkStatsFromProto(pb.Network),
+	}
+}
+
+func blockIOStatsToProto(bs *BlockIOStats) *proto.BlockIOUsage {
+	if bs == nil {
+		return nil
+	}
+
+	return &proto.BlockIOUsage{
+		ReadBytes:      bs.ReadBytes,
+		WriteBytes:     bs.WriteBytes,
+		ReadOps:        bs.ReadOps,
+		WriteOps:       bs.WriteOps,
+		MeasuredFields: bs.Measured,
+	}
+}
+
+func blockIOStatsFromProto(pb *proto.BlockIOUsage) *BlockIOStats {
+	if pb == nil {
+		return nil
+	}
+
+	return &BlockIOStats{
+		ReadBytes:  pb.ReadBytes,
+		WriteBytes: pb.WriteBytes,
+		ReadOps:    pb.ReadOps,
+		WriteOps:   pb.WriteOps,
+		Measured:   pb.MeasuredFields,
+	}
+}
+
+func networkStatsToProto(ns *NetworkStats) *proto.NetworkUsage {
+	if ns == nil {
+		return nil
+	}
+
+	return &proto.NetworkUsage{
+		RxBytes:        ns.RxBytes,
+		RxPackets:      ns.RxPackets,
+		RxErrors:       ns.RxErrors,
+		RxDropped:      ns.RxDropped,
+		TxBytes:        ns.TxBytes,
+		TxPackets:      ns.TxPackets,
+		TxErrors:       ns.TxErrors,
+		TxDropped:      ns.TxDropped,
+		MeasuredFields: ns.Measured,
+	}
+}
+
+func networkStatsFromProto(pb *proto.NetworkUsage) *NetworkStats {
+	if pb == nil {
+		return nil
+	}
+
+	return &NetworkStats{
+		RxBytes:   pb.RxBytes,
+		RxPackets: pb.RxPackets,
+		RxErrors:  pb.RxErrors,
+		RxDropped: pb.RxDropped,
+		TxBytes:   pb.TxBytes,
+		TxPackets: pb.TxPackets,
+		TxErrors:  pb.TxErrors,
+		TxDropped: pb.TxDropped,
+		Measured:  pb.MeasuredFields,
+	}
+}
+
+func pressureToProto(ps *PressureStats) *proto.Pressure {
+	if ps == nil {
+		return nil
+	}
+
+	return &proto.Pressure{
+		SomeAvg10:  ps.SomeAvg10,
+		SomeAvg60:  ps.SomeAvg60,
+		SomeAvg300: ps.SomeAvg300,
+		SomeTotal:  ps.SomeTotal,
+		FullAvg10:  ps.FullAvg10,
+		FullAvg60:  ps.FullAvg60,
+		FullAvg300: ps.FullAvg300,
+		FullTotal:  ps.FullTotal,
+	}
+}
+
+func pressureFromProto(pb *proto.Pressure) *PressureStats {
+	if pb == nil {
+		return nil
+	}
+
+	return &PressureStats{
+		SomeAvg10:  pb.SomeAvg10,
+		SomeAvg60:  pb.SomeAvg60,
+		SomeAvg300: pb.SomeAvg300,
+		SomeTotal:  pb.SomeTotal,
+		FullAvg10:  pb.FullAvg10,
+		FullAvg60:  pb.FullAvg60,
+		FullAvg300: pb.FullAvg300,
+		FullTotal:  pb.FullTotal,
 	}
 }
 
@@ -541,6 +704,7 @@ var cpuUsageMeasuredFieldToProtoMap = map[string]proto.CPUUsage_Fields{
 	"Throttled Periods": proto.CPUUsage_THROTTLED_PERIODS,
 	"Throttled Time":    proto.CPUUsage_THROTTLED_TIME,
 	"Percent":           proto.CPUUsage_PERCENT,
+	"Pressure":          proto.CPUUsage_PRESSURE,
 }
 
 var cpuUsageMeasuredFieldFromProtoMap = map[proto.CPUUsage_Fields]string{
@@ -550,6 +714,7 @@ var cpuUsageMeasuredFieldFromProtoMap = map[proto.CPUUsage_Fields]string{
 	proto.CPUUsage_THROTTLED_PERIODS: "Throttled Periods",
 	proto.CPUUsage_THROTTLED_TIME:    "Throttled Time",
 	proto.CPUUsage_PERCENT:           "Percent",
+	proto.CPUUsage_PRESSURE:          "Pressure",
 }
 
 func cpuUsageMeasuredFieldsToProto(fields []string) []proto.CPUUsage_Fields {
@@ -584,6 +749,7 @@ var memoryUsageMeasuredFieldToProtoMap = map[string]proto.MemoryUsage_Fields{
 	"Max Usage":        proto.MemoryUsage_MAX_USAGE,
 	"Kernel Usage":     proto.MemoryUsage_KERNEL_USAGE,
 	"Kernel Max Usage": proto.MemoryUsage_KERNEL_MAX_USAGE,
+	"Pressure":         proto.MemoryUsage_PRESSURE,
 }
 
 var memoryUsageMeasuredFieldFromProtoMap = map[proto.MemoryUsage_Fields]string{
@@ -594,6 +760,7 @@ var memoryUsageMeasuredFieldFromProtoMap = map[proto.MemoryUsage_Fields]string{
 	proto.MemoryUsage_MAX_USAGE:        "Max Usage",
 	proto.MemoryUsage_KERNEL_USAGE:     "Kernel Usage",
 	proto.MemoryUsage_KERNEL_MAX_USAGE: "Kernel Max Usage",
+	proto.MemoryUsage_PRESSURE:         "Pressure",
 }
 
 func memoryUsageMeasuredFieldsToProto(fields []string) []proto.MemoryUsage_Fields {
@@ -6,6 +6,7 @@ package drivers
 import (
 	"testing"
 
+	pb "github.com/golang/protobuf/proto"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers/proto"
@@ -38,6 +39,84 @@ func TestResourceUsageRoundTrip(t *testing.T) {
 	must.Eq(t, parsed, input)
 }
 
+func TestResourceUsageRoundTrip_Pressure(t *testing.T) {
+	input := &ResourceUsage{
+		CpuStats: &CpuStats{
+			ThrottledPeriods: 12,
+			ThrottledTime:    3456,
+			Pressure: &PressureStats{
+				SomeAvg10:  1.5,
+				SomeAvg60:  0.75,
+				SomeAvg300: 0.25,
+				SomeTotal:  123456,
+			},
+			Measured: []string{"Throttled Periods", "Throttled Time", "Pressure"},
+		},
+		MemoryStats: &MemoryStats{
+			RSS:   25681920,
+			Cache: 1024,
+			Pressure: &PressureStats{
+				SomeAvg10: 2.5,
+				SomeTotal: 789,
+				FullAvg10: 1.25,
+				FullTotal: 456,
+			},
+			Measured: []string{"RSS", "Cache", "Pressure"},
+		},
+	}
+
+	parsed := resourceUsageFromProto(resourceUsageToProto(input))
+	must.Eq(t, parsed, input)
+}
+
+func TestResourceUsageRoundTrip_IO(t *testing.T) {
+	input := &ResourceUsage{
+		CpuStats: &CpuStats{
+			Percent:  12.5,
+			Measured: []string{"Percent"},
+		},
+		MemoryStats: &MemoryStats{
+			RSS:      1024,
+			Measured: []string{"RSS"},
+		},
+		BlockIOStats: &BlockIOStats{
+			ReadBytes:  4096,
+			WriteBytes: 8192,
+			ReadOps:    1,
+			WriteOps:   2,
+			Measured:   []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"},
+		},
+		NetworkStats: &NetworkStats{
+			RxBytes:   100,
+			RxPackets: 10,
+			RxErrors:  1,
+			RxDropped: 2,
+			TxBytes:   200,
+			TxPackets: 20,
+			TxErrors:  3,
+			TxDropped: 4,
+			Measured:  []string{"Rx Bytes", "Tx Bytes"},
+		},
+	}
+
+	// the stats must survive the wire encoding of the driver plugins
+	b, err := pb.Marshal(resourceUsageToProto(input))
+	must.NoError(t, err)
+	var out proto.TaskResourceUsage
+	must.NoError(t, pb.Unmarshal(b, &out))
+
+	parsed := resourceUsageFromProto(&out)
+	must.Eq(t, parsed, input)
+
+	// stats not measured by the driver are left unset
+	parsed = resourceUsageFromProto(resourceUsageToProto(&ResourceUsage{
+		CpuStats:    &CpuStats{},
+		MemoryStats: &MemoryStats{},
+	}))
+	must.Nil(t, parsed.BlockIOStats)
+	must.Nil(t, parsed.NetworkStats)
+}
+
 func TestTaskConfigRoundTrip(t *testing.T) {
 
 	input := &TaskConfig{
@@ -64,6 +143,12 @@ func TestTaskConfigRoundTrip(t *testing.T) {
 				MemoryLimitBytes: 300 * 1024 * 1024,
 				CPUShares:        100,
 				PercentTicks:     float64(100) / float64(3200),
+				DiskIO: &DiskIOLimits{
+					Device:    "/dev/sda",
+					WriteBPS:  10 * 1024 * 1024,
+					ReadIOPS:  1000,
+					WriteIOPS: 500,
+				},
 			},
 			Ports: &structs.AllocatedPorts{
 				{
@@ -22,6 +22,7 @@ import (
 
 const (
 	FilterConstraintHostVolumes                    = "missing compatible host volumes"
+	FilterConstraintRescheduleAvoidNode            = "avoided after allocation failure"
 	FilterConstraintCSIPluginTemplate              = "CSI plugin %s is missing from client %s"
 	FilterConstraintCSIPluginUnhealthyTemplate     = "CSI plugin %s is unhealthy on client %s"
 	FilterConstraintCSIPluginMaxVolumesTemplate    = "CSI plugin %s has the maximum number of volumes on client %s"
@@ -505,6 +506,7 @@ type NetworkChecker struct {
 	ctx         Context
 	networkMode string
 	ports       []structs.Port
+	cniNetworks []string
 }
 
 func NewNetworkChecker(ctx Context) *NetworkChecker {
@@ -520,6 +522,7 @@ func (c *NetworkChecker) SetNetwork(network *structs.NetworkResource) {
 	c.ports = make([]structs.Port, len(network.DynamicPorts)+len(network.ReservedPorts))
 	c.ports = append(c.ports, network.DynamicPorts...)
 	c.ports = append(c.ports, network.ReservedPorts...)
+	c.cniNetworks = network.CNINetworks
 }
 
 func (c *NetworkChecker) Feasible(option *structs.Node) bool {
@@ -551,6 +554,13 @@ func (c *NetworkChecker) Feasible(option *structs.Node) bool {
 		}
 	}
 
+	for _, name := range c.cniNetworks {
+		if !hasNetworkMode(option, "cni/"+name) {
+			c.ctx.Metrics().FilterNode(option, fmt.Sprintf("missing CNI network %q", name))
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -579,6 +589,12 @@ func (c *NetworkChecker) hasHostNetworks(option *structs.Node) bool {
 }
 
 func (c *NetworkChecker) hasNetwork(option *structs.Node) bool {
+	return hasNetworkMode(option, c.networkMode)
+}
+
+// hasNetworkMode returns true if the node fingerprinted a network with the
+// given mode.
+func hasNetworkMode(option *structs.Node, networkMode string) bool {
 	if option.NodeResources == nil {
 		return false
 	}
@@ -588,7 +604,7 @@ func (c *NetworkChecker) hasNetwork(option *structs.Node) bool {
 		if mode == "" {
 			mode = "host"
 		}
-		if mode == c.networkMode {
+		if mode == networkMode {
 			return true
 		}
 	}
@@ -910,6 +926,17 @@ func (c *ConstraintChecker) Feasible(option *structs.Node) bool {
 	return true
 }
 
+// meetsConstraints returns whether the node meets all the constraints, without
+// recording the node as filtered in the metrics.
+func (c *ConstraintChecker) meetsConstraints(option *structs.Node) bool {
+	for _, constraint := range c.constraints {
+		if !c.meetsConstraint(constraint, option) {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *ConstraintChecker) meetsConstraint(constraint *structs.Constraint, option *structs.Node) bool {
 	// Resolve the targets. Targets that are not present are treated as `nil`.
 	// This is to allow for matching constraints where a target is not present.
@@ -1081,6 +1081,52 @@ func TestNetworkChecker(t *testing.T) {
 	}
 }
 
+func TestNetworkChecker_CNINetworks(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+
+	node := func(modes ...string) *structs.Node {
+		n := mock.Node()
+		for _, mode := range modes {
+			n.NodeResources.Networks = append(n.NodeResources.Networks, &structs.NetworkResource{Mode: mode})
+		}
+		return n
+	}
+
+	nodes := []*structs.Node{
+		node("bridge"),
+		node("bridge", "cni/storage"),
+		node("bridge", "cni/storage", "cni/sriov"),
+	}
+
+	checker := NewNetworkChecker(ctx)
+	cases := []struct {
+		network *structs.NetworkResource
+		results []bool
+	}{
+		{
+			network: &structs.NetworkResource{Mode: "bridge"},
+			results: []bool{true, true, true},
+		},
+		{
+			network: &structs.NetworkResource{Mode: "bridge", CNINetworks: []string{"storage"}},
+			results: []bool{false, true, true},
+		},
+		{
+			network: &structs.NetworkResource{Mode: "bridge", CNINetworks: []string{"storage", "sriov"}},
+			results: []bool{false, false, true},
+		},
+	}
+
+	for _, c := range cases {
+		checker.SetNetwork(c.network)
+		for i, node := range nodes {
+			must.Eq(t, c.results[i], checker.Feasible(node), must.Sprintf("networks=%v, idx=%d", c.network.CNINetworks, i))
+		}
+	}
+}
+
 func TestNetworkChecker_bridge_upgrade_path(t *testing.T) {
 	ci.Parallel(t)
 
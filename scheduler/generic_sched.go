@@ -5,7 +5,9 @@ package scheduler
 
 import (
 	"fmt"
+	"maps"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"time"
 
@@ -56,6 +58,15 @@ const (
 	// allocRescheduled is the status used when an allocation failed and was rescheduled
 	allocRescheduled = "alloc was rescheduled because it failed"
 
+	// allocDecommissioned is the status used when an allocation replaced by
+	// the canaries of a promoted deployment is stopped after its decommission
+	// delay.
+	allocDecommissioned = "alloc decommissioned after deployment promotion"
+
+	// allocSpreadSkew is the status used when an allocation is moved to bring
+	// a spread back within its max skew.
+	allocSpreadSkew = "alloc is being moved to reduce spread skew"
+
 	// blockedEvalMaxPlanDesc is the description used for blocked evals that are
 	// a result of hitting the max number of plan attempts
 	blockedEvalMaxPlanDesc = "created due to placement conflicts"
@@ -73,6 +84,11 @@ const (
 	// timeout has passed.
 	disconnectTimeoutFollowupEvalDesc = "created for delayed disconnect timeout"
 
+	// decommissionFollowupEvalDesc is the description used when creating
+	// follow up evals for allocations that should be stopped after their
+	// decommission delay has passed.
+	decommissionFollowupEvalDesc = "created for delayed decommission"
+
 	// maxPastRescheduleEvents is the maximum number of past reschedule event
 	// that we track when unlimited rescheduling is enabled
 	maxPastRescheduleEvents = 5
@@ -170,7 +186,9 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerJobDependency, structs.EvalTriggerDispatchQueue,
+		structs.EvalTriggerDecommissionDelay:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
@@ -387,6 +405,18 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.eval.Priority, s.planner.ServersMeetMinimumVersion(minVersionMaxClientDisconnect, true))
 
 	results := reconciler.Compute()
+
+	// Move allocations to bring spreads back within their max skew
+	repairs, err := s.computeSpreadRepairs(allocs, results)
+	if err != nil {
+		return err
+	}
+	for _, repair := range repairs {
+		results.destructiveUpdate = append(results.destructiveUpdate, repair)
+		if desired, ok := results.desiredTGUpdates[repair.placeTaskGroup.Name]; ok {
+			desired.Migrate++
+		}
+	}
 	s.logger.Debug("reconciled current state with desired state", "results", log.Fmt("%#v", results))
 
 	if s.eval.AnnotatePlan {
@@ -469,6 +499,138 @@ func (s *GenericScheduler) computeJobAllocs() error {
 	return s.computePlacements(destructive, place, results.taskGroupAllocNameIndexes)
 }
 
+// computeSpreadRepairs returns the destructive updates moving allocations of
+// task groups with a spread that has a max skew, from the attribute values
+// with the most allocations to the ones with the fewest, until the spread is
+// within its max skew. Spreads exceed their max skew when nodes with new
+// attribute values are added or allocations are stopped, and are repaired
+// once the moved allocations can be placed. Task groups with placements or
+// updates pending are left for the placements to repair.
+func (s *GenericScheduler) computeSpreadRepairs(allocs []*structs.Allocation, results *reconcileResults) ([]allocDestructiveResult, error) {
+	if s.batch || s.job == nil || s.job.Stopped() {
+		return nil, nil
+	}
+	if s.deployment != nil && s.deployment.Active() {
+		return nil, nil
+	}
+
+	pending := make(map[string]struct{})
+	for _, place := range results.place {
+		pending[place.taskGroup.Name] = struct{}{}
+	}
+	for _, update := range results.destructiveUpdate {
+		pending[update.placeTaskGroup.Name] = struct{}{}
+	}
+	updated := make(map[string]struct{})
+	for _, stop := range results.stop {
+		updated[stop.alloc.ID] = struct{}{}
+	}
+	for _, update := range results.inplaceUpdate {
+		updated[update.ID] = struct{}{}
+	}
+	for _, updates := range []map[string]*structs.Allocation{
+		results.attributeUpdates, results.disconnectUpdates, results.reconnectUpdates} {
+		for id := range updates {
+			updated[id] = struct{}{}
+		}
+	}
+
+	var nodes []*structs.Node
+	var nodesByID map[string]*structs.Node
+	var repairs []allocDestructiveResult
+	for _, tg := range s.job.TaskGroups {
+		spreads := maxSkewSpreads(s.job, tg)
+		if len(spreads) == 0 {
+			continue
+		}
+		if _, ok := pending[tg.Name]; ok {
+			continue
+		}
+
+		if nodes == nil {
+			var err error
+			nodes, _, _, err = readyNodesInDCsAndPool(s.state, s.job.Datacenters, s.job.NodePool)
+			if err != nil {
+				return nil, err
+			}
+			nodesByID = make(map[string]*structs.Node, len(nodes))
+			for _, node := range nodes {
+				nodesByID[node.ID] = node
+			}
+		}
+
+		var tgAllocs []*structs.Allocation
+		for _, alloc := range allocs {
+			if _, ok := updated[alloc.ID]; ok {
+				continue
+			}
+			if alloc.TaskGroup == tg.Name && !alloc.TerminalStatus() &&
+				alloc.ClientStatus == structs.AllocClientStatusRunning {
+				tgAllocs = append(tgAllocs, alloc)
+			}
+		}
+
+		moved := make(map[string]struct{})
+		for _, spread := range spreads {
+			// Count the allocations of each attribute value, and track the
+			// ones that may be moved
+			counts := make(map[string]int)
+			movable := make(map[string][]*structs.Allocation)
+			for value := range spreadDomains(s.ctx, s.job, tg, nodes, spread.Attribute) {
+				counts[value] = 0
+			}
+			for _, alloc := range tgAllocs {
+				node, ok := nodesByID[alloc.NodeID]
+				if !ok {
+					continue
+				}
+				value, ok := getProperty(node, spread.Attribute)
+				if !ok {
+					continue
+				}
+				if _, ok := counts[value]; !ok {
+					continue
+				}
+				counts[value]++
+				if _, ok := moved[alloc.ID]; !ok {
+					movable[value] = append(movable[value], alloc)
+				}
+			}
+
+			values := slices.Sorted(maps.Keys(counts))
+			for len(values) > 1 {
+				maxValue, minValue := values[0], values[0]
+				for _, value := range values {
+					if counts[value] > counts[maxValue] {
+						maxValue = value
+					}
+					if counts[value] < counts[minValue] {
+						minValue = value
+					}
+				}
+				if counts[maxValue]-counts[minValue] <= spread.MaxSkew || len(movable[maxValue]) == 0 {
+					break
+				}
+
+				n := len(movable[maxValue])
+				alloc := movable[maxValue][n-1]
+				movable[maxValue] = movable[maxValue][:n-1]
+				counts[maxValue]--
+				counts[minValue]++
+				moved[alloc.ID] = struct{}{}
+
+				repairs = append(repairs, allocDestructiveResult{
+					placeName:             alloc.Name,
+					placeTaskGroup:        tg,
+					stopAlloc:             alloc,
+					stopStatusDescription: allocSpreadSkew,
+				})
+			}
+		}
+	}
+	return repairs, nil
+}
+
 // downgradedJobForPlacement returns the previous stable version of the job for
 // downgrading a placement for non-canaries
 func (s *GenericScheduler) downgradedJobForPlacement(p placementResult) (string, *structs.Job, error) {
@@ -525,6 +687,10 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the placements of each gang so they can be backed out if any
+	// allocation of the gang fails to be placed
+	gangs := make(map[string][]*gangPlacement)
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
@@ -598,7 +764,7 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 			}
 
 			// Compute penalty nodes for rescheduled allocs
-			selectOptions := getSelectOptions(prevAllocation, preferredNode)
+			selectOptions := getSelectOptions(prevAllocation, preferredNode, now)
 			selectOptions.AllocName = missing.Name()
 			option := s.selectNextOption(tg, selectOptions)
 
@@ -708,6 +874,14 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
 
+				if tg.Gang != "" {
+					placement := &gangPlacement{alloc: alloc}
+					if stopPrevAlloc {
+						placement.stopped = prevAllocation
+					}
+					gangs[tg.Gang] = append(gangs[tg.Gang], placement)
+				}
+
 			} else {
 				// Lazy initialize the failed map
 				if s.failedTGAllocs == nil {
@@ -743,9 +917,105 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 		}
 	}
 
+	s.backOutFailedGangs(gangs)
 	return nil
 }
 
+// gangPlacement tracks the changes made to the plan by placing an allocation of
+// a gang.
+type gangPlacement struct {
+	alloc *structs.Allocation
+
+	// stopped is the previous allocation stopped by the placement
+	stopped *structs.Allocation
+}
+
+// backOutFailedGangs removes the placements of the gangs where any allocation
+// failed to be placed from the plan, so that the task groups of a gang are
+// placed all or nothing. Plans with placements of gangs are applied all at
+// once, so that they are not partially committed either.
+func (s *GenericScheduler) backOutFailedGangs(gangs map[string][]*gangPlacement) {
+	for gang, placements := range gangs {
+		var failed []string
+		for _, tg := range s.job.TaskGroups {
+			if _, ok := s.failedTGAllocs[tg.Name]; ok && tg.Gang == gang {
+				failed = append(failed, tg.Name)
+			}
+		}
+		if len(failed) == 0 {
+			s.plan.AllAtOnce = true
+			continue
+		}
+
+		s.logger.Debug("failed to place all allocations of gang, backing out its placements",
+			"gang", gang, "failed_task_groups", failed)
+
+		for _, placement := range placements {
+			backOutPlacement(s.plan, placement)
+
+			tgName := placement.alloc.TaskGroup
+			if metric, ok := s.failedTGAllocs[tgName]; ok {
+				metric.CoalescedFailures += 1
+				continue
+			}
+
+			metric := &structs.AllocMetric{}
+			for _, name := range failed {
+				metric.GangFailed = append(metric.GangFailed,
+					fmt.Sprintf("gang %q task group %q", gang, name))
+			}
+			s.failedTGAllocs[tgName] = metric
+		}
+	}
+}
+
+// backOutPlacement removes the allocation, its preemptions and the stop of the
+// previous allocation of the placement from the plan.
+func backOutPlacement(plan *structs.Plan, placement *gangPlacement) {
+	alloc := placement.alloc
+	plan.NodeAllocation[alloc.NodeID] = removeAllocID(plan.NodeAllocation[alloc.NodeID], alloc.ID)
+	if len(plan.NodeAllocation[alloc.NodeID]) == 0 {
+		delete(plan.NodeAllocation, alloc.NodeID)
+	}
+
+	if len(alloc.PreemptedAllocations) > 0 {
+		preemptions := plan.NodePreemptions[alloc.NodeID]
+		for _, id := range alloc.PreemptedAllocations {
+			preemptions = removeAllocID(preemptions, id)
+		}
+		if len(preemptions) > 0 {
+			plan.NodePreemptions[alloc.NodeID] = preemptions
+		} else {
+			delete(plan.NodePreemptions, alloc.NodeID)
+		}
+
+		if plan.Annotations != nil {
+			plan.Annotations.PreemptedAllocs = slices.DeleteFunc(plan.Annotations.PreemptedAllocs,
+				func(stub *structs.AllocListStub) bool {
+					return slices.Contains(alloc.PreemptedAllocations, stub.ID)
+				})
+			if desired := plan.Annotations.DesiredTGUpdates[alloc.TaskGroup]; desired != nil {
+				desired.Preemptions -= uint64(len(alloc.PreemptedAllocations))
+			}
+		}
+	}
+
+	if stopped := placement.stopped; stopped != nil {
+		plan.NodeUpdate[stopped.NodeID] = removeAllocID(plan.NodeUpdate[stopped.NodeID], stopped.ID)
+		if len(plan.NodeUpdate[stopped.NodeID]) == 0 {
+			delete(plan.NodeUpdate, stopped.NodeID)
+		}
+	}
+}
+
+// removeAllocID returns the allocations without the allocation with the given
+// ID.
+func removeAllocID(allocs []*structs.Allocation, id string) []*structs.Allocation {
+	return slices.DeleteFunc(allocs, func(alloc *structs.Allocation) bool {
+		return alloc.ID == id
+	})
+}
+
 // swapAllocInPlan updates a plan to swap out an allocation that's already in
 // the plan with an updated definition of that allocation. The updated
 // definition should be a deep copy.
@@ -793,6 +1063,13 @@ func (s *GenericScheduler) setNodes(job *structs.Job) ([]*structs.Node, map[stri
 	}
 
 	s.stack.SetNodes(nodes)
+
+	overflow, err := readyNodesInDCsAndOverflowPools(s.state, job.Datacenters, job.NodePool)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.stack.SetOverflowNodes(overflow)
+
 	return nodes, byDC, nil
 }
 
@@ -803,8 +1080,8 @@ func needsToSetNodes(a, b *structs.Job) bool {
 		a.NodePool != b.NodePool
 }
 
-// getSelectOptions sets up preferred nodes and penalty nodes
-func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs.Node) *SelectOptions {
+// getSelectOptions sets up preferred nodes, penalty nodes and avoided nodes
+func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs.Node, now time.Time) *SelectOptions {
 	selectOptions := &SelectOptions{}
 	if prevAllocation != nil {
 		penaltyNodes := make(map[string]struct{})
@@ -820,6 +1097,12 @@ func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs
 			}
 		}
 		selectOptions.PenaltyNodeIDs = penaltyNodes
+
+		// Exclude the nodes it recently failed on, if the reschedule policy
+		// asks for it
+		if prevAllocation.Job != nil {
+			selectOptions.AvoidNodeIDs = prevAllocation.ReschedulePolicy().AvoidedNodes(prevAllocation, now)
+		}
 	}
 	if preferredNode != nil {
 		selectOptions.PreferredNodes = []*structs.Node{preferredNode}
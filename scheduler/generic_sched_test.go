@@ -1094,6 +1094,164 @@ func TestServiceSched_JobRegister_NodePool_Downgrade(t *testing.T) {
 	}
 }
 
+// TestServiceSched_JobRegister_NodePool_Overflow tests that allocations are
+// placed in the node pools the job node pool overflows into, by priority, once
+// the job node pool is out of capacity.
+func TestServiceSched_JobRegister_NodePool_Overflow(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	poolProd := mock.NodePool()
+	poolBurst := mock.NodePool()
+	poolSpot := mock.NodePool()
+	poolEmpty := mock.NodePool()
+	poolProd.Overflow = []*structs.NodePoolOverflow{
+		{Pool: poolSpot.Name, Priority: 10},
+		{Pool: poolBurst.Name, Priority: 50},
+		{Pool: poolEmpty.Name, Priority: 100},
+	}
+	poolProd.SetHash()
+	must.NoError(t, h.State.UpsertNodePools(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.NodePool{poolProd, poolBurst, poolSpot, poolEmpty}))
+
+	// Create a node in each pool with capacity for 3 allocations.
+	nodePools := map[string]string{}
+	for _, pool := range []string{poolProd.Name, poolBurst.Name, poolSpot.Name} {
+		node := mock.Node()
+		node.NodePool = pool
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		nodePools[node.ID] = pool
+	}
+
+	job := mock.Job()
+	job.NodePool = poolProd.Name
+	job.TaskGroups[0].Count = 9
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 2500
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 0, h.CreateEvals)
+
+	placed := map[string]int{}
+	for nodeID, allocs := range h.Plans[0].NodeAllocation {
+		placed[nodePools[nodeID]] += len(allocs)
+	}
+	must.Eq(t, map[string]int{
+		poolProd.Name:  3,
+		poolBurst.Name: 3,
+		poolSpot.Name:  3,
+	}, placed)
+}
+
+func TestServiceSched_JobRegister_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		memoryMB int
+		placed   bool
+	}{
+		{
+			name:     "gang fits",
+			memoryMB: 256,
+			placed:   true,
+		},
+		{
+			name:     "gang does not fit",
+			memoryMB: 100_000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHarness(t)
+			for i := 0; i < 3; i++ {
+				node := mock.Node()
+				must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+			}
+
+			// Create a job with two groups in the same gang, one of which may
+			// not fit on any node, and a group outside of the gang.
+			job := mock.Job()
+			workers := job.TaskGroups[0]
+			workers.Name = "workers"
+			workers.Count = 3
+			workers.Gang = "training"
+
+			ps := workers.Copy()
+			ps.Name = "ps"
+			ps.Count = 1
+			ps.Tasks[0].Resources.MemoryMB = tc.memoryMB
+
+			other := workers.Copy()
+			other.Name = "other"
+			other.Count = 1
+			other.Gang = ""
+
+			job.TaskGroups = []*structs.TaskGroup{workers, ps, other}
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			eval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    job.Priority,
+				TriggeredBy: structs.EvalTriggerJobRegister,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+			must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+			must.Len(t, 1, h.Plans)
+			plan := h.Plans[0]
+
+			placed := map[string]int{}
+			for _, allocs := range plan.NodeAllocation {
+				for _, alloc := range allocs {
+					placed[alloc.TaskGroup]++
+				}
+			}
+
+			if tc.placed {
+				must.True(t, plan.AllAtOnce)
+				must.Eq(t, map[string]int{"workers": 3, "ps": 1, "other": 1}, placed)
+				must.Len(t, 0, h.CreateEvals)
+				return
+			}
+
+			// Only the group outside of the gang is placed and the gang is
+			// blocked until it can be placed entirely.
+			must.False(t, plan.AllAtOnce)
+			must.Eq(t, map[string]int{"other": 1}, placed)
+			must.Len(t, 1, h.CreateEvals)
+			must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+
+			must.Len(t, 1, h.Evals)
+			failed := h.Evals[0].FailedTGAllocs
+			must.MapLen(t, 2, failed)
+			must.MapContainsKey(t, failed, "ps")
+			must.Eq(t, []string{`gang "training" task group "ps"`}, failed["workers"].GangFailed)
+			must.Eq(t, 2, failed["workers"].CoalescedFailures)
+
+			must.Eq(t, 3, h.Evals[0].QueuedAllocations["workers"])
+			must.Eq(t, 1, h.Evals[0].QueuedAllocations["ps"])
+			must.Eq(t, 0, h.Evals[0].QueuedAllocations["other"])
+		})
+	}
+}
+
 // Test job registration with even spread across dc
 func TestServiceSched_EvenSpread(t *testing.T) {
 	ci.Parallel(t)
@@ -5284,6 +5442,79 @@ func TestBatchSched_Run_FailedAlloc(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestBatchSched_Array_PartialCompletion(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.Array = &structs.JobArray{Count: 4}
+	job.TaskGroups[0].Count = 4
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	// The first index completed, the second failed, the third is running and
+	// the last one was never placed.
+	tgName := job.TaskGroups[0].Name
+	now := time.Now()
+	var allocs []*structs.Allocation
+	for i, status := range []string{
+		structs.AllocClientStatusComplete,
+		structs.AllocClientStatusFailed,
+		structs.AllocClientStatusRunning,
+	} {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = structs.AllocName(job.ID, tgName, uint(i))
+		alloc.ClientStatus = status
+		alloc.TaskStates = map[string]*structs.TaskState{"web": {State: "dead",
+			StartedAt:  now.Add(-1 * time.Hour),
+			FinishedAt: now.Add(-10 * time.Second)}}
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewBatchScheduler, eval))
+	must.Len(t, 1, h.Plans)
+
+	// The failed index is rescheduled with its index, the missing index is
+	// placed, and the completed index isn't run again.
+	placed := make(map[string]*structs.Allocation)
+	for _, alloc := range h.Plans[0].NodeAllocation[node.ID] {
+		placed[alloc.Name] = alloc
+	}
+	must.MapLen(t, 2, placed)
+	must.MapContainsKeys(t, placed, []string{
+		structs.AllocName(job.ID, tgName, 1),
+		structs.AllocName(job.ID, tgName, 3),
+	})
+	must.Eq(t, allocs[1].ID, placed[structs.AllocName(job.ID, tgName, 1)].PreviousAllocation)
+
+	// The rescheduled index counts both of its attempts.
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, true)
+	must.NoError(t, err)
+	tgSummary := structs.NewJobArraySummary(job, out).TaskGroups[tgName]
+	must.Eq(t, 1, tgSummary.Complete)
+	must.Eq(t, 0, tgSummary.Failed)
+	must.Eq(t, 2, tgSummary.Indexes[1].Attempts)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
 func TestBatchSched_Run_LostAlloc(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6034,6 +6265,43 @@ func TestGenericSched_AllocFit_MemoryOversubscription(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestGenericSched_AllocFit_Burstable(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	node := mock.Node()
+	node.ReservedResources.Cpu.CpuShares = 0
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Burstable tasks are placed on their minimum CPU
+	job := mock.Job()
+	job.TaskGroups[0].Count = 10
+	job.TaskGroups[0].Tasks[0].Resources.CPU = 2800
+	job.TaskGroups[0].Tasks[0].Resources.CPUMax = 14000
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 10
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.Len(t, 5, out)
+	for _, alloc := range out {
+		cpu := alloc.AllocatedResources.Tasks["web"].Cpu
+		must.Eq(t, 2800, cpu.CpuShares)
+		must.Eq(t, 14000, cpu.CpuMax)
+	}
+}
+
 func TestGenericSched_ChainedAlloc(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6686,12 +6954,13 @@ func TestServiceSched_Preemption(t *testing.T) {
 
 	// Create a mock evaluation to register the job
 	eval := &structs.Evaluation{
-		Namespace:   structs.DefaultNamespace,
-		ID:          uuid.Generate(),
-		Priority:    job3.Priority,
-		TriggeredBy: structs.EvalTriggerJobRegister,
-		JobID:       job3.ID,
-		Status:      structs.EvalStatusPending,
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     job3.Priority,
+		TriggeredBy:  structs.EvalTriggerJobRegister,
+		JobID:        job3.ID,
+		Status:       structs.EvalStatusPending,
+		AnnotatePlan: true,
 	}
 
 	require.NoError(h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
@@ -6706,6 +6975,15 @@ func TestServiceSched_Preemption(t *testing.T) {
 	// Ensure the eval has no spawned blocked eval
 	require.Equal(0, len(h.CreateEvals))
 
+	// Ensure the plan annotations list the proposed preemptions
+	require.NotNil(plan.Annotations)
+	annotatedPreemptions := make(map[string]struct{})
+	for _, stub := range plan.Annotations.PreemptedAllocs {
+		annotatedPreemptions[stub.ID] = struct{}{}
+	}
+	require.Equal(expectedPreemptedAllocs, annotatedPreemptions)
+	require.Equal(uint64(2), plan.Annotations.DesiredTGUpdates["web"].Preemptions)
+
 	// Ensure the plan allocated
 	var planned []*structs.Allocation
 	for _, allocList := range plan.NodeAllocation {
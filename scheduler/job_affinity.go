@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"math"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// RequiredJobAffinityIterator is a FeasibleIterator which returns nodes that
+// satisfy the required job affinities of the task group. Nodes must run an
+// allocation matching each required affinity and must not run one matching
+// each required anti-affinity.
+type RequiredJobAffinityIterator struct {
+	ctx        Context
+	source     FeasibleIterator
+	namespace  string
+	affinities []*structs.JobAffinity
+}
+
+// NewRequiredJobAffinityIterator creates a RequiredJobAffinityIterator from a
+// source.
+func NewRequiredJobAffinityIterator(ctx Context, source FeasibleIterator) *RequiredJobAffinityIterator {
+	return &RequiredJobAffinityIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *RequiredJobAffinityIterator) SetJob(job *structs.Job) {
+	iter.namespace = job.Namespace
+}
+
+func (iter *RequiredJobAffinityIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.affinities = nil
+	for _, affinity := range tg.JobAffinities {
+		if affinity.Required {
+			iter.affinities = append(iter.affinities, affinity)
+		}
+	}
+}
+
+func (iter *RequiredJobAffinityIterator) Next() *structs.Node {
+	for {
+		option := iter.source.Next()
+		if option == nil || len(iter.affinities) == 0 {
+			return option
+		}
+
+		proposed, err := iter.ctx.ProposedAllocs(option.ID)
+		if err != nil {
+			iter.ctx.Logger().Named("job_affinity").Error("failed to get proposed allocations", "error", err)
+			continue
+		}
+
+		if affinity := iter.unsatisfied(proposed); affinity != nil {
+			iter.ctx.Metrics().FilterNode(option, affinity.String())
+			continue
+		}
+		return option
+	}
+}
+
+// unsatisfied returns the first required job affinity the allocations of a
+// node don't satisfy.
+func (iter *RequiredJobAffinityIterator) unsatisfied(proposed []*structs.Allocation) *structs.JobAffinity {
+	for _, affinity := range iter.affinities {
+		if matchesJobAffinity(iter.namespace, affinity, proposed) == affinity.IsAntiAffinity() {
+			return affinity
+		}
+	}
+	return nil
+}
+
+func (iter *RequiredJobAffinityIterator) Reset() {
+	iter.source.Reset()
+}
+
+// JobAffinityIterator is a RankIterator that applies a weighted score to nodes
+// according to whether they run allocations matching the job affinities of
+// the task group that are not required.
+type JobAffinityIterator struct {
+	ctx        Context
+	source     RankIterator
+	namespace  string
+	affinities []*structs.JobAffinity
+}
+
+// NewJobAffinityIterator is used to create a JobAffinityIterator that applies
+// a weighted score according to whether nodes run allocations matching the
+// job affinities of the task group.
+func NewJobAffinityIterator(ctx Context, source RankIterator) *JobAffinityIterator {
+	return &JobAffinityIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *JobAffinityIterator) SetJob(job *structs.Job) {
+	iter.namespace = job.Namespace
+}
+
+func (iter *JobAffinityIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.affinities = nil
+	for _, affinity := range tg.JobAffinities {
+		if !affinity.Required {
+			iter.affinities = append(iter.affinities, affinity)
+		}
+	}
+}
+
+func (iter *JobAffinityIterator) hasAffinities() bool {
+	return len(iter.affinities) > 0
+}
+
+func (iter *JobAffinityIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || !iter.hasAffinities() {
+		return option
+	}
+
+	proposed, err := option.ProposedAllocs(iter.ctx)
+	if err != nil {
+		iter.ctx.Logger().Named("job_affinity").Error("failed retrieving proposed allocations", "error", err)
+		return option
+	}
+
+	sumWeight := 0.0
+	totalAffinityScore := 0.0
+	for _, affinity := range iter.affinities {
+		sumWeight += math.Abs(float64(affinity.Weight))
+		if matchesJobAffinity(iter.namespace, affinity, proposed) {
+			totalAffinityScore += float64(affinity.Weight)
+		}
+	}
+	normScore := totalAffinityScore / sumWeight
+	option.Scores = append(option.Scores, normScore)
+	iter.ctx.Metrics().ScoreNode(option.Node, "job-affinity", normScore)
+	return option
+}
+
+func (iter *JobAffinityIterator) Reset() {
+	iter.source.Reset()
+}
+
+// matchesJobAffinity returns whether any of the allocations is matched by the
+// job affinity.
+func matchesJobAffinity(namespace string, affinity *structs.JobAffinity, allocs []*structs.Allocation) bool {
+	for _, alloc := range allocs {
+		if affinity.Matches(namespace, alloc) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestRequiredJobAffinityIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	nodes := []*structs.Node{mock.Node(), mock.Node(), mock.Node()}
+
+	// Run an allocation of another job on the first node and propose one on
+	// the second node.
+	existing := mock.Alloc()
+	existing.NodeID = nodes[0].ID
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{existing}))
+
+	proposed := mock.Alloc()
+	proposed.JobID = existing.JobID
+	proposed.Job = existing.Job
+	proposed.NodeID = nodes[1].ID
+	ctx.Plan().NodeAllocation[nodes[1].ID] = []*structs.Allocation{proposed}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+
+	testCases := []struct {
+		name     string
+		affinity *structs.JobAffinity
+		expected []*structs.Node
+	}{
+		{
+			name:     "affinity to job",
+			affinity: &structs.JobAffinity{Job: existing.JobID, Weight: 50, Required: true},
+			expected: nodes[:2],
+		},
+		{
+			name:     "anti-affinity to job",
+			affinity: &structs.JobAffinity{Job: existing.JobID, Weight: -50, Required: true},
+			expected: nodes[2:],
+		},
+		{
+			name:     "anti-affinity to service",
+			affinity: &structs.JobAffinity{Service: "web-frontend", Weight: -50, Required: true},
+			expected: nodes[2:],
+		},
+		{
+			name:     "preferences are not required",
+			affinity: &structs.JobAffinity{Job: existing.JobID, Weight: 50},
+			expected: nodes,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tg.JobAffinities = []*structs.JobAffinity{tc.affinity}
+
+			static := NewStaticIterator(ctx, nodes)
+			iter := NewRequiredJobAffinityIterator(ctx, static)
+			iter.SetJob(job)
+			iter.SetTaskGroup(tg)
+
+			must.Eq(t, tc.expected, collectFeasible(iter))
+		})
+	}
+
+	// The filtered nodes are explained by the affinity.
+	ctx.Reset()
+	affinity := &structs.JobAffinity{Job: existing.JobID, Weight: -50, Required: true}
+	tg.JobAffinities = []*structs.JobAffinity{affinity}
+	iter := NewRequiredJobAffinityIterator(ctx, NewStaticIterator(ctx, nodes))
+	iter.SetJob(job)
+	iter.SetTaskGroup(tg)
+	collectFeasible(iter)
+	must.Eq(t, 2, ctx.Metrics().ConstraintFiltered[affinity.String()])
+}
+
+func TestJobAffinityIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	nodes := []*RankedNode{{Node: mock.Node()}, {Node: mock.Node()}}
+
+	existing := mock.Alloc()
+	existing.NodeID = nodes[0].Node.ID
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{existing}))
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.JobAffinities = []*structs.JobAffinity{
+		{Job: existing.JobID, Weight: 50},
+		{Service: "web-admin", Weight: -50},
+		{Job: "other", Weight: 100, Required: true},
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	iter := NewJobAffinityIterator(ctx, static)
+	iter.SetJob(job)
+	iter.SetTaskGroup(tg)
+	out := collectRanked(iter)
+
+	// The first node matches both preferences, which cancel each other out,
+	// and the required affinity is not scored.
+	must.Len(t, 2, out)
+	must.Eq(t, []float64{0}, out[0].Scores)
+	must.Eq(t, []float64{0}, out[1].Scores)
+
+	tg.JobAffinities = tg.JobAffinities[:1]
+	iter.SetTaskGroup(tg)
+	for _, node := range nodes {
+		node.Scores = nil
+	}
+	static.Reset()
+	out = collectRanked(iter)
+	must.Eq(t, []float64{1}, out[0].Scores)
+	must.Eq(t, []float64{0}, out[1].Scores)
+}
@@ -119,6 +119,10 @@ type Preemptor struct {
 	// jobPriority is the priority of the job being preempted
 	jobPriority int
 
+	// minPriorityDelta is the minimum difference between jobPriority and the
+	// priority of the jobs of preemptible allocations
+	minPriorityDelta int
+
 	// jobID is the ID of the job being preempted
 	jobID *structs.NamespacedID
 
@@ -137,6 +141,7 @@ func NewPreemptor(jobPriority int, ctx Context, jobID *structs.NamespacedID) *Pr
 	return &Preemptor{
 		currentPreemptions: make(map[structs.NamespacedID]map[string]int),
 		jobPriority:        jobPriority,
+		minPriorityDelta:   structs.DefaultPreemptionMinPriorityDelta,
 		jobID:              jobID,
 		allocDetails:       make(map[string]*allocInfo),
 		ctx:                ctx,
@@ -153,6 +158,7 @@ func (p *Preemptor) Copy() *Preemptor {
 		currentPreemptions:     currentPreemptions,
 		allocDetails:           helper.DeepCopyMap(p.allocDetails),
 		jobPriority:            p.jobPriority,
+		minPriorityDelta:       p.minPriorityDelta,
 		jobID:                  p.jobID,
 		nodeRemainingResources: p.nodeRemainingResources.Copy(),
 		currentAllocs:          helper.CopySlice(p.currentAllocs),
@@ -160,6 +166,12 @@ func (p *Preemptor) Copy() *Preemptor {
 	}
 }
 
+// SetMinPriorityDelta sets the minimum difference between the priority of the
+// job and the priority of the jobs whose allocations can be preempted
+func (p *Preemptor) SetMinPriorityDelta(delta int) {
+	p.minPriorityDelta = delta
+}
+
 // SetNode sets the node
 func (p *Preemptor) SetNode(node *structs.Node) {
 	nodeRemainingResources := node.NodeResources.Comparable()
@@ -222,7 +234,8 @@ func (p *Preemptor) getNumPreemptions(alloc *structs.Allocation) int {
 }
 
 // PreemptForTaskGroup computes a list of allocations to preempt to accommodate
-// the resources asked for. Only allocs with a job priority < 10 of jobPriority are considered
+// the resources asked for. Only allocs with a job priority at least minPriorityDelta lower than
+// jobPriority are considered
 // This method is meant only for finding preemptible allocations based on CPU/Memory/Disk
 func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources) []*structs.Allocation {
 	resourcesNeeded := resourceAsk.Comparable()
@@ -234,7 +247,7 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	}
 
 	// Group candidates by priority, filter out ineligible allocs
-	allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, p.currentAllocs)
+	allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, p.minPriorityDelta, p.currentAllocs)
 
 	var bestAllocs []*structs.Allocation
 	allRequirementsMet := false
@@ -336,7 +349,7 @@ func (p *Preemptor) PreemptForNetwork(networkResourceAsk *structs.NetworkResourc
 		net := networks[0]
 
 		// Filter out alloc that's ineligible due to priority
-		if p.jobPriority-alloc.Job.Priority < 10 {
+		if p.jobPriority-alloc.Job.Priority < p.minPriorityDelta {
 			// Populate any reserved ports used by
 			// this allocation that cannot be preempted
 			for _, port := range net.ReservedPorts {
@@ -429,7 +442,7 @@ OUTER:
 		}
 
 		// Split by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, currentAllocs)
+		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, p.minPriorityDelta, currentAllocs)
 
 		for _, allocsGrp := range allocsByPriority {
 			allocs := allocsGrp.allocs
@@ -546,7 +559,7 @@ func (p *Preemptor) PreemptForDevice(ask *structs.RequestedDevice, devAlloc *dev
 OUTER:
 	for deviceIDTuple, allocsGrp := range deviceToAllocs {
 		// First group and sort allocations using this device by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, allocsGrp.allocs)
+		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, p.minPriorityDelta, allocsGrp.allocs)
 
 		// Reset preempted count for this device
 		preemptedCount := 0
@@ -689,17 +702,17 @@ func scoreForNetwork(resourceUsed *structs.NetworkResource, resourceNeeded *stru
 
 // filterAndGroupPreemptibleAllocs groups allocations by priority after filtering allocs
 // that are not preemptible based on the jobPriority arg
-func filterAndGroupPreemptibleAllocs(jobPriority int, current []*structs.Allocation) []*groupedAllocs {
+func filterAndGroupPreemptibleAllocs(jobPriority, minPriorityDelta int, current []*structs.Allocation) []*groupedAllocs {
 	allocsByPriority := make(map[int][]*structs.Allocation)
 	for _, alloc := range current {
 		if alloc.Job == nil {
 			continue
 		}
 
-		// Skip allocs whose priority is within a delta of 10
+		// Skip allocs whose priority is within the minimum delta
 		// This also skips any allocs of the current job
 		// for which we are attempting preemption
-		if jobPriority-alloc.Job.Priority < 10 {
+		if jobPriority-alloc.Job.Priority < minPriorityDelta {
 			continue
 		}
 		grpAllocs, ok := allocsByPriority[alloc.Job.Priority]
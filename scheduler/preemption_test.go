@@ -234,6 +234,7 @@ func TestPreemption_Normal(t *testing.T) {
 		nodeCapacity         *structs.NodeResources
 		resourceAsk          *structs.Resources
 		jobPriority          int
+		minPriorityDelta     int
 		currentPreemptions   []*structs.Allocation
 		preemptedAllocIDs    map[string]struct{}
 	}
@@ -817,6 +818,47 @@ func TestPreemption_Normal(t *testing.T) {
 				allocIDs[2]: {},
 			},
 		},
+		{
+			desc: "No preemption because priority delta is lower than configured minimum",
+			currentAllocations: []*structs.Allocation{
+				createAlloc(allocIDs[0], lowPrioJob, &structs.Resources{
+					CPU:      3200,
+					MemoryMB: 7256,
+					DiskMB:   4 * 1024,
+				}),
+			},
+			nodeReservedCapacity: reservedNodeResources,
+			nodeCapacity:         defaultNodeResources,
+			jobPriority:          50,
+			minPriorityDelta:     30,
+			resourceAsk: &structs.Resources{
+				CPU:      1000,
+				MemoryMB: 1024,
+				DiskMB:   4 * 1024,
+			},
+		},
+		{
+			desc: "Preemption because priority delta meets configured minimum",
+			currentAllocations: []*structs.Allocation{
+				createAlloc(allocIDs[0], lowPrioJob, &structs.Resources{
+					CPU:      3200,
+					MemoryMB: 7256,
+					DiskMB:   4 * 1024,
+				}),
+			},
+			nodeReservedCapacity: reservedNodeResources,
+			nodeCapacity:         defaultNodeResources,
+			jobPriority:          50,
+			minPriorityDelta:     20,
+			resourceAsk: &structs.Resources{
+				CPU:      1000,
+				MemoryMB: 1024,
+				DiskMB:   4 * 1024,
+			},
+			preemptedAllocIDs: map[string]struct{}{
+				allocIDs[0]: {},
+			},
+		},
 		{
 			desc: "one alloc meets static port need, another meets remaining mbits needed",
 			currentAllocations: []*structs.Allocation{
@@ -1407,7 +1449,9 @@ func TestPreemption_Normal(t *testing.T) {
 			job := mock.Job()
 			job.Priority = tc.jobPriority
 			binPackIter.SetJob(job)
-			binPackIter.SetSchedulerConfiguration(testSchedulerConfig)
+			schedConfig := testSchedulerConfig.Copy()
+			schedConfig.PreemptionConfig.MinPriorityDelta = tc.minPriorityDelta
+			binPackIter.SetSchedulerConfiguration(schedConfig)
 
 			taskGroup := &structs.TaskGroup{
 				EphemeralDisk: &structs.EphemeralDisk{},
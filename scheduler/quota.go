@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// QuotaIterator is a FeasibleIterator which returns no nodes once placing an
+// allocation of the task group would take the namespace of the job over the
+// limit of its quota in the region.
+type QuotaIterator struct {
+	ctx    Context
+	source FeasibleIterator
+
+	namespace string
+	quota     string
+	limit     *structs.QuotaLimit
+	used      *structs.QuotaResources
+	tg        *structs.TaskGroup
+
+	// exhausted caches the exhausted dimensions of the limit until the plan
+	// changes
+	exhausted []string
+	checked   bool
+}
+
+// NewQuotaIterator returns a QuotaIterator that filters the nodes of source.
+func NewQuotaIterator(ctx Context, source FeasibleIterator) FeasibleIterator {
+	return &QuotaIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *QuotaIterator) SetJob(job *structs.Job) {
+	iter.namespace = job.Namespace
+	iter.quota, iter.limit, iter.used = "", nil, nil
+	iter.checked = false
+
+	ns, err := iter.ctx.State().NamespaceByName(nil, job.Namespace)
+	if err != nil || ns == nil || ns.Quota == "" {
+		return
+	}
+	spec, err := iter.ctx.State().QuotaSpecByName(nil, ns.Quota)
+	if err != nil || spec == nil {
+		return
+	}
+	limit := spec.LimitForRegion(iter.ctx.State().Config().Region)
+	if limit == nil {
+		return
+	}
+	usage, err := iter.ctx.State().QuotaUsageByName(nil, ns.Quota)
+	if err != nil || usage == nil {
+		return
+	}
+	used, ok := usage.Used[limit.HashKey()]
+	if !ok || used.RegionLimit == nil {
+		return
+	}
+
+	iter.quota, iter.limit, iter.used = ns.Quota, limit, used.RegionLimit
+}
+
+func (iter *QuotaIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+	iter.checked = false
+}
+
+func (iter *QuotaIterator) Next() *structs.Node {
+	if iter.limit == nil {
+		return iter.source.Next()
+	}
+
+	if !iter.checked {
+		iter.exhausted = iter.exhaustedDimensions()
+		iter.checked = true
+	}
+	if len(iter.exhausted) != 0 {
+		iter.ctx.Metrics().ExhaustQuota(iter.exhausted)
+		iter.ctx.Eligibility().SetQuotaLimitReached(iter.quota)
+		return nil
+	}
+
+	return iter.source.Next()
+}
+
+func (iter *QuotaIterator) Reset() {
+	iter.checked = false
+	iter.source.Reset()
+}
+
+// exhaustedDimensions returns the dimensions of the limit that placing an
+// allocation of the task group, on top of the usage of the namespace and of
+// the current plan, would exhaust.
+func (iter *QuotaIterator) exhaustedDimensions() []string {
+	before, after, err := iter.ctx.Plan().QuotaUsageChange(iter.limit, iter.namespace, func(id string) (*structs.Allocation, error) {
+		return iter.ctx.State().AllocByID(nil, id)
+	})
+	if err != nil {
+		iter.ctx.Logger().Error("failed to compute quota usage of plan", "error", err)
+		return nil
+	}
+
+	ask := iter.limit.NewUsage()
+	ask.AddTaskGroup(iter.tg)
+
+	proposed := iter.used.Copy()
+	proposed.Subtract(before)
+	proposed.Add(after)
+	proposed.Add(ask)
+	return iter.limit.Exhausted(proposed, ask)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestQuotaIterator(t *testing.T) {
+	ci.Parallel(t)
+	state, ctx := testContext(t)
+
+	qs := mock.QuotaSpec()
+	qs.Limits[0].RegionLimit.CPU = 800
+	must.NoError(t, state.UpsertQuotaSpecs(100, []*structs.QuotaSpec{qs}))
+	ns := mock.Namespace()
+	ns.Quota = qs.Name
+	must.NoError(t, state.UpsertNamespaces(101, []*structs.Namespace{ns}))
+
+	job := mock.Job()
+	job.Namespace = ns.Name
+	existing := mock.Alloc()
+	existing.Namespace = ns.Name
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 102, []*structs.Allocation{existing}))
+
+	nodes := []*structs.Node{mock.Node(), mock.Node()}
+	static := NewStaticIterator(ctx, nodes)
+	quota := NewQuotaIterator(ctx, static)
+	contextual := quota.(ContextualIterator)
+	contextual.SetJob(job)
+	contextual.SetTaskGroup(job.TaskGroups[0])
+
+	// the task group asks for 500 MHz on top of the 500 MHz in use
+	must.Nil(t, quota.Next())
+	must.Eq(t, []string{"cpu exhausted (1000 needed > 800 limit)"}, ctx.Metrics().QuotaExhausted)
+	must.Eq(t, qs.Name, ctx.Eligibility().QuotaLimitReached())
+
+	// stopping the existing allocation in the plan frees its usage
+	ctx.Plan().AppendStoppedAlloc(existing, "", "", "")
+	quota.Reset()
+	must.NotNil(t, quota.Next())
+	must.NotNil(t, quota.Next())
+	must.Nil(t, quota.Next())
+
+	// namespaces without a quota are not limited
+	job.Namespace = structs.DefaultNamespace
+	contextual.SetJob(job)
+	quota.Reset()
+	ctx.Plan().NodeUpdate = make(map[string][]*structs.Allocation)
+	must.NotNil(t, quota.Next())
+}
@@ -165,6 +165,7 @@ type BinPackIterator struct {
 	jobId                  structs.NamespacedID
 	taskGroup              *structs.TaskGroup
 	memoryOversubscription bool
+	minPriorityDelta       int
 	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
 }
 
@@ -180,6 +181,7 @@ func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority i
 		// These are default values that may be overwritten by
 		// SetSchedulerConfiguration.
 		memoryOversubscription: false,
+		minPriorityDelta:       structs.DefaultPreemptionMinPriorityDelta,
 		scoreFit:               structs.ScoreFitBinPack,
 	}
 }
@@ -204,6 +206,12 @@ func (iter *BinPackIterator) SetSchedulerConfiguration(schedConfig *structs.Sche
 
 	// Set memory oversubscription.
 	iter.memoryOversubscription = schedConfig != nil && schedConfig.MemoryOversubscriptionEnabled
+
+	// Set the priority delta required for preemption.
+	iter.minPriorityDelta = structs.DefaultPreemptionMinPriorityDelta
+	if schedConfig != nil {
+		iter.minPriorityDelta = schedConfig.PreemptionConfig.EffectiveMinPriorityDelta()
+	}
 }
 
 func (iter *BinPackIterator) Next() *RankedNode {
@@ -276,6 +284,7 @@ NEXTNODE:
 
 		// Initialize preemptor with node
 		preemptor := NewPreemptor(iter.priority, iter.ctx, &iter.jobId)
+		preemptor.SetMinPriorityDelta(iter.minPriorityDelta)
 		preemptor.SetNode(option.Node)
 
 		// Count the number of existing preemptions
@@ -373,11 +382,13 @@ NEXTNODE:
 			taskResources := &structs.AllocatedTaskResources{
 				Cpu: structs.AllocatedCpuResources{
 					CpuShares: int64(task.Resources.CPU),
+					CpuMax:    int64(task.Resources.CPUMax),
 				},
 				Memory: structs.AllocatedMemoryResources{
 					MemoryMB: safemath.Add(
 						int64(task.Resources.MemoryMB), int64(task.Resources.SecretsMB)),
 				},
+				DiskIO: task.Resources.DiskIO.Copy(),
 			}
 			if iter.memoryOversubscription {
 				taskResources.Memory.MemoryMaxMB = safemath.Add(
@@ -857,11 +868,13 @@ func (iter *JobAntiAffinityIterator) Reset() {
 
 // NodeReschedulingPenaltyIterator is used to apply a penalty to
 // a node that had a previous failed allocation for the same job.
-// This is used when attempting to reschedule a failed alloc
+// This is used when attempting to reschedule a failed alloc. Nodes
+// the alloc recently failed on can also be avoided altogether.
 type NodeReschedulingPenaltyIterator struct {
 	ctx          Context
 	source       RankIterator
 	penaltyNodes map[string]struct{}
+	avoidNodes   map[string]struct{}
 }
 
 // NewNodeReschedulingPenaltyIterator is used to create a NodeReschedulingPenaltyIterator that
@@ -878,10 +891,23 @@ func (iter *NodeReschedulingPenaltyIterator) SetPenaltyNodes(penaltyNodes map[st
 	iter.penaltyNodes = penaltyNodes
 }
 
+// SetAvoidNodes sets the nodes which are filtered out. Unlike the penalty
+// nodes, they are kept when the iterator is reset.
+func (iter *NodeReschedulingPenaltyIterator) SetAvoidNodes(avoidNodes map[string]struct{}) {
+	iter.avoidNodes = avoidNodes
+}
+
 func (iter *NodeReschedulingPenaltyIterator) Next() *RankedNode {
-	option := iter.source.Next()
-	if option == nil {
-		return nil
+	var option *RankedNode
+	for {
+		option = iter.source.Next()
+		if option == nil {
+			return nil
+		}
+		if _, ok := iter.avoidNodes[option.Node.ID]; !ok {
+			break
+		}
+		iter.ctx.Metrics().FilterNode(option.Node, FilterConstraintRescheduleAvoidNode)
 	}
 
 	_, ok := iter.penaltyNodes[option.Node.ID]
@@ -18,6 +18,7 @@ import (
 	metrics "github.com/hashicorp/go-metrics/compat"
 
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	reconnectingpicker "github.com/hashicorp/nomad/scheduler/reconnecting_picker"
@@ -538,6 +539,10 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 		// reschedulable later and mark the allocations for in place updating
 		a.createRescheduleLaterEvals(rescheduleLater, all, tg.Name)
 	}
+	// Set aside the allocations being decommissioned after the promotion of
+	// a deployment, unless they can take over again.
+	untainted = a.computeDecommissioning(tg, untainted, desiredChanges)
+
 	// Create a structure for choosing names. Seed with the taken names
 	// which is the union of untainted, rescheduled, allocs on migrating
 	// nodes, and allocs on down nodes (includes canaries)
@@ -548,6 +553,14 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	// include stopped allocations.
 	isCanarying := dstate != nil && dstate.DesiredCanaries != 0 && !dstate.Promoted
 
+	// Decommission the allocations replaced by the promoted canaries instead
+	// of stopping them.
+	if !isCanarying && len(canaries) != 0 && tg.Update != nil && tg.Update.DecommissionDelay > 0 {
+		decommission := a.computeDecommissions(tg, untainted, canaries)
+		desiredChanges.Ignore += uint64(len(decommission))
+		untainted = untainted.difference(decommission)
+	}
+
 	stop := a.computeStop(tg, nameIndex, untainted, migrate, lost, canaries, isCanarying, lostLaterEvals)
 
 	desiredChanges.Stop += uint64(len(stop))
@@ -618,6 +631,113 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	return deploymentComplete
 }
 
+// computeDecommissioning returns the untainted set without the allocations
+// being decommissioned, which keep running deregistered from services after
+// the promotion of the canaries which replaced them. They are stopped once
+// their decommission deadline passed, by the follow up evaluation created for
+// their decommission delay or any later evaluation. If the job was
+// instead rolled back to a version they can be updated in-place to, they are
+// brought back into service and the allocations which replaced them are
+// stopped.
+func (a *allocReconciler) computeDecommissioning(tg *structs.TaskGroup, untainted allocSet, desiredChanges *structs.DesiredUpdates) allocSet {
+	decommissioning := make(allocSet)
+	revived := make(allocSet)
+	for id, alloc := range untainted {
+		if !alloc.DesiredTransition.ShouldDecommission() || alloc.TerminalStatus() {
+			continue
+		}
+
+		deadline := alloc.DesiredTransition.DecommissionDeadline
+		if deadline == nil || deadline.Sub(a.now) <= rescheduleWindowSize {
+			a.result.stop = append(a.result.stop, allocStopResult{
+				alloc:             alloc,
+				statusDescription: allocDecommissioned,
+			})
+			desiredChanges.Stop++
+			decommissioning[id] = alloc
+			continue
+		}
+
+		if ignore, destructive, _ := a.allocUpdateFn(alloc, a.job, tg); ignore || destructive {
+			desiredChanges.Ignore++
+			decommissioning[id] = alloc
+			continue
+		}
+
+		// The in-place update of the allocation clears its decommission
+		revive := alloc.Copy()
+		revive.DesiredTransition.Decommission = nil
+		revive.DesiredTransition.DecommissionDeadline = nil
+		revive.FollowupEvalID = ""
+		revived[id] = revive
+	}
+	if len(decommissioning) == 0 && len(revived) == 0 {
+		return untainted
+	}
+
+	// Stop the allocations which replaced the revived ones
+	replaced := make(allocSet)
+	names := revived.nameSet()
+	for id, alloc := range untainted.difference(decommissioning, revived) {
+		if _, ok := names[alloc.Name]; !ok || alloc.TerminalStatus() {
+			continue
+		}
+		a.result.stop = append(a.result.stop, allocStopResult{
+			alloc:             alloc,
+			statusDescription: allocNotNeeded,
+		})
+		desiredChanges.Stop++
+		replaced[id] = alloc
+	}
+
+	untainted = untainted.difference(decommissioning, replaced)
+	for id, alloc := range revived {
+		untainted[id] = alloc
+	}
+	return untainted
+}
+
+// computeDecommissions marks the allocations replaced by the promoted canaries
+// for decommission, and creates the follow up evaluation which stops them once
+// the decommission delay of the group passed. It returns the set of
+// allocations decommissioned.
+func (a *allocReconciler) computeDecommissions(tg *structs.TaskGroup, untainted, canaries allocSet) allocSet {
+	decommission := make(allocSet)
+	canaryNames := canaries.nameSet()
+	for id, alloc := range untainted.difference(canaries) {
+		if _, match := canaryNames[alloc.Name]; match && !alloc.TerminalStatus() {
+			decommission[id] = alloc
+		}
+	}
+	if len(decommission) == 0 {
+		return decommission
+	}
+
+	deadline := a.now.Add(tg.Update.DecommissionDelay)
+	eval := &structs.Evaluation{
+		ID:                uuid.Generate(),
+		Namespace:         a.job.Namespace,
+		Priority:          a.evalPriority,
+		Type:              a.job.Type,
+		TriggeredBy:       structs.EvalTriggerDecommissionDelay,
+		JobID:             a.job.ID,
+		JobModifyIndex:    a.job.ModifyIndex,
+		Status:            structs.EvalStatusPending,
+		StatusDescription: decommissionFollowupEvalDesc,
+		WaitUntil:         deadline,
+	}
+	a.appendFollowupEvals(tg.Name, []*structs.Evaluation{eval})
+
+	for id, alloc := range decommission {
+		updatedAlloc := alloc.Copy()
+		updatedAlloc.DesiredTransition.Decommission = pointer.Of(true)
+		updatedAlloc.DesiredTransition.DecommissionDeadline = pointer.Of(deadline)
+		updatedAlloc.FollowupEvalID = eval.ID
+		a.result.attributeUpdates[id] = updatedAlloc
+	}
+	return decommission
+}
+
 func (a *allocReconciler) initializeDeploymentState(group string, tg *structs.TaskGroup) (*structs.DeploymentState, bool) {
 	var dstate *structs.DeploymentState
 	existingDeployment := false
@@ -632,6 +752,7 @@ func (a *allocReconciler) initializeDeploymentState(group string, tg *structs.Ta
 			dstate.AutoRevert = tg.Update.AutoRevert
 			dstate.AutoPromote = tg.Update.AutoPromote
 			dstate.ProgressDeadline = tg.Update.ProgressDeadline
+			dstate.CanarySteps = slices.Clone(tg.Update.CanarySteps)
 		}
 	}
 
@@ -643,16 +764,26 @@ func (a *allocReconciler) requiresCanaries(tg *structs.TaskGroup, dstate *struct
 	canariesPromoted := dstate != nil && dstate.Promoted
 	return tg.Update != nil &&
 		len(destructive) != 0 &&
-		len(canaries) < tg.Update.Canary &&
+		len(canaries) < desiredCanaries(tg, dstate) &&
 		!canariesPromoted
 }
 
+// desiredCanaries returns the number of canaries of the task group at the
+// current canary step of the deployment.
+func desiredCanaries(tg *structs.TaskGroup, dstate *structs.DeploymentState) int {
+	var step int
+	if dstate != nil {
+		step = dstate.CanaryStep
+	}
+	return tg.Update.CanaryCount(tg.Count, step)
+}
+
 func (a *allocReconciler) computeCanaries(tg *structs.TaskGroup, dstate *structs.DeploymentState,
 	destructive, canaries allocSet, desiredChanges *structs.DesiredUpdates, nameIndex *allocNameIndex) {
-	dstate.DesiredCanaries = tg.Update.Canary
+	dstate.DesiredCanaries = desiredCanaries(tg, dstate)
 
 	if !a.deploymentPaused && !a.deploymentFailed {
-		desiredChanges.Canary += uint64(tg.Update.Canary - len(canaries))
+		desiredChanges.Canary += uint64(dstate.DesiredCanaries - len(canaries))
 		for _, name := range nameIndex.NextCanaries(uint(desiredChanges.Canary), canaries, destructive) {
 			a.result.place = append(a.result.place, allocPlaceResult{
 				name:      name,
@@ -3779,6 +3779,132 @@ func TestReconciler_NewCanaries_FillNames(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(1, 2), placeResultsToNames(r.place))
 }
 
+// Tests the reconciler places the canaries of the first canary step of a new
+// deployment
+func TestReconciler_NewCanaries_CanarySteps(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate.Copy()
+	job.TaskGroups[0].Update.Canary = 0
+	job.TaskGroups[0].Update.CanarySteps = []int{25, 50}
+
+	// Create 10 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		nil, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	newD := structs.NewDeployment(job, 50, r.deployment.CreateTime)
+	newD.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+	newD.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		DesiredCanaries: 3,
+		DesiredTotal:    10,
+		CanarySteps:     []int{25, 50},
+	}
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  newD,
+		deploymentUpdates: nil,
+		place:             3,
+		inplace:           0,
+		stop:              0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Canary: 3,
+				Ignore: 10,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, intRange(0, 2), placeResultsToNames(r.place))
+}
+
+// Tests the reconciler places the additional canaries once the deployment
+// moved to the next canary step
+func TestReconciler_CanarySteps_NextStep(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate.Copy()
+	job.TaskGroups[0].Update.Canary = 0
+	job.TaskGroups[0].Update.CanarySteps = []int{20, 50}
+
+	// Create an existing deployment at the second step which placed the
+	// canaries of the first step
+	d := structs.NewDeployment(job, 50, time.Now().UnixNano())
+	s := &structs.DeploymentState{
+		DesiredTotal:    10,
+		DesiredCanaries: 5,
+		CanarySteps:     []int{20, 50},
+		CanaryStep:      1,
+		PlacedAllocs:    2,
+		HealthyAllocs:   2,
+	}
+	d.TaskGroups[job.TaskGroups[0].Name] = s
+
+	// Create 10 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	// Create the healthy canaries of the first step
+	for i := 0; i < 2; i++ {
+		canary := mock.Alloc()
+		canary.Job = job
+		canary.JobID = job.ID
+		canary.NodeID = uuid.Generate()
+		canary.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		canary.TaskGroup = job.TaskGroups[0].Name
+		canary.DeploymentID = d.ID
+		canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: pointer.Of(true),
+			Canary:  true,
+		}
+		s.PlacedCanaries = append(s.PlacedCanaries, canary.ID)
+		allocs = append(allocs, canary)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		d, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             3,
+		inplace:           0,
+		stop:              0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Canary: 3,
+				Ignore: 12,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, intRange(2, 4), placeResultsToNames(r.place))
+}
+
 // Tests the reconciler handles canary promotion by unblocking max_parallel
 func TestReconciler_PromoteCanaries_Unblock(t *testing.T) {
 	ci.Parallel(t)
@@ -3937,6 +4063,183 @@ func TestReconciler_PromoteCanaries_CanariesEqualCount(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 1), stopResultsToNames(r.stop))
 }
 
+// Tests the reconciler decommissions the allocations replaced by promoted
+// canaries instead of stopping them, and stops them after the decommission
+// delay
+func TestReconciler_PromoteCanaries_DecommissionDelay(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate.Copy()
+	job.TaskGroups[0].Update.DecommissionDelay = 10 * time.Minute
+	job.TaskGroups[0].Count = 2
+
+	// Create an existing deployment that has placed some canaries and mark them
+	// promoted
+	d := structs.NewDeployment(job, 50, time.Now().UnixNano())
+	s := &structs.DeploymentState{
+		Promoted:        true,
+		DesiredTotal:    2,
+		DesiredCanaries: 2,
+		PlacedAllocs:    2,
+		HealthyAllocs:   2,
+	}
+	d.TaskGroups[job.TaskGroups[0].Name] = s
+
+	// Create 2 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	// Create the canaries
+	handled := make(map[string]allocUpdateType)
+	for i := 0; i < 2; i++ {
+		canary := mock.Alloc()
+		canary.Job = job
+		canary.JobID = job.ID
+		canary.NodeID = uuid.Generate()
+		canary.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		canary.TaskGroup = job.TaskGroups[0].Name
+		s.PlacedCanaries = append(s.PlacedCanaries, canary.ID)
+		canary.DeploymentID = d.ID
+		canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: pointer.Of(true),
+		}
+		allocs = append(allocs, canary)
+		handled[canary.ID] = allocUpdateFnIgnore
+	}
+
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, allocs, nil, "", 50, true)
+	now := time.Now()
+	reconciler.now = now
+	r := reconciler.Compute()
+
+	updates := []*structs.DeploymentStatusUpdate{
+		{
+			DeploymentID:      d.ID,
+			Status:            structs.DeploymentStatusSuccessful,
+			StatusDescription: structs.DeploymentStatusDescriptionSuccessful,
+		},
+	}
+
+	// The old allocations keep running
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: updates,
+		place:             0,
+		inplace:           0,
+		attributeUpdates:  2,
+		stop:              0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 4,
+			},
+		},
+	})
+
+	evals := r.desiredFollowupEvals[job.TaskGroups[0].Name]
+	must.Len(t, 1, evals)
+	must.Eq(t, structs.EvalTriggerDecommissionDelay, evals[0].TriggeredBy)
+	must.Eq(t, now.Add(10*time.Minute), evals[0].WaitUntil)
+	for _, i := range []int{0, 1} {
+		updated := r.attributeUpdates[allocs[i].ID]
+		must.NotNil(t, updated)
+		must.True(t, updated.DesiredTransition.ShouldDecommission())
+		must.Eq(t, now.Add(10*time.Minute), *updated.DesiredTransition.DecommissionDeadline)
+		must.Eq(t, evals[0].ID, updated.FollowupEvalID)
+		allocs[i] = updated
+	}
+
+	// The decommissioned allocations are ignored until their deadline, even
+	// by the follow up evaluation if it runs early
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, allocs, nil, evals[0].ID, 50, true)
+	reconciler.now = now.Add(time.Minute)
+	r = reconciler.Compute()
+	must.SliceEmpty(t, r.stop)
+	must.MapEmpty(t, r.attributeUpdates)
+
+	// The decommissioned allocations are stopped by any evaluation once their
+	// deadline passed
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, allocs, nil, uuid.Generate(), 50, true)
+	reconciler.now = now.Add(11 * time.Minute)
+	r = reconciler.Compute()
+	must.Len(t, 2, r.stop)
+	for _, stop := range r.stop {
+		must.Eq(t, allocDecommissioned, stop.statusDescription)
+		must.True(t, stop.alloc.DesiredTransition.ShouldDecommission())
+	}
+	must.Eq(t, 2, r.desiredTGUpdates[job.TaskGroups[0].Name].Stop)
+}
+
+// Tests the reconciler brings decommissioned allocations back into service
+// when the job is rolled back to their version
+func TestReconciler_DecommissionDelay_Revert(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate.Copy()
+	job.TaskGroups[0].Update.DecommissionDelay = 10 * time.Minute
+	job.TaskGroups[0].Count = 2
+
+	// Create 2 decommissioned allocations and the promoted allocations which
+	// replaced them
+	var allocs []*structs.Allocation
+	handled := make(map[string]allocUpdateType)
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.DesiredTransition.Decommission = pointer.Of(true)
+		alloc.DesiredTransition.DecommissionDeadline = pointer.Of(time.Now().Add(10 * time.Minute))
+		alloc.FollowupEvalID = uuid.Generate()
+		allocs = append(allocs, alloc)
+		handled[alloc.ID] = allocUpdateFnInplace
+
+		replacement := mock.Alloc()
+		replacement.Job = job
+		replacement.JobID = job.ID
+		replacement.NodeID = uuid.Generate()
+		replacement.Name = alloc.Name
+		replacement.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, replacement)
+	}
+
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		nil, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	// The decommissioned allocations are updated in-place and their
+	// replacements stopped, without placing canaries
+	must.SliceEmpty(t, r.place)
+	must.Len(t, 2, r.stop)
+	for _, stop := range r.stop {
+		must.False(t, stop.alloc.DesiredTransition.ShouldDecommission())
+	}
+	must.Len(t, 2, r.inplaceUpdate)
+	for _, alloc := range r.inplaceUpdate {
+		must.False(t, alloc.DesiredTransition.ShouldDecommission())
+		must.Nil(t, alloc.DesiredTransition.DecommissionDeadline)
+		must.Eq(t, "", alloc.FollowupEvalID)
+	}
+	must.Eq(t, &structs.DesiredUpdates{Stop: 2, InPlaceUpdate: 2},
+		r.desiredTGUpdates[job.TaskGroups[0].Name])
+}
+
 // Tests the reconciler checks the health of placed allocs to determine the
 // limit
 func TestReconciler_DeploymentLimit_HealthAccounting(t *testing.T) {
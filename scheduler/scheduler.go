@@ -129,6 +129,15 @@ type State interface {
 	// a given namespace, job ID and task group name
 	TaskGroupHostVolumeClaimsByFields(memdb.WatchSet, state.TgvcSearchableFields) (memdb.ResultIterator, error)
 
+	// NamespaceByName is used to lookup a namespace by name
+	NamespaceByName(memdb.WatchSet, string) (*structs.Namespace, error)
+
+	// QuotaSpecByName is used to lookup a quota specification by name
+	QuotaSpecByName(memdb.WatchSet, string) (*structs.QuotaSpec, error)
+
+	// QuotaUsageByName is used to lookup the usage of a quota specification
+	QuotaUsageByName(memdb.WatchSet, string) (*structs.QuotaUsage, error)
+
 	// LatestIndex returns the greatest index value for all indexes.
 	LatestIndex() (uint64, error)
 }
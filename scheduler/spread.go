@@ -4,6 +4,8 @@
 package scheduler
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -45,12 +47,27 @@ type SpreadIterator struct {
 	// existing allocs are computed once, and allocs from the plan are updated
 	// when Reset is called
 	groupPropertySets map[string][]*propertySet
+
+	// nodes is the set of nodes allocations may be placed on, which defines
+	// the attribute values spreads with a max skew are bounded across
+	nodes []*structs.Node
+
+	// attributeValues is a memoized map from task group and attribute to the
+	// values the nodes the task group is feasible on have for it
+	attributeValues map[spreadDomainKey]map[string]struct{}
+}
+
+// spreadDomainKey is the key of the memoized attribute values of a task group.
+type spreadDomainKey struct {
+	taskGroup string
+	attribute string
 }
 
 type spreadAttributeMap map[string]*spreadInfo
 
 type spreadInfo struct {
 	weight        int8
+	maxSkew       int
 	desiredCounts map[string]float64
 }
 
@@ -61,10 +78,17 @@ func NewSpreadIterator(ctx Context, source RankIterator) *SpreadIterator {
 		groupPropertySets: make(map[string][]*propertySet),
 		tgSpreadInfo:      make(map[string]spreadAttributeMap),
 		lowestSpreadBoost: -1.0,
+		attributeValues:   make(map[spreadDomainKey]map[string]struct{}),
 	}
 	return iter
 }
 
+// SetNodes sets the nodes allocations may be placed on.
+func (iter *SpreadIterator) SetNodes(nodes []*structs.Node) {
+	iter.nodes = nodes
+	iter.attributeValues = make(map[spreadDomainKey]map[string]struct{})
+}
+
 func (iter *SpreadIterator) Reset() {
 	iter.source.Reset()
 	for _, sets := range iter.groupPropertySets {
@@ -85,6 +109,7 @@ func (iter *SpreadIterator) SetJob(job *structs.Job) {
 	// versions of spread/properties to the new job version
 	iter.tgSpreadInfo = make(map[string]spreadAttributeMap)
 	iter.groupPropertySets = make(map[string][]*propertySet)
+	iter.attributeValues = make(map[spreadDomainKey]map[string]struct{})
 }
 
 func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
@@ -137,6 +162,13 @@ func (iter *SpreadIterator) Next() *RankedNode {
 
 		tgName := iter.tg.Name
 		propertySets := iter.groupPropertySets[tgName]
+
+		// Skip nodes that would place allocations beyond the max skew of a
+		// spread
+		if !iter.withinMaxSkew(option.Node, propertySets) {
+			continue
+		}
+
 		// Iterate over each spread attribute's property set and add a weighted score
 		totalSpreadScore := 0.0
 		for _, pset := range propertySets {
@@ -209,6 +241,96 @@ func (iter *SpreadIterator) Next() *RankedNode {
 	}
 }
 
+// withinMaxSkew returns whether placing an allocation on the node keeps the
+// difference between the number of allocations placed for each attribute value
+// within the max skew of the spreads that define one. The attribute values are
+// those of the nodes the task group is feasible on, so values with no
+// allocations yet are accounted for.
+func (iter *SpreadIterator) withinMaxSkew(node *structs.Node, propertySets []*propertySet) bool {
+	spreadAttributeMap := iter.tgSpreadInfo[iter.tg.Name]
+	for _, pset := range propertySets {
+		spreadDetails := spreadAttributeMap[pset.targetAttribute]
+		if spreadDetails == nil || spreadDetails.maxSkew == 0 {
+			continue
+		}
+
+		nValue, ok := getProperty(node, pset.targetAttribute)
+		if !ok {
+			iter.ctx.Metrics().FilterNode(node, fmt.Sprintf("missing property %q", pset.targetAttribute))
+			return false
+		}
+
+		combinedUseMap := pset.GetCombinedUseMap()
+		usedCount := combinedUseMap[nValue]
+		minCount := usedCount
+		for value := range iter.values(pset.targetAttribute) {
+			minCount = min(minCount, combinedUseMap[value])
+		}
+
+		// Add one to include placement on this node
+		skew := usedCount + 1 - minCount
+		if skew > uint64(spreadDetails.maxSkew) {
+			iter.ctx.Metrics().FilterNode(node, fmt.Sprintf("spread %q max_skew %d", pset.targetAttribute, spreadDetails.maxSkew))
+			return false
+		}
+	}
+	return true
+}
+
+// values returns the values the nodes the task group is feasible on have for
+// the attribute.
+func (iter *SpreadIterator) values(attribute string) map[string]struct{} {
+	key := spreadDomainKey{taskGroup: iter.tg.Name, attribute: attribute}
+	if values, ok := iter.attributeValues[key]; ok {
+		return values
+	}
+
+	values := spreadDomains(iter.ctx, iter.job, iter.tg, iter.nodes, attribute)
+	iter.attributeValues[key] = values
+	return values
+}
+
+// spreadDomains returns the values the nodes have for the attribute, which
+// spreads with a max skew are bounded across. Only the nodes that meet the
+// constraints and have the drivers of the task group are accounted for, as
+// the task group can't be placed on the other nodes whatever their capacity.
+func spreadDomains(ctx Context, job *structs.Job, tg *structs.TaskGroup, nodes []*structs.Node, attribute string) map[string]struct{} {
+	tgConstr := taskGroupConstraints(tg)
+	jobConstraints := NewConstraintChecker(ctx, job.Constraints)
+	tgConstraints := NewConstraintChecker(ctx, tgConstr.constraints)
+	drivers := NewDriverChecker(ctx, tgConstr.drivers)
+
+	values := make(map[string]struct{})
+	for _, node := range nodes {
+		if !jobConstraints.meetsConstraints(node) ||
+			!tgConstraints.meetsConstraints(node) ||
+			!drivers.hasDrivers(node) {
+			continue
+		}
+		if value, ok := getProperty(node, attribute); ok {
+			values[value] = struct{}{}
+		}
+	}
+	return values
+}
+
+// maxSkewSpreads returns the spreads of the job and task group that have a
+// max skew.
+func maxSkewSpreads(job *structs.Job, tg *structs.TaskGroup) []*structs.Spread {
+	var spreads []*structs.Spread
+	for _, spread := range job.Spreads {
+		if spread.MaxSkew > 0 {
+			spreads = append(spreads, spread)
+		}
+	}
+	for _, spread := range tg.Spreads {
+		if spread.MaxSkew > 0 {
+			spreads = append(spreads, spread)
+		}
+	}
+	return spreads
+}
+
 // evenSpreadScoreBoost is a scoring helper that calculates the score
 // for the option when even spread is desired (all attribute values get equal preference)
 func evenSpreadScoreBoost(pset *propertySet, option *structs.Node) float64 {
@@ -274,7 +396,7 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 	combinedSpreads = append(combinedSpreads, tg.Spreads...)
 	combinedSpreads = append(combinedSpreads, iter.jobSpreads...)
 	for _, spread := range combinedSpreads {
-		si := &spreadInfo{weight: spread.Weight, desiredCounts: make(map[string]float64)}
+		si := &spreadInfo{weight: spread.Weight, maxSkew: spread.MaxSkew, desiredCounts: make(map[string]float64)}
 		sumDesiredCounts := 0.0
 		for _, st := range spread.SpreadTarget {
 			desiredCount := (float64(st.Percent) / float64(100)) * float64(totalCount)
@@ -1155,3 +1155,182 @@ func TestSpread_ImplicitTargets(t *testing.T) {
 		})
 	}
 }
+
+func TestSpreadIterator_MaxSkew(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	var nodes []*structs.Node
+	var ranked []*RankedNode
+	for i, dc := range []string{"dc1", "dc2", "dc3"} {
+		node := mock.Node()
+		node.Datacenter = dc
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, node)
+		ranked = append(ranked, &RankedNode{Node: node})
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{{
+		Weight:    50,
+		Attribute: "${node.datacenter}",
+		MaxSkew:   1,
+	}}
+
+	newAlloc := func(node *structs.Node) *structs.Allocation {
+		return &structs.Allocation{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       job,
+			ID:        uuid.Generate(),
+			NodeID:    node.ID,
+		}
+	}
+	ctx.plan.NodeAllocation[nodes[0].ID] = []*structs.Allocation{newAlloc(nodes[0]), newAlloc(nodes[0])}
+	ctx.plan.NodeAllocation[nodes[1].ID] = []*structs.Allocation{newAlloc(nodes[1])}
+
+	placeable := func(nodes []*structs.Node) []string {
+		static := NewStaticRankIterator(ctx, ranked)
+		spreadIter := NewSpreadIterator(ctx, static)
+		spreadIter.SetNodes(nodes)
+		spreadIter.SetJob(job)
+		spreadIter.SetTaskGroup(tg)
+
+		var dcs []string
+		for _, rn := range collectRanked(spreadIter) {
+			dcs = append(dcs, rn.Node.Datacenter)
+		}
+		return dcs
+	}
+
+	// dc3 has no allocations, so only placing there keeps the skew within 1
+	must.Eq(t, []string{"dc3"}, placeable(nodes))
+
+	// without dc3 the allocations may be placed in dc2
+	must.Eq(t, []string{"dc2", "dc3"}, placeable(nodes[:2]))
+
+	// nodes the task group isn't feasible on don't bound the spread
+	infeasible := mock.Node()
+	infeasible.Datacenter = "dc4"
+	tg.Constraints = append(tg.Constraints, &structs.Constraint{
+		LTarget: "${node.datacenter}",
+		RTarget: "dc4",
+		Operand: "!=",
+	})
+	must.Eq(t, []string{"dc2", "dc3"}, placeable([]*structs.Node{nodes[0], nodes[1], infeasible}))
+}
+
+func TestSpread_MaxSkew(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	nodesToDcs := map[string]string{}
+	for dc, count := range map[string]int{"dc1": 4, "dc2": 1} {
+		for n := 0; n < count; n++ {
+			node := mock.Node()
+			node.Datacenter = dc
+			must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+			nodesToDcs[node.ID] = dc
+		}
+	}
+
+	// dc2 only fits one allocation, so the max skew bounds dc1 to two
+	// allocations instead of spreading the rest of them there.
+	job := mock.MinJob()
+	job.Datacenters = []string{"dc1", "dc2"}
+	job.TaskGroups[0].Count = 6
+	job.TaskGroups[0].Constraints = []*structs.Constraint{{
+		Operand: structs.ConstraintDistinctHosts,
+	}}
+	job.TaskGroups[0].Spreads = []*structs.Spread{{
+		Attribute: "${node.datacenter}",
+		Weight:    50,
+		MaxSkew:   1,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+	must.Len(t, 1, h.Plans)
+
+	dcCounts := map[string]int{}
+	for node, allocs := range h.Plans[0].NodeAllocation {
+		dcCounts[nodesToDcs[node]] += len(allocs)
+	}
+	must.Eq(t, map[string]int{"dc1": 2, "dc2": 1}, dcCounts)
+
+	must.Len(t, 1, h.Evals)
+	metrics := h.Evals[0].FailedTGAllocs[job.TaskGroups[0].Name]
+	must.NotNil(t, metrics)
+	must.Eq(t, 2, metrics.CoalescedFailures)
+}
+
+func TestSpread_MaxSkew_Repair(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	var nodes []*structs.Node
+	for _, dc := range []string{"dc1", "dc1", "dc1", "dc2"} {
+		node := mock.Node()
+		node.Datacenter = dc
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		nodes = append(nodes, node)
+	}
+
+	job := mock.Job()
+	job.Datacenters = []string{"dc1", "dc2"}
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].Spreads = []*structs.Spread{{
+		Attribute: "${node.datacenter}",
+		Weight:    50,
+		MaxSkew:   1,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	// the allocations were placed before dc2 had nodes
+	var allocs []*structs.Allocation
+	for i := 0; i < 3; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = nodes[i].ID
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerNodeUpdate,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+	must.Len(t, 1, h.Plans)
+
+	// one allocation is moved from dc1 to dc2
+	plan := h.Plans[0]
+	var stopped []*structs.Allocation
+	for _, updates := range plan.NodeUpdate {
+		stopped = append(stopped, updates...)
+	}
+	must.Len(t, 1, stopped)
+	must.Eq(t, allocSpreadSkew, stopped[0].DesiredDescription)
+	must.MapLen(t, 1, plan.NodeAllocation)
+	must.Len(t, 1, plan.NodeAllocation[nodes[3].ID])
+	must.Eq(t, stopped[0].Name, plan.NodeAllocation[nodes[3].ID][0].Name)
+}
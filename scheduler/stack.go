@@ -36,6 +36,7 @@ type Stack interface {
 
 type SelectOptions struct {
 	PenaltyNodeIDs          map[string]struct{}
+	AvoidNodeIDs            map[string]struct{}
 	PreferredNodes          []*structs.Node
 	Preempt                 bool
 	AllocName               string
@@ -49,6 +50,10 @@ type GenericStack struct {
 	ctx    Context
 	source *StaticIterator
 
+	// overflow is the nodes of the node pools to overflow into, from the
+	// highest to the lowest priority
+	overflow [][]*structs.Node
+
 	wrappedChecks        *FeasibilityWrapper
 	quota                FeasibleIterator
 	jobVersion           *uint64
@@ -64,12 +69,14 @@ type GenericStack struct {
 
 	distinctHostsConstraint    *DistinctHostsIterator
 	distinctPropertyConstraint *DistinctPropertyIterator
+	jobAffinityConstraint      *RequiredJobAffinityIterator
 	binPack                    *BinPackIterator
 	jobAntiAff                 *JobAntiAffinityIterator
 	nodeReschedulingPenalty    *NodeReschedulingPenaltyIterator
 	limit                      *LimitIterator
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
+	jobAffinity                *JobAffinityIterator
 	spread                     *SpreadIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
@@ -79,8 +86,27 @@ func (s *GenericStack) SetNodes(baseNodes []*structs.Node) {
 	idx, _ := s.ctx.State().LatestIndex()
 	shuffleNodes(s.ctx.Plan(), idx, baseNodes)
 
-	// Update the set of base nodes
+	// Update the set of base nodes, which no longer overflow
+	s.setBaseNodes(baseNodes)
+	s.overflow = nil
+}
+
+// SetOverflowNodes sets the nodes of the node pools to overflow into, from the
+// highest to the lowest priority, when none of the base nodes are feasible. It
+// must be called after SetNodes.
+func (s *GenericStack) SetOverflowNodes(overflow [][]*structs.Node) {
+	idx, _ := s.ctx.State().LatestIndex()
+	for _, nodes := range overflow {
+		shuffleNodes(s.ctx.Plan(), idx, nodes)
+	}
+	s.overflow = overflow
+}
+
+// setBaseNodes sets the nodes to select from and the limit of the nodes to
+// visit.
+func (s *GenericStack) setBaseNodes(baseNodes []*structs.Node) {
 	s.source.SetNodes(baseNodes)
+	s.spread.SetNodes(baseNodes)
 
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	// For batch jobs we only need to evaluate 2 options and depend on the
@@ -110,9 +136,11 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctHostsConstraint.SetJob(job)
 	s.distinctPropertyConstraint.SetJob(job)
+	s.jobAffinityConstraint.SetJob(job)
 	s.binPack.SetJob(job)
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
+	s.jobAffinity.SetJob(job)
 	s.spread.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
@@ -132,6 +160,47 @@ func (s *GenericStack) SetSchedulerConfiguration(schedConfig *structs.SchedulerC
 
 func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *RankedNode {
 
+	// This block handles trying to select from the nodes which aren't avoided
+	// first, and falls back to all the nodes if none of them are feasible
+	if options != nil && len(options.AvoidNodeIDs) > 0 {
+		optionsNew := *options
+		optionsNew.AvoidNodeIDs = nil
+		s.nodeReschedulingPenalty.SetAvoidNodes(options.AvoidNodeIDs)
+		option := s.Select(tg, &optionsNew)
+		s.nodeReschedulingPenalty.SetAvoidNodes(nil)
+		if option != nil {
+			return option
+		}
+		return s.Select(tg, &optionsNew)
+	}
+
+	// This block handles overflowing into the node pools of the overflow nodes
+	// if none of the base nodes are feasible, from the highest to the lowest
+	// priority. It also sets back the set of nodes to the original nodes
+	if len(s.overflow) > 0 {
+		originalNodes, overflow := s.source.nodes, s.overflow
+		s.overflow = nil
+		defer func() {
+			s.setBaseNodes(originalNodes)
+			s.overflow = overflow
+		}()
+
+		if option := s.Select(tg, options); option != nil {
+			return option
+		}
+
+		// The preferred nodes have already been tried
+		optionsNew := *options
+		optionsNew.PreferredNodes = nil
+		for _, nodes := range overflow {
+			s.setBaseNodes(nodes)
+			if option := s.Select(tg, &optionsNew); option != nil {
+				return option
+			}
+		}
+		return nil
+	}
+
 	// This block handles trying to select from preferred nodes if options specify them
 	// It also sets back the set of nodes to the original nodes
 	if options != nil && len(options.PreferredNodes) > 0 {
@@ -166,6 +235,7 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	}
 	s.distinctHostsConstraint.SetTaskGroup(tg)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
+	s.jobAffinityConstraint.SetTaskGroup(tg)
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.binPack.SetTaskGroup(tg)
 	if options != nil {
@@ -176,9 +246,10 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 		s.nodeReschedulingPenalty.SetPenaltyNodes(options.PenaltyNodeIDs)
 	}
 	s.nodeAffinity.SetTaskGroup(tg)
+	s.jobAffinity.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
 
-	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
+	if s.nodeAffinity.hasAffinities() || s.jobAffinity.hasAffinities() || s.spread.hasSpreads() {
 		// scoring spread across all nodes has quadratic behavior, so
 		// we need to consider a subset of nodes to keep evaluaton times
 		// reasonable but enough to ensure spread is correct. this
@@ -220,6 +291,7 @@ type SystemStack struct {
 	taskGroupNetwork     *NetworkChecker
 
 	distinctPropertyConstraint *DistinctPropertyIterator
+	jobAffinityConstraint      *RequiredJobAffinityIterator
 	binPack                    *BinPackIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
@@ -278,12 +350,15 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Filter on distinct property constraints.
 	s.distinctPropertyConstraint = NewDistinctPropertyIterator(ctx, s.wrappedChecks)
 
+	// Filter on required job affinities.
+	s.jobAffinityConstraint = NewRequiredJobAffinityIterator(ctx, s.distinctPropertyConstraint)
+
 	// Create the quota iterator to determine if placements would result in
 	// the quota attached to the namespace of the job to go over.
 	// Note: the quota iterator must be the last feasibility iterator before
 	// we upgrade to ranking, or our quota usage will include ineligible
 	// nodes!
-	s.quota = NewQuotaIterator(ctx, s.distinctPropertyConstraint)
+	s.quota = NewQuotaIterator(ctx, s.jobAffinityConstraint)
 
 	// Upgrade from feasible to rank iterator
 	rankSource := NewFeasibleRankIterator(ctx, s.quota)
@@ -324,6 +399,7 @@ func (s *SystemStack) SetJob(job *structs.Job) {
 	s.jobID = job.ID
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctPropertyConstraint.SetJob(job)
+	s.jobAffinityConstraint.SetJob(job)
 	s.binPack.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
@@ -361,6 +437,7 @@ func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ran
 	}
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
+	s.jobAffinityConstraint.SetTaskGroup(tg)
 	s.binPack.SetTaskGroup(tg)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
@@ -432,12 +509,15 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Filter on distinct property constraints.
 	s.distinctPropertyConstraint = NewDistinctPropertyIterator(ctx, s.distinctHostsConstraint)
 
+	// Filter on required job affinities.
+	s.jobAffinityConstraint = NewRequiredJobAffinityIterator(ctx, s.distinctPropertyConstraint)
+
 	// Create the quota iterator to determine if placements would result in
 	// the quota attached to the namespace of the job to go over.
 	// Note: the quota iterator must be the last feasibility iterator before
 	// we upgrade to ranking, or our quota usage will include ineligible
 	// nodes!
-	s.quota = NewQuotaIterator(ctx, s.distinctPropertyConstraint)
+	s.quota = NewQuotaIterator(ctx, s.jobAffinityConstraint)
 
 	// Upgrade from feasible to rank iterator
 	rankSource := NewFeasibleRankIterator(ctx, s.quota)
@@ -457,8 +537,11 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Apply scores based on affinity block
 	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeReschedulingPenalty)
 
+	// Apply scores based on job_affinity block
+	s.jobAffinity = NewJobAffinityIterator(ctx, s.nodeAffinity)
+
 	// Apply scores based on spread block
-	s.spread = NewSpreadIterator(ctx, s.nodeAffinity)
+	s.spread = NewSpreadIterator(ctx, s.jobAffinity)
 
 	// Add the preemption options scoring iterator
 	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
@@ -168,6 +168,35 @@ func TestServiceStack_Select_PreferringNodes(t *testing.T) {
 	must.Eq(t, prefNodes1, selectOptions.PreferredNodes)
 }
 
+func TestServiceStack_Select_AvoidingNodes(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+	}
+	stack := NewGenericStack(false, ctx)
+	stack.SetNodes(nodes)
+
+	job := mock.Job()
+	stack.SetJob(job)
+
+	// The avoided node is never selected while another node is feasible
+	avoid := map[string]struct{}{nodes[0].ID: {}}
+	for i := 0; i < 5; i++ {
+		selectOptions := &SelectOptions{AvoidNodeIDs: avoid}
+		option := stack.Select(job.TaskGroups[0], selectOptions)
+		must.NotNil(t, option, must.Sprintf("missing node %#v", ctx.Metrics()))
+		must.Eq(t, nodes[1].ID, option.Node.ID)
+	}
+
+	// The avoided nodes are used if no other node is feasible
+	avoid[nodes[1].ID] = struct{}{}
+	option := stack.Select(job.TaskGroups[0], &SelectOptions{AvoidNodeIDs: avoid})
+	must.NotNil(t, option, must.Sprintf("missing node %#v", ctx.Metrics()))
+}
+
 func TestServiceStack_Select_MetricsReset(t *testing.T) {
 	ci.Parallel(t)
 
@@ -87,6 +87,45 @@ func readyNodesInDCsAndPool(state State, dcs []string, pool string) ([]*structs.
 	return out, notReady, dcMap, nil
 }
 
+// readyNodesInDCsAndOverflowPools returns the ready nodes in the given
+// datacenters of each node pool the given pool overflows into, from the
+// highest to the lowest priority.
+func readyNodesInDCsAndOverflowPools(state State, dcs []string, pool string) ([][]*structs.Node, error) {
+	nodePool, err := state.NodePoolByName(nil, pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %q: %v", pool, err)
+	}
+	if nodePool == nil {
+		return nil, nil
+	}
+
+	var overflow [][]*structs.Node
+	for _, overflowPool := range nodePool.OverflowPools() {
+		nodes, _, _, err := readyNodesInDCsAndPool(state, dcs, overflowPool)
+		if err != nil {
+			return nil, err
+		}
+		if len(nodes) > 0 {
+			overflow = append(overflow, nodes)
+		}
+	}
+	return overflow, nil
+}
+
+// isInJobNodePools returns true if the node is in the node pool of the job or
+// in one of the node pools it overflows into.
+func isInJobNodePools(state State, node *structs.Node, job *structs.Job) bool {
+	if node.IsInPool(job.NodePool) {
+		return true
+	}
+
+	pool, err := state.NodePoolByName(nil, job.NodePool)
+	if err != nil || pool == nil {
+		return false
+	}
+	return slices.Contains(pool.OverflowPools(), node.NodePool)
+}
+
 // retryMax is used to retry a callback until it returns success or
 // a maximum number of attempts is reached. An optional reset function may be
 // passed which is called after each failed iteration. If the reset function is
@@ -318,6 +357,11 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 			return difference("task identity", at.Identities, bt.Identities)
 		}
 
+		// Secrets are written before the task starts
+		if !slices.EqualFunc(at.Secrets, bt.Secrets, func(a, b *structs.Secret) bool { return a.Equal(b) }) {
+			return difference("task secrets", at.Secrets, bt.Secrets)
+		}
+
 		// Most LogConfig updates are in-place but if we change Disabled we need
 		// to recreate the task to stop/start log collection and change the
 		// stdout/stderr of the task
@@ -348,6 +392,8 @@ func nonNetworkResourcesUpdated(a, b *structs.Resources) comparison {
 	switch {
 	case a.CPU != b.CPU:
 		return difference("task cpu", a.CPU, b.CPU)
+	case a.CPUMax != b.CPUMax:
+		return difference("task cpu max", a.CPUMax, b.CPUMax)
 	case a.Cores != b.Cores:
 		return difference("task cores", a.Cores, b.Cores)
 	case a.MemoryMB != b.MemoryMB:
@@ -360,6 +406,8 @@ func nonNetworkResourcesUpdated(a, b *structs.Resources) comparison {
 		return difference("numa", a.NUMA, b.NUMA)
 	case a.SecretsMB != b.SecretsMB:
 		return difference("task secrets", a.SecretsMB, b.SecretsMB)
+	case !a.DiskIO.Equal(b.DiskIO):
+		return difference("task disk io", a.DiskIO, b.DiskIO)
 	}
 	return same
 }
@@ -664,7 +712,7 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 			continue
 		}
 		// The alloc is on a node that's now in an ineligible node pool
-		if !node.IsInPool(job.NodePool) {
+		if !isInJobNodePools(ctx.State(), node, job) {
 			continue
 		}
 
@@ -693,6 +741,10 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 		// We do not allow network resources (reserved/dynamic ports)
 		// to be updated. This is guarded in taskUpdated, so we can
 		// safely restore those here.
+		//
+		// Changes to the CPU and memory of tasks are guarded the same way, so
+		// the allocated CPU and memory are restored too to keep the resources
+		// of tasks resized in place by Alloc.Resize.
 		for task, resources := range option.TaskResources {
 			var networks structs.Networks
 			var devices []*structs.AllocatedDeviceResource
@@ -700,6 +752,7 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 				if tr, ok := update.Alloc.AllocatedResources.Tasks[task]; ok {
 					networks = tr.Networks
 					devices = tr.Devices
+					restoreResizedResources(resources, tr)
 				}
 			} else if tr, ok := update.Alloc.TaskResources[task]; ok {
 				networks = tr.Networks
@@ -740,6 +793,15 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 	return updates[:n], updates[n:]
 }
 
+// restoreResizedResources copies the CPU and memory of the existing task
+// resources of an allocation to the task resources of its in-place update.
+func restoreResizedResources(resources, existing *structs.AllocatedTaskResources) {
+	resources.Cpu.CpuShares = existing.Cpu.CpuShares
+	resources.Cpu.CpuMax = existing.Cpu.CpuMax
+	resources.Memory.MemoryMB = existing.Memory.MemoryMB
+	resources.Memory.MemoryMaxMB = existing.Memory.MemoryMaxMB
+}
+
 // desiredUpdates takes the diffResult as well as the set of inplace and
 // destructive updates and returns a map of task groups to their set of desired
 // updates.
@@ -911,7 +973,7 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 		if !node.IsInAnyDC(newJob.Datacenters) {
 			return false, true, nil
 		}
-		if !node.IsInPool(newJob.NodePool) {
+		if !isInJobNodePools(ctx.State(), node, newJob) {
 			return false, true, nil
 		}
 
@@ -939,6 +1001,10 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 		// We do not allow network resources (reserved/dynamic ports) or reserved cores
 		// to be updated, and reserved cores should not be recomputed for alloc updates. This is guarded in taskUpdated, so we can
 		// safely restore those here.
+		//
+		// Changes to the CPU and memory of tasks are guarded the same way, so
+		// the allocated CPU and memory are restored too to keep the resources
+		// of tasks resized in place by Alloc.Resize.
 		for task, resources := range option.TaskResources {
 			var networks structs.Networks
 			var devices []*structs.AllocatedDeviceResource
@@ -948,6 +1014,7 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 					networks = tr.Networks
 					devices = tr.Devices
 					cores = tr.Cpu.ReservedCores
+					restoreResizedResources(resources, tr)
 				}
 			} else if tr, ok := existing.TaskResources[task]; ok {
 				networks = tr.Networks
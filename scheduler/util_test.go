@@ -445,6 +445,15 @@ func TestTasksUpdated(t *testing.T) {
 	j32.TaskGroups[0].Tasks[0].VolumeMounts = nil
 
 	must.True(t, tasksUpdated(j31, j32, name).modified)
+
+	// Change secrets
+	j33 := mock.Job()
+	j33.TaskGroups[0].Tasks[0].Secrets = []*structs.Secret{{Name: "db", Path: "db/creds"}}
+	j34 := j33.Copy()
+	must.False(t, tasksUpdated(j33, j34, name).modified)
+
+	j34.TaskGroups[0].Tasks[0].Secrets[0].Env = true
+	must.True(t, tasksUpdated(j33, j34, name).modified)
 }
 
 func TestTasksUpdated_connectServiceUpdated(t *testing.T) {
@@ -916,6 +925,92 @@ func TestInplaceUpdate_Success(t *testing.T) {
 	}
 }
 
+// TestInplaceUpdate_Resized asserts in-place updates keep the CPU and memory
+// of tasks resized in place, and that updates changing the resources of the
+// tasks in the job are destructive.
+func TestInplaceUpdate_Resized(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name           string
+		cpu            int
+		expDestructive bool
+	}{
+		{
+			name: "resources unchanged",
+		},
+		{
+			name:           "resources changed",
+			cpu:            700,
+			expDestructive: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := mock.Job()
+			node := mock.Node()
+
+			// The alloc was resized from the 500 CPU and 256MB memory of the job
+			alloc := mock.Alloc()
+			alloc.Job = job
+			alloc.JobID = job.ID
+			alloc.NodeID = node.ID
+			alloc.AllocatedResources.Tasks["web"].Cpu.CpuShares = 1000
+			alloc.AllocatedResources.Tasks["web"].Memory.MemoryMB = 600
+
+			newJob := job.Copy()
+			newJob.JobModifyIndex++
+			if tc.cpu != 0 {
+				newJob.TaskGroups[0].Tasks[0].Resources.CPU = tc.cpu
+			}
+			tg := newJob.TaskGroups[0]
+
+			t.Run("inplaceUpdate", func(t *testing.T) {
+				state, ctx := testContext(t)
+				must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 900, node))
+				must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+				stack := NewGenericStack(false, ctx)
+				stack.SetJob(newJob)
+				destructive, inplace := inplaceUpdate(ctx, mock.Eval(), newJob, stack,
+					[]allocTuple{{Alloc: alloc, TaskGroup: tg}})
+				if tc.expDestructive {
+					must.Len(t, 1, destructive)
+					must.Len(t, 0, inplace)
+					return
+				}
+				must.Len(t, 0, destructive)
+				must.Len(t, 1, inplace)
+
+				updated := ctx.plan.NodeAllocation[node.ID][0].AllocatedResources.Tasks["web"]
+				must.Eq(t, 1000, updated.Cpu.CpuShares)
+				must.Eq(t, 600, updated.Memory.MemoryMB)
+			})
+
+			t.Run("genericAllocUpdateFn", func(t *testing.T) {
+				state, ctx := testContext(t)
+				must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 900, node))
+				must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+				stack := NewGenericStack(false, ctx)
+				stack.SetJob(newJob)
+				ignore, destructive, newAlloc := genericAllocUpdateFn(ctx, stack, uuid.Generate())(alloc, newJob, tg)
+				must.False(t, ignore)
+				must.Eq(t, tc.expDestructive, destructive)
+				if tc.expDestructive {
+					return
+				}
+				must.NotNil(t, newAlloc)
+
+				updated := newAlloc.AllocatedResources.Tasks["web"]
+				must.Eq(t, 1000, updated.Cpu.CpuShares)
+				must.Eq(t, 600, updated.Memory.MemoryMB)
+			})
+		})
+	}
+}
+
 func TestInplaceUpdate_WildcardDatacenters(t *testing.T) {
 	ci.Parallel(t)
 
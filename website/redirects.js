@@ -93,6 +93,11 @@ module.exports = [
     destination: 'nomad/plugins/drivers/',
     permanent: true,
   },
+  {
+    source: '/nomad/plugins/drivers/podman',
+    destination: '/nomad/docs/drivers/podman',
+    permanent: true,
+  },
   {
     source: '/nomad/plugins/drivers/community/lxc',
     destination: '/nomad/plugins/drivers/community/',